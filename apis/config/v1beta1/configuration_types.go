@@ -19,9 +19,11 @@ package v1beta1
 import (
 	"time"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
+	tracingv1 "k8s.io/component-base/tracing/api/v1"
 )
 
 // +k8s:defaulter-gen=true
@@ -83,6 +85,126 @@ type Configuration struct {
 
 	// Resources provides additional configuration options for handling the resources.
 	Resources *Resources `json:"resources,omitempty"`
+
+	// AutoReactivation defines the policy for reactivating the workloads
+	// deactivated by Kueue. If not set, the deactivated workloads are not
+	// reactivated automatically.
+	// +optional
+	AutoReactivation *AutoReactivation `json:"autoReactivation,omitempty"`
+
+	// Tracing configures the OpenTelemetry tracing of the admission of the
+	// workloads. The spans of the queueing, flavor assignment, admission checks
+	// and start of the job of a workload belong to the same trace, identified
+	// by the UID of the workload.
+	// If not set, the tracing is disabled.
+	// +optional
+	Tracing *tracingv1.TracingConfiguration `json:"tracing,omitempty"`
+
+	// SchedulingAudit configures the audit log of the scheduling decisions.
+	// Every decision of the scheduler to reserve quota for a workload, to
+	// preempt other workloads or to skip a workload is recorded, along with
+	// the usage of the ClusterQueue, the fair-share values and the preemption
+	// victims that were considered.
+	// If not set, the audit log is disabled.
+	// +optional
+	SchedulingAudit *SchedulingAudit `json:"schedulingAudit,omitempty"`
+
+	// UsageReports configures the aggregation of the resources used by the
+	// workloads admitted in the LocalQueues into UsageReports, one per
+	// namespace and period, which can be used for chargeback.
+	// If not set, the usage is not reported.
+	// +optional
+	UsageReports *UsageReports `json:"usageReports,omitempty"`
+
+	// Notifications configures the webhooks to which Kueue posts a JSON
+	// payload on the lifecycle events of the workloads and ClusterQueues,
+	// such as a workload being admitted or a ClusterQueue being stopped.
+	// If not set, no notifications are sent.
+	// +optional
+	Notifications *Notifications `json:"notifications,omitempty"`
+
+	// ShadowMode, when true, puts all the ClusterQueues in shadow mode: Kueue
+	// creates the workloads and computes their admission, fair share and
+	// preemptions, but it never suspends or gates the jobs, nor evicts the
+	// workloads to preempt them. The would-be preemptions are recorded in
+	// events and metrics.
+	// Defaults to false.
+	// +optional
+	ShadowMode *bool `json:"shadowMode,omitempty"`
+
+	// QueueingOrder configures how the pending workloads of equal priority
+	// and timestamp are ordered in the queues and in the scheduling cycles.
+	// If not set, the order of these workloads is unspecified.
+	// +optional
+	QueueingOrder *QueueingOrder `json:"queueingOrder,omitempty"`
+
+	// LocalQueueAuthorization configures the webhooks to verify, with a
+	// SubjectAccessReview, that the users creating workloads or jobs in a
+	// LocalQueue are allowed a verb on the LocalQueue, so that the access to
+	// the LocalQueues can be governed by RBAC rather than by the namespaces
+	// alone.
+	// If not set, any user allowed to create a workload or job in a namespace
+	// can submit it to the LocalQueues of the namespace.
+	// +optional
+	LocalQueueAuthorization *LocalQueueAuthorization `json:"localQueueAuthorization,omitempty"`
+
+	// SubmitterIdentity configures the webhooks to record the identity of the
+	// users submitting the jobs and workloads, from the admission requests,
+	// in annotations of the workloads, so that their usage can be attributed
+	// to them, for example in the audit log of the scheduling decisions.
+	// If not set, the identity of the submitters is not recorded.
+	// +optional
+	SubmitterIdentity *SubmitterIdentity `json:"submitterIdentity,omitempty"`
+
+	// AdmissionPolicies are CEL expressions that the workloads must satisfy
+	// to be admitted in any ClusterQueue, in addition to the admission
+	// policies of their ClusterQueues.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	AdmissionPolicies []AdmissionPolicy `json:"admissionPolicies,omitempty"`
+
+	// DeviceReadiness configures Kueue to delay the start of the admitted
+	// jobs requesting devices until the nodes of their flavors report the
+	// devices as allocatable, to avoid the crash loops of the pods scheduled
+	// on nodes that just joined the cluster, before their device drivers and
+	// plugins are ready.
+	// If not set, the admitted jobs are started right away.
+	// +optional
+	DeviceReadiness *DeviceReadiness `json:"deviceReadiness,omitempty"`
+
+	// NodeInterruption configures the signals of the nodes about to be
+	// interrupted, like the reclaim of spot instances. When the
+	// NodeInterruptionRequeue feature gate is enabled, Kueue evicts and
+	// requeues the admitted workloads with pods on the signaled nodes,
+	// so that all their pods restart together on other nodes.
+	// If not set, the default taint keys are used.
+	// +optional
+	NodeInterruption *NodeInterruption `json:"nodeInterruption,omitempty"`
+
+	// DeviceHealth configures Kueue to track the unhealthy devices of the
+	// nodes, and to subtract them from the nominal quota of the flavors
+	// matching the nodes, so that workloads are not admitted against the
+	// capacity of broken devices.
+	// If not set, the health of the devices is not tracked.
+	// +optional
+	DeviceHealth *DeviceHealth `json:"deviceHealth,omitempty"`
+
+	// WorkloadHistory configures the archive of the finished workloads: a
+	// record of each workload is stored when it finishes, so that the history
+	// of the workloads outlives their objects, and it can be queried at
+	// /debug/workloads/history on the metrics server.
+	// If not set, the finished workloads are not archived.
+	// +optional
+	WorkloadHistory *WorkloadHistory `json:"workloadHistory,omitempty"`
+
+	// PriorityDecay configures the decay of the priority protecting the
+	// admitted workloads from preemption, once they run far beyond the
+	// expected duration declared in the kueue.x-k8s.io/expected-duration
+	// annotation of their jobs, so that they become the preferred victims.
+	// If not set, the priority of the workloads doesn't decay.
+	// +optional
+	PriorityDecay *PriorityDecay `json:"priorityDecay,omitempty"`
 }
 
 type ControllerManager struct {
@@ -149,6 +271,20 @@ type ControllerMetrics struct {
 	// metrics will be reported.
 	// +optional
 	EnableClusterQueueResources bool `json:"enableClusterQueueResources,omitempty"`
+
+	// LocalQueueMetrics controls the metrics reported per LocalQueue, when the
+	// LocalQueueMetrics feature gate is enabled.
+	// +optional
+	LocalQueueMetrics *LocalQueueMetrics `json:"localQueueMetrics,omitempty"`
+}
+
+type LocalQueueMetrics struct {
+	// LocalQueueSelector restricts the metrics reported per LocalQueue to the
+	// LocalQueues matching the selector, which bounds the cardinality of the
+	// metrics in clusters with many LocalQueues.
+	// Defaults to all the LocalQueues.
+	// +optional
+	LocalQueueSelector *metav1.LabelSelector `json:"localQueueSelector,omitempty"`
 }
 
 // ControllerHealth defines the health configs.
@@ -287,6 +423,382 @@ const (
 	EvictionTimestamp RequeuingTimestamp = "Eviction"
 )
 
+type AutoReactivation struct {
+	// ReasonCategories are the categories of the deactivation reasons for
+	// which the workloads are reactivated. The possible values are:
+	//
+	// - `Infrastructure` for the deactivations caused by the infrastructure, like
+	//   exceeding the re-queuing limit because the pods didn't become ready, or
+	//   the rejection of an admission check.
+	// - `User` for the deactivations caused by the workload, like exceeding its
+	//   maximum execution time.
+	//
+	// The workloads deactivated by setting `.spec.active` to `false` are never
+	// reactivated automatically.
+	//
+	// Defaults to [Infrastructure].
+	// +optional
+	ReasonCategories []string `json:"reasonCategories,omitempty"`
+
+	// BackoffLimitCount defines the maximum number of automatic reactivations
+	// of a workload. Once the number is reached, the workload stays deactivated.
+	// When it is null, the workloads are reactivated endlessly.
+	//
+	// Every backoff duration is "b*2^n" where:
+	// - "b" represents the base set by "BackoffBaseSeconds" parameter,
+	// - "n" represents the "workloadStatus.deactivation.reactivationCount".
+	//
+	// Defaults to null.
+	// +optional
+	BackoffLimitCount *int32 `json:"backoffLimitCount,omitempty"`
+
+	// BackoffBaseSeconds defines the base for the exponential backoff for
+	// reactivating a deactivated workload.
+	//
+	// Defaults to 600.
+	// +optional
+	BackoffBaseSeconds *int32 `json:"backoffBaseSeconds,omitempty"`
+
+	// BackoffMaxSeconds defines the maximum backoff time to reactivate a
+	// deactivated workload.
+	//
+	// Defaults to 86400.
+	// +optional
+	BackoffMaxSeconds *int32 `json:"backoffMaxSeconds,omitempty"`
+}
+
+const (
+	// InfrastructureReasonCategory is the category of the deactivations caused by the infrastructure.
+	InfrastructureReasonCategory = "Infrastructure"
+
+	// UserReasonCategory is the category of the deactivations caused by the workload.
+	UserReasonCategory = "User"
+)
+
+type SchedulingAudit struct {
+	// Path is the path of the file in which the decisions are appended, one
+	// JSON object per line.
+	// Exactly one of path and url must be set.
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// URL is the HTTP(S) endpoint to which the decisions are sent, in batches
+	// of JSON objects separated by new lines, with POST requests.
+	// Exactly one of path and url must be set.
+	// +optional
+	URL *string `json:"url,omitempty"`
+
+	// BufferSize is the maximum number of decisions waiting to be written.
+	// When the buffer is full, the new decisions are dropped, so that the
+	// audit log never slows down the scheduler.
+	//
+	// Defaults to 1000.
+	// +optional
+	BufferSize *int32 `json:"bufferSize,omitempty"`
+}
+
+type UsageReports struct {
+	// Period is the length of the period covered by each UsageReport. The
+	// periods are aligned to the Unix epoch, so that a Period of 24h makes a
+	// UsageReport per namespace and day, starting at midnight UTC.
+	//
+	// Defaults to 24h.
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty"`
+
+	// SyncInterval is how often the usage of the LocalQueues is sampled and
+	// added to the UsageReports. It must not be greater than the Period.
+	//
+	// Defaults to 1m.
+	// +optional
+	SyncInterval *metav1.Duration `json:"syncInterval,omitempty"`
+}
+
+type Notifications struct {
+	// Webhooks are the endpoints to which the notifications are posted.
+	Webhooks []NotificationWebhook `json:"webhooks,omitempty"`
+
+	// Kafka are the Kafka topics to which the notifications are produced.
+	Kafka []KafkaNotificationSink `json:"kafka,omitempty"`
+
+	// NATS are the NATS subjects to which the notifications are published.
+	NATS []NATSNotificationSink `json:"nats,omitempty"`
+
+	// CloudEvents are the CloudEvents consumers, like Argo Events or Knative
+	// Eventing, to which the notifications are sent as CloudEvents.
+	CloudEvents []CloudEventsNotificationSink `json:"cloudEvents,omitempty"`
+
+	// BufferSize is the maximum number of notifications waiting to be sent.
+	// When the buffer is full, the new notifications are dropped, so that the
+	// notifications never slow down the controllers.
+	//
+	// Defaults to 1000.
+	// +optional
+	BufferSize *int32 `json:"bufferSize,omitempty"`
+}
+
+type NotificationWebhook struct {
+	// URL is the HTTP(S) endpoint to which each notification is sent, as a
+	// JSON object, with a POST request.
+	URL string `json:"url"`
+
+	// Events are the types of the events notified to the webhook.
+	// The possible values are:
+	//
+	// - `WorkloadAdmitted`: the workload was admitted.
+	// - `WorkloadEvicted`: the workload was evicted, for a reason other than preemption.
+	// - `WorkloadPreempted`: the workload was evicted to be preempted.
+	// - `WorkloadFinished`: the workload finished.
+	// - `ClusterQueueStopped`: the stopPolicy of the ClusterQueue was set.
+	// - `ClusterQueueResumed`: the stopPolicy of the ClusterQueue was reset to None.
+	//
+	// If empty, all the events are notified.
+	// +optional
+	Events []NotificationEventType `json:"events,omitempty"`
+}
+
+type KafkaNotificationSink struct {
+	// RESTProxyURL is the HTTP(S) URL of the Kafka REST Proxy, through which
+	// each notification is produced to the topic with the v2 API, as a JSON
+	// record keyed by the workload or ClusterQueue.
+	RESTProxyURL string `json:"restProxyURL"`
+
+	// Topic is the Kafka topic to which the notifications are produced.
+	Topic string `json:"topic"`
+
+	// Events are the types of the events produced to the topic.
+	// See NotificationWebhook for the possible values.
+	// If empty, all the events are produced.
+	// +optional
+	Events []NotificationEventType `json:"events,omitempty"`
+}
+
+type NATSNotificationSink struct {
+	// URL is the address of the NATS server, in the form
+	// nats://[user:password@]host:port, or tls://[user:password@]host:port
+	// to connect with TLS. A user without a password is sent as the token.
+	URL string `json:"url"`
+
+	// Subject is the NATS subject to which the notifications are published,
+	// as JSON objects.
+	Subject string `json:"subject"`
+
+	// Events are the types of the events published to the subject.
+	// See NotificationWebhook for the possible values.
+	// If empty, all the events are published.
+	// +optional
+	Events []NotificationEventType `json:"events,omitempty"`
+}
+
+type CloudEventsNotificationSink struct {
+	// URL is the HTTP(S) endpoint to which each notification is sent as a
+	// CloudEvent, in the binary content mode of the HTTP protocol binding,
+	// like the URL of a Knative Broker or of an Argo Events webhook
+	// EventSource.
+	URL string `json:"url"`
+
+	// Source is the source attribute of the CloudEvents, identifying the
+	// Kueue instance which produced them.
+	//
+	// Defaults to kueue.x-k8s.io.
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Events are the types of the events sent to the endpoint.
+	// See NotificationWebhook for the possible values.
+	// If empty, all the events are sent.
+	// +optional
+	Events []NotificationEventType `json:"events,omitempty"`
+}
+
+type NotificationEventType string
+
+const (
+	WorkloadAdmittedNotification    NotificationEventType = "WorkloadAdmitted"
+	WorkloadEvictedNotification     NotificationEventType = "WorkloadEvicted"
+	WorkloadPreemptedNotification   NotificationEventType = "WorkloadPreempted"
+	WorkloadFinishedNotification    NotificationEventType = "WorkloadFinished"
+	ClusterQueueStoppedNotification NotificationEventType = "ClusterQueueStopped"
+	ClusterQueueResumedNotification NotificationEventType = "ClusterQueueResumed"
+)
+
+type QueueingOrder struct {
+	// TieBreakers are the keys, in order, by which the pending workloads of
+	// equal priority and timestamp are ordered. The workloads which are equal
+	// for all the keys are ordered by namespace and name, so that the same
+	// workloads are always scheduled in the same order, for example across
+	// identical deployments used for benchmarking.
+	// The possible values are:
+	//
+	// - `CreationTimestamp`: the earliest created workload first. It breaks
+	//   the ties of the workloads ordered by their eviction timestamp.
+	// - `NamespaceHash`: the workload with the lowest hash of its namespace
+	//   first, which interleaves the namespaces in a stable order independent
+	//   of their names.
+	// - `SubmissionCounter`: the workload queued first, in the order in which
+	//   Kueue observed the workloads, first.
+	//
+	// +listType=set
+	// +optional
+	TieBreakers []TieBreaker `json:"tieBreakers,omitempty"`
+}
+
+type TieBreaker string
+
+const (
+	CreationTimestampTieBreaker TieBreaker = "CreationTimestamp"
+	NamespaceHashTieBreaker     TieBreaker = "NamespaceHash"
+	SubmissionCounterTieBreaker TieBreaker = "SubmissionCounter"
+)
+
+type LocalQueueAuthorization struct {
+	// Verb is the verb the users need on the LocalQueues, in the
+	// kueue.x-k8s.io API group, to submit workloads to them.
+	// Defaults to submit.
+	// +optional
+	Verb string `json:"verb,omitempty"`
+}
+
+type SubmitterIdentity struct {
+	// Redaction is how the identities are recorded. The possible values are:
+	//
+	// - `None`: the user names, groups and service accounts are recorded as is.
+	// - `Hash`: the SHA-256 hashes of the user names, groups and service
+	//   accounts are recorded instead, which still attribute the usage of the
+	//   same submitter together without disclosing who they are.
+	//
+	// Defaults to None.
+	// +optional
+	Redaction SubmitterRedaction `json:"redaction,omitempty"`
+
+	// RecordGroups indicates whether the groups of the submitters are
+	// recorded along with their user names.
+	// Defaults to true.
+	// +optional
+	RecordGroups *bool `json:"recordGroups,omitempty"`
+}
+
+type SubmitterRedaction string
+
+const (
+	NoSubmitterRedaction   SubmitterRedaction = "None"
+	HashSubmitterRedaction SubmitterRedaction = "Hash"
+)
+
+type AdmissionPolicy struct {
+	// Name identifies the policy in the messages of the workloads violating it.
+	Name string `json:"name"`
+
+	// Expression is a CEL expression which must evaluate to true for the
+	// workload to be admitted. The Workload is available in the expression as
+	// the `workload` variable, for example:
+	// `workload.spec.podSets.all(ps, ps.count <= 8)`.
+	// The workloads for which the evaluation of the expression fails violate
+	// the policy.
+	Expression string `json:"expression"`
+
+	// Message is set in the conditions of the workloads violating the policy.
+	// If empty, the message mentions the expression.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Action determines what happens to the workloads violating the policy.
+	// The possible values are:
+	//
+	// - `Reject`: the workload is kept pending until it satisfies the policy,
+	//   or the policy is changed.
+	// - `Deactivate`: the workload is deactivated.
+	//
+	// Defaults to Reject.
+	// +optional
+	Action AdmissionPolicyAction `json:"action,omitempty"`
+}
+
+type AdmissionPolicyAction string
+
+const (
+	RejectAdmissionPolicyAction     AdmissionPolicyAction = "Reject"
+	DeactivateAdmissionPolicyAction AdmissionPolicyAction = "Deactivate"
+)
+
+type DeviceReadiness struct {
+	// ResourceNames are the resources provided by device plugins, like
+	// nvidia.com/gpu. The start of an admitted job requesting any of them
+	// is delayed while some of the nodes matching the node labels of its
+	// flavor are not ready, or don't report the resource as allocatable.
+	// The cordoned nodes are ignored.
+	// +listType=set
+	ResourceNames []corev1.ResourceName `json:"resourceNames"`
+
+	// Timeout is the maximum time, since the admission of a workload, for
+	// which the start of its job is delayed. The job is started once the
+	// timeout elapsed, even if the devices are not ready.
+	// Defaults to 10m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+type NodeInterruption struct {
+	// TaintKeys are the keys of the taints set on the nodes about to be
+	// interrupted, for example by the node termination handlers of the
+	// cloud providers.
+	// Defaults to the taint keys of the AWS node termination handler,
+	// GKE and Karpenter, if neither taintKeys nor annotationKeys are set.
+	// +listType=set
+	// +optional
+	TaintKeys []string `json:"taintKeys,omitempty"`
+
+	// AnnotationKeys are the keys of the annotations set on the nodes about
+	// to be interrupted, for example by the daemonsets watching the
+	// interruption notices of the cloud providers.
+	// +listType=set
+	// +optional
+	AnnotationKeys []string `json:"annotationKeys,omitempty"`
+}
+
+type DeviceHealth struct {
+	// ResourceNames are the resources provided by device plugins, like
+	// nvidia.com/gpu. The devices of a node reported in its capacity, but
+	// not in its allocatable resources, are unhealthy.
+	// +listType=set
+	ResourceNames []corev1.ResourceName `json:"resourceNames"`
+
+	// UnhealthyNodeConditions are the types of the node conditions set, for
+	// example by the node problem detector, when the devices of a node are
+	// unhealthy. All the devices of a node with any of the conditions True
+	// are unhealthy.
+	// +listType=set
+	// +optional
+	UnhealthyNodeConditions []corev1.NodeConditionType `json:"unhealthyNodeConditions,omitempty"`
+}
+
+type WorkloadHistory struct {
+	// File stores the records in a directory, in JSON Lines files, one per
+	// day. The directory should be on a persistent volume.
+	// Exactly one store must be set.
+	// +optional
+	File *WorkloadHistoryFileStore `json:"file,omitempty"`
+
+	// Retention is how long the records are kept in the store.
+	//
+	// Defaults to 2160h (90 days).
+	// +optional
+	Retention *metav1.Duration `json:"retention,omitempty"`
+
+	// BufferSize is the maximum number of records waiting to be stored.
+	// When the buffer is full, the new records are dropped, so that the
+	// archive never slows down the controllers.
+	//
+	// Defaults to 1000.
+	// +optional
+	BufferSize *int32 `json:"bufferSize,omitempty"`
+}
+
+type WorkloadHistoryFileStore struct {
+	// Directory is the directory in which the records are stored.
+	Directory string `json:"directory"`
+}
+
 type InternalCertManagement struct {
 	// Enable controls whether to enable internal cert management or not.
 	// Defaults to true. If you want to use a third-party management, e.g. cert-manager,
@@ -326,6 +838,7 @@ type Integrations struct {
 	//  - "kubeflow.org/xgboostjob"
 	//  - "pod"
 	//  - "deployment" (requires enabling pod integration)
+	//  - "replicaset" (requires enabling pod integration)
 	//  - "statefulset" (requires enabling pod integration)
 	Frameworks []string `json:"frameworks,omitempty"`
 	// List of GroupVersionKinds that are managed for Kueue by external controllers;
@@ -344,6 +857,105 @@ type Integrations struct {
 	// during the workload creation and are not updated even if the labels of the
 	// underlying job are changed.
 	LabelKeysToCopy []string `json:"labelKeysToCopy,omitempty"`
+
+	// Webhooks configures the mutating and validating webhooks of the
+	// integrations, per framework. Kueue applies the settings to its webhook
+	// configurations when it starts, so that, for example, the webhooks of an
+	// optional integration can fail open without affecting the other ones.
+	// The integrations which are not listed keep the settings of the webhook
+	// configurations.
+	Webhooks []IntegrationWebhook `json:"webhooks,omitempty"`
+
+	// Controllers configures the reconcilers of the integrations, per framework,
+	// so that, for example, the pod integration of a cluster running many pods
+	// can be tuned independently of the job reconcilers.
+	// The integrations which are not listed use the defaults of the manager.
+	Controllers []IntegrationController `json:"controllers,omitempty"`
+
+	// ExternalResumePolicy is how Kueue handles the jobs resumed while their
+	// workloads are not admitted, for example when a user, or another
+	// controller, un-suspends a queued job. The possible values are:
+	//
+	// - `Suspend`: the job is suspended again.
+	// - `Adopt`: the job keeps running, and its workload is switched to shadow
+	//   mode, so that the quota of its ClusterQueue is charged once the
+	//   workload is admitted.
+	// - `Alert`: the job keeps running, without charging quota, and a warning
+	//   event is recorded for the job.
+	//
+	// Defaults to Suspend.
+	// +optional
+	ExternalResumePolicy ExternalResumePolicy `json:"externalResumePolicy,omitempty"`
+}
+
+type ExternalResumePolicy string
+
+const (
+	SuspendExternalResumePolicy ExternalResumePolicy = "Suspend"
+	AdoptExternalResumePolicy   ExternalResumePolicy = "Adopt"
+	AlertExternalResumePolicy   ExternalResumePolicy = "Alert"
+)
+
+type IntegrationController struct {
+	// Framework is the name of the integration, as listed in frameworks.
+	Framework string `json:"framework"`
+
+	// MaxConcurrentReconciles is the maximum number of objects of the
+	// integration which are reconciled concurrently.
+	// If not set, the value from controller.groupKindConcurrency, or the
+	// default of the manager, is used.
+	MaxConcurrentReconciles *int32 `json:"maxConcurrentReconciles,omitempty"`
+
+	// ClientConnection configures a dedicated client for the reconciler of the
+	// integration. Its requests don't consume the rate limit of the client
+	// shared by the other controllers.
+	// If not set, the reconciler uses the client of the manager.
+	ClientConnection *ClientConnection `json:"clientConnection,omitempty"`
+
+	// ResyncPeriod is the interval after which the objects of the integration
+	// are reconciled again, even if they did not change.
+	// If not set, the objects are only reconciled when they, or their
+	// workloads, change.
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+
+	// Informer restricts the objects of the integration which are listed,
+	// watched and kept in memory by Kueue. It is only supported by the pod,
+	// deployment and statefulset integrations. The objects which are filtered
+	// out are ignored by all the controllers of Kueue.
+	// The pod informer can't be restricted when the TopologyAwareScheduling
+	// feature is enabled, since the usage of the pods not managed by Kueue is
+	// read from it.
+	// If not set, all the objects of the integration are watched.
+	Informer *IntegrationInformer `json:"informer,omitempty"`
+}
+
+type IntegrationInformer struct {
+	// LabelSelector restricts the objects to the ones whose labels match it.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// Namespaces restricts the objects to the ones in the listed namespaces.
+	// If empty, the objects of all the namespaces are watched.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+type IntegrationWebhook struct {
+	// Framework is the name of the integration, as listed in frameworks.
+	Framework string `json:"framework"`
+
+	// FailurePolicy defines how the errors calling the webhooks of the
+	// integration are handled. The possible values are Fail and Ignore.
+	// If not set, the failure policy of the webhook configurations is kept.
+	FailurePolicy *admissionregistrationv1.FailurePolicyType `json:"failurePolicy,omitempty"`
+
+	// TimeoutSeconds is the timeout of the calls to the webhooks of the
+	// integration, between 1 and 30 seconds.
+	// If not set, the timeout of the webhook configurations is kept.
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// NamespaceSelector restricts the namespaces of the objects sent to the
+	// webhooks of the integration.
+	// If not set, the namespace selector of the webhook configurations is kept.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 }
 
 type PodIntegrationOptions struct {
@@ -408,6 +1020,47 @@ const (
 	LessThanInitialShare        PreemptionStrategy = "LessThanInitialShare"
 )
 
+type VictimOrderingCriterion string
+
+const (
+	LowestPriority VictimOrderingCriterion = "LowestPriority"
+	Newest         VictimOrderingCriterion = "Newest"
+	Smallest       VictimOrderingCriterion = "Smallest"
+	LeastProgress  VictimOrderingCriterion = "LeastProgress"
+)
+
+// PriorityDecay configures the decay of the priority of the workloads
+// running beyond their expected duration. The decayed priority is only used
+// to select the workloads to preempt: it lets the workloads of the same and
+// of lower priorities preempt them, and they are preempted before the other
+// workloads of their priority.
+type PriorityDecay struct {
+	// ThresholdPercent is the percentage of the expected duration of a
+	// workload after which its priority starts to decay.
+	//
+	// Defaults to 200.
+	// +optional
+	ThresholdPercent *int32 `json:"thresholdPercent,omitempty"`
+
+	// Step is how much the priority decays once the workload passes the
+	// threshold, and again every Interval after that.
+	//
+	// Defaults to 100.
+	// +optional
+	Step *int32 `json:"step,omitempty"`
+
+	// Interval is the period between the decays of the priority.
+	//
+	// Defaults to 1h.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// MinPriority is the priority below which the priority doesn't decay.
+	// If not set, the priority decays without limit.
+	// +optional
+	MinPriority *int32 `json:"minPriority,omitempty"`
+}
+
 type FairSharing struct {
 	// enable indicates whether to enable fair sharing for all cohorts.
 	// Defaults to false.
@@ -430,4 +1083,21 @@ type FairSharing struct {
 	//   newest start time first.
 	// The default strategy is ["LessThanOrEqualToFinalShare", "LessThanInitialShare"].
 	PreemptionStrategies []PreemptionStrategy `json:"preemptionStrategies,omitempty"`
+
+	// victimOrdering indicates how the workloads to preempt are chosen among the
+	// workloads of a ClusterQueue. The criteria are applied in order, the next
+	// criterion being used when the previous ones consider the workloads equal.
+	// The workloads already being evicted, and the workloads of the other
+	// ClusterQueues of the cohort, are always preempted first.
+	// Possible values are:
+	// - LowestPriority: the workloads with the lowest priority first.
+	// - Newest: the workloads admitted most recently first.
+	// - Smallest: the workloads using the smallest share of the quota of the
+	//   cohort first.
+	// - LeastProgress: the workloads which completed the smallest fraction of
+	//   their pods first, as reported by the kueue.x-k8s.io/progress annotation,
+	//   like the completed indexes of Indexed Jobs. The workloads without the
+	//   annotation didn't progress.
+	// The default ordering is ["LowestPriority", "Newest"].
+	VictimOrdering []VictimOrderingCriterion `json:"victimOrdering,omitempty"`
 }
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -40,7 +41,7 @@ const (
 	DefaultLeaderElectionRetryPeriod                    = 2 * time.Second
 	DefaultClientConnectionQPS                  float32 = 20.0
 	DefaultClientConnectionBurst                int32   = 30
-	defaultPodsReadyTimeout                             = 5 * time.Minute
+	DefaultPodsReadyTimeout                             = 5 * time.Minute
 	DefaultQueueVisibilityUpdateIntervalSeconds int32   = 5
 	DefaultClusterQueuesMaxCount                int32   = 10
 	defaultJobFrameworkName                             = "batch/job"
@@ -49,9 +50,31 @@ const (
 	DefaultMultiKueueWorkerLostTimeout                  = 15 * time.Minute
 	DefaultRequeuingBackoffBaseSeconds                  = 60
 	DefaultRequeuingBackoffMaxSeconds                   = 3600
+	DefaultReactivationBackoffBaseSeconds               = 600
+	DefaultReactivationBackoffMaxSeconds                = 86400
+	DefaultSchedulingAuditBufferSize                    = 1000
+	DefaultUsageReportsPeriod                           = 24 * time.Hour
+	DefaultUsageReportsSyncInterval                     = time.Minute
+	DefaultNotificationsBufferSize                      = 1000
+	DefaultWorkloadHistoryRetention                     = 90 * 24 * time.Hour
+	DefaultWorkloadHistoryBufferSize                    = 1000
+	DefaultPriorityDecayThresholdPercent                = 200
+	DefaultPriorityDecayStep                            = 100
+	DefaultPriorityDecayInterval                        = time.Hour
 	DefaultResourceTransformationStrategy               = Retain
+	DefaultLocalQueueAuthorizationVerb                  = "submit"
+	DefaultDeviceReadinessTimeout                       = 10 * time.Minute
 )
 
+// DefaultNodeInterruptionTaintKeys are the keys of the taints set on the
+// nodes about to be interrupted by the AWS node termination handler, GKE
+// and Karpenter.
+var DefaultNodeInterruptionTaintKeys = []string{
+	"aws-node-termination-handler/spot-itn",
+	"cloud.google.com/impending-node-termination",
+	"karpenter.sh/disrupted",
+}
+
 func getOperatorNamespace() string {
 	if data, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace"); err == nil {
 		if ns := strings.TrimSpace(string(data)); len(ns) > 0 {
@@ -117,7 +140,7 @@ func SetDefaults_Configuration(cfg *Configuration) {
 	}
 	if cfg.WaitForPodsReady != nil {
 		if cfg.WaitForPodsReady.Timeout == nil {
-			cfg.WaitForPodsReady.Timeout = &metav1.Duration{Duration: defaultPodsReadyTimeout}
+			cfg.WaitForPodsReady.Timeout = &metav1.Duration{Duration: DefaultPodsReadyTimeout}
 		}
 		if cfg.WaitForPodsReady.BlockAdmission == nil {
 			defaultBlockAdmission := true
@@ -208,6 +231,9 @@ func SetDefaults_Configuration(cfg *Configuration) {
 	if fs := cfg.FairSharing; fs != nil && fs.Enable && len(fs.PreemptionStrategies) == 0 {
 		fs.PreemptionStrategies = []PreemptionStrategy{LessThanOrEqualToFinalShare, LessThanInitialShare}
 	}
+	if fs := cfg.FairSharing; fs != nil && fs.Enable && len(fs.VictimOrdering) == 0 {
+		fs.VictimOrdering = []VictimOrderingCriterion{LowestPriority, Newest}
+	}
 
 	if cfg.Resources != nil {
 		for idx := range cfg.Resources.Transformations {
@@ -216,4 +242,81 @@ func SetDefaults_Configuration(cfg *Configuration) {
 			}
 		}
 	}
+
+	if ar := cfg.AutoReactivation; ar != nil {
+		if len(ar.ReasonCategories) == 0 {
+			ar.ReasonCategories = []string{InfrastructureReasonCategory}
+		}
+		if ar.BackoffBaseSeconds == nil {
+			ar.BackoffBaseSeconds = ptr.To[int32](DefaultReactivationBackoffBaseSeconds)
+		}
+		if ar.BackoffMaxSeconds == nil {
+			ar.BackoffMaxSeconds = ptr.To[int32](DefaultReactivationBackoffMaxSeconds)
+		}
+	}
+
+	if sa := cfg.SchedulingAudit; sa != nil && sa.BufferSize == nil {
+		sa.BufferSize = ptr.To[int32](DefaultSchedulingAuditBufferSize)
+	}
+
+	if ur := cfg.UsageReports; ur != nil {
+		if ur.Period == nil {
+			ur.Period = &metav1.Duration{Duration: DefaultUsageReportsPeriod}
+		}
+		if ur.SyncInterval == nil {
+			ur.SyncInterval = &metav1.Duration{Duration: DefaultUsageReportsSyncInterval}
+		}
+	}
+
+	if n := cfg.Notifications; n != nil && n.BufferSize == nil {
+		n.BufferSize = ptr.To[int32](DefaultNotificationsBufferSize)
+	}
+
+	if wh := cfg.WorkloadHistory; wh != nil {
+		if wh.Retention == nil {
+			wh.Retention = &metav1.Duration{Duration: DefaultWorkloadHistoryRetention}
+		}
+		if wh.BufferSize == nil {
+			wh.BufferSize = ptr.To[int32](DefaultWorkloadHistoryBufferSize)
+		}
+	}
+
+	if pd := cfg.PriorityDecay; pd != nil {
+		if pd.ThresholdPercent == nil {
+			pd.ThresholdPercent = ptr.To[int32](DefaultPriorityDecayThresholdPercent)
+		}
+		if pd.Step == nil {
+			pd.Step = ptr.To[int32](DefaultPriorityDecayStep)
+		}
+		if pd.Interval == nil {
+			pd.Interval = &metav1.Duration{Duration: DefaultPriorityDecayInterval}
+		}
+	}
+
+	if a := cfg.LocalQueueAuthorization; a != nil && a.Verb == "" {
+		a.Verb = DefaultLocalQueueAuthorizationVerb
+	}
+
+	if si := cfg.SubmitterIdentity; si != nil {
+		if si.Redaction == "" {
+			si.Redaction = NoSubmitterRedaction
+		}
+		if si.RecordGroups == nil {
+			si.RecordGroups = ptr.To(true)
+		}
+	}
+
+	for i := range cfg.AdmissionPolicies {
+		if cfg.AdmissionPolicies[i].Action == "" {
+			cfg.AdmissionPolicies[i].Action = RejectAdmissionPolicyAction
+		}
+	}
+
+	if dr := cfg.DeviceReadiness; dr != nil && dr.Timeout == nil {
+		dr.Timeout = &metav1.Duration{Duration: DefaultDeviceReadinessTimeout}
+	}
+
+	if ni := cfg.NodeInterruption; ni != nil && len(ni.TaintKeys) == 0 && len(ni.AnnotationKeys) == 0 {
+		ni.TaintKeys = slices.Clone(DefaultNodeInterruptionTaintKeys)
+	}
 }
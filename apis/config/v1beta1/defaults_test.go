@@ -122,7 +122,7 @@ func TestSetDefaults_Configuration(t *testing.T) {
 		WorkerLostTimeout: &metav1.Duration{Duration: DefaultMultiKueueWorkerLostTimeout},
 	}
 
-	podsReadyTimeoutTimeout := metav1.Duration{Duration: defaultPodsReadyTimeout}
+	podsReadyTimeoutTimeout := metav1.Duration{Duration: DefaultPodsReadyTimeout}
 	podsReadyTimeoutOverwrite := metav1.Duration{Duration: time.Minute}
 
 	testCases := map[string]struct {
@@ -612,6 +612,7 @@ func TestSetDefaults_Configuration(t *testing.T) {
 				FairSharing: &FairSharing{
 					Enable:               true,
 					PreemptionStrategies: []PreemptionStrategy{LessThanOrEqualToFinalShare, LessThanInitialShare},
+					VictimOrdering:       []VictimOrderingCriterion{LowestPriority, Newest},
 				},
 			},
 		},
@@ -646,6 +647,315 @@ func TestSetDefaults_Configuration(t *testing.T) {
 				},
 			},
 		},
+		"autoReactivation": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				AutoReactivation: &AutoReactivation{
+					BackoffLimitCount: ptr.To[int32](3),
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				AutoReactivation: &AutoReactivation{
+					ReasonCategories:   []string{InfrastructureReasonCategory},
+					BackoffLimitCount:  ptr.To[int32](3),
+					BackoffBaseSeconds: ptr.To[int32](DefaultReactivationBackoffBaseSeconds),
+					BackoffMaxSeconds:  ptr.To[int32](DefaultReactivationBackoffMaxSeconds),
+				},
+			},
+		},
+		"schedulingAudit": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				SchedulingAudit: &SchedulingAudit{
+					Path: ptr.To("/var/log/kueue/audit.log"),
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				SchedulingAudit: &SchedulingAudit{
+					Path:       ptr.To("/var/log/kueue/audit.log"),
+					BufferSize: ptr.To[int32](DefaultSchedulingAuditBufferSize),
+				},
+			},
+		},
+		"usageReports": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				UsageReports: &UsageReports{
+					Period: &metav1.Duration{Duration: time.Hour},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				UsageReports: &UsageReports{
+					Period:       &metav1.Duration{Duration: time.Hour},
+					SyncInterval: &metav1.Duration{Duration: DefaultUsageReportsSyncInterval},
+				},
+			},
+		},
+		"notifications": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				Notifications: &Notifications{
+					Webhooks: []NotificationWebhook{{URL: "https://example.com/hook"}},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				Notifications: &Notifications{
+					Webhooks:   []NotificationWebhook{{URL: "https://example.com/hook"}},
+					BufferSize: ptr.To[int32](DefaultNotificationsBufferSize),
+				},
+			},
+		},
+		"workloadHistory": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				WorkloadHistory: &WorkloadHistory{
+					File: &WorkloadHistoryFileStore{Directory: "/var/lib/kueue/history"},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				WorkloadHistory: &WorkloadHistory{
+					File:       &WorkloadHistoryFileStore{Directory: "/var/lib/kueue/history"},
+					Retention:  &metav1.Duration{Duration: DefaultWorkloadHistoryRetention},
+					BufferSize: ptr.To[int32](DefaultWorkloadHistoryBufferSize),
+				},
+			},
+		},
+		"priorityDecay": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				PriorityDecay: &PriorityDecay{
+					MinPriority: ptr.To[int32](0),
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				PriorityDecay: &PriorityDecay{
+					ThresholdPercent: ptr.To[int32](DefaultPriorityDecayThresholdPercent),
+					Step:             ptr.To[int32](DefaultPriorityDecayStep),
+					Interval:         &metav1.Duration{Duration: DefaultPriorityDecayInterval},
+					MinPriority:      ptr.To[int32](0),
+				},
+			},
+		},
+		"local queue authorization": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				LocalQueueAuthorization: &LocalQueueAuthorization{},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				LocalQueueAuthorization: &LocalQueueAuthorization{
+					Verb: DefaultLocalQueueAuthorizationVerb,
+				},
+			},
+		},
+		"device readiness": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				DeviceReadiness: &DeviceReadiness{
+					ResourceNames: []corev1.ResourceName{"nvidia.com/gpu"},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				DeviceReadiness: &DeviceReadiness{
+					ResourceNames: []corev1.ResourceName{"nvidia.com/gpu"},
+					Timeout:       &metav1.Duration{Duration: DefaultDeviceReadinessTimeout},
+				},
+			},
+		},
+		"node interruption": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				NodeInterruption: &NodeInterruption{},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				NodeInterruption: &NodeInterruption{
+					TaintKeys: DefaultNodeInterruptionTaintKeys,
+				},
+			},
+		},
+		"node interruption annotation keys": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				NodeInterruption: &NodeInterruption{
+					AnnotationKeys: []string{"example.com/interruption-notice"},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				NodeInterruption: &NodeInterruption{
+					AnnotationKeys: []string{"example.com/interruption-notice"},
+				},
+			},
+		},
+		"submitter identity": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				SubmitterIdentity: &SubmitterIdentity{},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				SubmitterIdentity: &SubmitterIdentity{
+					Redaction:    NoSubmitterRedaction,
+					RecordGroups: ptr.To(true),
+				},
+			},
+		},
+		"admission policies": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				AdmissionPolicies: []AdmissionPolicy{
+					{Name: "team-label", Expression: "has(workload.metadata.labels.team)"},
+					{Name: "max-pods", Expression: "workload.spec.podSets.all(ps, ps.count <= 8)", Action: DeactivateAdmissionPolicyAction},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection:             defaultClientConnection,
+				Integrations:                 defaultIntegrations,
+				QueueVisibility:              defaultQueueVisibility,
+				MultiKueue:                   defaultMultiKueue,
+				ManagedJobsNamespaceSelector: defaultManagedJobsNamespaceSelector,
+				AdmissionPolicies: []AdmissionPolicy{
+					{Name: "team-label", Expression: "has(workload.metadata.labels.team)", Action: RejectAdmissionPolicyAction},
+					{Name: "max-pods", Expression: "workload.spec.podSets.all(ps, ps.count <= 8)", Action: DeactivateAdmissionPolicyAction},
+				},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -21,13 +21,65 @@ limitations under the License.
 package v1beta1
 
 import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/component-base/config/v1alpha1"
+	apiv1 "k8s.io/component-base/tracing/api/v1"
 	timex "time"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionPolicy) DeepCopyInto(out *AdmissionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionPolicy.
+func (in *AdmissionPolicy) DeepCopy() *AdmissionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoReactivation) DeepCopyInto(out *AutoReactivation) {
+	*out = *in
+	if in.ReasonCategories != nil {
+		in, out := &in.ReasonCategories, &out.ReasonCategories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BackoffLimitCount != nil {
+		in, out := &in.BackoffLimitCount, &out.BackoffLimitCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffBaseSeconds != nil {
+		in, out := &in.BackoffBaseSeconds, &out.BackoffBaseSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffMaxSeconds != nil {
+		in, out := &in.BackoffMaxSeconds, &out.BackoffMaxSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoReactivation.
+func (in *AutoReactivation) DeepCopy() *AutoReactivation {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoReactivation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientConnection) DeepCopyInto(out *ClientConnection) {
 	*out = *in
@@ -53,6 +105,26 @@ func (in *ClientConnection) DeepCopy() *ClientConnection {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventsNotificationSink) DeepCopyInto(out *CloudEventsNotificationSink) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]NotificationEventType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventsNotificationSink.
+func (in *CloudEventsNotificationSink) DeepCopy() *CloudEventsNotificationSink {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventsNotificationSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterQueueVisibility) DeepCopyInto(out *ClusterQueueVisibility) {
 	*out = *in
@@ -123,6 +195,81 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 		*out = new(Resources)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AutoReactivation != nil {
+		in, out := &in.AutoReactivation, &out.AutoReactivation
+		*out = new(AutoReactivation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(apiv1.TracingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SchedulingAudit != nil {
+		in, out := &in.SchedulingAudit, &out.SchedulingAudit
+		*out = new(SchedulingAudit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsageReports != nil {
+		in, out := &in.UsageReports, &out.UsageReports
+		*out = new(UsageReports)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(Notifications)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShadowMode != nil {
+		in, out := &in.ShadowMode, &out.ShadowMode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.QueueingOrder != nil {
+		in, out := &in.QueueingOrder, &out.QueueingOrder
+		*out = new(QueueingOrder)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LocalQueueAuthorization != nil {
+		in, out := &in.LocalQueueAuthorization, &out.LocalQueueAuthorization
+		*out = new(LocalQueueAuthorization)
+		**out = **in
+	}
+	if in.SubmitterIdentity != nil {
+		in, out := &in.SubmitterIdentity, &out.SubmitterIdentity
+		*out = new(SubmitterIdentity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdmissionPolicies != nil {
+		in, out := &in.AdmissionPolicies, &out.AdmissionPolicies
+		*out = make([]AdmissionPolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeviceReadiness != nil {
+		in, out := &in.DeviceReadiness, &out.DeviceReadiness
+		*out = new(DeviceReadiness)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeInterruption != nil {
+		in, out := &in.NodeInterruption, &out.NodeInterruption
+		*out = new(NodeInterruption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeviceHealth != nil {
+		in, out := &in.DeviceHealth, &out.DeviceHealth
+		*out = new(DeviceHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkloadHistory != nil {
+		in, out := &in.WorkloadHistory, &out.WorkloadHistory
+		*out = new(WorkloadHistory)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PriorityDecay != nil {
+		in, out := &in.PriorityDecay, &out.PriorityDecay
+		*out = new(PriorityDecay)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Configuration.
@@ -194,7 +341,7 @@ func (in *ControllerManager) DeepCopyInto(out *ControllerManager) {
 		*out = new(v1alpha1.LeaderElectionConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
-	out.Metrics = in.Metrics
+	in.Metrics.DeepCopyInto(&out.Metrics)
 	out.Health = in.Health
 	if in.Controller != nil {
 		in, out := &in.Controller, &out.Controller
@@ -216,6 +363,11 @@ func (in *ControllerManager) DeepCopy() *ControllerManager {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerMetrics) DeepCopyInto(out *ControllerMetrics) {
 	*out = *in
+	if in.LocalQueueMetrics != nil {
+		in, out := &in.LocalQueueMetrics, &out.LocalQueueMetrics
+		*out = new(LocalQueueMetrics)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerMetrics.
@@ -248,6 +400,56 @@ func (in *ControllerWebhook) DeepCopy() *ControllerWebhook {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceHealth) DeepCopyInto(out *DeviceHealth) {
+	*out = *in
+	if in.ResourceNames != nil {
+		in, out := &in.ResourceNames, &out.ResourceNames
+		*out = make([]corev1.ResourceName, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnhealthyNodeConditions != nil {
+		in, out := &in.UnhealthyNodeConditions, &out.UnhealthyNodeConditions
+		*out = make([]corev1.NodeConditionType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceHealth.
+func (in *DeviceHealth) DeepCopy() *DeviceHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceReadiness) DeepCopyInto(out *DeviceReadiness) {
+	*out = *in
+	if in.ResourceNames != nil {
+		in, out := &in.ResourceNames, &out.ResourceNames
+		*out = make([]corev1.ResourceName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceReadiness.
+func (in *DeviceReadiness) DeepCopy() *DeviceReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceReadiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FairSharing) DeepCopyInto(out *FairSharing) {
 	*out = *in
@@ -256,6 +458,11 @@ func (in *FairSharing) DeepCopyInto(out *FairSharing) {
 		*out = make([]PreemptionStrategy, len(*in))
 		copy(*out, *in)
 	}
+	if in.VictimOrdering != nil {
+		in, out := &in.VictimOrdering, &out.VictimOrdering
+		*out = make([]VictimOrderingCriterion, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FairSharing.
@@ -268,6 +475,96 @@ func (in *FairSharing) DeepCopy() *FairSharing {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationController) DeepCopyInto(out *IntegrationController) {
+	*out = *in
+	if in.MaxConcurrentReconciles != nil {
+		in, out := &in.MaxConcurrentReconciles, &out.MaxConcurrentReconciles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClientConnection != nil {
+		in, out := &in.ClientConnection, &out.ClientConnection
+		*out = new(ClientConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResyncPeriod != nil {
+		in, out := &in.ResyncPeriod, &out.ResyncPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Informer != nil {
+		in, out := &in.Informer, &out.Informer
+		*out = new(IntegrationInformer)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationController.
+func (in *IntegrationController) DeepCopy() *IntegrationController {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationController)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationInformer) DeepCopyInto(out *IntegrationInformer) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationInformer.
+func (in *IntegrationInformer) DeepCopy() *IntegrationInformer {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationInformer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationWebhook) DeepCopyInto(out *IntegrationWebhook) {
+	*out = *in
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(admissionregistrationv1.FailurePolicyType)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationWebhook.
+func (in *IntegrationWebhook) DeepCopy() *IntegrationWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Integrations) DeepCopyInto(out *Integrations) {
 	*out = *in
@@ -291,6 +588,20 @@ func (in *Integrations) DeepCopyInto(out *Integrations) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]IntegrationWebhook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make([]IntegrationController, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Integrations.
@@ -333,6 +644,61 @@ func (in *InternalCertManagement) DeepCopy() *InternalCertManagement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaNotificationSink) DeepCopyInto(out *KafkaNotificationSink) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]NotificationEventType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaNotificationSink.
+func (in *KafkaNotificationSink) DeepCopy() *KafkaNotificationSink {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaNotificationSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalQueueAuthorization) DeepCopyInto(out *LocalQueueAuthorization) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueAuthorization.
+func (in *LocalQueueAuthorization) DeepCopy() *LocalQueueAuthorization {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalQueueAuthorization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalQueueMetrics) DeepCopyInto(out *LocalQueueMetrics) {
+	*out = *in
+	if in.LocalQueueSelector != nil {
+		in, out := &in.LocalQueueSelector, &out.LocalQueueSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueMetrics.
+func (in *LocalQueueMetrics) DeepCopy() *LocalQueueMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalQueueMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultiKueue) DeepCopyInto(out *MultiKueue) {
 	*out = *in
@@ -363,6 +729,119 @@ func (in *MultiKueue) DeepCopy() *MultiKueue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATSNotificationSink) DeepCopyInto(out *NATSNotificationSink) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]NotificationEventType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSNotificationSink.
+func (in *NATSNotificationSink) DeepCopy() *NATSNotificationSink {
+	if in == nil {
+		return nil
+	}
+	out := new(NATSNotificationSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeInterruption) DeepCopyInto(out *NodeInterruption) {
+	*out = *in
+	if in.TaintKeys != nil {
+		in, out := &in.TaintKeys, &out.TaintKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnnotationKeys != nil {
+		in, out := &in.AnnotationKeys, &out.AnnotationKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeInterruption.
+func (in *NodeInterruption) DeepCopy() *NodeInterruption {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeInterruption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationWebhook) DeepCopyInto(out *NotificationWebhook) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]NotificationEventType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationWebhook.
+func (in *NotificationWebhook) DeepCopy() *NotificationWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Notifications) DeepCopyInto(out *Notifications) {
+	*out = *in
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]NotificationWebhook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = make([]KafkaNotificationSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NATS != nil {
+		in, out := &in.NATS, &out.NATS
+		*out = make([]NATSNotificationSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CloudEvents != nil {
+		in, out := &in.CloudEvents, &out.CloudEvents
+		*out = make([]CloudEventsNotificationSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BufferSize != nil {
+		in, out := &in.BufferSize, &out.BufferSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notifications.
+func (in *Notifications) DeepCopy() *Notifications {
+	if in == nil {
+		return nil
+	}
+	out := new(Notifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodIntegrationOptions) DeepCopyInto(out *PodIntegrationOptions) {
 	*out = *in
@@ -388,6 +867,41 @@ func (in *PodIntegrationOptions) DeepCopy() *PodIntegrationOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityDecay) DeepCopyInto(out *PriorityDecay) {
+	*out = *in
+	if in.ThresholdPercent != nil {
+		in, out := &in.ThresholdPercent, &out.ThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Step != nil {
+		in, out := &in.Step, &out.Step
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinPriority != nil {
+		in, out := &in.MinPriority, &out.MinPriority
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityDecay.
+func (in *PriorityDecay) DeepCopy() *PriorityDecay {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityDecay)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QueueVisibility) DeepCopyInto(out *QueueVisibility) {
 	*out = *in
@@ -408,6 +922,26 @@ func (in *QueueVisibility) DeepCopy() *QueueVisibility {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueueingOrder) DeepCopyInto(out *QueueingOrder) {
+	*out = *in
+	if in.TieBreakers != nil {
+		in, out := &in.TieBreakers, &out.TieBreakers
+		*out = make([]TieBreaker, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueueingOrder.
+func (in *QueueingOrder) DeepCopy() *QueueingOrder {
+	if in == nil {
+		return nil
+	}
+	out := new(QueueingOrder)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequeuingStrategy) DeepCopyInto(out *RequeuingStrategy) {
 	*out = *in
@@ -497,6 +1031,81 @@ func (in *Resources) DeepCopy() *Resources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingAudit) DeepCopyInto(out *SchedulingAudit) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	if in.BufferSize != nil {
+		in, out := &in.BufferSize, &out.BufferSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingAudit.
+func (in *SchedulingAudit) DeepCopy() *SchedulingAudit {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingAudit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubmitterIdentity) DeepCopyInto(out *SubmitterIdentity) {
+	*out = *in
+	if in.RecordGroups != nil {
+		in, out := &in.RecordGroups, &out.RecordGroups
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubmitterIdentity.
+func (in *SubmitterIdentity) DeepCopy() *SubmitterIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(SubmitterIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageReports) DeepCopyInto(out *UsageReports) {
+	*out = *in
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SyncInterval != nil {
+		in, out := &in.SyncInterval, &out.SyncInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageReports.
+func (in *UsageReports) DeepCopy() *UsageReports {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageReports)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WaitForPodsReady) DeepCopyInto(out *WaitForPodsReady) {
 	*out = *in
@@ -526,3 +1135,48 @@ func (in *WaitForPodsReady) DeepCopy() *WaitForPodsReady {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadHistory) DeepCopyInto(out *WorkloadHistory) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(WorkloadHistoryFileStore)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BufferSize != nil {
+		in, out := &in.BufferSize, &out.BufferSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadHistory.
+func (in *WorkloadHistory) DeepCopy() *WorkloadHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadHistoryFileStore) DeepCopyInto(out *WorkloadHistoryFileStore) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadHistoryFileStore.
+func (in *WorkloadHistoryFileStore) DeepCopy() *WorkloadHistoryFileStore {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadHistoryFileStore)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueuebeta "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// BudgetEnforcementAction is the action taken when a Budget is exceeded.
+// +kubebuilder:validation:Enum=Warn;StopAdmission;EvictLowestPriority
+type BudgetEnforcementAction string
+
+const (
+	// BudgetWarn only reports the exceeded Budget, in its status and with an
+	// event.
+	BudgetWarn BudgetEnforcementAction = "Warn"
+
+	// BudgetStopAdmission keeps the new workloads of the LocalQueues of the
+	// exceeded Budget pending until the next period.
+	BudgetStopAdmission BudgetEnforcementAction = "StopAdmission"
+
+	// BudgetEvictLowestPriority stops the admission, like StopAdmission, and
+	// evicts the admitted workloads of the LocalQueues, lowest priority
+	// first, one every time the consumption is updated.
+	BudgetEvictLowestPriority BudgetEnforcementAction = "EvictLowestPriority"
+)
+
+const (
+	// BudgetExceeded is the condition set when the consumption of a Budget
+	// reaches one of its limits in the current period.
+	BudgetExceeded = "Exceeded"
+)
+
+// BudgetSpec defines the LocalQueues bound to a Budget, their limits per
+// period and the action taken when the limits are reached.
+type BudgetSpec struct {
+	// localQueues are the names of the LocalQueues, in the namespace of the
+	// Budget, bound to the Budget. When empty, all the LocalQueues of the
+	// namespace are bound to the Budget.
+	//
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	LocalQueues []string `json:"localQueues,omitempty"`
+
+	// resourceHours are the maximum resource-hours, across all the flavors,
+	// that the workloads of the LocalQueues can consume in each period of
+	// the UsageReports.
+	// The resources without a limit aren't limited.
+	//
+	// +optional
+	ResourceHours corev1.ResourceList `json:"resourceHours,omitempty"`
+
+	// cost is the maximum monetary cost of the consumption of the
+	// LocalQueues in each period of the UsageReports.
+	//
+	// +optional
+	Cost *BudgetCost `json:"cost,omitempty"`
+
+	// enforcementAction is the action taken when the consumption reaches one
+	// of the limits, until the end of the period. The possible values are:
+	//
+	// - `Warn`: the Budget is only reported as exceeded.
+	// - `StopAdmission`: the new workloads of the LocalQueues are kept
+	//   pending.
+	// - `EvictLowestPriority`: the new workloads of the LocalQueues are kept
+	//   pending, and the admitted workloads are evicted, lowest priority
+	//   first, one every time the consumption is updated.
+	//
+	// +kubebuilder:default=Warn
+	// +optional
+	EnforcementAction BudgetEnforcementAction `json:"enforcementAction,omitempty"`
+}
+
+type BudgetCost struct {
+	// limit is the maximum cost of the consumption in each period, in the
+	// currency of the prices.
+	Limit resource.Quantity `json:"limit"`
+
+	// prices are the costs of one resource-hour of the resources. The
+	// resources without a price don't count towards the cost.
+	//
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	Prices []ResourcePrice `json:"prices"`
+}
+
+type ResourcePrice struct {
+	// flavor the price applies to. When empty, the price applies to the
+	// resource in the flavors without a price of their own.
+	//
+	// +optional
+	Flavor kueuebeta.ResourceFlavorReference `json:"flavor,omitempty"`
+
+	// resource the price applies to.
+	Resource corev1.ResourceName `json:"resource"`
+
+	// pricePerHour is the cost of one resource-hour of the resource.
+	PricePerHour resource.Quantity `json:"pricePerHour"`
+}
+
+// BudgetStatus defines the observed consumption of a Budget.
+type BudgetStatus struct {
+	// periodStart is the beginning of the period of the consumption.
+	//
+	// +optional
+	PeriodStart *metav1.Time `json:"periodStart,omitempty"`
+
+	// resourceHours is the consumption of the LocalQueues in the period, in
+	// resource-hours, across all the flavors.
+	//
+	// +optional
+	ResourceHours corev1.ResourceList `json:"resourceHours,omitempty"`
+
+	// cost is the cost of the consumption of the LocalQueues in the period.
+	// It is only set when the Budget has a cost limit.
+	//
+	// +optional
+	Cost *resource.Quantity `json:"cost,omitempty"`
+
+	// conditions hold the latest available observations of the Budget
+	// current state.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Action",JSONPath=".spec.enforcementAction",type=string,description="Action taken when the Budget is exceeded"
+// +kubebuilder:printcolumn:name="Exceeded",JSONPath=".status.conditions[?(@.type=='Exceeded')].status",type=string,description="Whether the Budget is exceeded in the current period"
+// +kubebuilder:printcolumn:name="Age",JSONPath=".metadata.creationTimestamp",type=date,description="Time this Budget was created"
+
+// Budget is the Schema for the budgets API. A Budget limits the
+// resource-hours, or their cost, consumed by the workloads of LocalQueues of
+// a namespace in each period of the UsageReports, and defines how the limits
+// are enforced.
+type Budget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BudgetSpec   `json:"spec,omitempty"`
+	Status BudgetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BudgetList contains a list of Budget
+type BudgetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Budget `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Budget{}, &BudgetList{})
+}
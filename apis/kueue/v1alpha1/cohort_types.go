@@ -60,6 +60,33 @@ type CohortSpec struct {
 	//+listType=atomic
 	//+kubebuilder:validation:MaxItems=16
 	ResourceGroups []kueuebeta.ResourceGroup `json:"resourceGroups,omitempty"`
+
+	// QueueingStrategy indicates the queueing strategy of the workloads
+	// across all the ClusterQueues of the Cohort subtree.
+	// Current Supported Strategies:
+	//
+	// - StrictFIFO: the workloads of all the ClusterQueues are ordered
+	// together, by priority and then by creation time. Older workloads that
+	// can't be admitted will block admitting newer workloads of any of the
+	// ClusterQueues, even if they fit available quota.
+	// - BestEffortFIFO: the workloads are ordered by the queueing strategy of
+	// their ClusterQueue.
+	//
+	// +kubebuilder:default=BestEffortFIFO
+	// +kubebuilder:validation:Enum=StrictFIFO;BestEffortFIFO
+	// +optional
+	QueueingStrategy kueuebeta.QueueingStrategy `json:"queueingStrategy,omitempty"`
+
+	// PriorityClassMappings remaps the WorkloadPriorityClasses of the jobs
+	// submitted to the ClusterQueues of the Cohort subtree, unless a
+	// ClusterQueue, or a Cohort closer to it, maps the same class.
+	// The mappings are applied when the workloads are created.
+	//
+	// +listType=map
+	// +listMapKey=from
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	PriorityClassMappings []kueuebeta.PriorityClassMapping `json:"priorityClassMappings,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ImageSignatureControllerName is the name used by the image signature
+	// admission check controller.
+	ImageSignatureControllerName = "kueue.x-k8s.io/image-signature"
+)
+
+// ImageSignatureConfigSpec defines the keys trusted to sign the images of
+// the workloads.
+type ImageSignatureConfigSpec struct {
+	// publicKeys are the PEM encoded ECDSA public keys trusted to sign the
+	// images, as generated by `cosign generate-key-pair`. An image is
+	// verified when it has a cosign signature made by any of the keys.
+	//
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	PublicKeys []string `json:"publicKeys"`
+
+	// credentialsSecretName is the name of a Secret, of type
+	// kubernetes.io/dockerconfigjson in the namespace of Kueue, with the
+	// credentials to pull the signatures from the registries.
+	// If empty, the registries are accessed anonymously.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster
+
+// ImageSignatureConfig is the Schema for the imagesignatureconfig API. It
+// holds the parameters of the admission checks verifying the signatures of
+// the images of the workloads before their admission.
+type ImageSignatureConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ImageSignatureConfigSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ImageSignatureConfigList contains a list of ImageSignatureConfig
+type ImageSignatureConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ImageSignatureConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ImageSignatureConfig{}, &ImageSignatureConfigList{})
+}
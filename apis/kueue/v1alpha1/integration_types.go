@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// IntegrationActive indicates that the Integration is applied to the
+	// controller and the webhook of its framework.
+	IntegrationActive = "Active"
+
+	// IntegrationReasonActive is the reason of the Active condition when
+	// the Integration is applied.
+	IntegrationReasonActive = "Active"
+
+	// IntegrationReasonFrameworkNotSetUp is the reason of the Active condition
+	// when the framework is not enabled in the configuration, or its API is
+	// not installed in the cluster.
+	IntegrationReasonFrameworkNotSetUp = "FrameworkNotSetUp"
+
+	// IntegrationReasonDuplicate is the reason of the Active condition when
+	// an older Integration exists for the same framework.
+	IntegrationReasonDuplicate = "Duplicate"
+
+	// IntegrationReasonInvalidNamespaceSelector is the reason of the Active
+	// condition when the namespaceSelector, or the namespaceSelector of a
+	// manageJobsWithoutQueueName rule, can't be converted to a selector.
+	// The previous scope of the framework is kept.
+	IntegrationReasonInvalidNamespaceSelector = "InvalidNamespaceSelector"
+)
+
+type ManageJobsWithoutQueueNameAction string
+
+const (
+	// ManageJobsWithoutQueueNameActionManage makes Kueue manage the jobs
+	// without a queue name, as if manageJobsWithoutQueueName was true.
+	ManageJobsWithoutQueueNameActionManage ManageJobsWithoutQueueNameAction = "Manage"
+
+	// ManageJobsWithoutQueueNameActionIgnore makes Kueue ignore the jobs
+	// without a queue name, as if manageJobsWithoutQueueName was false.
+	ManageJobsWithoutQueueNameActionIgnore ManageJobsWithoutQueueNameAction = "Ignore"
+)
+
+// ManageJobsWithoutQueueNameRule decides whether Kueue manages the jobs of
+// the framework without a queue name in the namespaces it selects.
+type ManageJobsWithoutQueueNameRule struct {
+	// namespaceSelector selects the namespaces to which the rule applies.
+	// An empty selector selects all the namespaces.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+
+	// action indicates whether Kueue manages the jobs without a queue name
+	// in the selected namespaces. The possible values are:
+	// - Manage: Kueue manages the jobs, regardless of the
+	//   manageJobsWithoutQueueName and managedJobsNamespaceSelector of the
+	//   configuration.
+	// - Ignore: Kueue ignores the jobs.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Manage;Ignore
+	Action ManageJobsWithoutQueueNameAction `json:"action"`
+}
+
+// IntegrationSpec defines the scope in which Kueue manages the jobs of a
+// framework.
+type IntegrationSpec struct {
+	// framework is the name of the integration, as listed in
+	// integrations.frameworks of the configuration, for example "batch/job".
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="field is immutable"
+	Framework string `json:"framework"`
+
+	// enabled indicates whether Kueue manages the new jobs of the framework.
+	// When false, Kueue neither suspends nor creates workloads for the new
+	// jobs; the jobs which already have a workload are managed until they
+	// finish.
+	//
+	// +optional
+	// +kubebuilder:default=true
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// namespaceSelector restricts the namespaces in which Kueue manages
+	// the new jobs of the framework, whether they have a queue name or not.
+	// For the jobs without a queue name, the namespace must also match the
+	// managedJobsNamespaceSelector of the configuration.
+	// Defaults to null, which selects all the namespaces.
+	//
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// manageJobsWithoutQueueName is a list of rules deciding whether Kueue
+	// manages the jobs of the framework without a queue name, per namespace.
+	// The rules are evaluated in order and the first rule selecting the
+	// namespace of the job applies. When no rule selects the namespace,
+	// manageJobsWithoutQueueName and managedJobsNamespaceSelector of the
+	// configuration apply.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=16
+	ManageJobsWithoutQueueName []ManageJobsWithoutQueueNameRule `json:"manageJobsWithoutQueueName,omitempty"`
+}
+
+// IntegrationStatus defines the observed state of an Integration.
+type IntegrationStatus struct {
+	// conditions hold the latest available observations of the Integration
+	// current state.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Framework",JSONPath=".spec.framework",type=string,description="Name of the integration"
+// +kubebuilder:printcolumn:name="Enabled",JSONPath=".spec.enabled",type=boolean,description="Whether Kueue manages the new jobs of the integration"
+// +kubebuilder:printcolumn:name="Active",JSONPath=".status.conditions[?(@.type=='Active')].status",type=string,description="Whether the Integration is applied"
+// +kubebuilder:printcolumn:name="Age",JSONPath=".metadata.creationTimestamp",type=date,description="Time this Integration was created"
+
+// Integration is the Schema for the integrations API. It enables or disables
+// an integration and restricts the namespaces in which Kueue manages its jobs,
+// without restarting Kueue.
+type Integration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IntegrationSpec   `json:"spec,omitempty"`
+	Status IntegrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IntegrationList contains a list of Integration
+type IntegrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Integration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Integration{}, &IntegrationList{})
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DayOfWeek is a day of the week, like Monday.
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type DayOfWeek string
+
+// ScheduleSpec defines the recurring windows during which the ClusterQueues
+// referencing a Schedule admit workloads.
+type ScheduleSpec struct {
+	// windows are the recurring windows during which the ClusterQueues are
+	// open. Outside of the windows, the ClusterQueues are closed: their
+	// pending workloads are held, while the admitted workloads keep running.
+	// The overlapping windows are merged.
+	//
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Windows []ScheduleWindow `json:"windows"`
+
+	// timeZone is the name of the time zone, from the IANA time zone
+	// database, of the times of the windows. Defaults to UTC.
+	//
+	// +kubebuilder:default=UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+type ScheduleWindow struct {
+	// daysOfWeek are the days on which the window opens. When empty, the
+	// window opens every day.
+	//
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=7
+	// +optional
+	DaysOfWeek []DayOfWeek `json:"daysOfWeek,omitempty"`
+
+	// start is the time of the day, in the HH:MM format, at which the window
+	// opens.
+	//
+	// +kubebuilder:validation:Pattern="^([01][0-9]|2[0-3]):[0-5][0-9]$"
+	Start string `json:"start"`
+
+	// end is the time of the day, in the HH:MM format, at which the window
+	// closes. When end is not after start, the window closes on the next
+	// day.
+	//
+	// +kubebuilder:validation:Pattern="^([01][0-9]|2[0-3]):[0-5][0-9]$"
+	End string `json:"end"`
+}
+
+// ScheduleStatus defines the observed state of a Schedule.
+type ScheduleStatus struct {
+	// open is whether the ClusterQueues referencing the Schedule are open.
+	//
+	// +optional
+	Open bool `json:"open"`
+
+	// nextTransitionTime is the time at which the ClusterQueues will next
+	// open or close. It is not set when the windows never open.
+	//
+	// +optional
+	NextTransitionTime *metav1.Time `json:"nextTransitionTime,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Open",JSONPath=".status.open",type=boolean,description="Whether the ClusterQueues of the Schedule are open"
+// +kubebuilder:printcolumn:name="Next Transition",JSONPath=".status.nextTransitionTime",type=string,description="Time at which the ClusterQueues will next open or close"
+
+// Schedule is the Schema for the schedules API. A Schedule defines the
+// recurring windows, like the business hours, during which the ClusterQueues
+// referencing it admit workloads.
+type Schedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ScheduleSpec   `json:"spec,omitempty"`
+	Status ScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScheduleList contains a list of Schedule
+type ScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Schedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Schedule{}, &ScheduleList{})
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueuebeta "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// TenantSpec defines the LocalQueues grouped in a Tenant and their
+// aggregate limits.
+type TenantSpec struct {
+	// localQueues are the LocalQueues of the Tenant, which can be in
+	// different namespaces. A LocalQueue can be in several Tenants, in which
+	// case the usageLimits of all of them apply to its workloads.
+	//
+	// +listType=map
+	// +listMapKey=namespace
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=1000
+	// +optional
+	LocalQueues []TenantLocalQueue `json:"localQueues,omitempty"`
+
+	// usageLimits are the maximum quantities of the resources, across all
+	// the flavors, that the workloads of all the LocalQueues of the Tenant
+	// can reserve together, whichever their ClusterQueues are. The workloads
+	// which would exceed a limit are kept pending until enough workloads of
+	// the Tenant finish.
+	// The resources without a limit aren't limited.
+	//
+	// +optional
+	UsageLimits corev1.ResourceList `json:"usageLimits,omitempty"`
+
+	// fairSharing defines the properties of the Tenant when the fair sharing
+	// is enabled. The workloads of the Tenants with the lowest share, the
+	// highest ratio between their usage and their usageLimits divided by
+	// their weight, are considered first for admission in each scheduling
+	// cycle.
+	//
+	// +optional
+	FairSharing *kueuebeta.FairSharing `json:"fairSharing,omitempty"`
+}
+
+type TenantLocalQueue struct {
+	// namespace of the LocalQueue.
+	//
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Namespace string `json:"namespace"`
+
+	// name of the LocalQueue.
+	//
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+	Name string `json:"name"`
+}
+
+// TenantStatus defines the observed usage of a Tenant.
+type TenantStatus struct {
+	// usage is the quantities of the resources, across all the flavors,
+	// reserved by the workloads of the LocalQueues of the Tenant.
+	//
+	// +optional
+	Usage corev1.ResourceList `json:"usage,omitempty"`
+
+	// reservingWorkloads is the number of workloads of the LocalQueues of
+	// the Tenant with a quota reservation.
+	//
+	// +optional
+	ReservingWorkloads int32 `json:"reservingWorkloads"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Reserving Workloads",JSONPath=".status.reservingWorkloads",type=integer,description="Number of workloads of the Tenant with a quota reservation"
+
+// Tenant is the Schema for the tenants API. A Tenant groups LocalQueues,
+// which can be in different namespaces and point to different
+// ClusterQueues, with limits on their combined usage and a fair sharing
+// weight, so that the quotas of an organization spanning several namespaces
+// can be managed together.
+type Tenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantSpec   `json:"spec,omitempty"`
+	Status TenantStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TenantList contains a list of Tenant
+type TenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Tenant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Tenant{}, &TenantList{})
+}
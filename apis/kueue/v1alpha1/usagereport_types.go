@@ -0,0 +1,135 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueuebeta "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// UsageReportSpec defines the period covered by a UsageReport.
+type UsageReportSpec struct {
+	// periodStart is the beginning of the period covered by the report.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	PeriodStart metav1.Time `json:"periodStart"`
+
+	// periodEnd is the end of the period covered by the report.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	PeriodEnd metav1.Time `json:"periodEnd"`
+}
+
+// UsageReportStatus defines the resource usage aggregated in a UsageReport.
+type UsageReportStatus struct {
+	// flavors is the resource usage of all the LocalQueues in the namespace
+	// during the period.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Flavors []FlavorUsageHours `json:"flavors,omitempty"`
+
+	// localQueues is the resource usage of each LocalQueue in the namespace
+	// during the period.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	LocalQueues []LocalQueueUsageHours `json:"localQueues,omitempty"`
+
+	// lastUpdateTime is the last time at which the usage was added to the
+	// report.
+	//
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+type LocalQueueUsageHours struct {
+	// name of the LocalQueue.
+	Name string `json:"name"`
+
+	// clusterQueue is the ClusterQueue the LocalQueue pointed to when the
+	// usage was last added to the report.
+	ClusterQueue kueuebeta.ClusterQueueReference `json:"clusterQueue"`
+
+	// flavors is the resource usage of the workloads admitted in the
+	// LocalQueue during the period.
+	//
+	// +listType=map
+	// +listMapKey=name
+	Flavors []FlavorUsageHours `json:"flavors"`
+}
+
+type FlavorUsageHours struct {
+	// name of the flavor.
+	Name kueuebeta.ResourceFlavorReference `json:"name"`
+
+	// resources lists the usage of the resources in the flavor.
+	//
+	// +listType=map
+	// +listMapKey=name
+	Resources []ResourceUsageHours `json:"resources"`
+}
+
+type ResourceUsageHours struct {
+	// name of the resource.
+	Name corev1.ResourceName `json:"name"`
+
+	// hours is the quantity of the resource used by the admitted workloads,
+	// integrated over the time they were admitted and expressed in
+	// resource-hours. For example, a workload using 2 CPUs during 30
+	// minutes uses 1 CPU-hour.
+	Hours resource.Quantity `json:"hours"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Period Start",JSONPath=".spec.periodStart",type=date,description="Beginning of the period covered by the report"
+// +kubebuilder:printcolumn:name="Period End",JSONPath=".spec.periodEnd",type=date,description="End of the period covered by the report"
+// +kubebuilder:printcolumn:name="Last Update",JSONPath=".status.lastUpdateTime",type=date,description="Last time the usage was added to the report"
+
+// UsageReport is the Schema for the usagereports API. Kueue creates one
+// UsageReport per namespace and period, reporting the resources used by the
+// workloads admitted in the LocalQueues of the namespace, for chargeback.
+type UsageReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   UsageReportSpec   `json:"spec,omitempty"`
+	Status UsageReportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// UsageReportList contains a list of UsageReport
+type UsageReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UsageReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&UsageReport{}, &UsageReportList{})
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueuebeta "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// WorkloadTemplateSpec defines the approved shape of the workloads
+// referencing a WorkloadTemplate, and the admission checks they skip.
+type WorkloadTemplateSpec struct {
+	// podSets are the approved shapes of the pod sets. A workload matches the
+	// template when each of its pod sets has a shape, by name, that it fits
+	// in.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	PodSets []WorkloadTemplatePodSet `json:"podSets"`
+
+	// maxPriority is the highest priority of the matching workloads. When
+	// not set, the priority of the workloads isn't limited.
+	//
+	// +optional
+	MaxPriority *int32 `json:"maxPriority,omitempty"`
+
+	// skippedAdmissionChecks are the names of the admission checks which the
+	// matching workloads skip, so that they are admitted as soon as their
+	// quota is reserved when no other admission check applies to them.
+	//
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	SkippedAdmissionChecks []string `json:"skippedAdmissionChecks,omitempty"`
+}
+
+type WorkloadTemplatePodSet struct {
+	// name is the name of the pod set.
+	//
+	// +kubebuilder:default=main
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name string `json:"name"`
+
+	// maxCount is the highest number of pods of the pod set.
+	//
+	// +kubebuilder:validation:Minimum=1
+	MaxCount int32 `json:"maxCount"`
+
+	// maxRequests are the highest requests of each pod of the pod set. The
+	// pod sets requesting a resource without a maximum don't match.
+	MaxRequests corev1.ResourceList `json:"maxRequests"`
+
+	// flavors are the ResourceFlavors which the pod set can be assigned. When
+	// empty, the pod set can be assigned any flavor.
+	//
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Flavors []kueuebeta.ResourceFlavorReference `json:"flavors,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Max Priority",JSONPath=".spec.maxPriority",type=integer,description="Highest priority of the matching workloads"
+// +kubebuilder:printcolumn:name="Age",JSONPath=".metadata.creationTimestamp",type=date,description="Time this WorkloadTemplate was created"
+
+// WorkloadTemplate is the Schema for the workloadtemplates API. A
+// WorkloadTemplate captures pre-approved shapes of workloads, like the
+// standard sizes of the training jobs. The workloads referencing a template
+// with the kueue.x-k8s.io/workload-template label, and matching its shape,
+// skip some admission checks for a fast-path admission.
+type WorkloadTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec WorkloadTemplateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkloadTemplateList contains a list of WorkloadTemplate
+type WorkloadTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkloadTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkloadTemplate{}, &WorkloadTemplateList{})
+}
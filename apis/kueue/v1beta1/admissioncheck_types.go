@@ -64,6 +64,14 @@ type AdmissionCheckSpec struct {
 	// check.
 	// +optional
 	Parameters *AdmissionCheckParametersReference `json:"parameters,omitempty"`
+
+	// PendingTimeout is the maximum time the check may stay Pending for a
+	// workload with quota reserved. When it's exceeded, Kueue sets the check
+	// to Retry, so that the workload releases its quota and is requeued.
+	// If not set, the check may stay Pending indefinitely.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')", message="must be greater than 0"
+	PendingTimeout *metav1.Duration `json:"pendingTimeout,omitempty"`
 }
 
 type AdmissionCheckParametersReference struct {
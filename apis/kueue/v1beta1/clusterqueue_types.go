@@ -91,6 +91,15 @@ type ClusterQueueSpec struct {
 	// If set to an empty selector `{}`, then all namespaces are eligible.
 	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 
+	// localQueueNamespaceSelector defines the namespaces in which LocalQueues
+	// can point at this clusterQueue, so that teams can't bind their LocalQueues
+	// to the capacity of other teams. The LocalQueues in other namespaces are
+	// rejected when they are created, and their workloads aren't admitted.
+	// Defaults to null, which allows the LocalQueues of all the namespaces.
+	//
+	// +optional
+	LocalQueueNamespaceSelector *metav1.LabelSelector `json:"localQueueNamespaceSelector,omitempty"`
+
 	// flavorFungibility defines whether a workload should try the next flavor
 	// before borrowing or preempting in the flavor being evaluated.
 	// +kubebuilder:default={}
@@ -141,6 +150,272 @@ type ClusterQueueSpec struct {
 	// fairSharing defines the properties of the ClusterQueue when participating in fair sharing.
 	// The values are only relevant if fair sharing is enabled in the Kueue configuration.
 	FairSharing *FairSharing `json:"fairSharing,omitempty"`
+
+	// maximumExecutionTimeSeconds if provided, determines the maximum time, in seconds,
+	// the workloads admitted by this ClusterQueue can be admitted before they are
+	// automatically deactivated.
+	//
+	// The limit applies to all the workloads, regardless of their integration.
+	// If the workload also specifies its own maximumExecutionTimeSeconds, the
+	// smaller of the two values is enforced.
+	//
+	// If unspecified, no execution time limit is enforced at the ClusterQueue level.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaximumExecutionTimeSeconds *int32 `json:"maximumExecutionTimeSeconds,omitempty"`
+
+	// quotaShrinkPolicy defines how the ClusterQueue behaves when its usage exceeds
+	// the quota available to it, for example after its nominalQuota was reduced.
+	// +optional
+	QuotaShrinkPolicy *QuotaShrinkPolicy `json:"quotaShrinkPolicy,omitempty"`
+
+	// shadowMode, when true, makes Kueue compute the admissions and preemptions
+	// of the workloads in this ClusterQueue without enforcing them, to evaluate
+	// the ClusterQueue on a busy cluster: the jobs are neither suspended nor
+	// gated, and the workloads are not evicted to be preempted. The would-be
+	// preemptions are recorded in the events and metrics.
+	//
+	// All the ClusterQueues are in shadow mode when shadowMode is set in the
+	// Kueue configuration.
+	// +optional
+	ShadowMode *bool `json:"shadowMode,omitempty"`
+
+	// allowedPriorityClasses lists the names of the WorkloadPriorityClasses and
+	// PriorityClasses that the jobs submitted to this ClusterQueue can use.
+	// The jobs with another priority class are rejected by the webhooks.
+	// The jobs without a priority class are always accepted.
+	//
+	// If empty, any priority class can be used.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=64
+	AllowedPriorityClasses []string `json:"allowedPriorityClasses,omitempty"`
+
+	// priorityClassMappings remaps the WorkloadPriorityClasses of the jobs
+	// submitted to this ClusterQueue, to normalize the priorities of the jobs
+	// of different teams, for example to clamp an external "high" class to an
+	// internal "normal" class. The workloads of the jobs with a mapped class
+	// get the name and the value of the class it is mapped to.
+	// The mappings of the ClusterQueue take precedence over the mappings of its
+	// Cohorts. The mappings are applied when the workloads are created.
+	// +optional
+	// +listType=map
+	// +listMapKey=from
+	// +kubebuilder:validation:MaxItems=64
+	PriorityClassMappings []PriorityClassMapping `json:"priorityClassMappings,omitempty"`
+
+	// admissionPolicies are CEL expressions that the workloads must satisfy
+	// to be admitted in this ClusterQueue, in addition to the admission
+	// policies in the Kueue configuration.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	AdmissionPolicies []AdmissionPolicy `json:"admissionPolicies,omitempty"`
+
+	// requestsAccountingPolicy defines which requests of the pods of the
+	// workloads are counted against the quota of the ClusterQueue.
+	// +optional
+	RequestsAccountingPolicy *RequestsAccountingPolicy `json:"requestsAccountingPolicy,omitempty"`
+
+	// waitForPodsReady overrides the waitForPodsReady configuration of Kueue
+	// for the workloads admitted by this ClusterQueue, so that ClusterQueues
+	// for different kinds of workloads, like serving and gang-scheduled
+	// training, can wait for the pods to be ready differently.
+	// The fields which are not set take the values of the Kueue configuration.
+	// +optional
+	WaitForPodsReady *ClusterQueueWaitForPodsReady `json:"waitForPodsReady,omitempty"`
+
+	// schedule is the name of the Schedule defining the recurring windows
+	// during which this ClusterQueue is open. Outside of the windows, the
+	// pending workloads are held until the ClusterQueue opens, while the
+	// admitted workloads keep running.
+	// If empty, the ClusterQueue is always open.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+}
+
+type QuotaShrinkAction string
+
+const (
+	// QuotaShrinkNone means that the admitted workloads keep running and new
+	// workloads are admitted as long as they fit the remaining quota.
+	QuotaShrinkNone QuotaShrinkAction = "None"
+
+	// QuotaShrinkHoldAdmission means that no new workloads requesting the
+	// exceeded resources are admitted until the usage is back within the quota.
+	QuotaShrinkHoldAdmission QuotaShrinkAction = "HoldAdmission"
+
+	// QuotaShrinkEvictLowestPriority means that no new workloads requesting the
+	// exceeded resources are admitted and the admitted workloads with the lowest priority are evicted, one at a time,
+	// until the usage is back within the quota.
+	QuotaShrinkEvictLowestPriority QuotaShrinkAction = "EvictLowestPriority"
+)
+
+// QuotaShrinkPolicy defines the behavior of a ClusterQueue whose usage exceeds its quota.
+// The ClusterQueue exceeds its quota when, for any flavor and resource, its usage is
+// above its nominalQuota plus what it is allowed to borrow from its cohort.
+type QuotaShrinkPolicy struct {
+	// action determines what happens when the ClusterQueue exceeds its quota.
+	// The possible values are:
+	//
+	// - `None` (default): admitted workloads keep running, new workloads are
+	//   admitted as long as they fit.
+	// - `HoldAdmission`: no new workloads requesting the exceeded resources are
+	//   admitted until the usage is within the quota.
+	// - `EvictLowestPriority`: no new workloads requesting the exceeded resources
+	//   are admitted and the admitted workloads with the lowest priority, using
+	//   the exceeded resources, are evicted until the usage is within the quota.
+	//
+	// +kubebuilder:default=None
+	// +kubebuilder:validation:Enum=None;HoldAdmission;EvictLowestPriority
+	Action QuotaShrinkAction `json:"action,omitempty"`
+
+	// evictionIntervalSeconds is the minimum time, in seconds, between two
+	// consecutive evictions when the action is EvictLowestPriority.
+	// An eviction is also held until the previously evicted workload released its quota.
+	// +optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=0
+	EvictionIntervalSeconds *int32 `json:"evictionIntervalSeconds,omitempty"`
+}
+
+type RequestsSource string
+
+const (
+	// RequestsSourceDeclared means that the requests of the pod templates are
+	// counted against the quota.
+	RequestsSourceDeclared RequestsSource = "Declared"
+
+	// RequestsSourceVPARecommendation means that the requests recommended by
+	// the VerticalPodAutoscaler of the job are counted against the quota, when
+	// they are lower than the requests of the pod templates.
+	RequestsSourceVPARecommendation RequestsSource = "VPARecommendation"
+)
+
+// RequestsAccountingPolicy defines which requests of the pods of the workloads
+// are counted against the quota of a ClusterQueue.
+type RequestsAccountingPolicy struct {
+	// source determines the requests counted against the quota.
+	// The possible values are:
+	//
+	// - `Declared` (default): the requests of the pod templates.
+	// - `VPARecommendation`: for the containers with a recommendation from the
+	//   VerticalPodAutoscaler targeting the job of the workload, the target
+	//   recommendation, when it is lower than the requests of the container.
+	//   The requests of the pods are not changed, so the pods can use more
+	//   resources than counted against the quota.
+	//   It requires the VPAQuotaAccounting feature gate.
+	//
+	// +kubebuilder:default=Declared
+	// +kubebuilder:validation:Enum=Declared;VPARecommendation
+	Source RequestsSource `json:"source,omitempty"`
+}
+
+type ClusterQueueWaitForPodsReady struct {
+	// enable indicates whether Kueue waits for the pods of the workloads
+	// admitted by this ClusterQueue to be ready.
+	// +optional
+	Enable *bool `json:"enable,omitempty"`
+
+	// timeout defines the time for an admitted workload to reach the
+	// PodsReady=true condition. When the timeout is exceeded, the workload
+	// is evicted and requeued in the same ClusterQueue.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// blockAdmission, when true, blocks the admission of the workloads to this
+	// ClusterQueue until all the admitted workloads of the ClusterQueues
+	// blocking admission reach the PodsReady=true condition.
+	// +optional
+	BlockAdmission *bool `json:"blockAdmission,omitempty"`
+
+	// requeuingStrategy defines the backoff for requeuing the workloads
+	// evicted for exceeding the timeout.
+	// +optional
+	RequeuingStrategy *ClusterQueueRequeuingStrategy `json:"requeuingStrategy,omitempty"`
+}
+
+type ClusterQueueRequeuingStrategy struct {
+	// backoffLimitCount defines the maximum number of requeuing retries.
+	// Once the number is reached, the workload is deactivated.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	BackoffLimitCount *int32 `json:"backoffLimitCount,omitempty"`
+
+	// backoffBaseSeconds defines the base for the exponential backoff for
+	// requeuing an evicted workload.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	BackoffBaseSeconds *int32 `json:"backoffBaseSeconds,omitempty"`
+
+	// backoffMaxSeconds defines the maximum backoff time to requeue an
+	// evicted workload.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	BackoffMaxSeconds *int32 `json:"backoffMaxSeconds,omitempty"`
+}
+
+type AdmissionPolicyAction string
+
+const (
+	// AdmissionPolicyReject means that the workloads violating the policy are
+	// kept pending.
+	AdmissionPolicyReject AdmissionPolicyAction = "Reject"
+
+	// AdmissionPolicyDeactivate means that the workloads violating the policy
+	// are deactivated.
+	AdmissionPolicyDeactivate AdmissionPolicyAction = "Deactivate"
+)
+
+// AdmissionPolicy is a CEL expression that the workloads must satisfy to be
+// admitted.
+// PriorityClassMapping maps a WorkloadPriorityClass to another one.
+type PriorityClassMapping struct {
+	// from is the name of the WorkloadPriorityClass of the jobs.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+	From string `json:"from"`
+
+	// to is the name of the WorkloadPriorityClass of their workloads.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+	To string `json:"to"`
+}
+
+type AdmissionPolicy struct {
+	// name identifies the policy in the messages of the workloads violating it.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// expression is a CEL expression which must evaluate to true for the
+	// workload to be admitted. The Workload is available in the expression as
+	// the `workload` variable, for example:
+	// `workload.spec.podSets.all(ps, ps.count <= 8)` or
+	// `has(workload.metadata.labels.team)`.
+	// The workloads for which the evaluation of the expression fails violate
+	// the policy.
+	// +kubebuilder:validation:MaxLength=4096
+	// +kubebuilder:validation:MinLength=1
+	Expression string `json:"expression"`
+
+	// message is set in the conditions of the workloads violating the policy.
+	// If empty, the message mentions the expression.
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	Message string `json:"message,omitempty"`
+
+	// action determines what happens to the workloads violating the policy.
+	// The possible values are:
+	//
+	// - `Reject` (default): the workload is kept pending until it satisfies
+	//   the policy, or the policy is changed.
+	// - `Deactivate`: the workload is deactivated.
+	//
+	// +kubebuilder:default=Reject
+	// +kubebuilder:validation:Enum=Reject;Deactivate
+	Action AdmissionPolicyAction `json:"action,omitempty"`
 }
 
 // AdmissionChecksStrategy defines a strategy for a AdmissionCheck.
@@ -314,6 +589,50 @@ type ClusterQueueStatus struct {
 	// FairSharing contains the information about the current status of fair sharing.
 	// +optional
 	FairSharing *FairSharingStatus `json:"fairSharing,omitempty"`
+
+	// borrowing is the breakdown of the quota that this ClusterQueue borrows
+	// from, and lends to, the other ClusterQueues of its cohort tree.
+	// It is only populated when the ClusterQueueBorrowingStatus feature gate
+	// is enabled and the ClusterQueue belongs to a cohort.
+	// +optional
+	Borrowing *ClusterQueueBorrowingStatus `json:"borrowing,omitempty"`
+}
+
+// ClusterQueueBorrowingStatus reports the quota flows between a ClusterQueue
+// and the other ClusterQueues of its cohort tree.
+// The quota borrowed by a ClusterQueue is attributed to the lending
+// ClusterQueues in proportion to their unused lendable quota. The rest of
+// the borrowed quota, if any, comes from the quotas of the cohorts.
+type ClusterQueueBorrowingStatus struct {
+	// borrowingFrom lists the quota that this ClusterQueue borrows from the
+	// other ClusterQueues, sorted by clusterQueue, flavor and resource.
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	BorrowingFrom []QuotaFlow `json:"borrowingFrom,omitempty"`
+
+	// lendingTo lists the quota that this ClusterQueue lends to the other
+	// ClusterQueues, sorted by clusterQueue, flavor and resource.
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	LendingTo []QuotaFlow `json:"lendingTo,omitempty"`
+}
+
+// QuotaFlow is an amount of quota of a flavor and resource that is borrowed
+// from, or lent to, another ClusterQueue.
+type QuotaFlow struct {
+	// clusterQueue is the name of the other ClusterQueue.
+	ClusterQueue ClusterQueueReference `json:"clusterQueue"`
+
+	// flavor is the name of the flavor.
+	Flavor ResourceFlavorReference `json:"flavor"`
+
+	// resource is the name of the resource.
+	Resource corev1.ResourceName `json:"resource"`
+
+	// amount is the quantity of quota borrowed or lent.
+	Amount resource.Quantity `json:"amount"`
 }
 
 type ClusterQueuePendingWorkloadsStatus struct {
@@ -508,6 +827,30 @@ type FairSharing struct {
 	// be at disadvantage against other ClusterQueues.
 	// +kubebuilder:default=1
 	Weight *resource.Quantity `json:"weight,omitempty"`
+
+	// resourceWeights overrides the weight for the listed resources, so that a
+	// ClusterQueue can be favored on the resources it primarily needs, such as
+	// GPUs, without changing its share of the other resources.
+	// The share of each resource is divided by the weight of the resource, or
+	// by the weight when it isn't listed.
+	// A zero resource weight implies infinite share value when using the resource
+	// above the nominal quota.
+	// The resource weights are ignored when the weight is zero.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	ResourceWeights []ResourceWeight `json:"resourceWeights,omitempty"`
+}
+
+// ResourceWeight is the fair sharing weight of a resource.
+type ResourceWeight struct {
+	// name of the resource.
+	Name corev1.ResourceName `json:"name"`
+
+	// weight of the resource.
+	Weight resource.Quantity `json:"weight"`
 }
 
 // +genclient
@@ -41,6 +41,30 @@ type LocalQueueSpec struct {
 	// +kubebuilder:validation:Enum=None;Hold;HoldAndDrain
 	// +kubebuilder:default="None"
 	StopPolicy *StopPolicy `json:"stopPolicy,omitempty"`
+
+	// pendingTimeout, if set, moves the workloads that remain pending in this
+	// LocalQueue for too long to a fallback LocalQueue in the same namespace.
+	// +optional
+	PendingTimeout *PendingTimeout `json:"pendingTimeout,omitempty"`
+}
+
+// PendingTimeout defines when and where the pending workloads of a LocalQueue
+// are re-routed.
+type PendingTimeout struct {
+	// timeoutSeconds is the maximum time, in seconds, a workload can remain
+	// pending in the LocalQueue without reserving quota. The time is counted
+	// from the moment the workload was queued in the LocalQueue.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds int32 `json:"timeoutSeconds"`
+
+	// fallbackQueueName is the name of the LocalQueue, in the same namespace,
+	// the workloads are moved to once timeoutSeconds is exceeded.
+	// The queue-name label of the job owning the workload is updated accordingly.
+	// +required
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+	FallbackQueueName string `json:"fallbackQueueName"`
 }
 
 // ClusterQueueReference is the name of the ClusterQueue.
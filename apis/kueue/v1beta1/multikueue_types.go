@@ -44,11 +44,17 @@ const (
 	SecretLocationType LocationType = "Secret"
 )
 
+// +kubebuilder:validation:XValidation:rule="self.locationType == 'Secret' || !has(self.secretKey)", message="secretKey can only be set when the locationType is Secret"
 type KubeConfig struct {
 	// Location of the KubeConfig.
 	//
 	// If LocationType is Secret then Location is the name of the secret inside the namespace in
-	// which the kueue controller manager is running. The config should be stored in the "kubeconfig" key.
+	// which the kueue controller manager is running. The config should be stored in the "kubeconfig" key,
+	// unless SecretKey is set.
+	//
+	// If LocationType is Path then Location is the path of the KubeConfig in the disk of the
+	// kueue-controller-manager, for example in a volume of the Secrets Store CSI driver. The
+	// changes of the file, including the atomic updates of the mounted volumes, are watched.
 	Location string `json:"location"`
 
 	// Type of the KubeConfig location.
@@ -56,6 +62,53 @@ type KubeConfig struct {
 	// +kubebuilder:default=Secret
 	// +kubebuilder:validation:Enum=Secret;Path
 	LocationType LocationType `json:"locationType"`
+
+	// SecretKey is the key of the Secret holding the KubeConfig, for the Secrets
+	// synchronized from external secret managers using their own keys.
+	// Defaults to "kubeconfig".
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern="^[-._a-zA-Z0-9]+$"
+	SecretKey string `json:"secretKey,omitempty"`
+
+	// ServiceAccountToken, when set, makes Kueue authenticate to the cluster with a
+	// bound token of a ServiceAccount, requested with the TokenRequest API and renewed
+	// before it expires, instead of with the credentials of the KubeConfig. The
+	// KubeConfig only needs to provide the server and its certificate authority.
+	// The cluster has to trust the service account issuer of the manager cluster.
+	//
+	// +optional
+	ServiceAccountToken *ServiceAccountTokenSource `json:"serviceAccountToken,omitempty"`
+}
+
+// ServiceAccountTokenSource describes the bound tokens requested for a
+// ServiceAccount.
+type ServiceAccountTokenSource struct {
+	// Name of the ServiceAccount, in the namespace in which the kueue controller
+	// manager is running.
+	//
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+	Name string `json:"name"`
+
+	// Audiences are the intended audiences of the token. The cluster should
+	// reject the tokens without one of its audiences.
+	// Defaults to the audiences of the API server of the manager cluster.
+	//
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=8
+	Audiences []string `json:"audiences,omitempty"`
+
+	// ExpirationSeconds is the requested duration of validity of the token.
+	// The tokens are renewed after 80% of their validity.
+	// Defaults to 3600 seconds.
+	//
+	// +optional
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=600
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
 }
 
 type MultiKueueClusterSpec struct {
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -204,6 +205,7 @@ type PodSetAssignment struct {
 	TopologyAssignment *TopologyAssignment `json:"topologyAssignment,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="has(self.domains) != has(self.domainGroups)", message="exactly one of domains and domainGroups must be set"
 type TopologyAssignment struct {
 	// levels is an ordered list of keys denoting the levels of the assigned
 	// topology (i.e. node label keys), from the highest to the lowest level of
@@ -217,9 +219,41 @@ type TopologyAssignment struct {
 
 	// domains is a list of topology assignments split by topology domains at
 	// the lowest level of the topology.
+	// Exactly one of domains and domainGroups is set.
 	//
-	// +required
-	Domains []TopologyDomainAssignment `json:"domains"`
+	// +optional
+	Domains []TopologyDomainAssignment `json:"domains,omitempty"`
+
+	// domainGroups is a compact representation of the list of topology
+	// assignments split by topology domains at the lowest level of the
+	// topology, used instead of domains, when the CompactTopologyAssignment
+	// feature gate is enabled, to keep the assignments of workloads with many
+	// pods within the size limits of the Workload objects.
+	// The domains sharing the values of the levels above the lowest level are
+	// grouped, in the order of the assignment.
+	//
+	// Example:
+	//
+	// topologyAssignment:
+	//   levels:
+	//   - cloud.provider.com/topology-rack
+	//   - kubernetes.io/hostname
+	//   domainGroups:
+	//   - values: [rack-1]
+	//     lowestLevelPrefix: node-
+	//     lowestLevelValues: ["1", "2", "3"]
+	//     counts: [4]
+	//   - values: [rack-2]
+	//     lowestLevelPrefix: node-
+	//     lowestLevelValues: ["4", "5"]
+	//     counts: [4, 2]
+	//
+	// Here, 4 Pods are to be scheduled on each of the nodes node-1, node-2,
+	// node-3 and node-4, and 2 Pods on the node node-5.
+	//
+	// +optional
+	// +listType=atomic
+	DomainGroups []TopologyDomainGroupAssignment `json:"domainGroups,omitempty"`
 }
 
 type TopologyDomainAssignment struct {
@@ -241,6 +275,48 @@ type TopologyDomainAssignment struct {
 	Count int32 `json:"count"`
 }
 
+// +kubebuilder:validation:XValidation:rule="size(self.counts) == 1 || size(self.counts) == size(self.lowestLevelValues)", message="counts should have a single item or one item per lowest level value"
+type TopologyDomainGroupAssignment struct {
+	// values is an ordered list of node selector values of the levels above
+	// the lowest level, shared by the domains of the group. It's empty if the
+	// topology has a single level.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=7
+	Values []string `json:"values,omitempty"`
+
+	// lowestLevelPrefix is the prefix shared by the node selector values of
+	// the lowest level of the domains of the group.
+	//
+	// +optional
+	LowestLevelPrefix string `json:"lowestLevelPrefix,omitempty"`
+
+	// lowestLevelSuffix is the suffix shared by the node selector values of
+	// the lowest level of the domains of the group.
+	//
+	// +optional
+	LowestLevelSuffix string `json:"lowestLevelSuffix,omitempty"`
+
+	// lowestLevelValues is the list of node selector values of the lowest
+	// level of the domains of the group, without their shared prefix and
+	// suffix.
+	//
+	// +required
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	LowestLevelValues []string `json:"lowestLevelValues"`
+
+	// counts indicates the number of Pods to be scheduled in each domain of
+	// the group, in the order of lowestLevelValues. A single count applies to
+	// all the domains of the group.
+	//
+	// +required
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	Counts []int32 `json:"counts"`
+}
+
 // +kubebuilder:validation:XValidation:rule="has(self.minCount) ? self.minCount <= self.count : true", message="minCount should be positive and less or equal to count"
 type PodSet struct {
 	// name is the PodSet name.
@@ -347,11 +423,146 @@ type WorkloadStatus struct {
 	// +kubebuilder:validation:MaxItems=8
 	ResourceRequests []PodSetRequest `json:"resourceRequests,omitempty"`
 
+	// recommendedRequests are the requests of a pod of each podSet, recommended
+	// by the VerticalPodAutoscaler of the job, which are counted against the
+	// quota instead of the requests of the pod templates, when the ClusterQueue
+	// of the workload has the VPARecommendation requestsAccountingPolicy.
+	// They are only updated while the workload has no quota reserved.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=8
+	RecommendedRequests []PodSetRequest `json:"recommendedRequests,omitempty"`
+
 	// accumulatedPastExexcutionTimeSeconds holds the total time, in seconds, the workload spent
 	// in Admitted state, in the previous `Admit` - `Evict` cycles.
 	//
 	// +optional
 	AccumulatedPastExexcutionTimeSeconds *int32 `json:"accumulatedPastExexcutionTimeSeconds,omitempty"`
+
+	// deactivation holds the details of the last deactivation of the workload
+	// and of its automatic reactivation.
+	//
+	// +optional
+	Deactivation *WorkloadDeactivation `json:"deactivation,omitempty"`
+
+	// eviction holds the reason and the category of the last eviction of the
+	// workload.
+	//
+	// +optional
+	Eviction *WorkloadEviction `json:"eviction,omitempty"`
+
+	// pendingReasons are the machine-readable reasons for which the workload is
+	// pending, per pod set and flavor.
+	// While the workload has no quota reserved, they are updated by every
+	// scheduling cycle which evaluates the workload. Once the workload has quota
+	// reserved, they list the admission checks which are not ready yet.
+	// They are only set when the WorkloadPendingReasons feature gate is enabled.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=64
+	PendingReasons []PendingReason `json:"pendingReasons,omitempty"`
+}
+
+type PendingReasonType string
+
+const (
+	// PendingReasonInsufficientQuota means that there is not enough unused quota
+	// for the resource in the flavor, in the ClusterQueue and its cohort.
+	PendingReasonInsufficientQuota PendingReasonType = "InsufficientQuota"
+
+	// PendingReasonExceedsMaximumCapacity means that the request for the resource
+	// exceeds the maximum capacity in the flavor, including the borrowing.
+	PendingReasonExceedsMaximumCapacity PendingReasonType = "ExceedsMaximumCapacity"
+
+	// PendingReasonResourceUnavailable means that the ClusterQueue doesn't
+	// provide the resource.
+	PendingReasonResourceUnavailable PendingReasonType = "ResourceUnavailable"
+
+	// PendingReasonFlavorNotFound means that the flavor doesn't exist.
+	PendingReasonFlavorNotFound PendingReasonType = "FlavorNotFound"
+
+	// PendingReasonFlavorNotPinned means that the pod set is pinned to another
+	// flavor by the kueue.x-k8s.io/podset-flavors annotation of the workload.
+	PendingReasonFlavorNotPinned PendingReasonType = "FlavorNotPinned"
+
+	// PendingReasonFlavorExcluded means that the flavor is excluded by the
+	// kueue.x-k8s.io/excluded-flavors annotation of the workload.
+	PendingReasonFlavorExcluded PendingReasonType = "FlavorExcluded"
+
+	// PendingReasonUntoleratedTaint means that the pod set doesn't tolerate a
+	// taint of the flavor.
+	PendingReasonUntoleratedTaint PendingReasonType = "UntoleratedTaint"
+
+	// PendingReasonNodeAffinityMismatch means that the node affinity of the pod
+	// set doesn't match the flavor.
+	PendingReasonNodeAffinityMismatch PendingReasonType = "NodeAffinityMismatch"
+
+	// PendingReasonTopologyInfeasible means that the topology request of the pod
+	// set can't be satisfied in the flavor.
+	PendingReasonTopologyInfeasible PendingReasonType = "TopologyInfeasible"
+
+	// PendingReasonNodePoolLimitExceeded means that the request for the
+	// resource, added to the usage of the flavor by all the ClusterQueues,
+	// exceeds the limits of the Karpenter NodePool of the flavor.
+	PendingReasonNodePoolLimitExceeded PendingReasonType = "NodePoolLimitExceeded"
+
+	// PendingReasonUnhealthyDevices means that the request for the resource,
+	// added to the usage of the flavor by all the ClusterQueues, exceeds the
+	// nominal quota of the flavor, reduced by the unhealthy devices of its
+	// nodes.
+	PendingReasonUnhealthyDevices PendingReasonType = "UnhealthyDevices"
+
+	// PendingReasonAdmissionCheck means that the admission check is not ready.
+	PendingReasonAdmissionCheck PendingReasonType = "AdmissionCheck"
+)
+
+type PendingReason struct {
+	// reason is the code of the reason for which the workload is pending.
+	// The possible values are "InsufficientQuota", "ExceedsMaximumCapacity",
+	// "ResourceUnavailable", "FlavorNotFound", "FlavorNotPinned", "FlavorExcluded",
+	// "UntoleratedTaint", "NodeAffinityMismatch", "TopologyInfeasible",
+	// "NodePoolLimitExceeded", "UnhealthyDevices" and "AdmissionCheck".
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Reason PendingReasonType `json:"reason"`
+
+	// podSet is the name of the pod set which can't be assigned the flavor.
+	//
+	// +optional
+	PodSet string `json:"podSet,omitempty"`
+
+	// flavor is the name of the flavor which can't be assigned.
+	//
+	// +optional
+	Flavor ResourceFlavorReference `json:"flavor,omitempty"`
+
+	// resource is the name of the resource, when the reason is specific to a
+	// resource.
+	//
+	// +optional
+	Resource corev1.ResourceName `json:"resource,omitempty"`
+
+	// missing is the quantity of the resource which is missing in the flavor,
+	// for the reasons "InsufficientQuota" and "ExceedsMaximumCapacity".
+	//
+	// +optional
+	Missing *resource.Quantity `json:"missing,omitempty"`
+
+	// admissionCheck is the name of the admission check which is not ready,
+	// for the reason "AdmissionCheck".
+	//
+	// +optional
+	AdmissionCheck string `json:"admissionCheck,omitempty"`
+
+	// message is a human readable message explaining the reason.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	Message string `json:"message,omitempty"`
 }
 
 type RequeueState struct {
@@ -371,6 +582,114 @@ type RequeueState struct {
 	RequeueAt *metav1.Time `json:"requeueAt,omitempty"`
 }
 
+// DeactivationReasonCategory classifies the reasons for the deactivation of a workload.
+type DeactivationReasonCategory string
+
+const (
+	// DeactivationCategoryInfrastructure classifies the deactivations caused
+	// by the infrastructure, like exceeding the maximum number of re-queuing
+	// retries because the pods didn't become ready, or the rejection of an
+	// admission check.
+	DeactivationCategoryInfrastructure DeactivationReasonCategory = "Infrastructure"
+
+	// DeactivationCategoryUser classifies the deactivations caused by the
+	// workload or its user, like exceeding the maximum execution time or
+	// setting spec.active to false.
+	DeactivationCategoryUser DeactivationReasonCategory = "User"
+)
+
+type WorkloadDeactivation struct {
+	// reason is the code of the reason for which the workload was deactivated.
+	// The possible values are:
+	//
+	// - "RequeuingLimitExceeded": the workload exceeded the maximum number of re-queuing retries.
+	// - "AdmissionCheck": at least one admission check of the workload was rejected.
+	// - "MaximumExecutionTimeExceeded": the workload exceeded its maximum execution time.
+	// - "Deactivated": spec.active was set to false.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Reason string `json:"reason"`
+
+	// category classifies the reason of the deactivation.
+	// The possible values are "Infrastructure" and "User".
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Infrastructure;User
+	Category DeactivationReasonCategory `json:"category"`
+
+	// reactivateAt records the time when the workload will be reactivated,
+	// when the auto reactivation policy of Kueue applies to the deactivation.
+	//
+	// +optional
+	ReactivateAt *metav1.Time `json:"reactivateAt,omitempty"`
+
+	// reactivationCount records the number of times the workload was
+	// reactivated automatically.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ReactivationCount int32 `json:"reactivationCount,omitempty"`
+}
+
+// EvictionReasonCategory classifies the reasons for the eviction of a
+// workload, consistently across the controllers evicting workloads.
+type EvictionReasonCategory string
+
+const (
+	// EvictionCategoryPodsReadyTimeout classifies the evictions caused by the
+	// pods of the workload not becoming ready within the timeout.
+	EvictionCategoryPodsReadyTimeout EvictionReasonCategory = "PodsReadyTimeout"
+
+	// EvictionCategoryPreemptedByReclaim classifies the preemptions to reclaim
+	// the quota lent to the cohort, or the fair share of another ClusterQueue.
+	EvictionCategoryPreemptedByReclaim EvictionReasonCategory = "PreemptedByReclaim"
+
+	// EvictionCategoryPreemptedByPriority classifies the preemptions by a
+	// workload with a higher priority in the same ClusterQueue.
+	EvictionCategoryPreemptedByPriority EvictionReasonCategory = "PreemptedByPriority"
+
+	// EvictionCategoryAdminStop classifies the evictions caused by an
+	// administrator, like stopping the queues, shrinking the quota or
+	// deactivating the workload.
+	EvictionCategoryAdminStop EvictionReasonCategory = "AdminStop"
+
+	// EvictionCategorySpotReclaim classifies the evictions caused by the
+	// interruption of a node, like the reclaim of a spot instance.
+	EvictionCategorySpotReclaim EvictionReasonCategory = "SpotReclaim"
+
+	// EvictionCategoryNodeFailure classifies the evictions caused by a node
+	// which is not ready or unreachable.
+	EvictionCategoryNodeFailure EvictionReasonCategory = "NodeFailure"
+
+	// EvictionCategoryChecksFailed classifies the evictions caused by the
+	// failure or the rejection of an admission check.
+	EvictionCategoryChecksFailed EvictionReasonCategory = "ChecksFailed"
+
+	// EvictionCategoryOther classifies the other evictions, like the in-place
+	// resize of the pods exceeding the available quota.
+	EvictionCategoryOther EvictionReasonCategory = "Other"
+)
+
+type WorkloadEviction struct {
+	// reason is the reason of the Evicted condition set by the eviction.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Reason string `json:"reason"`
+
+	// category classifies the reason of the eviction.
+	// The possible values are "PodsReadyTimeout", "PreemptedByReclaim",
+	// "PreemptedByPriority", "AdminStop", "SpotReclaim", "NodeFailure",
+	// "ChecksFailed" and "Other".
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=PodsReadyTimeout;PreemptedByReclaim;PreemptedByPriority;AdminStop;SpotReclaim;NodeFailure;ChecksFailed;Other
+	Category EvictionReasonCategory `json:"category"`
+}
+
 type AdmissionCheckState struct {
 	// name identifies the admission check.
 	// +required
@@ -400,6 +719,32 @@ type AdmissionCheckState struct {
 	// +listType=atomic
 	// +kubebuilder:validation:MaxItems=8
 	PodSetUpdates []PodSetUpdate `json:"podSetUpdates,omitempty"`
+
+	// history holds the previous states of the admission check, oldest first,
+	// with the times they were entered. At most 8 previous states are kept.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=8
+	History []AdmissionCheckStateTransition `json:"history,omitempty"`
+}
+
+// MaxAdmissionCheckHistory is the maximum number of previous states kept in
+// the history of an admission check.
+const MaxAdmissionCheckHistory = 8
+
+// AdmissionCheckStateTransition records a state entered by an admission check.
+type AdmissionCheckStateTransition struct {
+	// state entered by the admissionCheck, one of Pending, Ready, Retry, Rejected
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Pending;Ready;Retry;Rejected
+	State CheckState `json:"state"`
+	// lastTransitionTime is the time the state was entered.
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=date-time
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
 }
 
 // PodSetUpdate contains a list of pod set modifications suggested by AdmissionChecks.
@@ -498,6 +843,16 @@ const (
 	// WorkloadDeactivationTarget means that the Workload should be deactivated.
 	// This condition is temporary, so it should be removed after deactivation.
 	WorkloadDeactivationTarget = "DeactivationTarget"
+
+	// WorkloadQueueRerouted means that the Workload was moved to the fallback
+	// LocalQueue because it exceeded the pendingTimeout of its LocalQueue.
+	// The message records the source and the target LocalQueue.
+	WorkloadQueueRerouted = "QueueRerouted"
+
+	// WorkloadResizePending means that the counts of the podSets of the
+	// Workload, which has quota reserved, were increased, but the additional
+	// quota is not available in the ClusterQueue yet.
+	WorkloadResizePending = "ResizePending"
 )
 
 // Reasons for the WorkloadPreempted condition.
@@ -544,6 +899,27 @@ const (
 	// because the LocalQueue is Stopped.
 	WorkloadEvictedByLocalQueueStopped = "LocalQueueStopped"
 
+	// WorkloadEvictedByQuotaShrink indicates that the workload was evicted
+	// because the usage of its ClusterQueue exceeded the quota.
+	WorkloadEvictedByQuotaShrink = "QuotaShrink"
+
+	// WorkloadEvictedByPodResize indicates that the workload was evicted
+	// because the in-place resize of its pods exceeded the available quota.
+	WorkloadEvictedByPodResize = "PodResize"
+
+	// WorkloadEvictedByNodeInterruption indicates that the workload was evicted
+	// because one of the nodes of its pods is about to be interrupted.
+	WorkloadEvictedByNodeInterruption = "NodeInterruption"
+
+	// WorkloadEvictedByNodeFailure indicates that the workload was evicted
+	// because the nodes of a topology domain assigned to its pods failed,
+	// and no replacement domain was found.
+	WorkloadEvictedByNodeFailure = "NodeFailure"
+
+	// WorkloadEvictedByBudget indicates that the workload was evicted because
+	// the Budget of its LocalQueue was exceeded.
+	WorkloadEvictedByBudget = "BudgetExceeded"
+
 	// WorkloadEvictedByDeactivation indicates that the workload was evicted
 	// because spec.active is set to false.
 	// Deprecated: The reason is not set any longer, it is only kept temporarily to ensure
@@ -578,6 +954,14 @@ const (
 	// WorkloadMaximumExecutionTimeExceeded indicates that the workload exceeded its
 	// maximum execution time.
 	WorkloadMaximumExecutionTimeExceeded = "MaximumExecutionTimeExceeded"
+
+	// WorkloadPendingTimeoutExceeded indicates that the workload exceeded the
+	// pending timeout of its LocalQueue.
+	WorkloadPendingTimeoutExceeded = "PendingTimeoutExceeded"
+
+	// WorkloadAdmissionPolicyViolated indicates that the workload violated an
+	// admission policy with the Deactivate action.
+	WorkloadAdmissionPolicyViolated = "AdmissionPolicyViolated"
 )
 
 const (
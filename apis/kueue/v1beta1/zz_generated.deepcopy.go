@@ -135,6 +135,11 @@ func (in *AdmissionCheckSpec) DeepCopyInto(out *AdmissionCheckSpec) {
 		*out = new(AdmissionCheckParametersReference)
 		**out = **in
 	}
+	if in.PendingTimeout != nil {
+		in, out := &in.PendingTimeout, &out.PendingTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionCheckSpec.
@@ -158,6 +163,13 @@ func (in *AdmissionCheckState) DeepCopyInto(out *AdmissionCheckState) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]AdmissionCheckStateTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionCheckState.
@@ -170,6 +182,22 @@ func (in *AdmissionCheckState) DeepCopy() *AdmissionCheckState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionCheckStateTransition) DeepCopyInto(out *AdmissionCheckStateTransition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionCheckStateTransition.
+func (in *AdmissionCheckStateTransition) DeepCopy() *AdmissionCheckStateTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionCheckStateTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdmissionCheckStatus) DeepCopyInto(out *AdmissionCheckStatus) {
 	*out = *in
@@ -234,6 +262,21 @@ func (in *AdmissionChecksStrategy) DeepCopy() *AdmissionChecksStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionPolicy) DeepCopyInto(out *AdmissionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionPolicy.
+func (in *AdmissionPolicy) DeepCopy() *AdmissionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BorrowWithinCohort) DeepCopyInto(out *BorrowWithinCohort) {
 	*out = *in
@@ -281,6 +324,35 @@ func (in *ClusterQueue) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQueueBorrowingStatus) DeepCopyInto(out *ClusterQueueBorrowingStatus) {
+	*out = *in
+	if in.BorrowingFrom != nil {
+		in, out := &in.BorrowingFrom, &out.BorrowingFrom
+		*out = make([]QuotaFlow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LendingTo != nil {
+		in, out := &in.LendingTo, &out.LendingTo
+		*out = make([]QuotaFlow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueueBorrowingStatus.
+func (in *ClusterQueueBorrowingStatus) DeepCopy() *ClusterQueueBorrowingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQueueBorrowingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterQueueList) DeepCopyInto(out *ClusterQueueList) {
 	*out = *in
@@ -369,6 +441,36 @@ func (in *ClusterQueuePreemption) DeepCopy() *ClusterQueuePreemption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQueueRequeuingStrategy) DeepCopyInto(out *ClusterQueueRequeuingStrategy) {
+	*out = *in
+	if in.BackoffLimitCount != nil {
+		in, out := &in.BackoffLimitCount, &out.BackoffLimitCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffBaseSeconds != nil {
+		in, out := &in.BackoffBaseSeconds, &out.BackoffBaseSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffMaxSeconds != nil {
+		in, out := &in.BackoffMaxSeconds, &out.BackoffMaxSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueueRequeuingStrategy.
+func (in *ClusterQueueRequeuingStrategy) DeepCopy() *ClusterQueueRequeuingStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQueueRequeuingStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterQueueSpec) DeepCopyInto(out *ClusterQueueSpec) {
 	*out = *in
@@ -384,6 +486,11 @@ func (in *ClusterQueueSpec) DeepCopyInto(out *ClusterQueueSpec) {
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LocalQueueNamespaceSelector != nil {
+		in, out := &in.LocalQueueNamespaceSelector, &out.LocalQueueNamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FlavorFungibility != nil {
 		in, out := &in.FlavorFungibility, &out.FlavorFungibility
 		*out = new(FlavorFungibility)
@@ -414,6 +521,46 @@ func (in *ClusterQueueSpec) DeepCopyInto(out *ClusterQueueSpec) {
 		*out = new(FairSharing)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaximumExecutionTimeSeconds != nil {
+		in, out := &in.MaximumExecutionTimeSeconds, &out.MaximumExecutionTimeSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QuotaShrinkPolicy != nil {
+		in, out := &in.QuotaShrinkPolicy, &out.QuotaShrinkPolicy
+		*out = new(QuotaShrinkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShadowMode != nil {
+		in, out := &in.ShadowMode, &out.ShadowMode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowedPriorityClasses != nil {
+		in, out := &in.AllowedPriorityClasses, &out.AllowedPriorityClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PriorityClassMappings != nil {
+		in, out := &in.PriorityClassMappings, &out.PriorityClassMappings
+		*out = make([]PriorityClassMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdmissionPolicies != nil {
+		in, out := &in.AdmissionPolicies, &out.AdmissionPolicies
+		*out = make([]AdmissionPolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequestsAccountingPolicy != nil {
+		in, out := &in.RequestsAccountingPolicy, &out.RequestsAccountingPolicy
+		*out = new(RequestsAccountingPolicy)
+		**out = **in
+	}
+	if in.WaitForPodsReady != nil {
+		in, out := &in.WaitForPodsReady, &out.WaitForPodsReady
+		*out = new(ClusterQueueWaitForPodsReady)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueueSpec.
@@ -460,6 +607,11 @@ func (in *ClusterQueueStatus) DeepCopyInto(out *ClusterQueueStatus) {
 		*out = new(FairSharingStatus)
 		**out = **in
 	}
+	if in.Borrowing != nil {
+		in, out := &in.Borrowing, &out.Borrowing
+		*out = new(ClusterQueueBorrowingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueueStatus.
@@ -472,6 +624,41 @@ func (in *ClusterQueueStatus) DeepCopy() *ClusterQueueStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQueueWaitForPodsReady) DeepCopyInto(out *ClusterQueueWaitForPodsReady) {
+	*out = *in
+	if in.Enable != nil {
+		in, out := &in.Enable, &out.Enable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BlockAdmission != nil {
+		in, out := &in.BlockAdmission, &out.BlockAdmission
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequeuingStrategy != nil {
+		in, out := &in.RequeuingStrategy, &out.RequeuingStrategy
+		*out = new(ClusterQueueRequeuingStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueueWaitForPodsReady.
+func (in *ClusterQueueWaitForPodsReady) DeepCopy() *ClusterQueueWaitForPodsReady {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQueueWaitForPodsReady)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FairSharing) DeepCopyInto(out *FairSharing) {
 	*out = *in
@@ -480,6 +667,13 @@ func (in *FairSharing) DeepCopyInto(out *FairSharing) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.ResourceWeights != nil {
+		in, out := &in.ResourceWeights, &out.ResourceWeights
+		*out = make([]ResourceWeight, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FairSharing.
@@ -569,6 +763,11 @@ func (in *FlavorUsage) DeepCopy() *FlavorUsage {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeConfig) DeepCopyInto(out *KubeConfig) {
 	*out = *in
+	if in.ServiceAccountToken != nil {
+		in, out := &in.ServiceAccountToken, &out.ServiceAccountToken
+		*out = new(ServiceAccountTokenSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeConfig.
@@ -720,6 +919,11 @@ func (in *LocalQueueSpec) DeepCopyInto(out *LocalQueueSpec) {
 		*out = new(StopPolicy)
 		**out = **in
 	}
+	if in.PendingTimeout != nil {
+		in, out := &in.PendingTimeout, &out.PendingTimeout
+		*out = new(PendingTimeout)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueSpec.
@@ -780,7 +984,7 @@ func (in *MultiKueueCluster) DeepCopyInto(out *MultiKueueCluster) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -837,7 +1041,7 @@ func (in *MultiKueueClusterList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultiKueueClusterSpec) DeepCopyInto(out *MultiKueueClusterSpec) {
 	*out = *in
-	out.KubeConfig = in.KubeConfig
+	in.KubeConfig.DeepCopyInto(&out.KubeConfig)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiKueueClusterSpec.
@@ -950,6 +1154,41 @@ func (in *MultiKueueConfigSpec) DeepCopy() *MultiKueueConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingReason) DeepCopyInto(out *PendingReason) {
+	*out = *in
+	if in.Missing != nil {
+		in, out := &in.Missing, &out.Missing
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingReason.
+func (in *PendingReason) DeepCopy() *PendingReason {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingReason)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingTimeout) DeepCopyInto(out *PendingTimeout) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingTimeout.
+func (in *PendingTimeout) DeepCopy() *PendingTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSet) DeepCopyInto(out *PodSet) {
 	*out = *in
@@ -1120,6 +1359,21 @@ func (in *PodSetUpdate) DeepCopy() *PodSetUpdate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityClassMapping) DeepCopyInto(out *PriorityClassMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityClassMapping.
+func (in *PriorityClassMapping) DeepCopy() *PriorityClassMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityClassMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProvisioningRequestConfig) DeepCopyInto(out *ProvisioningRequestConfig) {
 	*out = *in
@@ -1240,6 +1494,42 @@ func (in *ProvisioningRequestRetryStrategy) DeepCopy() *ProvisioningRequestRetry
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaFlow) DeepCopyInto(out *QuotaFlow) {
+	*out = *in
+	out.Amount = in.Amount.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaFlow.
+func (in *QuotaFlow) DeepCopy() *QuotaFlow {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaFlow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaShrinkPolicy) DeepCopyInto(out *QuotaShrinkPolicy) {
+	*out = *in
+	if in.EvictionIntervalSeconds != nil {
+		in, out := &in.EvictionIntervalSeconds, &out.EvictionIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaShrinkPolicy.
+func (in *QuotaShrinkPolicy) DeepCopy() *QuotaShrinkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaShrinkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReclaimablePod) DeepCopyInto(out *ReclaimablePod) {
 	*out = *in
@@ -1255,6 +1545,21 @@ func (in *ReclaimablePod) DeepCopy() *ReclaimablePod {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestsAccountingPolicy) DeepCopyInto(out *RequestsAccountingPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestsAccountingPolicy.
+func (in *RequestsAccountingPolicy) DeepCopy() *RequestsAccountingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestsAccountingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequeueState) DeepCopyInto(out *RequeueState) {
 	*out = *in
@@ -1448,6 +1753,47 @@ func (in *ResourceUsage) DeepCopy() *ResourceUsage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceWeight) DeepCopyInto(out *ResourceWeight) {
+	*out = *in
+	out.Weight = in.Weight.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceWeight.
+func (in *ResourceWeight) DeepCopy() *ResourceWeight {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceWeight)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountTokenSource) DeepCopyInto(out *ServiceAccountTokenSource) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountTokenSource.
+func (in *ServiceAccountTokenSource) DeepCopy() *ServiceAccountTokenSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountTokenSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopologyAssignment) DeepCopyInto(out *TopologyAssignment) {
 	*out = *in
@@ -1463,6 +1809,13 @@ func (in *TopologyAssignment) DeepCopyInto(out *TopologyAssignment) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DomainGroups != nil {
+		in, out := &in.DomainGroups, &out.DomainGroups
+		*out = make([]TopologyDomainGroupAssignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyAssignment.
@@ -1495,6 +1848,36 @@ func (in *TopologyDomainAssignment) DeepCopy() *TopologyDomainAssignment {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyDomainGroupAssignment) DeepCopyInto(out *TopologyDomainGroupAssignment) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LowestLevelValues != nil {
+		in, out := &in.LowestLevelValues, &out.LowestLevelValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Counts != nil {
+		in, out := &in.Counts, &out.Counts
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyDomainGroupAssignment.
+func (in *TopologyDomainGroupAssignment) DeepCopy() *TopologyDomainGroupAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyDomainGroupAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Workload) DeepCopyInto(out *Workload) {
 	*out = *in
@@ -1522,6 +1905,40 @@ func (in *Workload) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadDeactivation) DeepCopyInto(out *WorkloadDeactivation) {
+	*out = *in
+	if in.ReactivateAt != nil {
+		in, out := &in.ReactivateAt, &out.ReactivateAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadDeactivation.
+func (in *WorkloadDeactivation) DeepCopy() *WorkloadDeactivation {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadDeactivation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadEviction) DeepCopyInto(out *WorkloadEviction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadEviction.
+func (in *WorkloadEviction) DeepCopy() *WorkloadEviction {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadEviction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadList) DeepCopyInto(out *WorkloadList) {
 	*out = *in
@@ -1687,11 +2104,35 @@ func (in *WorkloadStatus) DeepCopyInto(out *WorkloadStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RecommendedRequests != nil {
+		in, out := &in.RecommendedRequests, &out.RecommendedRequests
+		*out = make([]PodSetRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.AccumulatedPastExexcutionTimeSeconds != nil {
 		in, out := &in.AccumulatedPastExexcutionTimeSeconds, &out.AccumulatedPastExexcutionTimeSeconds
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Deactivation != nil {
+		in, out := &in.Deactivation, &out.Deactivation
+		*out = new(WorkloadDeactivation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Eviction != nil {
+		in, out := &in.Eviction, &out.Eviction
+		*out = new(WorkloadEviction)
+		**out = **in
+	}
+	if in.PendingReasons != nil {
+		in, out := &in.PendingReasons, &out.PendingReasons
+		*out = make([]PendingReason, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadStatus.
@@ -52,6 +52,29 @@ func TestPendingWorkloadsOptions(t *testing.T) {
 				Offset: 2,
 			},
 		},
+		"selectors, continue and watch": {
+			inputQueryParams: url.Values{
+				"labelSelector": {"team=a"},
+				"fieldSelector": {"metadata.namespace=ns"},
+				"continue":      {"token"},
+				"watch":         {"true"},
+			},
+			wantQueryParams: url.Values{
+				"limit":         {"1000"},
+				"offset":        {"0"},
+				"labelSelector": {"team=a"},
+				"fieldSelector": {"metadata.namespace=ns"},
+				"continue":      {"token"},
+				"watch":         {"true"},
+			},
+			wantPendingWorkloadOptions: PendingWorkloadOptions{
+				Limit:         1000,
+				LabelSelector: "team=a",
+				FieldSelector: "metadata.namespace=ns",
+				Continue:      "token",
+				Watch:         true,
+			},
+		},
 		"default values": {
 			inputQueryParams: url.Values{
 				"limit":  {"0"},
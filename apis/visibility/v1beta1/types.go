@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,6 +27,9 @@ import (
 // +k8s:openapi-gen=true
 // +genclient:nonNamespaced
 // +genclient:method=GetPendingWorkloadsSummary,verb=get,subresource=pendingworkloads,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkloadsSummary
+// +genclient:method=GetQuota,verb=get,subresource=quota,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.ClusterQueueQuota
+// +genclient:method=UpdateQuota,verb=update,subresource=quota,input=sigs.k8s.io/kueue/apis/visibility/v1beta1.ClusterQueueQuota,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.ClusterQueueQuota
+// +genclient:method=SimulatePreemption,verb=create,subresource=preemptionsimulation,input=sigs.k8s.io/kueue/apis/visibility/v1beta1.PreemptionSimulation,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.PreemptionSimulation
 type ClusterQueue struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -44,6 +49,7 @@ type ClusterQueueList struct {
 // +kubebuilder:object:root=true
 // +k8s:openapi-gen=true
 // +genclient:method=GetPendingWorkloadsSummary,verb=get,subresource=pendingworkloads,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.PendingWorkloadsSummary
+// +genclient:method=SimulateAdmission,verb=create,subresource=admissionsimulation,input=sigs.k8s.io/kueue/apis/visibility/v1beta1.AdmissionSimulation,result=sigs.k8s.io/kueue/apis/visibility/v1beta1.AdmissionSimulation
 type LocalQueue struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -75,6 +81,18 @@ type PendingWorkload struct {
 
 	// PositionInLocalQueue indicates the workload's position in the LocalQueue, starting from 0
 	PositionInLocalQueue int32 `json:"positionInLocalQueue"`
+
+	// RequestsAhead indicates the total resource requests of the workloads ahead
+	// of the workload in the ClusterQueue
+	// +optional
+	RequestsAhead corev1.ResourceList `json:"requestsAhead,omitempty"`
+
+	// EstimatedAdmissionTime is a best-effort estimation of when the workload gets
+	// quota reserved, derived from the number of workloads that got quota reserved
+	// in the ClusterQueue during the last hour. It is not set if no workload got
+	// quota reserved in the ClusterQueue during that period.
+	// +optional
+	EstimatedAdmissionTime *metav1.Time `json:"estimatedAdmissionTime,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -87,6 +105,12 @@ type PendingWorkloadsSummary struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Items []PendingWorkload `json:"items"`
+
+	// Continue is set when more pending workloads match the query than the
+	// limit. It can be passed as the continue query parameter to fetch the next
+	// page of pending workloads.
+	// +optional
+	Continue string `json:"continue,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -103,11 +127,226 @@ type PendingWorkloadOptions struct {
 
 	// Limit indicates max number of pending workloads that should be fetched. 1000 by default
 	Limit int64 `json:"limit,omitempty"`
+
+	// LabelSelector restricts the pending workloads to the ones whose labels match the selector
+	// +optional
+	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// FieldSelector restricts the pending workloads to the ones whose fields match the selector.
+	// The supported fields are metadata.name, metadata.namespace, localQueueName and priority
+	// +optional
+	FieldSelector string `json:"fieldSelector,omitempty"`
+
+	// Continue is the token returned in a previous summary to fetch the next page of pending
+	// workloads. When set, offset is ignored
+	// +optional
+	Continue string `json:"continue,omitempty"`
+
+	// Watch indicates that, instead of a summary, a stream of watch events should be returned,
+	// with the pending workloads that are added, modified or deleted from the query results
+	// +optional
+	Watch bool `json:"watch,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+
+// AdmissionSimulation is the request to simulate the admission of a workload
+// submitted to a LocalQueue, and its result, without creating the workload.
+type AdmissionSimulation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AdmissionSimulationSpec `json:"spec"`
+
+	// +optional
+	Status AdmissionSimulationStatus `json:"status,omitempty"`
+}
+
+// AdmissionSimulationSpec describes the workload whose admission is simulated.
+type AdmissionSimulationSpec struct {
+	// Priority is the priority of the workload. 0 by default
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// PodSets are the sets of homogeneous pods of the workload
+	PodSets []AdmissionSimulationPodSet `json:"podSets"`
+}
+
+// AdmissionSimulationPodSet is a set of homogeneous pods of the workload
+// whose admission is simulated.
+type AdmissionSimulationPodSet struct {
+	// Name is the name of the pod set
+	Name string `json:"name"`
+
+	// Count is the number of pods in the pod set
+	Count int32 `json:"count"`
+
+	// Requests are the resource requests of each pod
+	// +optional
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+
+	// NodeSelector is the node selector of the pods, used to select the
+	// flavors whose node labels match
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// AdmissionSimulationStatus is the result of the simulation.
+type AdmissionSimulationStatus struct {
+	// ClusterQueueName is the name of the ClusterQueue the LocalQueue points to
+	ClusterQueueName string `json:"clusterQueueName"`
+
+	// Mode is Fit if the workload fits in the unused quota, Preempt if it
+	// fits after preempting other workloads, and NoFit otherwise
+	Mode string `json:"mode"`
+
+	// Borrowing indicates that the workload would borrow quota from the cohort
+	// +optional
+	Borrowing bool `json:"borrowing,omitempty"`
+
+	// Preemptions is the number of workloads that would be preempted to admit
+	// the workload
+	// +optional
+	Preemptions int32 `json:"preemptions,omitempty"`
+
+	// Message explains why the workload doesn't fit, if it doesn't
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// PodSetAssignments are the flavors assigned to the resources of each
+	// pod set, if the workload fits
+	// +optional
+	PodSetAssignments []AdmissionSimulationPodSetAssignment `json:"podSetAssignments,omitempty"`
+
+	// PositionInClusterQueue is the position the workload would take among the
+	// pending workloads of the ClusterQueue, starting from 0
+	PositionInClusterQueue int32 `json:"positionInClusterQueue"`
+
+	// EstimatedAdmissionTime is a best-effort estimation of when the workload
+	// would get quota reserved, considering its position in the ClusterQueue,
+	// derived like the estimation for the pending workloads. It is not set if
+	// no workload got quota reserved in the ClusterQueue during the last hour.
+	// +optional
+	EstimatedAdmissionTime *metav1.Time `json:"estimatedAdmissionTime,omitempty"`
+}
+
+// AdmissionSimulationPodSetAssignment holds the flavors assigned to the
+// resources of a pod set.
+type AdmissionSimulationPodSetAssignment struct {
+	// Name is the name of the pod set
+	Name string `json:"name"`
+
+	// Flavors are the flavors assigned to each resource
+	Flavors map[corev1.ResourceName]string `json:"flavors,omitempty"`
+
+	// Count is the number of pods admitted, which is lower than the count of
+	// the pod set when the workload is partially admitted
+	Count int32 `json:"count"`
+}
+
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+
+// PreemptionSimulation is the request to simulate the admission of a pending
+// workload of a ClusterQueue, as if it was at the head of the ClusterQueue,
+// and its result, listing the workloads that would be preempted, without
+// preempting them.
+type PreemptionSimulation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PreemptionSimulationSpec `json:"spec"`
+
+	// +optional
+	Status PreemptionSimulationStatus `json:"status,omitempty"`
+}
+
+// PreemptionSimulationSpec describes the pending workload whose admission is
+// simulated.
+type PreemptionSimulationSpec struct {
+	// WorkloadNamespace is the namespace of the pending workload
+	WorkloadNamespace string `json:"workloadNamespace"`
+
+	// WorkloadName is the name of the pending workload
+	WorkloadName string `json:"workloadName"`
+
+	// Priority is the priority the admission of the workload is simulated
+	// with, instead of its own priority, for example to check the effect of
+	// raising it
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+}
+
+// PreemptionSimulationStatus is the result of the simulation.
+type PreemptionSimulationStatus struct {
+	// Mode is Fit if the workload fits in the unused quota, Preempt if it
+	// fits after preempting other workloads, and NoFit otherwise
+	Mode string `json:"mode"`
+
+	// Message explains why the workload doesn't fit, if it doesn't
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Victims are the workloads that would be preempted to admit the workload
+	// +optional
+	Victims []PreemptionVictim `json:"victims,omitempty"`
+}
+
+// PreemptionVictim is a workload that would be preempted.
+type PreemptionVictim struct {
+	// Namespace is the namespace of the workload
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the workload
+	Name string `json:"name"`
+
+	// ClusterQueueName is the name of the ClusterQueue the workload is admitted in
+	ClusterQueueName string `json:"clusterQueueName"`
+
+	// Priority is the priority of the workload
+	Priority int32 `json:"priority"`
+
+	// Reason is the reason of the preemption, like InClusterQueue or
+	// InCohortReclamation
+	Reason string `json:"reason"`
+}
+
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+
+// ClusterQueueQuota holds the nominal quotas of a ClusterQueue, per flavor
+// and resource. It is served as the quota subresource of the ClusterQueue,
+// so that its nominal quotas can be read and updated atomically, without
+// rewriting its resource groups.
+type ClusterQueueQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Quotas are the nominal quotas of the ClusterQueue. When updating, only
+	// the listed flavors and resources are changed
+	Quotas []FlavorResourceQuota `json:"quotas"`
+}
+
+// FlavorResourceQuota is the nominal quota of a resource in a flavor.
+type FlavorResourceQuota struct {
+	// Flavor is the name of the ResourceFlavor
+	Flavor string `json:"flavor"`
+
+	// Resource is the name of the resource
+	Resource corev1.ResourceName `json:"resource"`
+
+	// NominalQuota is the quantity of the resource in the flavor available
+	// to the ClusterQueue
+	NominalQuota resource.Quantity `json:"nominalQuota"`
 }
 
 func init() {
 	SchemeBuilder.Register(
 		&PendingWorkloadsSummary{},
 		&PendingWorkloadOptions{},
+		&AdmissionSimulation{},
+		&ClusterQueueQuota{},
+		&PreemptionSimulation{},
 	)
 }
@@ -21,9 +21,136 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionSimulation) DeepCopyInto(out *AdmissionSimulation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionSimulation.
+func (in *AdmissionSimulation) DeepCopy() *AdmissionSimulation {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionSimulation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AdmissionSimulation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionSimulationPodSet) DeepCopyInto(out *AdmissionSimulationPodSet) {
+	*out = *in
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionSimulationPodSet.
+func (in *AdmissionSimulationPodSet) DeepCopy() *AdmissionSimulationPodSet {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionSimulationPodSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionSimulationPodSetAssignment) DeepCopyInto(out *AdmissionSimulationPodSetAssignment) {
+	*out = *in
+	if in.Flavors != nil {
+		in, out := &in.Flavors, &out.Flavors
+		*out = make(map[v1.ResourceName]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionSimulationPodSetAssignment.
+func (in *AdmissionSimulationPodSetAssignment) DeepCopy() *AdmissionSimulationPodSetAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionSimulationPodSetAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionSimulationSpec) DeepCopyInto(out *AdmissionSimulationSpec) {
+	*out = *in
+	if in.PodSets != nil {
+		in, out := &in.PodSets, &out.PodSets
+		*out = make([]AdmissionSimulationPodSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionSimulationSpec.
+func (in *AdmissionSimulationSpec) DeepCopy() *AdmissionSimulationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionSimulationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionSimulationStatus) DeepCopyInto(out *AdmissionSimulationStatus) {
+	*out = *in
+	if in.PodSetAssignments != nil {
+		in, out := &in.PodSetAssignments, &out.PodSetAssignments
+		*out = make([]AdmissionSimulationPodSetAssignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EstimatedAdmissionTime != nil {
+		in, out := &in.EstimatedAdmissionTime, &out.EstimatedAdmissionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionSimulationStatus.
+func (in *AdmissionSimulationStatus) DeepCopy() *AdmissionSimulationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionSimulationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterQueue) DeepCopyInto(out *ClusterQueue) {
 	*out = *in
@@ -82,6 +209,54 @@ func (in *ClusterQueueList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQueueQuota) DeepCopyInto(out *ClusterQueueQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = make([]FlavorResourceQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueueQuota.
+func (in *ClusterQueueQuota) DeepCopy() *ClusterQueueQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQueueQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterQueueQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlavorResourceQuota) DeepCopyInto(out *FlavorResourceQuota) {
+	*out = *in
+	out.NominalQuota = in.NominalQuota.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlavorResourceQuota.
+func (in *FlavorResourceQuota) DeepCopy() *FlavorResourceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(FlavorResourceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalQueue) DeepCopyInto(out *LocalQueue) {
 	*out = *in
@@ -144,6 +319,17 @@ func (in *LocalQueueList) DeepCopyObject() runtime.Object {
 func (in *PendingWorkload) DeepCopyInto(out *PendingWorkload) {
 	*out = *in
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.RequestsAhead != nil {
+		in, out := &in.RequestsAhead, &out.RequestsAhead
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.EstimatedAdmissionTime != nil {
+		in, out := &in.EstimatedAdmissionTime, &out.EstimatedAdmissionTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingWorkload.
@@ -211,3 +397,85 @@ func (in *PendingWorkloadsSummary) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreemptionSimulation) DeepCopyInto(out *PreemptionSimulation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreemptionSimulation.
+func (in *PreemptionSimulation) DeepCopy() *PreemptionSimulation {
+	if in == nil {
+		return nil
+	}
+	out := new(PreemptionSimulation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PreemptionSimulation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreemptionSimulationSpec) DeepCopyInto(out *PreemptionSimulationSpec) {
+	*out = *in
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreemptionSimulationSpec.
+func (in *PreemptionSimulationSpec) DeepCopy() *PreemptionSimulationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PreemptionSimulationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreemptionSimulationStatus) DeepCopyInto(out *PreemptionSimulationStatus) {
+	*out = *in
+	if in.Victims != nil {
+		in, out := &in.Victims, &out.Victims
+		*out = make([]PreemptionVictim, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreemptionSimulationStatus.
+func (in *PreemptionSimulationStatus) DeepCopy() *PreemptionSimulationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PreemptionSimulationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreemptionVictim) DeepCopyInto(out *PreemptionVictim) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreemptionVictim.
+func (in *PreemptionVictim) DeepCopy() *PreemptionVictim {
+	if in == nil {
+		return nil
+	}
+	out := new(PreemptionVictim)
+	in.DeepCopyInto(out)
+	return out
+}
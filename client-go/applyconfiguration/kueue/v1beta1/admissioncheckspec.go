@@ -17,12 +17,17 @@ limitations under the License.
 
 package v1beta1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // AdmissionCheckSpecApplyConfiguration represents a declarative configuration of the AdmissionCheckSpec type for use
 // with apply.
 type AdmissionCheckSpecApplyConfiguration struct {
 	ControllerName    *string                                              `json:"controllerName,omitempty"`
 	RetryDelayMinutes *int64                                               `json:"retryDelayMinutes,omitempty"`
 	Parameters        *AdmissionCheckParametersReferenceApplyConfiguration `json:"parameters,omitempty"`
+	PendingTimeout    *v1.Duration                                         `json:"pendingTimeout,omitempty"`
 }
 
 // AdmissionCheckSpecApplyConfiguration constructs a declarative configuration of the AdmissionCheckSpec type for use with
@@ -54,3 +59,11 @@ func (b *AdmissionCheckSpecApplyConfiguration) WithParameters(value *AdmissionCh
 	b.Parameters = value
 	return b
 }
+
+// WithPendingTimeout sets the PendingTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PendingTimeout field is set to the value of the last call.
+func (b *AdmissionCheckSpecApplyConfiguration) WithPendingTimeout(value v1.Duration) *AdmissionCheckSpecApplyConfiguration {
+	b.PendingTimeout = &value
+	return b
+}
@@ -25,11 +25,12 @@ import (
 // AdmissionCheckStateApplyConfiguration represents a declarative configuration of the AdmissionCheckState type for use
 // with apply.
 type AdmissionCheckStateApplyConfiguration struct {
-	Name               *string                          `json:"name,omitempty"`
-	State              *v1beta1.CheckState              `json:"state,omitempty"`
-	LastTransitionTime *v1.Time                         `json:"lastTransitionTime,omitempty"`
-	Message            *string                          `json:"message,omitempty"`
-	PodSetUpdates      []PodSetUpdateApplyConfiguration `json:"podSetUpdates,omitempty"`
+	Name               *string                                           `json:"name,omitempty"`
+	State              *v1beta1.CheckState                               `json:"state,omitempty"`
+	LastTransitionTime *v1.Time                                          `json:"lastTransitionTime,omitempty"`
+	Message            *string                                           `json:"message,omitempty"`
+	PodSetUpdates      []PodSetUpdateApplyConfiguration                  `json:"podSetUpdates,omitempty"`
+	History            []AdmissionCheckStateTransitionApplyConfiguration `json:"history,omitempty"`
 }
 
 // AdmissionCheckStateApplyConfiguration constructs a declarative configuration of the AdmissionCheckState type for use with
@@ -82,3 +83,16 @@ func (b *AdmissionCheckStateApplyConfiguration) WithPodSetUpdates(values ...*Pod
 	}
 	return b
 }
+
+// WithHistory adds the given value to the History field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the History field.
+func (b *AdmissionCheckStateApplyConfiguration) WithHistory(values ...*AdmissionCheckStateTransitionApplyConfiguration) *AdmissionCheckStateApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithHistory")
+		}
+		b.History = append(b.History, *values[i])
+	}
+	return b
+}
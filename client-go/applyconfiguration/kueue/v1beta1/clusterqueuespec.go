@@ -25,16 +25,26 @@ import (
 // ClusterQueueSpecApplyConfiguration represents a declarative configuration of the ClusterQueueSpec type for use
 // with apply.
 type ClusterQueueSpecApplyConfiguration struct {
-	ResourceGroups          []ResourceGroupApplyConfiguration          `json:"resourceGroups,omitempty"`
-	Cohort                  *string                                    `json:"cohort,omitempty"`
-	QueueingStrategy        *kueuev1beta1.QueueingStrategy             `json:"queueingStrategy,omitempty"`
-	NamespaceSelector       *v1.LabelSelectorApplyConfiguration        `json:"namespaceSelector,omitempty"`
-	FlavorFungibility       *FlavorFungibilityApplyConfiguration       `json:"flavorFungibility,omitempty"`
-	Preemption              *ClusterQueuePreemptionApplyConfiguration  `json:"preemption,omitempty"`
-	AdmissionChecks         []string                                   `json:"admissionChecks,omitempty"`
-	AdmissionChecksStrategy *AdmissionChecksStrategyApplyConfiguration `json:"admissionChecksStrategy,omitempty"`
-	StopPolicy              *kueuev1beta1.StopPolicy                   `json:"stopPolicy,omitempty"`
-	FairSharing             *FairSharingApplyConfiguration             `json:"fairSharing,omitempty"`
+	ResourceGroups              []ResourceGroupApplyConfiguration               `json:"resourceGroups,omitempty"`
+	Cohort                      *string                                         `json:"cohort,omitempty"`
+	QueueingStrategy            *kueuev1beta1.QueueingStrategy                  `json:"queueingStrategy,omitempty"`
+	NamespaceSelector           *v1.LabelSelectorApplyConfiguration             `json:"namespaceSelector,omitempty"`
+	LocalQueueNamespaceSelector *v1.LabelSelectorApplyConfiguration             `json:"localQueueNamespaceSelector,omitempty"`
+	FlavorFungibility           *FlavorFungibilityApplyConfiguration            `json:"flavorFungibility,omitempty"`
+	Preemption                  *ClusterQueuePreemptionApplyConfiguration       `json:"preemption,omitempty"`
+	AdmissionChecks             []string                                        `json:"admissionChecks,omitempty"`
+	AdmissionChecksStrategy     *AdmissionChecksStrategyApplyConfiguration      `json:"admissionChecksStrategy,omitempty"`
+	StopPolicy                  *kueuev1beta1.StopPolicy                        `json:"stopPolicy,omitempty"`
+	FairSharing                 *FairSharingApplyConfiguration                  `json:"fairSharing,omitempty"`
+	MaximumExecutionTimeSeconds *int32                                          `json:"maximumExecutionTimeSeconds,omitempty"`
+	QuotaShrinkPolicy           *QuotaShrinkPolicyApplyConfiguration            `json:"quotaShrinkPolicy,omitempty"`
+	ShadowMode                  *bool                                           `json:"shadowMode,omitempty"`
+	AllowedPriorityClasses      []string                                        `json:"allowedPriorityClasses,omitempty"`
+	PriorityClassMappings       []PriorityClassMappingApplyConfiguration        `json:"priorityClassMappings,omitempty"`
+	AdmissionPolicies           []AdmissionPolicyApplyConfiguration             `json:"admissionPolicies,omitempty"`
+	RequestsAccountingPolicy    *RequestsAccountingPolicyApplyConfiguration     `json:"requestsAccountingPolicy,omitempty"`
+	WaitForPodsReady            *ClusterQueueWaitForPodsReadyApplyConfiguration `json:"waitForPodsReady,omitempty"`
+	Schedule                    *string                                         `json:"schedule,omitempty"`
 }
 
 // ClusterQueueSpecApplyConfiguration constructs a declarative configuration of the ClusterQueueSpec type for use with
@@ -80,6 +90,14 @@ func (b *ClusterQueueSpecApplyConfiguration) WithNamespaceSelector(value *v1.Lab
 	return b
 }
 
+// WithLocalQueueNamespaceSelector sets the LocalQueueNamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LocalQueueNamespaceSelector field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithLocalQueueNamespaceSelector(value *v1.LabelSelectorApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	b.LocalQueueNamespaceSelector = value
+	return b
+}
+
 // WithFlavorFungibility sets the FlavorFungibility field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the FlavorFungibility field is set to the value of the last call.
@@ -129,3 +147,87 @@ func (b *ClusterQueueSpecApplyConfiguration) WithFairSharing(value *FairSharingA
 	b.FairSharing = value
 	return b
 }
+
+// WithMaximumExecutionTimeSeconds sets the MaximumExecutionTimeSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaximumExecutionTimeSeconds field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithMaximumExecutionTimeSeconds(value int32) *ClusterQueueSpecApplyConfiguration {
+	b.MaximumExecutionTimeSeconds = &value
+	return b
+}
+
+// WithQuotaShrinkPolicy sets the QuotaShrinkPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the QuotaShrinkPolicy field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithQuotaShrinkPolicy(value *QuotaShrinkPolicyApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	b.QuotaShrinkPolicy = value
+	return b
+}
+
+// WithShadowMode sets the ShadowMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ShadowMode field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithShadowMode(value bool) *ClusterQueueSpecApplyConfiguration {
+	b.ShadowMode = &value
+	return b
+}
+
+// WithAllowedPriorityClasses adds the given value to the AllowedPriorityClasses field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AllowedPriorityClasses field.
+func (b *ClusterQueueSpecApplyConfiguration) WithAllowedPriorityClasses(values ...string) *ClusterQueueSpecApplyConfiguration {
+	for i := range values {
+		b.AllowedPriorityClasses = append(b.AllowedPriorityClasses, values[i])
+	}
+	return b
+}
+
+// WithPriorityClassMappings adds the given value to the PriorityClassMappings field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PriorityClassMappings field.
+func (b *ClusterQueueSpecApplyConfiguration) WithPriorityClassMappings(values ...*PriorityClassMappingApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPriorityClassMappings")
+		}
+		b.PriorityClassMappings = append(b.PriorityClassMappings, *values[i])
+	}
+	return b
+}
+
+// WithAdmissionPolicies adds the given value to the AdmissionPolicies field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AdmissionPolicies field.
+func (b *ClusterQueueSpecApplyConfiguration) WithAdmissionPolicies(values ...*AdmissionPolicyApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAdmissionPolicies")
+		}
+		b.AdmissionPolicies = append(b.AdmissionPolicies, *values[i])
+	}
+	return b
+}
+
+// WithRequestsAccountingPolicy sets the RequestsAccountingPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequestsAccountingPolicy field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithRequestsAccountingPolicy(value *RequestsAccountingPolicyApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	b.RequestsAccountingPolicy = value
+	return b
+}
+
+// WithWaitForPodsReady sets the WaitForPodsReady field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WaitForPodsReady field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithWaitForPodsReady(value *ClusterQueueWaitForPodsReadyApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	b.WaitForPodsReady = value
+	return b
+}
+
+// WithSchedule sets the Schedule field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Schedule field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithSchedule(value string) *ClusterQueueSpecApplyConfiguration {
+	b.Schedule = &value
+	return b
+}
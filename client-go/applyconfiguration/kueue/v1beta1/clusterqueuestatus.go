@@ -32,6 +32,7 @@ type ClusterQueueStatusApplyConfiguration struct {
 	Conditions             []v1.ConditionApplyConfiguration                      `json:"conditions,omitempty"`
 	PendingWorkloadsStatus *ClusterQueuePendingWorkloadsStatusApplyConfiguration `json:"pendingWorkloadsStatus,omitempty"`
 	FairSharing            *FairSharingStatusApplyConfiguration                  `json:"fairSharing,omitempty"`
+	Borrowing              *ClusterQueueBorrowingStatusApplyConfiguration        `json:"borrowing,omitempty"`
 }
 
 // ClusterQueueStatusApplyConfiguration constructs a declarative configuration of the ClusterQueueStatus type for use with
@@ -118,3 +119,11 @@ func (b *ClusterQueueStatusApplyConfiguration) WithFairSharing(value *FairSharin
 	b.FairSharing = value
 	return b
 }
+
+// WithBorrowing sets the Borrowing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Borrowing field is set to the value of the last call.
+func (b *ClusterQueueStatusApplyConfiguration) WithBorrowing(value *ClusterQueueBorrowingStatusApplyConfiguration) *ClusterQueueStatusApplyConfiguration {
+	b.Borrowing = value
+	return b
+}
@@ -24,7 +24,8 @@ import (
 // FairSharingApplyConfiguration represents a declarative configuration of the FairSharing type for use
 // with apply.
 type FairSharingApplyConfiguration struct {
-	Weight *resource.Quantity `json:"weight,omitempty"`
+	Weight          *resource.Quantity                 `json:"weight,omitempty"`
+	ResourceWeights []ResourceWeightApplyConfiguration `json:"resourceWeights,omitempty"`
 }
 
 // FairSharingApplyConfiguration constructs a declarative configuration of the FairSharing type for use with
@@ -40,3 +41,16 @@ func (b *FairSharingApplyConfiguration) WithWeight(value resource.Quantity) *Fai
 	b.Weight = &value
 	return b
 }
+
+// WithResourceWeights adds the given value to the ResourceWeights field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ResourceWeights field.
+func (b *FairSharingApplyConfiguration) WithResourceWeights(values ...*ResourceWeightApplyConfiguration) *FairSharingApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResourceWeights")
+		}
+		b.ResourceWeights = append(b.ResourceWeights, *values[i])
+	}
+	return b
+}
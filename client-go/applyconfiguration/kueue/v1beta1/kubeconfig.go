@@ -24,8 +24,10 @@ import (
 // KubeConfigApplyConfiguration represents a declarative configuration of the KubeConfig type for use
 // with apply.
 type KubeConfigApplyConfiguration struct {
-	Location     *string               `json:"location,omitempty"`
-	LocationType *v1beta1.LocationType `json:"locationType,omitempty"`
+	Location            *string                                      `json:"location,omitempty"`
+	LocationType        *v1beta1.LocationType                        `json:"locationType,omitempty"`
+	SecretKey           *string                                      `json:"secretKey,omitempty"`
+	ServiceAccountToken *ServiceAccountTokenSourceApplyConfiguration `json:"serviceAccountToken,omitempty"`
 }
 
 // KubeConfigApplyConfiguration constructs a declarative configuration of the KubeConfig type for use with
@@ -49,3 +51,19 @@ func (b *KubeConfigApplyConfiguration) WithLocationType(value v1beta1.LocationTy
 	b.LocationType = &value
 	return b
 }
+
+// WithSecretKey sets the SecretKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecretKey field is set to the value of the last call.
+func (b *KubeConfigApplyConfiguration) WithSecretKey(value string) *KubeConfigApplyConfiguration {
+	b.SecretKey = &value
+	return b
+}
+
+// WithServiceAccountToken sets the ServiceAccountToken field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceAccountToken field is set to the value of the last call.
+func (b *KubeConfigApplyConfiguration) WithServiceAccountToken(value *ServiceAccountTokenSourceApplyConfiguration) *KubeConfigApplyConfiguration {
+	b.ServiceAccountToken = value
+	return b
+}
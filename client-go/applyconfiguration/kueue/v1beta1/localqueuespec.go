@@ -24,8 +24,9 @@ import (
 // LocalQueueSpecApplyConfiguration represents a declarative configuration of the LocalQueueSpec type for use
 // with apply.
 type LocalQueueSpecApplyConfiguration struct {
-	ClusterQueue *v1beta1.ClusterQueueReference `json:"clusterQueue,omitempty"`
-	StopPolicy   *v1beta1.StopPolicy            `json:"stopPolicy,omitempty"`
+	ClusterQueue   *v1beta1.ClusterQueueReference    `json:"clusterQueue,omitempty"`
+	StopPolicy     *v1beta1.StopPolicy               `json:"stopPolicy,omitempty"`
+	PendingTimeout *PendingTimeoutApplyConfiguration `json:"pendingTimeout,omitempty"`
 }
 
 // LocalQueueSpecApplyConfiguration constructs a declarative configuration of the LocalQueueSpec type for use with
@@ -49,3 +50,11 @@ func (b *LocalQueueSpecApplyConfiguration) WithStopPolicy(value v1beta1.StopPoli
 	b.StopPolicy = &value
 	return b
 }
+
+// WithPendingTimeout sets the PendingTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PendingTimeout field is set to the value of the last call.
+func (b *LocalQueueSpecApplyConfiguration) WithPendingTimeout(value *PendingTimeoutApplyConfiguration) *LocalQueueSpecApplyConfiguration {
+	b.PendingTimeout = value
+	return b
+}
@@ -20,8 +20,9 @@ package v1beta1
 // TopologyAssignmentApplyConfiguration represents a declarative configuration of the TopologyAssignment type for use
 // with apply.
 type TopologyAssignmentApplyConfiguration struct {
-	Levels  []string                                     `json:"levels,omitempty"`
-	Domains []TopologyDomainAssignmentApplyConfiguration `json:"domains,omitempty"`
+	Levels       []string                                          `json:"levels,omitempty"`
+	Domains      []TopologyDomainAssignmentApplyConfiguration      `json:"domains,omitempty"`
+	DomainGroups []TopologyDomainGroupAssignmentApplyConfiguration `json:"domainGroups,omitempty"`
 }
 
 // TopologyAssignmentApplyConfiguration constructs a declarative configuration of the TopologyAssignment type for use with
@@ -52,3 +53,16 @@ func (b *TopologyAssignmentApplyConfiguration) WithDomains(values ...*TopologyDo
 	}
 	return b
 }
+
+// WithDomainGroups adds the given value to the DomainGroups field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DomainGroups field.
+func (b *TopologyAssignmentApplyConfiguration) WithDomainGroups(values ...*TopologyDomainGroupAssignmentApplyConfiguration) *TopologyAssignmentApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDomainGroups")
+		}
+		b.DomainGroups = append(b.DomainGroups, *values[i])
+	}
+	return b
+}
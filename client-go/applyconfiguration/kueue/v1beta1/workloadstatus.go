@@ -30,7 +30,11 @@ type WorkloadStatusApplyConfiguration struct {
 	ReclaimablePods                      []ReclaimablePodApplyConfiguration      `json:"reclaimablePods,omitempty"`
 	AdmissionChecks                      []AdmissionCheckStateApplyConfiguration `json:"admissionChecks,omitempty"`
 	ResourceRequests                     []PodSetRequestApplyConfiguration       `json:"resourceRequests,omitempty"`
+	RecommendedRequests                  []PodSetRequestApplyConfiguration       `json:"recommendedRequests,omitempty"`
 	AccumulatedPastExexcutionTimeSeconds *int32                                  `json:"accumulatedPastExexcutionTimeSeconds,omitempty"`
+	Deactivation                         *WorkloadDeactivationApplyConfiguration `json:"deactivation,omitempty"`
+	Eviction                             *WorkloadEvictionApplyConfiguration     `json:"eviction,omitempty"`
+	PendingReasons                       []PendingReasonApplyConfiguration       `json:"pendingReasons,omitempty"`
 }
 
 // WorkloadStatusApplyConfiguration constructs a declarative configuration of the WorkloadStatus type for use with
@@ -107,6 +111,19 @@ func (b *WorkloadStatusApplyConfiguration) WithResourceRequests(values ...*PodSe
 	return b
 }
 
+// WithRecommendedRequests adds the given value to the RecommendedRequests field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RecommendedRequests field.
+func (b *WorkloadStatusApplyConfiguration) WithRecommendedRequests(values ...*PodSetRequestApplyConfiguration) *WorkloadStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithRecommendedRequests")
+		}
+		b.RecommendedRequests = append(b.RecommendedRequests, *values[i])
+	}
+	return b
+}
+
 // WithAccumulatedPastExexcutionTimeSeconds sets the AccumulatedPastExexcutionTimeSeconds field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the AccumulatedPastExexcutionTimeSeconds field is set to the value of the last call.
@@ -114,3 +131,32 @@ func (b *WorkloadStatusApplyConfiguration) WithAccumulatedPastExexcutionTimeSeco
 	b.AccumulatedPastExexcutionTimeSeconds = &value
 	return b
 }
+
+// WithDeactivation sets the Deactivation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Deactivation field is set to the value of the last call.
+func (b *WorkloadStatusApplyConfiguration) WithDeactivation(value *WorkloadDeactivationApplyConfiguration) *WorkloadStatusApplyConfiguration {
+	b.Deactivation = value
+	return b
+}
+
+// WithEviction sets the Eviction field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Eviction field is set to the value of the last call.
+func (b *WorkloadStatusApplyConfiguration) WithEviction(value *WorkloadEvictionApplyConfiguration) *WorkloadStatusApplyConfiguration {
+	b.Eviction = value
+	return b
+}
+
+// WithPendingReasons adds the given value to the PendingReasons field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PendingReasons field.
+func (b *WorkloadStatusApplyConfiguration) WithPendingReasons(values ...*PendingReasonApplyConfiguration) *WorkloadStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPendingReasons")
+		}
+		b.PendingReasons = append(b.PendingReasons, *values[i])
+	}
+	return b
+}
@@ -35,12 +35,68 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=kueue.x-k8s.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("FlavorUsageHours"):
+		return &kueuev1alpha1.FlavorUsageHoursApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Budget"):
+		return &kueuev1alpha1.BudgetApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BudgetCost"):
+		return &kueuev1alpha1.BudgetCostApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BudgetSpec"):
+		return &kueuev1alpha1.BudgetSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BudgetStatus"):
+		return &kueuev1alpha1.BudgetStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ImageSignatureConfig"):
+		return &kueuev1alpha1.ImageSignatureConfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ImageSignatureConfigSpec"):
+		return &kueuev1alpha1.ImageSignatureConfigSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Integration"):
+		return &kueuev1alpha1.IntegrationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("IntegrationSpec"):
+		return &kueuev1alpha1.IntegrationSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("IntegrationStatus"):
+		return &kueuev1alpha1.IntegrationStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("LocalQueueUsageHours"):
+		return &kueuev1alpha1.LocalQueueUsageHoursApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ManageJobsWithoutQueueNameRule"):
+		return &kueuev1alpha1.ManageJobsWithoutQueueNameRuleApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ResourcePrice"):
+		return &kueuev1alpha1.ResourcePriceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ResourceUsageHours"):
+		return &kueuev1alpha1.ResourceUsageHoursApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Schedule"):
+		return &kueuev1alpha1.ScheduleApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScheduleSpec"):
+		return &kueuev1alpha1.ScheduleSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScheduleStatus"):
+		return &kueuev1alpha1.ScheduleStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScheduleWindow"):
+		return &kueuev1alpha1.ScheduleWindowApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Tenant"):
+		return &kueuev1alpha1.TenantApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TenantLocalQueue"):
+		return &kueuev1alpha1.TenantLocalQueueApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TenantSpec"):
+		return &kueuev1alpha1.TenantSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TenantStatus"):
+		return &kueuev1alpha1.TenantStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Topology"):
 		return &kueuev1alpha1.TopologyApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("TopologyLevel"):
 		return &kueuev1alpha1.TopologyLevelApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("TopologySpec"):
 		return &kueuev1alpha1.TopologySpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UsageReport"):
+		return &kueuev1alpha1.UsageReportApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UsageReportSpec"):
+		return &kueuev1alpha1.UsageReportSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UsageReportStatus"):
+		return &kueuev1alpha1.UsageReportStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("WorkloadTemplate"):
+		return &kueuev1alpha1.WorkloadTemplateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("WorkloadTemplatePodSet"):
+		return &kueuev1alpha1.WorkloadTemplatePodSetApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("WorkloadTemplateSpec"):
+		return &kueuev1alpha1.WorkloadTemplateSpecApplyConfiguration{}
 
 		// Group=kueue.x-k8s.io, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithKind("Admission"):
@@ -55,24 +111,34 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.AdmissionChecksStrategyApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionCheckState"):
 		return &kueuev1beta1.AdmissionCheckStateApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AdmissionCheckStateTransition"):
+		return &kueuev1beta1.AdmissionCheckStateTransitionApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionCheckStatus"):
 		return &kueuev1beta1.AdmissionCheckStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionCheckStrategyRule"):
 		return &kueuev1beta1.AdmissionCheckStrategyRuleApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AdmissionPolicy"):
+		return &kueuev1beta1.AdmissionPolicyApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("BorrowWithinCohort"):
 		return &kueuev1beta1.BorrowWithinCohortApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueue"):
 		return &kueuev1beta1.ClusterQueueApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueueBorrowingStatus"):
+		return &kueuev1beta1.ClusterQueueBorrowingStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueuePendingWorkload"):
 		return &kueuev1beta1.ClusterQueuePendingWorkloadApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueuePendingWorkloadsStatus"):
 		return &kueuev1beta1.ClusterQueuePendingWorkloadsStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueuePreemption"):
 		return &kueuev1beta1.ClusterQueuePreemptionApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueueRequeuingStrategy"):
+		return &kueuev1beta1.ClusterQueueRequeuingStrategyApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueueSpec"):
 		return &kueuev1beta1.ClusterQueueSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueueStatus"):
 		return &kueuev1beta1.ClusterQueueStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueueWaitForPodsReady"):
+		return &kueuev1beta1.ClusterQueueWaitForPodsReadyApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FairSharing"):
 		return &kueuev1beta1.FairSharingApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FairSharingStatus"):
@@ -107,6 +173,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.MultiKueueConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("MultiKueueConfigSpec"):
 		return &kueuev1beta1.MultiKueueConfigSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PendingReason"):
+		return &kueuev1beta1.PendingReasonApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PendingTimeout"):
+		return &kueuev1beta1.PendingTimeoutApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSet"):
 		return &kueuev1beta1.PodSetApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetAssignment"):
@@ -117,14 +187,22 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.PodSetTopologyRequestApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetUpdate"):
 		return &kueuev1beta1.PodSetUpdateApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PriorityClassMapping"):
+		return &kueuev1beta1.PriorityClassMappingApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ProvisioningRequestConfig"):
 		return &kueuev1beta1.ProvisioningRequestConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ProvisioningRequestConfigSpec"):
 		return &kueuev1beta1.ProvisioningRequestConfigSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ProvisioningRequestRetryStrategy"):
 		return &kueuev1beta1.ProvisioningRequestRetryStrategyApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("QuotaFlow"):
+		return &kueuev1beta1.QuotaFlowApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("QuotaShrinkPolicy"):
+		return &kueuev1beta1.QuotaShrinkPolicyApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ReclaimablePod"):
 		return &kueuev1beta1.ReclaimablePodApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("RequestsAccountingPolicy"):
+		return &kueuev1beta1.RequestsAccountingPolicyApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("RequeueState"):
 		return &kueuev1beta1.RequeueStateApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceFlavor"):
@@ -137,12 +215,22 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.ResourceQuotaApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceUsage"):
 		return &kueuev1beta1.ResourceUsageApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ResourceWeight"):
+		return &kueuev1beta1.ResourceWeightApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ServiceAccountTokenSource"):
+		return &kueuev1beta1.ServiceAccountTokenSourceApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("TopologyAssignment"):
 		return &kueuev1beta1.TopologyAssignmentApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("TopologyDomainAssignment"):
 		return &kueuev1beta1.TopologyDomainAssignmentApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("TopologyDomainGroupAssignment"):
+		return &kueuev1beta1.TopologyDomainGroupAssignmentApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("Workload"):
 		return &kueuev1beta1.WorkloadApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkloadDeactivation"):
+		return &kueuev1beta1.WorkloadDeactivationApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("WorkloadEviction"):
+		return &kueuev1beta1.WorkloadEvictionApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkloadPriorityClass"):
 		return &kueuev1beta1.WorkloadPriorityClassApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkloadSpec"):
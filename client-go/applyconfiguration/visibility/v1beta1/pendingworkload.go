@@ -18,6 +18,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
@@ -27,10 +28,12 @@ import (
 // with apply.
 type PendingWorkloadApplyConfiguration struct {
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	Priority                         *int32  `json:"priority,omitempty"`
-	LocalQueueName                   *string `json:"localQueueName,omitempty"`
-	PositionInClusterQueue           *int32  `json:"positionInClusterQueue,omitempty"`
-	PositionInLocalQueue             *int32  `json:"positionInLocalQueue,omitempty"`
+	Priority                         *int32               `json:"priority,omitempty"`
+	LocalQueueName                   *string              `json:"localQueueName,omitempty"`
+	PositionInClusterQueue           *int32               `json:"positionInClusterQueue,omitempty"`
+	PositionInLocalQueue             *int32               `json:"positionInLocalQueue,omitempty"`
+	RequestsAhead                    *corev1.ResourceList `json:"requestsAhead,omitempty"`
+	EstimatedAdmissionTime           *metav1.Time         `json:"estimatedAdmissionTime,omitempty"`
 }
 
 // PendingWorkloadApplyConfiguration constructs a declarative configuration of the PendingWorkload type for use with
@@ -213,6 +216,22 @@ func (b *PendingWorkloadApplyConfiguration) WithPositionInLocalQueue(value int32
 	return b
 }
 
+// WithRequestsAhead sets the RequestsAhead field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequestsAhead field is set to the value of the last call.
+func (b *PendingWorkloadApplyConfiguration) WithRequestsAhead(value corev1.ResourceList) *PendingWorkloadApplyConfiguration {
+	b.RequestsAhead = &value
+	return b
+}
+
+// WithEstimatedAdmissionTime sets the EstimatedAdmissionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EstimatedAdmissionTime field is set to the value of the last call.
+func (b *PendingWorkloadApplyConfiguration) WithEstimatedAdmissionTime(value metav1.Time) *PendingWorkloadApplyConfiguration {
+	b.EstimatedAdmissionTime = &value
+	return b
+}
+
 // GetName retrieves the value of the Name field in the declarative configuration.
 func (b *PendingWorkloadApplyConfiguration) GetName() *string {
 	b.ensureObjectMetaApplyConfigurationExists()
@@ -29,6 +29,7 @@ type PendingWorkloadsSummaryApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
 	Items                            []PendingWorkloadApplyConfiguration `json:"items,omitempty"`
+	Continue                         *string                             `json:"continue,omitempty"`
 }
 
 // PendingWorkloadsSummaryApplyConfiguration constructs a declarative configuration of the PendingWorkloadsSummary type for use with
@@ -211,6 +212,14 @@ func (b *PendingWorkloadsSummaryApplyConfiguration) WithItems(values ...*Pending
 	return b
 }
 
+// WithContinue sets the Continue field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Continue field is set to the value of the last call.
+func (b *PendingWorkloadsSummaryApplyConfiguration) WithContinue(value string) *PendingWorkloadsSummaryApplyConfiguration {
+	b.Continue = &value
+	return b
+}
+
 // GetName retrieves the value of the Name field in the declarative configuration.
 func (b *PendingWorkloadsSummaryApplyConfiguration) GetName() *string {
 	b.ensureObjectMetaApplyConfigurationExists()
@@ -27,10 +27,38 @@ type FakeKueueV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeKueueV1alpha1) Budgets(namespace string) v1alpha1.BudgetInterface {
+	return &FakeBudgets{c, namespace}
+}
+
+func (c *FakeKueueV1alpha1) ImageSignatureConfigs() v1alpha1.ImageSignatureConfigInterface {
+	return &FakeImageSignatureConfigs{c}
+}
+
+func (c *FakeKueueV1alpha1) Integrations() v1alpha1.IntegrationInterface {
+	return &FakeIntegrations{c}
+}
+
+func (c *FakeKueueV1alpha1) Schedules() v1alpha1.ScheduleInterface {
+	return &FakeSchedules{c}
+}
+
+func (c *FakeKueueV1alpha1) Tenants() v1alpha1.TenantInterface {
+	return &FakeTenants{c}
+}
+
 func (c *FakeKueueV1alpha1) Topologies() v1alpha1.TopologyInterface {
 	return &FakeTopologies{c}
 }
 
+func (c *FakeKueueV1alpha1) UsageReports(namespace string) v1alpha1.UsageReportInterface {
+	return &FakeUsageReports{c, namespace}
+}
+
+func (c *FakeKueueV1alpha1) WorkloadTemplates() v1alpha1.WorkloadTemplateInterface {
+	return &FakeWorkloadTemplates{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeKueueV1alpha1) RESTClient() rest.Interface {
@@ -17,4 +17,18 @@ limitations under the License.
 
 package v1alpha1
 
+type BudgetExpansion interface{}
+
+type ImageSignatureConfigExpansion interface{}
+
+type IntegrationExpansion interface{}
+
+type ScheduleExpansion interface{}
+
+type TenantExpansion interface{}
+
 type TopologyExpansion interface{}
+
+type UsageReportExpansion interface{}
+
+type WorkloadTemplateExpansion interface{}
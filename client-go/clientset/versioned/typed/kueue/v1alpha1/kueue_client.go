@@ -27,7 +27,14 @@ import (
 
 type KueueV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	BudgetsGetter
+	ImageSignatureConfigsGetter
+	IntegrationsGetter
+	SchedulesGetter
+	TenantsGetter
 	TopologiesGetter
+	UsageReportsGetter
+	WorkloadTemplatesGetter
 }
 
 // KueueV1alpha1Client is used to interact with features provided by the kueue.x-k8s.io group.
@@ -35,10 +42,38 @@ type KueueV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *KueueV1alpha1Client) Budgets(namespace string) BudgetInterface {
+	return newBudgets(c, namespace)
+}
+
+func (c *KueueV1alpha1Client) ImageSignatureConfigs() ImageSignatureConfigInterface {
+	return newImageSignatureConfigs(c)
+}
+
+func (c *KueueV1alpha1Client) Integrations() IntegrationInterface {
+	return newIntegrations(c)
+}
+
+func (c *KueueV1alpha1Client) Schedules() ScheduleInterface {
+	return newSchedules(c)
+}
+
+func (c *KueueV1alpha1Client) Tenants() TenantInterface {
+	return newTenants(c)
+}
+
 func (c *KueueV1alpha1Client) Topologies() TopologyInterface {
 	return newTopologies(c)
 }
 
+func (c *KueueV1alpha1Client) UsageReports(namespace string) UsageReportInterface {
+	return newUsageReports(c, namespace)
+}
+
+func (c *KueueV1alpha1Client) WorkloadTemplates() WorkloadTemplateInterface {
+	return newWorkloadTemplates(c)
+}
+
 // NewForConfig creates a new KueueV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
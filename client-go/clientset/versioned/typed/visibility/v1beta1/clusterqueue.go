@@ -47,6 +47,9 @@ type ClusterQueueInterface interface {
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ClusterQueue, err error)
 	Apply(ctx context.Context, clusterQueue *visibilityv1beta1.ClusterQueueApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.ClusterQueue, err error)
 	GetPendingWorkloadsSummary(ctx context.Context, clusterQueueName string, options v1.GetOptions) (*v1beta1.PendingWorkloadsSummary, error)
+	GetQuota(ctx context.Context, clusterQueueName string, options v1.GetOptions) (*v1beta1.ClusterQueueQuota, error)
+	UpdateQuota(ctx context.Context, clusterQueueName string, clusterQueueQuota *v1beta1.ClusterQueueQuota, opts v1.UpdateOptions) (*v1beta1.ClusterQueueQuota, error)
+	SimulatePreemption(ctx context.Context, clusterQueueName string, preemptionSimulation *v1beta1.PreemptionSimulation, opts v1.CreateOptions) (*v1beta1.PreemptionSimulation, error)
 
 	ClusterQueueExpansion
 }
@@ -81,3 +84,44 @@ func (c *clusterQueues) GetPendingWorkloadsSummary(ctx context.Context, clusterQ
 		Into(result)
 	return
 }
+
+// GetQuota takes name of the clusterQueue, and returns the corresponding v1beta1.ClusterQueueQuota object, and an error if there is any.
+func (c *clusterQueues) GetQuota(ctx context.Context, clusterQueueName string, options v1.GetOptions) (result *v1beta1.ClusterQueueQuota, err error) {
+	result = &v1beta1.ClusterQueueQuota{}
+	err = c.GetClient().Get().
+		Resource("clusterqueues").
+		Name(clusterQueueName).
+		SubResource("quota").
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateQuota takes the top resource name and the representation of a clusterQueueQuota and updates it. Returns the server's representation of the clusterQueueQuota, and an error, if there is any.
+func (c *clusterQueues) UpdateQuota(ctx context.Context, clusterQueueName string, clusterQueueQuota *v1beta1.ClusterQueueQuota, opts v1.UpdateOptions) (result *v1beta1.ClusterQueueQuota, err error) {
+	result = &v1beta1.ClusterQueueQuota{}
+	err = c.GetClient().Put().
+		Resource("clusterqueues").
+		Name(clusterQueueName).
+		SubResource("quota").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterQueueQuota).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// SimulatePreemption takes the representation of a preemptionSimulation and creates it.  Returns the server's representation of the preemptionSimulation, and an error, if there is any.
+func (c *clusterQueues) SimulatePreemption(ctx context.Context, clusterQueueName string, preemptionSimulation *v1beta1.PreemptionSimulation, opts v1.CreateOptions) (result *v1beta1.PreemptionSimulation, err error) {
+	result = &v1beta1.PreemptionSimulation{}
+	err = c.GetClient().Post().
+		Resource("clusterqueues").
+		Name(clusterQueueName).
+		SubResource("preemptionsimulation").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(preemptionSimulation).
+		Do(ctx).
+		Into(result)
+	return
+}
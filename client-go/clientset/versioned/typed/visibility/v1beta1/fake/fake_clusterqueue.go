@@ -159,3 +159,36 @@ func (c *FakeClusterQueues) GetPendingWorkloadsSummary(ctx context.Context, clus
 	}
 	return obj.(*v1beta1.PendingWorkloadsSummary), err
 }
+
+// GetQuota takes name of the clusterQueue, and returns the corresponding clusterQueueQuota object, and an error if there is any.
+func (c *FakeClusterQueues) GetQuota(ctx context.Context, clusterQueueName string, options v1.GetOptions) (result *v1beta1.ClusterQueueQuota, err error) {
+	emptyResult := &v1beta1.ClusterQueueQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetSubresourceActionWithOptions(clusterqueuesResource, "quota", clusterQueueName, options), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1beta1.ClusterQueueQuota), err
+}
+
+// UpdateQuota takes the representation of a clusterQueueQuota and updates it. Returns the server's representation of the clusterQueueQuota, and an error, if there is any.
+func (c *FakeClusterQueues) UpdateQuota(ctx context.Context, clusterQueueName string, clusterQueueQuota *v1beta1.ClusterQueueQuota, opts v1.UpdateOptions) (result *v1beta1.ClusterQueueQuota, err error) {
+	emptyResult := &v1beta1.ClusterQueueQuota{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceActionWithOptions(clusterqueuesResource, "quota", clusterQueueQuota, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1beta1.ClusterQueueQuota), err
+}
+
+// SimulatePreemption takes the representation of a preemptionSimulation and creates it.  Returns the server's representation of the preemptionSimulation, and an error, if there is any.
+func (c *FakeClusterQueues) SimulatePreemption(ctx context.Context, clusterQueueName string, preemptionSimulation *v1beta1.PreemptionSimulation, opts v1.CreateOptions) (result *v1beta1.PreemptionSimulation, err error) {
+	emptyResult := &v1beta1.PreemptionSimulation{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateSubresourceActionWithOptions(clusterqueuesResource, clusterQueueName, "preemptionsimulation", preemptionSimulation, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1beta1.PreemptionSimulation), err
+}
@@ -169,3 +169,15 @@ func (c *FakeLocalQueues) GetPendingWorkloadsSummary(ctx context.Context, localQ
 	}
 	return obj.(*v1beta1.PendingWorkloadsSummary), err
 }
+
+// SimulateAdmission takes the representation of a admissionSimulation and creates it.  Returns the server's representation of the admissionSimulation, and an error, if there is any.
+func (c *FakeLocalQueues) SimulateAdmission(ctx context.Context, localQueueName string, admissionSimulation *v1beta1.AdmissionSimulation, opts v1.CreateOptions) (result *v1beta1.AdmissionSimulation, err error) {
+	emptyResult := &v1beta1.AdmissionSimulation{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateSubresourceActionWithOptions(localqueuesResource, localQueueName, "admissionsimulation", c.ns, admissionSimulation, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1beta1.AdmissionSimulation), err
+}
@@ -47,6 +47,7 @@ type LocalQueueInterface interface {
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.LocalQueue, err error)
 	Apply(ctx context.Context, localQueue *visibilityv1beta1.LocalQueueApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.LocalQueue, err error)
 	GetPendingWorkloadsSummary(ctx context.Context, localQueueName string, options v1.GetOptions) (*v1beta1.PendingWorkloadsSummary, error)
+	SimulateAdmission(ctx context.Context, localQueueName string, admissionSimulation *v1beta1.AdmissionSimulation, opts v1.CreateOptions) (*v1beta1.AdmissionSimulation, error)
 
 	LocalQueueExpansion
 }
@@ -82,3 +83,18 @@ func (c *localQueues) GetPendingWorkloadsSummary(ctx context.Context, localQueue
 		Into(result)
 	return
 }
+
+// SimulateAdmission takes the representation of a admissionSimulation and creates it.  Returns the server's representation of the admissionSimulation, and an error, if there is any.
+func (c *localQueues) SimulateAdmission(ctx context.Context, localQueueName string, admissionSimulation *v1beta1.AdmissionSimulation, opts v1.CreateOptions) (result *v1beta1.AdmissionSimulation, err error) {
+	result = &v1beta1.AdmissionSimulation{}
+	err = c.GetClient().Post().
+		Namespace(c.GetNamespace()).
+		Resource("localqueues").
+		Name(localQueueName).
+		SubResource("admissionsimulation").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(admissionSimulation).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -54,8 +54,22 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=kueue.x-k8s.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("budgets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kueue().V1alpha1().Budgets().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("imagesignatureconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kueue().V1alpha1().ImageSignatureConfigs().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("integrations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kueue().V1alpha1().Integrations().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("schedules"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kueue().V1alpha1().Schedules().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("tenants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kueue().V1alpha1().Tenants().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("topologies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kueue().V1alpha1().Topologies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("usagereports"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kueue().V1alpha1().UsageReports().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("workloadtemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kueue().V1alpha1().WorkloadTemplates().Informer()}, nil
 
 		// Group=kueue.x-k8s.io, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("admissionchecks"):
@@ -23,8 +23,22 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// Budgets returns a BudgetInformer.
+	Budgets() BudgetInformer
+	// ImageSignatureConfigs returns a ImageSignatureConfigInformer.
+	ImageSignatureConfigs() ImageSignatureConfigInformer
+	// Integrations returns a IntegrationInformer.
+	Integrations() IntegrationInformer
+	// Schedules returns a ScheduleInformer.
+	Schedules() ScheduleInformer
+	// Tenants returns a TenantInformer.
+	Tenants() TenantInformer
 	// Topologies returns a TopologyInformer.
 	Topologies() TopologyInformer
+	// UsageReports returns a UsageReportInformer.
+	UsageReports() UsageReportInformer
+	// WorkloadTemplates returns a WorkloadTemplateInformer.
+	WorkloadTemplates() WorkloadTemplateInformer
 }
 
 type version struct {
@@ -38,7 +52,42 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// Budgets returns a BudgetInformer.
+func (v *version) Budgets() BudgetInformer {
+	return &budgetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ImageSignatureConfigs returns a ImageSignatureConfigInformer.
+func (v *version) ImageSignatureConfigs() ImageSignatureConfigInformer {
+	return &imageSignatureConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// Integrations returns a IntegrationInformer.
+func (v *version) Integrations() IntegrationInformer {
+	return &integrationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// Schedules returns a ScheduleInformer.
+func (v *version) Schedules() ScheduleInformer {
+	return &scheduleInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// Tenants returns a TenantInformer.
+func (v *version) Tenants() TenantInformer {
+	return &tenantInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Topologies returns a TopologyInformer.
 func (v *version) Topologies() TopologyInformer {
 	return &topologyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// UsageReports returns a UsageReportInformer.
+func (v *version) UsageReports() UsageReportInformer {
+	return &usageReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// WorkloadTemplates returns a WorkloadTemplateInformer.
+func (v *version) WorkloadTemplates() WorkloadTemplateInformer {
+	return &workloadTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -17,6 +17,42 @@ limitations under the License.
 
 package v1alpha1
 
+// BudgetListerExpansion allows custom methods to be added to
+// BudgetLister.
+type BudgetListerExpansion interface{}
+
+// BudgetNamespaceListerExpansion allows custom methods to be added to
+// BudgetNamespaceLister.
+type BudgetNamespaceListerExpansion interface{}
+
+// ImageSignatureConfigListerExpansion allows custom methods to be added to
+// ImageSignatureConfigLister.
+type ImageSignatureConfigListerExpansion interface{}
+
+// IntegrationListerExpansion allows custom methods to be added to
+// IntegrationLister.
+type IntegrationListerExpansion interface{}
+
+// ScheduleListerExpansion allows custom methods to be added to
+// ScheduleLister.
+type ScheduleListerExpansion interface{}
+
+// TenantListerExpansion allows custom methods to be added to
+// TenantLister.
+type TenantListerExpansion interface{}
+
 // TopologyListerExpansion allows custom methods to be added to
 // TopologyLister.
 type TopologyListerExpansion interface{}
+
+// UsageReportListerExpansion allows custom methods to be added to
+// UsageReportLister.
+type UsageReportListerExpansion interface{}
+
+// UsageReportNamespaceListerExpansion allows custom methods to be added to
+// UsageReportNamespaceLister.
+type UsageReportNamespaceListerExpansion interface{}
+
+// WorkloadTemplateListerExpansion allows custom methods to be added to
+// WorkloadTemplateLister.
+type WorkloadTemplateListerExpansion interface{}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/gateway/profile"
+	"sigs.k8s.io/kueue/cmd/gateway/server"
+	"sigs.k8s.io/kueue/pkg/util/useragent"
+)
+
+const (
+	ConfigFlag         = "config"
+	BindAddressFlag    = "bind-address"
+	TLSCertFileFlag    = "tls-cert-file"
+	TLSKeyFileFlag     = "tls-private-key-file"
+	AudiencesFlag      = "audiences"
+	QPSFlag            = "qps"
+	BurstFlag          = "burst"
+	VerbosityFlag      = "verbose"
+	VerboseFlagShort   = "v"
+	defaultBindAddress = ":8080"
+
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 30 * time.Second
+)
+
+var (
+	rootCmd = &cobra.Command{
+		Use:   "gateway",
+		Short: "Serve an HTTP API to submit jobs to Kueue from application profiles",
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			v, _ := cmd.Flags().GetCount(VerbosityFlag)
+			level := (v + 1) * -1
+			ctrl.SetLogger(zap.New(
+				zap.UseDevMode(true),
+				zap.ConsoleEncoder(),
+				zap.Level(zapcore.Level(level)),
+			))
+			return nil
+		},
+		RunE: serveCmd,
+	}
+)
+
+func init() {
+	rootCmd.PersistentFlags().CountP(VerbosityFlag, VerboseFlagShort, "verbosity (specify multiple times to increase the log level)")
+	rootCmd.Flags().String(ConfigFlag, "", "yaml file containing the application profiles")
+	rootCmd.Flags().String(BindAddressFlag, defaultBindAddress, "address on which the API is served")
+	rootCmd.Flags().String(TLSCertFileFlag, "", "file containing the x509 certificate for HTTPS")
+	rootCmd.Flags().String(TLSKeyFileFlag, "", "file containing the x509 private key matching --"+TLSCertFileFlag)
+	rootCmd.Flags().StringSlice(AudiencesFlag, nil, "audiences of the bearer tokens, the audiences of the API server if empty")
+	rootCmd.Flags().Float32(QPSFlag, 50, "client QPS, as described in https://kubernetes.io/docs/reference/config-api/apiserver-eventratelimit.v1alpha1/#eventratelimit-admission-k8s-io-v1alpha1-Limit")
+	rootCmd.Flags().Int(BurstFlag, 50, "client Burst, as described in https://kubernetes.io/docs/reference/config-api/apiserver-eventratelimit.v1alpha1/#eventratelimit-admission-k8s-io-v1alpha1-Limit")
+
+	_ = rootCmd.MarkFlagRequired(ConfigFlag)
+	rootCmd.MarkFlagsRequiredTogether(TLSCertFileFlag, TLSKeyFileFlag)
+}
+
+func main() {
+	err := rootCmd.Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+func serveCmd(cmd *cobra.Command, _ []string) error {
+	log := ctrl.Log.WithName("gateway")
+	flags := cmd.Flags()
+	configFile, _ := flags.GetString(ConfigFlag)
+	bindAddress, _ := flags.GetString(BindAddressFlag)
+	certFile, _ := flags.GetString(TLSCertFileFlag)
+	keyFile, _ := flags.GetString(TLSKeyFileFlag)
+	audiences, _ := flags.GetStringSlice(AudiencesFlag)
+
+	profiles, err := profile.LoadFile(configFile)
+	if err != nil {
+		return err
+	}
+	log.Info("Loaded the application profiles", "count", len(profiles))
+
+	kubeConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return err
+	}
+	if kubeConfig.UserAgent == "" {
+		kubeConfig.UserAgent = useragent.Default()
+	}
+	kubeConfig.QPS, _ = flags.GetFloat32(QPSFlag)
+	kubeConfig.Burst, _ = flags.GetInt(BurstFlag)
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return err
+	}
+	if err := kueue.AddToScheme(scheme); err != nil {
+		return err
+	}
+	c, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	srv := server.New(log, profiles, server.TokenReviewAuthenticator(c, audiences), server.ImpersonatingClientFactory(kubeConfig, scheme))
+	httpServer := &http.Server{
+		Addr:              bindAddress,
+		Handler:           srv.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			log.Error(err, "Shutting down the server")
+		}
+	}()
+
+	log.Info("Serving", "address", bindAddress, "tls", certFile != "")
+	if certFile != "" {
+		err = httpServer.ListenAndServeTLS(certFile, keyFile)
+	} else {
+		err = httpServer.ListenAndServe()
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
+)
+
+const (
+	// ProfileLabel is the label key set by the gateway in the jobs it creates,
+	// holding the name of their profile.
+	ProfileLabel = "gateway.kueue.x-k8s.io/profile"
+)
+
+var (
+	ErrNoContainers = errors.New("the job template has no containers")
+	ErrNoLocalQueue = errors.New("no LocalQueue")
+)
+
+// Profile is an application profile from which the gateway creates jobs: the
+// submissions only fill in the fields users commonly change, like the image
+// or the requests, in the template.
+type Profile struct {
+	Name string `json:"name"`
+	// LocalQueue is the default LocalQueue of the jobs.
+	LocalQueue string `json:"localQueue,omitempty"`
+	// Template is the template of the jobs, whose first container is the
+	// one customized by the submissions.
+	Template batchv1.JobTemplateSpec `json:"template"`
+}
+
+type Config struct {
+	Profiles []Profile `json:"profiles"`
+}
+
+// Submission is a request to create a job from a profile.
+type Submission struct {
+	Profile string `json:"profile"`
+	// Name of the job. When empty, it's generated from the generateName
+	// prefix, or the name of the profile.
+	Name         string            `json:"name,omitempty"`
+	GenerateName string            `json:"generateName,omitempty"`
+	LocalQueue   string            `json:"localQueue,omitempty"`
+	Image        string            `json:"image,omitempty"`
+	Command      []string          `json:"command,omitempty"`
+	Args         []string          `json:"args,omitempty"`
+	Env          map[string]string `json:"env,omitempty"`
+	Parallelism  *int32            `json:"parallelism,omitempty"`
+	Completions  *int32            `json:"completions,omitempty"`
+	// Requests of each pod, for example {"cpu": "2"}.
+	Requests map[corev1.ResourceName]string `json:"requests,omitempty"`
+	// MaximumExecutionTimeSeconds is the maximum time the job can run for,
+	// once admitted.
+	MaximumExecutionTimeSeconds *int32 `json:"maximumExecutionTimeSeconds,omitempty"`
+}
+
+// LoadFile returns the profiles of the config file, by name.
+func LoadFile(path string) (map[string]*Profile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := yaml.UnmarshalStrict(content, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	profiles := make(map[string]*Profile, len(cfg.Profiles))
+	for i := range cfg.Profiles {
+		p := &cfg.Profiles[i]
+		if p.Name == "" {
+			return nil, fmt.Errorf("profile %d: no name", i)
+		}
+		if _, found := profiles[p.Name]; found {
+			return nil, fmt.Errorf("profile %q: duplicated", p.Name)
+		}
+		if len(p.Template.Spec.Template.Spec.Containers) == 0 {
+			return nil, fmt.Errorf("profile %q: %w", p.Name, ErrNoContainers)
+		}
+		profiles[p.Name] = p
+	}
+	return profiles, nil
+}
+
+// Job returns the suspended job of the submission, in the namespace, from the
+// template of the profile.
+func (p *Profile) Job(namespace string, s *Submission) (*batchv1.Job, error) {
+	job := &batchv1.Job{
+		ObjectMeta: *p.Template.ObjectMeta.DeepCopy(),
+		Spec:       *p.Template.Spec.DeepCopy(),
+	}
+	job.Namespace = namespace
+	job.Name = s.Name
+	if job.Name == "" {
+		job.GenerateName = s.GenerateName
+		if job.GenerateName == "" {
+			job.GenerateName = p.Name + "-"
+		}
+	}
+	queue := s.LocalQueue
+	if queue == "" {
+		queue = p.LocalQueue
+	}
+	if queue == "" {
+		return nil, ErrNoLocalQueue
+	}
+	if job.Labels == nil {
+		job.Labels = make(map[string]string, 2)
+	}
+	job.Labels[constants.QueueLabel] = queue
+	job.Labels[ProfileLabel] = p.Name
+	if s.MaximumExecutionTimeSeconds != nil {
+		job.Labels[constants.MaxExecTimeSecondsLabel] = strconv.Itoa(int(*s.MaximumExecutionTimeSeconds))
+	}
+	job.Spec.Suspend = ptr.To(true)
+	if s.Parallelism != nil {
+		job.Spec.Parallelism = s.Parallelism
+	}
+	if s.Completions != nil {
+		job.Spec.Completions = s.Completions
+	}
+
+	if len(job.Spec.Template.Spec.Containers) == 0 {
+		return nil, ErrNoContainers
+	}
+	container := &job.Spec.Template.Spec.Containers[0]
+	if s.Image != "" {
+		container.Image = s.Image
+	}
+	if len(s.Command) > 0 {
+		container.Command = s.Command
+	}
+	if len(s.Args) > 0 {
+		container.Args = s.Args
+	}
+	envNames := utilmaps.Keys(s.Env)
+	slices.Sort(envNames)
+	for _, name := range envNames {
+		container.Env = append(container.Env, corev1.EnvVar{Name: name, Value: s.Env[name]})
+	}
+	for name, value := range s.Requests {
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("request %q: %w", name, err)
+		}
+		if container.Resources.Requests == nil {
+			container.Resources.Requests = make(corev1.ResourceList, len(s.Requests))
+		}
+		container.Resources.Requests[name] = q
+	}
+	return job, nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+const testConfig = `
+profiles:
+- name: training
+  localQueue: user-queue
+  template:
+    metadata:
+      labels:
+        team: ml
+    spec:
+      parallelism: 1
+      completions: 1
+      template:
+        spec:
+          restartPolicy: Never
+          containers:
+          - name: main
+            image: trainer:v1
+            env:
+            - name: EPOCHS
+              value: "10"
+`
+
+func TestLoadFile(t *testing.T) {
+	cases := map[string]struct {
+		config       string
+		wantProfiles []string
+		wantErr      bool
+	}{
+		"valid": {
+			config:       testConfig,
+			wantProfiles: []string{"training"},
+		},
+		"unknown field": {
+			config:  "profiles:\n- name: training\n  queue: user-queue\n",
+			wantErr: true,
+		},
+		"no containers": {
+			config:  "profiles:\n- name: training\n  template:\n    spec: {}\n",
+			wantErr: true,
+		},
+		"duplicated profile": {
+			config:  testConfig + "- name: training\n  template:\n    spec:\n      template:\n        spec:\n          containers:\n          - name: main\n",
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tc.config), 0o600); err != nil {
+				t.Fatalf("Writing the config: %v", err)
+			}
+			profiles, err := LoadFile(path)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Unexpected error, want=%v, got=%v", tc.wantErr, err)
+			}
+			var gotProfiles []string
+			for name := range profiles {
+				gotProfiles = append(gotProfiles, name)
+			}
+			if diff := cmp.Diff(tc.wantProfiles, gotProfiles); diff != "" {
+				t.Errorf("Unexpected profiles (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJob(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(testConfig), 0o600); err != nil {
+		t.Fatalf("Writing the config: %v", err)
+	}
+	profiles, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("Loading the profiles: %v", err)
+	}
+	baseJob := func() *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:    "ns",
+				GenerateName: "training-",
+				Labels: map[string]string{
+					"team":               "ml",
+					constants.QueueLabel: "user-queue",
+					ProfileLabel:         "training",
+				},
+			},
+			Spec: batchv1.JobSpec{
+				Parallelism: ptr.To[int32](1),
+				Completions: ptr.To[int32](1),
+				Suspend:     ptr.To(true),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						RestartPolicy: corev1.RestartPolicyNever,
+						Containers: []corev1.Container{{
+							Name:  "main",
+							Image: "trainer:v1",
+							Env:   []corev1.EnvVar{{Name: "EPOCHS", Value: "10"}},
+						}},
+					},
+				},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		submission Submission
+		wantJob    func() *batchv1.Job
+		wantErr    error
+	}{
+		"defaults of the profile": {
+			submission: Submission{Profile: "training"},
+			wantJob:    baseJob,
+		},
+		"customized job": {
+			submission: Submission{
+				Profile:     "training",
+				Name:        "resnet",
+				LocalQueue:  "team-queue",
+				Image:       "trainer:v2",
+				Command:     []string{"python", "train.py"},
+				Args:        []string{"--model=resnet"},
+				Env:         map[string]string{"LR": "0.1", "BATCH": "64"},
+				Parallelism: ptr.To[int32](4),
+				Completions: ptr.To[int32](4),
+				Requests:    map[corev1.ResourceName]string{corev1.ResourceCPU: "2"},
+			},
+			wantJob: func() *batchv1.Job {
+				job := baseJob()
+				job.Name = "resnet"
+				job.GenerateName = ""
+				job.Labels[constants.QueueLabel] = "team-queue"
+				job.Spec.Parallelism = ptr.To[int32](4)
+				job.Spec.Completions = ptr.To[int32](4)
+				c := &job.Spec.Template.Spec.Containers[0]
+				c.Image = "trainer:v2"
+				c.Command = []string{"python", "train.py"}
+				c.Args = []string{"--model=resnet"}
+				c.Env = append(c.Env, corev1.EnvVar{Name: "BATCH", Value: "64"}, corev1.EnvVar{Name: "LR", Value: "0.1"})
+				c.Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}
+				return job
+			},
+		},
+		"generated name and maximum execution time": {
+			submission: Submission{
+				Profile:                     "training",
+				GenerateName:                "resnet-",
+				MaximumExecutionTimeSeconds: ptr.To[int32](3600),
+			},
+			wantJob: func() *batchv1.Job {
+				job := baseJob()
+				job.GenerateName = "resnet-"
+				job.Labels[constants.MaxExecTimeSecondsLabel] = "3600"
+				return job
+			},
+		},
+		"invalid request": {
+			submission: Submission{
+				Profile:  "training",
+				Requests: map[corev1.ResourceName]string{corev1.ResourceCPU: "two"},
+			},
+			wantErr: resource.ErrFormatWrong,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job, err := profiles["training"].Job("ns", &tc.submission)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Unexpected error, want=%v, got=%v", tc.wantErr, err)
+			}
+			if tc.wantJob == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.wantJob(), job); diff != "" {
+				t.Errorf("Unexpected job (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
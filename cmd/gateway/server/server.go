@@ -0,0 +1,293 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibilityv1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/visibility/v1beta1"
+	"sigs.k8s.io/kueue/cmd/gateway/profile"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/util/maps"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const (
+	// maxSubmissionSize is the maximum size, in bytes, of a submission.
+	maxSubmissionSize = 1 << 20
+)
+
+var (
+	errNoToken = errors.New("no bearer token")
+
+	jobGVK = batchv1.SchemeGroupVersion.WithKind("Job")
+)
+
+// Authenticator returns the user of the bearer token.
+type Authenticator func(ctx context.Context, token string) (*authenticationv1.UserInfo, error)
+
+// Clients are the clients acting as a user.
+type Clients struct {
+	client.Client
+	Visibility visibilityv1beta1.VisibilityV1beta1Interface
+}
+
+// ClientFactory returns the clients acting as the user, so that the requests
+// of the users are authorized by the API server.
+type ClientFactory func(user *authenticationv1.UserInfo) (*Clients, error)
+
+// TokenReviewAuthenticator authenticates the bearer tokens with TokenReviews,
+// for the audiences, or the audiences of the API server if none.
+func TokenReviewAuthenticator(c client.Client, audiences []string) Authenticator {
+	return func(ctx context.Context, token string) (*authenticationv1.UserInfo, error) {
+		review := &authenticationv1.TokenReview{
+			Spec: authenticationv1.TokenReviewSpec{
+				Token:     token,
+				Audiences: audiences,
+			},
+		}
+		if err := c.Create(ctx, review); err != nil {
+			return nil, err
+		}
+		if !review.Status.Authenticated {
+			return nil, fmt.Errorf("token not authenticated: %s", review.Status.Error)
+		}
+		return &review.Status.User, nil
+	}
+}
+
+// ImpersonatingClientFactory returns clients impersonating the users.
+func ImpersonatingClientFactory(cfg *rest.Config, scheme *runtime.Scheme) ClientFactory {
+	return func(user *authenticationv1.UserInfo) (*Clients, error) {
+		userCfg := rest.CopyConfig(cfg)
+		userCfg.Impersonate = rest.ImpersonationConfig{
+			UserName: user.Username,
+			UID:      user.UID,
+			Groups:   user.Groups,
+			Extra:    make(map[string][]string, len(user.Extra)),
+		}
+		for key, value := range user.Extra {
+			userCfg.Impersonate.Extra[key] = value
+		}
+		c, err := client.New(userCfg, client.Options{Scheme: scheme})
+		if err != nil {
+			return nil, err
+		}
+		visibility, err := visibilityv1beta1.NewForConfig(userCfg)
+		if err != nil {
+			return nil, err
+		}
+		return &Clients{Client: c, Visibility: visibility}, nil
+	}
+}
+
+// ProfileInfo describes a profile to the users.
+type ProfileInfo struct {
+	Name       string `json:"name"`
+	LocalQueue string `json:"localQueue,omitempty"`
+}
+
+// JobStatus is the status of a job created by the gateway, from its workload.
+type JobStatus struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Workload is the name of the workload of the job, when it's created.
+	Workload string `json:"workload,omitempty"`
+	// Status is the status of the workload: pending, quotaReserved, admitted
+	// or finished.
+	Status       string             `json:"status"`
+	ClusterQueue string             `json:"clusterQueue,omitempty"`
+	Conditions   []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server is the HTTP gateway creating jobs from the submissions of the users,
+// on their behalf.
+type Server struct {
+	log          logr.Logger
+	profiles     map[string]*profile.Profile
+	authenticate Authenticator
+	clientFor    ClientFactory
+	clock        clock.Clock
+}
+
+func New(log logr.Logger, profiles map[string]*profile.Profile, authenticate Authenticator, clientFor ClientFactory) *Server {
+	return &Server{
+		log:          log,
+		profiles:     profiles,
+		authenticate: authenticate,
+		clientFor:    clientFor,
+		clock:        clock.RealClock{},
+	}
+}
+
+// Handler returns the handler of the API of the gateway.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/profiles", s.withUser(s.listProfiles))
+	mux.HandleFunc("POST /v1/namespaces/{namespace}/jobs", s.withUser(s.createJob))
+	mux.HandleFunc("GET /v1/namespaces/{namespace}/jobs/{name}", s.withUser(s.getJob))
+	mux.HandleFunc("POST /slurm/v1/namespaces/{namespace}/jobs", s.withUser(s.sbatch))
+	mux.HandleFunc("GET /slurm/v1/namespaces/{namespace}/jobs", s.withUser(s.squeue))
+	mux.HandleFunc("DELETE /slurm/v1/namespaces/{namespace}/jobs/{name}", s.withUser(s.scancel))
+	return mux
+}
+
+type userHandlerFunc func(w http.ResponseWriter, r *http.Request, c *Clients)
+
+// withUser authenticates the bearer token of the request, and serves it with
+// a client acting as its user.
+func (s *Server) withUser(handler userHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := s.log.WithValues("method", r.Method, "path", r.URL.Path)
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || token == "" {
+			writeError(w, http.StatusUnauthorized, errNoToken)
+			return
+		}
+		user, err := s.authenticate(r.Context(), token)
+		if err != nil {
+			log.V(2).Info("Authentication failed", "err", err)
+			writeError(w, http.StatusUnauthorized, errors.New("authentication failed"))
+			return
+		}
+		c, err := s.clientFor(user)
+		if err != nil {
+			log.Error(err, "Creating the client of the user", "user", user.Username)
+			writeError(w, http.StatusInternalServerError, errors.New("internal error"))
+			return
+		}
+		log.V(3).Info("Serving request", "user", user.Username)
+		handler(w, r, c)
+	}
+}
+
+func (s *Server) listProfiles(w http.ResponseWriter, _ *http.Request, _ *Clients) {
+	names := maps.Keys(s.profiles)
+	slices.Sort(names)
+	infos := make([]ProfileInfo, 0, len(names))
+	for _, name := range names {
+		infos = append(infos, ProfileInfo{Name: name, LocalQueue: s.profiles[name].LocalQueue})
+	}
+	writeJSON(w, http.StatusOK, infos)
+}
+
+func (s *Server) createJob(w http.ResponseWriter, r *http.Request, c *Clients) {
+	var submission profile.Submission
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSubmissionSize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&submission); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("decoding the submission: %w", err))
+		return
+	}
+	p, found := s.profiles[submission.Profile]
+	if !found {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unknown profile %q", submission.Profile))
+		return
+	}
+	job, err := p.Job(r.PathValue("namespace"), &submission)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := c.Create(r.Context(), job); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	s.log.V(2).Info("Created job", "job", client.ObjectKeyFromObject(job), "profile", p.Name)
+	writeJSON(w, http.StatusCreated, JobStatus{
+		Namespace: job.Namespace,
+		Name:      job.Name,
+		Status:    workload.StatusPending,
+	})
+}
+
+func (s *Server) getJob(w http.ResponseWriter, r *http.Request, c *Clients) {
+	var job batchv1.Job
+	key := client.ObjectKey{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+	if err := c.Get(r.Context(), key, &job); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if _, found := job.Labels[profile.ProfileLabel]; !found {
+		writeError(w, http.StatusNotFound, fmt.Errorf("job %s not created by the gateway", key))
+		return
+	}
+	status := JobStatus{
+		Namespace: job.Namespace,
+		Name:      job.Name,
+		Status:    workload.StatusPending,
+	}
+	var wl kueue.Workload
+	wlKey := client.ObjectKey{Namespace: job.Namespace, Name: jobframework.GetWorkloadNameForOwnerWithGVK(job.Name, job.UID, jobGVK)}
+	switch err := c.Get(r.Context(), wlKey, &wl); {
+	case apierrors.IsNotFound(err):
+		// The workload of the job is not created yet.
+	case err != nil:
+		writeAPIError(w, err)
+		return
+	default:
+		status.Workload = wl.Name
+		status.Status = workload.Status(&wl)
+		status.Conditions = wl.Status.Conditions
+		if wl.Status.Admission != nil {
+			status.ClusterQueue = string(wl.Status.Admission.ClusterQueue)
+		}
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, errorResponse{Error: err.Error()})
+}
+
+// writeAPIError forwards the errors of the API server, like Forbidden, with
+// their code.
+func writeAPIError(w http.ResponseWriter, err error) {
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		writeError(w, int(status.Status().Code), err)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	"sigs.k8s.io/kueue/cmd/gateway/profile"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const validToken = "valid-token"
+
+func TestServer(t *testing.T) {
+	profiles := map[string]*profile.Profile{
+		"training": {
+			Name:       "training",
+			LocalQueue: "user-queue",
+			Template: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "main", Image: "trainer:v1"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	gatewayJob := testingjob.MakeJob("resnet", "ns").
+		UID("job-uid").
+		Label(profile.ProfileLabel, "training").
+		Obj()
+	admittedWorkload := utiltesting.MakeWorkload(jobframework.GetWorkloadNameForOwnerWithGVK("resnet", "job-uid", jobGVK), "ns").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+		Admitted(true).
+		Obj()
+
+	cases := map[string]struct {
+		objs       []client.Object
+		method     string
+		path       string
+		token      string
+		body       string
+		wantCode   int
+		wantBody   any
+		wantJobKey *types.NamespacedName
+	}{
+		"no token": {
+			method:   http.MethodGet,
+			path:     "/v1/profiles",
+			wantCode: http.StatusUnauthorized,
+		},
+		"invalid token": {
+			method:   http.MethodGet,
+			path:     "/v1/profiles",
+			token:    "invalid",
+			wantCode: http.StatusUnauthorized,
+		},
+		"list the profiles": {
+			method:   http.MethodGet,
+			path:     "/v1/profiles",
+			token:    validToken,
+			wantCode: http.StatusOK,
+			wantBody: []ProfileInfo{{Name: "training", LocalQueue: "user-queue"}},
+		},
+		"create a job": {
+			method:   http.MethodPost,
+			path:     "/v1/namespaces/ns/jobs",
+			token:    validToken,
+			body:     `{"profile": "training", "name": "resnet", "image": "trainer:v2"}`,
+			wantCode: http.StatusCreated,
+			wantBody: JobStatus{
+				Namespace: "ns",
+				Name:      "resnet",
+				Status:    workload.StatusPending,
+			},
+			wantJobKey: &types.NamespacedName{Namespace: "ns", Name: "resnet"},
+		},
+		"create a job of an unknown profile": {
+			method:   http.MethodPost,
+			path:     "/v1/namespaces/ns/jobs",
+			token:    validToken,
+			body:     `{"profile": "inference"}`,
+			wantCode: http.StatusBadRequest,
+		},
+		"create a job with an unknown field": {
+			method:   http.MethodPost,
+			path:     "/v1/namespaces/ns/jobs",
+			token:    validToken,
+			body:     `{"profile": "training", "gpus": 8}`,
+			wantCode: http.StatusBadRequest,
+		},
+		"create an existing job": {
+			objs:     []client.Object{gatewayJob},
+			method:   http.MethodPost,
+			path:     "/v1/namespaces/ns/jobs",
+			token:    validToken,
+			body:     `{"profile": "training", "name": "resnet"}`,
+			wantCode: http.StatusConflict,
+		},
+		"get a job without workload": {
+			objs:     []client.Object{gatewayJob},
+			method:   http.MethodGet,
+			path:     "/v1/namespaces/ns/jobs/resnet",
+			token:    validToken,
+			wantCode: http.StatusOK,
+			wantBody: JobStatus{
+				Namespace: "ns",
+				Name:      "resnet",
+				Status:    workload.StatusPending,
+			},
+		},
+		"get an admitted job": {
+			objs:     []client.Object{gatewayJob, admittedWorkload},
+			method:   http.MethodGet,
+			path:     "/v1/namespaces/ns/jobs/resnet",
+			token:    validToken,
+			wantCode: http.StatusOK,
+			wantBody: JobStatus{
+				Namespace:    "ns",
+				Name:         "resnet",
+				Workload:     admittedWorkload.Name,
+				Status:       workload.StatusAdmitted,
+				ClusterQueue: "cq",
+				Conditions:   admittedWorkload.Status.Conditions,
+			},
+		},
+		"get a job not created by the gateway": {
+			objs:     []client.Object{testingjob.MakeJob("resnet", "ns").Obj()},
+			method:   http.MethodGet,
+			path:     "/v1/namespaces/ns/jobs/resnet",
+			token:    validToken,
+			wantCode: http.StatusNotFound,
+		},
+		"get a missing job": {
+			method:   http.MethodGet,
+			path:     "/v1/namespaces/ns/jobs/resnet",
+			token:    validToken,
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := utiltesting.NewClientBuilder().WithObjects(tc.objs...).Build()
+			authenticate := func(_ context.Context, token string) (*authenticationv1.UserInfo, error) {
+				if token != validToken {
+					return nil, errors.New("invalid token")
+				}
+				return &authenticationv1.UserInfo{Username: "alice"}, nil
+			}
+			clientFor := func(*authenticationv1.UserInfo) (*Clients, error) {
+				return &Clients{Client: cl, Visibility: fake.NewSimpleClientset().VisibilityV1beta1()}, nil
+			}
+			srv := New(logr.Discard(), profiles, authenticate, clientFor)
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantCode {
+				t.Fatalf("Unexpected code, want=%d, got=%d, body: %s", tc.wantCode, rec.Code, rec.Body.String())
+			}
+			if tc.wantBody != nil {
+				wantBody, err := json.Marshal(tc.wantBody)
+				if err != nil {
+					t.Fatalf("Encoding the wanted body: %v", err)
+				}
+				if diff := cmp.Diff(string(wantBody)+"\n", rec.Body.String()); diff != "" {
+					t.Errorf("Unexpected body (-want,+got):\n%s", diff)
+				}
+			}
+			if tc.wantJobKey != nil {
+				var job batchv1.Job
+				if err := cl.Get(context.Background(), *tc.wantJobKey, &job); err != nil {
+					t.Fatalf("Getting the created job: %v", err)
+				}
+				wantLabels := map[string]string{
+					constants.QueueLabel: "user-queue",
+					profile.ProfileLabel: "training",
+				}
+				if diff := cmp.Diff(wantLabels, job.Labels); diff != "" {
+					t.Errorf("Unexpected labels of the created job (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/gateway/profile"
+	"sigs.k8s.io/kueue/cmd/gateway/slurm"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const (
+	// defaultSlurmProfile is the profile of the batch scripts submitted without
+	// the profile query parameter.
+	defaultSlurmProfile = "slurm"
+
+	// The Slurm job states reported by squeue.
+	slurmPending   = "PD"
+	slurmRunning   = "R"
+	slurmCompleted = "CD"
+	slurmFailed    = "F"
+)
+
+// sbatch creates a job running the batch script of the body, from the options
+// of its #SBATCH directives, like sbatch.
+func (s *Server) sbatch(w http.ResponseWriter, r *http.Request, c *Clients) {
+	script, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSubmissionSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("reading the batch script: %w", err))
+		return
+	}
+	opts, err := slurm.ParseScript(string(script))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	profileName := r.URL.Query().Get("profile")
+	if profileName == "" {
+		profileName = defaultSlurmProfile
+	}
+	p, found := s.profiles[profileName]
+	if !found {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unknown profile %q", profileName))
+		return
+	}
+	submission, err := opts.Submission(p.Name, string(script))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	job, err := p.Job(r.PathValue("namespace"), submission)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := c.Create(r.Context(), job); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	s.log.V(2).Info("Created job from batch script", "job", client.ObjectKeyFromObject(job), "profile", p.Name, "ignoredOptions", opts.Ignored)
+	var out strings.Builder
+	if len(opts.Ignored) > 0 {
+		fmt.Fprintf(&out, "sbatch: warning: ignoring unsupported options: %s\n", strings.Join(opts.Ignored, " "))
+	}
+	fmt.Fprintf(&out, "Submitted batch job %s\n", job.Name)
+	writeText(w, http.StatusCreated, out.String())
+}
+
+// squeue lists the jobs created by the gateway in the namespace, like squeue,
+// optionally filtered by the user and partition query parameters.
+func (s *Server) squeue(w http.ResponseWriter, r *http.Request, c *Clients) {
+	namespace := r.PathValue("namespace")
+	query := r.URL.Query()
+	jobSelector := client.MatchingLabels{}
+	if partition := query.Get("partition"); partition != "" {
+		jobSelector[constants.QueueLabel] = partition
+	}
+	var jobs batchv1.JobList
+	if err := c.List(r.Context(), &jobs, client.InNamespace(namespace), jobSelector, client.HasLabels{profile.ProfileLabel}); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	var workloads kueue.WorkloadList
+	if err := c.List(r.Context(), &workloads, client.InNamespace(namespace)); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	workloadsByName := make(map[string]*kueue.Workload, len(workloads.Items))
+	for i := range workloads.Items {
+		workloadsByName[workloads.Items[i].Name] = &workloads.Items[i]
+	}
+
+	var out strings.Builder
+	tw := tabwriter.NewWriter(&out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "JOBID\tPARTITION\tNAME\tUSER\tST\tTIME\tNODES\tNODELIST(REASON)")
+	// The positions of the pending workloads in their LocalQueues, fetched
+	// once per LocalQueue.
+	positions := make(map[string]map[string]int32)
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		user := job.Annotations[constants.SubmitterUserAnnotation]
+		if u := query.Get("user"); u != "" && u != user {
+			continue
+		}
+		if user == "" {
+			user = "-"
+		}
+		partition := job.Labels[constants.QueueLabel]
+		wl := workloadsByName[jobframework.GetWorkloadNameForOwnerWithGVK(job.Name, job.UID, jobGVK)]
+		state, elapsed, nodeList := slurmPending, time.Duration(0), "(None)"
+		if wl != nil {
+			switch workload.Status(wl) {
+			case workload.StatusPending:
+				if _, found := positions[partition]; !found {
+					positions[partition] = s.pendingPositions(r, c, namespace, partition)
+				}
+				nodeList = pendingReason(wl, positions[partition])
+			case workload.StatusQuotaReserved:
+				nodeList = "(AdmissionChecks)"
+			case workload.StatusAdmitted:
+				state = slurmRunning
+				elapsed = sinceAdmission(wl, s.clock.Now())
+				nodeList = string(wl.Status.Admission.ClusterQueue)
+			case workload.StatusFinished:
+				state = slurmCompleted
+				if finished := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadFinished); finished != nil {
+					if finished.Reason == kueue.WorkloadFinishedReasonFailed {
+						state = slurmFailed
+					}
+					elapsed = sinceAdmission(wl, finished.LastTransitionTime.Time)
+				}
+				nodeList = ""
+				if wl.Status.Admission != nil {
+					nodeList = string(wl.Status.Admission.ClusterQueue)
+				}
+			}
+		}
+		// The name of the Slurm job is the prefix of the generated name.
+		name := strings.TrimSuffix(job.GenerateName, "-")
+		if name == "" {
+			name = job.Name
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n", job.Name, partition, name, user,
+			state, formatSlurmTime(elapsed), ptr.Deref(job.Spec.Parallelism, 1), nodeList)
+	}
+	_ = tw.Flush()
+	writeText(w, http.StatusOK, out.String())
+}
+
+// scancel deletes a job created by the gateway, like scancel.
+func (s *Server) scancel(w http.ResponseWriter, r *http.Request, c *Clients) {
+	var job batchv1.Job
+	key := client.ObjectKey{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+	if err := c.Get(r.Context(), key, &job); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if _, found := job.Labels[profile.ProfileLabel]; !found {
+		writeError(w, http.StatusNotFound, fmt.Errorf("job %s not created by the gateway", key))
+		return
+	}
+	if err := c.Delete(r.Context(), &job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	s.log.V(2).Info("Cancelled job", "job", key)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// pendingPositions returns the positions of the pending workloads of the
+// LocalQueue, by name, or nil if the visibility API can't be queried.
+func (s *Server) pendingPositions(r *http.Request, c *Clients, namespace, localQueue string) map[string]int32 {
+	summary, err := c.Visibility.LocalQueues(namespace).GetPendingWorkloadsSummary(r.Context(), localQueue, metav1.GetOptions{})
+	if err != nil {
+		s.log.V(2).Info("Getting the pending workloads", "localQueue", client.ObjectKey{Namespace: namespace, Name: localQueue}, "err", err)
+		return nil
+	}
+	positions := make(map[string]int32, len(summary.Items))
+	for _, pw := range summary.Items {
+		positions[pw.Name] = pw.PositionInLocalQueue
+	}
+	return positions
+}
+
+// pendingReason returns the Slurm reason of a pending workload: the head of
+// its LocalQueue waits for resources, and the others for their priority.
+func pendingReason(wl *kueue.Workload, positions map[string]int32) string {
+	if !ptr.Deref(wl.Spec.Active, true) {
+		return "(JobHeldUser)"
+	}
+	position, found := positions[wl.Name]
+	switch {
+	case !found:
+		return "(None)"
+	case position == 0:
+		return "(Resources)"
+	default:
+		return "(Priority)"
+	}
+}
+
+// sinceAdmission returns the time the workload has run for, since its
+// admission.
+func sinceAdmission(wl *kueue.Workload, now time.Time) time.Duration {
+	cond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadAdmitted)
+	if cond == nil {
+		return 0
+	}
+	if elapsed := now.Sub(cond.LastTransitionTime.Time); elapsed > 0 {
+		return elapsed
+	}
+	return 0
+}
+
+// formatSlurmTime formats the duration as squeue: minutes:seconds,
+// hours:minutes:seconds, or days-hours:minutes:seconds.
+func formatSlurmTime(d time.Duration) string {
+	seconds := int(d.Seconds())
+	days, hours, minutes := seconds/86400, seconds/3600%24, seconds/60%60
+	seconds %= 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%d-%02d:%02d:%02d", days, hours, minutes, seconds)
+	case hours > 0:
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	default:
+		return fmt.Sprintf("%d:%02d", minutes, seconds)
+	}
+}
+
+func writeText(w http.ResponseWriter, code int, body string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(code)
+	_, _ = io.WriteString(w, body)
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubetesting "k8s.io/client-go/testing"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	"sigs.k8s.io/kueue/cmd/gateway/profile"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
+)
+
+func TestSlurm(t *testing.T) {
+	profiles := map[string]*profile.Profile{
+		"slurm": {
+			Name:       "slurm",
+			LocalQueue: "default",
+			Template: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "main", Image: "hpc:v1"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	now := time.Now()
+	slurmJob := func(name, queue, user string) *testingjob.JobWrapper {
+		return testingjob.MakeJob(name, "ns").
+			UID(name+"-uid").
+			Label(profile.ProfileLabel, "slurm").
+			Queue(queue).
+			SetAnnotation(constants.SubmitterUserAnnotation, user)
+	}
+	workloadOf := func(name string) *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload(jobframework.GetWorkloadNameForOwnerWithGVK(name, types.UID(name+"-uid"), jobGVK), "ns")
+	}
+
+	cases := map[string]struct {
+		objs         []client.Object
+		pending      map[string][]visibility.PendingWorkload
+		method       string
+		path         string
+		body         string
+		wantCode     int
+		wantBody     string
+		wantJobKey   *types.NamespacedName
+		wantRequests corev1.ResourceList
+		wantNoJob    *types.NamespacedName
+	}{
+		"sbatch": {
+			method: http.MethodPost,
+			path:   "/slurm/v1/namespaces/ns/jobs",
+			body: `#!/bin/sh
+#SBATCH --job-name=hello --partition=gpu-queue
+#SBATCH --ntasks=2 --cpus-per-task=4 --mem=1G
+#SBATCH --exclusive
+hostname
+`,
+			wantCode:     http.StatusCreated,
+			wantBody:     "sbatch: warning: ignoring unsupported options: --exclusive\nSubmitted batch job hello-",
+			wantJobKey:   &types.NamespacedName{Namespace: "ns"},
+			wantRequests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+		"sbatch with an unknown profile": {
+			method:   http.MethodPost,
+			path:     "/slurm/v1/namespaces/ns/jobs?profile=mpi",
+			body:     "#!/bin/sh\nhostname\n",
+			wantCode: http.StatusBadRequest,
+		},
+		"sbatch with an invalid directive": {
+			method:   http.MethodPost,
+			path:     "/slurm/v1/namespaces/ns/jobs",
+			body:     "#!/bin/sh\n#SBATCH --time=forever\nhostname\n",
+			wantCode: http.StatusBadRequest,
+		},
+		"squeue": {
+			objs: []client.Object{
+				slurmJob("first", "default", "alice").Obj(),
+				slurmJob("second", "default", "bob").Parallelism(4).Obj(),
+				slurmJob("running", "gpu-queue", "alice").Obj(),
+				slurmJob("done", "gpu-queue", "alice").Obj(),
+				slurmJob("failed", "gpu-queue", "alice").Obj(),
+				slurmJob("held", "default", "alice").Obj(),
+				slurmJob("new", "default", "alice").Obj(),
+				testingjob.MakeJob("other", "ns").Queue("default").Obj(),
+				workloadOf("first").Queue("default").Obj(),
+				workloadOf("second").Queue("default").Obj(),
+				workloadOf("held").Queue("default").Active(false).Obj(),
+				workloadOf("running").Queue("gpu-queue").
+					ReserveQuota(utiltesting.MakeAdmission("gpu-cq").Obj()).
+					AdmittedAt(true, now.Add(-90*time.Second)).
+					Obj(),
+				workloadOf("done").Queue("gpu-queue").
+					ReserveQuota(utiltesting.MakeAdmission("gpu-cq").Obj()).
+					AdmittedAt(true, now.Add(-time.Hour)).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadFinished,
+						Status:             metav1.ConditionTrue,
+						Reason:             kueue.WorkloadFinishedReasonSucceeded,
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Obj(),
+				workloadOf("failed").Queue("gpu-queue").
+					ReserveQuota(utiltesting.MakeAdmission("gpu-cq").Obj()).
+					AdmittedAt(true, now.Add(-25*time.Hour)).
+					Condition(metav1.Condition{
+						Type:               kueue.WorkloadFinished,
+						Status:             metav1.ConditionTrue,
+						Reason:             kueue.WorkloadFinishedReasonFailed,
+						LastTransitionTime: metav1.NewTime(now),
+					}).
+					Obj(),
+			},
+			pending: map[string][]visibility.PendingWorkload{
+				"default": {
+					{ObjectMeta: metav1.ObjectMeta{Name: workloadOf("first").Obj().Name}, PositionInLocalQueue: 0},
+					{ObjectMeta: metav1.ObjectMeta{Name: workloadOf("second").Obj().Name}, PositionInLocalQueue: 1},
+				},
+			},
+			method:   http.MethodGet,
+			path:     "/slurm/v1/namespaces/ns/jobs",
+			wantCode: http.StatusOK,
+			wantBody: `JOBID    PARTITION  NAME     USER   ST  TIME        NODES  NODELIST(REASON)
+done     gpu-queue  done     alice  CD  1:00:00     1      gpu-cq
+failed   gpu-queue  failed   alice  F   1-01:00:00  1      gpu-cq
+first    default    first    alice  PD  0:00        1      (Resources)
+held     default    held     alice  PD  0:00        1      (JobHeldUser)
+new      default    new      alice  PD  0:00        1      (None)
+running  gpu-queue  running  alice  R   1:30        1      gpu-cq
+second   default    second   bob    PD  0:00        4      (Priority)
+`,
+		},
+		"squeue of a user and partition": {
+			objs: []client.Object{
+				slurmJob("first", "default", "alice").Obj(),
+				slurmJob("second", "default", "bob").Obj(),
+				slurmJob("third", "gpu-queue", "bob").Obj(),
+			},
+			method:   http.MethodGet,
+			path:     "/slurm/v1/namespaces/ns/jobs?user=bob&partition=default",
+			wantCode: http.StatusOK,
+			wantBody: `JOBID   PARTITION  NAME    USER  ST  TIME  NODES  NODELIST(REASON)
+second  default    second  bob   PD  0:00  1      (None)
+`,
+		},
+		"scancel": {
+			objs:      []client.Object{slurmJob("first", "default", "alice").Obj()},
+			method:    http.MethodDelete,
+			path:      "/slurm/v1/namespaces/ns/jobs/first",
+			wantCode:  http.StatusNoContent,
+			wantNoJob: &types.NamespacedName{Namespace: "ns", Name: "first"},
+		},
+		"scancel a job not created by the gateway": {
+			objs:     []client.Object{testingjob.MakeJob("other", "ns").Obj()},
+			method:   http.MethodDelete,
+			path:     "/slurm/v1/namespaces/ns/jobs/other",
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := utiltesting.NewClientBuilder().WithObjects(tc.objs...).Build()
+			clientset := fake.NewSimpleClientset()
+			clientset.PrependReactor("get", "localqueues", func(action kubetesting.Action) (bool, runtime.Object, error) {
+				getAction := action.(kubetesting.GetAction)
+				if action.GetSubresource() != "pendingworkloads" {
+					return false, nil, nil
+				}
+				items, found := tc.pending[getAction.GetName()]
+				if !found {
+					return true, nil, apierrors.NewNotFound(visibility.Resource("localqueues"), getAction.GetName())
+				}
+				return true, &visibility.PendingWorkloadsSummary{Items: items}, nil
+			})
+			authenticate := func(_ context.Context, token string) (*authenticationv1.UserInfo, error) {
+				if token != validToken {
+					return nil, errors.New("invalid token")
+				}
+				return &authenticationv1.UserInfo{Username: "alice"}, nil
+			}
+			clientFor := func(*authenticationv1.UserInfo) (*Clients, error) {
+				return &Clients{Client: cl, Visibility: clientset.VisibilityV1beta1()}, nil
+			}
+			srv := New(logr.Discard(), profiles, authenticate, clientFor)
+			srv.clock = testingclock.NewFakeClock(now)
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Authorization", "Bearer "+validToken)
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantCode {
+				t.Fatalf("Unexpected code, want=%d, got=%d, body: %s", tc.wantCode, rec.Code, rec.Body.String())
+			}
+			if tc.wantBody != "" && !strings.HasPrefix(rec.Body.String(), tc.wantBody) {
+				t.Errorf("Unexpected body (-want,+got):\n%s", cmp.Diff(tc.wantBody, rec.Body.String()))
+			}
+			if tc.wantJobKey != nil {
+				var jobs batchv1.JobList
+				if err := cl.List(context.Background(), &jobs, client.InNamespace(tc.wantJobKey.Namespace)); err != nil {
+					t.Fatalf("Listing the jobs: %v", err)
+				}
+				if len(jobs.Items) != 1 {
+					t.Fatalf("Unexpected number of jobs, want=1, got=%d", len(jobs.Items))
+				}
+				job := jobs.Items[0]
+				if diff := cmp.Diff("gpu-queue", job.Labels[constants.QueueLabel]); diff != "" {
+					t.Errorf("Unexpected queue of the created job (-want,+got):\n%s", diff)
+				}
+				if diff := cmp.Diff(tc.wantRequests, job.Spec.Template.Spec.Containers[0].Resources.Requests); diff != "" {
+					t.Errorf("Unexpected requests of the created job (-want,+got):\n%s", diff)
+				}
+			}
+			if tc.wantNoJob != nil {
+				var job batchv1.Job
+				if err := cl.Get(context.Background(), *tc.wantNoJob, &job); !apierrors.IsNotFound(err) {
+					t.Errorf("Unexpected error getting the cancelled job, want NotFound, got=%v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slurm
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kueue/cmd/gateway/profile"
+)
+
+const (
+	directivePrefix = "#SBATCH"
+
+	// GPUResource is the resource of the GPUs requested with --gres=gpu:N.
+	GPUResource corev1.ResourceName = "nvidia.com/gpu"
+)
+
+var (
+	// shortOptions maps the supported short options to their long names.
+	shortOptions = map[string]string{
+		"J": "job-name",
+		"p": "partition",
+		"N": "nodes",
+		"n": "ntasks",
+		"c": "cpus-per-task",
+		"t": "time",
+	}
+
+	invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+// Options are the supported sbatch options of a batch script.
+type Options struct {
+	JobName     string
+	Partition   string
+	Nodes       *int32
+	Tasks       *int32
+	CPUsPerTask *int32
+	// Memory is the memory per node, and MemoryPerCPU the memory per CPU, in
+	// Kubernetes quantities.
+	Memory       string
+	MemoryPerCPU string
+	GPUs         *int32
+	TimeSeconds  *int32
+	// Ignored are the directives which aren't supported, and ignored.
+	Ignored []string
+}
+
+// ParseScript returns the options of the #SBATCH directives of the batch
+// script. As sbatch, it stops at the first line which is not a comment.
+func ParseScript(script string) (*Options, error) {
+	opts := &Options{}
+	scanner := bufio.NewScanner(strings.NewReader(script))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		directive, found := strings.CutPrefix(line, directivePrefix)
+		if !found || (directive != "" && directive[0] != ' ' && directive[0] != '\t') {
+			continue
+		}
+		// The rest of the directive after a # is a comment.
+		directive, _, _ = strings.Cut(directive, "#")
+		if err := opts.parseArgs(strings.Fields(directive)); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+func (o *Options) parseArgs(args []string) error {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		var name, value string
+		hasValue := false
+		switch {
+		case strings.HasPrefix(arg, "--"):
+			name, value, hasValue = strings.Cut(arg[2:], "=")
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			long, found := shortOptions[arg[1:2]]
+			if !found {
+				o.Ignored = append(o.Ignored, arg)
+				continue
+			}
+			name = long
+			if len(arg) > 2 {
+				value, hasValue = arg[2:], true
+			}
+		default:
+			o.Ignored = append(o.Ignored, arg)
+			continue
+		}
+		if !hasValue && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			i++
+			value, hasValue = args[i], true
+		}
+		if err := o.set(name, value, hasValue); err != nil {
+			return fmt.Errorf("--%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (o *Options) set(name, value string, hasValue bool) error {
+	supported := true
+	switch name {
+	case "job-name", "partition", "nodes", "ntasks", "cpus-per-task", "mem", "mem-per-cpu", "gres", "gpus", "time":
+		if !hasValue {
+			return fmt.Errorf("no value")
+		}
+	default:
+		supported = false
+	}
+	var err error
+	switch name {
+	case "job-name":
+		o.JobName = value
+	case "partition":
+		o.Partition = value
+	case "nodes":
+		// The range of nodes, like 2-4, is not supported: the minimum is used.
+		min, _, _ := strings.Cut(value, "-")
+		o.Nodes, err = parseCount(min)
+	case "ntasks":
+		o.Tasks, err = parseCount(value)
+	case "cpus-per-task":
+		o.CPUsPerTask, err = parseCount(value)
+	case "mem":
+		o.Memory, err = parseMemory(value)
+	case "mem-per-cpu":
+		o.MemoryPerCPU, err = parseMemory(value)
+	case "gres":
+		o.GPUs, err = parseGRES(value)
+	case "gpus":
+		o.GPUs, err = parseGPUs(value)
+	case "time":
+		o.TimeSeconds, err = ParseTime(value)
+	}
+	if !supported {
+		o.Ignored = append(o.Ignored, "--"+name)
+	}
+	return err
+}
+
+// Submission returns the submission of the batch script, from the profile,
+// which runs the script in the first container with /bin/sh.
+func (o *Options) Submission(profileName, script string) (*profile.Submission, error) {
+	s := &profile.Submission{
+		Profile:                     profileName,
+		GenerateName:                generateName(o.JobName),
+		LocalQueue:                  o.Partition,
+		Command:                     []string{"/bin/sh", "-c"},
+		Args:                        []string{script},
+		MaximumExecutionTimeSeconds: o.TimeSeconds,
+		Env:                         make(map[string]string),
+		Requests:                    make(map[corev1.ResourceName]string),
+	}
+	// Each pod of the job runs a task, or a node when the tasks aren't set.
+	pods := o.Tasks
+	if pods == nil {
+		pods = o.Nodes
+	}
+	if pods != nil {
+		s.Parallelism = pods
+		s.Completions = pods
+		s.Env["SLURM_NTASKS"] = strconv.Itoa(int(*pods))
+	}
+	cpus := ptr.Deref(o.CPUsPerTask, 1)
+	if o.CPUsPerTask != nil {
+		s.Requests[corev1.ResourceCPU] = strconv.Itoa(int(cpus))
+		s.Env["SLURM_CPUS_PER_TASK"] = strconv.Itoa(int(cpus))
+	}
+	switch {
+	case o.Memory != "":
+		s.Requests[corev1.ResourceMemory] = o.Memory
+	case o.MemoryPerCPU != "":
+		memory, err := resource.ParseQuantity(o.MemoryPerCPU)
+		if err != nil {
+			return nil, err
+		}
+		memory.Mul(int64(cpus))
+		s.Requests[corev1.ResourceMemory] = memory.String()
+	}
+	if o.GPUs != nil {
+		s.Requests[GPUResource] = strconv.Itoa(int(*o.GPUs))
+	}
+	if o.JobName != "" {
+		s.Env["SLURM_JOB_NAME"] = o.JobName
+	}
+	if o.Partition != "" {
+		s.Env["SLURM_JOB_PARTITION"] = o.Partition
+	}
+	return s, nil
+}
+
+// generateName returns the prefix of the generated names of the jobs, from
+// the name of the Slurm job, which doesn't need to be unique nor a valid
+// Kubernetes name.
+func generateName(jobName string) string {
+	name := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(jobName), "-"), "-")
+	if name == "" {
+		return ""
+	}
+	if maxLength := validation.DNS1123LabelMaxLength - 10; len(name) > maxLength {
+		name = strings.TrimRight(name[:maxLength], "-")
+	}
+	return name + "-"
+}
+
+func parseCount(value string) (*int32, error) {
+	count, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("must be positive")
+	}
+	return ptr.To(int32(count)), nil
+}
+
+// parseMemory returns the quantity of the Slurm memory, in megabytes by
+// default, or with the K, M, G or T suffixes.
+func parseMemory(value string) (string, error) {
+	if value == "" {
+		return "", fmt.Errorf("invalid memory %q", value)
+	}
+	units := map[byte]string{'K': "Ki", 'M': "Mi", 'G': "Gi", 'T': "Ti"}
+	number, unit := value, "Mi"
+	if last := strings.ToUpper(value[len(value)-1:])[0]; units[last] != "" {
+		number, unit = value[:len(value)-1], units[last]
+	}
+	if _, err := strconv.ParseUint(number, 10, 64); err != nil {
+		return "", fmt.Errorf("invalid memory %q", value)
+	}
+	return number + unit, nil
+}
+
+// parseGRES returns the number of GPUs of the generic resources, like gpu:2
+// or gpu:a100:2.
+func parseGRES(value string) (*int32, error) {
+	for _, gres := range strings.Split(value, ",") {
+		parts := strings.Split(gres, ":")
+		if parts[0] != "gpu" {
+			continue
+		}
+		if len(parts) == 1 {
+			return ptr.To[int32](1), nil
+		}
+		return parseCount(parts[len(parts)-1])
+	}
+	return nil, nil
+}
+
+// parseGPUs returns the number of GPUs, like 2 or a100:2.
+func parseGPUs(value string) (*int32, error) {
+	parts := strings.Split(value, ":")
+	return parseCount(parts[len(parts)-1])
+}
+
+// ParseTime returns the seconds of a Slurm time limit, in one of the
+// minutes, minutes:seconds, hours:minutes:seconds, days-hours,
+// days-hours:minutes and days-hours:minutes:seconds formats.
+func ParseTime(value string) (*int32, error) {
+	days := 0
+	clock := value
+	if d, rest, found := strings.Cut(value, "-"); found {
+		var err error
+		if days, err = strconv.Atoi(d); err != nil {
+			return nil, fmt.Errorf("invalid time %q", value)
+		}
+		clock = rest
+	}
+	var fields []int
+	for _, f := range strings.Split(clock, ":") {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid time %q", value)
+		}
+		fields = append(fields, n)
+	}
+	var seconds int
+	switch {
+	case len(fields) > 3:
+		return nil, fmt.Errorf("invalid time %q", value)
+	case days > 0 || clock != value:
+		// days-hours[:minutes[:seconds]]
+		units := []int{3600, 60, 1}
+		for i, f := range fields {
+			seconds += f * units[i]
+		}
+		seconds += days * 24 * 3600
+	case len(fields) == 3:
+		seconds = fields[0]*3600 + fields[1]*60 + fields[2]
+	case len(fields) == 2:
+		seconds = fields[0]*60 + fields[1]
+	default:
+		seconds = fields[0] * 60
+	}
+	if seconds <= 0 {
+		return nil, fmt.Errorf("invalid time %q", value)
+	}
+	return ptr.To(int32(seconds)), nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slurm
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kueue/cmd/gateway/profile"
+)
+
+func TestParseScript(t *testing.T) {
+	cases := map[string]struct {
+		script   string
+		wantOpts *Options
+		wantErr  bool
+	}{
+		"no directives": {
+			script:   "#!/bin/bash\necho hello\n",
+			wantOpts: &Options{},
+		},
+		"long and short options": {
+			script: `#!/bin/bash
+# A comment.
+#SBATCH --job-name=My_Job
+#SBATCH -p gpu-queue
+#SBATCH --ntasks 4 --cpus-per-task=2 # Two CPUs per task.
+#SBATCH -t1:30:00
+#SBATCH --mem=8G
+#SBATCH --gres=gpu:a100:2
+#SBATCH --mail-type=END -x node1
+
+srun hostname
+#SBATCH --nodes=8
+`,
+			wantOpts: &Options{
+				JobName:     "My_Job",
+				Partition:   "gpu-queue",
+				Tasks:       ptr.To[int32](4),
+				CPUsPerTask: ptr.To[int32](2),
+				TimeSeconds: ptr.To[int32](5400),
+				Memory:      "8Gi",
+				GPUs:        ptr.To[int32](2),
+				Ignored:     []string{"--mail-type", "-x", "node1"},
+			},
+		},
+		"range of nodes": {
+			script: "#SBATCH -N 2-4\n#SBATCH --mem-per-cpu=512\n",
+			wantOpts: &Options{
+				Nodes:        ptr.To[int32](2),
+				MemoryPerCPU: "512Mi",
+			},
+		},
+		"not a directive": {
+			script:   "#SBATCHX --ntasks=4\n",
+			wantOpts: &Options{},
+		},
+		"missing value": {
+			script:  "#SBATCH --ntasks\n",
+			wantErr: true,
+		},
+		"invalid count": {
+			script:  "#SBATCH --ntasks=0\n",
+			wantErr: true,
+		},
+		"invalid memory": {
+			script:  "#SBATCH --mem=lots\n",
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			opts, err := ParseScript(tc.script)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Unexpected error, want=%v, got=%v", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.wantOpts, opts); diff != "" {
+				t.Errorf("Unexpected options (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	cases := map[string]struct {
+		value       string
+		wantSeconds *int32
+		wantErr     bool
+	}{
+		"minutes":                    {value: "90", wantSeconds: ptr.To[int32](5400)},
+		"minutes:seconds":            {value: "1:30", wantSeconds: ptr.To[int32](90)},
+		"hours:minutes:seconds":      {value: "2:00:30", wantSeconds: ptr.To[int32](7230)},
+		"days-hours":                 {value: "1-2", wantSeconds: ptr.To[int32](93600)},
+		"days-hours:minutes":         {value: "1-0:30", wantSeconds: ptr.To[int32](88200)},
+		"days-hours:minutes:seconds": {value: "0-0:0:10", wantSeconds: ptr.To[int32](10)},
+		"zero":                       {value: "0", wantErr: true},
+		"too many fields":            {value: "1:2:3:4", wantErr: true},
+		"not a number":               {value: "forever", wantErr: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			seconds, err := ParseTime(tc.value)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Unexpected error, want=%v, got=%v", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.wantSeconds, seconds); diff != "" {
+				t.Errorf("Unexpected seconds (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSubmission(t *testing.T) {
+	const script = "#!/bin/sh\nhostname\n"
+	cases := map[string]struct {
+		opts           Options
+		wantSubmission *profile.Submission
+	}{
+		"no options": {
+			wantSubmission: &profile.Submission{
+				Profile:  "slurm",
+				Command:  []string{"/bin/sh", "-c"},
+				Args:     []string{script},
+				Env:      map[string]string{},
+				Requests: map[corev1.ResourceName]string{},
+			},
+		},
+		"all the options": {
+			opts: Options{
+				JobName:     "My_Job",
+				Partition:   "gpu-queue",
+				Nodes:       ptr.To[int32](2),
+				Tasks:       ptr.To[int32](4),
+				CPUsPerTask: ptr.To[int32](2),
+				Memory:      "8Gi",
+				GPUs:        ptr.To[int32](1),
+				TimeSeconds: ptr.To[int32](600),
+			},
+			wantSubmission: &profile.Submission{
+				Profile:                     "slurm",
+				GenerateName:                "my-job-",
+				LocalQueue:                  "gpu-queue",
+				Command:                     []string{"/bin/sh", "-c"},
+				Args:                        []string{script},
+				Parallelism:                 ptr.To[int32](4),
+				Completions:                 ptr.To[int32](4),
+				MaximumExecutionTimeSeconds: ptr.To[int32](600),
+				Env: map[string]string{
+					"SLURM_NTASKS":        "4",
+					"SLURM_CPUS_PER_TASK": "2",
+					"SLURM_JOB_NAME":      "My_Job",
+					"SLURM_JOB_PARTITION": "gpu-queue",
+				},
+				Requests: map[corev1.ResourceName]string{
+					corev1.ResourceCPU:    "2",
+					corev1.ResourceMemory: "8Gi",
+					GPUResource:           "1",
+				},
+			},
+		},
+		"nodes and memory per CPU": {
+			opts: Options{
+				Nodes:        ptr.To[int32](2),
+				CPUsPerTask:  ptr.To[int32](4),
+				MemoryPerCPU: "512Mi",
+			},
+			wantSubmission: &profile.Submission{
+				Profile:     "slurm",
+				Command:     []string{"/bin/sh", "-c"},
+				Args:        []string{script},
+				Parallelism: ptr.To[int32](2),
+				Completions: ptr.To[int32](2),
+				Env: map[string]string{
+					"SLURM_NTASKS":        "2",
+					"SLURM_CPUS_PER_TASK": "4",
+				},
+				Requests: map[corev1.ResourceName]string{
+					corev1.ResourceCPU:    "4",
+					corev1.ResourceMemory: "2Gi",
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			submission, err := tc.opts.Submission("slurm", script)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantSubmission, submission); diff != "" {
+				t.Errorf("Unexpected submission (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
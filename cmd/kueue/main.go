@@ -22,6 +22,7 @@ import (
 	"flag"
 	"net/http"
 	"os"
+	"sync/atomic"
 
 	zaplog "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -37,6 +38,7 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/flowcontrol"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -45,27 +47,38 @@ import (
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/admissionpolicy"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/config"
 	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/controller/admissionchecks/imagesignature"
 	"sigs.k8s.io/kueue/pkg/controller/admissionchecks/multikueue"
 	"sigs.k8s.io/kueue/pkg/controller/admissionchecks/provisioning"
 	"sigs.k8s.io/kueue/pkg/controller/core"
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/controller/gc"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/controller/karpenter"
 	"sigs.k8s.io/kueue/pkg/controller/tas"
 	tasindexer "sigs.k8s.io/kueue/pkg/controller/tas/indexer"
+	"sigs.k8s.io/kueue/pkg/controller/vpa"
 	"sigs.k8s.io/kueue/pkg/debugger"
 	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/history"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/scheduler"
+	"sigs.k8s.io/kueue/pkg/scheduler/audit"
+	"sigs.k8s.io/kueue/pkg/tracing"
+	"sigs.k8s.io/kueue/pkg/util/authorization"
 	"sigs.k8s.io/kueue/pkg/util/cert"
 	"sigs.k8s.io/kueue/pkg/util/kubeversion"
+	"sigs.k8s.io/kueue/pkg/util/submitter"
 	"sigs.k8s.io/kueue/pkg/util/useragent"
 	"sigs.k8s.io/kueue/pkg/version"
 	"sigs.k8s.io/kueue/pkg/visibility"
 	"sigs.k8s.io/kueue/pkg/webhooks"
+	"sigs.k8s.io/kueue/pkg/workload"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -87,6 +100,7 @@ func init() {
 	utilruntime.Must(kueuealpha.AddToScheme(scheme))
 	utilruntime.Must(configapi.AddToScheme(scheme))
 	utilruntime.Must(autoscaling.AddToScheme(scheme))
+	utilruntime.Must(metricsv1beta1.AddToScheme(scheme))
 	// Add any additional framework integration types.
 	utilruntime.Must(
 		jobframework.ForEachIntegration(func(_ string, cb jobframework.IntegrationCallbacks) error {
@@ -131,6 +145,14 @@ func main() {
 	}
 
 	metrics.Register()
+	if lqMetrics := cfg.Metrics.LocalQueueMetrics; lqMetrics != nil && lqMetrics.LocalQueueSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(lqMetrics.LocalQueueSelector)
+		if err != nil {
+			setupLog.Error(err, "Unable to parse the LocalQueue selector of the metrics")
+			os.Exit(1)
+		}
+		metrics.SetLocalQueueSelector(selector)
+	}
 
 	kubeConfig := ctrl.GetConfigOrDie()
 	if kubeConfig.UserAgent == "" {
@@ -157,7 +179,10 @@ func main() {
 	} else {
 		close(certsReady)
 	}
-	cacheOptions := []cache.Option{cache.WithPodsReadyTracking(blockForPodsReady(&cfg))}
+	cacheOptions := []cache.Option{
+		cache.WithPodsReadyTracking(blockForPodsReady(&cfg)),
+		cache.WithWaitForPodsReady(config.WaitForPodsReadyIsEnabled(&cfg)),
+	}
 	queueOptions := []queue.Option{queue.WithPodsReadyRequeuingTimestamp(podsReadyRequeuingTimestamp(&cfg))}
 	if cfg.Resources != nil && len(cfg.Resources.ExcludeResourcePrefixes) > 0 {
 		cacheOptions = append(cacheOptions, cache.WithExcludedResourcePrefixes(cfg.Resources.ExcludeResourcePrefixes))
@@ -170,15 +195,61 @@ func main() {
 	if cfg.FairSharing != nil {
 		cacheOptions = append(cacheOptions, cache.WithFairSharing(cfg.FairSharing.Enable))
 	}
+	if ptr.Deref(cfg.ShadowMode, false) {
+		queueOptions = append(queueOptions, queue.WithShadowMode(true))
+	}
+	if cfg.QueueingOrder != nil {
+		queueOptions = append(queueOptions, queue.WithTieBreakers(cfg.QueueingOrder.TieBreakers))
+	}
 	cCache := cache.New(mgr.GetClient(), cacheOptions...)
 	queues := queue.NewManager(mgr.GetClient(), cCache, queueOptions...)
 
+	var cfgWatcher *config.Watcher
+	if features.Enabled(features.ConfigurationHotReload) && configFile != "" {
+		cfgWatcher = config.NewWatcher(scheme, configFile, &cfg)
+		if err := mgr.Add(cfgWatcher); err != nil {
+			setupLog.Error(err, "Unable to add the configuration watcher to manager")
+			os.Exit(1)
+		}
+		setupQueueingReconfiguration(cfgWatcher, cCache, queues)
+	}
+
 	ctx := ctrl.SetupSignalHandler()
+	tracerProvider, err := tracing.NewProvider(ctx, cfg.Tracing)
+	if err != nil {
+		setupLog.Error(err, "Unable to setup tracing")
+		os.Exit(1)
+	}
+	tracing.SetProvider(tracerProvider)
+
 	if err := setupIndexes(ctx, mgr, &cfg); err != nil {
 		setupLog.Error(err, "Unable to setup indexes")
 		os.Exit(1)
 	}
 	debugger.NewDumper(cCache, queues).ListenForSignal(ctx)
+	if err := mgr.AddMetricsServerExtraHandler(debugger.FairSharingPath, debugger.NewFairSharingHandler(cCache)); err != nil {
+		setupLog.Error(err, "Unable to setup the fair sharing debug endpoint")
+		os.Exit(1)
+	}
+
+	var coreOpts []core.SetupOption
+	if cfg.WorkloadHistory != nil {
+		store, err := history.NewStore(cfg.WorkloadHistory)
+		if err != nil {
+			setupLog.Error(err, "Unable to set up the workload history store")
+			os.Exit(1)
+		}
+		archiver := history.NewArchiver(store, cfg.WorkloadHistory)
+		if err := mgr.Add(archiver); err != nil {
+			setupLog.Error(err, "Unable to add the workload history to manager")
+			os.Exit(1)
+		}
+		if err := mgr.AddMetricsServerExtraHandler(history.QueryPath, history.NewQueryHandler(store)); err != nil {
+			setupLog.Error(err, "Unable to setup the workload history endpoint")
+			os.Exit(1)
+		}
+		coreOpts = append(coreOpts, core.WithWorkloadUpdateWatcher(archiver))
+	}
 
 	serverVersionFetcher := setupServerVersionFetcher(mgr, kubeConfig)
 
@@ -186,22 +257,25 @@ func main() {
 	// Cert won't be ready until manager starts, so start a goroutine here which
 	// will block until the cert is ready before setting up the controllers.
 	// Controllers who register after manager starts will start directly.
-	go setupControllers(ctx, mgr, cCache, queues, certsReady, &cfg, serverVersionFetcher)
+	go setupControllers(ctx, mgr, cCache, queues, certsReady, &cfg, serverVersionFetcher, cfgWatcher, coreOpts)
 
 	go queues.CleanUpOnContext(ctx)
 	go cCache.CleanUpOnContext(ctx)
 
+	sched := setupScheduler(mgr, cCache, queues, &cfg, cfgWatcher)
+
 	if features.Enabled(features.VisibilityOnDemand) {
-		go visibility.CreateAndStartVisibilityServer(ctx, queues)
+		go visibility.CreateAndStartVisibilityServer(ctx, mgr.GetClient(), queues, sched)
 	}
 
-	setupScheduler(mgr, cCache, queues, &cfg)
-
 	setupLog.Info("Starting manager")
 	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "Could not run manager")
 		os.Exit(1)
 	}
+	if err := tracerProvider.Shutdown(context.Background()); err != nil {
+		setupLog.Error(err, "Could not export the remaining spans")
+	}
 }
 
 func setupIndexes(ctx context.Context, mgr ctrl.Manager, cfg *configapi.Configuration) error {
@@ -220,6 +294,13 @@ func setupIndexes(ctx context.Context, mgr ctrl.Manager, cfg *configapi.Configur
 		}
 	}
 
+	if features.Enabled(features.ImageSignatureACC) {
+		if err := imagesignature.SetupIndexer(ctx, mgr.GetFieldIndexer()); err != nil {
+			setupLog.Error(err, "Could not setup image signature indexer")
+			os.Exit(1)
+		}
+	}
+
 	if features.Enabled(features.TopologyAwareScheduling) {
 		if err := tasindexer.SetupIndexes(ctx, mgr.GetFieldIndexer()); err != nil {
 			setupLog.Error(err, "Could not setup TAS indexer")
@@ -240,12 +321,12 @@ func setupIndexes(ctx context.Context, mgr ctrl.Manager, cfg *configapi.Configur
 	return jobframework.SetupIndexes(ctx, mgr.GetFieldIndexer(), opts...)
 }
 
-func setupControllers(ctx context.Context, mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager, certsReady chan struct{}, cfg *configapi.Configuration, serverVersionFetcher *kubeversion.ServerVersionFetcher) {
+func setupControllers(ctx context.Context, mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager, certsReady chan struct{}, cfg *configapi.Configuration, serverVersionFetcher *kubeversion.ServerVersionFetcher, cfgWatcher *config.Watcher, coreOpts []core.SetupOption) {
 	// The controllers won't work until the webhooks are operating, and the webhook won't work until the
 	// certs are all in place.
 	cert.WaitForCertsReady(setupLog, certsReady)
 
-	if failedCtrl, err := core.SetupControllers(mgr, queues, cCache, cfg); err != nil {
+	if failedCtrl, err := core.SetupControllers(mgr, queues, cCache, cfg, append(coreOpts, core.WithConfigWatcher(cfgWatcher))...); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", failedCtrl)
 		os.Exit(1)
 	}
@@ -265,6 +346,27 @@ func setupControllers(ctx context.Context, mgr ctrl.Manager, cCache *cache.Cache
 		}
 	}
 
+	if features.Enabled(features.ImageSignatureACC) {
+		ctrl, err := imagesignature.NewController(mgr.GetClient(), mgr.GetEventRecorderFor("kueue-image-signature-controller"), *cfg.Namespace)
+		if err != nil {
+			setupLog.Error(err, "Could not create the image signature controller")
+			os.Exit(1)
+		}
+		if err := ctrl.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Could not setup image signature controller")
+			os.Exit(1)
+		}
+	}
+
+	if features.Enabled(features.WorkloadGarbageCollector) {
+		provisioningRequests := features.Enabled(features.ProvisioningACC) && provisioning.ServerSupportsProvisioningRequest(mgr)
+		collector := gc.New(mgr.GetClient(), mgr.GetAPIReader(), gc.WithProvisioningRequests(provisioningRequests))
+		if err := mgr.Add(collector); err != nil {
+			setupLog.Error(err, "Unable to add the workload garbage collector to manager")
+			os.Exit(1)
+		}
+	}
+
 	if features.Enabled(features.MultiKueue) {
 		adapters, err := jobframework.GetMultiKueueAdapters(sets.New(cfg.Integrations.Frameworks...))
 		if err != nil {
@@ -282,6 +384,28 @@ func setupControllers(ctx context.Context, mgr ctrl.Manager, cCache *cache.Cache
 		}
 	}
 
+	if features.Enabled(features.KarpenterNodePoolLimits) {
+		if karpenter.ServerSupportsNodePools(mgr) {
+			if err := karpenter.NewNodePoolReconciler(mgr.GetClient(), cCache, queues).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "Could not setup the Karpenter NodePool controller")
+				os.Exit(1)
+			}
+		} else {
+			setupLog.Info("Skipping the Karpenter NodePool controller, the NodePool API is not available")
+		}
+	}
+
+	if features.Enabled(features.VPAQuotaAccounting) {
+		if vpa.ServerSupportsVerticalPodAutoscalers(mgr) {
+			if err := vpa.NewRecommendationReconciler(mgr.GetClient(), queues).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "Could not setup the VerticalPodAutoscaler recommendation controller")
+				os.Exit(1)
+			}
+		} else {
+			setupLog.Info("Skipping the VerticalPodAutoscaler recommendation controller, the VerticalPodAutoscaler API is not available")
+		}
+	}
+
 	if features.Enabled(features.TopologyAwareScheduling) {
 		if failedCtrl, err := tas.SetupControllers(mgr, queues, cCache, cfg); err != nil {
 			setupLog.Error(err, "Could not setup TAS controller", "controller", failedCtrl)
@@ -289,14 +413,24 @@ func setupControllers(ctx context.Context, mgr ctrl.Manager, cCache *cache.Cache
 		}
 	}
 
-	if failedWebhook, err := webhooks.Setup(mgr); err != nil {
+	var localQueueAuthorizer *authorization.LocalQueueAuthorizer
+	if cfg.LocalQueueAuthorization != nil {
+		localQueueAuthorizer = authorization.NewLocalQueueAuthorizer(mgr.GetClient(), cfg.LocalQueueAuthorization.Verb)
+	}
+	submitterRecorder := submitter.NewRecorder(cfg.SubmitterIdentity)
+	if failedWebhook, err := webhooks.Setup(mgr,
+		webhooks.WithLocalQueueAuthorizer(localQueueAuthorizer),
+		webhooks.WithSubmitterRecorder(submitterRecorder),
+	); err != nil {
 		setupLog.Error(err, "Unable to create webhook", "webhook", failedWebhook)
 		os.Exit(1)
 	}
 
+	waitForPodsReady := &atomic.Bool{}
+	waitForPodsReady.Store(config.WaitForPodsReadyIsEnabled(cfg))
 	opts := []jobframework.Option{
 		jobframework.WithManageJobsWithoutQueueName(cfg.ManageJobsWithoutQueueName),
-		jobframework.WithWaitForPodsReady(cfg.WaitForPodsReady),
+		jobframework.WithReloadableWaitForPodsReady(waitForPodsReady),
 		jobframework.WithKubeServerVersion(serverVersionFetcher),
 		jobframework.WithIntegrationOptions(corev1.SchemeGroupVersion.WithKind("Pod").String(), cfg.Integrations.PodOptions),
 		jobframework.WithEnabledFrameworks(cfg.Integrations.Frameworks),
@@ -305,20 +439,55 @@ func setupControllers(ctx context.Context, mgr ctrl.Manager, cCache *cache.Cache
 		jobframework.WithLabelKeysToCopy(cfg.Integrations.LabelKeysToCopy),
 		jobframework.WithCache(cCache),
 		jobframework.WithQueues(queues),
+		jobframework.WithAPIReader(mgr.GetAPIReader()),
+		jobframework.WithIntegrationControllers(cfg.Integrations.Controllers),
+		jobframework.WithLocalQueueAuthorizer(localQueueAuthorizer),
+		jobframework.WithSubmitterRecorder(submitterRecorder),
+		jobframework.WithDeviceReadiness(cfg.DeviceReadiness),
+		jobframework.WithExternalResumePolicy(cfg.Integrations.ExternalResumePolicy),
+	}
+	if features.Enabled(features.IntegrationScoping) {
+		opts = append(opts, jobframework.WithIntegrationScopes(jobframework.NewIntegrationScopes()))
 	}
+	var nsSelector *jobframework.ReloadableSelector
 	if features.Enabled(features.ManagedJobsNamespaceSelector) {
-		nsSelector, err := metav1.LabelSelectorAsSelector(cfg.ManagedJobsNamespaceSelector)
+		selector, err := metav1.LabelSelectorAsSelector(cfg.ManagedJobsNamespaceSelector)
 		if err != nil {
 			setupLog.Error(err, "Failed to parse managedJobsNamespaceSelector")
 			os.Exit(1)
 		}
+		nsSelector = jobframework.NewReloadableSelector(selector)
 		opts = append(opts, jobframework.WithManagedJobsNamespaceSelector(nsSelector))
 	}
+	if cfgWatcher != nil {
+		if err := cfgWatcher.Register("integrations", func(cfg *configapi.Configuration) error {
+			if nsSelector != nil {
+				selector, err := metav1.LabelSelectorAsSelector(cfg.ManagedJobsNamespaceSelector)
+				if err != nil {
+					return err
+				}
+				nsSelector.Set(selector)
+			}
+			waitForPodsReady.Store(config.WaitForPodsReadyIsEnabled(cfg))
+			return nil
+		}); err != nil {
+			setupLog.Error(err, "Unable to register the integrations in the configuration watcher")
+			os.Exit(1)
+		}
+	}
 
 	if err := jobframework.SetupControllers(ctx, mgr, setupLog, opts...); err != nil {
 		setupLog.Error(err, "Unable to create controller or webhook", "kubernetesVersion", serverVersionFetcher.GetServerVersion())
 		os.Exit(1)
 	}
+
+	if len(cfg.Integrations.Webhooks) > 0 {
+		updater := jobframework.NewWebhookConfigurationUpdater(mgr.GetClient(), cert.MutatingWebhookConfigurationName, cert.ValidatingWebhookConfigurationName, cfg.Integrations.Webhooks)
+		if err := mgr.Add(updater); err != nil {
+			setupLog.Error(err, "Unable to add the webhook configuration updater to manager")
+			os.Exit(1)
+		}
+	}
 }
 
 // setupProbeEndpoints registers the health endpoints
@@ -350,19 +519,92 @@ func setupProbeEndpoints(mgr ctrl.Manager, certsReady <-chan struct{}) {
 	}
 }
 
-func setupScheduler(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager, cfg *configapi.Configuration) {
+func setupScheduler(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager, cfg *configapi.Configuration, cfgWatcher *config.Watcher) *scheduler.Scheduler {
+	opts := []scheduler.Option{
+		scheduler.WithPodsReadyRequeuingTimestamp(podsReadyRequeuingTimestamp(cfg)),
+		scheduler.WithFairSharing(cfg.FairSharing),
+		scheduler.WithPriorityDecay(cfg.PriorityDecay),
+	}
+	if cfg.QueueingOrder != nil {
+		opts = append(opts, scheduler.WithTieBreakers(cfg.QueueingOrder.TieBreakers))
+	}
+	if len(cfg.AdmissionPolicies) > 0 {
+		policies, err := admissionpolicy.Compile(admissionpolicy.FromConfig(cfg.AdmissionPolicies))
+		if err != nil {
+			setupLog.Error(err, "Unable to compile the admission policies")
+			os.Exit(1)
+		}
+		opts = append(opts, scheduler.WithAdmissionPolicies(policies))
+	}
+	if cfg.SchedulingAudit != nil {
+		sink, err := audit.NewSink(cfg.SchedulingAudit)
+		if err != nil {
+			setupLog.Error(err, "Unable to set up the scheduling audit")
+			os.Exit(1)
+		}
+		if err := mgr.Add(sink); err != nil {
+			setupLog.Error(err, "Unable to add the scheduling audit to manager")
+			os.Exit(1)
+		}
+		opts = append(opts, scheduler.WithAuditRecorder(sink))
+	}
 	sched := scheduler.New(
 		queues,
 		cCache,
 		mgr.GetClient(),
 		mgr.GetEventRecorderFor(constants.AdmissionName),
-		scheduler.WithPodsReadyRequeuingTimestamp(podsReadyRequeuingTimestamp(cfg)),
-		scheduler.WithFairSharing(cfg.FairSharing),
+		opts...,
 	)
 	if err := mgr.Add(sched); err != nil {
 		setupLog.Error(err, "Unable to add scheduler to manager")
 		os.Exit(1)
 	}
+	if cfgWatcher != nil {
+		if err := cfgWatcher.Register("scheduler", func(cfg *configapi.Configuration) error {
+			sched.SetFairSharing(cfg.FairSharing)
+			sched.SetPriorityDecay(cfg.PriorityDecay)
+			return nil
+		}); err != nil {
+			setupLog.Error(err, "Unable to register the scheduler in the configuration watcher")
+			os.Exit(1)
+		}
+	}
+	return sched
+}
+
+// setupQueueingReconfiguration registers the cache and the queues in the
+// configuration watcher.
+func setupQueueingReconfiguration(cfgWatcher *config.Watcher, cCache *cache.Cache, queues *queue.Manager) {
+	if err := cfgWatcher.Register("cache", func(cfg *configapi.Configuration) error {
+		cCache.SetPodsReadyTracking(blockForPodsReady(cfg))
+		cCache.SetWaitForPodsReady(config.WaitForPodsReadyIsEnabled(cfg))
+		cCache.SetWorkloadInfoOptions(workloadInfoOptions(cfg)...)
+		cCache.SetFairSharing(cfg.FairSharing != nil && cfg.FairSharing.Enable)
+		return nil
+	}); err != nil {
+		setupLog.Error(err, "Unable to register the cache in the configuration watcher")
+		os.Exit(1)
+	}
+	if err := cfgWatcher.Register("queues", func(cfg *configapi.Configuration) error {
+		queues.SetWorkloadInfoOptions(workloadInfoOptions(cfg)...)
+		return nil
+	}); err != nil {
+		setupLog.Error(err, "Unable to register the queues in the configuration watcher")
+		os.Exit(1)
+	}
+}
+
+// workloadInfoOptions returns the options to compute the resources of the
+// workloads, matching the ones set up for the cache and the queues.
+func workloadInfoOptions(cfg *configapi.Configuration) []workload.InfoOption {
+	var opts []workload.InfoOption
+	if cfg.Resources != nil && len(cfg.Resources.ExcludeResourcePrefixes) > 0 {
+		opts = append(opts, workload.WithExcludedResourcePrefixes(cfg.Resources.ExcludeResourcePrefixes))
+	}
+	if features.Enabled(features.ConfigurableResourceTransformations) && cfg.Resources != nil && len(cfg.Resources.Transformations) > 0 {
+		opts = append(opts, workload.WithResourceTransformations(cfg.Resources.Transformations))
+	}
+	return opts
 }
 
 func setupServerVersionFetcher(mgr ctrl.Manager, kubeConfig *rest.Config) *kubeversion.ServerVersionFetcher {
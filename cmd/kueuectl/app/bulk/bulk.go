@@ -0,0 +1,343 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	kueuev1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+var (
+	bulkExample = templates.Examples(`
+		# Deactivate the pending workloads of a clusterqueue, in all namespaces
+		kueuectl bulk deactivate --clusterqueue my-clusterqueue --status pending --all-namespaces
+	`)
+)
+
+func NewBulkCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams, clock clock.Clock) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "bulk",
+		Short:   "Apply an operation to all the Workloads matching the filters",
+		Example: bulkExample,
+	}
+
+	cmd.AddCommand(NewDeleteCmd(clientGetter, streams, clock))
+	cmd.AddCommand(NewRequeueCmd(clientGetter, streams, clock))
+	cmd.AddCommand(NewDeactivateCmd(clientGetter, streams, clock))
+
+	return cmd
+}
+
+// Options are the options shared by the bulk operations.
+type Options struct {
+	LocalQueue    string
+	ClusterQueue  string
+	Statuses      []string
+	OlderThan     time.Duration
+	OwnerKind     string
+	LabelSelector string
+	Namespace     string
+	AllNamespaces bool
+	Confirmed     bool
+	// Timeout is the time to wait for the Workloads to be evicted, when requeueing them.
+	Timeout time.Duration
+
+	DryRunStrategy util.DryRunStrategy
+
+	Client        kueuev1beta1.KueueV1beta1Interface
+	DynamicClient dynamic.Interface
+	RestMapper    meta.RESTMapper
+
+	Clock clock.Clock
+
+	// verb and pastVerb name the operation in the messages.
+	verb     string
+	pastVerb string
+	// skipReason explains why the operation doesn't apply to the Workload, if it doesn't.
+	skipReason func(wl *v1beta1.Workload) string
+	// apply applies the operation to the Workloads, printing the result for each of them.
+	apply func(ctx context.Context, wls []*v1beta1.Workload) (int, error)
+
+	genericiooptions.IOStreams
+}
+
+func newOptions(streams genericiooptions.IOStreams, clock clock.Clock, verb, pastVerb string) *Options {
+	return &Options{
+		IOStreams: streams,
+		Clock:     clock,
+		verb:      verb,
+		pastVerb:  pastVerb,
+	}
+}
+
+func newCmd(clientGetter util.ClientGetter, o *Options, use, short, long, example string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: use + " [--localqueue NAME] [--clusterqueue NAME] [--status STATUS] [--older-than DURATION] " +
+			"[--owner-kind KIND] [--selector key1=value1] [--all-namespaces] [--yes] [--dry-run STRATEGY]",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Short:                 short,
+		Long:                  long,
+		Example:               example,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter, cmd)
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.LocalQueue, "localqueue", "q", "",
+		"Filter by the name of the LocalQueue of the workloads.")
+	cmd.Flags().StringVarP(&o.ClusterQueue, "clusterqueue", "c", "",
+		"Filter by the name of the ClusterQueue of the workloads.")
+	cmd.Flags().StringArrayVar(&o.Statuses, "status", nil,
+		`Filter by the status of the workloads. Must be "pending", "quotareserved", "admitted" or "finished".`)
+	cmd.Flags().DurationVar(&o.OlderThan, "older-than", 0,
+		"Filter the workloads created longer ago than the duration.")
+	cmd.Flags().StringVar(&o.OwnerKind, "owner-kind", "",
+		"Filter by the kind of the job owning the workloads, for example Job or RayJob.")
+	cmd.Flags().StringVarP(&o.LabelSelector, "selector", "l", "",
+		"Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2). Matching objects must satisfy all of the specified label constraints.")
+	cmd.Flags().BoolVarP(&o.Confirmed, "yes", "y", false,
+		"Automatic yes to the prompt for applying the operation.")
+	util.AddAllNamespacesFlagVar(cmd, &o.AllNamespaces)
+	util.AddDryRunFlag(cmd)
+
+	cobra.CheckErr(cmd.RegisterFlagCompletionFunc("clusterqueue", completion.ClusterQueueNameFunc(clientGetter, nil)))
+	cobra.CheckErr(cmd.RegisterFlagCompletionFunc("localqueue", completion.LocalQueueNameFunc(clientGetter, nil)))
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *Options) Complete(clientGetter util.ClientGetter, cmd *cobra.Command) error {
+	for i, status := range o.Statuses {
+		switch strings.ToLower(status) {
+		case workload.StatusPending, workload.StatusAdmitted, workload.StatusFinished:
+			o.Statuses[i] = strings.ToLower(status)
+		case strings.ToLower(workload.StatusQuotaReserved):
+			o.Statuses[i] = workload.StatusQuotaReserved
+		default:
+			return fmt.Errorf(`Invalid status value (%v). Must be "pending", "quotareserved", "admitted" or "finished".`, status)
+		}
+	}
+
+	var err error
+
+	o.Namespace, _, err = clientGetter.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	o.DryRunStrategy, err = util.GetDryRunStrategy(cmd)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.Client = clientset.KueueV1beta1()
+
+	o.DynamicClient, err = clientGetter.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	o.RestMapper, err = clientGetter.ToRESTMapper()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run applies the operation to the matching Workloads
+func (o *Options) Run(ctx context.Context) error {
+	workloads, err := o.matchingWorkloads(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(workloads) == 0 {
+		if !o.AllNamespaces {
+			fmt.Fprintf(o.ErrOut, "No resources found in %s namespace.\n", o.Namespace)
+		} else {
+			fmt.Fprintln(o.ErrOut, "No resources found")
+		}
+		return nil
+	}
+
+	var todo []*v1beta1.Workload
+	skipped := 0
+	for _, wl := range workloads {
+		if reason := o.skipReason(wl); reason != "" {
+			fmt.Fprintf(o.Out, "workload %s skipped, %s\n", workload.Key(wl), reason)
+			skipped++
+			continue
+		}
+		todo = append(todo, wl)
+	}
+
+	if len(todo) > 0 && o.DryRunStrategy == util.DryRunNone && !o.Confirmed {
+		if !o.confirmation(fmt.Sprintf("This operation will %s %d workloads.\n", o.verb, len(todo))) {
+			fmt.Fprintln(o.Out, "Operation is canceled")
+			return nil
+		}
+	}
+
+	done, err := o.apply(ctx, todo)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "%d workloads %s, %d skipped%s\n", done, o.pastVerb, skipped+len(todo)-done, o.dryRunSuffix())
+	return nil
+}
+
+// matchingWorkloads returns the Workloads matching all the filters, sorted by key.
+func (o *Options) matchingWorkloads(ctx context.Context) ([]*v1beta1.Workload, error) {
+	namespace := o.Namespace
+	if o.AllNamespaces {
+		namespace = ""
+	}
+
+	list, err := o.Client.Workloads(namespace).List(ctx, metav1.ListOptions{LabelSelector: o.LabelSelector})
+	if err != nil {
+		return nil, err
+	}
+
+	// The ClusterQueue of the pending Workloads is the one of their LocalQueue.
+	clusterQueues := make(map[string]string)
+	if o.ClusterQueue != "" {
+		lqs, err := o.Client.LocalQueues(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, lq := range lqs.Items {
+			clusterQueues[lq.Namespace+"/"+lq.Name] = string(lq.Spec.ClusterQueue)
+		}
+	}
+
+	statuses := sets.New(o.Statuses...)
+	now := o.Clock.Now()
+
+	var workloads []*v1beta1.Workload
+	for i := range list.Items {
+		wl := &list.Items[i]
+		if o.LocalQueue != "" && wl.Spec.QueueName != o.LocalQueue {
+			continue
+		}
+		if o.ClusterQueue != "" {
+			cq := clusterQueues[wl.Namespace+"/"+wl.Spec.QueueName]
+			if wl.Status.Admission != nil {
+				cq = string(wl.Status.Admission.ClusterQueue)
+			}
+			if cq != o.ClusterQueue {
+				continue
+			}
+		}
+		if statuses.Len() > 0 && !statuses.Has(workload.Status(wl)) {
+			continue
+		}
+		if o.OlderThan > 0 && now.Sub(wl.CreationTimestamp.Time) < o.OlderThan {
+			continue
+		}
+		if o.OwnerKind != "" && !slices.ContainsFunc(wl.OwnerReferences, func(owner metav1.OwnerReference) bool {
+			return strings.EqualFold(owner.Kind, o.OwnerKind)
+		}) {
+			continue
+		}
+		workloads = append(workloads, wl)
+	}
+
+	slices.SortFunc(workloads, func(a, b *v1beta1.Workload) int {
+		return strings.Compare(workload.Key(a), workload.Key(b))
+	})
+	return workloads, nil
+}
+
+func (o *Options) confirmation(message string) bool {
+	fmt.Fprint(o.Out, message, "Do you want to proceed (y/n)? ")
+
+	var input string
+
+	_, err := fmt.Fscan(o.In, &input)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(input, "y")
+}
+
+func (o *Options) setActive(ctx context.Context, wl *v1beta1.Workload, active bool) error {
+	wlOriginal := wl.DeepCopy()
+	wl.Spec.Active = ptr.To(active)
+	data, err := client.MergeFrom(wlOriginal).Data(wl)
+	if err != nil {
+		return err
+	}
+	_, err = o.Client.Workloads(wl.Namespace).Patch(ctx, wl.Name, types.MergePatchType, data, o.patchOptions())
+	return err
+}
+
+func (o *Options) patchOptions() metav1.PatchOptions {
+	opts := metav1.PatchOptions{}
+	if o.DryRunStrategy == util.DryRunServer {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	return opts
+}
+
+func (o *Options) dryRunSuffix() string {
+	switch o.DryRunStrategy {
+	case util.DryRunClient:
+		return " (client dry run)"
+	case util.DryRunServer:
+		return " (server dry run)"
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bulk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+var (
+	deactivateLong = templates.LongDesc(`
+		Deactivates all the Workloads matching the filters. Kueue evicts the
+		deactivated Workloads which have quota reserved, and doesn't admit
+		them until they are activated again, with "kueuectl resume workload".
+
+		The finished Workloads are skipped.
+	`)
+	deactivateExample = templates.Examples(`
+		# Deactivate the pending workloads of a clusterqueue, in all namespaces
+		kueuectl bulk deactivate --clusterqueue my-clusterqueue --status pending --all-namespaces
+
+		# Print the workloads of a localqueue created more than a day ago which would be deactivated
+		kueuectl bulk deactivate --localqueue my-localqueue --older-than 24h --dry-run client
+	`)
+)
+
+func NewDeactivateCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams, clock clock.Clock) *cobra.Command {
+	o := newOptions(streams, clock, "deactivate", "deactivated")
+	o.skipReason = deactivateSkipReason
+	o.apply = o.deactivateWorkloads
+
+	return newCmd(clientGetter, o, "deactivate", "Deactivate the Workloads matching the filters", deactivateLong, deactivateExample)
+}
+
+func deactivateSkipReason(wl *v1beta1.Workload) string {
+	switch {
+	case workload.IsFinished(wl):
+		return "it is finished"
+	case !ptr.Deref(wl.Spec.Active, true):
+		return "it is already deactivated"
+	}
+	return ""
+}
+
+func (o *Options) deactivateWorkloads(ctx context.Context, wls []*v1beta1.Workload) (int, error) {
+	for i, wl := range wls {
+		if o.DryRunStrategy != util.DryRunClient {
+			if err := o.setActive(ctx, wl, false); err != nil {
+				return i, fmt.Errorf("deactivating workload %s: %w", workload.Key(wl), err)
+			}
+		}
+		fmt.Fprintf(o.Out, "workload %s deactivated%s\n", workload.Key(wl), o.dryRunSuffix())
+	}
+	return len(wls), nil
+}
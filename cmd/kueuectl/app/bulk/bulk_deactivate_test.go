@@ -0,0 +1,234 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bulk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestDeactivateCmd(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	objs := []runtime.Object{
+		utiltesting.MakeLocalQueue("lq-a", metav1.NamespaceDefault).ClusterQueue("cq-a").Obj(),
+		utiltesting.MakeLocalQueue("lq-b", metav1.NamespaceDefault).ClusterQueue("cq-b").Obj(),
+		utiltesting.MakeLocalQueue("lq-a", "other").ClusterQueue("cq-a").Obj(),
+		utiltesting.MakeWorkload("pending", metav1.NamespaceDefault).
+			OwnerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "pending", "test-uid").
+			Queue("lq-a").
+			Creation(now.Add(-2 * time.Hour)).
+			Obj(),
+		utiltesting.MakeWorkload("admitted", metav1.NamespaceDefault).
+			OwnerReference(rayv1.GroupVersion.WithKind("RayJob"), "admitted", "test-uid").
+			Queue("lq-b").
+			ReserveQuota(utiltesting.MakeAdmission("cq-a").Obj()).
+			Admitted(true).
+			Creation(now.Add(-time.Hour)).
+			Obj(),
+		utiltesting.MakeWorkload("finished", metav1.NamespaceDefault).
+			Queue("lq-a").
+			Finished().
+			Creation(now.Add(-3 * time.Hour)).
+			Obj(),
+		utiltesting.MakeWorkload("inactive", metav1.NamespaceDefault).
+			Queue("lq-a").
+			Active(false).
+			Creation(now.Add(-3 * time.Hour)).
+			Obj(),
+		utiltesting.MakeWorkload("pending", "other").
+			Queue("lq-a").
+			Creation(now.Add(-3 * time.Hour)).
+			Obj(),
+	}
+
+	testCases := map[string]struct {
+		args       []string
+		in         string
+		wantActive map[string]bool
+		wantOut    string
+		wantOutErr string
+		wantErr    string
+	}{
+		"should deactivate the workloads in the namespace": {
+			args: []string{"--yes"},
+			wantActive: map[string]bool{
+				"default/admitted": false,
+				"default/finished": true,
+				"default/inactive": false,
+				"default/pending":  false,
+				"other/pending":    true,
+			},
+			wantOut: `workload default/finished skipped, it is finished
+workload default/inactive skipped, it is already deactivated
+workload default/admitted deactivated
+workload default/pending deactivated
+2 workloads deactivated, 2 skipped
+`,
+		},
+		"should filter by clusterqueue in all namespaces": {
+			args: []string{"--clusterqueue", "cq-a", "--status", "pending", "--all-namespaces", "--yes"},
+			wantActive: map[string]bool{
+				"default/admitted": true,
+				"default/finished": true,
+				"default/inactive": false,
+				"default/pending":  false,
+				"other/pending":    false,
+			},
+			wantOut: `workload default/inactive skipped, it is already deactivated
+workload default/pending deactivated
+workload other/pending deactivated
+2 workloads deactivated, 1 skipped
+`,
+		},
+		"should filter by localqueue, age and owner kind": {
+			args: []string{"--localqueue", "lq-a", "--older-than", "90m", "--owner-kind", "job", "--yes"},
+			wantActive: map[string]bool{
+				"default/admitted": true,
+				"default/finished": true,
+				"default/inactive": false,
+				"default/pending":  false,
+				"other/pending":    true,
+			},
+			wantOut: `workload default/pending deactivated
+1 workloads deactivated, 0 skipped
+`,
+		},
+		"should filter by status": {
+			args: []string{"--status", "admitted", "--status", "QuotaReserved", "--dry-run", "client"},
+			wantActive: map[string]bool{
+				"default/admitted": true,
+				"default/finished": true,
+				"default/inactive": false,
+				"default/pending":  true,
+				"other/pending":    true,
+			},
+			wantOut: `workload default/admitted deactivated (client dry run)
+1 workloads deactivated, 0 skipped (client dry run)
+`,
+		},
+		"should ask for confirmation": {
+			args: []string{"--localqueue", "lq-b"},
+			in:   "y\n",
+			wantActive: map[string]bool{
+				"default/admitted": false,
+				"default/finished": true,
+				"default/inactive": false,
+				"default/pending":  true,
+				"other/pending":    true,
+			},
+			wantOut: `This operation will deactivate 1 workloads.
+Do you want to proceed (y/n)? workload default/admitted deactivated
+1 workloads deactivated, 0 skipped
+`,
+		},
+		"should cancel the operation": {
+			args: []string{"--localqueue", "lq-b"},
+			in:   "n\n",
+			wantActive: map[string]bool{
+				"default/admitted": true,
+				"default/finished": true,
+				"default/inactive": false,
+				"default/pending":  true,
+				"other/pending":    true,
+			},
+			wantOut: `This operation will deactivate 1 workloads.
+Do you want to proceed (y/n)? Operation is canceled
+`,
+		},
+		"should not find workloads": {
+			args: []string{"--localqueue", "invalid"},
+			wantActive: map[string]bool{
+				"default/admitted": true,
+				"default/finished": true,
+				"default/inactive": false,
+				"default/pending":  true,
+				"other/pending":    true,
+			},
+			wantOutErr: "No resources found in default namespace.\n",
+		},
+		"should fail with invalid status": {
+			args:    []string{"--status", "running"},
+			wantErr: `Invalid status value (running). Must be "pending", "quotareserved", "admitted" or "finished".`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			streams, in, out, outErr := genericiooptions.NewTestIOStreams()
+			in.WriteString(tc.in)
+
+			clientset := fake.NewSimpleClientset(objs...)
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(clientset).
+				WithDynamicClient(dynamicfake.NewSimpleDynamicClient(k8sscheme.Scheme)).
+				WithRESTMapper(meta.NewDefaultRESTMapper([]schema.GroupVersion{}))
+
+			cmd := NewDeactivateCmd(tcg, streams, testingclock.NewFakeClock(now))
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+			if gotErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantOutErr, outErr.String()); diff != "" {
+				t.Errorf("Unexpected error output (-want/+got)\n%s", diff)
+			}
+
+			wls, err := clientset.KueueV1beta1().Workloads("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotActive := make(map[string]bool, len(wls.Items))
+			for _, wl := range wls.Items {
+				gotActive[workload.Key(&wl)] = ptr.Deref(wl.Spec.Active, true)
+			}
+			if diff := cmp.Diff(tc.wantActive, gotActive); diff != "" {
+				t.Errorf("Unexpected workload activation (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
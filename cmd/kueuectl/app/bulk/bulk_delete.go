@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bulk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+var (
+	deleteLong = templates.LongDesc(`
+		Deletes all the Workloads matching the filters, together with the jobs
+		owning them. The Workloads which are owned by jobs are deleted by the
+		garbage collector, once their jobs are deleted.
+	`)
+	deleteExample = templates.Examples(`
+		# Delete the finished workloads created more than a week ago, and their jobs
+		kueuectl bulk delete --status finished --older-than 168h --all-namespaces
+
+		# Print the RayJob workloads of a localqueue which would be deleted
+		kueuectl bulk delete --localqueue my-localqueue --owner-kind RayJob --dry-run client
+	`)
+)
+
+func NewDeleteCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams, clock clock.Clock) *cobra.Command {
+	o := newOptions(streams, clock, "delete", "deleted")
+	o.skipReason = func(*v1beta1.Workload) string { return "" }
+	o.apply = o.deleteWorkloads
+
+	return newCmd(clientGetter, o, "delete", "Delete the Workloads matching the filters and their jobs", deleteLong, deleteExample)
+}
+
+func (o *Options) deleteWorkloads(ctx context.Context, wls []*v1beta1.Workload) (int, error) {
+	for i, wl := range wls {
+		if o.DryRunStrategy != util.DryRunClient {
+			if err := o.deleteWorkload(ctx, wl); err != nil {
+				return i, fmt.Errorf("deleting workload %s: %w", workload.Key(wl), err)
+			}
+		}
+		fmt.Fprintf(o.Out, "workload %s deleted%s\n", workload.Key(wl), o.dryRunSuffix())
+	}
+	return len(wls), nil
+}
+
+// deleteWorkload deletes the owners of the Workload, or the Workload itself
+// if it has no owner.
+func (o *Options) deleteWorkload(ctx context.Context, wl *v1beta1.Workload) error {
+	deleteOptions := metav1.DeleteOptions{
+		PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+	}
+	if o.DryRunStrategy == util.DryRunServer {
+		deleteOptions.DryRun = []string{metav1.DryRunAll}
+	}
+
+	if len(wl.OwnerReferences) == 0 {
+		return client.IgnoreNotFound(o.Client.Workloads(wl.Namespace).Delete(ctx, wl.Name, deleteOptions))
+	}
+
+	for _, owner := range wl.OwnerReferences {
+		gv, err := schema.ParseGroupVersion(owner.APIVersion)
+		if err != nil {
+			return err
+		}
+		mapping, err := o.RestMapper.RESTMapping(gv.WithKind(owner.Kind).GroupKind(), gv.Version)
+		if err != nil {
+			return err
+		}
+		err = o.DynamicClient.Resource(mapping.Resource).Namespace(wl.Namespace).Delete(ctx, owner.Name, deleteOptions)
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
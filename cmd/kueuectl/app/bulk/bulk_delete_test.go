@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bulk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	testingclock "k8s.io/utils/clock/testing"
+
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestDeleteCmd(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	jobGVK := batchv1.SchemeGroupVersion.WithKind("Job")
+	makeJob := func(name string) *batchv1.Job {
+		return &batchv1.Job{
+			TypeMeta:   metav1.TypeMeta{Kind: "Job", APIVersion: "batch/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+		}
+	}
+	objs := []runtime.Object{
+		utiltesting.MakeWorkload("wl-old", metav1.NamespaceDefault).
+			OwnerReference(jobGVK, "job-old", "test-uid").
+			Queue("lq").
+			Finished().
+			Creation(now.Add(-48 * time.Hour)).
+			Obj(),
+		utiltesting.MakeWorkload("wl-new", metav1.NamespaceDefault).
+			OwnerReference(jobGVK, "job-new", "test-uid").
+			Queue("lq").
+			Finished().
+			Creation(now.Add(-time.Hour)).
+			Obj(),
+		utiltesting.MakeWorkload("wl-orphan", metav1.NamespaceDefault).
+			Queue("lq").
+			Finished().
+			Creation(now.Add(-48 * time.Hour)).
+			Obj(),
+		utiltesting.MakeWorkload("wl-pending", metav1.NamespaceDefault).
+			Queue("lq").
+			Creation(now.Add(-48 * time.Hour)).
+			Obj(),
+	}
+	jobs := []runtime.Object{makeJob("job-old"), makeJob("job-new")}
+
+	testCases := map[string]struct {
+		args          []string
+		wantWorkloads []string
+		wantJobs      []string
+		wantOut       string
+	}{
+		"should delete the old finished workloads and their jobs": {
+			args:          []string{"--status", "finished", "--older-than", "24h", "--yes"},
+			wantWorkloads: []string{"default/wl-new", "default/wl-old", "default/wl-pending"},
+			wantJobs:      []string{"default/job-new"},
+			wantOut: `workload default/wl-old deleted
+workload default/wl-orphan deleted
+2 workloads deleted, 0 skipped
+`,
+		},
+		"should not delete in client dry run": {
+			args:          []string{"--status", "finished", "--dry-run", "client"},
+			wantWorkloads: []string{"default/wl-new", "default/wl-old", "default/wl-orphan", "default/wl-pending"},
+			wantJobs:      []string{"default/job-new", "default/job-old"},
+			wantOut: `workload default/wl-new deleted (client dry run)
+workload default/wl-old deleted (client dry run)
+workload default/wl-orphan deleted (client dry run)
+3 workloads deleted, 0 skipped (client dry run)
+`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+
+			clientset := fake.NewSimpleClientset(objs...)
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(k8sscheme.Scheme, jobs...)
+			restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{})
+			restMapper.Add(jobGVK, meta.RESTScopeNamespace)
+
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(clientset).
+				WithDynamicClient(dynamicClient).
+				WithRESTMapper(restMapper)
+
+			cmd := NewDeleteCmd(tcg, streams, testingclock.NewFakeClock(now))
+			cmd.SetArgs(tc.args)
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			wls, err := clientset.KueueV1beta1().Workloads("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotWorkloads []string
+			for _, wl := range wls.Items {
+				gotWorkloads = append(gotWorkloads, workload.Key(&wl))
+			}
+			if diff := cmp.Diff(tc.wantWorkloads, gotWorkloads, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("Unexpected workloads (-want/+got)\n%s", diff)
+			}
+
+			mapping, err := restMapper.RESTMapping(jobGVK.GroupKind(), jobGVK.Version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			jobList, err := dynamicClient.Resource(mapping.Resource).Namespace("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotJobs []string
+			for _, job := range jobList.Items {
+				gotJobs = append(gotJobs, job.GetNamespace()+"/"+job.GetName())
+			}
+			if diff := cmp.Diff(tc.wantJobs, gotJobs, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("Unexpected jobs (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// pollInterval is the interval at which the eviction of the requeued workloads is checked.
+var pollInterval = time.Second
+
+const defaultRequeueTimeout = 5 * time.Minute
+
+var (
+	requeueLong = templates.LongDesc(`
+		Requeues all the Workloads matching the filters which have quota
+		reserved. The Workloads are deactivated until Kueue evicts them, and
+		activated again, so that they go back to their queue, releasing their
+		quota, and their jobs are suspended until they are admitted again.
+
+		The Workloads without quota reserved, the finished and the deactivated
+		Workloads are skipped.
+	`)
+	requeueExample = templates.Examples(`
+		# Requeue the workloads admitted in a clusterqueue, in all namespaces
+		kueuectl bulk requeue --clusterqueue my-clusterqueue --all-namespaces
+	`)
+)
+
+func NewRequeueCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams, clock clock.Clock) *cobra.Command {
+	o := newOptions(streams, clock, "requeue", "requeued")
+	o.skipReason = requeueSkipReason
+	o.apply = o.requeueWorkloads
+
+	cmd := newCmd(clientGetter, o, "requeue", "Requeue the Workloads matching the filters", requeueLong, requeueExample)
+	cmd.Use += " [--timeout DURATION]"
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", defaultRequeueTimeout,
+		"The length of time to wait for the workloads to be evicted.")
+
+	return cmd
+}
+
+func requeueSkipReason(wl *v1beta1.Workload) string {
+	switch {
+	case workload.IsFinished(wl):
+		return "it is finished"
+	case !ptr.Deref(wl.Spec.Active, true):
+		return "it is deactivated"
+	case !workload.HasQuotaReservation(wl):
+		return "it has no quota reserved"
+	}
+	return ""
+}
+
+// requeueWorkloads deactivates the Workloads, and activates each of them
+// again once it is evicted.
+func (o *Options) requeueWorkloads(ctx context.Context, wls []*v1beta1.Workload) (int, error) {
+	if o.DryRunStrategy != util.DryRunNone {
+		for _, wl := range wls {
+			if o.DryRunStrategy == util.DryRunServer {
+				if err := o.setActive(ctx, wl, false); err != nil {
+					return 0, fmt.Errorf("deactivating workload %s: %w", workload.Key(wl), err)
+				}
+			}
+			fmt.Fprintf(o.Out, "workload %s requeued%s\n", workload.Key(wl), o.dryRunSuffix())
+		}
+		return len(wls), nil
+	}
+
+	for i, wl := range wls {
+		if err := o.setActive(ctx, wl, false); err != nil {
+			return 0, fmt.Errorf("deactivating workload %s: %w, %d workloads stay deactivated", workload.Key(wl), err, i)
+		}
+	}
+
+	requeued := 0
+	evicting := wls
+	waitCtx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+	err := wait.PollUntilContextCancel(waitCtx, pollInterval, true, func(ctx context.Context) (bool, error) {
+		var remaining []*v1beta1.Workload
+		for _, wl := range evicting {
+			wl, err := o.Client.Workloads(wl.Namespace).Get(ctx, wl.Name, metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			if workload.HasQuotaReservation(wl) {
+				remaining = append(remaining, wl)
+				continue
+			}
+			if err := o.setActive(ctx, wl, true); err != nil {
+				return false, fmt.Errorf("activating workload %s: %w", workload.Key(wl), err)
+			}
+			fmt.Fprintf(o.Out, "workload %s requeued\n", workload.Key(wl))
+			requeued++
+		}
+		evicting = remaining
+		return len(evicting) == 0, nil
+	})
+	if err != nil {
+		if errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+			keys := make([]string, 0, len(evicting))
+			for _, wl := range evicting {
+				keys = append(keys, workload.Key(wl))
+			}
+			return requeued, fmt.Errorf("timed out waiting for the workloads to be evicted, they stay deactivated: %s", strings.Join(keys, ", "))
+		}
+		return requeued, err
+	}
+	return requeued, nil
+}
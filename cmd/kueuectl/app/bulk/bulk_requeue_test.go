@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bulk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	kubetesting "k8s.io/client-go/testing"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// wlState is the activation and quota reservation of a Workload.
+type wlState struct {
+	Active        bool
+	QuotaReserved bool
+}
+
+func TestRequeueCmd(t *testing.T) {
+	defer func(interval time.Duration) { pollInterval = interval }(pollInterval)
+	pollInterval = time.Millisecond
+
+	now := time.Now().Truncate(time.Second)
+	objs := []runtime.Object{
+		utiltesting.MakeWorkload("admitted", metav1.NamespaceDefault).
+			Queue("lq").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+			Admitted(true).
+			Obj(),
+		utiltesting.MakeWorkload("stuck", metav1.NamespaceDefault).
+			Queue("lq").
+			Label("evict", "false").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+			Obj(),
+		utiltesting.MakeWorkload("pending", metav1.NamespaceDefault).
+			Queue("lq").
+			Obj(),
+	}
+
+	testCases := map[string]struct {
+		args      []string
+		wantState map[string]wlState
+		wantOut   string
+		wantErr   string
+	}{
+		"should requeue the workloads with quota reserved": {
+			args: []string{"--selector", "evict!=false", "--yes"},
+			wantState: map[string]wlState{
+				"default/admitted": {Active: true},
+				"default/pending":  {Active: true},
+				"default/stuck":    {Active: true, QuotaReserved: true},
+			},
+			wantOut: `workload default/pending skipped, it has no quota reserved
+workload default/admitted requeued
+1 workloads requeued, 1 skipped
+`,
+		},
+		"should keep deactivated the workloads which are not evicted": {
+			args: []string{"--timeout", "50ms", "--yes"},
+			wantState: map[string]wlState{
+				"default/admitted": {Active: true},
+				"default/pending":  {Active: true},
+				"default/stuck":    {Active: false, QuotaReserved: true},
+			},
+			wantOut: `workload default/pending skipped, it has no quota reserved
+workload default/admitted requeued
+`,
+			wantErr: "timed out waiting for the workloads to be evicted, they stay deactivated: default/stuck",
+		},
+		"should deactivate the workloads in server dry run": {
+			args: []string{"--dry-run", "server"},
+			wantState: map[string]wlState{
+				"default/admitted": {Active: true, QuotaReserved: true},
+				"default/pending":  {Active: true},
+				"default/stuck":    {Active: true, QuotaReserved: true},
+			},
+			wantOut: `workload default/pending skipped, it has no quota reserved
+workload default/admitted requeued (server dry run)
+workload default/stuck requeued (server dry run)
+2 workloads requeued, 1 skipped (server dry run)
+`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+
+			clientset := fake.NewSimpleClientset(objs...)
+			// The fake clientset doesn't support server dry run.
+			clientset.PrependReactor("patch", "workloads", func(action kubetesting.Action) (bool, runtime.Object, error) {
+				patchAction := action.(kubetesting.PatchActionImpl)
+				return len(patchAction.PatchOptions.DryRun) > 0, nil, nil
+			})
+			// Kueue evicts the deactivated workloads, releasing their quota,
+			// unless they are labeled to stay admitted.
+			clientset.PrependReactor("get", "workloads", func(action kubetesting.Action) (bool, runtime.Object, error) {
+				getAction := action.(kubetesting.GetAction)
+				obj, err := clientset.Tracker().Get(action.GetResource(), getAction.GetNamespace(), getAction.GetName())
+				if err != nil {
+					return true, nil, err
+				}
+				wl := obj.(*kueue.Workload)
+				if !ptr.Deref(wl.Spec.Active, true) && wl.Labels["evict"] != "false" {
+					wl.Status.Admission = nil
+					meta.RemoveStatusCondition(&wl.Status.Conditions, kueue.WorkloadQuotaReserved)
+					meta.RemoveStatusCondition(&wl.Status.Conditions, kueue.WorkloadAdmitted)
+					if err := clientset.Tracker().Update(action.GetResource(), wl, wl.Namespace); err != nil {
+						return true, nil, err
+					}
+				}
+				return true, wl, nil
+			})
+
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(clientset).
+				WithDynamicClient(dynamicfake.NewSimpleDynamicClient(k8sscheme.Scheme)).
+				WithRESTMapper(meta.NewDefaultRESTMapper([]schema.GroupVersion{}))
+
+			cmd := NewRequeueCmd(tcg, streams, testingclock.NewFakeClock(now))
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			wls, err := clientset.Tracker().List(kueue.SchemeGroupVersion.WithResource("workloads"), kueue.SchemeGroupVersion.WithKind("Workload"), "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotState := make(map[string]wlState)
+			for _, wl := range wls.(*kueue.WorkloadList).Items {
+				gotState[workload.Key(&wl)] = wlState{
+					Active:        ptr.Deref(wl.Spec.Active, true),
+					QuotaReserved: workload.HasQuotaReservation(&wl),
+				}
+			}
+			if diff := cmp.Diff(tc.wantState, gotState); diff != "" {
+				t.Errorf("Unexpected workloads state (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// bundleKind is a kind of object which is part of the capacity configuration.
+type bundleKind struct {
+	gvk      schema.GroupVersionKind
+	resource string
+	// newObject returns an empty typed object, used to validate the objects of the kind.
+	newObject func() runtime.Object
+}
+
+// bundleKinds are the kinds of objects in a bundle, in the order in which they are
+// exported and imported, so that the objects are created after the ones they reference.
+var bundleKinds = []bundleKind{
+	{
+		gvk:       v1beta1.SchemeGroupVersion.WithKind("ResourceFlavor"),
+		resource:  "resourceflavors",
+		newObject: func() runtime.Object { return &v1beta1.ResourceFlavor{} },
+	},
+	{
+		gvk:       v1beta1.SchemeGroupVersion.WithKind("AdmissionCheck"),
+		resource:  "admissionchecks",
+		newObject: func() runtime.Object { return &v1beta1.AdmissionCheck{} },
+	},
+	{
+		gvk:       v1alpha1.SchemeGroupVersion.WithKind("Cohort"),
+		resource:  "cohorts",
+		newObject: func() runtime.Object { return &v1alpha1.Cohort{} },
+	},
+	{
+		gvk:       v1beta1.SchemeGroupVersion.WithKind("ClusterQueue"),
+		resource:  "clusterqueues",
+		newObject: func() runtime.Object { return &v1beta1.ClusterQueue{} },
+	},
+}
+
+func (k *bundleKind) gvr() schema.GroupVersionResource {
+	return k.gvk.GroupVersion().WithResource(k.resource)
+}
+
+// objectName returns the name of the object in the kubectl format, for example
+// clusterqueue.kueue.x-k8s.io/my-clusterqueue.
+func (k *bundleKind) objectName(name string) string {
+	return strings.ToLower(k.gvk.Kind) + "." + k.gvk.Group + "/" + name
+}
+
+func kindOf(gvk schema.GroupVersionKind) *bundleKind {
+	for i := range bundleKinds {
+		if bundleKinds[i].gvk == gvk {
+			return &bundleKinds[i]
+		}
+	}
+	return nil
+}
+
+// portable returns a copy of the object without the fields which are specific
+// to the cluster it was read from, such as the status, the UID and the resource version.
+func portable(k *bundleKind, obj *unstructured.Unstructured) unstructured.Unstructured {
+	out := unstructured.Unstructured{Object: map[string]any{}}
+	out.SetGroupVersionKind(k.gvk)
+	out.SetName(obj.GetName())
+	if labels := obj.GetLabels(); len(labels) > 0 {
+		out.SetLabels(labels)
+	}
+	annotations := obj.GetAnnotations()
+	delete(annotations, corev1.LastAppliedConfigAnnotation)
+	if len(annotations) > 0 {
+		out.SetAnnotations(annotations)
+	}
+	if spec, found := obj.Object["spec"]; found {
+		out.Object["spec"] = runtime.DeepCopyJSONValue(spec)
+	}
+	return out
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+)
+
+var (
+	exportLong = templates.LongDesc(`
+		Exports the capacity configuration of the cluster, that is all the
+		ResourceFlavors, AdmissionChecks, Cohorts and ClusterQueues, as a single
+		List, for disaster recovery or to promote it to another environment.
+
+		The status and the fields which are specific to the cluster, such as the
+		UID and the resource version, are not exported. The bundle can be imported
+		into another cluster with the import command.
+	`)
+	exportExample = templates.Examples(`
+		# Export the capacity configuration as YAML
+		kueuectl export > capacity.yaml
+
+		# Export the capacity configuration as JSON
+		kueuectl export -o json > capacity.json
+	`)
+)
+
+var exportOutputFormats = []string{"yaml", "json"}
+
+type ExportOptions struct {
+	OutputFormat string
+
+	DynamicClient dynamic.Interface
+	Printer       printers.ResourcePrinter
+
+	genericiooptions.IOStreams
+}
+
+func NewExportOptions(streams genericiooptions.IOStreams) *ExportOptions {
+	return &ExportOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewExportCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewExportOptions(streams)
+
+	cmd := &cobra.Command{
+		Use: "export [--output FORMAT]",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Short:                 "Export the capacity configuration",
+		Long:                  exportLong,
+		Example:               exportExample,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter)
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.OutputFormat, "output", "o", "yaml",
+		fmt.Sprintf("Output format. One of: (%s).", strings.Join(exportOutputFormats, ", ")))
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *ExportOptions) Complete(clientGetter util.ClientGetter) error {
+	switch o.OutputFormat {
+	case "yaml":
+		o.Printer = &printers.YAMLPrinter{}
+	case "json":
+		o.Printer = &printers.JSONPrinter{}
+	default:
+		return fmt.Errorf("invalid output format %q, allowed formats are: %s", o.OutputFormat, strings.Join(exportOutputFormats, ", "))
+	}
+
+	var err error
+	o.DynamicClient, err = clientGetter.DynamicClient()
+	return err
+}
+
+// Run prints the bundle with the capacity configuration
+func (o *ExportOptions) Run(ctx context.Context) error {
+	bundle := &unstructured.UnstructuredList{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "List",
+	}}
+	for i := range bundleKinds {
+		k := &bundleKinds[i]
+		list, err := o.DynamicClient.Resource(k.gvr()).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			// The Cohorts API might not be installed.
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		items := list.Items
+		slices.SortFunc(items, func(a, b unstructured.Unstructured) int {
+			return strings.Compare(a.GetName(), b.GetName())
+		})
+		for j := range items {
+			bundle.Items = append(bundle.Items, portable(k, &items[j]))
+		}
+	}
+	return o.Printer.PrintObj(bundle, o.Out)
+}
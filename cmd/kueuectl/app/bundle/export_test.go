@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/scheme"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+)
+
+func newDynamicClient(objs ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	listKinds := make(map[schema.GroupVersionResource]string, len(bundleKinds))
+	for i := range bundleKinds {
+		listKinds[bundleKinds[i].gvr()] = bundleKinds[i].gvk.Kind + "List"
+	}
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme.Scheme, listKinds, objs...)
+}
+
+func TestExportCmd(t *testing.T) {
+	testCases := map[string]struct {
+		objs    []runtime.Object
+		args    []string
+		wantOut string
+		wantErr string
+	}{
+		"empty cluster": {
+			wantOut: `apiVersion: v1
+items: []
+kind: List
+`,
+		},
+		"all kinds": {
+			objs: []runtime.Object{
+				&v1beta1.ClusterQueue{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "cq",
+						UID:             "cq-uid",
+						ResourceVersion: "12",
+						Annotations: map[string]string{
+							corev1.LastAppliedConfigAnnotation: "{}",
+						},
+					},
+					Spec: v1beta1.ClusterQueueSpec{
+						Cohort:          "team",
+						AdmissionChecks: []string{"check"},
+						ResourceGroups: []v1beta1.ResourceGroup{{
+							CoveredResources: []corev1.ResourceName{corev1.ResourceCPU},
+							Flavors: []v1beta1.FlavorQuotas{{
+								Name: "default",
+								Resources: []v1beta1.ResourceQuota{{
+									Name:         corev1.ResourceCPU,
+									NominalQuota: resource.MustParse("10"),
+								}},
+							}},
+						}},
+					},
+					Status: v1beta1.ClusterQueueStatus{
+						AdmittedWorkloads: 3,
+					},
+				},
+				&v1alpha1.Cohort{
+					ObjectMeta: metav1.ObjectMeta{Name: "team"},
+					Spec:       v1alpha1.CohortSpec{Parent: "org"},
+				},
+				&v1beta1.ResourceFlavor{
+					ObjectMeta: metav1.ObjectMeta{Name: "spot", Labels: map[string]string{"tier": "low"}},
+				},
+				&v1beta1.ResourceFlavor{
+					ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				},
+				&v1beta1.AdmissionCheck{
+					ObjectMeta: metav1.ObjectMeta{Name: "check"},
+					Spec:       v1beta1.AdmissionCheckSpec{ControllerName: "example.com/check"},
+				},
+			},
+			wantOut: `apiVersion: v1
+items:
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: ResourceFlavor
+  metadata:
+    name: default
+  spec: {}
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: ResourceFlavor
+  metadata:
+    labels:
+      tier: low
+    name: spot
+  spec: {}
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: AdmissionCheck
+  metadata:
+    name: check
+  spec:
+    controllerName: example.com/check
+- apiVersion: kueue.x-k8s.io/v1alpha1
+  kind: Cohort
+  metadata:
+    name: team
+  spec:
+    parent: org
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: ClusterQueue
+  metadata:
+    name: cq
+  spec:
+    admissionChecks:
+    - check
+    cohort: team
+    resourceGroups:
+    - coveredResources:
+      - cpu
+      flavors:
+      - name: default
+        resources:
+        - name: cpu
+          nominalQuota: "10"
+kind: List
+`,
+		},
+		"json": {
+			objs: []runtime.Object{
+				&v1beta1.ResourceFlavor{
+					ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				},
+			},
+			args: []string{"-o", "json"},
+			wantOut: `{
+    "apiVersion": "v1",
+    "items": [
+        {
+            "apiVersion": "kueue.x-k8s.io/v1beta1",
+            "kind": "ResourceFlavor",
+            "metadata": {
+                "name": "default"
+            },
+            "spec": {}
+        }
+    ],
+    "kind": "List"
+}
+`,
+		},
+		"invalid output format": {
+			args:    []string{"-o", "wide"},
+			wantErr: `invalid output format "wide", allowed formats are: yaml, json`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			tcg := cmdtesting.NewTestClientGetter().WithDynamicClient(newDynamicClient(tc.objs...))
+
+			cmd := NewExportCmd(tcg, streams)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			gotOut := out.String()
+			if diff := cmp.Diff(tc.wantOut, gotOut); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			if tc.wantErr == "" {
+				if gotOutErr := outErr.String(); gotOutErr != "" {
+					t.Errorf("Unexpected error output: %s", gotOutErr)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/dynamic"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+)
+
+var (
+	importLong = templates.LongDesc(`
+		Imports a capacity configuration bundle, created with the export command,
+		into the cluster.
+
+		The bundle is validated before any object is imported: the objects must be
+		ResourceFlavors, AdmissionChecks, Cohorts or ClusterQueues with valid fields,
+		and the ResourceFlavors and AdmissionChecks referenced by the ClusterQueues
+		must be part of the bundle or exist in the cluster. The objects which exist
+		in the cluster with a different spec are reported as invalid, unless
+		--overwrite is set, in which case their spec is replaced.
+	`)
+	importExample = templates.Examples(`
+		# Import the capacity configuration
+		kueuectl import -f capacity.yaml
+
+		# Validate the bundle against the cluster, without importing it
+		kueuectl import -f capacity.yaml --dry-run server
+
+		# Import the capacity configuration, replacing the spec of the existing objects
+		kueuectl import -f capacity.yaml --overwrite
+	`)
+)
+
+var errNoFilename = errors.New("must specify one of -f and -k")
+
+type ImportOptions struct {
+	FilenameOptions resource.FilenameOptions
+	Overwrite       bool
+
+	DryRunStrategy util.DryRunStrategy
+
+	DynamicClient dynamic.Interface
+	Infos         []*resource.Info
+
+	genericiooptions.IOStreams
+}
+
+// importedObject is an object of the bundle, along with the existing object
+// with the same name in the cluster, if any.
+type importedObject struct {
+	kind     *bundleKind
+	obj      *unstructured.Unstructured
+	existing *unstructured.Unstructured
+}
+
+func NewImportOptions(streams genericiooptions.IOStreams) *ImportOptions {
+	return &ImportOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewImportCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewImportOptions(streams)
+
+	cmd := &cobra.Command{
+		Use: "import -f FILENAME [--overwrite] [--dry-run STRATEGY]",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Short:                 "Import a capacity configuration bundle",
+		Long:                  importLong,
+		Example:               importExample,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter, cmd)
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	cmdutil.AddFilenameOptionFlags(cmd, &o.FilenameOptions, "containing the capacity configuration to import")
+	cmd.Flags().BoolVar(&o.Overwrite, "overwrite", false,
+		"If present, replace the spec of the objects which already exist in the cluster with a different spec.")
+	util.AddDryRunFlag(cmd)
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *ImportOptions) Complete(clientGetter util.ClientGetter, cmd *cobra.Command) error {
+	if cmdutil.IsFilenameSliceEmpty(o.FilenameOptions.Filenames, o.FilenameOptions.Kustomize) {
+		return errNoFilename
+	}
+
+	var err error
+
+	o.DryRunStrategy, err = util.GetDryRunStrategy(cmd)
+	if err != nil {
+		return err
+	}
+
+	o.DynamicClient, err = clientGetter.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	r := clientGetter.NewResourceBuilder().
+		Unstructured().
+		Local().
+		FilenameParam(false, &o.FilenameOptions).
+		Flatten().
+		Do()
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	o.Infos, err = r.Infos()
+	return err
+}
+
+// Run validates the bundle and imports its objects
+func (o *ImportOptions) Run(ctx context.Context) error {
+	objects, err := o.validate(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objects {
+		if err := o.importObject(ctx, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate returns the objects of the bundle in the order in which they should be
+// imported, or an error listing all the problems found in the bundle.
+func (o *ImportOptions) validate(ctx context.Context) ([]importedObject, error) {
+	var errs []error
+	byKind := make(map[*bundleKind][]importedObject, len(bundleKinds))
+	names := make(map[*bundleKind]map[string]bool, len(bundleKinds))
+	var clusterQueues []*v1beta1.ClusterQueue
+
+	for _, info := range o.Infos {
+		obj, ok := info.Object.(*unstructured.Unstructured)
+		if !ok {
+			errs = append(errs, fmt.Errorf("invalid object %s, unexpected type %T", info.ObjectName(), info.Object))
+			continue
+		}
+		k := kindOf(obj.GroupVersionKind())
+		if k == nil {
+			errs = append(errs, fmt.Errorf("%s %s is not part of the capacity configuration", obj.GetKind(), obj.GetName()))
+			continue
+		}
+		name := obj.GetName()
+		if name == "" {
+			errs = append(errs, fmt.Errorf("%s without name", k.gvk.Kind))
+			continue
+		}
+		if names[k] == nil {
+			names[k] = make(map[string]bool)
+		}
+		if names[k][name] {
+			errs = append(errs, fmt.Errorf("%s is duplicated", k.objectName(name)))
+			continue
+		}
+		names[k][name] = true
+
+		typed := k.newObject()
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(obj.Object, typed, true); err != nil {
+			errs = append(errs, fmt.Errorf("%s is invalid: %w", k.objectName(name), err))
+			continue
+		}
+		if cq, ok := typed.(*v1beta1.ClusterQueue); ok {
+			clusterQueues = append(clusterQueues, cq)
+		}
+		byKind[k] = append(byKind[k], importedObject{kind: k, obj: obj})
+	}
+
+	existing := make(map[*bundleKind]map[string]*unstructured.Unstructured, len(bundleKinds))
+	for i := range bundleKinds {
+		k := &bundleKinds[i]
+		list, err := o.DynamicClient.Resource(k.gvr()).List(ctx, metav1.ListOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		existing[k] = make(map[string]*unstructured.Unstructured)
+		if list != nil {
+			for j := range list.Items {
+				existing[k][list.Items[j].GetName()] = &list.Items[j]
+			}
+		}
+	}
+
+	exists := func(k *bundleKind, name string) bool {
+		return names[k][name] || existing[k][name] != nil
+	}
+	flavorKind := kindOf(v1beta1.SchemeGroupVersion.WithKind("ResourceFlavor"))
+	checkKind := kindOf(v1beta1.SchemeGroupVersion.WithKind("AdmissionCheck"))
+	for _, cq := range clusterQueues {
+		for _, rg := range cq.Spec.ResourceGroups {
+			for _, fq := range rg.Flavors {
+				if !exists(flavorKind, string(fq.Name)) {
+					errs = append(errs, fmt.Errorf("clusterqueue.%s/%s references the ResourceFlavor %s, which is neither in the bundle nor in the cluster", v1beta1.GroupVersion.Group, cq.Name, fq.Name))
+				}
+			}
+		}
+		for _, check := range admissionChecks(cq) {
+			if !exists(checkKind, check) {
+				errs = append(errs, fmt.Errorf("clusterqueue.%s/%s references the AdmissionCheck %s, which is neither in the bundle nor in the cluster", v1beta1.GroupVersion.Group, cq.Name, check))
+			}
+		}
+	}
+
+	var objects []importedObject
+	for i := range bundleKinds {
+		k := &bundleKinds[i]
+		for _, obj := range byKind[k] {
+			obj.existing = existing[k][obj.obj.GetName()]
+			if obj.existing != nil && !o.Overwrite && !unchanged(obj) {
+				errs = append(errs, fmt.Errorf("%s already exists with a different spec, set --overwrite to replace it", k.objectName(obj.obj.GetName())))
+			}
+			objects = append(objects, obj)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid bundle, no object was imported:\n%w", errors.Join(errs...))
+	}
+	return objects, nil
+}
+
+func (o *ImportOptions) importObject(ctx context.Context, obj importedObject) error {
+	name := obj.kind.objectName(obj.obj.GetName())
+	if obj.existing != nil && unchanged(obj) {
+		fmt.Fprintf(o.Out, "%s unchanged\n", name)
+		return nil
+	}
+
+	gvr := obj.kind.gvr()
+	if obj.existing == nil {
+		if o.DryRunStrategy != util.DryRunClient {
+			if _, err := o.DynamicClient.Resource(gvr).Create(ctx, obj.obj, metav1.CreateOptions{DryRun: o.dryRun()}); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(o.Out, "%s created%s\n", name, o.dryRunSuffix())
+		return nil
+	}
+
+	updated := obj.existing.DeepCopy()
+	updated.Object["spec"] = runtime.DeepCopyJSONValue(obj.obj.Object["spec"])
+	if o.DryRunStrategy != util.DryRunClient {
+		if _, err := o.DynamicClient.Resource(gvr).Update(ctx, updated, metav1.UpdateOptions{DryRun: o.dryRun()}); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(o.Out, "%s configured%s\n", name, o.dryRunSuffix())
+	return nil
+}
+
+func (o *ImportOptions) dryRun() []string {
+	if o.DryRunStrategy == util.DryRunServer {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
+func (o *ImportOptions) dryRunSuffix() string {
+	switch o.DryRunStrategy {
+	case util.DryRunClient:
+		return " (client dry run)"
+	case util.DryRunServer:
+		return " (server dry run)"
+	}
+	return ""
+}
+
+// unchanged returns whether the object of the bundle has the same spec as the existing object.
+func unchanged(obj importedObject) bool {
+	return equality.Semantic.DeepEqual(obj.obj.Object["spec"], obj.existing.Object["spec"])
+}
+
+// admissionChecks returns the names of the AdmissionChecks referenced by the ClusterQueue.
+func admissionChecks(cq *v1beta1.ClusterQueue) []string {
+	checks := make([]string, 0, len(cq.Spec.AdmissionChecks))
+	checks = append(checks, cq.Spec.AdmissionChecks...)
+	if cq.Spec.AdmissionChecksStrategy != nil {
+		for _, rule := range cq.Spec.AdmissionChecksStrategy.AdmissionChecks {
+			checks = append(checks, rule.Name)
+		}
+	}
+	return checks
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	kubetesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+)
+
+const testBundle = `apiVersion: v1
+kind: List
+items:
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: ClusterQueue
+  metadata:
+    name: cq
+  spec:
+    admissionChecks:
+    - check
+    resourceGroups:
+    - coveredResources:
+      - cpu
+      flavors:
+      - name: default
+        resources:
+        - name: cpu
+          nominalQuota: "10"
+- apiVersion: kueue.x-k8s.io/v1alpha1
+  kind: Cohort
+  metadata:
+    name: team
+  spec:
+    parent: org
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: AdmissionCheck
+  metadata:
+    name: check
+  spec:
+    controllerName: example.com/check
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: ResourceFlavor
+  metadata:
+    name: default
+  spec: {}
+`
+
+type objectAction struct {
+	Verb     string
+	Resource string
+	Name     string
+}
+
+func TestImportCmd(t *testing.T) {
+	testCases := map[string]struct {
+		bundle      string
+		objs        []runtime.Object
+		args        []string
+		wantActions []objectAction
+		wantOut     string
+		wantErr     string
+	}{
+		"imports the objects in dependency order": {
+			bundle: testBundle,
+			wantActions: []objectAction{
+				{Verb: "create", Resource: "resourceflavors", Name: "default"},
+				{Verb: "create", Resource: "admissionchecks", Name: "check"},
+				{Verb: "create", Resource: "cohorts", Name: "team"},
+				{Verb: "create", Resource: "clusterqueues", Name: "cq"},
+			},
+			wantOut: `resourceflavor.kueue.x-k8s.io/default created
+admissioncheck.kueue.x-k8s.io/check created
+cohort.kueue.x-k8s.io/team created
+clusterqueue.kueue.x-k8s.io/cq created
+`,
+		},
+		"client dry run": {
+			bundle: testBundle,
+			args:   []string{"--dry-run", "client"},
+			wantOut: `resourceflavor.kueue.x-k8s.io/default created (client dry run)
+admissioncheck.kueue.x-k8s.io/check created (client dry run)
+cohort.kueue.x-k8s.io/team created (client dry run)
+clusterqueue.kueue.x-k8s.io/cq created (client dry run)
+`,
+		},
+		"unchanged objects": {
+			bundle: testBundle,
+			objs: []runtime.Object{
+				&v1beta1.ResourceFlavor{
+					ObjectMeta: metav1.ObjectMeta{Name: "default", ResourceVersion: "3"},
+				},
+				&v1beta1.AdmissionCheck{
+					ObjectMeta: metav1.ObjectMeta{Name: "check"},
+					Spec:       v1beta1.AdmissionCheckSpec{ControllerName: "example.com/check"},
+				},
+			},
+			wantActions: []objectAction{
+				{Verb: "create", Resource: "cohorts", Name: "team"},
+				{Verb: "create", Resource: "clusterqueues", Name: "cq"},
+			},
+			wantOut: `resourceflavor.kueue.x-k8s.io/default unchanged
+admissioncheck.kueue.x-k8s.io/check unchanged
+cohort.kueue.x-k8s.io/team created
+clusterqueue.kueue.x-k8s.io/cq created
+`,
+		},
+		"existing object with a different spec": {
+			bundle: testBundle,
+			objs: []runtime.Object{
+				&v1beta1.AdmissionCheck{
+					ObjectMeta: metav1.ObjectMeta{Name: "check"},
+					Spec:       v1beta1.AdmissionCheckSpec{ControllerName: "example.com/other"},
+				},
+			},
+			wantErr: `invalid bundle, no object was imported:
+admissioncheck.kueue.x-k8s.io/check already exists with a different spec, set --overwrite to replace it`,
+		},
+		"overwrite the existing object with a different spec": {
+			bundle: testBundle,
+			objs: []runtime.Object{
+				&v1beta1.AdmissionCheck{
+					ObjectMeta: metav1.ObjectMeta{Name: "check"},
+					Spec:       v1beta1.AdmissionCheckSpec{ControllerName: "example.com/other"},
+				},
+			},
+			args: []string{"--overwrite"},
+			wantActions: []objectAction{
+				{Verb: "create", Resource: "resourceflavors", Name: "default"},
+				{Verb: "update", Resource: "admissionchecks", Name: "check"},
+				{Verb: "create", Resource: "cohorts", Name: "team"},
+				{Verb: "create", Resource: "clusterqueues", Name: "cq"},
+			},
+			wantOut: `resourceflavor.kueue.x-k8s.io/default created
+admissioncheck.kueue.x-k8s.io/check configured
+cohort.kueue.x-k8s.io/team created
+clusterqueue.kueue.x-k8s.io/cq created
+`,
+		},
+		"references to missing objects": {
+			bundle: `apiVersion: kueue.x-k8s.io/v1beta1
+kind: ClusterQueue
+metadata:
+  name: cq
+spec:
+  admissionChecksStrategy:
+    admissionChecks:
+    - name: check
+  resourceGroups:
+  - coveredResources:
+    - cpu
+    flavors:
+    - name: default
+      resources:
+      - name: cpu
+        nominalQuota: "10"
+`,
+			objs: []runtime.Object{
+				&v1beta1.ResourceFlavor{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+			},
+			wantErr: `invalid bundle, no object was imported:
+clusterqueue.kueue.x-k8s.io/cq references the ResourceFlavor default, which is neither in the bundle nor in the cluster
+clusterqueue.kueue.x-k8s.io/cq references the AdmissionCheck check, which is neither in the bundle nor in the cluster`,
+		},
+		"invalid objects": {
+			bundle: `apiVersion: v1
+kind: List
+items:
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: LocalQueue
+  metadata:
+    name: lq
+    namespace: default
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: ResourceFlavor
+  metadata:
+    name: default
+  spec:
+    nodeLabel: {}
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: AdmissionCheck
+  metadata:
+    name: check
+  spec:
+    controllerName: example.com/check
+- apiVersion: kueue.x-k8s.io/v1beta1
+  kind: AdmissionCheck
+  metadata:
+    name: check
+  spec:
+    controllerName: example.com/check
+`,
+			wantErr: `invalid bundle, no object was imported:
+LocalQueue lq is not part of the capacity configuration
+resourceflavor.kueue.x-k8s.io/default is invalid: strict decoding error: unknown field "spec.nodeLabel"
+admissioncheck.kueue.x-k8s.io/check is duplicated`,
+		},
+		"no file": {
+			wantErr: "must specify one of -f and -k",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			args := tc.args
+			if tc.bundle != "" {
+				path := filepath.Join(t.TempDir(), "bundle.yaml")
+				if err := os.WriteFile(path, []byte(tc.bundle), 0o600); err != nil {
+					t.Fatalf("Writing the bundle: %v", err)
+				}
+				args = append([]string{"-f", path}, args...)
+			}
+
+			dynamicClient := newDynamicClient(tc.objs...)
+			tcg := cmdtesting.NewTestClientGetter().WithDynamicClient(dynamicClient)
+
+			cmd := NewImportCmd(tcg, streams)
+			cmd.SetArgs(args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			var gotActions []objectAction
+			for _, action := range dynamicClient.Actions() {
+				if a, ok := action.(kubetesting.CreateAction); ok && (a.GetVerb() == "create" || a.GetVerb() == "update") {
+					gotActions = append(gotActions, objectAction{Verb: a.GetVerb(), Resource: a.GetResource().Resource, Name: objectName(a.GetObject())})
+				}
+			}
+			if diff := cmp.Diff(tc.wantActions, gotActions); diff != "" {
+				t.Errorf("Unexpected actions (-want/+got)\n%s", diff)
+			}
+
+			gotOut := out.String()
+			if diff := cmp.Diff(tc.wantOut, gotOut); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			if tc.wantErr == "" {
+				if gotOutErr := outErr.String(); gotOutErr != "" {
+					t.Errorf("Unexpected error output: %s", gotOutErr)
+				}
+			}
+		})
+	}
+}
+
+func objectName(obj runtime.Object) string {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	return accessor.GetName()
+}
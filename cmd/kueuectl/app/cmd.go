@@ -24,14 +24,27 @@ import (
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/utils/clock"
 
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/bulk"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/bundle"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/create"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/drain"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/fairshare"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/list"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/logs"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/migrate"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/passthrough"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/resubmit"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/resume"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/simulate"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/stats"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/stop"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/top"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/tree"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
 	"sigs.k8s.io/kueue/cmd/kueuectl/app/version"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/wait"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/why"
 )
 
 type KueuectlOptions struct {
@@ -78,7 +91,21 @@ func NewKueuectlCmd(o KueuectlOptions) *cobra.Command {
 	cmd.AddCommand(create.NewCreateCmd(clientGetter, o.IOStreams))
 	cmd.AddCommand(resume.NewResumeCmd(clientGetter, o.IOStreams))
 	cmd.AddCommand(stop.NewStopCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(drain.NewDrainCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(resubmit.NewResubmitCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(migrate.NewMigrateCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(bulk.NewBulkCmd(clientGetter, o.IOStreams, o.Clock))
 	cmd.AddCommand(list.NewListCmd(clientGetter, o.IOStreams, o.Clock))
+	cmd.AddCommand(top.NewTopCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(stats.NewStatsCmd(clientGetter, o.IOStreams, o.Clock))
+	cmd.AddCommand(why.NewWhyCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(simulate.NewSimulateCmd(clientGetter, o.IOStreams, o.Clock))
+	cmd.AddCommand(bundle.NewExportCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(bundle.NewImportCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(fairshare.NewFairShareCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(tree.NewTreeCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(logs.NewLogsCmd(clientGetter, o.IOStreams))
+	cmd.AddCommand(wait.NewWaitCmd(clientGetter, o.IOStreams))
 	cmd.AddCommand(passthrough.NewCommands(clientGetter, o.IOStreams)...)
 	cmd.AddCommand(version.NewVersionCmd(clientGetter, o.IOStreams))
 
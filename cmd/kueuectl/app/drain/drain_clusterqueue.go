@@ -0,0 +1,191 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	kueuev1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+)
+
+// pollInterval is the interval at which the progress of the drain is checked.
+var pollInterval = 5 * time.Second
+
+var (
+	cqLong = templates.LongDesc(`
+		Drains the given ClusterQueue, for decommissioning or maintenance.
+
+		The command stops the admission of new workloads to the ClusterQueue, and
+		waits until no workload has quota reserved in it, reporting the number of
+		remaining workloads. By default, the running workloads are left to finish.
+		With --evict, Kueue evicts them.
+	`)
+	cqExample = templates.Examples(`
+		# Drain the clusterqueue, waiting for the running workloads to finish
+		kueuectl drain clusterqueue my-clusterqueue
+
+		# Drain the clusterqueue, evicting the running workloads, for at most 10 minutes
+		kueuectl drain clusterqueue my-clusterqueue --evict --timeout 10m
+	`)
+)
+
+type ClusterQueueOptions struct {
+	ClusterQueueName string
+	Evict            bool
+	Timeout          time.Duration
+
+	Client kueuev1beta1.KueueV1beta1Interface
+
+	genericiooptions.IOStreams
+}
+
+func NewClusterQueueOptions(streams genericiooptions.IOStreams) *ClusterQueueOptions {
+	return &ClusterQueueOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewClusterQueueCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewClusterQueueOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:                   "clusterqueue NAME [--evict] [--timeout DURATION]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"cq"},
+		Short:                 "Drain the ClusterQueue",
+		Long:                  cqLong,
+		Example:               cqExample,
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgsFunction:     completion.ClusterQueueNameFunc(clientGetter, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			err := o.Complete(clientGetter, args)
+			if err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.Evict, "evict", false,
+		"If present, evict the workloads running in the ClusterQueue, instead of waiting for them to finish.")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 0,
+		"The length of time to wait for the ClusterQueue to be drained. Zero means wait indefinitely.")
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *ClusterQueueOptions) Complete(clientGetter util.ClientGetter, args []string) error {
+	o.ClusterQueueName = args[0]
+
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.Client = clientset.KueueV1beta1()
+
+	return nil
+}
+
+// Run stops the ClusterQueue and waits until it is drained
+func (o *ClusterQueueOptions) Run(ctx context.Context) error {
+	cq, err := o.Client.ClusterQueues().Get(ctx, o.ClusterQueueName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	cqOriginal := cq.DeepCopy()
+	o.stopClusterQueue(cq)
+
+	patch := client.MergeFrom(cqOriginal)
+	data, err := patch.Data(cq)
+	if err != nil {
+		return err
+	}
+	if _, err := o.Client.ClusterQueues().Patch(ctx, o.ClusterQueueName, types.MergePatchType, data, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "clusterqueue.kueue.x-k8s.io/%s stopped with the %s policy\n", o.ClusterQueueName, *cq.Spec.StopPolicy)
+
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	remaining := int32(-1)
+	err = wait.PollUntilContextCancel(ctx, pollInterval, true, func(ctx context.Context) (bool, error) {
+		cq, err := o.Client.ClusterQueues().Get(ctx, o.ClusterQueueName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		if count := workloadsInClusterQueue(cq); count != remaining {
+			remaining = count
+			if remaining > 0 {
+				fmt.Fprintf(o.Out, "waiting for %d workloads to %s\n", remaining, o.waitingFor())
+			}
+		}
+		return remaining == 0, nil
+	})
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timed out waiting for clusterqueue %s to be drained, %d workloads remaining", o.ClusterQueueName, remaining)
+		}
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "clusterqueue.kueue.x-k8s.io/%s drained\n", o.ClusterQueueName)
+	return nil
+}
+
+func (o *ClusterQueueOptions) stopClusterQueue(cq *v1beta1.ClusterQueue) {
+	switch {
+	case o.Evict:
+		cq.Spec.StopPolicy = ptr.To(v1beta1.HoldAndDrain)
+	case ptr.Deref(cq.Spec.StopPolicy, v1beta1.None) == v1beta1.None:
+		cq.Spec.StopPolicy = ptr.To(v1beta1.Hold)
+	}
+}
+
+func (o *ClusterQueueOptions) waitingFor() string {
+	if o.Evict {
+		return "be evicted"
+	}
+	return "finish"
+}
+
+// workloadsInClusterQueue returns the number of workloads which hold quota in the ClusterQueue.
+func workloadsInClusterQueue(cq *v1beta1.ClusterQueue) int32 {
+	return max(cq.Status.ReservingWorkloads, cq.Status.AdmittedWorkloads)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	kubetesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestClusterQueueCmd(t *testing.T) {
+	defer func(interval time.Duration) { pollInterval = interval }(pollInterval)
+	pollInterval = time.Millisecond
+
+	testCases := map[string]struct {
+		cq *v1beta1.ClusterQueue
+		// remainingWorkloads are the numbers of workloads reported in the status of
+		// the ClusterQueue by the successive checks of the progress of the drain.
+		remainingWorkloads []int32
+		args               []string
+		wantStopPolicy     *v1beta1.StopPolicy
+		wantOut            string
+		wantErr            string
+	}{
+		"should hold the empty clusterqueue": {
+			cq:             utiltesting.MakeClusterQueue("cq").Obj(),
+			args:           []string{"cq"},
+			wantStopPolicy: ptr.To(v1beta1.Hold),
+			wantOut: `clusterqueue.kueue.x-k8s.io/cq stopped with the Hold policy
+clusterqueue.kueue.x-k8s.io/cq drained
+`,
+		},
+		"should wait for the running workloads to finish": {
+			cq:                 utiltesting.MakeClusterQueue("cq").Obj(),
+			remainingWorkloads: []int32{2, 2, 1, 0},
+			args:               []string{"cq"},
+			wantStopPolicy:     ptr.To(v1beta1.Hold),
+			wantOut: `clusterqueue.kueue.x-k8s.io/cq stopped with the Hold policy
+waiting for 2 workloads to finish
+waiting for 1 workloads to finish
+clusterqueue.kueue.x-k8s.io/cq drained
+`,
+		},
+		"should evict the running workloads": {
+			cq:                 utiltesting.MakeClusterQueue("cq").StopPolicy(v1beta1.Hold).Obj(),
+			remainingWorkloads: []int32{3, 0},
+			args:               []string{"cq", "--evict"},
+			wantStopPolicy:     ptr.To(v1beta1.HoldAndDrain),
+			wantOut: `clusterqueue.kueue.x-k8s.io/cq stopped with the HoldAndDrain policy
+waiting for 3 workloads to be evicted
+clusterqueue.kueue.x-k8s.io/cq drained
+`,
+		},
+		"should keep evicting the workloads of the clusterqueue already drained": {
+			cq:             utiltesting.MakeClusterQueue("cq").StopPolicy(v1beta1.HoldAndDrain).Obj(),
+			args:           []string{"cq"},
+			wantStopPolicy: ptr.To(v1beta1.HoldAndDrain),
+			wantOut: `clusterqueue.kueue.x-k8s.io/cq stopped with the HoldAndDrain policy
+clusterqueue.kueue.x-k8s.io/cq drained
+`,
+		},
+		"should time out waiting for the running workloads": {
+			cq:                 utiltesting.MakeClusterQueue("cq").Obj(),
+			remainingWorkloads: []int32{1},
+			args:               []string{"cq", "--timeout", "50ms"},
+			wantStopPolicy:     ptr.To(v1beta1.Hold),
+			wantOut: `clusterqueue.kueue.x-k8s.io/cq stopped with the Hold policy
+waiting for 1 workloads to finish
+`,
+			wantErr: "timed out waiting for clusterqueue cq to be drained, 1 workloads remaining",
+		},
+		"should fail when the clusterqueue doesn't exist": {
+			args:    []string{"cq"},
+			wantErr: `clusterqueues.kueue.x-k8s.io "cq" not found`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+
+			var objs []runtime.Object
+			if tc.cq != nil {
+				objs = append(objs, tc.cq)
+			}
+			clientset := fake.NewSimpleClientset(objs...)
+			gets := 0
+			clientset.PrependReactor("get", "clusterqueues", func(action kubetesting.Action) (bool, runtime.Object, error) {
+				gets++
+				// The first get reads the ClusterQueue to stop it.
+				if gets == 1 || len(tc.remainingWorkloads) == 0 {
+					return false, nil, nil
+				}
+				obj, err := clientset.Tracker().Get(action.GetResource(), "", tc.cq.Name)
+				if err != nil {
+					return true, nil, err
+				}
+				cq := obj.(*v1beta1.ClusterQueue)
+				cq.Status.ReservingWorkloads = tc.remainingWorkloads[0]
+				cq.Status.AdmittedWorkloads = tc.remainingWorkloads[0]
+				if len(tc.remainingWorkloads) > 1 {
+					tc.remainingWorkloads = tc.remainingWorkloads[1:]
+				}
+				return true, cq, nil
+			})
+
+			tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(clientset)
+
+			cmd := NewClusterQueueCmd(tcg, streams)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			if tc.cq != nil {
+				gotCq, err := clientset.KueueV1beta1().ClusterQueues().Get(context.Background(), tc.cq.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("Unexpected error getting the clusterqueue: %v", err)
+				}
+				if diff := cmp.Diff(tc.wantStopPolicy, gotCq.Spec.StopPolicy); diff != "" {
+					t.Errorf("Unexpected stop policy (-want/+got)\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+)
+
+var (
+	describeLong = templates.LongDesc(`
+		Prints the tree of cohorts with the fair sharing state of their
+		ClusterQueues.
+
+		For each ClusterQueue, the command prints its weight, its current
+		weighted share, the quota it borrows from the cohort, and its order
+		among the ClusterQueues of the same cohort. The ClusterQueues with
+		the lowest share are considered first for admission, and the ones
+		with the highest share are preempted first.
+
+		The weighted share is only reported when fair sharing is enabled.
+	`)
+	describeExample = templates.Examples(`
+		# Describe the fair sharing state of all the cohorts
+		kueuectl fairshare describe
+
+		# Describe the fair sharing state of the cohort and its descendants
+		kueuectl fairshare describe my-cohort
+	`)
+)
+
+var cohortsGVR = v1alpha1.GroupVersion.WithResource("cohorts")
+
+type DescribeOptions struct {
+	Cohort string
+
+	ClientSet     versioned.Interface
+	DynamicClient dynamic.Interface
+
+	genericiooptions.IOStreams
+}
+
+// cohortNode is a cohort in the tree, either defined by a Cohort object or
+// implicitly, by the ClusterQueues and Cohorts referencing it.
+type cohortNode struct {
+	name          string
+	parent        string
+	children      []string
+	clusterQueues []*v1beta1.ClusterQueue
+}
+
+func NewDescribeOptions(streams genericiooptions.IOStreams) *DescribeOptions {
+	return &DescribeOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewDescribeCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewDescribeOptions(streams)
+
+	cmd := &cobra.Command{
+		Use: "describe [COHORT]",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Short:                 "Print the cohort tree with the fair sharing state of the ClusterQueues",
+		Long:                  describeLong,
+		Example:               describeExample,
+		Args:                  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter, args)
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *DescribeOptions) Complete(clientGetter util.ClientGetter, args []string) error {
+	if len(args) > 0 {
+		o.Cohort = args[0]
+	}
+
+	var err error
+
+	o.ClientSet, err = clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.DynamicClient, err = clientGetter.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run prints the cohort tree
+func (o *DescribeOptions) Run(ctx context.Context) error {
+	nodes, err := o.cohortTree(ctx)
+	if err != nil {
+		return err
+	}
+
+	var roots []string
+	if o.Cohort != "" {
+		if _, found := nodes[o.Cohort]; !found {
+			return fmt.Errorf("cohort %s not found", o.Cohort)
+		}
+		roots = []string{o.Cohort}
+	} else {
+		for name, node := range nodes {
+			if node.parent == "" {
+				roots = append(roots, name)
+			}
+		}
+		slices.Sort(roots)
+	}
+
+	if len(nodes) == 0 {
+		fmt.Fprintln(o.Out, "No cohorts found.")
+		return nil
+	}
+
+	visited := sets.New[string]()
+	trees := 0
+	printTree := func(root string) {
+		if trees > 0 {
+			fmt.Fprintln(o.Out)
+		}
+		trees++
+		printCohort(o.Out, nodes, root, 0, visited)
+	}
+	for _, root := range roots {
+		printTree(root)
+	}
+	// The cohorts which are not descendants of a root are part of a cycle.
+	if o.Cohort == "" {
+		for _, name := range slices.Sorted(maps.Keys(nodes)) {
+			if !visited.Has(name) {
+				printTree(name)
+			}
+		}
+	}
+	return nil
+}
+
+func (o *DescribeOptions) cohortTree(ctx context.Context) (map[string]*cohortNode, error) {
+	nodes := make(map[string]*cohortNode)
+	node := func(name string) *cohortNode {
+		if nodes[name] == nil {
+			nodes[name] = &cohortNode{name: name}
+		}
+		return nodes[name]
+	}
+
+	// The Cohorts API might not be installed, in which case all the cohorts are implicit.
+	list, err := o.DynamicClient.Resource(cohortsGVR).List(ctx, metav1.ListOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	if list != nil {
+		for i := range list.Items {
+			var cohort v1alpha1.Cohort
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, &cohort); err != nil {
+				return nil, fmt.Errorf("failed to convert unstructured object: %w", err)
+			}
+			n := node(cohort.Name)
+			n.parent = cohort.Spec.Parent
+			if n.parent != "" {
+				parent := node(n.parent)
+				parent.children = append(parent.children, n.name)
+			}
+		}
+	}
+
+	cqs, err := o.ClientSet.KueueV1beta1().ClusterQueues().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range cqs.Items {
+		cq := &cqs.Items[i]
+		if cq.Spec.Cohort == "" {
+			continue
+		}
+		n := node(cq.Spec.Cohort)
+		n.clusterQueues = append(n.clusterQueues, cq)
+	}
+
+	for _, n := range nodes {
+		slices.Sort(n.children)
+		slices.SortFunc(n.clusterQueues, func(a, b *v1beta1.ClusterQueue) int {
+			return cmp.Or(cmp.Compare(weightedShare(a), weightedShare(b)), strings.Compare(a.Name, b.Name))
+		})
+	}
+	return nodes, nil
+}
+
+func printCohort(out io.Writer, nodes map[string]*cohortNode, name string, depth int, visited sets.Set[string]) {
+	indent := strings.Repeat("  ", depth)
+	fmt.Fprintf(out, "%sCohort %s\n", indent, name)
+	if visited.Has(name) {
+		fmt.Fprintf(out, "%s  cycle detected, the members of the cohort can't admit workloads\n", indent)
+		return
+	}
+	visited.Insert(name)
+
+	n := nodes[name]
+	for i, cq := range n.clusterQueues {
+		fmt.Fprintf(out, "%s  ClusterQueue %s (weight: %s, weighted share: %s, order: %d)\n",
+			indent, cq.Name, formatWeight(cq), formatShare(cq), i+1)
+		for _, fu := range cq.Status.FlavorsUsage {
+			for _, ru := range fu.Resources {
+				if ru.Borrowed.IsZero() {
+					continue
+				}
+				fmt.Fprintf(out, "%s    borrowed %s in flavor %s: %s\n", indent, ru.Name, fu.Name, ru.Borrowed.String())
+			}
+		}
+	}
+	for _, child := range n.children {
+		printCohort(out, nodes, child, depth+1, visited)
+	}
+}
+
+func formatWeight(cq *v1beta1.ClusterQueue) string {
+	if cq.Spec.FairSharing == nil || cq.Spec.FairSharing.Weight == nil {
+		return "1"
+	}
+	return cq.Spec.FairSharing.Weight.String()
+}
+
+// weightedShare returns the weighted share of the ClusterQueue, or the maximum
+// share if it is unknown, for the ClusterQueues to be sorted last.
+func weightedShare(cq *v1beta1.ClusterQueue) int64 {
+	if cq.Status.FairSharing == nil {
+		return math.MaxInt64
+	}
+	return cq.Status.FairSharing.WeightedShare
+}
+
+func formatShare(cq *v1beta1.ClusterQueue) string {
+	switch {
+	case cq.Status.FairSharing == nil:
+		return "unknown"
+	case cq.Status.FairSharing.WeightedShare == math.MaxInt64:
+		return "max"
+	default:
+		return strconv.FormatInt(cq.Status.FairSharing.WeightedShare, 10)
+	}
+}
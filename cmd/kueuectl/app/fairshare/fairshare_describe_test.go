@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/scheme"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestDescribeCmd(t *testing.T) {
+	clusterQueues := []runtime.Object{
+		utiltesting.MakeClusterQueue("standalone").Obj(),
+		utiltesting.MakeClusterQueue("team-a-cq1").
+			Cohort("team-a").
+			FairWeight(resource.MustParse("2")).
+			WeightedShare(250).
+			FlavorsUsage(v1beta1.FlavorUsage{
+				Name: "on-demand",
+				Resources: []v1beta1.ResourceUsage{
+					{Name: "cpu", Total: resource.MustParse("14"), Borrowed: resource.MustParse("4")},
+					{Name: "memory", Total: resource.MustParse("10Gi")},
+				},
+			}).
+			Obj(),
+		utiltesting.MakeClusterQueue("team-a-cq2").
+			Cohort("team-a").
+			WeightedShare(0).
+			Obj(),
+		utiltesting.MakeClusterQueue("team-b-cq").
+			Cohort("team-b").
+			FairWeight(resource.MustParse("0")).
+			WeightedShare(math.MaxInt64).
+			Obj(),
+		utiltesting.MakeClusterQueue("org-cq").
+			Cohort("org").
+			Obj(),
+	}
+	cohorts := []runtime.Object{
+		utiltesting.MakeCohort("team-a").Parent("org").Obj(),
+		utiltesting.MakeCohort("team-b").Parent("org").Obj(),
+		utiltesting.MakeCohort("lab").Obj(),
+	}
+
+	testCases := map[string]struct {
+		cqs     []runtime.Object
+		cohorts []runtime.Object
+		args    []string
+		wantOut string
+		wantErr string
+	}{
+		"no cohorts": {
+			cqs:     []runtime.Object{utiltesting.MakeClusterQueue("standalone").Obj()},
+			wantOut: "No cohorts found.\n",
+		},
+		"implicit cohorts": {
+			cqs: clusterQueues,
+			wantOut: `Cohort org
+  ClusterQueue org-cq (weight: 1, weighted share: unknown, order: 1)
+
+Cohort team-a
+  ClusterQueue team-a-cq2 (weight: 1, weighted share: 0, order: 1)
+  ClusterQueue team-a-cq1 (weight: 2, weighted share: 250, order: 2)
+    borrowed cpu in flavor on-demand: 4
+
+Cohort team-b
+  ClusterQueue team-b-cq (weight: 0, weighted share: max, order: 1)
+`,
+		},
+		"cohort tree": {
+			cqs:     clusterQueues,
+			cohorts: cohorts,
+			wantOut: `Cohort lab
+
+Cohort org
+  ClusterQueue org-cq (weight: 1, weighted share: unknown, order: 1)
+  Cohort team-a
+    ClusterQueue team-a-cq2 (weight: 1, weighted share: 0, order: 1)
+    ClusterQueue team-a-cq1 (weight: 2, weighted share: 250, order: 2)
+      borrowed cpu in flavor on-demand: 4
+  Cohort team-b
+    ClusterQueue team-b-cq (weight: 0, weighted share: max, order: 1)
+`,
+		},
+		"subtree": {
+			cqs:     clusterQueues,
+			cohorts: cohorts,
+			args:    []string{"team-a"},
+			wantOut: `Cohort team-a
+  ClusterQueue team-a-cq2 (weight: 1, weighted share: 0, order: 1)
+  ClusterQueue team-a-cq1 (weight: 2, weighted share: 250, order: 2)
+    borrowed cpu in flavor on-demand: 4
+`,
+		},
+		"cycle": {
+			cohorts: []runtime.Object{
+				utiltesting.MakeCohort("a").Parent("b").Obj(),
+				utiltesting.MakeCohort("b").Parent("a").Obj(),
+			},
+			args: []string{"a"},
+			wantOut: `Cohort a
+  Cohort b
+    Cohort a
+      cycle detected, the members of the cohort can't admit workloads
+`,
+		},
+		"cycle without root": {
+			cohorts: []runtime.Object{
+				utiltesting.MakeCohort("a").Parent("b").Obj(),
+				utiltesting.MakeCohort("b").Parent("a").Obj(),
+				utiltesting.MakeCohort("c").Obj(),
+			},
+			wantOut: `Cohort c
+
+Cohort a
+  Cohort b
+    Cohort a
+      cycle detected, the members of the cohort can't admit workloads
+`,
+		},
+		"cohort not found": {
+			cqs:     clusterQueues,
+			args:    []string{"invalid"},
+			wantErr: "cohort invalid not found",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme.Scheme,
+				map[schema.GroupVersionResource]string{cohortsGVR: "CohortList"}, tc.cohorts...)
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(fake.NewSimpleClientset(tc.cqs...)).
+				WithDynamicClient(dynamicClient)
+
+			cmd := NewDescribeCmd(tcg, streams)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			gotOut := out.String()
+			if diff := cmp.Diff(tc.wantOut, gotOut); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			if tc.wantErr == "" {
+				if gotOutErr := outErr.String(); gotOutErr != "" {
+					t.Errorf("Unexpected error output: %s", gotOutErr)
+				}
+			}
+		})
+	}
+}
@@ -17,9 +17,11 @@ limitations under the License.
 package list
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -50,9 +52,17 @@ var (
 	wlExample = templates.Examples(`
 		# List Workload 
   		kueuectl list workload
+
+		# List Workload in the order in which they would be admitted
+		kueuectl list workload --sort-by position
 	`)
 )
 
+const (
+	sortByPosition = "position"
+	sortByPriority = "priority"
+)
+
 const (
 	workloadStatusAll = iota
 	workloadStatusPending
@@ -73,6 +83,7 @@ type WorkloadOptions struct {
 	ClusterQueueFilter string
 	LocalQueueFilter   string
 	StatusesFilter     sets.Set[int]
+	SortBy             string
 	forGVK             schema.GroupVersionKind
 	forName            string
 	forObject          *unstructured.Unstructured
@@ -96,7 +107,7 @@ func NewWorkloadCmd(clientGetter util.ClientGetter, streams genericiooptions.IOS
 	o := NewWorkloadOptions(streams, clock)
 
 	cmd := &cobra.Command{
-		Use: "workload [--clusterqueue CLUSTER_QUEUE_NAME] [--localqueue LOCAL_QUEUE_NAME] [--status STATUS] [--selector key1=value1] [--field-selector key1=value1] [--all-namespaces] [--for TYPE[.API-GROUP]/NAME]",
+		Use: "workload [--clusterqueue CLUSTER_QUEUE_NAME] [--localqueue LOCAL_QUEUE_NAME] [--status STATUS] [--selector key1=value1] [--field-selector key1=value1] [--all-namespaces] [--for TYPE[.API-GROUP]/NAME] [--sort-by position|priority]",
 		// To do not add "[flags]" suffix on the end of usage line
 		DisableFlagsInUseLine: true,
 		Aliases:               []string{"wl"},
@@ -126,6 +137,9 @@ func NewWorkloadCmd(clientGetter util.ClientGetter, streams genericiooptions.IOS
 	cobra.CheckErr(cmd.RegisterFlagCompletionFunc("localqueue", completion.LocalQueueNameFunc(clientGetter, nil)))
 
 	cmd.Flags().StringArray("status", nil, `Filter workloads by status. Must be "all", "pending", "admitted" or "finished"`)
+	cmd.Flags().StringVar(&o.SortBy, "sort-by", "",
+		`Sort workloads by "position" in their ClusterQueue, as reported by the visibility API, or by effective "priority"`)
+	cobra.CheckErr(cmd.RegisterFlagCompletionFunc("sort-by", cobra.FixedCompletions([]string{sortByPosition, sortByPriority}, cobra.ShellCompDirectiveNoFileComp)))
 
 	return cmd
 }
@@ -177,6 +191,10 @@ func (o *WorkloadOptions) Complete(clientGetter util.ClientGetter, cmd *cobra.Co
 		return err
 	}
 
+	if o.SortBy != "" && o.SortBy != sortByPosition && o.SortBy != sortByPriority {
+		return fmt.Errorf(`Invalid sort-by value (%v). Must be "position" or "priority".`, o.SortBy)
+	}
+
 	o.ClientSet, err = clientGetter.KueueClientSet()
 	if err != nil {
 		return err
@@ -279,6 +297,10 @@ func (o *WorkloadOptions) Run(ctx context.Context) error {
 
 	tabWriter := printers.GetNewTabWriter(o.Out)
 
+	// The workloads are printed page by page, unless they are sorted.
+	sorted := &v1beta1.WorkloadList{}
+	sortedResources := newListWorkloadResources()
+
 	var enableOwnerReferenceFilter bool
 	for {
 		headers := totalCount == 0
@@ -315,6 +337,17 @@ func (o *WorkloadOptions) Run(ctx context.Context) error {
 			return err
 		}
 
+		if o.SortBy != "" {
+			sorted.Items = append(sorted.Items, list.Items...)
+			sortedResources.merge(r)
+			if list.Continue != "" {
+				opts.Continue = list.Continue
+				continue
+			}
+			o.sortList(sorted, sortedResources)
+			list, r, headers = sorted, sortedResources, true
+		}
+
 		printer, err := o.ToPrinter(r, headers)
 		if err != nil {
 			return err
@@ -493,6 +526,35 @@ func (o *WorkloadOptions) apiResources(list *v1beta1.WorkloadList) (map[string]*
 	return apiResourceLists, nil
 }
 
+// sortList sorts the workloads by their position in the ClusterQueue or by their
+// effective priority. The workloads without position are sorted last.
+func (o *WorkloadOptions) sortList(list *v1beta1.WorkloadList, r *listWorkloadResources) {
+	slices.SortStableFunc(list.Items, func(a, b v1beta1.Workload) int {
+		switch o.SortBy {
+		case sortByPosition:
+			pa, okA := r.pendingWorkloads[workload.Key(&a)]
+			pb, okB := r.pendingWorkloads[workload.Key(&b)]
+			switch {
+			case okA && okB:
+				return cmp.Or(
+					strings.Compare(r.clusterQueueName(&a), r.clusterQueueName(&b)),
+					cmp.Compare(pa.PositionInClusterQueue, pb.PositionInClusterQueue),
+				)
+			case okA:
+				return -1
+			case okB:
+				return 1
+			}
+			return 0
+		default:
+			return cmp.Or(
+				cmp.Compare(r.effectivePriority(&b), r.effectivePriority(&a)),
+				a.CreationTimestamp.Compare(b.CreationTimestamp.Time),
+			)
+		}
+	})
+}
+
 func workloadPending(wl *v1beta1.Workload) bool {
 	return workload.Status(wl) == workload.StatusPending
 }
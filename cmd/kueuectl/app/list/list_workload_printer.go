@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"strings"
 
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -32,6 +33,7 @@ import (
 
 	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -51,6 +53,31 @@ func newListWorkloadResources() *listWorkloadResources {
 	}
 }
 
+func (r *listWorkloadResources) merge(other *listWorkloadResources) {
+	maps.Copy(r.localQueues, other.localQueues)
+	maps.Copy(r.pendingWorkloads, other.pendingWorkloads)
+	maps.Copy(r.apiResourceLists, other.apiResourceLists)
+}
+
+func (r *listWorkloadResources) clusterQueueName(wl *v1beta1.Workload) string {
+	if wl.Status.Admission != nil && len(wl.Status.Admission.ClusterQueue) > 0 {
+		return string(wl.Status.Admission.ClusterQueue)
+	}
+	if lq := r.localQueues[localQueueKeyForWorkload(wl)]; lq != nil {
+		return string(lq.Spec.ClusterQueue)
+	}
+	return ""
+}
+
+// effectivePriority returns the priority of the workload reported by the
+// visibility API if it's pending, or the priority in its spec.
+func (r *listWorkloadResources) effectivePriority(wl *v1beta1.Workload) int32 {
+	if pendingWorkload, ok := r.pendingWorkloads[workload.Key(wl)]; ok {
+		return pendingWorkload.Priority
+	}
+	return priority.Priority(wl)
+}
+
 type listWorkloadPrinter struct {
 	clock        clock.Clock
 	printOptions printers.PrintOptions
@@ -75,6 +102,7 @@ func (p *listWorkloadPrinter) PrintObj(obj runtime.Object, out io.Writer) error
 			{Name: "LocalQueue", Type: "string"},
 			{Name: "ClusterQueue", Type: "string"},
 			{Name: "Status", Type: "string"},
+			{Name: "Priority", Type: "integer"},
 			{Name: "Position in Queue", Type: "string"},
 			{Name: "Exec Time", Type: "string"},
 			{Name: "Age", Type: "string"},
@@ -128,13 +156,6 @@ func (p *listWorkloadPrinter) printWorkload(wl *v1beta1.Workload) metav1.TableRo
 		Object: runtime.RawExtension{Object: wl},
 	}
 
-	var clusterQueueName string
-	if wl.Status.Admission != nil && len(wl.Status.Admission.ClusterQueue) > 0 {
-		clusterQueueName = string(wl.Status.Admission.ClusterQueue)
-	} else if lq := p.resources.localQueues[localQueueKeyForWorkload(wl)]; lq != nil {
-		clusterQueueName = string(lq.Spec.ClusterQueue)
-	}
-
 	var positionInQueue string
 	if pendingWorkload, ok := p.resources.pendingWorkloads[workload.Key(wl)]; ok {
 		positionInQueue = fmt.Sprintf("%d", pendingWorkload.PositionInLocalQueue)
@@ -158,8 +179,9 @@ func (p *listWorkloadPrinter) printWorkload(wl *v1beta1.Workload) metav1.TableRo
 		strings.Join(p.crdTypes(wl), ", "),
 		strings.Join(p.crdNames(wl), ", "),
 		wl.Spec.QueueName,
-		clusterQueueName,
+		p.resources.clusterQueueName(wl),
 		strings.ToUpper(workload.Status(wl)),
+		p.resources.effectivePriority(wl),
 		positionInQueue,
 		execTime,
 		duration.HumanDuration(p.clock.Since(wl.CreationTimestamp.Time)),
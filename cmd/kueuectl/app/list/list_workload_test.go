@@ -84,8 +84,8 @@ func TestWorkloadCmd(t *testing.T) {
 					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING   0                                          60m
 `,
 		},
 		"should print workload list with localqueue filter": {
@@ -106,8 +106,8 @@ wl1               j1         lq1          cq1            PENDING
 					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING   0                                          60m
 `,
 		},
 		"should print workload list with localqueue filter (short flag)": {
@@ -128,8 +128,8 @@ wl1               j1         lq1          cq1            PENDING
 					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING   0                                          60m
 `,
 		},
 		"should print workload list with clusterqueue filter": {
@@ -150,8 +150,8 @@ wl1               j1         lq1          cq1            PENDING
 					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING   0                                          60m
 `,
 		},
 		"should print workload list with clusterqueue filter (short flag)": {
@@ -172,8 +172,8 @@ wl1               j1         lq1          cq1            PENDING
 					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING   0                                          60m
 `,
 		},
 		"should print workload list with all status flag": {
@@ -253,12 +253,12 @@ wl1               j1         lq1          cq1            PENDING
 					}...).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                    60m
-wl2               j2         lq2          cq2            ADMITTED                       60m         120m
-wl3               j3         lq3          cq3            PENDING                                    120m
-wl4               j4         lq4          cq4            FINISHED                       60m         3h
-wl5               j5         lq5          cq5            ADMITTED                       120m        3h
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING    0                                          60m
+wl2               j2         lq2          cq2            ADMITTED   0                              60m         120m
+wl3               j3         lq3          cq3            PENDING    0                                          120m
+wl4               j4         lq4          cq4            FINISHED   0                              60m         3h
+wl5               j5         lq5          cq5            ADMITTED   0                              120m        3h
 `,
 		},
 		"should print workload list with only admitted and finished status flags": {
@@ -305,9 +305,9 @@ wl5               j5         lq5          cq5            ADMITTED
 					}...).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     POSITION IN QUEUE   EXEC TIME   AGE
-wl2               j2         lq2          cq2            ADMITTED                       60m         120m
-wl3               j3         lq3          cq3            FINISHED                       60m         3h
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl2               j2         lq2          cq2            ADMITTED   0                              60m         120m
+wl3               j3         lq3          cq3            FINISHED   0                              60m         3h
 `,
 		},
 		"should print workload list with only pending filter": {
@@ -334,8 +334,8 @@ wl3               j3         lq3          cq3            FINISHED
 					}...).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING   0                                          60m
 `,
 		},
 		"should print workload list with only quotareserved filter": {
@@ -368,8 +368,8 @@ wl1               j1         lq1          cq1            PENDING
 					}...).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS          POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            QUOTARESERVED                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS          PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1               j1         lq1          cq1            QUOTARESERVED   0                                          60m
 `,
 		},
 		"should print workload list with only admitted filter": {
@@ -404,8 +404,8 @@ wl1               j1         lq1          cq1            QUOTARESERVED
 					}...).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            ADMITTED                       60m         60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1               j1         lq1          cq1            ADMITTED   0                              60m         60m
 `,
 		},
 		"should print workload list with only finished status filter": {
@@ -450,8 +450,8 @@ wl1               j1         lq1          cq1            ADMITTED
 					}...).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            FINISHED                       60m         60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1               j1         lq1          cq1            FINISHED   0                              60m         60m
 `,
 		},
 		"should print workload list with label selector filter": {
@@ -474,8 +474,8 @@ wl1               j1         lq1          cq1            FINISHED
 					Label("key", "value2").
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING   0                                          60m
 `,
 		},
 		"should print workload list with label selector filter (short flag)": {
@@ -498,8 +498,8 @@ wl1               j1         lq1          cq1            PENDING
 					Label("key", "value2").
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING                                   60m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING   0                                          60m
 `,
 		},
 		"should print workload list with Job types": {
@@ -557,10 +557,10 @@ wl1               j1         lq1          cq1            PENDING
 					Creation(testStartTime.Add(-3 * time.Hour).Truncate(time.Second)).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE                  JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1    job                       j1         lq1          cq1            PENDING                                   60m
-wl2    rayjob.ray.io             j2         lq2          cq2            PENDING                                   120m
-wl3    pytorchjob.kubeflow....   j3         lq3          cq3            PENDING                                   3h
+			wantOut: `NAME   JOB TYPE                  JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1    job                       j1         lq1          cq1            PENDING   0                                          60m
+wl2    rayjob.ray.io             j2         lq2          cq2            PENDING   0                                          120m
+wl3    pytorchjob.kubeflow....   j3         lq3          cq3            PENDING   0                                          3h
 `,
 		},
 		"should print workload list with resource filter": {
@@ -616,8 +616,8 @@ wl3    pytorchjob.kubeflow....   j3         lq3          cq3            PENDING
 					},
 				},
 			},
-			wantOut: `NAME   JOB TYPE    JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1    job.batch   job-test   lq1          cq1            PENDING                                   120m
+			wantOut: `NAME   JOB TYPE    JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1    job.batch   job-test   lq1          cq1            PENDING   0                                          120m
 `,
 		},
 		"should print workload list with resource filter and composable jobs": {
@@ -682,8 +682,8 @@ wl1    job.batch   job-test   lq1          cq1            PENDING
 					},
 				},
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME     LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl2    pod        pod-test-1   lq2          cq2            PENDING                                   3h
+			wantOut: `NAME   JOB TYPE   JOB NAME     LOCALQUEUE   CLUSTERQUEUE   STATUS    PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl2    pod        pod-test-1   lq2          cq2            PENDING   0                                          3h
 `,
 		},
 		"should print workload list with custom resource filter": {
@@ -750,8 +750,8 @@ wl2    pod        pod-test-1   lq2          cq2            PENDING
 					},
 				},
 			},
-			wantOut: `NAME   JOB TYPE        JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1    rayjob.ray.io   job-test   lq1          cq1            PENDING                                   120m
+			wantOut: `NAME   JOB TYPE        JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1    rayjob.ray.io   job-test   lq1          cq1            PENDING   0                                          120m
 `,
 		},
 		"should print workload list with full resource filter": {
@@ -818,8 +818,8 @@ wl1    rayjob.ray.io   job-test   lq1          cq1            PENDING
 					},
 				},
 			},
-			wantOut: `NAME   JOB TYPE        JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1    rayjob.ray.io   job-test   lq1          cq1            PENDING                                   120m
+			wantOut: `NAME   JOB TYPE        JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1    rayjob.ray.io   job-test   lq1          cq1            PENDING   0                                          120m
 `,
 		},
 		"should print workload list with position in queue": {
@@ -861,9 +861,112 @@ wl1    rayjob.ray.io   job-test   lq1          cq1            PENDING
 					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
 					Obj(),
 			},
-			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    POSITION IN QUEUE   EXEC TIME   AGE
-wl1               j1         lq1          cq1            PENDING   12                              60m
-wl2               j2         lq2          cq2            PENDING   22                              120m
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS    PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING   10         12                              60m
+wl2               j2         lq2          cq2            PENDING   20         22                              120m
+`,
+		},
+		"should print workload list sorted by position": {
+			args: []string{"--sort-by", "position"},
+			pendingWorkloads: []visibility.PendingWorkload{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "wl1",
+						Namespace: metav1.NamespaceDefault,
+					},
+					LocalQueueName:         "lq1",
+					PositionInClusterQueue: 1,
+					PositionInLocalQueue:   1,
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "wl2",
+						Namespace: metav1.NamespaceDefault,
+					},
+					LocalQueueName:         "lq2",
+					PositionInClusterQueue: 0,
+					PositionInLocalQueue:   0,
+				},
+			},
+			objs: []runtime.Object{
+				utiltesting.MakeLocalQueue("lq1", metav1.NamespaceDefault).ClusterQueue("cq1").Obj(),
+				utiltesting.MakeLocalQueue("lq2", metav1.NamespaceDefault).ClusterQueue("cq1").Obj(),
+				utiltesting.MakeWorkload("wl0", metav1.NamespaceDefault).
+					OwnerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "j0", "test-uid").
+					Queue("lq1").
+					Active(true).
+					Admission(utiltesting.MakeAdmission("cq1").Obj()).
+					Condition(metav1.Condition{
+						Type:   kueue.WorkloadAdmitted,
+						Status: metav1.ConditionTrue,
+					}).
+					Creation(testStartTime.Add(-3 * time.Hour).Truncate(time.Second)).
+					Obj(),
+				utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+					OwnerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "j1", "test-uid").
+					Queue("lq1").
+					Active(true).
+					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
+					Obj(),
+				utiltesting.MakeWorkload("wl2", metav1.NamespaceDefault).
+					OwnerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "j2", "test-uid").
+					Queue("lq2").
+					Active(true).
+					Creation(testStartTime.Add(-1 * time.Hour).Truncate(time.Second)).
+					Obj(),
+			},
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl2               j2         lq2          cq1            PENDING    0          0                               60m
+wl1               j1         lq1          cq1            PENDING    0          1                               120m
+wl0               j0         lq1          cq1            ADMITTED   0                              0s          3h
+`,
+		},
+		"should print workload list sorted by priority": {
+			args: []string{"--sort-by", "priority"},
+			pendingWorkloads: []visibility.PendingWorkload{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "wl1",
+						Namespace: metav1.NamespaceDefault,
+					},
+					Priority:             20,
+					LocalQueueName:       "lq1",
+					PositionInLocalQueue: 0,
+				},
+			},
+			objs: []runtime.Object{
+				utiltesting.MakeLocalQueue("lq1", metav1.NamespaceDefault).ClusterQueue("cq1").Obj(),
+				utiltesting.MakeWorkload("wl0", metav1.NamespaceDefault).
+					OwnerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "j0", "test-uid").
+					Queue("lq1").
+					Priority(10).
+					Active(true).
+					Admission(utiltesting.MakeAdmission("cq1").Obj()).
+					Condition(metav1.Condition{
+						Type:   kueue.WorkloadAdmitted,
+						Status: metav1.ConditionTrue,
+					}).
+					Creation(testStartTime.Add(-3 * time.Hour).Truncate(time.Second)).
+					Obj(),
+				utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+					OwnerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "j1", "test-uid").
+					Queue("lq1").
+					Priority(5).
+					Active(true).
+					Creation(testStartTime.Add(-2 * time.Hour).Truncate(time.Second)).
+					Obj(),
+				utiltesting.MakeWorkload("wl2", metav1.NamespaceDefault).
+					OwnerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "j2", "test-uid").
+					Queue("lq1").
+					Priority(10).
+					Active(true).
+					Creation(testStartTime.Add(-1 * time.Hour).Truncate(time.Second)).
+					Obj(),
+			},
+			wantOut: `NAME   JOB TYPE   JOB NAME   LOCALQUEUE   CLUSTERQUEUE   STATUS     PRIORITY   POSITION IN QUEUE   EXEC TIME   AGE
+wl1               j1         lq1          cq1            PENDING    20         0                               120m
+wl0               j0         lq1          cq1            ADMITTED   10                             0s          3h
+wl2               j2         lq1          cq1            PENDING    10                                         60m
 `,
 		},
 		"should print not found error": {
@@ -0,0 +1,404 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/dynamic"
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/workload"
+
+	_ "sigs.k8s.io/kueue/pkg/controller/jobs"
+)
+
+const defaultKueueNamespace = "kueue-system"
+
+var (
+	wlLong = templates.LongDesc(`
+		Prints the logs of the pods of a Workload dispatched by MultiKueue,
+		from the worker cluster where it is running.
+
+		The worker cluster is the one in which the copy of the Workload has
+		quota reserved. The command connects to it with the kubeconfig of the
+		MultiKueueCluster, read from its Secret in the namespace of Kueue, so
+		it requires the permission to read that Secret.
+	`)
+	wlExample = templates.Examples(`
+		# Print the logs of the pods of the workload
+		kueuectl logs workload my-workload
+
+		# Follow the logs of a container of the pods of the workload
+		kueuectl logs workload my-workload -c main -f
+
+		# Print the last 20 lines of the logs, when Kueue runs in another namespace
+		kueuectl logs workload my-workload --tail 20 --kueue-namespace my-kueue
+	`)
+)
+
+// remoteClientsFunc returns the clients of a worker cluster, given its kubeconfig.
+type remoteClientsFunc func(kubeconfig []byte) (versioned.Interface, k8s.Interface, error)
+
+type WorkloadOptions struct {
+	Name           string
+	Namespace      string
+	KueueNamespace string
+
+	Container string
+	Follow    bool
+	TailLines int64
+
+	ClientSet     versioned.Interface
+	K8sClientSet  k8s.Interface
+	DynamicClient dynamic.Interface
+	RestMapper    apimeta.RESTMapper
+
+	newRemoteClients remoteClientsFunc
+
+	genericiooptions.IOStreams
+}
+
+func NewWorkloadOptions(streams genericiooptions.IOStreams) *WorkloadOptions {
+	return &WorkloadOptions{
+		IOStreams:        streams,
+		newRemoteClients: newRemoteClients,
+	}
+}
+
+func NewWorkloadCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	return newWorkloadCmd(clientGetter, NewWorkloadOptions(streams))
+}
+
+func newWorkloadCmd(clientGetter util.ClientGetter, o *WorkloadOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "workload NAME [--namespace NAMESPACE] [--container CONTAINER] [--follow] [--tail LINES] [--kueue-namespace NAMESPACE]",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"wl"},
+		Short:                 "Print the logs of the pods of a MultiKueue Workload",
+		Long:                  wlLong,
+		Example:               wlExample,
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgsFunction:     completion.WorkloadNameFunc(clientGetter, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter, args)
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Container, "container", "c", "",
+		"Print the logs of this container. Defaults to all the containers of the pods.")
+	cmd.Flags().BoolVarP(&o.Follow, "follow", "f", false,
+		"Specify if the logs should be streamed.")
+	cmd.Flags().Int64Var(&o.TailLines, "tail", -1,
+		"Lines of recent log file to display. Defaults to -1, showing all log lines.")
+	cmd.Flags().StringVar(&o.KueueNamespace, "kueue-namespace", defaultKueueNamespace,
+		"The namespace in which Kueue runs, where the Secrets of the MultiKueueClusters are stored.")
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *WorkloadOptions) Complete(clientGetter util.ClientGetter, args []string) error {
+	o.Name = args[0]
+
+	var err error
+
+	o.Namespace, _, err = clientGetter.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	o.ClientSet, err = clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.K8sClientSet, err = clientGetter.K8sClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.DynamicClient, err = clientGetter.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	o.RestMapper, err = clientGetter.ToRESTMapper()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run prints the logs of the pods of the Workload in its worker cluster
+func (o *WorkloadOptions) Run(ctx context.Context) error {
+	wl, err := o.ClientSet.KueueV1beta1().Workloads(o.Namespace).Get(ctx, o.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	clusters, err := o.multiKueueClusters(ctx, wl)
+	if err != nil {
+		return err
+	}
+	if len(clusters) == 0 {
+		return fmt.Errorf("workload %s is not dispatched by MultiKueue, use kubectl logs to print the logs of its pods", workload.Key(wl))
+	}
+
+	selector, err := o.podLabelSelector(ctx, wl)
+	if err != nil {
+		return err
+	}
+
+	cluster, remote, err := o.workerCluster(ctx, wl, clusters)
+	if err != nil {
+		return err
+	}
+
+	pods, err := remote.CoreV1().Pods(wl.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	if len(pods.Items) == 0 {
+		return fmt.Errorf("no pods found for workload %s in the worker cluster %s", workload.Key(wl), cluster)
+	}
+	slices.SortFunc(pods.Items, func(a, b corev1.Pod) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var streams []logStream
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			if o.Container == "" || o.Container == container.Name {
+				streams = append(streams, logStream{pod: pod.Name, container: container.Name})
+			}
+		}
+	}
+	if len(streams) == 0 {
+		return fmt.Errorf("container %s not found in the pods of workload %s", o.Container, workload.Key(wl))
+	}
+
+	return o.printLogs(ctx, remote, wl.Namespace, streams)
+}
+
+// multiKueueClusters returns the names of the MultiKueueClusters to which the
+// Workload can be dispatched, through its MultiKueue admission checks.
+func (o *WorkloadOptions) multiKueueClusters(ctx context.Context, wl *v1beta1.Workload) ([]string, error) {
+	var clusters []string
+	for _, acs := range wl.Status.AdmissionChecks {
+		ac, err := o.ClientSet.KueueV1beta1().AdmissionChecks().Get(ctx, acs.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if ac.Spec.ControllerName != v1beta1.MultiKueueControllerName || ac.Spec.Parameters == nil {
+			continue
+		}
+		config, err := o.ClientSet.KueueV1beta1().MultiKueueConfigs().Get(ctx, ac.Spec.Parameters.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, config.Spec.Clusters...)
+	}
+	return clusters, nil
+}
+
+// podLabelSelector returns the label selector of the pods of the job owning
+// the Workload, which has the same name and namespace in the worker cluster.
+func (o *WorkloadOptions) podLabelSelector(ctx context.Context, wl *v1beta1.Workload) (string, error) {
+	owner := metav1.GetControllerOf(wl)
+	if owner == nil {
+		return "", fmt.Errorf("workload %s has no owner", workload.Key(wl))
+	}
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		return "", err
+	}
+	gvk := gv.WithKind(owner.Kind)
+	cbs, ok := jobframework.GetIntegrationByGVK(gvk)
+	if !ok || cbs.NewJob == nil {
+		return "", fmt.Errorf("%s is not supported by Kueue", owner.Kind)
+	}
+	job := cbs.NewJob()
+	jobWithPodLabelSelector, ok := job.(jobframework.JobWithPodLabelSelector)
+	if !ok {
+		return "", fmt.Errorf("the pods of %s can't be selected", owner.Kind)
+	}
+
+	mapping, err := o.RestMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return "", err
+	}
+	obj, err := o.DynamicClient.Resource(mapping.Resource).Namespace(wl.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), job.Object()); err != nil {
+		return "", fmt.Errorf("failed to convert unstructured object: %w", err)
+	}
+	return jobWithPodLabelSelector.PodLabelSelector(), nil
+}
+
+// workerCluster returns the name and the client of the cluster in which the
+// copy of the Workload has quota reserved.
+func (o *WorkloadOptions) workerCluster(ctx context.Context, wl *v1beta1.Workload, clusters []string) (string, k8s.Interface, error) {
+	var errs []error
+	for _, cluster := range clusters {
+		remoteClientSet, remoteK8sClientSet, err := o.connect(ctx, cluster)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		remoteWl, err := remoteClientSet.KueueV1beta1().Workloads(wl.Namespace).Get(ctx, wl.Name, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		if workload.HasQuotaReservation(remoteWl) {
+			return cluster, remoteK8sClientSet, nil
+		}
+	}
+	err := fmt.Errorf("workload %s is not running in any of the worker clusters %s", workload.Key(wl), strings.Join(clusters, ", "))
+	return "", nil, errors.Join(append([]error{err}, errs...)...)
+}
+
+// connect returns the clients of the MultiKueueCluster, built from its kubeconfig.
+func (o *WorkloadOptions) connect(ctx context.Context, cluster string) (versioned.Interface, k8s.Interface, error) {
+	mkc, err := o.ClientSet.KueueV1beta1().MultiKueueClusters().Get(ctx, cluster, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	if mkc.Spec.KubeConfig.LocationType != v1beta1.SecretLocationType {
+		return nil, nil, fmt.Errorf("the kubeconfig of multikueuecluster %s is not stored in a Secret", cluster)
+	}
+	secret, err := o.K8sClientSet.CoreV1().Secrets(o.KueueNamespace).Get(ctx, mkc.Spec.KubeConfig.Location, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	kubeconfig, found := secret.Data[v1beta1.MultiKueueConfigSecretKey]
+	if !found {
+		return nil, nil, fmt.Errorf("key %q not found in secret %s/%s", v1beta1.MultiKueueConfigSecretKey, o.KueueNamespace, secret.Name)
+	}
+	return o.newRemoteClients(kubeconfig)
+}
+
+type logStream struct {
+	pod       string
+	container string
+}
+
+// printLogs prints the logs of the containers, prefixed with the pod and the
+// container names when there are several. The logs are printed one container
+// after the other, unless they are followed.
+func (o *WorkloadOptions) printLogs(ctx context.Context, remote k8s.Interface, namespace string, streams []logStream) error {
+	var mu sync.Mutex
+	printStream := func(s logStream) error {
+		opts := &corev1.PodLogOptions{
+			Container: s.container,
+			Follow:    o.Follow,
+		}
+		if o.TailLines >= 0 {
+			opts.TailLines = ptr.To(o.TailLines)
+		}
+		logs, err := remote.CoreV1().Pods(namespace).GetLogs(s.pod, opts).Stream(ctx)
+		if err != nil {
+			return err
+		}
+		defer logs.Close()
+
+		var prefix string
+		if len(streams) > 1 {
+			prefix = fmt.Sprintf("[pod/%s/%s] ", s.pod, s.container)
+		}
+		scanner := bufio.NewScanner(logs)
+		for scanner.Scan() {
+			mu.Lock()
+			_, err := io.WriteString(o.Out, prefix+scanner.Text()+"\n")
+			mu.Unlock()
+			if err != nil {
+				return err
+			}
+		}
+		return scanner.Err()
+	}
+
+	if !o.Follow {
+		for _, s := range streams {
+			if err := printStream(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(streams))
+	for i, s := range streams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = printStream(s)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func newRemoteClients(kubeconfig []byte) (versioned.Interface, k8s.Interface, error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	clientSet, err := versioned.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	k8sClientSet, err := k8s.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return clientSet, k8sClientSet, nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8s "k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
+)
+
+type remoteCluster struct {
+	workloads []runtime.Object
+	pods      []runtime.Object
+}
+
+func TestWorkloadCmd(t *testing.T) {
+	jobGVK := batchv1.SchemeGroupVersion.WithKind("Job")
+	multiKueueObjs := []runtime.Object{
+		utiltesting.MakeAdmissionCheck("multikueue").
+			ControllerName(v1beta1.MultiKueueControllerName).
+			Parameters(v1beta1.GroupVersion.Group, "MultiKueueConfig", "config").
+			Obj(),
+		utiltesting.MakeMultiKueueConfig("config").Clusters("worker1", "worker2").Obj(),
+		utiltesting.MakeMultiKueueCluster("worker1").KubeConfig(v1beta1.SecretLocationType, "worker1-secret").Obj(),
+		utiltesting.MakeMultiKueueCluster("worker2").KubeConfig(v1beta1.SecretLocationType, "worker2-secret").Obj(),
+	}
+	secrets := []runtime.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker1-secret", Namespace: "kueue-system"},
+			Data:       map[string][]byte{v1beta1.MultiKueueConfigSecretKey: []byte("worker1")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker2-secret", Namespace: "kueue-system"},
+			Data:       map[string][]byte{v1beta1.MultiKueueConfigSecretKey: []byte("worker2")},
+		},
+	}
+	localWl := utiltesting.MakeWorkload("wl", metav1.NamespaceDefault).
+		ControllerReference(jobGVK, "job", "job-uid").
+		AdmissionCheck(v1beta1.AdmissionCheckState{Name: "multikueue", State: v1beta1.CheckStateReady}).
+		ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+		Obj()
+	reservingWl := utiltesting.MakeWorkload("wl", metav1.NamespaceDefault).
+		ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+		Obj()
+	pendingWl := utiltesting.MakeWorkload("wl", metav1.NamespaceDefault).Obj()
+	pod := func(name string, containers ...string) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+				Labels:    map[string]string{batchv1.JobNameLabel: "job"},
+			},
+		}
+		for _, c := range containers {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: c})
+		}
+		return p
+	}
+
+	testCases := map[string]struct {
+		objs    []runtime.Object
+		remotes map[string]remoteCluster
+		args    []string
+		wantOut string
+		wantErr string
+	}{
+		"prints the logs from the reserving worker": {
+			objs: append([]runtime.Object{localWl}, multiKueueObjs...),
+			remotes: map[string]remoteCluster{
+				"worker1": {workloads: []runtime.Object{pendingWl}},
+				"worker2": {
+					workloads: []runtime.Object{reservingWl},
+					pods:      []runtime.Object{pod("job-b", "main"), pod("job-a", "main"), pod("other")},
+				},
+			},
+			args: []string{"wl"},
+			wantOut: `[pod/job-a/main] fake logs
+[pod/job-b/main] fake logs
+`,
+		},
+		"single container": {
+			objs: append([]runtime.Object{localWl}, multiKueueObjs...),
+			remotes: map[string]remoteCluster{
+				"worker1": {
+					workloads: []runtime.Object{reservingWl},
+					pods:      []runtime.Object{pod("job-a", "main", "sidecar")},
+				},
+			},
+			args:    []string{"wl", "-c", "sidecar"},
+			wantOut: "fake logs\n",
+		},
+		"container not found": {
+			objs: append([]runtime.Object{localWl}, multiKueueObjs...),
+			remotes: map[string]remoteCluster{
+				"worker1": {
+					workloads: []runtime.Object{reservingWl},
+					pods:      []runtime.Object{pod("job-a", "main")},
+				},
+			},
+			args:    []string{"wl", "-c", "sidecar"},
+			wantErr: "container sidecar not found in the pods of workload default/wl",
+		},
+		"not running in a worker": {
+			objs: append([]runtime.Object{localWl}, multiKueueObjs...),
+			remotes: map[string]remoteCluster{
+				"worker1": {workloads: []runtime.Object{pendingWl}},
+			},
+			args: []string{"wl"},
+			wantErr: `workload default/wl is not running in any of the worker clusters worker1, worker2
+unknown cluster worker2`,
+		},
+		"not dispatched by MultiKueue": {
+			objs: []runtime.Object{
+				utiltesting.MakeWorkload("wl", metav1.NamespaceDefault).
+					ControllerReference(jobGVK, "job", "job-uid").
+					AdmissionCheck(v1beta1.AdmissionCheckState{Name: "prov", State: v1beta1.CheckStateReady}).
+					Obj(),
+				utiltesting.MakeAdmissionCheck("prov").ControllerName("kueue.x-k8s.io/provisioning-request").Obj(),
+			},
+			args:    []string{"wl"},
+			wantErr: "workload default/wl is not dispatched by MultiKueue, use kubectl logs to print the logs of its pods",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			restMapper := meta.NewDefaultRESTMapper(nil)
+			restMapper.Add(jobGVK, meta.RESTScopeNamespace)
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(k8sscheme.Scheme, testingjob.MakeJob("job", metav1.NamespaceDefault).Obj())
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(fake.NewSimpleClientset(tc.objs...)).
+				WithK8sClientset(k8sfake.NewSimpleClientset(secrets...)).
+				WithDynamicClient(dynamicClient).
+				WithRESTMapper(restMapper)
+
+			o := NewWorkloadOptions(streams)
+			o.newRemoteClients = func(kubeconfig []byte) (versioned.Interface, k8s.Interface, error) {
+				remote, found := tc.remotes[string(kubeconfig)]
+				if !found {
+					return nil, nil, fmt.Errorf("unknown cluster %s", kubeconfig)
+				}
+				return fake.NewSimpleClientset(remote.workloads...), k8sfake.NewSimpleClientset(remote.pods...), nil
+			}
+			cmd := newWorkloadCmd(tcg, o)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			gotOut := out.String()
+			if diff := cmp.Diff(tc.wantOut, gotOut); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			if tc.wantErr == "" {
+				if gotOutErr := outErr.String(); gotOutErr != "" {
+					t.Errorf("Unexpected error output: %s", gotOutErr)
+				}
+			}
+		})
+	}
+}
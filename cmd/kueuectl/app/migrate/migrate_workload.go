@@ -0,0 +1,450 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	kueuev1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// pollInterval is the interval at which the eviction of the requeued workloads is checked.
+var pollInterval = time.Second
+
+const defaultRequeueTimeout = 5 * time.Minute
+
+var (
+	wlLong = templates.LongDesc(`
+		Moves the pending Workloads from a LocalQueue to another LocalQueue
+		in the same namespace, or from the LocalQueues of a ClusterQueue to
+		the LocalQueues of another ClusterQueue, in all namespaces.
+
+		The queue name label of the jobs owning the Workloads is rewritten,
+		so that the jobs stay in the new queue. The Workloads which have
+		quota reserved are skipped, unless --requeue-admitted is set. In that
+		case, the Workloads are deactivated until they are evicted, moved, and
+		activated again in the new queue.
+	`)
+	wlExample = templates.Examples(`
+		# Move the pending workloads from a localqueue to another
+		kueuectl migrate workload --from-localqueue team-a --to-localqueue team-a-gpu
+
+		# Move all the workloads from the localqueues of a clusterqueue to the
+		# localqueues of another clusterqueue, in all namespaces
+		kueuectl migrate workload --from-clusterqueue old-cq --to-clusterqueue new-cq --requeue-admitted
+	`)
+)
+
+type WorkloadOptions struct {
+	FromLocalQueue   string
+	ToLocalQueue     string
+	FromClusterQueue string
+	ToClusterQueue   string
+	RequeueAdmitted  bool
+	Timeout          time.Duration
+
+	Namespace string
+
+	DryRunStrategy util.DryRunStrategy
+
+	Client        kueuev1beta1.KueueV1beta1Interface
+	DynamicClient dynamic.Interface
+	RestMapper    meta.RESTMapper
+
+	genericiooptions.IOStreams
+}
+
+func NewWorkloadOptions(streams genericiooptions.IOStreams) *WorkloadOptions {
+	return &WorkloadOptions{
+		Timeout:   defaultRequeueTimeout,
+		IOStreams: streams,
+	}
+}
+
+func NewWorkloadCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewWorkloadOptions(streams)
+
+	cmd := &cobra.Command{
+		Use: "workload (--from-localqueue NAME --to-localqueue NAME | --from-clusterqueue NAME --to-clusterqueue NAME) " +
+			"[--namespace NAMESPACE] [--requeue-admitted] [--timeout DURATION] [--dry-run STRATEGY]",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"wl", "workloads"},
+		Short:                 "Move the Workloads to another queue",
+		Long:                  wlLong,
+		Example:               wlExample,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter, cmd)
+			if err != nil {
+				return err
+			}
+
+			err = o.Validate()
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.FromLocalQueue, "from-localqueue", "",
+		"Name of the LocalQueue to move the workloads from.")
+	cmd.Flags().StringVar(&o.ToLocalQueue, "to-localqueue", "",
+		"Name of the LocalQueue to move the workloads to, in the same namespace.")
+	cmd.Flags().StringVar(&o.FromClusterQueue, "from-clusterqueue", "",
+		"Name of the ClusterQueue to move the workloads from, through its LocalQueues in all namespaces.")
+	cmd.Flags().StringVar(&o.ToClusterQueue, "to-clusterqueue", "",
+		"Name of the ClusterQueue to move the workloads to, through the LocalQueue pointing to it in the namespace of each workload.")
+	cmd.Flags().BoolVar(&o.RequeueAdmitted, "requeue-admitted", false,
+		"If present, also move the workloads which have quota reserved, by evicting them first.")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", defaultRequeueTimeout,
+		"The length of time to wait for each admitted workload to be evicted, when --requeue-admitted is set.")
+	util.AddDryRunFlag(cmd)
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *WorkloadOptions) Complete(clientGetter util.ClientGetter, cmd *cobra.Command) error {
+	var err error
+
+	o.Namespace, _, err = clientGetter.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	o.DryRunStrategy, err = util.GetDryRunStrategy(cmd)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.Client = clientset.KueueV1beta1()
+
+	o.DynamicClient, err = clientGetter.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	o.RestMapper, err = clientGetter.ToRESTMapper()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *WorkloadOptions) Validate() error {
+	byLocalQueue := o.FromLocalQueue != "" || o.ToLocalQueue != ""
+	byClusterQueue := o.FromClusterQueue != "" || o.ToClusterQueue != ""
+	switch {
+	case byLocalQueue && byClusterQueue:
+		return errors.New("the localqueue and clusterqueue flags are mutually exclusive")
+	case byLocalQueue && (o.FromLocalQueue == "" || o.ToLocalQueue == ""):
+		return errors.New("both --from-localqueue and --to-localqueue must be set")
+	case byLocalQueue && o.FromLocalQueue == o.ToLocalQueue:
+		return errors.New("the source and target localqueues must be different")
+	case byClusterQueue && (o.FromClusterQueue == "" || o.ToClusterQueue == ""):
+		return errors.New("both --from-clusterqueue and --to-clusterqueue must be set")
+	case byClusterQueue && o.FromClusterQueue == o.ToClusterQueue:
+		return errors.New("the source and target clusterqueues must be different")
+	case !byLocalQueue && !byClusterQueue:
+		return errors.New("either --from-localqueue and --to-localqueue, or --from-clusterqueue and --to-clusterqueue must be set")
+	}
+	return nil
+}
+
+// migration is the move of a Workload to the target LocalQueue in its namespace.
+type migration struct {
+	wl     *v1beta1.Workload
+	target string
+	// skipReason explains why the Workload can't be moved, if set.
+	skipReason string
+}
+
+// Run moves the Workloads
+func (o *WorkloadOptions) Run(ctx context.Context) error {
+	var migrations []migration
+	var err error
+	if o.FromLocalQueue != "" {
+		migrations, err = o.localQueueMigrations(ctx)
+	} else {
+		migrations, err = o.clusterQueueMigrations(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	var moved, skipped int
+	for _, m := range migrations {
+		if m.skipReason == "" && workload.HasQuotaReservation(m.wl) && !o.RequeueAdmitted {
+			m.skipReason = fmt.Sprintf("it has quota reserved in clusterqueue %s", m.wl.Status.Admission.ClusterQueue)
+		}
+		if m.skipReason != "" {
+			fmt.Fprintf(o.Out, "workload %s skipped, %s\n", workload.Key(m.wl), m.skipReason)
+			skipped++
+			continue
+		}
+		if workload.HasQuotaReservation(m.wl) {
+			if err := o.requeue(ctx, m); err != nil {
+				return fmt.Errorf("requeueing workload %s: %w", workload.Key(m.wl), err)
+			}
+		} else if err := o.move(ctx, m, false); err != nil {
+			return fmt.Errorf("moving workload %s: %w", workload.Key(m.wl), err)
+		}
+		moved++
+	}
+
+	fmt.Fprintf(o.Out, "%d workloads moved, %d skipped%s\n", moved, skipped, o.dryRunSuffix())
+	return nil
+}
+
+// localQueueMigrations returns the moves of the Workloads from the source to
+// the target LocalQueue, in the namespace.
+func (o *WorkloadOptions) localQueueMigrations(ctx context.Context) ([]migration, error) {
+	if _, err := o.Client.LocalQueues(o.Namespace).Get(ctx, o.ToLocalQueue, metav1.GetOptions{}); err != nil {
+		return nil, err
+	}
+	list, err := o.Client.Workloads(o.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var migrations []migration
+	for i := range list.Items {
+		wl := &list.Items[i]
+		if wl.Spec.QueueName == o.FromLocalQueue && !workload.IsFinished(wl) {
+			migrations = append(migrations, migration{wl: wl, target: o.ToLocalQueue})
+		}
+	}
+	sortMigrations(migrations)
+	return migrations, nil
+}
+
+// clusterQueueMigrations returns the moves of the Workloads from the LocalQueues
+// of the source ClusterQueue to the LocalQueue of the target ClusterQueue in
+// the namespace of each Workload.
+func (o *WorkloadOptions) clusterQueueMigrations(ctx context.Context) ([]migration, error) {
+	if _, err := o.Client.ClusterQueues().Get(ctx, o.ToClusterQueue, metav1.GetOptions{}); err != nil {
+		return nil, err
+	}
+	lqs, err := o.Client.LocalQueues("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	sources := make(map[string]bool)
+	targets := make(map[string][]string)
+	for _, lq := range lqs.Items {
+		switch string(lq.Spec.ClusterQueue) {
+		case o.FromClusterQueue:
+			sources[lq.Namespace+"/"+lq.Name] = true
+		case o.ToClusterQueue:
+			targets[lq.Namespace] = append(targets[lq.Namespace], lq.Name)
+		}
+	}
+
+	list, err := o.Client.Workloads("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var migrations []migration
+	for i := range list.Items {
+		wl := &list.Items[i]
+		if !sources[wl.Namespace+"/"+wl.Spec.QueueName] || workload.IsFinished(wl) {
+			continue
+		}
+		migrations = append(migrations, migration{wl: wl})
+	}
+	sortMigrations(migrations)
+
+	// Only move the workloads to an unambiguous LocalQueue.
+	for i := range migrations {
+		m := &migrations[i]
+		switch names := targets[m.wl.Namespace]; len(names) {
+		case 0:
+			m.skipReason = fmt.Sprintf("no localqueue in namespace %s points to clusterqueue %s", m.wl.Namespace, o.ToClusterQueue)
+		case 1:
+			m.target = names[0]
+		default:
+			slices.Sort(names)
+			m.skipReason = fmt.Sprintf("several localqueues in namespace %s point to clusterqueue %s: %s", m.wl.Namespace, o.ToClusterQueue, strings.Join(names, ", "))
+		}
+	}
+	return migrations, nil
+}
+
+func sortMigrations(migrations []migration) {
+	slices.SortFunc(migrations, func(a, b migration) int {
+		return strings.Compare(workload.Key(a.wl), workload.Key(b.wl))
+	})
+}
+
+// move rewrites the queue name of the Workload and of the objects owning it.
+// With activate, the Workload is also activated.
+func (o *WorkloadOptions) move(ctx context.Context, m migration, activate bool) error {
+	source := m.wl.Spec.QueueName
+	if o.DryRunStrategy != util.DryRunClient {
+		// The job reconciler resets the queue name of the Workload to the one of
+		// its job, so the label of the owners is rewritten first.
+		for _, owner := range m.wl.OwnerReferences {
+			if err := o.setQueueLabel(ctx, m.wl.Namespace, owner, m.target); err != nil {
+				return err
+			}
+		}
+
+		wlOriginal := m.wl.DeepCopy()
+		m.wl.Spec.QueueName = m.target
+		if activate {
+			m.wl.Spec.Active = ptr.To(true)
+		}
+		// The optimistic lock prevents moving the Workload if it got quota
+		// reserved since it was read.
+		data, err := client.MergeFromWithOptions(wlOriginal, client.MergeFromWithOptimisticLock{}).Data(m.wl)
+		if err != nil {
+			return err
+		}
+		if _, err := o.Client.Workloads(m.wl.Namespace).Patch(ctx, m.wl.Name, types.MergePatchType, data, o.patchOptions()); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(o.Out, "workload %s moved from localqueue %s to %s%s\n", workload.Key(m.wl), source, m.target, o.dryRunSuffix())
+	return nil
+}
+
+// setQueueLabel sets the queue name label of the owner of a Workload.
+func (o *WorkloadOptions) setQueueLabel(ctx context.Context, namespace string, owner metav1.OwnerReference, queue string) error {
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		return err
+	}
+	mapping, err := o.RestMapper.RESTMapping(gv.WithKind(owner.Kind).GroupKind(), gv.Version)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"labels": map[string]string{constants.QueueLabel: queue},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = o.DynamicClient.Resource(mapping.Resource).Namespace(namespace).Patch(ctx, owner.Name, types.MergePatchType, data, o.patchOptions())
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// requeue deactivates the Workload, waits until it is evicted, and moves it
+// to the target LocalQueue, activating it again.
+func (o *WorkloadOptions) requeue(ctx context.Context, m migration) error {
+	if o.DryRunStrategy != util.DryRunNone {
+		if o.DryRunStrategy == util.DryRunServer {
+			if err := o.setActive(ctx, m.wl, false); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(o.Out, "workload %s requeued from localqueue %s to %s%s\n", workload.Key(m.wl), m.wl.Spec.QueueName, m.target, o.dryRunSuffix())
+		return nil
+	}
+
+	if !ptr.Deref(m.wl.Spec.Active, true) {
+		return errors.New("the workload is deactivated")
+	}
+	if err := o.setActive(ctx, m.wl, false); err != nil {
+		return err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+	err := wait.PollUntilContextCancel(waitCtx, pollInterval, true, func(ctx context.Context) (bool, error) {
+		wl, err := o.Client.Workloads(m.wl.Namespace).Get(ctx, m.wl.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		m.wl = wl
+		return !workload.HasQuotaReservation(wl), nil
+	})
+	if err != nil {
+		if errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timed out waiting for the workload to be evicted, it stays deactivated in localqueue %s", m.wl.Spec.QueueName)
+		}
+		return err
+	}
+
+	return o.move(ctx, m, true)
+}
+
+func (o *WorkloadOptions) setActive(ctx context.Context, wl *v1beta1.Workload, active bool) error {
+	wlOriginal := wl.DeepCopy()
+	wl.Spec.Active = ptr.To(active)
+	data, err := client.MergeFrom(wlOriginal).Data(wl)
+	if err != nil {
+		return err
+	}
+	_, err = o.Client.Workloads(wl.Namespace).Patch(ctx, wl.Name, types.MergePatchType, data, o.patchOptions())
+	return err
+}
+
+func (o *WorkloadOptions) patchOptions() metav1.PatchOptions {
+	opts := metav1.PatchOptions{}
+	if o.DryRunStrategy == util.DryRunServer {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	return opts
+}
+
+func (o *WorkloadOptions) dryRunSuffix() string {
+	switch o.DryRunStrategy {
+	case util.DryRunClient:
+		return " (client dry run)"
+	case util.DryRunServer:
+		return " (server dry run)"
+	}
+	return ""
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	kubetesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// queueState is the queue name and activation of a Workload.
+type queueState struct {
+	QueueName string
+	Active    bool
+}
+
+func TestWorkloadCmd(t *testing.T) {
+	defer func(interval time.Duration) { pollInterval = interval }(pollInterval)
+	pollInterval = time.Millisecond
+
+	jobGVK := batchv1.SchemeGroupVersion.WithKind("Job")
+	makeJob := func(name, ns, queue string) *batchv1.Job {
+		return &batchv1.Job{
+			TypeMeta: metav1.TypeMeta{Kind: "Job", APIVersion: "batch/v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+				Labels:    map[string]string{constants.QueueLabel: queue},
+			},
+		}
+	}
+	admission := utiltesting.MakeAdmission("cq-a").Obj()
+
+	testCases := map[string]struct {
+		args       []string
+		objs       []runtime.Object
+		jobs       []runtime.Object
+		wantQueues map[string]queueState
+		wantJobs   map[string]string
+		wantOut    string
+		wantErr    string
+	}{
+		"should move the pending workloads between localqueues": {
+			args: []string{"--from-localqueue", "lq-a", "--to-localqueue", "lq-b"},
+			objs: []runtime.Object{
+				utiltesting.MakeLocalQueue("lq-b", metav1.NamespaceDefault).ClusterQueue("cq-b").Obj(),
+				utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+					ResourceVersion("1").
+					Queue("lq-a").
+					ControllerReference(jobGVK, "j1", "uid1").
+					Obj(),
+				utiltesting.MakeWorkload("wl2", metav1.NamespaceDefault).
+					ResourceVersion("1").
+					Queue("lq-a").
+					Active(false).
+					Obj(),
+				utiltesting.MakeWorkload("wl3", metav1.NamespaceDefault).
+					ResourceVersion("1").
+					Queue("lq-a").
+					ReserveQuota(admission).
+					Obj(),
+				utiltesting.MakeWorkload("wl4", metav1.NamespaceDefault).
+					ResourceVersion("1").
+					Queue("lq-a").
+					Finished().
+					Obj(),
+				utiltesting.MakeWorkload("wl5", metav1.NamespaceDefault).
+					ResourceVersion("1").
+					Queue("lq-c").
+					Obj(),
+			},
+			jobs: []runtime.Object{makeJob("j1", metav1.NamespaceDefault, "lq-a")},
+			wantQueues: map[string]queueState{
+				"default/wl1": {QueueName: "lq-b", Active: true},
+				"default/wl2": {QueueName: "lq-b", Active: false},
+				"default/wl3": {QueueName: "lq-a", Active: true},
+				"default/wl4": {QueueName: "lq-a", Active: true},
+				"default/wl5": {QueueName: "lq-c", Active: true},
+			},
+			wantJobs: map[string]string{"default/j1": "lq-b"},
+			wantOut: `workload default/wl1 moved from localqueue lq-a to lq-b
+workload default/wl2 moved from localqueue lq-a to lq-b
+workload default/wl3 skipped, it has quota reserved in clusterqueue cq-a
+2 workloads moved, 1 skipped
+`,
+		},
+		"should requeue the admitted workloads": {
+			args: []string{"--from-localqueue", "lq-a", "--to-localqueue", "lq-b", "--requeue-admitted"},
+			objs: []runtime.Object{
+				utiltesting.MakeLocalQueue("lq-b", metav1.NamespaceDefault).ClusterQueue("cq-b").Obj(),
+				utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+					ResourceVersion("1").
+					Queue("lq-a").
+					ControllerReference(jobGVK, "j1", "uid1").
+					ReserveQuota(admission).
+					Admitted(true).
+					Obj(),
+			},
+			jobs: []runtime.Object{makeJob("j1", metav1.NamespaceDefault, "lq-a")},
+			wantQueues: map[string]queueState{
+				"default/wl1": {QueueName: "lq-b", Active: true},
+			},
+			wantJobs: map[string]string{"default/j1": "lq-b"},
+			wantOut: `workload default/wl1 moved from localqueue lq-a to lq-b
+1 workloads moved, 0 skipped
+`,
+		},
+		"should move the workloads between the localqueues of the clusterqueues": {
+			args: []string{"--from-clusterqueue", "cq-a", "--to-clusterqueue", "cq-b"},
+			objs: []runtime.Object{
+				utiltesting.MakeClusterQueue("cq-b").Obj(),
+				utiltesting.MakeLocalQueue("lq-a", "ns1").ClusterQueue("cq-a").Obj(),
+				utiltesting.MakeLocalQueue("lq-b", "ns1").ClusterQueue("cq-b").Obj(),
+				utiltesting.MakeLocalQueue("lq-a", "ns2").ClusterQueue("cq-a").Obj(),
+				utiltesting.MakeLocalQueue("lq-a", "ns3").ClusterQueue("cq-a").Obj(),
+				utiltesting.MakeLocalQueue("lq-b1", "ns3").ClusterQueue("cq-b").Obj(),
+				utiltesting.MakeLocalQueue("lq-b2", "ns3").ClusterQueue("cq-b").Obj(),
+				utiltesting.MakeWorkload("wl1", "ns1").
+					ResourceVersion("1").
+					Queue("lq-a").
+					ControllerReference(jobGVK, "j1", "uid1").
+					Obj(),
+				utiltesting.MakeWorkload("wl2", "ns1").
+					ResourceVersion("1").
+					Queue("lq-b").
+					Obj(),
+				utiltesting.MakeWorkload("wl3", "ns2").
+					ResourceVersion("1").
+					Queue("lq-a").
+					Obj(),
+				utiltesting.MakeWorkload("wl4", "ns3").
+					ResourceVersion("1").
+					Queue("lq-a").
+					Obj(),
+			},
+			jobs: []runtime.Object{makeJob("j1", "ns1", "lq-a")},
+			wantQueues: map[string]queueState{
+				"ns1/wl1": {QueueName: "lq-b", Active: true},
+				"ns1/wl2": {QueueName: "lq-b", Active: true},
+				"ns2/wl3": {QueueName: "lq-a", Active: true},
+				"ns3/wl4": {QueueName: "lq-a", Active: true},
+			},
+			wantJobs: map[string]string{"ns1/j1": "lq-b"},
+			wantOut: `workload ns1/wl1 moved from localqueue lq-a to lq-b
+workload ns2/wl3 skipped, no localqueue in namespace ns2 points to clusterqueue cq-b
+workload ns3/wl4 skipped, several localqueues in namespace ns3 point to clusterqueue cq-b: lq-b1, lq-b2
+1 workloads moved, 2 skipped
+`,
+		},
+		"shouldn't move the workloads with client dry-run": {
+			args: []string{"--from-localqueue", "lq-a", "--to-localqueue", "lq-b", "--requeue-admitted", "--dry-run", "client"},
+			objs: []runtime.Object{
+				utiltesting.MakeLocalQueue("lq-b", metav1.NamespaceDefault).ClusterQueue("cq-b").Obj(),
+				utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+					ResourceVersion("1").
+					Queue("lq-a").
+					ControllerReference(jobGVK, "j1", "uid1").
+					Obj(),
+				utiltesting.MakeWorkload("wl2", metav1.NamespaceDefault).
+					ResourceVersion("1").
+					Queue("lq-a").
+					ReserveQuota(admission).
+					Obj(),
+			},
+			jobs: []runtime.Object{makeJob("j1", metav1.NamespaceDefault, "lq-a")},
+			wantQueues: map[string]queueState{
+				"default/wl1": {QueueName: "lq-a", Active: true},
+				"default/wl2": {QueueName: "lq-a", Active: true},
+			},
+			wantJobs: map[string]string{"default/j1": "lq-a"},
+			wantOut: `workload default/wl1 moved from localqueue lq-a to lq-b (client dry run)
+workload default/wl2 requeued from localqueue lq-a to lq-b (client dry run)
+2 workloads moved, 0 skipped (client dry run)
+`,
+		},
+		"should fail when the target localqueue doesn't exist": {
+			args:    []string{"--from-localqueue", "lq-a", "--to-localqueue", "lq-b"},
+			wantErr: `localqueues.kueue.x-k8s.io "lq-b" not found`,
+		},
+		"should fail when the target queue is missing": {
+			args:    []string{"--from-localqueue", "lq-a"},
+			wantErr: "both --from-localqueue and --to-localqueue must be set",
+		},
+		"should fail when localqueues and clusterqueues are mixed": {
+			args:    []string{"--from-localqueue", "lq-a", "--to-clusterqueue", "cq-b"},
+			wantErr: "the localqueue and clusterqueue flags are mutually exclusive",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+
+			clientset := fake.NewSimpleClientset(tc.objs...)
+			// Kueue evicts the deactivated workloads, releasing their quota.
+			clientset.PrependReactor("get", "workloads", func(action kubetesting.Action) (bool, runtime.Object, error) {
+				getAction := action.(kubetesting.GetAction)
+				obj, err := clientset.Tracker().Get(action.GetResource(), getAction.GetNamespace(), getAction.GetName())
+				if err != nil {
+					return true, nil, err
+				}
+				wl := obj.(*kueue.Workload)
+				if !ptr.Deref(wl.Spec.Active, true) {
+					wl.Status.Admission = nil
+					meta.RemoveStatusCondition(&wl.Status.Conditions, kueue.WorkloadQuotaReserved)
+					meta.RemoveStatusCondition(&wl.Status.Conditions, kueue.WorkloadAdmitted)
+				}
+				return true, wl, nil
+			})
+
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(k8sscheme.Scheme, tc.jobs...)
+			restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{})
+			restMapper.Add(jobGVK, meta.RESTScopeNamespace)
+
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(clientset).
+				WithDynamicClient(dynamicClient).
+				WithRESTMapper(restMapper)
+
+			cmd := NewWorkloadCmd(tcg, streams)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+			if gotErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			wls, err := clientset.KueueV1beta1().Workloads("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotQueues := make(map[string]queueState, len(wls.Items))
+			for _, wl := range wls.Items {
+				gotQueues[workload.Key(&wl)] = queueState{QueueName: wl.Spec.QueueName, Active: ptr.Deref(wl.Spec.Active, true)}
+			}
+			if diff := cmp.Diff(tc.wantQueues, gotQueues, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected workload queues (-want/+got)\n%s", diff)
+			}
+
+			mapping, err := restMapper.RESTMapping(jobGVK.GroupKind(), jobGVK.Version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			jobs, err := dynamicClient.Resource(mapping.Resource).Namespace("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotJobs := make(map[string]string, len(jobs.Items))
+			for _, job := range jobs.Items {
+				gotJobs[job.GetNamespace()+"/"+job.GetName()] = job.GetLabels()[constants.QueueLabel]
+			}
+			if diff := cmp.Diff(tc.wantJobs, gotJobs, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected job queues (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
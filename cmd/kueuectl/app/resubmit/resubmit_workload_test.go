@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resubmit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	kubetesting "k8s.io/client-go/testing"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestWorkloadCmd(t *testing.T) {
+	jobGVK := batchv1.SchemeGroupVersion.WithKind("Job")
+	finishedCondition := metav1.Condition{
+		Type:   kueue.WorkloadFinished,
+		Status: metav1.ConditionTrue,
+		Reason: kueue.WorkloadFinishedReasonFailed,
+	}
+	job := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{Kind: "Job", APIVersion: "batch/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "j1",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{constants.QueueLabel: "lq"},
+		},
+		Spec: batchv1.JobSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{batchv1.ControllerUidLabel: "uid"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{batchv1.ControllerUidLabel: "uid", batchv1.JobNameLabel: "j1"},
+				},
+				Spec: corev1.PodSpec{RestartPolicy: corev1.RestartPolicyNever},
+			},
+		},
+		Status: batchv1.JobStatus{Failed: 1},
+	}
+	clonedJob := batchv1.Job{
+		TypeMeta: metav1.TypeMeta{Kind: "Job", APIVersion: "batch/v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:         "j1-abcde",
+			GenerateName: "j1-",
+			Namespace:    metav1.NamespaceDefault,
+			Labels:       map[string]string{constants.QueueLabel: "lq"},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}},
+				Spec:       corev1.PodSpec{RestartPolicy: corev1.RestartPolicyNever},
+			},
+		},
+	}
+	originalJob := *job.DeepCopy()
+
+	testCases := map[string]struct {
+		args      []string
+		workloads []runtime.Object
+		jobs      []runtime.Object
+		wantJobs  []batchv1.Job
+		wantOut   string
+		wantErr   string
+	}{
+		"no arguments": {
+			args:     []string{},
+			wantJobs: []batchv1.Job{},
+			wantErr:  "accepts 1 arg(s), received 0",
+		},
+		"workload not found": {
+			args:     []string{"wl1"},
+			wantJobs: []batchv1.Job{},
+			wantErr:  `workloads.kueue.x-k8s.io "wl1" not found`,
+		},
+		"shouldn't resubmit a workload that is not finished": {
+			args: []string{"wl1"},
+			workloads: []runtime.Object{
+				utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).ControllerReference(jobGVK, "j1", "uid").Obj(),
+			},
+			jobs:     []runtime.Object{job.DeepCopy()},
+			wantJobs: []batchv1.Job{originalJob},
+			wantErr:  "cannot resubmit workload default/wl1: workload is not finished",
+		},
+		"should resubmit the job of a finished workload": {
+			args: []string{"wl1"},
+			workloads: []runtime.Object{
+				utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+					ControllerReference(jobGVK, "j1", "uid").
+					Condition(finishedCondition).
+					Obj(),
+			},
+			jobs:     []runtime.Object{job.DeepCopy()},
+			wantJobs: []batchv1.Job{originalJob, clonedJob},
+			wantOut:  "job.batch/j1-abcde created\n",
+		},
+		"shouldn't resubmit the job with client dry-run": {
+			args: []string{"wl1", "--dry-run", "client"},
+			workloads: []runtime.Object{
+				utiltesting.MakeWorkload("wl1", metav1.NamespaceDefault).
+					ControllerReference(jobGVK, "j1", "uid").
+					Condition(finishedCondition).
+					Obj(),
+			},
+			jobs:     []runtime.Object{job.DeepCopy()},
+			wantJobs: []batchv1.Job{originalJob},
+			wantOut:  "job.batch/<unknown> created (client dry run)\n",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+			clientset := fake.NewSimpleClientset(tc.workloads...)
+
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(k8sscheme.Scheme, tc.jobs...)
+			restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{})
+			restMapper.Add(jobGVK, meta.RESTScopeNamespace)
+			mapping, err := restMapper.RESTMapping(jobGVK.GroupKind(), jobGVK.Version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dynamicClient.PrependReactor("create", mapping.Resource.Resource, func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
+				// SimpleDynamicClient doesn't generate names.
+				obj := action.(kubetesting.CreateAction).GetObject().(*unstructured.Unstructured)
+				if obj.GetName() == "" {
+					obj.SetName(obj.GetGenerateName() + "abcde")
+				}
+				return false, nil, nil
+			})
+
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(clientset).
+				WithDynamicClient(dynamicClient).
+				WithRESTMapper(restMapper)
+
+			cmd := NewWorkloadCmd(tcg, streams)
+			cmd.SetOut(out)
+			cmd.SetErr(out)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			if gotErr == nil {
+				if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+					t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+				}
+			}
+
+			unstructuredList, err := dynamicClient.Resource(mapping.Resource).Namespace(metav1.NamespaceDefault).
+				List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotList := &batchv1.JobList{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredList.UnstructuredContent(), gotList); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantJobs, gotList.Items); diff != "" {
+				t.Errorf("Unexpected jobs (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	k8s "k8s.io/client-go/kubernetes"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/util/limitrange"
+
+	_ "sigs.k8s.io/kueue/pkg/controller/jobs"
+)
+
+var (
+	simulateLong = templates.LongDesc(`
+		Simulates the admission of the jobs in the given files, without
+		submitting them.
+
+		For each job, the command asks Kueue whether it would be admitted
+		in the ClusterQueue of its LocalQueue, on which flavors, and how
+		long it is expected to wait, considering the workloads ahead of it.
+		The LocalQueue is taken from the kueue.x-k8s.io/queue-name label of
+		the job, unless --localqueue is set.
+
+		The simulation requires the VisibilityOnDemand feature.
+	`)
+	simulateExample = templates.Examples(`
+		# Simulate the admission of the job
+		kueuectl simulate -f job.yaml
+
+		# Simulate the admission of the job in another LocalQueue
+		kueuectl simulate -f job.yaml --localqueue my-localqueue
+	`)
+)
+
+var (
+	errNoFilename     = errors.New("must specify one of -f and -k")
+	errUnsupportedJob = errors.New("is not supported by Kueue")
+)
+
+type SimulateOptions struct {
+	Clock clock.PassiveClock
+
+	FilenameOptions resource.FilenameOptions
+	LocalQueue      string
+
+	Namespace        string
+	EnforceNamespace bool
+
+	KueueClientset versioned.Interface
+	K8sClientset   k8s.Interface
+	Infos          []*resource.Info
+
+	genericiooptions.IOStreams
+}
+
+func NewSimulateOptions(streams genericiooptions.IOStreams, clock clock.PassiveClock) *SimulateOptions {
+	return &SimulateOptions{
+		IOStreams: streams,
+		Clock:     clock,
+	}
+}
+
+func NewSimulateCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams, clock clock.PassiveClock) *cobra.Command {
+	o := NewSimulateOptions(streams, clock)
+
+	cmd := &cobra.Command{
+		Use: "simulate -f FILENAME [--localqueue NAME]",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Short:                 "Simulate the admission of jobs without submitting them",
+		Long:                  simulateLong,
+		Example:               simulateExample,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter)
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	cmdutil.AddFilenameOptionFlags(cmd, &o.FilenameOptions, "containing the jobs to simulate")
+	cmd.Flags().StringVarP(&o.LocalQueue, "localqueue", "q", "",
+		"The LocalQueue the jobs would be submitted to. Defaults to the queue name label of each job.")
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *SimulateOptions) Complete(clientGetter util.ClientGetter) error {
+	if cmdutil.IsFilenameSliceEmpty(o.FilenameOptions.Filenames, o.FilenameOptions.Kustomize) {
+		return errNoFilename
+	}
+
+	var err error
+
+	o.Namespace, o.EnforceNamespace, err = clientGetter.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	o.KueueClientset, err = clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.K8sClientset, err = clientGetter.K8sClientSet()
+	if err != nil {
+		return err
+	}
+
+	r := clientGetter.NewResourceBuilder().
+		Unstructured().
+		Local().
+		NamespaceParam(o.Namespace).
+		DefaultNamespace().
+		FilenameParam(o.EnforceNamespace, &o.FilenameOptions).
+		Flatten().
+		Do()
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	o.Infos, err = r.Infos()
+	return err
+}
+
+// Run simulates the admission of each job
+func (o *SimulateOptions) Run(ctx context.Context) error {
+	for i, info := range o.Infos {
+		if i > 0 {
+			fmt.Fprintln(o.Out)
+		}
+		if err := o.simulate(ctx, info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *SimulateOptions) simulate(ctx context.Context, info *resource.Info) error {
+	obj, ok := info.Object.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("invalid object %s, unexpected type %T", info.ObjectName(), info.Object)
+	}
+	gvk := obj.GroupVersionKind()
+	cbs, ok := jobframework.GetIntegrationByGVK(gvk)
+	if !ok || cbs.NewJob == nil {
+		return fmt.Errorf("%s %w", gvk.Kind, errUnsupportedJob)
+	}
+	job := cbs.NewJob()
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), job.Object()); err != nil {
+		return fmt.Errorf("failed to convert unstructured object: %w", err)
+	}
+	// The namespace isn't defaulted for local objects.
+	namespace := obj.GetNamespace()
+	if namespace == "" {
+		namespace = o.Namespace
+	}
+
+	key := fmt.Sprintf("%s/%s", namespace, obj.GetName())
+	lqName := o.LocalQueue
+	if lqName == "" {
+		lqName = jobframework.QueueName(job)
+	}
+	if lqName == "" {
+		return fmt.Errorf("%s %s doesn't have the %s label, set --localqueue", gvk.Kind, key, constants.QueueLabel)
+	}
+
+	podSets := job.PodSets()
+	priority, err := o.priority(ctx, job.Object().GetLabels(), podSets)
+	if err != nil {
+		return err
+	}
+	simulation := &visibility.AdmissionSimulation{
+		ObjectMeta: metav1.ObjectMeta{Name: obj.GetName()},
+		Spec: visibility.AdmissionSimulationSpec{
+			Priority: priority,
+			PodSets:  make([]visibility.AdmissionSimulationPodSet, 0, len(podSets)),
+		},
+	}
+	for _, ps := range podSets {
+		simulation.Spec.PodSets = append(simulation.Spec.PodSets, visibility.AdmissionSimulationPodSet{
+			Name:         ps.Name,
+			Count:        ps.Count,
+			Requests:     limitrange.TotalRequests(&ps.Template.Spec),
+			NodeSelector: ps.Template.Spec.NodeSelector,
+		})
+	}
+
+	result, err := o.KueueClientset.VisibilityV1beta1().LocalQueues(namespace).SimulateAdmission(ctx, lqName, simulation, metav1.CreateOptions{})
+	if apierrors.IsNotFound(err) {
+		fmt.Fprintf(o.Out, "%s %s would not be admitted, because LocalQueue %s doesn't exist.\n", gvk.Kind, key, lqName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	o.printResult(o.Out, fmt.Sprintf("%s %s", gvk.Kind, key), lqName, &result.Status)
+	return nil
+}
+
+// priority returns the priority of the workload of the job, from its
+// WorkloadPriorityClass or, otherwise, from the PriorityClass of its pods.
+func (o *SimulateOptions) priority(ctx context.Context, labels map[string]string, podSets []v1beta1.PodSet) (int32, error) {
+	if name := labels[constants.WorkloadPriorityClassLabel]; name != "" {
+		wpc, err := o.KueueClientset.KueueV1beta1().WorkloadPriorityClasses().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return 0, err
+		}
+		return wpc.Value, nil
+	}
+	for _, ps := range podSets {
+		if name := ps.Template.Spec.PriorityClassName; name != "" {
+			pc, err := o.K8sClientset.SchedulingV1().PriorityClasses().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return 0, err
+			}
+			return pc.Value, nil
+		}
+	}
+	return 0, nil
+}
+
+func (o *SimulateOptions) printResult(out io.Writer, job, lqName string, status *visibility.AdmissionSimulationStatus) {
+	cq := status.ClusterQueueName
+	switch {
+	case status.Mode == "Fit" && status.Borrowing:
+		fmt.Fprintf(out, "%s would be admitted in ClusterQueue %s through LocalQueue %s, borrowing quota from the cohort.\n", job, cq, lqName)
+	case status.Mode == "Fit":
+		fmt.Fprintf(out, "%s would be admitted in ClusterQueue %s through LocalQueue %s.\n", job, cq, lqName)
+	case status.Mode == "Preempt" && status.Preemptions > 0:
+		fmt.Fprintf(out, "%s would be admitted in ClusterQueue %s through LocalQueue %s, after preempting %d workloads.\n", job, cq, lqName, status.Preemptions)
+	case status.Mode == "Preempt":
+		fmt.Fprintf(out, "%s would wait for quota to be released in ClusterQueue %s through LocalQueue %s.\n", job, cq, lqName)
+	default:
+		fmt.Fprintf(out, "%s would not be admitted in ClusterQueue %s through LocalQueue %s.\n", job, cq, lqName)
+	}
+	if status.Message != "" {
+		fmt.Fprintf(out, "  %s\n", status.Message)
+	}
+
+	if len(status.PodSetAssignments) > 0 {
+		fmt.Fprintln(out, "\nFlavors:")
+		for _, psa := range status.PodSetAssignments {
+			fmt.Fprintf(out, "  Pod set %s (count: %d):\n", psa.Name, psa.Count)
+			resources := make([]corev1.ResourceName, 0, len(psa.Flavors))
+			for res := range psa.Flavors {
+				resources = append(resources, res)
+			}
+			slices.Sort(resources)
+			for _, res := range resources {
+				fmt.Fprintf(out, "    %s: %s\n", res, psa.Flavors[res])
+			}
+		}
+	}
+
+	fmt.Fprintf(out, "\nWorkloads ahead in ClusterQueue %s: %d\n", cq, status.PositionInClusterQueue)
+	switch {
+	case status.EstimatedAdmissionTime == nil:
+		fmt.Fprintln(out, "Expected wait: unknown, no workload got quota reserved in the ClusterQueue during the last hour")
+	case !status.EstimatedAdmissionTime.Time.After(o.Clock.Now()):
+		fmt.Fprintln(out, "Expected wait: none")
+	default:
+		fmt.Fprintf(out, "Expected wait: %s\n", duration.HumanDuration(status.EstimatedAdmissionTime.Sub(o.Clock.Now())))
+	}
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	kubetesting "k8s.io/client-go/testing"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+const jobTemplate = `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: sample-job
+  labels:
+%s
+spec:
+  parallelism: 3
+  completions: 3
+  template:
+    spec:
+      priorityClassName: high
+      containers:
+      - name: main
+        image: busybox
+        resources:
+          requests:
+            cpu: "1"
+            memory: 200Mi
+      restartPolicy: Never
+`
+
+func TestSimulateCmd(t *testing.T) {
+	now := time.Date(2024, 10, 14, 10, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		labels         string
+		file           string
+		args           []string
+		objs           []runtime.Object
+		status         *visibility.AdmissionSimulationStatus
+		simulationErr  error
+		wantSimulation *visibility.AdmissionSimulation
+		wantOut        string
+		wantErr        string
+	}{
+		"fits": {
+			labels: "    kueue.x-k8s.io/queue-name: lq",
+			status: &visibility.AdmissionSimulationStatus{
+				ClusterQueueName: "cq",
+				Mode:             "Fit",
+				PodSetAssignments: []visibility.AdmissionSimulationPodSetAssignment{{
+					Name: "main",
+					Flavors: map[corev1.ResourceName]string{
+						corev1.ResourceMemory: "default",
+						corev1.ResourceCPU:    "on-demand",
+					},
+					Count: 3,
+				}},
+				EstimatedAdmissionTime: ptr.To(metav1.NewTime(now)),
+			},
+			wantSimulation: &visibility.AdmissionSimulation{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-job"},
+				Spec: visibility.AdmissionSimulationSpec{
+					Priority: 1000,
+					PodSets: []visibility.AdmissionSimulationPodSet{{
+						Name:  "main",
+						Count: 3,
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1"),
+							corev1.ResourceMemory: resource.MustParse("200Mi"),
+						},
+					}},
+				},
+			},
+			wantOut: `Job default/sample-job would be admitted in ClusterQueue cq through LocalQueue lq.
+
+Flavors:
+  Pod set main (count: 3):
+    cpu: on-demand
+    memory: default
+
+Workloads ahead in ClusterQueue cq: 0
+Expected wait: none
+`,
+		},
+		"fits after preempting workloads in another LocalQueue": {
+			labels: "    kueue.x-k8s.io/queue-name: lq\n    kueue.x-k8s.io/priority-class: urgent",
+			args:   []string{"--localqueue", "other-lq"},
+			status: &visibility.AdmissionSimulationStatus{
+				ClusterQueueName: "other-cq",
+				Mode:             "Preempt",
+				Preemptions:      2,
+				Message:          "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor on-demand, 1 more needed",
+				PodSetAssignments: []visibility.AdmissionSimulationPodSetAssignment{{
+					Name:    "main",
+					Flavors: map[corev1.ResourceName]string{corev1.ResourceCPU: "on-demand"},
+					Count:   3,
+				}},
+				PositionInClusterQueue: 1,
+				EstimatedAdmissionTime: ptr.To(metav1.NewTime(now.Add(2 * time.Minute))),
+			},
+			wantSimulation: &visibility.AdmissionSimulation{
+				ObjectMeta: metav1.ObjectMeta{Name: "sample-job"},
+				Spec: visibility.AdmissionSimulationSpec{
+					Priority: 2000,
+					PodSets: []visibility.AdmissionSimulationPodSet{{
+						Name:  "main",
+						Count: 3,
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1"),
+							corev1.ResourceMemory: resource.MustParse("200Mi"),
+						},
+					}},
+				},
+			},
+			wantOut: `Job default/sample-job would be admitted in ClusterQueue other-cq through LocalQueue other-lq, after preempting 2 workloads.
+  couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor on-demand, 1 more needed
+
+Flavors:
+  Pod set main (count: 3):
+    cpu: on-demand
+
+Workloads ahead in ClusterQueue other-cq: 1
+Expected wait: 2m
+`,
+		},
+		"doesn't fit": {
+			labels: "    kueue.x-k8s.io/queue-name: lq",
+			status: &visibility.AdmissionSimulationStatus{
+				ClusterQueueName:       "cq",
+				Mode:                   "NoFit",
+				Message:                "couldn't assign flavors to pod set main: insufficient quota for cpu in flavor on-demand, request > maximum capacity (3 > 2)",
+				PositionInClusterQueue: 4,
+			},
+			wantOut: `Job default/sample-job would not be admitted in ClusterQueue cq through LocalQueue lq.
+  couldn't assign flavors to pod set main: insufficient quota for cpu in flavor on-demand, request > maximum capacity (3 > 2)
+
+Workloads ahead in ClusterQueue cq: 4
+Expected wait: unknown, no workload got quota reserved in the ClusterQueue during the last hour
+`,
+		},
+		"nonexistent LocalQueue": {
+			labels:        "    kueue.x-k8s.io/queue-name: lq",
+			simulationErr: apierrors.NewNotFound(visibility.Resource("localqueue"), "lq"),
+			wantOut:       "Job default/sample-job would not be admitted, because LocalQueue lq doesn't exist.\n",
+		},
+		"no LocalQueue": {
+			labels:  "    app: sample",
+			wantErr: "Job default/sample-job doesn't have the kueue.x-k8s.io/queue-name label, set --localqueue",
+		},
+		"unsupported kind": {
+			file: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+`,
+			wantErr: "ConfigMap is not supported by Kueue",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			file := tc.file
+			if file == "" {
+				file = fmt.Sprintf(jobTemplate, tc.labels)
+			}
+			path := filepath.Join(t.TempDir(), "job.yaml")
+			if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+				t.Fatalf("Writing the file: %v", err)
+			}
+
+			clientset := fake.NewSimpleClientset(utiltesting.MakeWorkloadPriorityClass("urgent").PriorityValue(2000).Obj())
+			var gotSimulation *visibility.AdmissionSimulation
+			// The simulations are not stored, so the result is returned by a
+			// reaction on the subresource.
+			clientset.PrependReactor("create", "localqueues", func(action kubetesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "admissionsimulation" {
+					return false, nil, nil
+				}
+				if tc.simulationErr != nil {
+					return true, nil, tc.simulationErr
+				}
+				gotSimulation = action.(kubetesting.CreateAction).GetObject().(*visibility.AdmissionSimulation).DeepCopy()
+				result := gotSimulation.DeepCopy()
+				result.Status = *tc.status
+				return true, result, nil
+			})
+			k8sClientset := k8sfake.NewSimpleClientset(&schedulingv1.PriorityClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "high"},
+				Value:      1000,
+			})
+
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(clientset).
+				WithK8sClientset(k8sClientset).
+				WithNamespace("default")
+
+			cmd := NewSimulateCmd(tcg, streams, testingclock.NewFakeClock(now))
+			cmd.SetArgs(append([]string{"-f", path}, tc.args...))
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			if tc.wantSimulation != nil {
+				if diff := cmp.Diff(tc.wantSimulation, gotSimulation); diff != "" {
+					t.Errorf("Unexpected simulation request (-want/+got)\n%s", diff)
+				}
+			}
+
+			gotOut := out.String()
+			if diff := cmp.Diff(tc.wantOut, gotOut); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			if gotOutErr := outErr.String(); gotOutErr != "" {
+				t.Errorf("Unexpected error output: %s", gotOutErr)
+			}
+		})
+	}
+}
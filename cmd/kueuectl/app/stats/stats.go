@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+)
+
+var (
+	statsExample = templates.Examples(`
+		# Print the statistics of the clusterqueue over the last day, from the scheduling audit log
+		kueuectl stats clusterqueue my-clusterqueue --audit-log scheduling-audit.log
+	`)
+)
+
+func NewStatsCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams, clock clock.PassiveClock) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "stats",
+		Short:   "Display the historical statistics of the queues",
+		Example: statsExample,
+	}
+
+	cmd.AddCommand(NewClusterQueueCmd(clientGetter, streams, clock))
+
+	return cmd
+}
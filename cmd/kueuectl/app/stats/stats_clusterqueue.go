@@ -0,0 +1,278 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/scheduler/audit"
+)
+
+const defaultSince = 24 * time.Hour
+
+var (
+	cqLong = templates.LongDesc(`
+		Prints the statistics of the given ClusterQueue over a time window,
+		aggregated from the audit log of the scheduling decisions:
+
+		- the number of workloads which got quota reserved, and the rate per hour,
+		- the average and maximum time the workloads waited in the queue,
+		  before getting quota reserved,
+		- the number of workloads of the ClusterQueue which preempted other
+		  workloads, and the number of workloads of the ClusterQueue which
+		  were targeted for preemption,
+		- the peak usage of each flavor and resource observed by the scheduler.
+
+		The audit log must be enabled with the schedulingAudit field of the
+		Kueue configuration. Use "-" to read it from the standard input.
+	`)
+	cqExample = templates.Examples(`
+		# Print the statistics of the clusterqueue over the last day
+		kueuectl stats clusterqueue my-clusterqueue --audit-log scheduling-audit.log
+
+		# Print the statistics of the clusterqueue over the last hour, reading the audit log from Kueue
+		kubectl exec -n kueue-system deploy/kueue-controller-manager -- cat /var/log/kueue/scheduling-audit.log | \
+		  kueuectl stats clusterqueue my-clusterqueue --since 1h --audit-log -
+	`)
+)
+
+type ClusterQueueOptions struct {
+	ClusterQueueName string
+	AuditLog         string
+	Since            time.Duration
+
+	Clock clock.PassiveClock
+
+	genericiooptions.IOStreams
+}
+
+// clusterQueueStats are the statistics of a ClusterQueue over a time window.
+type clusterQueueStats struct {
+	start, end time.Time
+
+	admitted  int
+	totalWait time.Duration
+	maxWait   time.Duration
+	// preempting and preempted are the workloads which preempted and were
+	// targeted for preemption, respectively.
+	preempting sets.Set[types.UID]
+	preempted  sets.Set[string]
+	// peakUsage is the peak usage, by flavor and resource.
+	peakUsage map[string]map[string]resource.Quantity
+}
+
+func NewClusterQueueOptions(streams genericiooptions.IOStreams, clock clock.PassiveClock) *ClusterQueueOptions {
+	return &ClusterQueueOptions{
+		Since:     defaultSince,
+		Clock:     clock,
+		IOStreams: streams,
+	}
+}
+
+func NewClusterQueueCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams, clock clock.PassiveClock) *cobra.Command {
+	o := NewClusterQueueOptions(streams, clock)
+
+	cmd := &cobra.Command{
+		Use:                   "clusterqueue NAME --audit-log PATH [--since DURATION]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"cq"},
+		Short:                 "Display the statistics of the ClusterQueue over a time window",
+		Long:                  cqLong,
+		Example:               cqExample,
+		Args:                  cobra.ExactArgs(1),
+		ValidArgsFunction:     completion.ClusterQueueNameFunc(clientGetter, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			o.ClusterQueueName = args[0]
+			return o.Run()
+		},
+	}
+
+	cmd.Flags().StringVar(&o.AuditLog, "audit-log", "",
+		`Path of the audit log of the scheduling decisions, or "-" to read it from the standard input.`)
+	cmd.Flags().DurationVar(&o.Since, "since", defaultSince,
+		"Length of the time window, ending now.")
+	cobra.CheckErr(cmd.MarkFlagRequired("audit-log"))
+
+	return cmd
+}
+
+// Run prints the statistics of the ClusterQueue
+func (o *ClusterQueueOptions) Run() error {
+	if o.Since <= 0 {
+		return errors.New("--since must be greater than zero")
+	}
+
+	in := o.In
+	if o.AuditLog != "-" {
+		f, err := os.Open(o.AuditLog)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	end := o.Clock.Now()
+	stats, err := o.aggregate(in, end.Add(-o.Since), end)
+	if err != nil {
+		return err
+	}
+
+	return printStats(o.Out, o.ClusterQueueName, stats)
+}
+
+// aggregate reads the decisions and aggregates the ones of the ClusterQueue
+// taken within the window.
+func (o *ClusterQueueOptions) aggregate(in io.Reader, start, end time.Time) (*clusterQueueStats, error) {
+	stats := &clusterQueueStats{
+		start:      start,
+		end:        end,
+		preempting: sets.New[types.UID](),
+		preempted:  sets.New[string](),
+		peakUsage:  make(map[string]map[string]resource.Quantity),
+	}
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(nil, 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var d audit.Decision
+		if err := json.Unmarshal(scanner.Bytes(), &d); err != nil {
+			return nil, fmt.Errorf("invalid decision at line %d of the audit log: %w", line, err)
+		}
+		if d.Time.Before(start) || d.Time.After(end) {
+			continue
+		}
+		for _, target := range d.PreemptionTargets {
+			if target.ClusterQueue == o.ClusterQueueName {
+				stats.preempted.Insert(target.Workload)
+			}
+		}
+		if d.ClusterQueue != o.ClusterQueueName {
+			continue
+		}
+		if err := stats.add(&d); err != nil {
+			return nil, fmt.Errorf("invalid decision at line %d of the audit log: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (s *clusterQueueStats) add(d *audit.Decision) error {
+	switch d.Result {
+	case audit.QuotaReserved:
+		s.admitted++
+		if !d.QueueOrderTimestamp.IsZero() {
+			wait := d.Time.Sub(d.QueueOrderTimestamp)
+			s.totalWait += wait
+			s.maxWait = max(s.maxWait, wait)
+		}
+		// The usage of the ClusterQueue doesn't include the admitted workload.
+		return s.updatePeakUsage(d.ClusterQueueUsage, d.Usage)
+	case audit.Preempting:
+		s.preempting.Insert(d.UID)
+	}
+	return s.updatePeakUsage(d.ClusterQueueUsage)
+}
+
+// updatePeakUsage updates the peak usage with the sum of the quantities.
+func (s *clusterQueueStats) updatePeakUsage(quantities ...audit.Quantities) error {
+	sum := make(map[string]map[string]resource.Quantity)
+	for _, q := range quantities {
+		for flavor, resources := range q {
+			if sum[flavor] == nil {
+				sum[flavor] = make(map[string]resource.Quantity)
+			}
+			for name, value := range resources {
+				v, err := resource.ParseQuantity(value)
+				if err != nil {
+					return fmt.Errorf("usage of %s in flavor %s: %w", name, flavor, err)
+				}
+				total := sum[flavor][name]
+				total.Add(v)
+				sum[flavor][name] = total
+			}
+		}
+	}
+	for flavor, resources := range sum {
+		if s.peakUsage[flavor] == nil {
+			s.peakUsage[flavor] = make(map[string]resource.Quantity)
+		}
+		for name, v := range resources {
+			if peak, found := s.peakUsage[flavor][name]; !found || v.Cmp(peak) > 0 {
+				s.peakUsage[flavor][name] = v
+			}
+		}
+	}
+	return nil
+}
+
+func printStats(out io.Writer, name string, s *clusterQueueStats) error {
+	tw := printers.GetNewTabWriter(out)
+	window := s.end.Sub(s.start)
+	fmt.Fprintf(tw, "ClusterQueue:\t%s\n", name)
+	fmt.Fprintf(tw, "Window:\t%s to %s (%s)\n", s.start.UTC().Format(time.RFC3339), s.end.UTC().Format(time.RFC3339), duration.HumanDuration(window))
+	fmt.Fprintf(tw, "Admitted workloads:\t%d (%.2f per hour)\n", s.admitted, float64(s.admitted)/window.Hours())
+	if s.admitted > 0 {
+		fmt.Fprintf(tw, "Average wait:\t%s\n", duration.HumanDuration(s.totalWait/time.Duration(s.admitted)))
+		fmt.Fprintf(tw, "Maximum wait:\t%s\n", duration.HumanDuration(s.maxWait))
+	} else {
+		fmt.Fprintf(tw, "Average wait:\t-\n")
+		fmt.Fprintf(tw, "Maximum wait:\t-\n")
+	}
+	fmt.Fprintf(tw, "Preempting workloads:\t%d\n", s.preempting.Len())
+	fmt.Fprintf(tw, "Preempted workloads:\t%d\n", s.preempted.Len())
+	if len(s.peakUsage) == 0 {
+		fmt.Fprintf(tw, "Peak usage:\t-\n")
+	} else {
+		fmt.Fprintln(tw, "Peak usage:")
+		for _, flavor := range slices.Sorted(maps.Keys(s.peakUsage)) {
+			for _, name := range slices.Sorted(maps.Keys(s.peakUsage[flavor])) {
+				v := s.peakUsage[flavor][name]
+				fmt.Fprintf(tw, "  %s in flavor %s:\t%s\n", name, flavor, v.String())
+			}
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	testingclock "k8s.io/utils/clock/testing"
+
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+)
+
+const auditLog = `{"time":"2024-10-01T08:00:00Z","cycle":1,"workload":"team-a/old","uid":"old","clusterQueue":"cq-a","queueOrderTimestamp":"2024-10-01T07:00:00Z","result":"QuotaReserved","usage":{"default":{"cpu":"100"}}}
+{"time":"2024-10-01T10:00:00Z","cycle":2,"workload":"team-a/wl1","uid":"wl1","clusterQueue":"cq-a","queueOrderTimestamp":"2024-10-01T09:58:00Z","result":"QuotaReserved","usage":{"default":{"cpu":"4","memory":"8Gi"}},"clusterQueueUsage":{"default":{"cpu":"2","memory":"4Gi"}}}
+{"time":"2024-10-01T10:00:00Z","cycle":2,"position":1,"workload":"team-b/wl2","uid":"wl2","clusterQueue":"cq-b","queueOrderTimestamp":"2024-10-01T09:00:00Z","result":"Preempting","preemptionTargets":[{"workload":"team-a/wl0","clusterQueue":"cq-a","reason":"InCohortReclamation"}]}
+
+{"time":"2024-10-01T11:00:00Z","cycle":3,"workload":"team-a/wl3","uid":"wl3","clusterQueue":"cq-a","queueOrderTimestamp":"2024-10-01T10:50:00Z","result":"Preempting","clusterQueueUsage":{"default":{"cpu":"8","memory":"12Gi"}},"preemptionTargets":[{"workload":"team-a/wl1","clusterQueue":"cq-a","reason":"InClusterQueue"}]}
+{"time":"2024-10-01T11:00:10Z","cycle":4,"workload":"team-a/wl3","uid":"wl3","clusterQueue":"cq-a","queueOrderTimestamp":"2024-10-01T10:50:00Z","result":"Preempting","clusterQueueUsage":{"default":{"cpu":"8","memory":"12Gi"}},"preemptionTargets":[{"workload":"team-a/wl1","clusterQueue":"cq-a","reason":"InClusterQueue"}]}
+{"time":"2024-10-01T11:01:00Z","cycle":5,"workload":"team-a/wl3","uid":"wl3","clusterQueue":"cq-a","queueOrderTimestamp":"2024-10-01T10:50:00Z","result":"QuotaReserved","usage":{"default":{"cpu":"6"}},"clusterQueueUsage":{"default":{"cpu":"4","memory":"4Gi"}}}
+{"time":"2024-10-01T11:02:00Z","cycle":6,"workload":"team-a/wl4","uid":"wl4","clusterQueue":"cq-a","queueOrderTimestamp":"2024-10-01T11:00:00Z","result":"Inadmissible","clusterQueueUsage":{"default":{"cpu":"10","memory":"4Gi"}}}
+`
+
+func TestClusterQueueCmd(t *testing.T) {
+	now := time.Date(2024, time.October, 1, 12, 0, 0, 0, time.UTC)
+
+	auditLogPath := filepath.Join(t.TempDir(), "audit.log")
+	if err := os.WriteFile(auditLogPath, []byte(auditLog), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		args    []string
+		in      string
+		wantOut string
+		wantErr string
+	}{
+		"should aggregate the decisions of the clusterqueue": {
+			args: []string{"cq-a", "--audit-log", auditLogPath, "--since", "3h"},
+			wantOut: `ClusterQueue:           cq-a
+Window:                 2024-10-01T09:00:00Z to 2024-10-01T12:00:00Z (3h)
+Admitted workloads:     2 (0.67 per hour)
+Average wait:           6m30s
+Maximum wait:           11m
+Preempting workloads:   1
+Preempted workloads:    2
+Peak usage:
+  cpu in flavor default:      10
+  memory in flavor default:   12Gi
+`,
+		},
+		"should read the audit log from the standard input": {
+			args: []string{"cq-b", "--audit-log", "-"},
+			in:   auditLog,
+			wantOut: `ClusterQueue:           cq-b
+Window:                 2024-09-30T12:00:00Z to 2024-10-01T12:00:00Z (24h)
+Admitted workloads:     0 (0.00 per hour)
+Average wait:           -
+Maximum wait:           -
+Preempting workloads:   1
+Preempted workloads:    0
+Peak usage:             -
+`,
+		},
+		"should fail with an invalid decision": {
+			args:    []string{"cq-a", "--audit-log", "-"},
+			in:      "{\"time\":\"2024-10-01T10:00:00Z\"}\ninvalid\n",
+			wantErr: "invalid decision at line 2 of the audit log: invalid character 'i' looking for beginning of value",
+		},
+		"should fail with an invalid window": {
+			args:    []string{"cq-a", "--audit-log", "-", "--since", "0s"},
+			wantErr: "--since must be greater than zero",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, in, out, _ := genericiooptions.NewTestIOStreams()
+			in.WriteString(tc.in)
+
+			cmd := NewClusterQueueCmd(cmdtesting.NewTestClientGetter(), streams, testingclock.NewFakePassiveClock(now))
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package top
+
+import (
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+const unknownQuantity = "<unknown>"
+
+func addLabelSelectorFlagVar(cmd *cobra.Command, p *string) {
+	cmd.Flags().StringVarP(p, "selector", "l", "",
+		"Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2). Matching objects must satisfy all of the specified label constraints.")
+}
+
+func addNoHeadersFlagVar(cmd *cobra.Command, p *bool) {
+	cmd.Flags().BoolVar(p, "no-headers", false,
+		"If present, print output without headers.")
+}
+
+// clusterQueueUsage returns the usage of the flavor and resource in the
+// status of the ClusterQueue.
+func clusterQueueUsage(cq *v1beta1.ClusterQueue, flavor v1beta1.ResourceFlavorReference, name corev1.ResourceName) v1beta1.ResourceUsage {
+	for _, fu := range cq.Status.FlavorsUsage {
+		if fu.Name != flavor {
+			continue
+		}
+		for _, ru := range fu.Resources {
+			if ru.Name == name {
+				return ru
+			}
+		}
+	}
+	return v1beta1.ResourceUsage{Name: name}
+}
+
+// localQueueUsage returns the usage of the flavor and resource in the
+// status of the LocalQueue.
+func localQueueUsage(lq *v1beta1.LocalQueue, flavor v1beta1.ResourceFlavorReference, name corev1.ResourceName) resource.Quantity {
+	for _, fu := range lq.Status.FlavorUsage {
+		if fu.Name != flavor {
+			continue
+		}
+		for _, ru := range fu.Resources {
+			if ru.Name == name {
+				return ru.Total
+			}
+		}
+	}
+	return resource.Quantity{}
+}
+
+// lendableQuota returns the quota that the ClusterQueue can lend to its cohort,
+// which is the lendingLimit, if set, or the nominalQuota.
+func lendableQuota(rq *v1beta1.ResourceQuota) resource.Quantity {
+	if rq.LendingLimit != nil {
+		return *rq.LendingLimit
+	}
+	return rq.NominalQuota
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package top
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+)
+
+var (
+	topExample = templates.Examples(`
+		# Show the usage of all ClusterQueues
+		kueuectl top clusterqueue
+
+		# Show the usage of the LocalQueues in the current namespace
+		kueuectl top localqueue
+	`)
+)
+
+func NewTopCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "top",
+		Short:   "Display the resource usage of the queues",
+		Example: topExample,
+	}
+
+	cmd.AddCommand(NewClusterQueueCmd(clientGetter, streams))
+	cmd.AddCommand(NewLocalQueueCmd(clientGetter, streams))
+
+	return cmd
+}
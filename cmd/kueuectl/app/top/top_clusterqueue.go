@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package top
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	kueuev1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+)
+
+var (
+	cqLong = templates.LongDesc(`
+		Display the resource usage of ClusterQueues.
+
+		For each flavor and resource of the ClusterQueues, the command shows the
+		quota used by the admitted workloads, the nominal quota, the quota borrowed
+		from the cohort and the quota that can be lent to the cohort, along with
+		the number of pending and admitted workloads.
+	`)
+	cqExample = templates.Examples(`
+		# Show the usage of all ClusterQueues
+		kueuectl top clusterqueue
+
+		# Show the usage of the ClusterQueue my-cluster-queue
+		kueuectl top clusterqueue my-cluster-queue
+	`)
+)
+
+type ClusterQueueOptions struct {
+	Name          string
+	LabelSelector string
+	NoHeaders     bool
+
+	Client kueuev1beta1.KueueV1beta1Interface
+
+	genericiooptions.IOStreams
+}
+
+func NewClusterQueueOptions(streams genericiooptions.IOStreams) *ClusterQueueOptions {
+	return &ClusterQueueOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewClusterQueueCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewClusterQueueOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:                   "clusterqueue [NAME] [--selector key1=value1] [--no-headers]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"cq"},
+		Short:                 "Display the resource usage of ClusterQueues",
+		Long:                  cqLong,
+		Example:               cqExample,
+		Args:                  cobra.MaximumNArgs(1),
+		ValidArgsFunction:     completion.ClusterQueueNameFunc(clientGetter, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			err := o.Complete(clientGetter, args)
+			if err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+
+	addLabelSelectorFlagVar(cmd, &o.LabelSelector)
+	addNoHeadersFlagVar(cmd, &o.NoHeaders)
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *ClusterQueueOptions) Complete(clientGetter util.ClientGetter, args []string) error {
+	if len(args) > 0 {
+		o.Name = args[0]
+	}
+
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.Client = clientset.KueueV1beta1()
+
+	return nil
+}
+
+// Run prints the resource usage of the cluster queues.
+func (o *ClusterQueueOptions) Run(ctx context.Context) error {
+	list := &v1beta1.ClusterQueueList{}
+	if o.Name != "" {
+		cq, err := o.Client.ClusterQueues().Get(ctx, o.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		list.Items = append(list.Items, *cq)
+	} else {
+		var err error
+		list, err = o.Client.ClusterQueues().List(ctx, metav1.ListOptions{LabelSelector: o.LabelSelector})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(list.Items) == 0 {
+		fmt.Fprintln(o.ErrOut, "No resources found")
+		return nil
+	}
+
+	slices.SortFunc(list.Items, func(a, b v1beta1.ClusterQueue) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	printer := newClusterQueueTablePrinter().WithHeaders(!o.NoHeaders)
+	tabWriter := printers.GetNewTabWriter(o.Out)
+	if err := printer.PrintObj(list, tabWriter); err != nil {
+		return err
+	}
+	return tabWriter.Flush()
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package top
+
+import (
+	"errors"
+	"io"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+type topClusterQueuePrinter struct {
+	printOptions printers.PrintOptions
+}
+
+var _ printers.ResourcePrinter = (*topClusterQueuePrinter)(nil)
+
+func (p *topClusterQueuePrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	printer := printers.NewTablePrinter(p.printOptions)
+
+	list, ok := obj.(*v1beta1.ClusterQueueList)
+	if !ok {
+		return errors.New("invalid object type")
+	}
+
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string", Format: "name"},
+			{Name: "Cohort", Type: "string"},
+			{Name: "Flavor", Type: "string"},
+			{Name: "Resource", Type: "string"},
+			{Name: "Usage", Type: "string"},
+			{Name: "Nominal", Type: "string"},
+			{Name: "Borrowed", Type: "string"},
+			{Name: "Lendable", Type: "string"},
+			{Name: "Pending Workloads", Type: "integer"},
+			{Name: "Admitted Workloads", Type: "integer"},
+		},
+		Rows: p.printClusterQueueList(list),
+	}
+
+	return printer.PrintObj(table, out)
+}
+
+func (p *topClusterQueuePrinter) WithHeaders(f bool) *topClusterQueuePrinter {
+	p.printOptions.NoHeaders = !f
+	return p
+}
+
+func newClusterQueueTablePrinter() *topClusterQueuePrinter {
+	return &topClusterQueuePrinter{}
+}
+
+func (p *topClusterQueuePrinter) printClusterQueueList(list *v1beta1.ClusterQueueList) []metav1.TableRow {
+	var rows []metav1.TableRow
+	for index := range list.Items {
+		rows = append(rows, p.printClusterQueue(&list.Items[index])...)
+	}
+	return rows
+}
+
+func (p *topClusterQueuePrinter) printClusterQueue(clusterQueue *v1beta1.ClusterQueue) []metav1.TableRow {
+	var rows []metav1.TableRow
+	for _, rg := range clusterQueue.Spec.ResourceGroups {
+		for _, fq := range rg.Flavors {
+			for i := range fq.Resources {
+				rq := &fq.Resources[i]
+				usage := clusterQueueUsage(clusterQueue, fq.Name, rq.Name)
+				lendable := lendableQuota(rq)
+				rows = append(rows, metav1.TableRow{
+					Object: runtime.RawExtension{Object: clusterQueue},
+					Cells: []any{
+						clusterQueue.Name,
+						clusterQueue.Spec.Cohort,
+						string(fq.Name),
+						string(rq.Name),
+						usage.Total.String(),
+						rq.NominalQuota.String(),
+						usage.Borrowed.String(),
+						lendable.String(),
+						clusterQueue.Status.PendingWorkloads,
+						clusterQueue.Status.AdmittedWorkloads,
+					},
+				})
+			}
+		}
+	}
+	return rows
+}
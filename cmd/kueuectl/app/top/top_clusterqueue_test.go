@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package top
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestClusterQueueCmd(t *testing.T) {
+	testCases := map[string]struct {
+		objs       []runtime.Object
+		args       []string
+		wantOut    string
+		wantOutErr string
+		wantErr    error
+	}{
+		"should print the usage of each flavor and resource": {
+			objs: []runtime.Object{
+				utiltesting.MakeClusterQueue("cq2").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource("cpu", "4").Obj()).
+					Obj(),
+				utiltesting.MakeClusterQueue("cq1").
+					Cohort("all").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("on-demand").
+							Resource("cpu", "10", "", "5").
+							Resource("memory", "20Gi").
+							Obj(),
+						*utiltesting.MakeFlavorQuotas("spot").
+							Resource("cpu", "5").
+							Resource("memory", "10Gi").
+							Obj(),
+					).
+					FlavorsUsage(
+						v1beta1.FlavorUsage{
+							Name: "on-demand",
+							Resources: []v1beta1.ResourceUsage{
+								{Name: "cpu", Total: resource.MustParse("12"), Borrowed: resource.MustParse("2")},
+								{Name: "memory", Total: resource.MustParse("8Gi")},
+							},
+						},
+					).
+					PendingWorkloads(3).
+					AdmittedWorkloads(2).
+					Obj(),
+			},
+			wantOut: `NAME   COHORT   FLAVOR      RESOURCE   USAGE   NOMINAL   BORROWED   LENDABLE   PENDING WORKLOADS   ADMITTED WORKLOADS
+cq1    all      on-demand   cpu        12      10        2          5          3                   2
+cq1    all      on-demand   memory     8Gi     20Gi      0          20Gi       3                   2
+cq1    all      spot        cpu        0       5         0          5          3                   2
+cq1    all      spot        memory     0       10Gi      0          10Gi       3                   2
+cq2             default     cpu        0       4         0          4          0                   0
+`,
+		},
+		"should print the usage of the ClusterQueue with the given name": {
+			objs: []runtime.Object{
+				utiltesting.MakeClusterQueue("cq1").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource("cpu", "10").Obj()).
+					Obj(),
+				utiltesting.MakeClusterQueue("cq2").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource("cpu", "4").Obj()).
+					Obj(),
+			},
+			args: []string{"cq2", "--no-headers"},
+			wantOut: `cq2         default   cpu   0     4     0     4     0     0
+`,
+		},
+		"should filter by label selector": {
+			objs: []runtime.Object{
+				utiltesting.MakeClusterQueue("cq1").
+					Label("team", "a").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource("cpu", "10").Obj()).
+					Obj(),
+				utiltesting.MakeClusterQueue("cq2").
+					Label("team", "b").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource("cpu", "4").Obj()).
+					Obj(),
+			},
+			args: []string{"-l", "team=a"},
+			wantOut: `NAME   COHORT   FLAVOR    RESOURCE   USAGE   NOMINAL   BORROWED   LENDABLE   PENDING WORKLOADS   ADMITTED WORKLOADS
+cq1             default   cpu        0       10        0          10         0                   0
+`,
+		},
+		"should print not found error": {
+			wantOutErr: "No resources found\n",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(fake.NewSimpleClientset(tc.objs...))
+
+			cmd := NewClusterQueueCmd(tcg, streams)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			gotOut := out.String()
+			if diff := cmp.Diff(tc.wantOut, gotOut); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			gotOutErr := outErr.String()
+			if diff := cmp.Diff(tc.wantOutErr, gotOutErr); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package top
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	kueuev1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+)
+
+var (
+	lqLong = templates.LongDesc(`
+		Display the resource usage of LocalQueues.
+
+		For each flavor and resource of the ClusterQueue of the LocalQueues, the
+		command shows the quota used by the workloads admitted through the
+		LocalQueue and the nominal quota of the ClusterQueue, along with the
+		number of pending and admitted workloads.
+	`)
+	lqExample = templates.Examples(`
+		# Show the usage of the LocalQueues in the current namespace
+		kueuectl top localqueue
+
+		# Show the usage of the LocalQueues in all namespaces
+		kueuectl top localqueue -A
+	`)
+)
+
+type LocalQueueOptions struct {
+	Name          string
+	Namespace     string
+	AllNamespaces bool
+	LabelSelector string
+	NoHeaders     bool
+
+	Client kueuev1beta1.KueueV1beta1Interface
+
+	genericiooptions.IOStreams
+}
+
+func NewLocalQueueOptions(streams genericiooptions.IOStreams) *LocalQueueOptions {
+	return &LocalQueueOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewLocalQueueCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewLocalQueueOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:                   "localqueue [NAME] [--namespace NAMESPACE] [--selector key1=value1] [--all-namespaces] [--no-headers]",
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"lq"},
+		Short:                 "Display the resource usage of LocalQueues",
+		Long:                  lqLong,
+		Example:               lqExample,
+		Args:                  cobra.MaximumNArgs(1),
+		ValidArgsFunction:     completion.LocalQueueNameFunc(clientGetter, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			err := o.Complete(clientGetter, args)
+			if err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+
+	util.AddAllNamespacesFlagVar(cmd, &o.AllNamespaces)
+	addLabelSelectorFlagVar(cmd, &o.LabelSelector)
+	addNoHeadersFlagVar(cmd, &o.NoHeaders)
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *LocalQueueOptions) Complete(clientGetter util.ClientGetter, args []string) error {
+	var err error
+
+	if len(args) > 0 {
+		o.Name = args[0]
+	}
+
+	o.Namespace, _, err = clientGetter.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.Client = clientset.KueueV1beta1()
+
+	return nil
+}
+
+// Run prints the resource usage of the local queues.
+func (o *LocalQueueOptions) Run(ctx context.Context) error {
+	namespace := o.Namespace
+	if o.AllNamespaces {
+		namespace = ""
+	}
+
+	list := &v1beta1.LocalQueueList{}
+	if o.Name != "" && !o.AllNamespaces {
+		lq, err := o.Client.LocalQueues(namespace).Get(ctx, o.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		list.Items = append(list.Items, *lq)
+	} else {
+		var err error
+		list, err = o.Client.LocalQueues(namespace).List(ctx, metav1.ListOptions{LabelSelector: o.LabelSelector})
+		if err != nil {
+			return err
+		}
+		if o.Name != "" {
+			list.Items = slices.DeleteFunc(list.Items, func(lq v1beta1.LocalQueue) bool {
+				return lq.Name != o.Name
+			})
+		}
+	}
+
+	if len(list.Items) == 0 {
+		if !o.AllNamespaces {
+			fmt.Fprintf(o.ErrOut, "No resources found in %s namespace.\n", o.Namespace)
+		} else {
+			fmt.Fprintln(o.ErrOut, "No resources found")
+		}
+		return nil
+	}
+
+	slices.SortFunc(list.Items, func(a, b v1beta1.LocalQueue) int {
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	clusterQueues, err := o.clusterQueues(ctx)
+	if err != nil {
+		return err
+	}
+
+	printer := newLocalQueueTablePrinter().
+		WithNamespace(o.AllNamespaces).
+		WithHeaders(!o.NoHeaders).
+		WithClusterQueues(clusterQueues)
+	tabWriter := printers.GetNewTabWriter(o.Out)
+	if err := printer.PrintObj(list, tabWriter); err != nil {
+		return err
+	}
+	return tabWriter.Flush()
+}
+
+// clusterQueues returns the ClusterQueues by name. Users who are not allowed to
+// list the ClusterQueues still get the usage of the LocalQueues, without the quotas.
+func (o *LocalQueueOptions) clusterQueues(ctx context.Context) (map[string]*v1beta1.ClusterQueue, error) {
+	list, err := o.Client.ClusterQueues().List(ctx, metav1.ListOptions{})
+	if apierrors.IsForbidden(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	clusterQueues := make(map[string]*v1beta1.ClusterQueue, len(list.Items))
+	for i := range list.Items {
+		clusterQueues[list.Items[i].Name] = &list.Items[i]
+	}
+	return clusterQueues, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package top
+
+import (
+	"errors"
+	"io"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+type topLocalQueuePrinter struct {
+	clusterQueues map[string]*v1beta1.ClusterQueue
+	printOptions  printers.PrintOptions
+}
+
+var _ printers.ResourcePrinter = (*topLocalQueuePrinter)(nil)
+
+func (p *topLocalQueuePrinter) PrintObj(obj runtime.Object, out io.Writer) error {
+	printer := printers.NewTablePrinter(p.printOptions)
+
+	list, ok := obj.(*v1beta1.LocalQueueList)
+	if !ok {
+		return errors.New("invalid object type")
+	}
+
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string", Format: "name"},
+			{Name: "ClusterQueue", Type: "string"},
+			{Name: "Flavor", Type: "string"},
+			{Name: "Resource", Type: "string"},
+			{Name: "Usage", Type: "string"},
+			{Name: "Nominal", Type: "string"},
+			{Name: "Pending Workloads", Type: "integer"},
+			{Name: "Admitted Workloads", Type: "integer"},
+		},
+		Rows: p.printLocalQueueList(list),
+	}
+
+	return printer.PrintObj(table, out)
+}
+
+func (p *topLocalQueuePrinter) WithNamespace(f bool) *topLocalQueuePrinter {
+	p.printOptions.WithNamespace = f
+	return p
+}
+
+func (p *topLocalQueuePrinter) WithHeaders(f bool) *topLocalQueuePrinter {
+	p.printOptions.NoHeaders = !f
+	return p
+}
+
+func (p *topLocalQueuePrinter) WithClusterQueues(clusterQueues map[string]*v1beta1.ClusterQueue) *topLocalQueuePrinter {
+	p.clusterQueues = clusterQueues
+	return p
+}
+
+func newLocalQueueTablePrinter() *topLocalQueuePrinter {
+	return &topLocalQueuePrinter{}
+}
+
+func (p *topLocalQueuePrinter) printLocalQueueList(list *v1beta1.LocalQueueList) []metav1.TableRow {
+	var rows []metav1.TableRow
+	for index := range list.Items {
+		rows = append(rows, p.printLocalQueue(&list.Items[index])...)
+	}
+	return rows
+}
+
+func (p *topLocalQueuePrinter) printLocalQueue(localQueue *v1beta1.LocalQueue) []metav1.TableRow {
+	newRow := func(flavor, resource, usage, nominal string) metav1.TableRow {
+		return metav1.TableRow{
+			Object: runtime.RawExtension{Object: localQueue},
+			Cells: []any{
+				localQueue.Name,
+				string(localQueue.Spec.ClusterQueue),
+				flavor,
+				resource,
+				usage,
+				nominal,
+				localQueue.Status.PendingWorkloads,
+				localQueue.Status.AdmittedWorkloads,
+			},
+		}
+	}
+
+	var rows []metav1.TableRow
+	cq, found := p.clusterQueues[string(localQueue.Spec.ClusterQueue)]
+	if !found {
+		// Without the ClusterQueue, only the flavors and resources in use are known.
+		for _, fu := range localQueue.Status.FlavorUsage {
+			for _, ru := range fu.Resources {
+				rows = append(rows, newRow(string(fu.Name), string(ru.Name), ru.Total.String(), unknownQuantity))
+			}
+		}
+		return rows
+	}
+	for _, rg := range cq.Spec.ResourceGroups {
+		for _, fq := range rg.Flavors {
+			for _, rq := range fq.Resources {
+				usage := localQueueUsage(localQueue, fq.Name, rq.Name)
+				rows = append(rows, newRow(string(fq.Name), string(rq.Name), usage.String(), rq.NominalQuota.String()))
+			}
+		}
+	}
+	return rows
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package top
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	kubetesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestLocalQueueCmd(t *testing.T) {
+	clusterQueue := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("on-demand").
+				Resource("cpu", "10").
+				Resource("memory", "20Gi").
+				Obj(),
+		).
+		Obj()
+	localQueueUsage := v1beta1.LocalQueueFlavorUsage{
+		Name: "on-demand",
+		Resources: []v1beta1.LocalQueueResourceUsage{
+			{Name: "cpu", Total: resource.MustParse("6")},
+		},
+	}
+
+	testCases := map[string]struct {
+		objs           []runtime.Object
+		forbidCQAccess bool
+		ns             string
+		args           []string
+		wantOut        string
+		wantOutErr     string
+		wantErr        error
+	}{
+		"should print the usage of the LocalQueues in the namespace": {
+			objs: []runtime.Object{
+				clusterQueue,
+				utiltesting.MakeLocalQueue("lq1", "ns1").
+					ClusterQueue("cq").
+					FlavorUsage(localQueueUsage).
+					PendingWorkloads(1).
+					AdmittedWorkloads(2).
+					Obj(),
+				utiltesting.MakeLocalQueue("lq2", "ns2").
+					ClusterQueue("cq").
+					Obj(),
+			},
+			ns: "ns1",
+			wantOut: `NAME   CLUSTERQUEUE   FLAVOR      RESOURCE   USAGE   NOMINAL   PENDING WORKLOADS   ADMITTED WORKLOADS
+lq1    cq             on-demand   cpu        6       10        1                   2
+lq1    cq             on-demand   memory     0       20Gi      1                   2
+`,
+		},
+		"should print the usage of the LocalQueues in all namespaces": {
+			objs: []runtime.Object{
+				clusterQueue,
+				utiltesting.MakeLocalQueue("lq2", "ns2").
+					ClusterQueue("cq").
+					Obj(),
+				utiltesting.MakeLocalQueue("lq1", "ns1").
+					ClusterQueue("cq").
+					FlavorUsage(localQueueUsage).
+					Obj(),
+			},
+			args: []string{"-A"},
+			wantOut: `NAMESPACE   NAME   CLUSTERQUEUE   FLAVOR      RESOURCE   USAGE   NOMINAL   PENDING WORKLOADS   ADMITTED WORKLOADS
+ns1         lq1    cq             on-demand   cpu        6       10        0                   0
+ns1         lq1    cq             on-demand   memory     0       20Gi      0                   0
+ns2         lq2    cq             on-demand   cpu        0       10        0                   0
+ns2         lq2    cq             on-demand   memory     0       20Gi      0                   0
+`,
+		},
+		"should print the usage of the LocalQueue with the given name": {
+			objs: []runtime.Object{
+				clusterQueue,
+				utiltesting.MakeLocalQueue("lq1", "ns1").
+					ClusterQueue("cq").
+					Obj(),
+				utiltesting.MakeLocalQueue("lq2", "ns1").
+					ClusterQueue("cq").
+					FlavorUsage(localQueueUsage).
+					Obj(),
+			},
+			ns:   "ns1",
+			args: []string{"lq2"},
+			wantOut: `NAME   CLUSTERQUEUE   FLAVOR      RESOURCE   USAGE   NOMINAL   PENDING WORKLOADS   ADMITTED WORKLOADS
+lq2    cq             on-demand   cpu        6       10        0                   0
+lq2    cq             on-demand   memory     0       20Gi      0                   0
+`,
+		},
+		"should print the usage without the quotas when the ClusterQueues are forbidden": {
+			objs: []runtime.Object{
+				clusterQueue,
+				utiltesting.MakeLocalQueue("lq1", "ns1").
+					ClusterQueue("cq").
+					FlavorUsage(localQueueUsage).
+					Obj(),
+			},
+			forbidCQAccess: true,
+			ns:             "ns1",
+			wantOut: `NAME   CLUSTERQUEUE   FLAVOR      RESOURCE   USAGE   NOMINAL     PENDING WORKLOADS   ADMITTED WORKLOADS
+lq1    cq             on-demand   cpu        6       <unknown>   0                   0
+`,
+		},
+		"should print not found error": {
+			ns:         "ns1",
+			wantOutErr: "No resources found in ns1 namespace.\n",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			clientset := fake.NewSimpleClientset(tc.objs...)
+			if tc.forbidCQAccess {
+				clientset.PrependReactor("list", "clusterqueues", func(action kubetesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewForbidden(v1beta1.Resource("clusterqueues"), "", nil)
+				})
+			}
+
+			tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(clientset)
+			if len(tc.ns) > 0 {
+				tcg.WithNamespace(tc.ns)
+			}
+
+			cmd := NewLocalQueueCmd(tcg, streams)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			gotOut := out.String()
+			if diff := cmp.Diff(tc.wantOut, gotOut); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			gotOutErr := outErr.String()
+			if diff := cmp.Diff(tc.wantOutErr, gotOutErr); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+)
+
+var (
+	cohortLong = templates.LongDesc(`
+		Prints the hierarchy of the given cohort as a tree.
+
+		For the cohort and each of its descendants, the tree shows the quotas
+		defined by the Cohort object, and the member ClusterQueues, with their
+		quotas, their usage and the quota they borrow from the cohort.
+	`)
+	cohortExample = templates.Examples(`
+		# Print the tree of the cohort
+		kueuectl tree cohort my-cohort
+	`)
+)
+
+var cohortsGVR = v1alpha1.GroupVersion.WithResource("cohorts")
+
+type CohortOptions struct {
+	Cohort string
+
+	ClientSet     versioned.Interface
+	DynamicClient dynamic.Interface
+
+	genericiooptions.IOStreams
+}
+
+// cohortNode is a cohort in the hierarchy, either defined by a Cohort object
+// or implicitly, by the ClusterQueues and Cohorts referencing it.
+type cohortNode struct {
+	cohort        *v1alpha1.Cohort
+	children      []string
+	clusterQueues []*v1beta1.ClusterQueue
+}
+
+// treeItem is a line of the printed tree.
+type treeItem struct {
+	label    string
+	children []treeItem
+}
+
+func NewCohortOptions(streams genericiooptions.IOStreams) *CohortOptions {
+	return &CohortOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewCohortCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewCohortOptions(streams)
+
+	cmd := &cobra.Command{
+		Use: "cohort NAME",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Short:                 "Print the hierarchy of the cohort, with the quotas and the borrowing of its ClusterQueues",
+		Long:                  cohortLong,
+		Example:               cohortExample,
+		Args:                  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter, args)
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *CohortOptions) Complete(clientGetter util.ClientGetter, args []string) error {
+	o.Cohort = args[0]
+
+	var err error
+
+	o.ClientSet, err = clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.DynamicClient, err = clientGetter.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run prints the tree of the cohort
+func (o *CohortOptions) Run(ctx context.Context) error {
+	nodes, err := o.cohortNodes(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, found := nodes[o.Cohort]; !found {
+		return fmt.Errorf("cohort %s not found", o.Cohort)
+	}
+
+	root := cohortItem(nodes, o.Cohort, sets.New[string]())
+	fmt.Fprintln(o.Out, root.label)
+	printItems(o.Out, root.children, "")
+	return nil
+}
+
+func (o *CohortOptions) cohortNodes(ctx context.Context) (map[string]*cohortNode, error) {
+	nodes := make(map[string]*cohortNode)
+	node := func(name string) *cohortNode {
+		if nodes[name] == nil {
+			nodes[name] = &cohortNode{}
+		}
+		return nodes[name]
+	}
+
+	// The Cohorts API might not be installed, in which case all the cohorts are implicit.
+	list, err := o.DynamicClient.Resource(cohortsGVR).List(ctx, metav1.ListOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	if list != nil {
+		for i := range list.Items {
+			cohort := &v1alpha1.Cohort{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, cohort); err != nil {
+				return nil, fmt.Errorf("failed to convert unstructured object: %w", err)
+			}
+			node(cohort.Name).cohort = cohort
+			if cohort.Spec.Parent != "" {
+				parent := node(cohort.Spec.Parent)
+				parent.children = append(parent.children, cohort.Name)
+			}
+		}
+	}
+
+	cqs, err := o.ClientSet.KueueV1beta1().ClusterQueues().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range cqs.Items {
+		cq := &cqs.Items[i]
+		if cq.Spec.Cohort == "" {
+			continue
+		}
+		n := node(cq.Spec.Cohort)
+		n.clusterQueues = append(n.clusterQueues, cq)
+	}
+
+	for _, n := range nodes {
+		slices.Sort(n.children)
+		slices.SortFunc(n.clusterQueues, func(a, b *v1beta1.ClusterQueue) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+	return nodes, nil
+}
+
+// cohortItem builds the tree of the cohort: its own quotas first, then its
+// ClusterQueues and then its child cohorts.
+func cohortItem(nodes map[string]*cohortNode, name string, visited sets.Set[string]) treeItem {
+	item := treeItem{label: "Cohort " + name}
+	if visited.Has(name) {
+		item.label += " (cycle detected)"
+		return item
+	}
+	visited.Insert(name)
+
+	n := nodes[name]
+	if n.cohort != nil {
+		for _, rq := range resourceQuotas(n.cohort.Spec.ResourceGroups) {
+			item.children = append(item.children, treeItem{label: rq.label})
+		}
+	}
+	for _, cq := range n.clusterQueues {
+		item.children = append(item.children, clusterQueueItem(cq))
+	}
+	for _, child := range n.children {
+		item.children = append(item.children, cohortItem(nodes, child, visited))
+	}
+	return item
+}
+
+func clusterQueueItem(cq *v1beta1.ClusterQueue) treeItem {
+	item := treeItem{label: "ClusterQueue " + cq.Name}
+	for _, rq := range resourceQuotas(cq.Spec.ResourceGroups) {
+		label := rq.label
+		if usage := resourceUsage(cq, rq.flavor, rq.resource); usage != nil {
+			label += ", used " + usage.Total.String()
+			if !usage.Borrowed.IsZero() {
+				label += ", borrowed " + usage.Borrowed.String()
+			}
+		}
+		item.children = append(item.children, treeItem{label: label})
+	}
+	return item
+}
+
+type resourceQuota struct {
+	flavor   v1beta1.ResourceFlavorReference
+	resource string
+	label    string
+}
+
+func resourceQuotas(groups []v1beta1.ResourceGroup) []resourceQuota {
+	var quotas []resourceQuota
+	for _, rg := range groups {
+		for _, fq := range rg.Flavors {
+			for _, rq := range fq.Resources {
+				label := fmt.Sprintf("%s in flavor %s: nominal %s", rq.Name, fq.Name, rq.NominalQuota.String())
+				if rq.BorrowingLimit != nil {
+					label += ", borrowing limit " + rq.BorrowingLimit.String()
+				}
+				if rq.LendingLimit != nil {
+					label += ", lending limit " + rq.LendingLimit.String()
+				}
+				quotas = append(quotas, resourceQuota{flavor: fq.Name, resource: string(rq.Name), label: label})
+			}
+		}
+	}
+	return quotas
+}
+
+func resourceUsage(cq *v1beta1.ClusterQueue, flavor v1beta1.ResourceFlavorReference, resource string) *v1beta1.ResourceUsage {
+	for _, fu := range cq.Status.FlavorsUsage {
+		if fu.Name != flavor {
+			continue
+		}
+		for i := range fu.Resources {
+			if string(fu.Resources[i].Name) == resource {
+				return &fu.Resources[i]
+			}
+		}
+	}
+	return nil
+}
+
+func printItems(out io.Writer, items []treeItem, prefix string) {
+	for i, item := range items {
+		branch, indent := "├── ", "│   "
+		if i == len(items)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Fprintln(out, prefix+branch+item.label)
+		printItems(out, item.children, prefix+indent)
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/scheme"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestCohortCmd(t *testing.T) {
+	clusterQueues := []runtime.Object{
+		utiltesting.MakeClusterQueue("standalone").Obj(),
+		utiltesting.MakeClusterQueue("team-a-cq1").
+			Cohort("team-a").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("on-demand").
+				Resource("cpu", "10", "5").
+				Resource("memory", "10Gi").
+				Obj()).
+			FlavorsUsage(v1beta1.FlavorUsage{
+				Name: "on-demand",
+				Resources: []v1beta1.ResourceUsage{
+					{Name: "cpu", Total: resource.MustParse("14"), Borrowed: resource.MustParse("4")},
+					{Name: "memory", Total: resource.MustParse("2Gi")},
+				},
+			}).
+			Obj(),
+		utiltesting.MakeClusterQueue("team-a-cq0").
+			Cohort("team-a").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("on-demand").
+				Resource("cpu", "4", "", "2").
+				Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("org-cq").
+			Cohort("org").
+			Obj(),
+	}
+
+	testCases := map[string]struct {
+		cqs     []runtime.Object
+		cohorts []runtime.Object
+		args    []string
+		wantOut string
+		wantErr string
+	}{
+		"implicit cohort": {
+			cqs:  clusterQueues,
+			args: []string{"team-a"},
+			wantOut: `Cohort team-a
+├── ClusterQueue team-a-cq0
+│   └── cpu in flavor on-demand: nominal 4, lending limit 2
+└── ClusterQueue team-a-cq1
+    ├── cpu in flavor on-demand: nominal 10, borrowing limit 5, used 14, borrowed 4
+    └── memory in flavor on-demand: nominal 10Gi, used 2Gi
+`,
+		},
+		"cohort tree": {
+			cqs: clusterQueues,
+			cohorts: []runtime.Object{
+				utiltesting.MakeCohort("org").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("on-demand").
+						Resource("cpu", "8").
+						Obj()).
+					Obj(),
+				utiltesting.MakeCohort("team-a").Parent("org").Obj(),
+				utiltesting.MakeCohort("team-b").Parent("org").Obj(),
+			},
+			args: []string{"org"},
+			wantOut: `Cohort org
+├── cpu in flavor on-demand: nominal 8
+├── ClusterQueue org-cq
+├── Cohort team-a
+│   ├── ClusterQueue team-a-cq0
+│   │   └── cpu in flavor on-demand: nominal 4, lending limit 2
+│   └── ClusterQueue team-a-cq1
+│       ├── cpu in flavor on-demand: nominal 10, borrowing limit 5, used 14, borrowed 4
+│       └── memory in flavor on-demand: nominal 10Gi, used 2Gi
+└── Cohort team-b
+`,
+		},
+		"cycle": {
+			cohorts: []runtime.Object{
+				utiltesting.MakeCohort("a").Parent("b").Obj(),
+				utiltesting.MakeCohort("b").Parent("a").Obj(),
+			},
+			args: []string{"a"},
+			wantOut: `Cohort a
+└── Cohort b
+    └── Cohort a (cycle detected)
+`,
+		},
+		"cohort not found": {
+			cqs:     clusterQueues,
+			args:    []string{"invalid"},
+			wantErr: "cohort invalid not found",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme.Scheme,
+				map[schema.GroupVersionResource]string{cohortsGVR: "CohortList"}, tc.cohorts...)
+			tcg := cmdtesting.NewTestClientGetter().
+				WithKueueClientset(fake.NewSimpleClientset(tc.cqs...)).
+				WithDynamicClient(dynamicClient)
+
+			cmd := NewCohortCmd(tcg, streams)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			gotOut := out.String()
+			if diff := cmp.Diff(tc.wantOut, gotOut); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			if tc.wantErr == "" {
+				if gotOutErr := outErr.String(); gotOutErr != "" {
+					t.Errorf("Unexpected error output: %s", gotOutErr)
+				}
+			}
+		})
+	}
+}
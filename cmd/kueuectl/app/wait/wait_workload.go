@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	kueuev1beta1 "sigs.k8s.io/kueue/client-go/clientset/versioned/typed/kueue/v1beta1"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// pollInterval is the interval at which the state of the workload is checked.
+var pollInterval = 2 * time.Second
+
+const (
+	forAdmitted = "admitted"
+	forFinished = "finished"
+)
+
+var forStates = []string{forAdmitted, forFinished}
+
+var (
+	wlLong = templates.LongDesc(`
+		Waits until the given Workload is admitted or finished.
+
+		With --for finished, the command fails if the Workload finished
+		unsuccessfully. With --for admitted, the command fails if the
+		Workload finished without being admitted.
+	`)
+	wlExample = templates.Examples(`
+		# Wait for the workload to be admitted
+		kueuectl wait workload my-workload --for admitted
+
+		# Wait for the workload to finish, for at most 1 hour
+		kueuectl wait workload my-workload --for finished --timeout 1h
+	`)
+)
+
+type WorkloadOptions struct {
+	Name      string
+	Namespace string
+	For       string
+	Timeout   time.Duration
+
+	Client kueuev1beta1.KueueV1beta1Interface
+
+	genericiooptions.IOStreams
+}
+
+func NewWorkloadOptions(streams genericiooptions.IOStreams) *WorkloadOptions {
+	return &WorkloadOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewWorkloadCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewWorkloadOptions(streams)
+
+	cmd := &cobra.Command{
+		Use: "workload NAME --for STATE [--namespace NAMESPACE] [--timeout DURATION]",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"wl"},
+		Short:                 "Wait for the Workload to be admitted or finished",
+		Long:                  wlLong,
+		Example:               wlExample,
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgsFunction:     completion.WorkloadNameFunc(clientGetter, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter, args)
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVar(&o.For, "for", "",
+		fmt.Sprintf("The state to wait for. One of: (%s).", strings.Join(forStates, ", ")))
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 0,
+		"The length of time to wait for the Workload. Zero means wait indefinitely.")
+	cobra.CheckErr(cmd.MarkFlagRequired("for"))
+	cobra.CheckErr(cmd.RegisterFlagCompletionFunc("for", cobra.FixedCompletions(forStates, cobra.ShellCompDirectiveNoFileComp)))
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *WorkloadOptions) Complete(clientGetter util.ClientGetter, args []string) error {
+	o.Name = args[0]
+
+	if o.For != forAdmitted && o.For != forFinished {
+		return fmt.Errorf("invalid state %q, allowed states are: %s", o.For, strings.Join(forStates, ", "))
+	}
+
+	var err error
+
+	o.Namespace, _, err = clientGetter.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	o.Client = clientset.KueueV1beta1()
+
+	return nil
+}
+
+// Run waits until the Workload reaches the state
+func (o *WorkloadOptions) Run(ctx context.Context) error {
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	var wl *v1beta1.Workload
+	err := wait.PollUntilContextCancel(ctx, pollInterval, true, func(ctx context.Context) (bool, error) {
+		var err error
+		wl, err = o.Client.Workloads(o.Namespace).Get(ctx, o.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return workload.IsFinished(wl) || (o.For == forAdmitted && workload.IsAdmitted(wl)), nil
+	})
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("timed out waiting for workload %s/%s to be %s", o.Namespace, o.Name, o.For)
+		}
+		return err
+	}
+
+	if o.For == forAdmitted && workload.IsAdmitted(wl) {
+		fmt.Fprintf(o.Out, "workload.kueue.x-k8s.io/%s admitted\n", o.Name)
+		return nil
+	}
+
+	finished := apimeta.FindStatusCondition(wl.Status.Conditions, v1beta1.WorkloadFinished)
+	switch {
+	case o.For == forAdmitted:
+		return fmt.Errorf("workload %s finished without being admitted: %s", workload.Key(wl), finished.Message)
+	case finished.Reason == v1beta1.WorkloadFinishedReasonSucceeded:
+		fmt.Fprintf(o.Out, "workload.kueue.x-k8s.io/%s finished\n", o.Name)
+		return nil
+	default:
+		return fmt.Errorf("workload %s finished unsuccessfully: %s", workload.Key(wl), finished.Message)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	kubetesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestWorkloadCmd(t *testing.T) {
+	defer func(interval time.Duration) { pollInterval = interval }(pollInterval)
+	pollInterval = time.Millisecond
+
+	pending := utiltesting.MakeWorkload("wl", metav1.NamespaceDefault).Obj()
+	admitted := utiltesting.MakeWorkload("wl", metav1.NamespaceDefault).
+		ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+		Admitted(true).
+		Obj()
+	finished := func(reason, message string) *v1beta1.Workload {
+		return utiltesting.MakeWorkload("wl", metav1.NamespaceDefault).
+			Condition(metav1.Condition{
+				Type:    v1beta1.WorkloadFinished,
+				Status:  metav1.ConditionTrue,
+				Reason:  reason,
+				Message: message,
+			}).
+			Obj()
+	}
+
+	testCases := map[string]struct {
+		// states are the successive states of the workload returned by the checks.
+		states  []*v1beta1.Workload
+		args    []string
+		wantOut string
+		wantErr string
+	}{
+		"should wait for the workload to be admitted": {
+			states:  []*v1beta1.Workload{pending, pending, admitted},
+			args:    []string{"wl", "--for", "admitted"},
+			wantOut: "workload.kueue.x-k8s.io/wl admitted\n",
+		},
+		"should fail when the workload finished without being admitted": {
+			states:  []*v1beta1.Workload{pending, finished(v1beta1.WorkloadFinishedReasonAdmissionChecksRejected, "Admission checks [check] are rejected")},
+			args:    []string{"wl", "--for", "admitted"},
+			wantErr: "workload default/wl finished without being admitted: Admission checks [check] are rejected",
+		},
+		"should wait for the workload to finish": {
+			states:  []*v1beta1.Workload{pending, admitted, finished(v1beta1.WorkloadFinishedReasonSucceeded, "Job finished successfully")},
+			args:    []string{"wl", "--for", "finished"},
+			wantOut: "workload.kueue.x-k8s.io/wl finished\n",
+		},
+		"should fail when the workload failed": {
+			states:  []*v1beta1.Workload{admitted, finished(v1beta1.WorkloadFinishedReasonFailed, "Job has reached the specified backoff limit")},
+			args:    []string{"wl", "--for", "finished"},
+			wantErr: "workload default/wl finished unsuccessfully: Job has reached the specified backoff limit",
+		},
+		"should time out": {
+			states:  []*v1beta1.Workload{pending},
+			args:    []string{"wl", "--for", "admitted", "--timeout", "50ms"},
+			wantErr: "timed out waiting for workload default/wl to be admitted",
+		},
+		"should fail when the workload doesn't exist": {
+			args:    []string{"wl", "--for", "admitted"},
+			wantErr: `workloads.kueue.x-k8s.io "wl" not found`,
+		},
+		"should fail with an invalid state": {
+			args:    []string{"wl", "--for", "running"},
+			wantErr: `invalid state "running", allowed states are: admitted, finished`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+
+			clientset := fake.NewSimpleClientset()
+			clientset.PrependReactor("get", "workloads", func(action kubetesting.Action) (bool, runtime.Object, error) {
+				if len(tc.states) == 0 {
+					return false, nil, nil
+				}
+				wl := tc.states[0]
+				if len(tc.states) > 1 {
+					tc.states = tc.states[1:]
+				}
+				return true, wl.DeepCopy(), nil
+			})
+
+			tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(clientset)
+
+			cmd := NewWorkloadCmd(tcg, streams)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantOut, out.String()); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
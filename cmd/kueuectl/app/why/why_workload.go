@@ -0,0 +1,327 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package why
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/kubectl/pkg/util/templates"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/completion"
+	"sigs.k8s.io/kueue/cmd/kueuectl/app/util"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// maxWorkloadsAhead is the maximum number of workloads ahead that are listed.
+const maxWorkloadsAhead = 10
+
+var (
+	wlLong = templates.LongDesc(`
+		Explains in plain text why the given Workload is pending.
+
+		The explanation includes the result of the last admission attempt,
+		the quota available in each flavor of the ClusterQueue compared to
+		the requests of the Workload, the state of the admission checks
+		and the workloads ahead of the Workload in the ClusterQueue.
+	`)
+	wlExample = templates.Examples(`
+		# Explain why the workload is pending
+		kueuectl why workload my-workload
+	`)
+)
+
+type WorkloadOptions struct {
+	Name      string
+	Namespace string
+
+	ClientSet versioned.Interface
+
+	genericiooptions.IOStreams
+}
+
+func NewWorkloadOptions(streams genericiooptions.IOStreams) *WorkloadOptions {
+	return &WorkloadOptions{
+		IOStreams: streams,
+	}
+}
+
+func NewWorkloadCmd(clientGetter util.ClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewWorkloadOptions(streams)
+
+	cmd := &cobra.Command{
+		Use: "workload NAME [--namespace NAMESPACE]",
+		// To do not add "[flags]" suffix on the end of usage line
+		DisableFlagsInUseLine: true,
+		Aliases:               []string{"wl"},
+		Short:                 "Explain why the given Workload is pending",
+		Long:                  wlLong,
+		Example:               wlExample,
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgsFunction:     completion.WorkloadNameFunc(clientGetter, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			err := o.Complete(clientGetter, args)
+			if err != nil {
+				return err
+			}
+
+			return o.Run(cmd.Context())
+		},
+	}
+
+	return cmd
+}
+
+// Complete completes all the required options
+func (o *WorkloadOptions) Complete(clientGetter util.ClientGetter, args []string) error {
+	o.Name = args[0]
+
+	var err error
+
+	o.Namespace, _, err = clientGetter.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+
+	o.ClientSet, err = clientGetter.KueueClientSet()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run explains the state of the Workload
+func (o *WorkloadOptions) Run(ctx context.Context) error {
+	wl, err := o.ClientSet.KueueV1beta1().Workloads(o.Namespace).Get(ctx, o.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	key := workload.Key(wl)
+	switch {
+	case workload.Status(wl) == workload.StatusFinished:
+		fmt.Fprintf(o.Out, "Workload %s is finished.\n", key)
+		return nil
+	case workload.Status(wl) == workload.StatusAdmitted:
+		fmt.Fprintf(o.Out, "Workload %s is admitted in ClusterQueue %s.\n", key, wl.Status.Admission.ClusterQueue)
+		return nil
+	case workload.Status(wl) == workload.StatusQuotaReserved:
+		fmt.Fprintf(o.Out, "Workload %s has quota reserved in ClusterQueue %s, and is waiting for its admission checks.\n", key, wl.Status.Admission.ClusterQueue)
+		printAdmissionChecks(o.Out, wl)
+		return nil
+	case !ptr.Deref(wl.Spec.Active, true):
+		fmt.Fprintf(o.Out, "Workload %s is deactivated, Kueue doesn't attempt to admit it until it is activated.\n", key)
+		return nil
+	}
+
+	if wl.Spec.QueueName == "" {
+		fmt.Fprintf(o.Out, "Workload %s is pending, because it doesn't have a LocalQueue.\n", key)
+		return nil
+	}
+	lq, err := o.ClientSet.KueueV1beta1().LocalQueues(wl.Namespace).Get(ctx, wl.Spec.QueueName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		fmt.Fprintf(o.Out, "Workload %s is pending, because LocalQueue %s doesn't exist.\n", key, wl.Spec.QueueName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	cqName := string(lq.Spec.ClusterQueue)
+	cq, err := o.ClientSet.KueueV1beta1().ClusterQueues().Get(ctx, cqName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		fmt.Fprintf(o.Out, "Workload %s is pending, because ClusterQueue %s of LocalQueue %s doesn't exist.\n", key, cqName, lq.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Workload %s is pending in ClusterQueue %s through LocalQueue %s.\n", key, cqName, lq.Name)
+	if ptr.Deref(lq.Spec.StopPolicy, v1beta1.None) != v1beta1.None {
+		fmt.Fprintf(o.Out, "LocalQueue %s is stopped with the %s policy.\n", lq.Name, *lq.Spec.StopPolicy)
+	}
+	if ptr.Deref(cq.Spec.StopPolicy, v1beta1.None) != v1beta1.None {
+		fmt.Fprintf(o.Out, "ClusterQueue %s is stopped with the %s policy.\n", cqName, *cq.Spec.StopPolicy)
+	}
+	if cond := meta.FindStatusCondition(cq.Status.Conditions, v1beta1.ClusterQueueActive); cond != nil && cond.Status != metav1.ConditionTrue {
+		fmt.Fprintf(o.Out, "ClusterQueue %s is inactive: %s\n", cqName, cond.Message)
+	}
+
+	if cond := meta.FindStatusCondition(wl.Status.Conditions, v1beta1.WorkloadQuotaReserved); cond != nil && cond.Message != "" {
+		fmt.Fprintf(o.Out, "\nLast admission attempt (%s):\n  %s\n", cond.Reason, cond.Message)
+	}
+
+	printFlavors(o.Out, wl, cq)
+	printAdmissionChecks(o.Out, wl)
+	o.printWorkloadsAhead(ctx, wl, cqName)
+
+	return nil
+}
+
+// printFlavors compares the requests of each pod set of the Workload with the
+// quota available in each flavor which covers them.
+func printFlavors(out io.Writer, wl *v1beta1.Workload, cq *v1beta1.ClusterQueue) {
+	fmt.Fprintln(out, "\nFlavors tried:")
+	for _, ps := range workload.NewInfo(wl).TotalRequests {
+		fmt.Fprintf(out, "  Pod set %s (count: %d):\n", ps.Name, ps.Count)
+
+		covered := make(map[corev1.ResourceName]bool, len(ps.Requests))
+		for _, rg := range cq.Spec.ResourceGroups {
+			var requested []corev1.ResourceName
+			for _, name := range rg.CoveredResources {
+				if _, found := ps.Requests[name]; found {
+					requested = append(requested, name)
+					covered[name] = true
+				}
+			}
+			if len(requested) == 0 {
+				continue
+			}
+			for _, fq := range rg.Flavors {
+				fmt.Fprintf(out, "    Flavor %s:\n", fq.Name)
+				for _, name := range requested {
+					fmt.Fprintf(out, "      %s: %s\n", name, explainQuota(cq, &fq, name, ps.Requests[name]))
+				}
+			}
+		}
+
+		var uncovered []corev1.ResourceName
+		for name := range ps.Requests {
+			if !covered[name] {
+				uncovered = append(uncovered, name)
+			}
+		}
+		slices.Sort(uncovered)
+		for _, name := range uncovered {
+			fmt.Fprintf(out, "    %s: not covered by any resource group of the ClusterQueue\n", name)
+		}
+	}
+}
+
+// explainQuota describes the quota of the resource left in the flavor, and the
+// shortfall for the requested value.
+func explainQuota(cq *v1beta1.ClusterQueue, fq *v1beta1.FlavorQuotas, name corev1.ResourceName, requested int64) string {
+	idx := slices.IndexFunc(fq.Resources, func(rq v1beta1.ResourceQuota) bool { return rq.Name == name })
+	if idx < 0 {
+		return fmt.Sprintf("requested %s, no quota defined", resources.ResourceQuantityString(name, requested))
+	}
+	rq := &fq.Resources[idx]
+	reserved := reservedQuota(cq, fq.Name, name)
+	available := max(resources.ResourceValue(name, rq.NominalQuota)-resources.ResourceValue(name, reserved), 0)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "requested %s, available %s of nominal quota %s",
+		resources.ResourceQuantityString(name, requested),
+		resources.ResourceQuantityString(name, available),
+		rq.NominalQuota.String())
+	shortfall := requested - available
+	if shortfall <= 0 {
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, ", %s more needed", resources.ResourceQuantityString(name, shortfall))
+	switch {
+	case cq.Spec.Cohort == "":
+	case rq.BorrowingLimit != nil && resources.ResourceValue(name, *rq.BorrowingLimit) < shortfall:
+		fmt.Fprintf(&sb, ", more than the borrowing limit %s", rq.BorrowingLimit.String())
+	default:
+		fmt.Fprintf(&sb, ", to borrow from cohort %s", cq.Spec.Cohort)
+	}
+	return sb.String()
+}
+
+// reservedQuota returns the quota of the resource reserved by the workloads
+// in the flavor of the ClusterQueue.
+func reservedQuota(cq *v1beta1.ClusterQueue, flavor v1beta1.ResourceFlavorReference, name corev1.ResourceName) resource.Quantity {
+	for _, fu := range cq.Status.FlavorsReservation {
+		if fu.Name != flavor {
+			continue
+		}
+		for _, ru := range fu.Resources {
+			if ru.Name == name {
+				return ru.Total
+			}
+		}
+	}
+	return resource.Quantity{}
+}
+
+func printAdmissionChecks(out io.Writer, wl *v1beta1.Workload) {
+	if len(wl.Status.AdmissionChecks) == 0 {
+		return
+	}
+	fmt.Fprintln(out, "\nAdmission checks:")
+	for _, ac := range wl.Status.AdmissionChecks {
+		if ac.Message != "" {
+			fmt.Fprintf(out, "  %s: %s, %s\n", ac.Name, ac.State, ac.Message)
+		} else {
+			fmt.Fprintf(out, "  %s: %s\n", ac.Name, ac.State)
+		}
+	}
+}
+
+// printWorkloadsAhead lists the pending workloads ahead of the Workload in
+// the ClusterQueue, as reported by the visibility API.
+func (o *WorkloadOptions) printWorkloadsAhead(ctx context.Context, wl *v1beta1.Workload, cqName string) {
+	summary, err := o.ClientSet.VisibilityV1beta1().ClusterQueues().GetPendingWorkloadsSummary(ctx, cqName, metav1.GetOptions{})
+	if err != nil {
+		fmt.Fprintf(o.Out, "\nUnable to get the workloads ahead in ClusterQueue %s: %v\n", cqName, err)
+		return
+	}
+	idx := slices.IndexFunc(summary.Items, func(pw visibility.PendingWorkload) bool {
+		return pw.Name == wl.Name && pw.Namespace == wl.Namespace
+	})
+	if idx < 0 {
+		fmt.Fprintf(o.Out, "\nWorkload %s is not among the pending workloads of ClusterQueue %s.\n", workload.Key(wl), cqName)
+		return
+	}
+	position := summary.Items[idx].PositionInClusterQueue
+	var ahead []visibility.PendingWorkload
+	for _, pw := range summary.Items {
+		if pw.PositionInClusterQueue < position {
+			ahead = append(ahead, pw)
+		}
+	}
+	slices.SortFunc(ahead, func(a, b visibility.PendingWorkload) int {
+		return int(a.PositionInClusterQueue - b.PositionInClusterQueue)
+	})
+	fmt.Fprintf(o.Out, "\nWorkloads ahead in ClusterQueue %s: %d\n", cqName, position)
+	for i, pw := range ahead {
+		if i == maxWorkloadsAhead {
+			fmt.Fprintf(o.Out, "  ... and %d more\n", len(ahead)-maxWorkloadsAhead)
+			break
+		}
+		fmt.Fprintf(o.Out, "  %s/%s (priority %d, LocalQueue %s)\n", pw.Namespace, pw.Name, pw.Priority, pw.LocalQueueName)
+	}
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package why
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	kubetesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/client-go/clientset/versioned/fake"
+	cmdtesting "sigs.k8s.io/kueue/cmd/kueuectl/app/testing"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestWorkloadCmd(t *testing.T) {
+	clusterQueue := utiltesting.MakeClusterQueue("cq").
+		Cohort("all").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("on-demand").
+				Resource(corev1.ResourceCPU, "10", "1").
+				Resource(corev1.ResourceMemory, "20Gi").
+				Obj(),
+			*utiltesting.MakeFlavorQuotas("spot").
+				Resource(corev1.ResourceCPU, "5").
+				Resource(corev1.ResourceMemory, "10Gi").
+				Obj(),
+		).
+		Obj()
+	clusterQueue.Status.FlavorsReservation = []v1beta1.FlavorUsage{
+		{
+			Name: "on-demand",
+			Resources: []v1beta1.ResourceUsage{
+				{Name: corev1.ResourceCPU, Total: resource.MustParse("8")},
+				{Name: corev1.ResourceMemory, Total: resource.MustParse("4Gi")},
+			},
+		},
+		{
+			Name: "spot",
+			Resources: []v1beta1.ResourceUsage{
+				{Name: corev1.ResourceCPU, Total: resource.MustParse("2")},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		objs             []runtime.Object
+		pendingWorkloads []visibility.PendingWorkload
+		visibilityErr    error
+		args             []string
+		wantOut          string
+		wantOutErr       string
+		wantErr          string
+	}{
+		"should explain why the workload is pending": {
+			objs: []runtime.Object{
+				clusterQueue,
+				utiltesting.MakeLocalQueue("lq", metav1.NamespaceDefault).ClusterQueue("cq").Obj(),
+				utiltesting.MakeWorkload("wl", metav1.NamespaceDefault).
+					Queue("lq").
+					Request(corev1.ResourceCPU, "4").
+					Request(corev1.ResourceMemory, "1Gi").
+					Request("example.com/gpu", "1").
+					Condition(metav1.Condition{
+						Type:    v1beta1.WorkloadQuotaReserved,
+						Status:  metav1.ConditionFalse,
+						Reason:  "Pending",
+						Message: "couldn't assign flavors to pod set main: resource example.com/gpu unavailable in ClusterQueue",
+					}).
+					Obj(),
+			},
+			pendingWorkloads: []visibility.PendingWorkload{
+				{
+					ObjectMeta:             metav1.ObjectMeta{Name: "wl-b", Namespace: "other"},
+					Priority:               10,
+					LocalQueueName:         "lq-other",
+					PositionInClusterQueue: 1,
+				},
+				{
+					ObjectMeta:             metav1.ObjectMeta{Name: "wl-a", Namespace: metav1.NamespaceDefault},
+					Priority:               100,
+					LocalQueueName:         "lq",
+					PositionInClusterQueue: 0,
+				},
+				{
+					ObjectMeta:             metav1.ObjectMeta{Name: "wl", Namespace: metav1.NamespaceDefault},
+					LocalQueueName:         "lq",
+					PositionInClusterQueue: 2,
+				},
+				{
+					ObjectMeta:             metav1.ObjectMeta{Name: "wl-c", Namespace: metav1.NamespaceDefault},
+					LocalQueueName:         "lq",
+					PositionInClusterQueue: 3,
+				},
+			},
+			args: []string{"wl"},
+			wantOut: `Workload default/wl is pending in ClusterQueue cq through LocalQueue lq.
+
+Last admission attempt (Pending):
+  couldn't assign flavors to pod set main: resource example.com/gpu unavailable in ClusterQueue
+
+Flavors tried:
+  Pod set main (count: 1):
+    Flavor on-demand:
+      cpu: requested 4, available 2 of nominal quota 10, 2 more needed, more than the borrowing limit 1
+      memory: requested 1Gi, available 16Gi of nominal quota 20Gi
+    Flavor spot:
+      cpu: requested 4, available 3 of nominal quota 5, 1 more needed, to borrow from cohort all
+      memory: requested 1Gi, available 10Gi of nominal quota 10Gi
+    example.com/gpu: not covered by any resource group of the ClusterQueue
+
+Workloads ahead in ClusterQueue cq: 2
+  default/wl-a (priority 100, LocalQueue lq)
+  other/wl-b (priority 10, LocalQueue lq-other)
+`,
+		},
+		"should explain the pending admission checks": {
+			objs: []runtime.Object{
+				utiltesting.MakeWorkload("wl", metav1.NamespaceDefault).
+					Queue("lq").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					AdmissionCheck(v1beta1.AdmissionCheckState{
+						Name:    "provisioning",
+						State:   v1beta1.CheckStatePending,
+						Message: "Waiting for the nodes",
+					}).
+					AdmissionCheck(v1beta1.AdmissionCheckState{
+						Name:  "multikueue",
+						State: v1beta1.CheckStateReady,
+					}).
+					Obj(),
+			},
+			args: []string{"wl"},
+			wantOut: `Workload default/wl has quota reserved in ClusterQueue cq, and is waiting for its admission checks.
+
+Admission checks:
+  provisioning: Pending, Waiting for the nodes
+  multikueue: Ready
+`,
+		},
+		"should explain the missing LocalQueue": {
+			objs: []runtime.Object{
+				utiltesting.MakeWorkload("wl", metav1.NamespaceDefault).
+					Queue("lq").
+					Obj(),
+			},
+			args:    []string{"wl"},
+			wantOut: "Workload default/wl is pending, because LocalQueue lq doesn't exist.\n",
+		},
+		"should explain the stopped and inactive ClusterQueue": {
+			objs: []runtime.Object{
+				utiltesting.MakeClusterQueue("cq").
+					StopPolicy(v1beta1.Hold).
+					Condition(v1beta1.ClusterQueueActive, metav1.ConditionFalse, "Stopped", "Can't admit new workloads: is stopped.").
+					Obj(),
+				utiltesting.MakeLocalQueue("lq", metav1.NamespaceDefault).ClusterQueue("cq").Obj(),
+				utiltesting.MakeWorkload("wl", metav1.NamespaceDefault).
+					Queue("lq").
+					Obj(),
+			},
+			visibilityErr: apierrors.NewServiceUnavailable("visibility server unavailable"),
+			args:          []string{"wl"},
+			wantOut: `Workload default/wl is pending in ClusterQueue cq through LocalQueue lq.
+ClusterQueue cq is stopped with the Hold policy.
+ClusterQueue cq is inactive: Can't admit new workloads: is stopped.
+
+Flavors tried:
+  Pod set main (count: 1):
+
+Unable to get the workloads ahead in ClusterQueue cq: visibility server unavailable
+`,
+		},
+		"should explain the deactivated workload": {
+			objs: []runtime.Object{
+				utiltesting.MakeWorkload("wl", metav1.NamespaceDefault).
+					Queue("lq").
+					Active(false).
+					Obj(),
+			},
+			args:    []string{"wl"},
+			wantOut: "Workload default/wl is deactivated, Kueue doesn't attempt to admit it until it is activated.\n",
+		},
+		"should explain the admitted workload": {
+			objs: []runtime.Object{
+				utiltesting.MakeWorkload("wl", metav1.NamespaceDefault).
+					Queue("lq").
+					ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+					Admitted(true).
+					Obj(),
+			},
+			args:    []string{"wl"},
+			wantOut: "Workload default/wl is admitted in ClusterQueue cq.\n",
+		},
+		"should explain the finished workload": {
+			objs: []runtime.Object{
+				utiltesting.MakeWorkload("wl", metav1.NamespaceDefault).
+					Queue("lq").
+					Finished().
+					Obj(),
+			},
+			args:    []string{"wl"},
+			wantOut: "Workload default/wl is finished.\n",
+		},
+		"should fail when the workload doesn't exist": {
+			args:    []string{"wl"},
+			wantErr: `workloads.kueue.x-k8s.io "wl" not found`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, outErr := genericiooptions.NewTestIOStreams()
+
+			clientset := fake.NewSimpleClientset(tc.objs...)
+			// `SimpleClientset` doesn't allow to add `PendingWorkloadsSummary` objects,
+			// so the pending workloads are returned by a reaction on the subresource.
+			clientset.PrependReactor("get", "clusterqueues", func(action kubetesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "pendingworkloads" {
+					return false, nil, nil
+				}
+				if tc.visibilityErr != nil {
+					return true, nil, tc.visibilityErr
+				}
+				return true, &visibility.PendingWorkloadsSummary{Items: tc.pendingWorkloads}, nil
+			})
+
+			tcg := cmdtesting.NewTestClientGetter().WithKueueClientset(clientset)
+
+			cmd := NewWorkloadCmd(tcg, streams)
+			cmd.SetArgs(tc.args)
+
+			gotErr := cmd.Execute()
+			var gotErrStr string
+			if gotErr != nil {
+				gotErrStr = gotErr.Error()
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErrStr); diff != "" {
+				t.Errorf("Unexpected error (-want/+got)\n%s", diff)
+			}
+
+			gotOut := out.String()
+			if diff := cmp.Diff(tc.wantOut, gotOut); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+
+			gotOutErr := outErr.String()
+			if diff := cmp.Diff(tc.wantOutErr, gotOutErr); diff != "" {
+				t.Errorf("Unexpected output (-want/+got)\n%s", diff)
+			}
+		})
+	}
+}
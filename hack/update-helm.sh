@@ -103,6 +103,9 @@ search_webhook_pod_mutate="        path: /mutate--v1-pod"
 search_webhook_pod_validate="        path: /validate--v1-pod"
 search_webhook_deployment_mutate="        path: /mutate-apps-v1-deployment"
 search_webhook_deployment_validate="        path: /validate-apps-v1-deployment"
+search_webhook_replicaset_mutate="        path: /mutate-apps-v1-replicaset"
+search_webhook_replicaset_validate="        path: /validate-apps-v1-replicaset"
+search_webhook_configuration_validate="        path: /validate--v1-configmap"
 search_mutate_webhook_annotations='  name: '\''{{ include "kueue.fullname" . }}-mutating-webhook-configuration'\'''
 search_validate_webhook_annotations='  name: '\''{{ include "kueue.fullname" . }}-validating-webhook-configuration'\'''
 add_webhook_line=$(
@@ -196,6 +199,52 @@ add_webhook_deployment_validate=$(
             - '{{ .Release.Namespace }}'
 EOF
 )
+add_webhook_replicaset_mutate=$(
+  cat <<'EOF'
+    {{- if has "replicaset" $integrationsConfig.frameworks }}
+    failurePolicy: Fail
+    {{- else }}
+    failurePolicy: Ignore
+    {{- end }}
+    name: mreplicaset.kb.io
+    namespaceSelector:
+      matchExpressions:
+        - key: kubernetes.io/metadata.name
+          operator: NotIn
+          values:
+            - kube-system
+            - '{{ .Release.Namespace }}'
+EOF
+)
+add_webhook_replicaset_validate=$(
+  cat <<'EOF'
+    {{- if has "replicaset" $integrationsConfig.frameworks }}
+    failurePolicy: Fail
+    {{- else }}
+    failurePolicy: Ignore
+    {{- end }}
+    name: vreplicaset.kb.io
+    namespaceSelector:
+      matchExpressions:
+        - key: kubernetes.io/metadata.name
+          operator: NotIn
+          values:
+            - kube-system
+            - '{{ .Release.Namespace }}'
+EOF
+)
+add_webhook_configuration_validate=$(
+  cat <<'EOF'
+    failurePolicy: Ignore
+    name: vconfiguration.kb.io
+    namespaceSelector:
+      matchLabels:
+        kubernetes.io/metadata.name: '{{ .Release.Namespace }}'
+    objectSelector:
+      matchLabels:
+        kueue.x-k8s.io/configuration: "true"
+EOF
+)
 
 # Add certmanager and webhook values in the YAML files
 for output_file in "${DEST_CRD_DIR}"/*.yaml; do
@@ -306,6 +355,18 @@ for output_file in "${new_files[@]}"; do
       count=$((count+2))
       echo "$add_webhook_deployment_validate" >>"$output_file"
     fi
+    if [[ $line == "$search_webhook_replicaset_mutate" ]]; then
+      count=$((count+2))
+      echo "$add_webhook_replicaset_mutate" >>"$output_file"
+    fi
+    if [[ $line == "$search_webhook_replicaset_validate" ]]; then
+      count=$((count+2))
+      echo "$add_webhook_replicaset_validate" >>"$output_file"
+    fi
+    if [[ $line == "$search_webhook_configuration_validate" ]]; then
+      count=$((count+2))
+      echo "$add_webhook_configuration_validate" >>"$output_file"
+    fi
   done < "$input_file"
   rm "$input_file"
 done
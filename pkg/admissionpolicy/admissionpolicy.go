@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admissionpolicy
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+const (
+	workloadVariable = "workload"
+
+	// costLimit bounds the cost of evaluating an expression, so that a
+	// policy can't stall the scheduling cycles.
+	costLimit = 1_000_000
+)
+
+var (
+	supportedActions = []kueue.AdmissionPolicyAction{kueue.AdmissionPolicyReject, kueue.AdmissionPolicyDeactivate}
+
+	newEnv = sync.OnceValues(func() (*cel.Env, error) {
+		return cel.NewEnv(
+			cel.Variable(workloadVariable, cel.DynType),
+			ext.Strings(),
+		)
+	})
+)
+
+// Policy is an AdmissionPolicy with its compiled expression.
+type Policy struct {
+	name       string
+	expression string
+	message    string
+	action     kueue.AdmissionPolicyAction
+	program    cel.Program
+}
+
+// Violation describes the admission policy violated by a workload.
+type Violation struct {
+	Action  kueue.AdmissionPolicyAction
+	Message string
+}
+
+// Compile compiles the expressions of the policies.
+func Compile(policies []kueue.AdmissionPolicy) ([]*Policy, error) {
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	compiled := make([]*Policy, 0, len(policies))
+	var errs []error
+	for _, p := range policies {
+		program, err := compile(p.Expression)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("admission policy %q: %w", p.Name, err))
+			continue
+		}
+		action := p.Action
+		if action == "" {
+			action = kueue.AdmissionPolicyReject
+		}
+		compiled = append(compiled, &Policy{
+			name:       p.Name,
+			expression: p.Expression,
+			message:    p.Message,
+			action:     action,
+			program:    program,
+		})
+	}
+	return compiled, errors.Join(errs...)
+}
+
+func compile(expression string) (cel.Program, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if t := ast.OutputType(); !t.IsExactType(cel.BoolType) && !t.IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("the expression must evaluate to a bool, not to a %s", t)
+	}
+	return env.Program(ast, cel.CostLimit(costLimit))
+}
+
+// Validate returns the errors of the policies, including the errors compiling
+// their expressions.
+func Validate(policies []kueue.AdmissionPolicy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := sets.New[string]()
+	for i, p := range policies {
+		path := fldPath.Index(i)
+		if p.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("name"), ""))
+		} else if names.Has(p.Name) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("name"), p.Name))
+		}
+		names.Insert(p.Name)
+		if p.Action != "" && !sets.New(supportedActions...).Has(p.Action) {
+			allErrs = append(allErrs, field.NotSupported(path.Child("action"), p.Action, supportedActions))
+		}
+		if _, err := compile(p.Expression); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("expression"), p.Expression, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// FromConfig returns the admission policies of the Kueue configuration.
+func FromConfig(policies []configapi.AdmissionPolicy) []kueue.AdmissionPolicy {
+	if len(policies) == 0 {
+		return nil
+	}
+	ret := make([]kueue.AdmissionPolicy, len(policies))
+	for i, p := range policies {
+		ret[i] = kueue.AdmissionPolicy{
+			Name:       p.Name,
+			Expression: p.Expression,
+			Message:    p.Message,
+			Action:     kueue.AdmissionPolicyAction(p.Action),
+		}
+	}
+	return ret
+}
+
+// Evaluate returns the first policy, out of the groups of policies, violated
+// by the workload, or nil if it satisfies all of them.
+func Evaluate(wl *kueue.Workload, groups ...[]*Policy) *Violation {
+	var vars map[string]any
+	for _, policies := range groups {
+		for _, p := range policies {
+			if vars == nil {
+				obj, err := workloadVariableValue(wl)
+				if err != nil {
+					return &Violation{
+						Action:  p.action,
+						Message: fmt.Sprintf("The admission policies couldn't be evaluated: %v", err),
+					}
+				}
+				vars = map[string]any{workloadVariable: obj}
+			}
+			if v := p.evaluate(vars); v != nil {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+// workloadVariableValue returns the workload as an unstructured object, with
+// its labels and annotations always set, so that expressions like
+// `has(workload.metadata.labels.team)` don't fail for the workloads without
+// labels.
+func workloadVariableValue(wl *kueue.Workload) (map[string]any, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(wl)
+	if err != nil {
+		return nil, err
+	}
+	metadata, _ := obj["metadata"].(map[string]any)
+	if metadata == nil {
+		metadata = make(map[string]any, 2)
+		obj["metadata"] = metadata
+	}
+	for _, k := range []string{"labels", "annotations"} {
+		if _, found := metadata[k]; !found {
+			metadata[k] = map[string]any{}
+		}
+	}
+	return obj, nil
+}
+
+func (p *Policy) evaluate(vars map[string]any) *Violation {
+	out, _, err := p.program.Eval(vars)
+	if err != nil {
+		return &Violation{
+			Action:  p.action,
+			Message: fmt.Sprintf("The workload violates the admission policy %q: evaluating the expression failed: %v", p.name, err),
+		}
+	}
+	if allowed, ok := out.Value().(bool); ok && allowed {
+		return nil
+	}
+	message := p.message
+	if message == "" {
+		message = fmt.Sprintf("the expression %q isn't true", p.expression)
+	}
+	return &Violation{
+		Action:  p.action,
+		Message: fmt.Sprintf("The workload violates the admission policy %q: %s", p.name, message),
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admissionpolicy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestValidate(t *testing.T) {
+	policiesPath := field.NewPath("spec", "admissionPolicies")
+	cases := map[string]struct {
+		policies []kueue.AdmissionPolicy
+		wantErr  field.ErrorList
+	}{
+		"valid": {
+			policies: []kueue.AdmissionPolicy{
+				{Name: "team-label", Expression: "has(workload.metadata.labels.team)"},
+				{Name: "max-pods", Expression: "workload.spec.podSets.all(ps, ps.count <= 8)", Action: kueue.AdmissionPolicyDeactivate},
+			},
+		},
+		"invalid expression": {
+			policies: []kueue.AdmissionPolicy{
+				{Name: "broken", Expression: "workload.spec.podSets.all(ps, "},
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(policiesPath.Index(0).Child("expression"), "workload.spec.podSets.all(ps, ", ""),
+			},
+		},
+		"expression not evaluating to a bool": {
+			policies: []kueue.AdmissionPolicy{
+				{Name: "count", Expression: "size(workload.spec.podSets)"},
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(policiesPath.Index(0).Child("expression"), "size(workload.spec.podSets)", ""),
+			},
+		},
+		"duplicate names and unsupported action": {
+			policies: []kueue.AdmissionPolicy{
+				{Name: "team-label", Expression: "true"},
+				{Name: "team-label", Expression: "true", Action: "Evict"},
+			},
+			wantErr: field.ErrorList{
+				field.Duplicate(policiesPath.Index(1).Child("name"), "team-label"),
+				field.NotSupported(policiesPath.Index(1).Child("action"), kueue.AdmissionPolicyAction("Evict"), supportedActions),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := Validate(tc.policies, policiesPath)
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "Detail")); diff != "" {
+				t.Errorf("Unexpected errors (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	global := []kueue.AdmissionPolicy{
+		{Name: "team-label", Expression: "has(workload.metadata.labels.team)", Message: "the workload must have a team label"},
+	}
+	clusterQueue := []kueue.AdmissionPolicy{
+		{Name: "max-pods", Expression: "workload.spec.podSets.all(ps, ps.count <= 8)", Action: kueue.AdmissionPolicyDeactivate},
+		{Name: "missing-field", Expression: "workload.spec.missing == 1"},
+	}
+	cases := map[string]struct {
+		workload      *kueue.Workload
+		clusterQueue  []kueue.AdmissionPolicy
+		wantViolation *Violation
+	}{
+		"satisfied policies": {
+			workload: utiltesting.MakeWorkload("wl", "ns").Label("team", "a").PodSets(*utiltesting.MakePodSet("main", 8).Obj()).Obj(),
+		},
+		"violated global policy": {
+			workload: utiltesting.MakeWorkload("wl", "ns").PodSets(*utiltesting.MakePodSet("main", 16).Obj()).Obj(),
+			wantViolation: &Violation{
+				Action:  kueue.AdmissionPolicyReject,
+				Message: `The workload violates the admission policy "team-label": the workload must have a team label`,
+			},
+		},
+		"violated policy of the ClusterQueue": {
+			workload:     utiltesting.MakeWorkload("wl", "ns").Label("team", "a").PodSets(*utiltesting.MakePodSet("main", 16).Obj()).Obj(),
+			clusterQueue: clusterQueue,
+			wantViolation: &Violation{
+				Action:  kueue.AdmissionPolicyDeactivate,
+				Message: `The workload violates the admission policy "max-pods": the expression "workload.spec.podSets.all(ps, ps.count <= 8)" isn't true`,
+			},
+		},
+		"failed evaluation": {
+			workload:     utiltesting.MakeWorkload("wl", "ns").Label("team", "a").PodSets(*utiltesting.MakePodSet("main", 8).Obj()).Obj(),
+			clusterQueue: clusterQueue,
+			wantViolation: &Violation{
+				Action:  kueue.AdmissionPolicyReject,
+				Message: `The workload violates the admission policy "missing-field": evaluating the expression failed: no such key: missing`,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			globalPolicies, err := Compile(global)
+			if err != nil {
+				t.Fatalf("Compiling the global policies: %v", err)
+			}
+			cqPolicies, err := Compile(tc.clusterQueue)
+			if err != nil {
+				t.Fatalf("Compiling the policies of the ClusterQueue: %v", err)
+			}
+			got := Evaluate(tc.workload, globalPolicies, cqPolicies)
+			if diff := cmp.Diff(tc.wantViolation, got); diff != "" {
+				t.Errorf("Unexpected violation (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"cmp"
+	"slices"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+// maxQuotaFlows is the maximum number of entries in each list of the
+// borrowing status.
+const maxQuotaFlows = 64
+
+// borrowingStatus breaks down the quota that the ClusterQueue borrows from,
+// and lends to, the other ClusterQueues of its Cohort tree. For every
+// FlavorResource, the quota borrowed by each ClusterQueue is attributed to
+// the lending ClusterQueues in proportion to their idle lendable quota.
+// When more quota is borrowed than the ClusterQueues have idle, the rest
+// comes from the quotas of the Cohorts, which is not listed.
+//
+// It expects that the ClusterQueue has a parent and no cycles exist in its
+// Cohort tree.
+func borrowingStatus(cq *clusterQueue) *kueue.ClusterQueueBorrowingStatus {
+	cqs := subtreeClusterQueues(cq.Parent().getRootUnsafe(), nil)
+	status := &kueue.ClusterQueueBorrowingStatus{}
+	for fr := range cq.resourceNode.Quotas {
+		var totalBorrowed, totalIdle int64
+		for _, other := range cqs {
+			totalBorrowed += other.resourceNode.Borrowed(fr)
+			totalIdle += idleLendable(other.resourceNode, fr)
+		}
+		if totalBorrowed == 0 || totalIdle == 0 {
+			continue
+		}
+		denominator := max(totalBorrowed, totalIdle)
+		borrowed := cq.resourceNode.Borrowed(fr)
+		idle := idleLendable(cq.resourceNode, fr)
+		for _, other := range cqs {
+			if other == cq {
+				continue
+			}
+			if amount := proportion(borrowed, idleLendable(other.resourceNode, fr), denominator); amount > 0 {
+				status.BorrowingFrom = append(status.BorrowingFrom, quotaFlow(other.Name, fr, amount))
+			}
+			if amount := proportion(other.resourceNode.Borrowed(fr), idle, denominator); amount > 0 {
+				status.LendingTo = append(status.LendingTo, quotaFlow(other.Name, fr, amount))
+			}
+		}
+	}
+	status.BorrowingFrom = sortQuotaFlows(status.BorrowingFrom)
+	status.LendingTo = sortQuotaFlows(status.LendingTo)
+	return status
+}
+
+// idleLendable is the part of the lendable quota of the node which is not
+// used by the node itself.
+func idleLendable(r ResourceNode, fr resources.FlavorResource) int64 {
+	return max(0, min(r.Lendable(fr), r.SubtreeQuota[fr]-r.Usage[fr]))
+}
+
+// proportion returns borrowed*idle/denominator, computed in floating point
+// to avoid overflowing on large quantities, like memory bytes.
+func proportion(borrowed, idle, denominator int64) int64 {
+	if borrowed == 0 || idle == 0 {
+		return 0
+	}
+	return int64(float64(borrowed) * float64(idle) / float64(denominator))
+}
+
+func quotaFlow(cqName string, fr resources.FlavorResource, amount int64) kueue.QuotaFlow {
+	return kueue.QuotaFlow{
+		ClusterQueue: kueue.ClusterQueueReference(cqName),
+		Flavor:       fr.Flavor,
+		Resource:     fr.Resource,
+		Amount:       resources.ResourceQuantity(fr.Resource, amount),
+	}
+}
+
+// sortQuotaFlows sorts the flows by ClusterQueue, flavor and resource, and
+// truncates them to maxQuotaFlows.
+func sortQuotaFlows(flows []kueue.QuotaFlow) []kueue.QuotaFlow {
+	slices.SortFunc(flows, func(a, b kueue.QuotaFlow) int {
+		return cmp.Or(
+			cmp.Compare(a.ClusterQueue, b.ClusterQueue),
+			cmp.Compare(a.Flavor, b.Flavor),
+			cmp.Compare(a.Resource, b.Resource),
+		)
+	})
+	if len(flows) > maxQuotaFlows {
+		flows = flows[:maxQuotaFlows]
+	}
+	return flows
+}
+
+func subtreeClusterQueues(c *cohort, cqs []*clusterQueue) []*clusterQueue {
+	cqs = append(cqs, c.ChildCQs()...)
+	for _, child := range c.ChildCohorts() {
+		cqs = subtreeClusterQueues(child, cqs)
+	}
+	return cqs
+}
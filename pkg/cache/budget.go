@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+)
+
+// BudgetSnapshot is an exceeded Budget which stops the admission of the
+// workloads of its LocalQueues.
+type BudgetSnapshot struct {
+	// Name is the namespace/name of the Budget.
+	Name      string
+	Namespace string
+	// LocalQueues are the names of the LocalQueues bound to the Budget, or
+	// empty if all the LocalQueues of the namespace are bound.
+	LocalQueues sets.Set[string]
+}
+
+// BindsLocalQueue returns whether the LocalQueue, by namespace/name, is bound
+// to the Budget.
+func (b *BudgetSnapshot) BindsLocalQueue(lqKey string) bool {
+	namespace, name, found := strings.Cut(lqKey, "/")
+	if !found || namespace != b.Namespace {
+		return false
+	}
+	return b.LocalQueues.Len() == 0 || b.LocalQueues.Has(name)
+}
+
+// StopsAdmission returns whether the Budget is exceeded and its enforcement
+// action stops the admission of the workloads of its LocalQueues.
+func StopsAdmission(budget *kueuealpha.Budget) bool {
+	return budget.Spec.EnforcementAction != "" && budget.Spec.EnforcementAction != kueuealpha.BudgetWarn &&
+		meta.IsStatusConditionTrue(budget.Status.Conditions, kueuealpha.BudgetExceeded)
+}
+
+// AddOrUpdateBudget tracks the Budget while it stops the admission of the
+// workloads of its LocalQueues.
+func (c *Cache) AddOrUpdateBudget(budget *kueuealpha.Budget) {
+	c.Lock()
+	defer c.Unlock()
+	key := budget.Namespace + "/" + budget.Name
+	if !StopsAdmission(budget) {
+		delete(c.exceededBudgets, key)
+		return
+	}
+	c.exceededBudgets[key] = &BudgetSnapshot{
+		Name:        key,
+		Namespace:   budget.Namespace,
+		LocalQueues: sets.New(budget.Spec.LocalQueues...),
+	}
+}
+
+// DeleteBudget stops tracking the Budget, by namespace/name.
+func (c *Cache) DeleteBudget(key string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.exceededBudgets, key)
+}
+
+func (c *Cache) snapshotBudgets(snap *Snapshot) {
+	if len(c.exceededBudgets) == 0 {
+		return
+	}
+	snap.ExceededBudgets = make([]*BudgetSnapshot, 0, len(c.exceededBudgets))
+	for _, b := range c.exceededBudgets {
+		snap.ExceededBudgets = append(snap.ExceededBudgets, b)
+	}
+	slices.SortFunc(snap.ExceededBudgets, func(a, b *BudgetSnapshot) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// ExceededBudget returns the first exceeded Budget, by name, stopping the
+// admission of the workloads of the LocalQueue, or nil if there is none.
+func (s *Snapshot) ExceededBudget(lqKey string) *BudgetSnapshot {
+	for _, b := range s.ExceededBudgets {
+		if b.BindsLocalQueue(lqKey) {
+			return b
+		}
+	}
+	return nil
+}
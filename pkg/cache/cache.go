@@ -20,8 +20,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -48,6 +52,7 @@ var (
 	ErrCqNotFound          = errors.New("cluster queue not found")
 	errQNotFound           = errors.New("queue not found")
 	errWorkloadNotAdmitted = errors.New("workload not admitted by a ClusterQueue")
+	errTenantNotFound      = errors.New("tenant not found")
 )
 
 const (
@@ -59,6 +64,7 @@ const (
 type options struct {
 	workloadInfoOptions []workload.InfoOption
 	podsReadyTracking   bool
+	waitForPodsReady    bool
 	fairSharingEnabled  bool
 }
 
@@ -74,6 +80,15 @@ func WithPodsReadyTracking(f bool) Option {
 	}
 }
 
+// WithWaitForPodsReady indicates whether Kueue waits for the pods of the
+// admitted workloads to be ready, for the ClusterQueues which don't
+// override it in their waitForPodsReady.
+func WithWaitForPodsReady(f bool) Option {
+	return func(o *options) {
+		o.waitForPodsReady = f
+	}
+}
+
 func WithExcludedResourcePrefixes(excludedPrefixes []string) Option {
 	return func(o *options) {
 		o.workloadInfoOptions = append(o.workloadInfoOptions, workload.WithExcludedResourcePrefixes(excludedPrefixes))
@@ -104,12 +119,34 @@ type Cache struct {
 	assumedWorkloads    map[string]string
 	resourceFlavors     map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor
 	podsReadyTracking   bool
+	waitForPodsReady    bool
 	admissionChecks     map[string]AdmissionCheck
 	workloadInfoOptions []workload.InfoOption
 	fairSharingEnabled  bool
 
+	// epoch is advanced whenever the cache is write locked, and identifies
+	// the state of the cache in the snapshots.
+	epoch int64
+
 	hm hierarchy.Manager[*clusterQueue, *cohort]
 
+	tenants map[string]*tenant
+
+	// exceededBudgets are the Budgets stopping the admission of the
+	// workloads of their LocalQueues, by namespace/name.
+	exceededBudgets map[string]*BudgetSnapshot
+
+	// closedSchedules are the names of the Schedules whose ClusterQueues are
+	// closed.
+	closedSchedules sets.Set[string]
+
+	// workloadTemplates are the WorkloadTemplates by name.
+	workloadTemplates map[string]*kueuealpha.WorkloadTemplate
+
+	nodePools map[string]*nodePool
+
+	nodeDevices map[string]*nodeDevices
+
 	tasCache TASCache
 }
 
@@ -124,15 +161,128 @@ func New(client client.Client, opts ...Option) *Cache {
 		resourceFlavors:     make(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor),
 		admissionChecks:     make(map[string]AdmissionCheck),
 		podsReadyTracking:   options.podsReadyTracking,
+		waitForPodsReady:    options.waitForPodsReady,
 		workloadInfoOptions: options.workloadInfoOptions,
 		fairSharingEnabled:  options.fairSharingEnabled,
 		hm:                  hierarchy.NewManager[*clusterQueue, *cohort](newCohort),
+		tenants:             make(map[string]*tenant),
+		exceededBudgets:     make(map[string]*BudgetSnapshot),
+		closedSchedules:     sets.New[string](),
+		workloadTemplates:   make(map[string]*kueuealpha.WorkloadTemplate),
+		nodePools:           make(map[string]*nodePool),
+		nodeDevices:         make(map[string]*nodeDevices),
 		tasCache:            NewTASCache(client),
 	}
 	c.podsReadyCond.L = &c.RWMutex
 	return c
 }
 
+// Lock locks the cache for writing. As the cache is only locked for writing
+// to be modified, it advances the epoch of the cache.
+func (c *Cache) Lock() {
+	c.RWMutex.Lock()
+	c.epoch++
+}
+
+// WorkloadInfoOptions returns the options used to compute the usage of the workloads.
+func (c *Cache) WorkloadInfoOptions() []workload.InfoOption {
+	c.RLock()
+	defer c.RUnlock()
+	return c.workloadInfoOptions
+}
+
+// SetWorkloadInfoOptions replaces the options used to compute the usage of
+// the workloads. The usage of the workloads already in the cache is kept,
+// the options apply to the workloads added or updated afterwards.
+func (c *Cache) SetWorkloadInfoOptions(opts ...workload.InfoOption) {
+	c.Lock()
+	defer c.Unlock()
+	c.workloadInfoOptions = opts
+	for _, cq := range c.hm.ClusterQueues {
+		cq.workloadInfoOptions = opts
+	}
+}
+
+// SetPodsReadyTracking enables or disables the tracking of the PodsReady
+// condition of the admitted workloads, and wakes up the routines waiting
+// for the workloads to be ready.
+func (c *Cache) SetPodsReadyTracking(enabled bool) {
+	c.Lock()
+	defer c.Unlock()
+	if c.podsReadyTracking == enabled {
+		return
+	}
+	c.podsReadyTracking = enabled
+	c.updatePodsReadyTracking()
+}
+
+// SetWaitForPodsReady sets whether Kueue waits for the pods of the admitted
+// workloads to be ready, for the ClusterQueues which don't override it.
+func (c *Cache) SetWaitForPodsReady(enabled bool) {
+	c.Lock()
+	defer c.Unlock()
+	if c.waitForPodsReady == enabled {
+		return
+	}
+	c.waitForPodsReady = enabled
+	c.updatePodsReadyTracking()
+}
+
+// updatePodsReadyTracking updates the tracking of the PodsReady condition
+// of the ClusterQueues, and wakes up the routines waiting for the workloads
+// to be ready.
+func (c *Cache) updatePodsReadyTracking() {
+	for _, cq := range c.hm.ClusterQueues {
+		c.updateClusterQueuePodsReadyTracking(cq)
+	}
+	c.podsReadyCond.Broadcast()
+}
+
+// updateClusterQueuePodsReadyTracking enables or disables the tracking of the
+// PodsReady condition of the workloads of the ClusterQueue, depending on
+// whether the ClusterQueue blocks admission until they are ready.
+// Returns whether the tracking changed.
+func (c *Cache) updateClusterQueuePodsReadyTracking(cq *clusterQueue) bool {
+	tracking := c.blocksAdmissionForPodsReady(cq.waitForPodsReady)
+	if cq.podsReadyTracking == tracking {
+		return false
+	}
+	cq.podsReadyTracking = tracking
+	cq.WorkloadsNotReady = sets.New[string]()
+	if tracking {
+		for k, wi := range cq.Workloads {
+			if !apimeta.IsStatusConditionTrue(wi.Obj.Status.Conditions, kueue.WorkloadPodsReady) {
+				cq.WorkloadsNotReady.Insert(k)
+			}
+		}
+	}
+	return true
+}
+
+// blocksAdmissionForPodsReady returns whether a ClusterQueue with the given
+// waitForPodsReady blocks the admission until the admitted workloads are
+// ready, taking the fields not set from the configuration of Kueue.
+func (c *Cache) blocksAdmissionForPodsReady(cfg *kueue.ClusterQueueWaitForPodsReady) bool {
+	if cfg == nil {
+		return c.podsReadyTracking
+	}
+	globalEnabled := c.waitForPodsReady || c.podsReadyTracking
+	if !ptr.Deref(cfg.Enable, globalEnabled) {
+		return false
+	}
+	// When waitForPodsReady is only enabled by the ClusterQueue, blockAdmission
+	// defaults to true, like in the configuration of Kueue.
+	return ptr.Deref(cfg.BlockAdmission, c.podsReadyTracking || !globalEnabled)
+}
+
+// SetFairSharing enables or disables the reporting of the weighted share
+// of the ClusterQueues.
+func (c *Cache) SetFairSharing(enabled bool) {
+	c.Lock()
+	defer c.Unlock()
+	c.fairSharingEnabled = enabled
+}
+
 func (c *Cache) newClusterQueue(cq *kueue.ClusterQueue) (*clusterQueue, error) {
 	cqImpl := &clusterQueue{
 		Name:                cq.Name,
@@ -150,17 +300,14 @@ func (c *Cache) newClusterQueue(cq *kueue.ClusterQueue) (*clusterQueue, error) {
 	if err := cqImpl.updateClusterQueue(c.hm.CycleChecker, cq, c.resourceFlavors, c.admissionChecks, nil); err != nil {
 		return nil, err
 	}
+	c.updateClusterQueuePodsReadyTracking(cqImpl)
 
 	return cqImpl, nil
 }
 
-// WaitForPodsReady waits for all admitted workloads to be in the PodsReady condition
-// if podsReadyTracking is enabled, otherwise returns immediately.
+// WaitForPodsReady waits for all admitted workloads of the ClusterQueues
+// tracking the PodsReady condition to be in the PodsReady condition.
 func (c *Cache) WaitForPodsReady(ctx context.Context) {
-	if !c.podsReadyTracking {
-		return
-	}
-
 	c.Lock()
 	defer c.Unlock()
 
@@ -182,14 +329,20 @@ func (c *Cache) WaitForPodsReady(ctx context.Context) {
 }
 
 func (c *Cache) PodsReadyForAllAdmittedWorkloads(log logr.Logger) bool {
-	if !c.podsReadyTracking {
-		return true
-	}
 	c.Lock()
 	defer c.Unlock()
 	return c.podsReadyForAllAdmittedWorkloads(log)
 }
 
+// BlocksAdmissionForPodsReady returns whether the ClusterQueue blocks the
+// admission of its workloads until the admitted workloads are ready.
+func (c *Cache) BlocksAdmissionForPodsReady(cqName string) bool {
+	c.RLock()
+	defer c.RUnlock()
+	cq, ok := c.hm.ClusterQueues[cqName]
+	return ok && cq.podsReadyTracking
+}
+
 func (c *Cache) podsReadyForAllAdmittedWorkloads(log logr.Logger) bool {
 	for _, cq := range c.hm.ClusterQueues {
 		if len(cq.WorkloadsNotReady) > 0 {
@@ -306,6 +459,30 @@ func (c *Cache) ClusterQueueActive(name string) bool {
 	return c.clusterQueueInStatus(name, active)
 }
 
+// ClusterQueueExceededQuota returns the FlavorResources for which the ClusterQueue
+// uses more than the quota available to it, and the workloads reserving quota in
+// the ClusterQueue for any of them.
+func (c *Cache) ClusterQueueExceededQuota(name string) ([]resources.FlavorResource, []*workload.Info) {
+	c.RLock()
+	defer c.RUnlock()
+	cq := c.hm.ClusterQueues[name]
+	if cq == nil {
+		return nil, nil
+	}
+	exceeded := exceededQuota(cq)
+	if len(exceeded) == 0 {
+		return nil, nil
+	}
+	var wls []*workload.Info
+	for _, wi := range cq.Workloads {
+		usage := wi.FlavorResourceUsage()
+		if slices.ContainsFunc(exceeded, func(fr resources.FlavorResource) bool { return usage[fr] > 0 }) {
+			wls = append(wls, wi)
+		}
+	}
+	return exceeded, wls
+}
+
 func (c *Cache) ClusterQueueTerminating(name string) bool {
 	return c.clusterQueueInStatus(name, terminating)
 }
@@ -360,6 +537,7 @@ func (c *Cache) ClusterQueueEmpty(name string) bool {
 func (c *Cache) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) error {
 	c.Lock()
 	defer c.Unlock()
+	defer c.syncTenants()
 
 	if _, ok := c.hm.ClusterQueues[cq.Name]; ok {
 		return errors.New("ClusterQueue already exists")
@@ -406,6 +584,7 @@ func (c *Cache) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) err
 func (c *Cache) UpdateClusterQueue(cq *kueue.ClusterQueue) error {
 	c.Lock()
 	defer c.Unlock()
+	defer c.syncTenants()
 	cqImpl, ok := c.hm.ClusterQueues[cq.Name]
 	if !ok {
 		return ErrCqNotFound
@@ -415,6 +594,9 @@ func (c *Cache) UpdateClusterQueue(cq *kueue.ClusterQueue) error {
 	if err := cqImpl.updateClusterQueue(c.hm.CycleChecker, cq, c.resourceFlavors, c.admissionChecks, oldParent); err != nil {
 		return err
 	}
+	if c.updateClusterQueuePodsReadyTracking(cqImpl) {
+		c.podsReadyCond.Broadcast()
+	}
 	for _, qImpl := range cqImpl.localQueues {
 		if qImpl == nil {
 			return errQNotFound
@@ -427,6 +609,7 @@ func (c *Cache) UpdateClusterQueue(cq *kueue.ClusterQueue) error {
 func (c *Cache) DeleteClusterQueue(cq *kueue.ClusterQueue) {
 	c.Lock()
 	defer c.Unlock()
+	defer c.syncTenants()
 	_, ok := c.hm.ClusterQueues[cq.Name]
 	if !ok {
 		return
@@ -450,6 +633,15 @@ func (c *Cache) AddOrUpdateCohort(apiCohort *kueuealpha.Cohort) error {
 	return cohort.updateCohort(c.hm.CycleChecker, apiCohort, oldParent)
 }
 
+// CohortExists returns whether the Cohort is known to the cache, either
+// because it was created or because ClusterQueues or Cohorts refer to it.
+func (c *Cache) CohortExists(cohortName string) bool {
+	c.RLock()
+	defer c.RUnlock()
+	_, found := c.hm.Cohorts[cohortName]
+	return found
+}
+
 func (c *Cache) DeleteCohort(cohortName string) {
 	c.Lock()
 	defer c.Unlock()
@@ -466,6 +658,7 @@ func (c *Cache) DeleteCohort(cohortName string) {
 func (c *Cache) AddLocalQueue(q *kueue.LocalQueue) error {
 	c.Lock()
 	defer c.Unlock()
+	defer c.syncTenants()
 	cq, ok := c.hm.ClusterQueues[string(q.Spec.ClusterQueue)]
 	if !ok {
 		return nil
@@ -476,6 +669,7 @@ func (c *Cache) AddLocalQueue(q *kueue.LocalQueue) error {
 func (c *Cache) DeleteLocalQueue(q *kueue.LocalQueue) {
 	c.Lock()
 	defer c.Unlock()
+	defer c.syncTenants()
 	cq, ok := c.hm.ClusterQueues[string(q.Spec.ClusterQueue)]
 	if !ok {
 		return
@@ -489,6 +683,7 @@ func (c *Cache) UpdateLocalQueue(oldQ, newQ *kueue.LocalQueue) error {
 	}
 	c.Lock()
 	defer c.Unlock()
+	defer c.syncTenants()
 	cq, ok := c.hm.ClusterQueues[string(oldQ.Spec.ClusterQueue)]
 	if ok {
 		cq.deleteLocalQueue(oldQ)
@@ -522,7 +717,7 @@ func (c *Cache) addOrUpdateWorkload(w *kueue.Workload) bool {
 		clusterQueue.deleteWorkload(w)
 	}
 
-	if c.podsReadyTracking {
+	if clusterQueue.podsReadyTracking {
 		c.podsReadyCond.Broadcast()
 	}
 	return clusterQueue.addWorkload(w) == nil
@@ -547,7 +742,59 @@ func (c *Cache) UpdateWorkload(oldWl, newWl *kueue.Workload) error {
 	if !ok {
 		return errors.New("new ClusterQueue doesn't exist")
 	}
-	if c.podsReadyTracking {
+	if cq.podsReadyTracking {
+		c.podsReadyCond.Broadcast()
+	}
+	return cq.addWorkload(newWl)
+}
+
+// ResizeWorkload replaces the usage of the workload with quota reserved with
+// the usage of the resized workload. The additional usage of the workloads
+// which grow is reserved by the scheduler, in the scheduling cycle, which
+// checks that it is available in the snapshot.
+func (c *Cache) ResizeWorkload(oldWl, newWl *kueue.Workload) error {
+	c.Lock()
+	defer c.Unlock()
+
+	cq := c.clusterQueueForWorkload(oldWl)
+	if cq == nil {
+		return ErrCqNotFound
+	}
+	if _, found := cq.Workloads[workload.Key(oldWl)]; !found {
+		return errWorkloadNotAdmitted
+	}
+	return c.resizeWorkload(cq, oldWl, newWl)
+}
+
+// ShrinkWorkload replaces the usage of the workload with quota reserved with
+// the usage of the resized workload, if the resized workload doesn't use more
+// of any resource. Otherwise, it returns false without changing the usage, as
+// the resize has to be reserved by the scheduler.
+func (c *Cache) ShrinkWorkload(oldWl, newWl *kueue.Workload) (bool, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	cq := c.clusterQueueForWorkload(oldWl)
+	if cq == nil {
+		return false, ErrCqNotFound
+	}
+	wi, found := cq.Workloads[workload.Key(oldWl)]
+	if !found {
+		return false, errWorkloadNotAdmitted
+	}
+
+	oldUsage := wi.FlavorResourceUsage()
+	for fr, q := range workload.NewInfo(newWl, cq.workloadInfoOptions...).FlavorResourceUsage() {
+		if q > oldUsage[fr] {
+			return false, nil
+		}
+	}
+	return true, c.resizeWorkload(cq, oldWl, newWl)
+}
+
+func (c *Cache) resizeWorkload(cq *clusterQueue, oldWl, newWl *kueue.Workload) error {
+	cq.deleteWorkload(oldWl)
+	if cq.podsReadyTracking {
 		c.podsReadyCond.Broadcast()
 	}
 	return cq.addWorkload(newWl)
@@ -565,7 +812,7 @@ func (c *Cache) DeleteWorkload(w *kueue.Workload) error {
 	c.cleanupAssumedState(w)
 
 	cq.deleteWorkload(w)
-	if c.podsReadyTracking {
+	if cq.podsReadyTracking {
 		c.podsReadyCond.Broadcast()
 	}
 	return nil
@@ -609,10 +856,35 @@ func (c *Cache) AssumeWorkload(w *kueue.Workload) error {
 	if err := cq.addWorkload(w); err != nil {
 		return err
 	}
+	if features.Enabled(features.ReclaimDebtAccounting) {
+		cq.compensateReclaimDebt(cq.Workloads[k].FlavorResourceUsage())
+	}
 	c.assumedWorkloads[k] = string(w.Status.Admission.ClusterQueue)
 	return nil
 }
 
+// AddReclaimDebt records the quota within the nominal quota which the
+// ClusterQueue lost to the preemptions, so that its workloads are prioritized
+// in the next scheduling cycles until the debt is compensated.
+// The debt is only kept in memory, and it's dropped when the quotas of the
+// ClusterQueue change.
+func (c *Cache) AddReclaimDebt(cqName string, debt resources.FlavorResourceQuantities, now time.Time) {
+	c.Lock()
+	defer c.Unlock()
+	if cq, ok := c.hm.ClusterQueues[cqName]; ok {
+		cq.addReclaimDebt(debt, now)
+	}
+}
+
+// ForgetReclaimDebt drops the reclaim debt of the ClusterQueue.
+func (c *Cache) ForgetReclaimDebt(cqName string) {
+	c.Lock()
+	defer c.Unlock()
+	if cq, ok := c.hm.ClusterQueues[cqName]; ok {
+		cq.forgetReclaimDebt()
+	}
+}
+
 func (c *Cache) ForgetWorkload(w *kueue.Workload) error {
 	c.Lock()
 	defer c.Unlock()
@@ -631,7 +903,7 @@ func (c *Cache) ForgetWorkload(w *kueue.Workload) error {
 		return ErrCqNotFound
 	}
 	cq.deleteWorkload(w)
-	if c.podsReadyTracking {
+	if cq.podsReadyTracking {
 		c.podsReadyCond.Broadcast()
 	}
 	return nil
@@ -643,6 +915,21 @@ type ClusterQueueUsageStats struct {
 	AdmittedResources  []kueue.FlavorUsage
 	AdmittedWorkloads  int
 	WeightedShare      int64
+	// Cohorts are the Cohorts the ClusterQueue belongs to, from its
+	// parent to the root of the Cohort tree.
+	Cohorts []CohortUsageStats
+	// Borrowing is the quota borrowed from, and lent to, the other
+	// ClusterQueues of the Cohort tree. It is only set when the
+	// ClusterQueueBorrowingStatus feature is enabled.
+	Borrowing *kueue.ClusterQueueBorrowingStatus
+}
+
+// CohortUsageStats reports the quota available to the subtree of a Cohort
+// and the usage counting against it.
+type CohortUsageStats struct {
+	Name         string
+	SubtreeQuota resources.FlavorResourceQuantities
+	Usage        resources.FlavorResourceQuantities
 }
 
 // Usage reports the reserved and admitted resources and number of workloads holding them in the ClusterQueue.
@@ -667,6 +954,19 @@ func (c *Cache) Usage(cqObj *kueue.ClusterQueue) (*ClusterQueueUsageStats, error
 		stats.WeightedShare = int64(weightedShare)
 	}
 
+	if cq.HasParent() && !c.hm.CycleChecker.HasCycle(cq.Parent()) {
+		for cohort := cq.Parent(); cohort != nil; cohort = cohort.Parent() {
+			stats.Cohorts = append(stats.Cohorts, CohortUsageStats{
+				Name:         cohort.Name,
+				SubtreeQuota: maps.Clone(cohort.resourceNode.SubtreeQuota),
+				Usage:        maps.Clone(cohort.resourceNode.Usage),
+			})
+		}
+		if features.Enabled(features.ClusterQueueBorrowingStatus) {
+			stats.Borrowing = borrowingStatus(cq)
+		}
+	}
+
 	return stats, nil
 }
 
@@ -829,6 +1129,25 @@ func (c *Cache) ClusterQueuesUsingFlavor(flavor kueue.ResourceFlavorReference) [
 	return cqs
 }
 
+// ClusterQueuesInCohortTree returns the names of the other ClusterQueues
+// in the Cohort tree of the ClusterQueue.
+func (c *Cache) ClusterQueuesInCohortTree(cqName string) []string {
+	c.RLock()
+	defer c.RUnlock()
+
+	cq := c.hm.ClusterQueues[cqName]
+	if cq == nil || !cq.HasParent() || c.hm.CycleChecker.HasCycle(cq.Parent()) {
+		return nil
+	}
+	var cqs []string
+	for _, other := range subtreeClusterQueues(cq.Parent().getRootUnsafe(), nil) {
+		if other != cq {
+			cqs = append(cqs, other.Name)
+		}
+	}
+	return cqs
+}
+
 func (c *Cache) ClusterQueuesUsingAdmissionCheck(ac string) []string {
 	c.RLock()
 	defer c.RUnlock()
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -36,6 +37,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
 	"sigs.k8s.io/kueue/pkg/resources"
@@ -2061,6 +2063,176 @@ func TestClusterQueueUsage(t *testing.T) {
 	}
 }
 
+func TestClusterQueueUsageCohorts(t *testing.T) {
+	cq := utiltesting.MakeClusterQueue("foo").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "10", "", "4").
+				Obj(),
+		).
+		Cohort("child").Obj()
+	child := utiltesting.MakeCohort("child").Parent("root").Obj()
+	root := utiltesting.MakeCohort("root").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "5").
+				Obj(),
+		).Obj()
+	wl := utiltesting.MakeWorkload("one", "").
+		Request(corev1.ResourceCPU, "12").
+		ReserveQuota(utiltesting.MakeAdmission("foo").Assignment(corev1.ResourceCPU, "default", "12").Obj()).
+		Obj()
+	defaultCPU := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+
+	cache := New(utiltesting.NewFakeClient())
+	if err := cache.AddOrUpdateCohort(root); err != nil {
+		t.Fatalf("Adding Cohort: %v", err)
+	}
+	if err := cache.AddOrUpdateCohort(child); err != nil {
+		t.Fatalf("Adding Cohort: %v", err)
+	}
+	if err := cache.AddClusterQueue(context.Background(), cq); err != nil {
+		t.Fatalf("Adding ClusterQueue: %v", err)
+	}
+	if added := cache.AddOrUpdateWorkload(wl); !added {
+		t.Fatalf("Workload %s was not added", workload.Key(wl))
+	}
+	stats, err := cache.Usage(cq)
+	if err != nil {
+		t.Fatalf("Couldn't get usage: %v", err)
+	}
+	wantCohorts := []CohortUsageStats{
+		{
+			Name:         "child",
+			SubtreeQuota: resources.FlavorResourceQuantities{defaultCPU: 4_000},
+			Usage:        resources.FlavorResourceQuantities{defaultCPU: 6_000},
+		},
+		{
+			Name:         "root",
+			SubtreeQuota: resources.FlavorResourceQuantities{defaultCPU: 9_000},
+			Usage:        resources.FlavorResourceQuantities{defaultCPU: 6_000},
+		},
+	}
+	if diff := cmp.Diff(wantCohorts, stats.Cohorts); diff != "" {
+		t.Errorf("Unexpected cohorts usage (-want,+got):\n%s", diff)
+	}
+}
+
+func TestClusterQueueUsageBorrowing(t *testing.T) {
+	makeCQ := func(name, cohort, nominal, lendingLimit string) *kueue.ClusterQueue {
+		return utiltesting.MakeClusterQueue(name).
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, nominal, "", lendingLimit).
+					Obj(),
+			).
+			Cohort(cohort).Obj()
+	}
+	makeWl := func(name, cq, cpu string) *kueue.Workload {
+		return utiltesting.MakeWorkload(name, "").
+			Request(corev1.ResourceCPU, cpu).
+			ReserveQuota(utiltesting.MakeAdmission(cq).Assignment(corev1.ResourceCPU, "default", cpu).Obj()).
+			Obj()
+	}
+	makeFlow := func(cq, cpu string) kueue.QuotaFlow {
+		return kueue.QuotaFlow{
+			ClusterQueue: kueue.ClusterQueueReference(cq),
+			Flavor:       "default",
+			Resource:     corev1.ResourceCPU,
+			Amount:       resource.MustParse(cpu),
+		}
+	}
+	cqs := []*kueue.ClusterQueue{
+		makeCQ("a", "root", "10", ""),
+		makeCQ("b", "root", "10", ""),
+		makeCQ("c", "root", "10", "2"),
+		makeCQ("d", "child", "5", ""),
+		makeCQ("standalone", "", "5", ""),
+	}
+	wls := []*kueue.Workload{
+		makeWl("a", "a", "16"),
+		makeWl("b", "b", "2"),
+		makeWl("d", "d", "8"),
+		makeWl("standalone", "standalone", "2"),
+	}
+	cases := map[string]struct {
+		disableFeature bool
+		clusterQueue   string
+		wantBorrowing  *kueue.ClusterQueueBorrowingStatus
+	}{
+		"borrowing from the lenders in proportion to their idle quota": {
+			clusterQueue: "a",
+			wantBorrowing: &kueue.ClusterQueueBorrowingStatus{
+				BorrowingFrom: []kueue.QuotaFlow{
+					makeFlow("b", "4800m"),
+					makeFlow("c", "1200m"),
+				},
+			},
+		},
+		"borrowing from a child cohort": {
+			clusterQueue: "d",
+			wantBorrowing: &kueue.ClusterQueueBorrowingStatus{
+				BorrowingFrom: []kueue.QuotaFlow{
+					makeFlow("b", "2400m"),
+					makeFlow("c", "600m"),
+				},
+			},
+		},
+		"lending to the borrowers": {
+			clusterQueue: "b",
+			wantBorrowing: &kueue.ClusterQueueBorrowingStatus{
+				LendingTo: []kueue.QuotaFlow{
+					makeFlow("a", "4800m"),
+					makeFlow("d", "2400m"),
+				},
+			},
+		},
+		"lending up to the lending limit": {
+			clusterQueue: "c",
+			wantBorrowing: &kueue.ClusterQueueBorrowingStatus{
+				LendingTo: []kueue.QuotaFlow{
+					makeFlow("a", "1200m"),
+					makeFlow("d", "600m"),
+				},
+			},
+		},
+		"not in a cohort": {
+			clusterQueue: "standalone",
+		},
+		"feature disabled": {
+			disableFeature: true,
+			clusterQueue:   "a",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.ClusterQueueBorrowingStatus, !tc.disableFeature)
+			cache := New(utiltesting.NewFakeClient())
+			if err := cache.AddOrUpdateCohort(utiltesting.MakeCohort("child").Parent("root").Obj()); err != nil {
+				t.Fatalf("Adding Cohort: %v", err)
+			}
+			for _, cq := range cqs {
+				if err := cache.AddClusterQueue(context.Background(), cq); err != nil {
+					t.Fatalf("Adding ClusterQueue: %v", err)
+				}
+			}
+			for _, wl := range wls {
+				if added := cache.AddOrUpdateWorkload(wl); !added {
+					t.Fatalf("Workload %s was not added", workload.Key(wl))
+				}
+			}
+			cqObj := utiltesting.MakeClusterQueue(tc.clusterQueue).Obj()
+			stats, err := cache.Usage(cqObj)
+			if err != nil {
+				t.Fatalf("Couldn't get usage: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantBorrowing, stats.Borrowing); diff != "" {
+				t.Errorf("Unexpected borrowing (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestLocalQueueUsage(t *testing.T) {
 	cq := *utiltesting.MakeClusterQueue("foo").
 		ResourceGroup(
@@ -2284,6 +2456,320 @@ func TestLocalQueueUsage(t *testing.T) {
 	}
 }
 
+func TestTenantUsage(t *testing.T) {
+	cqs := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("cq-a").
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "10").Obj(),
+				*utiltesting.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "10").Obj(),
+			).Obj(),
+		utiltesting.MakeClusterQueue("cq-b").
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "10").Obj(),
+			).Obj(),
+	}
+	lqs := []*kueue.LocalQueue{
+		utiltesting.MakeLocalQueue("lq-a", "ns1").ClusterQueue("cq-a").Obj(),
+		utiltesting.MakeLocalQueue("lq-b", "ns2").ClusterQueue("cq-b").Obj(),
+		utiltesting.MakeLocalQueue("other", "ns2").ClusterQueue("cq-b").Obj(),
+	}
+	wls := []*kueue.Workload{
+		utiltesting.MakeWorkload("one", "ns1").Queue("lq-a").Request(corev1.ResourceCPU, "2").
+			ReserveQuota(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "on-demand", "2").Obj()).Obj(),
+		utiltesting.MakeWorkload("two", "ns1").Queue("lq-a").Request(corev1.ResourceCPU, "3").
+			ReserveQuota(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "spot", "3").Obj()).Obj(),
+		utiltesting.MakeWorkload("three", "ns2").Queue("lq-b").Request(corev1.ResourceCPU, "1").
+			ReserveQuota(utiltesting.MakeAdmission("cq-b").Assignment(corev1.ResourceCPU, "on-demand", "1").Obj()).Obj(),
+		utiltesting.MakeWorkload("four", "ns2").Queue("other").Request(corev1.ResourceCPU, "4").
+			ReserveQuota(utiltesting.MakeAdmission("cq-b").Assignment(corev1.ResourceCPU, "on-demand", "4").Obj()).Obj(),
+	}
+	tenant := utiltesting.MakeTenant("team").
+		LocalQueue("ns1", "lq-a").
+		LocalQueue("ns2", "lq-b").
+		UsageLimit(corev1.ResourceCPU, "8").
+		FairWeight(resource.MustParse("2")).
+		Obj()
+
+	ctx := context.Background()
+	cache := New(utiltesting.NewFakeClient())
+	for _, cq := range cqs {
+		if err := cache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Adding ClusterQueue: %v", err)
+		}
+	}
+	for _, lq := range lqs {
+		if err := cache.AddLocalQueue(lq); err != nil {
+			t.Fatalf("Adding LocalQueue: %v", err)
+		}
+	}
+	for _, wl := range wls {
+		if !cache.AddOrUpdateWorkload(wl) {
+			t.Fatalf("Workload %s was not added", workload.Key(wl))
+		}
+	}
+	if _, err := cache.TenantUsage("team"); !errors.Is(err, errTenantNotFound) {
+		t.Errorf("Unexpected error for a missing Tenant: %v", err)
+	}
+	cache.AddOrUpdateTenant(tenant)
+
+	gotUsage, err := cache.TenantUsage("team")
+	if err != nil {
+		t.Fatalf("Couldn't get the usage of the Tenant: %v", err)
+	}
+	wantUsage := &TenantUsageStats{
+		Usage:              corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("6")},
+		ReservingWorkloads: 3,
+	}
+	if diff := cmp.Diff(wantUsage, gotUsage); diff != "" {
+		t.Errorf("Unexpected usage of the Tenant (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"team"}, cache.TenantsOfLocalQueue("ns2/lq-b")); diff != "" {
+		t.Errorf("Unexpected Tenants of the LocalQueue (-want,+got):\n%s", diff)
+	}
+
+	snapshot, err := cache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error taking the snapshot: %v", err)
+	}
+	tenantSnapshot := snapshot.Tenants["team"]
+	if diff := cmp.Diff([]*TenantSnapshot{tenantSnapshot}, snapshot.LocalQueueTenants["ns1/lq-a"]); diff != "" {
+		t.Errorf("Unexpected Tenants of the LocalQueue in the snapshot (-want,+got):\n%s", diff)
+	}
+	if got := tenantSnapshot.WeightedShare(); got != 375 {
+		t.Errorf("Unexpected weighted share of the Tenant, want 375, got %d", got)
+	}
+	weightedSnapshot := *tenantSnapshot
+	weightedSnapshot.FairResourceWeights = map[corev1.ResourceName]resource.Quantity{corev1.ResourceCPU: resource.MustParse("3")}
+	if got := weightedSnapshot.WeightedShare(); got != 250 {
+		t.Errorf("Unexpected weighted share of the Tenant with a weight for cpu, want 250, got %d", got)
+	}
+	if diff := cmp.Diff([]corev1.ResourceName{corev1.ResourceCPU}, tenantSnapshot.ExceededLimits(resources.Requests{corev1.ResourceCPU: 3_000})); diff != "" {
+		t.Errorf("Unexpected exceeded limits of the Tenant (-want,+got):\n%s", diff)
+	}
+	if exceeded := tenantSnapshot.ExceededLimits(resources.Requests{corev1.ResourceCPU: 2_000, corev1.ResourceMemory: 1}); len(exceeded) != 0 {
+		t.Errorf("Unexpected exceeded limits of the Tenant: %v", exceeded)
+	}
+
+	checkUsage := func(step string, cpu string, reservingWorkloads int) {
+		t.Helper()
+		gotUsage, err := cache.TenantUsage("team")
+		if err != nil {
+			t.Fatalf("Couldn't get the usage of the Tenant after %s: %v", step, err)
+		}
+		wantUsage := &TenantUsageStats{
+			Usage:              corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			ReservingWorkloads: reservingWorkloads,
+		}
+		if diff := cmp.Diff(wantUsage, gotUsage); diff != "" {
+			t.Errorf("Unexpected usage of the Tenant after %s (-want,+got):\n%s", step, diff)
+		}
+	}
+	cache.AddOrUpdateWorkload(utiltesting.MakeWorkload("five", "ns2").Queue("lq-b").Request(corev1.ResourceCPU, "1").
+		ReserveQuota(utiltesting.MakeAdmission("cq-b").Assignment(corev1.ResourceCPU, "on-demand", "1").Obj()).Obj())
+	checkUsage("adding a workload", "7", 4)
+	if err := cache.DeleteWorkload(wls[0]); err != nil {
+		t.Fatalf("Deleting workload: %v", err)
+	}
+	checkUsage("deleting a workload", "5", 3)
+	cache.DeleteLocalQueue(lqs[1])
+	checkUsage("deleting a LocalQueue", "3", 1)
+	if err := cache.AddLocalQueue(lqs[1]); err != nil {
+		t.Fatalf("Adding LocalQueue: %v", err)
+	}
+	checkUsage("adding the LocalQueue back", "5", 3)
+
+	cache.DeleteTenant("team")
+	if got := cache.TenantsOfLocalQueue("ns2/lq-b"); len(got) != 0 {
+		t.Errorf("Unexpected Tenants of the LocalQueue after the deletion: %v", got)
+	}
+}
+
+func TestNodePoolFlavorCaps(t *testing.T) {
+	cqs := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("cq-a").
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "10").Obj(),
+				*utiltesting.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "10").Obj(),
+			).Obj(),
+		utiltesting.MakeClusterQueue("cq-b").
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "10").Obj(),
+			).Obj(),
+	}
+	flavors := []*kueue.ResourceFlavor{
+		utiltesting.MakeResourceFlavor("on-demand").NodeLabel(constants.KarpenterNodePoolLabel, "general").Obj(),
+		utiltesting.MakeResourceFlavor("spot").Obj(),
+	}
+	wls := []*kueue.Workload{
+		utiltesting.MakeWorkload("one", "ns").Request(corev1.ResourceCPU, "2").
+			ReserveQuota(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "on-demand", "2").Obj()).Obj(),
+		utiltesting.MakeWorkload("two", "ns").Request(corev1.ResourceCPU, "3").
+			ReserveQuota(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "spot", "3").Obj()).Obj(),
+		utiltesting.MakeWorkload("three", "ns").Request(corev1.ResourceCPU, "4").
+			ReserveQuota(utiltesting.MakeAdmission("cq-b").Assignment(corev1.ResourceCPU, "on-demand", "4").Obj()).Obj(),
+	}
+
+	ctx := context.Background()
+	cache := New(utiltesting.NewFakeClient())
+	for _, rf := range flavors {
+		cache.AddOrUpdateResourceFlavor(rf)
+	}
+	for _, cq := range cqs {
+		if err := cache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Adding ClusterQueue: %v", err)
+		}
+	}
+	for _, wl := range wls {
+		if !cache.AddOrUpdateWorkload(wl) {
+			t.Fatalf("Workload %s was not added", workload.Key(wl))
+		}
+	}
+	limits := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")}
+	provisioned := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("6")}
+	if !cache.AddOrUpdateNodePool("general", limits, provisioned) {
+		t.Error("Adding the NodePool wasn't reported as a change")
+	}
+	if cache.AddOrUpdateNodePool("general", limits, provisioned) {
+		t.Error("Updating the NodePool with the same capacity was reported as a change")
+	}
+
+	snapshot, err := cache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error taking the snapshot: %v", err)
+	}
+	wantCaps := map[kueue.ResourceFlavorReference]*FlavorCapSnapshot{
+		"on-demand": {
+			NodePool:    "general",
+			Limits:      resources.Requests{corev1.ResourceCPU: 8_000},
+			Provisioned: resources.Requests{corev1.ResourceCPU: 6_000},
+			Usage:       resources.Requests{corev1.ResourceCPU: 6_000},
+		},
+	}
+	if diff := cmp.Diff(wantCaps, snapshot.FlavorCaps); diff != "" {
+		t.Errorf("Unexpected flavor caps in the snapshot (-want,+got):\n%s", diff)
+	}
+	if snapshot.ClusterQueues["cq-b"].FlavorCaps["on-demand"] != snapshot.FlavorCaps["on-demand"] {
+		t.Error("The flavor caps aren't shared with the ClusterQueues of the snapshot")
+	}
+	flavorCap := snapshot.FlavorCaps["on-demand"]
+	if diff := cmp.Diff([]corev1.ResourceName{corev1.ResourceCPU}, flavorCap.ExceededLimits(resources.Requests{corev1.ResourceCPU: 3_000})); diff != "" {
+		t.Errorf("Unexpected exceeded limits of the flavor (-want,+got):\n%s", diff)
+	}
+	if exceeded := flavorCap.ExceededLimits(resources.Requests{corev1.ResourceCPU: 2_000, corev1.ResourceMemory: 1}); len(exceeded) != 0 {
+		t.Errorf("Unexpected exceeded limits of the flavor: %v", exceeded)
+	}
+
+	if !cache.DeleteNodePool("general") {
+		t.Error("Deleting the NodePool wasn't reported as a change")
+	}
+	snapshot, err = cache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error taking the snapshot: %v", err)
+	}
+	if len(snapshot.FlavorCaps) != 0 {
+		t.Errorf("Unexpected flavor caps after the deletion of the NodePool: %v", snapshot.FlavorCaps)
+	}
+}
+
+func TestUnhealthyDevicesFlavorCaps(t *testing.T) {
+	const gpu = corev1.ResourceName("nvidia.com/gpu")
+	cqs := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("cq-a").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("a100").Resource(gpu, "8").Obj()).
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("h100").Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("cq-b").
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("a100").Resource(gpu, "8").Obj(),
+			).Obj(),
+	}
+	flavors := []*kueue.ResourceFlavor{
+		utiltesting.MakeResourceFlavor("a100").NodeLabel("gpu-type", "a100").Obj(),
+		utiltesting.MakeResourceFlavor("h100").NodeLabel("gpu-type", "h100").Obj(),
+	}
+	wl := utiltesting.MakeWorkload("one", "ns").Request(gpu, "4").
+		ReserveQuota(utiltesting.MakeAdmission("cq-a").Assignment(gpu, "a100", "4").Obj()).Obj()
+
+	ctx := context.Background()
+	cache := New(utiltesting.NewFakeClient())
+	for _, rf := range flavors {
+		cache.AddOrUpdateResourceFlavor(rf)
+	}
+	for _, cq := range cqs {
+		if err := cache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Adding ClusterQueue: %v", err)
+		}
+	}
+	if !cache.AddOrUpdateWorkload(wl) {
+		t.Fatalf("Workload %s was not added", workload.Key(wl))
+	}
+	a100Labels := map[string]string{"gpu-type": "a100", "zone": "a"}
+	unhealthy := corev1.ResourceList{gpu: resource.MustParse("2")}
+	if !cache.AddOrUpdateNodeUnhealthyDevices("node-1", a100Labels, unhealthy) {
+		t.Error("Adding the unhealthy devices wasn't reported as a change")
+	}
+	if cache.AddOrUpdateNodeUnhealthyDevices("node-1", a100Labels, unhealthy) {
+		t.Error("Updating the node with the same unhealthy devices was reported as a change")
+	}
+	// The flavor has no quota for the unhealthy devices.
+	cache.AddOrUpdateNodeUnhealthyDevices("node-2", map[string]string{"gpu-type": "h100"}, corev1.ResourceList{gpu: resource.MustParse("1")})
+	if cache.AddOrUpdateNodeUnhealthyDevices("node-3", a100Labels, nil) {
+		t.Error("Adding a node without unhealthy devices was reported as a change")
+	}
+
+	snapshot, err := cache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error taking the snapshot: %v", err)
+	}
+	wantCaps := map[kueue.ResourceFlavorReference]*FlavorCapSnapshot{
+		"a100": {
+			Limits:    resources.Requests{gpu: 14},
+			Unhealthy: resources.Requests{gpu: 2},
+			Usage:     resources.Requests{gpu: 4},
+		},
+	}
+	if diff := cmp.Diff(wantCaps, snapshot.FlavorCaps); diff != "" {
+		t.Errorf("Unexpected flavor caps in the snapshot (-want,+got):\n%s", diff)
+	}
+
+	// The NodePool limits are kept when lower than the healthy quota.
+	cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("a100").
+		NodeLabel("gpu-type", "a100").
+		NodeLabel(constants.KarpenterNodePoolLabel, "gpu").
+		Obj())
+	cache.AddOrUpdateNodePool("gpu", corev1.ResourceList{gpu: resource.MustParse("10")}, nil)
+	snapshot, err = cache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error taking the snapshot: %v", err)
+	}
+	wantCaps = map[kueue.ResourceFlavorReference]*FlavorCapSnapshot{
+		"a100": {
+			NodePool:    "gpu",
+			Limits:      resources.Requests{gpu: 10},
+			Provisioned: resources.Requests{},
+			Usage:       resources.Requests{gpu: 4},
+		},
+	}
+	if diff := cmp.Diff(wantCaps, snapshot.FlavorCaps); diff != "" {
+		t.Errorf("Unexpected flavor caps with the NodePool (-want,+got):\n%s", diff)
+	}
+	cache.DeleteNodePool("gpu")
+
+	if !cache.AddOrUpdateNodeUnhealthyDevices("node-1", a100Labels, nil) {
+		t.Error("Removing the unhealthy devices wasn't reported as a change")
+	}
+	if !cache.DeleteNodeUnhealthyDevices("node-2") {
+		t.Error("Deleting the node wasn't reported as a change")
+	}
+	snapshot, err = cache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error taking the snapshot: %v", err)
+	}
+	if len(snapshot.FlavorCaps) != 0 {
+		t.Errorf("Unexpected flavor caps after the devices recovered: %v", snapshot.FlavorCaps)
+	}
+}
+
 func TestCacheQueueOperations(t *testing.T) {
 	cqs := []*kueue.ClusterQueue{
 		utiltesting.MakeClusterQueue("foo").
@@ -2870,6 +3356,123 @@ func TestWaitForPodsReadyCancelled(t *testing.T) {
 	cache.WaitForPodsReady(ctx)
 }
 
+func TestSetPodsReadyTracking(t *testing.T) {
+	cache := New(utiltesting.NewFakeClient())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := ctrl.LoggerFrom(ctx)
+
+	go cache.CleanUpOnContext(ctx)
+
+	cq := kueue.ClusterQueue{
+		ObjectMeta: metav1.ObjectMeta{Name: "one"},
+	}
+	if err := cache.AddClusterQueue(ctx, &cq); err != nil {
+		t.Fatalf("Failed adding clusterQueue: %v", err)
+	}
+	notReady := utiltesting.MakeWorkload("a", "").ReserveQuota(&kueue.Admission{
+		ClusterQueue: "one",
+	}).Obj()
+	ready := utiltesting.MakeWorkload("b", "").ReserveQuota(&kueue.Admission{
+		ClusterQueue: "one",
+	}).Condition(metav1.Condition{
+		Type:   kueue.WorkloadPodsReady,
+		Status: metav1.ConditionTrue,
+	}).Obj()
+	for _, wl := range []*kueue.Workload{notReady, ready} {
+		if !cache.AddOrUpdateWorkload(wl) {
+			t.Fatalf("Failed adding workload %s", wl.Name)
+		}
+	}
+	if !cache.PodsReadyForAllAdmittedWorkloads(log) {
+		t.Fatalf("Unexpected workloads without PodsReady while the tracking is disabled")
+	}
+
+	cache.SetPodsReadyTracking(true)
+	if diff := cmp.Diff(sets.New("/a"), cache.hm.ClusterQueues["one"].WorkloadsNotReady); diff != "" {
+		t.Errorf("Unexpected workloads not ready after enabling the tracking (-want,+got):\n%s", diff)
+	}
+
+	// disabling the tracking releases the routines waiting for the workloads to be ready
+	done := make(chan struct{})
+	go func() {
+		cache.WaitForPodsReady(ctx)
+		close(done)
+	}()
+	cache.SetPodsReadyTracking(false)
+	<-done
+	if cache.hm.ClusterQueues["one"].WorkloadsNotReady.Len() != 0 {
+		t.Errorf("Unexpected workloads not ready after disabling the tracking: %v", cache.hm.ClusterQueues["one"].WorkloadsNotReady)
+	}
+}
+
+func TestClusterQueuePodsReadyTracking(t *testing.T) {
+	cases := map[string]struct {
+		waitForPodsReady  bool
+		podsReadyTracking bool
+		cqConfig          *kueue.ClusterQueueWaitForPodsReady
+		wantBlocks        bool
+	}{
+		"global blocking": {
+			waitForPodsReady:  true,
+			podsReadyTracking: true,
+			wantBlocks:        true,
+		},
+		"global blocking, disabled by the ClusterQueue": {
+			waitForPodsReady:  true,
+			podsReadyTracking: true,
+			cqConfig:          &kueue.ClusterQueueWaitForPodsReady{Enable: ptr.To(false)},
+		},
+		"global blocking, not blocking in the ClusterQueue": {
+			waitForPodsReady:  true,
+			podsReadyTracking: true,
+			cqConfig:          &kueue.ClusterQueueWaitForPodsReady{BlockAdmission: ptr.To(false)},
+		},
+		"global not blocking, timeout set by the ClusterQueue": {
+			waitForPodsReady: true,
+			cqConfig:         &kueue.ClusterQueueWaitForPodsReady{Timeout: &metav1.Duration{Duration: time.Minute}},
+		},
+		"global disabled": {
+			cqConfig: &kueue.ClusterQueueWaitForPodsReady{Timeout: &metav1.Duration{Duration: time.Minute}},
+		},
+		"global disabled, enabled by the ClusterQueue": {
+			cqConfig:   &kueue.ClusterQueueWaitForPodsReady{Enable: ptr.To(true)},
+			wantBlocks: true,
+		},
+		"global disabled, enabled by the ClusterQueue without blocking": {
+			cqConfig: &kueue.ClusterQueueWaitForPodsReady{Enable: ptr.To(true), BlockAdmission: ptr.To(false)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, log := utiltesting.ContextWithLog(t)
+			cache := New(utiltesting.NewFakeClient(),
+				WithWaitForPodsReady(tc.waitForPodsReady),
+				WithPodsReadyTracking(tc.podsReadyTracking),
+			)
+			cq := utiltesting.MakeClusterQueue("cq").Obj()
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed adding clusterQueue: %v", err)
+			}
+			wl := utiltesting.MakeWorkload("wl", "").ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).Obj()
+			if !cache.AddOrUpdateWorkload(wl) {
+				t.Fatalf("Failed adding workload")
+			}
+
+			cq.Spec.WaitForPodsReady = tc.cqConfig
+			if err := cache.UpdateClusterQueue(cq); err != nil {
+				t.Fatalf("Failed updating clusterQueue: %v", err)
+			}
+			if got := cache.BlocksAdmissionForPodsReady("cq"); got != tc.wantBlocks {
+				t.Errorf("Unexpected BlocksAdmissionForPodsReady: %v, want %v", got, tc.wantBlocks)
+			}
+			if got := cache.PodsReadyForAllAdmittedWorkloads(log); got == tc.wantBlocks {
+				t.Errorf("Unexpected PodsReadyForAllAdmittedWorkloads: %v, want %v", got, !tc.wantBlocks)
+			}
+		})
+	}
+}
+
 // TestCachePodsReadyForAllAdmittedWorkloads verifies the condition used to determine whether to wait
 func TestCachePodsReadyForAllAdmittedWorkloads(t *testing.T) {
 	clusterQueues := []kueue.ClusterQueue{
@@ -3452,6 +4055,231 @@ func TestClusterQueueReadiness(t *testing.T) {
 	}
 }
 
+func TestClusterQueueExceededQuota(t *testing.T) {
+	cqWithQuota := func(cpu string) *kueue.ClusterQueue {
+		return utiltesting.MakeClusterQueue("cq").
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, cpu).
+					Resource(corev1.ResourceMemory, "10Gi").Obj()).
+			Obj()
+	}
+	cpuWorkload := utiltesting.MakeWorkload("cpu", "ns").
+		Request(corev1.ResourceCPU, "4").
+		ReserveQuota(utiltesting.MakeAdmission("cq").
+			Assignment(corev1.ResourceCPU, "default", "4").
+			Obj()).
+		Obj()
+	memoryWorkload := utiltesting.MakeWorkload("memory", "ns").
+		Request(corev1.ResourceMemory, "1Gi").
+		ReserveQuota(utiltesting.MakeAdmission("cq").
+			Assignment(corev1.ResourceMemory, "default", "1Gi").
+			Obj()).
+		Obj()
+
+	cases := map[string]struct {
+		clusterQueue  *kueue.ClusterQueue
+		workloads     []*kueue.Workload
+		wantExceeded  []resources.FlavorResource
+		wantWorkloads []string
+	}{
+		"within quota": {
+			clusterQueue: cqWithQuota("10"),
+			workloads:    []*kueue.Workload{cpuWorkload, memoryWorkload},
+		},
+		"quota shrunk below usage": {
+			clusterQueue:  cqWithQuota("2"),
+			workloads:     []*kueue.Workload{cpuWorkload, memoryWorkload},
+			wantExceeded:  []resources.FlavorResource{{Flavor: "default", Resource: corev1.ResourceCPU}},
+			wantWorkloads: []string{"ns/cpu"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cache := New(utiltesting.NewFakeClient())
+			cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cache.AddClusterQueue(context.Background(), cqWithQuota("10")); err != nil {
+				t.Fatalf("Failed adding ClusterQueue: %v", err)
+			}
+			for _, wl := range tc.workloads {
+				cache.AddOrUpdateWorkload(wl)
+			}
+			if err := cache.UpdateClusterQueue(tc.clusterQueue); err != nil {
+				t.Fatalf("Failed updating ClusterQueue: %v", err)
+			}
+
+			gotExceeded, gotWorkloads := cache.ClusterQueueExceededQuota("cq")
+			if diff := cmp.Diff(tc.wantExceeded, gotExceeded); diff != "" {
+				t.Errorf("Unexpected exceeded quota (-want,+got):\n%s", diff)
+			}
+			gotNames := make([]string, 0, len(gotWorkloads))
+			for _, wi := range gotWorkloads {
+				gotNames = append(gotNames, workload.Key(wi.Obj))
+			}
+			if diff := cmp.Diff(tc.wantWorkloads, gotNames, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected workloads (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResizeWorkload(t *testing.T) {
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "6").Obj()).
+		Obj()
+	wlWithPods := func(name string, pods int32) *kueue.Workload {
+		return utiltesting.MakeWorkload(name, "ns").
+			PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, int(pods)).Request(corev1.ResourceCPU, "1").Obj()).
+			ReserveQuota(utiltesting.MakeAdmission("cq").
+				Assignment(corev1.ResourceCPU, "default", fmt.Sprintf("%d", pods)).
+				AssignmentPodCount(pods).
+				Obj()).
+			Obj()
+	}
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+
+	cases := map[string]struct {
+		resized    *kueue.Workload
+		shrink     bool
+		wantShrunk bool
+		wantUsage  resources.FlavorResourceQuantities
+	}{
+		"grow": {
+			resized:   wlWithPods("wl", 4),
+			wantUsage: resources.FlavorResourceQuantities{cpu: 6_000},
+		},
+		"shrink": {
+			resized:   wlWithPods("wl", 1),
+			wantUsage: resources.FlavorResourceQuantities{cpu: 3_000},
+		},
+		"grow is not applied as a shrink": {
+			resized:   wlWithPods("wl", 3),
+			shrink:    true,
+			wantUsage: resources.FlavorResourceQuantities{cpu: 4_000},
+		},
+		"shrink is applied as a shrink": {
+			resized:    wlWithPods("wl", 1),
+			shrink:     true,
+			wantShrunk: true,
+			wantUsage:  resources.FlavorResourceQuantities{cpu: 3_000},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cache := New(utiltesting.NewFakeClient())
+			cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cache.AddClusterQueue(context.Background(), cq); err != nil {
+				t.Fatalf("Failed adding ClusterQueue: %v", err)
+			}
+			wl := wlWithPods("wl", 2)
+			cache.AddOrUpdateWorkload(wl)
+			cache.AddOrUpdateWorkload(wlWithPods("other", 2))
+
+			if tc.shrink {
+				gotShrunk, err := cache.ShrinkWorkload(wl, tc.resized)
+				if err != nil {
+					t.Fatalf("Failed shrinking workload: %v", err)
+				}
+				if gotShrunk != tc.wantShrunk {
+					t.Errorf("Unexpected shrunk, want=%v, got=%v", tc.wantShrunk, gotShrunk)
+				}
+			} else if err := cache.ResizeWorkload(wl, tc.resized); err != nil {
+				t.Fatalf("Failed resizing workload: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantUsage, cache.hm.ClusterQueues["cq"].resourceNode.Usage); diff != "" {
+				t.Errorf("Unexpected usage (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReclaimDebt(t *testing.T) {
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "6").Obj()).
+		Obj()
+	wlWithPods := func(name string, pods int32) *kueue.Workload {
+		return utiltesting.MakeWorkload(name, "ns").
+			PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, int(pods)).Request(corev1.ResourceCPU, "1").Obj()).
+			ReserveQuota(utiltesting.MakeAdmission("cq").
+				Assignment(corev1.ResourceCPU, "default", fmt.Sprintf("%d", pods)).
+				AssignmentPodCount(pods).
+				Obj()).
+			Obj()
+	}
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+
+	cases := map[string]struct {
+		debt          int64
+		assumed       *kueue.Workload
+		updatedQuotas *kueue.ClusterQueue
+		wantDebt      resources.FlavorResourceQuantities
+	}{
+		"debt within the unused nominal quota": {
+			debt:     3_000,
+			wantDebt: resources.FlavorResourceQuantities{cpu: 3_000},
+		},
+		"debt bounded by the unused nominal quota": {
+			debt:     5_000,
+			wantDebt: resources.FlavorResourceQuantities{cpu: 4_000},
+		},
+		"debt partially compensated by an admission": {
+			debt:     3_000,
+			assumed:  wlWithPods("assumed", 2),
+			wantDebt: resources.FlavorResourceQuantities{cpu: 1_000},
+		},
+		"debt compensated by an admission": {
+			debt:    2_000,
+			assumed: wlWithPods("assumed", 3),
+		},
+		"debt dropped when the quotas change": {
+			debt: 3_000,
+			updatedQuotas: utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(
+					*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "8").Obj()).
+				Obj(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.ReclaimDebtAccounting, true)
+			ctx := context.Background()
+			cache := New(utiltesting.NewFakeClient())
+			cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed adding ClusterQueue: %v", err)
+			}
+			cache.AddOrUpdateWorkload(wlWithPods("admitted", 2))
+
+			cache.AddReclaimDebt("cq", resources.FlavorResourceQuantities{cpu: tc.debt}, time.Now())
+			if tc.assumed != nil {
+				if err := cache.AssumeWorkload(tc.assumed); err != nil {
+					t.Fatalf("Failed assuming workload: %v", err)
+				}
+			}
+			if tc.updatedQuotas != nil {
+				if err := cache.UpdateClusterQueue(tc.updatedQuotas); err != nil {
+					t.Fatalf("Failed updating ClusterQueue: %v", err)
+				}
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Failed building snapshot: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantDebt, snapshot.ClusterQueues["cq"].ReclaimDebt); diff != "" {
+				t.Errorf("Unexpected reclaim debt (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestCohortCycles(t *testing.T) {
 	t.Run("self cycle", func(t *testing.T) {
 		cache := New(utiltesting.NewFakeClient())
@@ -23,6 +23,8 @@ import (
 	"math"
 	"slices"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -34,6 +36,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/admissionpolicy"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
 	"sigs.k8s.io/kueue/pkg/metrics"
@@ -57,9 +60,19 @@ type clusterQueue struct {
 	Workloads         map[string]*workload.Info
 	WorkloadsNotReady sets.Set[string]
 	NamespaceSelector labels.Selector
-	Preemption        kueue.ClusterQueuePreemption
-	FairWeight        resource.Quantity
-	FlavorFungibility kueue.FlavorFungibility
+	// LocalQueueNamespaceSelector selects the namespaces of the LocalQueues
+	// allowed to point at the ClusterQueue, nil if all are allowed.
+	LocalQueueNamespaceSelector labels.Selector
+	Preemption                  kueue.ClusterQueuePreemption
+	FairWeight                  resource.Quantity
+	// FairResourceWeights override the FairWeight for some resources.
+	FairResourceWeights map[corev1.ResourceName]resource.Quantity
+	FlavorFungibility   kueue.FlavorFungibility
+	QuotaShrinkAction   kueue.QuotaShrinkAction
+	AdmissionPolicies   []*admissionpolicy.Policy
+	// Schedule is the name of the Schedule opening and closing the
+	// ClusterQueue, empty if it is always open.
+	Schedule string
 	// Aggregates AdmissionChecks from both .spec.AdmissionChecks and .spec.AdmissionCheckStrategy
 	// Sets hold ResourceFlavors to which an AdmissionCheck should apply.
 	// In case its empty, it means an AdmissionCheck should apply to all ResourceFlavor
@@ -70,9 +83,15 @@ type clusterQueue struct {
 	AllocatableResourceGeneration int64
 
 	AdmittedUsage resources.FlavorResourceQuantities
+	// reclaimDebt is the quota within the nominal quota which the ClusterQueue
+	// lost to the preemptions, and which wasn't compensated yet by admissions.
+	reclaimDebt resources.FlavorResourceQuantities
+	// reclaimDebtRecorded is the last time reclaim debt was added.
+	reclaimDebtRecorded time.Time
 	// localQueues by (namespace/name).
 	localQueues                                     map[string]*queue
 	podsReadyTracking                               bool
+	waitForPodsReady                                *kueue.ClusterQueueWaitForPodsReady
 	missingFlavors                                  []kueue.ResourceFlavorReference
 	missingAdmissionChecks                          []string
 	inactiveAdmissionChecks                         []string
@@ -85,6 +104,9 @@ type clusterQueue struct {
 	admittedWorkloadsCount                          int
 	isStopped                                       bool
 	workloadInfoOptions                             []workload.InfoOption
+	// workloadsShared is set when Workloads is referenced by a snapshot, so that
+	// the map is copied before it is modified again.
+	workloadsShared atomic.Bool
 
 	resourceNode ResourceNode
 	hierarchy.ClusterQueue[*cohort]
@@ -119,6 +141,8 @@ type queue struct {
 	//TODO: rename this to better distinguish between reserved and "in use" quantities
 	usage         resources.FlavorResourceQuantities
 	admittedUsage resources.FlavorResourceQuantities
+	// tenants are the Tenants of the LocalQueue.
+	tenants []*tenant
 }
 
 func (c *clusterQueue) Active() bool {
@@ -133,7 +157,12 @@ var defaultPreemption = kueue.ClusterQueuePreemption{
 var defaultFlavorFungibility = kueue.FlavorFungibility{WhenCanBorrow: kueue.Borrow, WhenCanPreempt: kueue.TryNextFlavor}
 
 func (c *clusterQueue) updateClusterQueue(cycleChecker hierarchy.CycleChecker, in *kueue.ClusterQueue, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, admissionChecks map[string]AdmissionCheck, oldParent *cohort) error {
-	if c.updateQuotasAndResourceGroups(in.Spec.ResourceGroups) || oldParent != c.Parent() {
+	quotasChanged := c.updateQuotasAndResourceGroups(in.Spec.ResourceGroups)
+	if quotasChanged {
+		// The reclaim debt was accounted against the previous quotas.
+		c.forgetReclaimDebt()
+	}
+	if quotasChanged || oldParent != c.Parent() {
 		if oldParent != nil && oldParent != c.Parent() {
 			// ignore error when old Cohort has cycle.
 			_ = updateCohortTreeResources(oldParent, cycleChecker)
@@ -156,6 +185,19 @@ func (c *clusterQueue) updateClusterQueue(cycleChecker hierarchy.CycleChecker, i
 	}
 	c.NamespaceSelector = nsSelector
 
+	c.LocalQueueNamespaceSelector = nil
+	if in.Spec.LocalQueueNamespaceSelector != nil {
+		if c.LocalQueueNamespaceSelector, err = metav1.LabelSelectorAsSelector(in.Spec.LocalQueueNamespaceSelector); err != nil {
+			return err
+		}
+	}
+
+	admissionPolicies, err := admissionpolicy.Compile(in.Spec.AdmissionPolicies)
+	if err != nil {
+		return err
+	}
+	c.AdmissionPolicies = admissionPolicies
+
 	c.isStopped = ptr.Deref(in.Spec.StopPolicy, kueue.None) != kueue.None
 
 	c.AdmissionChecks = utilac.NewAdmissionChecks(in)
@@ -185,6 +227,16 @@ func (c *clusterQueue) updateClusterQueue(cycleChecker hierarchy.CycleChecker, i
 	if fs := in.Spec.FairSharing; fs != nil && fs.Weight != nil {
 		c.FairWeight = *fs.Weight
 	}
+	c.FairResourceWeights = resourceFairWeights(in.Spec.FairSharing)
+
+	c.QuotaShrinkAction = ""
+	if in.Spec.QuotaShrinkPolicy != nil {
+		c.QuotaShrinkAction = in.Spec.QuotaShrinkPolicy.Action
+	}
+
+	c.waitForPodsReady = in.Spec.WaitForPodsReady
+
+	c.Schedule = in.Spec.Schedule
 
 	return nil
 }
@@ -468,6 +520,7 @@ func (c *clusterQueue) addWorkload(w *kueue.Workload) error {
 		return errors.New("workload already exists in ClusterQueue")
 	}
 	wi := workload.NewInfo(w, c.workloadInfoOptions...)
+	c.ownWorkloads()
 	c.Workloads[k] = wi
 	c.updateWorkloadUsage(wi, 1)
 	if c.podsReadyTracking && !apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadPodsReady) {
@@ -491,19 +544,34 @@ func (c *clusterQueue) deleteWorkload(w *kueue.Workload) {
 	// workloads fit in ClusterQueue.
 	c.AllocatableResourceGeneration++
 
+	c.ownWorkloads()
 	delete(c.Workloads, k)
 	c.reportActiveWorkloads()
 }
 
+// sharedWorkloads returns the Workloads map to be referenced by a snapshot. The
+// map must not be modified by the caller.
+func (c *clusterQueue) sharedWorkloads() map[string]*workload.Info {
+	c.workloadsShared.Store(true)
+	return c.Workloads
+}
+
+// ownWorkloads copies the Workloads map if it is referenced by a snapshot,
+// so that it can be modified.
+func (c *clusterQueue) ownWorkloads() {
+	if c.workloadsShared.Load() {
+		c.Workloads = maps.Clone(c.Workloads)
+		c.workloadsShared.Store(false)
+	}
+}
+
 func (c *clusterQueue) reportActiveWorkloads() {
 	metrics.AdmittedActiveWorkloads.WithLabelValues(c.Name).Set(float64(c.admittedWorkloadsCount))
 	metrics.ReservingActiveWorkloads.WithLabelValues(c.Name).Set(float64(len(c.Workloads)))
 }
 
 func (q *queue) reportActiveWorkloads() {
-	qKeySlice := strings.Split(q.key, "/")
-	metrics.LocalQueueAdmittedActiveWorkloads.WithLabelValues(qKeySlice[1], qKeySlice[0]).Set(float64(q.admittedWorkloads))
-	metrics.LocalQueueReservingActiveWorkloads.WithLabelValues(qKeySlice[1], qKeySlice[0]).Set(float64(q.reservingWorkloads))
+	metrics.ReportLocalQueueActiveWorkloads(metrics.LQRefFromLocalQueueKey(q.key), q.reservingWorkloads, q.admittedWorkloads)
 }
 
 // updateWorkloadUsage updates the usage of the ClusterQueue for the workload
@@ -539,6 +607,9 @@ func (c *clusterQueue) updateWorkloadUsage(wi *workload.Info, m int64) {
 	if lq, ok := c.localQueues[qKey]; ok {
 		updateFlavorUsage(frUsage, lq.usage, m)
 		lq.reservingWorkloads += int(m)
+		for _, t := range lq.tenants {
+			t.updateUsage(frUsage.ByResource(), m)
+		}
 		if admitted {
 			updateFlavorUsage(frUsage, lq.admittedUsage, m)
 			lq.admittedWorkloads += int(m)
@@ -640,6 +711,13 @@ func (c *clusterQueue) fairWeight() *resource.Quantity {
 	return &c.FairWeight
 }
 
+func (c *clusterQueue) resourceFairWeight(rName corev1.ResourceName) resource.Quantity {
+	if w, found := c.FairResourceWeights[rName]; found {
+		return w
+	}
+	return c.FairWeight
+}
+
 func (c *clusterQueue) usageFor(fr resources.FlavorResource) int64 {
 	return c.resourceNode.Usage[fr]
 }
@@ -648,6 +726,54 @@ func (c *clusterQueue) QuotaFor(fr resources.FlavorResource) ResourceQuota {
 	return c.resourceNode.Quotas[fr]
 }
 
+// addReclaimDebt adds the quota within the nominal quota lost to a preemption.
+func (c *clusterQueue) addReclaimDebt(debt resources.FlavorResourceQuantities, now time.Time) {
+	if c.reclaimDebt == nil {
+		c.reclaimDebt = make(resources.FlavorResourceQuantities, len(debt))
+	}
+	for fr, q := range debt {
+		c.reclaimDebt[fr] += q
+	}
+	c.reclaimDebtRecorded = now
+}
+
+func (c *clusterQueue) forgetReclaimDebt() {
+	c.reclaimDebt = nil
+	c.reclaimDebtRecorded = time.Time{}
+}
+
+// compensateReclaimDebt reduces the reclaim debt by the usage of an admitted
+// workload.
+func (c *clusterQueue) compensateReclaimDebt(usage resources.FlavorResourceQuantities) {
+	for fr, q := range usage {
+		debt, found := c.reclaimDebt[fr]
+		if !found {
+			continue
+		}
+		if debt <= q {
+			delete(c.reclaimDebt, fr)
+		} else {
+			c.reclaimDebt[fr] = debt - q
+		}
+	}
+}
+
+// outstandingReclaimDebt returns the reclaim debt bounded by the nominal quota
+// which the ClusterQueue doesn't use, as the debt can't exceed what the
+// ClusterQueue is entitled to.
+func (c *clusterQueue) outstandingReclaimDebt() resources.FlavorResourceQuantities {
+	if len(c.reclaimDebt) == 0 {
+		return nil
+	}
+	debt := make(resources.FlavorResourceQuantities, len(c.reclaimDebt))
+	for fr, q := range c.reclaimDebt {
+		if unused := c.QuotaFor(fr).Nominal - c.usageFor(fr); unused > 0 {
+			debt[fr] = min(q, unused)
+		}
+	}
+	return debt
+}
+
 func (c *clusterQueue) resourceGroups() []ResourceGroup {
 	return c.ResourceGroups
 }
@@ -674,10 +800,24 @@ type dominantResourceShareNode interface {
 	HasParent() bool
 	parentResources() ResourceNode
 	fairWeight() *resource.Quantity
+	resourceFairWeight(corev1.ResourceName) resource.Quantity
 
 	netQuotaNode
 }
 
+// resourceFairWeights returns the weights of the resources listed in the
+// fair sharing properties, nil if there are none.
+func resourceFairWeights(fs *kueue.FairSharing) map[corev1.ResourceName]resource.Quantity {
+	if fs == nil || len(fs.ResourceWeights) == 0 {
+		return nil
+	}
+	weights := make(map[corev1.ResourceName]resource.Quantity, len(fs.ResourceWeights))
+	for _, rw := range fs.ResourceWeights {
+		weights[rw.Name] = rw.Weight
+	}
+	return weights
+}
+
 func dominantResourceShare(node dominantResourceShareNode, wlReq resources.FlavorResourceQuantities, m int64) (int, corev1.ResourceName) {
 	if !node.HasParent() {
 		return 0, ""
@@ -697,20 +837,23 @@ func dominantResourceShare(node dominantResourceShareNode, wlReq resources.Flavo
 		return 0, ""
 	}
 
-	var drs int64 = -1
+	var dws int64 = -1
 	var dRes corev1.ResourceName
 
 	lendable := node.parentResources().calculateLendable()
 	for rName, b := range borrowing {
 		if lr := lendable[rName]; lr > 0 {
-			ratio := b * 1000 / lr
+			weight := node.resourceFairWeight(rName)
+			if weight.IsZero() {
+				return math.MaxInt, rName
+			}
+			ratio := b * 1000 / lr * 1000 / weight.MilliValue()
 			// Use alphabetical order to get a deterministic resource name.
-			if ratio > drs || (ratio == drs && rName < dRes) {
-				drs = ratio
+			if ratio > dws || (ratio == dws && rName < dRes) {
+				dws = ratio
 				dRes = rName
 			}
 		}
 	}
-	dws := drs * 1000 / node.fairWeight().MilliValue()
 	return int(dws), dRes
 }
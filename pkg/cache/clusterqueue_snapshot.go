@@ -17,12 +17,16 @@ limitations under the License.
 package cache
 
 import (
+	"maps"
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/admissionpolicy"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/resources"
@@ -35,9 +39,19 @@ type ClusterQueueSnapshot struct {
 	Workloads         map[string]*workload.Info
 	WorkloadsNotReady sets.Set[string]
 	NamespaceSelector labels.Selector
-	Preemption        kueue.ClusterQueuePreemption
-	FairWeight        resource.Quantity
-	FlavorFungibility kueue.FlavorFungibility
+	// LocalQueueNamespaceSelector selects the namespaces of the LocalQueues
+	// allowed to point at the ClusterQueue, nil if all are allowed.
+	LocalQueueNamespaceSelector labels.Selector
+	Preemption                  kueue.ClusterQueuePreemption
+	FairWeight                  resource.Quantity
+	// FairResourceWeights override the FairWeight for some resources.
+	FairResourceWeights map[corev1.ResourceName]resource.Quantity
+	FlavorFungibility   kueue.FlavorFungibility
+	QuotaShrinkAction   kueue.QuotaShrinkAction
+	AdmissionPolicies   []*admissionpolicy.Policy
+	// Schedule is the name of the Schedule opening and closing the
+	// ClusterQueue, empty if it is always open.
+	Schedule string
 	// Aggregates AdmissionChecks from both .spec.AdmissionChecks and .spec.AdmissionCheckStrategy
 	// Sets hold ResourceFlavors to which an AdmissionCheck should apply.
 	// In case its empty, it means an AdmissionCheck should apply to all ResourceFlavor
@@ -51,6 +65,42 @@ type ClusterQueueSnapshot struct {
 	hierarchy.ClusterQueue[*CohortSnapshot]
 
 	TASFlavors map[kueue.ResourceFlavorReference]*TASFlavorSnapshot
+
+	// FlavorCaps are the caps on the usage of the ResourceFlavors, shared by
+	// all the ClusterQueues of the snapshot.
+	FlavorCaps map[kueue.ResourceFlavorReference]*FlavorCapSnapshot
+
+	// ReclaimDebt is the quota within the nominal quota which the ClusterQueue
+	// lost to the preemptions, and which it doesn't use yet.
+	ReclaimDebt resources.FlavorResourceQuantities
+	// ReclaimDebtRecorded is the last time reclaim debt was added to the
+	// ClusterQueue.
+	ReclaimDebtRecorded time.Time
+
+	// workloadsShared is set while Workloads is shared with the cache, so
+	// that the map is copied before it is modified.
+	workloadsShared bool
+}
+
+func (c *ClusterQueueSnapshot) ownWorkloads() {
+	if c.workloadsShared {
+		c.Workloads = maps.Clone(c.Workloads)
+		c.workloadsShared = false
+	}
+}
+
+// StrictFIFOCohort returns the outermost Cohort, among the ancestors of the
+// ClusterQueue, which orders the workloads of all its ClusterQueues together
+// in strict FIFO, or nil if there is none. It expects that no cycles exist in
+// the Cohort graph.
+func (c *ClusterQueueSnapshot) StrictFIFOCohort() *CohortSnapshot {
+	var strictFIFO *CohortSnapshot
+	for cohort := c.Parent(); cohort != nil; cohort = cohort.Parent() {
+		if cohort.QueueingStrategy == kueue.StrictFIFO {
+			strictFIFO = cohort
+		}
+	}
+	return strictFIFO
 }
 
 // RGByResource returns the ResourceGroup which contains capacity
@@ -85,6 +135,17 @@ func (c *ClusterQueueSnapshot) Fits(frq resources.FlavorResourceQuantities) bool
 	return true
 }
 
+// HasReclaimDebt returns whether the ClusterQueue has reclaim debt for any of
+// the flavor resources.
+func (c *ClusterQueueSnapshot) HasReclaimDebt(frq resources.FlavorResourceQuantities) bool {
+	for fr := range frq {
+		if c.ReclaimDebt[fr] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *ClusterQueueSnapshot) QuotaFor(fr resources.FlavorResource) ResourceQuota {
 	return c.ResourceNode.Quotas[fr]
 }
@@ -105,6 +166,12 @@ func (c *ClusterQueueSnapshot) Available(fr resources.FlavorResource) int64 {
 	return max(0, available(c, fr))
 }
 
+// ExceededQuota returns the FlavorResources for which the ClusterQueue uses
+// more than the quota available to it.
+func (c *ClusterQueueSnapshot) ExceededQuota() []resources.FlavorResource {
+	return exceededQuota(c)
+}
+
 // PotentialAvailable returns the largest workload this ClusterQueue could
 // possibly admit, accounting for its capacity and capacity borrowed
 // its from Cohort.
@@ -119,6 +186,13 @@ func (c *ClusterQueueSnapshot) fairWeight() *resource.Quantity {
 	return &c.FairWeight
 }
 
+func (c *ClusterQueueSnapshot) resourceFairWeight(rName corev1.ResourceName) resource.Quantity {
+	if w, found := c.FairResourceWeights[rName]; found {
+		return w
+	}
+	return c.FairWeight
+}
+
 func (c *ClusterQueueSnapshot) usageFor(fr resources.FlavorResource) int64 {
 	return c.ResourceNode.Usage[fr]
 }
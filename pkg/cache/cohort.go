@@ -18,6 +18,7 @@ package cache
 
 import (
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
 )
 
@@ -26,19 +27,21 @@ type cohort struct {
 	Name string
 	hierarchy.Cohort[*clusterQueue, *cohort]
 
-	resourceNode ResourceNode
+	resourceNode     ResourceNode
+	queueingStrategy kueue.QueueingStrategy
 }
 
 func newCohort(name string) *cohort {
 	return &cohort{
-		name,
-		hierarchy.NewCohort[*clusterQueue, *cohort](),
-		NewResourceNode(),
+		Name:         name,
+		Cohort:       hierarchy.NewCohort[*clusterQueue, *cohort](),
+		resourceNode: NewResourceNode(),
 	}
 }
 
 func (c *cohort) updateCohort(cycleChecker hierarchy.CycleChecker, apiCohort *kueuealpha.Cohort, oldParent *cohort) error {
 	c.resourceNode.Quotas = createResourceQuotas(apiCohort.Spec.ResourceGroups)
+	c.queueingStrategy = apiCohort.Spec.QueueingStrategy
 	if oldParent != nil && oldParent != c.Parent() {
 		// ignore error when old Cohort has cycle.
 		_ = updateCohortTreeResources(oldParent, cycleChecker)
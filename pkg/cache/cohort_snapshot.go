@@ -16,12 +16,16 @@ limitations under the License.
 
 package cache
 
-import "sigs.k8s.io/kueue/pkg/hierarchy"
+import (
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/hierarchy"
+)
 
 type CohortSnapshot struct {
 	Name string
 
-	ResourceNode ResourceNode
+	ResourceNode     ResourceNode
+	QueueingStrategy kueue.QueueingStrategy
 	hierarchy.Cohort[*ClusterQueueSnapshot, *CohortSnapshot]
 }
 
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+// nodeDevices are the unhealthy devices of a node.
+type nodeDevices struct {
+	labels    labels.Set
+	unhealthy resources.Requests
+}
+
+// AddOrUpdateNodeUnhealthyDevices sets the labels and the unhealthy devices
+// of a node. A node without unhealthy devices is removed. It returns whether
+// they changed.
+func (c *Cache) AddOrUpdateNodeUnhealthyDevices(name string, nodeLabels map[string]string, unhealthy corev1.ResourceList) bool {
+	c.Lock()
+	defer c.Unlock()
+	nd := &nodeDevices{
+		labels:    maps.Clone(nodeLabels),
+		unhealthy: resources.NewRequests(unhealthy),
+	}
+	old, found := c.nodeDevices[name]
+	if len(nd.unhealthy) == 0 {
+		delete(c.nodeDevices, name)
+		return found
+	}
+	if found && maps.Equal(old.labels, nd.labels) && maps.Equal(old.unhealthy, nd.unhealthy) {
+		return false
+	}
+	c.nodeDevices[name] = nd
+	return true
+}
+
+// DeleteNodeUnhealthyDevices removes a node. It returns whether it had
+// unhealthy devices.
+func (c *Cache) DeleteNodeUnhealthyDevices(name string) bool {
+	c.Lock()
+	defer c.Unlock()
+	_, found := c.nodeDevices[name]
+	delete(c.nodeDevices, name)
+	return found
+}
+
+// unhealthyFlavorDevices returns the unhealthy devices of the nodes matching
+// the node labels of the flavor.
+func (c *Cache) unhealthyFlavorDevices(rf *kueue.ResourceFlavor) resources.Requests {
+	selector := labels.SelectorFromSet(rf.Spec.NodeLabels)
+	var unhealthy resources.Requests
+	for _, nd := range c.nodeDevices {
+		if !selector.Matches(nd.labels) {
+			continue
+		}
+		if unhealthy == nil {
+			unhealthy = resources.Requests{}
+		}
+		unhealthy.Add(nd.unhealthy)
+	}
+	return unhealthy
+}
+
+// nominalFlavorQuota returns the nominal quota of the flavor, summed over all
+// the ClusterQueues and Cohorts.
+func (c *Cache) nominalFlavorQuota(name kueue.ResourceFlavorReference) resources.Requests {
+	nominal := resources.Requests{}
+	addQuotas := func(quotas map[resources.FlavorResource]ResourceQuota) {
+		for fr, quota := range quotas {
+			if fr.Flavor == name {
+				nominal[fr.Resource] += quota.Nominal
+			}
+		}
+	}
+	for _, cq := range c.hm.ClusterQueues {
+		addQuotas(cq.resourceNode.Quotas)
+	}
+	for _, cohort := range c.hm.Cohorts {
+		addQuotas(cohort.resourceNode.Quotas)
+	}
+	return nominal
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+// FlavorCapSnapshot is the cap on the usage of a ResourceFlavor by all the
+// ClusterQueues, in a scheduling cycle. It is set by the limits of the
+// Karpenter NodePool of the flavor, and by the nominal quota of the flavor
+// reduced by the unhealthy devices of its nodes, whichever is lower.
+type FlavorCapSnapshot struct {
+	NodePool    string
+	Limits      resources.Requests
+	Provisioned resources.Requests
+	// Unhealthy are the unhealthy devices of the nodes of the flavor, for
+	// the resources whose limits they set.
+	Unhealthy resources.Requests
+	Usage     resources.Requests
+}
+
+// ExceededLimits returns the resources, sorted by name, whose limits would be
+// exceeded by adding the usage.
+func (f *FlavorCapSnapshot) ExceededLimits(usage resources.Requests) []corev1.ResourceName {
+	var exceeded []corev1.ResourceName
+	for name, limit := range f.Limits {
+		if v := usage[name]; v > 0 && f.Usage[name]+v > limit {
+			exceeded = append(exceeded, name)
+		}
+	}
+	slices.Sort(exceeded)
+	return exceeded
+}
+
+func (f *FlavorCapSnapshot) AddUsage(usage resources.Requests) {
+	f.Usage.Add(usage)
+}
+
+// capUnhealthyDevices lowers the limits to the nominal quota minus the
+// unhealthy devices, for the resources with nominal quota.
+func (f *FlavorCapSnapshot) capUnhealthyDevices(nominal, unhealthy resources.Requests) {
+	for name, v := range unhealthy {
+		if nominal[name] == 0 {
+			continue
+		}
+		limit := max(0, nominal[name]-v)
+		if current, found := f.Limits[name]; found && current <= limit {
+			continue
+		}
+		f.Limits[name] = limit
+		if f.Unhealthy == nil {
+			f.Unhealthy = resources.Requests{}
+		}
+		f.Unhealthy[name] = v
+	}
+}
+
+func (c *Cache) snapshotFlavorCaps(snap *Snapshot) {
+	if len(c.nodePools) == 0 && len(c.nodeDevices) == 0 {
+		return
+	}
+	for name, rf := range c.resourceFlavors {
+		flavorCap := &FlavorCapSnapshot{Limits: resources.Requests{}}
+		if np, found := c.nodePools[rf.Spec.NodeLabels[constants.KarpenterNodePoolLabel]]; found && len(np.limits) > 0 {
+			flavorCap.NodePool = rf.Spec.NodeLabels[constants.KarpenterNodePoolLabel]
+			flavorCap.Limits = np.limits.Clone()
+			flavorCap.Provisioned = np.provisioned.Clone()
+		}
+		if unhealthy := c.unhealthyFlavorDevices(rf); len(unhealthy) > 0 {
+			flavorCap.capUnhealthyDevices(c.nominalFlavorQuota(name), unhealthy)
+		}
+		if len(flavorCap.Limits) == 0 {
+			continue
+		}
+		// The usage of the inactive ClusterQueues is included, as their
+		// workloads still run on the nodes of the flavor.
+		flavorCap.Usage = resources.Requests{}
+		for _, cq := range c.hm.ClusterQueues {
+			for fr, v := range cq.resourceNode.Usage {
+				if fr.Flavor == name {
+					flavorCap.Usage[fr.Resource] += v
+				}
+			}
+		}
+		if snap.FlavorCaps == nil {
+			snap.FlavorCaps = make(map[kueue.ResourceFlavorReference]*FlavorCapSnapshot)
+		}
+		snap.FlavorCaps[name] = flavorCap
+	}
+	for _, cq := range snap.ClusterQueues {
+		cq.FlavorCaps = snap.FlavorCaps
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+// nodePool is the capacity of a Karpenter NodePool.
+type nodePool struct {
+	limits      resources.Requests
+	provisioned resources.Requests
+}
+
+// AddOrUpdateNodePool sets the limits and the provisioned capacity of a
+// Karpenter NodePool. It returns whether they changed.
+func (c *Cache) AddOrUpdateNodePool(name string, limits, provisioned corev1.ResourceList) bool {
+	c.Lock()
+	defer c.Unlock()
+	np := &nodePool{
+		limits:      resources.NewRequests(limits),
+		provisioned: resources.NewRequests(provisioned),
+	}
+	if old, found := c.nodePools[name]; found && maps.Equal(old.limits, np.limits) && maps.Equal(old.provisioned, np.provisioned) {
+		return false
+	}
+	c.nodePools[name] = np
+	return true
+}
+
+// DeleteNodePool removes a Karpenter NodePool. It returns whether it existed.
+func (c *Cache) DeleteNodePool(name string) bool {
+	c.Lock()
+	defer c.Unlock()
+	_, found := c.nodePools[name]
+	delete(c.nodePools, name)
+	return found
+}
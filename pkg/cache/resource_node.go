@@ -17,8 +17,10 @@ limitations under the License.
 package cache
 
 import (
+	"cmp"
 	"errors"
 	"maps"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -68,6 +70,18 @@ func (r ResourceNode) guaranteedQuota(fr resources.FlavorResource) int64 {
 	return 0
 }
 
+// Lendable is the part of the SubtreeQuota which can be lent to the
+// node's Cohort.
+func (r ResourceNode) Lendable(fr resources.FlavorResource) int64 {
+	return r.SubtreeQuota[fr] - r.guaranteedQuota(fr)
+}
+
+// Borrowed is the usage above the SubtreeQuota, which is borrowed from
+// the node's Cohort.
+func (r ResourceNode) Borrowed(fr resources.FlavorResource) int64 {
+	return max(0, r.Usage[fr]-r.SubtreeQuota[fr])
+}
+
 type hierarchicalResourceNode interface {
 	getResourceNode() ResourceNode
 
@@ -116,6 +130,24 @@ func potentialAvailable(node hierarchicalResourceNode, fr resources.FlavorResour
 	return available
 }
 
+// exceededQuota returns the FlavorResources for which the node uses more
+// than its nominal quota and more than is available to it, sorted by flavor
+// and resource name. This can happen when capacity was removed after the
+// workloads were admitted.
+func exceededQuota(node hierarchicalResourceNode) []resources.FlavorResource {
+	r := node.getResourceNode()
+	var exceeded []resources.FlavorResource
+	for fr, usage := range r.Usage {
+		if usage > r.Quotas[fr].Nominal && available(node, fr) < 0 {
+			exceeded = append(exceeded, fr)
+		}
+	}
+	slices.SortFunc(exceeded, func(a, b resources.FlavorResource) int {
+		return cmp.Or(cmp.Compare(a.Flavor, b.Flavor), cmp.Compare(a.Resource, b.Resource))
+	})
+	return exceeded
+}
+
 // addUsage adds usage to the current node, and bubbles up usage to
 // its Cohort when usage exceeds guaranteedQuota.
 func addUsage(node hierarchicalResourceNode, fr resources.FlavorResource, val int64) {
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+)
+
+// AddOrUpdateSchedule tracks whether the ClusterQueues of the Schedule are
+// closed, from its status.
+func (c *Cache) AddOrUpdateSchedule(schedule *kueuealpha.Schedule) {
+	c.Lock()
+	defer c.Unlock()
+	if schedule.Status.Open {
+		c.closedSchedules.Delete(schedule.Name)
+		return
+	}
+	c.closedSchedules.Insert(schedule.Name)
+}
+
+// DeleteSchedule stops tracking the Schedule, so that its ClusterQueues are
+// open.
+func (c *Cache) DeleteSchedule(name string) {
+	c.Lock()
+	defer c.Unlock()
+	c.closedSchedules.Delete(name)
+}
+
+// ClusterQueuesWithSchedule returns the names of the ClusterQueues opened and
+// closed by the Schedule.
+func (c *Cache) ClusterQueuesWithSchedule(name string) []string {
+	c.RLock()
+	defer c.RUnlock()
+	var cqs []string
+	for _, cq := range c.hm.ClusterQueues {
+		if cq.Schedule == name {
+			cqs = append(cqs, cq.Name)
+		}
+	}
+	return cqs
+}
+
+func (c *Cache) snapshotSchedules(snap *Snapshot) {
+	if c.closedSchedules.Len() == 0 {
+		return
+	}
+	snap.ClosedSchedules = c.closedSchedules.Clone()
+}
+
+// ClosedBySchedule returns whether the ClusterQueue is closed by its
+// Schedule.
+func (s *Snapshot) ClosedBySchedule(cq *ClusterQueueSnapshot) bool {
+	return cq.Schedule != "" && s.ClosedSchedules.Has(cq.Schedule)
+}
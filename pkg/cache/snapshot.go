@@ -19,7 +19,6 @@ package cache
 import (
 	"context"
 	"fmt"
-	"maps"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -36,12 +35,30 @@ type Snapshot struct {
 	hierarchy.Manager[*ClusterQueueSnapshot, *CohortSnapshot]
 	ResourceFlavors          map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor
 	InactiveClusterQueueSets sets.Set[string]
+	// Tenants are the Tenants by name, and LocalQueueTenants the Tenants,
+	// sorted by name, of each LocalQueue by namespace/name.
+	Tenants           map[string]*TenantSnapshot
+	LocalQueueTenants map[string][]*TenantSnapshot
+	// ExceededBudgets are the Budgets, sorted by name, stopping the
+	// admission of the workloads of their LocalQueues.
+	ExceededBudgets []*BudgetSnapshot
+	// ClosedSchedules are the names of the Schedules whose ClusterQueues are
+	// closed.
+	ClosedSchedules sets.Set[string]
+	// FlavorCaps are the caps on the usage of the ResourceFlavors backed by
+	// Karpenter NodePools with limits, or by nodes with unhealthy devices,
+	// by flavor name.
+	FlavorCaps map[kueue.ResourceFlavorReference]*FlavorCapSnapshot
+	// Epoch identifies the state of the cache the snapshot was taken from.
+	// Snapshots taken from an unmodified cache have the same epoch.
+	Epoch int64
 }
 
 // RemoveWorkload removes a workload from its corresponding ClusterQueue and
 // updates resource usage.
 func (s *Snapshot) RemoveWorkload(wl *workload.Info) {
 	cq := s.ClusterQueues[wl.ClusterQueue]
+	cq.ownWorkloads()
 	delete(cq.Workloads, workload.Key(wl.Obj))
 	cq.removeUsage(wl.FlavorResourceUsage())
 }
@@ -50,6 +67,7 @@ func (s *Snapshot) RemoveWorkload(wl *workload.Info) {
 // updates resource usage.
 func (s *Snapshot) AddWorkload(wl *workload.Info) {
 	cq := s.ClusterQueues[wl.ClusterQueue]
+	cq.ownWorkloads()
 	cq.Workloads[workload.Key(wl.Obj)] = wl
 	cq.AddUsage(wl.FlavorResourceUsage())
 }
@@ -86,6 +104,7 @@ func (c *Cache) Snapshot(ctx context.Context) (*Snapshot, error) {
 		Manager:                  hierarchy.NewManager(newCohortSnapshot),
 		ResourceFlavors:          make(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, len(c.resourceFlavors)),
 		InactiveClusterQueueSets: sets.New[string](),
+		Epoch:                    c.epoch,
 	}
 	for _, cohort := range c.hm.Cohorts {
 		if c.hm.CycleChecker.HasCycle(cohort) {
@@ -93,6 +112,7 @@ func (c *Cache) Snapshot(ctx context.Context) (*Snapshot, error) {
 		}
 		snap.AddCohort(cohort.Name)
 		snap.Cohorts[cohort.Name].ResourceNode = cohort.resourceNode.Clone()
+		snap.Cohorts[cohort.Name].QueueingStrategy = cohort.queueingStrategy
 		if cohort.HasParent() {
 			snap.UpdateCohortEdge(cohort.Name, cohort.Parent().Name)
 		}
@@ -130,25 +150,40 @@ func (c *Cache) Snapshot(ctx context.Context) (*Snapshot, error) {
 		// Shallow copy is enough
 		snap.ResourceFlavors[name] = rf
 	}
+	c.snapshotTenants(&snap)
+	c.snapshotBudgets(&snap)
+	c.snapshotSchedules(&snap)
+	c.snapshotFlavorCaps(&snap)
 	return &snap, nil
 }
 
 // snapshotClusterQueue creates a copy of ClusterQueue that includes
 // references to immutable objects and deep copies of changing ones.
+// The workloads are shared with the ClusterQueue, and copied on write by
+// either of them, so that the cost of a snapshot doesn't grow with the
+// number of admitted workloads.
 func snapshotClusterQueue(c *clusterQueue) *ClusterQueueSnapshot {
 	cc := &ClusterQueueSnapshot{
 		Name:                          c.Name,
 		ResourceGroups:                make([]ResourceGroup, len(c.ResourceGroups)),
 		FlavorFungibility:             c.FlavorFungibility,
+		QuotaShrinkAction:             c.QuotaShrinkAction,
+		AdmissionPolicies:             c.AdmissionPolicies,
+		Schedule:                      c.Schedule,
 		FairWeight:                    c.FairWeight,
+		FairResourceWeights:           c.FairResourceWeights,
 		AllocatableResourceGeneration: c.AllocatableResourceGeneration,
-		Workloads:                     maps.Clone(c.Workloads),
+		Workloads:                     c.sharedWorkloads(),
+		workloadsShared:               true,
 		Preemption:                    c.Preemption,
 		NamespaceSelector:             c.NamespaceSelector,
+		LocalQueueNamespaceSelector:   c.LocalQueueNamespaceSelector,
 		Status:                        c.Status,
 		AdmissionChecks:               utilmaps.DeepCopySets[kueue.ResourceFlavorReference](c.AdmissionChecks),
 		ResourceNode:                  c.resourceNode.Clone(),
 		TASFlavors:                    make(map[kueue.ResourceFlavorReference]*TASFlavorSnapshot),
+		ReclaimDebt:                   c.outstandingReclaimDebt(),
+		ReclaimDebtRecorded:           c.reclaimDebtRecorded,
 	}
 	for i, rg := range c.ResourceGroups {
 		cc.ResourceGroups[i] = rg.Clone()
@@ -44,6 +44,8 @@ var snapCmpOpts = []cmp.Option{
 	cmpopts.IgnoreUnexported(hierarchy.ClusterQueue[*CohortSnapshot]{}),
 	cmpopts.IgnoreUnexported(hierarchy.Manager[*ClusterQueueSnapshot, *CohortSnapshot]{}),
 	cmpopts.IgnoreUnexported(hierarchy.CycleChecker{}),
+	cmpopts.IgnoreUnexported(ClusterQueueSnapshot{}),
+	cmpopts.IgnoreFields(Snapshot{}, "Epoch"),
 	cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
 }
 
@@ -1216,6 +1218,89 @@ func TestSnapshotAddRemoveWorkload(t *testing.T) {
 	}
 }
 
+func TestSnapshotWorkloadsCopyOnWrite(t *testing.T) {
+	ctx := context.Background()
+	cqCache := New(utiltesting.NewFakeClient())
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		Obj()
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+	}
+	makeWorkload := func(name string) *kueue.Workload {
+		return utiltesting.MakeWorkload(name, "").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1000m").Obj()).
+			Obj()
+	}
+	first, second := makeWorkload("first"), makeWorkload("second")
+	cqCache.AddOrUpdateWorkload(first)
+
+	snap, err := cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	wantSnapshotWorkloads := []string{"/first"}
+	if diff := cmp.Diff(wantSnapshotWorkloads, sets.List(sets.KeySet(snap.ClusterQueues["cq"].Workloads))); diff != "" {
+		t.Errorf("Unexpected workloads in the snapshot (-want,+got):\n%s", diff)
+	}
+
+	// Changes in the cache are not visible in the snapshot.
+	cqCache.AddOrUpdateWorkload(second)
+	if err := cqCache.DeleteWorkload(first); err != nil {
+		t.Fatalf("Couldn't delete workload from cache: %v", err)
+	}
+	if diff := cmp.Diff(wantSnapshotWorkloads, sets.List(sets.KeySet(snap.ClusterQueues["cq"].Workloads))); diff != "" {
+		t.Errorf("Unexpected workloads in the snapshot after updating the cache (-want,+got):\n%s", diff)
+	}
+
+	// Changes in the snapshot are not visible in the cache, nor in other snapshots.
+	otherSnap, err := cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	otherSnap.RemoveWorkload(otherSnap.ClusterQueues["cq"].Workloads["/second"])
+	if diff := cmp.Diff([]string{"/second"}, sets.List(sets.KeySet(cqCache.hm.ClusterQueues["cq"].Workloads))); diff != "" {
+		t.Errorf("Unexpected workloads in the cache after updating a snapshot (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantSnapshotWorkloads, sets.List(sets.KeySet(snap.ClusterQueues["cq"].Workloads))); diff != "" {
+		t.Errorf("Unexpected workloads in the snapshot after updating another snapshot (-want,+got):\n%s", diff)
+	}
+}
+
+func TestSnapshotEpoch(t *testing.T) {
+	ctx := context.Background()
+	cqCache := New(utiltesting.NewFakeClient())
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		Obj()
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+	}
+	snapshotEpoch := func() int64 {
+		t.Helper()
+		snap, err := cqCache.Snapshot(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error while building snapshot: %v", err)
+		}
+		return snap.Epoch
+	}
+
+	epoch := snapshotEpoch()
+	if got := snapshotEpoch(); got != epoch {
+		t.Errorf("Unexpected epoch of a snapshot of an unmodified cache, want %d, got %d", epoch, got)
+	}
+	cqCache.AddOrUpdateWorkload(utiltesting.MakeWorkload("wl", "").
+		Request(corev1.ResourceCPU, "1").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1000m").Obj()).
+		Obj())
+	if got := snapshotEpoch(); got == epoch {
+		t.Errorf("Unexpected epoch %d of a snapshot of a modified cache", got)
+	}
+}
+
 func TestSnapshotAddRemoveWorkloadWithLendingLimit(t *testing.T) {
 	flavors := []*kueue.ResourceFlavor{
 		utiltesting.MakeResourceFlavor("default").Obj(),
@@ -17,24 +17,60 @@ limitations under the License.
 package cache
 
 import (
+	"context"
+	"fmt"
 	"maps"
 	"sync"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/util/limitrange"
 )
 
 type TASCache struct {
 	sync.RWMutex
 	client  client.Client
 	flavors map[kueue.ResourceFlavorReference]*TASFlavorCache
+
+	// podUsage holds the usage of the non-TAS pods, shared by the flavors to
+	// maintain their topology domains incrementally.
+	podUsage *tasPodUsage
+}
+
+// tasPodUsage holds the usage of the non-TAS pods bound to the nodes. It's
+// initialized by listing the pods, when the first flavor is synced, and
+// maintained from the pod events afterwards.
+// It's locked before the flavors when both are locked.
+type tasPodUsage struct {
+	sync.Mutex
+	synced bool
+	// pods holds the node and the requests of the non-TAS pods accounted
+	// in nodes.
+	pods map[types.NamespacedName]tasPod
+	// nodes holds the usage of the non-TAS pods per node name.
+	nodes map[string]resources.Requests
+}
+
+type tasPod struct {
+	nodeName string
+	requests resources.Requests
 }
 
 func NewTASCache(client client.Client) TASCache {
 	return TASCache{
 		client:  client,
 		flavors: make(map[kueue.ResourceFlavorReference]*TASFlavorCache),
+		podUsage: &tasPodUsage{
+			pods:  make(map[types.NamespacedName]tasPod),
+			nodes: make(map[string]resources.Requests),
+		},
 	}
 }
 
@@ -62,3 +98,122 @@ func (t *TASCache) Delete(name kueue.ResourceFlavorReference) {
 	defer t.Unlock()
 	delete(t.flavors, name)
 }
+
+// SyncNode updates the topology domains of the flavors for the created or
+// updated node.
+func (t *TASCache) SyncNode(node *corev1.Node) {
+	t.podUsage.Lock()
+	defer t.podUsage.Unlock()
+	for _, flavor := range t.Clone() {
+		flavor.syncNode(node, t.podUsage.nodes[node.Name])
+	}
+}
+
+// DeleteNode removes the node from the topology domains of the flavors.
+func (t *TASCache) DeleteNode(name string) {
+	t.podUsage.Lock()
+	defer t.podUsage.Unlock()
+	for _, flavor := range t.Clone() {
+		flavor.deleteNode(name, t.podUsage.nodes[name])
+	}
+}
+
+// SyncPod updates the usage of the non-TAS pods for the created or updated
+// pod.
+func (t *TASCache) SyncPod(pod *corev1.Pod) {
+	t.podUsage.Lock()
+	defer t.podUsage.Unlock()
+	if !t.podUsage.synced {
+		return
+	}
+	flavors := t.Clone()
+	key := client.ObjectKeyFromObject(pod)
+	t.podUsage.removePod(key, flavors)
+	if p, ok := nonTASPodUsage(pod); ok {
+		t.podUsage.addPod(key, p, flavors)
+	}
+}
+
+// DeletePod removes the usage of the pod.
+func (t *TASCache) DeletePod(key types.NamespacedName) {
+	t.podUsage.Lock()
+	defer t.podUsage.Unlock()
+	if !t.podUsage.synced {
+		return
+	}
+	t.podUsage.removePod(key, t.Clone())
+}
+
+func (u *tasPodUsage) sync(ctx context.Context, c client.Client) error {
+	r, err := labels.NewRequirement(kueuealpha.TASLabel, selection.DoesNotExist, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build requirement for non-TAS pods: %w", err)
+	}
+	podListOpts := &client.ListOptions{}
+	podListOpts.LabelSelector = labels.NewSelector()
+	podListOpts.LabelSelector = podListOpts.LabelSelector.Add(*r)
+	pods := corev1.PodList{}
+	if err := c.List(ctx, &pods, podListOpts); err != nil {
+		return fmt.Errorf("failed to list non-TAS pods which are bound to nodes: %w", err)
+	}
+	clear(u.pods)
+	clear(u.nodes)
+	for i := range pods.Items {
+		if p, ok := nonTASPodUsage(&pods.Items[i]); ok {
+			u.addPod(client.ObjectKeyFromObject(&pods.Items[i]), p, nil)
+		}
+	}
+	u.synced = true
+	return nil
+}
+
+func (u *tasPodUsage) addPod(key types.NamespacedName, p tasPod, flavors map[kueue.ResourceFlavorReference]*TASFlavorCache) {
+	u.pods[key] = p
+	if _, found := u.nodes[p.nodeName]; !found {
+		u.nodes[p.nodeName] = resources.Requests{}
+	}
+	u.nodes[p.nodeName].Add(p.requests)
+	for _, flavor := range flavors {
+		flavor.updateNodeUsage(p.nodeName, p.requests, add)
+	}
+}
+
+func (u *tasPodUsage) removePod(key types.NamespacedName, flavors map[kueue.ResourceFlavorReference]*TASFlavorCache) {
+	p, found := u.pods[key]
+	if !found {
+		return
+	}
+	delete(u.pods, key)
+	u.nodes[p.nodeName].Sub(p.requests)
+	if isZero(u.nodes[p.nodeName]) {
+		delete(u.nodes, p.nodeName)
+	}
+	for _, flavor := range flavors {
+		flavor.updateNodeUsage(p.nodeName, p.requests, subtract)
+	}
+}
+
+// nonTASPodUsage returns the usage of the pod, if it's a non-TAS pod using
+// the capacity of its node.
+func nonTASPodUsage(pod *corev1.Pod) (tasPod, bool) {
+	if _, isTAS := pod.Labels[kueuealpha.TASLabel]; isTAS {
+		return tasPod{}, false
+	}
+	// skip unscheduled or terminal pods as they don't use any capacity
+	if len(pod.Spec.NodeName) == 0 || pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
+		return tasPod{}, false
+	}
+	return tasPod{
+		nodeName: pod.Spec.NodeName,
+		requests: resources.NewRequests(limitrange.TotalRequests(&pod.Spec)),
+	}, true
+}
+
+func isZero(r resources.Requests) bool {
+	for _, v := range r {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -27,8 +27,10 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	tasindexer "sigs.k8s.io/kueue/pkg/controller/tas/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
 	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
@@ -219,6 +221,7 @@ func TestFindTopologyAssignment(t *testing.T) {
 		requests       resources.Requests
 		count          int32
 		tolerations    []corev1.Toleration
+		affinity       *corev1.Affinity
 		wantAssignment *kueue.TopologyAssignment
 		wantReason     string
 	}{
@@ -1033,6 +1036,69 @@ func TestFindTopologyAssignment(t *testing.T) {
 				},
 			},
 		},
+		"skip node which doesn't match the required node affinity": {
+			nodes: []corev1.Node{
+				*testingnode.MakeNode("x1").
+					Label("zone", "zone-a").
+					Label(corev1.LabelHostname, "x1").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					}).
+					Ready().
+					Obj(),
+				*testingnode.MakeNode("x2").
+					Label("zone", "zone-a").
+					Label(corev1.LabelHostname, "x2").
+					StatusAllocatable(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					}).
+					Ready().
+					Obj(),
+			},
+			affinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+							MatchExpressions: []corev1.NodeSelectorRequirement{
+								{
+									Key:      corev1.LabelHostname,
+									Operator: corev1.NodeSelectorOpIn,
+									Values:   []string{"x2"},
+								},
+								{
+									Key:      "example.com/not-a-level",
+									Operator: corev1.NodeSelectorOpExists,
+								},
+							},
+						}},
+					},
+				},
+			},
+			request: kueue.PodSetTopologyRequest{
+				Preferred: ptr.To(corev1.LabelHostname),
+			},
+			nodeLabels: map[string]string{
+				"zone": "zone-a",
+			},
+			levels: defaultOneLevel,
+			requests: resources.Requests{
+				corev1.ResourceCPU: 1000,
+			},
+			count: 1,
+			wantAssignment: &kueue.TopologyAssignment{
+				Levels: defaultOneLevel,
+				Domains: []kueue.TopologyDomainAssignment{
+					{
+						Count: 1,
+						Values: []string{
+							"x2",
+						},
+					},
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -1057,7 +1123,7 @@ func TestFindTopologyAssignment(t *testing.T) {
 			if err != nil {
 				t.Fatalf("failed to build the snapshot: %v", err)
 			}
-			gotAssignment, reason := snapshot.FindTopologyAssignment(&tc.request, tc.requests, tc.count, tc.tolerations)
+			gotAssignment, reason := snapshot.FindTopologyAssignment(&tc.request, tc.requests, tc.count, &corev1.PodSpec{Tolerations: tc.tolerations, Affinity: tc.affinity})
 			if gotAssignment != nil {
 				sort.Slice(tc.wantAssignment.Domains, func(i, j int) bool {
 					return utiltas.DomainID(tc.wantAssignment.Domains[i].Values) < utiltas.DomainID(tc.wantAssignment.Domains[j].Values)
@@ -1072,3 +1138,104 @@ func TestFindTopologyAssignment(t *testing.T) {
 		})
 	}
 }
+
+func TestIncrementalSnapshot(t *testing.T) {
+	const tasRackLabel = "cloud.com/topology-rack"
+	levels := []string{tasRackLabel, corev1.LabelHostname}
+	makeNode := func(name string) *testingnode.NodeWrapper {
+		return testingnode.MakeNode(name).
+			Label("zone", "zone-a").
+			Label(tasRackLabel, "r1").
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("2"),
+			})
+	}
+	leafCapacities := func(s *TASFlavorSnapshot) map[utiltas.TopologyDomainID]resources.Requests {
+		result := make(map[utiltas.TopologyDomainID]resources.Requests, len(s.leaves))
+		for id, leaf := range s.leaves {
+			result[id] = leaf.freeCapacity
+		}
+		return result
+	}
+
+	ctx := context.Background()
+	nodes := []*corev1.Node{
+		makeNode("x1").Ready().Obj(),
+		makeNode("x2").Ready().Obj(),
+	}
+	pods := []*corev1.Pod{
+		testingpod.MakePod("p1", "ns").NodeName("x1").Request(corev1.ResourceCPU, "500m").Obj(),
+		testingpod.MakePod("p2", "ns").NodeName("x2").Label(kueuealpha.TASLabel, "true").Request(corev1.ResourceCPU, "1").Obj(),
+	}
+	clientBuilder := utiltesting.NewClientBuilder()
+	for _, node := range nodes {
+		clientBuilder.WithObjects(node)
+	}
+	for _, pod := range pods {
+		clientBuilder.WithObjects(pod)
+	}
+	_ = tasindexer.SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder))
+	cl := clientBuilder.Build()
+
+	features.SetFeatureGateDuringTest(t, features.TASIncrementalSnapshot, true)
+	tasCache := NewTASCache(cl)
+	tasFlavorCache := tasCache.NewTASFlavorCache("default", levels, map[string]string{"zone": "zone-a"}, nil)
+	tasCache.Set("tas-flavor", tasFlavorCache)
+
+	snapshot, err := tasFlavorCache.snapshot(ctx)
+	if err != nil {
+		t.Fatalf("failed to build the snapshot: %v", err)
+	}
+	wantCapacities := map[utiltas.TopologyDomainID]resources.Requests{
+		"x1": {corev1.ResourceCPU: 1500},
+		"x2": {corev1.ResourceCPU: 2000},
+	}
+	if diff := cmp.Diff(wantCapacities, leafCapacities(snapshot)); diff != "" {
+		t.Errorf("unexpected free capacity after sync (-want,+got): %s", diff)
+	}
+
+	newNode := makeNode("x3").Ready().Obj()
+	if err := cl.Create(ctx, newNode); err != nil {
+		t.Fatalf("failed to create the node: %v", err)
+	}
+	tasCache.SyncNode(newNode)
+	notReadyNode := makeNode("x2").NotReady().Obj()
+	notReadyNode.ResourceVersion = nodes[1].ResourceVersion
+	if err := cl.Status().Update(ctx, notReadyNode); err != nil {
+		t.Fatalf("failed to update the node: %v", err)
+	}
+	tasCache.SyncNode(notReadyNode)
+	newPod := testingpod.MakePod("p3", "ns").NodeName("x3").Request(corev1.ResourceCPU, "1").Obj()
+	if err := cl.Create(ctx, newPod); err != nil {
+		t.Fatalf("failed to create the pod: %v", err)
+	}
+	tasCache.SyncPod(newPod)
+	finishedPod := pods[0].DeepCopy()
+	finishedPod.Status.Phase = corev1.PodSucceeded
+	if err := cl.Status().Update(ctx, finishedPod); err != nil {
+		t.Fatalf("failed to update the pod: %v", err)
+	}
+	tasCache.SyncPod(finishedPod)
+
+	snapshot, err = tasFlavorCache.snapshot(ctx)
+	if err != nil {
+		t.Fatalf("failed to build the snapshot: %v", err)
+	}
+	wantCapacities = map[utiltas.TopologyDomainID]resources.Requests{
+		"x1": {corev1.ResourceCPU: 2000},
+		"x3": {corev1.ResourceCPU: 1000},
+	}
+	if diff := cmp.Diff(wantCapacities, leafCapacities(snapshot)); diff != "" {
+		t.Errorf("unexpected free capacity after the events (-want,+got): %s", diff)
+	}
+
+	features.SetFeatureGateDuringTest(t, features.TASIncrementalSnapshot, false)
+	listedSnapshot, err := tasCache.NewTASFlavorCache("default", levels, map[string]string{"zone": "zone-a"}, nil).snapshot(ctx)
+	if err != nil {
+		t.Fatalf("failed to build the snapshot: %v", err)
+	}
+	if diff := cmp.Diff(leafCapacities(listedSnapshot), leafCapacities(snapshot)); diff != "" {
+		t.Errorf("unexpected difference with the snapshot of the listed nodes (-listed,+incremental): %s", diff)
+	}
+}
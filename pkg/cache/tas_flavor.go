@@ -33,6 +33,7 @@ import (
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/controller/tas/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/util/limitrange"
 	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
@@ -69,6 +70,39 @@ type TASFlavorCache struct {
 
 	// usage maintains the usage per topology domain
 	usage map[utiltas.TopologyDomainID]resources.Requests
+
+	// podUsage holds the usage of the non-TAS pods per node, shared by the
+	// flavors.
+	podUsage *tasPodUsage
+
+	// synced indicates whether nodes and leaves were initialized by listing
+	// the nodes. Afterwards, they are maintained from the node and pod
+	// events, so that the snapshots are built without listing the nodes and
+	// the pods. Only used when the TASIncrementalSnapshot feature gate is
+	// enabled.
+	synced bool
+	// nodes maps the names of the Ready nodes of the flavor to their
+	// lowest-level topology domains and allocatable.
+	nodes map[string]tasNode
+	// leaves holds the lowest-level topology domains of the flavor.
+	leaves map[utiltas.TopologyDomainID]*tasLeaf
+}
+
+type tasNode struct {
+	domainID    utiltas.TopologyDomainID
+	allocatable resources.Requests
+}
+
+// tasLeaf holds the state of a lowest-level topology domain.
+type tasLeaf struct {
+	levelValues []string
+	// freeCapacity is the allocatable of the nodes of the domain, minus the
+	// usage of the non-TAS pods bound to them.
+	freeCapacity resources.Requests
+	// nodeTaints contains the list of taints for the node, only applies if
+	// the lowest level is node
+	nodeTaints []corev1.Taint
+	nodeCount  int
 }
 
 func (t *TASCache) NewTASFlavorCache(topologyName kueue.TopologyReference, levels []string, nodeLabels map[string]string,
@@ -80,11 +114,30 @@ func (t *TASCache) NewTASFlavorCache(topologyName kueue.TopologyReference, level
 		NodeLabels:   maps.Clone(nodeLabels),
 		Tolerations:  slices.Clone(tolerations),
 		usage:        make(map[utiltas.TopologyDomainID]resources.Requests),
+		podUsage:     t.podUsage,
 	}
 }
 
+// Snapshot returns the snapshot of the topology domains of the flavor, with
+// their free capacity, for the controllers assigning topology domains outside
+// of the scheduling cycles.
+func (c *TASFlavorCache) Snapshot(ctx context.Context) (*TASFlavorSnapshot, error) {
+	return c.snapshot(ctx)
+}
+
 func (c *TASFlavorCache) snapshot(ctx context.Context) (*TASFlavorSnapshot, error) {
 	log := ctrl.LoggerFrom(ctx)
+	if features.Enabled(features.TASIncrementalSnapshot) {
+		c.RLock()
+		synced := c.synced
+		c.RUnlock()
+		if !synced {
+			if err := c.sync(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return c.snapshotForLeaves(log), nil
+	}
 	nodes := &corev1.NodeList{}
 	requiredLabels := client.MatchingLabels{}
 	for k, v := range c.NodeLabels {
@@ -140,6 +193,152 @@ func (c *TASFlavorCache) snapshotForNodes(log logr.Logger, nodes []corev1.Node,
 	return snapshot
 }
 
+// snapshotForLeaves builds the snapshot from the lowest-level topology
+// domains maintained from the node and pod events.
+func (c *TASFlavorCache) snapshotForLeaves(log logr.Logger) *TASFlavorSnapshot {
+	c.RLock()
+	defer c.RUnlock()
+
+	log.V(3).Info("Constructing TAS snapshot", "nodeLabels", c.NodeLabels,
+		"levels", c.Levels, "nodeCount", len(c.nodes), "domainCount", len(c.leaves))
+	snapshot := newTASFlavorSnapshot(log, c.TopologyName, c.Levels, c.Tolerations)
+	for domainID, leaf := range c.leaves {
+		snapshot.addLeaf(domainID, leaf.levelValues, leaf.freeCapacity, leaf.nodeTaints)
+	}
+	snapshot.initialize()
+	for domainID, usage := range c.usage {
+		snapshot.addUsage(domainID, usage)
+	}
+	return snapshot
+}
+
+// sync initializes the lowest-level topology domains by listing the Ready
+// nodes of the flavor, and the usage of the non-TAS pods, if not synced yet.
+func (c *TASFlavorCache) sync(ctx context.Context) error {
+	// Holding the lock of the pod usage makes the node and pod events wait for
+	// the sync, instead of being dropped.
+	c.podUsage.Lock()
+	defer c.podUsage.Unlock()
+	if !c.podUsage.synced {
+		if err := c.podUsage.sync(ctx, c.client); err != nil {
+			return err
+		}
+	}
+	nodes := &corev1.NodeList{}
+	requiredLabels := client.MatchingLabels{}
+	for k, v := range c.NodeLabels {
+		requiredLabels[k] = v
+	}
+	requiredLabelKeys := client.HasLabels{}
+	requiredLabelKeys = append(requiredLabelKeys, c.Levels...)
+	err := c.client.List(ctx, nodes, requiredLabels, requiredLabelKeys, client.MatchingFields{indexer.ReadyNode: "true"})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes for TAS: %w", err)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.nodes = make(map[string]tasNode, len(nodes.Items))
+	c.leaves = make(map[utiltas.TopologyDomainID]*tasLeaf)
+	for i := range nodes.Items {
+		c.addNodeLocked(&nodes.Items[i], c.podUsage.nodes[nodes.Items[i].Name])
+	}
+	c.synced = true
+	return nil
+}
+
+func (c *TASFlavorCache) syncNode(node *corev1.Node, usage resources.Requests) {
+	c.Lock()
+	defer c.Unlock()
+	if !c.synced {
+		return
+	}
+	c.deleteNodeLocked(node.Name, usage)
+	if c.hasNode(node) {
+		c.addNodeLocked(node, usage)
+	}
+}
+
+func (c *TASFlavorCache) deleteNode(name string, usage resources.Requests) {
+	c.Lock()
+	defer c.Unlock()
+	if !c.synced {
+		return
+	}
+	c.deleteNodeLocked(name, usage)
+}
+
+// hasNode returns whether the node is a Ready node of the flavor.
+func (c *TASFlavorCache) hasNode(node *corev1.Node) bool {
+	for k, v := range c.NodeLabels {
+		if node.Labels[k] != v {
+			return false
+		}
+	}
+	for _, level := range c.Levels {
+		if _, found := node.Labels[level]; !found {
+			return false
+		}
+	}
+	return utiltas.IsNodeStatusConditionTrue(node.Status.Conditions, corev1.NodeReady)
+}
+
+func (c *TASFlavorCache) addNodeLocked(node *corev1.Node, usage resources.Requests) {
+	levelValues := utiltas.LevelValues(c.Levels, node.Labels)
+	domainID := leafDomainID(c.Levels, levelValues)
+	leaf, found := c.leaves[domainID]
+	if !found {
+		leaf = &tasLeaf{
+			levelValues:  levelValues,
+			freeCapacity: resources.Requests{},
+		}
+		c.leaves[domainID] = leaf
+	}
+	if isLowestLevelNode(c.Levels) {
+		leaf.nodeTaints = slices.Clone(node.Spec.Taints)
+	}
+	allocatable := resources.NewRequests(node.Status.Allocatable)
+	leaf.freeCapacity.Add(allocatable)
+	leaf.freeCapacity.Sub(usage)
+	leaf.nodeCount++
+	c.nodes[node.Name] = tasNode{domainID: domainID, allocatable: allocatable}
+}
+
+func (c *TASFlavorCache) deleteNodeLocked(name string, usage resources.Requests) {
+	node, found := c.nodes[name]
+	if !found {
+		return
+	}
+	delete(c.nodes, name)
+	leaf := c.leaves[node.domainID]
+	leaf.nodeCount--
+	if leaf.nodeCount == 0 {
+		delete(c.leaves, node.domainID)
+		return
+	}
+	leaf.freeCapacity.Sub(node.allocatable)
+	leaf.freeCapacity.Add(usage)
+}
+
+// updateNodeUsage updates the free capacity of the domain of the node for the
+// usage of a non-TAS pod.
+func (c *TASFlavorCache) updateNodeUsage(nodeName string, usage resources.Requests, op usageOp) {
+	c.Lock()
+	defer c.Unlock()
+	if !c.synced {
+		return
+	}
+	node, found := c.nodes[nodeName]
+	if !found {
+		return
+	}
+	if op == subtract {
+		c.leaves[node.domainID].freeCapacity.Add(usage)
+	} else {
+		c.leaves[node.domainID].freeCapacity.Sub(usage)
+	}
+}
+
 func (c *TASFlavorCache) addUsage(topologyRequests []workload.TopologyDomainRequests) {
 	c.updateUsage(topologyRequests, add)
 }
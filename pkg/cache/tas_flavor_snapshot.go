@@ -23,11 +23,14 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/resources"
 	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
+	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
 	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
 	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
 )
@@ -129,10 +132,7 @@ func newTASFlavorSnapshot(log logr.Logger, topologyName kueue.TopologyReference,
 
 func (s *TASFlavorSnapshot) addNode(node corev1.Node) utiltas.TopologyDomainID {
 	levelValues := utiltas.LevelValues(s.levelKeys, node.Labels)
-	domainID := utiltas.DomainID(levelValues)
-	if s.isLowestLevelNode() {
-		domainID = utiltas.DomainID(levelValues[len(levelValues)-1:])
-	}
+	domainID := leafDomainID(s.levelKeys, levelValues)
 	if _, found := s.leaves[domainID]; !found {
 		leafDomain := leafDomain{
 			domain: domain{
@@ -150,8 +150,34 @@ func (s *TASFlavorSnapshot) addNode(node corev1.Node) utiltas.TopologyDomainID {
 	return domainID
 }
 
+// addLeaf adds the lowest-level domain with its free capacity.
+func (s *TASFlavorSnapshot) addLeaf(domainID utiltas.TopologyDomainID, levelValues []string,
+	freeCapacity resources.Requests, nodeTaints []corev1.Taint) {
+	s.leaves[domainID] = &leafDomain{
+		domain: domain{
+			id:          domainID,
+			levelValues: levelValues,
+		},
+		freeCapacity: freeCapacity.Clone(),
+		nodeTaints:   nodeTaints,
+	}
+}
+
 func (s *TASFlavorSnapshot) isLowestLevelNode() bool {
-	return s.levelKeys[len(s.levelKeys)-1] == corev1.LabelHostname
+	return isLowestLevelNode(s.levelKeys)
+}
+
+func isLowestLevelNode(levels []string) bool {
+	return levels[len(levels)-1] == corev1.LabelHostname
+}
+
+// leafDomainID returns the ID of the lowest-level domain with the level
+// values. Only the hostname is used if the lowest level is node.
+func leafDomainID(levels, levelValues []string) utiltas.TopologyDomainID {
+	if isLowestLevelNode(levels) {
+		return utiltas.DomainID(levelValues[len(levelValues)-1:])
+	}
+	return utiltas.DomainID(levelValues)
 }
 
 // initialize prepares the topology tree structure. This structure holds
@@ -228,7 +254,7 @@ func (s *TASFlavorSnapshot) FindTopologyAssignment(
 	topologyRequest *kueue.PodSetTopologyRequest,
 	requests resources.Requests,
 	count int32,
-	podSetTolerations []corev1.Toleration) (*kueue.TopologyAssignment, string) {
+	podSetSpec *corev1.PodSpec) (*kueue.TopologyAssignment, string) {
 	required := topologyRequest.Required != nil
 	key := levelKey(topologyRequest)
 	if key == nil {
@@ -239,7 +265,7 @@ func (s *TASFlavorSnapshot) FindTopologyAssignment(
 		return nil, fmt.Sprintf("no requested topology level: %s", *key)
 	}
 	// phase 1 - determine the number of pods which can fit in each topology domain
-	s.fillInCounts(requests, append(podSetTolerations, s.tolerations...))
+	s.fillInCounts(requests, podSetSpec)
 
 	// phase 2a: determine the level at which the assignment is done along with
 	// the domains which can accommodate all pods
@@ -259,6 +285,97 @@ func (s *TASFlavorSnapshot) FindTopologyAssignment(
 	return s.buildAssignment(currFitDomain), ""
 }
 
+// FindReplacementDomains finds the lowest-level domains for count pods of a
+// PodSet, to replace the failed domains of its topology assignment. The
+// remaining domains are the domains of the assignment which didn't fail.
+// The replacement domains are in the same domain, at the requested level, as
+// the remaining domains. If the level is only preferred, they are anywhere in
+// the topology when the same domain lacks the capacity. If no domain remains,
+// the pods are assigned like by FindTopologyAssignment.
+// Returns the replacement domains, with the values of the assignment levels,
+// or the reason for which the pods don't fit.
+func (s *TASFlavorSnapshot) FindReplacementDomains(
+	topologyRequest *kueue.PodSetTopologyRequest,
+	assignmentLevels []string,
+	remaining []kueue.TopologyDomainAssignment,
+	requests resources.Requests,
+	count int32,
+	podSetSpec *corev1.PodSpec) ([]kueue.TopologyDomainAssignment, string) {
+	if len(remaining) == 0 {
+		assignment, reason := s.FindTopologyAssignment(topologyRequest, requests, count, podSetSpec)
+		if assignment == nil {
+			return nil, reason
+		}
+		return assignment.Domains, ""
+	}
+	s.fillInCounts(requests, podSetSpec)
+
+	candidates := make([]*domain, 0, len(s.leaves))
+	for _, leaf := range s.leaves {
+		candidates = append(candidates, &leaf.domain)
+	}
+	if parent := s.remainingDomainsParent(topologyRequest, assignmentLevels, remaining); parent != nil {
+		switch {
+		case parent.state >= count:
+			candidates = s.leavesOf(parent)
+		case topologyRequest.Required != nil:
+			return nil, s.notFitMessage(parent.state, count)
+		}
+	}
+	fitCount := int32(0)
+	for _, candidate := range candidates {
+		fitCount += candidate.state
+	}
+	if fitCount < count {
+		return nil, s.notFitMessage(fitCount, count)
+	}
+	domains := s.updateCountsToMinimum(s.sortedDomains(candidates), count)
+	slices.SortFunc(domains, func(a, b *domain) int {
+		return utilslices.OrderStringSlices(a.levelValues, b.levelValues)
+	})
+	levelIdx := len(s.levelKeys) - len(assignmentLevels)
+	result := make([]kueue.TopologyDomainAssignment, len(domains))
+	for i, domain := range domains {
+		result[i] = kueue.TopologyDomainAssignment{
+			Values: slices.Clone(domain.levelValues[levelIdx:]),
+			Count:  domain.state,
+		}
+	}
+	return result, ""
+}
+
+// remainingDomainsParent returns the domain, at the requested level, of the
+// remaining domains of an assignment, or nil if it's not found.
+func (s *TASFlavorSnapshot) remainingDomainsParent(topologyRequest *kueue.PodSetTopologyRequest,
+	assignmentLevels []string, remaining []kueue.TopologyDomainAssignment) *domain {
+	key := levelKey(topologyRequest)
+	if key == nil {
+		return nil
+	}
+	levelIdx, found := s.resolveLevelIdx(*key)
+	if !found {
+		return nil
+	}
+	for _, d := range remaining {
+		if leaf, found := s.leaves[leafDomainID(assignmentLevels, d.Values)]; found {
+			return s.domains[utiltas.DomainID(leaf.levelValues[:levelIdx+1])]
+		}
+	}
+	return nil
+}
+
+// leavesOf returns the lowest-level domains in the domain.
+func (s *TASFlavorSnapshot) leavesOf(d *domain) []*domain {
+	if len(d.children) == 0 {
+		return []*domain{d}
+	}
+	var result []*domain
+	for _, child := range d.children {
+		result = append(result, s.leavesOf(child)...)
+	}
+	return result
+}
+
 func (s *TASFlavorSnapshot) HasLevel(r *kueue.PodSetTopologyRequest) bool {
 	key := levelKey(r)
 	if key == nil {
@@ -385,12 +502,17 @@ func (s *TASFlavorSnapshot) sortedDomains(domains []*domain) []*domain {
 	return result
 }
 
-func (s *TASFlavorSnapshot) fillInCounts(requests resources.Requests, tolerations []corev1.Toleration) {
+// fillInCounts determines the number of pods of the PodSet which fit in each
+// topology domain. The lowest-level domains which don't match the required
+// node affinity of the PodSet, on the topology level keys, are excluded.
+func (s *TASFlavorSnapshot) fillInCounts(requests resources.Requests, podSetSpec *corev1.PodSpec) {
 	for _, domain := range s.domains {
 		// cleanup the state in case some remaining values are present from computing
 		// assignments for previous PodSets.
 		domain.state = 0
 	}
+	tolerations := slices.Concat(podSetSpec.Tolerations, s.tolerations)
+	selector := utilpod.RequiredNodeAffinityForKeys(podSetSpec, sets.New(s.levelKeys...))
 	for _, leaf := range s.leaves {
 		taint, untolerated := corev1helpers.FindMatchingUntoleratedTaint(leaf.nodeTaints, tolerations, func(t *corev1.Taint) bool {
 			return t.Effect == corev1.TaintEffectNoSchedule || t.Effect == corev1.TaintEffectNoExecute
@@ -399,6 +521,11 @@ func (s *TASFlavorSnapshot) fillInCounts(requests resources.Requests, toleration
 			s.log.V(2).Info("excluding node with untolerated taint", "domainID", leaf.id, "taint", taint)
 			continue
 		}
+		leafLabels := utiltas.NodeLabelsFromKeysAndValues(s.levelKeys, leaf.levelValues)
+		if match, err := selector.Match(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: leafLabels}}); !match || err != nil {
+			s.log.V(2).Info("excluding domain not matching the node affinity", "domainID", leaf.id, "error", err)
+			continue
+		}
 		leaf.state = requests.CountIn(leaf.freeCapacity)
 	}
 	for _, root := range s.roots {
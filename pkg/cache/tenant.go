@@ -0,0 +1,232 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+// tenant is a group of LocalQueues, in any namespace, with limits on their
+// combined usage.
+type tenant struct {
+	localQueues sets.Set[string]
+	usageLimits resources.Requests
+	fairWeight  resource.Quantity
+	// resourceFairWeights override the fairWeight for some resources.
+	resourceFairWeights map[corev1.ResourceName]resource.Quantity
+
+	// usage and reservingWorkloads are the combined usage and number of
+	// workloads with quota reserved of the LocalQueues, kept up to date as
+	// the workloads of the LocalQueues are added and removed.
+	usage              resources.Requests
+	reservingWorkloads int
+}
+
+// updateUsage adds, if m is 1, or removes, if m is -1, the usage of a
+// workload of one of the LocalQueues of the tenant.
+func (t *tenant) updateUsage(usage resources.Requests, m int64) {
+	if m == 1 {
+		t.usage.Add(usage)
+	}
+	if m == -1 {
+		t.usage.Sub(usage)
+	}
+	t.reservingWorkloads += int(m)
+}
+
+func newTenant(apiTenant *kueuealpha.Tenant) *tenant {
+	t := &tenant{
+		localQueues: sets.New[string](),
+		usageLimits: resources.NewRequests(apiTenant.Spec.UsageLimits),
+		fairWeight:  oneQuantity,
+		usage:       resources.Requests{},
+	}
+	for _, lq := range apiTenant.Spec.LocalQueues {
+		t.localQueues.Insert(lq.Namespace + "/" + lq.Name)
+	}
+	if fs := apiTenant.Spec.FairSharing; fs != nil && fs.Weight != nil {
+		t.fairWeight = *fs.Weight
+	}
+	t.resourceFairWeights = resourceFairWeights(apiTenant.Spec.FairSharing)
+	return t
+}
+
+// TenantUsageStats is the usage of the LocalQueues of a Tenant.
+type TenantUsageStats struct {
+	Usage              corev1.ResourceList
+	ReservingWorkloads int
+}
+
+// AddOrUpdateTenant adds or updates the LocalQueues and the limits of a
+// Tenant.
+func (c *Cache) AddOrUpdateTenant(apiTenant *kueuealpha.Tenant) {
+	c.Lock()
+	defer c.Unlock()
+	c.tenants[apiTenant.Name] = newTenant(apiTenant)
+	c.syncTenants()
+}
+
+func (c *Cache) DeleteTenant(name string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.tenants, name)
+	c.syncTenants()
+}
+
+// syncTenants links the LocalQueues to their Tenants and recomputes the usage
+// of the Tenants from the usage of their LocalQueues. It's called when the
+// Tenants, the ClusterQueues or the LocalQueues change, while the usage of
+// the Tenants is updated with the usage of the LocalQueues otherwise.
+func (c *Cache) syncTenants() {
+	for _, t := range c.tenants {
+		t.usage = resources.Requests{}
+		t.reservingWorkloads = 0
+	}
+	for _, cq := range c.hm.ClusterQueues {
+		for key, lq := range cq.localQueues {
+			lq.tenants = nil
+			for _, t := range c.tenants {
+				if !t.localQueues.Has(key) {
+					continue
+				}
+				lq.tenants = append(lq.tenants, t)
+				t.usage.Add(lq.usage.ByResource())
+				t.reservingWorkloads += lq.reservingWorkloads
+			}
+		}
+	}
+}
+
+// TenantsOfLocalQueue returns the names of the Tenants of the LocalQueue,
+// by namespace/name.
+func (c *Cache) TenantsOfLocalQueue(lqKey string) []string {
+	c.RLock()
+	defer c.RUnlock()
+	var names []string
+	for name, t := range c.tenants {
+		if t.localQueues.Has(lqKey) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// TenantUsage returns the usage, across all the flavors, of the workloads of
+// the LocalQueues of the Tenant.
+func (c *Cache) TenantUsage(name string) (*TenantUsageStats, error) {
+	c.RLock()
+	defer c.RUnlock()
+	t, found := c.tenants[name]
+	if !found {
+		return nil, errTenantNotFound
+	}
+	return &TenantUsageStats{
+		Usage:              t.usage.ToResourceList(),
+		ReservingWorkloads: t.reservingWorkloads,
+	}, nil
+}
+
+// TenantSnapshot is the state of a Tenant in a scheduling cycle.
+type TenantSnapshot struct {
+	Name        string
+	UsageLimits resources.Requests
+	Usage       resources.Requests
+	FairWeight  resource.Quantity
+	// FairResourceWeights override the FairWeight for some resources.
+	FairResourceWeights map[corev1.ResourceName]resource.Quantity
+}
+
+// ExceededLimits returns the resources, sorted by name, whose limits would be
+// exceeded by adding the usage.
+func (t *TenantSnapshot) ExceededLimits(usage resources.Requests) []corev1.ResourceName {
+	var exceeded []corev1.ResourceName
+	for name, limit := range t.UsageLimits {
+		if v := usage[name]; v > 0 && t.Usage[name]+v > limit {
+			exceeded = append(exceeded, name)
+		}
+	}
+	slices.Sort(exceeded)
+	return exceeded
+}
+
+func (t *TenantSnapshot) AddUsage(usage resources.Requests) {
+	t.Usage.Add(usage)
+}
+
+// WeightedShare returns the highest ratio, in permille, between the usage
+// and the limits of the Tenant, divided by the fair sharing weight of the
+// resource. Tenants without limits have a share of 0.
+func (t *TenantSnapshot) WeightedShare() int {
+	if len(t.UsageLimits) == 0 {
+		return 0
+	}
+	if t.FairWeight.IsZero() {
+		return math.MaxInt
+	}
+	var share int64
+	for name, limit := range t.UsageLimits {
+		if limit > 0 {
+			weight := t.FairWeight
+			if w, found := t.FairResourceWeights[name]; found {
+				weight = w
+			}
+			if weight.IsZero() {
+				if t.Usage[name] > 0 {
+					return math.MaxInt
+				}
+				continue
+			}
+			share = max(share, t.Usage[name]*1000/limit*1000/weight.MilliValue())
+		}
+	}
+	return int(share)
+}
+
+func (c *Cache) snapshotTenants(snap *Snapshot) {
+	if len(c.tenants) == 0 {
+		return
+	}
+	snap.Tenants = make(map[string]*TenantSnapshot, len(c.tenants))
+	snap.LocalQueueTenants = make(map[string][]*TenantSnapshot)
+	for name, t := range c.tenants {
+		ts := &TenantSnapshot{
+			Name:                name,
+			UsageLimits:         t.usageLimits.Clone(),
+			Usage:               t.usage.Clone(),
+			FairWeight:          t.fairWeight,
+			FairResourceWeights: t.resourceFairWeights,
+		}
+		snap.Tenants[name] = ts
+		for key := range t.localQueues {
+			snap.LocalQueueTenants[key] = append(snap.LocalQueueTenants[key], ts)
+		}
+	}
+	for _, tenants := range snap.LocalQueueTenants {
+		slices.SortFunc(tenants, func(a, b *TenantSnapshot) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+}
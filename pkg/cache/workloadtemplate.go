@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// AddOrUpdateWorkloadTemplate tracks the WorkloadTemplate, so that the
+// workloads matching it skip its admission checks.
+func (c *Cache) AddOrUpdateWorkloadTemplate(template *kueuealpha.WorkloadTemplate) {
+	c.Lock()
+	defer c.Unlock()
+	c.workloadTemplates[template.Name] = template.DeepCopy()
+}
+
+// DeleteWorkloadTemplate stops tracking the WorkloadTemplate.
+func (c *Cache) DeleteWorkloadTemplate(name string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.workloadTemplates, name)
+}
+
+// SkippedAdmissionChecks returns the admission checks skipped by the workload
+// for matching the WorkloadTemplate referenced by its label.
+func (c *Cache) SkippedAdmissionChecks(wl *kueue.Workload) sets.Set[string] {
+	c.RLock()
+	defer c.RUnlock()
+	return workload.SkippedAdmissionChecks(wl, c.workloadTemplates)
+}
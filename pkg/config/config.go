@@ -21,11 +21,15 @@ import (
 	"fmt"
 	"os"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
@@ -37,7 +41,10 @@ func fromFile(path string, scheme *runtime.Scheme, cfg *configapi.Configuration)
 	if err != nil {
 		return err
 	}
+	return decode(content, scheme, cfg)
+}
 
+func decode(content []byte, scheme *runtime.Scheme, cfg *configapi.Configuration) error {
 	codecs := serializer.NewCodecFactory(scheme, serializer.EnableStrict)
 
 	// Regardless of if the bytes are of any external version,
@@ -94,6 +101,47 @@ func addTo(o *ctrl.Options, cfg *configapi.Configuration) {
 	}
 }
 
+// informerObjects are the objects watched by the integrations whose informer
+// can be restricted, by framework name.
+var informerObjects = map[string]func() client.Object{
+	"pod":         func() client.Object { return &corev1.Pod{} },
+	"deployment":  func() client.Object { return &appsv1.Deployment{} },
+	"statefulset": func() client.Object { return &appsv1.StatefulSet{} },
+}
+
+// addIntegrationInformersTo restricts the objects of the integrations which
+// are listed and watched by the cache of the manager.
+func addIntegrationInformersTo(o *ctrl.Options, cfg *configapi.Configuration) error {
+	if cfg.Integrations == nil {
+		return nil
+	}
+	for _, controller := range cfg.Integrations.Controllers {
+		newObject, found := informerObjects[controller.Framework]
+		if controller.Informer == nil || !found {
+			continue
+		}
+		byObject := cache.ByObject{}
+		if controller.Informer.LabelSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(controller.Informer.LabelSelector)
+			if err != nil {
+				return fmt.Errorf("parsing the label selector of the %q informer: %w", controller.Framework, err)
+			}
+			byObject.Label = selector
+		}
+		if len(controller.Informer.Namespaces) > 0 {
+			byObject.Namespaces = make(map[string]cache.Config, len(controller.Informer.Namespaces))
+			for _, ns := range controller.Informer.Namespaces {
+				byObject.Namespaces[ns] = cache.Config{}
+			}
+		}
+		if o.Cache.ByObject == nil {
+			o.Cache.ByObject = make(map[client.Object]cache.ByObject)
+		}
+		o.Cache.ByObject[newObject()] = byObject
+	}
+	return nil
+}
+
 func addLeaderElectionTo(o *ctrl.Options, cfg *configapi.Configuration) {
 	if cfg.LeaderElection == nil {
 		// The source does not have any configuration; noop
@@ -172,9 +220,31 @@ func Load(scheme *runtime.Scheme, configFile string) (ctrl.Options, configapi.Co
 		return options, cfg, err
 	}
 	addTo(&options, &cfg)
+	if err := addIntegrationInformersTo(&options, &cfg); err != nil {
+		return options, cfg, err
+	}
 	return options, cfg, err
 }
 
+// ValidateContent decodes the configuration from content and validates it, the same
+// way as Load does for the configuration file, except that the unknown and
+// duplicated fields are returned as warnings, so that a configuration for a
+// newer version of Kueue is not rejected only because of its new fields.
+func ValidateContent(scheme *runtime.Scheme, content []byte) ([]string, error) {
+	var warnings []string
+	cfg := configapi.Configuration{}
+	if err := decode(content, scheme, &cfg); err != nil {
+		strictErr, isStrict := runtime.AsStrictDecodingError(err)
+		if !isStrict {
+			return nil, err
+		}
+		for _, e := range strictErr.Errors() {
+			warnings = append(warnings, e.Error())
+		}
+	}
+	return warnings, validate(&cfg, scheme).ToAggregate()
+}
+
 func WaitForPodsReadyIsEnabled(cfg *configapi.Configuration) bool {
 	return cfg.WaitForPodsReady != nil && cfg.WaitForPodsReady.Enable
 }
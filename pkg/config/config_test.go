@@ -18,10 +18,13 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
+	"maps"
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
@@ -989,6 +992,62 @@ webhook:
 	}
 }
 
+func TestAddIntegrationInformersTo(t *testing.T) {
+	cfg := &configapi.Configuration{
+		Integrations: &configapi.Integrations{
+			Frameworks: []string{"batch/job", "pod", "statefulset"},
+			Controllers: []configapi.IntegrationController{
+				{
+					Framework:               "batch/job",
+					MaxConcurrentReconciles: ptr.To[int32](5),
+				},
+				{
+					Framework: "pod",
+					Informer: &configapi.IntegrationInformer{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kueue.x-k8s.io/managed": "true"}},
+						Namespaces:    []string{"team-a", "team-b"},
+					},
+				},
+				{
+					Framework: "statefulset",
+					Informer: &configapi.IntegrationInformer{
+						Namespaces: []string{"team-a"},
+					},
+				},
+			},
+		},
+	}
+	type informer struct {
+		Label      string
+		Namespaces []string
+	}
+	want := map[string]informer{
+		"*v1.Pod": {
+			Label:      "kueue.x-k8s.io/managed=true",
+			Namespaces: []string{"team-a", "team-b"},
+		},
+		"*v1.StatefulSet": {
+			Namespaces: []string{"team-a"},
+		},
+	}
+
+	options := ctrl.Options{}
+	if err := addIntegrationInformersTo(&options, cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got := make(map[string]informer, len(options.Cache.ByObject))
+	for obj, byObject := range options.Cache.ByObject {
+		i := informer{Namespaces: slices.Sorted(maps.Keys(byObject.Namespaces))}
+		if byObject.Label != nil {
+			i.Label = byObject.Label.String()
+		}
+		got[fmt.Sprintf("%T", obj)] = i
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected informers (-want,+got):\n%s", diff)
+	}
+}
+
 func TestEncode(t *testing.T) {
 	testScheme := runtime.NewScheme()
 	err := configapi.AddToScheme(testScheme)
@@ -1136,3 +1195,69 @@ func TestWaitForPodsReadyIsEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateContent(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	err := configapi.AddToScheme(testScheme)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		content      string
+		wantWarnings []string
+		wantErr      bool
+	}{
+		"valid configuration": {
+			content: `
+apiVersion: config.kueue.x-k8s.io/v1beta1
+kind: Configuration
+integrations:
+  frameworks:
+  - batch/job
+`,
+		},
+		"unknown field": {
+			content: `
+apiVersion: config.kueue.x-k8s.io/v1beta1
+kind: Configuration
+unknownField: true
+integrations:
+  frameworks:
+  - batch/job
+`,
+			wantWarnings: []string{`unknown field "unknownField"`},
+		},
+		"invalid configuration": {
+			content: `
+apiVersion: config.kueue.x-k8s.io/v1beta1
+kind: Configuration
+waitForPodsReady:
+  enable: false
+  blockAdmission: true
+integrations:
+  frameworks:
+  - batch/job
+`,
+			wantErr: true,
+		},
+		"not a configuration": {
+			content: `
+apiVersion: v1
+kind: ConfigMap
+`,
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotWarnings, err := ValidateContent(testScheme, []byte(tc.content))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Unexpected error, want error: %v, got: %v", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, gotWarnings); diff != "" {
+				t.Errorf("Unexpected warnings (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
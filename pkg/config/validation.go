@@ -18,10 +18,13 @@ package config
 
 import (
 	"fmt"
+	neturl "net/url"
 	"slices"
 	"strings"
+	"time"
 	"unsafe"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,10 +35,12 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	apimachineryutilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	tracingv1 "k8s.io/component-base/tracing/api/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/pkg/admissionpolicy"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	podworkload "sigs.k8s.io/kueue/pkg/controller/jobs/pod"
 	"sigs.k8s.io/kueue/pkg/features"
@@ -52,14 +57,32 @@ var (
 	integrationsExternalFrameworkPath = integrationsPath.Child("externalFrameworks")
 	podOptionsPath                    = integrationsPath.Child("podOptions")
 	namespaceSelectorPath             = podOptionsPath.Child("namespaceSelector")
+	integrationsWebhooksPath          = integrationsPath.Child("webhooks")
+	integrationsControllersPath       = integrationsPath.Child("controllers")
+	externalResumePolicyPath          = integrationsPath.Child("externalResumePolicy")
 	managedJobsNamespaceSelectorPath  = field.NewPath("managedJobsNamespaceSelector")
 	waitForPodsReadyPath              = field.NewPath("waitForPodsReady")
 	requeuingStrategyPath             = waitForPodsReadyPath.Child("requeuingStrategy")
 	multiKueuePath                    = field.NewPath("multiKueue")
 	fsPreemptionStrategiesPath        = field.NewPath("fairSharing", "preemptionStrategies")
+	fsVictimOrderingPath              = field.NewPath("fairSharing", "victimOrdering")
 	internalCertManagementPath        = field.NewPath("internalCertManagement")
 	queueVisibilityPath               = field.NewPath("queueVisibility")
 	resourceTransformationPath        = field.NewPath("resources", "transformations")
+	autoReactivationPath              = field.NewPath("autoReactivation")
+	tracingPath                       = field.NewPath("tracing")
+	localQueueSelectorPath            = field.NewPath("metrics", "localQueueMetrics", "localQueueSelector")
+	schedulingAuditPath               = field.NewPath("schedulingAudit")
+	usageReportsPath                  = field.NewPath("usageReports")
+	notificationsPath                 = field.NewPath("notifications")
+	tieBreakersPath                   = field.NewPath("queueingOrder", "tieBreakers")
+	submitterRedactionPath            = field.NewPath("submitterIdentity", "redaction")
+	admissionPoliciesPath             = field.NewPath("admissionPolicies")
+	deviceReadinessPath               = field.NewPath("deviceReadiness")
+	nodeInterruptionPath              = field.NewPath("nodeInterruption")
+	deviceHealthPath                  = field.NewPath("deviceHealth")
+	workloadHistoryPath               = field.NewPath("workloadHistory")
+	priorityDecayPath                 = field.NewPath("priorityDecay")
 )
 
 func validate(c *configapi.Configuration, scheme *runtime.Scheme) field.ErrorList {
@@ -72,6 +95,20 @@ func validate(c *configapi.Configuration, scheme *runtime.Scheme) field.ErrorLis
 	allErrs = append(allErrs, validateInternalCertManagement(c)...)
 	allErrs = append(allErrs, validateResourceTransformations(c)...)
 	allErrs = append(allErrs, validateManagedJobsNamespaceSelector(c)...)
+	allErrs = append(allErrs, validateAutoReactivation(c)...)
+	allErrs = append(allErrs, tracingv1.ValidateTracingConfiguration(c.Tracing, nil, tracingPath)...)
+	allErrs = append(allErrs, validateLocalQueueMetrics(c)...)
+	allErrs = append(allErrs, validateSchedulingAudit(c)...)
+	allErrs = append(allErrs, validateUsageReports(c)...)
+	allErrs = append(allErrs, validateNotifications(c)...)
+	allErrs = append(allErrs, validateQueueingOrder(c)...)
+	allErrs = append(allErrs, validateSubmitterIdentity(c)...)
+	allErrs = append(allErrs, validateAdmissionPolicies(c)...)
+	allErrs = append(allErrs, validateDeviceReadiness(c)...)
+	allErrs = append(allErrs, validateNodeInterruption(c)...)
+	allErrs = append(allErrs, validateDeviceHealth(c)...)
+	allErrs = append(allErrs, validateWorkloadHistory(c)...)
+	allErrs = append(allErrs, validatePriorityDecay(c)...)
 	return allErrs
 }
 
@@ -116,6 +153,10 @@ func validateMultiKueue(c *configapi.Configuration) field.ErrorList {
 func validateWaitForPodsReady(c *configapi.Configuration) field.ErrorList {
 	var allErrs field.ErrorList
 	if !WaitForPodsReadyIsEnabled(c) {
+		if c.WaitForPodsReady != nil && ptr.Deref(c.WaitForPodsReady.BlockAdmission, false) {
+			allErrs = append(allErrs, field.Invalid(waitForPodsReadyPath.Child("blockAdmission"),
+				*c.WaitForPodsReady.BlockAdmission, "can only be set when waitForPodsReady.enable is true"))
+		}
 		return allErrs
 	}
 	if c.WaitForPodsReady.Timeout != nil && c.WaitForPodsReady.Timeout.Duration < 0 {
@@ -175,15 +216,23 @@ func validateIntegrations(c *configapi.Configuration, scheme *runtime.Scheme) fi
 	managedFrameworks := sets.New[string]()
 	availableBuiltInFrameworks := jobframework.GetIntegrationsList()
 	for idx, framework := range c.Integrations.Frameworks {
-		if cb, found := jobframework.GetIntegration(framework); !found {
+		cb, found := jobframework.GetIntegration(framework)
+		if !found {
 			allErrs = append(allErrs, field.NotSupported(integrationsFrameworksPath.Index(idx), framework, availableBuiltInFrameworks))
-		} else if gvk, err := apiutil.GVKForObject(cb.JobType, scheme); err == nil {
+			continue
+		}
+		if gvk, err := apiutil.GVKForObject(cb.JobType, scheme); err == nil {
 			if managedFrameworks.Has(gvk.String()) {
 				allErrs = append(allErrs, field.Duplicate(integrationsFrameworksPath.Index(idx), framework))
 			} else {
 				managedFrameworks = managedFrameworks.Insert(gvk.String())
 			}
 		}
+		for _, dep := range cb.DependencyList {
+			if !slices.Contains(c.Integrations.Frameworks, dep) {
+				allErrs = append(allErrs, field.Invalid(integrationsFrameworksPath.Index(idx), framework, fmt.Sprintf("requires the %q integration to be enabled", dep)))
+			}
+		}
 	}
 	for idx, framework := range c.Integrations.ExternalFrameworks {
 		gvk, _ := schema.ParseKindArg(framework)
@@ -198,6 +247,105 @@ func validateIntegrations(c *configapi.Configuration, scheme *runtime.Scheme) fi
 	}
 
 	allErrs = append(allErrs, validatePodIntegrationOptions(c)...)
+	allErrs = append(allErrs, validateIntegrationWebhooks(c)...)
+	allErrs = append(allErrs, validateIntegrationControllers(c)...)
+	if p := c.Integrations.ExternalResumePolicy; p != "" && !slices.Contains(validExternalResumePolicies, p) {
+		allErrs = append(allErrs, field.NotSupported(externalResumePolicyPath, p, validExternalResumePolicies))
+	}
+	return allErrs
+}
+
+func validateIntegrationWebhooks(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	frameworks := sets.New[string]()
+	for idx, webhook := range c.Integrations.Webhooks {
+		path := integrationsWebhooksPath.Index(idx)
+		switch {
+		case !slices.Contains(c.Integrations.Frameworks, webhook.Framework):
+			allErrs = append(allErrs, field.NotSupported(path.Child("framework"), webhook.Framework, c.Integrations.Frameworks))
+		case frameworks.Has(webhook.Framework):
+			allErrs = append(allErrs, field.Duplicate(path.Child("framework"), webhook.Framework))
+		default:
+			frameworks.Insert(webhook.Framework)
+		}
+		if webhook.FailurePolicy != nil {
+			validPolicies := []admissionregistrationv1.FailurePolicyType{admissionregistrationv1.Fail, admissionregistrationv1.Ignore}
+			if !slices.Contains(validPolicies, *webhook.FailurePolicy) {
+				allErrs = append(allErrs, field.NotSupported(path.Child("failurePolicy"), *webhook.FailurePolicy, validPolicies))
+			}
+		}
+		if webhook.TimeoutSeconds != nil && (*webhook.TimeoutSeconds < 1 || *webhook.TimeoutSeconds > 30) {
+			allErrs = append(allErrs, field.Invalid(path.Child("timeoutSeconds"), *webhook.TimeoutSeconds, "must be between 1 and 30"))
+		}
+		if webhook.NamespaceSelector != nil {
+			allErrs = append(allErrs, validation.ValidateLabelSelector(webhook.NamespaceSelector, validation.LabelSelectorValidationOptions{}, path.Child("namespaceSelector"))...)
+		}
+	}
+	return allErrs
+}
+
+func validateIntegrationControllers(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	frameworks := sets.New[string]()
+	for idx, controller := range c.Integrations.Controllers {
+		path := integrationsControllersPath.Index(idx)
+		switch {
+		case !slices.Contains(c.Integrations.Frameworks, controller.Framework):
+			allErrs = append(allErrs, field.NotSupported(path.Child("framework"), controller.Framework, c.Integrations.Frameworks))
+		case frameworks.Has(controller.Framework):
+			allErrs = append(allErrs, field.Duplicate(path.Child("framework"), controller.Framework))
+		default:
+			frameworks.Insert(controller.Framework)
+		}
+		if controller.MaxConcurrentReconciles != nil && *controller.MaxConcurrentReconciles <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("maxConcurrentReconciles"), *controller.MaxConcurrentReconciles, "must be greater than 0"))
+		}
+		if cc := controller.ClientConnection; cc != nil {
+			if cc.QPS != nil && *cc.QPS <= 0 {
+				allErrs = append(allErrs, field.Invalid(path.Child("clientConnection", "qps"), *cc.QPS, "must be greater than 0"))
+			}
+			if cc.Burst != nil && *cc.Burst <= 0 {
+				allErrs = append(allErrs, field.Invalid(path.Child("clientConnection", "burst"), *cc.Burst, "must be greater than 0"))
+			}
+		}
+		if controller.ResyncPeriod != nil && controller.ResyncPeriod.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("resyncPeriod"), controller.ResyncPeriod.Duration.String(), "must be greater than 0"))
+		}
+		if controller.Informer != nil {
+			allErrs = append(allErrs, validateIntegrationInformer(controller.Framework, controller.Informer, path.Child("informer"))...)
+		}
+	}
+	return allErrs
+}
+
+func validateIntegrationInformer(framework string, informer *configapi.IntegrationInformer, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if _, found := informerObjects[framework]; !found {
+		allErrs = append(allErrs, field.Forbidden(path, fmt.Sprintf("not supported by the %q integration", framework)))
+	}
+	if framework == podworkload.FrameworkName && features.Enabled(features.TopologyAwareScheduling) {
+		// The usage of the pods not managed by Kueue, accounted by TopologyAwareScheduling,
+		// is read from the pods cached by the manager.
+		allErrs = append(allErrs, field.Forbidden(path, fmt.Sprintf("not supported when the %s feature is enabled", features.TopologyAwareScheduling)))
+	}
+	if informer.LabelSelector != nil {
+		selectorPath := path.Child("labelSelector")
+		allErrs = append(allErrs, validation.ValidateLabelSelector(informer.LabelSelector, validation.LabelSelectorValidationOptions{}, selectorPath)...)
+		if _, err := metav1.LabelSelectorAsSelector(informer.LabelSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(selectorPath, informer.LabelSelector, err.Error()))
+		}
+	}
+	namespaces := sets.New[string]()
+	for i, ns := range informer.Namespaces {
+		nsPath := path.Child("namespaces").Index(i)
+		for _, msg := range apimachineryvalidation.ValidateNamespaceName(ns, false) {
+			allErrs = append(allErrs, field.Invalid(nsPath, ns, msg))
+		}
+		if namespaces.Has(ns) {
+			allErrs = append(allErrs, field.Duplicate(nsPath, ns))
+		}
+		namespaces.Insert(ns)
+	}
 	return allErrs
 }
 
@@ -261,6 +409,13 @@ var (
 		}
 		return ss
 	}()
+
+	validVictimOrderingCriteria = sets.New(
+		configapi.LowestPriority,
+		configapi.Newest,
+		configapi.Smallest,
+		configapi.LeastProgress,
+	)
 )
 
 func validateFairSharing(c *configapi.Configuration) field.ErrorList {
@@ -281,6 +436,16 @@ func validateFairSharing(c *configapi.Configuration) field.ErrorList {
 			allErrs = append(allErrs, field.NotSupported(fsPreemptionStrategiesPath, fs.PreemptionStrategies, validStrategySetsStr))
 		}
 	}
+	seenCriteria := sets.New[configapi.VictimOrderingCriterion]()
+	for idx, criterion := range fs.VictimOrdering {
+		path := fsVictimOrderingPath.Index(idx)
+		if !validVictimOrderingCriteria.Has(criterion) {
+			allErrs = append(allErrs, field.NotSupported(path, criterion, sets.List(validVictimOrderingCriteria)))
+		} else if seenCriteria.Has(criterion) {
+			allErrs = append(allErrs, field.Duplicate(path, criterion))
+		}
+		seenCriteria.Insert(criterion)
+	}
 	return allErrs
 }
 
@@ -306,6 +471,46 @@ func validateResourceTransformations(c *configapi.Configuration) field.ErrorList
 	return allErrs
 }
 
+func validateAutoReactivation(c *configapi.Configuration) field.ErrorList {
+	ar := c.AutoReactivation
+	if ar == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	validCategories := []string{configapi.InfrastructureReasonCategory, configapi.UserReasonCategory}
+	seenCategories := sets.New[string]()
+	for idx, category := range ar.ReasonCategories {
+		categoryPath := autoReactivationPath.Child("reasonCategories").Index(idx)
+		if !slices.Contains(validCategories, category) {
+			allErrs = append(allErrs, field.NotSupported(categoryPath, category, validCategories))
+		}
+		if seenCategories.Has(category) {
+			allErrs = append(allErrs, field.Duplicate(categoryPath, category))
+		}
+		seenCategories.Insert(category)
+	}
+	if ptr.Deref(ar.BackoffLimitCount, 0) < 0 {
+		allErrs = append(allErrs, field.Invalid(autoReactivationPath.Child("backoffLimitCount"),
+			*ar.BackoffLimitCount, apimachineryvalidation.IsNegativeErrorMsg))
+	}
+	if ptr.Deref(ar.BackoffBaseSeconds, 0) < 0 {
+		allErrs = append(allErrs, field.Invalid(autoReactivationPath.Child("backoffBaseSeconds"),
+			*ar.BackoffBaseSeconds, apimachineryvalidation.IsNegativeErrorMsg))
+	}
+	if ptr.Deref(ar.BackoffMaxSeconds, 0) < 0 {
+		allErrs = append(allErrs, field.Invalid(autoReactivationPath.Child("backoffMaxSeconds"),
+			*ar.BackoffMaxSeconds, apimachineryvalidation.IsNegativeErrorMsg))
+	}
+	return allErrs
+}
+
+func validateLocalQueueMetrics(c *configapi.Configuration) field.ErrorList {
+	if c.Metrics.LocalQueueMetrics == nil {
+		return nil
+	}
+	return validation.ValidateLabelSelector(c.Metrics.LocalQueueMetrics.LocalQueueSelector, validation.LabelSelectorValidationOptions{}, localQueueSelectorPath)
+}
+
 func validateManagedJobsNamespaceSelector(c *configapi.Configuration) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -338,3 +543,287 @@ func validateManagedJobsNamespaceSelector(c *configapi.Configuration) field.Erro
 
 	return allErrs
 }
+
+func validateSchedulingAudit(c *configapi.Configuration) field.ErrorList {
+	sa := c.SchedulingAudit
+	if sa == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	path, url := ptr.Deref(sa.Path, ""), ptr.Deref(sa.URL, "")
+	switch {
+	case path == "" && url == "":
+		allErrs = append(allErrs, field.Required(schedulingAuditPath, "one of path and url must be set"))
+	case path != "" && url != "":
+		allErrs = append(allErrs, field.Invalid(schedulingAuditPath.Child("url"), url, "must not be set together with path"))
+	case url != "":
+		if u, err := neturl.Parse(url); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(schedulingAuditPath.Child("url"), url, "must be an absolute http or https URL"))
+		}
+	}
+	if ptr.Deref(sa.BufferSize, 0) <= 0 {
+		allErrs = append(allErrs, field.Invalid(schedulingAuditPath.Child("bufferSize"),
+			ptr.Deref(sa.BufferSize, 0), "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validateUsageReports(c *configapi.Configuration) field.ErrorList {
+	ur := c.UsageReports
+	if ur == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	var period, syncInterval time.Duration
+	if ur.Period != nil {
+		period = ur.Period.Duration
+	}
+	if ur.SyncInterval != nil {
+		syncInterval = ur.SyncInterval.Duration
+	}
+	if period <= 0 {
+		allErrs = append(allErrs, field.Invalid(usageReportsPath.Child("period"), period.String(), "must be greater than 0"))
+	}
+	if syncInterval <= 0 {
+		allErrs = append(allErrs, field.Invalid(usageReportsPath.Child("syncInterval"), syncInterval.String(), "must be greater than 0"))
+	} else if period > 0 && syncInterval > period {
+		allErrs = append(allErrs, field.Invalid(usageReportsPath.Child("syncInterval"), syncInterval.String(), "must not be greater than the period"))
+	}
+	return allErrs
+}
+
+var validNotificationEventTypes = []configapi.NotificationEventType{
+	configapi.WorkloadAdmittedNotification,
+	configapi.WorkloadEvictedNotification,
+	configapi.WorkloadPreemptedNotification,
+	configapi.WorkloadFinishedNotification,
+	configapi.ClusterQueueStoppedNotification,
+	configapi.ClusterQueueResumedNotification,
+}
+
+func validateNotifications(c *configapi.Configuration) field.ErrorList {
+	n := c.Notifications
+	if n == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	for i, webhook := range n.Webhooks {
+		webhookPath := notificationsPath.Child("webhooks").Index(i)
+		if !isHTTPURL(webhook.URL) {
+			allErrs = append(allErrs, field.Invalid(webhookPath.Child("url"), webhook.URL, "must be an absolute http or https URL"))
+		}
+		allErrs = append(allErrs, validateNotificationEvents(webhookPath.Child("events"), webhook.Events)...)
+	}
+	for i, kafka := range n.Kafka {
+		kafkaPath := notificationsPath.Child("kafka").Index(i)
+		if !isHTTPURL(kafka.RESTProxyURL) {
+			allErrs = append(allErrs, field.Invalid(kafkaPath.Child("restProxyURL"), kafka.RESTProxyURL, "must be an absolute http or https URL"))
+		}
+		if kafka.Topic == "" {
+			allErrs = append(allErrs, field.Required(kafkaPath.Child("topic"), ""))
+		}
+		allErrs = append(allErrs, validateNotificationEvents(kafkaPath.Child("events"), kafka.Events)...)
+	}
+	for i, nats := range n.NATS {
+		natsPath := notificationsPath.Child("nats").Index(i)
+		if u, err := neturl.Parse(nats.URL); err != nil || (u.Scheme != "nats" && u.Scheme != "tls") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(natsPath.Child("url"), nats.URL, "must be an absolute nats or tls URL"))
+		}
+		if nats.Subject == "" {
+			allErrs = append(allErrs, field.Required(natsPath.Child("subject"), ""))
+		} else if strings.ContainsAny(nats.Subject, " \t\r\n") {
+			allErrs = append(allErrs, field.Invalid(natsPath.Child("subject"), nats.Subject, "must not contain whitespaces"))
+		}
+		allErrs = append(allErrs, validateNotificationEvents(natsPath.Child("events"), nats.Events)...)
+	}
+	for i, ce := range n.CloudEvents {
+		cePath := notificationsPath.Child("cloudEvents").Index(i)
+		if !isHTTPURL(ce.URL) {
+			allErrs = append(allErrs, field.Invalid(cePath.Child("url"), ce.URL, "must be an absolute http or https URL"))
+		}
+		if _, err := neturl.Parse(ce.Source); err != nil {
+			allErrs = append(allErrs, field.Invalid(cePath.Child("source"), ce.Source, "must be a URI reference"))
+		}
+		allErrs = append(allErrs, validateNotificationEvents(cePath.Child("events"), ce.Events)...)
+	}
+	if ptr.Deref(n.BufferSize, 0) <= 0 {
+		allErrs = append(allErrs, field.Invalid(notificationsPath.Child("bufferSize"),
+			ptr.Deref(n.BufferSize, 0), "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validateNotificationEvents(path *field.Path, events []configapi.NotificationEventType) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, eventType := range events {
+		if !slices.Contains(validNotificationEventTypes, eventType) {
+			allErrs = append(allErrs, field.NotSupported(path.Index(i), eventType, validNotificationEventTypes))
+		}
+	}
+	return allErrs
+}
+
+var validTieBreakers = []configapi.TieBreaker{
+	configapi.CreationTimestampTieBreaker,
+	configapi.NamespaceHashTieBreaker,
+	configapi.SubmissionCounterTieBreaker,
+}
+
+func validateQueueingOrder(c *configapi.Configuration) field.ErrorList {
+	if c.QueueingOrder == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	seen := sets.New[configapi.TieBreaker]()
+	for i, tb := range c.QueueingOrder.TieBreakers {
+		switch {
+		case !slices.Contains(validTieBreakers, tb):
+			allErrs = append(allErrs, field.NotSupported(tieBreakersPath.Index(i), tb, validTieBreakers))
+		case seen.Has(tb):
+			allErrs = append(allErrs, field.Duplicate(tieBreakersPath.Index(i), tb))
+		}
+		seen.Insert(tb)
+	}
+	return allErrs
+}
+
+var validExternalResumePolicies = []configapi.ExternalResumePolicy{
+	configapi.SuspendExternalResumePolicy,
+	configapi.AdoptExternalResumePolicy,
+	configapi.AlertExternalResumePolicy,
+}
+
+var validSubmitterRedactions = []configapi.SubmitterRedaction{
+	configapi.NoSubmitterRedaction,
+	configapi.HashSubmitterRedaction,
+}
+
+func validateSubmitterIdentity(c *configapi.Configuration) field.ErrorList {
+	if c.SubmitterIdentity == nil {
+		return nil
+	}
+	if r := c.SubmitterIdentity.Redaction; r != "" && !slices.Contains(validSubmitterRedactions, r) {
+		return field.ErrorList{field.NotSupported(submitterRedactionPath, r, validSubmitterRedactions)}
+	}
+	return nil
+}
+
+func validateAdmissionPolicies(c *configapi.Configuration) field.ErrorList {
+	return admissionpolicy.Validate(admissionpolicy.FromConfig(c.AdmissionPolicies), admissionPoliciesPath)
+}
+
+func validateDeviceReadiness(c *configapi.Configuration) field.ErrorList {
+	dr := c.DeviceReadiness
+	if dr == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if len(dr.ResourceNames) == 0 {
+		allErrs = append(allErrs, field.Required(deviceReadinessPath.Child("resourceNames"), ""))
+	}
+	for i, name := range dr.ResourceNames {
+		for _, msg := range apimachineryutilvalidation.IsQualifiedName(string(name)) {
+			allErrs = append(allErrs, field.Invalid(deviceReadinessPath.Child("resourceNames").Index(i), name, msg))
+		}
+	}
+	if dr.Timeout != nil && dr.Timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(deviceReadinessPath.Child("timeout"), dr.Timeout.Duration.String(), "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validateNodeInterruption(c *configapi.Configuration) field.ErrorList {
+	ni := c.NodeInterruption
+	if ni == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	for i, key := range ni.TaintKeys {
+		for _, msg := range apimachineryutilvalidation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(nodeInterruptionPath.Child("taintKeys").Index(i), key, msg))
+		}
+	}
+	for i, key := range ni.AnnotationKeys {
+		for _, msg := range apimachineryutilvalidation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(nodeInterruptionPath.Child("annotationKeys").Index(i), key, msg))
+		}
+	}
+	return allErrs
+}
+
+func validateDeviceHealth(c *configapi.Configuration) field.ErrorList {
+	dh := c.DeviceHealth
+	if dh == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if len(dh.ResourceNames) == 0 {
+		allErrs = append(allErrs, field.Required(deviceHealthPath.Child("resourceNames"), ""))
+	}
+	for i, name := range dh.ResourceNames {
+		for _, msg := range apimachineryutilvalidation.IsQualifiedName(string(name)) {
+			allErrs = append(allErrs, field.Invalid(deviceHealthPath.Child("resourceNames").Index(i), name, msg))
+		}
+	}
+	for i, condType := range dh.UnhealthyNodeConditions {
+		for _, msg := range apimachineryutilvalidation.IsQualifiedName(string(condType)) {
+			allErrs = append(allErrs, field.Invalid(deviceHealthPath.Child("unhealthyNodeConditions").Index(i), condType, msg))
+		}
+	}
+	return allErrs
+}
+
+func validateWorkloadHistory(c *configapi.Configuration) field.ErrorList {
+	wh := c.WorkloadHistory
+	if wh == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	switch {
+	case wh.File == nil:
+		allErrs = append(allErrs, field.Required(workloadHistoryPath.Child("file"), "a store must be set"))
+	case wh.File.Directory == "":
+		allErrs = append(allErrs, field.Required(workloadHistoryPath.Child("file", "directory"), ""))
+	}
+	if wh.Retention == nil || wh.Retention.Duration <= 0 {
+		var retention time.Duration
+		if wh.Retention != nil {
+			retention = wh.Retention.Duration
+		}
+		allErrs = append(allErrs, field.Invalid(workloadHistoryPath.Child("retention"), retention.String(), "must be greater than 0"))
+	}
+	if ptr.Deref(wh.BufferSize, 0) <= 0 {
+		allErrs = append(allErrs, field.Invalid(workloadHistoryPath.Child("bufferSize"),
+			ptr.Deref(wh.BufferSize, 0), "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validatePriorityDecay(c *configapi.Configuration) field.ErrorList {
+	pd := c.PriorityDecay
+	if pd == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if ptr.Deref(pd.ThresholdPercent, 0) < 100 {
+		allErrs = append(allErrs, field.Invalid(priorityDecayPath.Child("thresholdPercent"),
+			ptr.Deref(pd.ThresholdPercent, 0), "must be greater than or equal to 100"))
+	}
+	if ptr.Deref(pd.Step, 0) <= 0 {
+		allErrs = append(allErrs, field.Invalid(priorityDecayPath.Child("step"),
+			ptr.Deref(pd.Step, 0), "must be greater than 0"))
+	}
+	if pd.Interval == nil || pd.Interval.Duration <= 0 {
+		var interval time.Duration
+		if pd.Interval != nil {
+			interval = pd.Interval.Duration
+		}
+		allErrs = append(allErrs, field.Invalid(priorityDecayPath.Child("interval"), interval.String(), "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func isHTTPURL(url string) bool {
+	u, err := neturl.Parse(url)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+)
+
+// ErrRestartRequired is returned when the new configuration changes fields
+// which can only be applied by restarting Kueue.
+var ErrRestartRequired = errors.New("the configuration changes fields which require a restart")
+
+// ReconfigureFunc applies the reloadable fields of the configuration to a
+// component which is already running.
+type ReconfigureFunc func(cfg *configapi.Configuration) error
+
+type reconfigurer struct {
+	name string
+	fn   ReconfigureFunc
+}
+
+// Watcher watches the configuration file and applies the changes of the
+// reloadable fields, without restarting Kueue. The reloadable fields are
+// waitForPodsReady, except requeuingStrategy.timestamp,
+// managedJobsNamespaceSelector, resources and fairSharing.
+//
+// A new configuration is only applied if it is valid and it doesn't change
+// any other field. If a component fails to apply it, the components which
+// already applied it are reconfigured with the previous configuration.
+type Watcher struct {
+	scheme     *runtime.Scheme
+	configFile string
+
+	mu            sync.Mutex
+	current       configapi.Configuration
+	reloaded      bool
+	reconfigurers []reconfigurer
+}
+
+// NewWatcher returns a Watcher for the configuration file, which was loaded
+// into cfg.
+func NewWatcher(scheme *runtime.Scheme, configFile string, cfg *configapi.Configuration) *Watcher {
+	return &Watcher{
+		scheme:     scheme,
+		configFile: configFile,
+		current:    *cfg.DeepCopy(),
+	}
+}
+
+// Register adds a component to reconfigure when the configuration changes.
+// The components are reconfigured in the order in which they are registered.
+// If the configuration was already reloaded, the component is reconfigured
+// with it immediately.
+func (w *Watcher) Register(name string, fn ReconfigureFunc) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.reloaded {
+		if err := fn(&w.current); err != nil {
+			return fmt.Errorf("reconfiguring %s: %w", name, err)
+		}
+	}
+	w.reconfigurers = append(w.reconfigurers, reconfigurer{name: name, fn: fn})
+	return nil
+}
+
+// Start implements the Runnable interface to reload the configuration when
+// the file changes, until the context is canceled.
+func (w *Watcher) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("config-watcher")
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer fsWatcher.Close()
+	// Watch the directory, as the ConfigMap volumes replace the file by
+	// swapping a symbolic link, instead of writing it.
+	if err := fsWatcher.Add(filepath.Dir(w.configFile)); err != nil {
+		return fmt.Errorf("watching the configuration file: %w", err)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-fsWatcher.Errors:
+			log.Error(err, "Watching the configuration file")
+		case e := <-fsWatcher.Events:
+			if e.Has(fsnotify.Chmod) {
+				continue
+			}
+			reloaded, err := w.Reload()
+			if err != nil {
+				log.Error(err, "Unable to reload the configuration, keeping the previous one", "file", w.configFile)
+				continue
+			}
+			if reloaded {
+				log.Info("Reloaded the configuration", "file", w.configFile)
+			}
+		}
+	}
+}
+
+// NeedLeaderElection implements LeaderElectionRunnable, as all the replicas
+// need to apply the configuration.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Reload loads the configuration file and applies it, if it changed.
+// It returns whether the configuration was applied.
+func (w *Watcher) Reload() (bool, error) {
+	_, cfg, err := Load(w.scheme, w.configFile)
+	if err != nil {
+		return false, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if equality.Semantic.DeepEqual(w.current, cfg) {
+		return false, nil
+	}
+	if fields := restartRequiredFields(&w.current, &cfg); len(fields) > 0 {
+		return false, fmt.Errorf("%w: %s", ErrRestartRequired, strings.Join(fields, ", "))
+	}
+	for i, r := range w.reconfigurers {
+		if err := r.fn(&cfg); err != nil {
+			err = fmt.Errorf("reconfiguring %s: %w", r.name, err)
+			for _, applied := range w.reconfigurers[:i+1] {
+				if rollbackErr := applied.fn(&w.current); rollbackErr != nil {
+					err = errors.Join(err, fmt.Errorf("rolling back %s: %w", applied.name, rollbackErr))
+				}
+			}
+			return false, err
+		}
+	}
+	w.current = cfg
+	w.reloaded = true
+	return true, nil
+}
+
+// restartRequiredFields returns the names of the fields, other than the
+// reloadable ones, which differ between the configurations.
+func restartRequiredFields(oldCfg, newCfg *configapi.Configuration) []string {
+	oldValue := reflect.ValueOf(withoutReloadableFields(oldCfg))
+	newValue := reflect.ValueOf(withoutReloadableFields(newCfg))
+	fields := changedFields(oldValue, newValue)
+	if requeuingTimestamp(oldCfg) != requeuingTimestamp(newCfg) {
+		fields = append(fields, "waitForPodsReady.requeuingStrategy.timestamp")
+	}
+	return fields
+}
+
+func changedFields(oldValue, newValue reflect.Value) []string {
+	var fields []string
+	for i := range oldValue.NumField() {
+		field := oldValue.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" {
+			// Inlined struct.
+			if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(configapi.Configuration{}.TypeMeta) {
+				fields = append(fields, changedFields(oldValue.Field(i), newValue.Field(i))...)
+			}
+			continue
+		}
+		if !equality.Semantic.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+func withoutReloadableFields(cfg *configapi.Configuration) configapi.Configuration {
+	c := cfg.DeepCopy()
+	c.ManagedJobsNamespaceSelector = nil
+	c.Resources = nil
+	c.FairSharing = nil
+	c.PriorityDecay = nil
+	c.WaitForPodsReady = nil
+	return *c
+}
+
+func requeuingTimestamp(cfg *configapi.Configuration) configapi.RequeuingTimestamp {
+	if cfg.WaitForPodsReady != nil && cfg.WaitForPodsReady.RequeuingStrategy != nil && cfg.WaitForPodsReady.RequeuingStrategy.Timestamp != nil {
+		return *cfg.WaitForPodsReady.RequeuingStrategy.Timestamp
+	}
+	return configapi.EvictionTimestamp
+}
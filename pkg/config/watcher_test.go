@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+)
+
+func TestWatcherReload(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	if err := configapi.AddToScheme(testScheme); err != nil {
+		t.Fatal(err)
+	}
+
+	const initialConfig = `
+apiVersion: config.kueue.x-k8s.io/v1beta1
+kind: Configuration
+namespace: kueue-system
+fairSharing:
+  enable: false
+`
+	errFailed := errors.New("failed")
+
+	cases := map[string]struct {
+		newConfig    string
+		failFor      string
+		wantReloaded bool
+		wantErr      error
+		wantCalls    []string
+	}{
+		"unchanged configuration": {
+			newConfig: initialConfig,
+		},
+		"reloadable field changed": {
+			newConfig: `
+apiVersion: config.kueue.x-k8s.io/v1beta1
+kind: Configuration
+namespace: kueue-system
+fairSharing:
+  enable: true
+waitForPodsReady:
+  enable: true
+`,
+			wantReloaded: true,
+			wantCalls: []string{
+				"cache: fairSharing=true, waitForPodsReady=true",
+				"scheduler: fairSharing=true, waitForPodsReady=true",
+			},
+		},
+		"field requiring a restart changed": {
+			newConfig: `
+apiVersion: config.kueue.x-k8s.io/v1beta1
+kind: Configuration
+namespace: kueue-tenant-a
+fairSharing:
+  enable: true
+`,
+			wantErr: ErrRestartRequired,
+		},
+		"requeuing timestamp changed": {
+			newConfig: `
+apiVersion: config.kueue.x-k8s.io/v1beta1
+kind: Configuration
+namespace: kueue-system
+waitForPodsReady:
+  enable: true
+  requeuingStrategy:
+    timestamp: Creation
+`,
+			wantErr: ErrRestartRequired,
+		},
+		"invalid configuration": {
+			newConfig: `
+apiVersion: config.kueue.x-k8s.io/v1beta1
+kind: Configuration
+namespace: kueue-system
+fairSharing:
+  enable: true
+  preemptionStrategies: [Unknown]
+`,
+			wantErr: cmpopts.AnyError,
+		},
+		"reconfiguration failed, rolled back": {
+			newConfig: `
+apiVersion: config.kueue.x-k8s.io/v1beta1
+kind: Configuration
+namespace: kueue-system
+fairSharing:
+  enable: true
+`,
+			failFor: "scheduler",
+			wantErr: errFailed,
+			wantCalls: []string{
+				"cache: fairSharing=true, waitForPodsReady=false",
+				"scheduler: fairSharing=true, waitForPodsReady=false",
+				"cache: fairSharing=false, waitForPodsReady=false",
+				"scheduler: fairSharing=false, waitForPodsReady=false",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configFile, []byte(initialConfig), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, cfg, err := Load(testScheme, configFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := NewWatcher(testScheme, configFile, &cfg)
+			var calls []string
+			for _, name := range []string{"cache", "scheduler"} {
+				if err := w.Register(name, func(cfg *configapi.Configuration) error {
+					calls = append(calls, fmt.Sprintf("%s: fairSharing=%v, waitForPodsReady=%v",
+						name, cfg.FairSharing != nil && cfg.FairSharing.Enable, WaitForPodsReadyIsEnabled(cfg)))
+					if tc.failFor == name && cfg.FairSharing.Enable {
+						return errFailed
+					}
+					return nil
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := os.WriteFile(configFile, []byte(tc.newConfig), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			reloaded, err := w.Reload()
+			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Unexpected error (-want,+got):\n%s", diff)
+			}
+			if reloaded != tc.wantReloaded {
+				t.Errorf("Unexpected reloaded, want=%v, got=%v", tc.wantReloaded, reloaded)
+			}
+			if diff := cmp.Diff(tc.wantCalls, calls); diff != "" {
+				t.Errorf("Unexpected reconfigurations (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -21,11 +21,12 @@ import (
 )
 
 const (
-	KueueName              = "kueue"
-	JobControllerName      = KueueName + "-job-controller"
-	WorkloadControllerName = KueueName + "-workload-controller"
-	AdmissionName          = KueueName + "-admission"
-	ReclaimablePodsMgr     = KueueName + "-reclaimable-pods"
+	KueueName                  = "kueue"
+	JobControllerName          = KueueName + "-job-controller"
+	WorkloadControllerName     = KueueName + "-workload-controller"
+	ClusterQueueControllerName = KueueName + "-clusterqueue-controller"
+	AdmissionName              = KueueName + "-admission"
+	ReclaimablePodsMgr         = KueueName + "-reclaimable-pods"
 
 	// UpdatesBatchPeriod is the batch period to hold workload updates
 	// before syncing a Queue and ClusterQueue objects.
@@ -43,4 +44,9 @@ const (
 
 	// ManagedByKueueLabel label that signalize that an object is managed by Kueue
 	ManagedByKueueLabel = "kueue.x-k8s.io/managed"
+
+	// KarpenterNodePoolLabel is the label set by Karpenter on the nodes it
+	// provisions, with the name of their NodePool. The ResourceFlavors with
+	// this node label are capped by the limits of the NodePool.
+	KarpenterNodePoolLabel = "karpenter.sh/nodepool"
 )
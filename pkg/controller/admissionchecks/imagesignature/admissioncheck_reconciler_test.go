@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagesignature
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestReconcileAdmissionCheck(t *testing.T) {
+	_, publicKey := newKey(t)
+	check := utiltesting.MakeAdmissionCheck("check").
+		ControllerName(kueuealpha.ImageSignatureControllerName).
+		Parameters(kueuealpha.GroupVersion.Group, ConfigKind, "config").
+		Generation(1).
+		Obj()
+	cases := map[string]struct {
+		configs       []kueuealpha.ImageSignatureConfig
+		wantCondition *metav1.Condition
+	}{
+		"config missing": {
+			wantCondition: &metav1.Condition{
+				Type:               kueue.AdmissionCheckActive,
+				Status:             metav1.ConditionFalse,
+				Reason:             "BadParametersRef",
+				Message:            `imagesignatureconfigs.kueue.x-k8s.io "config" not found`,
+				ObservedGeneration: 1,
+			},
+		},
+		"invalid public key": {
+			configs: []kueuealpha.ImageSignatureConfig{{
+				ObjectMeta: metav1.ObjectMeta{Name: "config"},
+				Spec:       kueuealpha.ImageSignatureConfigSpec{PublicKeys: []string{publicKey, "not a key"}},
+			}},
+			wantCondition: &metav1.Condition{
+				Type:               kueue.AdmissionCheckActive,
+				Status:             metav1.ConditionFalse,
+				Reason:             "InvalidPublicKeys",
+				Message:            "the public key 1 isn't PEM encoded",
+				ObservedGeneration: 1,
+			},
+		},
+		"config found": {
+			configs: []kueuealpha.ImageSignatureConfig{{
+				ObjectMeta: metav1.ObjectMeta{Name: "config"},
+				Spec:       kueuealpha.ImageSignatureConfigSpec{PublicKeys: []string{publicKey}},
+			}},
+			wantCondition: &metav1.Condition{
+				Type:               kueue.AdmissionCheckActive,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Active",
+				Message:            "The admission check is active",
+				ObservedGeneration: 1,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sClient := utiltesting.NewClientBuilder().
+				WithObjects(check.DeepCopy()).
+				WithStatusSubresource(check).
+				WithLists(&kueuealpha.ImageSignatureConfigList{Items: tc.configs}).
+				Build()
+			helper, err := newImageSignatureConfigHelper(k8sClient)
+			if err != nil {
+				t.Fatalf("Creating the config helper: %v", err)
+			}
+			reconciler := acReconciler{client: k8sClient, helper: helper}
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: check.Name}}); err != nil {
+				t.Fatalf("Unexpected reconcile error: %v", err)
+			}
+			gotCheck := &kueue.AdmissionCheck{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: check.Name}, gotCheck); err != nil {
+				t.Fatalf("Getting the admission check: %v", err)
+			}
+			gotCondition := apimeta.FindStatusCondition(gotCheck.Status.Conditions, kueue.AdmissionCheckActive)
+			if diff := cmp.Diff(tc.wantCondition, gotCondition, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("Unexpected active condition (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
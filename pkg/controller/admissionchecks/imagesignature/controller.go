@@ -0,0 +1,341 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagesignature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
+	"sigs.k8s.io/kueue/pkg/util/api"
+	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+type imageSignatureConfigHelper = admissioncheck.ConfigHelper[*kueuealpha.ImageSignatureConfig, kueuealpha.ImageSignatureConfig]
+
+func newImageSignatureConfigHelper(c client.Client) (*imageSignatureConfigHelper, error) {
+	return admissioncheck.NewConfigHelper[*kueuealpha.ImageSignatureConfig](c)
+}
+
+type Controller struct {
+	client     client.Client
+	record     record.EventRecorder
+	helper     *imageSignatureConfigHelper
+	namespace  string
+	httpClient *http.Client
+}
+
+var _ reconcile.Reconciler = (*Controller)(nil)
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;watch;update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=admissionchecks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=imagesignatureconfigs,verbs=get;list;watch
+
+// NewController returns the controller verifying the signatures of the
+// images of the workloads. The Secrets with the credentials of the
+// registries are read from the namespace.
+func NewController(client client.Client, record record.EventRecorder, namespace string) (*Controller, error) {
+	helper, err := newImageSignatureConfigHelper(client)
+	if err != nil {
+		return nil, err
+	}
+	return &Controller{
+		client:     client,
+		record:     record,
+		helper:     helper,
+		namespace:  namespace,
+		httpClient: http.DefaultClient,
+	}, nil
+}
+
+// Reconcile verifies the images of the workloads with a quota reservation
+// for their pending image signature admission checks.
+func (c *Controller) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	wl := &kueue.Workload{}
+	log := ctrl.LoggerFrom(ctx)
+	if err := c.client.Get(ctx, req.NamespacedName, wl); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	if !workload.HasQuotaReservation(wl) || workload.IsFinished(wl) || workload.IsEvicted(wl) {
+		return reconcile.Result{}, nil
+	}
+
+	relevantChecks, err := admissioncheck.FilterForController(ctx, c.client, wl.Status.AdmissionChecks, kueuealpha.ImageSignatureControllerName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	wlPatch := workload.BaseSSAWorkload(wl)
+	recorderMessages := make([]string, 0, len(relevantChecks))
+	updated := false
+	var requeueAfter time.Duration
+	for _, check := range relevantChecks {
+		checkState := *workload.FindAdmissionCheck(wl.Status.AdmissionChecks, check)
+		if checkState.State != kueue.CheckStatePending {
+			continue
+		}
+		state, message, err := c.verifyCheck(ctx, wl, check)
+		if err != nil {
+			log.V(2).Error(err, "Verifying the images of the workload", "workload", klog.KObj(wl), "check", check)
+			message = fmt.Sprintf("Retrying after failure: %v", err)
+			requeueAfter = retryInterval
+		}
+		if checkState.State == state && checkState.Message == message {
+			continue
+		}
+		updated = true
+		if checkState.State != state {
+			recorderMessages = append(recorderMessages, fmt.Sprintf("Admission check %s updated state from %s to %s with message %s", check, checkState.State, state, message))
+		}
+		checkState.State = state
+		checkState.Message = message
+		workload.SetAdmissionCheckState(&wlPatch.Status.AdmissionChecks, checkState)
+	}
+	if updated {
+		if err := c.client.Status().Patch(ctx, wlPatch, client.Apply, client.FieldOwner(kueuealpha.ImageSignatureControllerName), client.ForceOwnership); err != nil {
+			return reconcile.Result{}, err
+		}
+		for _, message := range recorderMessages {
+			c.record.Event(wl, corev1.EventTypeNormal, "AdmissionCheckUpdated", api.TruncateEventMessage(message))
+		}
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// verifyCheck returns the state and the message of the check after
+// verifying the images of the workload, or an error if the verification
+// should be retried.
+func (c *Controller) verifyCheck(ctx context.Context, wl *kueue.Workload, check string) (kueue.CheckState, string, error) {
+	cfg, err := c.helper.ConfigForAdmissionCheck(ctx, check)
+	if client.IgnoreNotFound(err) != nil && !errors.Is(err, admissioncheck.ErrBadParametersRef) && !errors.Is(err, admissioncheck.ErrNilParametersRef) {
+		return kueue.CheckStatePending, "", err
+	}
+	if err != nil {
+		return kueue.CheckStatePending, CheckInactiveMessage, nil
+	}
+	keys, err := parsePublicKeys(cfg.Spec.PublicKeys)
+	if err != nil {
+		return kueue.CheckStatePending, CheckInactiveMessage, nil
+	}
+	creds, err := c.registryCredentials(ctx, cfg)
+	if err != nil {
+		return kueue.CheckStatePending, "", err
+	}
+	v := &verifier{
+		registry: newRegistryClient(c.httpClient, creds),
+		keys:     keys,
+	}
+	for _, image := range workloadImages(wl) {
+		if err := v.verify(ctx, image); err != nil {
+			if errors.Is(err, errVerificationFailed) {
+				return kueue.CheckStateRejected, err.Error(), nil
+			}
+			return kueue.CheckStatePending, "", err
+		}
+	}
+	return kueue.CheckStateReady, ImagesVerifiedMessage, nil
+}
+
+func (c *Controller) registryCredentials(ctx context.Context, cfg *kueuealpha.ImageSignatureConfig) (map[string]credentials, error) {
+	if cfg.Spec.CredentialsSecretName == "" {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	if err := c.client.Get(ctx, types.NamespacedName{Namespace: c.namespace, Name: cfg.Spec.CredentialsSecretName}, secret); err != nil {
+		return nil, fmt.Errorf("getting the credentials of the registries: %w", err)
+	}
+	data, found := secret.Data[corev1.DockerConfigJsonKey]
+	if !found {
+		return nil, fmt.Errorf("the Secret %s doesn't have the %s key", cfg.Spec.CredentialsSecretName, corev1.DockerConfigJsonKey)
+	}
+	creds, err := parseDockerConfigJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding the credentials of the registries: %w", err)
+	}
+	return creds, nil
+}
+
+// workloadImages returns the sorted images of the containers and of the
+// init containers of all the podSets of the workload.
+func workloadImages(wl *kueue.Workload) []string {
+	images := sets.New[string]()
+	for _, ps := range wl.Spec.PodSets {
+		for _, container := range ps.Template.Spec.InitContainers {
+			images.Insert(container.Image)
+		}
+		for _, container := range ps.Template.Spec.Containers {
+			images.Insert(container.Image)
+		}
+	}
+	ret := sets.List(images)
+	return slices.DeleteFunc(ret, func(image string) bool { return image == "" })
+}
+
+type acHandler struct {
+	client client.Client
+}
+
+var _ handler.EventHandler = (*acHandler)(nil)
+
+func (a *acHandler) Create(ctx context.Context, event event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	ac, isAc := event.Object.(*kueue.AdmissionCheck)
+	if !isAc || ac.Spec.ControllerName != kueuealpha.ImageSignatureControllerName {
+		return
+	}
+	if err := a.reconcileWorkloadsUsing(ctx, ac.Name, q); err != nil {
+		ctrl.LoggerFrom(ctx).V(5).Error(err, "Failure on create event", "admissionCheck", klog.KObj(ac))
+	}
+}
+
+func (a *acHandler) Update(ctx context.Context, event event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldAc, isOldAc := event.ObjectOld.(*kueue.AdmissionCheck)
+	newAc, isNewAc := event.ObjectNew.(*kueue.AdmissionCheck)
+	if !isNewAc || !isOldAc {
+		return
+	}
+	if oldAc.Spec.ControllerName == kueuealpha.ImageSignatureControllerName || newAc.Spec.ControllerName == kueuealpha.ImageSignatureControllerName {
+		if err := a.reconcileWorkloadsUsing(ctx, oldAc.Name, q); err != nil {
+			ctrl.LoggerFrom(ctx).V(5).Error(err, "Failure on update event", "admissionCheck", klog.KObj(oldAc))
+		}
+	}
+}
+
+func (a *acHandler) Delete(ctx context.Context, event event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	ac, isAc := event.Object.(*kueue.AdmissionCheck)
+	if !isAc || ac.Spec.ControllerName != kueuealpha.ImageSignatureControllerName {
+		return
+	}
+	if err := a.reconcileWorkloadsUsing(ctx, ac.Name, q); err != nil {
+		ctrl.LoggerFrom(ctx).V(5).Error(err, "Failure on delete event", "admissionCheck", klog.KObj(ac))
+	}
+}
+
+func (a *acHandler) Generic(_ context.Context, _ event.GenericEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// nothing to do for now
+}
+
+func (a *acHandler) reconcileWorkloadsUsing(ctx context.Context, check string, q workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	list := &kueue.WorkloadList{}
+	if err := a.client.List(ctx, list, client.MatchingFields{WorkloadsWithAdmissionCheckKey: check}); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	for i := range list.Items {
+		q.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+	}
+	return nil
+}
+
+type configHandler struct {
+	client            client.Client
+	acHandlerOverride func(ctx context.Context, config string, q workqueue.TypedRateLimitingInterface[reconcile.Request]) error
+}
+
+var _ handler.EventHandler = (*configHandler)(nil)
+
+func (h *configHandler) Create(ctx context.Context, event event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.handle(ctx, event.Object, q)
+}
+
+func (h *configHandler) Update(ctx context.Context, event event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.handle(ctx, event.ObjectNew, q)
+}
+
+func (h *configHandler) Delete(ctx context.Context, event event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.handle(ctx, event.Object, q)
+}
+
+func (h *configHandler) Generic(_ context.Context, _ event.GenericEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// nothing to do for now
+}
+
+func (h *configHandler) handle(ctx context.Context, obj client.Object, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	cfg, isConfig := obj.(*kueuealpha.ImageSignatureConfig)
+	if !isConfig {
+		return
+	}
+	if err := h.reconcileWorkloadsUsing(ctx, cfg.Name, q); err != nil {
+		ctrl.LoggerFrom(ctx).V(5).Error(err, "Failure on image signature config event", "imageSignatureConfig", klog.KObj(cfg))
+	}
+}
+
+func (h *configHandler) reconcileWorkloadsUsing(ctx context.Context, config string, q workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	list := &kueue.AdmissionCheckList{}
+	if err := h.client.List(ctx, list, client.MatchingFields{AdmissionCheckUsingConfigKey: config}); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	for _, user := range utilslices.Map(list.Items, func(ac *kueue.AdmissionCheck) string { return ac.Name }) {
+		if h.acHandlerOverride != nil {
+			if err := h.acHandlerOverride(ctx, user, q); err != nil {
+				return err
+			}
+		} else {
+			q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: user}})
+		}
+	}
+	return nil
+}
+
+func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	ach := &acHandler{
+		client: c.client,
+	}
+	ch := &configHandler{
+		client:            c.client,
+		acHandlerOverride: ach.reconcileWorkloadsUsing,
+	}
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named("image-signature-workload").
+		For(&kueue.Workload{}).
+		Watches(&kueue.AdmissionCheck{}, ach).
+		Watches(&kueuealpha.ImageSignatureConfig{}, ch).
+		Complete(c)
+	if err != nil {
+		return err
+	}
+
+	acReconciler := &acReconciler{
+		client: c.client,
+		helper: c.helper,
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("image-signature-admissioncheck").
+		For(&kueue.AdmissionCheck{}).
+		Watches(&kueuealpha.ImageSignatureConfig{}, &configHandler{client: c.client}).
+		Complete(acReconciler)
+}
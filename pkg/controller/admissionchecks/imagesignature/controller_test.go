@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagesignature
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+const (
+	testNamespace     = "ns"
+	kueueNamespace    = "kueue-system"
+	credentialsSecret = "registry-credentials"
+)
+
+func TestReconcile(t *testing.T) {
+	trustedKey, trustedPEM := newKey(t)
+	registry := newTestRegistry(t)
+	registry.sign(t, registry.pushImage("signed"), trustedKey)
+	registry.sign(t, registry.pushImage("signed-init"), trustedKey)
+	registry.pushImage("unsigned")
+	signedImage := registry.host() + "/team/app:signed"
+	unsignedImage := registry.host() + "/team/app:unsigned"
+
+	dockerConfig := fmt.Sprintf(`{"auths":{%q:{"auth":%q}}}`, registry.host(), base64.StdEncoding.EncodeToString([]byte(testUsername+":"+testPassword)))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: credentialsSecret, Namespace: kueueNamespace},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(dockerConfig)},
+	}
+	config := kueuealpha.ImageSignatureConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "config"},
+		Spec: kueuealpha.ImageSignatureConfigSpec{
+			PublicKeys:            []string{trustedPEM},
+			CredentialsSecretName: credentialsSecret,
+		},
+	}
+	check := *utiltesting.MakeAdmissionCheck("check").
+		ControllerName(kueuealpha.ImageSignatureControllerName).
+		Parameters(kueuealpha.GroupVersion.Group, ConfigKind, "config").
+		Obj()
+	otherCheck := *utiltesting.MakeAdmissionCheck("other-check").
+		ControllerName("other-controller").
+		Obj()
+	baseWorkload := utiltesting.MakeWorkload("wl", testNamespace).
+		ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+		AdmissionCheck(kueue.AdmissionCheckState{Name: "check", State: kueue.CheckStatePending}).
+		AdmissionCheck(kueue.AdmissionCheckState{Name: "other-check", State: kueue.CheckStatePending})
+
+	cases := map[string]struct {
+		workload         *kueue.Workload
+		configs          []kueuealpha.ImageSignatureConfig
+		secrets          []corev1.Secret
+		wantChecks       []kueue.AdmissionCheckState
+		wantRequeueAfter time.Duration
+	}{
+		"signed images": {
+			workload: baseWorkload.Clone().
+				PodSets(*utiltesting.MakePodSet("main", 1).
+					Image(signedImage).
+					InitContainers(corev1.Container{Name: "init", Image: registry.host() + "/team/app:signed-init"}).
+					Obj()).
+				Obj(),
+			configs: []kueuealpha.ImageSignatureConfig{config},
+			secrets: []corev1.Secret{*secret},
+			wantChecks: []kueue.AdmissionCheckState{
+				{Name: "check", State: kueue.CheckStateReady, Message: ImagesVerifiedMessage},
+				{Name: "other-check", State: kueue.CheckStatePending},
+			},
+		},
+		"unsigned image": {
+			workload: baseWorkload.Clone().
+				PodSets(
+					*utiltesting.MakePodSet("driver", 1).Image(signedImage).Obj(),
+					*utiltesting.MakePodSet("workers", 2).Image(unsignedImage).Obj(),
+				).
+				Obj(),
+			configs: []kueuealpha.ImageSignatureConfig{config},
+			secrets: []corev1.Secret{*secret},
+			wantChecks: []kueue.AdmissionCheckState{
+				{Name: "check", State: kueue.CheckStateRejected, Message: fmt.Sprintf("image signature verification failed: the image %q isn't signed", unsignedImage)},
+				{Name: "other-check", State: kueue.CheckStatePending},
+			},
+		},
+		"missing credentials": {
+			workload: baseWorkload.Clone().
+				PodSets(*utiltesting.MakePodSet("main", 1).Image(signedImage).Obj()).
+				Obj(),
+			configs: []kueuealpha.ImageSignatureConfig{config},
+			wantChecks: []kueue.AdmissionCheckState{
+				{Name: "check", State: kueue.CheckStatePending, Message: `Retrying after failure: getting the credentials of the registries: secrets "registry-credentials" not found`},
+				{Name: "other-check", State: kueue.CheckStatePending},
+			},
+			wantRequeueAfter: retryInterval,
+		},
+		"missing config": {
+			workload: baseWorkload.Clone().
+				PodSets(*utiltesting.MakePodSet("main", 1).Image(signedImage).Obj()).
+				Obj(),
+			wantChecks: []kueue.AdmissionCheckState{
+				{Name: "check", State: kueue.CheckStatePending, Message: CheckInactiveMessage},
+				{Name: "other-check", State: kueue.CheckStatePending},
+			},
+		},
+		"check not pending": {
+			workload: utiltesting.MakeWorkload("wl", testNamespace).
+				PodSets(*utiltesting.MakePodSet("main", 1).Image(unsignedImage).Obj()).
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				AdmissionCheck(kueue.AdmissionCheckState{Name: "check", State: kueue.CheckStateReady}).
+				Obj(),
+			configs: []kueuealpha.ImageSignatureConfig{config},
+			secrets: []corev1.Secret{*secret},
+			wantChecks: []kueue.AdmissionCheckState{
+				{Name: "check", State: kueue.CheckStateReady},
+			},
+		},
+		"no quota reservation": {
+			workload: utiltesting.MakeWorkload("wl", testNamespace).
+				PodSets(*utiltesting.MakePodSet("main", 1).Image(unsignedImage).Obj()).
+				AdmissionCheck(kueue.AdmissionCheckState{Name: "check", State: kueue.CheckStatePending}).
+				Obj(),
+			configs: []kueuealpha.ImageSignatureConfig{config},
+			secrets: []corev1.Secret{*secret},
+			wantChecks: []kueue.AdmissionCheckState{
+				{Name: "check", State: kueue.CheckStatePending},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			builder := utiltesting.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+				WithObjects(tc.workload).
+				WithStatusSubresource(tc.workload).
+				WithLists(
+					&kueue.AdmissionCheckList{Items: []kueue.AdmissionCheck{check, otherCheck}},
+					&kueuealpha.ImageSignatureConfigList{Items: tc.configs},
+					&corev1.SecretList{Items: tc.secrets},
+				)
+			_ = SetupIndexer(ctx, utiltesting.AsIndexer(builder))
+			k8sClient := builder.Build()
+			controller, err := NewController(k8sClient, &utiltesting.EventRecorder{}, kueueNamespace)
+			if err != nil {
+				t.Fatalf("Setting up the image signature controller: %v", err)
+			}
+			controller.httpClient = registry.server.Client()
+
+			result, err := controller.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: tc.workload.Name}})
+			if err != nil {
+				t.Fatalf("Unexpected reconcile error: %v", err)
+			}
+			if result.RequeueAfter != tc.wantRequeueAfter {
+				t.Errorf("Unexpected requeue after %v, want %v", result.RequeueAfter, tc.wantRequeueAfter)
+			}
+			gotWl := &kueue.Workload{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: tc.workload.Name}, gotWl); err != nil {
+				t.Fatalf("Getting the workload: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantChecks, gotWl.Status.AdmissionChecks, cmpopts.IgnoreFields(kueue.AdmissionCheckState{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("Unexpected admission checks (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagesignature
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
+	"sigs.k8s.io/kueue/pkg/util/slices"
+)
+
+const (
+	WorkloadsWithAdmissionCheckKey = "status.imageSignatureAdmissionChecks"
+	AdmissionCheckUsingConfigKey   = "spec.imageSignatureConfig"
+)
+
+var (
+	configGVK = kueuealpha.GroupVersion.WithKind(ConfigKind)
+)
+
+func indexWorkloadsChecks(obj client.Object) []string {
+	wl, isWl := obj.(*kueue.Workload)
+	if !isWl || len(wl.Status.AdmissionChecks) == 0 {
+		return nil
+	}
+	return slices.Map(wl.Status.AdmissionChecks, func(c *kueue.AdmissionCheckState) string { return c.Name })
+}
+
+func SetupIndexer(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &kueue.Workload{}, WorkloadsWithAdmissionCheckKey, indexWorkloadsChecks); err != nil {
+		return fmt.Errorf("setting index on workloads checks: %w", err)
+	}
+	if err := indexer.IndexField(ctx, &kueue.AdmissionCheck{}, AdmissionCheckUsingConfigKey, admissioncheck.IndexerByConfigFunction(kueuealpha.ImageSignatureControllerName, configGVK)); err != nil {
+		return fmt.Errorf("setting index on admission checks config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagesignature
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	dockerHubRegistry    = "docker.io"
+	dockerHubAPIRegistry = "registry-1.docker.io"
+	dockerHubAuthKey     = "https://index.docker.io/v1/"
+
+	// maxManifestSize bounds the size of the manifests and of the signature
+	// payloads read from the registries.
+	maxManifestSize = 4 << 20
+)
+
+var (
+	errNotFound = errors.New("not found in the registry")
+
+	manifestMediaTypes = []string{
+		"application/vnd.oci.image.manifest.v1+json",
+		"application/vnd.oci.image.index.v1+json",
+		"application/vnd.docker.distribution.manifest.v2+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+	}
+)
+
+// imageReference is a parsed container image reference.
+type imageReference struct {
+	registry   string
+	repository string
+	tag        string
+	digest     string
+}
+
+// parseImageReference parses an image reference, like the container runtimes
+// do: images without a registry are pulled from Docker Hub, and images
+// without a tag nor a digest use the latest tag.
+func parseImageReference(image string) (*imageReference, error) {
+	ref := &imageReference{}
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.digest = name[i+1:]
+		name = name[:i]
+		if !strings.HasPrefix(ref.digest, "sha256:") || len(ref.digest) != len("sha256:")+64 {
+			return nil, fmt.Errorf("invalid digest in the image %q", image)
+		}
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.tag = name[i+1:]
+		name = name[:i]
+	}
+	if i := strings.Index(name, "/"); i >= 0 && (strings.ContainsAny(name[:i], ".:") || name[:i] == "localhost") {
+		ref.registry = name[:i]
+		ref.repository = name[i+1:]
+	} else {
+		ref.registry = dockerHubRegistry
+		ref.repository = name
+		if !strings.Contains(name, "/") {
+			ref.repository = "library/" + name
+		}
+	}
+	if ref.repository == "" || ref.repository != strings.ToLower(ref.repository) {
+		return nil, fmt.Errorf("invalid repository in the image %q", image)
+	}
+	if ref.tag == "" && ref.digest == "" {
+		ref.tag = "latest"
+	}
+	return ref, nil
+}
+
+// apiHost returns the host serving the distribution API of the registry.
+func (r *imageReference) apiHost() string {
+	if r.registry == dockerHubRegistry {
+		return dockerHubAPIRegistry
+	}
+	return r.registry
+}
+
+// credentials are the credentials of a registry, as stored in the
+// kubernetes.io/dockerconfigjson Secrets.
+type credentials struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+func (c *credentials) basicAuth() (string, string, bool) {
+	if c.Username != "" {
+		return c.Username, c.Password, true
+	}
+	decoded, err := base64.StdEncoding.DecodeString(c.Auth)
+	if err != nil {
+		return "", "", false
+	}
+	return strings.Cut(string(decoded), ":")
+}
+
+// parseDockerConfigJSON returns the credentials, by registry, of a
+// kubernetes.io/dockerconfigjson Secret.
+func parseDockerConfigJSON(data []byte) (map[string]credentials, error) {
+	config := struct {
+		Auths map[string]credentials `json:"auths"`
+	}{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	auths := make(map[string]credentials, len(config.Auths))
+	for key, creds := range config.Auths {
+		host := key
+		if u, err := url.Parse(key); err == nil && u.Host != "" {
+			host = u.Host
+		}
+		if key == dockerHubAuthKey || host == "index.docker.io" {
+			host = dockerHubRegistry
+		}
+		auths[host] = creds
+	}
+	return auths, nil
+}
+
+// registryClient is a minimal client of the OCI distribution API, able to
+// pull the manifests and the blobs of the repositories.
+type registryClient struct {
+	httpClient  *http.Client
+	credentials map[string]credentials
+	// tokens are the bearer tokens obtained, by registry and repository.
+	tokens map[string]string
+}
+
+func newRegistryClient(httpClient *http.Client, creds map[string]credentials) *registryClient {
+	return &registryClient{
+		httpClient:  httpClient,
+		credentials: creds,
+		tokens:      make(map[string]string),
+	}
+}
+
+// resolveDigest returns the digest of the manifest of the image.
+func (c *registryClient) resolveDigest(ctx context.Context, ref *imageReference) (string, error) {
+	if ref.digest != "" {
+		return ref.digest, nil
+	}
+	resp, err := c.get(ctx, ref, http.MethodHead, "manifests/"+ref.tag, manifestMediaTypes)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+	// Some registries don't return the digest on HEAD requests.
+	manifest, err := c.manifest(ctx, ref, ref.tag)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(manifest)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// manifest returns the manifest of the repository with the tag or digest.
+func (c *registryClient) manifest(ctx context.Context, ref *imageReference, tagOrDigest string) ([]byte, error) {
+	resp, err := c.get(ctx, ref, http.MethodGet, "manifests/"+tagOrDigest, manifestMediaTypes)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+}
+
+// blob returns the blob of the repository with the digest, after checking
+// that its content matches the digest.
+func (c *registryClient) blob(ctx context.Context, ref *imageReference, digest string) ([]byte, error) {
+	resp, err := c.get(ctx, ref, http.MethodGet, "blobs/"+digest, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if got := "sha256:" + hex.EncodeToString(sum[:]); got != digest {
+		return nil, fmt.Errorf("the blob %s of %s/%s has the digest %s", digest, ref.registry, ref.repository, got)
+	}
+	return data, nil
+}
+
+// get sends a request to the distribution API of the repository, getting
+// a bearer token or using the basic authentication when requested by the
+// registry.
+func (c *registryClient) get(ctx context.Context, ref *imageReference, method, path string, accept []string) (*http.Response, error) {
+	endpoint := fmt.Sprintf("https://%s/v2/%s/%s", ref.apiHost(), ref.repository, path)
+	tokenKey := ref.registry + "/" + ref.repository
+	resp, err := c.do(ctx, method, endpoint, accept, c.tokens[tokenKey], nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		creds, hasCreds := c.credentials[ref.registry]
+		scheme, params := parseChallenge(challenge)
+		switch {
+		case scheme == "bearer":
+			token, err := c.token(ctx, params, &creds, hasCreds)
+			if err != nil {
+				return nil, err
+			}
+			c.tokens[tokenKey] = token
+			resp, err = c.do(ctx, method, endpoint, accept, token, nil)
+		case scheme == "basic" && hasCreds:
+			resp, err = c.do(ctx, method, endpoint, accept, "", &creds)
+		default:
+			return nil, fmt.Errorf("unauthorized access to %s/%s", ref.registry, ref.repository)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s/%s:%s %w", ref.registry, ref.repository, path, errNotFound)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %d getting %s from %s/%s", resp.StatusCode, path, ref.registry, ref.repository)
+	}
+	return resp, nil
+}
+
+func (c *registryClient) do(ctx context.Context, method, endpoint string, accept []string, token string, creds *credentials) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(accept) > 0 {
+		req.Header.Set("Accept", strings.Join(accept, ","))
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if creds != nil {
+		if username, password, ok := creds.basicAuth(); ok {
+			req.SetBasicAuth(username, password)
+		}
+	}
+	return c.httpClient.Do(req)
+}
+
+// token gets a bearer token from the authorization service of the
+// registry, as described by the WWW-Authenticate challenge.
+func (c *registryClient) token(ctx context.Context, params map[string]string, creds *credentials, hasCreds bool) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid realm %q in the authentication challenge", params["realm"])
+	}
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if v := params[key]; v != "" {
+			query.Set(key, v)
+		}
+	}
+	realm.RawQuery = query.Encode()
+	var basic *credentials
+	if hasCreds {
+		basic = creds
+	}
+	resp, err := c.do(ctx, http.MethodGet, realm.String(), nil, "", basic)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d getting a token from %s", resp.StatusCode, realm.Host)
+	}
+	body := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&body); err != nil {
+		return "", fmt.Errorf("decoding the token from %s: %w", realm.Host, err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// parseChallenge parses a WWW-Authenticate header, like
+// `Bearer realm="https://auth.example.com/token",service="registry"`.
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := make(map[string]string)
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key != "" {
+			params[strings.ToLower(strings.TrimSpace(key))] = value
+		}
+	}
+	return strings.ToLower(scheme), params
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagesignature
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// signatureAnnotation is the annotation of the layers of the cosign
+	// signature manifests holding the signature of the layer.
+	signatureAnnotation = "dev.cosignproject.cosign/signature"
+)
+
+var (
+	errVerificationFailed = errors.New("image signature verification failed")
+)
+
+// parsePublicKeys parses the PEM encoded ECDSA public keys.
+func parsePublicKeys(pems []string) ([]*ecdsa.PublicKey, error) {
+	keys := make([]*ecdsa.PublicKey, 0, len(pems))
+	for i, p := range pems {
+		block, _ := pem.Decode([]byte(p))
+		if block == nil {
+			return nil, fmt.Errorf("the public key %d isn't PEM encoded", i)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the public key %d: %w", i, err)
+		}
+		ecdsaKey, isECDSA := key.(*ecdsa.PublicKey)
+		if !isECDSA {
+			return nil, fmt.Errorf("the public key %d isn't an ECDSA key", i)
+		}
+		keys = append(keys, ecdsaKey)
+	}
+	return keys, nil
+}
+
+// verifier verifies the cosign signatures of the images.
+type verifier struct {
+	registry *registryClient
+	keys     []*ecdsa.PublicKey
+}
+
+type signatureManifest struct {
+	Layers []struct {
+		Digest      string            `json:"digest"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"layers"`
+}
+
+// simpleSigningPayload is the payload signed by cosign.
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// verify checks that the image has a cosign signature, stored in the
+// sha256-<digest>.sig tag of its repository, made by one of the keys for
+// the digest of the image.
+// The errors wrapping errVerificationFailed are final, the others can be
+// retried.
+func (v *verifier) verify(ctx context.Context, image string) error {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errVerificationFailed, err)
+	}
+	digest, err := v.registry.resolveDigest(ctx, ref)
+	if errors.Is(err, errNotFound) {
+		return fmt.Errorf("%w: the image %q doesn't exist", errVerificationFailed, image)
+	}
+	if err != nil {
+		return fmt.Errorf("resolving the digest of the image %q: %w", image, err)
+	}
+	data, err := v.registry.manifest(ctx, ref, strings.Replace(digest, ":", "-", 1)+".sig")
+	if errors.Is(err, errNotFound) {
+		return fmt.Errorf("%w: the image %q isn't signed", errVerificationFailed, image)
+	}
+	if err != nil {
+		return fmt.Errorf("getting the signatures of the image %q: %w", image, err)
+	}
+	manifest := signatureManifest{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("%w: decoding the signatures of the image %q: %w", errVerificationFailed, image, err)
+	}
+	for _, layer := range manifest.Layers {
+		signature, found := layer.Annotations[signatureAnnotation]
+		if !found {
+			continue
+		}
+		payload, err := v.registry.blob(ctx, ref, layer.Digest)
+		if err != nil {
+			return fmt.Errorf("getting the signature payload of the image %q: %w", image, err)
+		}
+		if v.verifySignature(payload, signature, digest) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: the image %q isn't signed by a trusted key", errVerificationFailed, image)
+}
+
+// verifySignature checks that the signature of the payload was made by one
+// of the keys, and that the payload is about the digest.
+func (v *verifier) verifySignature(payload []byte, signature string, digest string) bool {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	hash := sha256.Sum256(payload)
+	verified := false
+	for _, key := range v.keys {
+		if ecdsa.VerifyASN1(key, hash[:], sig) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return false
+	}
+	content := simpleSigningPayload{}
+	if err := json.Unmarshal(payload, &content); err != nil {
+		return false
+	}
+	return content.Critical.Image.DockerManifestDigest == digest
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagesignature
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testRegistry is a registry serving signed images, which requires a bearer
+// token obtained with the basic authentication.
+type testRegistry struct {
+	server    *httptest.Server
+	manifests map[string][]byte
+	blobs     map[string][]byte
+}
+
+const (
+	testUsername = "puller"
+	testPassword = "secret"
+	testToken    = "token"
+)
+
+func newTestRegistry(t *testing.T) *testRegistry {
+	t.Helper()
+	r := &testRegistry{
+		manifests: make(map[string][]byte),
+		blobs:     make(map[string][]byte),
+	}
+	r.server = httptest.NewTLSServer(http.HandlerFunc(r.serve))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *testRegistry) host() string {
+	return strings.TrimPrefix(r.server.URL, "https://")
+}
+
+func (r *testRegistry) serve(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		if user, password, ok := req.BasicAuth(); !ok || user != testUsername || password != testPassword {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": testToken})
+		return
+	}
+	if req.Header.Get("Authorization") != "Bearer "+testToken {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, r.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var content []byte
+	var found bool
+	if _, ref, isManifest := strings.Cut(req.URL.Path, "/manifests/"); isManifest {
+		content, found = r.manifests[ref]
+		if found {
+			sum := sha256.Sum256(content)
+			w.Header().Set("Docker-Content-Digest", "sha256:"+hex.EncodeToString(sum[:]))
+		}
+	} else if _, digest, isBlob := strings.Cut(req.URL.Path, "/blobs/"); isBlob {
+		content, found = r.blobs[digest]
+	}
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if req.Method == http.MethodGet {
+		_, _ = w.Write(content)
+	}
+}
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// pushImage adds an image with the tag to the registry and returns its
+// digest.
+func (r *testRegistry) pushImage(tag string) string {
+	manifest := []byte(fmt.Sprintf(`{"schemaVersion":2,"config":{"digest":"sha256:%x"}}`, sha256.Sum256([]byte(tag))))
+	digest := digestOf(manifest)
+	r.manifests[tag] = manifest
+	r.manifests[digest] = manifest
+	return digest
+}
+
+// sign adds a cosign signature of the digest, made by the key.
+func (r *testRegistry) sign(t *testing.T, digest string, key *ecdsa.PrivateKey) {
+	t.Helper()
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"%s/team/app"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, r.host(), digest))
+	hash := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	if err != nil {
+		t.Fatalf("Signing the payload: %v", err)
+	}
+	payloadDigest := digestOf(payload)
+	r.blobs[payloadDigest] = payload
+	manifest, err := json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"layers": []map[string]any{{
+			"mediaType":   "application/vnd.dev.cosign.simplesigning.v1+json",
+			"digest":      payloadDigest,
+			"annotations": map[string]string{signatureAnnotation: base64.StdEncoding.EncodeToString(sig)},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Encoding the signature manifest: %v", err)
+	}
+	r.manifests[strings.Replace(digest, ":", "-", 1)+".sig"] = manifest
+}
+
+func (r *testRegistry) credentials() map[string]credentials {
+	return map[string]credentials{
+		r.host(): {Auth: base64.StdEncoding.EncodeToString([]byte(testUsername + ":" + testPassword))},
+	}
+}
+
+func newKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Generating a key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("Encoding the public key: %v", err)
+	}
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestParseImageReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	cases := map[string]struct {
+		image   string
+		want    *imageReference
+		wantErr bool
+	}{
+		"docker hub official image": {
+			image: "busybox",
+			want:  &imageReference{registry: "docker.io", repository: "library/busybox", tag: "latest"},
+		},
+		"docker hub image with a tag": {
+			image: "team/app:v1",
+			want:  &imageReference{registry: "docker.io", repository: "team/app", tag: "v1"},
+		},
+		"registry with a port and a digest": {
+			image: "registry.example.com:5000/team/app:v1@" + digest,
+			want:  &imageReference{registry: "registry.example.com:5000", repository: "team/app", tag: "v1", digest: digest},
+		},
+		"localhost": {
+			image: "localhost/app",
+			want:  &imageReference{registry: "localhost", repository: "app", tag: "latest"},
+		},
+		"invalid digest": {
+			image:   "app@sha256:abc",
+			wantErr: true,
+		},
+		"uppercase repository": {
+			image:   "registry.example.com/Team/app",
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseImageReference(tc.image)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(imageReference{})); diff != "" {
+				t.Errorf("Unexpected reference (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	trustedKey, trustedPEM := newKey(t)
+	otherKey, _ := newKey(t)
+	registry := newTestRegistry(t)
+	signedDigest := registry.pushImage("signed")
+	registry.sign(t, signedDigest, trustedKey)
+	registry.pushImage("unsigned")
+	otherDigest := registry.pushImage("signed-by-other")
+	registry.sign(t, otherDigest, otherKey)
+	// The signature of an image copied to another tag doesn't verify
+	// another image.
+	copiedDigest := registry.pushImage("copied-signature")
+	registry.manifests[strings.Replace(copiedDigest, ":", "-", 1)+".sig"] = registry.manifests[strings.Replace(signedDigest, ":", "-", 1)+".sig"]
+
+	cases := map[string]struct {
+		image           string
+		credentials     map[string]credentials
+		wantFinalFailed bool
+	}{
+		"signed by a trusted key": {
+			image:       registry.host() + "/team/app:signed",
+			credentials: registry.credentials(),
+		},
+		"signed by a trusted key, by digest": {
+			image:       registry.host() + "/team/app@" + signedDigest,
+			credentials: registry.credentials(),
+		},
+		"unsigned": {
+			image:           registry.host() + "/team/app:unsigned",
+			credentials:     registry.credentials(),
+			wantFinalFailed: true,
+		},
+		"signed by another key": {
+			image:           registry.host() + "/team/app:signed-by-other",
+			credentials:     registry.credentials(),
+			wantFinalFailed: true,
+		},
+		"signature of another image": {
+			image:           registry.host() + "/team/app:copied-signature",
+			credentials:     registry.credentials(),
+			wantFinalFailed: true,
+		},
+		"missing image": {
+			image:           registry.host() + "/team/app:missing",
+			credentials:     registry.credentials(),
+			wantFinalFailed: true,
+		},
+		"missing credentials": {
+			image: registry.host() + "/team/app:signed",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			keys, err := parsePublicKeys([]string{trustedPEM})
+			if err != nil {
+				t.Fatalf("Parsing the public keys: %v", err)
+			}
+			v := &verifier{
+				registry: newRegistryClient(registry.server.Client(), tc.credentials),
+				keys:     keys,
+			}
+			err = v.verify(context.Background(), tc.image)
+			if gotFinal := errors.Is(err, errVerificationFailed); gotFinal != tc.wantFinalFailed {
+				t.Errorf("Unexpected final verification failure: %v", err)
+			}
+			if tc.credentials == nil && err == nil {
+				t.Errorf("Expected an error accessing the registry without credentials")
+			}
+			if tc.credentials != nil && !tc.wantFinalFailed && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
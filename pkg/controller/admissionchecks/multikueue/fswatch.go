@@ -32,6 +32,11 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 )
 
+// atomicWriterDataDir is the symlink swapped by the atomic writer of the
+// projected volumes, like the ones of the secrets or the CSI secrets store,
+// to update all the files of the volume at once.
+const atomicWriterDataDir = "..data"
+
 var (
 	errNotStarted = errors.New("not started")
 )
@@ -97,10 +102,18 @@ func (w *KubeConfigFSWatcher) Start(ctx context.Context) error {
 	return nil
 }
 
-func (w *KubeConfigFSWatcher) clustersForPath(path string) []string {
+func (w *KubeConfigFSWatcher) clustersForPath(filePath string) []string {
 	w.lock.RLock()
 	defer w.lock.RUnlock()
-	return w.fileToClusters[path].UnsortedList()
+	if path.Base(filePath) != atomicWriterDataDir {
+		return w.fileToClusters[filePath].UnsortedList()
+	}
+	// the files of the directory are symlinks to the data directory which was swapped
+	clusters := set.New[string]()
+	for file := range w.parentDirToFiles[path.Dir(filePath)] {
+		clusters = clusters.Union(w.fileToClusters[file])
+	}
+	return clusters.UnsortedList()
 }
 
 func (w *KubeConfigFSWatcher) notifyPathWrite(path string) {
@@ -129,6 +129,37 @@ func TestFSWatch(t *testing.T) {
 			},
 			wantEventsForClusters: set.New("c1"),
 		},
+		"atomic writer data swap": {
+			prepareFnc: func(basePath string) error {
+				if err := os.Mkdir(filepath.Join(basePath, "..v1"), 0777); err != nil {
+					return err
+				}
+				if err := os.WriteFile(filepath.Join(basePath, "..v1", "c1.kubeconfig"), []byte("123"), 0666); err != nil {
+					return err
+				}
+				if err := os.Symlink("..v1", filepath.Join(basePath, "..data")); err != nil {
+					return err
+				}
+				return os.Symlink(filepath.Join("..data", "c1.kubeconfig"), filepath.Join(basePath, "c1.kubeconfig"))
+			},
+			clusters: map[string]string{
+				"c1": "c1.kubeconfig",
+				"c2": "c1.kubeconfig",
+			},
+			opFnc: func(basePath string) error {
+				if err := os.Mkdir(filepath.Join(basePath, "..v2"), 0777); err != nil {
+					return err
+				}
+				if err := os.WriteFile(filepath.Join(basePath, "..v2", "c1.kubeconfig"), []byte("123456"), 0666); err != nil {
+					return err
+				}
+				if err := os.Symlink("..v2", filepath.Join(basePath, "..data_tmp")); err != nil {
+					return err
+				}
+				return os.Rename(filepath.Join(basePath, "..data_tmp"), filepath.Join(basePath, "..data"))
+			},
+			wantEventsForClusters: set.New("c1", "c2"),
+		},
 	}
 
 	for name, tc := range cases {
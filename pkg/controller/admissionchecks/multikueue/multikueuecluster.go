@@ -26,6 +26,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -36,8 +37,10 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -59,6 +62,13 @@ const (
 	// this set will provide waiting time between 0 to 5m20s
 	retryIncrement = 5 * time.Second
 	retryMaxSteps  = 7
+
+	// serviceAccountAuthInfo is the name of the user, in the kubeconfig,
+	// holding the bound token of the ServiceAccount.
+	serviceAccountAuthInfo = "kueue-service-account-token"
+	// defaultTokenExpirationSeconds is the requested validity of the bound
+	// tokens when the MultiKueueCluster doesn't set one.
+	defaultTokenExpirationSeconds = 3600
 )
 
 // retryAfter returns an exponentially increasing interval between
@@ -335,6 +345,8 @@ type clustersReconciler struct {
 	fsWatcher *KubeConfigFSWatcher
 
 	adapters map[string]jobframework.MultiKueueAdapter
+
+	clock clock.Clock
 }
 
 var _ manager.Runnable = (*clustersReconciler)(nil)
@@ -413,6 +425,19 @@ func (c *clustersReconciler) Reconcile(ctx context.Context, req reconcile.Reques
 		return reconcile.Result{}, c.updateStatus(ctx, cluster, false, "BadConfig", err.Error())
 	}
 
+	// the bound tokens are renewed before they expire
+	var renewAfter time.Duration
+	if tokenSource := cluster.Spec.KubeConfig.ServiceAccountToken; tokenSource != nil {
+		kubeConfig, renewAfter, err = c.withServiceAccountToken(ctx, kubeConfig, tokenSource)
+		if err != nil {
+			log.Error(err, "requesting the service account token")
+			if updateErr := c.updateStatus(ctx, cluster, false, "ServiceAccountTokenFailed", err.Error()); updateErr != nil {
+				return reconcile.Result{}, updateErr
+			}
+			return reconcile.Result{}, err
+		}
+	}
+
 	if retryAfter, err := c.setRemoteClientConfig(ctx, cluster.Name, kubeConfig, c.origin); err != nil {
 		log.Error(err, "setting kubeconfig", "retryAfter", retryAfter)
 		if err := c.updateStatus(ctx, cluster, false, "ClientConnectionFailed", err.Error()); err != nil {
@@ -421,18 +446,22 @@ func (c *clustersReconciler) Reconcile(ctx context.Context, req reconcile.Reques
 			return reconcile.Result{RequeueAfter: ptr.Deref(retryAfter, 0)}, nil
 		}
 	}
-	return reconcile.Result{}, c.updateStatus(ctx, cluster, true, "Active", "Connected")
+	return reconcile.Result{RequeueAfter: renewAfter}, c.updateStatus(ctx, cluster, true, "Active", "Connected")
 }
 
 func (c *clustersReconciler) getKubeConfig(ctx context.Context, ref *kueue.KubeConfig) ([]byte, bool, error) {
 	if ref.LocationType == kueue.SecretLocationType {
-		return c.getKubeConfigFromSecret(ctx, ref.Location)
+		secretKey := ref.SecretKey
+		if secretKey == "" {
+			secretKey = kueue.MultiKueueConfigSecretKey
+		}
+		return c.getKubeConfigFromSecret(ctx, ref.Location, secretKey)
 	}
 	// Otherwise it's path
 	return c.getKubeConfigFromPath(ref.Location)
 }
 
-func (c *clustersReconciler) getKubeConfigFromSecret(ctx context.Context, secretName string) ([]byte, bool, error) {
+func (c *clustersReconciler) getKubeConfigFromSecret(ctx context.Context, secretName, secretKey string) ([]byte, bool, error) {
 	sec := corev1.Secret{}
 	secretObjKey := types.NamespacedName{
 		Namespace: c.configNamespace,
@@ -443,9 +472,9 @@ func (c *clustersReconciler) getKubeConfigFromSecret(ctx context.Context, secret
 		return nil, !apierrors.IsNotFound(err), err
 	}
 
-	kconfigBytes, found := sec.Data[kueue.MultiKueueConfigSecretKey]
+	kconfigBytes, found := sec.Data[secretKey]
 	if !found {
-		return nil, false, fmt.Errorf("key %q not found in secret %q", kueue.MultiKueueConfigSecretKey, secretName)
+		return nil, false, fmt.Errorf("key %q not found in secret %q", secretKey, secretName)
 	}
 
 	return kconfigBytes, false, nil
@@ -456,6 +485,50 @@ func (c *clustersReconciler) getKubeConfigFromPath(path string) ([]byte, bool, e
 	return content, false, err
 }
 
+// withServiceAccountToken replaces the credentials of the current context of
+// the kubeconfig by a bound token of the ServiceAccount, and returns the time
+// after which the token should be renewed.
+func (c *clustersReconciler) withServiceAccountToken(ctx context.Context, kubeconfig []byte, source *kueue.ServiceAccountTokenSource) ([]byte, time.Duration, error) {
+	cfg, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, 0, fmt.Errorf("loading the kubeconfig: %w", err)
+	}
+	currentContext, found := cfg.Contexts[cfg.CurrentContext]
+	if !found {
+		return nil, 0, fmt.Errorf("the current context %q isn't defined in the kubeconfig", cfg.CurrentContext)
+	}
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: c.configNamespace,
+			Name:      source.Name,
+		},
+	}
+	tokenRequest := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         source.Audiences,
+			ExpirationSeconds: ptr.To(ptr.Deref(source.ExpirationSeconds, defaultTokenExpirationSeconds)),
+		},
+	}
+	if err := c.localClient.SubResource("token").Create(ctx, sa, tokenRequest); err != nil {
+		return nil, 0, fmt.Errorf("requesting a token for the ServiceAccount %q: %w", source.Name, err)
+	}
+
+	currentContext.AuthInfo = serviceAccountAuthInfo
+	if cfg.AuthInfos == nil {
+		cfg.AuthInfos = make(map[string]*clientcmdapi.AuthInfo, 1)
+	}
+	cfg.AuthInfos[serviceAccountAuthInfo] = &clientcmdapi.AuthInfo{Token: tokenRequest.Status.Token}
+	content, err := clientcmd.Write(*cfg)
+	if err != nil {
+		return nil, 0, fmt.Errorf("writing the kubeconfig: %w", err)
+	}
+
+	// renew the token after 80% of its validity
+	validity := tokenRequest.Status.ExpirationTimestamp.Sub(c.clock.Now())
+	return content, max(validity*4/5, 0), nil
+}
+
 func (c *clustersReconciler) updateStatus(ctx context.Context, cluster *kueue.MultiKueueCluster, active bool, reason, message string) error {
 	newCondition := metav1.Condition{
 		Type:               kueue.MultiKueueClusterActive,
@@ -506,6 +579,7 @@ func (c *clustersReconciler) getRemoteClients() []*remoteClient {
 }
 
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;watch;update
+// +kubebuilder:rbac:groups="",resources=serviceaccounts/token,verbs=create
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=multikueueclusters,verbs=get;list;watch
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=multikueueclusters/status,verbs=get;update;patch
 
@@ -520,6 +594,7 @@ func newClustersReconciler(c client.Client, namespace string, gcInterval time.Du
 		watchEndedCh:    make(chan event.GenericEvent, eventChBufferSize),
 		fsWatcher:       fsWatcher,
 		adapters:        adapters,
+		clock:           realClock,
 	}
 }
 
@@ -24,12 +24,16 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/clientcmd"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -46,6 +50,8 @@ import (
 var (
 	errInvalidConfig = errors.New("invalid kubeconfig")
 	errCannotWatch   = errors.New("client cannot watch")
+
+	errServiceAccountNotFound = errors.New(`serviceaccounts "multikueue" not found`)
 )
 
 func fakeClientBuilder(kubeconfig []byte, _ client.Options) (client.WithWatch, error) {
@@ -134,6 +140,38 @@ func TestUpdateConfig(t *testing.T) {
 				},
 			},
 		},
+		"new valid client with a custom secret key": {
+			reconcileFor: "worker1",
+			clusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					KubeConfig(kueue.SecretLocationType, "worker1").
+					KubeConfigSecretKey("worker1.yaml").
+					Generation(1).
+					Obj(),
+			},
+			secrets: []corev1.Secret{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "worker1", Namespace: TestNamespace},
+					Data: map[string][]byte{
+						kueue.MultiKueueConfigSecretKey: []byte("other kubeconfig"),
+						"worker1.yaml":                  []byte("worker1 kubeconfig"),
+					},
+				},
+			},
+			wantClusters: []kueue.MultiKueueCluster{
+				*utiltesting.MakeMultiKueueCluster("worker1").
+					KubeConfig(kueue.SecretLocationType, "worker1").
+					KubeConfigSecretKey("worker1.yaml").
+					Active(metav1.ConditionTrue, "Active", "Connected", 1).
+					Generation(1).
+					Obj(),
+			},
+			wantRemoteClients: map[string]*remoteClient{
+				"worker1": {
+					kubeconfig: []byte("worker1 kubeconfig"),
+				},
+			},
+		},
 		"update client with valid secret config": {
 			reconcileFor: "worker1",
 			clusters: []kueue.MultiKueueCluster{
@@ -426,6 +464,132 @@ func TestUpdateConfig(t *testing.T) {
 	}
 }
 
+func TestUpdateConfigWithServiceAccountToken(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- name: worker1
+  cluster:
+    server: https://worker1.example.com
+users:
+- name: admin
+  user:
+    token: static-token
+contexts:
+- name: worker1
+  context:
+    cluster: worker1
+    user: admin
+current-context: worker1
+`
+	cases := map[string]struct {
+		tokenErr error
+
+		wantErr          error
+		wantToken        string
+		wantRequeueAfter time.Duration
+		wantCluster      *kueue.MultiKueueCluster
+	}{
+		"token is injected and renewed before its expiration": {
+			wantToken:        "bound-token",
+			wantRequeueAfter: 48 * time.Minute,
+			wantCluster: utiltesting.MakeMultiKueueCluster("worker1").
+				KubeConfig(kueue.SecretLocationType, "worker1").
+				ServiceAccountToken("multikueue", "worker1").
+				Active(metav1.ConditionTrue, "Active", "Connected", 1).
+				Generation(1).
+				Obj(),
+		},
+		"token request fails": {
+			tokenErr: errServiceAccountNotFound,
+			wantErr:  errServiceAccountNotFound,
+			wantCluster: utiltesting.MakeMultiKueueCluster("worker1").
+				KubeConfig(kueue.SecretLocationType, "worker1").
+				ServiceAccountToken("multikueue", "worker1").
+				Active(metav1.ConditionFalse, "ServiceAccountTokenFailed", "requesting a token for the ServiceAccount \"multikueue\": serviceaccounts \"multikueue\" not found", 1).
+				Generation(1).
+				Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cluster := utiltesting.MakeMultiKueueCluster("worker1").
+				KubeConfig(kueue.SecretLocationType, "worker1").
+				ServiceAccountToken("multikueue", "worker1").
+				Generation(1).
+				Obj()
+			var gotRequest *authenticationv1.TokenRequest
+			builder, ctx := getClientBuilder()
+			builder = builder.WithObjects(cluster, ptr.To(makeTestSecret("worker1", kubeconfig)))
+			builder = builder.WithStatusSubresource(cluster)
+			builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+					if tc.tokenErr != nil {
+						return tc.tokenErr
+					}
+					tr := subResource.(*authenticationv1.TokenRequest)
+					gotRequest = tr.DeepCopy()
+					tr.Status.Token = "bound-token"
+					tr.Status.ExpirationTimestamp = metav1.NewTime(now.Add(time.Duration(*tr.Spec.ExpirationSeconds) * time.Second))
+					return nil
+				},
+			})
+			c := builder.Build()
+
+			adapters, _ := jobframework.GetMultiKueueAdapters(sets.New[string]("batch/job"))
+			reconciler := newClustersReconciler(c, TestNamespace, 0, defaultOrigin, nil, adapters)
+			//nolint:fatcontext
+			reconciler.rootContext = ctx
+			reconciler.builderOverride = fakeClientBuilder
+			reconciler.clock = testingclock.NewFakeClock(now)
+
+			res, gotErr := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "worker1"}})
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("unexpected reconcile error (-want/+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantRequeueAfter, res.RequeueAfter); diff != "" {
+				t.Errorf("unexpected requeue after (-want/+got):\n%s", diff)
+			}
+
+			gotCluster := &kueue.MultiKueueCluster{}
+			if err := c.Get(ctx, types.NamespacedName{Name: "worker1"}, gotCluster); err != nil {
+				t.Fatalf("unexpected get cluster error: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantCluster, gotCluster,
+				cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion"),
+				cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("unexpected cluster (-want/+got):\n%s", diff)
+			}
+
+			if tc.wantToken == "" {
+				return
+			}
+			wantRequest := &authenticationv1.TokenRequest{
+				Spec: authenticationv1.TokenRequestSpec{
+					Audiences:         []string{"worker1"},
+					ExpirationSeconds: ptr.To[int64](defaultTokenExpirationSeconds),
+				},
+			}
+			if diff := cmp.Diff(wantRequest, gotRequest); diff != "" {
+				t.Errorf("unexpected token request (-want/+got):\n%s", diff)
+			}
+			rc, found := reconciler.controllerFor("worker1")
+			if !found {
+				t.Fatal("the remote client wasn't created")
+			}
+			cfg, err := clientcmd.Load(rc.kubeconfig)
+			if err != nil {
+				t.Fatalf("unexpected kubeconfig error: %s", err)
+			}
+			gotAuthInfo := cfg.AuthInfos[cfg.Contexts[cfg.CurrentContext].AuthInfo]
+			if gotAuthInfo == nil || gotAuthInfo.Token != tc.wantToken {
+				t.Errorf("unexpected credentials of the current context: %v", gotAuthInfo)
+			}
+		})
+	}
+}
+
 func TestRemoteClientGC(t *testing.T) {
 	baseJobBuilder := testingjob.MakeJob("job1", TestNamespace)
 	baseWlBuilder := utiltesting.MakeWorkload("wl1", TestNamespace).ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job1", "test-uuid")
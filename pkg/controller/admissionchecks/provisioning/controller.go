@@ -550,7 +550,7 @@ func (c *Controller) syncCheckStates(
 						// We don't want to Retry on old ProvisioningRequests
 						updated = true
 						updateCheckState(&checkState, kueue.CheckStateRetry)
-						workload.UpdateRequeueState(wlPatch, backoffBaseSeconds, backoffMaxSeconds, c.clock)
+						workload.UpdateRequeueState(wlPatch, backoffBaseSeconds, backoffMaxSeconds, workload.RequeuingBackoffJitter, c.clock)
 					}
 				} else {
 					updated = true
@@ -576,7 +576,7 @@ func (c *Controller) syncCheckStates(
 						} else if wl.Status.RequeueState == nil || getAttempt(log, pr, wl.Name, check) > ptr.Deref(wl.Status.RequeueState.Count, 0) {
 							updated = true
 							updateCheckState(&checkState, kueue.CheckStateRetry)
-							workload.UpdateRequeueState(wlPatch, backoffBaseSeconds, backoffMaxSeconds, c.clock)
+							workload.UpdateRequeueState(wlPatch, backoffBaseSeconds, backoffMaxSeconds, workload.RequeuingBackoffJitter, c.clock)
 						}
 					} else {
 						updated = true
@@ -41,9 +41,115 @@ const (
 	// This label is always mutable because it might be useful for the preemption.
 	WorkloadPriorityClassLabel = "kueue.x-k8s.io/priority-class"
 
+	// WorkloadTemplateLabel is the label key of the job, copied to its workload,
+	// holding the name of the WorkloadTemplate which the workload is expected to match.
+	WorkloadTemplateLabel = "kueue.x-k8s.io/workload-template"
+
 	// ProvReqAnnotationPrefix is the prefix for annotations that should be pass to ProvisioningRequest as Parameters.
 	ProvReqAnnotationPrefix = "provreq.kueue.x-k8s.io/"
 
 	// MaxExecTimeSecondsLabel is the label key in the job that holds the maximum execution time.
 	MaxExecTimeSecondsLabel = `kueue.x-k8s.io/max-exec-time-seconds`
+
+	// ExpectedDurationAnnotation is the annotation key in the job, copied to its workload,
+	// holding the duration which the job is expected to run for once admitted.
+	ExpectedDurationAnnotation = "kueue.x-k8s.io/expected-duration"
+
+	// PodSetFlavorsAnnotation is the annotation key in the job, copied to its workload,
+	// holding the comma-separated <podSet>=<flavor> pairs pinning pod sets to flavors.
+	PodSetFlavorsAnnotation = "kueue.x-k8s.io/podset-flavors"
+
+	// ExcludedFlavorsAnnotation is the annotation key in the job, copied to its workload,
+	// holding the comma-separated names of the flavors which are never assigned to it.
+	ExcludedFlavorsAnnotation = "kueue.x-k8s.io/excluded-flavors"
+
+	// ProgressAnnotation is the annotation key set by Kueue in the workloads of the jobs
+	// reporting their progress, holding the <completed>/<total> counts of their pods.
+	ProgressAnnotation = "kueue.x-k8s.io/progress"
+
+	// IdleTimeoutAnnotation is the annotation key in a serving workload that holds the
+	// duration after which an idle workload is reclaimed.
+	IdleTimeoutAnnotation = "kueue.x-k8s.io/idle-timeout"
+
+	// IdleActionAnnotation is the annotation key in a serving workload that holds the
+	// action used to reclaim its quota once it is idle, ScaleDown or Deactivate.
+	IdleActionAnnotation = "kueue.x-k8s.io/idle-action"
+
+	// IdleCPUThresholdAnnotation is the annotation key in a serving workload that holds
+	// the CPU usage, summed over all its pods, below which the workload is idle.
+	IdleCPUThresholdAnnotation = "kueue.x-k8s.io/idle-cpu-threshold"
+
+	// IdleSinceAnnotation is the annotation key set by Kueue in a serving workload
+	// holding the time since when the workload is idle.
+	IdleSinceAnnotation = "kueue.x-k8s.io/idle-since"
+
+	// IdleReclaimedReplicasAnnotation is the annotation key set by Kueue in a serving
+	// workload holding its number of replicas before it was scaled down for being idle.
+	IdleReclaimedReplicasAnnotation = "kueue.x-k8s.io/idle-reclaimed-replicas"
+
+	// IdleRestoreAnnotation is the annotation key which, set in a serving workload whose
+	// quota was reclaimed for being idle, makes Kueue scale it back up to the replicas
+	// recorded in IdleReclaimedReplicasAnnotation, or activate its Workload again.
+	IdleRestoreAnnotation = "kueue.x-k8s.io/idle-restore"
+
+	// ReroutedFromAnnotation is the annotation key set by Kueue in a workload moved to
+	// the fallback queues of its LocalQueues after exceeding their pending timeout,
+	// holding the comma-separated names of the LocalQueues which it was moved out of.
+	ReroutedFromAnnotation = "kueue.x-k8s.io/rerouted-from"
+
+	// ResubmitAnnotation is the annotation key which, set in a finished workload,
+	// makes Kueue create a copy of its job, with the same queue settings. Kueue then
+	// removes it and sets the ResubmittedAsAnnotation in the workload.
+	ResubmitAnnotation = "kueue.x-k8s.io/resubmit"
+
+	// ResubmittedAsAnnotation is the annotation key set by Kueue in a workload whose
+	// job was resubmitted, holding the name of the copy of the job.
+	ResubmittedAsAnnotation = "kueue.x-k8s.io/resubmitted-as"
+
+	// ShadowModeLabel is the label key set by Kueue, with the value "true", in the
+	// workloads created while their ClusterQueue is in shadow mode. Kueue never stops
+	// the jobs of these workloads.
+	ShadowModeLabel = "kueue.x-k8s.io/shadow-mode"
+
+	// AdmitAllAnnotation is the annotation key which, set to "true" in a ClusterQueue,
+	// puts it in shadow mode and makes Kueue start the jobs of all its pending workloads
+	// without waiting for their admission, as a break-glass measure. Kueue sets it, and
+	// the ShadowModeLabel, in these workloads, which stay pending until they are admitted.
+	AdmitAllAnnotation = "kueue.x-k8s.io/admit-all"
+
+	// SubmitterUserAnnotation is the annotation key set by Kueue, in the jobs and
+	// workloads, holding the name of the user who submitted them.
+	SubmitterUserAnnotation = "kueue.x-k8s.io/submitter-user"
+
+	// SubmitterGroupsAnnotation is the annotation key set by Kueue, in the jobs and
+	// workloads, holding the comma-separated groups of the user who submitted them.
+	SubmitterGroupsAnnotation = "kueue.x-k8s.io/submitter-groups"
+
+	// SubmitterServiceAccountAnnotation is the annotation key set by Kueue, in the jobs
+	// and workloads submitted by a service account, holding the namespace and name of
+	// the service account.
+	SubmitterServiceAccountAnnotation = "kueue.x-k8s.io/submitter-service-account"
+
+	// SafeToEvictAnnotation is the annotation key of the cluster autoscaler which, set
+	// to "false" in a pod, prevents the scale down of its node.
+	SafeToEvictAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+
+	// ScaleDownProtectedLabel is the label key set by Kueue, with the value "true", in
+	// the pods of the admitted gang workloads which it protects from the scale down of
+	// their nodes by setting the SafeToEvictAnnotation.
+	ScaleDownProtectedLabel = "kueue.x-k8s.io/scale-down-protected"
+
+	// AdmissionSchedulingGate is the scheduling gate set by Kueue in the pods which
+	// are kept from being scheduled until their workloads are admitted.
+	AdmissionSchedulingGate = "kueue.x-k8s.io/admission"
+
+	// EvictionCategoryAnnotation is the annotation key set by Kueue in the events
+	// of the evictions of the workloads, holding the category of the eviction.
+	EvictionCategoryAnnotation = "kueue.x-k8s.io/eviction-category"
+
+	// ReplicaAdmissionChunkSizeAnnotation is the annotation key which, set in a
+	// Deployment to a positive number N, makes Kueue admit its replicas in groups of
+	// N pods, in the order of their creation, each group once the previous ones are
+	// admitted.
+	ReplicaAdmissionChunkSizeAnnotation = "kueue.x-k8s.io/replica-admission-chunk-size"
 )
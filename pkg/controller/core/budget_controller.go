@@ -0,0 +1,342 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// BudgetReconciler updates the consumption of the Budgets from the
+// UsageReports of their namespaces and enforces their limits. The Budgets
+// stopping the admission are synchronized in cache.Cache.
+type BudgetReconciler struct {
+	client   client.Client
+	log      logr.Logger
+	cache    *cache.Cache
+	qManager *queue.Manager
+	recorder record.EventRecorder
+	clock    clock.Clock
+}
+
+func NewBudgetReconciler(client client.Client, cache *cache.Cache, qManager *queue.Manager, recorder record.EventRecorder) *BudgetReconciler {
+	return &BudgetReconciler{
+		client:   client,
+		log:      ctrl.Log.WithName("budget-reconciler"),
+		cache:    cache,
+		qManager: qManager,
+		recorder: recorder,
+		clock:    realClock,
+	}
+}
+
+func (r *BudgetReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kueuealpha.Budget{}).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Watches(&kueuealpha.UsageReport{}, &budgetUsageReportHandler{client: r.client}).
+		WithEventFilter(r).
+		Complete(WithLeadingManager(mgr, r, &kueuealpha.Budget{}, cfg))
+}
+
+func (r *BudgetReconciler) Create(e event.CreateEvent) bool {
+	budget, match := e.Object.(*kueuealpha.Budget)
+	if !match {
+		return true
+	}
+	r.log.V(2).Info("Budget create event", "budget", klog.KObj(budget))
+	r.cache.AddOrUpdateBudget(budget)
+	return true
+}
+
+func (r *BudgetReconciler) Update(e event.UpdateEvent) bool {
+	oldBudget, oldIsBudget := e.ObjectOld.(*kueuealpha.Budget)
+	newBudget, newIsBudget := e.ObjectNew.(*kueuealpha.Budget)
+	if !oldIsBudget || !newIsBudget {
+		return true
+	}
+	log := r.log.WithValues("budget", klog.KObj(newBudget))
+	log.V(2).Info("Budget update event")
+	r.cache.AddOrUpdateBudget(newBudget)
+	if cache.StopsAdmission(oldBudget) && !cache.StopsAdmission(newBudget) {
+		r.qManager.QueueInadmissibleWorkloadsOfBudget(logr.NewContext(context.Background(), log), oldBudget)
+	}
+	return !equality.Semantic.DeepEqual(oldBudget.Spec, newBudget.Spec)
+}
+
+func (r *BudgetReconciler) Delete(e event.DeleteEvent) bool {
+	budget, match := e.Object.(*kueuealpha.Budget)
+	if !match {
+		return true
+	}
+	log := r.log.WithValues("budget", klog.KObj(budget))
+	log.V(2).Info("Budget delete event")
+	r.cache.DeleteBudget(budget.Namespace + "/" + budget.Name)
+	if cache.StopsAdmission(budget) {
+		r.qManager.QueueInadmissibleWorkloadsOfBudget(logr.NewContext(context.Background(), log), budget)
+	}
+	return false
+}
+
+func (r *BudgetReconciler) Generic(event.GenericEvent) bool {
+	return true
+}
+
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=budgets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=budgets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=usagereports,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;watch;update;patch
+
+func (r *BudgetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var budget kueuealpha.Budget
+	if err := r.client.Get(ctx, req.NamespacedName, &budget); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log := ctrl.LoggerFrom(ctx).WithValues("budget", klog.KObj(&budget))
+	ctx = ctrl.LoggerInto(ctx, log)
+	log.V(2).Info("Reconciling Budget")
+
+	now := r.clock.Now()
+	report, err := r.currentUsageReport(ctx, budget.Namespace, now)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	oldStatus := budget.Status.DeepCopy()
+	exceededLimits := updateBudgetConsumption(&budget, report)
+	wasExceeded := meta.IsStatusConditionTrue(budget.Status.Conditions, kueuealpha.BudgetExceeded)
+	cond := metav1.Condition{
+		Type:               kueuealpha.BudgetExceeded,
+		Status:             metav1.ConditionFalse,
+		Reason:             "WithinLimits",
+		Message:            "The consumption is within the limits of the Budget",
+		ObservedGeneration: budget.Generation,
+	}
+	if len(exceededLimits) > 0 {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "LimitsReached"
+		cond.Message = fmt.Sprintf("The consumption reached the limits of the Budget for %s", strings.Join(exceededLimits, ", "))
+	}
+	meta.SetStatusCondition(&budget.Status.Conditions, cond)
+	if !equality.Semantic.DeepEqual(oldStatus, &budget.Status) {
+		if err := r.client.Status().Update(ctx, &budget); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+	}
+
+	var result ctrl.Result
+	if report != nil {
+		// Recheck at the end of the period, in case no usage is reported
+		// in the next one.
+		result.RequeueAfter = report.Spec.PeriodEnd.Sub(now)
+	}
+	if len(exceededLimits) == 0 {
+		return result, nil
+	}
+	if !wasExceeded {
+		r.recorder.Event(&budget, corev1.EventTypeWarning, "BudgetExceeded", cond.Message)
+	}
+	if budget.Spec.EnforcementAction == kueuealpha.BudgetEvictLowestPriority {
+		return result, r.evictLowestPriority(ctx, &budget, cond.Message)
+	}
+	return result, nil
+}
+
+// currentUsageReport returns the UsageReport of the namespace covering now,
+// or nil if there is none.
+func (r *BudgetReconciler) currentUsageReport(ctx context.Context, namespace string, now time.Time) (*kueuealpha.UsageReport, error) {
+	var reports kueuealpha.UsageReportList
+	if err := r.client.List(ctx, &reports, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for i := range reports.Items {
+		report := &reports.Items[i]
+		if !now.Before(report.Spec.PeriodStart.Time) && now.Before(report.Spec.PeriodEnd.Time) {
+			return report, nil
+		}
+	}
+	return nil, nil
+}
+
+// updateBudgetConsumption sets the consumption of the LocalQueues bound to the
+// Budget, reported in the UsageReport, in the status of the Budget. It
+// returns the limits reached, sorted.
+func updateBudgetConsumption(budget *kueuealpha.Budget, report *kueuealpha.UsageReport) []string {
+	budget.Status.PeriodStart = nil
+	budget.Status.ResourceHours = nil
+	budget.Status.Cost = nil
+	consumed := corev1.ResourceList{}
+	var milliCost int64
+	if report != nil {
+		budget.Status.PeriodStart = ptr.To(report.Spec.PeriodStart)
+		for _, lq := range report.Status.LocalQueues {
+			if len(budget.Spec.LocalQueues) > 0 && !slices.Contains(budget.Spec.LocalQueues, lq.Name) {
+				continue
+			}
+			for _, fu := range lq.Flavors {
+				for _, ru := range fu.Resources {
+					total := consumed[ru.Name]
+					total.Add(ru.Hours)
+					consumed[ru.Name] = total
+					if price := budgetPrice(budget.Spec.Cost, fu.Name, ru.Name); price != nil {
+						milliCost += ru.Hours.MilliValue() * price.MilliValue() / 1000
+					}
+				}
+			}
+		}
+	}
+	if len(consumed) > 0 {
+		budget.Status.ResourceHours = consumed
+	}
+
+	var exceeded []string
+	for name, limit := range budget.Spec.ResourceHours {
+		if hours := consumed[name]; hours.Cmp(limit) >= 0 {
+			exceeded = append(exceeded, string(name))
+		}
+	}
+	slices.Sort(exceeded)
+	if budget.Spec.Cost != nil {
+		cost := resource.NewMilliQuantity(milliCost, resource.DecimalSI)
+		budget.Status.Cost = cost
+		if cost.Cmp(budget.Spec.Cost.Limit) >= 0 {
+			exceeded = append(exceeded, "cost")
+		}
+	}
+	return exceeded
+}
+
+// budgetPrice returns the price of the resource in the flavor, falling back to
+// the price of the resource without a flavor.
+func budgetPrice(cost *kueuealpha.BudgetCost, flavor kueue.ResourceFlavorReference, name corev1.ResourceName) *resource.Quantity {
+	if cost == nil {
+		return nil
+	}
+	var fallback *resource.Quantity
+	for i := range cost.Prices {
+		price := &cost.Prices[i]
+		if price.Resource != name {
+			continue
+		}
+		switch price.Flavor {
+		case flavor:
+			return &price.PricePerHour
+		case "":
+			fallback = &price.PricePerHour
+		}
+	}
+	return fallback
+}
+
+// evictLowestPriority evicts the admitted workload of the LocalQueues of the
+// Budget with the lowest priority, unless an evicted workload is still
+// releasing its quota.
+func (r *BudgetReconciler) evictLowestPriority(ctx context.Context, budget *kueuealpha.Budget, message string) error {
+	var wls kueue.WorkloadList
+	if err := r.client.List(ctx, &wls, client.InNamespace(budget.Namespace)); err != nil {
+		return err
+	}
+	var candidates []*workload.Info
+	for i := range wls.Items {
+		wl := &wls.Items[i]
+		if !workload.HasQuotaReservation(wl) || workload.IsFinished(wl) {
+			continue
+		}
+		if len(budget.Spec.LocalQueues) > 0 && !slices.Contains(budget.Spec.LocalQueues, wl.Spec.QueueName) {
+			continue
+		}
+		if meta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) {
+			// wait for the evicted workloads to release their quota.
+			return nil
+		}
+		candidates = append(candidates, workload.NewInfo(wl))
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Slice(candidates, quotaShrinkCandidatesOrdering(candidates, r.clock.Now()))
+	wl := candidates[0].Obj
+	message = fmt.Sprintf("The Budget %s is exceeded: %s", budget.Name, message)
+	workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByBudget, kueue.EvictionCategoryAdminStop, message)
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	ctrl.LoggerFrom(ctx).V(2).Info("Evicted workload due to the exceeded Budget", "workload", klog.KObj(wl))
+	workload.ReportEvictedWorkload(r.recorder, wl, string(wl.Status.Admission.ClusterQueue), kueue.WorkloadEvictedByBudget, message)
+	return nil
+}
+
+// budgetUsageReportHandler signals the controller to reconcile the Budgets of
+// the namespace of the UsageReport in the event.
+type budgetUsageReportHandler struct {
+	client client.Client
+}
+
+func (h *budgetUsageReportHandler) Create(ctx context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.queueBudgets(ctx, e.Object, q)
+}
+
+func (h *budgetUsageReportHandler) Update(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.queueBudgets(ctx, e.ObjectNew, q)
+}
+
+func (h *budgetUsageReportHandler) Delete(context.Context, event.DeleteEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *budgetUsageReportHandler) Generic(context.Context, event.GenericEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *budgetUsageReportHandler) queueBudgets(ctx context.Context, obj client.Object, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	var budgets kueuealpha.BudgetList
+	if err := h.client.List(ctx, &budgets, client.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list the Budgets of the namespace", "namespace", obj.GetNamespace())
+		return
+	}
+	for _, budget := range budgets.Items {
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: budget.Namespace, Name: budget.Name}})
+	}
+}
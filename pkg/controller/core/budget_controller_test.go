@@ -0,0 +1,242 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestBudgetReconcile(t *testing.T) {
+	periodStart := time.Date(2024, time.October, 14, 0, 0, 0, 0, time.UTC)
+	now := periodStart.Add(12 * time.Hour)
+	resourceHours := func(flavor kueue.ResourceFlavorReference, hours string) kueuealpha.FlavorUsageHours {
+		return kueuealpha.FlavorUsageHours{
+			Name:      flavor,
+			Resources: []kueuealpha.ResourceUsageHours{{Name: corev1.ResourceCPU, Hours: resource.MustParse(hours)}},
+		}
+	}
+	report := &kueuealpha.UsageReport{
+		ObjectMeta: metav1.ObjectMeta{Name: UsageReportName(periodStart), Namespace: "ns"},
+		Spec: kueuealpha.UsageReportSpec{
+			PeriodStart: metav1.NewTime(periodStart),
+			PeriodEnd:   metav1.NewTime(periodStart.Add(24 * time.Hour)),
+		},
+		Status: kueuealpha.UsageReportStatus{
+			LocalQueues: []kueuealpha.LocalQueueUsageHours{
+				{Name: "lq", ClusterQueue: "cq", Flavors: []kueuealpha.FlavorUsageHours{resourceHours("default", "4"), resourceHours("spot", "3")}},
+				{Name: "other", ClusterQueue: "cq", Flavors: []kueuealpha.FlavorUsageHours{resourceHours("default", "20")}},
+			},
+		},
+	}
+	admittedWorkload := func(name string, priority int32) *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload(name, "ns").
+			Queue("lq").
+			Priority(priority).
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+			Admitted(true)
+	}
+
+	cases := map[string]struct {
+		budget          *kueuealpha.Budget
+		noReport        bool
+		wantStatus      kueuealpha.BudgetStatus
+		wantStopsAdmit  bool
+		wantEvicted     map[string]string
+		wantRequeueTime time.Duration
+	}{
+		"within the limits": {
+			budget: utiltesting.MakeBudget("budget", "ns").
+				LocalQueues("lq").
+				ResourceHours(corev1.ResourceCPU, "10").
+				EnforcementAction(kueuealpha.BudgetStopAdmission).
+				Obj(),
+			wantStatus: kueuealpha.BudgetStatus{
+				PeriodStart:   ptr.To(metav1.NewTime(periodStart)),
+				ResourceHours: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("7")},
+				Conditions: []metav1.Condition{{
+					Type:    kueuealpha.BudgetExceeded,
+					Status:  metav1.ConditionFalse,
+					Reason:  "WithinLimits",
+					Message: "The consumption is within the limits of the Budget",
+				}},
+			},
+			wantEvicted:     map[string]string{"low": "", "high": ""},
+			wantRequeueTime: 12 * time.Hour,
+		},
+		"resource-hours reached, with all the LocalQueues of the namespace": {
+			budget: utiltesting.MakeBudget("budget", "ns").
+				ResourceHours(corev1.ResourceCPU, "27").
+				EnforcementAction(kueuealpha.BudgetStopAdmission).
+				Obj(),
+			wantStatus: kueuealpha.BudgetStatus{
+				PeriodStart:   ptr.To(metav1.NewTime(periodStart)),
+				ResourceHours: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("27")},
+				Conditions: []metav1.Condition{{
+					Type:    kueuealpha.BudgetExceeded,
+					Status:  metav1.ConditionTrue,
+					Reason:  "LimitsReached",
+					Message: "The consumption reached the limits of the Budget for cpu",
+				}},
+			},
+			wantStopsAdmit:  true,
+			wantEvicted:     map[string]string{"low": "", "high": ""},
+			wantRequeueTime: 12 * time.Hour,
+		},
+		"cost reached, only warning": {
+			budget: utiltesting.MakeBudget("budget", "ns").
+				LocalQueues("lq").
+				CostLimit("10").
+				Price("default", corev1.ResourceCPU, "2").
+				Price("", corev1.ResourceCPU, "1").
+				Obj(),
+			wantStatus: kueuealpha.BudgetStatus{
+				PeriodStart:   ptr.To(metav1.NewTime(periodStart)),
+				ResourceHours: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("7")},
+				Cost:          ptr.To(resource.MustParse("11")),
+				Conditions: []metav1.Condition{{
+					Type:    kueuealpha.BudgetExceeded,
+					Status:  metav1.ConditionTrue,
+					Reason:  "LimitsReached",
+					Message: "The consumption reached the limits of the Budget for cost",
+				}},
+			},
+			wantEvicted:     map[string]string{"low": "", "high": ""},
+			wantRequeueTime: 12 * time.Hour,
+		},
+		"evict the lowest priority workload": {
+			budget: utiltesting.MakeBudget("budget", "ns").
+				LocalQueues("lq").
+				ResourceHours(corev1.ResourceCPU, "5").
+				EnforcementAction(kueuealpha.BudgetEvictLowestPriority).
+				Obj(),
+			wantStatus: kueuealpha.BudgetStatus{
+				PeriodStart:   ptr.To(metav1.NewTime(periodStart)),
+				ResourceHours: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("7")},
+				Conditions: []metav1.Condition{{
+					Type:    kueuealpha.BudgetExceeded,
+					Status:  metav1.ConditionTrue,
+					Reason:  "LimitsReached",
+					Message: "The consumption reached the limits of the Budget for cpu",
+				}},
+			},
+			wantStopsAdmit:  true,
+			wantEvicted:     map[string]string{"low": kueue.WorkloadEvictedByBudget, "high": ""},
+			wantRequeueTime: 12 * time.Hour,
+		},
+		"no report for the current period": {
+			budget: utiltesting.MakeBudget("budget", "ns").
+				ResourceHours(corev1.ResourceCPU, "5").
+				EnforcementAction(kueuealpha.BudgetEvictLowestPriority).
+				Obj(),
+			noReport: true,
+			wantStatus: kueuealpha.BudgetStatus{
+				Conditions: []metav1.Condition{{
+					Type:    kueuealpha.BudgetExceeded,
+					Status:  metav1.ConditionFalse,
+					Reason:  "WithinLimits",
+					Message: "The consumption is within the limits of the Budget",
+				}},
+			},
+			wantEvicted: map[string]string{"low": "", "high": ""},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			workloads := []*kueue.Workload{
+				admittedWorkload("low", 1).Obj(),
+				admittedWorkload("high", 2).Obj(),
+			}
+			builder := utiltesting.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+				WithObjects(tc.budget).
+				WithStatusSubresource(tc.budget)
+			if !tc.noReport {
+				builder = builder.WithObjects(report.DeepCopy())
+			}
+			for _, wl := range workloads {
+				builder = builder.WithObjects(wl).WithStatusSubresource(wl)
+			}
+			cl := builder.Build()
+			cqCache := cache.New(cl)
+			reconciler := NewBudgetReconciler(cl, cqCache, queue.NewManager(cl, cqCache), record.NewFakeRecorder(10))
+			reconciler.clock = testingclock.NewFakeClock(now)
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.budget)})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.RequeueAfter != tc.wantRequeueTime {
+				t.Errorf("Unexpected requeue time, want=%v, got=%v", tc.wantRequeueTime, result.RequeueAfter)
+			}
+			var got kueuealpha.Budget
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(tc.budget), &got); err != nil {
+				t.Fatalf("Getting the Budget: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantStatus, got.Status, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("Unexpected status (-want,+got):\n%s", diff)
+			}
+
+			reconciler.Update(event.UpdateEvent{ObjectOld: tc.budget, ObjectNew: &got})
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Taking the snapshot: %v", err)
+			}
+			if stopsAdmit := snapshot.ExceededBudget("ns/lq") != nil; stopsAdmit != tc.wantStopsAdmit {
+				t.Errorf("Unexpected admission stop of the LocalQueue, want=%v, got=%v", tc.wantStopsAdmit, stopsAdmit)
+			}
+
+			gotEvicted := make(map[string]string, len(workloads))
+			for _, wl := range workloads {
+				gotWorkload := &kueue.Workload{}
+				if err := cl.Get(ctx, client.ObjectKeyFromObject(wl), gotWorkload); err != nil {
+					t.Fatalf("Getting the workload: %v", err)
+				}
+				gotEvicted[wl.Name] = ""
+				if cond := apimeta.FindStatusCondition(gotWorkload.Status.Conditions, kueue.WorkloadEvicted); cond != nil {
+					gotEvicted[wl.Name] = cond.Reason
+				}
+			}
+			if diff := cmp.Diff(tc.wantEvicted, gotEvicted); diff != "" {
+				t.Errorf("Unexpected eviction reasons (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
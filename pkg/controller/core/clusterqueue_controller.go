@@ -18,6 +18,10 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -29,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
@@ -48,6 +53,8 @@ import (
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/util/resource"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	"sigs.k8s.io/kueue/pkg/workload"
@@ -72,10 +79,16 @@ type ClusterQueueReconciler struct {
 	snapUpdateCh                         chan event.GenericEvent
 	watchers                             []ClusterQueueUpdateWatcher
 	reportResourceMetrics                bool
-	fairSharingEnabled                   bool
+	fairSharingEnabled                   atomic.Bool
 	queueVisibilityUpdateInterval        time.Duration
 	queueVisibilityClusterQueuesMaxCount int32
 	clock                                clock.Clock
+	recorder                             record.EventRecorder
+
+	// lastQuotaShrinkEvictions is the time of the last eviction
+	// due to the quotaShrinkPolicy, by ClusterQueue name.
+	lastQuotaShrinkEvictionsLock sync.Mutex
+	lastQuotaShrinkEvictions     map[string]time.Time
 }
 
 type ClusterQueueReconcilerOptions struct {
@@ -85,6 +98,7 @@ type ClusterQueueReconcilerOptions struct {
 	QueueVisibilityUpdateInterval        time.Duration
 	QueueVisibilityClusterQueuesMaxCount int32
 	clock                                clock.Clock
+	Recorder                             record.EventRecorder
 }
 
 // ClusterQueueReconcilerOption configures the reconciler.
@@ -124,6 +138,13 @@ func WithQueueVisibilityClusterQueuesMaxCount(value int32) ClusterQueueReconcile
 	}
 }
 
+// WithEventRecorder sets the recorder used to report the evictions triggered by the ClusterQueue.
+func WithEventRecorder(recorder record.EventRecorder) ClusterQueueReconcilerOption {
+	return func(o *ClusterQueueReconcilerOptions) {
+		o.Recorder = recorder
+	}
+}
+
 // func WithClock(_ testing.TB, c clock.Clock) ClusterQueueReconcilerOption {}
 func WithClock(_ testing.TB, c clock.Clock) ClusterQueueReconcilerOption {
 	return func(o *ClusterQueueReconcilerOptions) {
@@ -132,7 +153,8 @@ func WithClock(_ testing.TB, c clock.Clock) ClusterQueueReconcilerOption {
 }
 
 var defaultCQOptions = ClusterQueueReconcilerOptions{
-	clock: realClock,
+	clock:    realClock,
+	Recorder: &record.FakeRecorder{},
 }
 
 func NewClusterQueueReconciler(
@@ -145,7 +167,7 @@ func NewClusterQueueReconciler(
 	for _, opt := range opts {
 		opt(&options)
 	}
-	return &ClusterQueueReconciler{
+	r := &ClusterQueueReconciler{
 		client:                               client,
 		log:                                  ctrl.Log.WithName("cluster-queue-reconciler"),
 		qManager:                             qMgr,
@@ -157,11 +179,20 @@ func NewClusterQueueReconciler(
 		snapUpdateCh:                         make(chan event.GenericEvent, updateChBuffer),
 		watchers:                             options.Watchers,
 		reportResourceMetrics:                options.ReportResourceMetrics,
-		fairSharingEnabled:                   options.FairSharingEnabled,
 		queueVisibilityUpdateInterval:        options.QueueVisibilityUpdateInterval,
 		queueVisibilityClusterQueuesMaxCount: options.QueueVisibilityClusterQueuesMaxCount,
 		clock:                                options.clock,
+		recorder:                             options.Recorder,
+		lastQuotaShrinkEvictions:             make(map[string]time.Time),
 	}
+	r.fairSharingEnabled.Store(options.FairSharingEnabled)
+	return r
+}
+
+// SetFairSharing enables or disables the reporting of the fair sharing
+// status of the ClusterQueues, from their next status update.
+func (r *ClusterQueueReconciler) SetFairSharing(enabled bool) {
+	r.fairSharingEnabled.Store(enabled)
 }
 
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
@@ -212,7 +243,87 @@ func (r *ClusterQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	if err := r.updateCqStatusIfChanged(ctx, newCQObj, cqCondition, reason, msg); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
-	return ctrl.Result{}, nil
+	return r.reconcileQuotaShrink(ctx, &cqObj)
+}
+
+// reconcileQuotaShrink evicts the admitted workload with the lowest priority when the
+// ClusterQueue exceeds its quota and its quotaShrinkPolicy is EvictLowestPriority.
+// The workloads are evicted one at a time, at most once per evictionIntervalSeconds.
+func (r *ClusterQueueReconciler) reconcileQuotaShrink(ctx context.Context, cq *kueue.ClusterQueue) (ctrl.Result, error) {
+	policy := cq.Spec.QuotaShrinkPolicy
+	if policy == nil || policy.Action != kueue.QuotaShrinkEvictLowestPriority || !cq.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	exceeded, candidates := r.cache.ClusterQueueExceededQuota(cq.Name)
+	if len(exceeded) == 0 {
+		return ctrl.Result{}, nil
+	}
+	if slices.IndexFunc(candidates, func(wi *workload.Info) bool {
+		return meta.IsStatusConditionTrue(wi.Obj.Status.Conditions, kueue.WorkloadEvicted)
+	}) != -1 {
+		// wait for the evicted workloads to release their quota.
+		return ctrl.Result{}, nil
+	}
+	if len(candidates) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	interval := time.Duration(ptr.Deref(policy.EvictionIntervalSeconds, 60)) * time.Second
+	r.lastQuotaShrinkEvictionsLock.Lock()
+	defer r.lastQuotaShrinkEvictionsLock.Unlock()
+	if last, found := r.lastQuotaShrinkEvictions[cq.Name]; found {
+		if remaining := interval - r.clock.Since(last); remaining > 0 {
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	}
+
+	now := r.clock.Now()
+	sort.Slice(candidates, quotaShrinkCandidatesOrdering(candidates, now))
+	victim := candidates[0]
+
+	log := ctrl.LoggerFrom(ctx)
+	wl := &kueue.Workload{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(victim.Obj), wl); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	message := fmt.Sprintf("ClusterQueue %s exceeds its quota", cq.Name)
+	workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByQuotaShrink, kueue.EvictionCategoryAdminStop, message)
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log.V(2).Info("Evicted workload due to the quota shrink policy", "workload", klog.KObj(wl), "exceededQuota", exceeded)
+	workload.ReportEvictedWorkload(r.recorder, wl, cq.Name, kueue.WorkloadEvictedByQuotaShrink, message)
+	r.lastQuotaShrinkEvictions[cq.Name] = now
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// quotaShrinkCandidatesOrdering orders the workloads by increasing priority
+// and, for equal priorities, by decreasing quota reservation time.
+func quotaShrinkCandidatesOrdering(candidates []*workload.Info, now time.Time) func(int, int) bool {
+	return func(i, j int) bool {
+		a := candidates[i]
+		b := candidates[j]
+		pa := priority.Priority(a.Obj)
+		pb := priority.Priority(b.Obj)
+		if pa != pb {
+			return pa < pb
+		}
+		timeA := quotaReservationTime(a.Obj, now)
+		timeB := quotaReservationTime(b.Obj, now)
+		if !timeA.Equal(timeB) {
+			return timeA.After(timeB)
+		}
+		// Arbitrary comparison for deterministic sorting.
+		return a.Obj.UID < b.Obj.UID
+	}
+}
+
+func quotaReservationTime(wl *kueue.Workload, now time.Time) time.Time {
+	cond := meta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return now
+	}
+	return cond.LastTransitionTime.Time
 }
 
 func (r *ClusterQueueReconciler) NotifyWorkloadUpdate(oldWl, newWl *kueue.Workload) {
@@ -301,6 +412,9 @@ func (r *ClusterQueueReconciler) Delete(e event.DeleteEvent) bool {
 	r.qManager.DeleteSnapshot(cq)
 
 	metrics.ClearClusterQueueResourceMetrics(cq.Name)
+	if cq.Spec.Cohort != "" && !r.cache.CohortExists(cq.Spec.Cohort) {
+		metrics.ClearCohortMetrics(cq.Spec.Cohort)
+	}
 	r.log.V(2).Info("Cleared resource metrics for deleted ClusterQueue.", "clusterQueue", klog.KObj(cq))
 
 	return true
@@ -337,6 +451,9 @@ func (r *ClusterQueueReconciler) Update(e event.UpdateEvent) bool {
 	if r.reportResourceMetrics {
 		updateResourceMetrics(oldCq, newCq)
 	}
+	if oldCq.Spec.Cohort != newCq.Spec.Cohort && oldCq.Spec.Cohort != "" && !r.cache.CohortExists(oldCq.Spec.Cohort) {
+		metrics.ClearCohortMetrics(oldCq.Spec.Cohort)
+	}
 	return true
 }
 
@@ -355,7 +472,11 @@ func recordResourceMetrics(cq *kueue.ClusterQueue) {
 				nominal := resource.QuantityToFloat(&r.NominalQuota)
 				borrow := resource.QuantityToFloat(r.BorrowingLimit)
 				lend := resource.QuantityToFloat(r.LendingLimit)
-				metrics.ReportClusterQueueQuotas(cq.Spec.Cohort, cq.Name, string(fq.Name), string(r.Name), nominal, borrow, lend)
+				lendable := nominal
+				if features.Enabled(features.LendingLimit) && r.LendingLimit != nil {
+					lendable = min(nominal, lend)
+				}
+				metrics.ReportClusterQueueQuotas(cq.Spec.Cohort, cq.Name, string(fq.Name), string(r.Name), nominal, borrow, lend, lendable)
 			}
 		}
 	}
@@ -364,7 +485,7 @@ func recordResourceMetrics(cq *kueue.ClusterQueue) {
 		fr := &cq.Status.FlavorsReservation[fri]
 		for ri := range fr.Resources {
 			r := &fr.Resources[ri]
-			metrics.ReportClusterQueueResourceReservations(cq.Spec.Cohort, cq.Name, string(fr.Name), string(r.Name), resource.QuantityToFloat(&r.Total))
+			metrics.ReportClusterQueueResourceReservations(cq.Spec.Cohort, cq.Name, string(fr.Name), string(r.Name), resource.QuantityToFloat(&r.Total), resource.QuantityToFloat(&r.Borrowed))
 		}
 	}
 
@@ -377,6 +498,16 @@ func recordResourceMetrics(cq *kueue.ClusterQueue) {
 	}
 }
 
+func recordCohortResourceMetrics(cohorts []cache.CohortUsageStats) {
+	for _, cohort := range cohorts {
+		for fr, value := range cohort.SubtreeQuota {
+			quota := resources.ResourceQuantity(fr.Resource, value)
+			usage := resources.ResourceQuantity(fr.Resource, cohort.Usage[fr])
+			metrics.ReportCohortSubtreeResources(cohort.Name, string(fr.Flavor), string(fr.Resource), resource.QuantityToFloat(&quota), resource.QuantityToFloat(&usage))
+		}
+	}
+}
+
 func updateResourceMetrics(oldCq, newCq *kueue.ClusterQueue) {
 	// if the cohort changed, drop all the old metrics
 	if oldCq.Spec.Cohort != newCq.Spec.Cohort {
@@ -464,6 +595,7 @@ func clearOldResourceQuotas(oldCq, newCq *kueue.ClusterQueue) {
 // receive events.
 type cqWorkloadHandler struct {
 	qManager *queue.Manager
+	cache    *cache.Cache
 }
 
 func (h *cqWorkloadHandler) Create(context.Context, event.CreateEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
@@ -480,6 +612,13 @@ func (h *cqWorkloadHandler) Generic(_ context.Context, e event.GenericEvent, q w
 	req := h.requestForWorkloadClusterQueue(w)
 	if req != nil {
 		q.AddAfter(*req, constants.UpdatesBatchPeriod)
+		// The usage of the ClusterQueue changes the quota borrowed from, and
+		// lent to, the other ClusterQueues of its Cohort tree.
+		if features.Enabled(features.ClusterQueueBorrowingStatus) {
+			for _, cqName := range h.cache.ClusterQueuesInCohortTree(req.Name) {
+				q.AddAfter(reconcile.Request{NamespacedName: types.NamespacedName{Name: cqName}}, constants.UpdatesBatchPeriod)
+			}
+		}
 	}
 }
 
@@ -625,6 +764,7 @@ func (h *cqSnapshotHandler) Generic(_ context.Context, e event.GenericEvent, q w
 func (r *ClusterQueueReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
 	wHandler := cqWorkloadHandler{
 		qManager: r.qManager,
+		cache:    r.cache,
 	}
 	nsHandler := cqNamespaceHandler{
 		qManager: r.qManager,
@@ -683,7 +823,10 @@ func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
 		Message:            msg,
 		ObservedGeneration: cq.Generation,
 	})
-	if r.fairSharingEnabled {
+	if r.reportResourceMetrics {
+		recordCohortResourceMetrics(stats.Cohorts)
+	}
+	if r.fairSharingEnabled.Load() {
 		if r.reportResourceMetrics {
 			metrics.ReportClusterQueueWeightedShare(cq.Name, stats.WeightedShare)
 		}
@@ -694,6 +837,7 @@ func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
 	} else {
 		cq.Status.FairSharing = nil
 	}
+	cq.Status.Borrowing = stats.Borrowing
 	if !equality.Semantic.DeepEqual(cq.Status, oldStatus) {
 		return r.client.Status().Update(ctx, cq)
 	}
@@ -24,10 +24,14 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	testingclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
@@ -247,6 +251,60 @@ func TestUpdateCqStatusIfChanged(t *testing.T) {
 	}
 }
 
+func TestUpdateCqStatusBorrowing(t *testing.T) {
+	features.SetFeatureGateDuringTest(t, features.ClusterQueueBorrowingStatus, true)
+	makeCQ := func(name string) *kueue.ClusterQueue {
+		return utiltesting.MakeClusterQueue(name).
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "5").Obj()).
+			Obj()
+	}
+	borrower := makeCQ("borrower")
+	lender := makeCQ("lender")
+	wl := utiltesting.MakeWorkload("wl", "").
+		Request(corev1.ResourceCPU, "7").
+		ReserveQuota(utiltesting.MakeAdmission("borrower").Assignment(corev1.ResourceCPU, "default", "7").Obj()).
+		Obj()
+	ctx, log := utiltesting.ContextWithLog(t)
+	cl := utiltesting.NewClientBuilder().WithObjects(borrower, lender).WithStatusSubresource(borrower, lender).Build()
+	cqCache := cache.New(cl)
+	qManager := queue.NewManager(cl, cqCache)
+	for _, cq := range []*kueue.ClusterQueue{borrower, lender} {
+		if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Inserting clusterQueue in cache: %v", err)
+		}
+		if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Inserting clusterQueue in manager: %v", err)
+		}
+	}
+	cqCache.AddOrUpdateWorkload(wl)
+	if got, want := cqCache.ClusterQueuesInCohortTree("borrower"), []string{"lender"}; !cmp.Equal(got, want) {
+		t.Errorf("Unexpected ClusterQueues in the cohort tree, got %v, want %v", got, want)
+	}
+	r := &ClusterQueueReconciler{
+		client:   cl,
+		log:      log,
+		cache:    cqCache,
+		qManager: qManager,
+	}
+	wantBorrowing := map[string]*kueue.ClusterQueueBorrowingStatus{
+		"borrower": {
+			BorrowingFrom: []kueue.QuotaFlow{{ClusterQueue: "lender", Flavor: "default", Resource: corev1.ResourceCPU, Amount: resource.MustParse("2")}},
+		},
+		"lender": {
+			LendingTo: []kueue.QuotaFlow{{ClusterQueue: "borrower", Flavor: "default", Resource: corev1.ResourceCPU, Amount: resource.MustParse("2")}},
+		},
+	}
+	for _, cq := range []*kueue.ClusterQueue{borrower, lender} {
+		if err := r.updateCqStatusIfChanged(ctx, cq, metav1.ConditionTrue, "Ready", "Can admit new workloads"); err != nil {
+			t.Fatalf("Updating the status of the ClusterQueue %s: %v", cq.Name, err)
+		}
+		if diff := cmp.Diff(wantBorrowing[cq.Name], cq.Status.Borrowing); diff != "" {
+			t.Errorf("Unexpected borrowing status of the ClusterQueue %s (-want,+got):\n%s", cq.Name, diff)
+		}
+	}
+}
+
 type cqMetrics struct {
 	NominalDPs   []testingmetrics.MetricDataPoint
 	BorrowingDPs []testingmetrics.MetricDataPoint
@@ -603,3 +661,124 @@ func TestClusterQueuePendingWorkloadsStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileQuotaShrink(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cqWithQuota := func(cpu string) *kueue.ClusterQueue {
+		return utiltesting.MakeClusterQueue("cq").
+			QuotaShrinkPolicy(kueue.QuotaShrinkEvictLowestPriority, ptr.To[int32](30)).
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, cpu).Obj()).
+			Obj()
+	}
+	admittedWorkload := func(name string, priority int32, reservedAt time.Time) *kueue.Workload {
+		return utiltesting.MakeWorkload(name, "ns").
+			Priority(priority).
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj(), reservedAt).
+			Obj()
+	}
+
+	cases := map[string]struct {
+		clusterQueue *kueue.ClusterQueue
+		workloads    []*kueue.Workload
+		lastEviction *time.Time
+		wantEvicted  []string
+		wantRequeue  time.Duration
+	}{
+		"within quota": {
+			clusterQueue: cqWithQuota("10"),
+			workloads: []*kueue.Workload{
+				admittedWorkload("a", 0, now),
+				admittedWorkload("b", 10, now),
+			},
+		},
+		"quota exceeded, evict the lowest priority workload": {
+			clusterQueue: cqWithQuota("2"),
+			workloads: []*kueue.Workload{
+				admittedWorkload("a", 10, now),
+				admittedWorkload("b", 0, now.Add(-time.Minute)),
+				admittedWorkload("c", 0, now.Add(-2*time.Minute)),
+			},
+			wantEvicted: []string{"b"},
+			wantRequeue: 30 * time.Second,
+		},
+		"quota exceeded, eviction interval not elapsed": {
+			clusterQueue: cqWithQuota("2"),
+			workloads: []*kueue.Workload{
+				admittedWorkload("a", 10, now),
+				admittedWorkload("b", 0, now),
+			},
+			lastEviction: ptr.To(now.Add(-10 * time.Second)),
+			wantRequeue:  20 * time.Second,
+		},
+		"quota exceeded, waiting for an evicted workload to release its quota": {
+			clusterQueue: cqWithQuota("2"),
+			workloads: []*kueue.Workload{
+				admittedWorkload("a", 10, now),
+				utiltesting.MakeWorkload("b", "ns").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj(), now).
+					Condition(metav1.Condition{
+						Type:   kueue.WorkloadEvicted,
+						Status: metav1.ConditionTrue,
+						Reason: kueue.WorkloadEvictedByQuotaShrink,
+					}).
+					Obj(),
+			},
+			wantEvicted: []string{"b"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			objs := []client.Object{tc.clusterQueue}
+			for _, wl := range tc.workloads {
+				objs = append(objs, wl)
+			}
+			cl := utiltesting.NewClientBuilder().
+				WithObjects(objs...).
+				WithStatusSubresource(objs...).
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+				Build()
+			cCache := cache.New(cl)
+			cCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cCache.AddClusterQueue(ctx, cqWithQuota("10")); err != nil {
+				t.Fatalf("Inserting clusterQueue in cache: %v", err)
+			}
+			for _, wl := range tc.workloads {
+				cCache.AddOrUpdateWorkload(wl)
+			}
+			if err := cCache.UpdateClusterQueue(tc.clusterQueue); err != nil {
+				t.Fatalf("Updating clusterQueue in cache: %v", err)
+			}
+			fakeClock := testingclock.NewFakeClock(now)
+			r := NewClusterQueueReconciler(cl, queue.NewManager(cl, cCache), cCache, WithClock(t, fakeClock))
+			if tc.lastEviction != nil {
+				r.lastQuotaShrinkEvictions[tc.clusterQueue.Name] = *tc.lastEviction
+			}
+
+			gotResult, err := r.reconcileQuotaShrink(ctx, tc.clusterQueue)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantRequeue, gotResult.RequeueAfter); diff != "" {
+				t.Errorf("Unexpected requeue after (-want,+got):\n%s", diff)
+			}
+
+			var wls kueue.WorkloadList
+			if err := cl.List(ctx, &wls); err != nil {
+				t.Fatalf("Listing workloads: %v", err)
+			}
+			var gotEvicted []string
+			for _, wl := range wls.Items {
+				if apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) {
+					gotEvicted = append(gotEvicted, wl.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.wantEvicted, gotEvicted); diff != "" {
+				t.Errorf("Unexpected evicted workloads (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
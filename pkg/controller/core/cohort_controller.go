@@ -32,6 +32,7 @@ import (
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
 )
 
@@ -99,6 +100,9 @@ func (r *CohortReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			log.V(2).Info("Cohort is being deleted")
 			r.cache.DeleteCohort(req.NamespacedName.Name)
 			r.qManager.DeleteCohort(req.NamespacedName.Name)
+			if !r.cache.CohortExists(req.NamespacedName.Name) {
+				metrics.ClearCohortMetrics(req.NamespacedName.Name)
+			}
 		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
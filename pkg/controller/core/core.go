@@ -19,21 +19,55 @@ package core
 import (
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/config"
 	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/notifications"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 const (
 	updateChBuffer = 10
 )
 
+type setupOptions struct {
+	configWatcher *config.Watcher
+	wlWatchers    []WorkloadUpdateWatcher
+}
+
+// SetupOption configures the setup of the core controllers.
+type SetupOption func(*setupOptions)
+
+// WithConfigWatcher registers the controllers which apply the reloadable
+// fields of the configuration in the watcher.
+func WithConfigWatcher(w *config.Watcher) SetupOption {
+	return func(o *setupOptions) {
+		o.configWatcher = w
+	}
+}
+
+// WithWorkloadUpdateWatcher adds a watcher of the updates of the workloads,
+// like the archive of the finished workloads.
+func WithWorkloadUpdateWatcher(w WorkloadUpdateWatcher) SetupOption {
+	return func(o *setupOptions) {
+		o.wlWatchers = append(o.wlWatchers, w)
+	}
+}
+
 // SetupControllers sets up the core controllers. It returns the name of the
 // controller that failed to create and an error, if any.
-func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache, cfg *configapi.Configuration) (string, error) {
+func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache, cfg *configapi.Configuration, opts ...SetupOption) (string, error) {
+	var options setupOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 	rfRec := NewResourceFlavorReconciler(mgr.GetClient(), qManager, cc)
 	if err := rfRec.SetupWithManager(mgr, cfg); err != nil {
 		return "ResourceFlavor", err
@@ -47,6 +81,19 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 		return "LocalQueue", err
 	}
 
+	var notifier *notifications.Notifier
+	cqWatchers := []ClusterQueueUpdateWatcher{rfRec, acRec}
+	if cfg.Notifications != nil {
+		var err error
+		if notifier, err = notifications.New(cfg.Notifications); err != nil {
+			return "Notifier", err
+		}
+		if err := mgr.Add(notifier); err != nil {
+			return "Unable to add Notifier to manager", err
+		}
+		cqWatchers = append(cqWatchers, notifier)
+	}
+
 	var fairSharingEnabled bool
 	if cfg.FairSharing != nil {
 		fairSharingEnabled = cfg.FairSharing.Enable
@@ -60,7 +107,8 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 		WithReportResourceMetrics(cfg.Metrics.EnableClusterQueueResources),
 		WithQueueVisibilityClusterQueuesMaxCount(queueVisibilityClusterQueuesMaxCount(cfg)),
 		WithFairSharing(fairSharingEnabled),
-		WithWatchers(rfRec, acRec),
+		WithWatchers(cqWatchers...),
+		WithEventRecorder(mgr.GetEventRecorderFor(constants.ClusterQueueControllerName)),
 	)
 	if err := mgr.Add(cqRec); err != nil {
 		return "Unable to add ClusterQueue to manager", err
@@ -76,13 +124,92 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 		return "Cohort", err
 	}
 
-	if err := NewWorkloadReconciler(mgr.GetClient(), qManager, cc,
+	var tenantRec *TenantReconciler
+	if features.Enabled(features.Tenants) {
+		tenantRec = NewTenantReconciler(mgr.GetClient(), cc, qManager)
+		if err := tenantRec.SetupWithManager(mgr, cfg); err != nil {
+			return "Tenant", err
+		}
+	}
+
+	scheduleRec := NewScheduleReconciler(mgr.GetClient(), cc, qManager)
+	if err := scheduleRec.SetupWithManager(mgr, cfg); err != nil {
+		return "Schedule", err
+	}
+
+	if err := NewWorkloadTemplateReconciler(cc).SetupWithManager(mgr); err != nil {
+		return "WorkloadTemplate", err
+	}
+
+	wlWatchers := []WorkloadUpdateWatcher{qRec, cqRec}
+	if tenantRec != nil {
+		wlWatchers = append(wlWatchers, tenantRec)
+	}
+	if notifier != nil {
+		wlWatchers = append(wlWatchers, notifier)
+	}
+	wlWatchers = append(wlWatchers, options.wlWatchers...)
+	wlRec := NewWorkloadReconciler(mgr.GetClient(), qManager, cc,
 		mgr.GetEventRecorderFor(constants.WorkloadControllerName),
-		WithWorkloadUpdateWatchers(qRec, cqRec),
+		WithWorkloadUpdateWatchers(wlWatchers...),
 		WithWaitForPodsReady(waitForPodsReady(cfg.WaitForPodsReady)),
-	).SetupWithManager(mgr, cfg); err != nil {
+		WithAutoReactivation(autoReactivation(cfg.AutoReactivation)),
+	)
+	if err := wlRec.SetupWithManager(mgr, cfg); err != nil {
 		return "Workload", err
 	}
+
+	if features.Enabled(features.ScaleDownProtection) {
+		if err := NewScaleDownProtectionReconciler(mgr.GetClient()).SetupWithManager(mgr, cfg); err != nil {
+			return "ScaleDownProtection", err
+		}
+	}
+
+	if features.Enabled(features.WorkloadResubmit) {
+		resubmitRec := NewResubmitReconciler(mgr.GetClient(), mgr.GetEventRecorderFor(constants.WorkloadControllerName))
+		if err := resubmitRec.SetupWithManager(mgr, cfg); err != nil {
+			return "WorkloadResubmit", err
+		}
+	}
+
+	if features.Enabled(features.NodeInterruptionRequeue) {
+		niRec := NewNodeInterruptionReconciler(mgr.GetClient(), mgr.GetEventRecorderFor(constants.WorkloadControllerName), cfg.NodeInterruption)
+		if err := niRec.SetupWithManager(mgr, cfg); err != nil {
+			return "NodeInterruption", err
+		}
+	}
+
+	if cfg.DeviceHealth != nil {
+		dhRec := NewDeviceHealthReconciler(mgr.GetClient(), cc, qManager, cfg.DeviceHealth)
+		if err := dhRec.SetupWithManager(mgr); err != nil {
+			return "DeviceHealth", err
+		}
+	}
+
+	if options.configWatcher != nil {
+		if err := options.configWatcher.Register("ClusterQueue", func(cfg *configapi.Configuration) error {
+			cqRec.SetFairSharing(cfg.FairSharing != nil && cfg.FairSharing.Enable)
+			return nil
+		}); err != nil {
+			return "ClusterQueue", err
+		}
+		if err := options.configWatcher.Register("Workload", func(cfg *configapi.Configuration) error {
+			wlRec.SetWaitForPodsReady(waitForPodsReady(cfg.WaitForPodsReady))
+			return nil
+		}); err != nil {
+			return "Workload", err
+		}
+	}
+
+	if cfg.UsageReports != nil {
+		if err := mgr.Add(NewUsageReporter(mgr.GetClient(), cc, cfg.UsageReports)); err != nil {
+			return "Unable to add UsageReporter to manager", err
+		}
+		budgetRec := NewBudgetReconciler(mgr.GetClient(), cc, qManager, mgr.GetEventRecorderFor(constants.WorkloadControllerName))
+		if err := budgetRec.SetupWithManager(mgr, cfg); err != nil {
+			return "Budget", err
+		}
+	}
 	return "", nil
 }
 
@@ -97,7 +224,23 @@ func waitForPodsReady(cfg *configapi.WaitForPodsReady) *waitForPodsReadyConfig {
 		result.requeuingBackoffBaseSeconds = *cfg.RequeuingStrategy.BackoffBaseSeconds
 		result.requeuingBackoffLimitCount = cfg.RequeuingStrategy.BackoffLimitCount
 		result.requeuingBackoffMaxDuration = time.Duration(*cfg.RequeuingStrategy.BackoffMaxSeconds) * time.Second
-		result.requeuingBackoffJitter = 0.0001
+		result.requeuingBackoffJitter = workload.RequeuingBackoffJitter
+	}
+	return &result
+}
+
+func autoReactivation(cfg *configapi.AutoReactivation) *autoReactivationConfig {
+	if cfg == nil {
+		return nil
+	}
+	result := autoReactivationConfig{
+		reasonCategories:   sets.New[kueue.DeactivationReasonCategory](),
+		backoffLimitCount:  cfg.BackoffLimitCount,
+		backoffBaseSeconds: *cfg.BackoffBaseSeconds,
+		backoffMaxDuration: time.Duration(*cfg.BackoffMaxSeconds) * time.Second,
+	}
+	for _, category := range cfg.ReasonCategories {
+		result.reasonCategories.Insert(kueue.DeactivationReasonCategory(category))
 	}
 	return &result
 }
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+)
+
+// DeviceHealthReconciler synchronizes the unhealthy devices of the nodes in
+// cache.Cache, where they reduce the capacity of the ResourceFlavors
+// matching the nodes.
+type DeviceHealthReconciler struct {
+	client              client.Client
+	cache               *cache.Cache
+	qManager            *queue.Manager
+	resourceNames       []corev1.ResourceName
+	unhealthyConditions sets.Set[corev1.NodeConditionType]
+}
+
+func NewDeviceHealthReconciler(client client.Client, cache *cache.Cache, qManager *queue.Manager, cfg *config.DeviceHealth) *DeviceHealthReconciler {
+	return &DeviceHealthReconciler{
+		client:              client,
+		cache:               cache,
+		qManager:            qManager,
+		resourceNames:       cfg.ResourceNames,
+		unhealthyConditions: sets.New(cfg.UnhealthyNodeConditions...),
+	}
+}
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+
+func (r *DeviceHealthReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	node := &corev1.Node{}
+	if err := r.client.Get(ctx, req.NamespacedName, node); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		if r.cache.DeleteNodeUnhealthyDevices(req.Name) {
+			log.V(2).Info("Node with unhealthy devices deleted")
+			r.queueInadmissibleWorkloads(ctx)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	unhealthy := r.unhealthyDevices(node)
+	if r.cache.AddOrUpdateNodeUnhealthyDevices(node.Name, node.Labels, unhealthy) {
+		log.V(2).Info("Unhealthy devices of the node updated", "unhealthy", unhealthy)
+		// The workloads could fit in the capacity of the recovered devices.
+		r.queueInadmissibleWorkloads(ctx)
+	}
+	return ctrl.Result{}, nil
+}
+
+// unhealthyDevices returns the devices reported in the capacity of the node,
+// but not in its allocatable resources, or all the devices of the node when
+// it has an unhealthy condition.
+func (r *DeviceHealthReconciler) unhealthyDevices(node *corev1.Node) corev1.ResourceList {
+	allUnhealthy := slices.ContainsFunc(node.Status.Conditions, func(c corev1.NodeCondition) bool {
+		return c.Status == corev1.ConditionTrue && r.unhealthyConditions.Has(c.Type)
+	})
+	unhealthy := corev1.ResourceList{}
+	for _, name := range r.resourceNames {
+		capacity, found := node.Status.Capacity[name]
+		if !found {
+			continue
+		}
+		if !allUnhealthy {
+			capacity.Sub(node.Status.Allocatable[name])
+		}
+		if capacity.Sign() > 0 {
+			unhealthy[name] = capacity
+		}
+	}
+	return unhealthy
+}
+
+func (r *DeviceHealthReconciler) queueInadmissibleWorkloads(ctx context.Context) {
+	r.qManager.QueueInadmissibleWorkloads(ctx, sets.New(r.qManager.GetClusterQueueNames()...))
+}
+
+func (r *DeviceHealthReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("device-health").
+		For(&corev1.Node{}).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(r)
+}
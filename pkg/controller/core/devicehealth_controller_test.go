@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
+)
+
+func TestDeviceHealthReconcile(t *testing.T) {
+	const gpu = corev1.ResourceName("nvidia.com/gpu")
+	gpuNode := func() *testingnode.NodeWrapper {
+		return testingnode.MakeNode("node").
+			Label("gpu-type", "a100").
+			StatusCapacity(corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("96"),
+				gpu:                resource.MustParse("8"),
+			})
+	}
+	cfg := &config.DeviceHealth{
+		ResourceNames:           []corev1.ResourceName{gpu},
+		UnhealthyNodeConditions: []corev1.NodeConditionType{"GPUUnhealthy"},
+	}
+	cases := map[string]struct {
+		node     *corev1.Node
+		wantCaps map[kueue.ResourceFlavorReference]*cache.FlavorCapSnapshot
+	}{
+		"healthy devices": {
+			node: gpuNode().
+				StatusAllocatable(corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("94"),
+					gpu:                resource.MustParse("8"),
+				}).
+				Obj(),
+		},
+		"devices missing from the allocatable resources": {
+			node: gpuNode().
+				StatusAllocatable(corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("94"),
+					gpu:                resource.MustParse("6"),
+				}).
+				Obj(),
+			wantCaps: map[kueue.ResourceFlavorReference]*cache.FlavorCapSnapshot{
+				"a100": {
+					Limits:    resources.Requests{gpu: 14},
+					Unhealthy: resources.Requests{gpu: 2},
+					Usage:     resources.Requests{},
+				},
+			},
+		},
+		"unhealthy node condition": {
+			node: gpuNode().
+				StatusAllocatable(corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("94"),
+					gpu:                resource.MustParse("8"),
+				}).
+				StatusConditions(corev1.NodeCondition{Type: "GPUUnhealthy", Status: corev1.ConditionTrue}).
+				Obj(),
+			wantCaps: map[kueue.ResourceFlavorReference]*cache.FlavorCapSnapshot{
+				"a100": {
+					Limits:    resources.Requests{gpu: 8},
+					Unhealthy: resources.Requests{gpu: 8},
+					Usage:     resources.Requests{},
+				},
+			},
+		},
+		"deleted node": {},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			builder := utiltesting.NewClientBuilder()
+			if tc.node != nil {
+				builder = builder.WithObjects(tc.node)
+			}
+			cl := builder.Build()
+			ctx := context.Background()
+			cqCache := cache.New(cl)
+			qManager := queue.NewManager(cl, cqCache)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("a100").NodeLabel("gpu-type", "a100").Obj())
+			if err := cqCache.AddClusterQueue(ctx, utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("a100").Resource(gpu, "16").Obj()).
+				Obj()); err != nil {
+				t.Fatalf("Adding ClusterQueue: %v", err)
+			}
+			// The node had unhealthy devices in a previous reconciliation.
+			cqCache.AddOrUpdateNodeUnhealthyDevices("node", map[string]string{"gpu-type": "a100"}, corev1.ResourceList{gpu: resource.MustParse("1")})
+
+			reconciler := NewDeviceHealthReconciler(cl, cqCache, qManager, cfg)
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "node"}}); err != nil {
+				t.Fatalf("Reconcile returned error: %v", err)
+			}
+
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error taking the snapshot: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantCaps, snapshot.FlavorCaps); diff != "" {
+				t.Errorf("Unexpected flavor caps (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -26,7 +26,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 )
 
@@ -38,6 +40,8 @@ const (
 	WorkloadQuotaReservedKey   = "status.quotaReserved"
 	WorkloadRuntimeClassKey    = "spec.runtimeClass"
 	OwnerReferenceUID          = "metadata.ownerReferences.uid"
+	PodWorkloadKey             = "metadata.annotations.workload"
+	PodNodeNameKey             = "spec.nodeName"
 )
 
 func IndexQueueClusterQueue(obj client.Object) []string {
@@ -112,6 +116,26 @@ func IndexWorkloadRuntimeClass(obj client.Object) []string {
 	return nil
 }
 
+func IndexPodWorkload(obj client.Object) []string {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil
+	}
+	value, found := pod.Annotations[kueuealpha.WorkloadAnnotation]
+	if !found {
+		return nil
+	}
+	return []string{value}
+}
+
+func IndexPodNodeName(obj client.Object) []string {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || pod.Spec.NodeName == "" {
+		return nil
+	}
+	return []string{pod.Spec.NodeName}
+}
+
 func IndexOwnerUID(obj client.Object) []string {
 	return slices.Map(obj.GetOwnerReferences(), func(o *metav1.OwnerReference) string { return string(o.UID) })
 }
@@ -139,5 +163,15 @@ func Setup(ctx context.Context, indexer client.FieldIndexer) error {
 	if err := indexer.IndexField(ctx, &kueue.Workload{}, OwnerReferenceUID, IndexOwnerUID); err != nil {
 		return fmt.Errorf("setting index on ownerReferences.uid for Workload: %w", err)
 	}
+	if features.Enabled(features.InPlacePodResize) {
+		if err := indexer.IndexField(ctx, &corev1.Pod{}, PodWorkloadKey, IndexPodWorkload); err != nil {
+			return fmt.Errorf("setting index on workload for Pod: %w", err)
+		}
+	}
+	if features.Enabled(features.NodeInterruptionRequeue) {
+		if err := indexer.IndexField(ctx, &corev1.Pod{}, PodNodeNameKey, IndexPodNodeName); err != nil {
+			return fmt.Errorf("setting index on nodeName for Pod: %w", err)
+		}
+	}
 	return nil
 }
@@ -138,6 +138,10 @@ func (r *LocalQueueReconciler) Create(e event.CreateEvent) bool {
 	log := r.log.WithValues("localQueue", klog.KObj(q))
 	log.V(2).Info("LocalQueue create event")
 
+	if features.Enabled(features.LocalQueueMetrics) {
+		metrics.ObserveLocalQueue(q)
+	}
+
 	if ptr.Deref(q.Spec.StopPolicy, kueue.None) == kueue.None {
 		ctx := logr.NewContext(context.Background(), log)
 		if err := r.queues.AddLocalQueue(ctx, q); err != nil {
@@ -170,6 +174,9 @@ func (r *LocalQueueReconciler) Delete(e event.DeleteEvent) bool {
 	r.log.V(2).Info("LocalQueue delete event", "localQueue", klog.KObj(q))
 	r.queues.DeleteLocalQueue(q)
 	r.cache.DeleteLocalQueue(q)
+	if features.Enabled(features.LocalQueueMetrics) {
+		metrics.ForgetLocalQueue(localQueueReferenceFromLocalQueue(q))
+	}
 	return true
 }
 
@@ -183,6 +190,10 @@ func (r *LocalQueueReconciler) Update(e event.UpdateEvent) bool {
 	log := r.log.WithValues("localQueue", klog.KObj(newLq))
 	log.V(2).Info("Queue update event")
 
+	if features.Enabled(features.LocalQueueMetrics) && metrics.ObserveLocalQueue(newLq) {
+		recordLocalQueueUsageMetrics(newLq)
+	}
+
 	oldStopPolicy := ptr.Deref(oldLq.Spec.StopPolicy, kueue.None)
 	newStopPolicy := ptr.Deref(newLq.Spec.StopPolicy, kueue.None)
 
@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// NodeInterruptionReconciler evicts the admitted workloads with pods on the
+// nodes signaled as about to be interrupted, by a taint or an annotation,
+// so that the workloads are requeued and all their pods restart together,
+// instead of the pods on the interrupted nodes failing alone.
+type NodeInterruptionReconciler struct {
+	client         client.Client
+	recorder       record.EventRecorder
+	taintKeys      sets.Set[string]
+	annotationKeys sets.Set[string]
+}
+
+func NewNodeInterruptionReconciler(client client.Client, recorder record.EventRecorder, cfg *config.NodeInterruption) *NodeInterruptionReconciler {
+	r := &NodeInterruptionReconciler{
+		client:         client,
+		recorder:       recorder,
+		taintKeys:      sets.New(config.DefaultNodeInterruptionTaintKeys...),
+		annotationKeys: sets.New[string](),
+	}
+	if cfg != nil {
+		r.taintKeys = sets.New(cfg.TaintKeys...)
+		r.annotationKeys = sets.New(cfg.AnnotationKeys...)
+	}
+	return r
+}
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
+
+func (r *NodeInterruptionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	node := &corev1.Node{}
+	if err := r.client.Get(ctx, req.NamespacedName, node); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	signal, category := r.interruptionSignal(node)
+	if signal == "" {
+		return ctrl.Result{}, nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods, client.MatchingFields{indexer.PodNodeNameKey: node.Name}); err != nil {
+		return ctrl.Result{}, err
+	}
+	workloads := sets.New[types.NamespacedName]()
+	for _, pod := range pods.Items {
+		if wlName, found := pod.Annotations[kueuealpha.WorkloadAnnotation]; found {
+			workloads.Insert(types.NamespacedName{Namespace: pod.Namespace, Name: wlName})
+		}
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	message := fmt.Sprintf("Node %s is about to be interrupted, signaled by %s", node.Name, signal)
+	for key := range workloads {
+		wl := &kueue.Workload{}
+		if err := r.client.Get(ctx, key, wl); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return ctrl.Result{}, err
+			}
+			continue
+		}
+		if !workload.IsAdmitted(wl) || workload.IsFinished(wl) || apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) {
+			continue
+		}
+		workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByNodeInterruption, category, message)
+		if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return ctrl.Result{}, err
+			}
+			continue
+		}
+		log.V(2).Info("Evicted workload due to the node interruption", "workload", klog.KObj(wl), "signal", signal)
+		workload.ReportEvictedWorkload(r.recorder, wl, string(wl.Status.Admission.ClusterQueue), kueue.WorkloadEvictedByNodeInterruption, message)
+	}
+	return ctrl.Result{}, nil
+}
+
+// interruptionSignal returns the description of the taint or annotation
+// signaling that the node is about to be interrupted, and the category of
+// the evictions, or an empty string if the node is not signaled.
+// The not-ready and unreachable taints of the node lifecycle controller are
+// classified as node failures, any other signal as a spot reclaim.
+func (r *NodeInterruptionReconciler) interruptionSignal(node *corev1.Node) (string, kueue.EvictionReasonCategory) {
+	for _, taint := range node.Spec.Taints {
+		if r.taintKeys.Has(taint.Key) {
+			category := kueue.EvictionCategorySpotReclaim
+			if taint.Key == corev1.TaintNodeNotReady || taint.Key == corev1.TaintNodeUnreachable {
+				category = kueue.EvictionCategoryNodeFailure
+			}
+			return fmt.Sprintf("the taint %s", taint.Key), category
+		}
+	}
+	for key := range node.Annotations {
+		if r.annotationKeys.Has(key) {
+			return fmt.Sprintf("the annotation %s", key), kueue.EvictionCategorySpotReclaim
+		}
+	}
+	return "", ""
+}
+
+func (r *NodeInterruptionReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("node-interruption").
+		For(&corev1.Node{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			node, ok := obj.(*corev1.Node)
+			if !ok {
+				return false
+			}
+			signal, _ := r.interruptionSignal(node)
+			return signal != ""
+		}))).
+		Complete(WithLeadingManager(mgr, reconcile.Reconciler(r), &corev1.Node{}, cfg))
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
+	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestNodeInterruptionReconcile(t *testing.T) {
+	admittedWorkload := func(name string) *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload(name, "ns").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+			Admitted(true)
+	}
+	workloadPod := func(name, wlName, nodeName string) client.Object {
+		return testingpod.MakePod(name, "ns").
+			Annotation(kueuealpha.WorkloadAnnotation, wlName).
+			NodeName(nodeName).
+			Obj()
+	}
+	cases := map[string]struct {
+		cfg          *config.NodeInterruption
+		node         *corev1.Node
+		wantEvicted  map[string]string
+		wantCategory kueue.EvictionReasonCategory
+	}{
+		"default taint": {
+			node: testingnode.MakeNode("node").
+				Taints(corev1.Taint{Key: "aws-node-termination-handler/spot-itn", Effect: corev1.TaintEffectNoSchedule}).
+				Obj(),
+			wantEvicted: map[string]string{
+				"wl":        kueue.WorkloadEvictedByNodeInterruption,
+				"other-wl":  "",
+				"pending":   "",
+				"preempted": kueue.WorkloadEvictedByPreemption,
+			},
+			wantCategory: kueue.EvictionCategorySpotReclaim,
+		},
+		"configured annotation": {
+			cfg: &config.NodeInterruption{AnnotationKeys: []string{"example.com/interruption-notice"}},
+			node: func() *corev1.Node {
+				node := testingnode.MakeNode("node").Obj()
+				node.Annotations = map[string]string{"example.com/interruption-notice": "true"}
+				return node
+			}(),
+			wantEvicted: map[string]string{
+				"wl":        kueue.WorkloadEvictedByNodeInterruption,
+				"other-wl":  "",
+				"pending":   "",
+				"preempted": kueue.WorkloadEvictedByPreemption,
+			},
+			wantCategory: kueue.EvictionCategorySpotReclaim,
+		},
+		"configured not-ready taint": {
+			cfg: &config.NodeInterruption{TaintKeys: []string{corev1.TaintNodeNotReady}},
+			node: testingnode.MakeNode("node").
+				Taints(corev1.Taint{Key: corev1.TaintNodeNotReady, Effect: corev1.TaintEffectNoExecute}).
+				Obj(),
+			wantEvicted: map[string]string{
+				"wl":        kueue.WorkloadEvictedByNodeInterruption,
+				"other-wl":  "",
+				"pending":   "",
+				"preempted": kueue.WorkloadEvictedByPreemption,
+			},
+			wantCategory: kueue.EvictionCategoryNodeFailure,
+		},
+		"not signaled": {
+			node: testingnode.MakeNode("node").
+				Taints(corev1.Taint{Key: "example.com/other", Effect: corev1.TaintEffectNoSchedule}).
+				Obj(),
+			wantEvicted: map[string]string{
+				"wl":        "",
+				"other-wl":  "",
+				"pending":   "",
+				"preempted": kueue.WorkloadEvictedByPreemption,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			workloads := []*kueue.Workload{
+				admittedWorkload("wl").Obj(),
+				admittedWorkload("other-wl").Obj(),
+				utiltesting.MakeWorkload("pending", "ns").Obj(),
+				admittedWorkload("preempted").
+					Condition(metav1.Condition{
+						Type:   kueue.WorkloadEvicted,
+						Status: metav1.ConditionTrue,
+						Reason: kueue.WorkloadEvictedByPreemption,
+					}).
+					Obj(),
+			}
+			builder := utiltesting.NewClientBuilder().
+				WithIndex(&corev1.Pod{}, indexer.PodNodeNameKey, indexer.IndexPodNodeName).
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+				WithObjects(
+					tc.node,
+					workloadPod("pod1", "wl", "node"),
+					workloadPod("pod2", "wl", "other-node"),
+					workloadPod("other", "other-wl", "other-node"),
+					workloadPod("pending", "pending", "node"),
+					workloadPod("preempted", "preempted", "node"),
+				)
+			for _, wl := range workloads {
+				builder = builder.WithObjects(wl).WithStatusSubresource(wl)
+			}
+			cl := builder.Build()
+			reconciler := NewNodeInterruptionReconciler(cl, record.NewFakeRecorder(10), tc.cfg)
+
+			ctx := context.Background()
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "node"}}); err != nil {
+				t.Fatalf("Reconcile returned error: %v", err)
+			}
+
+			gotEvicted := make(map[string]string, len(workloads))
+			for _, wl := range workloads {
+				gotWorkload := &kueue.Workload{}
+				if err := cl.Get(ctx, client.ObjectKeyFromObject(wl), gotWorkload); err != nil {
+					t.Fatalf("Getting the workload: %v", err)
+				}
+				if cond := apimeta.FindStatusCondition(gotWorkload.Status.Conditions, kueue.WorkloadEvicted); cond != nil {
+					gotEvicted[wl.Name] = cond.Reason
+				} else {
+					gotEvicted[wl.Name] = ""
+				}
+				if wl.Name == "wl" && tc.wantCategory != "" {
+					if gotCategory := workload.EvictionCategory(gotWorkload, kueue.WorkloadEvictedByNodeInterruption); gotCategory != tc.wantCategory {
+						t.Errorf("Unexpected eviction category, want=%q, got=%q", tc.wantCategory, gotCategory)
+					}
+				}
+			}
+			if diff := cmp.Diff(tc.wantEvicted, gotEvicted); diff != "" {
+				t.Errorf("Unexpected eviction reasons (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
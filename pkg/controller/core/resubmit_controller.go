@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	clientutil "sigs.k8s.io/kueue/pkg/util/client"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const (
+	ReasonResubmitted    = "Resubmitted"
+	ReasonResubmitFailed = "ResubmitFailed"
+)
+
+// ResubmitReconciler creates a copy of the job of the finished workloads
+// requested with the resubmit annotation, so that the users interacting only
+// with the Workloads can retry their jobs with the same queue settings.
+type ResubmitReconciler struct {
+	client   client.Client
+	recorder record.EventRecorder
+}
+
+func NewResubmitReconciler(client client.Client, recorder record.EventRecorder) *ResubmitReconciler {
+	return &ResubmitReconciler{client: client, recorder: recorder}
+}
+
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;create
+// +kubebuilder:rbac:groups=jobset.x-k8s.io,resources=jobsets,verbs=get;create
+// +kubebuilder:rbac:groups=kubeflow.org,resources=mpijobs;mxjobs;paddlejobs;pytorchjobs;tfjobs;xgboostjobs,verbs=get;create
+// +kubebuilder:rbac:groups=ray.io,resources=rayjobs;rayclusters,verbs=get;create
+
+func (r *ResubmitReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	wl := &kueue.Workload{}
+	if err := r.client.Get(ctx, req.NamespacedName, wl); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if _, requested := wl.Annotations[controllerconsts.ResubmitAnnotation]; !requested {
+		return ctrl.Result{}, nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+
+	cloneName, err := r.resubmit(ctx, wl)
+	if err != nil {
+		if !apierrors.IsNotFound(err) && !isRequestError(err) {
+			return ctrl.Result{}, err
+		}
+		// The request can't be satisfied, so it's dropped.
+		log.V(2).Info("Cannot resubmit the job of the workload", "reason", err.Error())
+		r.recorder.Eventf(wl, corev1.EventTypeWarning, ReasonResubmitFailed, "Cannot resubmit the job: %v", err)
+	}
+
+	if err := clientutil.Patch(ctx, r.client, wl, true, func() (bool, error) {
+		delete(wl.Annotations, controllerconsts.ResubmitAnnotation)
+		if cloneName != "" {
+			wl.Annotations[controllerconsts.ResubmittedAsAnnotation] = cloneName
+		}
+		return true, nil
+	}); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if cloneName != "" {
+		log.V(2).Info("Resubmitted the job of the workload", "job", cloneName)
+		r.recorder.Eventf(wl, corev1.EventTypeNormal, ReasonResubmitted, "Resubmitted the job as %s", cloneName)
+	}
+	return ctrl.Result{}, nil
+}
+
+// resubmit creates the copy of the job of the finished workload and returns
+// its name. The copy is named after the workload, so that a retried request
+// finds the copy already created.
+func (r *ResubmitReconciler) resubmit(ctx context.Context, wl *kueue.Workload) (string, error) {
+	owner, err := workload.ResubmittableJob(wl)
+	if err != nil {
+		return "", err
+	}
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		return "", err
+	}
+	job := &unstructured.Unstructured{}
+	job.SetGroupVersionKind(gv.WithKind(owner.Kind))
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: wl.Namespace, Name: owner.Name}, job); err != nil {
+		return "", err
+	}
+
+	clone := workload.CloneJob(job)
+	clone.SetGenerateName("")
+	clone.SetName(workload.ResubmittedJobName(job.GetName(), wl.UID))
+	if err := r.client.Create(ctx, clone); err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", err
+	}
+	return clone.GetName(), nil
+}
+
+func isRequestError(err error) bool {
+	return errors.Is(err, workload.ErrWorkloadNotFinished) || errors.Is(err, workload.ErrWorkloadWithoutJob)
+}
+
+func (r *ResubmitReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("workload-resubmit").
+		For(&kueue.Workload{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			_, requested := obj.GetAnnotations()[controllerconsts.ResubmitAnnotation]
+			return requested
+		}))).
+		Complete(WithLeadingManager(mgr, reconcile.Reconciler(r), &kueue.Workload{}, cfg))
+}
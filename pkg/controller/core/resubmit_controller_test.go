@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestResubmitReconcile(t *testing.T) {
+	jobGVK := batchv1.SchemeGroupVersion.WithKind("Job")
+	wlKey := types.NamespacedName{Namespace: "ns", Name: "wl"}
+	requested := map[string]string{controllerconsts.ResubmitAnnotation: "true"}
+	cloneName := workload.ResubmittedJobName("job", "wl-uid")
+	baseWorkload := utiltesting.MakeWorkload("wl", "ns").
+		UID("wl-uid").
+		ControllerReference(jobGVK, "job", "job-uid")
+	baseJob := testingjob.MakeJob("job", "ns").Queue("lq").Parallelism(2)
+	jobNamed := func(name string) *batchv1.Job {
+		job := baseJob.Clone().Obj()
+		job.Name = name
+		return job
+	}
+
+	cases := map[string]struct {
+		workload        *kueue.Workload
+		objs            []client.Object
+		wantAnnotations map[string]string
+		wantClone       *batchv1.Job
+		wantEvents      []utiltesting.EventRecord
+	}{
+		"not requested": {
+			workload: baseWorkload.Clone().Finished().Obj(),
+			objs:     []client.Object{baseJob.Clone().Obj()},
+		},
+		"finished workload": {
+			workload: baseWorkload.Clone().Finished().Annotations(requested).Obj(),
+			objs:     []client.Object{baseJob.Clone().Obj()},
+			wantAnnotations: map[string]string{
+				controllerconsts.ResubmittedAsAnnotation: cloneName,
+			},
+			wantClone: jobNamed(cloneName),
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       wlKey,
+				EventType: corev1.EventTypeNormal,
+				Reason:    ReasonResubmitted,
+				Message:   "Resubmitted the job as " + cloneName,
+			}},
+		},
+		"clone already created": {
+			workload: baseWorkload.Clone().Finished().Annotations(requested).Obj(),
+			objs:     []client.Object{baseJob.Clone().Obj(), jobNamed(cloneName)},
+			wantAnnotations: map[string]string{
+				controllerconsts.ResubmittedAsAnnotation: cloneName,
+			},
+			wantClone: jobNamed(cloneName),
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       wlKey,
+				EventType: corev1.EventTypeNormal,
+				Reason:    ReasonResubmitted,
+				Message:   "Resubmitted the job as " + cloneName,
+			}},
+		},
+		"running workload": {
+			workload:        baseWorkload.Clone().Annotations(requested).Obj(),
+			objs:            []client.Object{baseJob.Clone().Obj()},
+			wantAnnotations: map[string]string{},
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       wlKey,
+				EventType: corev1.EventTypeWarning,
+				Reason:    ReasonResubmitFailed,
+				Message:   "Cannot resubmit the job: workload is not finished",
+			}},
+		},
+		"deleted job": {
+			workload:        baseWorkload.Clone().Finished().Annotations(requested).Obj(),
+			wantAnnotations: map[string]string{},
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       wlKey,
+				EventType: corev1.EventTypeWarning,
+				Reason:    ReasonResubmitFailed,
+				Message:   `Cannot resubmit the job: jobs.batch "job" not found`,
+			}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			objs := append([]client.Object{tc.workload}, tc.objs...)
+			cl := utiltesting.NewClientBuilder().WithObjects(objs...).Build()
+			recorder := &utiltesting.EventRecorder{}
+			r := NewResubmitReconciler(cl, recorder)
+
+			ctx := context.Background()
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: wlKey}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var gotWorkload kueue.Workload
+			if err := cl.Get(ctx, wlKey, &gotWorkload); err != nil {
+				t.Fatalf("Failed obtaining the workload: %v", err)
+			}
+			wantAnnotations := tc.wantAnnotations
+			if wantAnnotations == nil {
+				wantAnnotations = tc.workload.Annotations
+			}
+			if diff := cmp.Diff(wantAnnotations, gotWorkload.Annotations, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected workload annotations (-want,+got):\n%s", diff)
+			}
+
+			var jobs batchv1.JobList
+			if err := cl.List(ctx, &jobs, client.InNamespace("ns")); err != nil {
+				t.Fatalf("Failed listing the jobs: %v", err)
+			}
+			var gotClone *batchv1.Job
+			for i := range jobs.Items {
+				if jobs.Items[i].Name == cloneName {
+					gotClone = &jobs.Items[i]
+				}
+			}
+			if diff := cmp.Diff(tc.wantClone, gotClone, cmpopts.IgnoreFields(batchv1.Job{}, "TypeMeta", "ObjectMeta.ResourceVersion"), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected resubmitted job (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantEvents, recorder.RecordedEvents); diff != "" {
+				t.Errorf("Unexpected events (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
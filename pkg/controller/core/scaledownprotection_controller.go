@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// ScaleDownProtectionReconciler removes the protection from the scale down of
+// the nodes, set by the job reconcilers in the pods of the admitted gang
+// workloads, once the workloads finish or are deleted.
+type ScaleDownProtectionReconciler struct {
+	client client.Client
+}
+
+func NewScaleDownProtectionReconciler(client client.Client) *ScaleDownProtectionReconciler {
+	return &ScaleDownProtectionReconciler{client: client}
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch
+
+func (r *ScaleDownProtectionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	wl := &kueue.Workload{}
+	err := r.client.Get(ctx, req.NamespacedName, wl)
+	if client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, err
+	}
+	if err == nil && !workload.IsFinished(wl) {
+		return ctrl.Result{}, nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods, client.InNamespace(req.Namespace), client.MatchingLabels{controllerconsts.ScaleDownProtectedLabel: "true"}); err != nil {
+		return ctrl.Result{}, err
+	}
+	log := ctrl.LoggerFrom(ctx)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Annotations[kueuealpha.WorkloadAnnotation] != req.Name {
+			continue
+		}
+		patch := client.MergeFrom(pod.DeepCopy())
+		delete(pod.Labels, controllerconsts.ScaleDownProtectedLabel)
+		delete(pod.Annotations, controllerconsts.SafeToEvictAnnotation)
+		if err := r.client.Patch(ctx, pod, patch); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+		log.V(3).Info("Removed the scale down protection of the pod", "pod", klog.KObj(pod))
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *ScaleDownProtectionReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("scale-down-protection").
+		For(&kueue.Workload{}).
+		Complete(WithLeadingManager(mgr, reconcile.Reconciler(r), &kueue.Workload{}, cfg))
+}
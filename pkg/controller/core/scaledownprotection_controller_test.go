@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func protectedPod(name, wlName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+			Labels: map[string]string{
+				controllerconsts.ScaleDownProtectedLabel: "true",
+			},
+			Annotations: map[string]string{
+				controllerconsts.SafeToEvictAnnotation: "false",
+				kueuealpha.WorkloadAnnotation:          wlName,
+			},
+		},
+	}
+}
+
+func TestScaleDownProtectionReconcile(t *testing.T) {
+	unprotected := map[string]string{kueuealpha.WorkloadAnnotation: "wl"}
+	protected := protectedPod("", "wl").Annotations
+
+	cases := map[string]struct {
+		workload        *kueue.Workload
+		wantAnnotations map[string]map[string]string
+	}{
+		"running workload": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				Admitted(true).
+				Obj(),
+			wantAnnotations: map[string]map[string]string{
+				"pod1":  protected,
+				"pod2":  protected,
+				"other": protectedPod("", "other-wl").Annotations,
+			},
+		},
+		"finished workload": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				Admitted(true).
+				Finished().
+				Obj(),
+			wantAnnotations: map[string]map[string]string{
+				"pod1":  unprotected,
+				"pod2":  unprotected,
+				"other": protectedPod("", "other-wl").Annotations,
+			},
+		},
+		"deleted workload": {
+			wantAnnotations: map[string]map[string]string{
+				"pod1":  unprotected,
+				"pod2":  unprotected,
+				"other": protectedPod("", "other-wl").Annotations,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			builder := utiltesting.NewClientBuilder().WithObjects(
+				protectedPod("pod1", "wl"),
+				protectedPod("pod2", "wl"),
+				protectedPod("other", "other-wl"),
+			)
+			if tc.workload != nil {
+				builder = builder.WithObjects(tc.workload)
+			}
+			cl := builder.Build()
+			reconciler := NewScaleDownProtectionReconciler(cl)
+
+			ctx := context.Background()
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "wl"}})
+			if err != nil {
+				t.Fatalf("Reconcile returned error: %v", err)
+			}
+
+			pods := &corev1.PodList{}
+			if err := cl.List(ctx, pods, client.InNamespace("ns")); err != nil {
+				t.Fatalf("Listing pods: %v", err)
+			}
+			gotAnnotations := make(map[string]map[string]string, len(pods.Items))
+			for _, pod := range pods.Items {
+				gotAnnotations[pod.Name] = pod.Annotations
+				_, isLabeled := pod.Labels[controllerconsts.ScaleDownProtectedLabel]
+				_, isProtected := pod.Annotations[controllerconsts.SafeToEvictAnnotation]
+				if isLabeled != isProtected {
+					t.Errorf("Pod %q has the label %v, and the annotation %v", pod.Name, isLabeled, isProtected)
+				}
+			}
+			if diff := cmp.Diff(tc.wantAnnotations, gotAnnotations); diff != "" {
+				t.Errorf("Unexpected annotations (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
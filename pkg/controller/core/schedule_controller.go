@@ -0,0 +1,236 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+	// The time zones of the Schedules are loaded from the embedded database,
+	// as the Kueue image doesn't include one.
+	_ "time/tzdata"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+)
+
+// ScheduleReconciler opens and closes the ClusterQueues of the Schedules at
+// the boundaries of their windows, in their status. The closed Schedules are
+// synchronized in cache.Cache.
+type ScheduleReconciler struct {
+	client   client.Client
+	log      logr.Logger
+	cache    *cache.Cache
+	qManager *queue.Manager
+	clock    clock.Clock
+}
+
+func NewScheduleReconciler(client client.Client, cache *cache.Cache, qManager *queue.Manager) *ScheduleReconciler {
+	return &ScheduleReconciler{
+		client:   client,
+		log:      ctrl.Log.WithName("schedule-reconciler"),
+		cache:    cache,
+		qManager: qManager,
+		clock:    realClock,
+	}
+}
+
+func (r *ScheduleReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kueuealpha.Schedule{}).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		WithEventFilter(r).
+		Complete(WithLeadingManager(mgr, r, &kueuealpha.Schedule{}, cfg))
+}
+
+func (r *ScheduleReconciler) Create(e event.CreateEvent) bool {
+	schedule, match := e.Object.(*kueuealpha.Schedule)
+	if !match {
+		return true
+	}
+	r.log.V(2).Info("Schedule create event", "schedule", klog.KObj(schedule))
+	r.cache.AddOrUpdateSchedule(schedule)
+	return true
+}
+
+func (r *ScheduleReconciler) Update(e event.UpdateEvent) bool {
+	oldSchedule, oldIsSchedule := e.ObjectOld.(*kueuealpha.Schedule)
+	newSchedule, newIsSchedule := e.ObjectNew.(*kueuealpha.Schedule)
+	if !oldIsSchedule || !newIsSchedule {
+		return true
+	}
+	log := r.log.WithValues("schedule", klog.KObj(newSchedule))
+	log.V(2).Info("Schedule update event")
+	r.cache.AddOrUpdateSchedule(newSchedule)
+	if !oldSchedule.Status.Open && newSchedule.Status.Open {
+		r.queueInadmissibleWorkloads(logr.NewContext(context.Background(), log), newSchedule.Name)
+	}
+	return !equality.Semantic.DeepEqual(oldSchedule.Spec, newSchedule.Spec)
+}
+
+func (r *ScheduleReconciler) Delete(e event.DeleteEvent) bool {
+	schedule, match := e.Object.(*kueuealpha.Schedule)
+	if !match {
+		return true
+	}
+	log := r.log.WithValues("schedule", klog.KObj(schedule))
+	log.V(2).Info("Schedule delete event")
+	r.cache.DeleteSchedule(schedule.Name)
+	if !schedule.Status.Open {
+		r.queueInadmissibleWorkloads(logr.NewContext(context.Background(), log), schedule.Name)
+	}
+	return false
+}
+
+func (r *ScheduleReconciler) Generic(event.GenericEvent) bool {
+	return true
+}
+
+// queueInadmissibleWorkloads releases the workloads held by the ClusterQueues
+// of the Schedule when they open.
+func (r *ScheduleReconciler) queueInadmissibleWorkloads(ctx context.Context, name string) {
+	if cqNames := r.cache.ClusterQueuesWithSchedule(name); len(cqNames) > 0 {
+		r.qManager.QueueInadmissibleWorkloads(ctx, sets.New(cqNames...))
+	}
+}
+
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=schedules,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=schedules/status,verbs=get;update;patch
+
+func (r *ScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var schedule kueuealpha.Schedule
+	if err := r.client.Get(ctx, req.NamespacedName, &schedule); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log := ctrl.LoggerFrom(ctx).WithValues("schedule", klog.KObj(&schedule))
+	ctx = ctrl.LoggerInto(ctx, log)
+	log.V(2).Info("Reconciling Schedule")
+
+	now := r.clock.Now()
+	open, next, err := scheduleState(&schedule.Spec, now)
+	if err != nil {
+		// The Schedule is reconciled again when its spec is fixed.
+		log.Error(err, "Invalid Schedule")
+		return ctrl.Result{}, nil
+	}
+	oldStatus := schedule.Status.DeepCopy()
+	schedule.Status.Open = open
+	schedule.Status.NextTransitionTime = nil
+	if next != nil {
+		schedule.Status.NextTransitionTime = ptr.To(metav1.NewTime(*next))
+	}
+	if !equality.Semantic.DeepEqual(oldStatus, &schedule.Status) {
+		if err := r.client.Status().Update(ctx, &schedule); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+	}
+	if next == nil {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+}
+
+type scheduleInterval struct {
+	start, end time.Time
+}
+
+// scheduleState returns whether the windows of the Schedule are open at now,
+// and the time of the next transition, or nil if there is none.
+func scheduleState(spec *kueuealpha.ScheduleSpec, now time.Time) (bool, *time.Time, error) {
+	loc, err := time.LoadLocation(spec.TimeZone)
+	if err != nil {
+		return false, nil, fmt.Errorf("loading the time zone %q: %w", spec.TimeZone, err)
+	}
+	intervals, err := scheduleIntervals(spec.Windows, now.In(loc))
+	if err != nil {
+		return false, nil, err
+	}
+	for _, interval := range intervals {
+		if now.Before(interval.start) {
+			return false, &interval.start, nil
+		}
+		if now.Before(interval.end) {
+			// The windows repeat every week, so that an interval of more than
+			// a week never closes.
+			if interval.end.Sub(now) > 8*24*time.Hour {
+				return true, nil, nil
+			}
+			return true, &interval.end, nil
+		}
+	}
+	return false, nil, nil
+}
+
+// scheduleIntervals returns the merged intervals of the windows, sorted by
+// start, opening from the day before now and over the next two weeks.
+func scheduleIntervals(windows []kueuealpha.ScheduleWindow, now time.Time) ([]scheduleInterval, error) {
+	var intervals []scheduleInterval
+	year, month, day := now.Date()
+	for _, window := range windows {
+		start, err := time.Parse("15:04", window.Start)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the start of a window: %w", err)
+		}
+		end, err := time.Parse("15:04", window.End)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the end of a window: %w", err)
+		}
+		for d := -1; d <= 15; d++ {
+			date := time.Date(year, month, day+d, 0, 0, 0, 0, now.Location())
+			if len(window.DaysOfWeek) > 0 && !slices.Contains(window.DaysOfWeek, kueuealpha.DayOfWeek(date.Weekday().String())) {
+				continue
+			}
+			interval := scheduleInterval{
+				start: time.Date(year, month, day+d, start.Hour(), start.Minute(), 0, 0, now.Location()),
+				end:   time.Date(year, month, day+d, end.Hour(), end.Minute(), 0, 0, now.Location()),
+			}
+			if !interval.end.After(interval.start) {
+				interval.end = time.Date(year, month, day+d+1, end.Hour(), end.Minute(), 0, 0, now.Location())
+			}
+			intervals = append(intervals, interval)
+		}
+	}
+	slices.SortFunc(intervals, func(a, b scheduleInterval) int {
+		return a.start.Compare(b.start)
+	})
+	merged := intervals[:0]
+	for _, interval := range intervals {
+		if last := len(merged) - 1; last >= 0 && !interval.start.After(merged[last].end) {
+			if interval.end.After(merged[last].end) {
+				merged[last].end = interval.end
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged, nil
+}
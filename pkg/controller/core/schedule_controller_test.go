@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestScheduleReconcile(t *testing.T) {
+	weekdays := []kueuealpha.DayOfWeek{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"}
+	// Wednesday, October 16th 2024.
+	wednesday := func(hour, minute int) time.Time {
+		return time.Date(2024, time.October, 16, hour, minute, 0, 0, time.UTC)
+	}
+
+	cases := map[string]struct {
+		schedule        *kueuealpha.Schedule
+		now             time.Time
+		wantStatus      kueuealpha.ScheduleStatus
+		wantRequeueTime time.Duration
+	}{
+		"open within the business hours": {
+			schedule: utiltesting.MakeSchedule("business-hours").Window("09:00", "17:00", weekdays...).Obj(),
+			now:      wednesday(12, 0),
+			wantStatus: kueuealpha.ScheduleStatus{
+				Open:               true,
+				NextTransitionTime: ptr.To(metav1.NewTime(wednesday(17, 0))),
+			},
+			wantRequeueTime: 5 * time.Hour,
+		},
+		"closed on the weekend until Monday": {
+			schedule: utiltesting.MakeSchedule("business-hours").Window("09:00", "17:00", weekdays...).Obj(),
+			now:      time.Date(2024, time.October, 19, 12, 0, 0, 0, time.UTC),
+			wantStatus: kueuealpha.ScheduleStatus{
+				NextTransitionTime: ptr.To(metav1.NewTime(time.Date(2024, time.October, 21, 9, 0, 0, 0, time.UTC))),
+			},
+			wantRequeueTime: 45 * time.Hour,
+		},
+		"open in an overnight window started the day before": {
+			schedule: utiltesting.MakeSchedule("nights").Window("22:00", "06:00").Obj(),
+			now:      wednesday(3, 0),
+			wantStatus: kueuealpha.ScheduleStatus{
+				Open:               true,
+				NextTransitionTime: ptr.To(metav1.NewTime(wednesday(6, 0))),
+			},
+			wantRequeueTime: 3 * time.Hour,
+		},
+		"overlapping windows are merged": {
+			schedule: utiltesting.MakeSchedule("afternoons").
+				Window("09:00", "13:00").
+				Window("12:00", "18:00", "Wednesday").
+				Obj(),
+			now: wednesday(10, 0),
+			wantStatus: kueuealpha.ScheduleStatus{
+				Open:               true,
+				NextTransitionTime: ptr.To(metav1.NewTime(wednesday(18, 0))),
+			},
+			wantRequeueTime: 8 * time.Hour,
+		},
+		"windows in the time zone of the Schedule": {
+			schedule: utiltesting.MakeSchedule("new-york").
+				TimeZone("America/New_York").
+				Window("09:00", "17:00", weekdays...).
+				Obj(),
+			now: wednesday(12, 0),
+			wantStatus: kueuealpha.ScheduleStatus{
+				NextTransitionTime: ptr.To(metav1.NewTime(wednesday(13, 0))),
+			},
+			wantRequeueTime: time.Hour,
+		},
+		"windows covering every day never close": {
+			schedule: utiltesting.MakeSchedule("always").Window("00:00", "00:00").Obj(),
+			now:      wednesday(12, 0),
+			wantStatus: kueuealpha.ScheduleStatus{
+				Open: true,
+			},
+		},
+		"invalid time zone": {
+			schedule: utiltesting.MakeSchedule("invalid").
+				TimeZone("Mars/Olympus_Mons").
+				Window("09:00", "17:00").
+				Open(true).
+				Obj(),
+			now: wednesday(12, 0),
+			wantStatus: kueuealpha.ScheduleStatus{
+				Open: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			cl := utiltesting.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+				WithObjects(tc.schedule).
+				WithStatusSubresource(tc.schedule).
+				Build()
+			cqCache := cache.New(cl)
+			reconciler := NewScheduleReconciler(cl, cqCache, queue.NewManager(cl, cqCache))
+			reconciler.clock = testingclock.NewFakeClock(tc.now)
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.schedule)})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.RequeueAfter != tc.wantRequeueTime {
+				t.Errorf("Unexpected requeue time, want=%v, got=%v", tc.wantRequeueTime, result.RequeueAfter)
+			}
+			var got kueuealpha.Schedule
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(tc.schedule), &got); err != nil {
+				t.Fatalf("Getting the Schedule: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantStatus, got.Status); diff != "" {
+				t.Errorf("Unexpected status (-want,+got):\n%s", diff)
+			}
+
+			reconciler.Update(event.UpdateEvent{ObjectOld: tc.schedule, ObjectNew: &got})
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Taking the snapshot: %v", err)
+			}
+			if closed := snapshot.ClosedSchedules.Has(tc.schedule.Name); closed == tc.wantStatus.Open {
+				t.Errorf("Unexpected closed Schedule in the snapshot, want=%v, got=%v", !tc.wantStatus.Open, closed)
+			}
+		})
+	}
+}
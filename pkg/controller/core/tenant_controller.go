@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// TenantReconciler synchronizes the Tenants in cache.Cache and queue.Manager
+// with the Tenant Kubernetes objects, and updates their usage in their
+// status.
+type TenantReconciler struct {
+	client     client.Client
+	log        logr.Logger
+	cache      *cache.Cache
+	qManager   *queue.Manager
+	wlUpdateCh chan event.GenericEvent
+}
+
+func NewTenantReconciler(client client.Client, cache *cache.Cache, qManager *queue.Manager) *TenantReconciler {
+	return &TenantReconciler{
+		client:     client,
+		log:        ctrl.Log.WithName("tenant-reconciler"),
+		cache:      cache,
+		qManager:   qManager,
+		wlUpdateCh: make(chan event.GenericEvent, updateChBuffer),
+	}
+}
+
+func (r *TenantReconciler) NotifyWorkloadUpdate(oldWl, newWl *kueue.Workload) {
+	if oldWl != nil {
+		r.wlUpdateCh <- event.GenericEvent{Object: oldWl}
+		if newWl != nil && oldWl.Spec.QueueName != newWl.Spec.QueueName {
+			r.wlUpdateCh <- event.GenericEvent{Object: newWl}
+		}
+		return
+	}
+	if newWl != nil {
+		r.wlUpdateCh <- event.GenericEvent{Object: newWl}
+	}
+}
+
+func (r *TenantReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kueuealpha.Tenant{}).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		WatchesRawSource(source.Channel(r.wlUpdateCh, &tenantWorkloadHandler{cache: r.cache})).
+		WithEventFilter(r).
+		Complete(WithLeadingManager(mgr, r, &kueuealpha.Tenant{}, cfg))
+}
+
+func (r *TenantReconciler) Create(e event.CreateEvent) bool {
+	tenant, match := e.Object.(*kueuealpha.Tenant)
+	if !match {
+		return true
+	}
+	log := r.log.WithValues("tenant", klog.KObj(tenant))
+	log.V(2).Info("Tenant create event")
+	r.cache.AddOrUpdateTenant(tenant)
+	r.qManager.AddOrUpdateTenant(logr.NewContext(context.Background(), log), tenant)
+	return true
+}
+
+func (r *TenantReconciler) Update(e event.UpdateEvent) bool {
+	oldTenant, oldIsTenant := e.ObjectOld.(*kueuealpha.Tenant)
+	newTenant, newIsTenant := e.ObjectNew.(*kueuealpha.Tenant)
+	if !oldIsTenant || !newIsTenant {
+		return true
+	}
+	log := r.log.WithValues("tenant", klog.KObj(newTenant))
+	if equality.Semantic.DeepEqual(oldTenant.Spec, newTenant.Spec) {
+		log.V(3).Info("Skip Tenant update event as its spec is unchanged")
+		return false
+	}
+	log.V(2).Info("Tenant update event")
+	r.cache.AddOrUpdateTenant(newTenant)
+	r.qManager.AddOrUpdateTenant(logr.NewContext(context.Background(), log), newTenant)
+	return true
+}
+
+func (r *TenantReconciler) Delete(e event.DeleteEvent) bool {
+	tenant, match := e.Object.(*kueuealpha.Tenant)
+	if !match {
+		return true
+	}
+	log := r.log.WithValues("tenant", klog.KObj(tenant))
+	log.V(2).Info("Tenant delete event")
+	r.cache.DeleteTenant(tenant.Name)
+	r.qManager.DeleteTenant(logr.NewContext(context.Background(), log), tenant.Name)
+	return false
+}
+
+func (r *TenantReconciler) Generic(e event.GenericEvent) bool {
+	r.log.V(3).Info("Got Workload event", "workload", klog.KObj(e.Object))
+	return true
+}
+
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=tenants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=tenants/status,verbs=get;update;patch
+
+func (r *TenantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var tenant kueuealpha.Tenant
+	if err := r.client.Get(ctx, req.NamespacedName, &tenant); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log := ctrl.LoggerFrom(ctx).WithValues("tenant", klog.KObj(&tenant))
+	log.V(2).Info("Reconciling Tenant")
+
+	stats, err := r.cache.TenantUsage(tenant.Name)
+	if err != nil {
+		log.V(2).Info("Tenant not found in the cache", "error", err)
+		return ctrl.Result{}, nil
+	}
+	oldStatus := tenant.Status.DeepCopy()
+	tenant.Status.Usage = stats.Usage
+	tenant.Status.ReservingWorkloads = int32(stats.ReservingWorkloads)
+	if equality.Semantic.DeepEqual(oldStatus, &tenant.Status) {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{}, client.IgnoreNotFound(r.client.Status().Update(ctx, &tenant))
+}
+
+// tenantWorkloadHandler signals the controller to reconcile the Tenants of
+// the LocalQueue of the workload in the event.
+// Since the events come from a channel Source, only the Generic handler will
+// receive events.
+type tenantWorkloadHandler struct {
+	cache *cache.Cache
+}
+
+func (h *tenantWorkloadHandler) Create(context.Context, event.CreateEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *tenantWorkloadHandler) Update(context.Context, event.UpdateEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *tenantWorkloadHandler) Delete(context.Context, event.DeleteEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *tenantWorkloadHandler) Generic(_ context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	w := e.Object.(*kueue.Workload)
+	if w.Name == "" {
+		return
+	}
+	for _, name := range h.cache.TenantsOfLocalQueue(workload.QueueKey(w)) {
+		q.AddAfter(reconcile.Request{NamespacedName: types.NamespacedName{Name: name}}, constants.UpdatesBatchPeriod)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestTenantReconcileStatus(t *testing.T) {
+	tenant := utiltesting.MakeTenant("team").
+		LocalQueue("ns1", "lq").
+		LocalQueue("ns2", "lq").
+		UsageLimit(corev1.ResourceCPU, "10").
+		Obj()
+	cl := utiltesting.NewClientBuilder().
+		WithObjects(tenant).
+		WithStatusSubresource(tenant).
+		Build()
+	ctx := context.Background()
+	cqCache := cache.New(cl)
+	qManager := queue.NewManager(cl, cqCache)
+	reconciler := NewTenantReconciler(cl, cqCache, qManager)
+
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		Obj()
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Adding ClusterQueue: %v", err)
+	}
+	for _, ns := range []string{"ns1", "ns2", "ns3"} {
+		if err := cqCache.AddLocalQueue(utiltesting.MakeLocalQueue("lq", ns).ClusterQueue("cq").Obj()); err != nil {
+			t.Fatalf("Adding LocalQueue: %v", err)
+		}
+		wl := utiltesting.MakeWorkload("wl", ns).Queue("lq").Request(corev1.ResourceCPU, "2").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+			Obj()
+		if !cqCache.AddOrUpdateWorkload(wl) {
+			t.Fatalf("Workload %s/%s was not added", wl.Namespace, wl.Name)
+		}
+	}
+	reconciler.Create(event.CreateEvent{Object: tenant})
+
+	if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tenant)}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var got kueuealpha.Tenant
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(tenant), &got); err != nil {
+		t.Fatalf("Getting the Tenant: %v", err)
+	}
+	wantStatus := kueuealpha.TenantStatus{
+		Usage:              corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+		ReservingWorkloads: 2,
+	}
+	if diff := cmp.Diff(wantStatus, got.Status); diff != "" {
+		t.Errorf("Unexpected status (-want,+got):\n%s", diff)
+	}
+
+	reconciler.Delete(event.DeleteEvent{Object: tenant})
+	if names := cqCache.TenantsOfLocalQueue("ns1/lq"); len(names) != 0 {
+		t.Errorf("Unexpected Tenants of the LocalQueue after the deletion: %v", names)
+	}
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+)
+
+// UsageReporter periodically samples the resources used by the workloads
+// admitted in the LocalQueues and adds them, in resource-hours, to the
+// UsageReport of the namespace for the current period.
+// The usage while Kueue is not running, or not the leader, is not reported.
+type UsageReporter struct {
+	client       client.Client
+	cache        *cache.Cache
+	log          logr.Logger
+	clock        clock.Clock
+	period       time.Duration
+	syncInterval time.Duration
+	lastSync     time.Time
+}
+
+func NewUsageReporter(client client.Client, cc *cache.Cache, cfg *configapi.UsageReports) *UsageReporter {
+	return &UsageReporter{
+		client:       client,
+		cache:        cc,
+		log:          ctrl.Log.WithName("usage-reporter"),
+		clock:        realClock,
+		period:       cfg.Period.Duration,
+		syncInterval: cfg.SyncInterval.Duration,
+	}
+}
+
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=usagereports,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=usagereports/status,verbs=get;update;patch
+
+// Start samples the usage every syncInterval until the context is done.
+func (r *UsageReporter) Start(ctx context.Context) error {
+	ctx = ctrl.LoggerInto(ctx, r.log)
+	wait.UntilWithContext(ctx, r.sync, r.syncInterval)
+	return nil
+}
+
+func (r *UsageReporter) sync(ctx context.Context) {
+	now := r.clock.Now()
+	from := r.lastSync
+	r.lastSync = now
+	if from.IsZero() {
+		// The first sample only sets the beginning of the reported usage.
+		return
+	}
+	if err := r.report(ctx, from, now); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to report the usage of the LocalQueues")
+	}
+}
+
+// localQueueUsage is the usage of a LocalQueue at the time of a sample.
+type localQueueUsage struct {
+	name         string
+	clusterQueue kueue.ClusterQueueReference
+	flavors      []kueue.LocalQueueFlavorUsage
+}
+
+// report adds the usage of the LocalQueues, sampled now, for the time
+// between from and to, splitting it among the periods it spans.
+func (r *UsageReporter) report(ctx context.Context, from, to time.Time) error {
+	var lqs kueue.LocalQueueList
+	if err := r.client.List(ctx, &lqs); err != nil {
+		return err
+	}
+	usageByNamespace := make(map[string][]localQueueUsage)
+	for i := range lqs.Items {
+		lq := &lqs.Items[i]
+		stats, err := r.cache.LocalQueueUsage(lq)
+		if err != nil || !hasUsage(stats.AdmittedResources) {
+			continue
+		}
+		usageByNamespace[lq.Namespace] = append(usageByNamespace[lq.Namespace], localQueueUsage{
+			name:         lq.Name,
+			clusterQueue: lq.Spec.ClusterQueue,
+			flavors:      stats.AdmittedResources,
+		})
+	}
+	namespaces := make([]string, 0, len(usageByNamespace))
+	for ns := range usageByNamespace {
+		namespaces = append(namespaces, ns)
+	}
+	slices.Sort(namespaces)
+
+	var errs []error
+	for start := r.periodStart(from); start.Before(to); start = start.Add(r.period) {
+		end := start.Add(r.period)
+		duration := minTime(to, end).Sub(maxTime(from, start))
+		for _, ns := range namespaces {
+			if err := r.addUsage(ctx, ns, start, minTime(to, end), usageByNamespace[ns], duration); err != nil {
+				errs = append(errs, fmt.Errorf("namespace %s: %w", ns, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// periodStart returns the beginning of the period containing t, with the
+// periods aligned to the Unix epoch.
+func (r *UsageReporter) periodStart(t time.Time) time.Time {
+	nanos := t.UnixNano()
+	return time.Unix(0, nanos-nanos%int64(r.period)).UTC()
+}
+
+func (r *UsageReporter) addUsage(ctx context.Context, namespace string, start, updateTime time.Time, usage []localQueueUsage, duration time.Duration) error {
+	var report kueuealpha.UsageReport
+	key := types.NamespacedName{Namespace: namespace, Name: UsageReportName(start)}
+	if err := r.client.Get(ctx, key, &report); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		report = kueuealpha.UsageReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Spec: kueuealpha.UsageReportSpec{
+				PeriodStart: metav1.NewTime(start),
+				PeriodEnd:   metav1.NewTime(start.Add(r.period)),
+			},
+		}
+		if err := r.client.Create(ctx, &report); err != nil {
+			return err
+		}
+	}
+	for _, lqUsage := range usage {
+		addLocalQueueUsageHours(&report.Status, lqUsage, duration)
+	}
+	report.Status.LastUpdateTime = ptr.To(metav1.NewTime(updateTime))
+	return r.client.Status().Update(ctx, &report)
+}
+
+// UsageReportName returns the name of the UsageReports of the period
+// beginning at start.
+func UsageReportName(start time.Time) string {
+	return "usage-" + start.UTC().Format("20060102-150405")
+}
+
+func hasUsage(flavors []kueue.LocalQueueFlavorUsage) bool {
+	for _, fu := range flavors {
+		for _, ru := range fu.Resources {
+			if !ru.Total.IsZero() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func addLocalQueueUsageHours(status *kueuealpha.UsageReportStatus, usage localQueueUsage, duration time.Duration) {
+	idx := slices.IndexFunc(status.LocalQueues, func(lq kueuealpha.LocalQueueUsageHours) bool { return lq.Name == usage.name })
+	if idx < 0 {
+		status.LocalQueues = append(status.LocalQueues, kueuealpha.LocalQueueUsageHours{Name: usage.name})
+		slices.SortFunc(status.LocalQueues, func(a, b kueuealpha.LocalQueueUsageHours) int { return cmp.Compare(a.Name, b.Name) })
+		idx = slices.IndexFunc(status.LocalQueues, func(lq kueuealpha.LocalQueueUsageHours) bool { return lq.Name == usage.name })
+	}
+	lq := &status.LocalQueues[idx]
+	lq.ClusterQueue = usage.clusterQueue
+	for _, fu := range usage.flavors {
+		for _, ru := range fu.Resources {
+			hours := resourceHours(ru.Total, duration)
+			if hours.IsZero() {
+				continue
+			}
+			lq.Flavors = addResourceUsageHours(lq.Flavors, fu.Name, ru, hours)
+			status.Flavors = addResourceUsageHours(status.Flavors, fu.Name, ru, hours)
+		}
+	}
+}
+
+func addResourceUsageHours(flavors []kueuealpha.FlavorUsageHours, flavor kueue.ResourceFlavorReference, ru kueue.LocalQueueResourceUsage, hours resource.Quantity) []kueuealpha.FlavorUsageHours {
+	fIdx := slices.IndexFunc(flavors, func(f kueuealpha.FlavorUsageHours) bool { return f.Name == flavor })
+	if fIdx < 0 {
+		flavors = append(flavors, kueuealpha.FlavorUsageHours{Name: flavor})
+		slices.SortFunc(flavors, func(a, b kueuealpha.FlavorUsageHours) int { return cmp.Compare(a.Name, b.Name) })
+		fIdx = slices.IndexFunc(flavors, func(f kueuealpha.FlavorUsageHours) bool { return f.Name == flavor })
+	}
+	f := &flavors[fIdx]
+	rIdx := slices.IndexFunc(f.Resources, func(r kueuealpha.ResourceUsageHours) bool { return r.Name == ru.Name })
+	if rIdx < 0 {
+		f.Resources = append(f.Resources, kueuealpha.ResourceUsageHours{Name: ru.Name, Hours: hours})
+		slices.SortFunc(f.Resources, func(a, b kueuealpha.ResourceUsageHours) int { return cmp.Compare(a.Name, b.Name) })
+		return flavors
+	}
+	f.Resources[rIdx].Hours.Add(hours)
+	return flavors
+}
+
+// resourceHours returns the quantity used during the duration, in
+// resource-hours, rounded to a thousandth.
+func resourceHours(q resource.Quantity, duration time.Duration) resource.Quantity {
+	milliHours := math.Round(float64(q.MilliValue()) * duration.Hours())
+	return *resource.NewMilliQuantity(int64(milliHours), q.Format)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestUsageReporter(t *testing.T) {
+	ctx := context.Background()
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		Obj()
+	lq := utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj()
+	idleLq := utiltesting.MakeLocalQueue("idle", "other").ClusterQueue("cq").Obj()
+	wl := utiltesting.MakeWorkload("wl", "ns").
+		Queue("lq").
+		Request(corev1.ResourceCPU, "2").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+		Admitted(true).
+		Obj()
+
+	cl := utiltesting.NewClientBuilder().
+		WithObjects(lq, idleLq).
+		WithStatusSubresource(&kueuealpha.UsageReport{}).
+		Build()
+	cqCache := cache.New(cl)
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Adding ClusterQueue: %v", err)
+	}
+	if !cqCache.AddOrUpdateWorkload(wl) {
+		t.Fatal("Workload was not added")
+	}
+
+	now := time.Date(2024, time.October, 14, 23, 30, 0, 0, time.UTC)
+	fakeClock := testingclock.NewFakeClock(now)
+	reporter := NewUsageReporter(cl, cqCache, &configapi.UsageReports{
+		Period:       &metav1.Duration{Duration: 24 * time.Hour},
+		SyncInterval: &metav1.Duration{Duration: time.Hour},
+	})
+	reporter.clock = fakeClock
+
+	reporter.sync(ctx)
+	fakeClock.Step(time.Hour)
+	reporter.sync(ctx)
+	fakeClock.Step(30 * time.Minute)
+	reporter.sync(ctx)
+
+	usage := func(hours string) []kueuealpha.FlavorUsageHours {
+		return []kueuealpha.FlavorUsageHours{{
+			Name: "default",
+			Resources: []kueuealpha.ResourceUsageHours{{
+				Name:  corev1.ResourceCPU,
+				Hours: resource.MustParse(hours),
+			}},
+		}}
+	}
+	firstDay := time.Date(2024, time.October, 14, 0, 0, 0, 0, time.UTC)
+	secondDay := firstDay.Add(24 * time.Hour)
+	wantReports := []kueuealpha.UsageReport{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "usage-20241014-000000"},
+			Spec: kueuealpha.UsageReportSpec{
+				PeriodStart: metav1.NewTime(firstDay),
+				PeriodEnd:   metav1.NewTime(secondDay),
+			},
+			Status: kueuealpha.UsageReportStatus{
+				Flavors: usage("1"),
+				LocalQueues: []kueuealpha.LocalQueueUsageHours{{
+					Name:         "lq",
+					ClusterQueue: "cq",
+					Flavors:      usage("1"),
+				}},
+				LastUpdateTime: ptr.To(metav1.NewTime(secondDay)),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "usage-20241015-000000"},
+			Spec: kueuealpha.UsageReportSpec{
+				PeriodStart: metav1.NewTime(secondDay),
+				PeriodEnd:   metav1.NewTime(secondDay.Add(24 * time.Hour)),
+			},
+			Status: kueuealpha.UsageReportStatus{
+				Flavors: usage("2"),
+				LocalQueues: []kueuealpha.LocalQueueUsageHours{{
+					Name:         "lq",
+					ClusterQueue: "cq",
+					Flavors:      usage("2"),
+				}},
+				LastUpdateTime: ptr.To(metav1.NewTime(fakeClock.Now())),
+			},
+		},
+	}
+
+	var reports kueuealpha.UsageReportList
+	if err := cl.List(ctx, &reports); err != nil {
+		t.Fatalf("Listing UsageReports: %v", err)
+	}
+	if diff := cmp.Diff(wantReports, reports.Items,
+		cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion"),
+		cmpopts.EquateApproxTime(time.Second)); diff != "" {
+		t.Errorf("Unexpected UsageReports (-want,+got):\n%s", diff)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -28,6 +29,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	nodev1 "k8s.io/api/node/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -45,13 +47,18 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/tracing"
 	utilac "sigs.k8s.io/kueue/pkg/util/admissioncheck"
+	clientutil "sigs.k8s.io/kueue/pkg/util/client"
+	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
 	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -60,6 +67,12 @@ var (
 	realClock = clock.RealClock{}
 )
 
+const (
+	// resizeRetryInterval is the interval after which the resize of a
+	// workload, for which the additional quota was not available, is retried.
+	resizeRetryInterval = 10 * time.Second
+)
+
 type waitForPodsReadyConfig struct {
 	timeout                     time.Duration
 	requeuingBackoffLimitCount  *int32
@@ -68,9 +81,73 @@ type waitForPodsReadyConfig struct {
 	requeuingBackoffJitter      float64
 }
 
+// forClusterQueue returns the configuration of the PodsReady timeout for the
+// workloads of a ClusterQueue, overriding the global configuration with the
+// fields set in the ClusterQueue. Returns nil if the ClusterQueue doesn't
+// wait for the pods to be ready.
+func (c *waitForPodsReadyConfig) forClusterQueue(cqCfg *kueue.ClusterQueueWaitForPodsReady) *waitForPodsReadyConfig {
+	if cqCfg == nil {
+		return c
+	}
+	if !ptr.Deref(cqCfg.Enable, c != nil) {
+		return nil
+	}
+	result := waitForPodsReadyConfig{
+		timeout:                     config.DefaultPodsReadyTimeout,
+		requeuingBackoffBaseSeconds: config.DefaultRequeuingBackoffBaseSeconds,
+		requeuingBackoffMaxDuration: config.DefaultRequeuingBackoffMaxSeconds * time.Second,
+		requeuingBackoffJitter:      workload.RequeuingBackoffJitter,
+	}
+	if c != nil {
+		result = *c
+	}
+	if cqCfg.Timeout != nil {
+		result.timeout = cqCfg.Timeout.Duration
+	}
+	if rs := cqCfg.RequeuingStrategy; rs != nil {
+		if rs.BackoffLimitCount != nil {
+			result.requeuingBackoffLimitCount = rs.BackoffLimitCount
+		}
+		if rs.BackoffBaseSeconds != nil {
+			result.requeuingBackoffBaseSeconds = *rs.BackoffBaseSeconds
+		}
+		if rs.BackoffMaxSeconds != nil {
+			result.requeuingBackoffMaxDuration = time.Duration(*rs.BackoffMaxSeconds) * time.Second
+		}
+	}
+	return &result
+}
+
+type autoReactivationConfig struct {
+	reasonCategories   sets.Set[kueue.DeactivationReasonCategory]
+	backoffLimitCount  *int32
+	backoffBaseSeconds int32
+	backoffMaxDuration time.Duration
+}
+
+// applies returns true if the workload should be reactivated after the deactivation.
+// The workloads deactivated by setting spec.active to false are not reactivated.
+func (c *autoReactivationConfig) applies(deactivation *kueue.WorkloadDeactivation) bool {
+	if c == nil || deactivation.Reason == kueue.WorkloadDeactivated || !c.reasonCategories.Has(deactivation.Category) {
+		return false
+	}
+	return c.backoffLimitCount == nil || deactivation.ReactivationCount < *c.backoffLimitCount
+}
+
+// backoff returns the time to wait before the reactivation of a workload
+// which was already reactivated count times.
+func (c *autoReactivationConfig) backoff(count int32) time.Duration {
+	backoff := time.Duration(c.backoffBaseSeconds) * time.Second
+	for i := int32(0); i < count && backoff < c.backoffMaxDuration; i++ {
+		backoff *= 2
+	}
+	return min(backoff, c.backoffMaxDuration)
+}
+
 type options struct {
 	watchers               []WorkloadUpdateWatcher
 	waitForPodsReadyConfig *waitForPodsReadyConfig
+	autoReactivationConfig *autoReactivationConfig
 }
 
 // Option configures the reconciler.
@@ -83,6 +160,13 @@ func WithWaitForPodsReady(value *waitForPodsReadyConfig) Option {
 	}
 }
 
+// WithAutoReactivation indicates the configuration for the automatic reactivation of the workloads.
+func WithAutoReactivation(value *autoReactivationConfig) Option {
+	return func(o *options) {
+		o.autoReactivationConfig = value
+	}
+}
+
 // WithWorkloadUpdateWatchers allows to specify the workload update watchers
 func WithWorkloadUpdateWatchers(value ...WorkloadUpdateWatcher) Option {
 	return func(o *options) {
@@ -103,9 +187,14 @@ type WorkloadReconciler struct {
 	cache            *cache.Cache
 	client           client.Client
 	watchers         []WorkloadUpdateWatcher
-	waitForPodsReady *waitForPodsReadyConfig
+	autoReactivation *autoReactivationConfig
 	recorder         record.EventRecorder
 	clock            clock.Clock
+
+	// waitForPodsReadyLock protects waitForPodsReady, which can be replaced
+	// while the reconciler is running.
+	waitForPodsReadyLock sync.RWMutex
+	waitForPodsReady     *waitForPodsReadyConfig
 }
 
 func NewWorkloadReconciler(client client.Client, queues *queue.Manager, cache *cache.Cache, recorder record.EventRecorder, opts ...Option) *WorkloadReconciler {
@@ -121,17 +210,33 @@ func NewWorkloadReconciler(client client.Client, queues *queue.Manager, cache *c
 		cache:            cache,
 		watchers:         options.watchers,
 		waitForPodsReady: options.waitForPodsReadyConfig,
+		autoReactivation: options.autoReactivationConfig,
 		recorder:         recorder,
 		clock:            realClock,
 	}
 }
 
+// SetWaitForPodsReady replaces the configuration of the PodsReady timeout
+// and of the requeuing backoff. A nil configuration disables the timeout.
+func (r *WorkloadReconciler) SetWaitForPodsReady(value *waitForPodsReadyConfig) {
+	r.waitForPodsReadyLock.Lock()
+	defer r.waitForPodsReadyLock.Unlock()
+	r.waitForPodsReady = value
+}
+
+func (r *WorkloadReconciler) waitForPodsReadyConfig() *waitForPodsReadyConfig {
+	r.waitForPodsReadyLock.RLock()
+	defer r.waitForPodsReadyLock.RUnlock()
+	return r.waitForPodsReady
+}
+
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;watch;update;patch
 // +kubebuilder:rbac:groups="",resources=limitranges,verbs=get;list;watch
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/finalizers,verbs=update
 // +kubebuilder:rbac:groups=node.k8s.io,resources=runtimeclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 
 func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	var wl kueue.Workload
@@ -160,11 +265,11 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 		var updated bool
 		if cond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadRequeued); cond != nil && cond.Status == metav1.ConditionFalse {
-			switch cond.Reason {
-			case kueue.WorkloadDeactivated, kueue.WorkloadEvictedByDeactivation:
+			switch {
+			case workload.IsDeactivationReason(cond.Reason):
 				workload.SetRequeuedCondition(&wl, kueue.WorkloadReactivated, "The workload was reactivated", true)
 				updated = true
-			case kueue.WorkloadEvictedByPodsReadyTimeout, kueue.WorkloadEvictedByAdmissionCheck:
+			case cond.Reason == kueue.WorkloadEvictedByPodsReadyTimeout || cond.Reason == kueue.WorkloadEvictedByAdmissionCheck:
 				var requeueAfter time.Duration
 				if wl.Status.RequeueState != nil && wl.Status.RequeueState.RequeueAt != nil {
 					requeueAfter = wl.Status.RequeueState.RequeueAt.Time.Sub(r.clock.Now())
@@ -179,6 +284,14 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 				updated = true
 			}
 		}
+		if deactivation := wl.Status.Deactivation; deactivation != nil && deactivation.ReactivateAt != nil {
+			// The workload was reactivated, either automatically or by the user.
+			if !r.clock.Now().Before(deactivation.ReactivateAt.Time) {
+				deactivation.ReactivationCount++
+			}
+			deactivation.ReactivateAt = nil
+			updated = true
+		}
 
 		if updated {
 			return ctrl.Result{}, workload.ApplyAdmissionStatus(ctx, r.client, &wl, true)
@@ -189,14 +302,24 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		message := "The workload is deactivated"
 		dtCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadDeactivationTarget)
 		if !apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) {
+			category := workload.DeactivationEvictionCategory("")
 			if dtCond != nil {
 				reason = fmt.Sprintf("%sDueTo%s", reason, dtCond.Reason)
 				message = fmt.Sprintf("%s due to %s", message, dtCond.Message)
+				category = workload.DeactivationEvictionCategory(dtCond.Reason)
 			}
-			workload.SetEvictedCondition(&wl, reason, message)
+			workload.SetEvictedCondition(&wl, reason, category, message)
 			updated = true
 			evicted = true
 		}
+		if evicted || dtCond != nil {
+			deactivationReason := kueue.WorkloadDeactivated
+			if dtCond != nil {
+				deactivationReason = dtCond.Reason
+			}
+			r.setDeactivation(&wl, deactivationReason)
+			updated = true
+		}
 		if dtCond != nil {
 			apimeta.RemoveStatusCondition(&wl.Status.Conditions, kueue.WorkloadDeactivationTarget)
 		}
@@ -214,6 +337,11 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			}
 			return ctrl.Result{}, nil
 		}
+		if !workload.HasQuotaReservation(&wl) {
+			if reactivated, reactivateAfter, err := r.reconcileAutoReactivation(ctx, &wl); reactivated || reactivateAfter > 0 || err != nil {
+				return ctrl.Result{RequeueAfter: reactivateAfter}, err
+			}
+		}
 	}
 
 	lq := kueue.LocalQueue{}
@@ -230,9 +358,9 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	}
 
 	cqName, cqOk := r.queues.ClusterQueueForWorkload(&wl)
+	cq := kueue.ClusterQueue{}
 	if cqOk {
 		// because we need to react to API cluster cq events, the list of checks from a cache can lead to race conditions
-		cq := kueue.ClusterQueue{}
 		if err := r.client.Get(ctx, types.NamespacedName{Name: cqName}, &cq); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -257,8 +385,14 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			quotaReservedCondition := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
 			quotaReservedWaitTime := r.clock.Since(quotaReservedCondition.LastTransitionTime.Time)
 			r.recorder.Eventf(&wl, corev1.EventTypeNormal, "Admitted", "Admitted by ClusterQueue %v, wait time since reservation was %.0fs", wl.Status.Admission.ClusterQueue, quotaReservedWaitTime.Seconds())
+			tracing.RecordWorkloadSpan(ctx, &wl, tracing.SpanAdmissionChecks, quotaReservedCondition.LastTransitionTime.Time,
+				tracing.ClusterQueueKey.String(cqName),
+				tracing.AdmissionCheckKey.StringSlice(utilslices.Map(wl.Status.AdmissionChecks, func(ac *kueue.AdmissionCheckState) string { return ac.Name })),
+			)
 			metrics.AdmittedWorkload(kueue.ClusterQueueReference(cqName), queuedWaitTime)
 			metrics.AdmissionChecksWaitTime(kueue.ClusterQueueReference(cqName), quotaReservedWaitTime)
+			metrics.ReportTimeToAdmission(kueue.ClusterQueueReference(cqName), wl.Spec.PriorityClassName, queuedWaitTime)
+			metrics.ReportAdmissionLatency(kueue.ClusterQueueReference(cqName), wl.Spec.PriorityClassName, metrics.AdmissionLatencyAdmissionCheckWait, quotaReservedWaitTime)
 			if features.Enabled(features.LocalQueueMetrics) {
 				metrics.LocalQueueAdmittedWorkload(metrics.LQRefFromWorkload(&wl), queuedWaitTime)
 				metrics.LocalQueueAdmissionChecksWaitTime(metrics.LQRefFromWorkload(&wl), quotaReservedWaitTime)
@@ -272,6 +406,11 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			return ctrl.Result{}, err
 		}
 
+		checksTimedOut, pendingChecksRecheckAfter, err := r.reconcilePendingChecksTimeout(ctx, &wl)
+		if checksTimedOut || err != nil {
+			return ctrl.Result{}, err
+		}
+
 		if updated, err := r.reconcileOnLocalQueueActiveState(ctx, &wl, lqExists, &lq); updated || err != nil {
 			return ctrl.Result{}, err
 		}
@@ -280,19 +419,26 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			return ctrl.Result{}, err
 		}
 
-		podsReadyRecheckAfter, err := r.reconcileNotReadyTimeout(ctx, req, &wl)
+		resized, resizeRecheckAfter, err := r.reconcileResize(ctx, &wl)
+		if resized || err != nil {
+			return ctrl.Result{RequeueAfter: resizeRecheckAfter}, err
+		}
+
+		podsReadyRecheckAfter, err := r.reconcileNotReadyTimeout(ctx, req, &wl, &cq)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
-		maxExecRecheckAfter, err := r.reconcileMaxExecutionTime(ctx, &wl)
+		maxExecRecheckAfter, err := r.reconcileMaxExecutionTime(ctx, &wl, &cq)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
 
 		// get the minimun non-zero value
-		recheckAfter := min(podsReadyRecheckAfter, maxExecRecheckAfter)
-		if recheckAfter == 0 {
-			recheckAfter = max(podsReadyRecheckAfter, maxExecRecheckAfter)
+		var recheckAfter time.Duration
+		for _, d := range []time.Duration{podsReadyRecheckAfter, maxExecRecheckAfter, pendingChecksRecheckAfter, resizeRecheckAfter} {
+			if d > 0 && (recheckAfter == 0 || d < recheckAfter) {
+				recheckAfter = d
+			}
 		}
 		return ctrl.Result{RequeueAfter: recheckAfter}, nil
 	}
@@ -324,9 +470,38 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		}
 	}
 
+	if cqOk && workload.IsActive(&wl) && cq.Annotations[controllerconsts.AdmitAllAnnotation] == "true" && !workload.AdmittedByBypass(&wl) {
+		return ctrl.Result{}, r.admitByBypass(ctx, &wl, cqName)
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// admitByBypass marks the pending workload of a ClusterQueue which admits all the
+// workloads, so that its job is started, and never stopped, without waiting for
+// its admission. The workload stays pending, so that its quota is reserved once
+// it's available.
+func (r *WorkloadReconciler) admitByBypass(ctx context.Context, wl *kueue.Workload, cqName string) error {
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Admitting the workload by bypass, the ClusterQueue admits all the workloads", "clusterQueue", klog.KRef("", cqName))
+	err := clientutil.Patch(ctx, r.client, wl, true, func() (bool, error) {
+		if wl.Labels == nil {
+			wl.Labels = make(map[string]string, 1)
+		}
+		wl.Labels[controllerconsts.ShadowModeLabel] = "true"
+		if wl.Annotations == nil {
+			wl.Annotations = make(map[string]string, 1)
+		}
+		wl.Annotations[controllerconsts.AdmitAllAnnotation] = "true"
+		return true, nil
+	})
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	r.recorder.Eventf(wl, corev1.EventTypeWarning, "AdmittedByBypass", "The ClusterQueue %s admits all the workloads, starting the job without admission", cqName)
+	return nil
+}
+
 // isDisabledRequeuedByClusterQueueStopped returns true if the workload is unset requeued by cluster queue stopped.
 func isDisabledRequeuedByClusterQueueStopped(w *kueue.Workload) bool {
 	return isDisabledRequeuedByReason(w, kueue.WorkloadEvictedByClusterQueueStopped)
@@ -343,14 +518,52 @@ func isDisabledRequeuedByReason(w *kueue.Workload, reason string) bool {
 	return cond != nil && cond.Status == metav1.ConditionFalse && cond.Reason == reason
 }
 
-// reconcileMaxExecutionTime deactivates the workload if its MaximumExecutionTimeSeconds is exceeded or returns a retry after value.
-func (r *WorkloadReconciler) reconcileMaxExecutionTime(ctx context.Context, wl *kueue.Workload) (time.Duration, error) {
+// setDeactivation records the reason for the deactivation of the workload and,
+// when the auto reactivation policy applies to it, the time for reactivating it.
+func (r *WorkloadReconciler) setDeactivation(wl *kueue.Workload, reason string) {
+	deactivation := &kueue.WorkloadDeactivation{
+		Reason:   reason,
+		Category: workload.DeactivationReasonCategory(reason),
+	}
+	if wl.Status.Deactivation != nil {
+		deactivation.ReactivationCount = wl.Status.Deactivation.ReactivationCount
+	}
+	if r.autoReactivation.applies(deactivation) {
+		deactivation.ReactivateAt = ptr.To(metav1.NewTime(r.clock.Now().Add(r.autoReactivation.backoff(deactivation.ReactivationCount))))
+	}
+	wl.Status.Deactivation = deactivation
+}
+
+// reconcileAutoReactivation reactivates the deactivated workload once its reactivation
+// time is reached, or returns the time remaining until then. It returns true if the
+// workload was reactivated.
+func (r *WorkloadReconciler) reconcileAutoReactivation(ctx context.Context, wl *kueue.Workload) (bool, time.Duration, error) {
+	deactivation := wl.Status.Deactivation
+	if deactivation == nil || deactivation.ReactivateAt == nil {
+		return false, 0, nil
+	}
+	if remaining := deactivation.ReactivateAt.Sub(r.clock.Now()); remaining > 0 {
+		return false, remaining, nil
+	}
+	wl.Spec.Active = ptr.To(true)
+	if err := r.client.Update(ctx, wl); err != nil {
+		return false, 0, client.IgnoreNotFound(err)
+	}
+	ctrl.LoggerFrom(ctx).V(2).Info("Reactivated the workload", "reason", deactivation.Reason, "reactivationCount", deactivation.ReactivationCount+1)
+	r.recorder.Eventf(wl, corev1.EventTypeNormal, kueue.WorkloadReactivated, "The workload was reactivated after being deactivated due to %s", deactivation.Reason)
+	return true, 0, nil
+}
+
+// reconcileMaxExecutionTime deactivates the workload if its maximum execution time is exceeded or returns a retry after value.
+// The maximum execution time is the smaller of the values set on the workload and on its ClusterQueue.
+func (r *WorkloadReconciler) reconcileMaxExecutionTime(ctx context.Context, wl *kueue.Workload, cq *kueue.ClusterQueue) (time.Duration, error) {
 	admittedCondition := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadAdmitted)
-	if admittedCondition == nil || admittedCondition.Status != metav1.ConditionTrue || wl.Spec.MaximumExecutionTimeSeconds == nil {
+	maxExecTime := maximumExecutionTimeSeconds(wl, cq)
+	if admittedCondition == nil || admittedCondition.Status != metav1.ConditionTrue || maxExecTime == nil {
 		return 0, nil
 	}
 
-	remainingTime := time.Duration(*wl.Spec.MaximumExecutionTimeSeconds-ptr.Deref(wl.Status.AccumulatedPastExexcutionTimeSeconds, 0))*time.Second - r.clock.Since(admittedCondition.LastTransitionTime.Time)
+	remainingTime := time.Duration(*maxExecTime-ptr.Deref(wl.Status.AccumulatedPastExexcutionTimeSeconds, 0))*time.Second - r.clock.Since(admittedCondition.LastTransitionTime.Time)
 	if remainingTime > 0 {
 		return remainingTime, nil
 	}
@@ -361,11 +574,106 @@ func (r *WorkloadReconciler) reconcileMaxExecutionTime(ctx context.Context, wl *
 		if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true); err != nil {
 			return 0, err
 		}
-		r.recorder.Eventf(wl, corev1.EventTypeWarning, kueue.WorkloadMaximumExecutionTimeExceeded, "The maximum execution time (%ds) exceeded", *wl.Spec.MaximumExecutionTimeSeconds)
+		r.recorder.Eventf(wl, corev1.EventTypeWarning, kueue.WorkloadMaximumExecutionTimeExceeded, "The maximum execution time (%ds) exceeded", *maxExecTime)
 	}
 	return 0, nil
 }
 
+// maximumExecutionTimeSeconds returns the effective maximum execution time of the workload,
+// taking into account the limit configured on its ClusterQueue.
+func maximumExecutionTimeSeconds(wl *kueue.Workload, cq *kueue.ClusterQueue) *int32 {
+	wlLimit := wl.Spec.MaximumExecutionTimeSeconds
+	var cqLimit *int32
+	if cq != nil {
+		cqLimit = cq.Spec.MaximumExecutionTimeSeconds
+	}
+	switch {
+	case wlLimit == nil:
+		return cqLimit
+	case cqLimit == nil:
+		return wlLimit
+	default:
+		return ptr.To(min(*wlLimit, *cqLimit))
+	}
+}
+
+// reconcileResize updates the admission of the workload to the counts of its podSets,
+// if they changed after the quota was reserved, and to the resources of its pods, if
+// they were resized in place. The resizes which need additional quota are requested
+// to the scheduler, which reserves it in the scheduling cycle, and it returns a
+// retry after value to request them again while they are pending. When the scheduler
+// marks the resize as pending for insufficient quota and the pods were resized, the
+// workload is evicted. It returns true if the workload status was updated.
+func (r *WorkloadReconciler) reconcileResize(ctx context.Context, wl *kueue.Workload) (bool, time.Duration, error) {
+	if apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) {
+		return false, 0, nil
+	}
+	resizedAdmission := wl.Status.Admission
+	if features.Enabled(features.ElasticWorkloadResize) && workload.ResizeRequested(wl) {
+		resizedAdmission = workload.ResizedAdmission(wl)
+	}
+	var podsResized bool
+	if features.Enabled(features.InPlacePodResize) {
+		podsAdmission, err := r.podsResizedAdmission(ctx, wl, resizedAdmission)
+		if err != nil {
+			return false, 0, err
+		}
+		podsResized = !equality.Semantic.DeepEqual(resizedAdmission, podsAdmission)
+		resizedAdmission = podsAdmission
+	}
+	if equality.Semantic.DeepEqual(wl.Status.Admission, resizedAdmission) {
+		return false, 0, nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+
+	resizedWl := wl.DeepCopy()
+	resizedWl.Status.Admission = resizedAdmission
+	shrunk, err := r.cache.ShrinkWorkload(wl, resizedWl)
+	if err != nil {
+		return false, 0, err
+	}
+
+	if !shrunk && podsResized && workload.ResizePendingForQuota(wl) {
+		log.V(3).Info("Workload is evicted, the resized pods exceed the available quota")
+		cqName := string(wl.Status.Admission.ClusterQueue)
+		message := fmt.Sprintf("The resized pods exceed the available quota in ClusterQueue %s", cqName)
+		workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByPodResize, kueue.EvictionCategoryOther, message)
+		if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true); err != nil {
+			return false, 0, client.IgnoreNotFound(err)
+		}
+		workload.ReportEvictedWorkload(r.recorder, wl, cqName, kueue.WorkloadEvictedByPodResize, message)
+		return true, 0, nil
+	}
+
+	if !shrunk {
+		log.V(3).Info("Requesting the additional quota of the resized workload")
+		r.queues.RequestResize(resizedWl)
+		return false, resizeRetryInterval, nil
+	}
+
+	workload.SetResizedCondition(resizedWl)
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, resizedWl, true); err != nil {
+		// Restore the usage of the workload before the resize.
+		r.cache.AddOrUpdateWorkload(wl)
+		return false, 0, client.IgnoreNotFound(err)
+	}
+	log.V(2).Info("Workload resized", "clusterQueue", klog.KRef("", string(wl.Status.Admission.ClusterQueue)))
+	r.recorder.Eventf(wl, corev1.EventTypeNormal, "Resized", "Resized in ClusterQueue %v", wl.Status.Admission.ClusterQueue)
+	return true, 0, nil
+}
+
+// podsResizedAdmission returns the admission with the resource usage computed from
+// the resources of the pods of the workload.
+func (r *WorkloadReconciler) podsResizedAdmission(ctx context.Context, wl *kueue.Workload, admission *kueue.Admission) (*kueue.Admission, error) {
+	var pods corev1.PodList
+	if err := r.client.List(ctx, &pods, client.InNamespace(wl.Namespace), client.MatchingFields{indexer.PodWorkloadKey: wl.Name}); err != nil {
+		return nil, err
+	}
+	wlCopy := wl.DeepCopy()
+	workload.AdjustResources(ctx, r.client, wlCopy)
+	return workload.PodsResizedAdmission(wlCopy, admission, pods.Items, r.cache.WorkloadInfoOptions()...), nil
+}
+
 // reconcileCheckBasedEviction returns true if Workload has been deactivated or evicted
 func (r *WorkloadReconciler) reconcileCheckBasedEviction(ctx context.Context, wl *kueue.Workload) (bool, error) {
 	if apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) || (!workload.HasRetryChecks(wl) && !workload.HasRejectedChecks(wl)) {
@@ -389,7 +697,7 @@ func (r *WorkloadReconciler) reconcileCheckBasedEviction(ctx context.Context, wl
 	}
 	// at this point we know a Workload has at least one Retry AdmissionCheck
 	message := "At least one admission check is false"
-	workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByAdmissionCheck, message)
+	workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByAdmissionCheck, kueue.EvictionCategoryChecksFailed, message)
 	workload.ResetChecksOnEviction(wl, r.clock.Now())
 	if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true); err != nil {
 		return false, client.IgnoreNotFound(err)
@@ -399,9 +707,68 @@ func (r *WorkloadReconciler) reconcileCheckBasedEviction(ctx context.Context, wl
 	return true, nil
 }
 
+// reconcilePendingChecksTimeout sets to Retry the admission checks which stayed
+// Pending, since the quota reservation of the workload, for longer than their
+// pendingTimeout, so that the workload is evicted and requeued.
+// Returns whether any check timed out, or else the time after which the next
+// check times out.
+func (r *WorkloadReconciler) reconcilePendingChecksTimeout(ctx context.Context, wl *kueue.Workload) (bool, time.Duration, error) {
+	if apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) {
+		return false, 0, nil
+	}
+	now := r.clock.Now()
+	quotaReserved := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
+	var timedOut []string
+	var recheckAfter time.Duration
+	for i := range wl.Status.AdmissionChecks {
+		check := wl.Status.AdmissionChecks[i]
+		if check.State != kueue.CheckStatePending {
+			continue
+		}
+		ac := &kueue.AdmissionCheck{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: check.Name}, ac); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return false, 0, err
+		}
+		if ac.Spec.PendingTimeout == nil || ac.Spec.PendingTimeout.Duration <= 0 {
+			continue
+		}
+		pendingSince := check.LastTransitionTime.Time
+		if quotaReserved != nil && quotaReserved.LastTransitionTime.After(pendingSince) {
+			pendingSince = quotaReserved.LastTransitionTime.Time
+		}
+		if remaining := pendingSince.Add(ac.Spec.PendingTimeout.Duration).Sub(now); remaining > 0 {
+			if recheckAfter == 0 || remaining < recheckAfter {
+				recheckAfter = remaining
+			}
+			continue
+		}
+		workload.SetAdmissionCheckState(&wl.Status.AdmissionChecks, kueue.AdmissionCheckState{
+			Name:               check.Name,
+			State:              kueue.CheckStateRetry,
+			LastTransitionTime: metav1.NewTime(now),
+			Message:            fmt.Sprintf("Pending for longer than the timeout of %s", ac.Spec.PendingTimeout.Duration),
+		})
+		timedOut = append(timedOut, check.Name)
+	}
+	if len(timedOut) == 0 {
+		return false, recheckAfter, nil
+	}
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true); err != nil {
+		return false, 0, client.IgnoreNotFound(err)
+	}
+	ctrl.LoggerFrom(ctx).V(3).Info("Admission checks timed out", "admissionChecks", timedOut)
+	r.recorder.Eventf(wl, corev1.EventTypeWarning, "AdmissionCheckTimedOut", "The admission checks %v were Pending for longer than their timeout", timedOut)
+	return true, 0, nil
+}
+
 func (r *WorkloadReconciler) reconcileSyncAdmissionChecks(ctx context.Context, wl *kueue.Workload, cq *kueue.ClusterQueue) (bool, error) {
 	log := ctrl.LoggerFrom(ctx)
 	admissionChecks := workload.AdmissionChecksForWorkload(log, wl, utilac.NewAdmissionChecks(cq))
+	// The workloads matching their WorkloadTemplate skip some admission checks.
+	admissionChecks = admissionChecks.Difference(r.cache.SkippedAdmissionChecks(wl))
 	newChecks, shouldUpdate := syncAdmissionCheckConditions(wl.Status.AdmissionChecks, admissionChecks)
 	if shouldUpdate {
 		log.V(3).Info("The workload needs admission checks updates", "clusterQueue", klog.KRef("", cq.Name), "admissionChecks", admissionChecks)
@@ -426,13 +793,14 @@ func (r *WorkloadReconciler) reconcileOnLocalQueueActiveState(ctx context.Contex
 			return false, nil
 		}
 		log.V(3).Info("Workload is evicted because the LocalQueue is stopped", "localQueue", klog.KRef(wl.Namespace, wl.Spec.QueueName))
-		workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByLocalQueueStopped, "The LocalQueue is stopped")
+		workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByLocalQueueStopped, kueue.EvictionCategoryAdminStop, "The LocalQueue is stopped")
 		workload.ResetChecksOnEviction(wl, r.clock.Now())
 		err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true)
 		if err == nil {
 			cqName := string(lq.Spec.ClusterQueue)
 			if slices.Contains(r.queues.GetClusterQueueNames(), cqName) {
 				metrics.ReportEvictedWorkloads(cqName, kueue.WorkloadEvictedByLocalQueueStopped)
+				metrics.ReportEvictionCategory(cqName, kueue.EvictionCategoryAdminStop)
 				if features.Enabled(features.LocalQueueMetrics) {
 					metrics.ReportLocalQueueEvictedWorkloads(metrics.LQRefFromWorkload(wl), kueue.WorkloadEvictedByLocalQueueStopped)
 				}
@@ -477,7 +845,7 @@ func (r *WorkloadReconciler) reconcileOnClusterQueueActiveState(ctx context.Cont
 		}
 		log.V(3).Info("Workload is evicted because the ClusterQueue is stopped", "clusterQueue", klog.KRef("", cqName))
 		message := "The ClusterQueue is stopped"
-		workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByClusterQueueStopped, message)
+		workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByClusterQueueStopped, kueue.EvictionCategoryAdminStop, message)
 		workload.ResetChecksOnEviction(wl, r.clock.Now())
 		err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true)
 		if err == nil {
@@ -536,14 +904,15 @@ func syncAdmissionCheckConditions(conds []kueue.AdmissionCheckState, admissionCh
 	return conds, shouldUpdate
 }
 
-func (r *WorkloadReconciler) reconcileNotReadyTimeout(ctx context.Context, req ctrl.Request, wl *kueue.Workload) (time.Duration, error) {
+func (r *WorkloadReconciler) reconcileNotReadyTimeout(ctx context.Context, req ctrl.Request, wl *kueue.Workload, cq *kueue.ClusterQueue) (time.Duration, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	if !workload.IsActive(wl) || apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) {
 		// the workload has already been evicted by the PodsReadyTimeout or been deactivated.
 		return 0, nil
 	}
-	countingTowardsTimeout, recheckAfter := r.admittedNotReadyWorkload(wl)
+	podsReadyCfg := r.waitForPodsReadyConfig().forClusterQueue(cq.Spec.WaitForPodsReady)
+	countingTowardsTimeout, recheckAfter := r.admittedNotReadyWorkload(podsReadyCfg, wl)
 	if !countingTowardsTimeout {
 		return 0, nil
 	}
@@ -552,11 +921,11 @@ func (r *WorkloadReconciler) reconcileNotReadyTimeout(ctx context.Context, req c
 		return recheckAfter, nil
 	}
 	log.V(2).Info("Start the eviction of the workload due to exceeding the PodsReady timeout")
-	if deactivated, err := r.triggerDeactivationOrBackoffRequeue(ctx, wl); deactivated || err != nil {
+	if deactivated, err := r.triggerDeactivationOrBackoffRequeue(ctx, podsReadyCfg, wl); deactivated || err != nil {
 		return 0, client.IgnoreNotFound(err)
 	}
 	message := fmt.Sprintf("Exceeded the PodsReady timeout %s", req.NamespacedName.String())
-	workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByPodsReadyTimeout, message)
+	workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByPodsReadyTimeout, kueue.EvictionCategoryPodsReadyTimeout, message)
 	workload.ResetChecksOnEviction(wl, r.clock.Now())
 	err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true)
 	if err == nil {
@@ -570,12 +939,12 @@ func (r *WorkloadReconciler) reconcileNotReadyTimeout(ctx context.Context, req c
 // if a re-queued number has already exceeded the limit of re-queuing backoff.
 // Otherwise, it increments a re-queueing count and update a time to be re-queued.
 // It returns true as a first value if a workload triggered deactivation.
-func (r *WorkloadReconciler) triggerDeactivationOrBackoffRequeue(ctx context.Context, wl *kueue.Workload) (bool, error) {
+func (r *WorkloadReconciler) triggerDeactivationOrBackoffRequeue(ctx context.Context, podsReadyCfg *waitForPodsReadyConfig, wl *kueue.Workload) (bool, error) {
 	if wl.Status.RequeueState == nil {
 		wl.Status.RequeueState = &kueue.RequeueState{}
 	}
 	// If requeuingBackoffLimitCount equals to null, the workloads is repeatedly and endless re-queued.
-	if r.waitForPodsReady.requeuingBackoffLimitCount != nil && ptr.Deref(wl.Status.RequeueState.Count, 0)+1 > *r.waitForPodsReady.requeuingBackoffLimitCount {
+	if podsReadyCfg.requeuingBackoffLimitCount != nil && ptr.Deref(wl.Status.RequeueState.Count, 0)+1 > *podsReadyCfg.requeuingBackoffLimitCount {
 		workload.SetDeactivationTarget(wl, kueue.WorkloadRequeuingLimitExceeded,
 			"exceeding the maximum number of re-queuing retries")
 		if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true); err != nil {
@@ -583,7 +952,7 @@ func (r *WorkloadReconciler) triggerDeactivationOrBackoffRequeue(ctx context.Con
 		}
 		return true, nil
 	}
-	workload.UpdateRequeueState(wl, r.waitForPodsReady.requeuingBackoffBaseSeconds, int32(r.waitForPodsReady.requeuingBackoffMaxDuration.Seconds()), r.clock)
+	workload.UpdateRequeueState(wl, podsReadyCfg.requeuingBackoffBaseSeconds, int32(podsReadyCfg.requeuingBackoffMaxDuration.Seconds()), podsReadyCfg.requeuingBackoffJitter, r.clock)
 	return false, nil
 }
 
@@ -727,7 +1096,8 @@ func (r *WorkloadReconciler) Update(e event.UpdateEvent) bool {
 		}
 		immediate := backoff <= 0
 		// trigger the move of associated inadmissibleWorkloads, if there are any.
-		r.queues.QueueAssociatedInadmissibleWorkloadsAfter(ctx, wl, func() {
+		// The old workload holds the flavors in which the quota is released.
+		r.queues.QueueAssociatedInadmissibleWorkloadsAfter(ctx, oldWl, func() {
 			// Delete the workload from cache while holding the queues lock
 			// to guarantee that requeued workloads are taken into account before
 			// the next scheduling cycle.
@@ -757,7 +1127,8 @@ func (r *WorkloadReconciler) Update(e event.UpdateEvent) bool {
 				}
 			})
 		}
-	case prevStatus == workload.StatusAdmitted && status == workload.StatusAdmitted && !equality.Semantic.DeepEqual(oldWl.Status.ReclaimablePods, wl.Status.ReclaimablePods):
+	case prevStatus == workload.StatusAdmitted && status == workload.StatusAdmitted && (!equality.Semantic.DeepEqual(oldWl.Status.ReclaimablePods, wl.Status.ReclaimablePods) ||
+		!equality.Semantic.DeepEqual(oldWl.Status.Admission, wl.Status.Admission)):
 		// trigger the move of associated inadmissibleWorkloads, if there are any.
 		r.queues.QueueAssociatedInadmissibleWorkloadsAfter(ctx, wl, func() {
 			// Update the workload from cache while holding the queues lock
@@ -794,14 +1165,17 @@ func (r *WorkloadReconciler) notifyWatchers(oldWl, newWl *kueue.Workload) {
 func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
 	ruh := &resourceUpdatesHandler{r: r}
 	wqh := &workloadQueueHandler{r: r}
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&kueue.Workload{}).
 		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
 		Watches(&corev1.LimitRange{}, ruh).
 		Watches(&nodev1.RuntimeClass{}, ruh).
 		Watches(&kueue.ClusterQueue{}, wqh).
-		Watches(&kueue.LocalQueue{}, wqh).
-		WithEventFilter(r).
+		Watches(&kueue.LocalQueue{}, wqh)
+	if features.Enabled(features.InPlacePodResize) {
+		b = b.Watches(&corev1.Pod{}, &podResizeHandler{})
+	}
+	return b.WithEventFilter(r).
 		Complete(WithLeadingManager(mgr, r, &kueue.Workload{}, cfg))
 }
 
@@ -811,8 +1185,8 @@ func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Conf
 // True (False or not set). The second value is the remaining time to exceed the
 // specified timeout counted since max of the LastTransitionTime's for the
 // Admitted and PodsReady conditions.
-func (r *WorkloadReconciler) admittedNotReadyWorkload(wl *kueue.Workload) (bool, time.Duration) {
-	if r.waitForPodsReady == nil {
+func (r *WorkloadReconciler) admittedNotReadyWorkload(podsReadyCfg *waitForPodsReadyConfig, wl *kueue.Workload) (bool, time.Duration) {
+	if podsReadyCfg == nil {
 		// the timeout is not configured for the workload controller
 		return false, 0
 	}
@@ -830,7 +1204,7 @@ func (r *WorkloadReconciler) admittedNotReadyWorkload(wl *kueue.Workload) (bool,
 	if podsReadyCond != nil && podsReadyCond.Status == metav1.ConditionFalse && podsReadyCond.LastTransitionTime.After(admittedCond.LastTransitionTime.Time) {
 		elapsedTime = r.clock.Since(podsReadyCond.LastTransitionTime.Time)
 	}
-	waitFor := r.waitForPodsReady.timeout - elapsedTime
+	waitFor := podsReadyCfg.timeout - elapsedTime
 	if waitFor < 0 {
 		waitFor = 0
 	}
@@ -900,6 +1274,58 @@ func (h *resourceUpdatesHandler) queueReconcileForPending(ctx context.Context, _
 	}
 }
 
+// podResizeHandler queues the reconcile of the workload of a pod when the
+// resources of the pod change, for example, when it's resized in place.
+type podResizeHandler struct{}
+
+var _ handler.EventHandler = (*podResizeHandler)(nil)
+
+func (h *podResizeHandler) Create(_ context.Context, _ event.CreateEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *podResizeHandler) Update(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldPod, isOldPod := e.ObjectOld.(*corev1.Pod)
+	newPod, isNewPod := e.ObjectNew.(*corev1.Pod)
+	if !isOldPod || !isNewPod {
+		return
+	}
+	if utilpod.IsActive(oldPod) == utilpod.IsActive(newPod) && equality.Semantic.DeepEqual(podResources(oldPod), podResources(newPod)) {
+		return
+	}
+	h.queueReconcileForWorkload(ctx, newPod, q)
+}
+
+func (h *podResizeHandler) Delete(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if pod, isPod := e.Object.(*corev1.Pod); isPod {
+		h.queueReconcileForWorkload(ctx, pod, q)
+	}
+}
+
+func (h *podResizeHandler) Generic(_ context.Context, _ event.GenericEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *podResizeHandler) queueReconcileForWorkload(ctx context.Context, pod *corev1.Pod, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	wlName, found := pod.Annotations[kueuealpha.WorkloadAnnotation]
+	if !found {
+		return
+	}
+	log := ctrl.LoggerFrom(ctx).WithValues("pod", klog.KObj(pod))
+	log.V(5).Info("Queue reconcile for the workload of the resized pod", "workload", klog.KRef(pod.Namespace, wlName))
+	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: wlName}})
+}
+
+// podResources returns the resources of the containers of the pod.
+func podResources(pod *corev1.Pod) []corev1.ResourceRequirements {
+	res := make([]corev1.ResourceRequirements, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	for i := range pod.Spec.InitContainers {
+		res = append(res, pod.Spec.InitContainers[i].Resources)
+	}
+	for i := range pod.Spec.Containers {
+		res = append(res, pod.Spec.Containers[i].Resources)
+	}
+	return res
+}
+
 type workloadQueueHandler struct {
 	r *WorkloadReconciler
 }
@@ -933,7 +1359,8 @@ func (w *workloadQueueHandler) Update(ctx context.Context, ev event.UpdateEvent,
 		if !newCq.DeletionTimestamp.IsZero() ||
 			!utilslices.CmpNoOrder(oldCq.Spec.AdmissionChecks, newCq.Spec.AdmissionChecks) ||
 			!gocmp.Equal(oldCq.Spec.AdmissionChecksStrategy, newCq.Spec.AdmissionChecksStrategy) ||
-			!ptr.Equal(oldCq.Spec.StopPolicy, newCq.Spec.StopPolicy) {
+			!ptr.Equal(oldCq.Spec.StopPolicy, newCq.Spec.StopPolicy) ||
+			oldCq.Annotations[controllerconsts.AdmitAllAnnotation] != newCq.Annotations[controllerconsts.AdmitAllAnnotation] {
 			w.queueReconcileForWorkloadsOfClusterQueue(ctx, newCq.Name, wq)
 		}
 		return
@@ -36,10 +36,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 func TestAdmittedNotReadyWorkload(t *testing.T) {
@@ -179,8 +185,8 @@ func TestAdmittedNotReadyWorkload(t *testing.T) {
 
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			wRec := WorkloadReconciler{waitForPodsReady: tc.waitForPodsReady, clock: fakeClock}
-			countingTowardsTimeout, recheckAfter := wRec.admittedNotReadyWorkload(&tc.workload)
+			wRec := WorkloadReconciler{clock: fakeClock}
+			countingTowardsTimeout, recheckAfter := wRec.admittedNotReadyWorkload(tc.waitForPodsReady, &tc.workload)
 
 			if tc.wantCountingTowardsTimeout != countingTowardsTimeout {
 				t.Errorf("Unexpected countingTowardsTimeout, want=%v, got=%v", tc.wantCountingTowardsTimeout, countingTowardsTimeout)
@@ -192,6 +198,73 @@ func TestAdmittedNotReadyWorkload(t *testing.T) {
 	}
 }
 
+func TestWaitForPodsReadyForClusterQueue(t *testing.T) {
+	global := &waitForPodsReadyConfig{
+		timeout:                     5 * time.Minute,
+		requeuingBackoffLimitCount:  ptr.To[int32](3),
+		requeuingBackoffBaseSeconds: 60,
+		requeuingBackoffMaxDuration: time.Hour,
+		requeuingBackoffJitter:      workload.RequeuingBackoffJitter,
+	}
+	testCases := map[string]struct {
+		global   *waitForPodsReadyConfig
+		cqConfig *kueue.ClusterQueueWaitForPodsReady
+		want     *waitForPodsReadyConfig
+	}{
+		"not overridden": {
+			global: global,
+			want:   global,
+		},
+		"disabled by the ClusterQueue": {
+			global:   global,
+			cqConfig: &kueue.ClusterQueueWaitForPodsReady{Enable: ptr.To(false)},
+		},
+		"fields overridden by the ClusterQueue": {
+			global: global,
+			cqConfig: &kueue.ClusterQueueWaitForPodsReady{
+				Timeout: &metav1.Duration{Duration: time.Minute},
+				RequeuingStrategy: &kueue.ClusterQueueRequeuingStrategy{
+					BackoffMaxSeconds: ptr.To[int32](600),
+				},
+			},
+			want: &waitForPodsReadyConfig{
+				timeout:                     time.Minute,
+				requeuingBackoffLimitCount:  ptr.To[int32](3),
+				requeuingBackoffBaseSeconds: 60,
+				requeuingBackoffMaxDuration: 10 * time.Minute,
+				requeuingBackoffJitter:      workload.RequeuingBackoffJitter,
+			},
+		},
+		"globally disabled": {
+			cqConfig: &kueue.ClusterQueueWaitForPodsReady{Timeout: &metav1.Duration{Duration: time.Minute}},
+		},
+		"enabled by the ClusterQueue": {
+			cqConfig: &kueue.ClusterQueueWaitForPodsReady{
+				Enable: ptr.To(true),
+				RequeuingStrategy: &kueue.ClusterQueueRequeuingStrategy{
+					BackoffLimitCount: ptr.To[int32](1),
+				},
+			},
+			want: &waitForPodsReadyConfig{
+				timeout:                     5 * time.Minute,
+				requeuingBackoffLimitCount:  ptr.To[int32](1),
+				requeuingBackoffBaseSeconds: 60,
+				requeuingBackoffMaxDuration: time.Hour,
+				requeuingBackoffJitter:      workload.RequeuingBackoffJitter,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.global.forClusterQueue(tc.cqConfig)
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(waitForPodsReadyConfig{})); diff != "" {
+				t.Errorf("Unexpected configuration (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestSyncCheckStates(t *testing.T) {
 	now := metav1.NewTime(time.Now())
 	cases := map[string]struct {
@@ -332,6 +405,7 @@ func TestReconcile(t *testing.T) {
 		wantEvents     []utiltesting.EventRecord
 		wantResult     reconcile.Result
 		reconcilerOpts []Option
+		checks         []*kueue.AdmissionCheck
 	}{
 		"assign Admission Checks from ClusterQueue.spec.AdmissionCheckStrategy": {
 			workload: utiltesting.MakeWorkload("wl", "ns").
@@ -579,11 +653,13 @@ func TestReconcile(t *testing.T) {
 						Name:    "check-1",
 						State:   kueue.CheckStatePending,
 						Message: "Reset to Pending after eviction. Previously: Rejected",
+						History: []kueue.AdmissionCheckStateTransition{{State: kueue.CheckStateRejected}},
 					},
 					kueue.AdmissionCheckState{
 						Name:    "check-2",
 						State:   kueue.CheckStatePending,
 						Message: "Reset to Pending after eviction. Previously: Retry",
+						History: []kueue.AdmissionCheckStateTransition{{State: kueue.CheckStateRetry}},
 					},
 				).
 				Conditions(
@@ -601,6 +677,14 @@ func TestReconcile(t *testing.T) {
 						Message: "Admission check(s): [check-1], were rejected",
 					},
 				).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:   kueue.WorkloadEvictedByAdmissionCheck,
+					Category: kueue.DeactivationCategoryInfrastructure,
+				}).
+				Eviction(&kueue.WorkloadEviction{
+					Reason:   "DeactivatedDueToAdmissionCheck",
+					Category: kueue.EvictionCategoryChecksFailed,
+				}).
 				Obj(),
 			wantEvents: []utiltesting.EventRecord{
 				{
@@ -630,10 +714,12 @@ func TestReconcile(t *testing.T) {
 					Name:    "check-1",
 					State:   kueue.CheckStatePending,
 					Message: "Reset to Pending after eviction. Previously: Retry",
+					History: []kueue.AdmissionCheckStateTransition{{State: kueue.CheckStateRetry}},
 				}, kueue.AdmissionCheckState{
 					Name:    "check-2",
 					State:   kueue.CheckStatePending,
 					Message: "Reset to Pending after eviction. Previously: Ready",
+					History: []kueue.AdmissionCheckStateTransition{{State: kueue.CheckStateReady}},
 				}).
 				Condition(metav1.Condition{
 					Type:    "Evicted",
@@ -641,6 +727,10 @@ func TestReconcile(t *testing.T) {
 					Reason:  "AdmissionCheck",
 					Message: "At least one admission check is false",
 				}).
+				Eviction(&kueue.WorkloadEviction{
+					Reason:   kueue.WorkloadEvictedByAdmissionCheck,
+					Category: kueue.EvictionCategoryChecksFailed,
+				}).
 				Obj(),
 			wantEvents: []utiltesting.EventRecord{
 				{
@@ -651,6 +741,64 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
+		"pending check exceeding its timeout should be set to retry": {
+			checks: []*kueue.AdmissionCheck{
+				utiltesting.MakeAdmissionCheck("check").PendingTimeout(5 * time.Minute).Obj(),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltesting.MakeAdmission("q1").Obj(), testStartTime.Add(-10*time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:               "check",
+					State:              kueue.CheckStatePending,
+					LastTransitionTime: metav1.NewTime(testStartTime.Add(-10 * time.Minute)),
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuotaAt(utiltesting.MakeAdmission("q1").Obj(), testStartTime.Add(-10*time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:    "check",
+					State:   kueue.CheckStateRetry,
+					Message: "Pending for longer than the timeout of 5m0s",
+					History: []kueue.AdmissionCheckStateTransition{{
+						State:              kueue.CheckStatePending,
+						LastTransitionTime: metav1.NewTime(testStartTime.Add(-10 * time.Minute)),
+					}},
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Warning",
+					Reason:    "AdmissionCheckTimedOut",
+					Message:   "The admission checks [check] were Pending for longer than their timeout",
+				},
+			},
+		},
+		"pending check within its timeout should be rechecked": {
+			checks: []*kueue.AdmissionCheck{
+				utiltesting.MakeAdmissionCheck("check").PendingTimeout(5 * time.Minute).Obj(),
+			},
+			cq: utiltesting.MakeClusterQueue("q1").AdmissionChecks("check").Obj(),
+			lq: utiltesting.MakeLocalQueue("queue", "ns").ClusterQueue("q1").Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("queue").
+				ReserveQuotaAt(utiltesting.MakeAdmission("q1").Obj(), testStartTime.Add(-time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:               "check",
+					State:              kueue.CheckStatePending,
+					LastTransitionTime: metav1.NewTime(testStartTime.Add(-10 * time.Minute)),
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("queue").
+				ReserveQuotaAt(utiltesting.MakeAdmission("q1").Obj(), testStartTime.Add(-time.Minute)).
+				AdmissionCheck(kueue.AdmissionCheckState{
+					Name:  "check",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+			wantResult: reconcile.Result{RequeueAfter: 4 * time.Minute},
+		},
 		"increment re-queue count": {
 			reconcilerOpts: []Option{
 				WithWaitForPodsReady(&waitForPodsReadyConfig{
@@ -685,6 +833,7 @@ func TestReconcile(t *testing.T) {
 					Name:    "check",
 					State:   kueue.CheckStatePending,
 					Message: "Reset to Pending after eviction. Previously: Ready",
+					History: []kueue.AdmissionCheckStateTransition{{State: kueue.CheckStateReady}},
 				}).
 				Generation(1).
 				Condition(metav1.Condition{
@@ -696,6 +845,10 @@ func TestReconcile(t *testing.T) {
 				}).
 				// 10s * 2^(4-1) = 80s
 				RequeueState(ptr.To[int32](4), ptr.To(metav1.NewTime(testStartTime.Add(80*time.Second).Truncate(time.Second)))).
+				Eviction(&kueue.WorkloadEviction{
+					Reason:   kueue.WorkloadEvictedByPodsReadyTimeout,
+					Category: kueue.EvictionCategoryPodsReadyTimeout,
+				}).
 				Obj(),
 			wantEvents: []utiltesting.EventRecord{
 				{
@@ -780,6 +933,7 @@ func TestReconcile(t *testing.T) {
 					Name:    "check",
 					State:   kueue.CheckStatePending,
 					Message: "Reset to Pending after eviction. Previously: Ready",
+					History: []kueue.AdmissionCheckStateTransition{{State: kueue.CheckStateReady}},
 				}).
 				Generation(1).
 				Condition(metav1.Condition{
@@ -791,6 +945,10 @@ func TestReconcile(t *testing.T) {
 				}).
 				//  10s * 2^(11-1) = 10240s > requeuingBackoffMaxSeconds; then wait time should be limited to requeuingBackoffMaxSeconds
 				RequeueState(ptr.To[int32](11), ptr.To(metav1.NewTime(testStartTime.Add(7200*time.Second).Truncate(time.Second)))).
+				Eviction(&kueue.WorkloadEviction{
+					Reason:   kueue.WorkloadEvictedByPodsReadyTimeout,
+					Category: kueue.EvictionCategoryPodsReadyTimeout,
+				}).
 				Obj(),
 			wantEvents: []utiltesting.EventRecord{
 				{
@@ -821,6 +979,266 @@ func TestReconcile(t *testing.T) {
 				}).
 				Obj(),
 		},
+		"should increment the reactivation count when the workload is reactivated automatically": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Active(true).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadRequeued,
+					Status:  metav1.ConditionFalse,
+					Reason:  "DeactivatedDueToRequeuingLimitExceeded",
+					Message: "The workload is deactivated due to exceeding the maximum number of re-queuing retries",
+				}).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:            kueue.WorkloadRequeuingLimitExceeded,
+					Category:          kueue.DeactivationCategoryInfrastructure,
+					ReactivateAt:      ptr.To(metav1.NewTime(testStartTime.Add(-time.Second))),
+					ReactivationCount: 1,
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Active(true).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadRequeued,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadReactivated,
+					Message: "The workload was reactivated",
+				}).
+				// The reactivateAt should be reset in the real cluster, but the fake client doesn't allow us to do it.
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:            kueue.WorkloadRequeuingLimitExceeded,
+					Category:          kueue.DeactivationCategoryInfrastructure,
+					ReactivateAt:      ptr.To(metav1.NewTime(testStartTime.Add(-time.Second))),
+					ReactivationCount: 2,
+				}).
+				Obj(),
+		},
+		"should not increment the reactivation count when the workload is reactivated by the user before the reactivation time": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Active(true).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:       kueue.WorkloadEvictedByAdmissionCheck,
+					Category:     kueue.DeactivationCategoryInfrastructure,
+					ReactivateAt: ptr.To(metav1.NewTime(testStartTime.Add(time.Minute))),
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Active(true).
+				// The reactivateAt should be reset in the real cluster, but the fake client doesn't allow us to do it.
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:       kueue.WorkloadEvictedByAdmissionCheck,
+					Category:     kueue.DeactivationCategoryInfrastructure,
+					ReactivateAt: ptr.To(metav1.NewTime(testStartTime.Add(time.Minute))),
+				}).
+				Obj(),
+		},
+		"should set the reactivation time when the workload is deactivated due to exceeding the requeuing limit": {
+			reconcilerOpts: []Option{
+				WithAutoReactivation(&autoReactivationConfig{
+					reasonCategories:   sets.New(kueue.DeactivationCategoryInfrastructure),
+					backoffBaseSeconds: 600,
+					backoffMaxDuration: 24 * time.Hour,
+				}),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadDeactivationTarget,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadRequeuingLimitExceeded,
+					Message: "exceeding the maximum number of re-queuing retries",
+				}).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:            kueue.WorkloadRequeuingLimitExceeded,
+					Category:          kueue.DeactivationCategoryInfrastructure,
+					ReactivationCount: 2,
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  "DeactivatedDueToRequeuingLimitExceeded",
+					Message: "The workload is deactivated due to exceeding the maximum number of re-queuing retries",
+				}).
+				// DeactivationTarget condition should be deleted in the real cluster, but the fake client doesn't allow us to do it.
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadDeactivationTarget,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadRequeuingLimitExceeded,
+					Message: "exceeding the maximum number of re-queuing retries",
+				}).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:            kueue.WorkloadRequeuingLimitExceeded,
+					Category:          kueue.DeactivationCategoryInfrastructure,
+					ReactivateAt:      ptr.To(metav1.NewTime(testStartTime.Add(40 * time.Minute))),
+					ReactivationCount: 2,
+				}).
+				Eviction(&kueue.WorkloadEviction{
+					Reason:   "DeactivatedDueToRequeuingLimitExceeded",
+					Category: kueue.EvictionCategoryPodsReadyTimeout,
+				}).
+				Obj(),
+		},
+		"should not set the reactivation time when the workload is deactivated by the user": {
+			reconcilerOpts: []Option{
+				WithAutoReactivation(&autoReactivationConfig{
+					reasonCategories:   sets.New(kueue.DeactivationCategoryInfrastructure, kueue.DeactivationCategoryUser),
+					backoffBaseSeconds: 600,
+					backoffMaxDuration: 24 * time.Hour,
+				}),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").Active(false).Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadDeactivated,
+					Message: "The workload is deactivated",
+				}).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:   kueue.WorkloadDeactivated,
+					Category: kueue.DeactivationCategoryUser,
+				}).
+				Eviction(&kueue.WorkloadEviction{
+					Reason:   kueue.WorkloadDeactivated,
+					Category: kueue.EvictionCategoryAdminStop,
+				}).
+				Obj(),
+		},
+		"should not set the reactivation time when the backoff limit is reached": {
+			reconcilerOpts: []Option{
+				WithAutoReactivation(&autoReactivationConfig{
+					reasonCategories:   sets.New(kueue.DeactivationCategoryInfrastructure),
+					backoffLimitCount:  ptr.To[int32](2),
+					backoffBaseSeconds: 600,
+					backoffMaxDuration: 24 * time.Hour,
+				}),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadDeactivationTarget,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByAdmissionCheck,
+					Message: "Admission check(s): [check], were rejected",
+				}).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:            kueue.WorkloadEvictedByAdmissionCheck,
+					Category:          kueue.DeactivationCategoryInfrastructure,
+					ReactivationCount: 2,
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  "DeactivatedDueToAdmissionCheck",
+					Message: "The workload is deactivated due to Admission check(s): [check], were rejected",
+				}).
+				// DeactivationTarget condition should be deleted in the real cluster, but the fake client doesn't allow us to do it.
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadDeactivationTarget,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByAdmissionCheck,
+					Message: "Admission check(s): [check], were rejected",
+				}).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:            kueue.WorkloadEvictedByAdmissionCheck,
+					Category:          kueue.DeactivationCategoryInfrastructure,
+					ReactivationCount: 2,
+				}).
+				Eviction(&kueue.WorkloadEviction{
+					Reason:   "DeactivatedDueToAdmissionCheck",
+					Category: kueue.EvictionCategoryChecksFailed,
+				}).
+				Obj(),
+		},
+		"should wait until the reactivation time before reactivating the workload": {
+			reconcilerOpts: []Option{
+				WithAutoReactivation(&autoReactivationConfig{
+					reasonCategories:   sets.New(kueue.DeactivationCategoryInfrastructure),
+					backoffBaseSeconds: 600,
+					backoffMaxDuration: 24 * time.Hour,
+				}),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  "DeactivatedDueToRequeuingLimitExceeded",
+					Message: "The workload is deactivated due to exceeding the maximum number of re-queuing retries",
+				}).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:       kueue.WorkloadRequeuingLimitExceeded,
+					Category:     kueue.DeactivationCategoryInfrastructure,
+					ReactivateAt: ptr.To(metav1.NewTime(testStartTime.Add(5 * time.Minute))),
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  "DeactivatedDueToRequeuingLimitExceeded",
+					Message: "The workload is deactivated due to exceeding the maximum number of re-queuing retries",
+				}).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:       kueue.WorkloadRequeuingLimitExceeded,
+					Category:     kueue.DeactivationCategoryInfrastructure,
+					ReactivateAt: ptr.To(metav1.NewTime(testStartTime.Add(5 * time.Minute))),
+				}).
+				Obj(),
+			wantResult: reconcile.Result{RequeueAfter: 5 * time.Minute},
+		},
+		"should reactivate the workload when the reactivation time is reached": {
+			reconcilerOpts: []Option{
+				WithAutoReactivation(&autoReactivationConfig{
+					reasonCategories:   sets.New(kueue.DeactivationCategoryInfrastructure),
+					backoffBaseSeconds: 600,
+					backoffMaxDuration: 24 * time.Hour,
+				}),
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  "DeactivatedDueToRequeuingLimitExceeded",
+					Message: "The workload is deactivated due to exceeding the maximum number of re-queuing retries",
+				}).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:       kueue.WorkloadRequeuingLimitExceeded,
+					Category:     kueue.DeactivationCategoryInfrastructure,
+					ReactivateAt: ptr.To(metav1.NewTime(testStartTime)),
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Active(true).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  "DeactivatedDueToRequeuingLimitExceeded",
+					Message: "The workload is deactivated due to exceeding the maximum number of re-queuing retries",
+				}).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:       kueue.WorkloadRequeuingLimitExceeded,
+					Category:     kueue.DeactivationCategoryInfrastructure,
+					ReactivateAt: ptr.To(metav1.NewTime(testStartTime)),
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "wl", Namespace: "ns"},
+					EventType: corev1.EventTypeNormal,
+					Reason:    kueue.WorkloadReactivated,
+					Message:   "The workload was reactivated after being deactivated due to RequeuingLimitExceeded",
+				},
+			},
+		},
 		"should keep the WorkloadRequeued condition until the WaitForPodsReady backoff expires": {
 			workload: utiltesting.MakeWorkload("wl", "ns").
 				Active(true).
@@ -1003,6 +1421,14 @@ func TestReconcile(t *testing.T) {
 					Reason:  kueue.WorkloadDeactivated,
 					Message: "The workload is deactivated",
 				}).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:   kueue.WorkloadDeactivated,
+					Category: kueue.DeactivationCategoryUser,
+				}).
+				Eviction(&kueue.WorkloadEviction{
+					Reason:   kueue.WorkloadDeactivated,
+					Category: kueue.EvictionCategoryAdminStop,
+				}).
 				Obj(),
 		},
 		"should set the Evicted condition with Deactivated reason when the .spec.active=False and Admitted": {
@@ -1021,6 +1447,14 @@ func TestReconcile(t *testing.T) {
 					Reason:  kueue.WorkloadDeactivated,
 					Message: "The workload is deactivated",
 				}).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:   kueue.WorkloadDeactivated,
+					Category: kueue.DeactivationCategoryUser,
+				}).
+				Eviction(&kueue.WorkloadEviction{
+					Reason:   kueue.WorkloadDeactivated,
+					Category: kueue.EvictionCategoryAdminStop,
+				}).
 				Obj(),
 			wantEvents: []utiltesting.EventRecord{
 				{
@@ -1053,6 +1487,14 @@ func TestReconcile(t *testing.T) {
 					Reason:  kueue.WorkloadDeactivated,
 					Message: "The workload is deactivated",
 				}).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:   kueue.WorkloadDeactivated,
+					Category: kueue.DeactivationCategoryUser,
+				}).
+				Eviction(&kueue.WorkloadEviction{
+					Reason:   kueue.WorkloadDeactivated,
+					Category: kueue.EvictionCategoryAdminStop,
+				}).
 				Obj(),
 			wantEvents: []utiltesting.EventRecord{
 				{
@@ -1113,6 +1555,14 @@ func TestReconcile(t *testing.T) {
 					Reason:  kueue.WorkloadRequeuingLimitExceeded,
 					Message: "exceeding the maximum number of re-queuing retries",
 				}).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:   kueue.WorkloadRequeuingLimitExceeded,
+					Category: kueue.DeactivationCategoryInfrastructure,
+				}).
+				Eviction(&kueue.WorkloadEviction{
+					Reason:   "DeactivatedDueToRequeuingLimitExceeded",
+					Category: kueue.EvictionCategoryPodsReadyTimeout,
+				}).
 				Obj(),
 			wantEvents: []utiltesting.EventRecord{
 				{
@@ -1176,6 +1626,14 @@ func TestReconcile(t *testing.T) {
 				}).
 				// The requeueState should be reset in the real cluster, but the fake client doesn't allow us to do it.
 				RequeueState(ptr.To[int32](100), nil).
+				Deactivation(&kueue.WorkloadDeactivation{
+					Reason:   kueue.WorkloadRequeuingLimitExceeded,
+					Category: kueue.DeactivationCategoryInfrastructure,
+				}).
+				Eviction(&kueue.WorkloadEviction{
+					Reason:   "DeactivatedDueToRequeuingLimitExceeded",
+					Category: kueue.EvictionCategoryPodsReadyTimeout,
+				}).
 				Obj(),
 			wantEvents: []utiltesting.EventRecord{
 				{
@@ -1230,6 +1688,10 @@ func TestReconcile(t *testing.T) {
 					Reason:  kueue.WorkloadEvictedByClusterQueueStopped,
 					Message: "The ClusterQueue is stopped",
 				}).
+				Eviction(&kueue.WorkloadEviction{
+					Reason:   kueue.WorkloadEvictedByClusterQueueStopped,
+					Category: kueue.EvictionCategoryAdminStop,
+				}).
 				Obj(),
 			wantEvents: []utiltesting.EventRecord{
 				{
@@ -1260,6 +1722,10 @@ func TestReconcile(t *testing.T) {
 					Reason:  kueue.WorkloadEvictedByLocalQueueStopped,
 					Message: "The LocalQueue is stopped",
 				}).
+				Eviction(&kueue.WorkloadEviction{
+					Reason:   kueue.WorkloadEvictedByLocalQueueStopped,
+					Category: kueue.EvictionCategoryAdminStop,
+				}).
 				Obj(),
 		},
 		"should set the Inadmissible reason on QuotaReservation condition when the LocalQueue was deleted": {
@@ -1458,6 +1924,41 @@ func TestReconcile(t *testing.T) {
 				Obj(),
 		},
 
+		"should mark the pending workload as admitted by bypass if its ClusterQueue admits all the workloads": {
+			lq: utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").AdmitAll().Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Active(true).
+				Queue("lq").
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Active(true).
+				Queue("lq").
+				Label(controllerconsts.ShadowModeLabel, "true").
+				Annotations(map[string]string{controllerconsts.AdmitAllAnnotation: "true"}).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueue.WorkloadInadmissible,
+					Message: "ClusterQueue cq is inactive",
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Warning",
+					Reason:    "AdmittedByBypass",
+					Message:   "The ClusterQueue cq admits all the workloads, starting the job without admission",
+				},
+			},
+		},
+
 		"admitted workload with max execution time": {
 			workload: utiltesting.MakeWorkload("wl", "ns").
 				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
@@ -1502,11 +2003,63 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
+		"admitted workload with max execution time from the ClusterQueue": {
+			lq: utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").MaximumExecutionTimeSeconds(120).Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				AdmittedAt(true, testStartTime.Add(-time.Minute)).
+				ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "ownername", "owneruid").
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				AdmittedAt(true, testStartTime.Add(-time.Minute)).
+				ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "ownername", "owneruid").
+				Obj(),
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+		},
+		"admitted workload with max execution time - expired by the ClusterQueue limit": {
+			lq: utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+			cq: utiltesting.MakeClusterQueue("cq").MaximumExecutionTimeSeconds(60).Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				MaximumExecutionTimeSeconds(300).
+				AdmittedAt(true, testStartTime.Add(-2*time.Minute)).
+				ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "ownername", "owneruid").
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				MaximumExecutionTimeSeconds(300).
+				AdmittedAt(true, testStartTime.Add(-2*time.Minute)).
+				ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "ownername", "owneruid").
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadDeactivationTarget,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadMaximumExecutionTimeExceeded,
+					Message: "exceeding the maximum execution time",
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Warning",
+					Reason:    "MaximumExecutionTimeExceeded",
+					Message:   "The maximum execution time (60s) exceeded",
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			objs := []client.Object{tc.workload}
 			clientBuilder := utiltesting.NewClientBuilder().WithObjects(objs...).WithStatusSubresource(objs...).WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge})
+			for _, ac := range tc.checks {
+				clientBuilder = clientBuilder.WithObjects(ac)
+			}
 			cl := clientBuilder.Build()
 			recorder := &utiltesting.EventRecorder{}
 
@@ -1564,3 +2117,285 @@ func TestReconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileResize(t *testing.T) {
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+		Obj()
+	lq := utiltesting.MakeLocalQueue("queue", "ns").ClusterQueue("cq").Obj()
+	admittedWorkload := func(name string, count, admittedCount int32) *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload(name, "ns").
+			Queue("queue").
+			PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, int(count)).Request(corev1.ResourceCPU, "1").Obj()).
+			ReserveQuota(utiltesting.MakeAdmission("cq").
+				Assignment(corev1.ResourceCPU, "default", fmt.Sprintf("%d", admittedCount)).
+				AssignmentPodCount(admittedCount).
+				Obj()).
+			Admitted(true)
+	}
+
+	cases := map[string]struct {
+		workload           *kueue.Workload
+		otherWorkloads     []*kueue.Workload
+		wantWorkload       *kueue.Workload
+		wantEvents         []utiltesting.EventRecord
+		wantResizeRequests []string
+		wantResult         reconcile.Result
+	}{
+		"grow is requested to the scheduler": {
+			workload:           admittedWorkload("wl", 3, 2).Obj(),
+			wantWorkload:       admittedWorkload("wl", 3, 2).Obj(),
+			wantResizeRequests: []string{"ns/wl"},
+			wantResult:         reconcile.Result{RequeueAfter: resizeRetryInterval},
+		},
+		"shrink": {
+			workload: admittedWorkload("wl", 1, 3).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadResizePending,
+					Status:  metav1.ConditionTrue,
+					Reason:  "InsufficientQuota",
+					Message: "The additional quota is not available in ClusterQueue cq",
+				}).
+				Obj(),
+			otherWorkloads: []*kueue.Workload{admittedWorkload("other", 1, 1).Obj()},
+			wantWorkload: admittedWorkload("wl", 1, 1).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadResizePending,
+					Status:  metav1.ConditionFalse,
+					Reason:  "Resized",
+					Message: "Resized in ClusterQueue cq",
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Normal",
+					Reason:    "Resized",
+					Message:   "Resized in ClusterQueue cq",
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.ElasticWorkloadResize, true)
+			objs := []client.Object{tc.workload}
+			clientBuilder := utiltesting.NewClientBuilder().WithObjects(objs...).WithStatusSubresource(objs...).WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge})
+			cl := clientBuilder.Build()
+			recorder := &utiltesting.EventRecorder{}
+
+			cqCache := cache.New(cl)
+			qManager := queue.NewManager(cl, cqCache)
+			reconciler := NewWorkloadReconciler(cl, qManager, cqCache, recorder)
+
+			ctxWithLogger, _ := utiltesting.ContextWithLog(t)
+			ctx, ctxCancel := context.WithCancel(ctxWithLogger)
+			defer ctxCancel()
+
+			if err := cl.Create(ctx, cq.DeepCopy()); err != nil {
+				t.Fatalf("couldn't create the cluster queue: %v", err)
+			}
+			if err := cl.Create(ctx, lq.DeepCopy()); err != nil {
+				t.Fatalf("couldn't create the local queue: %v", err)
+			}
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("couldn't add the cluster queue to the cache: %v", err)
+			}
+			if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("couldn't add the cluster queue to the queues: %v", err)
+			}
+			if err := qManager.AddLocalQueue(ctx, lq); err != nil {
+				t.Fatalf("couldn't add the local queue to the queues: %v", err)
+			}
+			cqCache.AddOrUpdateWorkload(tc.workload)
+			for _, wl := range tc.otherWorkloads {
+				cqCache.AddOrUpdateWorkload(wl)
+			}
+
+			gotResult, gotError := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.workload)})
+			if gotError != nil {
+				t.Errorf("unexpected reconcile error: %v", gotError)
+			}
+			if diff := cmp.Diff(tc.wantResult, gotResult); diff != "" {
+				t.Errorf("unexpected reconcile result (-want/+got):\n%s", diff)
+			}
+
+			gotWorkload := &kueue.Workload{}
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(tc.workload), gotWorkload); err != nil {
+				t.Fatalf("Could not get Workloads after reconcile: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantWorkload, gotWorkload, workloadCmpOpts...); diff != "" {
+				t.Errorf("Workloads after reconcile (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantEvents, recorder.RecordedEvents); diff != "" {
+				t.Errorf("unexpected events (-want/+got):\n%s", diff)
+			}
+			var gotResizeRequests []string
+			for _, wi := range qManager.PopResizeRequests() {
+				gotResizeRequests = append(gotResizeRequests, workload.Key(wi.Obj))
+			}
+			if diff := cmp.Diff(tc.wantResizeRequests, gotResizeRequests); diff != "" {
+				t.Errorf("unexpected resize requests (-want/+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcilePodResize(t *testing.T) {
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+		Obj()
+	lq := utiltesting.MakeLocalQueue("queue", "ns").ClusterQueue("cq").Obj()
+	admittedWorkload := func(name, cpu string) *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload(name, "ns").
+			Queue("queue").
+			PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 2).Request(corev1.ResourceCPU, "1").Obj()).
+			ReserveQuota(utiltesting.MakeAdmission("cq").
+				Assignment(corev1.ResourceCPU, "default", cpu).
+				AssignmentPodCount(2).
+				Obj()).
+			Admitted(true)
+	}
+	pod := func(name, cpu string) *corev1.Pod {
+		return testingpod.MakePod(name, "ns").
+			Label(kueuealpha.PodSetLabel, kueue.DefaultPodSetName).
+			Annotation(kueuealpha.WorkloadAnnotation, "wl").
+			Request(corev1.ResourceCPU, cpu).
+			Obj()
+	}
+
+	resizePending := metav1.Condition{
+		Type:    kueue.WorkloadResizePending,
+		Status:  metav1.ConditionTrue,
+		Reason:  "InsufficientQuota",
+		Message: "The additional quota is not available in ClusterQueue cq",
+	}
+
+	cases := map[string]struct {
+		workload           *kueue.Workload
+		pods               []*corev1.Pod
+		otherWorkloads     []*kueue.Workload
+		wantWorkload       *kueue.Workload
+		wantEvents         []utiltesting.EventRecord
+		wantResizeRequests []string
+	}{
+		"pods not resized": {
+			workload:     admittedWorkload("wl", "2").Obj(),
+			pods:         []*corev1.Pod{pod("pod1", "1"), pod("pod2", "1")},
+			wantWorkload: admittedWorkload("wl", "2").Obj(),
+		},
+		"pod grown is requested to the scheduler": {
+			workload:           admittedWorkload("wl", "2").Obj(),
+			pods:               []*corev1.Pod{pod("pod1", "2"), pod("pod2", "1")},
+			wantWorkload:       admittedWorkload("wl", "2").Obj(),
+			wantResizeRequests: []string{"ns/wl"},
+		},
+		"pod grown over quota": {
+			workload: admittedWorkload("wl", "2").Condition(resizePending).Obj(),
+			pods:     []*corev1.Pod{pod("pod1", "2"), pod("pod2", "2")},
+			wantWorkload: admittedWorkload("wl", "2").
+				Condition(resizePending).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueue.WorkloadEvictedByPodResize,
+					Message: "The resized pods exceed the available quota in ClusterQueue cq",
+				}).
+				Eviction(&kueue.WorkloadEviction{
+					Reason:   kueue.WorkloadEvictedByPodResize,
+					Category: kueue.EvictionCategoryOther,
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Normal",
+					Reason:    "EvictedDueToPodResize",
+					Message:   "The resized pods exceed the available quota in ClusterQueue cq",
+				},
+			},
+		},
+		"pod shrunk": {
+			workload:     admittedWorkload("wl", "2").Obj(),
+			pods:         []*corev1.Pod{pod("pod1", "500m"), pod("pod2", "1")},
+			wantWorkload: admittedWorkload("wl", "1500m").Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Normal",
+					Reason:    "Resized",
+					Message:   "Resized in ClusterQueue cq",
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.InPlacePodResize, true)
+			objs := []client.Object{tc.workload}
+			for _, p := range tc.pods {
+				objs = append(objs, p)
+			}
+			clientBuilder := utiltesting.NewClientBuilder().
+				WithObjects(objs...).
+				WithStatusSubresource(tc.workload).
+				WithIndex(&corev1.Pod{}, indexer.PodWorkloadKey, indexer.IndexPodWorkload).
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge})
+			cl := clientBuilder.Build()
+			recorder := &utiltesting.EventRecorder{}
+
+			cqCache := cache.New(cl)
+			qManager := queue.NewManager(cl, cqCache)
+			reconciler := NewWorkloadReconciler(cl, qManager, cqCache, recorder)
+
+			ctxWithLogger, _ := utiltesting.ContextWithLog(t)
+			ctx, ctxCancel := context.WithCancel(ctxWithLogger)
+			defer ctxCancel()
+
+			if err := cl.Create(ctx, cq.DeepCopy()); err != nil {
+				t.Fatalf("couldn't create the cluster queue: %v", err)
+			}
+			if err := cl.Create(ctx, lq.DeepCopy()); err != nil {
+				t.Fatalf("couldn't create the local queue: %v", err)
+			}
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("couldn't add the cluster queue to the cache: %v", err)
+			}
+			if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("couldn't add the cluster queue to the queues: %v", err)
+			}
+			if err := qManager.AddLocalQueue(ctx, lq); err != nil {
+				t.Fatalf("couldn't add the local queue to the queues: %v", err)
+			}
+			cqCache.AddOrUpdateWorkload(tc.workload)
+			for _, wl := range tc.otherWorkloads {
+				cqCache.AddOrUpdateWorkload(wl)
+			}
+
+			_, gotError := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.workload)})
+			if gotError != nil {
+				t.Errorf("unexpected reconcile error: %v", gotError)
+			}
+
+			gotWorkload := &kueue.Workload{}
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(tc.workload), gotWorkload); err != nil {
+				t.Fatalf("Could not get Workloads after reconcile: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantWorkload, gotWorkload, workloadCmpOpts...); diff != "" {
+				t.Errorf("Workloads after reconcile (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantEvents, recorder.RecordedEvents); diff != "" {
+				t.Errorf("unexpected events (-want/+got):\n%s", diff)
+			}
+			var gotResizeRequests []string
+			for _, wi := range qManager.PopResizeRequests() {
+				gotResizeRequests = append(gotResizeRequests, workload.Key(wi.Obj))
+			}
+			if diff := cmp.Diff(tc.wantResizeRequests, gotResizeRequests); diff != "" {
+				t.Errorf("unexpected resize requests (-want/+got):\n%s", diff)
+			}
+		})
+	}
+}
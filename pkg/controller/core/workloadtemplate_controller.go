@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	"sigs.k8s.io/kueue/pkg/cache"
+)
+
+// WorkloadTemplateReconciler synchronizes the WorkloadTemplates in
+// cache.Cache, so that the scheduler and the workload reconciler find the
+// admission checks skipped by the workloads without reading the templates
+// from the API.
+type WorkloadTemplateReconciler struct {
+	log   logr.Logger
+	cache *cache.Cache
+}
+
+func NewWorkloadTemplateReconciler(cache *cache.Cache) *WorkloadTemplateReconciler {
+	return &WorkloadTemplateReconciler{
+		log:   ctrl.Log.WithName("workloadtemplate-reconciler"),
+		cache: cache,
+	}
+}
+
+func (r *WorkloadTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kueuealpha.WorkloadTemplate{}).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		WithEventFilter(r).
+		Complete(r)
+}
+
+func (r *WorkloadTemplateReconciler) Create(e event.CreateEvent) bool {
+	template, match := e.Object.(*kueuealpha.WorkloadTemplate)
+	if !match {
+		return false
+	}
+	r.log.V(2).Info("WorkloadTemplate create event", "workloadTemplate", klog.KObj(template))
+	r.cache.AddOrUpdateWorkloadTemplate(template)
+	return false
+}
+
+func (r *WorkloadTemplateReconciler) Update(e event.UpdateEvent) bool {
+	template, match := e.ObjectNew.(*kueuealpha.WorkloadTemplate)
+	if !match {
+		return false
+	}
+	r.log.V(2).Info("WorkloadTemplate update event", "workloadTemplate", klog.KObj(template))
+	r.cache.AddOrUpdateWorkloadTemplate(template)
+	return false
+}
+
+func (r *WorkloadTemplateReconciler) Delete(e event.DeleteEvent) bool {
+	template, match := e.Object.(*kueuealpha.WorkloadTemplate)
+	if !match {
+		return false
+	}
+	r.log.V(2).Info("WorkloadTemplate delete event", "workloadTemplate", klog.KObj(template))
+	r.cache.DeleteWorkloadTemplate(template.Name)
+	return false
+}
+
+func (r *WorkloadTemplateReconciler) Generic(event.GenericEvent) bool {
+	return false
+}
+
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloadtemplates,verbs=get;list;watch
+
+// Reconcile does nothing, as the WorkloadTemplates are synchronized in the
+// cache from their events.
+func (r *WorkloadTemplateReconciler) Reconcile(context.Context, ctrl.Request) (ctrl.Result, error) {
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	defaultInterval    = 5 * time.Minute
+	defaultGracePeriod = 5 * time.Minute
+
+	// The reasons for which the objects are garbage collected.
+	ReasonOwnerDeleted     = "OwnerDeleted"
+	ReasonPodGroupDeleted  = "PodGroupDeleted"
+	ReasonWorkloadDeleted  = "WorkloadDeleted"
+	ReasonWorkloadFinished = "WorkloadFinished"
+)
+
+var realClock = clock.RealClock{}
+
+type options struct {
+	interval             time.Duration
+	gracePeriod          time.Duration
+	provisioningRequests bool
+	clock                clock.Clock
+}
+
+// Option configures the garbage collector.
+type Option func(*options)
+
+var defaultOptions = options{
+	interval:    defaultInterval,
+	gracePeriod: defaultGracePeriod,
+	clock:       realClock,
+}
+
+// WithInterval sets the time between two runs of the garbage collector.
+func WithInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.interval = interval
+	}
+}
+
+// WithGracePeriod sets the time an object needs to stay orphaned before it's
+// collected, so that the objects the controllers are about to clean, or the
+// owners of which aren't observed yet, aren't collected.
+func WithGracePeriod(gracePeriod time.Duration) Option {
+	return func(o *options) {
+		o.gracePeriod = gracePeriod
+	}
+}
+
+// WithProvisioningRequests enables collecting the dangling
+// ProvisioningRequests.
+func WithProvisioningRequests(enabled bool) Option {
+	return func(o *options) {
+		o.provisioningRequests = enabled
+	}
+}
+
+// WithClock sets the clock of the garbage collector.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// GarbageCollector periodically cleans the objects left behind when the
+// controllers can't clean them anymore:
+//   - the Workloads of deleted owners, with their finalizer stuck,
+//   - the Workloads of pod groups whose pods are all deleted,
+//   - the ProvisioningRequests of deleted or finished Workloads.
+type GarbageCollector struct {
+	client client.Client
+	// apiReader reads the owners of the Workloads, which aren't necessarily
+	// watched by the manager.
+	apiReader client.Reader
+	options
+}
+
+var _ manager.Runnable = (*GarbageCollector)(nil)
+
+func New(c client.Client, apiReader client.Reader, opts ...Option) *GarbageCollector {
+	options := defaultOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &GarbageCollector{
+		client:    c,
+		apiReader: apiReader,
+		options:   options,
+	}
+}
+
+// Start runs the garbage collector until the context is done. It's only run
+// by the leader.
+func (gc *GarbageCollector) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("WorkloadGC")
+	ctx = ctrl.LoggerInto(ctx, log)
+	log.V(2).Info("Starting Garbage Collector", "interval", gc.interval)
+	for {
+		select {
+		case <-ctx.Done():
+			log.V(2).Info("Garbage Collector Stopped")
+			return nil
+		case <-gc.clock.After(gc.interval):
+			gc.collect(ctx)
+		}
+	}
+}
+
+func (gc *GarbageCollector) collect(ctx context.Context) {
+	log := ctrl.LoggerFrom(ctx)
+	log.V(4).Info("Run Garbage Collection")
+	if err := gc.collectWorkloads(ctx); err != nil {
+		log.Error(err, "Collecting the orphaned workloads")
+	}
+	if gc.provisioningRequests {
+		if err := gc.collectProvisioningRequests(ctx); err != nil {
+			log.Error(err, "Collecting the dangling provisioning requests")
+		}
+	}
+}
+
+// expired returns whether the grace period elapsed since the time.
+func (gc *GarbageCollector) expired(t time.Time) bool {
+	return gc.clock.Since(t) >= gc.gracePeriod
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	autoscaling "k8s.io/autoscaler/cluster-autoscaler/apis/provisioningrequest/autoscaling.x-k8s.io/v1beta1"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	podcontroller "sigs.k8s.io/kueue/pkg/controller/jobs/pod"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
+	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+)
+
+func TestCollect(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	old := now.Add(-time.Hour)
+	jobGVK := batchv1.SchemeGroupVersion.WithKind("Job")
+	podGVK := corev1.SchemeGroupVersion.WithKind("Pod")
+	workloadGVK := kueue.GroupVersion.WithKind("Workload")
+	groupAnnotations := map[string]string{
+		podcontroller.IsGroupWorkloadAnnotationKey: podcontroller.IsGroupWorkloadAnnotationValue,
+	}
+	makeRequest := func(name string, created time.Time, owner *kueue.Workload) *autoscaling.ProvisioningRequest {
+		return &autoscaling.ProvisioningRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "ns",
+				CreationTimestamp: metav1.NewTime(created),
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: workloadGVK.GroupVersion().String(),
+					Kind:       workloadGVK.Kind,
+					Name:       owner.Name,
+					UID:        owner.UID,
+					Controller: ptr.To(true),
+				}},
+			},
+		}
+	}
+	runningWl := utiltesting.MakeWorkload("running", "ns").UID("running-uid").Obj()
+	finishedWl := utiltesting.MakeWorkload("finished", "ns").UID("finished-uid").Finished().Obj()
+	deletedWl := utiltesting.MakeWorkload("deleted", "ns").UID("deleted-uid").Obj()
+
+	cases := map[string]struct {
+		objs                 []client.Object
+		provisioningRequests bool
+		wantWorkloads        sets.Set[string]
+		wantRequests         sets.Set[string]
+	}{
+		"workloads of existing owners are kept": {
+			objs: []client.Object{
+				testingjob.MakeJob("job", "ns").UID("job-uid").Obj(),
+				utiltesting.MakeWorkload("wl", "ns").
+					ControllerReference(jobGVK, "job", "job-uid").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					Creation(old).
+					Obj(),
+			},
+			wantWorkloads: sets.New("wl"),
+		},
+		"orphaned workloads are deleted": {
+			objs: []client.Object{
+				utiltesting.MakeWorkload("wl", "ns").
+					ControllerReference(jobGVK, "job", "job-uid").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					Creation(old).
+					Obj(),
+			},
+			wantWorkloads: sets.New[string](),
+		},
+		"workloads of recreated owners are deleted": {
+			objs: []client.Object{
+				testingjob.MakeJob("job", "ns").UID("new-job-uid").Obj(),
+				utiltesting.MakeWorkload("wl", "ns").
+					ControllerReference(jobGVK, "job", "job-uid").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					Creation(old).
+					Obj(),
+			},
+			wantWorkloads: sets.New[string](),
+		},
+		"finalizers of deleted orphaned workloads are removed": {
+			objs: []client.Object{
+				utiltesting.MakeWorkload("wl", "ns").
+					ControllerReference(jobGVK, "job", "job-uid").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					Creation(old).
+					DeletionTimestamp(old).
+					Obj(),
+			},
+			wantWorkloads: sets.New[string](),
+		},
+		"orphaned workloads within the grace period are kept": {
+			objs: []client.Object{
+				utiltesting.MakeWorkload("wl", "ns").
+					ControllerReference(jobGVK, "job", "job-uid").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					Creation(now).
+					Obj(),
+			},
+			wantWorkloads: sets.New("wl"),
+		},
+		"workloads of pod groups are deleted when all the pods are deleted": {
+			objs: []client.Object{
+				testingpod.MakePod("pod-a1", "ns").UID("pod-a1-uid").Obj(),
+				utiltesting.MakeWorkload("group-a", "ns").
+					OwnerReference(podGVK, "pod-a1", "pod-a1-uid").
+					OwnerReference(podGVK, "pod-a2", "pod-a2-uid").
+					Annotations(groupAnnotations).
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					Creation(old).
+					Obj(),
+				utiltesting.MakeWorkload("group-b", "ns").
+					OwnerReference(podGVK, "pod-b1", "pod-b1-uid").
+					OwnerReference(podGVK, "pod-b2", "pod-b2-uid").
+					Annotations(groupAnnotations).
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					Creation(old).
+					Obj(),
+			},
+			wantWorkloads: sets.New("group-a"),
+		},
+		"provisioning requests of deleted and finished workloads are deleted": {
+			objs: []client.Object{
+				runningWl,
+				finishedWl,
+				makeRequest("running-check-1", old, runningWl),
+				makeRequest("finished-check-1", old, finishedWl),
+				makeRequest("deleted-check-1", old, deletedWl),
+				makeRequest("deleted-check-2", now, deletedWl),
+			},
+			provisioningRequests: true,
+			wantWorkloads:        sets.New("running", "finished"),
+			wantRequests:         sets.New("running-check-1", "deleted-check-2"),
+		},
+		"provisioning requests aren't collected when disabled": {
+			objs: []client.Object{
+				makeRequest("deleted-check-1", old, deletedWl),
+			},
+			wantWorkloads: sets.New[string](),
+			wantRequests:  sets.New("deleted-check-1"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder(autoscaling.AddToScheme).WithObjects(tc.objs...).Build()
+			collector := New(cl, cl,
+				WithClock(testingclock.NewFakeClock(now)),
+				WithProvisioningRequests(tc.provisioningRequests))
+
+			collector.collect(ctx)
+
+			wls := &kueue.WorkloadList{}
+			if err := cl.List(ctx, wls); err != nil {
+				t.Fatalf("Listing workloads: %v", err)
+			}
+			gotWorkloads := sets.New[string]()
+			for _, wl := range wls.Items {
+				gotWorkloads.Insert(wl.Name)
+			}
+			if diff := cmp.Diff(tc.wantWorkloads, gotWorkloads, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected workloads (-want,+got):\n%s", diff)
+			}
+			prs := &autoscaling.ProvisioningRequestList{}
+			if err := cl.List(ctx, prs); err != nil {
+				t.Fatalf("Listing provisioning requests: %v", err)
+			}
+			gotRequests := sets.New[string]()
+			for _, pr := range prs.Items {
+				gotRequests.Insert(pr.Name)
+			}
+			if diff := cmp.Diff(tc.wantRequests, gotRequests, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected provisioning requests (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
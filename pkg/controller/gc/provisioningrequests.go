@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	autoscaling "k8s.io/autoscaler/cluster-autoscaler/apis/provisioningrequest/autoscaling.x-k8s.io/v1beta1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const provisioningRequestKind = "ProvisioningRequest"
+
+// +kubebuilder:rbac:groups=autoscaling.x-k8s.io,resources=provisioningrequests,verbs=get;list;watch;delete
+
+// collectProvisioningRequests deletes the ProvisioningRequests of the deleted
+// or finished Workloads.
+func (gc *GarbageCollector) collectProvisioningRequests(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+	prs := &autoscaling.ProvisioningRequestList{}
+	if err := gc.client.List(ctx, prs); err != nil {
+		return fmt.Errorf("listing provisioning requests: %w", err)
+	}
+	for i := range prs.Items {
+		pr := &prs.Items[i]
+		prLog := log.WithValues("provisioningRequest", klog.KObj(pr))
+		owner := metav1.GetControllerOf(pr)
+		if owner == nil || owner.Kind != workloadKind || owner.APIVersion != kueue.GroupVersion.String() {
+			continue
+		}
+		if !pr.DeletionTimestamp.IsZero() || !gc.expired(pr.CreationTimestamp.Time) {
+			continue
+		}
+		reason, err := gc.provisioningRequestDangling(ctx, pr.Namespace, owner)
+		if err != nil {
+			prLog.Error(err, "Reading the workload of provisioning request")
+			continue
+		}
+		if reason == "" {
+			continue
+		}
+		prLog.V(3).Info("WorkloadGC deleting dangling provisioning request", "reason", reason)
+		if err := gc.client.Delete(ctx, pr); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				prLog.Error(err, "Deleting dangling provisioning request")
+			}
+			continue
+		}
+		metrics.ReportGarbageCollectedObject(provisioningRequestKind, reason)
+	}
+	return nil
+}
+
+// provisioningRequestDangling returns the reason for which the
+// ProvisioningRequest owned by the Workload isn't needed anymore, or an
+// empty reason if it's still needed.
+func (gc *GarbageCollector) provisioningRequestDangling(ctx context.Context, namespace string, owner *metav1.OwnerReference) (string, error) {
+	wl := &kueue.Workload{}
+	if err := gc.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: owner.Name}, wl); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return "", err
+		}
+		return ReasonWorkloadDeleted, nil
+	}
+	if wl.UID != owner.UID {
+		return ReasonWorkloadDeleted, nil
+	}
+	if workload.IsFinished(wl) {
+		return ReasonWorkloadFinished, nil
+	}
+	return "", nil
+}
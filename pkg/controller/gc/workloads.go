@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	podcontroller "sigs.k8s.io/kueue/pkg/controller/jobs/pod"
+	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const workloadKind = "Workload"
+
+// collectWorkloads removes the finalizer of the Workloads all the owners of
+// which are deleted, and deletes them.
+func (gc *GarbageCollector) collectWorkloads(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx)
+	wls := &kueue.WorkloadList{}
+	if err := gc.client.List(ctx, wls); err != nil {
+		return fmt.Errorf("listing workloads: %w", err)
+	}
+	for i := range wls.Items {
+		wl := &wls.Items[i]
+		wlLog := log.WithValues("workload", klog.KObj(wl))
+		if len(wl.OwnerReferences) == 0 {
+			continue
+		}
+		if wl.DeletionTimestamp.IsZero() && !gc.expired(wl.CreationTimestamp.Time) {
+			continue
+		}
+		if !wl.DeletionTimestamp.IsZero() && (!gc.expired(wl.DeletionTimestamp.Time) || len(wl.Finalizers) == 0) {
+			continue
+		}
+		orphaned, err := gc.ownersDeleted(ctx, wl)
+		if err != nil {
+			wlLog.V(2).Info("Skip workload with unknown owners", "error", err)
+			continue
+		}
+		if !orphaned {
+			continue
+		}
+		reason := ReasonOwnerDeleted
+		if wl.Annotations[podcontroller.IsGroupWorkloadAnnotationKey] == podcontroller.IsGroupWorkloadAnnotationValue {
+			reason = ReasonPodGroupDeleted
+		}
+		wlLog.V(3).Info("WorkloadGC deleting orphaned workload", "reason", reason)
+		// The finalizer is removed first, as the deletion would conflict with
+		// the update.
+		if err := workload.RemoveFinalizer(ctx, gc.client, wl); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				wlLog.Error(err, "Removing the finalizer of orphaned workload")
+			}
+			continue
+		}
+		if wl.DeletionTimestamp.IsZero() {
+			if err := gc.client.Delete(ctx, wl); err != nil {
+				if client.IgnoreNotFound(err) != nil {
+					wlLog.Error(err, "Deleting orphaned workload")
+				}
+				continue
+			}
+		}
+		metrics.ReportGarbageCollectedObject(workloadKind, reason)
+	}
+	return nil
+}
+
+// ownersDeleted returns whether all the owners of the object are deleted.
+func (gc *GarbageCollector) ownersDeleted(ctx context.Context, obj client.Object) (bool, error) {
+	for _, ref := range obj.GetOwnerReferences() {
+		deleted, err := gc.ownerDeleted(ctx, obj.GetNamespace(), ref)
+		if err != nil || !deleted {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (gc *GarbageCollector) ownerDeleted(ctx context.Context, namespace string, ref metav1.OwnerReference) (bool, error) {
+	owner := &metav1.PartialObjectMetadata{}
+	owner.SetGroupVersionKind(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind))
+	err := gc.apiReader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, owner)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	// The owner was recreated with the same name.
+	return owner.GetUID() != ref.UID, nil
+}
@@ -27,6 +27,8 @@ import (
 
 	"sigs.k8s.io/kueue/pkg/controller/jobframework/webhook"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/authorization"
+	"sigs.k8s.io/kueue/pkg/util/submitter"
 )
 
 // BaseWebhook applies basic defaulting and validation for jobs.
@@ -34,8 +36,11 @@ type BaseWebhook struct {
 	Client                       client.Client
 	ManageJobsWithoutQueueName   bool
 	ManagedJobsNamespaceSelector labels.Selector
+	IntegrationScope             *IntegrationScope
 	FromObject                   func(runtime.Object) GenericJob
 	Queues                       *queue.Manager
+	LocalQueueAuthorizer         *authorization.LocalQueueAuthorizer
+	SubmitterRecorder            *submitter.Recorder
 }
 
 func BaseWebhookFactory(job GenericJob, fromObject func(runtime.Object) GenericJob) func(ctrl.Manager, ...Option) error {
@@ -45,8 +50,11 @@ func BaseWebhookFactory(job GenericJob, fromObject func(runtime.Object) GenericJ
 			Client:                       mgr.GetClient(),
 			ManageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
 			ManagedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
+			IntegrationScope:             options.IntegrationScope,
 			FromObject:                   fromObject,
 			Queues:                       options.Queues,
+			LocalQueueAuthorizer:         options.LocalQueueAuthorizer,
+			SubmitterRecorder:            options.SubmitterRecorder,
 		}
 		return webhook.WebhookManagedBy(mgr).
 			For(job.Object()).
@@ -64,7 +72,10 @@ func (w *BaseWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	log := ctrl.LoggerFrom(ctx)
 	log.V(5).Info("Applying defaults")
 	ApplyDefaultLocalQueue(job.Object(), w.Queues.DefaultLocalQueueExist)
-	return ApplyDefaultForSuspend(ctx, job, w.Client, w.ManageJobsWithoutQueueName, w.ManagedJobsNamespaceSelector)
+	if err := w.SubmitterRecorder.Record(ctx, job.Object()); err != nil {
+		return err
+	}
+	return ApplyDefaultForSuspend(ctx, job, w.Client, w.Queues, w.ManageJobsWithoutQueueName, w.ManagedJobsNamespaceSelector, w.IntegrationScope)
 }
 
 var _ admission.CustomValidator = &BaseWebhook{}
@@ -75,6 +86,9 @@ func (w *BaseWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (a
 	log := ctrl.LoggerFrom(ctx)
 	log.V(5).Info("Validating create")
 	allErrs := ValidateJobOnCreate(job)
+	allErrs = append(allErrs, ValidateLocalQueueAuthorization(ctx, w.LocalQueueAuthorizer, job.Object())...)
+	allErrs = append(allErrs, ValidateAllowedPriorityClass(w.Queues, job)...)
+	allErrs = append(allErrs, ValidateExcludedFlavorsInClusterQueue(w.Queues, job)...)
 	if jobWithValidation, ok := job.(JobWithCustomValidation); ok {
 		allErrs = append(allErrs, jobWithValidation.ValidateOnCreate()...)
 	}
@@ -88,6 +102,8 @@ func (w *BaseWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime
 	log := ctrl.LoggerFrom(ctx)
 	log.Info("Validating update")
 	allErrs := ValidateJobOnUpdate(oldJob, newJob)
+	allErrs = append(allErrs, ValidateAllowedPriorityClassOnUpdate(w.Queues, oldJob, newJob)...)
+	allErrs = append(allErrs, ValidateExcludedFlavorsInClusterQueueOnUpdate(w.Queues, oldJob, newJob)...)
 	if jobWithValidation, ok := newJob.(JobWithCustomValidation); ok {
 		allErrs = append(allErrs, jobWithValidation.ValidateOnUpdate(oldJob)...)
 	}
@@ -27,11 +27,12 @@ import (
 
 	"sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/queue"
 )
 
-func ApplyDefaultForSuspend(ctx context.Context, job GenericJob, k8sClient client.Client,
-	manageJobsWithoutQueueName bool, managedJobsNamespaceSelector labels.Selector) error {
-	suspend, err := WorkloadShouldBeSuspended(ctx, job.Object(), k8sClient, manageJobsWithoutQueueName, managedJobsNamespaceSelector)
+func ApplyDefaultForSuspend(ctx context.Context, job GenericJob, k8sClient client.Client, queues *queue.Manager,
+	manageJobsWithoutQueueName bool, managedJobsNamespaceSelector labels.Selector, integrationScope *IntegrationScope) error {
+	suspend, err := WorkloadShouldBeSuspended(ctx, job.Object(), k8sClient, queues, manageJobsWithoutQueueName, managedJobsNamespaceSelector, integrationScope)
 	if err != nil {
 		return err
 	}
@@ -42,18 +43,33 @@ func ApplyDefaultForSuspend(ctx context.Context, job GenericJob, k8sClient clien
 }
 
 // WorkloadShouldBeSuspended determines whether jobObj should be default suspended on creation
-func WorkloadShouldBeSuspended(ctx context.Context, jobObj client.Object, k8sClient client.Client,
-	manageJobsWithoutQueueName bool, managedJobsNamespaceSelector labels.Selector) (bool, error) {
+func WorkloadShouldBeSuspended(ctx context.Context, jobObj client.Object, k8sClient client.Client, queues *queue.Manager,
+	manageJobsWithoutQueueName bool, managedJobsNamespaceSelector labels.Selector, integrationScope *IntegrationScope) (bool, error) {
 	// Do not default suspend a job whose owner is already managed by Kueue
 	if owner := metav1.GetControllerOf(jobObj); owner != nil && IsOwnerManagedByKueue(owner) {
 		return false, nil
 	}
 
+	// Do not default suspend a job out of the scope of the Integration of its framework
+	if managed, err := integrationScope.ManagesNamespace(ctx, k8sClient, jobObj.GetNamespace()); err != nil || !managed {
+		return false, err
+	}
+
+	// Do not default suspend a job whose ClusterQueue is in shadow mode
+	if queues.LocalQueueInShadowMode(queue.QueueKey(jobObj.GetNamespace(), QueueNameForObject(jobObj))) {
+		return false, nil
+	}
+
 	// Jobs with queue names whose parents are not managed by Kueue are default suspended
 	if QueueNameForObject(jobObj) != "" {
 		return true, nil
 	}
 
+	// The rules of the Integration take precedence over the configuration
+	if manage, matched, err := integrationScope.ManagesJobsWithoutQueueName(ctx, k8sClient, jobObj.GetNamespace()); err != nil || matched {
+		return manage, err
+	}
+
 	// Logic for managing jobs without queue names.
 	if manageJobsWithoutQueueName {
 		if features.Enabled(features.ManagedJobsNamespaceSelector) {
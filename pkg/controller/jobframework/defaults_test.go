@@ -22,6 +22,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"sigs.k8s.io/kueue/pkg/features"
@@ -54,11 +55,25 @@ func TestWorkloadShouldBeSuspended(t *testing.T) {
 		},
 	}
 	namespaceSelector, _ := metav1.LabelSelectorAsSelector(ls)
+	onlyManagedNamespace := &IntegrationScope{}
+	onlyManagedNamespace.Set(true, labels.SelectorFromSet(labels.Set{"kubernetes.io/metadata.name": managedNamespace.Name}), nil)
+	disabled := &IntegrationScope{}
+	disabled.Set(false, labels.Everything(), nil)
+	ignoreUnmanagedNamespace := &IntegrationScope{}
+	ignoreUnmanagedNamespace.Set(true, labels.Everything(), []ManageJobsWithoutQueueNameRule{
+		{NamespaceSelector: labels.SelectorFromSet(labels.Set{"kubernetes.io/metadata.name": unmanagedNamespace.Name}), Manage: false},
+		{NamespaceSelector: labels.Everything(), Manage: true},
+	})
+	manageUnmanagedNamespace := &IntegrationScope{}
+	manageUnmanagedNamespace.Set(true, labels.Everything(), []ManageJobsWithoutQueueNameRule{
+		{NamespaceSelector: labels.SelectorFromSet(labels.Set{"kubernetes.io/metadata.name": unmanagedNamespace.Name}), Manage: true},
+	})
 
 	cases := map[string]struct {
 		obj                        client.Object
 		manageJobsWithoutQueueName bool
 		featureGateEnabled         bool
+		integrationScope           *IntegrationScope
 		wantSuspend                bool
 	}{
 		"job with queue name ": {
@@ -111,6 +126,62 @@ func TestWorkloadShouldBeSuspended(t *testing.T) {
 			featureGateEnabled:         false,
 			wantSuspend:                true,
 		},
+		"job with queue name in namespace selected by the integration": {
+			obj:                        utiltestingjob.MakeJob("test-job", managedNamespace.Name).Queue("default").Obj(),
+			manageJobsWithoutQueueName: false,
+			featureGateEnabled:         true,
+			integrationScope:           onlyManagedNamespace,
+			wantSuspend:                true,
+		},
+		"job with queue name in namespace not selected by the integration": {
+			obj:                        utiltestingjob.MakeJob("test-job", unmanagedNamespace.Name).Queue("default").Obj(),
+			manageJobsWithoutQueueName: false,
+			featureGateEnabled:         true,
+			integrationScope:           onlyManagedNamespace,
+			wantSuspend:                false,
+		},
+		"job with queue name with integration disabled": {
+			obj:                        utiltestingjob.MakeJob("test-job", managedNamespace.Name).Queue("default").Obj(),
+			manageJobsWithoutQueueName: false,
+			featureGateEnabled:         true,
+			integrationScope:           disabled,
+			wantSuspend:                false,
+		},
+		"job without queue name with manageJobs with integration disabled": {
+			obj:                        utiltestingjob.MakeJob("test-job", managedNamespace.Name).Obj(),
+			manageJobsWithoutQueueName: true,
+			featureGateEnabled:         true,
+			integrationScope:           disabled,
+			wantSuspend:                false,
+		},
+		"job without queue name managed by the first matching rule": {
+			obj:                        utiltestingjob.MakeJob("test-job", managedNamespace.Name).Obj(),
+			manageJobsWithoutQueueName: false,
+			featureGateEnabled:         true,
+			integrationScope:           ignoreUnmanagedNamespace,
+			wantSuspend:                true,
+		},
+		"job without queue name ignored by the first matching rule": {
+			obj:                        utiltestingjob.MakeJob("test-job", unmanagedNamespace.Name).Obj(),
+			manageJobsWithoutQueueName: true,
+			featureGateEnabled:         true,
+			integrationScope:           ignoreUnmanagedNamespace,
+			wantSuspend:                false,
+		},
+		"job without queue name managed by a rule in namespace not matching the selector": {
+			obj:                        utiltestingjob.MakeJob("test-job", unmanagedNamespace.Name).Obj(),
+			manageJobsWithoutQueueName: true,
+			featureGateEnabled:         true,
+			integrationScope:           manageUnmanagedNamespace,
+			wantSuspend:                true,
+		},
+		"job without queue name not matching any rule": {
+			obj:                        utiltestingjob.MakeJob("test-job", managedNamespace.Name).Obj(),
+			manageJobsWithoutQueueName: false,
+			featureGateEnabled:         true,
+			integrationScope:           manageUnmanagedNamespace,
+			wantSuspend:                false,
+		},
 	}
 
 	for tcName, tc := range cases {
@@ -121,7 +192,7 @@ func TestWorkloadShouldBeSuspended(t *testing.T) {
 			ctx, _ := utiltesting.ContextWithLog(t)
 
 			features.SetFeatureGateDuringTest(t, features.ManagedJobsNamespaceSelector, tc.featureGateEnabled)
-			suspend, err := WorkloadShouldBeSuspended(ctx, tc.obj, client, tc.manageJobsWithoutQueueName, namespaceSelector)
+			suspend, err := WorkloadShouldBeSuspended(ctx, tc.obj, client, nil, tc.manageJobsWithoutQueueName, namespaceSelector, tc.integrationScope)
 			if err != nil {
 				t.Errorf("Got error: %v", err)
 			}
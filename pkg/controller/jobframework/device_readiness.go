@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
+)
+
+// deviceReadinessCheckInterval is the period at which the devices of the
+// nodes of the admitted workloads are checked while their jobs are delayed.
+const deviceReadinessCheckInterval = 15 * time.Second
+
+// flavorResource is a resource assigned to a flavor.
+type flavorResource struct {
+	flavor   kueue.ResourceFlavorReference
+	resource corev1.ResourceName
+}
+
+// waitForDevices returns when to check the devices again, if the start of
+// the job of the admitted workload should be delayed because some nodes of
+// the flavors assigned to the devices it requests are not ready, or 0 if the
+// job can be started.
+func (r *JobReconciler) waitForDevices(ctx context.Context, object client.Object, wl *kueue.Workload) (time.Duration, error) {
+	if r.deviceReadiness == nil || wl.Status.Admission == nil {
+		return 0, nil
+	}
+	remaining := r.deviceReadiness.Timeout.Duration
+	if admittedCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadAdmitted); admittedCond != nil {
+		remaining -= r.clock.Since(admittedCond.LastTransitionTime.Time)
+	}
+	if remaining <= 0 {
+		return 0, nil
+	}
+
+	var devices []flavorResource
+	for _, psa := range wl.Status.Admission.PodSetAssignments {
+		for _, name := range r.deviceReadiness.ResourceNames {
+			usage, requested := psa.ResourceUsage[name]
+			flavor, assigned := psa.Flavors[name]
+			if !requested || usage.IsZero() || !assigned {
+				continue
+			}
+			if fr := (flavorResource{flavor: flavor, resource: name}); !slices.Contains(devices, fr) {
+				devices = append(devices, fr)
+			}
+		}
+	}
+	for _, fr := range devices {
+		notReady, err := r.nodesWithoutDevice(ctx, fr)
+		if err != nil {
+			return 0, err
+		}
+		if len(notReady) > 0 {
+			msg := fmt.Sprintf("Waiting for the %s devices of the nodes %v of the flavor %s", fr.resource, notReady, fr.flavor)
+			ctrl.LoggerFrom(ctx).V(2).Info("Delaying the start of the job", "reason", msg)
+			r.record.Event(object, corev1.EventTypeNormal, ReasonWaitingForDevices, msg)
+			return min(remaining, deviceReadinessCheckInterval), nil
+		}
+	}
+	return 0, nil
+}
+
+// nodesWithoutDevice returns the names, sorted, of the schedulable nodes
+// matching the node labels of the flavor which are not ready, or don't
+// report the resource as allocatable.
+// The flavors without node labels are not checked.
+func (r *JobReconciler) nodesWithoutDevice(ctx context.Context, fr flavorResource) ([]string, error) {
+	rf := &kueue.ResourceFlavor{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: string(fr.flavor)}, rf); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if len(rf.Spec.NodeLabels) == 0 {
+		return nil, nil
+	}
+	nodes := &corev1.NodeList{}
+	if err := r.client.List(ctx, nodes, client.MatchingLabels(rf.Spec.NodeLabels)); err != nil {
+		return nil, err
+	}
+	var notReady []string
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable || !node.DeletionTimestamp.IsZero() {
+			continue
+		}
+		allocatable := node.Status.Allocatable[fr.resource]
+		if !utiltas.IsNodeStatusConditionTrue(node.Status.Conditions, corev1.NodeReady) || allocatable.IsZero() {
+			notReady = append(notReady, node.Name)
+		}
+	}
+	slices.Sort(notReady)
+	return notReady, nil
+}
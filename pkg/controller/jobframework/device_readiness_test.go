@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
+)
+
+func TestWaitForDevices(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	gpuFlavor := utiltesting.MakeResourceFlavor("gpu").NodeLabel("instance-type", "a100").Obj()
+	gpuNode := func(name string) *testingnode.NodeWrapper {
+		return testingnode.MakeNode(name).Label("instance-type", "a100")
+	}
+	gpus := corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")}
+	gpuWorkload := utiltesting.MakeWorkload("wl", "ns").
+		ReserveQuota(utiltesting.MakeAdmission("cq").
+			Assignment(corev1.ResourceCPU, "gpu", "1").
+			Assignment("nvidia.com/gpu", "gpu", "1").
+			Obj()).
+		AdmittedAt(true, now.Add(-time.Minute))
+	cases := map[string]struct {
+		deviceReadiness  *configapi.DeviceReadiness
+		workload         *kueue.Workload
+		nodes            []client.Object
+		wantRecheckAfter time.Duration
+	}{
+		"not configured": {
+			workload: gpuWorkload.Clone().Obj(),
+			nodes:    []client.Object{gpuNode("n1").NotReady().Obj()},
+		},
+		"devices ready": {
+			deviceReadiness: &configapi.DeviceReadiness{
+				ResourceNames: []corev1.ResourceName{"nvidia.com/gpu"},
+				Timeout:       &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			workload: gpuWorkload.Clone().Obj(),
+			nodes: []client.Object{
+				gpuNode("n1").Ready().StatusAllocatable(gpus).Obj(),
+				testingnode.MakeNode("cpu").Ready().Obj(),
+			},
+		},
+		"node not ready": {
+			deviceReadiness: &configapi.DeviceReadiness{
+				ResourceNames: []corev1.ResourceName{"nvidia.com/gpu"},
+				Timeout:       &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			workload: gpuWorkload.Clone().Obj(),
+			nodes: []client.Object{
+				gpuNode("n1").Ready().StatusAllocatable(gpus).Obj(),
+				gpuNode("n2").NotReady().StatusAllocatable(gpus).Obj(),
+			},
+			wantRecheckAfter: deviceReadinessCheckInterval,
+		},
+		"device plugin not registered": {
+			deviceReadiness: &configapi.DeviceReadiness{
+				ResourceNames: []corev1.ResourceName{"nvidia.com/gpu"},
+				Timeout:       &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			workload:         gpuWorkload.Clone().Obj(),
+			nodes:            []client.Object{gpuNode("n1").Ready().Obj()},
+			wantRecheckAfter: deviceReadinessCheckInterval,
+		},
+		"cordoned node": {
+			deviceReadiness: &configapi.DeviceReadiness{
+				ResourceNames: []corev1.ResourceName{"nvidia.com/gpu"},
+				Timeout:       &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			workload: gpuWorkload.Clone().Obj(),
+			nodes: []client.Object{
+				gpuNode("n1").Ready().StatusAllocatable(gpus).Obj(),
+				func() client.Object {
+					node := gpuNode("n2").NotReady().Obj()
+					node.Spec.Unschedulable = true
+					return node
+				}(),
+			},
+		},
+		"timeout almost elapsed": {
+			deviceReadiness: &configapi.DeviceReadiness{
+				ResourceNames: []corev1.ResourceName{"nvidia.com/gpu"},
+				Timeout:       &metav1.Duration{Duration: time.Minute + 5*time.Second},
+			},
+			workload:         gpuWorkload.Clone().Obj(),
+			nodes:            []client.Object{gpuNode("n1").Ready().Obj()},
+			wantRecheckAfter: 5 * time.Second,
+		},
+		"timeout elapsed": {
+			deviceReadiness: &configapi.DeviceReadiness{
+				ResourceNames: []corev1.ResourceName{"nvidia.com/gpu"},
+				Timeout:       &metav1.Duration{Duration: 30 * time.Second},
+			},
+			workload: gpuWorkload.Clone().Obj(),
+			nodes:    []client.Object{gpuNode("n1").Ready().Obj()},
+		},
+		"no devices requested": {
+			deviceReadiness: &configapi.DeviceReadiness{
+				ResourceNames: []corev1.ResourceName{"nvidia.com/gpu"},
+				Timeout:       &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("cq").
+					Assignment(corev1.ResourceCPU, "gpu", "1").
+					Obj()).
+				AdmittedAt(true, now.Add(-time.Minute)).
+				Obj(),
+			nodes: []client.Object{gpuNode("n1").Ready().Obj()},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := utiltesting.NewClientBuilder().
+				WithObjects(gpuFlavor, tc.workload).
+				WithObjects(tc.nodes...).
+				Build()
+			recorder := record.NewFakeRecorder(10)
+			r := NewReconciler(cl, recorder,
+				WithDeviceReadiness(tc.deviceReadiness),
+				WithClock(t, testingclock.NewFakeClock(now)),
+			)
+
+			recheckAfter, err := r.waitForDevices(context.Background(), tc.workload, tc.workload)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if recheckAfter != tc.wantRecheckAfter {
+				t.Errorf("Unexpected recheck after, want=%v, got=%v", tc.wantRecheckAfter, recheckAfter)
+			}
+			if gotEvents := len(recorder.Events); (gotEvents > 0) != (tc.wantRecheckAfter > 0) {
+				t.Errorf("Unexpected number of events: %d", gotEvents)
+			}
+		})
+	}
+}
@@ -27,4 +27,9 @@ const (
 	ReasonFinishedWorkload      = "FinishedWorkload"
 	ReasonErrWorkloadCompose    = "ErrWorkloadCompose"
 	ReasonUpdatedAdmissionCheck = "UpdatedAdmissionCheck"
+	ReasonRerouted              = "Rerouted"
+	ReasonIdleReclaimed         = "IdleReclaimed"
+	ReasonIdleRestored          = "IdleRestored"
+	ReasonWaitingForDevices     = "WaitingForDevices"
+	ReasonExternallyResumed     = "ExternallyResumed"
 )
@@ -0,0 +1,380 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
+	clientutil "sigs.k8s.io/kueue/pkg/util/client"
+)
+
+// IdleAction is the action used to reclaim the quota of an idle serving workload.
+type IdleAction string
+
+const (
+	// IdleActionScaleDown scales the serving workload down to zero replicas.
+	IdleActionScaleDown IdleAction = "ScaleDown"
+	// IdleActionDeactivate deactivates the Workload of the serving workload.
+	IdleActionDeactivate IdleAction = "Deactivate"
+)
+
+// idleCheckInterval is the period at which the usage of the serving
+// workloads configured for the idle reclamation is checked.
+const idleCheckInterval = time.Minute
+
+var defaultIdleCPUThreshold = resource.MustParse("1m")
+
+// IdleReclamation is the idle reclamation configuration of a serving workload.
+type IdleReclamation struct {
+	Timeout      time.Duration
+	Action       IdleAction
+	CPUThreshold resource.Quantity
+}
+
+// IdleReclaimable is implemented by the serving integrations supporting the
+// reclamation of the quota of idle workloads.
+type IdleReclaimable interface {
+	// Object returns the serving workload instance.
+	Object() client.Object
+	// PodSelector returns the selector of the pods of the serving workload.
+	PodSelector() (labels.Selector, error)
+	// Replicas returns the desired number of replicas.
+	Replicas() int32
+	// SetReplicas sets the desired number of replicas.
+	SetReplicas(replicas int32)
+}
+
+// IdleDeactivatable is implemented by the serving integrations whose pods are
+// managed by a single Workload, which can be deactivated to reclaim the quota.
+type IdleDeactivatable interface {
+	IdleReclaimable
+	// WorkloadName returns the name of the Workload of the serving workload.
+	WorkloadName() string
+}
+
+// IdleReclamationFor returns the idle reclamation configuration of the object,
+// or nil if the object is not configured for the idle reclamation.
+func IdleReclamationFor(obj client.Object) (*IdleReclamation, error) {
+	annotations := obj.GetAnnotations()
+	timeoutStr, found := annotations[constants.IdleTimeoutAnnotation]
+	if !found {
+		return nil, nil
+	}
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil {
+		return nil, err
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("%q is not a positive duration", timeoutStr)
+	}
+	cfg := &IdleReclamation{
+		Timeout:      timeout,
+		Action:       IdleActionScaleDown,
+		CPUThreshold: defaultIdleCPUThreshold,
+	}
+	if action, found := annotations[constants.IdleActionAnnotation]; found {
+		cfg.Action = IdleAction(action)
+	}
+	if thresholdStr, found := annotations[constants.IdleCPUThresholdAnnotation]; found {
+		if cfg.CPUThreshold, err = resource.ParseQuantity(thresholdStr); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// ValidateIdleReclamation validates the idle reclamation annotations of the object,
+// given the actions supported by its integration.
+func ValidateIdleReclamation(obj client.Object, supportedActions ...IdleAction) field.ErrorList {
+	var allErrs field.ErrorList
+	annotations := obj.GetAnnotations()
+	if timeout, found := annotations[constants.IdleTimeoutAnnotation]; found {
+		if d, err := time.ParseDuration(timeout); err != nil || d <= 0 {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key(constants.IdleTimeoutAnnotation), timeout, "must be a positive duration"))
+		}
+	}
+	if action, found := annotations[constants.IdleActionAnnotation]; found {
+		supported := make([]string, len(supportedActions))
+		for i, a := range supportedActions {
+			supported[i] = string(a)
+		}
+		if !slices.Contains(supported, action) {
+			allErrs = append(allErrs, field.NotSupported(annotationsPath.Key(constants.IdleActionAnnotation), action, supported))
+		}
+	}
+	if threshold, found := annotations[constants.IdleCPUThresholdAnnotation]; found {
+		if q, err := resource.ParseQuantity(threshold); err != nil || q.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(annotationsPath.Key(constants.IdleCPUThresholdAnnotation), threshold, "must be a non-negative quantity"))
+		}
+	}
+	return allErrs
+}
+
+// IdleReclaimer reclaims the quota of the serving workloads which are idle,
+// based on the CPU usage of their pods reported by the metrics API.
+type IdleReclaimer struct {
+	client    client.Client
+	apiReader client.Reader
+	record    record.EventRecorder
+	clock     clock.Clock
+}
+
+func NewIdleReclaimer(client client.Client, record record.EventRecorder, opts ...Option) *IdleReclaimer {
+	options := ProcessOptions(opts...)
+	r := &IdleReclaimer{
+		client:    client,
+		apiReader: options.APIReader,
+		record:    record,
+		clock:     options.Clock,
+	}
+	if r.apiReader == nil {
+		r.apiReader = client
+	}
+	return r
+}
+
+// Reconcile checks whether the serving workload is idle and, if it has been
+// idle for longer than its timeout, reclaims its quota.
+func (r *IdleReclaimer) Reconcile(ctx context.Context, job IdleReclaimable) (ctrl.Result, error) {
+	if !features.Enabled(features.IdleServingReclamation) {
+		return ctrl.Result{}, nil
+	}
+	object := job.Object()
+	log := ctrl.LoggerFrom(ctx)
+
+	cfg, err := IdleReclamationFor(object)
+	if err != nil {
+		log.V(2).Info("Ignoring invalid idle reclamation configuration", "err", err)
+		return ctrl.Result{}, nil
+	}
+	if restored, err := r.restore(ctx, job, cfg); err != nil || restored {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if cfg == nil || QueueNameForObject(object) == "" {
+		return ctrl.Result{}, r.markActive(ctx, job)
+	}
+
+	reclaimed, err := r.isReclaimed(ctx, job, cfg)
+	if err != nil || reclaimed {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	idle, err := r.isIdle(ctx, job, cfg)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !idle {
+		return ctrl.Result{RequeueAfter: idleCheckInterval}, r.markActive(ctx, job)
+	}
+
+	idleSince, err := time.Parse(time.RFC3339, object.GetAnnotations()[constants.IdleSinceAnnotation])
+	if err != nil {
+		now := r.clock.Now()
+		if err := clientutil.Patch(ctx, r.client, object, true, func() (bool, error) {
+			setAnnotation(object, constants.IdleSinceAnnotation, now.UTC().Format(time.RFC3339))
+			return true, nil
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+		log.V(3).Info("Serving workload is idle", "idleSince", now)
+		return ctrl.Result{RequeueAfter: min(cfg.Timeout, idleCheckInterval)}, nil
+	}
+	if remaining := cfg.Timeout - r.clock.Since(idleSince); remaining > 0 {
+		return ctrl.Result{RequeueAfter: min(remaining, idleCheckInterval)}, nil
+	}
+	return ctrl.Result{}, r.reclaim(ctx, job, cfg)
+}
+
+func (r *IdleReclaimer) isReclaimed(ctx context.Context, job IdleReclaimable, cfg *IdleReclamation) (bool, error) {
+	if cfg.Action != IdleActionDeactivate {
+		return job.Replicas() == 0, nil
+	}
+	deactivatable, ok := job.(IdleDeactivatable)
+	if !ok {
+		return true, nil
+	}
+	wl := &kueue.Workload{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: deactivatable.WorkloadName(), Namespace: job.Object().GetNamespace()}, wl); err != nil {
+		return false, err
+	}
+	return !ptr.Deref(wl.Spec.Active, true), nil
+}
+
+func (r *IdleReclaimer) isIdle(ctx context.Context, job IdleReclaimable, cfg *IdleReclamation) (bool, error) {
+	selector, err := job.PodSelector()
+	if err != nil {
+		return false, err
+	}
+	var podMetrics metricsv1beta1.PodMetricsList
+	if err := r.apiReader.List(ctx, &podMetrics, client.InNamespace(job.Object().GetNamespace()), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return false, fmt.Errorf("listing pod metrics: %w", err)
+	}
+	// Without metrics, the serving workload is not considered idle.
+	if len(podMetrics.Items) == 0 {
+		return false, nil
+	}
+	var usage resource.Quantity
+	for _, pm := range podMetrics.Items {
+		for _, c := range pm.Containers {
+			usage.Add(c.Usage[corev1.ResourceCPU])
+		}
+	}
+	return usage.Cmp(cfg.CPUThreshold) < 0, nil
+}
+
+// markActive removes the annotations tracking the idleness of the serving workload.
+func (r *IdleReclaimer) markActive(ctx context.Context, job IdleReclaimable) error {
+	object := job.Object()
+	annotations := object.GetAnnotations()
+	_, idle := annotations[constants.IdleSinceAnnotation]
+	_, reclaimed := annotations[constants.IdleReclaimedReplicasAnnotation]
+	if !idle && !(reclaimed && job.Replicas() > 0) {
+		return nil
+	}
+	return clientutil.Patch(ctx, r.client, object, true, func() (bool, error) {
+		delete(annotations, constants.IdleSinceAnnotation)
+		if job.Replicas() > 0 {
+			delete(annotations, constants.IdleReclaimedReplicasAnnotation)
+		}
+		object.SetAnnotations(annotations)
+		return true, nil
+	})
+}
+
+func (r *IdleReclaimer) reclaim(ctx context.Context, job IdleReclaimable, cfg *IdleReclamation) error {
+	object := job.Object()
+	log := ctrl.LoggerFrom(ctx)
+	msg := fmt.Sprintf("Reclaimed the quota after being idle for %s", cfg.Timeout)
+
+	if deactivatable, ok := job.(IdleDeactivatable); ok && cfg.Action == IdleActionDeactivate {
+		wl := &kueue.Workload{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: deactivatable.WorkloadName(), Namespace: object.GetNamespace()}, wl); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if err := clientutil.Patch(ctx, r.client, wl, true, func() (bool, error) {
+			wl.Spec.Active = ptr.To(false)
+			return true, nil
+		}); err != nil {
+			return err
+		}
+		log.V(2).Info("Deactivated the workload of the idle serving workload", "workload", klog.KObj(wl))
+	} else {
+		replicas := job.Replicas()
+		if err := clientutil.Patch(ctx, r.client, object, true, func() (bool, error) {
+			setAnnotation(object, constants.IdleReclaimedReplicasAnnotation, strconv.Itoa(int(replicas)))
+			job.SetReplicas(0)
+			return true, nil
+		}); err != nil {
+			return err
+		}
+		log.V(2).Info("Scaled down the idle serving workload", "replicas", replicas)
+	}
+
+	if err := r.markActive(ctx, job); err != nil {
+		return err
+	}
+	r.record.Event(object, corev1.EventTypeNormal, ReasonIdleReclaimed, msg)
+	return nil
+}
+
+// restore brings back the serving workload whose quota was reclaimed, when
+// requested with the restore annotation. A workload scaled down is also
+// scaled back up when it's no longer configured for the idle reclamation.
+// Returns whether the serving workload was restored.
+func (r *IdleReclaimer) restore(ctx context.Context, job IdleReclaimable, cfg *IdleReclamation) (bool, error) {
+	object := job.Object()
+	annotations := object.GetAnnotations()
+	_, requested := annotations[constants.IdleRestoreAnnotation]
+	replicasStr, scaledDown := annotations[constants.IdleReclaimedReplicasAnnotation]
+	scaledDown = scaledDown && job.Replicas() == 0
+	if !requested && (!scaledDown || cfg != nil) {
+		return false, nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+
+	if deactivatable, ok := job.(IdleDeactivatable); ok && requested && !scaledDown && cfg != nil && cfg.Action == IdleActionDeactivate {
+		wl := &kueue.Workload{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: deactivatable.WorkloadName(), Namespace: object.GetNamespace()}, wl); err != nil {
+			return false, err
+		}
+		if !ptr.Deref(wl.Spec.Active, true) {
+			if err := clientutil.Patch(ctx, r.client, wl, true, func() (bool, error) {
+				wl.Spec.Active = ptr.To(true)
+				return true, nil
+			}); err != nil {
+				return false, err
+			}
+			log.V(2).Info("Activated the workload of the restored serving workload", "workload", klog.KObj(wl))
+			r.record.Event(object, corev1.EventTypeNormal, ReasonIdleRestored, "Activated the workload reclaimed for being idle")
+		}
+	}
+
+	var replicas int
+	if scaledDown {
+		var err error
+		if replicas, err = strconv.Atoi(replicasStr); err != nil || replicas <= 0 {
+			log.V(2).Info("Ignoring invalid number of reclaimed replicas", "replicas", replicasStr)
+			replicas = 0
+		}
+	}
+	if err := clientutil.Patch(ctx, r.client, object, true, func() (bool, error) {
+		delete(annotations, constants.IdleRestoreAnnotation)
+		delete(annotations, constants.IdleSinceAnnotation)
+		if replicas > 0 {
+			delete(annotations, constants.IdleReclaimedReplicasAnnotation)
+			job.SetReplicas(int32(replicas))
+		}
+		object.SetAnnotations(annotations)
+		return true, nil
+	}); err != nil {
+		return false, err
+	}
+	if replicas > 0 {
+		log.V(2).Info("Scaled up the serving workload reclaimed for being idle", "replicas", replicas)
+		r.record.Eventf(object, corev1.EventTypeNormal, ReasonIdleRestored, "Scaled up to %d replicas after being reclaimed for being idle", replicas)
+	}
+	return true, nil
+}
+
+func setAnnotation(obj client.Object, key, value string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[key] = value
+	obj.SetAnnotations(annotations)
+}
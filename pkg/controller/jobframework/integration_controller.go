@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+)
+
+// notSetUpRequeueInterval is the interval at which the Integrations of the
+// frameworks which are not set up are checked again, as the API of the
+// framework may be installed after Kueue started.
+const notSetUpRequeueInterval = time.Minute
+
+// IntegrationReconciler applies the Integration objects to the scopes of the
+// integrations, and reports whether they are applied in their status.
+// It runs in all the replicas, as they all serve the webhooks, but only the
+// leading replica updates the status.
+type IntegrationReconciler struct {
+	client  client.Client
+	scopes  *IntegrationScopes
+	elected <-chan struct{}
+}
+
+func NewIntegrationReconciler(client client.Client, scopes *IntegrationScopes, elected <-chan struct{}) *IntegrationReconciler {
+	return &IntegrationReconciler{
+		client:  client,
+		scopes:  scopes,
+		elected: elected,
+	}
+}
+
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=integrations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=integrations/status,verbs=get;update;patch
+
+// Reconcile applies the oldest Integration of the framework, whose name is
+// the name of the request, to its scope.
+func (r *IntegrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	framework := req.Name
+	log := ctrl.LoggerFrom(ctx).WithValues("framework", framework)
+
+	var list kueuealpha.IntegrationList
+	if err := r.client.List(ctx, &list); err != nil {
+		return ctrl.Result{}, err
+	}
+	var integrations []*kueuealpha.Integration
+	for i := range list.Items {
+		if list.Items[i].Spec.Framework == framework && list.Items[i].DeletionTimestamp.IsZero() {
+			integrations = append(integrations, &list.Items[i])
+		}
+	}
+	scope := r.scopes.For(framework)
+	if len(integrations) == 0 {
+		log.V(2).Info("No Integration for the framework, managing all its jobs")
+		scope.Reset()
+		return ctrl.Result{}, nil
+	}
+	slices.SortFunc(integrations, func(a, b *kueuealpha.Integration) int {
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	active := integrations[0]
+	condition := metav1.Condition{
+		Type:    kueuealpha.IntegrationActive,
+		Status:  metav1.ConditionTrue,
+		Reason:  kueuealpha.IntegrationReasonActive,
+		Message: "The Integration is applied",
+	}
+	var result ctrl.Result
+	selector, err := namespaceSelectorFor(active)
+	var rules []ManageJobsWithoutQueueNameRule
+	if err == nil {
+		rules, err = manageJobsWithoutQueueNameRulesFor(active)
+	}
+	if err != nil {
+		// Keep the previous scope, so that a mistake doesn't change the
+		// namespaces in which the jobs are managed.
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kueuealpha.IntegrationReasonInvalidNamespaceSelector
+		condition.Message = fmt.Sprintf("Invalid namespaceSelector: %v", err)
+	} else {
+		enabled := ptr.Deref(active.Spec.Enabled, true)
+		log.V(2).Info("Applying the Integration", "integration", active.Name, "enabled", enabled, "namespaceSelector", selector.String(), "manageJobsWithoutQueueNameRules", len(rules))
+		scope.Set(enabled, selector, rules)
+		if !manager.getEnabledIntegrations().Has(framework) {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = kueuealpha.IntegrationReasonFrameworkNotSetUp
+			condition.Message = "The framework is not enabled in the configuration, or its API is not installed"
+			result.RequeueAfter = notSetUpRequeueInterval
+		}
+	}
+
+	select {
+	case <-r.elected:
+	default:
+		return result, nil
+	}
+	if err := r.updateCondition(ctx, active, condition); err != nil {
+		return ctrl.Result{}, err
+	}
+	for _, duplicate := range integrations[1:] {
+		if err := r.updateCondition(ctx, duplicate, metav1.Condition{
+			Type:    kueuealpha.IntegrationActive,
+			Status:  metav1.ConditionFalse,
+			Reason:  kueuealpha.IntegrationReasonDuplicate,
+			Message: fmt.Sprintf("The Integration %s is applied to the framework", active.Name),
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return result, nil
+}
+
+func namespaceSelectorFor(integration *kueuealpha.Integration) (labels.Selector, error) {
+	if integration.Spec.NamespaceSelector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(integration.Spec.NamespaceSelector)
+}
+
+func manageJobsWithoutQueueNameRulesFor(integration *kueuealpha.Integration) ([]ManageJobsWithoutQueueNameRule, error) {
+	if len(integration.Spec.ManageJobsWithoutQueueName) == 0 {
+		return nil, nil
+	}
+	rules := make([]ManageJobsWithoutQueueNameRule, 0, len(integration.Spec.ManageJobsWithoutQueueName))
+	for i, rule := range integration.Spec.ManageJobsWithoutQueueName {
+		selector, err := metav1.LabelSelectorAsSelector(&rule.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("manageJobsWithoutQueueName[%d]: %w", i, err)
+		}
+		rules = append(rules, ManageJobsWithoutQueueNameRule{
+			NamespaceSelector: selector,
+			Manage:            rule.Action == kueuealpha.ManageJobsWithoutQueueNameActionManage,
+		})
+	}
+	return rules, nil
+}
+
+func (r *IntegrationReconciler) updateCondition(ctx context.Context, integration *kueuealpha.Integration, condition metav1.Condition) error {
+	condition.ObservedGeneration = integration.Generation
+	if !apimeta.SetStatusCondition(&integration.Status.Conditions, condition) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.client.Status().Update(ctx, integration))
+}
+
+func (r *IntegrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("integration").
+		Watches(&kueuealpha.Integration{}, handler.EnqueueRequestsFromMapFunc(
+			func(_ context.Context, obj client.Object) []reconcile.Request {
+				integration, isIntegration := obj.(*kueuealpha.Integration)
+				if !isIntegration {
+					return nil
+				}
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: integration.Spec.Framework}}}
+			},
+		)).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(r)
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestIntegrationReconciler(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	teamA := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"kueue": "enabled"}},
+	}
+	teamB := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-b"},
+	}
+	integration := func(name string, created time.Time, spec kueuealpha.IntegrationSpec) *kueuealpha.Integration {
+		return &kueuealpha.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: spec,
+		}
+	}
+	onlyTeamA := kueuealpha.IntegrationSpec{
+		Framework: "batch/job",
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"kueue": "enabled"},
+		},
+	}
+
+	cases := map[string]struct {
+		integrations      []*kueuealpha.Integration
+		enabledFrameworks []string
+		wantManages       map[string]bool
+		// wantRuleManages holds, per namespace, whether the jobs without a queue
+		// name are managed, for the namespaces selected by a rule.
+		wantRuleManages map[string]bool
+		wantConditions  map[string][]metav1.Condition
+		wantResult      ctrl.Result
+	}{
+		"no integration": {
+			enabledFrameworks: []string{"batch/job"},
+			wantManages:       map[string]bool{"team-a": true, "team-b": true},
+		},
+		"integration without namespace selector": {
+			integrations: []*kueuealpha.Integration{
+				integration("job", now, kueuealpha.IntegrationSpec{Framework: "batch/job"}),
+			},
+			enabledFrameworks: []string{"batch/job"},
+			wantManages:       map[string]bool{"team-a": true, "team-b": true},
+			wantConditions: map[string][]metav1.Condition{
+				"job": {{
+					Type:    kueuealpha.IntegrationActive,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueuealpha.IntegrationReasonActive,
+					Message: "The Integration is applied",
+				}},
+			},
+		},
+		"integration with namespace selector": {
+			integrations:      []*kueuealpha.Integration{integration("job", now, onlyTeamA)},
+			enabledFrameworks: []string{"batch/job"},
+			wantManages:       map[string]bool{"team-a": true, "team-b": false},
+			wantConditions: map[string][]metav1.Condition{
+				"job": {{
+					Type:    kueuealpha.IntegrationActive,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueuealpha.IntegrationReasonActive,
+					Message: "The Integration is applied",
+				}},
+			},
+		},
+		"disabled integration": {
+			integrations: []*kueuealpha.Integration{
+				integration("job", now, kueuealpha.IntegrationSpec{Framework: "batch/job", Enabled: ptr.To(false)}),
+			},
+			enabledFrameworks: []string{"batch/job"},
+			wantManages:       map[string]bool{"team-a": false, "team-b": false},
+			wantConditions: map[string][]metav1.Condition{
+				"job": {{
+					Type:    kueuealpha.IntegrationActive,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueuealpha.IntegrationReasonActive,
+					Message: "The Integration is applied",
+				}},
+			},
+		},
+		"framework not set up": {
+			integrations: []*kueuealpha.Integration{integration("job", now, onlyTeamA)},
+			wantManages:  map[string]bool{"team-a": true, "team-b": false},
+			wantConditions: map[string][]metav1.Condition{
+				"job": {{
+					Type:    kueuealpha.IntegrationActive,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueuealpha.IntegrationReasonFrameworkNotSetUp,
+					Message: "The framework is not enabled in the configuration, or its API is not installed",
+				}},
+			},
+			wantResult: ctrl.Result{RequeueAfter: notSetUpRequeueInterval},
+		},
+		"duplicate integrations": {
+			integrations: []*kueuealpha.Integration{
+				integration("newer", now, kueuealpha.IntegrationSpec{Framework: "batch/job", Enabled: ptr.To(false)}),
+				integration("older", now.Add(-time.Minute), onlyTeamA),
+			},
+			enabledFrameworks: []string{"batch/job"},
+			wantManages:       map[string]bool{"team-a": true, "team-b": false},
+			wantConditions: map[string][]metav1.Condition{
+				"older": {{
+					Type:    kueuealpha.IntegrationActive,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueuealpha.IntegrationReasonActive,
+					Message: "The Integration is applied",
+				}},
+				"newer": {{
+					Type:    kueuealpha.IntegrationActive,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueuealpha.IntegrationReasonDuplicate,
+					Message: "The Integration older is applied to the framework",
+				}},
+			},
+		},
+		"integration with manageJobsWithoutQueueName rules": {
+			integrations: []*kueuealpha.Integration{
+				integration("job", now, kueuealpha.IntegrationSpec{
+					Framework: "batch/job",
+					ManageJobsWithoutQueueName: []kueuealpha.ManageJobsWithoutQueueNameRule{
+						{
+							NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"kueue": "enabled"}},
+							Action:            kueuealpha.ManageJobsWithoutQueueNameActionManage,
+						},
+					},
+				}),
+			},
+			enabledFrameworks: []string{"batch/job"},
+			wantManages:       map[string]bool{"team-a": true, "team-b": true},
+			wantRuleManages:   map[string]bool{"team-a": true},
+			wantConditions: map[string][]metav1.Condition{
+				"job": {{
+					Type:    kueuealpha.IntegrationActive,
+					Status:  metav1.ConditionTrue,
+					Reason:  kueuealpha.IntegrationReasonActive,
+					Message: "The Integration is applied",
+				}},
+			},
+		},
+		"integration with invalid manageJobsWithoutQueueName rule": {
+			integrations: []*kueuealpha.Integration{
+				integration("job", now, kueuealpha.IntegrationSpec{
+					Framework: "batch/job",
+					ManageJobsWithoutQueueName: []kueuealpha.ManageJobsWithoutQueueNameRule{
+						{
+							NamespaceSelector: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "kueue", Operator: "Unknown"},
+							}},
+							Action: kueuealpha.ManageJobsWithoutQueueNameActionIgnore,
+						},
+					},
+				}),
+			},
+			enabledFrameworks: []string{"batch/job"},
+			wantManages:       map[string]bool{"team-a": false, "team-b": false},
+			wantConditions: map[string][]metav1.Condition{
+				"job": {{
+					Type:    kueuealpha.IntegrationActive,
+					Status:  metav1.ConditionFalse,
+					Reason:  kueuealpha.IntegrationReasonInvalidNamespaceSelector,
+					Message: `Invalid namespaceSelector: manageJobsWithoutQueueName[0]: "Unknown" is not a valid label selector operator`,
+				}},
+			},
+		},
+		"integration of another framework": {
+			integrations: []*kueuealpha.Integration{
+				integration("jobset", now, kueuealpha.IntegrationSpec{Framework: "jobset.x-k8s.io/jobset", Enabled: ptr.To(false)}),
+			},
+			enabledFrameworks: []string{"batch/job"},
+			wantManages:       map[string]bool{"team-a": true, "team-b": true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Cleanup(EnableIntegrationsForTest(t, tc.enabledFrameworks...))
+			ctx, _ := utiltesting.ContextWithLog(t)
+			builder := utiltesting.NewClientBuilder().
+				WithObjects(teamA, teamB).
+				WithStatusSubresource(&kueuealpha.Integration{})
+			for _, integration := range tc.integrations {
+				builder = builder.WithObjects(integration.DeepCopy())
+			}
+			cl := builder.Build()
+			scopes := NewIntegrationScopes()
+			// A previous scope is reset when the Integration is removed.
+			scopes.For("batch/job").Set(false, nil, nil)
+			elected := make(chan struct{})
+			close(elected)
+			r := NewIntegrationReconciler(cl, scopes, elected)
+
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "batch/job"}})
+			if err != nil {
+				t.Fatalf("Reconcile returned error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantResult, result); diff != "" {
+				t.Errorf("Unexpected result (-want,+got):\n%s", diff)
+			}
+
+			gotManages := make(map[string]bool, len(tc.wantManages))
+			for ns := range tc.wantManages {
+				managed, err := scopes.For("batch/job").ManagesNamespace(ctx, cl, ns)
+				if err != nil {
+					t.Fatalf("Checking the scope in namespace %s: %v", ns, err)
+				}
+				gotManages[ns] = managed
+			}
+			if diff := cmp.Diff(tc.wantManages, gotManages); diff != "" {
+				t.Errorf("Unexpected managed namespaces (-want,+got):\n%s", diff)
+			}
+
+			gotRuleManages := make(map[string]bool)
+			for _, ns := range []string{teamA.Name, teamB.Name} {
+				manage, matched, err := scopes.For("batch/job").ManagesJobsWithoutQueueName(ctx, cl, ns)
+				if err != nil {
+					t.Fatalf("Checking the rules in namespace %s: %v", ns, err)
+				}
+				if matched {
+					gotRuleManages[ns] = manage
+				}
+			}
+			if diff := cmp.Diff(tc.wantRuleManages, gotRuleManages, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected namespaces managed by the rules (-want,+got):\n%s", diff)
+			}
+
+			var list kueuealpha.IntegrationList
+			if err := cl.List(ctx, &list); err != nil {
+				t.Fatalf("Listing Integrations: %v", err)
+			}
+			gotConditions := make(map[string][]metav1.Condition)
+			for _, integration := range list.Items {
+				if len(integration.Status.Conditions) > 0 {
+					gotConditions[integration.Name] = integration.Status.Conditions
+				}
+			}
+			if diff := cmp.Diff(tc.wantConditions, gotConditions, cmpopts.EquateEmpty(),
+				cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "ObservedGeneration")); diff != "" {
+				t.Errorf("Unexpected conditions (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IntegrationScope holds whether Kueue manages the new jobs of an integration,
+// and in which namespaces, as set by the Integration object of the framework.
+// A nil or unset IntegrationScope doesn't restrict the jobs managed by Kueue.
+type IntegrationScope struct {
+	state atomic.Pointer[integrationScopeState]
+}
+
+type integrationScopeState struct {
+	enabled           bool
+	namespaceSelector labels.Selector
+	rules             []ManageJobsWithoutQueueNameRule
+}
+
+// ManageJobsWithoutQueueNameRule decides whether Kueue manages the jobs without
+// a queue name in the namespaces matching the selector.
+type ManageJobsWithoutQueueNameRule struct {
+	NamespaceSelector labels.Selector
+	Manage            bool
+}
+
+// Set restricts the new jobs managed by Kueue to the namespaces matching the
+// selector, or to no job if the integration is not enabled. The rules, evaluated
+// in order, decide whether the jobs without a queue name are managed.
+func (s *IntegrationScope) Set(enabled bool, namespaceSelector labels.Selector, rules []ManageJobsWithoutQueueNameRule) {
+	s.state.Store(&integrationScopeState{
+		enabled:           enabled,
+		namespaceSelector: namespaceSelector,
+		rules:             rules,
+	})
+}
+
+// Reset removes the restrictions of the scope.
+func (s *IntegrationScope) Reset() {
+	s.state.Store(nil)
+}
+
+// ManagesNamespace returns whether Kueue manages the new jobs of the
+// integration created in the namespace.
+func (s *IntegrationScope) ManagesNamespace(ctx context.Context, c client.Reader, namespace string) (bool, error) {
+	if s == nil {
+		return true, nil
+	}
+	state := s.state.Load()
+	if state == nil {
+		return true, nil
+	}
+	if !state.enabled {
+		return false, nil
+	}
+	if state.namespaceSelector.Empty() {
+		return true, nil
+	}
+	ns := corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		return false, fmt.Errorf("failed to get namespace: %w", err)
+	}
+	return state.namespaceSelector.Matches(labels.Set(ns.GetLabels())), nil
+}
+
+// ManagesJobsWithoutQueueName returns whether a rule of the scope selects the
+// namespace and, if so, whether Kueue manages the jobs without a queue name
+// created in the namespace. When no rule matches, the configuration applies.
+func (s *IntegrationScope) ManagesJobsWithoutQueueName(ctx context.Context, c client.Reader, namespace string) (manage bool, matched bool, err error) {
+	if s == nil {
+		return false, false, nil
+	}
+	state := s.state.Load()
+	if state == nil || len(state.rules) == 0 {
+		return false, false, nil
+	}
+	ns := corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		return false, false, fmt.Errorf("failed to get namespace: %w", err)
+	}
+	for _, rule := range state.rules {
+		if rule.NamespaceSelector.Matches(labels.Set(ns.GetLabels())) {
+			return rule.Manage, true, nil
+		}
+	}
+	return false, false, nil
+}
+
+// IntegrationScopes holds the IntegrationScope of each integration, as
+// shared by its reconciler, its webhook, and the Integration reconciler.
+type IntegrationScopes struct {
+	mu     sync.Mutex
+	scopes map[string]*IntegrationScope
+}
+
+func NewIntegrationScopes() *IntegrationScopes {
+	return &IntegrationScopes{
+		scopes: make(map[string]*IntegrationScope),
+	}
+}
+
+// For returns the IntegrationScope of the integration.
+func (s *IntegrationScopes) For(name string) *IntegrationScope {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	scope, found := s.scopes[name]
+	if !found {
+		scope = &IntegrationScope{}
+		s.scopes[name] = scope
+	}
+	return scope
+}
@@ -276,11 +276,15 @@ func GetIntegrationByGVK(gvk schema.GroupVersionKind) (IntegrationCallbacks, boo
 }
 
 func matchingGVK(integration IntegrationCallbacks, gvk schema.GroupVersionKind) bool {
+	return gvk == integrationGVK(integration)
+}
+
+// integrationGVK returns the GroupVersionKind of the jobs of the integration.
+func integrationGVK(integration IntegrationCallbacks) schema.GroupVersionKind {
 	if integration.NewJob != nil {
-		return gvk == integration.NewJob().GVK()
-	} else {
-		return gvk == integration.GVK
+		return integration.NewJob().GVK()
 	}
+	return integration.GVK
 }
 
 // GetIntegrationsList returns the list of currently registered frameworks.
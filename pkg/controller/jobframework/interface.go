@@ -20,6 +20,7 @@ import (
 	"context"
 	"strconv"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -75,6 +76,12 @@ type JobWithReclaimablePods interface {
 	ReclaimablePods() ([]kueue.ReclaimablePod, error)
 }
 
+type JobWithProgress interface {
+	// Progress returns the counts of the completed pods and of all the pods
+	// of the job.
+	Progress() (completed, total int32)
+}
+
 type StopReason string
 
 const (
@@ -144,6 +151,21 @@ type JobWithCustomWorkloadConditions interface {
 	CustomWorkloadConditions(wl *kueue.Workload) ([]metav1.Condition, bool)
 }
 
+// JobWithSchedulingGates interface should be implemented by generic jobs whose
+// pods are kept from running by the admission scheduling gate, instead of, or in
+// addition to, a suspend field, for example the custom resources lacking a suspend
+// field. Suspend is expected to gate the pod templates of the job, with
+// GatePodTemplate, and RunWithPodSetsInfo to ungate them, with UngatePodTemplate.
+// The jobframework ungates the pods of the job created while it was suspended
+// once the job is started, and deletes the running pods of the job when stopping
+// it, for its controller to recreate them gated.
+type JobWithSchedulingGates interface {
+	JobWithPodLabelSelector
+	// PodSetName returns the name of the podSet of the pod of the job,
+	// or an empty string if the pod doesn't belong to any podSet.
+	PodSetName(pod *corev1.Pod) string
+}
+
 func QueueName(job GenericJob) string {
 	return QueueNameForObject(job.Object())
 }
@@ -170,6 +192,27 @@ func MaximumExecutionTimeSeconds(job GenericJob) *int32 {
 	return ptr.To(int32(v))
 }
 
+// workloadAnnotations are the annotations of the jobs copied to their
+// workloads.
+var workloadAnnotations = []string{
+	constants.ExpectedDurationAnnotation,
+	constants.PodSetFlavorsAnnotation,
+	constants.ExcludedFlavorsAnnotation,
+}
+
+// CopyWorkloadAnnotations copies the annotations of the object which are
+// used by Kueue to the workload constructed for it.
+func CopyWorkloadAnnotations(wl *kueue.Workload, object client.Object) {
+	for _, k := range workloadAnnotations {
+		if v, found := object.GetAnnotations()[k]; found {
+			if wl.Annotations == nil {
+				wl.Annotations = make(map[string]string, len(workloadAnnotations))
+			}
+			wl.Annotations[k] = v
+		}
+	}
+}
+
 func workloadPriorityClassName(job GenericJob) string {
 	object := job.Object()
 	if workloadPriorityClassLabel := object.GetLabels()[constants.WorkloadPriorityClassLabel]; workloadPriorityClassLabel != "" {
@@ -178,6 +221,18 @@ func workloadPriorityClassName(job GenericJob) string {
 	return ""
 }
 
+// priorityClassName returns the name of the WorkloadPriorityClass of the job,
+// or else the name of its PriorityClass.
+func priorityClassName(job GenericJob) string {
+	if workloadPriorityClass := workloadPriorityClassName(job); len(workloadPriorityClass) > 0 {
+		return workloadPriorityClass
+	}
+	if jobWithPriorityClass, isImplemented := job.(JobWithPriorityClass); isImplemented {
+		return jobWithPriorityClass.PriorityClass()
+	}
+	return extractPriorityFromPodSets(job.PodSets())
+}
+
 func PrebuiltWorkloadFor(job GenericJob) (string, bool) {
 	name, found := job.Object().GetLabels()[constants.PrebuiltWorkloadLabel]
 	return name, found
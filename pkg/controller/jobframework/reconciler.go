@@ -21,9 +21,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -52,13 +55,16 @@ import (
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/podset"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/tracing"
 	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
+	"sigs.k8s.io/kueue/pkg/util/authorization"
 	clientutil "sigs.k8s.io/kueue/pkg/util/client"
 	"sigs.k8s.io/kueue/pkg/util/equality"
 	"sigs.k8s.io/kueue/pkg/util/kubeversion"
 	"sigs.k8s.io/kueue/pkg/util/maps"
 	utilpriority "sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/util/slices"
+	"sigs.k8s.io/kueue/pkg/util/submitter"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -80,15 +86,21 @@ type JobReconciler struct {
 	record                       record.EventRecorder
 	manageJobsWithoutQueueName   bool
 	managedJobsNamespaceSelector labels.Selector
-	waitForPodsReady             bool
+	waitForPodsReady             *atomic.Bool
 	labelKeysToCopy              []string
 	clock                        clock.Clock
+	integrationScope             *IntegrationScope
+	queues                       *queue.Manager
+	resyncPeriod                 time.Duration
+	deviceReadiness              *configapi.DeviceReadiness
+	externalResumePolicy         configapi.ExternalResumePolicy
 }
 
 type Options struct {
 	ManageJobsWithoutQueueName   bool
 	ManagedJobsNamespaceSelector labels.Selector
 	WaitForPodsReady             bool
+	ReloadableWaitForPodsReady   *atomic.Bool
 	KubeServerVersion            *kubeversion.ServerVersionFetcher
 	IntegrationOptions           map[string]any // IntegrationOptions key is "$GROUP/$VERSION, Kind=$KIND".
 	EnabledFrameworks            sets.Set[string]
@@ -98,6 +110,15 @@ type Options struct {
 	Queues                       *queue.Manager
 	Cache                        *cache.Cache
 	Clock                        clock.Clock
+	APIReader                    client.Reader
+	IntegrationScopes            *IntegrationScopes
+	IntegrationScope             *IntegrationScope
+	IntegrationControllers       map[string]configapi.IntegrationController // IntegrationControllers key is the framework name.
+	ResyncPeriod                 time.Duration
+	LocalQueueAuthorizer         *authorization.LocalQueueAuthorizer
+	SubmitterRecorder            *submitter.Recorder
+	DeviceReadiness              *configapi.DeviceReadiness
+	ExternalResumePolicy         configapi.ExternalResumePolicy
 }
 
 // Option configures the reconciler.
@@ -135,6 +156,16 @@ func WithWaitForPodsReady(w *configapi.WaitForPodsReady) Option {
 	}
 }
 
+// WithReloadableWaitForPodsReady indicates that the controller should add the
+// PodsReady condition to the workloads while enabled is true, so that it can
+// be changed without restarting the controller. It takes precedence over
+// WithWaitForPodsReady.
+func WithReloadableWaitForPodsReady(enabled *atomic.Bool) Option {
+	return func(o *Options) {
+		o.ReloadableWaitForPodsReady = enabled
+	}
+}
+
 func WithKubeServerVersion(v *kubeversion.ServerVersionFetcher) Option {
 	return func(o *Options) {
 		o.KubeServerVersion = v
@@ -200,6 +231,82 @@ func WithCache(c *cache.Cache) Option {
 	}
 }
 
+// WithAPIReader sets the reader used for the objects that are not
+// served by the manager's cache, like the pod metrics.
+func WithAPIReader(r client.Reader) Option {
+	return func(o *Options) {
+		o.APIReader = r
+	}
+}
+
+// WithIntegrationScopes sets the scopes of the integrations, as set by the
+// Integration objects. Each reconciler and webhook receives the scope of
+// its own integration.
+func WithIntegrationScopes(s *IntegrationScopes) Option {
+	return func(o *Options) {
+		o.IntegrationScopes = s
+	}
+}
+
+// WithIntegrationScope sets the scope of the integration of the reconciler
+// or webhook.
+func WithIntegrationScope(s *IntegrationScope) Option {
+	return func(o *Options) {
+		o.IntegrationScope = s
+	}
+}
+
+// WithIntegrationControllers sets the settings of the reconcilers of the
+// integrations.
+func WithIntegrationControllers(controllers []configapi.IntegrationController) Option {
+	return func(o *Options) {
+		o.IntegrationControllers = make(map[string]configapi.IntegrationController, len(controllers))
+		for _, c := range controllers {
+			o.IntegrationControllers[c.Framework] = c
+		}
+	}
+}
+
+// WithResyncPeriod sets the interval after which the reconciler reconciles
+// the jobs again, even if they did not change.
+func WithResyncPeriod(d time.Duration) Option {
+	return func(o *Options) {
+		o.ResyncPeriod = d
+	}
+}
+
+// WithLocalQueueAuthorizer sets the authorizer with which the webhooks verify
+// that the users creating jobs are allowed to submit them to their LocalQueues.
+func WithLocalQueueAuthorizer(a *authorization.LocalQueueAuthorizer) Option {
+	return func(o *Options) {
+		o.LocalQueueAuthorizer = a
+	}
+}
+
+// WithSubmitterRecorder sets the recorder with which the webhooks record the
+// identity of the users creating jobs.
+func WithSubmitterRecorder(r *submitter.Recorder) Option {
+	return func(o *Options) {
+		o.SubmitterRecorder = r
+	}
+}
+
+// WithDeviceReadiness delays the start of the admitted jobs requesting
+// devices until the nodes of their flavors report the devices.
+func WithDeviceReadiness(dr *configapi.DeviceReadiness) Option {
+	return func(o *Options) {
+		o.DeviceReadiness = dr
+	}
+}
+
+// WithExternalResumePolicy sets how the reconciler handles the jobs resumed
+// while their workloads are not admitted.
+func WithExternalResumePolicy(p configapi.ExternalResumePolicy) Option {
+	return func(o *Options) {
+		o.ExternalResumePolicy = p
+	}
+}
+
 // WithClock sets the clock of the reconciler.
 // It default to system's clock and should only
 // be changed in testing.
@@ -219,14 +326,24 @@ func NewReconciler(
 	opts ...Option) *JobReconciler {
 	options := ProcessOptions(opts...)
 
+	waitForPodsReady := options.ReloadableWaitForPodsReady
+	if waitForPodsReady == nil {
+		waitForPodsReady = &atomic.Bool{}
+		waitForPodsReady.Store(options.WaitForPodsReady)
+	}
 	return &JobReconciler{
 		client:                       client,
 		record:                       record,
 		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
 		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
-		waitForPodsReady:             options.WaitForPodsReady,
+		waitForPodsReady:             waitForPodsReady,
 		labelKeysToCopy:              options.LabelKeysToCopy,
 		clock:                        options.Clock,
+		integrationScope:             options.IntegrationScope,
+		queues:                       options.Queues,
+		resyncPeriod:                 options.ResyncPeriod,
+		deviceReadiness:              options.DeviceReadiness,
+		externalResumePolicy:         options.ExternalResumePolicy,
 	}
 }
 
@@ -255,21 +372,10 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 
 	if dropFinalizers {
 		// Remove workload finalizer
-		workloads := &kueue.WorkloadList{}
-
-		if cJob, isComposable := job.(ComposableJob); isComposable {
-			var err error
-			workloads, err = cJob.ListChildWorkloads(ctx, r.client, req.NamespacedName)
-			if err != nil {
-				log.Error(err, "Removing finalizer")
-				return ctrl.Result{}, err
-			}
-		} else {
-			if err := r.client.List(ctx, workloads, client.InNamespace(req.Namespace),
-				client.MatchingFields{GetOwnerKey(job.GVK()): req.Name}); err != nil {
-				log.Error(err, "Unable to list child workloads")
-				return ctrl.Result{}, err
-			}
+		workloads, err := r.listChildWorkloads(ctx, job, req)
+		if err != nil {
+			log.Error(err, "Removing finalizer")
+			return ctrl.Result{}, err
 		}
 		for i := range workloads.Items {
 			err := workload.RemoveFinalizer(ctx, r.client, &workloads.Items[i])
@@ -292,15 +398,37 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if r.resyncPeriod > 0 {
+		defer func() {
+			if err == nil && result.IsZero() {
+				result.RequeueAfter = r.resyncPeriod
+			}
+		}()
+	}
+
 	isStandaloneJob := true
 	objectOwner := metav1.GetControllerOf(object)
 	if objectOwner != nil && IsOwnerManagedByKueue(objectOwner) {
 		isStandaloneJob = false
 	}
 
+	// the rules of the Integration of the framework take precedence over
+	// manageJobsWithoutQueueName and the managed jobs namespace selector.
+	manageJobsWithoutQueueName, ruleMatched := r.manageJobsWithoutQueueName, false
+	if QueueName(job) == "" {
+		manage, matched, err := r.integrationScope.ManagesJobsWithoutQueueName(ctx, r.client, req.Namespace)
+		if err != nil {
+			log.Error(err, "failed to check the rules of the integration")
+			return ctrl.Result{}, err
+		}
+		if matched {
+			manageJobsWithoutQueueName, ruleMatched = manage, true
+		}
+	}
+
 	// when manageJobsWithoutQueueName is disabled we only reconcile jobs that either
 	// have a queue-name label or have a kueue-managed parent that has a queue-name label.
-	if !r.manageJobsWithoutQueueName && QueueName(job) == "" {
+	if !manageJobsWithoutQueueName && QueueName(job) == "" {
 		if isStandaloneJob {
 			log.V(3).Info("queue-name label is not set, ignoring the job", "queueName", QueueName(job))
 			return ctrl.Result{}, nil
@@ -325,6 +453,13 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 				log.Error(err, "couldn't get the parent job workload")
 				return ctrl.Result{}, err
 			} else if parentWorkload == nil || !workload.IsAdmitted(parentWorkload) {
+				if shadow, err := r.parentInShadowMode(ctx, object, parentWorkload); err != nil {
+					log.Error(err, "couldn't check whether the parent job is in shadow mode")
+					return ctrl.Result{}, err
+				} else if shadow {
+					log.V(3).Info("parent job is in shadow mode, not suspending the child job")
+					return ctrl.Result{}, nil
+				}
 				if err := clientutil.Patch(ctx, r.client, object, true, func() (bool, error) {
 					job.Suspend()
 					return true, nil
@@ -338,9 +473,27 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, nil
 	}
 
+	// when the Integration of the framework disables it, or doesn't select the namespace,
+	// the new standalone jobs are not managed. The jobs which already have a workload
+	// are managed until they finish, so that their quota is released.
+	if managed, err := r.integrationScope.ManagesNamespace(ctx, r.client, req.Namespace); err != nil {
+		log.Error(err, "failed to check the scope of the integration")
+		return ctrl.Result{}, err
+	} else if !managed {
+		workloads, err := r.listChildWorkloads(ctx, job, req)
+		if err != nil {
+			log.Error(err, "Unable to list child workloads")
+			return ctrl.Result{}, err
+		}
+		if len(workloads.Items) == 0 {
+			log.V(3).Info("the integration doesn't manage the new jobs in the namespace, ignoring the job", "namespace", req.Namespace)
+			return ctrl.Result{}, nil
+		}
+	}
+
 	// when manageJobsWithoutQueueName is enabled, standalone jobs without queue names
 	// are still not managed if they don't match the namespace selector.
-	if features.Enabled(features.ManagedJobsNamespaceSelector) && r.manageJobsWithoutQueueName && QueueName(job) == "" {
+	if features.Enabled(features.ManagedJobsNamespaceSelector) && manageJobsWithoutQueueName && !ruleMatched && QueueName(job) == "" {
 		ns := corev1.Namespace{}
 		err := r.client.Get(ctx, client.ObjectKey{Name: job.Object().GetNamespace()}, &ns)
 		if err != nil {
@@ -456,9 +609,16 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	// 4.1 update the progress of the workload if reported by the job
+	if jobProgress, implementsProgress := job.(JobWithProgress); implementsProgress && workload.HasQuotaReservation(wl) {
+		if updated, err := r.updateWorkloadProgress(ctx, jobProgress, wl); updated || err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// 5. handle WaitForPodsReady only for a standalone job.
 	// handle a job when waitForPodsReady is enabled, and it is the main job
-	if r.waitForPodsReady {
+	if r.waitsForPodsReady(wl) {
 		log.V(3).Info("Handling a job when waitForPodsReady is enabled")
 		condition := generatePodsReadyCondition(job, wl)
 		// optimization to avoid sending the update request if the status didn't change
@@ -479,10 +639,13 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 			return ctrl.Result{}, err
 		}
 		if workload.HasQuotaReservation(wl) {
-			if !job.IsActive() {
+			// In shadow mode the job is not stopped, so the admission is cleared right away.
+			if !job.IsActive() || r.inShadowMode(job, wl) {
 				log.V(6).Info("The job is no longer active, clear the workloads admission")
-				// The requeued condition status set to true only on EvictedByPreemption
-				setRequeued := evCond.Reason == kueue.WorkloadEvictedByPreemption
+				// The requeued condition status set to true only on EvictedByPreemption, EvictedByNodeInterruption
+				// and EvictedByNodeFailure
+				setRequeued := evCond.Reason == kueue.WorkloadEvictedByPreemption || evCond.Reason == kueue.WorkloadEvictedByNodeInterruption ||
+					evCond.Reason == kueue.WorkloadEvictedByNodeFailure
 				workload.SetRequeuedCondition(wl, evCond.Reason, evCond.Message, setRequeued)
 				_ = workload.UnsetQuotaReservationWithCondition(wl, "Pending", evCond.Message, r.clock.Now())
 				err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true)
@@ -498,6 +661,9 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 	if job.IsSuspended() {
 		// start the job if the workload has been admitted, and the job is still suspended
 		if workload.IsAdmitted(wl) {
+			if recheckAfter, err := r.waitForDevices(ctx, object, wl); recheckAfter > 0 || err != nil {
+				return ctrl.Result{RequeueAfter: recheckAfter}, err
+			}
 			log.V(2).Info("Job admitted, unsuspending")
 			err := r.startJob(ctx, job, object, wl)
 			if err != nil {
@@ -514,6 +680,16 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 			return ctrl.Result{}, err
 		}
 
+		// start the job without admission if its ClusterQueue admitted all the workloads.
+		if workload.AdmittedByBypass(wl) && workload.IsActive(wl) {
+			log.V(2).Info("Job admitted by bypass, unsuspending")
+			err := r.startJobWithoutAdmission(ctx, job, object, wl)
+			if err != nil {
+				log.Error(err, "Unsuspending job")
+			}
+			return ctrl.Result{}, err
+		}
+
 		if workload.HasQuotaReservation(wl) {
 			r.recordAdmissionCheckUpdate(wl, job)
 		}
@@ -528,19 +704,26 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 			}
 			return ctrl.Result{}, err
 		}
+		// move the job to the fallback queue if it exceeded the pending timeout.
+		if rerouted, recheckAfter, err := r.reconcilePendingTimeout(ctx, job, object, wl); rerouted || recheckAfter > 0 || err != nil {
+			return ctrl.Result{RequeueAfter: recheckAfter}, err
+		}
 		log.V(3).Info("Job is suspended and workload not yet admitted by a clusterQueue, nothing to do")
 		return ctrl.Result{}, nil
 	}
 
 	// 8. handle job is unsuspended.
 	if !workload.IsAdmitted(wl) {
-		// the job must be suspended if the workload is not yet admitted.
-		log.V(2).Info("Running job is not admitted by a cluster queue, suspending")
-		err := r.stopJob(ctx, job, wl, StopReasonNotAdmitted, "Not admitted by cluster queue")
+		return ctrl.Result{}, r.handleExternallyResumedJob(ctx, job, object, wl)
+	}
+
+	// ungate the pods of the job created before it was started.
+	if _, implements := job.(JobWithSchedulingGates); implements {
+		info, err := getPodSetsInfoFromStatus(ctx, r.client, wl)
 		if err != nil {
-			log.Error(err, "Suspending job with non admitted workload")
+			return ctrl.Result{}, err
 		}
-		return ctrl.Result{}, err
+		return ctrl.Result{}, r.ungatePods(ctx, job, object, info)
 	}
 
 	// workload is admitted and job is running, nothing to do.
@@ -548,6 +731,45 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 	return ctrl.Result{}, nil
 }
 
+// handleExternallyResumedJob enforces the external resume policy on a job
+// running while its workload is not admitted.
+func (r *JobReconciler) handleExternallyResumedJob(ctx context.Context, job GenericJob, object client.Object, wl *kueue.Workload) error {
+	log := ctrl.LoggerFrom(ctx)
+	if r.inShadowMode(job, wl) {
+		log.V(3).Info("Job running with a workload in shadow mode, nothing to do")
+		return nil
+	}
+	switch r.externalResumePolicy {
+	case configapi.AdoptExternalResumePolicy:
+		log.V(2).Info("Running job is not admitted by a cluster queue, adopting")
+		err := clientutil.Patch(ctx, r.client, wl, true, func() (bool, error) {
+			if wl.Labels == nil {
+				wl.Labels = make(map[string]string, 1)
+			}
+			wl.Labels[controllerconsts.ShadowModeLabel] = "true"
+			return true, nil
+		})
+		if err != nil {
+			log.Error(err, "Adopting job with non admitted workload")
+			return client.IgnoreNotFound(err)
+		}
+		r.record.Eventf(object, corev1.EventTypeWarning, ReasonExternallyResumed, "Resumed without admission, adopted, the quota is charged once the workload %s is admitted", klog.KObj(wl))
+		return nil
+	case configapi.AlertExternalResumePolicy:
+		log.V(2).Info("Running job is not admitted by a cluster queue, alerting")
+		r.record.Eventf(object, corev1.EventTypeWarning, ReasonExternallyResumed, "Resumed without admission, running without charging quota")
+		return nil
+	default:
+		// the job must be suspended if the workload is not yet admitted.
+		log.V(2).Info("Running job is not admitted by a cluster queue, suspending")
+		err := r.stopJob(ctx, job, wl, StopReasonNotAdmitted, "Not admitted by cluster queue")
+		if err != nil {
+			log.Error(err, "Suspending job with non admitted workload")
+		}
+		return err
+	}
+}
+
 func (r *JobReconciler) recordAdmissionCheckUpdate(wl *kueue.Workload, job GenericJob) {
 	message := ""
 	object := job.Object()
@@ -570,6 +792,70 @@ func (r *JobReconciler) recordAdmissionCheckUpdate(wl *kueue.Workload, job Gener
 	}
 }
 
+// reconcilePendingTimeout moves the job to the fallback LocalQueue when its workload
+// has been pending for longer than the pendingTimeout of its LocalQueue.
+// Returns whether the job was moved, or the time after which it should be checked again.
+func (r *JobReconciler) reconcilePendingTimeout(ctx context.Context, job GenericJob, object client.Object, wl *kueue.Workload) (bool, time.Duration, error) {
+	if workload.HasQuotaReservation(wl) || wl.Spec.QueueName == "" {
+		return false, 0, nil
+	}
+	// the queue-name of composable jobs is spread across multiple objects.
+	if _, isComposable := job.(ComposableJob); isComposable {
+		return false, 0, nil
+	}
+	lq := kueue.LocalQueue{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: wl.Namespace, Name: wl.Spec.QueueName}, &lq); err != nil {
+		return false, 0, client.IgnoreNotFound(err)
+	}
+	pendingTimeout := lq.Spec.PendingTimeout
+	if pendingTimeout == nil || pendingTimeout.FallbackQueueName == wl.Spec.QueueName {
+		return false, 0, nil
+	}
+	remainingTime := time.Duration(pendingTimeout.TimeoutSeconds)*time.Second - r.clock.Since(workload.QueuedTime(wl))
+	if remainingTime > 0 {
+		return false, remainingTime, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	sourceQueue := wl.Spec.QueueName
+	targetQueue := pendingTimeout.FallbackQueueName
+	var reroutedFrom []string
+	if value := wl.Annotations[controllerconsts.ReroutedFromAnnotation]; value != "" {
+		reroutedFrom = strings.Split(value, ",")
+	}
+	if sets.New(reroutedFrom...).Has(targetQueue) {
+		log.V(2).Info("The workload was already moved out of the fallback queue, keeping it in its queue", "localQueue", sourceQueue, "fallbackQueue", targetQueue)
+		return false, 0, nil
+	}
+	log.V(2).Info("Workload exceeded the pending timeout, moving the job to the fallback queue", "localQueue", sourceQueue, "fallbackQueue", targetQueue)
+	if err := clientutil.Patch(ctx, r.client, object, true, func() (bool, error) {
+		jobLabels := object.GetLabels()
+		if jobLabels == nil {
+			jobLabels = make(map[string]string, 1)
+		}
+		jobLabels[controllerconsts.QueueLabel] = targetQueue
+		object.SetLabels(jobLabels)
+		return true, nil
+	}); err != nil {
+		return false, 0, err
+	}
+
+	wl.Spec.QueueName = targetQueue
+	if wl.Annotations == nil {
+		wl.Annotations = make(map[string]string, 1)
+	}
+	wl.Annotations[controllerconsts.ReroutedFromAnnotation] = strings.Join(append(reroutedFrom, sourceQueue), ",")
+	if err := r.client.Update(ctx, wl); err != nil {
+		return false, 0, err
+	}
+	message := fmt.Sprintf("Moved from LocalQueue %s to %s after exceeding the pending timeout of %ds", sourceQueue, targetQueue, pendingTimeout.TimeoutSeconds)
+	if err := workload.UpdateStatus(ctx, r.client, wl, kueue.WorkloadQueueRerouted, metav1.ConditionTrue, kueue.WorkloadPendingTimeoutExceeded, message, constants.JobControllerName); err != nil {
+		return false, 0, err
+	}
+	r.record.Event(object, corev1.EventTypeNormal, ReasonRerouted, message)
+	return true, 0, nil
+}
+
 // IsParentJobManaged checks whether the parent job is managed by kueue.
 func (r *JobReconciler) IsParentJobManaged(ctx context.Context, jobObj client.Object, namespace string) (bool, error) {
 	owner := metav1.GetControllerOf(jobObj)
@@ -600,6 +886,54 @@ func (r *JobReconciler) getParentWorkload(ctx context.Context, object client.Obj
 	return &wlList.Items[0], nil
 }
 
+// inShadowMode returns whether Kueue must not stop the job, because its workload
+// was created in shadow mode, or its ClusterQueue is in shadow mode.
+func (r *JobReconciler) inShadowMode(job GenericJob, wl *kueue.Workload) bool {
+	return workload.InShadowMode(wl) || r.queues.LocalQueueInShadowMode(queue.QueueKey(job.Object().GetNamespace(), QueueName(job)))
+}
+
+// waitsForPodsReady returns whether the PodsReady condition of the workload
+// is maintained, as set by the waitForPodsReady of the ClusterQueue admitting
+// the workload, or else by the configuration of Kueue.
+func (r *JobReconciler) waitsForPodsReady(wl *kueue.Workload) bool {
+	if wl != nil && wl.Status.Admission != nil {
+		if enabled := r.queues.ClusterQueueWaitForPodsReady(string(wl.Status.Admission.ClusterQueue)); enabled != nil {
+			return *enabled
+		}
+	}
+	return r.waitForPodsReady.Load()
+}
+
+// parentInShadowMode returns whether the parent job of a child job is in shadow mode,
+// given the workload of the parent job, if any.
+func (r *JobReconciler) parentInShadowMode(ctx context.Context, object client.Object, parentWorkload *kueue.Workload) (bool, error) {
+	if workload.InShadowMode(parentWorkload) {
+		return true, nil
+	}
+	owner := metav1.GetControllerOf(object)
+	parentJob := GetEmptyOwnerObject(owner)
+	if parentJob == nil {
+		return false, fmt.Errorf("workload owner %v: %w", owner, ErrUnknownWorkloadOwner)
+	}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: owner.Name, Namespace: object.GetNamespace()}, parentJob); err != nil {
+		return false, errors.Join(ErrWorkloadOwnerNotFound, err)
+	}
+	return r.queues.LocalQueueInShadowMode(queue.QueueKey(object.GetNamespace(), QueueNameForObject(parentJob))), nil
+}
+
+// listChildWorkloads returns the workloads owned by the job.
+func (r *JobReconciler) listChildWorkloads(ctx context.Context, job GenericJob, req ctrl.Request) (*kueue.WorkloadList, error) {
+	if cJob, isComposable := job.(ComposableJob); isComposable {
+		return cJob.ListChildWorkloads(ctx, r.client, req.NamespacedName)
+	}
+	workloads := &kueue.WorkloadList{}
+	if err := r.client.List(ctx, workloads, client.InNamespace(req.Namespace),
+		client.MatchingFields{GetOwnerKey(job.GVK()): req.Name}); err != nil {
+		return nil, err
+	}
+	return workloads, nil
+}
+
 // ensureOneWorkload will query for the single matched workload corresponding to job and return it.
 // If there are more than one workload, we should delete the excess ones.
 // The returned workload could be nil.
@@ -813,16 +1147,36 @@ func equivalentToWorkload(ctx context.Context, c client.Client, job GenericJob,
 		if equality.ComparePodSetSlices(jobPodSets, runningPodSets, workload.IsAdmitted(wl)) {
 			return true
 		}
-		// If the workload is admitted but the job is suspended, do the check
-		// against the non-running info.
+		// If the workload is admitted but the job is suspended, or runs in shadow mode
+		// without being started by Kueue, do the check against the non-running info.
 		// This might allow some violating jobs to pass equivalency checks, but their
 		// workloads would be invalidated in the next sync after unsuspending.
-		return job.IsSuspended() && equality.ComparePodSetSlices(jobPodSets, wl.Spec.PodSets, workload.IsAdmitted(wl))
+		return (job.IsSuspended() || workload.InShadowMode(wl)) && equality.ComparePodSetSlices(jobPodSets, wl.Spec.PodSets, workload.IsAdmitted(wl))
 	}
 
 	return equality.ComparePodSetSlices(jobPodSets, wl.Spec.PodSets, workload.IsAdmitted(wl))
 }
 
+// updateWorkloadProgress sets the ProgressAnnotation of the workload to the
+// progress of the job, once some of its pods completed.
+func (r *JobReconciler) updateWorkloadProgress(ctx context.Context, job JobWithProgress, wl *kueue.Workload) (bool, error) {
+	completed, total := job.Progress()
+	if completed <= 0 || total <= 0 {
+		return false, nil
+	}
+	value := workload.ProgressValue(completed, total)
+	if wl.Annotations[controllerconsts.ProgressAnnotation] == value {
+		return false, nil
+	}
+	if wl.Annotations == nil {
+		wl.Annotations = make(map[string]string)
+	}
+	wl.Annotations[controllerconsts.ProgressAnnotation] = value
+	log := ctrl.LoggerFrom(ctx)
+	log.V(3).Info("Updating the progress of the workload", "workload", klog.KObj(wl), "progress", value)
+	return true, r.client.Update(ctx, wl)
+}
+
 func (r *JobReconciler) updateWorkloadToMatchJob(ctx context.Context, job GenericJob, object client.Object, wl *kueue.Workload) (*kueue.Workload, error) {
 	newWl, err := r.constructWorkload(ctx, job, object)
 	if err != nil {
@@ -843,7 +1197,14 @@ func (r *JobReconciler) updateWorkloadToMatchJob(ctx context.Context, job Generi
 }
 
 // startJob will unsuspend the job, and also inject the node affinity.
-func (r *JobReconciler) startJob(ctx context.Context, job GenericJob, object client.Object, wl *kueue.Workload) error {
+func (r *JobReconciler) startJob(ctx context.Context, job GenericJob, object client.Object, wl *kueue.Workload) (err error) {
+	spanOpts := []trace.SpanStartOption{trace.WithAttributes(tracing.JobKey.String(klog.KObj(object).String()))}
+	if admittedCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadAdmitted); admittedCond != nil {
+		spanOpts = append(spanOpts, trace.WithTimestamp(admittedCond.LastTransitionTime.Time))
+	}
+	ctx, span := tracing.StartWorkloadSpan(ctx, wl, tracing.SpanJobStart, spanOpts...)
+	defer func() { tracing.EndSpan(span, err) }()
+
 	info, err := getPodSetsInfoFromStatus(ctx, r.client, wl)
 	if err != nil {
 		return err
@@ -863,7 +1224,29 @@ func (r *JobReconciler) startJob(ctx context.Context, job GenericJob, object cli
 		r.record.Event(object, corev1.EventTypeNormal, ReasonStarted, msg)
 	}
 
-	return nil
+	return r.ungatePods(ctx, job, object, info)
+}
+
+// startJobWithoutAdmission starts the job of a workload which is admitted by
+// bypass, with the counts of the pod sets of the workload, as no flavors are
+// assigned to them.
+func (r *JobReconciler) startJobWithoutAdmission(ctx context.Context, job GenericJob, object client.Object, wl *kueue.Workload) error {
+	info := make([]podset.PodSetInfo, len(wl.Spec.PodSets))
+	for i := range wl.Spec.PodSets {
+		info[i] = podset.PodSetInfo{Name: wl.Spec.PodSets[i].Name, Count: wl.Spec.PodSets[i].Count}
+	}
+	msg := "Started without admission, the ClusterQueue admits all the workloads"
+
+	if cj, implements := job.(ComposableJob); implements {
+		return cj.Run(ctx, r.client, info, r.record, msg)
+	}
+	if err := clientutil.Patch(ctx, r.client, object, true, func() (bool, error) {
+		return true, job.RunWithPodSetsInfo(info)
+	}); err != nil {
+		return err
+	}
+	r.record.Event(object, corev1.EventTypeNormal, ReasonStarted, msg)
+	return r.ungatePods(ctx, job, object, info)
 }
 
 // stopJob will suspend the job, and also restore node affinity, reset job status if needed.
@@ -871,6 +1254,11 @@ func (r *JobReconciler) startJob(ctx context.Context, job GenericJob, object cli
 func (r *JobReconciler) stopJob(ctx context.Context, job GenericJob, wl *kueue.Workload, stopReason StopReason, eventMsg string) error {
 	object := job.Object()
 
+	if r.inShadowMode(job, wl) {
+		ctrl.LoggerFrom(ctx).V(2).Info("Not stopping the job in shadow mode", "stopReason", stopReason, "message", eventMsg)
+		return nil
+	}
+
 	info := GetPodSetsInfoFromWorkload(wl)
 
 	if jws, implements := job.(JobWithCustomStop); implements {
@@ -896,7 +1284,7 @@ func (r *JobReconciler) stopJob(ctx context.Context, job GenericJob, wl *kueue.W
 	}
 
 	if job.IsSuspended() {
-		return nil
+		return r.deleteUngatedPods(ctx, job, object)
 	}
 
 	if err := clientutil.Patch(ctx, r.client, object, true, func() (bool, error) {
@@ -910,7 +1298,7 @@ func (r *JobReconciler) stopJob(ctx context.Context, job GenericJob, wl *kueue.W
 	}
 
 	r.record.Event(object, corev1.EventTypeNormal, ReasonStopped, eventMsg)
-	return nil
+	return r.deleteUngatedPods(ctx, job, object)
 }
 
 func (r *JobReconciler) finalizeJob(ctx context.Context, job GenericJob) error {
@@ -951,6 +1339,8 @@ func (r *JobReconciler) constructWorkload(ctx context.Context, job GenericJob, o
 			MaximumExecutionTimeSeconds: MaximumExecutionTimeSeconds(job),
 		},
 	}
+	wl.Annotations = submitter.CopyAnnotations(wl.Annotations, job.Object().GetAnnotations())
+	CopyWorkloadAnnotations(wl, job.Object())
 	if wl.Labels == nil {
 		wl.Labels = make(map[string]string)
 	}
@@ -964,6 +1354,9 @@ func (r *JobReconciler) constructWorkload(ctx context.Context, job GenericJob, o
 			"LabelValue", jobUID,
 		)
 	}
+	if template, found := object.GetLabels()[controllerconsts.WorkloadTemplateLabel]; found {
+		wl.Labels[controllerconsts.WorkloadTemplateLabel] = template
+	}
 
 	if err := ctrl.SetControllerReference(object, wl, r.client.Scheme()); err != nil {
 		return nil, err
@@ -977,6 +1370,15 @@ func (r *JobReconciler) prepareWorkload(ctx context.Context, job GenericJob, wl
 	if err != nil {
 		return err
 	}
+	if source == constants.WorkloadPriorityClassSource {
+		if mapped, found := r.queues.LocalQueueMappedPriorityClass(queue.QueueKey(job.Object().GetNamespace(), QueueName(job)), priorityClassName); found {
+			log := ctrl.LoggerFrom(ctx)
+			log.V(2).Info("Mapping the WorkloadPriorityClass of the workload", "from", priorityClassName, "to", mapped)
+			if priorityClassName, source, p, err = utilpriority.GetPriorityFromWorkloadPriorityClass(ctx, r.client, mapped); err != nil {
+				return err
+			}
+		}
+	}
 
 	wl.Spec.PriorityClassName = priorityClassName
 	wl.Spec.Priority = &p
@@ -1022,10 +1424,13 @@ func getPodSetsInfoFromStatus(ctx context.Context, c client.Client, w *kueue.Wor
 		if err != nil {
 			return nil, err
 		}
-		if features.Enabled(features.TopologyAwareScheduling) {
+		if features.Enabled(features.TopologyAwareScheduling) || features.Enabled(features.InPlacePodResize) || features.Enabled(features.NodeInterruptionRequeue) {
 			info.Labels[kueuealpha.PodSetLabel] = podSetFlavor.Name
 			info.Annotations[kueuealpha.WorkloadAnnotation] = w.Name
 		}
+		if features.Enabled(features.ScaleDownProtection) && isGang(w) {
+			protectFromScaleDown(w, &w.Spec.PodSets[i], &info)
+		}
 		for _, admissionCheck := range w.Status.AdmissionChecks {
 			for _, podSetUpdate := range admissionCheck.PodSetUpdates {
 				if podSetUpdate.Name == info.Name {
@@ -1041,6 +1446,27 @@ func getPodSetsInfoFromStatus(ctx context.Context, c client.Client, w *kueue.Wor
 	return podSetsInfo, nil
 }
 
+// isGang returns whether more than one pod of the workload was admitted.
+func isGang(w *kueue.Workload) bool {
+	var count int32
+	for i, psa := range w.Status.Admission.PodSetAssignments {
+		count += ptr.Deref(psa.Count, w.Spec.PodSets[i].Count)
+	}
+	return count > 1
+}
+
+// protectFromScaleDown sets the annotation preventing the cluster autoscaler
+// from scaling down the nodes of the pods, unless the pod template already
+// sets it, and the label used to remove it once the workload finishes.
+func protectFromScaleDown(w *kueue.Workload, ps *kueue.PodSet, info *podset.PodSetInfo) {
+	if _, found := ps.Template.Annotations[controllerconsts.SafeToEvictAnnotation]; found {
+		return
+	}
+	info.Annotations[controllerconsts.SafeToEvictAnnotation] = "false"
+	info.Annotations[kueuealpha.WorkloadAnnotation] = w.Name
+	info.Labels[controllerconsts.ScaleDownProtectedLabel] = "true"
+}
+
 func (r *JobReconciler) handleJobWithNoWorkload(ctx context.Context, job GenericJob, object client.Object) error {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -1052,8 +1478,9 @@ func (r *JobReconciler) handleJobWithNoWorkload(ctx context.Context, job Generic
 		}
 	}
 
-	// Wait until there are no active pods.
-	if job.IsActive() {
+	// Wait until there are no active pods, unless the job runs in shadow mode.
+	shadow := r.inShadowMode(job, nil)
+	if job.IsActive() && !shadow {
 		log.V(2).Info("Job is suspended but still has active pods, waiting")
 		return nil
 	}
@@ -1071,6 +1498,12 @@ func (r *JobReconciler) handleJobWithNoWorkload(ctx context.Context, job Generic
 	if err != nil {
 		return err
 	}
+	if shadow {
+		if wl.Labels == nil {
+			wl.Labels = make(map[string]string, 1)
+		}
+		wl.Labels[controllerconsts.ShadowModeLabel] = "true"
+	}
 	if err = r.client.Create(ctx, wl); err != nil {
 		return err
 	}
@@ -1145,6 +1578,10 @@ func (r *genericReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 func (r *genericReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	b := ctrl.NewControllerManagedBy(mgr).
 		For(r.newJob().Object()).Owns(&kueue.Workload{})
+	if _, implements := r.newJob().(JobWithSchedulingGates); implements {
+		// reconcile the job when its pods change, to ungate the ones created gated after it was started.
+		b = b.Owns(&corev1.Pod{})
+	}
 	c := mgr.GetClient()
 	for _, f := range r.setup {
 		b = f(b, c)
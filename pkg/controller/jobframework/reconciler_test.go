@@ -29,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/clock"
 	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
@@ -121,6 +122,12 @@ func TestProcessOptions(t *testing.T) {
 					PodSelector: &metav1.LabelSelector{},
 				}),
 				WithLabelKeysToCopy([]string{"toCopyKey"}),
+				WithIntegrationControllers([]configapi.IntegrationController{{
+					Framework:               "batch/job",
+					MaxConcurrentReconciles: ptr.To[int32](10),
+				}}),
+				WithResyncPeriod(time.Minute),
+				WithDeviceReadiness(&configapi.DeviceReadiness{ResourceNames: []corev1.ResourceName{"nvidia.com/gpu"}}),
 				WithClock(t, fakeClock),
 			},
 			wantOpts: Options{
@@ -133,6 +140,14 @@ func TestProcessOptions(t *testing.T) {
 					},
 				},
 				LabelKeysToCopy: []string{"toCopyKey"},
+				IntegrationControllers: map[string]configapi.IntegrationController{
+					"batch/job": {
+						Framework:               "batch/job",
+						MaxConcurrentReconciles: ptr.To[int32](10),
+					},
+				},
+				ResyncPeriod:    time.Minute,
+				DeviceReadiness: &configapi.DeviceReadiness{ResourceNames: []corev1.ResourceName{"nvidia.com/gpu"}},
 				Clock:           fakeClock,
 			},
 		},
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"sync/atomic"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ReloadableSelector is a labels.Selector whose requirements can be replaced
+// after it was passed to the reconcilers and webhooks, so that the changes of
+// the configuration apply without restarting them.
+type ReloadableSelector struct {
+	selector atomic.Pointer[labels.Selector]
+}
+
+var _ labels.Selector = (*ReloadableSelector)(nil)
+
+// NewReloadableSelector returns a ReloadableSelector with the requirements of s.
+func NewReloadableSelector(s labels.Selector) *ReloadableSelector {
+	r := &ReloadableSelector{}
+	r.Set(s)
+	return r
+}
+
+// Set replaces the requirements of the selector.
+func (r *ReloadableSelector) Set(s labels.Selector) {
+	r.selector.Store(&s)
+}
+
+func (r *ReloadableSelector) get() labels.Selector {
+	return *r.selector.Load()
+}
+
+func (r *ReloadableSelector) Matches(l labels.Labels) bool {
+	return r.get().Matches(l)
+}
+
+func (r *ReloadableSelector) Empty() bool {
+	return r.get().Empty()
+}
+
+func (r *ReloadableSelector) String() string {
+	return r.get().String()
+}
+
+// Add returns a new selector, with the current requirements and the given ones,
+// which is not affected by the following calls to Set.
+func (r *ReloadableSelector) Add(reqs ...labels.Requirement) labels.Selector {
+	return r.get().Add(reqs...)
+}
+
+func (r *ReloadableSelector) Requirements() (labels.Requirements, bool) {
+	return r.get().Requirements()
+}
+
+// DeepCopySelector returns a copy of the current requirements, which is not
+// affected by the following calls to Set.
+func (r *ReloadableSelector) DeepCopySelector() labels.Selector {
+	return r.get().DeepCopySelector()
+}
+
+func (r *ReloadableSelector) RequiresExactMatch(label string) (string, bool) {
+	return r.get().RequiresExactMatch(label)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestGetPodSetsInfoFromStatusScaleDownProtection(t *testing.T) {
+	protectedAnnotations := map[string]string{
+		controllerconsts.SafeToEvictAnnotation: "false",
+		kueuealpha.WorkloadAnnotation:          "wl",
+	}
+	protectedLabels := map[string]string{
+		controllerconsts.ScaleDownProtectedLabel: "true",
+	}
+
+	cases := map[string]struct {
+		enableGate      bool
+		podSets         []kueue.PodSet
+		wantAnnotations []map[string]string
+		wantLabels      []map[string]string
+	}{
+		"gang workload": {
+			enableGate: true,
+			podSets: []kueue.PodSet{
+				*utiltesting.MakePodSet("driver", 1).Obj(),
+				*utiltesting.MakePodSet("workers", 4).Obj(),
+			},
+			wantAnnotations: []map[string]string{protectedAnnotations, protectedAnnotations},
+			wantLabels:      []map[string]string{protectedLabels, protectedLabels},
+		},
+		"gang workload with the feature gate disabled": {
+			podSets: []kueue.PodSet{
+				*utiltesting.MakePodSet("workers", 4).Obj(),
+			},
+			wantAnnotations: []map[string]string{{}},
+			wantLabels:      []map[string]string{{}},
+		},
+		"single pod workload": {
+			enableGate: true,
+			podSets: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 1).Obj(),
+			},
+			wantAnnotations: []map[string]string{{}},
+			wantLabels:      []map[string]string{{}},
+		},
+		"pod template setting the safe to evict annotation": {
+			enableGate: true,
+			podSets: []kueue.PodSet{
+				*utiltesting.MakePodSet("workers", 4).
+					Annotations(map[string]string{controllerconsts.SafeToEvictAnnotation: "true"}).
+					Obj(),
+			},
+			wantAnnotations: []map[string]string{{}},
+			wantLabels:      []map[string]string{{}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.ScaleDownProtection, tc.enableGate)
+			wl := utiltesting.MakeWorkload("wl", "ns").PodSets(tc.podSets...).Obj()
+			admission := &kueue.Admission{ClusterQueue: "cq"}
+			for _, ps := range tc.podSets {
+				admission.PodSetAssignments = append(admission.PodSetAssignments, kueue.PodSetAssignment{
+					Name:          ps.Name,
+					ResourceUsage: corev1.ResourceList{},
+					Count:         ptr.To(ps.Count),
+				})
+			}
+			wl.Status.Admission = admission
+
+			cl := utiltesting.NewClientBuilder().Build()
+			infos, err := getPodSetsInfoFromStatus(context.Background(), cl, wl)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			gotAnnotations := make([]map[string]string, len(infos))
+			gotLabels := make([]map[string]string, len(infos))
+			for i := range infos {
+				gotAnnotations[i] = infos[i].Annotations
+				gotLabels[i] = infos[i].Labels
+			}
+			if diff := cmp.Diff(tc.wantAnnotations, gotAnnotations); diff != "" {
+				t.Errorf("Unexpected annotations (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantLabels, gotLabels); diff != "" {
+				t.Errorf("Unexpected labels (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
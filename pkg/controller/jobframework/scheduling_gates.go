@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/podset"
+	clientutil "sigs.k8s.io/kueue/pkg/util/client"
+	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
+)
+
+// GatePodTemplate adds the admission scheduling gate to the pod template.
+// Returns whether the pod template was changed.
+func GatePodTemplate(template *corev1.PodTemplateSpec) bool {
+	if IsPodTemplateGated(template) {
+		return false
+	}
+	template.Spec.SchedulingGates = append(template.Spec.SchedulingGates, corev1.PodSchedulingGate{
+		Name: controllerconsts.AdmissionSchedulingGate,
+	})
+	return true
+}
+
+// UngatePodTemplate removes the admission scheduling gate from the pod template.
+// Returns whether the pod template was changed.
+func UngatePodTemplate(template *corev1.PodTemplateSpec) bool {
+	idx := slices.IndexFunc(template.Spec.SchedulingGates, func(g corev1.PodSchedulingGate) bool {
+		return g.Name == controllerconsts.AdmissionSchedulingGate
+	})
+	if idx < 0 {
+		return false
+	}
+	template.Spec.SchedulingGates = slices.Delete(template.Spec.SchedulingGates, idx, idx+1)
+	return true
+}
+
+// IsPodTemplateGated returns whether the pod template has the admission scheduling gate.
+func IsPodTemplateGated(template *corev1.PodTemplateSpec) bool {
+	return slices.ContainsFunc(template.Spec.SchedulingGates, func(g corev1.PodSchedulingGate) bool {
+		return g.Name == controllerconsts.AdmissionSchedulingGate
+	})
+}
+
+// listJobPods returns the pods of the job, selected by its pod label selector.
+func (r *JobReconciler) listJobPods(ctx context.Context, job JobWithSchedulingGates, object client.Object) ([]corev1.Pod, error) {
+	selector, err := labels.Parse(job.PodLabelSelector())
+	if err != nil {
+		return nil, err
+	}
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods, client.InNamespace(object.GetNamespace()), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// ungatePods removes the admission scheduling gate from the pods of the job
+// created while it was suspended, injecting the podSetsInfo of their podSets.
+func (r *JobReconciler) ungatePods(ctx context.Context, job GenericJob, object client.Object, podSetsInfo []podset.PodSetInfo) error {
+	jsg, implements := job.(JobWithSchedulingGates)
+	if !implements {
+		return nil
+	}
+	pods, err := r.listJobPods(ctx, jsg, object)
+	if err != nil {
+		return err
+	}
+	log := ctrl.LoggerFrom(ctx)
+	for i := range pods {
+		pod := &pods[i]
+		if !utilpod.HasGate(pod, controllerconsts.AdmissionSchedulingGate) || !utilpod.IsActive(pod) {
+			continue
+		}
+		psName := jsg.PodSetName(pod)
+		infoIdx := slices.IndexFunc(podSetsInfo, func(info podset.PodSetInfo) bool { return info.Name == psName })
+		if infoIdx < 0 {
+			log.V(2).Info("Not ungating the pod of an unknown podSet", "pod", klog.KObj(pod), "podSet", psName)
+			continue
+		}
+		if err := clientutil.Patch(ctx, r.client, pod, true, func() (bool, error) {
+			utilpod.Ungate(pod, controllerconsts.AdmissionSchedulingGate)
+			return true, podset.Merge(&pod.ObjectMeta, &pod.Spec, podSetsInfo[infoIdx])
+		}); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.V(3).Info("Ungated the pod of the job", "pod", klog.KObj(pod))
+	}
+	return nil
+}
+
+// deleteUngatedPods deletes the running pods of the stopped job, for its
+// controller to recreate them from the gated pod templates.
+func (r *JobReconciler) deleteUngatedPods(ctx context.Context, job GenericJob, object client.Object) error {
+	jsg, implements := job.(JobWithSchedulingGates)
+	if !implements {
+		return nil
+	}
+	pods, err := r.listJobPods(ctx, jsg, object)
+	if err != nil {
+		return err
+	}
+	log := ctrl.LoggerFrom(ctx)
+	for i := range pods {
+		pod := &pods[i]
+		if utilpod.HasGate(pod, controllerconsts.AdmissionSchedulingGate) || !utilpod.IsActive(pod) {
+			continue
+		}
+		if err := r.client.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.V(3).Info("Deleted the running pod of the stopped job", "pod", klog.KObj(pod))
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/podset"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
+	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+)
+
+// gatedJob is a job lacking a suspend field, kept from running by gating its pods.
+type gatedJob struct {
+	*batchv1.Job
+}
+
+var _ JobWithSchedulingGates = (*gatedJob)(nil)
+
+func (j *gatedJob) Object() client.Object { return j.Job }
+func (j *gatedJob) IsSuspended() bool     { return IsPodTemplateGated(&j.Spec.Template) }
+func (j *gatedJob) Suspend()              { GatePodTemplate(&j.Spec.Template) }
+func (j *gatedJob) RunWithPodSetsInfo(podSetsInfo []podset.PodSetInfo) error {
+	UngatePodTemplate(&j.Spec.Template)
+	return podset.Merge(&j.Spec.Template.ObjectMeta, &j.Spec.Template.Spec, podSetsInfo[0])
+}
+func (j *gatedJob) RestorePodSetsInfo([]podset.PodSetInfo) bool { return false }
+func (j *gatedJob) Finished() (string, bool, bool)              { return "", false, false }
+func (j *gatedJob) PodSets() []kueue.PodSet                     { return nil }
+func (j *gatedJob) IsActive() bool                              { return false }
+func (j *gatedJob) PodsReady() bool                             { return false }
+func (j *gatedJob) GVK() schema.GroupVersionKind                { return batchv1.SchemeGroupVersion.WithKind("Job") }
+func (j *gatedJob) PodLabelSelector() string                    { return "job-name=job" }
+func (j *gatedJob) PodSetName(pod *corev1.Pod) string           { return pod.Labels["role"] }
+
+func TestPodTemplateGates(t *testing.T) {
+	template := &corev1.PodTemplateSpec{}
+	if IsPodTemplateGated(template) {
+		t.Errorf("Unexpected gated pod template")
+	}
+	if !GatePodTemplate(template) || GatePodTemplate(template) {
+		t.Errorf("Unexpected result of gating the pod template")
+	}
+	if !IsPodTemplateGated(template) {
+		t.Errorf("Expected gated pod template")
+	}
+	if !UngatePodTemplate(template) || UngatePodTemplate(template) {
+		t.Errorf("Unexpected result of ungating the pod template")
+	}
+	if len(template.Spec.SchedulingGates) != 0 {
+		t.Errorf("Unexpected scheduling gates: %v", template.Spec.SchedulingGates)
+	}
+}
+
+func TestSchedulingGatedPods(t *testing.T) {
+	jobPod := func(name, role string) *testingpod.PodWrapper {
+		return testingpod.MakePod(name, "ns").Label("job-name", "job").Label("role", role)
+	}
+	podSetsInfo := []podset.PodSetInfo{{
+		Name:         "main",
+		NodeSelector: map[string]string{"instance-type": "spot"},
+	}}
+	pods := []client.Object{
+		jobPod("gated", "main").Gate(controllerconsts.AdmissionSchedulingGate).Obj(),
+		jobPod("unknown-podset", "other").Gate(controllerconsts.AdmissionSchedulingGate).Obj(),
+		jobPod("running", "main").StatusPhase(corev1.PodRunning).Obj(),
+		jobPod("succeeded", "main").StatusPhase(corev1.PodSucceeded).Obj(),
+		testingpod.MakePod("other-job", "ns").Label("job-name", "other").Label("role", "main").Obj(),
+	}
+
+	type podState struct {
+		Gated        bool
+		NodeSelector map[string]string
+	}
+	listPods := func(t *testing.T, cl client.Client) map[string]podState {
+		t.Helper()
+		list := &corev1.PodList{}
+		if err := cl.List(context.Background(), list, client.InNamespace("ns")); err != nil {
+			t.Fatalf("Listing pods: %v", err)
+		}
+		got := make(map[string]podState, len(list.Items))
+		for _, pod := range list.Items {
+			got[pod.Name] = podState{
+				Gated:        len(pod.Spec.SchedulingGates) > 0,
+				NodeSelector: pod.Spec.NodeSelector,
+			}
+		}
+		return got
+	}
+
+	cases := map[string]struct {
+		stop     bool
+		wantPods map[string]podState
+	}{
+		"start": {
+			wantPods: map[string]podState{
+				"gated":          {NodeSelector: map[string]string{"instance-type": "spot"}},
+				"unknown-podset": {Gated: true},
+				"running":        {},
+				"succeeded":      {},
+				"other-job":      {},
+			},
+		},
+		"stop": {
+			stop: true,
+			wantPods: map[string]podState{
+				"gated":          {Gated: true},
+				"unknown-podset": {Gated: true},
+				"succeeded":      {},
+				"other-job":      {},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job := &gatedJob{Job: testingjob.MakeJob("job", "ns").Obj()}
+			objs := make([]client.Object, 0, len(pods))
+			for _, pod := range pods {
+				objs = append(objs, pod.DeepCopyObject().(client.Object))
+			}
+			cl := utiltesting.NewClientBuilder().WithObjects(objs...).Build()
+			r := NewReconciler(cl, record.NewFakeRecorder(10))
+
+			var err error
+			if tc.stop {
+				err = r.deleteUngatedPods(context.Background(), job, job.Object())
+			} else {
+				err = r.ungatePods(context.Background(), job, job.Object(), podSetsInfo)
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantPods, listPods(t, cl)); diff != "" {
+				t.Errorf("Unexpected pods (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
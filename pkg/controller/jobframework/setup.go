@@ -20,16 +20,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/config"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 )
 
 const (
@@ -66,6 +72,11 @@ func (m *integrationManager) setupControllers(ctx context.Context, mgr ctrl.Mana
 			return err
 		}
 	}
+	if options.IntegrationScopes != nil {
+		if err := NewIntegrationReconciler(mgr.GetClient(), options.IntegrationScopes, mgr.Elected()).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create the integration controller: %w", err)
+		}
+	}
 	return m.forEach(func(name string, cb IntegrationCallbacks) error {
 		logger := log.WithValues("jobFrameworkName", name)
 		fwkNamePrefix := fmt.Sprintf("jobFrameworkName %q", name)
@@ -88,12 +99,12 @@ func (m *integrationManager) setupControllers(ctx context.Context, mgr ctrl.Mana
 				logger.Info("No matching API in the server for job framework, skipped setup of controller and webhook")
 				go waitForAPI(ctx, mgr, log, gvk, func() {
 					log.Info("API now available, starting controller and webhook", "gvk", gvk)
-					if err := m.setupControllerAndWebhook(mgr, name, fwkNamePrefix, cb, options, opts...); err != nil {
+					if err := m.setupControllerAndWebhook(mgr, name, fwkNamePrefix, gvk, cb, options, opts...); err != nil {
 						log.Error(err, "Failed to setup controller and webhook for job framework")
 					}
 				})
 			} else {
-				if err := m.setupControllerAndWebhook(mgr, name, fwkNamePrefix, cb, options, opts...); err != nil {
+				if err := m.setupControllerAndWebhook(mgr, name, fwkNamePrefix, gvk, cb, options, opts...); err != nil {
 					return err
 				}
 			}
@@ -105,12 +116,32 @@ func (m *integrationManager) setupControllers(ctx context.Context, mgr ctrl.Mana
 	})
 }
 
-func (m *integrationManager) setupControllerAndWebhook(mgr ctrl.Manager, name string, fwkNamePrefix string, cb IntegrationCallbacks, options Options, opts ...Option) error {
+func (m *integrationManager) setupControllerAndWebhook(mgr ctrl.Manager, name string, fwkNamePrefix string, gvk schema.GroupVersionKind, cb IntegrationCallbacks, options Options, opts ...Option) error {
+	if options.IntegrationScopes != nil {
+		opts = append(slices.Clip(opts), WithIntegrationScope(options.IntegrationScopes.For(name)))
+	}
+	reconcilerMgr := mgr
+	reconcilerClient := mgr.GetClient()
+	if settings, found := options.IntegrationControllers[name]; found {
+		if settings.MaxConcurrentReconciles != nil {
+			reconcilerMgr = withMaxConcurrentReconciles(mgr, gvk, int(*settings.MaxConcurrentReconciles))
+		}
+		if settings.ClientConnection != nil {
+			c, err := newIntegrationClient(mgr, settings.ClientConnection)
+			if err != nil {
+				return fmt.Errorf("%s: unable to create client: %w", fwkNamePrefix, err)
+			}
+			reconcilerClient = c
+		}
+		if settings.ResyncPeriod != nil {
+			opts = append(slices.Clip(opts), WithResyncPeriod(settings.ResyncPeriod.Duration))
+		}
+	}
 	if err := cb.NewReconciler(
-		mgr.GetClient(),
+		reconcilerClient,
 		mgr.GetEventRecorderFor(fmt.Sprintf("%s-%s-controller", name, options.ManagerName)),
 		opts...,
-	).SetupWithManager(mgr); err != nil {
+	).SetupWithManager(reconcilerMgr); err != nil {
 		return fmt.Errorf("%s: %w", fwkNamePrefix, err)
 	}
 	if err := cb.SetupWebhook(mgr, opts...); err != nil {
@@ -120,6 +151,45 @@ func (m *integrationManager) setupControllerAndWebhook(mgr ctrl.Manager, name st
 	return nil
 }
 
+// concurrencyManager overrides the controller options of the manager, so that
+// the reconciler of an integration uses its own number of concurrent reconciles.
+type concurrencyManager struct {
+	ctrl.Manager
+	controllerOptions config.Controller
+}
+
+func (m *concurrencyManager) GetControllerOptions() config.Controller {
+	return m.controllerOptions
+}
+
+func withMaxConcurrentReconciles(mgr ctrl.Manager, gvk schema.GroupVersionKind, maxConcurrentReconciles int) ctrl.Manager {
+	controllerOptions := mgr.GetControllerOptions()
+	controllerOptions.GroupKindConcurrency = maps.Clone(controllerOptions.GroupKindConcurrency)
+	if controllerOptions.GroupKindConcurrency == nil {
+		controllerOptions.GroupKindConcurrency = make(map[string]int, 1)
+	}
+	controllerOptions.GroupKindConcurrency[gvk.GroupKind().String()] = maxConcurrentReconciles
+	return &concurrencyManager{Manager: mgr, controllerOptions: controllerOptions}
+}
+
+// newIntegrationClient returns a client, reading from the cache of the manager,
+// which is rate limited independently of the client of the manager.
+func newIntegrationClient(mgr ctrl.Manager, cc *configapi.ClientConnection) (client.Client, error) {
+	cfg := rest.CopyConfig(mgr.GetConfig())
+	cfg.RateLimiter = nil
+	if cc.QPS != nil {
+		cfg.QPS = *cc.QPS
+	}
+	if cc.Burst != nil {
+		cfg.Burst = int(*cc.Burst)
+	}
+	return client.New(cfg, client.Options{
+		Scheme: mgr.GetScheme(),
+		Mapper: mgr.GetRESTMapper(),
+		Cache:  &client.CacheOptions{Reader: mgr.GetCache()},
+	})
+}
+
 func waitForAPI(ctx context.Context, mgr ctrl.Manager, log logr.Logger, gvk schema.GroupVersionKind, action func()) {
 	rateLimiter := workqueue.NewTypedItemExponentialFailureRateLimiter[string](baseBackoffWaitForIntegration, maxBackoffWaitForIntegration)
 	item := gvk.String()
@@ -36,10 +36,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/config"
 	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
@@ -102,6 +105,25 @@ func TestSetupControllers(t *testing.T) {
 			},
 			wantEnabledIntegrations: []string{"batch/job", "kubeflow.org/mpijob"},
 		},
+		"setup controllers with the settings of the integration controllers": {
+			opts: []Option{
+				WithEnabledFrameworks([]string{"batch/job", "kubeflow.org/mpijob"}),
+				WithIntegrationControllers([]configapi.IntegrationController{{
+					Framework:               "batch/job",
+					MaxConcurrentReconciles: ptr.To[int32](20),
+					ClientConnection: &configapi.ClientConnection{
+						QPS:   ptr.To[float32](50),
+						Burst: ptr.To[int32](100),
+					},
+					ResyncPeriod: &metav1.Duration{Duration: time.Minute},
+				}}),
+			},
+			mapperGVKs: []schema.GroupVersionKind{
+				batchv1.SchemeGroupVersion.WithKind("Job"),
+				kfmpi.SchemeGroupVersionKind,
+			},
+			wantEnabledIntegrations: []string{"batch/job", "kubeflow.org/mpijob"},
+		},
 		"mapper doesn't have kubeflow.org/mpijob, but no error occur": {
 			opts: []Option{
 				WithEnabledFrameworks([]string{"batch/job", "kubeflow.org/mpijob"}),
@@ -303,3 +325,26 @@ func TestSetupIndexes(t *testing.T) {
 		})
 	}
 }
+
+func TestWithMaxConcurrentReconciles(t *testing.T) {
+	mgr, err := ctrlmgr.New(&rest.Config{}, ctrlmgr.Options{
+		Scheme: utiltesting.NewClientBuilder().Build().Scheme(),
+		MapperProvider: func(*rest.Config, *http.Client) (apimeta.RESTMapper, error) {
+			return apimeta.NewDefaultRESTMapper(nil), nil
+		},
+		Controller: config.Controller{
+			GroupKindConcurrency: map[string]int{"Pod": 5, "Job.batch": 5},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to setup manager: %v", err)
+	}
+
+	got := withMaxConcurrentReconciles(mgr, batchv1.SchemeGroupVersion.WithKind("Job"), 20).GetControllerOptions().GroupKindConcurrency
+	if diff := cmp.Diff(map[string]int{"Pod": 5, "Job.batch": 20}, got); diff != "" {
+		t.Errorf("Unexpected group kind concurrency (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]int{"Pod": 5, "Job.batch": 5}, mgr.GetControllerOptions().GroupKindConcurrency); diff != "" {
+		t.Errorf("Unexpected change of the group kind concurrency of the manager (-want,+got):\n%s", diff)
+	}
+}
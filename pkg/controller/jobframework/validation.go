@@ -17,14 +17,17 @@ limitations under the License.
 package jobframework
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	kfmpi "github.com/kubeflow/mpi-operator/pkg/apis/kubeflow/v2beta1"
 	kftraining "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -32,6 +35,9 @@ import (
 	jobset "sigs.k8s.io/jobset/api/jobset/v1alpha2"
 
 	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/authorization"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 var (
@@ -39,6 +45,8 @@ var (
 	labelsPath                    = field.NewPath("metadata", "labels")
 	queueNameLabelPath            = labelsPath.Key(constants.QueueLabel)
 	maxExecTimeLabelPath          = labelsPath.Key(constants.MaxExecTimeSecondsLabel)
+	expectedDurationPath          = annotationsPath.Key(constants.ExpectedDurationAnnotation)
+	excludedFlavorsPath           = annotationsPath.Key(constants.ExcludedFlavorsAnnotation)
 	workloadPriorityClassNamePath = labelsPath.Key(constants.WorkloadPriorityClassLabel)
 	supportedPrebuiltWlJobGVKs    = sets.New(
 		batchv1.SchemeGroupVersion.WithKind("Job").String(),
@@ -54,6 +62,9 @@ var (
 func ValidateJobOnCreate(job GenericJob) field.ErrorList {
 	allErrs := validateCreateForQueueName(job)
 	allErrs = append(allErrs, validateCreateForMaxExecTime(job)...)
+	allErrs = append(allErrs, validateCreateForExpectedDuration(job)...)
+	allErrs = append(allErrs, workload.ValidatePinnedFlavors(job.Object().GetAnnotations(), annotationsPath)...)
+	allErrs = append(allErrs, workload.ValidateExcludedFlavors(job.Object().GetAnnotations(), annotationsPath)...)
 	return allErrs
 }
 
@@ -62,6 +73,134 @@ func ValidateJobOnUpdate(oldJob, newJob GenericJob) field.ErrorList {
 	allErrs := validateUpdateForQueueName(oldJob, newJob)
 	allErrs = append(allErrs, validateUpdateForWorkloadPriorityClassName(oldJob, newJob)...)
 	allErrs = append(allErrs, validateUpdateForMaxExecTime(oldJob, newJob)...)
+	allErrs = append(allErrs, validateUpdateForPinnedFlavors(oldJob, newJob)...)
+	allErrs = append(allErrs, validateUpdateForExcludedFlavors(oldJob, newJob)...)
+	return allErrs
+}
+
+// ValidateLocalQueueAuthorization verifies that the user creating the object
+// is allowed to submit it to its LocalQueue. The objects created by other
+// controllers are not verified, as their users were verified when creating
+// their owners.
+func ValidateLocalQueueAuthorization(ctx context.Context, authorizer *authorization.LocalQueueAuthorizer, obj client.Object) field.ErrorList {
+	if metav1.GetControllerOf(obj) != nil {
+		return nil
+	}
+	return authorizer.Authorize(ctx, obj.GetNamespace(), QueueNameForObject(obj), queueNameLabelPath)
+}
+
+// ValidateAllowedPriorityClass verifies that the ClusterQueue of the LocalQueue
+// of the job allows its priority class. The jobs whose owners are managed by
+// Kueue are not verified, as they don't have their own workloads.
+func ValidateAllowedPriorityClass(queues *queue.Manager, job GenericJob) field.ErrorList {
+	if owner := metav1.GetControllerOf(job.Object()); owner != nil && IsOwnerManagedByKueue(owner) {
+		return nil
+	}
+	return validateAllowedPriorityClass(queues, job.Object(), func() string { return priorityClassName(job) })
+}
+
+// ValidateAllowedPriorityClassOnUpdate verifies that the ClusterQueue allows
+// the priority class of the job when the job changes its LocalQueue or its
+// priority class.
+func ValidateAllowedPriorityClassOnUpdate(queues *queue.Manager, oldJob, newJob GenericJob) field.ErrorList {
+	if owner := metav1.GetControllerOf(newJob.Object()); owner != nil && IsOwnerManagedByKueue(owner) {
+		return nil
+	}
+	return validateAllowedPriorityClass(queues, newJob.Object(), func() string {
+		newPriorityClass := priorityClassName(newJob)
+		// The jobs keep their priority class in their LocalQueue when the
+		// ClusterQueue restricts the priority classes after their creation.
+		if QueueName(oldJob) == QueueName(newJob) && priorityClassName(oldJob) == newPriorityClass {
+			return ""
+		}
+		return newPriorityClass
+	})
+}
+
+// ValidateAllowedPriorityClassForObject verifies that the ClusterQueue of the
+// LocalQueue of an object which isn't a GenericJob, like a Deployment, allows
+// its WorkloadPriorityClass or else the PriorityClass of its pods.
+func ValidateAllowedPriorityClassForObject(queues *queue.Manager, obj client.Object, podPriorityClass string) field.ErrorList {
+	return validateAllowedPriorityClass(queues, obj, func() string {
+		if workloadPriorityClass := obj.GetLabels()[constants.WorkloadPriorityClassLabel]; workloadPriorityClass != "" {
+			return workloadPriorityClass
+		}
+		return podPriorityClass
+	})
+}
+
+// validateAllowedPriorityClass gets the priority class only for the
+// ClusterQueues restricting the priority classes, as getting the priority
+// class of a job can require computing its PodSets. An empty priority class
+// is always allowed.
+func validateAllowedPriorityClass(queues *queue.Manager, obj client.Object, getPriorityClass func() string) field.ErrorList {
+	queueName := QueueNameForObject(obj)
+	if queueName == "" {
+		return nil
+	}
+	allowed := queues.LocalQueueAllowedPriorityClasses(queue.QueueKey(obj.GetNamespace(), queueName))
+	if allowed == nil {
+		return nil
+	}
+	if priorityClass := getPriorityClass(); priorityClass != "" && !allowed.Has(priorityClass) {
+		return field.ErrorList{field.Forbidden(queueNameLabelPath, fmt.Sprintf("the priority class %q is not allowed in the ClusterQueue of the LocalQueue %q", priorityClass, queueName))}
+	}
+	return nil
+}
+
+// ValidateExcludedFlavorsInClusterQueue verifies that the flavors excluded by
+// the job are flavors of the ClusterQueue of its LocalQueue. The jobs whose
+// owners are managed by Kueue are not verified, as they don't have their own
+// workloads.
+func ValidateExcludedFlavorsInClusterQueue(queues *queue.Manager, job GenericJob) field.ErrorList {
+	if owner := metav1.GetControllerOf(job.Object()); owner != nil && IsOwnerManagedByKueue(owner) {
+		return nil
+	}
+	return validateExcludedFlavorsInClusterQueue(queues, job.Object())
+}
+
+// ValidateExcludedFlavorsInClusterQueueOnUpdate verifies that the flavors
+// excluded by the job are flavors of the ClusterQueue when the job changes
+// its LocalQueue or its excluded flavors.
+func ValidateExcludedFlavorsInClusterQueueOnUpdate(queues *queue.Manager, oldJob, newJob GenericJob) field.ErrorList {
+	if owner := metav1.GetControllerOf(newJob.Object()); owner != nil && IsOwnerManagedByKueue(owner) {
+		return nil
+	}
+	// The jobs keep their excluded flavors in their LocalQueue when the
+	// flavors are removed from the ClusterQueue after their creation.
+	if QueueName(oldJob) == QueueName(newJob) &&
+		oldJob.Object().GetAnnotations()[constants.ExcludedFlavorsAnnotation] == newJob.Object().GetAnnotations()[constants.ExcludedFlavorsAnnotation] {
+		return nil
+	}
+	return validateExcludedFlavorsInClusterQueue(queues, newJob.Object())
+}
+
+// validateExcludedFlavorsInClusterQueue skips the ClusterQueues which aren't
+// known yet and the invalid annotations, which are reported by
+// ValidateJobOnCreate.
+func validateExcludedFlavorsInClusterQueue(queues *queue.Manager, obj client.Object) field.ErrorList {
+	value, found := obj.GetAnnotations()[constants.ExcludedFlavorsAnnotation]
+	if !found {
+		return nil
+	}
+	queueName := QueueNameForObject(obj)
+	if queueName == "" {
+		return nil
+	}
+	flavors := queues.LocalQueueFlavors(queue.QueueKey(obj.GetNamespace(), queueName))
+	if flavors == nil {
+		return nil
+	}
+	excluded, err := workload.ParseExcludedFlavors(value)
+	if err != nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	for _, flavor := range sets.List(excluded) {
+		if !flavors.Has(flavor) {
+			allErrs = append(allErrs, field.Invalid(excludedFlavorsPath, value, fmt.Sprintf("flavor %q is not in the ClusterQueue of the LocalQueue %q", flavor, queueName)))
+		}
+	}
 	return allErrs
 }
 
@@ -138,6 +277,41 @@ func validateCreateForMaxExecTime(job GenericJob) field.ErrorList {
 	return nil
 }
 
+func validateCreateForExpectedDuration(job GenericJob) field.ErrorList {
+	if strVal, found := job.Object().GetAnnotations()[constants.ExpectedDurationAnnotation]; found {
+		if d, err := time.ParseDuration(strVal); err != nil || d <= 0 {
+			return field.ErrorList{field.Invalid(expectedDurationPath, strVal, "must be a positive duration")}
+		}
+	}
+	return nil
+}
+
+func validateUpdateForPinnedFlavors(oldJob, newJob GenericJob) field.ErrorList {
+	newValue := newJob.Object().GetAnnotations()[constants.PodSetFlavorsAnnotation]
+	oldValue := oldJob.Object().GetAnnotations()[constants.PodSetFlavorsAnnotation]
+	if newValue == oldValue {
+		return nil
+	}
+	allErrs := workload.ValidatePinnedFlavors(newJob.Object().GetAnnotations(), annotationsPath)
+	if !newJob.IsSuspended() || !oldJob.IsSuspended() {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newValue, oldValue, annotationsPath.Key(constants.PodSetFlavorsAnnotation))...)
+	}
+	return allErrs
+}
+
+func validateUpdateForExcludedFlavors(oldJob, newJob GenericJob) field.ErrorList {
+	newValue := newJob.Object().GetAnnotations()[constants.ExcludedFlavorsAnnotation]
+	oldValue := oldJob.Object().GetAnnotations()[constants.ExcludedFlavorsAnnotation]
+	if newValue == oldValue {
+		return nil
+	}
+	allErrs := workload.ValidateExcludedFlavors(newJob.Object().GetAnnotations(), annotationsPath)
+	if !newJob.IsSuspended() || !oldJob.IsSuspended() {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newValue, oldValue, excludedFlavorsPath)...)
+	}
+	return allErrs
+}
+
 func validateUpdateForMaxExecTime(oldJob, newJob GenericJob) field.ErrorList {
 	if !newJob.IsSuspended() || !oldJob.IsSuspended() {
 		return apivalidation.ValidateImmutableField(newJob.Object().GetLabels()[constants.MaxExecTimeSecondsLabel], oldJob.Object().GetLabels()[constants.MaxExecTimeSecondsLabel], maxExecTimeLabelPath)
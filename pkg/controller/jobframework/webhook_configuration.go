@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+)
+
+// WebhookConfigurationUpdater applies the webhook settings of the integrations,
+// from the configuration, to the mutating and validating webhook configurations
+// of Kueue.
+type WebhookConfigurationUpdater struct {
+	client               client.Client
+	mutatingConfigName   string
+	validatingConfigName string
+	webhooks             []configapi.IntegrationWebhook
+}
+
+func NewWebhookConfigurationUpdater(c client.Client, mutatingConfigName, validatingConfigName string, webhooks []configapi.IntegrationWebhook) *WebhookConfigurationUpdater {
+	return &WebhookConfigurationUpdater{
+		client:               c,
+		mutatingConfigName:   mutatingConfigName,
+		validatingConfigName: validatingConfigName,
+		webhooks:             webhooks,
+	}
+}
+
+// Start updates the webhook configurations once. It implements manager.Runnable,
+// so that only the leading replica updates them.
+func (u *WebhookConfigurationUpdater) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("webhook-configuration-updater")
+	mutatingPaths, validatingPaths, err := u.webhooksByPath()
+	if err != nil {
+		return err
+	}
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var mwc admissionregistrationv1.MutatingWebhookConfiguration
+		if err := u.client.Get(ctx, client.ObjectKey{Name: u.mutatingConfigName}, &mwc); err != nil {
+			return err
+		}
+		updated := false
+		for i := range mwc.Webhooks {
+			wh := &mwc.Webhooks[i]
+			if settings, found := mutatingPaths[webhookServicePath(wh.ClientConfig)]; found {
+				updated = applyWebhookSettings(settings, &wh.FailurePolicy, &wh.TimeoutSeconds, &wh.NamespaceSelector) || updated
+			}
+		}
+		if !updated {
+			return nil
+		}
+		log.V(2).Info("Updating the mutating webhook configuration", "name", mwc.Name)
+		return u.client.Update(ctx, &mwc)
+	}); err != nil {
+		return fmt.Errorf("updating the mutating webhook configuration %s: %w", u.mutatingConfigName, err)
+	}
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var vwc admissionregistrationv1.ValidatingWebhookConfiguration
+		if err := u.client.Get(ctx, client.ObjectKey{Name: u.validatingConfigName}, &vwc); err != nil {
+			return err
+		}
+		updated := false
+		for i := range vwc.Webhooks {
+			wh := &vwc.Webhooks[i]
+			if settings, found := validatingPaths[webhookServicePath(wh.ClientConfig)]; found {
+				updated = applyWebhookSettings(settings, &wh.FailurePolicy, &wh.TimeoutSeconds, &wh.NamespaceSelector) || updated
+			}
+		}
+		if !updated {
+			return nil
+		}
+		log.V(2).Info("Updating the validating webhook configuration", "name", vwc.Name)
+		return u.client.Update(ctx, &vwc)
+	}); err != nil {
+		return fmt.Errorf("updating the validating webhook configuration %s: %w", u.validatingConfigName, err)
+	}
+	return nil
+}
+
+// webhooksByPath returns the settings of the webhooks, indexed by the path at
+// which the mutating and validating webhooks of their integration are served.
+func (u *WebhookConfigurationUpdater) webhooksByPath() (map[string]*configapi.IntegrationWebhook, map[string]*configapi.IntegrationWebhook, error) {
+	mutating := make(map[string]*configapi.IntegrationWebhook, len(u.webhooks))
+	validating := make(map[string]*configapi.IntegrationWebhook, len(u.webhooks))
+	for i := range u.webhooks {
+		cb, found := GetIntegration(u.webhooks[i].Framework)
+		if !found {
+			return nil, nil, fmt.Errorf("%q %w", u.webhooks[i].Framework, errIntegrationNotFound)
+		}
+		gvk := integrationGVK(cb)
+		mutating[webhookPath("mutate", gvk)] = &u.webhooks[i]
+		validating[webhookPath("validate", gvk)] = &u.webhooks[i]
+	}
+	return mutating, validating, nil
+}
+
+// webhookPath returns the path at which controller-runtime serves the webhook
+// of the kind, for example "/mutate-batch-v1-job".
+func webhookPath(prefix string, gvk schema.GroupVersionKind) string {
+	return "/" + prefix + "-" + strings.ReplaceAll(gvk.Group, ".", "-") + "-" + gvk.Version + "-" + strings.ToLower(gvk.Kind)
+}
+
+func webhookServicePath(cc admissionregistrationv1.WebhookClientConfig) string {
+	if cc.Service == nil || cc.Service.Path == nil {
+		return ""
+	}
+	return *cc.Service.Path
+}
+
+func applyWebhookSettings(settings *configapi.IntegrationWebhook, failurePolicy **admissionregistrationv1.FailurePolicyType, timeoutSeconds **int32, namespaceSelector **metav1.LabelSelector) bool {
+	updated := false
+	if settings.FailurePolicy != nil && !apiequality.Semantic.DeepEqual(*failurePolicy, settings.FailurePolicy) {
+		*failurePolicy = ptr.To(*settings.FailurePolicy)
+		updated = true
+	}
+	if settings.TimeoutSeconds != nil && !apiequality.Semantic.DeepEqual(*timeoutSeconds, settings.TimeoutSeconds) {
+		*timeoutSeconds = ptr.To(*settings.TimeoutSeconds)
+		updated = true
+	}
+	if settings.NamespaceSelector != nil && !apiequality.Semantic.DeepEqual(*namespaceSelector, settings.NamespaceSelector) {
+		*namespaceSelector = settings.NamespaceSelector.DeepCopy()
+		updated = true
+	}
+	return updated
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+
+	_ "sigs.k8s.io/kueue/pkg/controller/jobs"
+
+	. "sigs.k8s.io/kueue/pkg/controller/jobframework"
+)
+
+func TestWebhookConfigurationUpdater(t *testing.T) {
+	clientConfig := func(path string) admissionregistrationv1.WebhookClientConfig {
+		return admissionregistrationv1.WebhookClientConfig{
+			Service: &admissionregistrationv1.ServiceReference{Name: "kueue-webhook-service", Path: ptr.To(path)},
+		}
+	}
+	mwc := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "mwc"},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{
+				Name:           "mjob.kb.io",
+				ClientConfig:   clientConfig("/mutate-batch-v1-job"),
+				FailurePolicy:  ptr.To(admissionregistrationv1.Fail),
+				TimeoutSeconds: ptr.To[int32](10),
+			},
+			{
+				Name:          "mworkload.kb.io",
+				ClientConfig:  clientConfig("/mutate-kueue-x-k8s-io-v1beta1-workload"),
+				FailurePolicy: ptr.To(admissionregistrationv1.Fail),
+			},
+		},
+	}
+	vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "vwc"},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{
+				Name:           "vjob.kb.io",
+				ClientConfig:   clientConfig("/validate-batch-v1-job"),
+				FailurePolicy:  ptr.To(admissionregistrationv1.Fail),
+				TimeoutSeconds: ptr.To[int32](10),
+			},
+		},
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+
+	cases := map[string]struct {
+		webhooks       []configapi.IntegrationWebhook
+		wantMutating   []admissionregistrationv1.MutatingWebhook
+		wantValidating []admissionregistrationv1.ValidatingWebhook
+		wantErr        bool
+	}{
+		"failure policy and namespace selector": {
+			webhooks: []configapi.IntegrationWebhook{{
+				Framework:         "batch/job",
+				FailurePolicy:     ptr.To(admissionregistrationv1.Ignore),
+				NamespaceSelector: selector,
+			}},
+			wantMutating: []admissionregistrationv1.MutatingWebhook{
+				{
+					Name:              "mjob.kb.io",
+					ClientConfig:      clientConfig("/mutate-batch-v1-job"),
+					FailurePolicy:     ptr.To(admissionregistrationv1.Ignore),
+					TimeoutSeconds:    ptr.To[int32](10),
+					NamespaceSelector: selector,
+				},
+				mwc.Webhooks[1],
+			},
+			wantValidating: []admissionregistrationv1.ValidatingWebhook{
+				{
+					Name:              "vjob.kb.io",
+					ClientConfig:      clientConfig("/validate-batch-v1-job"),
+					FailurePolicy:     ptr.To(admissionregistrationv1.Ignore),
+					TimeoutSeconds:    ptr.To[int32](10),
+					NamespaceSelector: selector,
+				},
+			},
+		},
+		"timeout": {
+			webhooks: []configapi.IntegrationWebhook{{
+				Framework:      "batch/job",
+				TimeoutSeconds: ptr.To[int32](3),
+			}},
+			wantMutating: []admissionregistrationv1.MutatingWebhook{
+				{
+					Name:           "mjob.kb.io",
+					ClientConfig:   clientConfig("/mutate-batch-v1-job"),
+					FailurePolicy:  ptr.To(admissionregistrationv1.Fail),
+					TimeoutSeconds: ptr.To[int32](3),
+				},
+				mwc.Webhooks[1],
+			},
+			wantValidating: []admissionregistrationv1.ValidatingWebhook{
+				{
+					Name:           "vjob.kb.io",
+					ClientConfig:   clientConfig("/validate-batch-v1-job"),
+					FailurePolicy:  ptr.To(admissionregistrationv1.Fail),
+					TimeoutSeconds: ptr.To[int32](3),
+				},
+			},
+		},
+		"integration without webhook in the configurations": {
+			webhooks: []configapi.IntegrationWebhook{{
+				Framework:     "pod",
+				FailurePolicy: ptr.To(admissionregistrationv1.Ignore),
+			}},
+			wantMutating:   mwc.Webhooks,
+			wantValidating: vwc.Webhooks,
+		},
+		"unknown integration": {
+			webhooks: []configapi.IntegrationWebhook{{
+				Framework:     "unknown",
+				FailurePolicy: ptr.To(admissionregistrationv1.Ignore),
+			}},
+			wantMutating:   mwc.Webhooks,
+			wantValidating: vwc.Webhooks,
+			wantErr:        true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Cleanup(EnableIntegrationsForTest(t, "batch/job", "pod"))
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().WithObjects(mwc.DeepCopy(), vwc.DeepCopy()).Build()
+
+			err := NewWebhookConfigurationUpdater(cl, "mwc", "vwc", tc.webhooks).Start(ctx)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Unexpected error, want error: %v, got: %v", tc.wantErr, err)
+			}
+
+			var gotMWC admissionregistrationv1.MutatingWebhookConfiguration
+			if err := cl.Get(ctx, client.ObjectKey{Name: "mwc"}, &gotMWC); err != nil {
+				t.Fatalf("Getting the mutating webhook configuration: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantMutating, gotMWC.Webhooks); diff != "" {
+				t.Errorf("Unexpected mutating webhooks (-want,+got):\n%s", diff)
+			}
+			var gotVWC admissionregistrationv1.ValidatingWebhookConfiguration
+			if err := cl.Get(ctx, client.ObjectKey{Name: "vwc"}, &gotVWC); err != nil {
+				t.Fatalf("Getting the validating webhook configuration: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantValidating, gotVWC.Webhooks); diff != "" {
+				t.Errorf("Unexpected validating webhooks (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
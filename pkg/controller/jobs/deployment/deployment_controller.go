@@ -20,9 +20,12 @@ import (
 	"context"
 
 	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
@@ -39,7 +42,7 @@ const (
 func init() {
 	utilruntime.Must(jobframework.RegisterIntegration(FrameworkName, jobframework.IntegrationCallbacks{
 		SetupIndexes:   SetupIndexes,
-		NewReconciler:  jobframework.NewNoopReconcilerFactory(gvk),
+		NewReconciler:  NewReconciler,
 		GVK:            gvk,
 		SetupWebhook:   SetupWebhook,
 		JobType:        &appsv1.Deployment{},
@@ -62,6 +65,20 @@ func (d *Deployment) GVK() schema.GroupVersionKind {
 	return gvk
 }
 
+var _ jobframework.IdleReclaimable = (*Deployment)(nil)
+
+func (d *Deployment) PodSelector() (labels.Selector, error) {
+	return metav1.LabelSelectorAsSelector(d.Spec.Selector)
+}
+
+func (d *Deployment) Replicas() int32 {
+	return ptr.Deref(d.Spec.Replicas, 1)
+}
+
+func (d *Deployment) SetReplicas(replicas int32) {
+	d.Spec.Replicas = ptr.To(replicas)
+}
+
 func SetupIndexes(context.Context, client.FieldIndexer) error {
 	return nil
 }
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/features"
+)
+
+// +kubebuilder:rbac:groups="apps",resources=deployments,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="apps",resources=replicasets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="metrics.k8s.io",resources=pods,verbs=get;list
+
+var (
+	_ jobframework.JobReconcilerInterface = (*Reconciler)(nil)
+)
+
+// Reconciler assigns the replicas of the Deployments admitted in chunks to
+// their chunks, and reclaims the quota of the idle Deployments.
+// The pods of the Deployments are managed by the Pod integration.
+type Reconciler struct {
+	client        client.Client
+	idleReclaimer *jobframework.IdleReclaimer
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	d := &appsv1.Deployment{}
+	if err := r.client.Get(ctx, req.NamespacedName, d); err != nil {
+		// we'll ignore not-found errors, since there is nothing to do.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithValues("deployment", klog.KObj(d))
+	ctx = ctrl.LoggerInto(ctx, log)
+	log.V(2).Info("Reconciling Deployment")
+
+	if err := r.assignReplicaChunks(ctx, d); err != nil {
+		return ctrl.Result{}, err
+	}
+	if !features.Enabled(features.IdleServingReclamation) {
+		return ctrl.Result{}, nil
+	}
+	return r.idleReclaimer.Reconcile(ctx, fromObject(d))
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ctrl.Log.V(3).Info("Setting up Deployment reconciler")
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.Deployment{}).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(deploymentForChunkedPod)).
+		Complete(r)
+}
+
+func NewReconciler(client client.Client, record record.EventRecorder, opts ...jobframework.Option) jobframework.JobReconcilerInterface {
+	return &Reconciler{
+		client:        client,
+		idleReclaimer: jobframework.NewIdleReclaimer(client, record, opts...),
+	}
+}
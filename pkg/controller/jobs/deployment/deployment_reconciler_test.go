@@ -0,0 +1,199 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/jobs/pod"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingdeployment "sigs.k8s.io/kueue/pkg/util/testingjobs/deployment"
+	testingjobspod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+)
+
+func TestReconcileReplicaChunks(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	const rsName = "deploy-5d8f7c"
+	replicaSet := appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: rsName, Namespace: "ns"},
+		Spec:       appsv1.ReplicaSetSpec{Replicas: ptr.To[int32](3)},
+	}
+	replica := func(name string, created time.Duration) *testingjobspod.PodWrapper {
+		return testingjobspod.MakePod(name, "ns").
+			Label("app", "deploy-pod").
+			Label(appsv1.DefaultDeploymentUniqueLabelKey, "5d8f7c").
+			Label(pod.ManagedLabelKey, pod.ManagedLabelValue).
+			Annotation(constants.ReplicaAdmissionChunkSizeAnnotation, "2").
+			OwnerReference(rsName, appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).
+			CreationTimestamp(now.Add(created)).
+			KueueSchedulingGate()
+	}
+	inChunk := func(w *testingjobspod.PodWrapper, chunk, totalCount string) *testingjobspod.PodWrapper {
+		return w.Group(chunk).
+			GroupTotalCount(totalCount).
+			Annotation(pod.GroupFastAdmissionAnnotation, "true").
+			PodGroupServingAnnotation(true).
+			KueueFinalizer()
+	}
+	ungated := func(w *testingjobspod.PodWrapper) *testingjobspod.PodWrapper {
+		w.Spec.SchedulingGates = nil
+		return w
+	}
+	chunkedDeployment := testingdeployment.MakeDeployment("deploy", "ns").
+		Queue("lq").
+		Annotation(constants.ReplicaAdmissionChunkSizeAnnotation, "2").
+		Obj()
+
+	cases := map[string]struct {
+		deployment *appsv1.Deployment
+		pods       []corev1.Pod
+		wantPods   []corev1.Pod
+	}{
+		"the first replicas are assigned to a chunk": {
+			deployment: chunkedDeployment,
+			pods: []corev1.Pod{
+				*replica("p3", 2*time.Second).Obj(),
+				*replica("p1", 0).Obj(),
+				*replica("p2", time.Second).Obj(),
+			},
+			wantPods: []corev1.Pod{
+				*inChunk(replica("p1", 0), "p1", "2").Obj(),
+				*inChunk(replica("p2", time.Second), "p1", "2").Obj(),
+				*replica("p3", 2*time.Second).Obj(),
+			},
+		},
+		"the next chunk is started once the previous chunk is admitted": {
+			deployment: chunkedDeployment,
+			pods: []corev1.Pod{
+				*ungated(inChunk(replica("p1", 0), "p1", "2")).Obj(),
+				*ungated(inChunk(replica("p2", time.Second), "p1", "2")).Obj(),
+				*replica("p3", 2*time.Second).Obj(),
+			},
+			wantPods: []corev1.Pod{
+				*ungated(inChunk(replica("p1", 0), "p1", "2")).Obj(),
+				*ungated(inChunk(replica("p2", time.Second), "p1", "2")).Obj(),
+				*inChunk(replica("p3", 2*time.Second), "p3", "1").Obj(),
+			},
+		},
+		"a replacement replica joins the chunk of the replica it replaces": {
+			deployment: chunkedDeployment,
+			pods: []corev1.Pod{
+				*ungated(inChunk(replica("p1", 0), "p1", "2")).Obj(),
+				*inChunk(replica("p3", 2*time.Second), "p3", "1").Obj(),
+				*replica("p4", 3*time.Second).Obj(),
+			},
+			wantPods: []corev1.Pod{
+				*ungated(inChunk(replica("p1", 0), "p1", "2")).Obj(),
+				*inChunk(replica("p3", 2*time.Second), "p3", "1").Obj(),
+				*inChunk(replica("p4", 3*time.Second), "p1", "2").Obj(),
+			},
+		},
+		"the replicas of a deployment without chunks are ignored": {
+			deployment: testingdeployment.MakeDeployment("deploy", "ns").Queue("lq").Obj(),
+			pods: []corev1.Pod{
+				*replica("p1", 0).Obj(),
+			},
+			wantPods: []corev1.Pod{
+				*replica("p1", 0).Obj(),
+			},
+		},
+		"the unmanaged replicas are ignored": {
+			deployment: chunkedDeployment,
+			pods: []corev1.Pod{
+				*replica("p1", 0).Label(pod.ManagedLabelKey, "false").Obj(),
+			},
+			wantPods: []corev1.Pod{
+				*replica("p1", 0).Label(pod.ManagedLabelKey, "false").Obj(),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			objs := []client.Object{tc.deployment.DeepCopy(), replicaSet.DeepCopy()}
+			for _, p := range tc.pods {
+				objs = append(objs, p.DeepCopy())
+			}
+			kClient := utiltesting.NewClientBuilder().WithObjects(objs...).Build()
+			reconciler := NewReconciler(kClient, record.NewFakeRecorder(10))
+
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.deployment)}); err != nil {
+				t.Fatalf("Reconcile returned error: %v", err)
+			}
+
+			gotPods := &corev1.PodList{}
+			if err := kClient.List(ctx, gotPods); err != nil {
+				t.Fatalf("Could not list the pods after reconcile: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantPods, gotPods.Items,
+				cmpopts.EquateEmpty(),
+				cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion"),
+				cmpopts.IgnoreMapEntries(func(k, _ string) bool { return k == pod.RoleHashAnnotation }),
+			); diff != "" {
+				t.Errorf("Pods after reconcile (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeploymentForChunkedPod(t *testing.T) {
+	rsGVK := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	cases := map[string]struct {
+		pod  *corev1.Pod
+		want []reconcile.Request
+	}{
+		"replica admitted in chunks": {
+			pod: testingjobspod.MakePod("p", "ns").
+				Label(appsv1.DefaultDeploymentUniqueLabelKey, "5d8f7c").
+				Annotation(constants.ReplicaAdmissionChunkSizeAnnotation, "2").
+				OwnerReference("deploy-5d8f7c", rsGVK).
+				Obj(),
+			want: []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: "ns", Name: "deploy"}}},
+		},
+		"replica not admitted in chunks": {
+			pod: testingjobspod.MakePod("p", "ns").
+				Label(appsv1.DefaultDeploymentUniqueLabelKey, "5d8f7c").
+				OwnerReference("deploy-5d8f7c", rsGVK).
+				Obj(),
+		},
+		"pod not owned by a replica set": {
+			pod: testingjobspod.MakePod("p", "ns").
+				Annotation(constants.ReplicaAdmissionChunkSizeAnnotation, "2").
+				Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			if diff := cmp.Diff(tc.want, deploymentForChunkedPod(ctx, tc.pod)); diff != "" {
+				t.Errorf("Unexpected requests (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -31,18 +31,24 @@ import (
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework/webhook"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/authorization"
+	"sigs.k8s.io/kueue/pkg/util/submitter"
 )
 
 type Webhook struct {
-	client client.Client
-	queues *queue.Manager
+	client               client.Client
+	queues               *queue.Manager
+	localQueueAuthorizer *authorization.LocalQueueAuthorizer
+	submitterRecorder    *submitter.Recorder
 }
 
 func SetupWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 	options := jobframework.ProcessOptions(opts...)
 	wh := &Webhook{
-		client: mgr.GetClient(),
-		queues: options.Queues,
+		client:               mgr.GetClient(),
+		queues:               options.Queues,
+		localQueueAuthorizer: options.LocalQueueAuthorizer,
+		submitterRecorder:    options.SubmitterRecorder,
 	}
 	obj := &appsv1.Deployment{}
 	return webhook.WebhookManagedBy(mgr).
@@ -63,6 +69,9 @@ func (wh *Webhook) Default(ctx context.Context, obj runtime.Object) error {
 	log.V(5).Info("Propagating queue-name")
 
 	jobframework.ApplyDefaultLocalQueue(deployment.Object(), wh.queues.DefaultLocalQueueExist)
+	if err := wh.submitterRecorder.Record(ctx, deployment.Object()); err != nil {
+		return err
+	}
 
 	// Because Deployment is built using a NoOpReconciler handling of jobs without queue names is delegating to the Pod webhook.
 	queueName := jobframework.QueueNameForObject(deployment.Object())
@@ -71,6 +80,15 @@ func (wh *Webhook) Default(ctx context.Context, obj runtime.Object) error {
 			deployment.Spec.Template.Labels = make(map[string]string, 1)
 		}
 		deployment.Spec.Template.Labels[constants.QueueLabel] = queueName
+		deployment.Spec.Template.Annotations = submitter.CopyAnnotations(deployment.Spec.Template.Annotations, deployment.Annotations)
+		if chunkSize, found := deployment.Annotations[constants.ReplicaAdmissionChunkSizeAnnotation]; found {
+			if deployment.Spec.Template.Annotations == nil {
+				deployment.Spec.Template.Annotations = make(map[string]string, 1)
+			}
+			deployment.Spec.Template.Annotations[constants.ReplicaAdmissionChunkSizeAnnotation] = chunkSize
+		} else {
+			delete(deployment.Spec.Template.Annotations, constants.ReplicaAdmissionChunkSizeAnnotation)
+		}
 	}
 
 	return nil
@@ -87,13 +105,19 @@ func (wh *Webhook) ValidateCreate(ctx context.Context, obj runtime.Object) (warn
 	log.V(5).Info("Validating create")
 
 	allErrs := jobframework.ValidateQueueName(deployment.Object())
+	allErrs = append(allErrs, jobframework.ValidateIdleReclamation(deployment.Object(), jobframework.IdleActionScaleDown)...)
+	allErrs = append(allErrs, validateReplicaAdmissionChunkSize(deployment)...)
+	allErrs = append(allErrs, jobframework.ValidateLocalQueueAuthorization(ctx, wh.localQueueAuthorizer, deployment.Object())...)
+	allErrs = append(allErrs, jobframework.ValidateAllowedPriorityClassForObject(wh.queues, deployment.Object(), deployment.Spec.Template.Spec.PriorityClassName)...)
 
 	return nil, allErrs.ToAggregate()
 }
 
 var (
-	labelsPath         = field.NewPath("metadata", "labels")
-	queueNameLabelPath = labelsPath.Key(constants.QueueLabel)
+	labelsPath                     = field.NewPath("metadata", "labels")
+	queueNameLabelPath             = labelsPath.Key(constants.QueueLabel)
+	annotationsPath                = field.NewPath("metadata", "annotations")
+	replicaChunkSizeAnnotationPath = annotationsPath.Key(constants.ReplicaAdmissionChunkSizeAnnotation)
 )
 
 func (wh *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (warnings admission.Warnings, err error) {
@@ -108,12 +132,17 @@ func (wh *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Ob
 
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, jobframework.ValidateQueueName(newDeployment.Object())...)
+	allErrs = append(allErrs, jobframework.ValidateIdleReclamation(newDeployment.Object(), jobframework.IdleActionScaleDown)...)
+	allErrs = append(allErrs, validateReplicaAdmissionChunkSize(newDeployment)...)
 
 	// Prevents updating the queue-name if at least one Pod is not suspended
 	// or if the queue-name has been deleted.
 	if oldDeployment.Status.ReadyReplicas > 0 || newQueueName == "" {
 		allErrs = append(allErrs, apivalidation.ValidateImmutableField(oldQueueName, newQueueName, queueNameLabelPath)...)
 	}
+	if oldQueueName != newQueueName || oldDeployment.Spec.Template.Spec.PriorityClassName != newDeployment.Spec.Template.Spec.PriorityClassName {
+		allErrs = append(allErrs, jobframework.ValidateAllowedPriorityClassForObject(wh.queues, newDeployment.Object(), newDeployment.Spec.Template.Spec.PriorityClassName)...)
+	}
 
 	return warnings, allErrs.ToAggregate()
 }
@@ -121,3 +150,14 @@ func (wh *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Ob
 func (wh *Webhook) ValidateDelete(context.Context, runtime.Object) (warnings admission.Warnings, err error) {
 	return nil, nil
 }
+
+func validateReplicaAdmissionChunkSize(d *Deployment) field.ErrorList {
+	value, found := d.Annotations[constants.ReplicaAdmissionChunkSizeAnnotation]
+	if !found {
+		return nil
+	}
+	if _, err := replicaAdmissionChunkSize(value); err != nil {
+		return field.ErrorList{field.Invalid(replicaChunkSizeAnnotationPath, value, err.Error())}
+	}
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
@@ -63,6 +64,28 @@ func TestDefault(t *testing.T) {
 				PodTemplateSpecQueue("new-test-queue").
 				Obj(),
 		},
+		"deployment with queue admitted in chunks": {
+			deployment: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				Annotation(constants.ReplicaAdmissionChunkSizeAnnotation, "2").
+				Obj(),
+			want: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				Annotation(constants.ReplicaAdmissionChunkSizeAnnotation, "2").
+				PodTemplateSpecQueue("test-queue").
+				PodTemplateSpecAnnotation(constants.ReplicaAdmissionChunkSizeAnnotation, "2").
+				Obj(),
+		},
+		"deployment with queue no longer admitted in chunks": {
+			deployment: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				PodTemplateSpecAnnotation(constants.ReplicaAdmissionChunkSizeAnnotation, "2").
+				Obj(),
+			want: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				PodTemplateSpecQueue("test-queue").
+				Obj(),
+		},
 		"deployment without queue with pod template spec queue": {
 			deployment: testingdeployment.MakeDeployment("test-pod", "").PodTemplateSpecQueue("test-queue").Obj(),
 			want:       testingdeployment.MakeDeployment("test-pod", "").PodTemplateSpecQueue("test-queue").Obj(),
@@ -118,7 +141,7 @@ func TestDefault(t *testing.T) {
 			if err := w.Default(ctx, tc.deployment); err != nil {
 				t.Errorf("failed to set defaults for v1/deployment: %s", err)
 			}
-			if diff := cmp.Diff(tc.want, tc.deployment); len(diff) != 0 {
+			if diff := cmp.Diff(tc.want, tc.deployment, cmpopts.EquateEmpty()); len(diff) != 0 {
 				t.Errorf("Default() mismatch (-want,+got):\n%s", diff)
 			}
 		})
@@ -150,6 +173,54 @@ func TestValidateCreate(t *testing.T) {
 				},
 			}.ToAggregate(),
 		},
+		"valid idle reclamation": {
+			deployment: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				Annotation(constants.IdleTimeoutAnnotation, "30m").
+				Annotation(constants.IdleActionAnnotation, "ScaleDown").
+				Annotation(constants.IdleCPUThresholdAnnotation, "10m").
+				Obj(),
+		},
+		"invalid idle reclamation": {
+			deployment: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				Annotation(constants.IdleTimeoutAnnotation, "-1m").
+				Annotation(constants.IdleActionAnnotation, "Deactivate").
+				Annotation(constants.IdleCPUThresholdAnnotation, "low").
+				Obj(),
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "metadata.annotations[kueue.x-k8s.io/idle-timeout]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "metadata.annotations[kueue.x-k8s.io/idle-action]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "metadata.annotations[kueue.x-k8s.io/idle-cpu-threshold]",
+				},
+			}.ToAggregate(),
+		},
+		"valid replica admission chunk size": {
+			deployment: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				Annotation(constants.ReplicaAdmissionChunkSizeAnnotation, "4").
+				Obj(),
+		},
+		"invalid replica admission chunk size": {
+			deployment: testingdeployment.MakeDeployment("test-pod", "").
+				Queue("test-queue").
+				Annotation(constants.ReplicaAdmissionChunkSizeAnnotation, "0").
+				Obj(),
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "metadata.annotations[kueue.x-k8s.io/replica-admission-chunk-size]",
+				},
+			}.ToAggregate(),
+		},
 	}
 
 	for name, tc := range testCases {
@@ -0,0 +1,191 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/jobs/pod"
+	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
+)
+
+var errInvalidChunkSize = errors.New("must be a positive integer")
+
+func replicaAdmissionChunkSize(value string) (int, error) {
+	size, err := strconv.Atoi(value)
+	if err != nil || size <= 0 {
+		return 0, errInvalidChunkSize
+	}
+	return size, nil
+}
+
+// replicaChunk is the pod group of a chunk of replicas of a ReplicaSet.
+type replicaChunk struct {
+	name       string
+	totalCount int
+	members    int
+	admitted   bool
+}
+
+// assignReplicaChunks assigns the replicas of the Deployment admitted in chunks
+// to the pod groups of the chunks of their ReplicaSets.
+func (r *Reconciler) assignReplicaChunks(ctx context.Context, d *appsv1.Deployment) error {
+	if _, found := d.Annotations[constants.ReplicaAdmissionChunkSizeAnnotation]; !found {
+		return nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		return err
+	}
+	pods := &corev1.PodList{}
+	if err := r.client.List(ctx, pods, client.InNamespace(d.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return err
+	}
+	podsByReplicaSet := make(map[string][]*corev1.Pod)
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if _, chunked := p.Annotations[constants.ReplicaAdmissionChunkSizeAnnotation]; !chunked || !isActive(p) || p.Labels[pod.ManagedLabelKey] != pod.ManagedLabelValue {
+			continue
+		}
+		if owner := metav1.GetControllerOf(p); owner != nil && owner.Kind == "ReplicaSet" {
+			podsByReplicaSet[owner.Name] = append(podsByReplicaSet[owner.Name], p)
+		}
+	}
+	for _, rsName := range slices.Sorted(maps.Keys(podsByReplicaSet)) {
+		rs := &appsv1.ReplicaSet{}
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: d.Namespace, Name: rsName}, rs); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			continue
+		}
+		if err := r.assignChunks(ctx, podsByReplicaSet[rsName], int(ptr.Deref(rs.Spec.Replicas, 1))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assignChunks assigns the pods of a ReplicaSet, in the order of their
+// creation, to the chunks with fewer members than their total count, or to a
+// new chunk. A new chunk is only started once the previous chunks of the
+// ReplicaSet are admitted, so that the chunks are admitted in order.
+func (r *Reconciler) assignChunks(ctx context.Context, pods []*corev1.Pod, replicas int) error {
+	log := ctrl.LoggerFrom(ctx)
+	chunkSize, err := replicaAdmissionChunkSize(pods[0].Annotations[constants.ReplicaAdmissionChunkSizeAnnotation])
+	if err != nil {
+		log.V(2).Info("Ignoring the pods with an invalid chunk size", "pod", klog.KObj(pods[0]))
+		return nil
+	}
+	slices.SortFunc(pods, func(a, b *corev1.Pod) int {
+		return cmp.Or(
+			a.CreationTimestamp.Compare(b.CreationTimestamp.Time),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+
+	var chunks []*replicaChunk
+	chunksByName := make(map[string]*replicaChunk)
+	var unassigned []*corev1.Pod
+	assignedCount := 0
+	for _, p := range pods {
+		name := p.Labels[pod.GroupNameLabel]
+		if name == "" {
+			unassigned = append(unassigned, p)
+			continue
+		}
+		chunk, found := chunksByName[name]
+		if !found {
+			totalCount, _ := strconv.Atoi(p.Annotations[pod.GroupTotalCountAnnotation])
+			chunk = &replicaChunk{name: name, totalCount: totalCount}
+			chunks = append(chunks, chunk)
+			chunksByName[name] = chunk
+			assignedCount += totalCount
+		}
+		chunk.members++
+		chunk.admitted = chunk.admitted || !utilpod.HasGate(p, constants.AdmissionSchedulingGate)
+	}
+	pending := slices.ContainsFunc(chunks, func(c *replicaChunk) bool { return !c.admitted })
+
+	for i, p := range unassigned {
+		idx := slices.IndexFunc(chunks, func(c *replicaChunk) bool { return c.members < c.totalCount })
+		if idx == -1 {
+			if pending {
+				log.V(3).Info("Waiting for the admission of the previous chunk", "pod", klog.KObj(p))
+				return nil
+			}
+			totalCount := min(chunkSize, replicas-assignedCount)
+			if totalCount <= 0 {
+				totalCount = min(chunkSize, len(unassigned)-i)
+			}
+			chunks = append(chunks, &replicaChunk{name: p.Name, totalCount: totalCount})
+			idx = len(chunks) - 1
+			assignedCount += totalCount
+			pending = true
+		}
+		chunk := chunks[idx]
+		if err := pod.AssignToChunk(p, chunk.name, chunk.totalCount); err != nil {
+			return err
+		}
+		if err := r.client.Update(ctx, p); err != nil {
+			return err
+		}
+		log.V(3).Info("Assigned the pod to a chunk", "pod", klog.KObj(p), "chunk", chunk.name, "totalCount", chunk.totalCount)
+		chunk.members++
+	}
+	return nil
+}
+
+func isActive(p *corev1.Pod) bool {
+	return p.DeletionTimestamp.IsZero() && p.Status.Phase != corev1.PodSucceeded && p.Status.Phase != corev1.PodFailed
+}
+
+// deploymentForChunkedPod maps the replicas admitted in chunks to the
+// reconcile requests of their Deployments, whose names are the names of
+// their ReplicaSets without the pod template hash.
+func deploymentForChunkedPod(_ context.Context, o client.Object) []reconcile.Request {
+	if _, chunked := o.GetAnnotations()[constants.ReplicaAdmissionChunkSizeAnnotation]; !chunked {
+		return nil
+	}
+	owner := metav1.GetControllerOf(o)
+	hash := o.GetLabels()[appsv1.DefaultDeploymentUniqueLabelKey]
+	if owner == nil || owner.Kind != "ReplicaSet" || hash == "" {
+		return nil
+	}
+	name, found := strings.CutSuffix(owner.Name, "-"+hash)
+	if !found {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: o.GetNamespace(), Name: name}}}
+}
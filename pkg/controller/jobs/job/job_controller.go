@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -143,6 +144,7 @@ type Job batchv1.Job
 
 var _ jobframework.GenericJob = (*Job)(nil)
 var _ jobframework.JobWithReclaimablePods = (*Job)(nil)
+var _ jobframework.JobWithProgress = (*Job)(nil)
 var _ jobframework.JobWithCustomStop = (*Job)(nil)
 
 func (j *Job) Object() client.Object {
@@ -230,6 +232,43 @@ func (j *Job) ReclaimablePods() ([]kueue.ReclaimablePod, error) {
 	}}, nil
 }
 
+// Progress returns the number of the completed indexes of an Indexed job, or
+// of the succeeded pods of a NonIndexed job, out of its completions. Jobs
+// without completions don't report their progress.
+func (j *Job) Progress() (int32, int32) {
+	total := ptr.Deref(j.Spec.Completions, 0)
+	if total <= 0 {
+		return 0, 0
+	}
+	if ptr.Deref(j.Spec.CompletionMode, batchv1.NonIndexedCompletion) == batchv1.IndexedCompletion {
+		return countIndexes(j.Status.CompletedIndexes), total
+	}
+	return j.Status.Succeeded, total
+}
+
+// countIndexes returns the number of indexes in a list of indexes in the
+// text format of the job status, like "1,3-5".
+func countIndexes(indexes string) int32 {
+	var count int32
+	for _, interval := range strings.Split(indexes, ",") {
+		if interval == "" {
+			continue
+		}
+		first, last, isRange := strings.Cut(interval, "-")
+		if !isRange {
+			count++
+			continue
+		}
+		firstIdx, errFirst := strconv.ParseInt(first, 10, 32)
+		lastIdx, errLast := strconv.ParseInt(last, 10, 32)
+		if errFirst != nil || errLast != nil || lastIdx < firstIdx {
+			continue
+		}
+		count += int32(lastIdx - firstIdx + 1)
+	}
+	return count
+}
+
 // The following labels are managed internally by batch/job controller, we should not
 // propagate them to the workload.
 var (
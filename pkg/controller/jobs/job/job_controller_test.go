@@ -35,6 +35,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/constants"
@@ -42,6 +43,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/podset"
+	"sigs.k8s.io/kueue/pkg/queue"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	utiltestingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
 )
@@ -170,6 +172,72 @@ func TestPodsReady(t *testing.T) {
 	}
 }
 
+func TestProgress(t *testing.T) {
+	testcases := map[string]struct {
+		job           Job
+		wantCompleted int32
+		wantTotal     int32
+	}{
+		"no completions": {
+			job: Job{
+				Spec: batchv1.JobSpec{
+					Parallelism: ptr.To[int32](3),
+				},
+				Status: batchv1.JobStatus{
+					Succeeded: 1,
+				},
+			},
+		},
+		"non indexed": {
+			job: Job{
+				Spec: batchv1.JobSpec{
+					Parallelism: ptr.To[int32](3),
+					Completions: ptr.To[int32](6),
+				},
+				Status: batchv1.JobStatus{
+					Succeeded: 2,
+				},
+			},
+			wantCompleted: 2,
+			wantTotal:     6,
+		},
+		"indexed": {
+			job: Job{
+				Spec: batchv1.JobSpec{
+					Parallelism:    ptr.To[int32](3),
+					Completions:    ptr.To[int32](10),
+					CompletionMode: ptr.To(batchv1.IndexedCompletion),
+				},
+				Status: batchv1.JobStatus{
+					Succeeded:        6,
+					CompletedIndexes: "1,3-5,8",
+				},
+			},
+			wantCompleted: 5,
+			wantTotal:     10,
+		},
+		"indexed; no completed indexes": {
+			job: Job{
+				Spec: batchv1.JobSpec{
+					Parallelism:    ptr.To[int32](3),
+					Completions:    ptr.To[int32](10),
+					CompletionMode: ptr.To(batchv1.IndexedCompletion),
+				},
+			},
+			wantTotal: 10,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			gotCompleted, gotTotal := tc.job.Progress()
+			if gotCompleted != tc.wantCompleted || gotTotal != tc.wantTotal {
+				t.Errorf("Unexpected progress (want: %d/%d, got: %d/%d)", tc.wantCompleted, tc.wantTotal, gotCompleted, gotTotal)
+			}
+		})
+	}
+}
+
 func TestPodSetsInfo(t *testing.T) {
 	testcases := map[string]struct {
 		job                  *Job
@@ -475,6 +543,9 @@ func TestReconciler(t *testing.T) {
 		},
 	}
 
+	disabledIntegration := &jobframework.IntegrationScope{}
+	disabledIntegration.Set(false, labels.Everything(), nil)
+
 	cases := map[string]struct {
 		enableTopologyAwareScheduling bool
 
@@ -483,6 +554,8 @@ func TestReconciler(t *testing.T) {
 		workloads         []kueue.Workload
 		otherJobs         []batchv1.Job
 		priorityClasses   []client.Object
+		localQueues       []client.Object
+		clusterQueues     []*kueue.ClusterQueue
 		wantJob           batchv1.Job
 		wantWorkloads     []kueue.Workload
 		wantEvents        []utiltesting.EventRecord
@@ -1533,6 +1606,40 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"job out of the scope of the integration is ignored": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithIntegrationScope(disabledIntegration),
+			},
+			job:     *baseJobWrapper.DeepCopy(),
+			wantJob: *baseJobWrapper.DeepCopy(),
+		},
+		"job out of the scope of the integration with admitted workload is unsuspended": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithIntegrationScope(disabledIntegration),
+			},
+			job: *baseJobWrapper.DeepCopy(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(false).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Started",
+					Message:   "Admitted by clusterQueue cq",
+				},
+			},
+		},
 		"suspended job with matching admitted workload is unsuspended": {
 			reconcilerOptions: []jobframework.Option{
 				jobframework.WithManageJobsWithoutQueueName(true),
@@ -1561,6 +1668,36 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"suspended job with workload admitted by bypass is unsuspended": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithManageJobsWithoutQueueName(true),
+				jobframework.WithManagedJobsNamespaceSelector(labels.Everything()),
+			},
+			job: *baseJobWrapper.DeepCopy(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(false).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Labels(map[string]string{controllerconsts.ShadowModeLabel: "true"}).
+					Annotations(map[string]string{controllerconsts.AdmitAllAnnotation: "true"}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Labels(map[string]string{controllerconsts.ShadowModeLabel: "true"}).
+					Annotations(map[string]string{controllerconsts.AdmitAllAnnotation: "true"}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Started",
+					Message:   "Started without admission, the ClusterQueue admits all the workloads",
+				},
+			},
+		},
 		"non-matching admitted workload is deleted": {
 			reconcilerOptions: []jobframework.Option{
 				jobframework.WithManageJobsWithoutQueueName(true),
@@ -2251,6 +2388,58 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"the workload is created with the WorkloadPriorityClass mapped by the ClusterQueue": {
+			job: *baseJobWrapper.
+				Clone().
+				Suspend(false).
+				Queue("test-queue").
+				UID("test-uid").
+				WorkloadPriorityClass("test-wpc").
+				Obj(),
+			priorityClasses: []client.Object{
+				baseWPCWrapper.Obj(),
+				utiltesting.MakeWorkloadPriorityClass("normal-wpc").PriorityValue(10).Obj(),
+			},
+			localQueues: []client.Object{
+				utiltesting.MakeLocalQueue("test-queue", "ns").ClusterQueue("cq").Obj(),
+			},
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq").PriorityClassMapping("test-wpc", "normal-wpc").Obj(),
+			},
+			wantJob: *baseJobWrapper.
+				Clone().
+				Queue("test-queue").
+				UID("test-uid").
+				WorkloadPriorityClass("test-wpc").
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("test-queue").
+					PriorityClass("normal-wpc").
+					Priority(10).
+					PriorityClassSource(constants.WorkloadPriorityClassSource).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "test-uid",
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Stopped",
+					Message:   "Missing Workload; unable to restore pod templates",
+				},
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created Workload: ns/" + GetWorkloadNameForJob(baseJobWrapper.Name, types.UID("test-uid")),
+				},
+			},
+		},
 		"the workload is created when queue name is set, with PriorityClass": {
 			job: *baseJobWrapper.
 				Clone().
@@ -2420,6 +2609,105 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"when the running job is not admitted it is adopted with the Adopt external resume policy": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithExternalResumePolicy(configapi.AdoptExternalResumePolicy),
+			},
+			job: *baseJobWrapper.
+				Clone().
+				Suspend(false).
+				Label(controllerconsts.PrebuiltWorkloadLabel, "prebuilt-workload").
+				UID("test-uid").
+				Obj(),
+			wantJob: *baseJobWrapper.
+				Clone().
+				Suspend(false).
+				Label(controllerconsts.PrebuiltWorkloadLabel, "prebuilt-workload").
+				UID("test-uid").
+				Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("prebuilt-workload", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").PriorityClass("test-pc").Obj()).
+					Queue("test-queue").
+					PriorityClass("test-wpc").
+					Priority(100).
+					PriorityClassSource(constants.WorkloadPriorityClassSource).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("prebuilt-workload", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").PriorityClass("test-pc").Obj()).
+					Queue("test-queue").
+					PriorityClass("test-wpc").
+					Priority(100).
+					PriorityClassSource(constants.WorkloadPriorityClassSource).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel:     "test-uid",
+						controllerconsts.ShadowModeLabel: "true",
+					}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job", "test-uid").
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Warning",
+					Reason:    "ExternallyResumed",
+					Message:   "Resumed without admission, adopted, the quota is charged once the workload ns/prebuilt-workload is admitted",
+				},
+			},
+		},
+		"when the running job is not admitted it keeps running with the Alert external resume policy": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithExternalResumePolicy(configapi.AlertExternalResumePolicy),
+			},
+			job: *baseJobWrapper.
+				Clone().
+				Suspend(false).
+				Label(controllerconsts.PrebuiltWorkloadLabel, "prebuilt-workload").
+				UID("test-uid").
+				Obj(),
+			wantJob: *baseJobWrapper.
+				Clone().
+				Suspend(false).
+				Label(controllerconsts.PrebuiltWorkloadLabel, "prebuilt-workload").
+				UID("test-uid").
+				Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("prebuilt-workload", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").PriorityClass("test-pc").Obj()).
+					Queue("test-queue").
+					PriorityClass("test-wpc").
+					Priority(100).
+					PriorityClassSource(constants.WorkloadPriorityClassSource).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("prebuilt-workload", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").PriorityClass("test-pc").Obj()).
+					Queue("test-queue").
+					PriorityClass("test-wpc").
+					Priority(100).
+					PriorityClassSource(constants.WorkloadPriorityClassSource).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "test-uid",
+					}).
+					ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job", "test-uid").
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Warning",
+					Reason:    "ExternallyResumed",
+					Message:   "Resumed without admission, running without charging quota",
+				},
+			},
+		},
 		"when the prebuilt workload is owned by another object": {
 			job: *baseJobWrapper.
 				Clone().
@@ -2840,6 +3128,58 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"the expected duration is passed to the created workload": {
+			job: *baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.ExpectedDurationAnnotation, "2h").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.ExpectedDurationAnnotation, "2h").
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Annotations(map[string]string{controllerconsts.ExpectedDurationAnnotation: "2h"}).
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("foo").
+					Priority(0).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: string(baseJobWrapper.GetUID())}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created Workload: ns/" + GetWorkloadNameForJob(baseJobWrapper.Name, baseJobWrapper.GetUID()),
+				},
+			},
+		},
+		"the pinned flavors are passed to the created workload": {
+			job: *baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.PodSetFlavorsAnnotation, "main=spot").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.PodSetFlavorsAnnotation, "main=spot").
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Annotations(map[string]string{controllerconsts.PodSetFlavorsAnnotation: "main=spot"}).
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("foo").
+					Priority(0).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: string(baseJobWrapper.GetUID())}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "CreatedWorkload",
+					Message:   "Created Workload: ns/" + GetWorkloadNameForJob(baseJobWrapper.Name, baseJobWrapper.GetUID()),
+				},
+			},
+		},
 		"the maximum execution time is updated in the workload": {
 			job: *baseJobWrapper.Clone().
 				Label(controllerconsts.MaxExecTimeSecondsLabel, "10").
@@ -2876,6 +3216,176 @@ func TestReconciler(t *testing.T) {
 				},
 			},
 		},
+		"the progress of the running job is set in the workload": {
+			job: *baseJobWrapper.Clone().
+				Suspend(false).
+				Completions(20).
+				Succeeded(5).
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(false).
+				Completions(20).
+				Succeeded(5).
+				Obj(),
+			workloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Annotations(map[string]string{controllerconsts.ProgressAnnotation: "4/20"}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*baseWorkloadWrapper.Clone().
+					Admitted(true).
+					Annotations(map[string]string{controllerconsts.ProgressAnnotation: "5/20"}).
+					Obj(),
+			},
+		},
+		"the job is moved to the fallback queue when the pending timeout is exceeded": {
+			localQueues: []client.Object{
+				utiltesting.MakeLocalQueue("foo", "ns").ClusterQueue("cq").PendingTimeout(60, "fallback").Obj(),
+			},
+			job: *baseJobWrapper.Clone().Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Queue("fallback").
+				Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("foo").
+					Priority(0).
+					Creation(testStartTime.Add(-2 * time.Minute)).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: string(baseJobWrapper.GetUID())}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("fallback").
+					Priority(0).
+					Creation(testStartTime.Add(-2 * time.Minute)).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: string(baseJobWrapper.GetUID())}).
+					Annotations(map[string]string{controllerconsts.ReroutedFromAnnotation: "foo"}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadQueueRerouted,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadPendingTimeoutExceeded,
+						Message: "Moved from LocalQueue foo to fallback after exceeding the pending timeout of 60s",
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    jobframework.ReasonRerouted,
+					Message:   "Moved from LocalQueue foo to fallback after exceeding the pending timeout of 60s",
+				},
+			},
+		},
+		"the job is moved along a cycle of fallback queues up to a queue it was moved out of": {
+			localQueues: []client.Object{
+				utiltesting.MakeLocalQueue("foo", "ns").ClusterQueue("cq").PendingTimeout(60, "fallback").Obj(),
+				utiltesting.MakeLocalQueue("fallback", "ns").ClusterQueue("cq").PendingTimeout(60, "foo").Obj(),
+			},
+			job: *baseJobWrapper.Clone().Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Queue("fallback").
+				Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("foo").
+					Priority(0).
+					Creation(testStartTime.Add(-2 * time.Minute)).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: string(baseJobWrapper.GetUID())}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("fallback").
+					Priority(0).
+					Creation(testStartTime.Add(-2 * time.Minute)).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: string(baseJobWrapper.GetUID())}).
+					Annotations(map[string]string{controllerconsts.ReroutedFromAnnotation: "foo"}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadQueueRerouted,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadPendingTimeoutExceeded,
+						Message: "Moved from LocalQueue foo to fallback after exceeding the pending timeout of 60s",
+					}).
+					Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "job", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    jobframework.ReasonRerouted,
+					Message:   "Moved from LocalQueue foo to fallback after exceeding the pending timeout of 60s",
+				},
+			},
+		},
+		"the job is kept in its queue when the fallback queue is one it was moved out of": {
+			localQueues: []client.Object{
+				utiltesting.MakeLocalQueue("foo", "ns").ClusterQueue("cq").PendingTimeout(60, "fallback").Obj(),
+				utiltesting.MakeLocalQueue("fallback", "ns").ClusterQueue("cq").PendingTimeout(60, "foo").Obj(),
+			},
+			job:     *baseJobWrapper.Clone().Queue("fallback").Obj(),
+			wantJob: *baseJobWrapper.Clone().Queue("fallback").Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("fallback").
+					Priority(0).
+					Creation(testStartTime.Add(-2 * time.Minute)).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: string(baseJobWrapper.GetUID())}).
+					Annotations(map[string]string{controllerconsts.ReroutedFromAnnotation: "foo"}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("fallback").
+					Priority(0).
+					Creation(testStartTime.Add(-2 * time.Minute)).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: string(baseJobWrapper.GetUID())}).
+					Annotations(map[string]string{controllerconsts.ReroutedFromAnnotation: "foo"}).
+					Obj(),
+			},
+		},
+		"the job is kept in its queue while the pending timeout is not exceeded": {
+			localQueues: []client.Object{
+				utiltesting.MakeLocalQueue("foo", "ns").ClusterQueue("cq").PendingTimeout(600, "fallback").Obj(),
+			},
+			job:     *baseJobWrapper.Clone().Obj(),
+			wantJob: *baseJobWrapper.Clone().Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("foo").
+					Priority(0).
+					Creation(testStartTime.Add(-2 * time.Minute)).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: string(baseJobWrapper.GetUID())}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("foo").
+					Priority(0).
+					Creation(testStartTime.Add(-2 * time.Minute)).
+					Labels(map[string]string{controllerconsts.JobUIDLabel: string(baseJobWrapper.GetUID())}).
+					Obj(),
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -2886,6 +3396,7 @@ func TestReconciler(t *testing.T) {
 				t.Fatalf("Could not setup indexes: %v", err)
 			}
 			objs := append(tc.priorityClasses, &tc.job, utiltesting.MakeResourceFlavor("default").Obj(), testNamespace)
+			objs = append(objs, tc.localQueues...)
 			kcBuilder := clientBuilder.
 				WithObjects(objs...)
 
@@ -2913,8 +3424,23 @@ func TestReconciler(t *testing.T) {
 					t.Fatalf("Could not create workload: %v", err)
 				}
 			}
+			reconcilerOptions := append(tc.reconcilerOptions, jobframework.WithClock(t, fakeClock))
+			if len(tc.clusterQueues) > 0 {
+				queueManager := queue.NewManager(utiltesting.NewFakeClient(), nil)
+				for _, cq := range tc.clusterQueues {
+					if err := queueManager.AddClusterQueue(ctx, cq); err != nil {
+						t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+					}
+				}
+				for _, lq := range tc.localQueues {
+					if err := queueManager.AddLocalQueue(ctx, lq.(*kueue.LocalQueue)); err != nil {
+						t.Fatalf("Inserting queue %s in manager: %v", lq.GetName(), err)
+					}
+				}
+				reconcilerOptions = append(reconcilerOptions, jobframework.WithQueues(queueManager))
+			}
 			recorder := &utiltesting.EventRecorder{}
-			reconciler := NewReconciler(kClient, recorder, append(tc.reconcilerOptions, jobframework.WithClock(t, fakeClock))...)
+			reconciler := NewReconciler(kClient, recorder, reconcilerOptions...)
 
 			jobKey := client.ObjectKeyFromObject(&tc.job)
 			_, err := reconciler.Reconcile(ctx, reconcile.Request{
@@ -38,6 +38,8 @@ import (
 	"sigs.k8s.io/kueue/pkg/controller/jobframework/webhook"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/authorization"
+	"sigs.k8s.io/kueue/pkg/util/submitter"
 )
 
 var (
@@ -50,8 +52,11 @@ type JobWebhook struct {
 	client                       client.Client
 	manageJobsWithoutQueueName   bool
 	managedJobsNamespaceSelector labels.Selector
+	integrationScope             *jobframework.IntegrationScope
 	queues                       *queue.Manager
 	cache                        *cache.Cache
+	localQueueAuthorizer         *authorization.LocalQueueAuthorizer
+	submitterRecorder            *submitter.Recorder
 }
 
 // SetupWebhook configures the webhook for batchJob.
@@ -61,8 +66,11 @@ func SetupWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 		client:                       mgr.GetClient(),
 		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
 		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
+		integrationScope:             options.IntegrationScope,
 		queues:                       options.Queues,
 		cache:                        options.Cache,
+		localQueueAuthorizer:         options.LocalQueueAuthorizer,
+		submitterRecorder:            options.SubmitterRecorder,
 	}
 	obj := &batchv1.Job{}
 	return webhook.WebhookManagedBy(mgr).
@@ -83,7 +91,10 @@ func (w *JobWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	log.V(5).Info("Applying defaults")
 
 	jobframework.ApplyDefaultLocalQueue(job.Object(), w.queues.DefaultLocalQueueExist)
-	if err := jobframework.ApplyDefaultForSuspend(ctx, job, w.client, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector); err != nil {
+	if err := w.submitterRecorder.Record(ctx, job.Object()); err != nil {
+		return err
+	}
+	if err := jobframework.ApplyDefaultForSuspend(ctx, job, w.client, w.queues, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector, w.integrationScope); err != nil {
 		return err
 	}
 
@@ -123,7 +134,11 @@ func (w *JobWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (ad
 	job := fromObject(obj)
 	log := ctrl.LoggerFrom(ctx).WithName("job-webhook")
 	log.V(5).Info("Validating create")
-	return nil, w.validateCreate(job).ToAggregate()
+	allErrs := w.validateCreate(job)
+	allErrs = append(allErrs, jobframework.ValidateLocalQueueAuthorization(ctx, w.localQueueAuthorizer, job.Object())...)
+	allErrs = append(allErrs, jobframework.ValidateAllowedPriorityClass(w.queues, job)...)
+	allErrs = append(allErrs, jobframework.ValidateExcludedFlavorsInClusterQueue(w.queues, job)...)
+	return nil, allErrs.ToAggregate()
 }
 
 func (w *JobWebhook) validateCreate(job *Job) field.ErrorList {
@@ -173,7 +188,10 @@ func (w *JobWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.
 	newJob := fromObject(newObj)
 	log := ctrl.LoggerFrom(ctx).WithName("job-webhook")
 	log.V(5).Info("Validating update")
-	return nil, w.validateUpdate(oldJob, newJob).ToAggregate()
+	allErrs := w.validateUpdate(oldJob, newJob)
+	allErrs = append(allErrs, jobframework.ValidateAllowedPriorityClassOnUpdate(w.queues, oldJob, newJob)...)
+	allErrs = append(allErrs, jobframework.ValidateExcludedFlavorsInClusterQueueOnUpdate(w.queues, oldJob, newJob)...)
+	return nil, allErrs.ToAggregate()
 }
 
 func (w *JobWebhook) validateUpdate(oldJob, newJob *Job) field.ErrorList {
@@ -34,6 +34,7 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
@@ -54,6 +55,9 @@ var (
 	queueNameLabelPath            = labelsPath.Key(constants.QueueLabel)
 	prebuiltWlNameLabelPath       = labelsPath.Key(constants.PrebuiltWorkloadLabel)
 	maxExecTimeLabelPath          = labelsPath.Key(constants.MaxExecTimeSecondsLabel)
+	expectedDurationPath          = annotationsPath.Key(constants.ExpectedDurationAnnotation)
+	podSetFlavorsPath             = annotationsPath.Key(constants.PodSetFlavorsAnnotation)
+	excludedFlavorsPath           = annotationsPath.Key(constants.ExcludedFlavorsAnnotation)
 	queueNameAnnotationsPath      = annotationsPath.Key(constants.QueueAnnotation)
 	workloadPriorityClassNamePath = labelsPath.Key(constants.WorkloadPriorityClassLabel)
 )
@@ -213,6 +217,63 @@ func TestValidateCreate(t *testing.T) {
 				field.Invalid(maxExecTimeLabelPath, 0, "should be greater than 0"),
 			},
 		},
+		{
+			name: "valid expected duration",
+			job: testingutil.MakeJob("job", "default").
+				SetAnnotation(constants.ExpectedDurationAnnotation, "2h30m").
+				Obj(),
+			wantErr: nil,
+		},
+		{
+			name: "invalid expected duration",
+			job: testingutil.MakeJob("job", "default").
+				SetAnnotation(constants.ExpectedDurationAnnotation, "2 hours").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(expectedDurationPath, "2 hours", "must be a positive duration"),
+			},
+		},
+		{
+			name: "zero expected duration",
+			job: testingutil.MakeJob("job", "default").
+				SetAnnotation(constants.ExpectedDurationAnnotation, "0s").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(expectedDurationPath, "0s", "must be a positive duration"),
+			},
+		},
+		{
+			name: "valid pinned flavors",
+			job: testingutil.MakeJob("job", "default").
+				SetAnnotation(constants.PodSetFlavorsAnnotation, "main=spot").
+				Obj(),
+			wantErr: nil,
+		},
+		{
+			name: "invalid pinned flavors",
+			job: testingutil.MakeJob("job", "default").
+				SetAnnotation(constants.PodSetFlavorsAnnotation, "spot").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(podSetFlavorsPath, "spot", `"spot" is not a <podSet>=<flavor> pair`),
+			},
+		},
+		{
+			name: "valid excluded flavors",
+			job: testingutil.MakeJob("job", "default").
+				SetAnnotation(constants.ExcludedFlavorsAnnotation, "spot,preemptible").
+				Obj(),
+			wantErr: nil,
+		},
+		{
+			name: "invalid excluded flavors",
+			job: testingutil.MakeJob("job", "default").
+				SetAnnotation(constants.ExcludedFlavorsAnnotation, "spot,spot").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(excludedFlavorsPath, "spot,spot", `flavor "spot" is excluded more than once`),
+			},
+		},
 		{
 			name: "negative maximum execution time",
 			job: testingutil.MakeJob("job", "default").
@@ -287,6 +348,137 @@ func TestValidateCreate(t *testing.T) {
 	}
 }
 
+func TestValidateAllowedPriorityClass(t *testing.T) {
+	testcases := map[string]struct {
+		job     *batchv1.Job
+		oldJob  *batchv1.Job
+		wantErr field.ErrorList
+	}{
+		"allowed priority class": {
+			job: testingutil.MakeJob("job", "default").Queue("restricted").PriorityClass("low").Obj(),
+		},
+		"no priority class": {
+			job: testingutil.MakeJob("job", "default").Queue("restricted").Obj(),
+		},
+		"not allowed priority class": {
+			job: testingutil.MakeJob("job", "default").Queue("restricted").PriorityClass("critical").Obj(),
+			wantErr: field.ErrorList{
+				field.Forbidden(queueNameLabelPath, `the priority class "critical" is not allowed in the ClusterQueue of the LocalQueue "restricted"`),
+			},
+		},
+		"not allowed workload priority class": {
+			job: testingutil.MakeJob("job", "default").Queue("restricted").WorkloadPriorityClass("critical").PriorityClass("low").Obj(),
+			wantErr: field.ErrorList{
+				field.Forbidden(queueNameLabelPath, `the priority class "critical" is not allowed in the ClusterQueue of the LocalQueue "restricted"`),
+			},
+		},
+		"priority class allowed in another ClusterQueue": {
+			job: testingutil.MakeJob("job", "default").Queue("open").PriorityClass("critical").Obj(),
+		},
+		"unchanged queue and priority class": {
+			oldJob: testingutil.MakeJob("job", "default").Queue("restricted").PriorityClass("critical").Obj(),
+			job:    testingutil.MakeJob("job", "default").Queue("restricted").PriorityClass("critical").Suspend(false).Obj(),
+		},
+		"moved to a restricted ClusterQueue": {
+			oldJob: testingutil.MakeJob("job", "default").Queue("open").PriorityClass("critical").Obj(),
+			job:    testingutil.MakeJob("job", "default").Queue("restricted").PriorityClass("critical").Obj(),
+			wantErr: field.ErrorList{
+				field.Forbidden(queueNameLabelPath, `the priority class "critical" is not allowed in the ClusterQueue of the LocalQueue "restricted"`),
+			},
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			queueManager := queue.NewManager(utiltesting.NewFakeClient(), nil)
+			for _, cq := range []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("restricted").AllowedPriorityClasses("low").Obj(),
+				utiltesting.MakeClusterQueue("open").Obj(),
+			} {
+				if err := queueManager.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+				}
+				if err := queueManager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue(cq.Name, "default").ClusterQueue(cq.Name).Obj()); err != nil {
+					t.Fatalf("Inserting queue %s in manager: %v", cq.Name, err)
+				}
+			}
+
+			var gotErr field.ErrorList
+			if tc.oldJob == nil {
+				gotErr = jobframework.ValidateAllowedPriorityClass(queueManager, (*Job)(tc.job))
+			} else {
+				gotErr = jobframework.ValidateAllowedPriorityClassOnUpdate(queueManager, (*Job)(tc.oldJob), (*Job)(tc.job))
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Errorf("Unexpected errors (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateExcludedFlavorsInClusterQueue(t *testing.T) {
+	testcases := map[string]struct {
+		job     *batchv1.Job
+		oldJob  *batchv1.Job
+		wantErr field.ErrorList
+	}{
+		"no excluded flavors": {
+			job: testingutil.MakeJob("job", "default").Queue("lq").Obj(),
+		},
+		"excluded flavor of the ClusterQueue": {
+			job: testingutil.MakeJob("job", "default").Queue("lq").SetAnnotation(constants.ExcludedFlavorsAnnotation, "spot").Obj(),
+		},
+		"excluded flavor not in the ClusterQueue": {
+			job: testingutil.MakeJob("job", "default").Queue("lq").SetAnnotation(constants.ExcludedFlavorsAnnotation, "spot,preemptible").Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(excludedFlavorsPath, "spot,preemptible", `flavor "preemptible" is not in the ClusterQueue of the LocalQueue "lq"`),
+			},
+		},
+		"unknown LocalQueue": {
+			job: testingutil.MakeJob("job", "default").Queue("missing").SetAnnotation(constants.ExcludedFlavorsAnnotation, "preemptible").Obj(),
+		},
+		"unchanged queue and excluded flavors": {
+			oldJob: testingutil.MakeJob("job", "default").Queue("lq").SetAnnotation(constants.ExcludedFlavorsAnnotation, "preemptible").Obj(),
+			job:    testingutil.MakeJob("job", "default").Queue("lq").SetAnnotation(constants.ExcludedFlavorsAnnotation, "preemptible").Suspend(false).Obj(),
+		},
+		"changed excluded flavors": {
+			oldJob: testingutil.MakeJob("job", "default").Queue("lq").SetAnnotation(constants.ExcludedFlavorsAnnotation, "spot").Obj(),
+			job:    testingutil.MakeJob("job", "default").Queue("lq").SetAnnotation(constants.ExcludedFlavorsAnnotation, "preemptible").Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(excludedFlavorsPath, "preemptible", `flavor "preemptible" is not in the ClusterQueue of the LocalQueue "lq"`),
+			},
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			queueManager := queue.NewManager(utiltesting.NewFakeClient(), nil)
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(
+					*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "5").Obj(),
+					*utiltesting.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "5").Obj(),
+				).
+				Obj()
+			if err := queueManager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+			}
+			if err := queueManager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue("lq", "default").ClusterQueue(cq.Name).Obj()); err != nil {
+				t.Fatalf("Inserting queue lq in manager: %v", err)
+			}
+
+			var gotErr field.ErrorList
+			if tc.oldJob == nil {
+				gotErr = jobframework.ValidateExcludedFlavorsInClusterQueue(queueManager, (*Job)(tc.job))
+			} else {
+				gotErr = jobframework.ValidateExcludedFlavorsInClusterQueueOnUpdate(queueManager, (*Job)(tc.oldJob), (*Job)(tc.job))
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr); diff != "" {
+				t.Errorf("Unexpected errors (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestValidateUpdate(t *testing.T) {
 	testcases := []struct {
 		name    string
@@ -300,6 +492,34 @@ func TestValidateUpdate(t *testing.T) {
 			newJob:  testingutil.MakeJob("job", "default").Queue("queue").Suspend(false).Obj(),
 			wantErr: nil,
 		},
+		{
+			name:    "change pinned flavors with suspend is true",
+			oldJob:  testingutil.MakeJob("job", "default").SetAnnotation(constants.PodSetFlavorsAnnotation, "main=on-demand").Obj(),
+			newJob:  testingutil.MakeJob("job", "default").SetAnnotation(constants.PodSetFlavorsAnnotation, "main=spot").Obj(),
+			wantErr: nil,
+		},
+		{
+			name:   "change pinned flavors with suspend is false",
+			oldJob: testingutil.MakeJob("job", "default").SetAnnotation(constants.PodSetFlavorsAnnotation, "main=on-demand").Suspend(false).Obj(),
+			newJob: testingutil.MakeJob("job", "default").SetAnnotation(constants.PodSetFlavorsAnnotation, "main=spot").Suspend(false).Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(podSetFlavorsPath, "main=spot", apivalidation.FieldImmutableErrorMsg),
+			},
+		},
+		{
+			name:    "change excluded flavors with suspend is true",
+			oldJob:  testingutil.MakeJob("job", "default").Obj(),
+			newJob:  testingutil.MakeJob("job", "default").SetAnnotation(constants.ExcludedFlavorsAnnotation, "spot").Obj(),
+			wantErr: nil,
+		},
+		{
+			name:   "change excluded flavors with suspend is false",
+			oldJob: testingutil.MakeJob("job", "default").Suspend(false).Obj(),
+			newJob: testingutil.MakeJob("job", "default").SetAnnotation(constants.ExcludedFlavorsAnnotation, "spot").Suspend(false).Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(excludedFlavorsPath, "spot", apivalidation.FieldImmutableErrorMsg),
+			},
+		},
 		{
 			name:   "add queue name with suspend is false",
 			oldJob: testingutil.MakeJob("job", "default").Obj(),
@@ -35,6 +35,8 @@ import (
 	"sigs.k8s.io/kueue/pkg/controller/jobframework/webhook"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/authorization"
+	"sigs.k8s.io/kueue/pkg/util/submitter"
 )
 
 var (
@@ -45,8 +47,11 @@ type JobSetWebhook struct {
 	client                       client.Client
 	manageJobsWithoutQueueName   bool
 	managedJobsNamespaceSelector labels.Selector
+	integrationScope             *jobframework.IntegrationScope
 	queues                       *queue.Manager
 	cache                        *cache.Cache
+	localQueueAuthorizer         *authorization.LocalQueueAuthorizer
+	submitterRecorder            *submitter.Recorder
 }
 
 // SetupJobSetWebhook configures the webhook for kubeflow JobSet.
@@ -56,8 +61,11 @@ func SetupJobSetWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 		client:                       mgr.GetClient(),
 		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
 		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
+		integrationScope:             options.IntegrationScope,
 		queues:                       options.Queues,
 		cache:                        options.Cache,
+		localQueueAuthorizer:         options.LocalQueueAuthorizer,
+		submitterRecorder:            options.SubmitterRecorder,
 	}
 	obj := &jobsetapi.JobSet{}
 	return webhook.WebhookManagedBy(mgr).
@@ -78,7 +86,10 @@ func (w *JobSetWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	log.V(5).Info("Applying defaults")
 
 	jobframework.ApplyDefaultLocalQueue(jobSet.Object(), w.queues.DefaultLocalQueueExist)
-	if err := jobframework.ApplyDefaultForSuspend(ctx, jobSet, w.client, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector); err != nil {
+	if err := w.submitterRecorder.Record(ctx, jobSet.Object()); err != nil {
+		return err
+	}
+	if err := jobframework.ApplyDefaultForSuspend(ctx, jobSet, w.client, w.queues, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector, w.integrationScope); err != nil {
 		return err
 	}
 
@@ -118,7 +129,11 @@ func (w *JobSetWebhook) ValidateCreate(ctx context.Context, obj runtime.Object)
 	jobSet := fromObject(obj)
 	log := ctrl.LoggerFrom(ctx).WithName("jobset-webhook")
 	log.Info("Validating create")
-	return nil, w.validateCreate(jobSet).ToAggregate()
+	allErrs := w.validateCreate(jobSet)
+	allErrs = append(allErrs, jobframework.ValidateLocalQueueAuthorization(ctx, w.localQueueAuthorizer, jobSet.Object())...)
+	allErrs = append(allErrs, jobframework.ValidateAllowedPriorityClass(w.queues, jobSet)...)
+	allErrs = append(allErrs, jobframework.ValidateExcludedFlavorsInClusterQueue(w.queues, jobSet)...)
+	return nil, allErrs.ToAggregate()
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
@@ -127,7 +142,10 @@ func (w *JobSetWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runti
 	newJobSet := fromObject(newObj)
 	log := ctrl.LoggerFrom(ctx).WithName("jobset-webhook")
 	log.Info("Validating update")
-	return nil, w.validateUpdate(oldJobSet, newJobSet).ToAggregate()
+	allErrs := w.validateUpdate(oldJobSet, newJobSet)
+	allErrs = append(allErrs, jobframework.ValidateAllowedPriorityClassOnUpdate(w.queues, oldJobSet, newJobSet)...)
+	allErrs = append(allErrs, jobframework.ValidateExcludedFlavorsInClusterQueueOnUpdate(w.queues, oldJobSet, newJobSet)...)
+	return nil, allErrs.ToAggregate()
 }
 
 func (w *JobSetWebhook) validateUpdate(oldJob, newJob *JobSet) field.ErrorList {
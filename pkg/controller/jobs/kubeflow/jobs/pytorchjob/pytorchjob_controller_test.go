@@ -305,6 +305,45 @@ func TestPodSets(t *testing.T) {
 	}
 }
 
+func TestReclaimablePods(t *testing.T) {
+	jobTemplate := testingpytorchjob.MakePyTorchJob("pytorchjob", "ns").
+		PyTorchReplicaSpecs(
+			testingpytorchjob.PyTorchReplicaSpecRequirement{
+				ReplicaType:  kftraining.PyTorchJobReplicaTypeMaster,
+				ReplicaCount: 1,
+			},
+			testingpytorchjob.PyTorchReplicaSpecRequirement{
+				ReplicaType:  kftraining.PyTorchJobReplicaTypeWorker,
+				ReplicaCount: 4,
+			},
+		)
+	testCases := map[string]struct {
+		job  *kftraining.PyTorchJob
+		want []kueue.ReclaimablePod
+	}{
+		"no succeeded pods": {
+			job: jobTemplate.Clone().Obj(),
+		},
+		"succeeded workers": {
+			job: jobTemplate.Clone().
+				StatusReplicaSucceeded(kftraining.PyTorchJobReplicaTypeWorker, 3).
+				Obj(),
+			want: []kueue.ReclaimablePod{{Name: "worker", Count: 3}},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := fromObject(tc.job).ReclaimablePods()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected reclaimable pods (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestValidate(t *testing.T) {
 	testCases := map[string]struct {
 		job      *kftraining.PyTorchJob
@@ -39,6 +39,7 @@ type KubeflowJob struct {
 var _ jobframework.GenericJob = (*KubeflowJob)(nil)
 var _ jobframework.JobWithPriorityClass = (*KubeflowJob)(nil)
 var _ jobframework.JobWithCustomValidation = (*KubeflowJob)(nil)
+var _ jobframework.JobWithReclaimablePods = (*KubeflowJob)(nil)
 
 func (j *KubeflowJob) Object() client.Object {
 	return j.KFJobControl.Object()
@@ -162,6 +163,23 @@ func (j *KubeflowJob) PriorityClass() string {
 	return ""
 }
 
+// ReclaimablePods returns the succeeded pods of each replica type, whose
+// quota is no longer needed.
+func (j *KubeflowJob) ReclaimablePods() ([]kueue.ReclaimablePod, error) {
+	var ret []kueue.ReclaimablePod
+	for _, replicaType := range j.OrderedReplicaTypes() {
+		status := j.KFJobControl.JobStatus().ReplicaStatuses[replicaType]
+		if status == nil || status.Succeeded == 0 {
+			continue
+		}
+		ret = append(ret, kueue.ReclaimablePod{
+			Name:  strings.ToLower(string(replicaType)),
+			Count: min(status.Succeeded, podsCount(j.KFJobControl.ReplicaSpecs(), replicaType)),
+		})
+	}
+	return ret, nil
+}
+
 func (j *KubeflowJob) OrderedReplicaTypes() []kftraining.ReplicaType {
 	replicaTypes := j.KFJobControl.OrderedReplicaTypes()
 	result := make([]kftraining.ReplicaType, 0, len(replicaTypes))
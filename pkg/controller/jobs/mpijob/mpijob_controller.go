@@ -80,6 +80,7 @@ type MPIJob kfmpi.MPIJob
 
 var _ jobframework.GenericJob = (*MPIJob)(nil)
 var _ jobframework.JobWithPriorityClass = (*MPIJob)(nil)
+var _ jobframework.JobWithReclaimablePods = (*MPIJob)(nil)
 
 func (j *MPIJob) Object() client.Object {
 	return (*kfmpi.MPIJob)(j)
@@ -197,6 +198,23 @@ func (j *MPIJob) PodsReady() bool {
 	return false
 }
 
+// ReclaimablePods returns the succeeded pods of each replica type, whose
+// quota is no longer needed.
+func (j *MPIJob) ReclaimablePods() ([]kueue.ReclaimablePod, error) {
+	var ret []kueue.ReclaimablePod
+	for _, replicaType := range orderedReplicaTypes(&j.Spec) {
+		status := j.Status.ReplicaStatuses[replicaType]
+		if status == nil || status.Succeeded == 0 {
+			continue
+		}
+		ret = append(ret, kueue.ReclaimablePod{
+			Name:  strings.ToLower(string(replicaType)),
+			Count: min(status.Succeeded, podsCount(&j.Spec, replicaType)),
+		})
+	}
+	return ret, nil
+}
+
 func SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
 	return jobframework.SetupWorkloadOwnerIndex(ctx, indexer, gvk)
 }
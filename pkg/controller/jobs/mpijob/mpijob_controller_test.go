@@ -353,6 +353,52 @@ var (
 	}
 )
 
+func TestReclaimablePods(t *testing.T) {
+	jobTemplate := testingmpijob.MakeMPIJob("job", "ns").MPIJobReplicaSpecs(
+		testingmpijob.MPIJobReplicaSpecRequirement{
+			ReplicaType:  kfmpi.MPIReplicaTypeLauncher,
+			ReplicaCount: 1,
+		},
+		testingmpijob.MPIJobReplicaSpecRequirement{
+			ReplicaType:  kfmpi.MPIReplicaTypeWorker,
+			ReplicaCount: 3,
+		},
+	)
+	testCases := map[string]struct {
+		job  *kfmpi.MPIJob
+		want []kueue.ReclaimablePod
+	}{
+		"no succeeded pods": {
+			job: jobTemplate.Clone().Obj(),
+		},
+		"succeeded workers": {
+			job: jobTemplate.Clone().
+				StatusReplicaSucceeded(kfmpi.MPIReplicaTypeLauncher, 0).
+				StatusReplicaSucceeded(kfmpi.MPIReplicaTypeWorker, 2).
+				Obj(),
+			want: []kueue.ReclaimablePod{{Name: "worker", Count: 2}},
+		},
+		"succeeded pods capped to the replicas": {
+			job: jobTemplate.Clone().
+				StatusReplicaSucceeded(kfmpi.MPIReplicaTypeLauncher, 1).
+				StatusReplicaSucceeded(kfmpi.MPIReplicaTypeWorker, 4).
+				Obj(),
+			want: []kueue.ReclaimablePod{{Name: "launcher", Count: 1}, {Name: "worker", Count: 3}},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := fromObject(tc.job).ReclaimablePods()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected reclaimable pods (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestReconciler(t *testing.T) {
 	baseWPCWrapper := utiltesting.MakeWorkloadPriorityClass("test-wpc").
 		PriorityValue(100)
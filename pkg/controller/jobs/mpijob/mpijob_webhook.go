@@ -36,7 +36,9 @@ import (
 	"sigs.k8s.io/kueue/pkg/controller/jobframework/webhook"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/authorization"
 	"sigs.k8s.io/kueue/pkg/util/kubeversion"
+	"sigs.k8s.io/kueue/pkg/util/submitter"
 )
 
 var (
@@ -47,9 +49,12 @@ type MpiJobWebhook struct {
 	client                       client.Client
 	manageJobsWithoutQueueName   bool
 	managedJobsNamespaceSelector labels.Selector
+	integrationScope             *jobframework.IntegrationScope
 	kubeServerVersion            *kubeversion.ServerVersionFetcher
 	queues                       *queue.Manager
 	cache                        *cache.Cache
+	localQueueAuthorizer         *authorization.LocalQueueAuthorizer
+	submitterRecorder            *submitter.Recorder
 }
 
 // SetupMPIJobWebhook configures the webhook for MPIJob.
@@ -59,9 +64,12 @@ func SetupMPIJobWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 		client:                       mgr.GetClient(),
 		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
 		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
+		integrationScope:             options.IntegrationScope,
 		kubeServerVersion:            options.KubeServerVersion,
 		queues:                       options.Queues,
 		cache:                        options.Cache,
+		localQueueAuthorizer:         options.LocalQueueAuthorizer,
+		submitterRecorder:            options.SubmitterRecorder,
 	}
 	obj := &v2beta1.MPIJob{}
 	return webhook.WebhookManagedBy(mgr).
@@ -82,7 +90,10 @@ func (w *MpiJobWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	log.V(5).Info("Applying defaults")
 
 	jobframework.ApplyDefaultLocalQueue(mpiJob.Object(), w.queues.DefaultLocalQueueExist)
-	if err := jobframework.ApplyDefaultForSuspend(ctx, mpiJob, w.client, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector); err != nil {
+	if err := w.submitterRecorder.Record(ctx, mpiJob.Object()); err != nil {
+		return err
+	}
+	if err := jobframework.ApplyDefaultForSuspend(ctx, mpiJob, w.client, w.queues, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector, w.integrationScope); err != nil {
 		return err
 	}
 
@@ -122,7 +133,11 @@ func (w *MpiJobWebhook) ValidateCreate(ctx context.Context, obj runtime.Object)
 	mpiJob := fromObject(obj)
 	log := ctrl.LoggerFrom(ctx).WithName("mpijob-webhook")
 	log.Info("Validating create")
-	return nil, w.validateCommon(mpiJob).ToAggregate()
+	allErrs := w.validateCommon(mpiJob)
+	allErrs = append(allErrs, jobframework.ValidateLocalQueueAuthorization(ctx, w.localQueueAuthorizer, mpiJob.Object())...)
+	allErrs = append(allErrs, jobframework.ValidateAllowedPriorityClass(w.queues, mpiJob)...)
+	allErrs = append(allErrs, jobframework.ValidateExcludedFlavorsInClusterQueue(w.queues, mpiJob)...)
+	return nil, allErrs.ToAggregate()
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
@@ -133,6 +148,8 @@ func (w *MpiJobWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runti
 	log.Info("Validating update")
 	allErrs := jobframework.ValidateJobOnUpdate(oldMpiJob, newMpiJob)
 	allErrs = append(allErrs, w.validateCommon(newMpiJob)...)
+	allErrs = append(allErrs, jobframework.ValidateAllowedPriorityClassOnUpdate(w.queues, oldMpiJob, newMpiJob)...)
+	allErrs = append(allErrs, jobframework.ValidateExcludedFlavorsInClusterQueueOnUpdate(w.queues, oldMpiJob, newMpiJob)...)
 	return nil, allErrs.ToAggregate()
 }
 
@@ -59,10 +59,11 @@ import (
 	"sigs.k8s.io/kueue/pkg/util/parallelize"
 	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
 	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
+	"sigs.k8s.io/kueue/pkg/util/submitter"
 )
 
 const (
-	SchedulingGateName             = "kueue.x-k8s.io/admission"
+	SchedulingGateName             = controllerconsts.AdmissionSchedulingGate
 	FrameworkName                  = "pod"
 	ConditionTypeTerminationTarget = "TerminationTarget"
 	errMsgIncorrectGroupRoleCount  = "pod group can't include more than 8 roles"
@@ -522,7 +523,8 @@ func (p *Pod) Skip() bool {
 	if v, ok := p.pod.GetLabels()[ManagedLabelKey]; p.isFound && (!ok || v != ManagedLabelValue) {
 		return true
 	}
-	return false
+	// Skip the replicas admitted in chunks until they are assigned to a chunk.
+	return p.isFound && AwaitsChunk(&p.pod)
 }
 
 // podGroupName returns a value of GroupNameLabel for the pod object.
@@ -971,6 +973,8 @@ func (p *Pod) ConstructComposableWorkload(ctx context.Context, c client.Client,
 			MaximumExecutionTimeSeconds: jobframework.MaximumExecutionTimeSeconds(p),
 		},
 	}
+	wl.Annotations = submitter.CopyAnnotations(wl.Annotations, p.pod.GetAnnotations())
+	jobframework.CopyWorkloadAnnotations(wl, object)
 
 	// Construct workload for a single pod
 	if !p.isGroup {
@@ -40,7 +40,9 @@ import (
 	"sigs.k8s.io/kueue/pkg/controller/jobframework/webhook"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/authorization"
 	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
+	"sigs.k8s.io/kueue/pkg/util/submitter"
 )
 
 const (
@@ -72,8 +74,11 @@ type PodWebhook struct {
 	queues                       *queue.Manager
 	manageJobsWithoutQueueName   bool
 	managedJobsNamespaceSelector labels.Selector
+	integrationScope             *jobframework.IntegrationScope
 	namespaceSelector            *metav1.LabelSelector
 	podSelector                  *metav1.LabelSelector
+	localQueueAuthorizer         *authorization.LocalQueueAuthorizer
+	submitterRecorder            *submitter.Recorder
 }
 
 // SetupWebhook configures the webhook for pods.
@@ -88,6 +93,9 @@ func SetupWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 		queues:                       options.Queues,
 		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
 		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
+		integrationScope:             options.IntegrationScope,
+		localQueueAuthorizer:         options.LocalQueueAuthorizer,
+		submitterRecorder:            options.SubmitterRecorder,
 		namespaceSelector:            podOpts.NamespaceSelector,
 		podSelector:                  podOpts.PodSelector,
 	}
@@ -159,7 +167,7 @@ func (w *PodWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	}
 	log.V(5).Info("Found pod namespace", "Namespace.Name", ns.GetName())
 	jobframework.ApplyDefaultLocalQueue(pod.Object(), w.queues.DefaultLocalQueueExist)
-	suspend, err := jobframework.WorkloadShouldBeSuspended(ctx, pod.Object(), w.client, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector)
+	suspend, err := jobframework.WorkloadShouldBeSuspended(ctx, pod.Object(), w.client, w.queues, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector, w.integrationScope)
 	if err != nil {
 		return err
 	}
@@ -188,12 +196,18 @@ func (w *PodWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	}
 
 	if suspend {
-		controllerutil.AddFinalizer(pod.Object(), PodFinalizer)
-
+		if err := w.submitterRecorder.Record(ctx, pod.Object()); err != nil {
+			return err
+		}
 		if pod.pod.Labels == nil {
 			pod.pod.Labels = make(map[string]string)
 		}
 		pod.pod.Labels[ManagedLabelKey] = ManagedLabelValue
+		// The replicas admitted in chunks get the finalizer once they are
+		// assigned to a chunk, so that they can be deleted freely until then.
+		if !AwaitsChunk(&pod.pod) {
+			controllerutil.AddFinalizer(pod.Object(), PodFinalizer)
+		}
 
 		gate(&pod.pod)
 
@@ -233,6 +247,9 @@ func (w *PodWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (ad
 
 	allErrs := jobframework.ValidateJobOnCreate(pod)
 	allErrs = append(allErrs, validateCommon(pod)...)
+	allErrs = append(allErrs, jobframework.ValidateLocalQueueAuthorization(ctx, w.localQueueAuthorizer, pod.Object())...)
+	allErrs = append(allErrs, jobframework.ValidateAllowedPriorityClass(w.queues, pod)...)
+	allErrs = append(allErrs, jobframework.ValidateExcludedFlavorsInClusterQueue(w.queues, pod)...)
 
 	if warn := warningForPodManagedLabel(pod); warn != "" {
 		warnings = append(warnings, warn)
@@ -251,8 +268,12 @@ func (w *PodWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.
 
 	allErrs := jobframework.ValidateJobOnUpdate(oldPod, newPod)
 	allErrs = append(allErrs, validateCommon(newPod)...)
+	allErrs = append(allErrs, jobframework.ValidateAllowedPriorityClassOnUpdate(w.queues, oldPod, newPod)...)
+	allErrs = append(allErrs, jobframework.ValidateExcludedFlavorsInClusterQueueOnUpdate(w.queues, oldPod, newPod)...)
 
-	allErrs = append(allErrs, validation.ValidateImmutableField(podGroupName(newPod.pod), podGroupName(oldPod.pod), groupNameLabelPath)...)
+	if !AwaitsChunk(&oldPod.pod) {
+		allErrs = append(allErrs, validation.ValidateImmutableField(podGroupName(newPod.pod), podGroupName(oldPod.pod), groupNameLabelPath)...)
+	}
 	allErrs = append(allErrs, validateUpdateForRetriableInGroupAnnotation(oldPod, newPod)...)
 
 	if warn := warningForPodManagedLabel(newPod); warn != "" {
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+// AwaitsChunk returns true if the pod is a managed replica admitted in chunks,
+// which is not assigned to the pod group of a chunk yet. Such pods stay gated,
+// without a workload, until they are assigned.
+func AwaitsChunk(pod *corev1.Pod) bool {
+	if pod.Labels[ManagedLabelKey] != ManagedLabelValue || podGroupName(*pod) != "" {
+		return false
+	}
+	_, chunked := pod.Annotations[constants.ReplicaAdmissionChunkSizeAnnotation]
+	return chunked
+}
+
+// AssignToChunk makes the pod a member of the pod group of a chunk of
+// totalCount replicas, admitted as soon as its first pod is assigned.
+func AssignToChunk(pod *corev1.Pod, chunk string, totalCount int) error {
+	roleHash, err := getRoleHash(*pod)
+	if err != nil {
+		return err
+	}
+	if pod.Labels == nil {
+		pod.Labels = make(map[string]string, 1)
+	}
+	pod.Labels[GroupNameLabel] = chunk
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string, 4)
+	}
+	pod.Annotations[GroupTotalCountAnnotation] = strconv.Itoa(totalCount)
+	pod.Annotations[GroupFastAdmissionAnnotation] = "true"
+	pod.Annotations[GroupServingAnnotation] = "true"
+	pod.Annotations[RoleHashAnnotation] = roleHash
+	controllerutil.AddFinalizer(pod, PodFinalizer)
+	return nil
+}
@@ -29,6 +29,8 @@ import (
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework/webhook"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/authorization"
+	"sigs.k8s.io/kueue/pkg/util/submitter"
 )
 
 var (
@@ -42,6 +44,9 @@ type RayClusterWebhook struct {
 	queues                       *queue.Manager
 	manageJobsWithoutQueueName   bool
 	managedJobsNamespaceSelector labels.Selector
+	integrationScope             *jobframework.IntegrationScope
+	localQueueAuthorizer         *authorization.LocalQueueAuthorizer
+	submitterRecorder            *submitter.Recorder
 }
 
 // SetupRayClusterWebhook configures the webhook for rayv1 RayCluster.
@@ -55,6 +60,9 @@ func SetupRayClusterWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error
 		queues:                       options.Queues,
 		manageJobsWithoutQueueName:   options.ManageJobsWithoutQueueName,
 		managedJobsNamespaceSelector: options.ManagedJobsNamespaceSelector,
+		integrationScope:             options.IntegrationScope,
+		localQueueAuthorizer:         options.LocalQueueAuthorizer,
+		submitterRecorder:            options.SubmitterRecorder,
 	}
 	obj := &rayv1.RayCluster{}
 	return webhook.WebhookManagedBy(mgr).
@@ -74,7 +82,10 @@ func (w *RayClusterWebhook) Default(ctx context.Context, obj runtime.Object) err
 	log := ctrl.LoggerFrom(ctx).WithName("raycluster-webhook")
 	log.V(10).Info("Applying defaults")
 	jobframework.ApplyDefaultLocalQueue(job.Object(), w.queues.DefaultLocalQueueExist)
-	return jobframework.ApplyDefaultForSuspend(ctx, job, w.client, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector)
+	if err := w.submitterRecorder.Record(ctx, job.Object()); err != nil {
+		return err
+	}
+	return jobframework.ApplyDefaultForSuspend(ctx, job, w.client, w.queues, w.manageJobsWithoutQueueName, w.managedJobsNamespaceSelector, w.integrationScope)
 }
 
 // +kubebuilder:webhook:path=/validate-ray-io-v1-raycluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=ray.io,resources=rayclusters,verbs=create;update,versions=v1,name=vraycluster.kb.io,admissionReviewVersions=v1
@@ -86,7 +97,11 @@ func (w *RayClusterWebhook) ValidateCreate(ctx context.Context, obj runtime.Obje
 	job := obj.(*rayv1.RayCluster)
 	log := ctrl.LoggerFrom(ctx).WithName("raycluster-webhook")
 	log.V(10).Info("Validating create")
-	return nil, w.validateCreate(job).ToAggregate()
+	allErrs := w.validateCreate(job)
+	allErrs = append(allErrs, jobframework.ValidateLocalQueueAuthorization(ctx, w.localQueueAuthorizer, job)...)
+	allErrs = append(allErrs, jobframework.ValidateAllowedPriorityClass(w.queues, (*RayCluster)(job))...)
+	allErrs = append(allErrs, jobframework.ValidateExcludedFlavorsInClusterQueue(w.queues, (*RayCluster)(job))...)
+	return nil, allErrs.ToAggregate()
 }
 
 func (w *RayClusterWebhook) validateCreate(job *rayv1.RayCluster) field.ErrorList {
@@ -141,6 +156,8 @@ func (w *RayClusterWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj r
 		log.Info("Validating update")
 		allErrors := jobframework.ValidateJobOnUpdate((*RayCluster)(oldJob), (*RayCluster)(newJob))
 		allErrors = append(allErrors, w.validateCreate(newJob)...)
+		allErrors = append(allErrors, jobframework.ValidateAllowedPriorityClassOnUpdate(w.queues, (*RayCluster)(oldJob), (*RayCluster)(newJob))...)
+		allErrors = append(allErrors, jobframework.ValidateExcludedFlavorsInClusterQueueOnUpdate(w.queues, (*RayCluster)(oldJob), (*RayCluster)(newJob))...)
 		return nil, allErrors.ToAggregate()
 	}
 	return nil, nil
@@ -20,9 +20,12 @@ import (
 	"context"
 
 	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
@@ -62,6 +65,24 @@ func (d *StatefulSet) GVK() schema.GroupVersionKind {
 	return gvk
 }
 
+var _ jobframework.IdleDeactivatable = (*StatefulSet)(nil)
+
+func (d *StatefulSet) PodSelector() (labels.Selector, error) {
+	return metav1.LabelSelectorAsSelector(d.Spec.Selector)
+}
+
+func (d *StatefulSet) Replicas() int32 {
+	return ptr.Deref(d.Spec.Replicas, 1)
+}
+
+func (d *StatefulSet) SetReplicas(replicas int32) {
+	d.Spec.Replicas = ptr.To(replicas)
+}
+
+func (d *StatefulSet) WorkloadName() string {
+	return GetWorkloadName(d.Name)
+}
+
 func SetupIndexes(context.Context, client.FieldIndexer) error {
 	return nil
 }
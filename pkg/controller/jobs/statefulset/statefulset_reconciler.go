@@ -26,22 +26,28 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/controller/jobs/pod"
 	clientutil "sigs.k8s.io/kueue/pkg/util/client"
 	"sigs.k8s.io/kueue/pkg/util/parallelize"
 )
 
-// +kubebuilder:rbac:groups="apps",resources=statefulsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="apps",resources=statefulsets,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="metrics.k8s.io",resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch
 
 var (
 	_ jobframework.JobReconcilerInterface = (*Reconciler)(nil)
 )
 
 type Reconciler struct {
-	client client.Client
+	client        client.Client
+	idleReclaimer *jobframework.IdleReclaimer
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
@@ -61,7 +67,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	if err := r.syncVolumeTopology(ctx, sts); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return r.idleReclaimer.Reconcile(ctx, fromObject(sts))
 }
 
 func (r *Reconciler) fetchAndFinalizePods(ctx context.Context, namespace, statefulSetName string) error {
@@ -94,9 +104,15 @@ func (r *Reconciler) finalizePods(ctx context.Context, pods []corev1.Pod) error
 
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	ctrl.Log.V(3).Info("Setting up StatefulSet reconciler")
-	return ctrl.NewControllerManagedBy(mgr).For(&appsv1.StatefulSet{}).Complete(r)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.StatefulSet{}).
+		Watches(&kueue.Workload{}, handler.EnqueueRequestsFromMapFunc(r.statefulSetForWorkload)).
+		Complete(r)
 }
 
-func NewReconciler(client client.Client, _ record.EventRecorder, _ ...jobframework.Option) jobframework.JobReconcilerInterface {
-	return &Reconciler{client: client}
+func NewReconciler(client client.Client, record record.EventRecorder, opts ...jobframework.Option) jobframework.JobReconcilerInterface {
+	return &Reconciler{
+		client:        client,
+		idleReclaimer: jobframework.NewIdleReclaimer(client, record, opts...),
+	}
 }
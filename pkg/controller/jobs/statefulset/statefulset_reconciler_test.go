@@ -17,17 +17,27 @@ limitations under the License.
 package statefulset
 
 import (
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	testingclock "k8s.io/utils/clock/testing"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/controller/jobs/pod"
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	testingjobspod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
 	statefulsettesting "sigs.k8s.io/kueue/pkg/util/testingjobs/statefulset"
@@ -41,12 +51,31 @@ var (
 )
 
 func TestReconciler(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	podMetrics := func(cpu string) metricsv1beta1.PodMetrics {
+		return metricsv1beta1.PodMetrics{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sts-0",
+				Namespace: "ns",
+				Labels:    map[string]string{"app": "sts-pod"},
+			},
+			Containers: []metricsv1beta1.ContainerMetrics{{
+				Name:  "c",
+				Usage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			}},
+		}
+	}
+
 	cases := map[string]struct {
-		statefulSet     appsv1.StatefulSet
-		pods            []corev1.Pod
-		wantStatefulSet appsv1.StatefulSet
-		wantPods        []corev1.Pod
-		wantErr         error
+		enableIdleReclamation bool
+		statefulSet           appsv1.StatefulSet
+		pods                  []corev1.Pod
+		podMetrics            []metricsv1beta1.PodMetrics
+		workloads             []kueue.Workload
+		wantStatefulSet       appsv1.StatefulSet
+		wantPods              []corev1.Pod
+		wantWorkloads         []kueue.Workload
+		wantErr               error
 	}{
 		"statefulset with finished pods": {
 			statefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
@@ -88,20 +117,175 @@ func TestReconciler(t *testing.T) {
 					Obj(),
 			},
 		},
+		"idle statefulset is marked as idle": {
+			enableIdleReclamation: true,
+			statefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(3).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				DeepCopy(),
+			podMetrics: []metricsv1beta1.PodMetrics{podMetrics("0")},
+			wantStatefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(3).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				Annotation(constants.IdleSinceAnnotation, now.UTC().Format(time.RFC3339)).
+				DeepCopy(),
+		},
+		"busy statefulset is no longer idle": {
+			enableIdleReclamation: true,
+			statefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(3).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				Annotation(constants.IdleSinceAnnotation, now.Add(-time.Hour).UTC().Format(time.RFC3339)).
+				DeepCopy(),
+			podMetrics: []metricsv1beta1.PodMetrics{podMetrics("100m")},
+			wantStatefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(3).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				DeepCopy(),
+		},
+		"idle statefulset is not reclaimed when the feature is disabled": {
+			statefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(3).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				Annotation(constants.IdleSinceAnnotation, now.Add(-time.Hour).UTC().Format(time.RFC3339)).
+				DeepCopy(),
+			podMetrics: []metricsv1beta1.PodMetrics{podMetrics("0")},
+			wantStatefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(3).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				Annotation(constants.IdleSinceAnnotation, now.Add(-time.Hour).UTC().Format(time.RFC3339)).
+				DeepCopy(),
+		},
+		"idle statefulset is scaled down after the idle timeout": {
+			enableIdleReclamation: true,
+			statefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(3).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				Annotation(constants.IdleSinceAnnotation, now.Add(-time.Hour).UTC().Format(time.RFC3339)).
+				DeepCopy(),
+			podMetrics: []metricsv1beta1.PodMetrics{podMetrics("0")},
+			wantStatefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(0).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				Annotation(constants.IdleReclaimedReplicasAnnotation, "3").
+				DeepCopy(),
+		},
+		"reclaimed statefulset is scaled back up when requested": {
+			enableIdleReclamation: true,
+			statefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(0).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				Annotation(constants.IdleReclaimedReplicasAnnotation, "3").
+				Annotation(constants.IdleRestoreAnnotation, "true").
+				DeepCopy(),
+			wantStatefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(3).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				DeepCopy(),
+		},
+		"reclaimed statefulset is scaled back up when no longer configured for the idle reclamation": {
+			enableIdleReclamation: true,
+			statefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(0).
+				Queue("lq").
+				Annotation(constants.IdleReclaimedReplicasAnnotation, "3").
+				DeepCopy(),
+			wantStatefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(3).
+				Queue("lq").
+				DeepCopy(),
+		},
+		"reclaimed statefulset stays scaled down until restored": {
+			enableIdleReclamation: true,
+			statefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(0).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				Annotation(constants.IdleReclaimedReplicasAnnotation, "3").
+				DeepCopy(),
+			wantStatefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(0).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				Annotation(constants.IdleReclaimedReplicasAnnotation, "3").
+				DeepCopy(),
+		},
+		"deactivated statefulset is activated again when requested": {
+			enableIdleReclamation: true,
+			statefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(3).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				Annotation(constants.IdleActionAnnotation, string(jobframework.IdleActionDeactivate)).
+				Annotation(constants.IdleRestoreAnnotation, "true").
+				DeepCopy(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload(GetWorkloadName("sts"), "ns").Active(false).Obj(),
+			},
+			wantStatefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(3).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				Annotation(constants.IdleActionAnnotation, string(jobframework.IdleActionDeactivate)).
+				DeepCopy(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload(GetWorkloadName("sts"), "ns").Active(true).Obj(),
+			},
+		},
+		"idle statefulset is deactivated after the idle timeout": {
+			enableIdleReclamation: true,
+			statefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(3).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				Annotation(constants.IdleActionAnnotation, string(jobframework.IdleActionDeactivate)).
+				Annotation(constants.IdleSinceAnnotation, now.Add(-time.Hour).UTC().Format(time.RFC3339)).
+				DeepCopy(),
+			podMetrics: []metricsv1beta1.PodMetrics{podMetrics("0")},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload(GetWorkloadName("sts"), "ns").Obj(),
+			},
+			wantStatefulSet: *statefulsettesting.MakeStatefulSet("sts", "ns").
+				Replicas(3).
+				Queue("lq").
+				Annotation(constants.IdleTimeoutAnnotation, "10m").
+				Annotation(constants.IdleActionAnnotation, string(jobframework.IdleActionDeactivate)).
+				DeepCopy(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload(GetWorkloadName("sts"), "ns").Active(false).Obj(),
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.IdleServingReclamation, tc.enableIdleReclamation)
 			ctx, _ := utiltesting.ContextWithLog(t)
-			clientBuilder := utiltesting.NewClientBuilder()
+			clientBuilder := utiltesting.NewClientBuilder(metricsv1beta1.AddToScheme)
 
 			objs := []client.Object{&tc.statefulSet}
 			for _, p := range tc.pods {
 				objs = append(objs, p.DeepCopy())
 			}
+			for _, pm := range tc.podMetrics {
+				objs = append(objs, pm.DeepCopy())
+			}
+			for _, wl := range tc.workloads {
+				objs = append(objs, wl.DeepCopy())
+			}
 
 			kClient := clientBuilder.WithObjects(objs...).Build()
 
-			reconciler := NewReconciler(kClient, nil)
+			reconciler := NewReconciler(kClient, record.NewFakeRecorder(10), jobframework.WithClock(t, testingclock.NewFakeClock(now)))
 
 			statefulSetKey := client.ObjectKeyFromObject(&tc.statefulSet)
 			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: statefulSetKey})
@@ -126,6 +310,137 @@ func TestReconciler(t *testing.T) {
 			if diff := cmp.Diff(tc.wantPods, gotPodList.Items, baseCmpOpts...); diff != "" {
 				t.Errorf("Pods after reconcile (-want,+got):\n%s", diff)
 			}
+
+			gotWorkloads := &kueue.WorkloadList{}
+			if err := kClient.List(ctx, gotWorkloads); err != nil {
+				t.Fatalf("Could not get WorkloadList after reconcile: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantWorkloads, gotWorkloads.Items, baseCmpOpts...); diff != "" {
+				t.Errorf("Workloads after reconcile (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileVolumeTopology(t *testing.T) {
+	zoneTerm := func(zone string) corev1.NodeSelectorTerm {
+		return corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{
+			Key:      corev1.LabelTopologyZone,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{zone},
+		}}}
+	}
+	affinity := func(terms ...corev1.NodeSelectorTerm) *corev1.Affinity {
+		return &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms},
+		}}
+	}
+	boundClaim := func(name, volumeName string) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: volumeName},
+		}
+	}
+	volume := func(name string, terms ...corev1.NodeSelectorTerm) corev1.PersistentVolume {
+		return corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.PersistentVolumeSpec{NodeAffinity: &corev1.VolumeNodeAffinity{
+				Required: &corev1.NodeSelector{NodeSelectorTerms: terms},
+			}},
+		}
+	}
+	workloadWithAffinity := func(a *corev1.Affinity) *utiltesting.WorkloadWrapper {
+		ps := utiltesting.MakePodSet("main", 2).Obj()
+		ps.Template.Spec.Affinity = a
+		return utiltesting.MakeWorkload(GetWorkloadName("sts"), "ns").PodSets(*ps)
+	}
+	statefulSet := statefulsettesting.MakeStatefulSet("sts", "ns").
+		Queue("lq").
+		Replicas(2).
+		VolumeClaimTemplate("data")
+
+	cases := map[string]struct {
+		disableFeature bool
+		statefulSet    *appsv1.StatefulSet
+		claims         []corev1.PersistentVolumeClaim
+		volumes        []corev1.PersistentVolume
+		workload       *kueue.Workload
+		wantWorkload   *kueue.Workload
+	}{
+		"the workload is restricted to the zone of the bound volumes": {
+			statefulSet:  statefulSet.DeepCopy(),
+			claims:       []corev1.PersistentVolumeClaim{boundClaim("data-sts-0", "pv-0"), boundClaim("data-sts-1", "pv-1")},
+			volumes:      []corev1.PersistentVolume{volume("pv-0", zoneTerm("zone-a")), volume("pv-1", zoneTerm("zone-a"))},
+			workload:     workloadWithAffinity(nil).Obj(),
+			wantWorkload: workloadWithAffinity(affinity(zoneTerm("zone-a"))).Obj(),
+		},
+		"the volume topology is combined with the node affinity of the pod template": {
+			statefulSet: func() *appsv1.StatefulSet {
+				sts := statefulSet.DeepCopy()
+				sts.Spec.Template.Spec.Affinity = affinity(zoneTerm("zone-a"), zoneTerm("zone-b"))
+				return sts
+			}(),
+			claims:   []corev1.PersistentVolumeClaim{boundClaim("data-sts-0", "pv-0")},
+			volumes:  []corev1.PersistentVolume{volume("pv-0", zoneTerm("zone-b"))},
+			workload: workloadWithAffinity(affinity(zoneTerm("zone-a"), zoneTerm("zone-b"))).Obj(),
+			wantWorkload: workloadWithAffinity(affinity(
+				corev1.NodeSelectorTerm{MatchExpressions: slices.Concat(zoneTerm("zone-a").MatchExpressions, zoneTerm("zone-b").MatchExpressions)},
+				corev1.NodeSelectorTerm{MatchExpressions: slices.Concat(zoneTerm("zone-b").MatchExpressions, zoneTerm("zone-b").MatchExpressions)},
+			)).Obj(),
+		},
+		"the volume topology is removed when no volume is bound": {
+			statefulSet:  statefulSet.DeepCopy(),
+			claims:       []corev1.PersistentVolumeClaim{boundClaim("data-sts-0", "")},
+			workload:     workloadWithAffinity(affinity(zoneTerm("zone-a"))).Obj(),
+			wantWorkload: workloadWithAffinity(&corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{}}).Obj(),
+		},
+		"the workload with quota reserved isn't updated": {
+			statefulSet: statefulSet.DeepCopy(),
+			claims:      []corev1.PersistentVolumeClaim{boundClaim("data-sts-0", "pv-0")},
+			volumes:     []corev1.PersistentVolume{volume("pv-0", zoneTerm("zone-a"))},
+			workload: workloadWithAffinity(nil).
+				ReserveQuota(utiltesting.MakeAdmission("cq", "main").Obj()).
+				Obj(),
+			wantWorkload: workloadWithAffinity(nil).
+				ReserveQuota(utiltesting.MakeAdmission("cq", "main").Obj()).
+				Obj(),
+		},
+		"the workload isn't updated when the feature is disabled": {
+			disableFeature: true,
+			statefulSet:    statefulSet.DeepCopy(),
+			claims:         []corev1.PersistentVolumeClaim{boundClaim("data-sts-0", "pv-0")},
+			volumes:        []corev1.PersistentVolume{volume("pv-0", zoneTerm("zone-a"))},
+			workload:       workloadWithAffinity(nil).Obj(),
+			wantWorkload:   workloadWithAffinity(nil).Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.StatefulSetVolumeTopology, !tc.disableFeature)
+			ctx, _ := utiltesting.ContextWithLog(t)
+
+			objs := []client.Object{tc.statefulSet, tc.workload}
+			for i := range tc.claims {
+				objs = append(objs, &tc.claims[i])
+			}
+			for i := range tc.volumes {
+				objs = append(objs, &tc.volumes[i])
+			}
+			kClient := utiltesting.NewClientBuilder(metricsv1beta1.AddToScheme).WithObjects(objs...).Build()
+			reconciler := NewReconciler(kClient, record.NewFakeRecorder(10))
+
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.statefulSet)}); err != nil {
+				t.Fatalf("Reconcile returned error: %v", err)
+			}
+
+			gotWorkload := &kueue.Workload{}
+			if err := kClient.Get(ctx, client.ObjectKeyFromObject(tc.workload), gotWorkload); err != nil {
+				t.Fatalf("Could not get the workload after reconcile: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantWorkload.Spec, gotWorkload.Spec, baseCmpOpts...); diff != "" {
+				t.Errorf("Workload spec after reconcile (-want,+got):\n%s", diff)
+			}
 		})
 	}
 }
@@ -35,12 +35,16 @@ import (
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/controller/jobs/pod"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/authorization"
+	"sigs.k8s.io/kueue/pkg/util/submitter"
 )
 
 type Webhook struct {
 	client                     client.Client
 	queues                     *queue.Manager
 	manageJobsWithoutQueueName bool
+	localQueueAuthorizer       *authorization.LocalQueueAuthorizer
+	submitterRecorder          *submitter.Recorder
 }
 
 func SetupWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
@@ -49,6 +53,8 @@ func SetupWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 		client:                     mgr.GetClient(),
 		queues:                     options.Queues,
 		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
+		localQueueAuthorizer:       options.LocalQueueAuthorizer,
+		submitterRecorder:          options.SubmitterRecorder,
 	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&appsv1.StatefulSet{}).
@@ -68,6 +74,9 @@ func (wh *Webhook) Default(ctx context.Context, obj runtime.Object) error {
 
 	// Because StatefuleSet is built using a NoOpReconciler handling of jobs without queue names is delegating to the Pod webhook.
 	jobframework.ApplyDefaultLocalQueue(ss.Object(), wh.queues.DefaultLocalQueueExist)
+	if err := wh.submitterRecorder.Record(ctx, ss.Object()); err != nil {
+		return err
+	}
 	queueName := jobframework.QueueNameForObject(ss.Object())
 	if queueName != "" {
 		if ss.Spec.Template.Labels == nil {
@@ -83,6 +92,7 @@ func (wh *Webhook) Default(ctx context.Context, obj runtime.Object) error {
 		ss.Spec.Template.Annotations[pod.GroupFastAdmissionAnnotation] = "true"
 		ss.Spec.Template.Annotations[pod.GroupServingAnnotation] = "true"
 		ss.Spec.Template.Annotations[kueuealpha.PodGroupPodIndexLabelAnnotation] = appsv1.PodIndexLabel
+		ss.Spec.Template.Annotations = submitter.CopyAnnotations(ss.Spec.Template.Annotations, ss.Annotations)
 	}
 
 	return nil
@@ -99,6 +109,9 @@ func (wh *Webhook) ValidateCreate(ctx context.Context, obj runtime.Object) (warn
 	log.V(5).Info("Validating create")
 
 	allErrs := jobframework.ValidateQueueName(sts.Object())
+	allErrs = append(allErrs, validateIdleReclamation(sts)...)
+	allErrs = append(allErrs, jobframework.ValidateLocalQueueAuthorization(ctx, wh.localQueueAuthorizer, sts.Object())...)
+	allErrs = append(allErrs, jobframework.ValidateAllowedPriorityClassForObject(wh.queues, sts.Object(), sts.Spec.Template.Spec.PriorityClassName)...)
 
 	return nil, allErrs.ToAggregate()
 }
@@ -123,6 +136,10 @@ func (wh *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Ob
 	newQueueName := jobframework.QueueNameForObject(newStatefulSet.Object())
 
 	allErrs := apivalidation.ValidateImmutableField(oldQueueName, newQueueName, queueNameLabelPath)
+	allErrs = append(allErrs, validateIdleReclamation(newStatefulSet)...)
+	if oldStatefulSet.Spec.Template.Spec.PriorityClassName != newStatefulSet.Spec.Template.Spec.PriorityClassName {
+		allErrs = append(allErrs, jobframework.ValidateAllowedPriorityClassForObject(wh.queues, newStatefulSet.Object(), newStatefulSet.Spec.Template.Spec.PriorityClassName)...)
+	}
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(
 		newStatefulSet.Spec.Template.GetLabels()[constants.QueueLabel],
 		oldStatefulSet.Spec.Template.GetLabels()[constants.QueueLabel],
@@ -154,6 +171,10 @@ func (wh *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Ob
 	return warnings, allErrs.ToAggregate()
 }
 
+func validateIdleReclamation(sts *StatefulSet) field.ErrorList {
+	return jobframework.ValidateIdleReclamation(sts.Object(), jobframework.IdleActionScaleDown, jobframework.IdleActionDeactivate)
+}
+
 func (wh *Webhook) ValidateDelete(context.Context, runtime.Object) (warnings admission.Warnings, err error) {
 	return nil, nil
 }
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// syncVolumeTopology restricts the required node affinity of the PodSets of
+// the pending workload of the StatefulSet to the nodes which can attach the
+// bound volumes of its replicas, so that the workload is only admitted to the
+// flavors and the topology domains of those nodes.
+func (r *Reconciler) syncVolumeTopology(ctx context.Context, sts *appsv1.StatefulSet) error {
+	if !features.Enabled(features.StatefulSetVolumeTopology) || len(sts.Spec.VolumeClaimTemplates) == 0 || jobframework.QueueNameForObject(sts) == "" {
+		return nil
+	}
+	wl := &kueue.Workload{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: sts.Namespace, Name: GetWorkloadName(sts.Name)}, wl); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	// The PodSets of a workload with quota reserved are immutable.
+	if workload.HasQuotaReservation(wl) || workload.IsFinished(wl) {
+		return nil
+	}
+	volumeTerms, err := r.volumeNodeSelectorTerms(ctx, sts)
+	if err != nil {
+		return err
+	}
+	want := requiredNodeSelector(andNodeSelectorTerms(requiredNodeSelectorTerms(sts.Spec.Template.Spec.Affinity), volumeTerms))
+	updated := false
+	for i := range wl.Spec.PodSets {
+		spec := &wl.Spec.PodSets[i].Template.Spec
+		if equality.Semantic.DeepEqual(requiredNodeSelector(requiredNodeSelectorTerms(spec.Affinity)), want) {
+			continue
+		}
+		if spec.Affinity == nil {
+			spec.Affinity = &corev1.Affinity{}
+		}
+		if spec.Affinity.NodeAffinity == nil {
+			spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+		}
+		spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = want.DeepCopy()
+		updated = true
+	}
+	if !updated {
+		return nil
+	}
+	ctrl.LoggerFrom(ctx).V(3).Info("Updating the node affinity of the workload for the volume topology", "workload", klog.KObj(wl))
+	return client.IgnoreNotFound(r.client.Update(ctx, wl))
+}
+
+// volumeNodeSelectorTerms returns the node selector terms matching the nodes
+// which can attach all the bound persistent volumes of the replicas of the
+// StatefulSet, or nil if the volumes don't restrict the nodes.
+func (r *Reconciler) volumeNodeSelectorTerms(ctx context.Context, sts *appsv1.StatefulSet) ([]corev1.NodeSelectorTerm, error) {
+	var selectors []*corev1.NodeSelector
+	start := int32(0)
+	if sts.Spec.Ordinals != nil {
+		start = sts.Spec.Ordinals.Start
+	}
+	for ordinal := start; ordinal < start+ptr.Deref(sts.Spec.Replicas, 1); ordinal++ {
+		for _, template := range sts.Spec.VolumeClaimTemplates {
+			pvc := &corev1.PersistentVolumeClaim{}
+			pvcKey := types.NamespacedName{Namespace: sts.Namespace, Name: fmt.Sprintf("%s-%s-%d", template.Name, sts.Name, ordinal)}
+			if err := r.client.Get(ctx, pvcKey, pvc); err != nil {
+				if client.IgnoreNotFound(err) != nil {
+					return nil, err
+				}
+				continue
+			}
+			if pvc.Spec.VolumeName == "" {
+				continue
+			}
+			pv := &corev1.PersistentVolume{}
+			if err := r.client.Get(ctx, types.NamespacedName{Name: pvc.Spec.VolumeName}, pv); err != nil {
+				if client.IgnoreNotFound(err) != nil {
+					return nil, err
+				}
+				continue
+			}
+			if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+				continue
+			}
+			if !slices.ContainsFunc(selectors, func(s *corev1.NodeSelector) bool {
+				return equality.Semantic.DeepEqual(s, pv.Spec.NodeAffinity.Required)
+			}) {
+				selectors = append(selectors, pv.Spec.NodeAffinity.Required)
+			}
+		}
+	}
+	var terms []corev1.NodeSelectorTerm
+	for _, s := range selectors {
+		terms = andNodeSelectorTerms(terms, s.NodeSelectorTerms)
+	}
+	return terms, nil
+}
+
+func requiredNodeSelectorTerms(affinity *corev1.Affinity) []corev1.NodeSelectorTerm {
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return nil
+	}
+	return affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+}
+
+func requiredNodeSelector(terms []corev1.NodeSelectorTerm) *corev1.NodeSelector {
+	if len(terms) == 0 {
+		return nil
+	}
+	return &corev1.NodeSelector{NodeSelectorTerms: terms}
+}
+
+// andNodeSelectorTerms returns the node selector terms matching the nodes
+// matched by both lists of ORed terms. An empty list matches all the nodes.
+func andNodeSelectorTerms(a, b []corev1.NodeSelectorTerm) []corev1.NodeSelectorTerm {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 {
+		return a
+	}
+	result := make([]corev1.NodeSelectorTerm, 0, len(a)*len(b))
+	for _, ta := range a {
+		for _, tb := range b {
+			result = append(result, corev1.NodeSelectorTerm{
+				MatchExpressions: slices.Concat(ta.MatchExpressions, tb.MatchExpressions),
+				MatchFields:      slices.Concat(ta.MatchFields, tb.MatchFields),
+			})
+		}
+	}
+	return result
+}
+
+// statefulSetForWorkload maps the workloads of the pod groups to the reconcile
+// requests of the StatefulSets owning their pods.
+func (r *Reconciler) statefulSetForWorkload(ctx context.Context, o client.Object) []reconcile.Request {
+	if !features.Enabled(features.StatefulSetVolumeTopology) {
+		return nil
+	}
+	idx := slices.IndexFunc(o.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+		return ref.Kind == "Pod" && ref.APIVersion == corev1.SchemeGroupVersion.String()
+	})
+	if idx == -1 {
+		return nil
+	}
+	p := &corev1.Pod{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetOwnerReferences()[idx].Name}, p); err != nil {
+		return nil
+	}
+	owner := metav1.GetControllerOf(p)
+	if owner == nil || owner.Kind != gvk.Kind || owner.APIVersion != gvk.GroupVersion().String() {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: p.Namespace, Name: owner.Name}}}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karpenter
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+)
+
+var (
+	// NodePoolGVK is the kind of the Karpenter NodePools.
+	NodePoolGVK = schema.GroupVersionKind{Group: "karpenter.sh", Version: "v1", Kind: "NodePool"}
+)
+
+// nodePoolCapacity holds the fields of the NodePools read by Kueue.
+type nodePoolCapacity struct {
+	Spec struct {
+		Limits corev1.ResourceList `json:"limits,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		Resources corev1.ResourceList `json:"resources,omitempty"`
+	} `json:"status"`
+}
+
+func newNodePool() *unstructured.Unstructured {
+	np := &unstructured.Unstructured{}
+	np.SetGroupVersionKind(NodePoolGVK)
+	return np
+}
+
+// ServerSupportsNodePools returns whether the NodePool API of Karpenter is
+// installed in the cluster.
+func ServerSupportsNodePools(mgr manager.Manager) bool {
+	_, err := mgr.GetRESTMapper().RESTMapping(NodePoolGVK.GroupKind(), NodePoolGVK.Version)
+	return err == nil
+}
+
+// NodePoolReconciler synchronizes the limits and the provisioned capacity of
+// the Karpenter NodePools in cache.Cache, where they cap the usage of the
+// ResourceFlavors of the NodePools.
+type NodePoolReconciler struct {
+	client   client.Client
+	cache    *cache.Cache
+	qManager *queue.Manager
+}
+
+func NewNodePoolReconciler(client client.Client, cache *cache.Cache, qManager *queue.Manager) *NodePoolReconciler {
+	return &NodePoolReconciler{
+		client:   client,
+		cache:    cache,
+		qManager: qManager,
+	}
+}
+
+// +kubebuilder:rbac:groups=karpenter.sh,resources=nodepools,verbs=get;list;watch
+
+func (r *NodePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	np := newNodePool()
+	if err := r.client.Get(ctx, req.NamespacedName, np); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		if r.cache.DeleteNodePool(req.Name) {
+			log.V(2).Info("NodePool deleted")
+			r.queueInadmissibleWorkloads(ctx)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	capacity := nodePoolCapacity{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(np.Object, &capacity); err != nil {
+		log.Error(err, "Invalid NodePool limits or resources")
+		return ctrl.Result{}, nil
+	}
+	if r.cache.AddOrUpdateNodePool(req.Name, capacity.Spec.Limits, capacity.Status.Resources) {
+		log.V(2).Info("NodePool capacity updated", "limits", capacity.Spec.Limits, "resources", capacity.Status.Resources)
+		// The workloads could fit in the new limits, or in the usage released
+		// by the workloads of other cohorts, whose nodes left the NodePool.
+		r.queueInadmissibleWorkloads(ctx)
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *NodePoolReconciler) queueInadmissibleWorkloads(ctx context.Context) {
+	r.qManager.QueueInadmissibleWorkloads(ctx, sets.New(r.qManager.GetClusterQueueNames()...))
+}
+
+func (r *NodePoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("karpenter-nodepool").
+		For(newNodePool()).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(r)
+}
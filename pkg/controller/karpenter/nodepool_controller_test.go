@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package karpenter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func makeNodePool(name string, limits, provisioned map[string]any) *unstructured.Unstructured {
+	np := newNodePool()
+	np.SetName(name)
+	if limits != nil {
+		_ = unstructured.SetNestedMap(np.Object, limits, "spec", "limits")
+	}
+	if provisioned != nil {
+		_ = unstructured.SetNestedMap(np.Object, provisioned, "status", "resources")
+	}
+	return np
+}
+
+func TestNodePoolReconcile(t *testing.T) {
+	cases := map[string]struct {
+		nodePool *unstructured.Unstructured
+		wantCaps map[kueue.ResourceFlavorReference]*cache.FlavorCapSnapshot
+	}{
+		"nodepool with limits": {
+			nodePool: makeNodePool("general",
+				map[string]any{"cpu": int64(100), "memory": "1000Gi"},
+				map[string]any{"cpu": "12", "memory": "48Gi"},
+			),
+			wantCaps: map[kueue.ResourceFlavorReference]*cache.FlavorCapSnapshot{
+				"karpenter": {
+					NodePool: "general",
+					Limits: resources.NewRequests(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("100"),
+						corev1.ResourceMemory: resource.MustParse("1000Gi"),
+					}),
+					Provisioned: resources.NewRequests(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("12"),
+						corev1.ResourceMemory: resource.MustParse("48Gi"),
+					}),
+					Usage: resources.Requests{},
+				},
+			},
+		},
+		"nodepool without limits": {
+			nodePool: makeNodePool("general", nil, map[string]any{"cpu": "12"}),
+		},
+		"deleted nodepool": {},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mapper := meta.NewDefaultRESTMapper(nil)
+			mapper.Add(NodePoolGVK, meta.RESTScopeRoot)
+			builder := utiltesting.NewClientBuilder().WithRESTMapper(mapper)
+			if tc.nodePool != nil {
+				builder = builder.WithObjects(tc.nodePool)
+			}
+			cl := builder.Build()
+			ctx := context.Background()
+			cqCache := cache.New(cl)
+			qManager := queue.NewManager(cl, cqCache)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("karpenter").
+				NodeLabel(constants.KarpenterNodePoolLabel, "general").
+				Obj())
+			// The NodePool is known from a previous reconciliation.
+			cqCache.AddOrUpdateNodePool("general", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}, nil)
+
+			reconciler := NewNodePoolReconciler(cl, cqCache, qManager)
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "general"}})
+			if err != nil {
+				t.Fatalf("Reconcile returned error: %v", err)
+			}
+
+			snapshot, err := cqCache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error taking the snapshot: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantCaps, snapshot.FlavorCaps); diff != "" {
+				t.Errorf("Unexpected flavor caps (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
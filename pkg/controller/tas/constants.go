@@ -19,4 +19,5 @@ package tas
 const (
 	TASResourceFlavorController = "tas-resource-flavor-controller"
 	TASTopologyUngater          = "tas-topology-ungater"
+	TASNodeFailureController    = "tas-node-failure-controller"
 )
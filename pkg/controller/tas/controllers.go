@@ -21,6 +21,7 @@ import (
 
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 )
 
@@ -34,5 +35,11 @@ func SetupControllers(mgr ctrl.Manager, queues *queue.Manager, cache *cache.Cach
 	if ctrlName, err := topologyUngater.setupWithManager(mgr, cfg); err != nil {
 		return ctrlName, err
 	}
+	if features.Enabled(features.TASFailedNodeReplacement) {
+		nodeFailureRec := newNodeFailureReconciler(mgr.GetClient(), cache, mgr.GetEventRecorderFor(TASNodeFailureController))
+		if ctrlName, err := nodeFailureRec.setupWithManager(mgr, cfg); err != nil {
+			return ctrlName, err
+		}
+	}
 	return "", nil
 }
@@ -0,0 +1,330 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tas
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/core"
+	"sigs.k8s.io/kueue/pkg/controller/tas/indexer"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// nodeFailureReconciler replaces the topology domains without Ready nodes in
+// the assignments of the workloads admitted by TAS, so that their pods
+// recreated by the job controllers aren't ungated to the failed domains. If
+// no replacement is found, the workload is evicted with the NodeFailure
+// reason, and requeued.
+type nodeFailureReconciler struct {
+	client   client.Client
+	tasCache *cache.TASCache
+	recorder record.EventRecorder
+}
+
+var _ reconcile.Reconciler = (*nodeFailureReconciler)(nil)
+var _ predicate.Predicate = (*nodeFailureReconciler)(nil)
+
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
+
+func newNodeFailureReconciler(c client.Client, cache *cache.Cache, recorder record.EventRecorder) *nodeFailureReconciler {
+	return &nodeFailureReconciler{
+		client:   c,
+		tasCache: cache.TASCache(),
+		recorder: recorder,
+	}
+}
+
+func (r *nodeFailureReconciler) setupWithManager(mgr ctrl.Manager, cfg *configapi.Configuration) (string, error) {
+	return TASNodeFailureController, ctrl.NewControllerManagedBy(mgr).
+		Named(TASNodeFailureController).
+		For(&kueue.Workload{}).
+		Watches(&corev1.Node{}, &failedNodeHandler{client: r.client}).
+		WithEventFilter(r).
+		Complete(core.WithLeadingManager(mgr, r, &kueue.Workload{}, cfg))
+}
+
+var _ handler.EventHandler = (*failedNodeHandler)(nil)
+
+// failedNodeHandler queues the workloads admitted by TAS to the domains of
+// the nodes which are deleted or become not Ready.
+type failedNodeHandler struct {
+	client client.Client
+}
+
+func (h *failedNodeHandler) Create(context.Context, event.CreateEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *failedNodeHandler) Update(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldNode, isOldNode := e.ObjectOld.(*corev1.Node)
+	newNode, isNewNode := e.ObjectNew.(*corev1.Node)
+	if !isOldNode || !isNewNode || !isNodeReady(oldNode) || isNodeReady(newNode) {
+		return
+	}
+	h.queueReconcileForNode(ctx, newNode, q)
+}
+
+func (h *failedNodeHandler) Delete(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if node, isNode := e.Object.(*corev1.Node); isNode {
+		h.queueReconcileForNode(ctx, node, q)
+	}
+}
+
+func (h *failedNodeHandler) Generic(context.Context, event.GenericEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (h *failedNodeHandler) queueReconcileForNode(ctx context.Context, node *corev1.Node, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	workloads := &kueue.WorkloadList{}
+	if err := h.client.List(ctx, workloads); err != nil {
+		log := ctrl.LoggerFrom(ctx).WithValues("node", klog.KObj(node))
+		log.Error(err, "Could not list workloads")
+		return
+	}
+	for i := range workloads.Items {
+		wl := &workloads.Items[i]
+		if isAdmittedByTAS(wl) && slices.ContainsFunc(wl.Status.Admission.PodSetAssignments, func(psa kueue.PodSetAssignment) bool {
+			return psa.TopologyAssignment != nil && assignmentHasNodeDomain(psa.TopologyAssignment, node)
+		}) {
+			q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: wl.Name, Namespace: wl.Namespace}})
+		}
+	}
+}
+
+func (r *nodeFailureReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("workload", req.NamespacedName.String())
+	log.V(2).Info("Reconcile TAS node failures")
+
+	wl := &kueue.Workload{}
+	if err := r.client.Get(ctx, req.NamespacedName, wl); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	if !isAdmittedByTAS(wl) || workload.IsFinished(wl) || apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) {
+		return reconcile.Result{}, nil
+	}
+
+	var repaired []string
+	for i := range wl.Status.Admission.PodSetAssignments {
+		psa := &wl.Status.Admission.PodSetAssignments[i]
+		if psa.TopologyAssignment == nil {
+			continue
+		}
+		domains := utiltas.TopologyDomains(psa.TopologyAssignment)
+		failed, err := r.failedDomains(ctx, psa.TopologyAssignment.Levels, domains)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(failed) == 0 {
+			continue
+		}
+		replacements, reason, err := r.findReplacementDomains(ctx, wl, psa, domains, failed)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if replacements == nil {
+			message := fmt.Sprintf("The topology domains %v of the PodSet %s have no Ready nodes, and no replacement was found: %s",
+				domainValues(domains, failed), psa.Name, reason)
+			return reconcile.Result{}, r.evict(ctx, wl, message)
+		}
+		assignment := &kueue.TopologyAssignment{
+			Levels:  psa.TopologyAssignment.Levels,
+			Domains: replaceDomains(domains, failed, replacements),
+		}
+		if len(psa.TopologyAssignment.DomainGroups) > 0 {
+			assignment = utiltas.CompactTopologyAssignment(assignment)
+		}
+		log.V(2).Info("Replacing the failed topology domains", "podSet", psa.Name, "failedDomains", domainValues(domains, failed), "replacements", replacements)
+		psa.TopologyAssignment = assignment
+		repaired = append(repaired, psa.Name)
+	}
+	if len(repaired) == 0 {
+		return reconcile.Result{}, nil
+	}
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	r.recorder.Eventf(wl, corev1.EventTypeNormal, "TopologyAssignmentRepaired",
+		"Replaced the topology domains without Ready nodes of the PodSets %v", repaired)
+	return reconcile.Result{}, nil
+}
+
+// failedDomains returns the indexes of the domains of the assignment without
+// Ready nodes.
+func (r *nodeFailureReconciler) failedDomains(ctx context.Context, levels []string, domains []kueue.TopologyDomainAssignment) ([]int, error) {
+	var failed []int
+	for i := range domains {
+		nodes := &corev1.NodeList{}
+		if err := r.client.List(ctx, nodes, client.MatchingLabels(utiltas.NodeLabelsFromKeysAndValues(levels, domains[i].Values)),
+			client.MatchingFields{indexer.ReadyNode: "true"}); err != nil {
+			return nil, err
+		}
+		if len(nodes.Items) == 0 {
+			failed = append(failed, i)
+		}
+	}
+	return failed, nil
+}
+
+// findReplacementDomains finds the domains for the pods of the failed domains
+// of the PodSet assignment, in the snapshot of its TAS flavor. Returns nil and
+// the reason if they don't fit.
+func (r *nodeFailureReconciler) findReplacementDomains(ctx context.Context, wl *kueue.Workload, psa *kueue.PodSetAssignment,
+	domains []kueue.TopologyDomainAssignment, failed []int) ([]kueue.TopologyDomainAssignment, string, error) {
+	var tasFlavor *cache.TASFlavorCache
+	for _, flavor := range psa.Flavors {
+		if tasFlavor = r.tasCache.Get(flavor); tasFlavor != nil {
+			break
+		}
+	}
+	psIdx := slices.IndexFunc(wl.Spec.PodSets, func(ps kueue.PodSet) bool { return ps.Name == psa.Name })
+	if tasFlavor == nil || psIdx < 0 || wl.Spec.PodSets[psIdx].TopologyRequest == nil {
+		return nil, "no TAS information for the PodSet", nil
+	}
+	podSet := &wl.Spec.PodSets[psIdx]
+	snapshot, err := tasFlavor.Snapshot(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	podCount := podSet.Count
+	if psa.Count != nil {
+		podCount = *psa.Count
+	}
+	singlePodRequests := resources.NewRequests(psa.ResourceUsage)
+	singlePodRequests.Divide(int64(podCount))
+
+	remaining := make([]kueue.TopologyDomainAssignment, 0, len(domains)-len(failed))
+	failedCount := int32(0)
+	for i := range domains {
+		if slices.Contains(failed, i) {
+			failedCount += domains[i].Count
+		} else {
+			remaining = append(remaining, domains[i])
+		}
+	}
+	replacements, reason := snapshot.FindReplacementDomains(podSet.TopologyRequest, psa.TopologyAssignment.Levels,
+		remaining, singlePodRequests, failedCount, &podSet.Template.Spec)
+	return replacements, reason, nil
+}
+
+func (r *nodeFailureReconciler) evict(ctx context.Context, wl *kueue.Workload, message string) error {
+	workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByNodeFailure, kueue.EvictionCategoryNodeFailure, message)
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	ctrl.LoggerFrom(ctx).V(2).Info("Evicted workload due to the node failure", "workload", klog.KObj(wl), "message", message)
+	workload.ReportEvictedWorkload(r.recorder, wl, string(wl.Status.Admission.ClusterQueue), kueue.WorkloadEvictedByNodeFailure, message)
+	return nil
+}
+
+func (r *nodeFailureReconciler) Create(event event.CreateEvent) bool {
+	wl, isWl := event.Object.(*kueue.Workload)
+	if isWl {
+		return isAdmittedByTAS(wl)
+	}
+	return true
+}
+
+func (r *nodeFailureReconciler) Delete(event event.DeleteEvent) bool {
+	return false
+}
+
+func (r *nodeFailureReconciler) Update(event event.UpdateEvent) bool {
+	wl, isWl := event.ObjectNew.(*kueue.Workload)
+	if isWl {
+		return isAdmittedByTAS(wl)
+	}
+	return true
+}
+
+func (r *nodeFailureReconciler) Generic(event event.GenericEvent) bool {
+	return false
+}
+
+// replaceDomains returns the domains of the assignment with the failed
+// domains replaced, at their positions, by the replacement domains. The
+// domains with the same values are merged.
+func replaceDomains(domains []kueue.TopologyDomainAssignment, failed []int, replacements []kueue.TopologyDomainAssignment) []kueue.TopologyDomainAssignment {
+	result := make([]kueue.TopologyDomainAssignment, 0, len(domains)+len(replacements))
+	positions := make(map[utiltas.TopologyDomainID]int)
+	add := func(values []string, count int32) {
+		domainID := utiltas.DomainID(values)
+		if pos, found := positions[domainID]; found {
+			result[pos].Count += count
+			return
+		}
+		positions[domainID] = len(result)
+		result = append(result, kueue.TopologyDomainAssignment{Values: slices.Clone(values), Count: count})
+	}
+	pending := slices.Clone(replacements)
+	for i, d := range domains {
+		if !slices.Contains(failed, i) {
+			add(d.Values, d.Count)
+			continue
+		}
+		for need := d.Count; need > 0 && len(pending) > 0; {
+			take := min(need, pending[0].Count)
+			add(pending[0].Values, take)
+			need -= take
+			if pending[0].Count -= take; pending[0].Count == 0 {
+				pending = pending[1:]
+			}
+		}
+	}
+	return result
+}
+
+func domainValues(domains []kueue.TopologyDomainAssignment, idxs []int) [][]string {
+	result := make([][]string, len(idxs))
+	for i, idx := range idxs {
+		result[i] = domains[idx].Values
+	}
+	return result
+}
+
+// assignmentHasNodeDomain returns whether the node is in one of the domains of
+// the assignment.
+func assignmentHasNodeDomain(ta *kueue.TopologyAssignment, node *corev1.Node) bool {
+	values := utiltas.LevelValues(ta.Levels, node.Labels)
+	return slices.ContainsFunc(utiltas.TopologyDomains(ta), func(d kueue.TopologyDomainAssignment) bool {
+		return slices.Equal(d.Values, values)
+	})
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	return utiltas.IsNodeStatusConditionTrue(node.Status.Conditions, corev1.NodeReady)
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tas
+
+import (
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/tas/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingnode "sigs.k8s.io/kueue/pkg/util/testingjobs/node"
+)
+
+func TestNodeFailureReconcile(t *testing.T) {
+	makeNode := func(name, block string, cpu string) *testingnode.NodeWrapper {
+		return testingnode.MakeNode(name).
+			Label(tasBlockLabel, block).
+			Label(corev1.LabelHostname, name).
+			StatusAllocatable(corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)})
+	}
+	hostnameAssignment := func(domains ...kueue.TopologyDomainAssignment) *kueue.TopologyAssignment {
+		return &kueue.TopologyAssignment{
+			Levels:  []string{corev1.LabelHostname},
+			Domains: domains,
+		}
+	}
+	domain := func(hostname string, count int32) kueue.TopologyDomainAssignment {
+		return kueue.TopologyDomainAssignment{Values: []string{hostname}, Count: count}
+	}
+
+	cases := map[string]struct {
+		nodes          []corev1.Node
+		podSet         *kueue.PodSet
+		assignment     *kueue.TopologyAssignment
+		wantAssignment *kueue.TopologyAssignment
+		wantEvicted    bool
+	}{
+		"no failed domain": {
+			nodes: []corev1.Node{
+				*makeNode("x1", "b1", "1").Ready().Obj(),
+				*makeNode("x2", "b1", "1").Ready().Obj(),
+				*makeNode("x3", "b1", "1").Ready().Obj(),
+			},
+			podSet:         utiltesting.MakePodSet("main", 2).Request(corev1.ResourceCPU, "1").RequiredTopologyRequest(tasBlockLabel).Obj(),
+			assignment:     hostnameAssignment(domain("x1", 1), domain("x2", 1)),
+			wantAssignment: hostnameAssignment(domain("x1", 1), domain("x2", 1)),
+		},
+		"failed domain replaced in the same block": {
+			nodes: []corev1.Node{
+				*makeNode("x1", "b1", "1").Ready().Obj(),
+				*makeNode("x2", "b1", "1").NotReady().Obj(),
+				*makeNode("x3", "b1", "1").Ready().Obj(),
+				*makeNode("x4", "b2", "2").Ready().Obj(),
+			},
+			podSet:         utiltesting.MakePodSet("main", 2).Request(corev1.ResourceCPU, "1").RequiredTopologyRequest(tasBlockLabel).Obj(),
+			assignment:     hostnameAssignment(domain("x1", 1), domain("x2", 1)),
+			wantAssignment: hostnameAssignment(domain("x1", 1), domain("x3", 1)),
+		},
+		"deleted node replaced in the same block": {
+			nodes: []corev1.Node{
+				*makeNode("x1", "b1", "1").Ready().Obj(),
+				*makeNode("x3", "b1", "1").Ready().Obj(),
+			},
+			podSet:         utiltesting.MakePodSet("main", 2).Request(corev1.ResourceCPU, "1").RequiredTopologyRequest(tasBlockLabel).Obj(),
+			assignment:     hostnameAssignment(domain("x2", 1), domain("x1", 1)),
+			wantAssignment: hostnameAssignment(domain("x3", 1), domain("x1", 1)),
+		},
+		"evicted when the required block lacks the capacity": {
+			nodes: []corev1.Node{
+				*makeNode("x1", "b1", "1").Ready().Obj(),
+				*makeNode("x2", "b1", "1").NotReady().Obj(),
+				*makeNode("x4", "b2", "2").Ready().Obj(),
+			},
+			podSet:         utiltesting.MakePodSet("main", 2).Request(corev1.ResourceCPU, "1").RequiredTopologyRequest(tasBlockLabel).Obj(),
+			assignment:     hostnameAssignment(domain("x1", 1), domain("x2", 1)),
+			wantAssignment: hostnameAssignment(domain("x1", 1), domain("x2", 1)),
+			wantEvicted:    true,
+		},
+		"failed domain replaced in another block when the block is preferred": {
+			nodes: []corev1.Node{
+				*makeNode("x1", "b1", "1").Ready().Obj(),
+				*makeNode("x2", "b1", "1").NotReady().Obj(),
+				*makeNode("x4", "b2", "2").Ready().Obj(),
+			},
+			podSet:         utiltesting.MakePodSet("main", 2).Request(corev1.ResourceCPU, "1").PreferredTopologyRequest(tasBlockLabel).Obj(),
+			assignment:     hostnameAssignment(domain("x1", 1), domain("x2", 1)),
+			wantAssignment: hostnameAssignment(domain("x1", 1), domain("x4", 1)),
+		},
+		"all the domains failed": {
+			nodes: []corev1.Node{
+				*makeNode("x1", "b1", "1").NotReady().Obj(),
+				*makeNode("x2", "b1", "1").NotReady().Obj(),
+				*makeNode("x4", "b2", "2").Ready().Obj(),
+			},
+			podSet:         utiltesting.MakePodSet("main", 2).Request(corev1.ResourceCPU, "1").RequiredTopologyRequest(tasBlockLabel).Obj(),
+			assignment:     hostnameAssignment(domain("x1", 1), domain("x2", 1)),
+			wantAssignment: hostnameAssignment(domain("x4", 2)),
+		},
+		"compact assignment": {
+			nodes: []corev1.Node{
+				*makeNode("x1", "b1", "1").Ready().Obj(),
+				*makeNode("x2", "b1", "1").NotReady().Obj(),
+				*makeNode("x3", "b1", "1").Ready().Obj(),
+			},
+			podSet: utiltesting.MakePodSet("main", 2).Request(corev1.ResourceCPU, "1").RequiredTopologyRequest(tasBlockLabel).Obj(),
+			assignment: &kueue.TopologyAssignment{
+				Levels: []string{corev1.LabelHostname},
+				DomainGroups: []kueue.TopologyDomainGroupAssignment{{
+					LowestLevelPrefix: "x",
+					LowestLevelValues: []string{"1", "2"},
+					Counts:            []int32{1},
+				}},
+			},
+			wantAssignment: &kueue.TopologyAssignment{
+				Levels: []string{corev1.LabelHostname},
+				DomainGroups: []kueue.TopologyDomainGroupAssignment{{
+					LowestLevelPrefix: "x",
+					LowestLevelValues: []string{"1", "3"},
+					Counts:            []int32{1},
+				}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.TopologyAwareScheduling, true)
+			features.SetFeatureGateDuringTest(t, features.TASFailedNodeReplacement, true)
+			ctx, _ := utiltesting.ContextWithLog(t)
+
+			wl := utiltesting.MakeWorkload("wl", "ns").
+				PodSets(*tc.podSet).
+				ReserveQuota(utiltesting.MakeAdmission("cq").PodSets(kueue.PodSetAssignment{
+					Name:               "main",
+					Flavors:            map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "tas-flavor"},
+					ResourceUsage:      corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+					Count:              ptr.To[int32](2),
+					TopologyAssignment: tc.assignment,
+				}).Obj()).
+				Admitted(true).
+				Obj()
+			clientBuilder := utiltesting.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{SubResourcePatch: utiltesting.TreatSSAAsStrategicMerge}).
+				WithObjects(wl).
+				WithStatusSubresource(wl)
+			for i := range tc.nodes {
+				clientBuilder = clientBuilder.WithObjects(&tc.nodes[i])
+			}
+			if err := indexer.SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+				t.Fatalf("Could not setup indexes: %v", err)
+			}
+			cl := clientBuilder.Build()
+
+			cqCache := cache.New(cl)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("tas-flavor").TopologyName("default").Obj())
+			tasCache := cqCache.TASCache()
+			tasCache.Set("tas-flavor", tasCache.NewTASFlavorCache("default", []string{tasBlockLabel, corev1.LabelHostname}, nil, nil))
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("tas-flavor").Resource(corev1.ResourceCPU, "10").Obj()).
+				Obj()
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Could not add the ClusterQueue: %v", err)
+			}
+			cqCache.AddOrUpdateWorkload(wl)
+
+			recorder := record.NewFakeRecorder(10)
+			reconciler := newNodeFailureReconciler(cl, cqCache, recorder)
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(wl)}); err != nil {
+				t.Fatalf("Reconcile returned error: %v", err)
+			}
+
+			gotWorkload := &kueue.Workload{}
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(wl), gotWorkload); err != nil {
+				t.Fatalf("Could not get the workload: %v", err)
+			}
+			if diff := gocmp.Diff(tc.wantAssignment, gotWorkload.Status.Admission.PodSetAssignments[0].TopologyAssignment); diff != "" {
+				t.Errorf("Unexpected topology assignment (-want,+got):\n%s", diff)
+			}
+			gotEvicted := apimeta.IsStatusConditionTrue(gotWorkload.Status.Conditions, kueue.WorkloadEvicted)
+			if gotEvicted != tc.wantEvicted {
+				t.Errorf("Unexpected eviction, want=%v, got=%v", tc.wantEvicted, gotEvicted)
+			}
+			if tc.wantEvicted {
+				if diff := gocmp.Diff(&kueue.WorkloadEviction{
+					Reason:   kueue.WorkloadEvictedByNodeFailure,
+					Category: kueue.EvictionCategoryNodeFailure,
+				}, gotWorkload.Status.Eviction); diff != "" {
+					t.Errorf("Unexpected eviction status (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestReplaceDomains(t *testing.T) {
+	domain := func(hostname string, count int32) kueue.TopologyDomainAssignment {
+		return kueue.TopologyDomainAssignment{Values: []string{hostname}, Count: count}
+	}
+	cases := map[string]struct {
+		domains      []kueue.TopologyDomainAssignment
+		failed       []int
+		replacements []kueue.TopologyDomainAssignment
+		want         []kueue.TopologyDomainAssignment
+	}{
+		"replaced at the position of the failed domain": {
+			domains:      []kueue.TopologyDomainAssignment{domain("x1", 2), domain("x2", 2), domain("x3", 2)},
+			failed:       []int{1},
+			replacements: []kueue.TopologyDomainAssignment{domain("x4", 1), domain("x5", 1)},
+			want:         []kueue.TopologyDomainAssignment{domain("x1", 2), domain("x4", 1), domain("x5", 1), domain("x3", 2)},
+		},
+		"split across the failed domains": {
+			domains:      []kueue.TopologyDomainAssignment{domain("x1", 2), domain("x2", 2)},
+			failed:       []int{0, 1},
+			replacements: []kueue.TopologyDomainAssignment{domain("x3", 3), domain("x4", 1)},
+			want:         []kueue.TopologyDomainAssignment{domain("x3", 3), domain("x4", 1)},
+		},
+		"merged with a remaining domain": {
+			domains:      []kueue.TopologyDomainAssignment{domain("x1", 2), domain("x2", 2)},
+			failed:       []int{1},
+			replacements: []kueue.TopologyDomainAssignment{domain("x1", 1), domain("x3", 1)},
+			want:         []kueue.TopologyDomainAssignment{domain("x1", 3), domain("x3", 1)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := replaceDomains(tc.domains, tc.failed, tc.replacements)
+			if diff := gocmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected domains (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
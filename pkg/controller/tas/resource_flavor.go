@@ -40,6 +40,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/core"
 	"sigs.k8s.io/kueue/pkg/controller/tas/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
 )
@@ -77,11 +78,15 @@ func (r *rfReconciler) setupWithManager(mgr ctrl.Manager, cache *cache.Cache, cf
 	nodeHandler := nodeHandler{
 		tasCache: cache.TASCache(),
 	}
-	return TASResourceFlavorController, ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		Named(TASResourceFlavorController).
 		For(&kueue.ResourceFlavor{}).
 		Watches(&corev1.Node{}, &nodeHandler).
-		Watches(&kueuealpha.Topology{}, &topologyHandler{client: r.client, tasCache: r.tasCache}).
+		Watches(&kueuealpha.Topology{}, &topologyHandler{client: r.client, tasCache: r.tasCache})
+	if features.Enabled(features.TASIncrementalSnapshot) {
+		b = b.Watches(&corev1.Pod{}, &nonTASPodHandler{tasCache: r.tasCache})
+	}
+	return TASResourceFlavorController, b.
 		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
 		WithEventFilter(r).
 		Complete(core.WithLeadingManager(mgr, r, &kueue.ResourceFlavor{}, cfg))
@@ -99,6 +104,9 @@ func (h *nodeHandler) Create(_ context.Context, e event.CreateEvent, q workqueue
 	if !isNode {
 		return
 	}
+	if features.Enabled(features.TASIncrementalSnapshot) {
+		h.tasCache.SyncNode(node)
+	}
 	h.queueReconcileForNode(node, q)
 }
 
@@ -108,6 +116,9 @@ func (h *nodeHandler) Update(ctx context.Context, e event.UpdateEvent, q workque
 	if !isOldNode || !isNewNode {
 		return
 	}
+	if features.Enabled(features.TASIncrementalSnapshot) {
+		h.tasCache.SyncNode(newNode)
+	}
 	h.queueReconcileForNode(oldNode, q)
 	h.queueReconcileForNode(newNode, q)
 }
@@ -117,6 +128,9 @@ func (h *nodeHandler) Delete(_ context.Context, e event.DeleteEvent, q workqueue
 	if !isNode {
 		return
 	}
+	if features.Enabled(features.TASIncrementalSnapshot) {
+		h.tasCache.DeleteNode(node.Name)
+	}
 	h.queueReconcileForNode(node, q)
 }
 
@@ -137,6 +151,35 @@ func (h *nodeHandler) queueReconcileForNode(node *corev1.Node, q workqueue.Typed
 func (h *nodeHandler) Generic(context.Context, event.GenericEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
 }
 
+var _ handler.EventHandler = (*nonTASPodHandler)(nil)
+
+// nonTASPodHandler maintains the usage of the non-TAS pods in the TAS cache.
+// It doesn't trigger reconciles, as the nodes of the pods don't change.
+type nonTASPodHandler struct {
+	tasCache *cache.TASCache
+}
+
+func (h *nonTASPodHandler) Create(_ context.Context, e event.CreateEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if pod, isPod := e.Object.(*corev1.Pod); isPod {
+		h.tasCache.SyncPod(pod)
+	}
+}
+
+func (h *nonTASPodHandler) Update(_ context.Context, e event.UpdateEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if pod, isPod := e.ObjectNew.(*corev1.Pod); isPod {
+		h.tasCache.SyncPod(pod)
+	}
+}
+
+func (h *nonTASPodHandler) Delete(_ context.Context, e event.DeleteEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if pod, isPod := e.Object.(*corev1.Pod); isPod {
+		h.tasCache.DeletePod(client.ObjectKeyFromObject(pod))
+	}
+}
+
+func (h *nonTASPodHandler) Generic(context.Context, event.GenericEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
 var _ handler.EventHandler = (*topologyHandler)(nil)
 
 // topologyHandler handles node update events.
@@ -278,7 +278,7 @@ func podsToUngateInfo(
 	psa *kueue.PodSetAssignment,
 	podToUngateWithDomain []podWithDomain) []podWithUngateInfo {
 	domainIDToLabelValues := make(map[utiltas.TopologyDomainID][]string)
-	for _, psaDomain := range psa.TopologyAssignment.Domains {
+	for _, psaDomain := range utiltas.TopologyDomains(psa.TopologyAssignment) {
 		domainID := utiltas.DomainID(psaDomain.Values)
 		domainIDToLabelValues[domainID] = psaDomain.Values
 	}
@@ -309,13 +309,14 @@ func assignGatedPodsToDomainsByRanks(
 	psa *kueue.PodSetAssignment,
 	rankToGatedPod map[int]*corev1.Pod) []podWithDomain {
 	toUngate := make([]podWithDomain, 0)
+	domains := utiltas.TopologyDomains(psa.TopologyAssignment)
 	totalCount := 0
-	for i := range psa.TopologyAssignment.Domains {
-		totalCount += int(psa.TopologyAssignment.Domains[i].Count)
+	for i := range domains {
+		totalCount += int(domains[i].Count)
 	}
 	rankToDomainID := make([]utiltas.TopologyDomainID, totalCount)
 	index := int32(0)
-	for _, domain := range psa.TopologyAssignment.Domains {
+	for _, domain := range domains {
 		for s := range domain.Count {
 			rankToDomainID[index+s] = utiltas.DomainID(domain.Values)
 		}
@@ -352,7 +353,7 @@ func assignGatedPodsToDomainsGreedy(
 		"domainIDToUngatedCount", domainIDToUngatedCnt,
 		"levelKeys", levelKeys)
 	toUngate := make([]podWithDomain, 0)
-	for _, psaDomain := range psa.TopologyAssignment.Domains {
+	for _, psaDomain := range utiltas.TopologyDomains(psa.TopologyAssignment) {
 		domainID := utiltas.DomainID(psaDomain.Values)
 		ungatedInDomainCnt := domainIDToUngatedCnt[domainID]
 		remainingUngatedInDomain := max(psaDomain.Count-ungatedInDomainCnt, 0)
@@ -0,0 +1,290 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpa
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/limitrange"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+var (
+	// VerticalPodAutoscalerGVK is the kind of the VerticalPodAutoscalers.
+	VerticalPodAutoscalerGVK = schema.GroupVersionKind{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler"}
+)
+
+// verticalPodAutoscaler holds the fields of the VerticalPodAutoscalers read
+// by Kueue.
+type verticalPodAutoscaler struct {
+	Spec struct {
+		TargetRef *struct {
+			APIVersion string `json:"apiVersion,omitempty"`
+			Kind       string `json:"kind"`
+			Name       string `json:"name"`
+		} `json:"targetRef,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		Recommendation *struct {
+			ContainerRecommendations []struct {
+				ContainerName string              `json:"containerName,omitempty"`
+				Target        corev1.ResourceList `json:"target"`
+			} `json:"containerRecommendations,omitempty"`
+		} `json:"recommendation,omitempty"`
+	} `json:"status"`
+}
+
+// targets returns whether the VerticalPodAutoscaler targets the object.
+func (v *verticalPodAutoscaler) targets(owner *metav1.OwnerReference) bool {
+	ref := v.Spec.TargetRef
+	if ref == nil || ref.Kind != owner.Kind || ref.Name != owner.Name {
+		return false
+	}
+	refGV, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return false
+	}
+	ownerGV, err := schema.ParseGroupVersion(owner.APIVersion)
+	return err == nil && refGV.Group == ownerGV.Group
+}
+
+// targetRequests returns the target recommendations, by container name.
+func (v *verticalPodAutoscaler) targetRequests() map[string]corev1.ResourceList {
+	if v.Status.Recommendation == nil {
+		return nil
+	}
+	targets := make(map[string]corev1.ResourceList, len(v.Status.Recommendation.ContainerRecommendations))
+	for _, cr := range v.Status.Recommendation.ContainerRecommendations {
+		targets[cr.ContainerName] = cr.Target
+	}
+	return targets
+}
+
+func newVerticalPodAutoscaler() *unstructured.Unstructured {
+	vpa := &unstructured.Unstructured{}
+	vpa.SetGroupVersionKind(VerticalPodAutoscalerGVK)
+	return vpa
+}
+
+// ServerSupportsVerticalPodAutoscalers returns whether the
+// VerticalPodAutoscaler API is installed in the cluster.
+func ServerSupportsVerticalPodAutoscalers(mgr manager.Manager) bool {
+	_, err := mgr.GetRESTMapper().RESTMapping(VerticalPodAutoscalerGVK.GroupKind(), VerticalPodAutoscalerGVK.Version)
+	return err == nil
+}
+
+// RecommendationReconciler records, in the status of the pending workloads
+// of the ClusterQueues with the VPARecommendation requestsAccountingPolicy,
+// the requests recommended by the VerticalPodAutoscalers of their jobs.
+type RecommendationReconciler struct {
+	client   client.Client
+	qManager *queue.Manager
+}
+
+func NewRecommendationReconciler(client client.Client, qManager *queue.Manager) *RecommendationReconciler {
+	return &RecommendationReconciler{
+		client:   client,
+		qManager: qManager,
+	}
+}
+
+// +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;watch
+
+func (r *RecommendationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	wl := &kueue.Workload{}
+	if err := r.client.Get(ctx, req.NamespacedName, wl); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if workload.HasQuotaReservation(wl) || workload.IsFinished(wl) {
+		return ctrl.Result{}, nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+
+	var recommended []kueue.PodSetRequest
+	useRecommendation, err := r.usesRecommendation(ctx, wl)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if useRecommendation {
+		if recommended, err = r.recommendedRequests(ctx, wl); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if equality.Semantic.DeepEqual(recommended, wl.Status.RecommendedRequests) {
+		return ctrl.Result{}, nil
+	}
+	log.V(2).Info("Updating the recommended requests", "recommendedRequests", recommended)
+	wlPatch := wl.DeepCopy()
+	wlPatch.Status.RecommendedRequests = recommended
+	return ctrl.Result{}, client.IgnoreNotFound(r.client.Status().Patch(ctx, wlPatch, client.MergeFrom(wl)))
+}
+
+// usesRecommendation returns whether the ClusterQueue of the workload counts
+// the recommended requests against its quota.
+func (r *RecommendationReconciler) usesRecommendation(ctx context.Context, wl *kueue.Workload) (bool, error) {
+	cqName, found := r.qManager.ClusterQueueForWorkload(wl)
+	if !found {
+		return false, nil
+	}
+	cq := &kueue.ClusterQueue{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: cqName}, cq); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	policy := cq.Spec.RequestsAccountingPolicy
+	return policy != nil && policy.Source == kueue.RequestsSourceVPARecommendation, nil
+}
+
+// recommendedRequests returns the requests of a pod of each podSet, using
+// the target recommendations of the VerticalPodAutoscaler of the job of the
+// workload when they are lower than the requests of the containers.
+// The podSets without a lower recommendation are omitted.
+func (r *RecommendationReconciler) recommendedRequests(ctx context.Context, wl *kueue.Workload) ([]kueue.PodSetRequest, error) {
+	owner := metav1.GetControllerOf(wl)
+	if owner == nil {
+		return nil, nil
+	}
+	vpas := &unstructured.UnstructuredList{}
+	vpas.SetGroupVersionKind(VerticalPodAutoscalerGVK.GroupVersion().WithKind(VerticalPodAutoscalerGVK.Kind + "List"))
+	if err := r.client.List(ctx, vpas, client.InNamespace(wl.Namespace)); err != nil {
+		return nil, err
+	}
+	var targets map[string]corev1.ResourceList
+	for i := range vpas.Items {
+		vpa := verticalPodAutoscaler{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(vpas.Items[i].Object, &vpa); err != nil {
+			ctrl.LoggerFrom(ctx).V(3).Info("Skipping invalid VerticalPodAutoscaler", "verticalPodAutoscaler", klog.KObj(&vpas.Items[i]), "error", err)
+			continue
+		}
+		if vpa.targets(owner) {
+			targets = vpa.targetRequests()
+			break
+		}
+	}
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	var recommended []kueue.PodSetRequest
+	for _, ps := range wl.Spec.PodSets {
+		spec := ps.Template.Spec.DeepCopy()
+		lowered := false
+		for i := range spec.Containers {
+			c := &spec.Containers[i]
+			for name, target := range targets[c.Name] {
+				if declared, found := c.Resources.Requests[name]; found && target.Cmp(declared) < 0 {
+					c.Resources.Requests[name] = target
+					lowered = true
+				}
+			}
+		}
+		if lowered {
+			recommended = append(recommended, kueue.PodSetRequest{
+				Name:      ps.Name,
+				Resources: limitrange.TotalRequests(spec),
+			})
+		}
+	}
+	return recommended, nil
+}
+
+func (r *RecommendationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("vpa-recommendation").
+		For(&kueue.Workload{}).
+		Watches(newVerticalPodAutoscaler(), handler.EnqueueRequestsFromMapFunc(r.workloadsOfVerticalPodAutoscaler)).
+		Watches(&kueue.ClusterQueue{}, handler.EnqueueRequestsFromMapFunc(r.workloadsOfClusterQueue),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc: func(event.CreateEvent) bool { return false },
+				DeleteFunc: func(event.DeleteEvent) bool { return false },
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					oldCq, isOldCq := e.ObjectOld.(*kueue.ClusterQueue)
+					newCq, isNewCq := e.ObjectNew.(*kueue.ClusterQueue)
+					return isOldCq && isNewCq && !equality.Semantic.DeepEqual(oldCq.Spec.RequestsAccountingPolicy, newCq.Spec.RequestsAccountingPolicy)
+				},
+			})).
+		Complete(r)
+}
+
+// workloadsOfVerticalPodAutoscaler returns the workloads of the job targeted
+// by the VerticalPodAutoscaler.
+func (r *RecommendationReconciler) workloadsOfVerticalPodAutoscaler(ctx context.Context, obj client.Object) []reconcile.Request {
+	u, isUnstructured := obj.(*unstructured.Unstructured)
+	if !isUnstructured {
+		return nil
+	}
+	vpa := verticalPodAutoscaler{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &vpa); err != nil || vpa.Spec.TargetRef == nil {
+		return nil
+	}
+	wls := &kueue.WorkloadList{}
+	if err := r.client.List(ctx, wls, client.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Listing the workloads of the VerticalPodAutoscaler", "verticalPodAutoscaler", klog.KObj(obj))
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range wls.Items {
+		if owner := metav1.GetControllerOf(&wls.Items[i]); owner != nil && vpa.targets(owner) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&wls.Items[i])})
+		}
+	}
+	return requests
+}
+
+// workloadsOfClusterQueue returns the pending workloads of the LocalQueues
+// of the ClusterQueue.
+func (r *RecommendationReconciler) workloadsOfClusterQueue(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := ctrl.LoggerFrom(ctx)
+	lqs := &kueue.LocalQueueList{}
+	if err := r.client.List(ctx, lqs, client.MatchingFields{indexer.QueueClusterQueueKey: obj.GetName()}); err != nil {
+		log.Error(err, "Listing the LocalQueues of the ClusterQueue", "clusterQueue", klog.KObj(obj))
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, lq := range lqs.Items {
+		wls := &kueue.WorkloadList{}
+		if err := r.client.List(ctx, wls, client.InNamespace(lq.Namespace), client.MatchingFields{indexer.WorkloadQueueKey: lq.Name}); err != nil {
+			log.Error(err, "Listing the workloads of the LocalQueue", "localQueue", klog.KObj(&lq))
+			continue
+		}
+		for i := range wls.Items {
+			if !workload.HasQuotaReservation(&wls.Items[i]) {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&wls.Items[i])})
+			}
+		}
+	}
+	return requests
+}
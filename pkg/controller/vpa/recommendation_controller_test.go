@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpa
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+var jobGVK = schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}
+
+func makeVerticalPodAutoscaler(name, targetKind, targetName string, targets map[string]map[string]any) *unstructured.Unstructured {
+	vpa := newVerticalPodAutoscaler()
+	vpa.SetName(name)
+	vpa.SetNamespace("ns")
+	_ = unstructured.SetNestedMap(vpa.Object, map[string]any{
+		"apiVersion": "batch/v1",
+		"kind":       targetKind,
+		"name":       targetName,
+	}, "spec", "targetRef")
+	var recommendations []any
+	for container, target := range targets {
+		recommendations = append(recommendations, map[string]any{
+			"containerName": container,
+			"target":        target,
+		})
+	}
+	if recommendations != nil {
+		_ = unstructured.SetNestedSlice(vpa.Object, recommendations, "status", "recommendation", "containerRecommendations")
+	}
+	return vpa
+}
+
+func TestRecommendationReconcile(t *testing.T) {
+	baseWorkload := utiltesting.MakeWorkload("wl", "ns").
+		Queue("lq").
+		ControllerReference(jobGVK, "job", "job-uid").
+		PodSets(
+			*utiltesting.MakePodSet("main", 4).
+				Request(corev1.ResourceCPU, "2").
+				Request(corev1.ResourceMemory, "4Gi").
+				Obj(),
+		)
+	cases := map[string]struct {
+		source    kueue.RequestsSource
+		workload  *kueue.Workload
+		vpas      []*unstructured.Unstructured
+		wantState []kueue.PodSetRequest
+	}{
+		"lower recommendation": {
+			source:   kueue.RequestsSourceVPARecommendation,
+			workload: baseWorkload.Clone().Obj(),
+			vpas: []*unstructured.Unstructured{
+				makeVerticalPodAutoscaler("other", "Job", "other-job", map[string]map[string]any{
+					"c": {"cpu": "100m"},
+				}),
+				makeVerticalPodAutoscaler("vpa", "Job", "job", map[string]map[string]any{
+					"c": {"cpu": "500m", "memory": "8Gi"},
+				}),
+			},
+			wantState: []kueue.PodSetRequest{
+				{
+					Name: "main",
+					Resources: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+					},
+				},
+			},
+		},
+		"higher recommendation": {
+			source:   kueue.RequestsSourceVPARecommendation,
+			workload: baseWorkload.Clone().Obj(),
+			vpas: []*unstructured.Unstructured{
+				makeVerticalPodAutoscaler("vpa", "Job", "job", map[string]map[string]any{
+					"c": {"cpu": "4", "memory": "8Gi"},
+				}),
+			},
+		},
+		"recommendation for another kind": {
+			source:   kueue.RequestsSourceVPARecommendation,
+			workload: baseWorkload.Clone().Obj(),
+			vpas: []*unstructured.Unstructured{
+				makeVerticalPodAutoscaler("vpa", "Deployment", "job", map[string]map[string]any{
+					"c": {"cpu": "500m"},
+				}),
+			},
+		},
+		"declared requests": {
+			source: kueue.RequestsSourceDeclared,
+			workload: baseWorkload.Clone().
+				RecommendedRequests(kueue.PodSetRequest{
+					Name:      "main",
+					Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				}).
+				Obj(),
+			vpas: []*unstructured.Unstructured{
+				makeVerticalPodAutoscaler("vpa", "Job", "job", map[string]map[string]any{
+					"c": {"cpu": "500m"},
+				}),
+			},
+		},
+		"quota reserved": {
+			source: kueue.RequestsSourceVPARecommendation,
+			workload: baseWorkload.Clone().
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				Obj(),
+			vpas: []*unstructured.Unstructured{
+				makeVerticalPodAutoscaler("vpa", "Job", "job", map[string]map[string]any{
+					"c": {"cpu": "500m"},
+				}),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mapper := meta.NewDefaultRESTMapper(nil)
+			mapper.Add(VerticalPodAutoscalerGVK, meta.RESTScopeNamespace)
+			cq := utiltesting.MakeClusterQueue("cq").RequestsAccountingPolicy(tc.source).Obj()
+			lq := utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj()
+			builder := utiltesting.NewClientBuilder().
+				WithRESTMapper(mapper).
+				WithObjects(cq, lq, tc.workload).
+				WithStatusSubresource(tc.workload)
+			for _, vpa := range tc.vpas {
+				builder = builder.WithObjects(vpa)
+			}
+			cl := builder.Build()
+			ctx := context.Background()
+			qManager := queue.NewManager(cl, cache.New(cl))
+			if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Adding the ClusterQueue: %v", err)
+			}
+			if err := qManager.AddLocalQueue(ctx, lq); err != nil {
+				t.Fatalf("Adding the LocalQueue: %v", err)
+			}
+
+			reconciler := NewRecommendationReconciler(cl, qManager)
+			key := client.ObjectKeyFromObject(tc.workload)
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile returned error: %v", err)
+			}
+
+			wantState := tc.wantState
+			if tc.workload.Status.Admission != nil {
+				wantState = tc.workload.Status.RecommendedRequests
+			}
+			gotWorkload := &kueue.Workload{}
+			if err := cl.Get(ctx, key, gotWorkload); err != nil {
+				t.Fatalf("Getting the workload: %v", err)
+			}
+			if diff := cmp.Diff(wantState, gotWorkload.Status.RecommendedRequests); diff != "" {
+				t.Errorf("Unexpected recommended requests (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
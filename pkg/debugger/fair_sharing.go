@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugger
+
+import (
+	"cmp"
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+// FairSharingPath is the path of the endpoint, served together with the
+// metrics, reporting the fair sharing and borrowing state of the
+// ClusterQueues and Cohorts.
+const FairSharingPath = "/debug/fairsharing"
+
+// FairSharingState is the fair sharing and borrowing state of the
+// ClusterQueues and Cohorts, sorted by name.
+type FairSharingState struct {
+	ClusterQueues []ClusterQueueFairSharingState `json:"clusterQueues"`
+	Cohorts       []CohortFairSharingState       `json:"cohorts"`
+}
+
+type ClusterQueueFairSharingState struct {
+	Name             string                                    `json:"name"`
+	Cohort           string                                    `json:"cohort,omitempty"`
+	FairWeight       resource.Quantity                         `json:"fairWeight"`
+	ResourceWeights  map[corev1.ResourceName]resource.Quantity `json:"resourceWeights,omitempty"`
+	WeightedShare    int64                                     `json:"weightedShare"`
+	DominantResource corev1.ResourceName                       `json:"dominantResource,omitempty"`
+	Resources        []ResourceState                           `json:"resources"`
+}
+
+type CohortFairSharingState struct {
+	Name      string          `json:"name"`
+	Parent    string          `json:"parent,omitempty"`
+	Resources []ResourceState `json:"resources"`
+}
+
+// ResourceState holds the quantities of a resource in a flavor for a
+// ClusterQueue or a Cohort. For a Cohort, the quota includes the quota
+// lent by its children, and the usage only the part of the children's
+// usage that they borrow.
+type ResourceState struct {
+	Flavor   kueue.ResourceFlavorReference `json:"flavor"`
+	Resource corev1.ResourceName           `json:"resource"`
+	Quota    resource.Quantity             `json:"quota"`
+	Usage    resource.Quantity             `json:"usage"`
+	Borrowed resource.Quantity             `json:"borrowed"`
+	Lendable resource.Quantity             `json:"lendable"`
+}
+
+type fairSharingHandler struct {
+	cache *cache.Cache
+}
+
+// NewFairSharingHandler returns the handler of the FairSharingPath, which
+// reports the FairSharingState built from a snapshot of the cache.
+func NewFairSharingHandler(c *cache.Cache) http.Handler {
+	return &fairSharingHandler{cache: c}
+}
+
+func (h *fairSharingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := ctrl.LoggerFrom(r.Context()).WithName("debugger")
+	snapshot, err := h.cache.Snapshot(r.Context())
+	if err != nil {
+		log.Error(err, "unexpected error while building snapshot")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(fairSharingState(snapshot)); err != nil {
+		log.Error(err, "Failed to write the fair sharing state")
+	}
+}
+
+func fairSharingState(snapshot *cache.Snapshot) *FairSharingState {
+	state := &FairSharingState{
+		ClusterQueues: make([]ClusterQueueFairSharingState, 0, len(snapshot.ClusterQueues)),
+		Cohorts:       make([]CohortFairSharingState, 0, len(snapshot.Cohorts)),
+	}
+	for _, cq := range snapshot.ClusterQueues {
+		weightedShare, dominantResource := cq.DominantResourceShare()
+		cqState := ClusterQueueFairSharingState{
+			Name:             cq.Name,
+			FairWeight:       cq.FairWeight,
+			ResourceWeights:  cq.FairResourceWeights,
+			WeightedShare:    int64(weightedShare),
+			DominantResource: dominantResource,
+			Resources:        resourcesState(cq.ResourceNode),
+		}
+		if cq.HasParent() {
+			cqState.Cohort = cq.Parent().Name
+		}
+		state.ClusterQueues = append(state.ClusterQueues, cqState)
+	}
+	for _, cohort := range snapshot.Cohorts {
+		cohortState := CohortFairSharingState{
+			Name:      cohort.Name,
+			Resources: resourcesState(cohort.ResourceNode),
+		}
+		if cohort.HasParent() {
+			cohortState.Parent = cohort.Parent().Name
+		}
+		state.Cohorts = append(state.Cohorts, cohortState)
+	}
+	slices.SortFunc(state.ClusterQueues, func(a, b ClusterQueueFairSharingState) int { return cmp.Compare(a.Name, b.Name) })
+	slices.SortFunc(state.Cohorts, func(a, b CohortFairSharingState) int { return cmp.Compare(a.Name, b.Name) })
+	return state
+}
+
+func resourcesState(node cache.ResourceNode) []ResourceState {
+	state := make([]ResourceState, 0, len(node.SubtreeQuota))
+	for fr, quota := range node.SubtreeQuota {
+		state = append(state, ResourceState{
+			Flavor:   fr.Flavor,
+			Resource: fr.Resource,
+			Quota:    resources.ResourceQuantity(fr.Resource, quota),
+			Usage:    resources.ResourceQuantity(fr.Resource, node.Usage[fr]),
+			Borrowed: resources.ResourceQuantity(fr.Resource, node.Borrowed(fr)),
+			Lendable: resources.ResourceQuantity(fr.Resource, node.Lendable(fr)),
+		})
+	}
+	slices.SortFunc(state, func(a, b ResourceState) int {
+		return cmp.Or(cmp.Compare(a.Flavor, b.Flavor), cmp.Compare(a.Resource, b.Resource))
+	})
+	return state
+}
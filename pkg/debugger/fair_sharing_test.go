@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/kueue/pkg/cache"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestFairSharingHandler(t *testing.T) {
+	ctx := context.Background()
+	cqCache := cache.New(utiltesting.NewFakeClient(), cache.WithFairSharing(true))
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	for _, cq := range []struct {
+		name, nominal, lendingLimit string
+	}{
+		{name: "a", nominal: "10", lendingLimit: "4"},
+		{name: "b", nominal: "6"},
+	} {
+		if err := cqCache.AddClusterQueue(ctx, utiltesting.MakeClusterQueue(cq.name).
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, cq.nominal, "", cq.lendingLimit).
+					Obj(),
+			).
+			Cohort("cohort").Obj()); err != nil {
+			t.Fatalf("Adding ClusterQueue %s: %v", cq.name, err)
+		}
+	}
+	wl := utiltesting.MakeWorkload("wl", "").
+		Request(corev1.ResourceCPU, "12").
+		ReserveQuota(utiltesting.MakeAdmission("a").Assignment(corev1.ResourceCPU, "default", "12").Obj()).
+		Obj()
+	if added := cqCache.AddOrUpdateWorkload(wl); !added {
+		t.Fatal("Workload was not added")
+	}
+
+	rec := httptest.NewRecorder()
+	NewFairSharingHandler(cqCache).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, FairSharingPath, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Got status %d, want %d", rec.Code, http.StatusOK)
+	}
+	var got FairSharingState
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("Decoding the fair sharing state: %v", err)
+	}
+
+	want := FairSharingState{
+		ClusterQueues: []ClusterQueueFairSharingState{
+			{
+				Name:             "a",
+				Cohort:           "cohort",
+				FairWeight:       resource.MustParse("1"),
+				WeightedShare:    200,
+				DominantResource: corev1.ResourceCPU,
+				Resources: []ResourceState{{
+					Flavor:   "default",
+					Resource: corev1.ResourceCPU,
+					Quota:    resource.MustParse("10"),
+					Usage:    resource.MustParse("12"),
+					Borrowed: resource.MustParse("2"),
+					Lendable: resource.MustParse("4"),
+				}},
+			},
+			{
+				Name:          "b",
+				Cohort:        "cohort",
+				FairWeight:    resource.MustParse("1"),
+				WeightedShare: 0,
+				Resources: []ResourceState{{
+					Flavor:   "default",
+					Resource: corev1.ResourceCPU,
+					Quota:    resource.MustParse("6"),
+					Usage:    resource.MustParse("0"),
+					Borrowed: resource.MustParse("0"),
+					Lendable: resource.MustParse("6"),
+				}},
+			},
+		},
+		Cohorts: []CohortFairSharingState{
+			{
+				Name: "cohort",
+				Resources: []ResourceState{{
+					Flavor:   "default",
+					Resource: corev1.ResourceCPU,
+					Quota:    resource.MustParse("10"),
+					Usage:    resource.MustParse("6"),
+					Borrowed: resource.MustParse("0"),
+					Lendable: resource.MustParse("10"),
+				}},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected fair sharing state (-want,+got):\n%s", diff)
+	}
+}
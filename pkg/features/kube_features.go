@@ -169,6 +169,168 @@ const (
 	//
 	// Enable to set default LocalQueue.
 	LocalQueueDefaulting featuregate.Feature = "LocalQueueDefaulting"
+
+	// alpha: v0.10
+	//
+	// Enable the reclamation of the quota used by idle serving workloads.
+	IdleServingReclamation featuregate.Feature = "IdleServingReclamation"
+
+	// alpha: v0.10
+	//
+	// Enable changing the podSets counts of workloads with quota reserved,
+	// without evicting them.
+	ElasticWorkloadResize featuregate.Feature = "ElasticWorkloadResize"
+
+	// alpha: v0.10
+	//
+	// Enable charging the in-place resizes of the pods of admitted workloads
+	// against the quota of their ClusterQueue.
+	InPlacePodResize featuregate.Feature = "InPlacePodResize"
+
+	// alpha: v0.10
+	//
+	// Enable reporting the machine-readable reasons for which the workloads
+	// are pending in their status.
+	WorkloadPendingReasons featuregate.Feature = "WorkloadPendingReasons"
+
+	// alpha: v0.10
+	//
+	// Enable reloading the reloadable fields of the configuration when its
+	// file changes, without restarting Kueue.
+	ConfigurationHotReload featuregate.Feature = "ConfigurationHotReload"
+
+	// alpha: v0.10
+	//
+	// Enable the Integration API, to disable the integrations, or restrict
+	// the namespaces in which Kueue manages their jobs, without restarting Kueue.
+	IntegrationScoping featuregate.Feature = "IntegrationScoping"
+
+	// alpha: v0.10
+	//
+	// Enable computing the flavor assignments and preemption targets of the
+	// workloads of independent cohorts concurrently in a scheduling cycle.
+	ParallelCohortScheduling featuregate.Feature = "ParallelCohortScheduling"
+
+	// alpha: v0.10
+	//
+	// Enable coalescing the status updates of the pending workloads made by
+	// the scheduler, and applying them in batches.
+	BatchedWorkloadStatusUpdates featuregate.Feature = "BatchedWorkloadStatusUpdates"
+
+	// alpha: v0.10
+	//
+	// Enable reusing the flavor assignments of identical pending workloads
+	// which couldn't be admitted, while the cache is not modified.
+	FlavorAssignmentCache featuregate.Feature = "FlavorAssignmentCache"
+
+	// alpha: v0.10
+	//
+	// Enable requeuing only the inadmissible workloads waiting for the flavors
+	// in which quota is released, instead of all the inadmissible workloads
+	// of the cohort.
+	FlavorAwareRequeue featuregate.Feature = "FlavorAwareRequeue"
+
+	// alpha: v0.10
+	//
+	// Enable storing the topology assignments of the workloads in the compact
+	// domainGroups representation.
+	CompactTopologyAssignment featuregate.Feature = "CompactTopologyAssignment"
+
+	// alpha: v0.10
+	//
+	// Enable maintaining the topology domains of the TAS flavors from the node
+	// and pod events, instead of listing the nodes and pods to build the
+	// snapshot in every scheduling cycle.
+	TASIncrementalSnapshot featuregate.Feature = "TASIncrementalSnapshot"
+
+	// alpha: v0.10
+	//
+	// Enable the garbage collector of the orphaned Workloads and of the
+	// dangling ProvisioningRequests.
+	WorkloadGarbageCollector featuregate.Feature = "WorkloadGarbageCollector"
+
+	// alpha: v0.10
+	//
+	// Enable accounting the quota within the nominal quota of the ClusterQueues
+	// lost to the preemptions, and prioritizing the ClusterQueues in debt in the
+	// subsequent scheduling cycles.
+	ReclaimDebtAccounting featuregate.Feature = "ReclaimDebtAccounting"
+
+	// alpha: v0.10
+	//
+	// Enable the StrictFIFO queueing strategy of the Cohorts, which orders the
+	// workloads of all the ClusterQueues of the Cohort together.
+	CohortStrictFIFO featuregate.Feature = "CohortStrictFIFO"
+
+	// alpha: v0.10
+	//
+	// Enables the Image Signature Admission Check Controller, which verifies
+	// the cosign signatures of the images of the workloads before their
+	// admission.
+	ImageSignatureACC featuregate.Feature = "ImageSignatureACC"
+
+	// alpha: v0.10
+	//
+	// Enable protecting the nodes of the pods of the admitted gang workloads
+	// from the scale down by the cluster autoscaler, until the workloads
+	// finish.
+	ScaleDownProtection featuregate.Feature = "ScaleDownProtection"
+
+	// alpha: v0.10
+	//
+	// Enable capping the usage of the ResourceFlavors of the Karpenter
+	// NodePools by the limits of the NodePools.
+	KarpenterNodePoolLimits featuregate.Feature = "KarpenterNodePoolLimits"
+
+	// alpha: v0.10
+	//
+	// Enable counting the requests recommended by the VerticalPodAutoscalers
+	// against the quota of the ClusterQueues with the VPARecommendation
+	// requestsAccountingPolicy.
+	VPAQuotaAccounting featuregate.Feature = "VPAQuotaAccounting"
+
+	// alpha: v0.10
+	//
+	// Enable evicting and requeuing the admitted workloads with pods on the
+	// nodes signaled as about to be interrupted.
+	NodeInterruptionRequeue featuregate.Feature = "NodeInterruptionRequeue"
+
+	// alpha: v0.10
+	//
+	// Enable replacing the topology domains without Ready nodes in the
+	// assignments of the workloads admitted by TAS, or evicting the workloads
+	// if no replacement is found.
+	TASFailedNodeReplacement featuregate.Feature = "TASFailedNodeReplacement"
+
+	// alpha: v0.10
+	//
+	// Enable reporting the quota that the ClusterQueues borrow from and lend
+	// to the other ClusterQueues of their cohort trees, in their status.
+	ClusterQueueBorrowingStatus featuregate.Feature = "ClusterQueueBorrowingStatus"
+
+	// alpha: v0.10
+	//
+	// Enable the quota subresource of the ClusterQueues in the visibility API,
+	// to read and update their nominal quotas atomically.
+	ClusterQueueQuotaSubresource featuregate.Feature = "ClusterQueueQuotaSubresource"
+
+	// alpha: v0.10
+	//
+	// Enable restricting the flavors and the topology domains assigned to the
+	// pending StatefulSets to the nodes which can attach their bound volumes.
+	StatefulSetVolumeTopology featuregate.Feature = "StatefulSetVolumeTopology"
+
+	// alpha: v0.10
+	//
+	// Enable the Tenants, which cap the combined usage of groups of
+	// LocalQueues across namespaces and ClusterQueues.
+	Tenants featuregate.Feature = "Tenants"
+
+	// alpha: v0.10
+	//
+	// Enable resubmitting the jobs of the finished workloads requested with the
+	// kueue.x-k8s.io/resubmit annotation.
+	WorkloadResubmit featuregate.Feature = "WorkloadResubmit"
 )
 
 func init() {
@@ -201,6 +363,32 @@ var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	ManagedJobsNamespaceSelector:        {Default: true, PreRelease: featuregate.Beta},
 	LocalQueueMetrics:                   {Default: false, PreRelease: featuregate.Alpha},
 	LocalQueueDefaulting:                {Default: false, PreRelease: featuregate.Alpha},
+	IdleServingReclamation:              {Default: false, PreRelease: featuregate.Alpha},
+	ElasticWorkloadResize:               {Default: false, PreRelease: featuregate.Alpha},
+	InPlacePodResize:                    {Default: false, PreRelease: featuregate.Alpha},
+	WorkloadPendingReasons:              {Default: false, PreRelease: featuregate.Alpha},
+	ConfigurationHotReload:              {Default: false, PreRelease: featuregate.Alpha},
+	IntegrationScoping:                  {Default: false, PreRelease: featuregate.Alpha},
+	ParallelCohortScheduling:            {Default: false, PreRelease: featuregate.Alpha},
+	BatchedWorkloadStatusUpdates:        {Default: false, PreRelease: featuregate.Alpha},
+	FlavorAssignmentCache:               {Default: false, PreRelease: featuregate.Alpha},
+	FlavorAwareRequeue:                  {Default: false, PreRelease: featuregate.Alpha},
+	CompactTopologyAssignment:           {Default: false, PreRelease: featuregate.Alpha},
+	TASIncrementalSnapshot:              {Default: false, PreRelease: featuregate.Alpha},
+	WorkloadGarbageCollector:            {Default: false, PreRelease: featuregate.Alpha},
+	ReclaimDebtAccounting:               {Default: false, PreRelease: featuregate.Alpha},
+	CohortStrictFIFO:                    {Default: false, PreRelease: featuregate.Alpha},
+	ImageSignatureACC:                   {Default: false, PreRelease: featuregate.Alpha},
+	ScaleDownProtection:                 {Default: false, PreRelease: featuregate.Alpha},
+	KarpenterNodePoolLimits:             {Default: false, PreRelease: featuregate.Alpha},
+	VPAQuotaAccounting:                  {Default: false, PreRelease: featuregate.Alpha},
+	NodeInterruptionRequeue:             {Default: false, PreRelease: featuregate.Alpha},
+	TASFailedNodeReplacement:            {Default: false, PreRelease: featuregate.Alpha},
+	ClusterQueueBorrowingStatus:         {Default: false, PreRelease: featuregate.Alpha},
+	ClusterQueueQuotaSubresource:        {Default: false, PreRelease: featuregate.Alpha},
+	StatefulSetVolumeTopology:           {Default: false, PreRelease: featuregate.Alpha},
+	Tenants:                             {Default: false, PreRelease: featuregate.Alpha},
+	WorkloadResubmit:                    {Default: false, PreRelease: featuregate.Alpha},
 }
 
 func SetFeatureGateDuringTest(tb testing.TB, f featuregate.Feature, value bool) {
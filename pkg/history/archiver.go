@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const (
+	// maxBatchSize is the maximum number of records appended at once.
+	maxBatchSize = 100
+	// pruneInterval is how often the records older than the retention are
+	// deleted.
+	pruneInterval = time.Hour
+)
+
+// Archiver stores the records of the workloads when they finish, so that
+// they outlive the Workload objects, asynchronously.
+// The records are only stored by the leader, and the records produced while
+// the buffer is full are dropped.
+type Archiver struct {
+	store     Store
+	retention time.Duration
+	clock     clock.WithTicker
+	records   chan Record
+	started   atomic.Bool
+	dropped   atomic.Int64
+}
+
+// NewArchiver returns the Archiver storing the records in the store, for the
+// configuration.
+func NewArchiver(store Store, cfg *config.WorkloadHistory) *Archiver {
+	a := &Archiver{
+		store:     store,
+		retention: config.DefaultWorkloadHistoryRetention,
+		clock:     clock.RealClock{},
+		records:   make(chan Record, ptr.Deref(cfg.BufferSize, config.DefaultWorkloadHistoryBufferSize)),
+	}
+	if cfg.Retention != nil {
+		a.retention = cfg.Retention.Duration
+	}
+	return a
+}
+
+// Store returns the store of the records.
+func (a *Archiver) Store() Store {
+	return a.store
+}
+
+// NotifyWorkloadUpdate implements the WorkloadUpdateWatcher interface of the
+// workload controller.
+func (a *Archiver) NotifyWorkloadUpdate(oldWl, newWl *kueue.Workload) {
+	if oldWl == nil || newWl == nil || workload.IsFinished(oldWl) || !workload.IsFinished(newWl) {
+		return
+	}
+	if !a.started.Load() {
+		return
+	}
+	select {
+	case a.records <- NewRecord(newWl):
+	default:
+		a.dropped.Add(1)
+	}
+}
+
+// Start implements the Runnable interface to store the records, and to
+// prune the ones older than the retention, until the context is canceled.
+func (a *Archiver) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("workload-history")
+	a.started.Store(true)
+	defer a.started.Store(false)
+	defer func() {
+		if err := a.store.Close(); err != nil {
+			log.Error(err, "Closing the workload history store", "store", a.store.String())
+		}
+	}()
+	ticker := a.clock.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	a.prune(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C():
+			a.prune(ctx)
+		case r := <-a.records:
+			batch := a.batch(r)
+			if err := a.store.Append(ctx, batch); err != nil {
+				log.Error(err, "Storing the records of the finished workloads", "count", len(batch), "store", a.store.String())
+			}
+			if dropped := a.dropped.Swap(0); dropped > 0 {
+				log.Info("Dropped records of finished workloads because the buffer was full", "count", dropped)
+			}
+		}
+	}
+}
+
+// batch returns the record along with the records already waiting in the
+// buffer, up to maxBatchSize.
+func (a *Archiver) batch(r Record) []Record {
+	batch := []Record{r}
+	for len(batch) < maxBatchSize {
+		select {
+		case r := <-a.records:
+			batch = append(batch, r)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func (a *Archiver) prune(ctx context.Context) {
+	if err := a.store.Prune(ctx, a.clock.Now().Add(-a.retention)); err != nil {
+		ctrl.LoggerFrom(ctx).WithName("workload-history").Error(err, "Pruning the workload history", "store", a.store.String())
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestNotifyWorkloadUpdate(t *testing.T) {
+	admittedAt := baseTime.Add(-time.Hour)
+	admitted := utiltesting.MakeWorkload("wl", "ns").
+		UID("uid").
+		Queue("lq").
+		Priority(100).
+		Creation(baseTime.Add(-2*time.Hour)).
+		ControllerReference(batchv1.SchemeGroupVersion.WithKind("Job"), "job", "job-uid").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").AssignmentPodCount(1).Obj()).
+		AdmittedAt(true, admittedAt)
+	finished := admitted.Clone().Condition(metav1.Condition{
+		Type:               kueue.WorkloadFinished,
+		Status:             metav1.ConditionTrue,
+		Reason:             kueue.WorkloadFinishedReasonSucceeded,
+		Message:            "Job finished successfully",
+		LastTransitionTime: metav1.NewTime(baseTime),
+	})
+
+	cases := map[string]struct {
+		oldWl       *kueue.Workload
+		newWl       *kueue.Workload
+		notStarted  bool
+		wantRecords []Record
+	}{
+		"finished": {
+			oldWl: admitted.Obj(),
+			newWl: finished.Obj(),
+			wantRecords: []Record{{
+				Namespace:     "ns",
+				Name:          "wl",
+				UID:           "uid",
+				Owner:         "Job/job",
+				LocalQueue:    "lq",
+				ClusterQueue:  "cq",
+				Priority:      100,
+				CreationTime:  baseTime.Add(-2 * time.Hour),
+				AdmissionTime: ptr.To(admittedAt),
+				FinishTime:    baseTime,
+				FinishReason:  kueue.WorkloadFinishedReasonSucceeded,
+				FinishMessage: "Job finished successfully",
+				PodSets: []PodSetRecord{{
+					Name:    kueue.DefaultPodSetName,
+					Count:   1,
+					Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "default"},
+					Usage:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				}},
+			}},
+		},
+		"already finished": {
+			oldWl: finished.Obj(),
+			newWl: finished.Obj(),
+		},
+		"admitted": {
+			oldWl: utiltesting.MakeWorkload("wl", "ns").Obj(),
+			newWl: admitted.Obj(),
+		},
+		"created": {
+			newWl: finished.Obj(),
+		},
+		"not started": {
+			oldWl:      admitted.Obj(),
+			newWl:      finished.Obj(),
+			notStarted: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := NewArchiver(nil, &config.WorkloadHistory{BufferSize: ptr.To[int32](10)})
+			a.started.Store(!tc.notStarted)
+			a.NotifyWorkloadUpdate(tc.oldWl, tc.newWl)
+			var got []Record
+			for len(a.records) > 0 {
+				got = append(got, <-a.records)
+			}
+			if diff := cmp.Diff(tc.wantRecords, got); diff != "" {
+				t.Errorf("Unexpected records (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestArchiverStoresAndPrunes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Creating the store: %v", err)
+	}
+	old := testRecord("ns", "old", "cq", baseTime.Add(-72*time.Hour))
+	if err := store.Append(ctx, []Record{old}); err != nil {
+		t.Fatalf("Appending the records: %v", err)
+	}
+	a := NewArchiver(store, &config.WorkloadHistory{
+		Retention:  &metav1.Duration{Duration: 48 * time.Hour},
+		BufferSize: ptr.To[int32](10),
+	})
+	fakeClock := testingclock.NewFakeClock(baseTime)
+	a.clock = fakeClock
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = a.Start(ctx)
+	}()
+	// Wait for the archiver to be started, as the records are only queued
+	// once it is.
+	for !a.started.Load() {
+		time.Sleep(time.Millisecond)
+	}
+	a.records <- testRecord("ns", "new", "cq", baseTime)
+
+	var gotNames []string
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		records, err := store.Query(ctx, &Query{})
+		if err != nil {
+			t.Fatalf("Querying the records: %v", err)
+		}
+		gotNames = nil
+		for _, r := range records {
+			gotNames = append(gotNames, r.Name)
+		}
+		if len(gotNames) == 1 && gotNames[0] == "new" {
+			break
+		}
+	}
+	if diff := cmp.Diff([]string{"new"}, gotNames); diff != "" {
+		t.Errorf("Unexpected records (-want,+got):\n%s", diff)
+	}
+	cancel()
+	<-done
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// QueryPath is the path of the endpoint, served together with the
+	// metrics, returning the records of the finished workloads.
+	QueryPath = "/debug/workloads/history"
+
+	defaultQueryLimit = 100
+	maxQueryLimit     = 1000
+)
+
+// QueryResult is the response of the QueryPath.
+type QueryResult struct {
+	Records []Record `json:"records"`
+}
+
+type queryHandler struct {
+	store Store
+}
+
+// NewQueryHandler returns the handler of the QueryPath, which returns the
+// records of the store, the most recently finished first, selected by the
+// namespace, localQueue, clusterQueue, since and until (RFC 3339) query
+// parameters, up to limit records.
+func NewQueryHandler(store Store) http.Handler {
+	return &queryHandler{store: store}
+}
+
+func (h *queryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := ctrl.LoggerFrom(r.Context()).WithName("workload-history")
+	q, err := parseQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	records, err := h.store.Query(r.Context(), q)
+	if err != nil {
+		log.Error(err, "Querying the workload history", "store", h.store.String())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if records == nil {
+		records = []Record{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&QueryResult{Records: records}); err != nil {
+		log.Error(err, "Failed to write the workload history")
+	}
+}
+
+func parseQuery(r *http.Request) (*Query, error) {
+	values := r.URL.Query()
+	q := &Query{
+		Namespace:    values.Get("namespace"),
+		LocalQueue:   values.Get("localQueue"),
+		ClusterQueue: values.Get("clusterQueue"),
+		Limit:        defaultQueryLimit,
+	}
+	var err error
+	if since := values.Get("since"); since != "" {
+		if q.Since, err = time.Parse(time.RFC3339, since); err != nil {
+			return nil, fmt.Errorf("invalid since: %w", err)
+		}
+	}
+	if until := values.Get("until"); until != "" {
+		if q.Until, err = time.Parse(time.RFC3339, until); err != nil {
+			return nil, fmt.Errorf("invalid until: %w", err)
+		}
+	}
+	if limit := values.Get("limit"); limit != "" {
+		if q.Limit, err = strconv.Atoi(limit); err != nil || q.Limit <= 0 || q.Limit > maxQueryLimit {
+			return nil, fmt.Errorf("invalid limit %q: must be between 1 and %d", limit, maxQueryLimit)
+		}
+	}
+	return q, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestQueryHandler(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Creating the store: %v", err)
+	}
+	if err := store.Append(context.Background(), []Record{
+		testRecord("ns1", "a", "cq1", baseTime.Add(-24*time.Hour)),
+		testRecord("ns2", "b", "cq1", baseTime.Add(-time.Hour)),
+		testRecord("ns1", "c", "cq2", baseTime),
+	}); err != nil {
+		t.Fatalf("Appending the records: %v", err)
+	}
+
+	cases := map[string]struct {
+		query      string
+		wantStatus int
+		wantNames  []string
+	}{
+		"all the records": {
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"c", "b", "a"},
+		},
+		"namespace and clusterQueue": {
+			query:      "?namespace=ns1&clusterQueue=cq1",
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"a"},
+		},
+		"since and limit": {
+			query:      "?since=2024-10-13T12:00:00Z&limit=1",
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"c"},
+		},
+		"no records": {
+			query:      "?localQueue=other",
+			wantStatus: http.StatusOK,
+			wantNames:  []string{},
+		},
+		"invalid since": {
+			query:      "?since=yesterday",
+			wantStatus: http.StatusBadRequest,
+		},
+		"invalid limit": {
+			query:      "?limit=1001",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewQueryHandler(store).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, QueryPath+tc.query, nil))
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("Unexpected status %d, want %d: %s", rec.Code, tc.wantStatus, rec.Body.String())
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			var result QueryResult
+			if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+				t.Fatalf("Decoding the response: %v", err)
+			}
+			gotNames := []string{}
+			for _, r := range result.Records {
+				gotNames = append(gotNames, r.Name)
+			}
+			if diff := cmp.Diff(tc.wantNames, gotNames); diff != "" {
+				t.Errorf("Unexpected records (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
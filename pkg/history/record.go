@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// Record is the archived record of a finished workload.
+type Record struct {
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	UID       types.UID `json:"uid"`
+	// Owner is the kind and name of the controller of the workload, like
+	// Job/sample, if any.
+	Owner             string `json:"owner,omitempty"`
+	LocalQueue        string `json:"localQueue"`
+	ClusterQueue      string `json:"clusterQueue,omitempty"`
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	Priority          int32  `json:"priority"`
+
+	CreationTime time.Time `json:"creationTime"`
+	// AdmissionTime is the time of the admission of the workload, if it was
+	// admitted.
+	AdmissionTime *time.Time `json:"admissionTime,omitempty"`
+	FinishTime    time.Time  `json:"finishTime"`
+	// FinishReason and FinishMessage come from the Finished condition of the
+	// workload.
+	FinishReason  string `json:"finishReason,omitempty"`
+	FinishMessage string `json:"finishMessage,omitempty"`
+	// RequeueCount is the number of times the workload was requeued after an
+	// eviction.
+	RequeueCount int32 `json:"requeueCount,omitempty"`
+
+	// PodSets are the pod sets of the workload, along with the flavors and
+	// the usage of their admission.
+	PodSets []PodSetRecord `json:"podSets,omitempty"`
+}
+
+type PodSetRecord struct {
+	Name    string                                                `json:"name"`
+	Count   int32                                                 `json:"count"`
+	Flavors map[corev1.ResourceName]kueue.ResourceFlavorReference `json:"flavors,omitempty"`
+	Usage   corev1.ResourceList                                   `json:"usage,omitempty"`
+}
+
+// NewRecord returns the record of the finished workload.
+func NewRecord(wl *kueue.Workload) Record {
+	r := Record{
+		Namespace:         wl.Namespace,
+		Name:              wl.Name,
+		UID:               wl.UID,
+		LocalQueue:        wl.Spec.QueueName,
+		PriorityClassName: wl.Spec.PriorityClassName,
+		Priority:          ptr.Deref(wl.Spec.Priority, 0),
+		CreationTime:      wl.CreationTimestamp.UTC(),
+	}
+	for _, ref := range wl.OwnerReferences {
+		if ptr.Deref(ref.Controller, false) {
+			r.Owner = ref.Kind + "/" + ref.Name
+		}
+	}
+	if admitted := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadAdmitted); admitted != nil && admitted.Status == metav1.ConditionTrue {
+		r.AdmissionTime = ptr.To(admitted.LastTransitionTime.UTC())
+	}
+	if finished := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadFinished); finished != nil {
+		r.FinishTime = finished.LastTransitionTime.UTC()
+		r.FinishReason = finished.Reason
+		r.FinishMessage = finished.Message
+	}
+	if wl.Status.RequeueState != nil {
+		r.RequeueCount = ptr.Deref(wl.Status.RequeueState.Count, 0)
+	}
+	if wl.Status.Admission != nil {
+		r.ClusterQueue = string(wl.Status.Admission.ClusterQueue)
+		for _, psa := range wl.Status.Admission.PodSetAssignments {
+			r.PodSets = append(r.PodSets, PodSetRecord{
+				Name:    psa.Name,
+				Count:   ptr.Deref(psa.Count, 0),
+				Flavors: psa.Flavors,
+				Usage:   psa.ResourceUsage,
+			})
+		}
+	} else {
+		for _, ps := range wl.Spec.PodSets {
+			r.PodSets = append(r.PodSets, PodSetRecord{Name: ps.Name, Count: ps.Count})
+		}
+	}
+	return r
+}
+
+// Query selects the records of a store. The empty fields match all the
+// records.
+type Query struct {
+	Namespace    string
+	LocalQueue   string
+	ClusterQueue string
+	// Since and Until bound the finish times of the records, Until being
+	// excluded.
+	Since time.Time
+	Until time.Time
+	// Limit is the maximum number of records returned, or 0 for no limit.
+	Limit int
+}
+
+// Matches returns whether the record is selected by the query.
+func (q *Query) Matches(r *Record) bool {
+	return (q.Namespace == "" || q.Namespace == r.Namespace) &&
+		(q.LocalQueue == "" || q.LocalQueue == r.LocalQueue) &&
+		(q.ClusterQueue == "" || q.ClusterQueue == r.ClusterQueue) &&
+		(q.Since.IsZero() || !r.FinishTime.Before(q.Since)) &&
+		(q.Until.IsZero() || r.FinishTime.Before(q.Until))
+}
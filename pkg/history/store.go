@@ -0,0 +1,223 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+)
+
+const (
+	fileDayLayout = "2006-01-02"
+	filePrefix    = "workloads-"
+	fileSuffix    = ".jsonl"
+)
+
+// Store is a persistence backend of the records of the finished workloads.
+// The methods can be called concurrently.
+type Store interface {
+	fmt.Stringer
+	// Append stores the records.
+	Append(ctx context.Context, records []Record) error
+	// Query returns the records selected by the query, the most recently
+	// finished first.
+	Query(ctx context.Context, q *Query) ([]Record, error)
+	// Prune deletes the records finished before the time. The stores may keep
+	// the records up to a day older.
+	Prune(ctx context.Context, before time.Time) error
+	// Close releases the resources held by the store.
+	Close() error
+}
+
+// NewStore returns the store of the configuration.
+func NewStore(cfg *config.WorkloadHistory) (Store, error) {
+	if cfg.File != nil {
+		return NewFileStore(cfg.File.Directory)
+	}
+	return nil, errors.New("no store for the workload history")
+}
+
+// FileStore stores the records in a directory, in JSON Lines files, one per
+// day of the finish times of the records, in UTC.
+type FileStore struct {
+	dir string
+	mu  sync.RWMutex
+}
+
+var _ Store = (*FileStore)(nil)
+
+// NewFileStore returns the FileStore of the directory, creating it if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating the workload history directory: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) String() string {
+	return s.dir
+}
+
+func (s *FileStore) Append(_ context.Context, records []Record) error {
+	byDay := make(map[string][]byte)
+	for i := range records {
+		line, err := json.Marshal(&records[i])
+		if err != nil {
+			return err
+		}
+		day := records[i].FinishTime.UTC().Format(fileDayLayout)
+		byDay[day] = append(append(byDay[day], line...), '\n')
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for day, content := range byDay {
+		if err := appendFile(s.path(day), content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *FileStore) Query(ctx context.Context, q *Query) ([]Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	days, err := s.days()
+	if err != nil {
+		return nil, err
+	}
+	var records []Record
+	// The days are read from the most recent one, until the limit.
+	for _, day := range slices.Backward(days) {
+		if !q.Since.IsZero() && day < q.Since.UTC().Format(fileDayLayout) {
+			break
+		}
+		if !q.Until.IsZero() && day > q.Until.UTC().Format(fileDayLayout) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		dayRecords, err := readFile(s.path(day), q)
+		if err != nil {
+			return nil, err
+		}
+		slices.SortStableFunc(dayRecords, func(a, b Record) int { return b.FinishTime.Compare(a.FinishTime) })
+		records = append(records, dayRecords...)
+		if q.Limit > 0 && len(records) >= q.Limit {
+			return records[:q.Limit], nil
+		}
+	}
+	return records, nil
+}
+
+func (s *FileStore) Prune(_ context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	days, err := s.days()
+	if err != nil {
+		return err
+	}
+	oldest := before.UTC().Format(fileDayLayout)
+	for _, day := range days {
+		if day >= oldest {
+			break
+		}
+		if err := os.Remove(s.path(day)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *FileStore) Close() error {
+	return nil
+}
+
+func (s *FileStore) path(day string) string {
+	return filepath.Join(s.dir, filePrefix+day+fileSuffix)
+}
+
+// days returns the days of the files of the store, sorted.
+func (s *FileStore) days() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var days []string
+	for _, e := range entries {
+		day, found := strings.CutPrefix(e.Name(), filePrefix)
+		if !found || e.IsDir() {
+			continue
+		}
+		if day, found = strings.CutSuffix(day, fileSuffix); !found {
+			continue
+		}
+		if _, err := time.Parse(fileDayLayout, day); err == nil {
+			days = append(days, day)
+		}
+	}
+	slices.Sort(days)
+	return days, nil
+}
+
+func appendFile(path string, content []byte) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(content); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readFile returns the records of the file selected by the query.
+func readFile(path string, q *Query) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("decoding a record of %s: %w", path, err)
+		}
+		if q.Matches(&r) {
+			records = append(records, r)
+		}
+	}
+	return records, scanner.Err()
+}
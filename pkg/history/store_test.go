@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var baseTime = time.Date(2024, 10, 14, 10, 0, 0, 0, time.UTC)
+
+func testRecord(namespace, name, clusterQueue string, finishTime time.Time) Record {
+	return Record{
+		Namespace:    namespace,
+		Name:         name,
+		LocalQueue:   "lq",
+		ClusterQueue: clusterQueue,
+		CreationTime: finishTime.Add(-time.Hour),
+		FinishTime:   finishTime,
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	records := []Record{
+		testRecord("ns1", "a", "cq1", baseTime.Add(-48*time.Hour)),
+		testRecord("ns1", "b", "cq1", baseTime.Add(-24*time.Hour)),
+		testRecord("ns2", "c", "cq2", baseTime.Add(-23*time.Hour)),
+		testRecord("ns1", "d", "cq2", baseTime),
+		testRecord("ns2", "e", "cq1", baseTime.Add(time.Minute)),
+	}
+
+	cases := map[string]struct {
+		query       Query
+		pruneBefore time.Time
+		wantNames   []string
+	}{
+		"all the records, the most recent first": {
+			wantNames: []string{"e", "d", "c", "b", "a"},
+		},
+		"namespace": {
+			query:     Query{Namespace: "ns1"},
+			wantNames: []string{"d", "b", "a"},
+		},
+		"clusterQueue and localQueue": {
+			query:     Query{ClusterQueue: "cq2", LocalQueue: "lq"},
+			wantNames: []string{"d", "c"},
+		},
+		"time range": {
+			query:     Query{Since: baseTime.Add(-24 * time.Hour), Until: baseTime.Add(time.Minute)},
+			wantNames: []string{"d", "c", "b"},
+		},
+		"limit": {
+			query:     Query{Limit: 2},
+			wantNames: []string{"e", "d"},
+		},
+		"pruned": {
+			pruneBefore: baseTime.Add(-24 * time.Hour),
+			wantNames:   []string{"e", "d", "c", "b"},
+		},
+		"no records": {
+			query: Query{Namespace: "ns3"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "history")
+			store, err := NewFileStore(dir)
+			if err != nil {
+				t.Fatalf("Creating the store: %v", err)
+			}
+			// The records are appended in two batches, to check that the
+			// files are appended to.
+			if err := store.Append(ctx, records[:3]); err != nil {
+				t.Fatalf("Appending the records: %v", err)
+			}
+			if err := store.Append(ctx, records[3:]); err != nil {
+				t.Fatalf("Appending the records: %v", err)
+			}
+			// The other files of the directory are ignored.
+			if err := os.WriteFile(filepath.Join(dir, "README"), []byte("history"), 0o600); err != nil {
+				t.Fatalf("Writing a file: %v", err)
+			}
+			if !tc.pruneBefore.IsZero() {
+				if err := store.Prune(ctx, tc.pruneBefore); err != nil {
+					t.Fatalf("Pruning the records: %v", err)
+				}
+			}
+			got, err := store.Query(ctx, &tc.query)
+			if err != nil {
+				t.Fatalf("Querying the records: %v", err)
+			}
+			var gotNames []string
+			for _, r := range got {
+				gotNames = append(gotNames, r.Name)
+			}
+			if diff := cmp.Diff(tc.wantNames, gotNames); diff != "" {
+				t.Errorf("Unexpected records (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,10 +18,13 @@ package metrics
 
 import (
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -30,6 +33,7 @@ import (
 )
 
 type AdmissionResult string
+type AdmissionLatencyCause string
 type ClusterQueueStatus string
 
 type LocalQueueReference struct {
@@ -41,6 +45,17 @@ const (
 	AdmissionResultSuccess      AdmissionResult = "success"
 	AdmissionResultInadmissible AdmissionResult = "inadmissible"
 
+	// AdmissionLatencyQuotaWait is the time a workload waited in the queue
+	// until the scheduling cycle in which it got quota reservation.
+	AdmissionLatencyQuotaWait AdmissionLatencyCause = "quota_wait"
+	// AdmissionLatencyAdmissionCheckWait is the time a workload waited from the
+	// quota reservation until all its admission checks were ready.
+	AdmissionLatencyAdmissionCheckWait AdmissionLatencyCause = "admission_check_wait"
+	// AdmissionLatencySchedulingOverhead is the time spent by the scheduler
+	// from the start of the scheduling cycle until the quota reservation was
+	// applied.
+	AdmissionLatencySchedulingOverhead AdmissionLatencyCause = "scheduling_overhead"
+
 	PendingStatusActive       = "active"
 	PendingStatusInadmissible = "inadmissible"
 
@@ -62,6 +77,12 @@ const (
 var (
 	CQStatuses = []ClusterQueueStatus{CQStatusPending, CQStatusActive, CQStatusTerminating}
 
+	// localQueueSelector selects the LocalQueues for which the metrics are reported,
+	// and unselectedLocalQueues holds the observed LocalQueues which don't match it.
+	localQueuesLock       sync.RWMutex
+	localQueueSelector    = labels.Everything()
+	unselectedLocalQueues = sets.New[LocalQueueReference]()
+
 	// Metrics tied to the scheduler
 
 	AdmissionAttemptsTotal = prometheus.NewCounterVec(
@@ -87,6 +108,33 @@ The label 'result' can have the following values:
 		}, []string{"result"},
 	)
 
+	cohortSchedulingCycleDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cohort_scheduling_cycle_duration_seconds",
+			Help: `The time spent in an admission attempt evaluating the workloads of the ClusterQueues in the 'cohort',
+including the flavor assignment, the search of preemption targets and the admission.
+The ClusterQueues without a cohort are reported with an empty 'cohort'.`,
+		}, []string{"cohort"},
+	)
+
+	CohortSchedulingCycleWorkloadsEvaluatedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cohort_scheduling_cycle_workloads_evaluated_total",
+			Help:      "The total number of workloads evaluated in the admission attempts, per 'cohort'",
+		}, []string{"cohort"},
+	)
+
+	CohortSchedulingCycleWorkloadsSkippedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cohort_scheduling_cycle_workloads_skipped_total",
+			Help: `The total number of workloads which fit or could preempt in the admission attempts, but were skipped
+because another workload of the 'cohort' took the resources or the preemption targets first, per 'cohort'`,
+		}, []string{"cohort"},
+	)
+
 	AdmissionCyclePreemptionSkips = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Subsystem: constants.KueueName,
@@ -96,6 +144,19 @@ The label 'result' can have the following values:
 		}, []string{"cluster_queue"},
 	)
 
+	// Metrics tied to the garbage collector
+
+	GarbageCollectedObjectsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "garbage_collected_objects_total",
+			Help: `The total number of objects cleaned by the garbage collector, per 'kind' and 'reason'.
+'kind' can have the following values:
+- "Workload" with the reasons "OwnerDeleted" and "PodGroupDeleted", when the owners of the workload are deleted.
+- "ProvisioningRequest" with the reasons "WorkloadDeleted" and "WorkloadFinished".`,
+		}, []string{"kind", "reason"},
+	)
+
 	// Metrics tied to the queue system.
 
 	PendingWorkloads = prometheus.NewGaugeVec(
@@ -206,6 +267,28 @@ The label 'result' can have the following values:
 		}, []string{"name", "namespace"},
 	)
 
+	admissionLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: constants.KueueName,
+			Name:      "admission_latency_seconds",
+			Help: `The time spent by a workload until admission, per 'cluster_queue', 'priority_class' and 'cause'.
+The label 'cause' can have the following values:
+- "quota_wait" means the time between a workload was created or requeued until the scheduling cycle in which it got quota reservation.
+- "admission_check_wait" means the time from when a workload got the quota reservation until all its admission checks were ready.
+- "scheduling_overhead" means the time from the start of the scheduling cycle until the quota reservation was applied.`,
+			Buckets: generateExponentialBuckets(14),
+		}, []string{"cluster_queue", "priority_class", "cause"},
+	)
+
+	timeToAdmission = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: constants.KueueName,
+			Name:      "time_to_admission_seconds",
+			Help:      "The time between a workload was created or requeued until admission, per 'cluster_queue' and 'priority_class'",
+			Buckets:   generateExponentialBuckets(14),
+		}, []string{"cluster_queue", "priority_class"},
+	)
+
 	EvictedWorkloadsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Subsystem: constants.KueueName,
@@ -220,6 +303,23 @@ The label 'reason' can have the following values:
 		}, []string{"cluster_queue", "reason"},
 	)
 
+	EvictedWorkloadsByCategoryTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "evicted_workloads_by_category_total",
+			Help: `The number of evicted workloads per 'cluster_queue' and 'category',
+The label 'category' can have the following values:
+- "PodsReadyTimeout" means that the pods of the workload didn't become ready within the timeout.
+- "PreemptedByReclaim" means that the workload was preempted to reclaim the quota lent to the cohort, or the fair share of another ClusterQueue.
+- "PreemptedByPriority" means that the workload was preempted by a workload with a higher priority in the same ClusterQueue.
+- "AdminStop" means that the workload was evicted by stopping its queues, shrinking the quota or deactivating the workload.
+- "SpotReclaim" means that one of the nodes of the workload was about to be interrupted.
+- "NodeFailure" means that one of the nodes of the workload was not ready or unreachable.
+- "ChecksFailed" means that an admission check of the workload failed or was rejected.
+- "Other" means that the workload was evicted for another reason`,
+		}, []string{"cluster_queue", "category"},
+	)
+
 	LocalQueueEvictedWorkloadsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Subsystem: constants.KueueName,
@@ -247,6 +347,43 @@ The label 'reason' can have the following values:
 		}, []string{"preempting_cluster_queue", "reason"},
 	)
 
+	ShadowPreemptionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "shadow_preemptions_total",
+			Help: `The number of workloads that would have been preempted per 'preempting_cluster_queue',
+when the ClusterQueue of the preempting workload is in shadow mode.
+The label 'reason' has the same values as in preempted_workloads_total.`,
+		}, []string{"preempting_cluster_queue", "reason"},
+	)
+
+	PreemptedWorkloadsByTargetTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "preempted_workloads_by_target_total",
+			Help: `The number of preempted workloads per 'preempting_cluster_queue', 'target_cluster_queue' and 'reason'.
+The label 'reason' can have the following values:
+- "InClusterQueue" means that the workload was preempted by a workload in the same ClusterQueue.
+- "InCohortReclamation" means that the workload was preempted by a workload in the same cohort due to reclamation of nominal quota.
+- "InCohortFairSharing" means that the workload was preempted by a workload in the same cohort due to fair sharing.
+- "InCohortReclaimWhileBorrowing" means that the workload was preempted by a workload in the same cohort due to reclamation of nominal quota while borrowing.`,
+		}, []string{"preempting_cluster_queue", "target_cluster_queue", "reason"},
+	)
+
+	preemptedWorkloadRuntime = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: constants.KueueName,
+			Name:      "preempted_workload_runtime_seconds",
+			Help: `The time between a workload got quota reservation until it was preempted, per 'preempting_cluster_queue', 'target_cluster_queue' and 'reason'.
+The label 'reason' can have the following values:
+- "InClusterQueue" means that the workload was preempted by a workload in the same ClusterQueue.
+- "InCohortReclamation" means that the workload was preempted by a workload in the same cohort due to reclamation of nominal quota.
+- "InCohortFairSharing" means that the workload was preempted by a workload in the same cohort due to fair sharing.
+- "InCohortReclaimWhileBorrowing" means that the workload was preempted by a workload in the same cohort due to reclamation of nominal quota while borrowing.`,
+			Buckets: generateExponentialBuckets(14),
+		}, []string{"preempting_cluster_queue", "target_cluster_queue", "reason"},
+	)
+
 	// Metrics tied to the cache.
 
 	ReservingActiveWorkloads = prometheus.NewGaugeVec(
@@ -309,6 +446,14 @@ For a LocalQueue, the metric only reports a value of 1 for one of the statuses.`
 		}, []string{"cohort", "cluster_queue", "flavor", "resource"},
 	)
 
+	ClusterQueueResourceBorrowed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cluster_queue_resource_borrowed",
+			Help:      `Reports the cluster_queue's resource reservation above the nominal quota, borrowed from the cohort, within all the flavors`,
+		}, []string{"cohort", "cluster_queue", "flavor", "resource"},
+	)
+
 	ClusterQueueResourceUsage = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Subsystem: constants.KueueName,
@@ -357,6 +502,33 @@ For a LocalQueue, the metric only reports a value of 1 for one of the statuses.`
 		}, []string{"cohort", "cluster_queue", "flavor", "resource"},
 	)
 
+	ClusterQueueResourceLendable = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cluster_queue_resource_lendable",
+			Help:      `Reports the cluster_queue's nominal quota which can be lent to the cohort, constrained by the lending limit, within all the flavors`,
+		}, []string{"cohort", "cluster_queue", "flavor", "resource"},
+	)
+
+	CohortSubtreeQuota = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cohort_subtree_quota",
+			Help: `Reports the cohort's quota, including the quota lent by the cluster_queues and cohorts
+in its subtree, within all the flavors`,
+		}, []string{"cohort", "flavor", "resource"},
+	)
+
+	CohortSubtreeResourceUsage = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cohort_subtree_resource_usage",
+			Help: `Reports the usage counting against the cohort's subtree quota, that is the
+resource reservations of the cluster_queues and cohorts in its subtree above their
+unlendable quota, within all the flavors`,
+		}, []string{"cohort", "flavor", "resource"},
+	)
+
 	ClusterQueueWeightedShare = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Subsystem: constants.KueueName,
@@ -380,12 +552,24 @@ func AdmissionAttempt(result AdmissionResult, duration time.Duration) {
 	admissionAttemptDuration.WithLabelValues(string(result)).Observe(duration.Seconds())
 }
 
+// ReportCohortSchedulingCycle records the time spent evaluating the workloads
+// of the cohort in an admission attempt, along with the number of workloads
+// evaluated and skipped.
+func ReportCohortSchedulingCycle(cohort string, duration time.Duration, evaluated, skipped int) {
+	cohortSchedulingCycleDuration.WithLabelValues(cohort).Observe(duration.Seconds())
+	CohortSchedulingCycleWorkloadsEvaluatedTotal.WithLabelValues(cohort).Add(float64(evaluated))
+	CohortSchedulingCycleWorkloadsSkippedTotal.WithLabelValues(cohort).Add(float64(skipped))
+}
+
 func QuotaReservedWorkload(cqName kueue.ClusterQueueReference, waitTime time.Duration) {
 	QuotaReservedWorkloadsTotal.WithLabelValues(string(cqName)).Inc()
 	quotaReservedWaitTime.WithLabelValues(string(cqName)).Observe(waitTime.Seconds())
 }
 
 func LocalQueueQuotaReservedWorkload(lq LocalQueueReference, waitTime time.Duration) {
+	if !localQueueSelected(lq) {
+		return
+	}
 	LocalQueueQuotaReservedWorkloadsTotal.WithLabelValues(lq.Name, lq.Namespace).Inc()
 	localQueueQuotaReservedWaitTime.WithLabelValues(lq.Name, lq.Namespace).Observe(waitTime.Seconds())
 }
@@ -396,6 +580,9 @@ func AdmittedWorkload(cqName kueue.ClusterQueueReference, waitTime time.Duration
 }
 
 func LocalQueueAdmittedWorkload(lq LocalQueueReference, waitTime time.Duration) {
+	if !localQueueSelected(lq) {
+		return
+	}
 	LocalQueueAdmittedWorkloadsTotal.WithLabelValues(lq.Name, lq.Namespace).Inc()
 	localQueueAdmissionWaitTime.WithLabelValues(lq.Name, lq.Namespace).Observe(waitTime.Seconds())
 }
@@ -405,15 +592,37 @@ func AdmissionChecksWaitTime(cqName kueue.ClusterQueueReference, waitTime time.D
 }
 
 func LocalQueueAdmissionChecksWaitTime(lq LocalQueueReference, waitTime time.Duration) {
+	if !localQueueSelected(lq) {
+		return
+	}
 	localQueueAdmissionChecksWaitTime.WithLabelValues(lq.Name, lq.Namespace).Observe(waitTime.Seconds())
 }
 
+// ReportAdmissionLatency records the time spent by a workload of the priority
+// class until admission due to the cause.
+func ReportAdmissionLatency(cqName kueue.ClusterQueueReference, priorityClass string, cause AdmissionLatencyCause, latency time.Duration) {
+	admissionLatency.WithLabelValues(string(cqName), priorityClass, string(cause)).Observe(latency.Seconds())
+}
+
+// ReportTimeToAdmission records the time between a workload of the priority
+// class was queued until admission.
+func ReportTimeToAdmission(cqName kueue.ClusterQueueReference, priorityClass string, waitTime time.Duration) {
+	timeToAdmission.WithLabelValues(string(cqName), priorityClass).Observe(waitTime.Seconds())
+}
+
+func ReportGarbageCollectedObject(kind, reason string) {
+	GarbageCollectedObjectsTotal.WithLabelValues(kind, reason).Inc()
+}
+
 func ReportPendingWorkloads(cqName string, active, inadmissible int) {
 	PendingWorkloads.WithLabelValues(cqName, PendingStatusActive).Set(float64(active))
 	PendingWorkloads.WithLabelValues(cqName, PendingStatusInadmissible).Set(float64(inadmissible))
 }
 
 func ReportLocalQueuePendingWorkloads(lq LocalQueueReference, active, inadmissible int) {
+	if !localQueueSelected(lq) {
+		return
+	}
 	LocalQueuePendingWorkloads.WithLabelValues(lq.Name, lq.Namespace, PendingStatusActive).Set(float64(active))
 	LocalQueuePendingWorkloads.WithLabelValues(lq.Name, lq.Namespace, PendingStatusInadmissible).Set(float64(inadmissible))
 }
@@ -422,15 +631,32 @@ func ReportEvictedWorkloads(cqName, reason string) {
 	EvictedWorkloadsTotal.WithLabelValues(cqName, reason).Inc()
 }
 
+// ReportEvictionCategory records the category of the eviction of a workload
+// from the ClusterQueue.
+func ReportEvictionCategory(cqName string, category kueue.EvictionReasonCategory) {
+	EvictedWorkloadsByCategoryTotal.WithLabelValues(cqName, string(category)).Inc()
+}
+
 func ReportLocalQueueEvictedWorkloads(lq LocalQueueReference, reason string) {
+	if !localQueueSelected(lq) {
+		return
+	}
 	LocalQueueEvictedWorkloadsTotal.WithLabelValues(lq.Name, lq.Namespace, reason).Inc()
 }
 
-func ReportPreemption(preemptingCqName, preemptingReason, targetCqName string) {
+func ReportPreemption(preemptingCqName, preemptingReason, targetCqName string, targetRuntime time.Duration) {
 	PreemptedWorkloadsTotal.WithLabelValues(preemptingCqName, preemptingReason).Inc()
+	PreemptedWorkloadsByTargetTotal.WithLabelValues(preemptingCqName, targetCqName, preemptingReason).Inc()
+	preemptedWorkloadRuntime.WithLabelValues(preemptingCqName, targetCqName, preemptingReason).Observe(targetRuntime.Seconds())
 	ReportEvictedWorkloads(targetCqName, kueue.WorkloadEvictedByPreemption)
 }
 
+// ReportShadowPreemption records a preemption that was not issued because
+// the ClusterQueue of the preempting workload is in shadow mode.
+func ReportShadowPreemption(preemptingCqName, preemptingReason string) {
+	ShadowPreemptionsTotal.WithLabelValues(preemptingCqName, preemptingReason).Inc()
+}
+
 func LQRefFromWorkload(wl *kueue.Workload) LocalQueueReference {
 	return LocalQueueReference{
 		Name:      wl.Spec.QueueName,
@@ -455,8 +681,60 @@ func ClearClusterQueueMetrics(cqName string) {
 	AdmittedWorkloadsTotal.DeleteLabelValues(cqName)
 	admissionWaitTime.DeleteLabelValues(cqName)
 	admissionChecksWaitTime.DeleteLabelValues(cqName)
+	admissionLatency.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
+	timeToAdmission.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
 	EvictedWorkloadsTotal.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
+	EvictedWorkloadsByCategoryTotal.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
 	PreemptedWorkloadsTotal.DeletePartialMatch(prometheus.Labels{"preempting_cluster_queue": cqName})
+	ShadowPreemptionsTotal.DeletePartialMatch(prometheus.Labels{"preempting_cluster_queue": cqName})
+	PreemptedWorkloadsByTargetTotal.DeletePartialMatch(prometheus.Labels{"preempting_cluster_queue": cqName})
+	PreemptedWorkloadsByTargetTotal.DeletePartialMatch(prometheus.Labels{"target_cluster_queue": cqName})
+	preemptedWorkloadRuntime.DeletePartialMatch(prometheus.Labels{"preempting_cluster_queue": cqName})
+	preemptedWorkloadRuntime.DeletePartialMatch(prometheus.Labels{"target_cluster_queue": cqName})
+}
+
+// SetLocalQueueSelector restricts the metrics reported per LocalQueue to the
+// LocalQueues matching the selector.
+func SetLocalQueueSelector(selector labels.Selector) {
+	localQueuesLock.Lock()
+	defer localQueuesLock.Unlock()
+	localQueueSelector = selector
+}
+
+// ObserveLocalQueue records whether the LocalQueue matches the selector of the
+// LocalQueues for which the metrics are reported. When the LocalQueue stops
+// matching it, its metrics are cleared. It returns true if the LocalQueue
+// started matching the selector.
+func ObserveLocalQueue(lq *kueue.LocalQueue) bool {
+	ref := LocalQueueReference{Name: lq.Name, Namespace: lq.Namespace}
+	localQueuesLock.Lock()
+	defer localQueuesLock.Unlock()
+	wasSelected := !unselectedLocalQueues.Has(ref)
+	if localQueueSelector.Matches(labels.Set(lq.Labels)) {
+		unselectedLocalQueues.Delete(ref)
+		return !wasSelected
+	}
+	unselectedLocalQueues.Insert(ref)
+	if wasSelected {
+		ClearLocalQueueMetrics(ref)
+		ClearLocalQueueCacheMetrics(ref)
+		ClearLocalQueueResourceMetrics(ref)
+	}
+	return false
+}
+
+// ForgetLocalQueue stops tracking whether the deleted LocalQueue matches the
+// selector of the LocalQueues for which the metrics are reported.
+func ForgetLocalQueue(lq LocalQueueReference) {
+	localQueuesLock.Lock()
+	defer localQueuesLock.Unlock()
+	unselectedLocalQueues.Delete(lq)
+}
+
+func localQueueSelected(lq LocalQueueReference) bool {
+	localQueuesLock.RLock()
+	defer localQueuesLock.RUnlock()
+	return !unselectedLocalQueues.Has(lq)
 }
 
 func ClearLocalQueueMetrics(lq LocalQueueReference) {
@@ -485,6 +763,9 @@ var (
 )
 
 func ReportLocalQueueStatus(lq LocalQueueReference, conditionStatus metav1.ConditionStatus) {
+	if !localQueueSelected(lq) {
+		return
+	}
 	for _, status := range ConditionStatusValues {
 		var v float64
 		if status == conditionStatus {
@@ -502,6 +783,14 @@ func ClearCacheMetrics(cqName string) {
 	}
 }
 
+func ReportLocalQueueActiveWorkloads(lq LocalQueueReference, reserving, admitted int) {
+	if !localQueueSelected(lq) {
+		return
+	}
+	LocalQueueReservingActiveWorkloads.WithLabelValues(lq.Name, lq.Namespace).Set(float64(reserving))
+	LocalQueueAdmittedActiveWorkloads.WithLabelValues(lq.Name, lq.Namespace).Set(float64(admitted))
+}
+
 func ClearLocalQueueCacheMetrics(lq LocalQueueReference) {
 	LocalQueueReservingActiveWorkloads.DeleteLabelValues(lq.Name, lq.Namespace)
 	LocalQueueAdmittedActiveWorkloads.DeleteLabelValues(lq.Name, lq.Namespace)
@@ -510,19 +799,29 @@ func ClearLocalQueueCacheMetrics(lq LocalQueueReference) {
 	}
 }
 
-func ReportClusterQueueQuotas(cohort, queue, flavor, resource string, nominal, borrowing, lending float64) {
+func ReportClusterQueueQuotas(cohort, queue, flavor, resource string, nominal, borrowing, lending, lendable float64) {
 	ClusterQueueResourceNominalQuota.WithLabelValues(cohort, queue, flavor, resource).Set(nominal)
 	ClusterQueueResourceBorrowingLimit.WithLabelValues(cohort, queue, flavor, resource).Set(borrowing)
 	if features.Enabled(features.LendingLimit) {
 		ClusterQueueResourceLendingLimit.WithLabelValues(cohort, queue, flavor, resource).Set(lending)
 	}
+	ClusterQueueResourceLendable.WithLabelValues(cohort, queue, flavor, resource).Set(lendable)
 }
 
-func ReportClusterQueueResourceReservations(cohort, queue, flavor, resource string, usage float64) {
+func ReportClusterQueueResourceReservations(cohort, queue, flavor, resource string, usage, borrowed float64) {
 	ClusterQueueResourceReservations.WithLabelValues(cohort, queue, flavor, resource).Set(usage)
+	ClusterQueueResourceBorrowed.WithLabelValues(cohort, queue, flavor, resource).Set(borrowed)
+}
+
+func ReportCohortSubtreeResources(cohort, flavor, resource string, quota, usage float64) {
+	CohortSubtreeQuota.WithLabelValues(cohort, flavor, resource).Set(quota)
+	CohortSubtreeResourceUsage.WithLabelValues(cohort, flavor, resource).Set(usage)
 }
 
 func ReportLocalQueueResourceReservations(lq LocalQueueReference, flavor, resource string, usage float64) {
+	if !localQueueSelected(lq) {
+		return
+	}
 	LocalQueueResourceReservations.WithLabelValues(lq.Name, lq.Namespace, flavor, resource).Set(usage)
 }
 
@@ -531,6 +830,9 @@ func ReportClusterQueueResourceUsage(cohort, queue, flavor, resource string, usa
 }
 
 func ReportLocalQueueResourceUsage(lq LocalQueueReference, flavor, resource string, usage float64) {
+	if !localQueueSelected(lq) {
+		return
+	}
 	LocalQueueResourceUsage.WithLabelValues(lq.Name, lq.Namespace, flavor, resource).Set(usage)
 }
 
@@ -547,8 +849,21 @@ func ClearClusterQueueResourceMetrics(cqName string) {
 	if features.Enabled(features.LendingLimit) {
 		ClusterQueueResourceLendingLimit.DeletePartialMatch(lbls)
 	}
+	ClusterQueueResourceLendable.DeletePartialMatch(lbls)
 	ClusterQueueResourceUsage.DeletePartialMatch(lbls)
 	ClusterQueueResourceReservations.DeletePartialMatch(lbls)
+	ClusterQueueResourceBorrowed.DeletePartialMatch(lbls)
+}
+
+func ClearCohortMetrics(cohortName string) {
+	lbls := prometheus.Labels{
+		"cohort": cohortName,
+	}
+	CohortSubtreeQuota.DeletePartialMatch(lbls)
+	CohortSubtreeResourceUsage.DeletePartialMatch(lbls)
+	cohortSchedulingCycleDuration.DeleteLabelValues(cohortName)
+	CohortSchedulingCycleWorkloadsEvaluatedTotal.DeleteLabelValues(cohortName)
+	CohortSchedulingCycleWorkloadsSkippedTotal.DeleteLabelValues(cohortName)
 }
 
 func ClearLocalQueueResourceMetrics(lq LocalQueueReference) {
@@ -575,6 +890,7 @@ func ClearClusterQueueResourceQuotas(cqName, flavor, resource string) {
 	if features.Enabled(features.LendingLimit) {
 		ClusterQueueResourceLendingLimit.DeletePartialMatch(lbls)
 	}
+	ClusterQueueResourceLendable.DeletePartialMatch(lbls)
 }
 
 func ClearClusterQueueResourceUsage(cqName, flavor, resource string) {
@@ -601,6 +917,7 @@ func ClearClusterQueueResourceReservations(cqName, flavor, resource string) {
 	}
 
 	ClusterQueueResourceReservations.DeletePartialMatch(lbls)
+	ClusterQueueResourceBorrowed.DeletePartialMatch(lbls)
 }
 
 func Register() {
@@ -608,6 +925,10 @@ func Register() {
 		AdmissionAttemptsTotal,
 		admissionAttemptDuration,
 		AdmissionCyclePreemptionSkips,
+		cohortSchedulingCycleDuration,
+		CohortSchedulingCycleWorkloadsEvaluatedTotal,
+		CohortSchedulingCycleWorkloadsSkippedTotal,
+		GarbageCollectedObjectsTotal,
 		PendingWorkloads,
 		ReservingActiveWorkloads,
 		AdmittedActiveWorkloads,
@@ -615,16 +936,26 @@ func Register() {
 		quotaReservedWaitTime,
 		AdmittedWorkloadsTotal,
 		EvictedWorkloadsTotal,
+		EvictedWorkloadsByCategoryTotal,
 		PreemptedWorkloadsTotal,
+		ShadowPreemptionsTotal,
+		PreemptedWorkloadsByTargetTotal,
+		preemptedWorkloadRuntime,
 		admissionWaitTime,
 		admissionChecksWaitTime,
+		admissionLatency,
+		timeToAdmission,
 		ClusterQueueResourceUsage,
 		ClusterQueueByStatus,
 		ClusterQueueResourceReservations,
 		ClusterQueueResourceNominalQuota,
 		ClusterQueueResourceBorrowingLimit,
 		ClusterQueueResourceLendingLimit,
+		ClusterQueueResourceBorrowed,
+		ClusterQueueResourceLendable,
 		ClusterQueueWeightedShare,
+		CohortSubtreeQuota,
+		CohortSubtreeResourceUsage,
 	)
 	if features.Enabled(features.LocalQueueMetrics) {
 		RegisterLQMetrics()
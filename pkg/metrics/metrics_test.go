@@ -18,10 +18,14 @@ package metrics
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/util/testing/metrics"
 )
 
@@ -46,20 +50,22 @@ func TestGenerateExponentialBuckets(t *testing.T) {
 }
 
 func TestReportAndCleanupClusterQueueMetrics(t *testing.T) {
-	ReportClusterQueueQuotas("cohort", "queue", "flavor", "res", 5, 10, 3)
-	ReportClusterQueueQuotas("cohort", "queue", "flavor2", "res", 1, 2, 1)
+	ReportClusterQueueQuotas("cohort", "queue", "flavor", "res", 5, 10, 3, 3)
+	ReportClusterQueueQuotas("cohort", "queue", "flavor2", "res", 1, 2, 1, 1)
 
 	expectFilteredMetricsCount(t, ClusterQueueResourceNominalQuota, 2, "cluster_queue", "queue")
 	expectFilteredMetricsCount(t, ClusterQueueResourceBorrowingLimit, 2, "cluster_queue", "queue")
 	expectFilteredMetricsCount(t, ClusterQueueResourceLendingLimit, 2, "cluster_queue", "queue")
 
-	ReportClusterQueueResourceReservations("cohort", "queue", "flavor", "res", 7)
-	ReportClusterQueueResourceReservations("cohort", "queue", "flavor2", "res", 3)
+	ReportClusterQueueResourceReservations("cohort", "queue", "flavor", "res", 7, 0)
+	ReportClusterQueueResourceReservations("cohort", "queue", "flavor2", "res", 3, 0)
 
 	ReportClusterQueueResourceUsage("cohort", "queue", "flavor", "res", 7)
 	ReportClusterQueueResourceUsage("cohort", "queue", "flavor2", "res", 3)
 
+	expectFilteredMetricsCount(t, ClusterQueueResourceLendable, 2, "cluster_queue", "queue")
 	expectFilteredMetricsCount(t, ClusterQueueResourceReservations, 2, "cluster_queue", "queue")
+	expectFilteredMetricsCount(t, ClusterQueueResourceBorrowed, 2, "cluster_queue", "queue")
 	expectFilteredMetricsCount(t, ClusterQueueResourceUsage, 2, "cluster_queue", "queue")
 
 	ClearClusterQueueResourceMetrics("queue")
@@ -67,15 +73,33 @@ func TestReportAndCleanupClusterQueueMetrics(t *testing.T) {
 	expectFilteredMetricsCount(t, ClusterQueueResourceNominalQuota, 0, "cluster_queue", "queue")
 	expectFilteredMetricsCount(t, ClusterQueueResourceBorrowingLimit, 0, "cluster_queue", "queue")
 	expectFilteredMetricsCount(t, ClusterQueueResourceLendingLimit, 0, "cluster_queue", "queue")
+	expectFilteredMetricsCount(t, ClusterQueueResourceLendable, 0, "cluster_queue", "queue")
 	expectFilteredMetricsCount(t, ClusterQueueResourceReservations, 0, "cluster_queue", "queue")
+	expectFilteredMetricsCount(t, ClusterQueueResourceBorrowed, 0, "cluster_queue", "queue")
 	expectFilteredMetricsCount(t, ClusterQueueResourceUsage, 0, "cluster_queue", "queue")
 }
 
+func TestReportAndCleanupCohortMetrics(t *testing.T) {
+	ReportCohortSubtreeResources("cohort", "flavor", "res", 10, 4)
+	ReportCohortSubtreeResources("cohort", "flavor2", "res", 5, 0)
+	ReportCohortSubtreeResources("other-cohort", "flavor", "res", 3, 1)
+
+	expectFilteredMetricsCount(t, CohortSubtreeQuota, 2, "cohort", "cohort")
+	expectFilteredMetricsCount(t, CohortSubtreeResourceUsage, 2, "cohort", "cohort")
+
+	ClearCohortMetrics("cohort")
+
+	expectFilteredMetricsCount(t, CohortSubtreeQuota, 0, "cohort", "cohort")
+	expectFilteredMetricsCount(t, CohortSubtreeResourceUsage, 0, "cohort", "cohort")
+	expectFilteredMetricsCount(t, CohortSubtreeQuota, 1, "cohort", "other-cohort")
+	expectFilteredMetricsCount(t, CohortSubtreeResourceUsage, 1, "cohort", "other-cohort")
+}
+
 func TestReportAndCleanupClusterQueueQuotas(t *testing.T) {
-	ReportClusterQueueQuotas("cohort", "queue", "flavor", "res", 5, 10, 3)
-	ReportClusterQueueQuotas("cohort", "queue", "flavor", "res2", 5, 10, 3)
-	ReportClusterQueueQuotas("cohort", "queue", "flavor2", "res", 1, 2, 1)
-	ReportClusterQueueQuotas("cohort", "queue", "flavor2", "res2", 1, 2, 1)
+	ReportClusterQueueQuotas("cohort", "queue", "flavor", "res", 5, 10, 3, 3)
+	ReportClusterQueueQuotas("cohort", "queue", "flavor", "res2", 5, 10, 3, 3)
+	ReportClusterQueueQuotas("cohort", "queue", "flavor2", "res", 1, 2, 1, 1)
+	ReportClusterQueueQuotas("cohort", "queue", "flavor2", "res2", 1, 2, 1, 1)
 
 	expectFilteredMetricsCount(t, ClusterQueueResourceNominalQuota, 4, "cluster_queue", "queue")
 	expectFilteredMetricsCount(t, ClusterQueueResourceBorrowingLimit, 4, "cluster_queue", "queue")
@@ -105,10 +129,10 @@ func TestReportAndCleanupClusterQueueQuotas(t *testing.T) {
 }
 
 func TestReportAndCleanupClusterQueueUsage(t *testing.T) {
-	ReportClusterQueueResourceReservations("cohort", "queue", "flavor", "res", 5)
-	ReportClusterQueueResourceReservations("cohort", "queue", "flavor", "res2", 5)
-	ReportClusterQueueResourceReservations("cohort", "queue", "flavor2", "res", 1)
-	ReportClusterQueueResourceReservations("cohort", "queue", "flavor2", "res2", 1)
+	ReportClusterQueueResourceReservations("cohort", "queue", "flavor", "res", 5, 0)
+	ReportClusterQueueResourceReservations("cohort", "queue", "flavor", "res2", 5, 0)
+	ReportClusterQueueResourceReservations("cohort", "queue", "flavor2", "res", 1, 0)
+	ReportClusterQueueResourceReservations("cohort", "queue", "flavor2", "res2", 1, 0)
 
 	expectFilteredMetricsCount(t, ClusterQueueResourceReservations, 4, "cluster_queue", "queue")
 
@@ -156,11 +180,23 @@ func TestReportAndCleanupClusterQueueEvictedNumber(t *testing.T) {
 	expectFilteredMetricsCount(t, EvictedWorkloadsTotal, 0, "cluster_queue", "cluster_queue1")
 }
 
+func TestReportAndCleanupClusterQueueEvictionCategories(t *testing.T) {
+	ReportEvictionCategory("cluster_queue1", kueue.EvictionCategoryPodsReadyTimeout)
+	ReportEvictionCategory("cluster_queue1", kueue.EvictionCategorySpotReclaim)
+
+	expectFilteredMetricsCount(t, EvictedWorkloadsByCategoryTotal, 2, "cluster_queue", "cluster_queue1")
+	expectFilteredMetricsCount(t, EvictedWorkloadsByCategoryTotal, 1, "cluster_queue", "cluster_queue1", "category", "PodsReadyTimeout")
+	expectFilteredMetricsCount(t, EvictedWorkloadsByCategoryTotal, 1, "cluster_queue", "cluster_queue1", "category", "SpotReclaim")
+
+	ClearClusterQueueMetrics("cluster_queue1")
+	expectFilteredMetricsCount(t, EvictedWorkloadsByCategoryTotal, 0, "cluster_queue", "cluster_queue1")
+}
+
 func TestReportAndCleanupClusterQueuePreemptedNumber(t *testing.T) {
-	ReportPreemption("cluster_queue1", "InClusterQueue", "cluster_queue1")
-	ReportPreemption("cluster_queue1", "InCohortReclamation", "cluster_queue1")
-	ReportPreemption("cluster_queue1", "InCohortFairSharing", "cluster_queue1")
-	ReportPreemption("cluster_queue1", "InCohortReclaimWhileBorrowing", "cluster_queue1")
+	ReportPreemption("cluster_queue1", "InClusterQueue", "cluster_queue1", time.Minute)
+	ReportPreemption("cluster_queue1", "InCohortReclamation", "cluster_queue1", time.Minute)
+	ReportPreemption("cluster_queue1", "InCohortFairSharing", "cluster_queue1", time.Minute)
+	ReportPreemption("cluster_queue1", "InCohortReclaimWhileBorrowing", "cluster_queue1", time.Minute)
 
 	expectFilteredMetricsCount(t, PreemptedWorkloadsTotal, 4, "preempting_cluster_queue", "cluster_queue1")
 	expectFilteredMetricsCount(t, EvictedWorkloadsTotal, 1, "cluster_queue", "cluster_queue1")
@@ -173,3 +209,107 @@ func TestReportAndCleanupClusterQueuePreemptedNumber(t *testing.T) {
 	expectFilteredMetricsCount(t, PreemptedWorkloadsTotal, 0, "preempting_cluster_queue", "cluster_queue1")
 	expectFilteredMetricsCount(t, EvictedWorkloadsTotal, 0, "cluster_queue", "cluster_queue1")
 }
+
+func TestReportAndCleanupClusterQueuePreemptedByTarget(t *testing.T) {
+	ReportPreemption("cluster_queue1", "InClusterQueue", "cluster_queue1", time.Minute)
+	ReportPreemption("cluster_queue1", "InCohortReclamation", "cluster_queue2", time.Hour)
+	ReportPreemption("cluster_queue2", "InCohortFairSharing", "cluster_queue1", time.Second)
+
+	expectFilteredMetricsCount(t, PreemptedWorkloadsByTargetTotal, 2, "preempting_cluster_queue", "cluster_queue1")
+	expectFilteredMetricsCount(t, PreemptedWorkloadsByTargetTotal, 2, "target_cluster_queue", "cluster_queue1")
+	expectFilteredMetricsCount(t, PreemptedWorkloadsByTargetTotal, 1, "preempting_cluster_queue", "cluster_queue1", "target_cluster_queue", "cluster_queue2", "reason", "InCohortReclamation")
+	expectFilteredMetricsCount(t, preemptedWorkloadRuntime, 2, "preempting_cluster_queue", "cluster_queue1")
+	expectFilteredMetricsCount(t, preemptedWorkloadRuntime, 1, "preempting_cluster_queue", "cluster_queue2", "target_cluster_queue", "cluster_queue1", "reason", "InCohortFairSharing")
+
+	ClearClusterQueueMetrics("cluster_queue1")
+	expectFilteredMetricsCount(t, PreemptedWorkloadsByTargetTotal, 0, "preempting_cluster_queue", "cluster_queue1")
+	expectFilteredMetricsCount(t, PreemptedWorkloadsByTargetTotal, 0, "target_cluster_queue", "cluster_queue1")
+	expectFilteredMetricsCount(t, preemptedWorkloadRuntime, 0, "preempting_cluster_queue", "cluster_queue1")
+	expectFilteredMetricsCount(t, preemptedWorkloadRuntime, 0, "target_cluster_queue", "cluster_queue1")
+
+	ClearClusterQueueMetrics("cluster_queue2")
+}
+
+func TestReportAndCleanupCohortSchedulingCycle(t *testing.T) {
+	ReportCohortSchedulingCycle("cohort1", time.Millisecond, 3, 1)
+	ReportCohortSchedulingCycle("cohort2", time.Millisecond, 1, 0)
+
+	expectFilteredMetricsCount(t, cohortSchedulingCycleDuration, 1, "cohort", "cohort1")
+	expectFilteredMetricsCount(t, CohortSchedulingCycleWorkloadsEvaluatedTotal, 1, "cohort", "cohort1")
+	expectFilteredMetricsCount(t, CohortSchedulingCycleWorkloadsSkippedTotal, 1, "cohort", "cohort1")
+
+	ClearCohortMetrics("cohort1")
+	expectFilteredMetricsCount(t, cohortSchedulingCycleDuration, 0, "cohort", "cohort1")
+	expectFilteredMetricsCount(t, CohortSchedulingCycleWorkloadsEvaluatedTotal, 0, "cohort", "cohort1")
+	expectFilteredMetricsCount(t, CohortSchedulingCycleWorkloadsSkippedTotal, 0, "cohort", "cohort1")
+	expectFilteredMetricsCount(t, CohortSchedulingCycleWorkloadsEvaluatedTotal, 1, "cohort", "cohort2")
+
+	ClearCohortMetrics("cohort2")
+}
+
+func TestReportAndCleanupClusterQueueAdmissionLatency(t *testing.T) {
+	ReportAdmissionLatency("cluster_queue1", "high", AdmissionLatencyQuotaWait, time.Minute)
+	ReportAdmissionLatency("cluster_queue1", "high", AdmissionLatencySchedulingOverhead, time.Second)
+	ReportAdmissionLatency("cluster_queue1", "high", AdmissionLatencyAdmissionCheckWait, time.Minute)
+	ReportAdmissionLatency("cluster_queue1", "low", AdmissionLatencyQuotaWait, time.Hour)
+	ReportTimeToAdmission("cluster_queue1", "high", 2*time.Minute)
+	ReportTimeToAdmission("cluster_queue1", "low", time.Hour)
+
+	expectFilteredMetricsCount(t, admissionLatency, 4, "cluster_queue", "cluster_queue1")
+	expectFilteredMetricsCount(t, admissionLatency, 3, "cluster_queue", "cluster_queue1", "priority_class", "high")
+	expectFilteredMetricsCount(t, admissionLatency, 2, "cluster_queue", "cluster_queue1", "cause", "quota_wait")
+	expectFilteredMetricsCount(t, timeToAdmission, 2, "cluster_queue", "cluster_queue1")
+	expectFilteredMetricsCount(t, timeToAdmission, 1, "cluster_queue", "cluster_queue1", "priority_class", "low")
+
+	ClearClusterQueueMetrics("cluster_queue1")
+	expectFilteredMetricsCount(t, admissionLatency, 0, "cluster_queue", "cluster_queue1")
+	expectFilteredMetricsCount(t, timeToAdmission, 0, "cluster_queue", "cluster_queue1")
+}
+
+func TestLocalQueueSelector(t *testing.T) {
+	SetLocalQueueSelector(labels.SelectorFromSet(labels.Set{"metrics": "true"}))
+	t.Cleanup(func() { SetLocalQueueSelector(labels.Everything()) })
+
+	selected := &kueue.LocalQueue{ObjectMeta: metav1.ObjectMeta{Name: "selected", Namespace: "ns", Labels: map[string]string{"metrics": "true"}}}
+	other := &kueue.LocalQueue{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns"}}
+	selectedRef := LocalQueueReference{Name: "selected", Namespace: "ns"}
+	otherRef := LocalQueueReference{Name: "other", Namespace: "ns"}
+
+	ObserveLocalQueue(selected)
+	ObserveLocalQueue(other)
+	for _, lq := range []LocalQueueReference{selectedRef, otherRef} {
+		ReportLocalQueuePendingWorkloads(lq, 1, 0)
+		ReportLocalQueueResourceUsage(lq, "flavor", "res", 5)
+		ReportLocalQueueActiveWorkloads(lq, 1, 1)
+	}
+
+	expectFilteredMetricsCount(t, LocalQueuePendingWorkloads, 2, "name", "selected")
+	expectFilteredMetricsCount(t, LocalQueueResourceUsage, 1, "name", "selected")
+	expectFilteredMetricsCount(t, LocalQueueAdmittedActiveWorkloads, 1, "name", "selected")
+	expectFilteredMetricsCount(t, LocalQueuePendingWorkloads, 0, "name", "other")
+	expectFilteredMetricsCount(t, LocalQueueResourceUsage, 0, "name", "other")
+	expectFilteredMetricsCount(t, LocalQueueAdmittedActiveWorkloads, 0, "name", "other")
+
+	// The LocalQueue stops matching the selector.
+	selected.Labels = nil
+	if ObserveLocalQueue(selected) {
+		t.Errorf("The LocalQueue reported to start matching the selector")
+	}
+	expectFilteredMetricsCount(t, LocalQueuePendingWorkloads, 0, "name", "selected")
+	expectFilteredMetricsCount(t, LocalQueueResourceUsage, 0, "name", "selected")
+	expectFilteredMetricsCount(t, LocalQueueAdmittedActiveWorkloads, 0, "name", "selected")
+
+	// The LocalQueue starts matching the selector.
+	other.Labels = map[string]string{"metrics": "true"}
+	if !ObserveLocalQueue(other) {
+		t.Errorf("The LocalQueue didn't report to start matching the selector")
+	}
+	ReportLocalQueuePendingWorkloads(otherRef, 1, 0)
+	expectFilteredMetricsCount(t, LocalQueuePendingWorkloads, 2, "name", "other")
+
+	ClearLocalQueueMetrics(otherRef)
+	ForgetLocalQueue(selectedRef)
+	ForgetLocalQueue(otherRef)
+	ClearLocalQueueCacheMetrics(otherRef)
+	ClearLocalQueueResourceMetrics(otherRef)
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const natsTimeout = 10 * time.Second
+
+// natsSink publishes each notification as a JSON message to a NATS subject,
+// with the NATS client protocol. Every message is followed by a PING, so
+// that Send returns once the server processed the message.
+type natsSink struct {
+	address string
+	host    string
+	tls     bool
+	subject string
+	options natsConnectOptions
+
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+type natsInfo struct {
+	TLSRequired bool `json:"tls_required"`
+}
+
+type natsConnectOptions struct {
+	Verbose     bool   `json:"verbose"`
+	Pedantic    bool   `json:"pedantic"`
+	TLSRequired bool   `json:"tls_required"`
+	Name        string `json:"name"`
+	Lang        string `json:"lang"`
+	User        string `json:"user,omitempty"`
+	Pass        string `json:"pass,omitempty"`
+	AuthToken   string `json:"auth_token,omitempty"`
+}
+
+func newNATSSink(rawURL, subject string) (*natsSink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	s := &natsSink{
+		address: u.Host,
+		host:    u.Hostname(),
+		tls:     u.Scheme == "tls",
+		subject: subject,
+		options: natsConnectOptions{Name: "kueue", Lang: "go"},
+	}
+	if u.Port() == "" {
+		s.address = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	if u.User != nil {
+		if pass, found := u.User.Password(); found {
+			s.options.User = u.User.Username()
+			s.options.Pass = pass
+		} else {
+			s.options.AuthToken = u.User.Username()
+		}
+	}
+	return s, nil
+}
+
+func (s *natsSink) String() string {
+	return "nats://" + s.address + "/" + s.subject
+}
+
+func (s *natsSink) Send(ctx context.Context, notification *Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	msg := fmt.Appendf(nil, "PUB %s %d\r\n%s\r\nPING\r\n", s.subject, len(payload), payload)
+	// The server closes idle connections which don't answer its PINGs, so a
+	// publish on a stale connection is retried once on a new connection.
+	for attempt := 0; ; attempt++ {
+		err = s.publish(ctx, msg)
+		if err == nil || attempt > 0 || ctx.Err() != nil {
+			return err
+		}
+	}
+}
+
+func (s *natsSink) publish(ctx context.Context, msg []byte) error {
+	if s.conn == nil {
+		if err := s.connect(ctx); err != nil {
+			return err
+		}
+	}
+	if err := s.conn.SetDeadline(deadline(ctx)); err != nil {
+		return s.fail(err)
+	}
+	if _, err := s.conn.Write(msg); err != nil {
+		return s.fail(err)
+	}
+	return s.fail(s.waitPong())
+}
+
+func (s *natsSink) connect(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: natsTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	s.reader = bufio.NewReader(conn)
+	if err := conn.SetDeadline(deadline(ctx)); err != nil {
+		return s.fail(err)
+	}
+	line, err := s.readLine()
+	if err != nil {
+		return s.fail(err)
+	}
+	op, args, _ := strings.Cut(line, " ")
+	if op != "INFO" {
+		return s.fail(fmt.Errorf("unexpected greeting %q", line))
+	}
+	var info natsInfo
+	if err := json.Unmarshal([]byte(args), &info); err != nil {
+		return s.fail(fmt.Errorf("decoding the server info: %w", err))
+	}
+	if s.tls || info.TLSRequired {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return s.fail(err)
+		}
+		s.conn = tlsConn
+		s.reader = bufio.NewReader(tlsConn)
+	}
+	options := s.options
+	options.TLSRequired = s.tls || info.TLSRequired
+	connect, err := json.Marshal(&options)
+	if err != nil {
+		return s.fail(err)
+	}
+	if _, err := fmt.Fprintf(s.conn, "CONNECT %s\r\nPING\r\n", connect); err != nil {
+		return s.fail(err)
+	}
+	return s.fail(s.waitPong())
+}
+
+// waitPong reads the messages of the server until the PONG answering the
+// last PING, answering the PINGs of the server.
+func (s *natsSink) waitPong() error {
+	for {
+		line, err := s.readLine()
+		if err != nil {
+			return err
+		}
+		op, args, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(op) {
+		case "PONG":
+			return nil
+		case "PING":
+			if _, err := s.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case "-ERR":
+			return fmt.Errorf("server error: %s", args)
+		}
+	}
+}
+
+func (s *natsSink) readLine() (string, error) {
+	line, err := s.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// fail closes the connection if err is not nil, so that the next publish
+// connects again, and returns err.
+func (s *natsSink) fail(err error) error {
+	if err != nil && s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		s.reader = nil
+	}
+	return err
+}
+
+func (s *natsSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	s.reader = nil
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+func deadline(ctx context.Context) time.Time {
+	d := time.Now().Add(natsTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(d) {
+		return ctxDeadline
+	}
+	return d
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// Notification is the JSON payload posted to the webhooks.
+type Notification struct {
+	Type config.NotificationEventType `json:"type"`
+	Time time.Time                    `json:"time"`
+	// Workload is set for the events of workloads.
+	Workload *WorkloadReference `json:"workload,omitempty"`
+	// ClusterQueue is the ClusterQueue of the event, or in which the workload
+	// is admitted.
+	ClusterQueue string `json:"clusterQueue,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+	Message      string `json:"message,omitempty"`
+}
+
+type WorkloadReference struct {
+	Namespace  string    `json:"namespace"`
+	Name       string    `json:"name"`
+	UID        types.UID `json:"uid"`
+	LocalQueue string    `json:"localQueue"`
+}
+
+// key returns the key of the object of the notification, used to partition
+// the notifications in the event streams.
+func (n *Notification) key() string {
+	if n.Workload != nil {
+		return n.Workload.Namespace + "/" + n.Workload.Name
+	}
+	return n.ClusterQueue
+}
+
+// Notifier sends the notifications of the lifecycle events of the workloads
+// and ClusterQueues to the sinks, asynchronously.
+// The notifications are only sent by the leader, and the notifications
+// produced while the buffer is full are dropped.
+type Notifier struct {
+	sinks         []filteredSink
+	clock         clock.Clock
+	notifications chan Notification
+	started       atomic.Bool
+	dropped       atomic.Int64
+}
+
+// filteredSink is a sink along with the types of the events sent to it,
+// where no types means all the types.
+type filteredSink struct {
+	Sink
+	events []config.NotificationEventType
+}
+
+// New returns the Notifier for the configuration.
+func New(cfg *config.Notifications) (*Notifier, error) {
+	n := &Notifier{
+		clock:         clock.RealClock{},
+		notifications: make(chan Notification, ptr.Deref(cfg.BufferSize, config.DefaultNotificationsBufferSize)),
+	}
+	for _, webhook := range cfg.Webhooks {
+		n.sinks = append(n.sinks, filteredSink{Sink: newWebhookSink(webhook.URL), events: webhook.Events})
+	}
+	for _, kafka := range cfg.Kafka {
+		n.sinks = append(n.sinks, filteredSink{Sink: newKafkaSink(kafka.RESTProxyURL, kafka.Topic), events: kafka.Events})
+	}
+	for _, ce := range cfg.CloudEvents {
+		n.sinks = append(n.sinks, filteredSink{Sink: newCloudEventsSink(ce.URL, ce.Source), events: ce.Events})
+	}
+	for _, nats := range cfg.NATS {
+		sink, err := newNATSSink(nats.URL, nats.Subject)
+		if err != nil {
+			return nil, fmt.Errorf("setting up the NATS sink %q: %w", nats.URL, err)
+		}
+		n.sinks = append(n.sinks, filteredSink{Sink: sink, events: nats.Events})
+	}
+	return n, nil
+}
+
+// NotifyWorkloadUpdate implements the WorkloadUpdateWatcher interface of the
+// workload controller.
+func (n *Notifier) NotifyWorkloadUpdate(oldWl, newWl *kueue.Workload) {
+	if oldWl == nil || newWl == nil {
+		return
+	}
+	if !workload.IsAdmitted(oldWl) && workload.IsAdmitted(newWl) {
+		n.notifyWorkload(config.WorkloadAdmittedNotification, newWl, kueue.WorkloadAdmitted)
+	}
+	if becameTrue(oldWl, newWl, kueue.WorkloadEvicted) {
+		eventType := config.WorkloadEvictedNotification
+		if apimeta.FindStatusCondition(newWl.Status.Conditions, kueue.WorkloadEvicted).Reason == kueue.WorkloadEvictedByPreemption {
+			eventType = config.WorkloadPreemptedNotification
+		}
+		n.notifyWorkload(eventType, newWl, kueue.WorkloadEvicted)
+	}
+	if becameTrue(oldWl, newWl, kueue.WorkloadFinished) {
+		n.notifyWorkload(config.WorkloadFinishedNotification, newWl, kueue.WorkloadFinished)
+	}
+}
+
+// NotifyClusterQueueUpdate implements the ClusterQueueUpdateWatcher interface
+// of the ClusterQueue controller.
+func (n *Notifier) NotifyClusterQueueUpdate(oldCq, newCq *kueue.ClusterQueue) {
+	if oldCq == nil || newCq == nil {
+		return
+	}
+	wasStopped, stopped := isStopped(oldCq), isStopped(newCq)
+	switch {
+	case !wasStopped && stopped:
+		n.notify(Notification{
+			Type:         config.ClusterQueueStoppedNotification,
+			ClusterQueue: newCq.Name,
+			Reason:       string(*newCq.Spec.StopPolicy),
+		})
+	case wasStopped && !stopped:
+		n.notify(Notification{
+			Type:         config.ClusterQueueResumedNotification,
+			ClusterQueue: newCq.Name,
+		})
+	}
+}
+
+func (n *Notifier) notifyWorkload(eventType config.NotificationEventType, wl *kueue.Workload, conditionType string) {
+	notification := Notification{
+		Type: eventType,
+		Workload: &WorkloadReference{
+			Namespace:  wl.Namespace,
+			Name:       wl.Name,
+			UID:        wl.UID,
+			LocalQueue: wl.Spec.QueueName,
+		},
+	}
+	if wl.Status.Admission != nil {
+		notification.ClusterQueue = string(wl.Status.Admission.ClusterQueue)
+	}
+	if c := apimeta.FindStatusCondition(wl.Status.Conditions, conditionType); c != nil {
+		notification.Reason = c.Reason
+		notification.Message = c.Message
+	}
+	n.notify(notification)
+}
+
+// notify queues the notification, unless the Notifier isn't started, or the
+// buffer is full.
+func (n *Notifier) notify(notification Notification) {
+	if !n.started.Load() {
+		return
+	}
+	notification.Time = n.clock.Now()
+	select {
+	case n.notifications <- notification:
+	default:
+		n.dropped.Add(1)
+	}
+}
+
+// Start implements the Runnable interface to send the notifications until
+// the context is canceled.
+func (n *Notifier) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("notifications")
+	n.started.Store(true)
+	defer n.started.Store(false)
+	defer func() {
+		for _, sink := range n.sinks {
+			if err := sink.Close(); err != nil {
+				log.Error(err, "Closing the notification sink", "sink", sink.String())
+			}
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-n.notifications:
+			for _, sink := range n.sinks {
+				if len(sink.events) > 0 && !slices.Contains(sink.events, notification.Type) {
+					continue
+				}
+				if err := sink.Send(ctx, &notification); err != nil {
+					log.Error(err, "Sending the notification", "type", notification.Type, "sink", sink.String())
+				}
+			}
+			if dropped := n.dropped.Swap(0); dropped > 0 {
+				log.Info("Dropped notifications because the buffer was full", "count", dropped)
+			}
+		}
+	}
+}
+
+func becameTrue(oldWl, newWl *kueue.Workload, conditionType string) bool {
+	return !apimeta.IsStatusConditionTrue(oldWl.Status.Conditions, conditionType) &&
+		apimeta.IsStatusConditionTrue(newWl.Status.Conditions, conditionType)
+}
+
+func isStopped(cq *kueue.ClusterQueue) bool {
+	return ptr.Deref(cq.Spec.StopPolicy, kueue.None) != kueue.None
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestNotifyWorkloadUpdate(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	admission := utiltesting.MakeAdmission("cq").Obj()
+	pending := utiltesting.MakeWorkload("wl", "ns").UID("uid").Queue("lq")
+	admitted := pending.Clone().ReserveQuota(admission).Admitted(true)
+	wlRef := &WorkloadReference{Namespace: "ns", Name: "wl", UID: "uid", LocalQueue: "lq"}
+
+	cases := map[string]struct {
+		oldWl, newWl *kueue.Workload
+		want         []Notification
+	}{
+		"created": {
+			newWl: admitted.Clone().Obj(),
+		},
+		"admitted": {
+			oldWl: pending.Clone().Obj(),
+			newWl: admitted.Clone().Obj(),
+			want: []Notification{{
+				Type:         config.WorkloadAdmittedNotification,
+				Time:         now,
+				Workload:     wlRef,
+				ClusterQueue: "cq",
+				Reason:       "ByTest",
+				Message:      "Admitted by ClusterQueue cq",
+			}},
+		},
+		"evicted": {
+			oldWl: admitted.Clone().Obj(),
+			newWl: admitted.Clone().Condition(metav1.Condition{
+				Type:    kueue.WorkloadEvicted,
+				Status:  metav1.ConditionTrue,
+				Reason:  kueue.WorkloadEvictedByPodsReadyTimeout,
+				Message: "Exceeded the PodsReady timeout",
+			}).Obj(),
+			want: []Notification{{
+				Type:         config.WorkloadEvictedNotification,
+				Time:         now,
+				Workload:     wlRef,
+				ClusterQueue: "cq",
+				Reason:       kueue.WorkloadEvictedByPodsReadyTimeout,
+				Message:      "Exceeded the PodsReady timeout",
+			}},
+		},
+		"preempted": {
+			oldWl: admitted.Clone().Obj(),
+			newWl: admitted.Clone().Condition(metav1.Condition{
+				Type:    kueue.WorkloadEvicted,
+				Status:  metav1.ConditionTrue,
+				Reason:  kueue.WorkloadEvictedByPreemption,
+				Message: "Preempted to accommodate a workload",
+			}).Obj(),
+			want: []Notification{{
+				Type:         config.WorkloadPreemptedNotification,
+				Time:         now,
+				Workload:     wlRef,
+				ClusterQueue: "cq",
+				Reason:       kueue.WorkloadEvictedByPreemption,
+				Message:      "Preempted to accommodate a workload",
+			}},
+		},
+		"finished": {
+			oldWl: admitted.Clone().Obj(),
+			newWl: admitted.Clone().Finished().Obj(),
+			want: []Notification{{
+				Type:         config.WorkloadFinishedNotification,
+				Time:         now,
+				Workload:     wlRef,
+				ClusterQueue: "cq",
+				Reason:       "ByTest",
+				Message:      "Finished by test",
+			}},
+		},
+		"unchanged": {
+			oldWl: admitted.Clone().Obj(),
+			newWl: admitted.Clone().Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			n := newTestNotifier(t, &config.Notifications{BufferSize: ptr.To[int32](10)})
+			n.clock = testingclock.NewFakeClock(now)
+			n.started.Store(true)
+			n.NotifyWorkloadUpdate(tc.oldWl, tc.newWl)
+			if diff := cmp.Diff(tc.want, drain(n)); diff != "" {
+				t.Errorf("Unexpected notifications (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNotifyClusterQueueUpdate(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cases := map[string]struct {
+		oldCq, newCq *kueue.ClusterQueue
+		want         []Notification
+	}{
+		"stopped": {
+			oldCq: utiltesting.MakeClusterQueue("cq").Obj(),
+			newCq: utiltesting.MakeClusterQueue("cq").StopPolicy(kueue.HoldAndDrain).Obj(),
+			want: []Notification{{
+				Type:         config.ClusterQueueStoppedNotification,
+				Time:         now,
+				ClusterQueue: "cq",
+				Reason:       string(kueue.HoldAndDrain),
+			}},
+		},
+		"resumed": {
+			oldCq: utiltesting.MakeClusterQueue("cq").StopPolicy(kueue.Hold).Obj(),
+			newCq: utiltesting.MakeClusterQueue("cq").StopPolicy(kueue.None).Obj(),
+			want: []Notification{{
+				Type:         config.ClusterQueueResumedNotification,
+				Time:         now,
+				ClusterQueue: "cq",
+			}},
+		},
+		"stop policy changed": {
+			oldCq: utiltesting.MakeClusterQueue("cq").StopPolicy(kueue.Hold).Obj(),
+			newCq: utiltesting.MakeClusterQueue("cq").StopPolicy(kueue.HoldAndDrain).Obj(),
+		},
+		"deleted": {
+			oldCq: utiltesting.MakeClusterQueue("cq").StopPolicy(kueue.Hold).Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			n := newTestNotifier(t, &config.Notifications{BufferSize: ptr.To[int32](10)})
+			n.clock = testingclock.NewFakeClock(now)
+			n.started.Store(true)
+			n.NotifyClusterQueueUpdate(tc.oldCq, tc.newCq)
+			if diff := cmp.Diff(tc.want, drain(n)); diff != "" {
+				t.Errorf("Unexpected notifications (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNotifierNotStarted(t *testing.T) {
+	n := newTestNotifier(t, &config.Notifications{BufferSize: ptr.To[int32](10)})
+	n.NotifyClusterQueueUpdate(
+		utiltesting.MakeClusterQueue("cq").Obj(),
+		utiltesting.MakeClusterQueue("cq").StopPolicy(kueue.Hold).Obj(),
+	)
+	if got := drain(n); len(got) != 0 {
+		t.Errorf("Unexpected notifications before start: %v", got)
+	}
+}
+
+func TestNotifierSendsToWebhooks(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string][]config.NotificationEventType)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var notification Notification
+		if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+			t.Errorf("Decoding the notification: %v", err)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Unexpected content type %q", got)
+		}
+		mu.Lock()
+		received[r.URL.Path] = append(received[r.URL.Path], notification.Type)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	n := newTestNotifier(t, &config.Notifications{
+		Webhooks: []config.NotificationWebhook{
+			{URL: server.URL + "/all"},
+			{URL: server.URL + "/stopped", Events: []config.NotificationEventType{config.ClusterQueueStoppedNotification}},
+		},
+		BufferSize: ptr.To[int32](10),
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = n.Start(ctx)
+		close(done)
+	}()
+	for !n.started.Load() {
+		time.Sleep(time.Millisecond)
+	}
+
+	running := utiltesting.MakeClusterQueue("cq").Obj()
+	stopped := utiltesting.MakeClusterQueue("cq").StopPolicy(kueue.Hold).Obj()
+	n.NotifyClusterQueueUpdate(running, stopped)
+	n.NotifyClusterQueueUpdate(stopped, running)
+
+	want := map[string][]config.NotificationEventType{
+		"/all":     {config.ClusterQueueStoppedNotification, config.ClusterQueueResumedNotification},
+		"/stopped": {config.ClusterQueueStoppedNotification},
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		diff := cmp.Diff(want, received)
+		mu.Unlock()
+		if diff == "" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Unexpected notifications received (-want,+got):\n%s", diff)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+}
+
+func newTestNotifier(t *testing.T, cfg *config.Notifications) *Notifier {
+	t.Helper()
+	n, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Creating the notifier: %v", err)
+	}
+	return n
+}
+
+func drain(n *Notifier) []Notification {
+	var got []Notification
+	for {
+		select {
+		case notification := <-n.notifications:
+			got = append(got, notification)
+		default:
+			return got
+		}
+	}
+}
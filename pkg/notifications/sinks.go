@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const (
+	httpTimeout = 10 * time.Second
+
+	// DefaultCloudEventsSource is the source of the CloudEvents when the sink
+	// doesn't set it.
+	DefaultCloudEventsSource = "kueue.x-k8s.io"
+
+	// cloudEventsTypePrefix prefixes the types of the notifications in the
+	// types of the CloudEvents, in reverse-DNS notation.
+	cloudEventsTypePrefix = "io.x-k8s.kueue."
+)
+
+// Sink is a destination of the notifications.
+type Sink interface {
+	fmt.Stringer
+	// Send delivers the notification, returning when it was accepted by the
+	// destination.
+	Send(ctx context.Context, notification *Notification) error
+	// Close releases the resources held by the sink.
+	Close() error
+}
+
+// webhookSink posts each notification as a JSON object.
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookSink(url string) *webhookSink {
+	return &webhookSink{url: url, client: &http.Client{Timeout: httpTimeout}}
+}
+
+func (s *webhookSink) String() string {
+	return s.url
+}
+
+func (s *webhookSink) Send(ctx context.Context, notification *Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.client, s.url, "application/json", body, nil)
+}
+
+func (s *webhookSink) Close() error {
+	return nil
+}
+
+// kafkaSink produces each notification as a JSON record to a Kafka topic,
+// through the v2 API of the Kafka REST Proxy.
+type kafkaSink struct {
+	url    string
+	client *http.Client
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string        `json:"key"`
+	Value *Notification `json:"value"`
+}
+
+func newKafkaSink(restProxyURL, topic string) *kafkaSink {
+	return &kafkaSink{
+		url:    restProxyURL + "/topics/" + url.PathEscape(topic),
+		client: &http.Client{Timeout: httpTimeout},
+	}
+}
+
+func (s *kafkaSink) String() string {
+	return s.url
+}
+
+func (s *kafkaSink) Send(ctx context.Context, notification *Notification) error {
+	body, err := json.Marshal(&kafkaRecords{
+		Records: []kafkaRecord{{Key: notification.key(), Value: notification}},
+	})
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.client, s.url, "application/vnd.kafka.json.v2+json", body, nil)
+}
+
+func (s *kafkaSink) Close() error {
+	return nil
+}
+
+// cloudEventsSink posts each notification as a CloudEvent, in the binary
+// content mode of the HTTP protocol binding: the attributes of the event are
+// sent as ce- headers, and the notification as the JSON data.
+type cloudEventsSink struct {
+	url    string
+	source string
+	client *http.Client
+}
+
+func newCloudEventsSink(url, source string) *cloudEventsSink {
+	if source == "" {
+		source = DefaultCloudEventsSource
+	}
+	return &cloudEventsSink{url: url, source: source, client: &http.Client{Timeout: httpTimeout}}
+}
+
+func (s *cloudEventsSink) String() string {
+	return s.url
+}
+
+func (s *cloudEventsSink) Send(ctx context.Context, notification *Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.client, s.url, "application/json", body, http.Header{
+		"Ce-Specversion": {"1.0"},
+		"Ce-Id":          {string(uuid.NewUUID())},
+		"Ce-Source":      {s.source},
+		"Ce-Type":        {cloudEventsTypePrefix + string(notification.Type)},
+		"Ce-Subject":     {notification.key()},
+		"Ce-Time":        {notification.Time.UTC().Format(time.RFC3339Nano)},
+	})
+}
+
+func (s *cloudEventsSink) Close() error {
+	return nil
+}
+
+// post posts the body with the headers, which can be nil.
+func post(ctx context.Context, client *http.Client, url, contentType string, body []byte, header http.Header) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
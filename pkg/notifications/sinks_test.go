@@ -0,0 +1,254 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+)
+
+var testNotification = Notification{
+	Type:         config.WorkloadAdmittedNotification,
+	Time:         time.Date(2024, 10, 14, 10, 0, 0, 0, time.UTC),
+	Workload:     &WorkloadReference{Namespace: "ns", Name: "wl", UID: "uid", LocalQueue: "lq"},
+	ClusterQueue: "cq",
+	Reason:       "Admitted",
+}
+
+func TestKafkaSink(t *testing.T) {
+	var gotRecords kafkaRecords
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/topics/kueue-events" {
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/vnd.kafka.json.v2+json" {
+			t.Errorf("Unexpected content type %q", got)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&gotRecords); err != nil {
+			t.Errorf("Decoding the records: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	sink := newKafkaSink(server.URL, "kueue-events")
+	notification := testNotification
+	if err := sink.Send(context.Background(), &notification); err != nil {
+		t.Fatalf("Sending the notification: %v", err)
+	}
+	wantRecords := kafkaRecords{Records: []kafkaRecord{{Key: "ns/wl", Value: &testNotification}}}
+	if diff := cmp.Diff(wantRecords, gotRecords); diff != "" {
+		t.Errorf("Unexpected records (-want,+got):\n%s", diff)
+	}
+}
+
+func TestCloudEventsSink(t *testing.T) {
+	cases := map[string]struct {
+		source     string
+		wantSource string
+	}{
+		"default source": {
+			wantSource: DefaultCloudEventsSource,
+		},
+		"custom source": {
+			source:     "https://cluster-a.example.com/kueue",
+			wantSource: "https://cluster-a.example.com/kueue",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var gotHeader http.Header
+			var gotNotification Notification
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotHeader = r.Header
+				if err := json.NewDecoder(r.Body).Decode(&gotNotification); err != nil {
+					t.Errorf("Decoding the data: %v", err)
+				}
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer server.Close()
+
+			sink := newCloudEventsSink(server.URL, tc.source)
+			notification := testNotification
+			if err := sink.Send(context.Background(), &notification); err != nil {
+				t.Fatalf("Sending the notification: %v", err)
+			}
+			if gotHeader.Get("Ce-Id") == "" {
+				t.Error("The CloudEvent has no id")
+			}
+			wantAttributes := map[string]string{
+				"Content-Type":   "application/json",
+				"Ce-Specversion": "1.0",
+				"Ce-Source":      tc.wantSource,
+				"Ce-Type":        "io.x-k8s.kueue.WorkloadAdmitted",
+				"Ce-Subject":     "ns/wl",
+				"Ce-Time":        "2024-10-14T10:00:00Z",
+			}
+			gotAttributes := make(map[string]string, len(wantAttributes))
+			for key := range wantAttributes {
+				gotAttributes[key] = gotHeader.Get(key)
+			}
+			if diff := cmp.Diff(wantAttributes, gotAttributes); diff != "" {
+				t.Errorf("Unexpected attributes (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(testNotification, gotNotification); diff != "" {
+				t.Errorf("Unexpected data (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// fakeNATSServer accepts connections and records the published messages.
+// Each connection is closed after closeAfter messages, if set.
+type fakeNATSServer struct {
+	listener   net.Listener
+	closeAfter int
+	connects   chan string
+	messages   chan string
+}
+
+func newFakeNATSServer(t *testing.T, closeAfter int) *fakeNATSServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listening: %v", err)
+	}
+	s := &fakeNATSServer{
+		listener:   listener,
+		closeAfter: closeAfter,
+		connects:   make(chan string, 10),
+		messages:   make(chan string, 10),
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *fakeNATSServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	fmt.Fprint(conn, "INFO {\"server_id\":\"fake\",\"max_payload\":1048576}\r\n")
+	published := 0
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		op, args, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+		switch op {
+		case "CONNECT":
+			s.connects <- args
+		case "PING":
+			fmt.Fprint(conn, "PING\r\n")
+			if _, err := reader.ReadString('\n'); err != nil {
+				return
+			}
+			fmt.Fprint(conn, "PONG\r\n")
+			if s.closeAfter > 0 && published == s.closeAfter {
+				return
+			}
+		case "PUB":
+			fields := strings.Fields(args)
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+			s.messages <- fields[0] + " " + string(payload[:size])
+			published++
+		}
+	}
+}
+
+func TestNATSSink(t *testing.T) {
+	cases := map[string]struct {
+		url         string
+		closeAfter  int
+		wantConnect natsConnectOptions
+	}{
+		"user and password": {
+			url:         "nats://kueue:secret@%s",
+			wantConnect: natsConnectOptions{Name: "kueue", Lang: "go", User: "kueue", Pass: "secret"},
+		},
+		"token": {
+			url:         "nats://token@%s",
+			wantConnect: natsConnectOptions{Name: "kueue", Lang: "go", AuthToken: "token"},
+		},
+		"reconnects when the connection is closed": {
+			url:         "nats://%s",
+			closeAfter:  1,
+			wantConnect: natsConnectOptions{Name: "kueue", Lang: "go"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := newFakeNATSServer(t, tc.closeAfter)
+			sink, err := newNATSSink(fmt.Sprintf(tc.url, server.listener.Addr()), "kueue.events")
+			if err != nil {
+				t.Fatalf("Creating the sink: %v", err)
+			}
+			defer sink.Close()
+
+			payload, _ := json.Marshal(&testNotification)
+			for range 2 {
+				notification := testNotification
+				if err := sink.Send(context.Background(), &notification); err != nil {
+					t.Fatalf("Sending the notification: %v", err)
+				}
+				if diff := cmp.Diff("kueue.events "+string(payload), <-server.messages); diff != "" {
+					t.Errorf("Unexpected message (-want,+got):\n%s", diff)
+				}
+			}
+
+			var gotConnect natsConnectOptions
+			if err := json.Unmarshal([]byte(<-server.connects), &gotConnect); err != nil {
+				t.Fatalf("Decoding the CONNECT options: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantConnect, gotConnect); diff != "" {
+				t.Errorf("Unexpected CONNECT options (-want,+got):\n%s", diff)
+			}
+			wantConnects := 0
+			if tc.closeAfter > 0 {
+				wantConnects = 1
+			}
+			if got := len(server.connects); got != wantConnects {
+				t.Errorf("Unexpected number of reconnections, want %d, got %d", wantConnects, got)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -27,10 +28,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
 	"sigs.k8s.io/kueue/pkg/util/heap"
 	utilpriority "sigs.k8s.io/kueue/pkg/util/priority"
@@ -46,6 +51,10 @@ const (
 	RequeueReasonPendingPreemption     RequeueReason = "PendingPreemption"
 )
 
+// admissionThroughputWindow is the period over which the quota reservations
+// in a ClusterQueue are counted to estimate its admission throughput.
+const admissionThroughputWindow = time.Hour
+
 var (
 	realClock = clock.RealClock{}
 )
@@ -55,10 +64,31 @@ type ClusterQueue struct {
 	name              string
 	heap              heap.Heap[workload.Info]
 	namespaceSelector labels.Selector
-	active            bool
+	// lqNamespaceSelector selects the namespaces of the LocalQueues allowed
+	// to point at the ClusterQueue.
+	lqNamespaceSelector labels.Selector
+	active              bool
+	shadowMode          bool
+	admitAll            bool
+	// waitForPodsReady overrides whether Kueue waits for the pods of the
+	// admitted workloads to be ready, when set.
+	waitForPodsReady *bool
+	// priorityClassMappings maps the WorkloadPriorityClasses of the jobs to
+	// the ones of their workloads.
+	priorityClassMappings map[string]string
 
 	// inadmissibleWorkloads are workloads that have been tried at least once and couldn't be admitted.
 	inadmissibleWorkloads map[string]*workload.Info
+	// inadmissibleOrder orders inadmissibleWorkloads like the heap, so that
+	// inspecting the head of the ClusterQueue doesn't require sorting them.
+	inadmissibleOrder heap.Heap[workload.Info]
+
+	// activeByLocalQueue and inadmissibleByLocalQueue index the number of
+	// workloads in the heap and in inadmissibleWorkloads by LocalQueue key,
+	// so that counting the pending workloads of a LocalQueue doesn't require
+	// visiting all the workloads of the ClusterQueue.
+	activeByLocalQueue       map[string]int
+	inadmissibleByLocalQueue map[string]int
 
 	// popCycle identifies the last call to Pop. It's incremented when calling Pop.
 	// popCycle and queueInadmissibleCycle are used to track when there is a requeuing
@@ -76,6 +106,10 @@ type ClusterQueue struct {
 
 	queueingStrategy kueue.QueueingStrategy
 
+	// quotaReservations are the times, in increasing order, at which workloads
+	// got quota reserved in the ClusterQueue within the admissionThroughputWindow.
+	quotaReservations []time.Time
+
 	rwm sync.RWMutex
 
 	clock clock.Clock
@@ -101,12 +135,15 @@ func newClusterQueue(cq *kueue.ClusterQueue, wo workload.Ordering) (*ClusterQueu
 func newClusterQueueImpl(wo workload.Ordering, clock clock.Clock) *ClusterQueue {
 	lessFunc := queueOrderingFunc(wo)
 	return &ClusterQueue{
-		heap:                   *heap.New(workloadKey, lessFunc),
-		inadmissibleWorkloads:  make(map[string]*workload.Info),
-		queueInadmissibleCycle: -1,
-		lessFunc:               lessFunc,
-		rwm:                    sync.RWMutex{},
-		clock:                  clock,
+		heap:                     *heap.New(workloadKey, lessFunc),
+		inadmissibleWorkloads:    make(map[string]*workload.Info),
+		inadmissibleOrder:        *heap.New(workloadKey, lessFunc),
+		activeByLocalQueue:       make(map[string]int),
+		inadmissibleByLocalQueue: make(map[string]int),
+		queueInadmissibleCycle:   -1,
+		lessFunc:                 lessFunc,
+		rwm:                      sync.RWMutex{},
+		clock:                    clock,
 	}
 }
 
@@ -121,10 +158,48 @@ func (c *ClusterQueue) Update(apiCQ *kueue.ClusterQueue) error {
 		return err
 	}
 	c.namespaceSelector = nsSelector
+	c.lqNamespaceSelector = labels.Everything()
+	if apiCQ.Spec.LocalQueueNamespaceSelector != nil {
+		if c.lqNamespaceSelector, err = metav1.LabelSelectorAsSelector(apiCQ.Spec.LocalQueueNamespaceSelector); err != nil {
+			return err
+		}
+	}
 	c.active = apimeta.IsStatusConditionTrue(apiCQ.Status.Conditions, kueue.ClusterQueueActive)
+	c.shadowMode = ptr.Deref(apiCQ.Spec.ShadowMode, false)
+	c.admitAll = apiCQ.Annotations[controllerconsts.AdmitAllAnnotation] == "true"
+	c.waitForPodsReady = nil
+	if apiCQ.Spec.WaitForPodsReady != nil {
+		c.waitForPodsReady = apiCQ.Spec.WaitForPodsReady.Enable
+	}
+	c.priorityClassMappings = priorityClassMappings(apiCQ.Spec.PriorityClassMappings)
 	return nil
 }
 
+// ShadowMode returns whether the ClusterQueue is in shadow mode, either from
+// its spec or because it admits all the workloads.
+func (c *ClusterQueue) ShadowMode() bool {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	return c.shadowMode || c.admitAll
+}
+
+// WaitForPodsReady returns whether the ClusterQueue overrides waiting for the
+// pods of the admitted workloads to be ready, or nil if it doesn't.
+func (c *ClusterQueue) WaitForPodsReady() *bool {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	return c.waitForPodsReady
+}
+
+// mappedPriorityClass returns the WorkloadPriorityClass to which the
+// ClusterQueue maps the priority class, if it does.
+func (c *ClusterQueue) mappedPriorityClass(priorityClass string) (string, bool) {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	to, found := c.priorityClassMappings[priorityClass]
+	return to, found
+}
+
 // AddFromLocalQueue pushes all workloads belonging to this queue to
 // the ClusterQueue. If at least one workload is added, returns true,
 // otherwise returns false.
@@ -133,7 +208,7 @@ func (c *ClusterQueue) AddFromLocalQueue(q *LocalQueue) bool {
 	defer c.rwm.Unlock()
 	added := false
 	for _, info := range q.items {
-		if c.heap.PushIfNotPresent(info) {
+		if c.pushIfNotPresent(info) {
 			added = true
 		}
 	}
@@ -158,17 +233,59 @@ func (c *ClusterQueue) PushOrUpdate(wInfo *workload.Info) {
 				apimeta.FindStatusCondition(wInfo.Obj.Status.Conditions, kueue.WorkloadEvicted)) &&
 			equality.Semantic.DeepEqual(apimeta.FindStatusCondition(oldInfo.Obj.Status.Conditions, kueue.WorkloadRequeued),
 				apimeta.FindStatusCondition(wInfo.Obj.Status.Conditions, kueue.WorkloadRequeued)) {
-			c.inadmissibleWorkloads[key] = wInfo
+			c.setInadmissible(key, wInfo)
 			return
 		}
 		// otherwise move or update in place in the queue.
-		delete(c.inadmissibleWorkloads, key)
+		c.deleteInadmissible(key)
 	}
 	if c.heap.GetByKey(key) == nil && !c.backoffWaitingTimeExpired(wInfo) {
-		c.inadmissibleWorkloads[key] = wInfo
+		c.setInadmissible(key, wInfo)
 		return
 	}
+	if oldInfo := c.heap.GetByKey(key); oldInfo != nil {
+		decrementCount(c.activeByLocalQueue, workload.QueueKey(oldInfo.Obj))
+	}
 	c.heap.PushOrUpdate(wInfo)
+	c.activeByLocalQueue[workload.QueueKey(wInfo.Obj)]++
+}
+
+// pushIfNotPresent pushes the workload to the heap, unless it's already in
+// the heap, and returns whether it was pushed.
+func (c *ClusterQueue) pushIfNotPresent(wInfo *workload.Info) bool {
+	if !c.heap.PushIfNotPresent(wInfo) {
+		return false
+	}
+	c.activeByLocalQueue[workload.QueueKey(wInfo.Obj)]++
+	return true
+}
+
+// setInadmissible adds or replaces the workload in inadmissibleWorkloads.
+func (c *ClusterQueue) setInadmissible(key string, wInfo *workload.Info) {
+	c.deleteInadmissible(key)
+	c.inadmissibleWorkloads[key] = wInfo
+	c.inadmissibleOrder.PushOrUpdate(wInfo)
+	c.inadmissibleByLocalQueue[workload.QueueKey(wInfo.Obj)]++
+}
+
+// deleteInadmissible removes the workload from inadmissibleWorkloads, if
+// present.
+func (c *ClusterQueue) deleteInadmissible(key string) {
+	if wInfo, found := c.inadmissibleWorkloads[key]; found {
+		delete(c.inadmissibleWorkloads, key)
+		c.inadmissibleOrder.Delete(key)
+		decrementCount(c.inadmissibleByLocalQueue, workload.QueueKey(wInfo.Obj))
+	}
+}
+
+// decrementCount decrements the count of the key, removing it when it gets
+// to zero.
+func decrementCount(counts map[string]int, key string) {
+	if counts[key] <= 1 {
+		delete(counts, key)
+		return
+	}
+	counts[key]--
 }
 
 // backoffWaitingTimeExpired returns true if the current time is after the requeueAt
@@ -196,8 +313,11 @@ func (c *ClusterQueue) Delete(w *kueue.Workload) {
 // delete removes the workload from ClusterQueue without lock.
 func (c *ClusterQueue) delete(w *kueue.Workload) {
 	key := workload.Key(w)
-	delete(c.inadmissibleWorkloads, key)
-	c.heap.Delete(key)
+	c.deleteInadmissible(key)
+	if wInfo := c.heap.GetByKey(key); wInfo != nil {
+		c.heap.Delete(key)
+		decrementCount(c.activeByLocalQueue, workload.QueueKey(wInfo.Obj))
+	}
 	c.forgetInflightByKey(key)
 }
 
@@ -206,12 +326,6 @@ func (c *ClusterQueue) delete(w *kueue.Workload) {
 func (c *ClusterQueue) DeleteFromLocalQueue(q *LocalQueue) {
 	c.rwm.Lock()
 	defer c.rwm.Unlock()
-	for _, w := range q.items {
-		key := workload.Key(w.Obj)
-		if wl := c.inadmissibleWorkloads[key]; wl != nil {
-			delete(c.inadmissibleWorkloads, key)
-		}
-	}
 	for _, w := range q.items {
 		c.delete(w.Obj)
 	}
@@ -233,9 +347,9 @@ func (c *ClusterQueue) requeueIfNotPresent(wInfo *workload.Info, immediate bool)
 		inadmissibleWl := c.inadmissibleWorkloads[key]
 		if inadmissibleWl != nil {
 			wInfo = inadmissibleWl
-			delete(c.inadmissibleWorkloads, key)
+			c.deleteInadmissible(key)
 		}
-		return c.heap.PushIfNotPresent(wInfo)
+		return c.pushIfNotPresent(wInfo)
 	}
 
 	if c.inadmissibleWorkloads[key] != nil {
@@ -246,7 +360,7 @@ func (c *ClusterQueue) requeueIfNotPresent(wInfo *workload.Info, immediate bool)
 		return false
 	}
 
-	c.inadmissibleWorkloads[key] = wInfo
+	c.setInadmissible(key, wInfo)
 
 	return true
 }
@@ -260,6 +374,14 @@ func (c *ClusterQueue) forgetInflightByKey(key string) {
 // QueueInadmissibleWorkloads moves all workloads from inadmissibleWorkloads to heap.
 // If at least one workload is moved, returns true, otherwise returns false.
 func (c *ClusterQueue) QueueInadmissibleWorkloads(ctx context.Context, client client.Client) bool {
+	return c.QueueInadmissibleWorkloadsWaitingFor(ctx, client, nil)
+}
+
+// QueueInadmissibleWorkloadsWaitingFor moves the workloads from
+// inadmissibleWorkloads to heap, which are waiting for any of the flavors, or
+// for any event. If flavors is nil, it moves all the workloads.
+// If at least one workload is moved, returns true, otherwise returns false.
+func (c *ClusterQueue) QueueInadmissibleWorkloadsWaitingFor(ctx context.Context, client client.Client, flavors sets.Set[kueue.ResourceFlavorReference]) bool {
 	c.rwm.Lock()
 	defer c.rwm.Unlock()
 	c.queueInadmissibleCycle = c.popCycle
@@ -267,22 +389,35 @@ func (c *ClusterQueue) QueueInadmissibleWorkloads(ctx context.Context, client cl
 		return false
 	}
 
-	inadmissibleWorkloads := make(map[string]*workload.Info)
 	moved := false
 	for key, wInfo := range c.inadmissibleWorkloads {
+		if !waitingForAny(wInfo, flavors) {
+			continue
+		}
 		ns := corev1.Namespace{}
 		err := client.Get(ctx, types.NamespacedName{Name: wInfo.Obj.Namespace}, &ns)
-		if err != nil || !c.namespaceSelector.Matches(labels.Set(ns.Labels)) || !c.backoffWaitingTimeExpired(wInfo) {
-			inadmissibleWorkloads[key] = wInfo
-		} else {
-			moved = c.heap.PushIfNotPresent(wInfo) || moved
+		if err != nil || !c.namespaceSelector.Matches(labels.Set(ns.Labels)) || !c.lqNamespaceSelector.Matches(labels.Set(ns.Labels)) || !c.backoffWaitingTimeExpired(wInfo) {
+			continue
 		}
+		c.deleteInadmissible(key)
+		moved = c.pushIfNotPresent(wInfo) || moved
 	}
-
-	c.inadmissibleWorkloads = inadmissibleWorkloads
 	return moved
 }
 
+// waitingForAny returns whether the workload is waiting for any of the flavors.
+func waitingForAny(wInfo *workload.Info, flavors sets.Set[kueue.ResourceFlavorReference]) bool {
+	if flavors == nil || wInfo.WaitingFlavors == nil {
+		return true
+	}
+	for flavor := range flavors {
+		if wInfo.WaitingFlavors.Has(flavor) {
+			return true
+		}
+	}
+	return false
+}
+
 // Pending returns the total number of pending workloads.
 func (c *ClusterQueue) Pending() int {
 	c.rwm.RLock()
@@ -318,6 +453,7 @@ func (c *ClusterQueue) Pop() *workload.Info {
 		return nil
 	}
 	c.inflight = c.heap.Pop()
+	decrementCount(c.activeByLocalQueue, workload.QueueKey(c.inflight.Obj))
 	return c.inflight
 }
 
@@ -360,6 +496,53 @@ func (c *ClusterQueue) Snapshot() []*workload.Info {
 	return elements
 }
 
+// Head returns up to n pending workloads from the head of this ClusterQueue,
+// in order, including the inadmissible ones. Its cost depends on n, but not on
+// the number of pending workloads.
+func (c *ClusterQueue) Head(n int) []*workload.Info {
+	c.rwm.RLock()
+	defer c.rwm.RUnlock()
+	if n <= 0 {
+		return nil
+	}
+	elements := c.heap.Top(n)
+	elements = append(elements, c.inadmissibleOrder.Top(n)...)
+	if c.inflight != nil {
+		elements = append(elements, c.inflight)
+	}
+	sort.Slice(elements, func(i, j int) bool {
+		return c.lessFunc(elements[i], elements[j])
+	})
+	return elements[:min(n, len(elements))]
+}
+
+// RecordQuotaReservation records that a workload got quota reserved in the
+// ClusterQueue.
+func (c *ClusterQueue) RecordQuotaReservation() {
+	c.rwm.Lock()
+	defer c.rwm.Unlock()
+	now := c.clock.Now()
+	c.pruneQuotaReservations(now)
+	c.quotaReservations = append(c.quotaReservations, now)
+}
+
+// AdmissionThroughput returns the number of workloads per second that got
+// quota reserved in the ClusterQueue within the admissionThroughputWindow.
+func (c *ClusterQueue) AdmissionThroughput() float64 {
+	c.rwm.Lock()
+	defer c.rwm.Unlock()
+	c.pruneQuotaReservations(c.clock.Now())
+	return float64(len(c.quotaReservations)) / admissionThroughputWindow.Seconds()
+}
+
+func (c *ClusterQueue) pruneQuotaReservations(now time.Time) {
+	since := now.Add(-admissionThroughputWindow)
+	i := sort.Search(len(c.quotaReservations), func(i int) bool {
+		return c.quotaReservations[i].After(since)
+	})
+	c.quotaReservations = c.quotaReservations[i:]
+}
+
 // Info returns workload.Info for the workload key.
 // Users of this method should not modify the returned object.
 func (c *ClusterQueue) Info(key string) *workload.Info {
@@ -400,16 +583,23 @@ func (c *ClusterQueue) Active() bool {
 // The workload should not be reinserted if it's already in the ClusterQueue.
 // Returns true if the workload was inserted.
 func (c *ClusterQueue) RequeueIfNotPresent(wInfo *workload.Info, reason RequeueReason) bool {
-	if c.queueingStrategy == kueue.StrictFIFO {
+	if c.queueingStrategy == kueue.StrictFIFO || c.inStrictFIFOCohort() {
 		return c.requeueIfNotPresent(wInfo, reason != RequeueReasonNamespaceMismatch)
 	}
 	return c.requeueIfNotPresent(wInfo, reason == RequeueReasonFailedAfterNomination || reason == RequeueReasonPendingPreemption)
 }
 
+// inStrictFIFOCohort returns whether one of the Cohorts above the ClusterQueue
+// orders the workloads of all its ClusterQueues together in strict FIFO, in
+// which case the ClusterQueue can't skip its older workloads either.
+func (c *ClusterQueue) inStrictFIFOCohort() bool {
+	return features.Enabled(features.CohortStrictFIFO) && c.HasParent() && c.Parent().strictFIFO()
+}
+
 // queueOrderingFunc returns a function used by the clusterQueue heap algorithm
 // to sort workloads. The function sorts workloads based on their priority.
 // When priorities are equal, it uses the workload's creation or eviction
-// time.
+// time, and then the tie-breakers, if any.
 func queueOrderingFunc(wo workload.Ordering) func(a, b *workload.Info) bool {
 	return func(a, b *workload.Info) bool {
 		p1 := utilpriority.Priority(a.Obj)
@@ -421,6 +611,11 @@ func queueOrderingFunc(wo workload.Ordering) func(a, b *workload.Info) bool {
 
 		tA := wo.GetQueueOrderTimestamp(a.Obj)
 		tB := wo.GetQueueOrderTimestamp(b.Obj)
+		if tA.Equal(tB) {
+			if c := wo.CompareTies(a, b); c != 0 {
+				return c < 0
+			}
+		}
 		return !tB.Before(tA)
 	}
 }
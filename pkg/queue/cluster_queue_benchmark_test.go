@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// benchmarkPendingWorkloads is the number of pending workloads in the
+// ClusterQueue of the benchmarks. The operations on the ClusterQueue are
+// expected to stay under a millisecond with this number of workloads.
+const benchmarkPendingWorkloads = 100_000
+
+// benchmarkHeadSize is the number of workloads inspected at the head of the
+// ClusterQueue, like the default maxCount of the pending workloads in the
+// status of the ClusterQueues.
+const benchmarkHeadSize = 10
+
+// maxOperationLatency is the maximum average latency of the operations on a
+// ClusterQueue with benchmarkPendingWorkloads workloads.
+const maxOperationLatency = time.Millisecond
+
+func benchmarkWorkloads(n int) []*workload.Info {
+	now := time.Now()
+	infos := make([]*workload.Info, n)
+	for i := range infos {
+		wl := utiltesting.MakeWorkload(fmt.Sprintf("wl-%d", i), "ns").
+			Queue("lq").
+			Priority(int32(i % 10)).
+			Creation(now.Add(time.Duration(i) * time.Millisecond)).
+			Obj()
+		infos[i] = workload.NewInfo(wl)
+	}
+	return infos
+}
+
+func benchmarkClusterQueue(tb testing.TB, infos []*workload.Info) *ClusterQueue {
+	tb.Helper()
+	cq, err := newClusterQueue(utiltesting.MakeClusterQueue("cq").Obj(), workload.Ordering{})
+	if err != nil {
+		tb.Fatalf("Creating the ClusterQueue: %v", err)
+	}
+	for _, info := range infos {
+		cq.PushOrUpdate(info)
+	}
+	// Some of the workloads are inadmissible, as after scheduling cycles.
+	for _, info := range infos[:len(infos)/10] {
+		cq.Delete(info.Obj)
+		cq.requeueIfNotPresent(info, false)
+	}
+	return cq
+}
+
+func BenchmarkClusterQueuePushOrUpdate(b *testing.B) {
+	infos := benchmarkWorkloads(benchmarkPendingWorkloads + b.N)
+	cq := benchmarkClusterQueue(b, infos[:benchmarkPendingWorkloads])
+	b.ResetTimer()
+	for i := range b.N {
+		cq.PushOrUpdate(infos[benchmarkPendingWorkloads+i])
+	}
+}
+
+func BenchmarkClusterQueueUpdatePriority(b *testing.B) {
+	infos := benchmarkWorkloads(benchmarkPendingWorkloads)
+	cq := benchmarkClusterQueue(b, infos)
+	updated := make([]*workload.Info, b.N)
+	for i := range updated {
+		wl := infos[(i*7919)%benchmarkPendingWorkloads].Obj.DeepCopy()
+		wl.Spec.Priority = ptr.To(int32(i % 20))
+		updated[i] = workload.NewInfo(wl)
+	}
+	b.ResetTimer()
+	for i := range b.N {
+		cq.PushOrUpdate(updated[i])
+	}
+}
+
+func BenchmarkClusterQueuePopAndRequeue(b *testing.B) {
+	infos := benchmarkWorkloads(benchmarkPendingWorkloads)
+	cq := benchmarkClusterQueue(b, infos)
+	b.ResetTimer()
+	for range b.N {
+		head := cq.Pop()
+		cq.RequeueIfNotPresent(head, RequeueReasonFailedAfterNomination)
+	}
+}
+
+func BenchmarkClusterQueueDelete(b *testing.B) {
+	infos := benchmarkWorkloads(benchmarkPendingWorkloads + b.N)
+	cq := benchmarkClusterQueue(b, infos)
+	b.ResetTimer()
+	for i := range b.N {
+		cq.Delete(infos[benchmarkPendingWorkloads+i].Obj)
+	}
+}
+
+func BenchmarkClusterQueueInfo(b *testing.B) {
+	infos := benchmarkWorkloads(benchmarkPendingWorkloads)
+	cq := benchmarkClusterQueue(b, infos)
+	keys := make([]string, len(infos))
+	for i, info := range infos {
+		keys[i] = workload.Key(info.Obj)
+	}
+	b.ResetTimer()
+	for i := range b.N {
+		if cq.Info(keys[i%len(keys)]) == nil {
+			b.Fatalf("Workload %s not found", keys[i%len(keys)])
+		}
+	}
+}
+
+func BenchmarkClusterQueueHead(b *testing.B) {
+	infos := benchmarkWorkloads(benchmarkPendingWorkloads)
+	cq := benchmarkClusterQueue(b, infos)
+	b.ResetTimer()
+	for range b.N {
+		if len(cq.Head(benchmarkHeadSize)) != benchmarkHeadSize {
+			b.Fatalf("Expected %d workloads at the head", benchmarkHeadSize)
+		}
+	}
+}
+
+func BenchmarkPendingInLocalQueue(b *testing.B) {
+	infos := benchmarkWorkloads(benchmarkPendingWorkloads)
+	m := NewManager(utiltesting.NewFakeClient(), nil)
+	cq := benchmarkClusterQueue(b, infos)
+	m.hm.AddClusterQueue(cq)
+	lq := newLocalQueue(utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj())
+	b.ResetTimer()
+	for range b.N {
+		if m.PendingActiveInLocalQueue(lq)+m.PendingInadmissibleInLocalQueue(lq) != benchmarkPendingWorkloads {
+			b.Fatalf("Expected %d pending workloads in the LocalQueue", benchmarkPendingWorkloads)
+		}
+	}
+}
+
+// TestClusterQueueOperationsLatency guards the latency of the operations on
+// a ClusterQueue with benchmarkPendingWorkloads workloads.
+func TestClusterQueueOperationsLatency(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping the latency test in short mode")
+	}
+	const operations = 1_000
+	infos := benchmarkWorkloads(benchmarkPendingWorkloads + operations)
+	cq := benchmarkClusterQueue(t, infos[:benchmarkPendingWorkloads])
+	m := NewManager(utiltesting.NewFakeClient(), nil)
+	m.hm.AddClusterQueue(cq)
+	lq := newLocalQueue(utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj())
+
+	updated := make([]*workload.Info, operations)
+	for i := range updated {
+		wl := infos[(i*7919)%benchmarkPendingWorkloads].Obj.DeepCopy()
+		wl.Spec.Priority = ptr.To(int32(i % 20))
+		updated[i] = workload.NewInfo(wl)
+	}
+
+	cases := []struct {
+		name string
+		op   func(i int)
+	}{
+		{
+			name: "push",
+			op:   func(i int) { cq.PushOrUpdate(infos[benchmarkPendingWorkloads+i]) },
+		},
+		{
+			name: "update priority",
+			op:   func(i int) { cq.PushOrUpdate(updated[i]) },
+		},
+		{
+			name: "pop and requeue",
+			op: func(int) {
+				head := cq.Pop()
+				cq.RequeueIfNotPresent(head, RequeueReasonFailedAfterNomination)
+			},
+		},
+		{
+			name: "inspect head",
+			op:   func(int) { cq.Head(benchmarkHeadSize) },
+		},
+		{
+			name: "count pending in LocalQueue",
+			op: func(int) {
+				m.PendingActiveInLocalQueue(lq)
+				m.PendingInadmissibleInLocalQueue(lq)
+			},
+		},
+	}
+	for _, tc := range cases {
+		start := time.Now()
+		for i := range operations {
+			tc.op(i)
+		}
+		if latency := time.Since(start) / operations; latency > maxOperationLatency {
+			t.Errorf("Average latency of %q with %d pending workloads is %v, want at most %v", tc.name, benchmarkPendingWorkloads, latency, maxOperationLatency)
+		}
+	}
+}
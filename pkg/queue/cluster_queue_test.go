@@ -455,6 +455,88 @@ func TestClusterQueueImpl(t *testing.T) {
 			if got := cq.Pending(); got != test.wantPending {
 				t.Errorf("Got %d pending workloads, want %d", got, test.wantPending)
 			}
+			checkLocalQueueCounts(t, cq)
+		})
+	}
+}
+
+// checkLocalQueueCounts checks that the counts of the workloads by LocalQueue
+// match the workloads in the heap and in inadmissibleWorkloads.
+func checkLocalQueueCounts(t *testing.T, cq *ClusterQueue) {
+	t.Helper()
+	wantActive := make(map[string]int)
+	for _, wInfo := range cq.heap.List() {
+		wantActive[workload.QueueKey(wInfo.Obj)]++
+	}
+	if diff := cmp.Diff(wantActive, cq.activeByLocalQueue); diff != "" {
+		t.Errorf("Unexpected active workloads by LocalQueue (-want,+got):\n%s", diff)
+	}
+	wantInadmissible := make(map[string]int)
+	for _, wInfo := range cq.inadmissibleWorkloads {
+		wantInadmissible[workload.QueueKey(wInfo.Obj)]++
+	}
+	if diff := cmp.Diff(wantInadmissible, cq.inadmissibleByLocalQueue); diff != "" {
+		t.Errorf("Unexpected inadmissible workloads by LocalQueue (-want,+got):\n%s", diff)
+	}
+}
+
+func TestClusterQueueHead(t *testing.T) {
+	now := time.Now()
+	makeInfo := func(name string, priority int32, created time.Duration) *workload.Info {
+		return workload.NewInfo(utiltesting.MakeWorkload(name, "ns").
+			Queue("lq").
+			Priority(priority).
+			Creation(now.Add(created)).
+			Obj())
+	}
+	cq := newClusterQueueImpl(defaultOrdering, testingclock.NewFakeClock(now))
+	for _, wInfo := range []*workload.Info{
+		makeInfo("low", 0, 0),
+		makeInfo("high-old", 10, 0),
+		makeInfo("high-new", 10, time.Second),
+		makeInfo("mid", 5, 0),
+	} {
+		cq.PushOrUpdate(wInfo)
+	}
+	for _, wInfo := range []*workload.Info{
+		makeInfo("inadmissible-high", 10, 2*time.Second),
+		makeInfo("inadmissible-low", 1, 0),
+	} {
+		cq.requeueIfNotPresent(wInfo, false)
+	}
+	if inflight := cq.Pop(); inflight == nil || inflight.Obj.Name != "high-old" {
+		t.Fatalf("Unexpected head popped: %v", inflight)
+	}
+
+	cases := map[string]struct {
+		n    int
+		want []string
+	}{
+		"none": {
+			n: 0,
+		},
+		"first": {
+			n:    1,
+			want: []string{"ns/high-old"},
+		},
+		"first with the inadmissible": {
+			n:    4,
+			want: []string{"ns/high-old", "ns/high-new", "ns/inadmissible-high", "ns/mid"},
+		},
+		"all": {
+			n:    10,
+			want: []string{"ns/high-old", "ns/high-new", "ns/inadmissible-high", "ns/mid", "ns/inadmissible-low", "ns/low"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			for _, wInfo := range cq.Head(tc.n) {
+				got = append(got, workload.Key(wInfo.Obj))
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected head (-want,+got):\n%s", diff)
+			}
 		})
 	}
 }
@@ -905,3 +987,27 @@ func TestStrictFIFORequeueIfNotPresent(t *testing.T) {
 		})
 	}
 }
+
+func TestAdmissionThroughput(t *testing.T) {
+	now := time.Now()
+	fakeClock := testingclock.NewFakeClock(now)
+	cq := newClusterQueueImpl(defaultOrdering, fakeClock)
+	if got := cq.AdmissionThroughput(); got != 0 {
+		t.Errorf("Unexpected throughput without quota reservations, got %v", got)
+	}
+	cq.RecordQuotaReservation()
+	fakeClock.Step(30 * time.Minute)
+	cq.RecordQuotaReservation()
+	cq.RecordQuotaReservation()
+	if diff := cmp.Diff(3/admissionThroughputWindow.Seconds(), cq.AdmissionThroughput()); diff != "" {
+		t.Errorf("Unexpected throughput (-want,+got):\n%s", diff)
+	}
+	fakeClock.Step(45 * time.Minute)
+	if diff := cmp.Diff(2/admissionThroughputWindow.Seconds(), cq.AdmissionThroughput()); diff != "" {
+		t.Errorf("Unexpected throughput after the first reservation expired (-want,+got):\n%s", diff)
+	}
+	fakeClock.Step(time.Hour)
+	if got := cq.AdmissionThroughput(); got != 0 {
+		t.Errorf("Unexpected throughput after all the reservations expired, got %v", got)
+	}
+}
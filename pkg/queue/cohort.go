@@ -16,20 +16,56 @@ limitations under the License.
 
 package queue
 
-import "sigs.k8s.io/kueue/pkg/hierarchy"
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/hierarchy"
+)
 
 // cohort is a set of ClusterQueues that can borrow resources from
 // each other.
 type cohort struct {
 	Name string
 	hierarchy.Cohort[*ClusterQueue, *cohort]
+
+	queueingStrategy kueue.QueueingStrategy
+	// priorityClassMappings maps the WorkloadPriorityClasses of the jobs to
+	// the ones of their workloads.
+	priorityClassMappings map[string]string
 }
 
 func newCohort(name string) *cohort {
 	return &cohort{
-		name,
-		hierarchy.NewCohort[*ClusterQueue, *cohort](),
+		Name:   name,
+		Cohort: hierarchy.NewCohort[*ClusterQueue, *cohort](),
+	}
+}
+
+// strictFIFO returns whether the Cohort, or one of its ancestors, orders the
+// workloads of all its ClusterQueues together in strict FIFO.
+func (c *cohort) strictFIFO() bool {
+	visited := sets.New[string]()
+	for co := c; co != nil && !visited.Has(co.Name); co = co.Parent() {
+		if co.queueingStrategy == kueue.StrictFIFO {
+			return true
+		}
+		visited.Insert(co.Name)
+	}
+	return false
+}
+
+// priorityClassMappings returns the mappings of the WorkloadPriorityClasses
+// by the name of the class they map.
+func priorityClassMappings(mappings []kueue.PriorityClassMapping) map[string]string {
+	if len(mappings) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(mappings))
+	for _, mapping := range mappings {
+		m[mapping.From] = mapping.To
 	}
+	return m
 }
 
 func (c *cohort) GetName() string {
@@ -61,17 +61,11 @@ func (q *LocalQueue) AddOrUpdate(info *workload.Info) {
 
 func (m *Manager) PendingActiveInLocalQueue(lq *LocalQueue) int {
 	c, ok := m.getClusterQueueLockless(lq.ClusterQueue)
-	result := 0
 	if !ok {
 		return 0
 	}
-	for _, wl := range c.heap.List() {
-		wlLqKey := workload.QueueKey(wl.Obj)
-		if wlLqKey == lq.Key {
-			result++
-		}
-	}
-	if c.inflight != nil && workloadKey(c.inflight) == lq.Key {
+	result := c.activeByLocalQueue[lq.Key]
+	if c.inflight != nil && workload.QueueKey(c.inflight.Obj) == lq.Key {
 		result++
 	}
 	return result
@@ -82,12 +76,5 @@ func (m *Manager) PendingInadmissibleInLocalQueue(lq *LocalQueue) int {
 	if !ok {
 		return 0
 	}
-	result := 0
-	for _, wl := range c.inadmissibleWorkloads {
-		wlLqKey := workload.QueueKey(wl.Obj)
-		if wlLqKey == lq.Key {
-			result++
-		}
-	}
-	return result
+	return c.inadmissibleByLocalQueue[lq.Key]
 }
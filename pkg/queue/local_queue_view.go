@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// localQueueView holds the ClusterQueues of the LocalQueues, the
+// ClusterQueues in shadow mode, and the priority classes allowed in and the
+// flavors of the ClusterQueues, for the webhooks. It is updated by the Manager
+// along with the queues, under its lock, and read without taking the lock, so
+// that the latency of the webhooks doesn't depend on the contention of the
+// lock during the scheduling cycles.
+type localQueueView struct {
+	// clusterQueues maps the keys of the LocalQueues to the names of their
+	// ClusterQueues.
+	clusterQueues sync.Map
+	// shadowClusterQueues holds the names of the ClusterQueues in shadow mode.
+	shadowClusterQueues sync.Map
+	// allowedPriorityClasses maps the names of the ClusterQueues restricting
+	// the priority classes to the sets of the allowed priority classes.
+	allowedPriorityClasses sync.Map
+	// flavors maps the names of the ClusterQueues to the sets of the flavors
+	// of their resource groups.
+	flavors sync.Map
+}
+
+func (v *localQueueView) setLocalQueue(key, cqName string) {
+	v.clusterQueues.Store(key, cqName)
+}
+
+func (v *localQueueView) deleteLocalQueue(key string) {
+	v.clusterQueues.Delete(key)
+}
+
+func (v *localQueueView) setShadowMode(cqName string, enabled bool) {
+	if enabled {
+		v.shadowClusterQueues.Store(cqName, struct{}{})
+	} else {
+		v.shadowClusterQueues.Delete(cqName)
+	}
+}
+
+func (v *localQueueView) hasLocalQueue(key string) bool {
+	_, found := v.clusterQueues.Load(key)
+	return found
+}
+
+func (v *localQueueView) localQueueInShadowMode(key string) bool {
+	cqName, found := v.clusterQueues.Load(key)
+	if !found {
+		return false
+	}
+	_, shadow := v.shadowClusterQueues.Load(cqName)
+	return shadow
+}
+
+func (v *localQueueView) setAllowedPriorityClasses(cqName string, names []string) {
+	if len(names) > 0 {
+		v.allowedPriorityClasses.Store(cqName, sets.New(names...))
+	} else {
+		v.allowedPriorityClasses.Delete(cqName)
+	}
+}
+
+func (v *localQueueView) localQueueAllowedPriorityClasses(key string) sets.Set[string] {
+	cqName, found := v.clusterQueues.Load(key)
+	if !found {
+		return nil
+	}
+	allowed, restricted := v.allowedPriorityClasses.Load(cqName)
+	if !restricted {
+		return nil
+	}
+	return allowed.(sets.Set[string])
+}
+
+func (v *localQueueView) setFlavors(cqName string, resourceGroups []kueue.ResourceGroup) {
+	flavors := sets.New[kueue.ResourceFlavorReference]()
+	for _, rg := range resourceGroups {
+		for _, fq := range rg.Flavors {
+			flavors.Insert(fq.Name)
+		}
+	}
+	v.flavors.Store(cqName, flavors)
+}
+
+func (v *localQueueView) deleteFlavors(cqName string) {
+	v.flavors.Delete(cqName)
+}
+
+func (v *localQueueView) localQueueFlavors(key string) sets.Set[kueue.ResourceFlavorReference] {
+	cqName, found := v.clusterQueues.Load(key)
+	if !found {
+		return nil
+	}
+	flavors, found := v.flavors.Load(cqName)
+	if !found {
+		return nil
+	}
+	return flavors.(sets.Set[kueue.ResourceFlavorReference])
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -46,7 +48,9 @@ var (
 
 type options struct {
 	podsReadyRequeuingTimestamp config.RequeuingTimestamp
+	tieBreakers                 []config.TieBreaker
 	workloadInfoOptions         []workload.InfoOption
+	shadowMode                  bool
 }
 
 // Option configures the manager.
@@ -65,6 +69,14 @@ func WithPodsReadyRequeuingTimestamp(ts config.RequeuingTimestamp) Option {
 	}
 }
 
+// WithTieBreakers sets the keys ordering the workloads of equal priority and
+// timestamp.
+func WithTieBreakers(tieBreakers []config.TieBreaker) Option {
+	return func(o *options) {
+		o.tieBreakers = tieBreakers
+	}
+}
+
 // WithExcludedResourcePrefixes sets the list of excluded resource prefixes
 func WithExcludedResourcePrefixes(excludedPrefixes []string) Option {
 	return func(o *options) {
@@ -79,6 +91,13 @@ func WithResourceTransformations(transforms []config.ResourceTransformation) Opt
 	}
 }
 
+// WithShadowMode sets whether all the ClusterQueues are in shadow mode.
+func WithShadowMode(enabled bool) Option {
+	return func(o *options) {
+		o.shadowMode = enabled
+	}
+}
+
 type Manager struct {
 	sync.RWMutex
 	cond sync.Cond
@@ -91,10 +110,26 @@ type Manager struct {
 	snapshots      map[string][]kueue.ClusterQueuePendingWorkload
 
 	workloadOrdering workload.Ordering
+	// submissionCounters hold the submission counters of the queued
+	// workloads, by workload key, when the workloads are ordered by them.
+	submissionCounters    map[string]int64
+	nextSubmissionCounter int64
 
 	workloadInfoOptions []workload.InfoOption
 
+	shadowMode bool
+
 	hm hierarchy.Manager[*ClusterQueue, *cohort]
+
+	// tenants are the keys of the LocalQueues of the Tenants, by Tenant name.
+	tenants map[string]sets.Set[string]
+
+	// resizeRequests are the resized workloads with quota reserved, which
+	// request additional quota from their ClusterQueues, by workload key.
+	resizeRequests map[string]*workload.Info
+
+	// webhookView is the view of the queues read by the webhooks.
+	webhookView localQueueView
 }
 
 func NewManager(client client.Client, checker StatusChecker, opts ...Option) *Manager {
@@ -110,20 +145,35 @@ func NewManager(client client.Client, checker StatusChecker, opts ...Option) *Ma
 		snapshots:      make(map[string][]kueue.ClusterQueuePendingWorkload, 0),
 		workloadOrdering: workload.Ordering{
 			PodsReadyRequeuingTimestamp: options.podsReadyRequeuingTimestamp,
+			TieBreakers:                 options.tieBreakers,
 		},
+		submissionCounters:  make(map[string]int64),
 		workloadInfoOptions: options.workloadInfoOptions,
+		shadowMode:          options.shadowMode,
 		hm:                  hierarchy.NewManager[*ClusterQueue, *cohort](newCohort),
+		tenants:             make(map[string]sets.Set[string]),
+		resizeRequests:      make(map[string]*workload.Info),
 	}
 	m.cond.L = &m.RWMutex
 	return m
 }
 
+// SetWorkloadInfoOptions replaces the options used to compute the resource
+// requests of the workloads added or updated afterwards.
+func (m *Manager) SetWorkloadInfoOptions(opts ...workload.InfoOption) {
+	m.Lock()
+	defer m.Unlock()
+	m.workloadInfoOptions = opts
+}
+
 func (m *Manager) AddOrUpdateCohort(ctx context.Context, cohort *kueuealpha.Cohort) {
 	m.Lock()
 	defer m.Unlock()
 	m.hm.AddCohort(cohort.Name)
 	m.hm.UpdateCohortEdge(cohort.Name, cohort.Spec.Parent)
-	if m.requeueWorkloadsCohort(ctx, m.hm.Cohorts[cohort.Name]) {
+	m.hm.Cohorts[cohort.Name].queueingStrategy = cohort.Spec.QueueingStrategy
+	m.hm.Cohorts[cohort.Name].priorityClassMappings = priorityClassMappings(cohort.Spec.PriorityClassMappings)
+	if m.requeueWorkloadsCohort(ctx, m.hm.Cohorts[cohort.Name], nil) {
 		m.Broadcast()
 	}
 }
@@ -134,6 +184,51 @@ func (m *Manager) DeleteCohort(cohortName string) {
 	m.hm.DeleteCohort(cohortName)
 }
 
+// AddOrUpdateTenant updates the LocalQueues of the Tenant, and requeues the
+// inadmissible workloads of the ClusterQueues of its old and new LocalQueues,
+// as its limits could have changed.
+func (m *Manager) AddOrUpdateTenant(ctx context.Context, tenant *kueuealpha.Tenant) {
+	m.Lock()
+	defer m.Unlock()
+	lqKeys := sets.New[string]()
+	for _, lq := range tenant.Spec.LocalQueues {
+		lqKeys.Insert(lq.Namespace + "/" + lq.Name)
+	}
+	oldKeys := m.tenants[tenant.Name]
+	m.tenants[tenant.Name] = lqKeys
+	if m.requeueWorkloadsLocalQueues(ctx, lqKeys.Union(oldKeys)) {
+		m.Broadcast()
+	}
+}
+
+func (m *Manager) DeleteTenant(ctx context.Context, tenantName string) {
+	m.Lock()
+	defer m.Unlock()
+	lqKeys := m.tenants[tenantName]
+	delete(m.tenants, tenantName)
+	if m.requeueWorkloadsLocalQueues(ctx, lqKeys) {
+		m.Broadcast()
+	}
+}
+
+// QueueInadmissibleWorkloadsOfBudget requeues the inadmissible workloads of
+// the ClusterQueues of the LocalQueues bound to the Budget, as it could have
+// stopped blocking their admission.
+func (m *Manager) QueueInadmissibleWorkloadsOfBudget(ctx context.Context, budget *kueuealpha.Budget) {
+	m.Lock()
+	defer m.Unlock()
+	lqKeys := sets.New[string]()
+	for key := range m.localQueues {
+		namespace, name, _ := strings.Cut(key, "/")
+		if namespace == budget.Namespace && (len(budget.Spec.LocalQueues) == 0 || slices.Contains(budget.Spec.LocalQueues, name)) {
+			lqKeys.Insert(key)
+		}
+	}
+	if m.requeueWorkloadsLocalQueues(ctx, lqKeys) {
+		m.Broadcast()
+	}
+}
+
 func (m *Manager) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) error {
 	m.Lock()
 	defer m.Unlock()
@@ -148,6 +243,9 @@ func (m *Manager) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) e
 	}
 	m.hm.AddClusterQueue(cqImpl)
 	m.hm.UpdateClusterQueueEdge(cq.Name, cq.Spec.Cohort)
+	m.webhookView.setShadowMode(cq.Name, cqImpl.ShadowMode())
+	m.webhookView.setAllowedPriorityClasses(cq.Name, cq.Spec.AllowedPriorityClasses)
+	m.webhookView.setFlavors(cq.Name, cq.Spec.ResourceGroups)
 
 	// Iterate through existing queues, as queues corresponding to this cluster
 	// queue might have been added earlier.
@@ -164,7 +262,7 @@ func (m *Manager) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) e
 		}
 	}
 
-	queued := m.requeueWorkloadsCQ(ctx, cqImpl)
+	queued := m.requeueWorkloadsCQ(ctx, cqImpl, nil)
 	m.reportPendingWorkloads(cq.Name, cqImpl)
 
 	// needs to be iterated over again here incase inadmissible workloads were added by requeueWorkloadsCQ
@@ -197,10 +295,13 @@ func (m *Manager) UpdateClusterQueue(ctx context.Context, cq *kueue.ClusterQueue
 		return err
 	}
 	m.hm.UpdateClusterQueueEdge(cq.Name, cq.Spec.Cohort)
+	m.webhookView.setShadowMode(cq.Name, cqImpl.ShadowMode())
+	m.webhookView.setAllowedPriorityClasses(cq.Name, cq.Spec.AllowedPriorityClasses)
+	m.webhookView.setFlavors(cq.Name, cq.Spec.ResourceGroups)
 
 	// TODO(#8): Selectively move workloads based on the exact event.
 	// If any workload becomes admissible or the queue becomes active.
-	if (specUpdated && m.requeueWorkloadsCQ(ctx, cqImpl)) || (!oldActive && cqImpl.Active()) {
+	if (specUpdated && m.requeueWorkloadsCQ(ctx, cqImpl, nil)) || (!oldActive && cqImpl.Active()) {
 		m.reportPendingWorkloads(cq.Name, cqImpl)
 		if features.Enabled(features.LocalQueueMetrics) {
 			for _, q := range m.localQueues {
@@ -222,15 +323,17 @@ func (m *Manager) DeleteClusterQueue(cq *kueue.ClusterQueue) {
 		return
 	}
 	m.hm.DeleteClusterQueue(cq.Name)
+	m.webhookView.setShadowMode(cq.Name, false)
+	m.webhookView.setAllowedPriorityClasses(cq.Name, nil)
+	m.webhookView.deleteFlavors(cq.Name)
 	metrics.ClearClusterQueueMetrics(cq.Name)
 }
 
+// DefaultLocalQueueExist returns whether the default LocalQueue exists in the
+// namespace. It doesn't take the lock of the manager, as it's called by the
+// webhooks.
 func (m *Manager) DefaultLocalQueueExist(namespace string) bool {
-	m.Lock()
-	defer m.Unlock()
-
-	_, ok := m.localQueues[DefaultQueueKey(namespace)]
-	return ok
+	return m.webhookView.hasLocalQueue(DefaultQueueKey(namespace))
 }
 
 func (m *Manager) AddLocalQueue(ctx context.Context, q *kueue.LocalQueue) error {
@@ -243,6 +346,7 @@ func (m *Manager) AddLocalQueue(ctx context.Context, q *kueue.LocalQueue) error
 	}
 	qImpl := newLocalQueue(q)
 	m.localQueues[key] = qImpl
+	m.webhookView.setLocalQueue(key, qImpl.ClusterQueue)
 	// Iterate through existing workloads, as workloads corresponding to this
 	// queue might have been added earlier.
 	var workloads kueue.WorkloadList
@@ -254,7 +358,7 @@ func (m *Manager) AddLocalQueue(ctx context.Context, q *kueue.LocalQueue) error
 			continue
 		}
 		workload.AdjustResources(ctx, m.client, &w)
-		qImpl.AddOrUpdate(workload.NewInfo(&w, m.workloadInfoOptions...))
+		qImpl.AddOrUpdate(m.newWorkloadInfo(&w))
 	}
 	cq := m.hm.ClusterQueues[qImpl.ClusterQueue]
 	if cq != nil && cq.AddFromLocalQueue(qImpl) {
@@ -281,6 +385,7 @@ func (m *Manager) UpdateLocalQueue(q *kueue.LocalQueue) error {
 		}
 	}
 	qImpl.update(q)
+	m.webhookView.setLocalQueue(Key(q), qImpl.ClusterQueue)
 	return nil
 }
 
@@ -300,6 +405,7 @@ func (m *Manager) DeleteLocalQueue(q *kueue.LocalQueue) {
 		metrics.ClearLocalQueueMetrics(metrics.LQRefFromLocalQueueKey(key))
 	}
 	delete(m.localQueues, key)
+	m.webhookView.deleteLocalQueue(key)
 }
 
 func (m *Manager) PendingWorkloads(q *kueue.LocalQueue) (int32, error) {
@@ -326,6 +432,17 @@ func (m *Manager) Pending(cq *kueue.ClusterQueue) (int, error) {
 	return cqImpl.Pending(), nil
 }
 
+// PendingInClusterQueue returns the number of pending workloads of the
+// ClusterQueue, active or inadmissible. The heads popped in the current
+// scheduling cycle are not counted.
+func (m *Manager) PendingInClusterQueue(cqName string) int {
+	cq := m.getClusterQueue(cqName)
+	if cq == nil {
+		return 0
+	}
+	return cq.Pending()
+}
+
 func (m *Manager) QueueForWorkloadExists(wl *kueue.Workload) bool {
 	m.RLock()
 	defer m.RUnlock()
@@ -361,7 +478,7 @@ func (m *Manager) AddOrUpdateWorkloadWithoutLock(w *kueue.Workload) bool {
 	if q == nil {
 		return false
 	}
-	wInfo := workload.NewInfo(w, m.workloadInfoOptions...)
+	wInfo := m.newWorkloadInfo(w)
 	q.AddOrUpdate(wInfo)
 	cq := m.hm.ClusterQueues[q.ClusterQueue]
 	if cq == nil {
@@ -413,12 +530,55 @@ func (m *Manager) RequeueWorkload(ctx context.Context, info *workload.Info, reas
 	return added
 }
 
+// newWorkloadInfo returns the Info of a workload being queued, with its
+// submission counter when the workloads are ordered by it.
+func (m *Manager) newWorkloadInfo(w *kueue.Workload) *workload.Info {
+	info := workload.NewInfo(w, m.workloadInfoOptions...)
+	if m.workloadOrdering.UsesSubmissionCounter() {
+		k := workload.Key(w)
+		counter, found := m.submissionCounters[k]
+		if !found {
+			m.nextSubmissionCounter++
+			counter = m.nextSubmissionCounter
+			m.submissionCounters[k] = counter
+		}
+		info.SubmissionCounter = counter
+	}
+	return info
+}
+
 func (m *Manager) DeleteWorkload(w *kueue.Workload) {
 	m.Lock()
 	m.deleteWorkloadFromQueueAndClusterQueue(w, workload.QueueKey(w))
+	delete(m.submissionCounters, workload.Key(w))
+	delete(m.resizeRequests, workload.Key(w))
 	m.Unlock()
 }
 
+// RequestResize requests the additional quota used by the resized workload,
+// with quota reserved, to be reserved in the next scheduling cycle. It
+// replaces the previous request of the workload.
+func (m *Manager) RequestResize(w *kueue.Workload) {
+	m.Lock()
+	defer m.Unlock()
+	info := m.newWorkloadInfo(w)
+	info.ClusterQueue = string(w.Status.Admission.ClusterQueue)
+	m.resizeRequests[workload.Key(w)] = info
+	m.cond.Broadcast()
+}
+
+// PopResizeRequests returns the pending resize requests and clears them.
+func (m *Manager) PopResizeRequests() []workload.Info {
+	m.Lock()
+	defer m.Unlock()
+	requests := make([]workload.Info, 0, len(m.resizeRequests))
+	for key, info := range m.resizeRequests {
+		requests = append(requests, *info)
+		delete(m.resizeRequests, key)
+	}
+	return requests
+}
+
 func (m *Manager) deleteWorkloadFromQueueAndClusterQueue(w *kueue.Workload, qKey string) {
 	q := m.localQueues[qKey]
 	if q == nil {
@@ -438,6 +598,8 @@ func (m *Manager) deleteWorkloadFromQueueAndClusterQueue(w *kueue.Workload, qKey
 // QueueAssociatedInadmissibleWorkloadsAfter requeues into the heaps all
 // previously inadmissible workloads in the same ClusterQueue and cohort (if
 // they exist) as the provided admitted workload to the heaps.
+// If the FlavorAwareRequeue feature is enabled, only the workloads waiting
+// for the flavors assigned to the admitted workload are requeued.
 // An optional action can be executed at the beginning of the function,
 // while holding the lock, to provide atomicity with the operations in the
 // queues.
@@ -457,11 +619,57 @@ func (m *Manager) QueueAssociatedInadmissibleWorkloadsAfter(ctx context.Context,
 		return
 	}
 
-	if m.requeueWorkloadsCQ(ctx, cq) {
+	var flavors sets.Set[kueue.ResourceFlavorReference]
+	if features.Enabled(features.FlavorAwareRequeue) {
+		flavors = assignedFlavors(w)
+	}
+	queued := m.requeueWorkloadsCQ(ctx, cq, flavors)
+	// The workloads of the other LocalQueues of the Tenants of the workload
+	// could fit in the limits of the Tenants now, whichever their flavors are.
+	for _, lqKeys := range m.tenants {
+		if lqKeys.Has(q.Key) {
+			queued = m.requeueWorkloadsLocalQueues(ctx, lqKeys) || queued
+		}
+	}
+	if queued {
 		m.Broadcast()
 	}
 }
 
+// requeueWorkloadsLocalQueues moves the inadmissible workloads of the
+// ClusterQueues of the LocalQueues, and of their cohorts, to the heaps.
+// It returns whether any workload was moved.
+func (m *Manager) requeueWorkloadsLocalQueues(ctx context.Context, lqKeys sets.Set[string]) bool {
+	cqNames := sets.New[string]()
+	for key := range lqKeys {
+		if q := m.localQueues[key]; q != nil {
+			cqNames.Insert(q.ClusterQueue)
+		}
+	}
+	queued := false
+	for name := range cqNames {
+		if cq := m.hm.ClusterQueues[name]; cq != nil {
+			queued = m.requeueWorkloadsCQ(ctx, cq, nil) || queued
+		}
+	}
+	return queued
+}
+
+// assignedFlavors returns the flavors assigned to the workload, or nil if the
+// workload has no quota reserved.
+func assignedFlavors(w *kueue.Workload) sets.Set[kueue.ResourceFlavorReference] {
+	if !workload.HasQuotaReservation(w) {
+		return nil
+	}
+	flavors := sets.New[kueue.ResourceFlavorReference]()
+	for _, psa := range w.Status.Admission.PodSetAssignments {
+		for _, flavor := range psa.Flavors {
+			flavors.Insert(flavor)
+		}
+	}
+	return flavors
+}
+
 // QueueInadmissibleWorkloads moves all inadmissibleWorkloads in
 // corresponding ClusterQueues to heap. If at least one workload queued,
 // we will broadcast the event.
@@ -478,7 +686,7 @@ func (m *Manager) QueueInadmissibleWorkloads(ctx context.Context, cqNames sets.S
 		if !exists {
 			continue
 		}
-		if m.requeueWorkloadsCQ(ctx, cq) {
+		if m.requeueWorkloadsCQ(ctx, cq, nil) {
 			queued = true
 		}
 	}
@@ -491,8 +699,9 @@ func (m *Manager) QueueInadmissibleWorkloads(ctx context.Context, cqNames sets.S
 // requeueWorkloadsCQ moves all workloads in the same
 // cohort with this ClusterQueue from inadmissibleWorkloads to heap. If the
 // cohort of this ClusterQueue is empty, it just moves all workloads in this
-// ClusterQueue. If at least one workload is moved, returns true, otherwise
-// returns false.
+// ClusterQueue. If flavors is not nil, only the workloads waiting for any
+// of them are moved. If at least one workload is moved, returns true,
+// otherwise returns false.
 // The events listed below could make workloads in the same cohort admissible.
 // Then requeueWorkloadsCQ need to be invoked.
 // 1. delete events for any admitted workload in the cohort.
@@ -503,11 +712,11 @@ func (m *Manager) QueueInadmissibleWorkloads(ctx context.Context, cqNames sets.S
 // WARNING: must hold a read-lock on the manager when calling,
 // or otherwise risk encountering an infinite loop if a Cohort
 // cycle is introduced.
-func (m *Manager) requeueWorkloadsCQ(ctx context.Context, cq *ClusterQueue) bool {
+func (m *Manager) requeueWorkloadsCQ(ctx context.Context, cq *ClusterQueue, flavors sets.Set[kueue.ResourceFlavorReference]) bool {
 	if cq.HasParent() {
-		return m.requeueWorkloadsCohort(ctx, cq.Parent())
+		return m.requeueWorkloadsCohort(ctx, cq.Parent(), flavors)
 	}
-	return cq.QueueInadmissibleWorkloads(ctx, m.client)
+	return cq.QueueInadmissibleWorkloadsWaitingFor(ctx, m.client, flavors)
 }
 
 // moveWorkloadsCohorts checks for a cycle, the moves all inadmissible
@@ -517,7 +726,7 @@ func (m *Manager) requeueWorkloadsCQ(ctx context.Context, cq *ClusterQueue) bool
 // WARNING: must hold a read-lock on the manager when calling,
 // or otherwise risk encountering an infinite loop if a Cohort
 // cycle is introduced.
-func (m *Manager) requeueWorkloadsCohort(ctx context.Context, cohort *cohort) bool {
+func (m *Manager) requeueWorkloadsCohort(ctx context.Context, cohort *cohort, flavors sets.Set[kueue.ResourceFlavorReference]) bool {
 	log := ctrl.LoggerFrom(ctx)
 
 	if m.hm.CycleChecker.HasCycle(cohort) {
@@ -526,16 +735,16 @@ func (m *Manager) requeueWorkloadsCohort(ctx context.Context, cohort *cohort) bo
 	}
 	root := cohort.getRootUnsafe()
 	log.V(2).Info("Attempting to move workloads", "cohort", cohort.Name, "root", root.Name)
-	return requeueWorkloadsCohortSubtree(ctx, m, root)
+	return requeueWorkloadsCohortSubtree(ctx, m, root, flavors)
 }
 
-func requeueWorkloadsCohortSubtree(ctx context.Context, m *Manager, cohort *cohort) bool {
+func requeueWorkloadsCohortSubtree(ctx context.Context, m *Manager, cohort *cohort, flavors sets.Set[kueue.ResourceFlavorReference]) bool {
 	queued := false
 	for _, clusterQueue := range cohort.ChildCQs() {
-		queued = clusterQueue.QueueInadmissibleWorkloads(ctx, m.client) || queued
+		queued = clusterQueue.QueueInadmissibleWorkloadsWaitingFor(ctx, m.client, flavors) || queued
 	}
 	for _, childCohort := range cohort.ChildCohorts() {
-		queued = requeueWorkloadsCohortSubtree(ctx, m, childCohort) || queued
+		queued = requeueWorkloadsCohortSubtree(ctx, m, childCohort, flavors) || queued
 	}
 	return queued
 }
@@ -560,7 +769,8 @@ func (m *Manager) CleanUpOnContext(ctx context.Context) {
 }
 
 // Heads returns the heads of the queues, along with their associated ClusterQueue.
-// It blocks if the queues empty until they have elements or the context terminates.
+// It blocks if the queues empty until they have elements, or resizes are
+// requested, or the context terminates.
 func (m *Manager) Heads(ctx context.Context) []workload.Info {
 	m.Lock()
 	defer m.Unlock()
@@ -568,7 +778,7 @@ func (m *Manager) Heads(ctx context.Context) []workload.Info {
 	for {
 		workloads := m.heads()
 		log.V(3).Info("Obtained ClusterQueue heads", "count", len(workloads))
-		if len(workloads) != 0 {
+		if len(workloads) != 0 || len(m.resizeRequests) != 0 {
 			return workloads
 		}
 		select {
@@ -657,6 +867,24 @@ func (m *Manager) PendingWorkloadsInfo(cqName string) []*workload.Info {
 	return cq.Snapshot()
 }
 
+// RecordQuotaReservation records that a workload got quota reserved in the
+// ClusterQueue, which is used to estimate its admission throughput.
+func (m *Manager) RecordQuotaReservation(cqName string) {
+	if cq := m.getClusterQueue(cqName); cq != nil {
+		cq.RecordQuotaReservation()
+	}
+}
+
+// AdmissionThroughput returns the number of workloads per second that recently
+// got quota reserved in the ClusterQueue.
+func (m *Manager) AdmissionThroughput(cqName string) float64 {
+	cq := m.getClusterQueue(cqName)
+	if cq == nil {
+		return 0
+	}
+	return cq.AdmissionThroughput()
+}
+
 // ClusterQueueFromLocalQueue returns ClusterQueue name and whether it's found,
 // given a QueueKey(namespace/localQueueName) as the parameter
 func (m *Manager) ClusterQueueFromLocalQueue(localQueueKey string) (string, bool) {
@@ -668,6 +896,105 @@ func (m *Manager) ClusterQueueFromLocalQueue(localQueueKey string) (string, bool
 	return "", false
 }
 
+// ClusterQueueInShadowMode returns whether the ClusterQueue is in shadow mode,
+// in which Kueue computes the admissions and preemptions of its workloads
+// without enforcing them.
+func (m *Manager) ClusterQueueInShadowMode(cqName string) bool {
+	if m == nil {
+		return false
+	}
+	if m.shadowMode {
+		return true
+	}
+	m.RLock()
+	defer m.RUnlock()
+	cq := m.hm.ClusterQueues[cqName]
+	return cq != nil && cq.ShadowMode()
+}
+
+// ClusterQueueWaitForPodsReady returns whether the ClusterQueue overrides
+// waiting for the pods of the admitted workloads to be ready, or nil if it
+// doesn't, or it doesn't exist.
+func (m *Manager) ClusterQueueWaitForPodsReady(cqName string) *bool {
+	if m == nil {
+		return nil
+	}
+	m.RLock()
+	defer m.RUnlock()
+	cq := m.hm.ClusterQueues[cqName]
+	if cq == nil {
+		return nil
+	}
+	return cq.WaitForPodsReady()
+}
+
+// LocalQueueInShadowMode returns whether the ClusterQueue of the LocalQueue,
+// given its QueueKey(namespace/localQueueName), is in shadow mode. It doesn't
+// take the lock of the manager, as it's called by the webhooks.
+func (m *Manager) LocalQueueInShadowMode(localQueueKey string) bool {
+	if m == nil {
+		return false
+	}
+	if m.shadowMode {
+		return true
+	}
+	return m.webhookView.localQueueInShadowMode(localQueueKey)
+}
+
+// LocalQueueAllowedPriorityClasses returns the priority classes allowed in the
+// ClusterQueue of the LocalQueue, given its QueueKey(namespace/localQueueName),
+// or nil when any priority class is allowed. The returned set must not be
+// modified. It doesn't take the lock of the manager, as it's called by the
+// webhooks.
+func (m *Manager) LocalQueueAllowedPriorityClasses(localQueueKey string) sets.Set[string] {
+	if m == nil {
+		return nil
+	}
+	return m.webhookView.localQueueAllowedPriorityClasses(localQueueKey)
+}
+
+// LocalQueueFlavors returns the flavors of the resource groups of the
+// ClusterQueue of the LocalQueue, given its QueueKey(namespace/localQueueName),
+// or nil when the ClusterQueue is unknown. The returned set must not be
+// modified. It doesn't take the lock of the manager, as it's called by the
+// webhooks.
+func (m *Manager) LocalQueueFlavors(localQueueKey string) sets.Set[kueue.ResourceFlavorReference] {
+	if m == nil {
+		return nil
+	}
+	return m.webhookView.localQueueFlavors(localQueueKey)
+}
+
+// LocalQueueMappedPriorityClass returns the WorkloadPriorityClass to which
+// the ClusterQueue of the LocalQueue, given its QueueKey(namespace/localQueueName),
+// or otherwise the closest of its Cohorts mapping it, maps the priority class.
+func (m *Manager) LocalQueueMappedPriorityClass(localQueueKey, priorityClass string) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+	m.RLock()
+	defer m.RUnlock()
+	q := m.localQueues[localQueueKey]
+	if q == nil {
+		return "", false
+	}
+	cq := m.hm.ClusterQueues[q.ClusterQueue]
+	if cq == nil {
+		return "", false
+	}
+	if to, found := cq.mappedPriorityClass(priorityClass); found {
+		return to, true
+	}
+	visited := sets.New[string]()
+	for co := cq.Parent(); co != nil && !visited.Has(co.Name); co = co.Parent() {
+		if to, found := co.priorityClassMappings[priorityClass]; found {
+			return to, true
+		}
+		visited.Insert(co.Name)
+	}
+	return "", false
+}
+
 func QueueKey(namespace, name string) string {
 	return fmt.Sprintf("%s/%s", namespace, name)
 }
@@ -680,13 +1007,7 @@ func (m *Manager) UpdateSnapshot(cqName string, maxCount int32) bool {
 		return false
 	}
 	newSnapshot := make([]kueue.ClusterQueuePendingWorkload, 0)
-	for index, info := range cq.Snapshot() {
-		if int32(index) >= maxCount {
-			break
-		}
-		if info == nil {
-			continue
-		}
+	for _, info := range cq.Head(int(maxCount)) {
 		newSnapshot = append(newSnapshot, kueue.ClusterQueuePendingWorkload{
 			Name:      info.Obj.Name,
 			Namespace: info.Obj.Namespace,
@@ -30,10 +30,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -249,11 +252,204 @@ func TestRequeueWorkloadsCohortCycle(t *testing.T) {
 
 	// This method is where we do a cycle check. We call it to ensure
 	// it behaves properly when a cycle exists
-	if manager.requeueWorkloadsCohort(ctx, manager.hm.Cohorts["cohort-a"]) {
+	if manager.requeueWorkloadsCohort(ctx, manager.hm.Cohorts["cohort-a"], nil) {
 		t.Fatal("Expected moveWorkloadsCohort to return false")
 	}
 }
 
+func TestRequeueWorkloadStrictFIFOCohort(t *testing.T) {
+	cases := map[string]struct {
+		enableCohortStrictFIFO bool
+		wantInadmissible       map[string][]string
+	}{
+		"feature disabled": {
+			wantInadmissible: map[string][]string{
+				"cq1": {"default/a"},
+			},
+		},
+		"feature enabled": {
+			enableCohortStrictFIFO: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.CohortStrictFIFO, tc.enableCohortStrictFIFO)
+			cohorts := []*kueuealpha.Cohort{
+				utiltesting.MakeCohort("root").QueueingStrategy(kueue.StrictFIFO).Obj(),
+				utiltesting.MakeCohort("child").Parent("root").Obj(),
+			}
+			cq := utiltesting.MakeClusterQueue("cq1").Cohort("child").Obj()
+			lq := utiltesting.MakeLocalQueue("foo", defaultNamespace).ClusterQueue("cq1").Obj()
+			wl := utiltesting.MakeWorkload("a", defaultNamespace).Queue("foo").Creation(time.Now()).Obj()
+			ctx := context.Background()
+			cl := utiltesting.NewFakeClient(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: defaultNamespace}},
+			)
+			manager := NewManager(cl, nil)
+			for _, cohort := range cohorts {
+				manager.AddOrUpdateCohort(ctx, cohort)
+			}
+			if err := manager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed adding clusterQueue %s: %v", cq.Name, err)
+			}
+			if err := manager.AddLocalQueue(ctx, lq); err != nil {
+				t.Fatalf("Failed adding queue %s: %v", lq.Name, err)
+			}
+			if err := cl.Create(ctx, wl); err != nil {
+				t.Fatalf("Failed adding workload to client: %v", err)
+			}
+			manager.AddOrUpdateWorkload(wl)
+			heads := manager.Heads(ctx)
+			if len(heads) != 1 {
+				t.Fatalf("Unexpected heads, want 1, got %d", len(heads))
+			}
+			if !manager.RequeueWorkload(ctx, &heads[0], RequeueReasonGeneric) {
+				t.Fatalf("Failed to requeue workload %s", wl.Name)
+			}
+			if diff := cmp.Diff(tc.wantInadmissible, manager.DumpInadmissible()); diff != "" {
+				t.Errorf("Unexpected inadmissible workloads (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestQueueAssociatedInadmissibleWorkloadsWaitingForFlavors tests that the
+// inadmissible workloads are requeued when quota is released in the flavors
+// they are waiting for.
+func TestQueueAssociatedInadmissibleWorkloadsWaitingForFlavors(t *testing.T) {
+	waiting := map[string]sets.Set[kueue.ResourceFlavorReference]{
+		"on-demand": sets.New[kueue.ResourceFlavorReference]("on-demand"),
+		"spot":      sets.New[kueue.ResourceFlavorReference]("spot"),
+		"any":       nil,
+	}
+	cases := map[string]struct {
+		enableFlavorAwareRequeue bool
+		released                 *kueue.Workload
+		wantInadmissible         map[string][]string
+	}{
+		"all workloads are requeued when the feature is disabled": {
+			released: utiltesting.MakeWorkload("released", defaultNamespace).Queue("foo").
+				ReserveQuota(utiltesting.MakeAdmission("cq1").Assignment(corev1.ResourceCPU, "on-demand", "1").Obj()).
+				Obj(),
+		},
+		"workloads waiting for the released flavor are requeued": {
+			enableFlavorAwareRequeue: true,
+			released: utiltesting.MakeWorkload("released", defaultNamespace).Queue("foo").
+				ReserveQuota(utiltesting.MakeAdmission("cq1").Assignment(corev1.ResourceCPU, "on-demand", "1").Obj()).
+				Obj(),
+			wantInadmissible: map[string][]string{
+				"cq2": {"default/spot"},
+			},
+		},
+		"all workloads are requeued when the released flavors are unknown": {
+			enableFlavorAwareRequeue: true,
+			released:                 utiltesting.MakeWorkload("released", defaultNamespace).Queue("foo").Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.FlavorAwareRequeue, tc.enableFlavorAwareRequeue)
+			ctx := context.Background()
+			cl := utiltesting.NewFakeClient(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: defaultNamespace}},
+			)
+			manager := NewManager(cl, nil)
+			for _, cq := range []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").Cohort("alpha").Obj(),
+				utiltesting.MakeClusterQueue("cq2").Cohort("alpha").Obj(),
+			} {
+				if err := manager.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Failed adding clusterQueue %s: %v", cq.Name, err)
+				}
+				// Increase the popCycle to ensure that the workloads will be added as inadmissible.
+				manager.getClusterQueue(cq.Name).popCycle++
+			}
+			for _, q := range []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue("foo", defaultNamespace).ClusterQueue("cq1").Obj(),
+				utiltesting.MakeLocalQueue("bar", defaultNamespace).ClusterQueue("cq2").Obj(),
+			} {
+				if err := manager.AddLocalQueue(ctx, q); err != nil {
+					t.Fatalf("Failed adding queue %s: %v", q.Name, err)
+				}
+			}
+			for name, flavors := range waiting {
+				wl := utiltesting.MakeWorkload(name, defaultNamespace).Queue("bar").Obj()
+				if err := cl.Create(ctx, wl); err != nil {
+					t.Fatalf("Failed adding workload to client: %v", err)
+				}
+				info := workload.NewInfo(wl)
+				info.WaitingFlavors = flavors
+				manager.RequeueWorkload(ctx, info, RequeueReasonGeneric)
+			}
+
+			manager.QueueAssociatedInadmissibleWorkloadsAfter(ctx, tc.released, nil)
+			if diff := cmp.Diff(tc.wantInadmissible, manager.DumpInadmissible(), cmpDump...); diff != "" {
+				t.Errorf("Unexpected inadmissible workloads (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQueueAssociatedInadmissibleWorkloadsOfTenants(t *testing.T) {
+	cases := map[string]struct {
+		tenant           *kueuealpha.Tenant
+		wantInadmissible map[string][]string
+	}{
+		"workloads of other ClusterQueues aren't requeued without a Tenant": {
+			wantInadmissible: map[string][]string{
+				"cq2": {"default/pending"},
+			},
+		},
+		"workloads of the other LocalQueues of the Tenant are requeued": {
+			tenant: utiltesting.MakeTenant("team").
+				LocalQueue(defaultNamespace, "foo").
+				LocalQueue(defaultNamespace, "bar").
+				Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			cl := utiltesting.NewFakeClient(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: defaultNamespace}},
+			)
+			manager := NewManager(cl, nil)
+			for _, cq := range []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").Obj(),
+				utiltesting.MakeClusterQueue("cq2").Obj(),
+			} {
+				if err := manager.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Failed adding clusterQueue %s: %v", cq.Name, err)
+				}
+				// Increase the popCycle to ensure that the workloads will be added as inadmissible.
+				manager.getClusterQueue(cq.Name).popCycle++
+			}
+			for _, q := range []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue("foo", defaultNamespace).ClusterQueue("cq1").Obj(),
+				utiltesting.MakeLocalQueue("bar", defaultNamespace).ClusterQueue("cq2").Obj(),
+			} {
+				if err := manager.AddLocalQueue(ctx, q); err != nil {
+					t.Fatalf("Failed adding queue %s: %v", q.Name, err)
+				}
+			}
+			if tc.tenant != nil {
+				manager.AddOrUpdateTenant(ctx, tc.tenant)
+			}
+			wl := utiltesting.MakeWorkload("pending", defaultNamespace).Queue("bar").Obj()
+			if err := cl.Create(ctx, wl); err != nil {
+				t.Fatalf("Failed adding workload to client: %v", err)
+			}
+			manager.RequeueWorkload(ctx, workload.NewInfo(wl), RequeueReasonGeneric)
+
+			released := utiltesting.MakeWorkload("released", defaultNamespace).Queue("foo").Obj()
+			manager.QueueAssociatedInadmissibleWorkloadsAfter(ctx, released, nil)
+			if diff := cmp.Diff(tc.wantInadmissible, manager.DumpInadmissible(), cmpDump...); diff != "" {
+				t.Errorf("Unexpected inadmissible workloads (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 // TestClusterQueueToActive tests that managers cond gets a broadcast when
 // a cluster queue becomes active.
 func TestClusterQueueToActive(t *testing.T) {
@@ -401,6 +597,272 @@ func TestDeleteLocalQueue(t *testing.T) {
 	}
 }
 
+func TestShadowMode(t *testing.T) {
+	cases := map[string]struct {
+		options       []Option
+		clusterQueues []*kueue.ClusterQueue
+		wantCQShadow  map[string]bool
+		wantLQShadow  map[string]bool
+	}{
+		"no shadow mode": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").Obj(),
+				utiltesting.MakeClusterQueue("cq2").ShadowMode(false).Obj(),
+			},
+			wantCQShadow: map[string]bool{"cq1": false, "cq2": false, "missing": false},
+			wantLQShadow: map[string]bool{"ns/lq1": false, "ns/lq2": false, "ns/missing": false},
+		},
+		"shadow mode in a ClusterQueue": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").ShadowMode(true).Obj(),
+				utiltesting.MakeClusterQueue("cq2").Obj(),
+			},
+			wantCQShadow: map[string]bool{"cq1": true, "cq2": false, "missing": false},
+			wantLQShadow: map[string]bool{"ns/lq1": true, "ns/lq2": false, "ns/missing": false},
+		},
+		"ClusterQueue admitting all the workloads": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").AdmitAll().Obj(),
+				utiltesting.MakeClusterQueue("cq2").Obj(),
+			},
+			wantCQShadow: map[string]bool{"cq1": true, "cq2": false, "missing": false},
+			wantLQShadow: map[string]bool{"ns/lq1": true, "ns/lq2": false, "ns/missing": false},
+		},
+		"global shadow mode": {
+			options: []Option{WithShadowMode(true)},
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq1").Obj(),
+				utiltesting.MakeClusterQueue("cq2").ShadowMode(false).Obj(),
+			},
+			wantCQShadow: map[string]bool{"cq1": true, "cq2": true, "missing": true},
+			wantLQShadow: map[string]bool{"ns/lq1": true, "ns/lq2": true, "ns/missing": true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			manager := NewManager(utiltesting.NewFakeClient(), nil, tc.options...)
+			for _, cq := range tc.clusterQueues {
+				if err := manager.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Failed adding ClusterQueue %s: %v", cq.Name, err)
+				}
+			}
+			for _, lq := range []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue("lq1", "ns").ClusterQueue("cq1").Obj(),
+				utiltesting.MakeLocalQueue("lq2", "ns").ClusterQueue("cq2").Obj(),
+			} {
+				if err := manager.AddLocalQueue(ctx, lq); err != nil {
+					t.Fatalf("Failed adding LocalQueue %s: %v", lq.Name, err)
+				}
+			}
+			gotCQShadow := make(map[string]bool, len(tc.wantCQShadow))
+			for cqName := range tc.wantCQShadow {
+				gotCQShadow[cqName] = manager.ClusterQueueInShadowMode(cqName)
+			}
+			if diff := cmp.Diff(tc.wantCQShadow, gotCQShadow); diff != "" {
+				t.Errorf("Unexpected shadow mode of ClusterQueues (-want,+got):\n%s", diff)
+			}
+			gotLQShadow := make(map[string]bool, len(tc.wantLQShadow))
+			for lqKey := range tc.wantLQShadow {
+				gotLQShadow[lqKey] = manager.LocalQueueInShadowMode(lqKey)
+			}
+			if diff := cmp.Diff(tc.wantLQShadow, gotLQShadow); diff != "" {
+				t.Errorf("Unexpected shadow mode of LocalQueues (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLocalQueueAllowsPriorityClass(t *testing.T) {
+	ctx := context.Background()
+	manager := NewManager(utiltesting.NewFakeClient(), nil)
+	restricted := utiltesting.MakeClusterQueue("restricted").AllowedPriorityClasses("low", "medium").Obj()
+	for _, cq := range []*kueue.ClusterQueue{restricted, utiltesting.MakeClusterQueue("open").Obj()} {
+		if err := manager.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Failed adding ClusterQueue %s: %v", cq.Name, err)
+		}
+	}
+	for _, lq := range []*kueue.LocalQueue{
+		utiltesting.MakeLocalQueue("lq1", "ns").ClusterQueue("restricted").Obj(),
+		utiltesting.MakeLocalQueue("lq2", "ns").ClusterQueue("open").Obj(),
+	} {
+		if err := manager.AddLocalQueue(ctx, lq); err != nil {
+			t.Fatalf("Failed adding LocalQueue %s: %v", lq.Name, err)
+		}
+	}
+	gotAllowed := func() map[string]sets.Set[string] {
+		got := make(map[string]sets.Set[string])
+		for _, lqKey := range []string{"ns/lq1", "ns/lq2", "ns/missing"} {
+			got[lqKey] = manager.LocalQueueAllowedPriorityClasses(lqKey)
+		}
+		return got
+	}
+	want := map[string]sets.Set[string]{
+		"ns/lq1":     sets.New("low", "medium"),
+		"ns/lq2":     nil,
+		"ns/missing": nil,
+	}
+	if diff := cmp.Diff(want, gotAllowed()); diff != "" {
+		t.Errorf("Unexpected allowed priority classes (-want,+got):\n%s", diff)
+	}
+
+	restricted = restricted.DeepCopy()
+	restricted.Spec.AllowedPriorityClasses = append(restricted.Spec.AllowedPriorityClasses, "critical")
+	if err := manager.UpdateClusterQueue(ctx, restricted, true); err != nil {
+		t.Fatalf("Failed updating ClusterQueue: %v", err)
+	}
+	want["ns/lq1"] = sets.New("low", "medium", "critical")
+	if diff := cmp.Diff(want, gotAllowed()); diff != "" {
+		t.Errorf("Unexpected allowed priority classes after the update (-want,+got):\n%s", diff)
+	}
+}
+
+func TestLocalQueueFlavors(t *testing.T) {
+	ctx := context.Background()
+	manager := NewManager(utiltesting.NewFakeClient(), nil)
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "5").Obj(),
+			*utiltesting.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "5").Obj(),
+		).
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("gpu").Resource("example.com/gpu", "2").Obj(),
+		).
+		Obj()
+	if err := manager.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Failed adding ClusterQueue %s: %v", cq.Name, err)
+	}
+	if err := manager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj()); err != nil {
+		t.Fatalf("Failed adding LocalQueue: %v", err)
+	}
+	gotFlavors := func() map[string]sets.Set[kueue.ResourceFlavorReference] {
+		got := make(map[string]sets.Set[kueue.ResourceFlavorReference])
+		for _, lqKey := range []string{"ns/lq", "ns/missing"} {
+			got[lqKey] = manager.LocalQueueFlavors(lqKey)
+		}
+		return got
+	}
+	want := map[string]sets.Set[kueue.ResourceFlavorReference]{
+		"ns/lq":      sets.New[kueue.ResourceFlavorReference]("on-demand", "spot", "gpu"),
+		"ns/missing": nil,
+	}
+	if diff := cmp.Diff(want, gotFlavors()); diff != "" {
+		t.Errorf("Unexpected flavors (-want,+got):\n%s", diff)
+	}
+
+	manager.DeleteClusterQueue(cq)
+	want["ns/lq"] = nil
+	if diff := cmp.Diff(want, gotFlavors()); diff != "" {
+		t.Errorf("Unexpected flavors after the deletion (-want,+got):\n%s", diff)
+	}
+}
+
+func TestLocalQueueMappedPriorityClass(t *testing.T) {
+	ctx := context.Background()
+	manager := NewManager(utiltesting.NewFakeClient(), nil)
+	manager.AddOrUpdateCohort(ctx, utiltesting.MakeCohort("root").
+		PriorityClassMapping("high", "normal").
+		PriorityClassMapping("critical", "normal").
+		Obj())
+	manager.AddOrUpdateCohort(ctx, utiltesting.MakeCohort("team").
+		Parent("root").
+		PriorityClassMapping("critical", "high").
+		Obj())
+	for _, cq := range []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("mapping").Cohort("team").PriorityClassMapping("high", "low").Obj(),
+		utiltesting.MakeClusterQueue("inheriting").Cohort("team").Obj(),
+		utiltesting.MakeClusterQueue("standalone").Obj(),
+	} {
+		if err := manager.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Failed adding ClusterQueue %s: %v", cq.Name, err)
+		}
+		if err := manager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue(cq.Name, "ns").ClusterQueue(cq.Name).Obj()); err != nil {
+			t.Fatalf("Failed adding LocalQueue %s: %v", cq.Name, err)
+		}
+	}
+	type mapped struct {
+		To    string
+		Found bool
+	}
+	gotMapped := func() map[string]mapped {
+		got := make(map[string]mapped)
+		for _, lqKey := range []string{"ns/mapping", "ns/inheriting", "ns/standalone", "ns/missing"} {
+			for _, priorityClass := range []string{"critical", "high", "low"} {
+				to, found := manager.LocalQueueMappedPriorityClass(lqKey, priorityClass)
+				got[lqKey+":"+priorityClass] = mapped{To: to, Found: found}
+			}
+		}
+		return got
+	}
+	want := map[string]mapped{
+		"ns/mapping:critical":    {To: "high", Found: true},
+		"ns/mapping:high":        {To: "low", Found: true},
+		"ns/mapping:low":         {},
+		"ns/inheriting:critical": {To: "high", Found: true},
+		"ns/inheriting:high":     {To: "normal", Found: true},
+		"ns/inheriting:low":      {},
+		"ns/standalone:critical": {},
+		"ns/standalone:high":     {},
+		"ns/standalone:low":      {},
+		"ns/missing:critical":    {},
+		"ns/missing:high":        {},
+		"ns/missing:low":         {},
+	}
+	if diff := cmp.Diff(want, gotMapped()); diff != "" {
+		t.Errorf("Unexpected mapped priority classes (-want,+got):\n%s", diff)
+	}
+
+	manager.DeleteCohort("team")
+	want["ns/mapping:critical"] = mapped{}
+	want["ns/inheriting:critical"] = mapped{}
+	want["ns/inheriting:high"] = mapped{}
+	if diff := cmp.Diff(want, gotMapped()); diff != "" {
+		t.Errorf("Unexpected mapped priority classes after deleting the Cohort (-want,+got):\n%s", diff)
+	}
+}
+
+// TestWebhookView tests that the view of the queues read by the webhooks
+// follows the updates of the LocalQueues and ClusterQueues.
+func TestWebhookView(t *testing.T) {
+	ctx := context.Background()
+	manager := NewManager(utiltesting.NewFakeClient(), nil)
+	shadowCQ := utiltesting.MakeClusterQueue("shadow").ShadowMode(true).Obj()
+	for _, cq := range []*kueue.ClusterQueue{utiltesting.MakeClusterQueue("cq").Obj(), shadowCQ} {
+		if err := manager.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Failed adding ClusterQueue %s: %v", cq.Name, err)
+		}
+	}
+	lq := utiltesting.MakeLocalQueue("default", "ns").ClusterQueue("cq").Obj()
+	lqKey := Key(lq)
+	check := func(step string, wantDefault, wantShadow bool) {
+		t.Helper()
+		if got := manager.DefaultLocalQueueExist("ns"); got != wantDefault {
+			t.Errorf("Unexpected existence of the default LocalQueue %s, want %t, got %t", step, wantDefault, got)
+		}
+		if got := manager.LocalQueueInShadowMode(lqKey); got != wantShadow {
+			t.Errorf("Unexpected shadow mode of the LocalQueue %s, want %t, got %t", step, wantShadow, got)
+		}
+	}
+
+	check("before adding it", false, false)
+	if err := manager.AddLocalQueue(ctx, lq); err != nil {
+		t.Fatalf("Failed adding LocalQueue: %v", err)
+	}
+	check("after adding it", true, false)
+	lq.Spec.ClusterQueue = "shadow"
+	if err := manager.UpdateLocalQueue(lq); err != nil {
+		t.Fatalf("Failed updating LocalQueue: %v", err)
+	}
+	check("after moving it to a ClusterQueue in shadow mode", true, true)
+	shadowCQ.Spec.ShadowMode = ptr.To(false)
+	if err := manager.UpdateClusterQueue(ctx, shadowCQ, true); err != nil {
+		t.Fatalf("Failed updating ClusterQueue: %v", err)
+	}
+	check("after disabling the shadow mode of its ClusterQueue", true, false)
+	manager.DeleteLocalQueue(lq)
+	check("after deleting it", false, false)
+}
+
 func TestAddWorkload(t *testing.T) {
 	manager := NewManager(utiltesting.NewFakeClient(), nil)
 	cq := utiltesting.MakeClusterQueue("cq").Obj()
@@ -931,6 +1393,62 @@ func TestHeads(t *testing.T) {
 	}
 }
 
+func TestHeadsWithTieBreakers(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cases := map[string]struct {
+		tieBreakers []config.TieBreaker
+		deleted     []string
+		want        []string
+	}{
+		"submission counter": {
+			tieBreakers: []config.TieBreaker{config.SubmissionCounterTieBreaker},
+			want:        []string{"c", "b", "a"},
+		},
+		"submission counter of a queued again workload": {
+			tieBreakers: []config.TieBreaker{config.SubmissionCounterTieBreaker},
+			deleted:     []string{"c"},
+			want:        []string{"b", "a", "c"},
+		},
+		"namespace and name": {
+			tieBreakers: []config.TieBreaker{config.CreationTimestampTieBreaker},
+			want:        []string{"a", "b", "c"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), headsTimeout)
+			defer cancel()
+			manager := NewManager(utiltesting.NewFakeClient(), nil, WithTieBreakers(tc.tieBreakers))
+			if err := manager.AddClusterQueue(ctx, utiltesting.MakeClusterQueue("cq").Obj()); err != nil {
+				t.Fatalf("Failed adding ClusterQueue: %v", err)
+			}
+			if err := manager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj()); err != nil {
+				t.Fatalf("Failed adding LocalQueue: %v", err)
+			}
+			go manager.CleanUpOnContext(ctx)
+			workloads := make(map[string]*kueue.Workload)
+			for _, name := range []string{"c", "b", "a"} {
+				workloads[name] = utiltesting.MakeWorkload(name, "ns").Creation(now).Queue("lq").Obj()
+				manager.AddOrUpdateWorkload(workloads[name])
+			}
+			for _, name := range tc.deleted {
+				manager.DeleteWorkload(workloads[name])
+				manager.AddOrUpdateWorkload(workloads[name])
+			}
+
+			var got []string
+			for range tc.want {
+				for _, h := range manager.Heads(ctx) {
+					got = append(got, h.Obj.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected order of the heads (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 var ignoreTypeMeta = cmpopts.IgnoreTypes(metav1.TypeMeta{})
 
 // TestHeadAsync ensures that Heads call is blocked until the queues are filled
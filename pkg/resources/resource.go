@@ -28,3 +28,24 @@ type FlavorResource struct {
 }
 
 type FlavorResourceQuantities map[FlavorResource]int64
+
+// ByResource returns the quantities summed over the flavors.
+func (q FlavorResourceQuantities) ByResource() Requests {
+	r := make(Requests, len(q))
+	for fr, v := range q {
+		r[fr.Resource] += v
+	}
+	return r
+}
+
+// ByFlavor returns the quantities of each flavor.
+func (q FlavorResourceQuantities) ByFlavor() map[kueue.ResourceFlavorReference]Requests {
+	r := make(map[kueue.ResourceFlavorReference]Requests)
+	for fr, v := range q {
+		if r[fr.Flavor] == nil {
+			r[fr.Flavor] = make(Requests)
+		}
+		r[fr.Flavor][fr.Resource] += v
+	}
+	return r
+}
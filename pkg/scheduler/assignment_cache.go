@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"encoding/json"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/util/priority"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// assignmentCache holds the flavor assignments of the workloads which couldn't
+// be admitted, keyed by the shape of the workloads, so that the identical
+// workloads queued after them, for example by array-style submissions, reuse
+// the assignment instead of computing it again.
+// The assignments are only valid for the epoch of the cache they were computed
+// in, as any modification of the cache could make a workload fit.
+type assignmentCache struct {
+	mu          sync.Mutex
+	epoch       int64
+	assignments map[string]flavorassigner.Assignment
+}
+
+func newAssignmentCache() *assignmentCache {
+	return &assignmentCache{
+		assignments: make(map[string]flavorassigner.Assignment),
+	}
+}
+
+// assignmentShape holds the fields of a workload, and of its ClusterQueue,
+// that determine its flavor assignment, when it has no preemption targets.
+type assignmentShape struct {
+	ClusterQueue string
+	FairSharing  bool
+	Priority     int32
+	PodSets      []podSetShape
+	// ExcludedFlavors are sorted by name.
+	ExcludedFlavors []kueue.ResourceFlavorReference `json:",omitempty"`
+}
+
+type podSetShape struct {
+	Name            string
+	Count           int32
+	MinCount        *int32
+	Requests        resources.Requests
+	NodeSelector    map[string]string
+	NodeAffinity    *corev1.NodeAffinity
+	Tolerations     []corev1.Toleration
+	TopologyRequest *kueue.PodSetTopologyRequest
+	PinnedFlavor    kueue.ResourceFlavorReference `json:",omitempty"`
+}
+
+// assignmentCacheKey returns the key of the workload in the assignment cache.
+// It returns false if the assignment of the workload can't be cached, because
+// it depends on more than the shape of the workload: the last assignment
+// of the workload, the topology of the nodes, or the creation time of the
+// workload, when the ClusterQueue preempts newer workloads of equal priority.
+func assignmentCacheKey(wl *workload.Info, cq *cache.ClusterQueueSnapshot, fairSharing bool) (string, bool) {
+	if wl.LastAssignment != nil || len(cq.TASFlavors) > 0 ||
+		cq.Preemption.WithinClusterQueue == kueue.PreemptionPolicyLowerOrNewerEqualPriority {
+		return "", false
+	}
+	shape := assignmentShape{
+		ClusterQueue: cq.Name,
+		FairSharing:  fairSharing,
+		Priority:     priority.Priority(wl.Obj),
+		PodSets:      make([]podSetShape, len(wl.Obj.Spec.PodSets)),
+	}
+	if excluded := workload.ExcludedFlavors(wl.Obj); excluded.Len() > 0 {
+		shape.ExcludedFlavors = sets.List(excluded)
+	}
+	pinnedFlavors := workload.PinnedFlavors(wl.Obj)
+	for i := range wl.Obj.Spec.PodSets {
+		ps := &wl.Obj.Spec.PodSets[i]
+		shape.PodSets[i] = podSetShape{
+			Name:            ps.Name,
+			PinnedFlavor:    pinnedFlavors[ps.Name],
+			Count:           ps.Count,
+			MinCount:        ps.MinCount,
+			NodeSelector:    ps.Template.Spec.NodeSelector,
+			Tolerations:     ps.Template.Spec.Tolerations,
+			TopologyRequest: ps.TopologyRequest,
+		}
+		if i < len(wl.TotalRequests) {
+			shape.PodSets[i].Requests = wl.TotalRequests[i].Requests
+		}
+		if ps.Template.Spec.Affinity != nil {
+			shape.PodSets[i].NodeAffinity = ps.Template.Spec.Affinity.NodeAffinity
+		}
+	}
+	key, err := json.Marshal(shape)
+	if err != nil {
+		return "", false
+	}
+	return string(key), true
+}
+
+// get returns the assignment cached for the key in the epoch.
+func (c *assignmentCache) get(epoch int64, key string) (flavorassigner.Assignment, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.epoch != epoch {
+		return flavorassigner.Assignment{}, false
+	}
+	assignment, found := c.assignments[key]
+	if !found {
+		return flavorassigner.Assignment{}, false
+	}
+	// The last state is kept by the workload, so it isn't shared.
+	assignment.LastState = *assignment.LastState.Clone()
+	return assignment, true
+}
+
+// set caches the assignment for the key in the epoch, dropping the
+// assignments of the previous epochs.
+func (c *assignmentCache) set(epoch int64, key string, assignment flavorassigner.Assignment) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if epoch < c.epoch {
+		return
+	}
+	if epoch > c.epoch {
+		c.epoch = epoch
+		clear(c.assignments)
+	}
+	assignment.LastState = *assignment.LastState.Clone()
+	c.assignments[key] = assignment
+}
+
+// reset drops all the cached assignments.
+func (c *assignmentCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.assignments)
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestAssignmentCacheKey(t *testing.T) {
+	makeWorkload := func(name string) *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload(name, "ns").
+			PodSets(*utiltesting.MakePodSet("main", 3).
+				Request(corev1.ResourceCPU, "1").
+				NodeSelector(map[string]string{"zone": "a"}).
+				Obj())
+	}
+	cq := &cache.ClusterQueueSnapshot{Name: "cq"}
+	cases := map[string]struct {
+		first, second *workload.Info
+		cq            *cache.ClusterQueueSnapshot
+		wantCacheable bool
+		wantSameKey   bool
+	}{
+		"identical workloads": {
+			first:         workload.NewInfo(makeWorkload("a").Obj()),
+			second:        workload.NewInfo(makeWorkload("b").Obj()),
+			cq:            cq,
+			wantCacheable: true,
+			wantSameKey:   true,
+		},
+		"different priority": {
+			first:         workload.NewInfo(makeWorkload("a").Obj()),
+			second:        workload.NewInfo(makeWorkload("b").Priority(100).Obj()),
+			cq:            cq,
+			wantCacheable: true,
+		},
+		"different requests": {
+			first: workload.NewInfo(makeWorkload("a").Obj()),
+			second: workload.NewInfo(utiltesting.MakeWorkload("b", "ns").
+				PodSets(*utiltesting.MakePodSet("main", 3).
+					Request(corev1.ResourceCPU, "2").
+					NodeSelector(map[string]string{"zone": "a"}).
+					Obj()).
+				Obj()),
+			cq:            cq,
+			wantCacheable: true,
+		},
+		"different node selector": {
+			first: workload.NewInfo(makeWorkload("a").Obj()),
+			second: workload.NewInfo(utiltesting.MakeWorkload("b", "ns").
+				PodSets(*utiltesting.MakePodSet("main", 3).
+					Request(corev1.ResourceCPU, "1").
+					NodeSelector(map[string]string{"zone": "b"}).
+					Obj()).
+				Obj()),
+			cq:            cq,
+			wantCacheable: true,
+		},
+		"different pinned flavor": {
+			first: workload.NewInfo(makeWorkload("a").
+				Annotations(map[string]string{controllerconsts.PodSetFlavorsAnnotation: "main=on-demand"}).
+				Obj()),
+			second: workload.NewInfo(makeWorkload("b").
+				Annotations(map[string]string{controllerconsts.PodSetFlavorsAnnotation: "main=spot"}).
+				Obj()),
+			cq:            cq,
+			wantCacheable: true,
+		},
+		"different excluded flavors": {
+			first: workload.NewInfo(makeWorkload("a").
+				Annotations(map[string]string{controllerconsts.ExcludedFlavorsAnnotation: "spot"}).
+				Obj()),
+			second:        workload.NewInfo(makeWorkload("b").Obj()),
+			cq:            cq,
+			wantCacheable: true,
+		},
+		"same excluded flavors in another order": {
+			first: workload.NewInfo(makeWorkload("a").
+				Annotations(map[string]string{controllerconsts.ExcludedFlavorsAnnotation: "spot,preemptible"}).
+				Obj()),
+			second: workload.NewInfo(makeWorkload("b").
+				Annotations(map[string]string{controllerconsts.ExcludedFlavorsAnnotation: "preemptible,spot"}).
+				Obj()),
+			cq:            cq,
+			wantCacheable: true,
+			wantSameKey:   true,
+		},
+		"workload with a last assignment": {
+			first: workload.NewInfo(makeWorkload("a").Obj()),
+			second: func() *workload.Info {
+				wl := workload.NewInfo(makeWorkload("b").Obj())
+				wl.LastAssignment = &workload.AssignmentClusterQueueState{}
+				return wl
+			}(),
+			cq: cq,
+		},
+		"ClusterQueue preempting newer workloads of equal priority": {
+			first:  workload.NewInfo(makeWorkload("a").Obj()),
+			second: workload.NewInfo(makeWorkload("b").Obj()),
+			cq: &cache.ClusterQueueSnapshot{
+				Name: "cq",
+				Preemption: kueue.ClusterQueuePreemption{
+					WithinClusterQueue: kueue.PreemptionPolicyLowerOrNewerEqualPriority,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			firstKey, _ := assignmentCacheKey(tc.first, tc.cq, false)
+			secondKey, secondCacheable := assignmentCacheKey(tc.second, tc.cq, false)
+			if secondCacheable != tc.wantCacheable {
+				t.Errorf("Unexpected cacheable, want %t, got %t", tc.wantCacheable, secondCacheable)
+			}
+			if tc.wantCacheable {
+				if gotSameKey := firstKey == secondKey; gotSameKey != tc.wantSameKey {
+					t.Errorf("Unexpected equality of the keys, want %t, got %t", tc.wantSameKey, gotSameKey)
+				}
+			}
+		})
+	}
+}
+
+func TestAssignmentCache(t *testing.T) {
+	assignment := flavorassigner.Assignment{
+		PodSets: []flavorassigner.PodSetAssignment{{Name: "main", Count: 1}},
+		LastState: workload.AssignmentClusterQueueState{
+			LastTriedFlavorIdx: []map[corev1.ResourceName]int{{corev1.ResourceCPU: 0}},
+		},
+	}
+	cmpOpts := cmpopts.IgnoreUnexported(flavorassigner.Assignment{}, flavorassigner.PodSetAssignment{})
+	c := newAssignmentCache()
+
+	c.set(2, "key", assignment)
+	got, found := c.get(2, "key")
+	if !found {
+		t.Fatalf("Expected the assignment to be cached")
+	}
+	if diff := cmp.Diff(assignment, got, cmpOpts); diff != "" {
+		t.Errorf("Unexpected assignment (-want,+got):\n%s", diff)
+	}
+	got.LastState.LastTriedFlavorIdx[0][corev1.ResourceCPU] = 1
+	if got, _ := c.get(2, "key"); got.LastState.LastTriedFlavorIdx[0][corev1.ResourceCPU] != 0 {
+		t.Errorf("Expected the last state of the cached assignment not to be shared")
+	}
+
+	if _, found := c.get(3, "key"); found {
+		t.Errorf("Unexpected assignment cached in another epoch")
+	}
+	c.set(1, "stale", assignment)
+	if _, found := c.get(1, "stale"); found {
+		t.Errorf("Unexpected assignment cached in a previous epoch")
+	}
+	c.set(3, "other", assignment)
+	if _, found := c.get(2, "key"); found {
+		t.Errorf("Unexpected assignment of a previous epoch after a new epoch")
+	}
+	c.reset()
+	if _, found := c.get(3, "other"); found {
+		t.Errorf("Unexpected assignment after reset")
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"maps"
+
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/scheduler/audit"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/util/priority"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// snapshotClusterQueueUsage keeps the current usage of the ClusterQueue of
+// the entry, before the entry is processed.
+func snapshotClusterQueueUsage(e *entry, snapshot *cache.Snapshot) {
+	cq := snapshot.ClusterQueues[e.ClusterQueue]
+	if cq == nil {
+		return
+	}
+	e.clusterQueueUsage = maps.Clone(cq.ResourceNode.Usage)
+	e.clusterQueueShare, _ = cq.DominantResourceShare()
+}
+
+func (s *Scheduler) auditDecision(e *entry, position int) audit.Decision {
+	d := audit.Decision{
+		Time:              s.clock.Now(),
+		Cycle:             s.attemptCount,
+		Position:          position,
+		Workload:          workload.Key(e.Obj),
+		UID:               e.Obj.UID,
+		ClusterQueue:      e.ClusterQueue,
+		Priority:          priority.Priority(e.Obj),
+		Submitter:         e.Obj.Annotations[constants.SubmitterUserAnnotation],
+		Result:            auditResult(e),
+		Message:           e.inadmissibleMsg,
+		Borrowing:         e.assignment.Borrowing,
+		Usage:             audit.NewQuantities(e.assignment.Usage),
+		ClusterQueueUsage: audit.NewQuantities(e.clusterQueueUsage),
+	}
+	if ts := s.workloadOrdering.GetQueueOrderTimestamp(e.Obj); ts != nil {
+		d.QueueOrderTimestamp = ts.Time
+	}
+	if len(e.assignment.PodSets) > 0 {
+		d.Mode = e.assignment.RepresentativeMode().String()
+	}
+	if s.fairSharing.Load().Enable {
+		d.FairSharing = &audit.FairSharing{
+			DominantResourceShare: e.dominantResourceShare,
+			DominantResource:      string(e.dominantResourceName),
+			ClusterQueueShare:     e.clusterQueueShare,
+		}
+	}
+	for _, target := range e.preemptionTargets {
+		d.PreemptionTargets = append(d.PreemptionTargets, audit.PreemptionTarget{
+			Workload:     workload.Key(target.WorkloadInfo.Obj),
+			ClusterQueue: target.WorkloadInfo.ClusterQueue,
+			Reason:       target.Reason,
+		})
+	}
+	return d
+}
+
+func auditResult(e *entry) audit.Result {
+	switch {
+	case e.status == assumed:
+		return audit.QuotaReserved
+	case e.status == skipped:
+		return audit.Skipped
+	case e.status == notNominated && e.assignment.RepresentativeMode() == flavorassigner.Preempt && len(e.preemptionTargets) > 0:
+		return audit.Preempting
+	default:
+		return audit.Inadmissible
+	}
+}
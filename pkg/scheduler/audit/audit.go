@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records the decisions taken by the scheduler for every
+// workload it evaluates, so that they can be inspected after the fact.
+package audit
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+// Result is the outcome of the evaluation of a workload in a scheduling cycle.
+type Result string
+
+const (
+	// QuotaReserved means that the workload got quota reserved in the ClusterQueue.
+	QuotaReserved Result = "QuotaReserved"
+	// Preempting means that the workload issued the preemption of other workloads
+	// and waits for them to release their quota.
+	Preempting Result = "Preempting"
+	// Skipped means that the workload could have been admitted, but it was
+	// skipped in favor of a workload evaluated earlier in the same cycle.
+	Skipped Result = "Skipped"
+	// Inadmissible means that the workload doesn't fit in the ClusterQueue,
+	// even with preemption, or that it failed to be admitted.
+	Inadmissible Result = "Inadmissible"
+)
+
+// Decision is the record of the evaluation of a workload in a scheduling cycle.
+type Decision struct {
+	// Time is the time at which the scheduling cycle ended.
+	Time time.Time `json:"time"`
+	// Cycle identifies the scheduling cycle, since the last restart of Kueue.
+	Cycle int64 `json:"cycle"`
+	// Position is the position of the workload among the heads evaluated in
+	// the cycle, after sorting them.
+	Position int `json:"position"`
+
+	Workload     string    `json:"workload"`
+	UID          types.UID `json:"uid"`
+	ClusterQueue string    `json:"clusterQueue"`
+	Priority     int32     `json:"priority"`
+	// Submitter is the user who submitted the workload, when the identity of
+	// the submitters is recorded.
+	Submitter string `json:"submitter,omitempty"`
+	// QueueOrderTimestamp is the timestamp used to order the workload in
+	// its queue.
+	QueueOrderTimestamp time.Time `json:"queueOrderTimestamp"`
+
+	Result  Result `json:"result"`
+	Message string `json:"message,omitempty"`
+
+	// Mode is the representative mode of the flavor assignment of the
+	// workload: Fit, Preempt or NoFit.
+	Mode      string `json:"mode,omitempty"`
+	Borrowing bool   `json:"borrowing,omitempty"`
+	// Usage is the usage of the workload, by flavor and resource, for the
+	// assigned flavors.
+	Usage Quantities `json:"usage,omitempty"`
+	// ClusterQueueUsage is the usage of the ClusterQueue, by flavor and
+	// resource, when the workload was evaluated. It includes the usage of the
+	// workloads evaluated earlier in the same cycle.
+	ClusterQueueUsage Quantities `json:"clusterQueueUsage,omitempty"`
+	// FairSharing holds the fair-share values, when the fair sharing is enabled.
+	FairSharing *FairSharing `json:"fairSharing,omitempty"`
+	// PreemptionTargets are the victims considered to make room for the workload.
+	PreemptionTargets []PreemptionTarget `json:"preemptionTargets,omitempty"`
+}
+
+// FairSharing holds the dominant resource shares used to order the workloads.
+type FairSharing struct {
+	// DominantResourceShare is the share of the ClusterQueue, including the
+	// workload, for its dominant resource.
+	DominantResourceShare int    `json:"dominantResourceShare"`
+	DominantResource      string `json:"dominantResource,omitempty"`
+	// ClusterQueueShare is the share of the ClusterQueue, without the workload.
+	ClusterQueueShare int `json:"clusterQueueShare"`
+}
+
+// PreemptionTarget is a workload considered for preemption.
+type PreemptionTarget struct {
+	Workload     string `json:"workload"`
+	ClusterQueue string `json:"clusterQueue"`
+	Reason       string `json:"reason"`
+}
+
+// Quantities are the quantities of resources, by flavor and resource.
+type Quantities map[string]map[string]string
+
+// NewQuantities converts the FlavorResourceQuantities to Quantities,
+// dropping the zero quantities.
+func NewQuantities(frq resources.FlavorResourceQuantities) Quantities {
+	var q Quantities
+	for fr, v := range frq {
+		if v == 0 {
+			continue
+		}
+		if q == nil {
+			q = make(Quantities)
+		}
+		flavor := string(fr.Flavor)
+		if q[flavor] == nil {
+			q[flavor] = make(map[string]string)
+		}
+		q[flavor][string(fr.Resource)] = resources.ResourceQuantityString(fr.Resource, v)
+	}
+	return q
+}
+
+// Recorder records the scheduling decisions.
+type Recorder interface {
+	// Record records the decision. It must not block.
+	Record(Decision)
+}
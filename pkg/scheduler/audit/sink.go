@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+)
+
+const (
+	// maxBatchSize is the maximum number of decisions written at once.
+	maxBatchSize = 100
+	httpTimeout  = 10 * time.Second
+)
+
+// Sink is a Recorder which writes the decisions asynchronously, either to a
+// file or to an HTTP endpoint, in the JSON Lines format.
+// The decisions recorded while the buffer is full are dropped.
+type Sink struct {
+	decisions chan Decision
+	dropped   atomic.Int64
+	write     func(ctx context.Context, body []byte) error
+	close     func() error
+}
+
+var _ Recorder = (*Sink)(nil)
+
+// NewSink returns the Sink for the configuration.
+func NewSink(cfg *config.SchedulingAudit) (*Sink, error) {
+	s := &Sink{
+		decisions: make(chan Decision, ptr.Deref(cfg.BufferSize, config.DefaultSchedulingAuditBufferSize)),
+		close:     func() error { return nil },
+	}
+	switch {
+	case ptr.Deref(cfg.Path, "") != "":
+		f, err := os.OpenFile(*cfg.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("opening the scheduling audit file: %w", err)
+		}
+		s.write = func(_ context.Context, body []byte) error {
+			_, err := f.Write(body)
+			return err
+		}
+		s.close = f.Close
+	case ptr.Deref(cfg.URL, "") != "":
+		client := &http.Client{Timeout: httpTimeout}
+		s.write = func(ctx context.Context, body []byte) error {
+			return post(ctx, client, *cfg.URL, body)
+		}
+	default:
+		return nil, errors.New("no destination for the scheduling audit")
+	}
+	return s, nil
+}
+
+// Record implements Recorder. It drops the decision if the buffer is full.
+func (s *Sink) Record(d Decision) {
+	select {
+	case s.decisions <- d:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// Start implements the Runnable interface to write the decisions until the
+// context is canceled.
+func (s *Sink) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("scheduling-audit")
+	defer func() {
+		if err := s.close(); err != nil {
+			log.Error(err, "Closing the scheduling audit")
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case d := <-s.decisions:
+			batch := s.batch(d)
+			body, err := encode(batch)
+			if err == nil {
+				err = s.write(ctx, body)
+			}
+			if err != nil {
+				log.Error(err, "Writing the scheduling decisions", "count", len(batch))
+			}
+			if dropped := s.dropped.Swap(0); dropped > 0 {
+				log.Info("Dropped scheduling decisions because the buffer was full", "count", dropped)
+			}
+		}
+	}
+}
+
+// batch returns the decision along with the decisions already waiting in
+// the buffer, up to maxBatchSize.
+func (s *Sink) batch(d Decision) []Decision {
+	batch := []Decision{d}
+	for len(batch) < maxBatchSize {
+		select {
+		case d := <-s.decisions:
+			batch = append(batch, d)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func encode(decisions []Decision) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for i := range decisions {
+		if err := enc.Encode(&decisions[i]); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func post(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/pkg/resources"
+)
+
+var testDecisions = []Decision{
+	{
+		Time:         time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC),
+		Cycle:        1,
+		Workload:     "ns/a",
+		ClusterQueue: "cq",
+		Result:       QuotaReserved,
+		Mode:         "Fit",
+		Usage:        Quantities{"default": {"cpu": "1"}},
+	},
+	{
+		Time:         time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC),
+		Cycle:        1,
+		Position:     1,
+		Workload:     "ns/b",
+		ClusterQueue: "cq",
+		Result:       Preempting,
+		Mode:         "Preempt",
+		PreemptionTargets: []PreemptionTarget{
+			{Workload: "ns/c", ClusterQueue: "cq", Reason: "InClusterQueue"},
+		},
+	},
+}
+
+func TestSink(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []Decision
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+			t.Errorf("Unexpected content type %q", ct)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, decode(t, r.Body)...)
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	cases := map[string]struct {
+		cfg  config.SchedulingAudit
+		read func(t *testing.T) []Decision
+	}{
+		"file": {
+			cfg: config.SchedulingAudit{Path: ptr.To(path)},
+			read: func(t *testing.T) []Decision {
+				f, err := os.Open(path)
+				if err != nil {
+					t.Fatalf("Opening the audit file: %v", err)
+				}
+				defer f.Close()
+				return decode(t, f)
+			},
+		},
+		"url": {
+			cfg: config.SchedulingAudit{URL: ptr.To(server.URL)},
+			read: func(t *testing.T) []Decision {
+				mu.Lock()
+				defer mu.Unlock()
+				return received
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sink, err := NewSink(&tc.cfg)
+			if err != nil {
+				t.Fatalf("Creating the sink: %v", err)
+			}
+			for _, d := range testDecisions {
+				sink.Record(d)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				_ = sink.Start(ctx)
+			}()
+			deadline := time.Now().Add(5 * time.Second)
+			var got []Decision
+			for time.Now().Before(deadline) {
+				if got = tc.read(t); len(got) == len(testDecisions) {
+					break
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			cancel()
+			<-done
+			if diff := cmp.Diff(testDecisions, got); diff != "" {
+				t.Errorf("Unexpected decisions (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSinkDropsWhenFull(t *testing.T) {
+	sink, err := NewSink(&config.SchedulingAudit{
+		Path:       ptr.To(filepath.Join(t.TempDir(), "audit.log")),
+		BufferSize: ptr.To[int32](1),
+	})
+	if err != nil {
+		t.Fatalf("Creating the sink: %v", err)
+	}
+	for _, d := range testDecisions {
+		sink.Record(d)
+	}
+	if got := sink.dropped.Load(); got != 1 {
+		t.Errorf("Dropped %d decisions, want 1", got)
+	}
+}
+
+func TestNewQuantities(t *testing.T) {
+	got := NewQuantities(resources.FlavorResourceQuantities{
+		{Flavor: "default", Resource: "cpu"}:    1500,
+		{Flavor: "default", Resource: "memory"}: 1024,
+		{Flavor: "spot", Resource: "cpu"}:       0,
+	})
+	want := Quantities{"default": {"cpu": "1500m", "memory": "1Ki"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected quantities (-want,+got):\n%s", diff)
+	}
+}
+
+func decode(t *testing.T, r io.Reader) []Decision {
+	t.Helper()
+	var decisions []Decision
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var d Decision
+		if err := json.Unmarshal(scanner.Bytes(), &d); err != nil {
+			t.Errorf("Decoding the decision %q: %v", scanner.Text(), err)
+		}
+		decisions = append(decisions, d)
+	}
+	return decisions
+}
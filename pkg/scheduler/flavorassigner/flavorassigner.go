@@ -29,13 +29,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
-	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
 	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
+	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -98,6 +99,22 @@ func (a *Assignment) Message() string {
 	return builder.String()
 }
 
+// PendingReasons returns the reasons for which the flavors couldn't be
+// assigned immediately to the pod sets.
+func (a *Assignment) PendingReasons() []kueue.PendingReason {
+	var pendingReasons []kueue.PendingReason
+	for _, ps := range a.PodSets {
+		if ps.Status == nil || ps.Status.IsError() {
+			continue
+		}
+		for _, r := range ps.Status.pendingReasons {
+			r.PodSet = ps.Name
+			pendingReasons = append(pendingReasons, r)
+		}
+	}
+	return pendingReasons
+}
+
 func (a *Assignment) ToAPI() []kueue.PodSetAssignment {
 	psFlavors := make([]kueue.PodSetAssignment, len(a.PodSets))
 	for i := range psFlavors {
@@ -125,19 +142,27 @@ func (a *Assignment) TotalRequestsFor(wl *workload.Info) resources.FlavorResourc
 }
 
 type Status struct {
-	reasons []string
-	err     error
+	reasons        []string
+	pendingReasons []kueue.PendingReason
+	err            error
 }
 
 func (s *Status) IsError() bool {
 	return s != nil && s.err != nil
 }
 
-func (s *Status) append(r ...string) *Status {
-	s.reasons = append(s.reasons, r...)
+// appendPendingReason appends the reason, along with its message.
+func (s *Status) appendPendingReason(r kueue.PendingReason) *Status {
+	s.reasons = append(s.reasons, r.Message)
+	s.pendingReasons = append(s.pendingReasons, r)
 	return s
 }
 
+func (s *Status) merge(o *Status) {
+	s.reasons = append(s.reasons, o.reasons...)
+	s.pendingReasons = append(s.pendingReasons, o.pendingReasons...)
+}
+
 func (s *Status) Message() string {
 	if s == nil {
 		return ""
@@ -203,12 +228,16 @@ func (psa *PodSetAssignment) toAPI() kueue.PodSetAssignment {
 	for res, flvAssignment := range psa.Flavors {
 		flavors[res] = flvAssignment.Name
 	}
+	topologyAssignment := psa.TopologyAssignment.DeepCopy()
+	if features.Enabled(features.CompactTopologyAssignment) {
+		topologyAssignment = utiltas.CompactTopologyAssignment(topologyAssignment)
+	}
 	return kueue.PodSetAssignment{
 		Name:               psa.Name,
 		Flavors:            flavors,
 		ResourceUsage:      psa.Requests,
 		Count:              ptr.To(psa.Count),
-		TopologyAssignment: psa.TopologyAssignment.DeepCopy(),
+		TopologyAssignment: topologyAssignment,
 	}
 }
 
@@ -284,6 +313,12 @@ type FlavorAssigner struct {
 	resourceFlavors   map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor
 	enableFairSharing bool
 	oracle            preemptionOracle
+	// pinnedFlavors are the flavors to which the pod sets are pinned, by pod
+	// set name.
+	pinnedFlavors map[string]kueue.ResourceFlavorReference
+	// excludedFlavors are the flavors which are never assigned to the
+	// workload.
+	excludedFlavors sets.Set[kueue.ResourceFlavorReference]
 }
 
 func New(wl *workload.Info, cq *cache.ClusterQueueSnapshot, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, enableFairSharing bool, oracle preemptionOracle) *FlavorAssigner {
@@ -293,6 +328,8 @@ func New(wl *workload.Info, cq *cache.ClusterQueueSnapshot, resourceFlavors map[
 		resourceFlavors:   resourceFlavors,
 		enableFairSharing: enableFairSharing,
 		oracle:            oracle,
+		pinnedFlavors:     workload.PinnedFlavors(wl.Obj),
+		excludedFlavors:   workload.ExcludedFlavors(wl.Obj),
 	}
 }
 
@@ -384,7 +421,7 @@ func (psa *PodSetAssignment) append(flavors ResourceAssignment, status *Status)
 	if psa.Status == nil {
 		psa.Status = status
 	} else if status != nil {
-		psa.Status.reasons = append(psa.Status.reasons, status.reasons...)
+		psa.Status.merge(status)
 	}
 }
 
@@ -416,9 +453,11 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 ) (ResourceAssignment, *Status) {
 	resourceGroup := a.cq.RGByResource(resName)
 	if resourceGroup == nil {
-		return nil, &Status{
-			reasons: []string{fmt.Sprintf("resource %s unavailable in ClusterQueue", resName)},
-		}
+		return nil, (&Status{}).appendPendingReason(kueue.PendingReason{
+			Reason:   kueue.PendingReasonResourceUnavailable,
+			Resource: resName,
+			Message:  fmt.Sprintf("resource %s unavailable in ClusterQueue", resName),
+		})
 	}
 
 	status := &Status{}
@@ -430,22 +469,47 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 	bestAssignmentMode := noFit
 
 	// We will only check against the flavors' labels for the resource.
-	selector := flavorSelector(podSpec, resourceGroup.LabelKeys)
+	selector := utilpod.RequiredNodeAffinityForKeys(podSpec, resourceGroup.LabelKeys)
+	pinnedFlavor, pinned := a.pinnedFlavors[ps.Name]
 	attemptedFlavorIdx := -1
 	idx := a.wl.LastAssignment.NextFlavorToTryForPodSetResource(psID, resName)
 	for ; idx < len(resourceGroup.Flavors); idx++ {
 		attemptedFlavorIdx = idx
 		fName := resourceGroup.Flavors[idx]
+		if pinned && fName != pinnedFlavor {
+			status.appendPendingReason(kueue.PendingReason{
+				Reason:  kueue.PendingReasonFlavorNotPinned,
+				Flavor:  fName,
+				Message: fmt.Sprintf("podSet %s is pinned to flavor %s", ps.Name, pinnedFlavor),
+			})
+			continue
+		}
+		if a.excludedFlavors.Has(fName) {
+			status.appendPendingReason(kueue.PendingReason{
+				Reason:  kueue.PendingReasonFlavorExcluded,
+				Flavor:  fName,
+				Message: fmt.Sprintf("flavor %s is excluded by the workload", fName),
+			})
+			continue
+		}
 		flavor, exist := a.resourceFlavors[fName]
 		if !exist {
 			log.Error(nil, "Flavor not found", "Flavor", fName)
-			status.append(fmt.Sprintf("flavor %s not found", fName))
+			status.appendPendingReason(kueue.PendingReason{
+				Reason:  kueue.PendingReasonFlavorNotFound,
+				Flavor:  fName,
+				Message: fmt.Sprintf("flavor %s not found", fName),
+			})
 			continue
 		}
 		if features.Enabled(features.TopologyAwareScheduling) {
 			if message := checkPodSetAndFlavorMatchForTAS(a.cq, ps, flavor); message != nil {
 				log.Error(nil, *message)
-				status.append(*message)
+				status.appendPendingReason(kueue.PendingReason{
+					Reason:  kueue.PendingReasonTopologyInfeasible,
+					Flavor:  fName,
+					Message: *message,
+				})
 				continue
 			}
 		}
@@ -453,7 +517,11 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 			return t.Effect == corev1.TaintEffectNoSchedule || t.Effect == corev1.TaintEffectNoExecute
 		})
 		if untolerated {
-			status.append(fmt.Sprintf("untolerated taint %s in flavor %s", taint, fName))
+			status.appendPendingReason(kueue.PendingReason{
+				Reason:  kueue.PendingReasonUntoleratedTaint,
+				Flavor:  fName,
+				Message: fmt.Sprintf("untolerated taint %s in flavor %s", taint, fName),
+			})
 			continue
 		}
 		if match, err := selector.Match(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: flavor.Spec.NodeLabels}}); !match || err != nil {
@@ -461,9 +529,19 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 				status.err = err
 				return nil, status
 			}
-			status.append(fmt.Sprintf("flavor %s doesn't match node affinity", fName))
+			status.appendPendingReason(kueue.PendingReason{
+				Reason:  kueue.PendingReasonNodeAffinityMismatch,
+				Flavor:  fName,
+				Message: fmt.Sprintf("flavor %s doesn't match node affinity", fName),
+			})
 			continue
 		}
+		if flavorCap := a.cq.FlavorCaps[fName]; flavorCap != nil {
+			if s := flavorCapStatus(fName, flavorCap, requests, assignmentUsage); s != nil {
+				status.merge(s)
+				continue
+			}
+		}
 		needsBorrowing := false
 		assignments := make(ResourceAssignment, len(requests))
 		// Calculate representativeMode for this assignment as the worst mode among all requests.
@@ -474,7 +552,7 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 			fr := resources.FlavorResource{Flavor: fName, Resource: rName}
 			mode, borrow, s := a.fitsResourceQuota(log, fr, val+assignmentUsage[fr], resQuota)
 			if s != nil {
-				status.reasons = append(status.reasons, s.reasons...)
+				status.merge(s)
 			}
 			if mode < representativeMode {
 				representativeMode = mode
@@ -528,6 +606,40 @@ func (a *FlavorAssigner) findFlavorForPodSetResource(
 	return bestAssignment, status
 }
 
+// flavorCapStatus returns a Status with the reasons for which the requests,
+// added to the flavor usage by the previous pod sets, exceed the cap set on
+// the flavor by the limits of its Karpenter NodePool or by the unhealthy
+// devices of its nodes, or nil if they don't.
+func flavorCapStatus(fName kueue.ResourceFlavorReference, flavorCap *cache.FlavorCapSnapshot, requests resources.Requests, assignmentUsage resources.FlavorResourceQuantities) *Status {
+	usage := make(resources.Requests, len(requests))
+	for rName, val := range requests {
+		usage[rName] = val + assignmentUsage[resources.FlavorResource{Flavor: fName, Resource: rName}]
+	}
+	exceeded := flavorCap.ExceededLimits(usage)
+	if len(exceeded) == 0 {
+		return nil
+	}
+	status := &Status{}
+	for _, rName := range exceeded {
+		missing := flavorCap.Usage[rName] + usage[rName] - flavorCap.Limits[rName]
+		reason := kueue.PendingReason{
+			Reason:   kueue.PendingReasonNodePoolLimitExceeded,
+			Flavor:   fName,
+			Resource: rName,
+			Missing:  ptr.To(resources.ResourceQuantity(rName, missing)),
+			Message: fmt.Sprintf("insufficient capacity for %s in flavor %s, limited to %s by the Karpenter NodePool %s, %s more needed",
+				rName, fName, resources.ResourceQuantityString(rName, flavorCap.Limits[rName]), flavorCap.NodePool, resources.ResourceQuantityString(rName, missing)),
+		}
+		if unhealthy, found := flavorCap.Unhealthy[rName]; found {
+			reason.Reason = kueue.PendingReasonUnhealthyDevices
+			reason.Message = fmt.Sprintf("insufficient capacity for %s in flavor %s, limited to %s by %s unhealthy devices, %s more needed",
+				rName, fName, resources.ResourceQuantityString(rName, flavorCap.Limits[rName]), resources.ResourceQuantityString(rName, unhealthy), resources.ResourceQuantityString(rName, missing))
+		}
+		status.appendPendingReason(reason)
+	}
+	return status
+}
+
 func shouldTryNextFlavor(representativeMode granularMode, flavorFungibility kueue.FlavorFungibility, needsBorrowing bool) bool {
 	policyPreempt := flavorFungibility.WhenCanPreempt
 	policyBorrow := flavorFungibility.WhenCanBorrow
@@ -548,52 +660,6 @@ func shouldTryNextFlavor(representativeMode granularMode, flavorFungibility kueu
 	return true
 }
 
-func flavorSelector(spec *corev1.PodSpec, allowedKeys sets.Set[string]) nodeaffinity.RequiredNodeAffinity {
-	// This function generally replicates the implementation of kube-scheduler's NodeAffinity
-	// Filter plugin as of v1.24.
-	var specCopy corev1.PodSpec
-
-	// Remove affinity constraints with irrelevant keys.
-	if len(spec.NodeSelector) != 0 {
-		specCopy.NodeSelector = map[string]string{}
-		for k, v := range spec.NodeSelector {
-			if allowedKeys.Has(k) {
-				specCopy.NodeSelector[k] = v
-			}
-		}
-	}
-
-	affinity := spec.Affinity
-	if affinity != nil && affinity.NodeAffinity != nil && affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
-		var termsCopy []corev1.NodeSelectorTerm
-		for _, t := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
-			var expCopy []corev1.NodeSelectorRequirement
-			for _, e := range t.MatchExpressions {
-				if allowedKeys.Has(e.Key) {
-					expCopy = append(expCopy, e)
-				}
-			}
-			// If a term becomes empty, it means node affinity matches any flavor since those terms are ORed,
-			// and so matching gets reduced to spec.NodeSelector
-			if len(expCopy) == 0 {
-				termsCopy = nil
-				break
-			}
-			termsCopy = append(termsCopy, corev1.NodeSelectorTerm{MatchExpressions: expCopy})
-		}
-		if len(termsCopy) != 0 {
-			specCopy.Affinity = &corev1.Affinity{
-				NodeAffinity: &corev1.NodeAffinity{
-					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
-						NodeSelectorTerms: termsCopy,
-					},
-				},
-			}
-		}
-	}
-	return nodeaffinity.GetRequiredNodeAffinity(&corev1.Pod{Spec: specCopy})
-}
-
 // fitsResourceQuota returns how this flavor could be assigned to the resource,
 // according to the remaining quota in the ClusterQueue and cohort.
 // If it fits, also returns if borrowing required. Similarly, it returns information
@@ -609,8 +675,14 @@ func (a *FlavorAssigner) fitsResourceQuota(log logr.Logger, fr resources.FlavorR
 
 	// No Fit
 	if val > maxCapacity {
-		status.append(fmt.Sprintf("insufficient quota for %s in flavor %s, request > maximum capacity (%s > %s)",
-			fr.Resource, fr.Flavor, resources.ResourceQuantityString(fr.Resource, val), resources.ResourceQuantityString(fr.Resource, maxCapacity)))
+		status.appendPendingReason(kueue.PendingReason{
+			Reason:   kueue.PendingReasonExceedsMaximumCapacity,
+			Flavor:   fr.Flavor,
+			Resource: fr.Resource,
+			Missing:  ptr.To(resources.ResourceQuantity(fr.Resource, val-maxCapacity)),
+			Message: fmt.Sprintf("insufficient quota for %s in flavor %s, request > maximum capacity (%s > %s)",
+				fr.Resource, fr.Flavor, resources.ResourceQuantityString(fr.Resource, val), resources.ResourceQuantityString(fr.Resource, maxCapacity)),
+		})
 		return noFit, false, &status
 	}
 
@@ -630,8 +702,14 @@ func (a *FlavorAssigner) fitsResourceQuota(log logr.Logger, fr resources.FlavorR
 		mode = preempt
 	}
 
-	status.append(fmt.Sprintf("insufficient unused quota for %s in flavor %s, %s more needed",
-		fr.Resource, fr.Flavor, resources.ResourceQuantityString(fr.Resource, val-available)))
+	status.appendPendingReason(kueue.PendingReason{
+		Reason:   kueue.PendingReasonInsufficientQuota,
+		Flavor:   fr.Flavor,
+		Resource: fr.Resource,
+		Missing:  ptr.To(resources.ResourceQuantity(fr.Resource, val-available)),
+		Message: fmt.Sprintf("insufficient unused quota for %s in flavor %s, %s more needed",
+			fr.Resource, fr.Flavor, resources.ResourceQuantityString(fr.Resource, val-available)),
+	})
 
 	return mode, borrow, &status
 }
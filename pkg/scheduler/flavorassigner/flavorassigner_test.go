@@ -25,9 +25,11 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
@@ -77,6 +79,7 @@ func TestAssignFlavors(t *testing.T) {
 		secondaryClusterQueueUsage resources.FlavorResourceQuantities
 		wantRepMode                FlavorAssignmentMode
 		wantAssignment             Assignment
+		wantPendingReasons         []kueue.PendingReason
 		disableLendingLimit        bool
 		enableFairSharing          bool
 	}{
@@ -214,6 +217,14 @@ func TestAssignFlavors(t *testing.T) {
 					{Flavor: "default", Resource: corev1.ResourceCPU}: 2_000,
 				},
 			},
+			wantPendingReasons: []kueue.PendingReason{{
+				Reason:   kueue.PendingReasonInsufficientQuota,
+				PodSet:   "main",
+				Flavor:   "default",
+				Resource: corev1.ResourceCPU,
+				Missing:  ptr.To(resource.MustParse("1")),
+				Message:  "insufficient unused quota for cpu in flavor default, 1 more needed",
+			}},
 		},
 		"multiple resource groups, fits": {
 			wlPods: []kueue.PodSet{
@@ -731,6 +742,20 @@ func TestAssignFlavors(t *testing.T) {
 				}},
 				Usage: resources.FlavorResourceQuantities{},
 			},
+			wantPendingReasons: []kueue.PendingReason{
+				{
+					Reason:  kueue.PendingReasonNodeAffinityMismatch,
+					PodSet:  "main",
+					Flavor:  "one",
+					Message: "flavor one doesn't match node affinity",
+				},
+				{
+					Reason:  kueue.PendingReasonNodeAffinityMismatch,
+					PodSet:  "main",
+					Flavor:  "two",
+					Message: "flavor two doesn't match node affinity",
+				},
+			},
 		},
 		"multiple specs, fit different flavors": {
 			wlPods: []kueue.PodSet{
@@ -880,6 +905,14 @@ func TestAssignFlavors(t *testing.T) {
 				}},
 				Usage: resources.FlavorResourceQuantities{},
 			},
+			wantPendingReasons: []kueue.PendingReason{{
+				Reason:   kueue.PendingReasonExceedsMaximumCapacity,
+				PodSet:   "main",
+				Flavor:   "one",
+				Resource: corev1.ResourceCPU,
+				Missing:  ptr.To(resource.MustParse("1")),
+				Message:  "insufficient quota for cpu in flavor one, request > maximum capacity (2 > 1)",
+			}},
 		},
 		"past max, but can preempt in ClusterQueue": {
 			wlPods: []kueue.PodSet{
@@ -1158,6 +1191,12 @@ func TestAssignFlavors(t *testing.T) {
 				}},
 				Usage: resources.FlavorResourceQuantities{},
 			},
+			wantPendingReasons: []kueue.PendingReason{{
+				Reason:   kueue.PendingReasonResourceUnavailable,
+				PodSet:   "main",
+				Resource: "example.com/gpu",
+				Message:  "resource example.com/gpu unavailable in ClusterQueue",
+			}},
 		},
 		"num pods fit": {
 			wlPods: []kueue.PodSet{
@@ -2026,6 +2065,11 @@ func TestAssignFlavors(t *testing.T) {
 			if diff := cmp.Diff(tc.wantAssignment, assignment, cmpopts.IgnoreUnexported(Assignment{}, FlavorAssignment{}), cmpopts.IgnoreFields(Assignment{}, "LastState")); diff != "" {
 				t.Errorf("Unexpected assignment (-want,+got):\n%s", diff)
 			}
+			if tc.wantPendingReasons != nil {
+				if diff := cmp.Diff(tc.wantPendingReasons, assignment.PendingReasons()); diff != "" {
+					t.Errorf("Unexpected pending reasons (-want,+got):\n%s", diff)
+				}
+			}
 		})
 	}
 }
@@ -2314,6 +2358,336 @@ func TestDeletedFlavors(t *testing.T) {
 	}
 }
 
+func TestFlavorCaps(t *testing.T) {
+	flavorCaps := map[kueue.ResourceFlavorReference]*cache.FlavorCapSnapshot{
+		"on-demand": {
+			NodePool: "general",
+			Limits:   resources.Requests{corev1.ResourceCPU: 4_000},
+			Usage:    resources.Requests{corev1.ResourceCPU: 2_000},
+		},
+		"degraded": {
+			Limits:    resources.Requests{corev1.ResourceCPU: 4_000},
+			Unhealthy: resources.Requests{corev1.ResourceCPU: 6_000},
+			Usage:     resources.Requests{corev1.ResourceCPU: 2_000},
+		},
+	}
+	cases := map[string]struct {
+		request     string
+		flavors     []string
+		wantMode    FlavorAssignmentMode
+		wantFlavor  kueue.ResourceFlavorReference
+		wantReasons []kueue.PendingReason
+	}{
+		"fits in the cap": {
+			request:    "2",
+			flavors:    []string{"on-demand", "spot"},
+			wantMode:   Fit,
+			wantFlavor: "on-demand",
+		},
+		"exceeds the cap, next flavor": {
+			request:    "3",
+			flavors:    []string{"on-demand", "spot"},
+			wantMode:   Fit,
+			wantFlavor: "spot",
+		},
+		"exceeds the cap, no other flavor": {
+			request:  "3",
+			flavors:  []string{"on-demand"},
+			wantMode: NoFit,
+			wantReasons: []kueue.PendingReason{{
+				Reason:   kueue.PendingReasonNodePoolLimitExceeded,
+				PodSet:   "main",
+				Flavor:   "on-demand",
+				Resource: corev1.ResourceCPU,
+				Missing:  ptr.To(resource.MustParse("1")),
+				Message:  "insufficient capacity for cpu in flavor on-demand, limited to 4 by the Karpenter NodePool general, 1 more needed",
+			}},
+		},
+		"exceeds the cap reduced by the unhealthy devices": {
+			request:  "3",
+			flavors:  []string{"degraded"},
+			wantMode: NoFit,
+			wantReasons: []kueue.PendingReason{{
+				Reason:   kueue.PendingReasonUnhealthyDevices,
+				PodSet:   "main",
+				Flavor:   "degraded",
+				Resource: corev1.ResourceCPU,
+				Missing:  ptr.To(resource.MustParse("1")),
+				Message:  "insufficient capacity for cpu in flavor degraded, limited to 4 by 6 unhealthy devices, 1 more needed",
+			}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			log := testr.NewWithOptions(t, testr.Options{
+				Verbosity: 2,
+			})
+			wlInfo := workload.NewInfo(&kueue.Workload{
+				Spec: kueue.WorkloadSpec{
+					PodSets: []kueue.PodSet{
+						*utiltesting.MakePodSet("main", 1).
+							Request(corev1.ResourceCPU, tc.request).
+							Obj(),
+					},
+				},
+			})
+			var flavorQuotas []kueue.FlavorQuotas
+			flavorMap := make(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor)
+			for _, name := range tc.flavors {
+				flavorQuotas = append(flavorQuotas, *utiltesting.MakeFlavorQuotas(name).Resource(corev1.ResourceCPU, "10").Obj())
+				flavorMap[kueue.ResourceFlavorReference(name)] = utiltesting.MakeResourceFlavor(name).Obj()
+			}
+			cq := utiltesting.MakeClusterQueue("cq").ResourceGroup(flavorQuotas...).Obj()
+
+			cache := cache.New(utiltesting.NewFakeClient())
+			for _, flavor := range flavorMap {
+				cache.AddOrUpdateResourceFlavor(flavor)
+			}
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed to add CQ to cache")
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+			clusterQueue := snapshot.ClusterQueues["cq"]
+			clusterQueue.FlavorCaps = flavorCaps
+
+			assignment := New(wlInfo, clusterQueue, flavorMap, false, &testOracle{}).Assign(log, nil)
+			if repMode := assignment.RepresentativeMode(); repMode != tc.wantMode {
+				t.Errorf("Unexpected representative mode, want %s, got %s", tc.wantMode, repMode)
+			}
+			if tc.wantFlavor != "" {
+				if got := assignment.PodSets[0].Flavors[corev1.ResourceCPU].Name; got != tc.wantFlavor {
+					t.Errorf("Unexpected flavor, want %s, got %s", tc.wantFlavor, got)
+				}
+			}
+			if diff := cmp.Diff(tc.wantReasons, assignment.PendingReasons(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected pending reasons (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPinnedFlavors(t *testing.T) {
+	cases := map[string]struct {
+		annotation  string
+		wantMode    FlavorAssignmentMode
+		wantFlavors map[string]kueue.ResourceFlavorReference
+		wantReasons []kueue.PendingReason
+	}{
+		"not pinned": {
+			wantMode:    Fit,
+			wantFlavors: map[string]kueue.ResourceFlavorReference{"driver": "on-demand", "workers": "spot"},
+		},
+		"pinned pod sets": {
+			annotation:  "driver=spot,workers=spot",
+			wantMode:    Fit,
+			wantFlavors: map[string]kueue.ResourceFlavorReference{"driver": "spot", "workers": "spot"},
+		},
+		"pinned to a flavor without enough quota": {
+			annotation: "workers=on-demand",
+			wantMode:   NoFit,
+			wantReasons: []kueue.PendingReason{
+				{
+					Reason:   kueue.PendingReasonExceedsMaximumCapacity,
+					PodSet:   "workers",
+					Flavor:   "on-demand",
+					Resource: corev1.ResourceCPU,
+					Missing:  ptr.To(resource.MustParse("3")),
+					Message:  "insufficient quota for cpu in flavor on-demand, request > maximum capacity (7 > 4)",
+				},
+				{
+					Reason:  kueue.PendingReasonFlavorNotPinned,
+					PodSet:  "workers",
+					Flavor:  "spot",
+					Message: "podSet workers is pinned to flavor on-demand",
+				},
+			},
+		},
+		"pinned to a flavor not in the ClusterQueue": {
+			annotation: "driver=reserved",
+			wantMode:   NoFit,
+			wantReasons: []kueue.PendingReason{
+				{
+					Reason:  kueue.PendingReasonFlavorNotPinned,
+					PodSet:  "driver",
+					Flavor:  "on-demand",
+					Message: "podSet driver is pinned to flavor reserved",
+				},
+				{
+					Reason:  kueue.PendingReasonFlavorNotPinned,
+					PodSet:  "driver",
+					Flavor:  "spot",
+					Message: "podSet driver is pinned to flavor reserved",
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			log := testr.NewWithOptions(t, testr.Options{
+				Verbosity: 2,
+			})
+			wl := utiltesting.MakeWorkload("wl", "ns").
+				PodSets(
+					*utiltesting.MakePodSet("driver", 1).
+						Request(corev1.ResourceCPU, "1").
+						Obj(),
+					*utiltesting.MakePodSet("workers", 3).
+						Request(corev1.ResourceCPU, "2").
+						Obj(),
+				)
+			if tc.annotation != "" {
+				wl.Annotations(map[string]string{controllerconsts.PodSetFlavorsAnnotation: tc.annotation})
+			}
+			wlInfo := workload.NewInfo(wl.Obj())
+			flavorMap := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+				"on-demand": utiltesting.MakeResourceFlavor("on-demand").Obj(),
+				"spot":      utiltesting.MakeResourceFlavor("spot").Obj(),
+			}
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(
+					*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "4").Obj(),
+					*utiltesting.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "10").Obj(),
+				).
+				Obj()
+
+			cache := cache.New(utiltesting.NewFakeClient())
+			for _, flavor := range flavorMap {
+				cache.AddOrUpdateResourceFlavor(flavor)
+			}
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed to add CQ to cache")
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+
+			assignment := New(wlInfo, snapshot.ClusterQueues["cq"], flavorMap, false, &testOracle{}).Assign(log, nil)
+			if repMode := assignment.RepresentativeMode(); repMode != tc.wantMode {
+				t.Errorf("Unexpected representative mode, want %s, got %s", tc.wantMode, repMode)
+			}
+			if tc.wantFlavors != nil {
+				gotFlavors := make(map[string]kueue.ResourceFlavorReference, len(assignment.PodSets))
+				for _, psa := range assignment.PodSets {
+					gotFlavors[psa.Name] = psa.Flavors[corev1.ResourceCPU].Name
+				}
+				if diff := cmp.Diff(tc.wantFlavors, gotFlavors); diff != "" {
+					t.Errorf("Unexpected flavors (-want,+got):\n%s", diff)
+				}
+			}
+			if diff := cmp.Diff(tc.wantReasons, assignment.PendingReasons(), cmpopts.EquateEmpty(),
+				cmpopts.SortSlices(func(a, b kueue.PendingReason) bool { return a.Flavor < b.Flavor })); diff != "" {
+				t.Errorf("Unexpected pending reasons (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExcludedFlavors(t *testing.T) {
+	cases := map[string]struct {
+		annotation  string
+		wantMode    FlavorAssignmentMode
+		wantFlavors map[string]kueue.ResourceFlavorReference
+		wantReasons []kueue.PendingReason
+	}{
+		"no excluded flavors": {
+			wantMode:    Fit,
+			wantFlavors: map[string]kueue.ResourceFlavorReference{"driver": "on-demand", "workers": "spot"},
+		},
+		"excluded flavor": {
+			annotation:  "on-demand",
+			wantMode:    Fit,
+			wantFlavors: map[string]kueue.ResourceFlavorReference{"driver": "spot", "workers": "spot"},
+		},
+		"excluded flavor with enough quota for the workload": {
+			annotation: "spot",
+			wantMode:   NoFit,
+			wantReasons: []kueue.PendingReason{
+				{
+					Reason:   kueue.PendingReasonExceedsMaximumCapacity,
+					PodSet:   "workers",
+					Flavor:   "on-demand",
+					Resource: corev1.ResourceCPU,
+					Missing:  ptr.To(resource.MustParse("3")),
+					Message:  "insufficient quota for cpu in flavor on-demand, request > maximum capacity (7 > 4)",
+				},
+				{
+					Reason:  kueue.PendingReasonFlavorExcluded,
+					PodSet:  "workers",
+					Flavor:  "spot",
+					Message: "flavor spot is excluded by the workload",
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			log := testr.NewWithOptions(t, testr.Options{
+				Verbosity: 2,
+			})
+			wl := utiltesting.MakeWorkload("wl", "ns").
+				PodSets(
+					*utiltesting.MakePodSet("driver", 1).
+						Request(corev1.ResourceCPU, "1").
+						Obj(),
+					*utiltesting.MakePodSet("workers", 3).
+						Request(corev1.ResourceCPU, "2").
+						Obj(),
+				)
+			if tc.annotation != "" {
+				wl.Annotations(map[string]string{controllerconsts.ExcludedFlavorsAnnotation: tc.annotation})
+			}
+			wlInfo := workload.NewInfo(wl.Obj())
+			flavorMap := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+				"on-demand": utiltesting.MakeResourceFlavor("on-demand").Obj(),
+				"spot":      utiltesting.MakeResourceFlavor("spot").Obj(),
+			}
+			cq := utiltesting.MakeClusterQueue("cq").
+				ResourceGroup(
+					*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "4").Obj(),
+					*utiltesting.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "10").Obj(),
+				).
+				Obj()
+
+			cache := cache.New(utiltesting.NewFakeClient())
+			for _, flavor := range flavorMap {
+				cache.AddOrUpdateResourceFlavor(flavor)
+			}
+			if err := cache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Failed to add CQ to cache")
+			}
+			snapshot, err := cache.Snapshot(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error while building snapshot: %v", err)
+			}
+
+			assignment := New(wlInfo, snapshot.ClusterQueues["cq"], flavorMap, false, &testOracle{}).Assign(log, nil)
+			if repMode := assignment.RepresentativeMode(); repMode != tc.wantMode {
+				t.Errorf("Unexpected representative mode, want %s, got %s", tc.wantMode, repMode)
+			}
+			if tc.wantFlavors != nil {
+				gotFlavors := make(map[string]kueue.ResourceFlavorReference, len(assignment.PodSets))
+				for _, psa := range assignment.PodSets {
+					gotFlavors[psa.Name] = psa.Flavors[corev1.ResourceCPU].Name
+				}
+				if diff := cmp.Diff(tc.wantFlavors, gotFlavors); diff != "" {
+					t.Errorf("Unexpected flavors (-want,+got):\n%s", diff)
+				}
+			}
+			if diff := cmp.Diff(tc.wantReasons, assignment.PendingReasons(), cmpopts.EquateEmpty(),
+				cmpopts.SortSlices(func(a, b kueue.PendingReason) bool { return a.Flavor < b.Flavor })); diff != "" {
+				t.Errorf("Unexpected pending reasons (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestLastAssignmentOutdated(t *testing.T) {
 	type args struct {
 		wl *workload.Info
@@ -40,7 +40,10 @@ func assignTopology(log logr.Logger,
 		if psAssignment.Status == nil {
 			psAssignment.Status = &Status{}
 		}
-		psAssignment.Status.append("Workload requires Topology, but there is no TAS cache information")
+		psAssignment.Status.appendPendingReason(kueue.PendingReason{
+			Reason:  kueue.PendingReasonTopologyInfeasible,
+			Message: "Workload requires Topology, but there is no TAS cache information",
+		})
 		psAssignment.Flavors = nil
 	default:
 		singlePodRequests := psResources.Requests.Clone()
@@ -60,18 +63,26 @@ func assignTopology(log logr.Logger,
 			if psAssignment.Status == nil {
 				psAssignment.Status = &Status{}
 			}
-			psAssignment.Status.append("Workload requires Topology, but there is no TAS cache information for the assigned flavor")
+			psAssignment.Status.appendPendingReason(kueue.PendingReason{
+				Reason:  kueue.PendingReasonTopologyInfeasible,
+				Flavor:  *tasFlvr,
+				Message: "Workload requires Topology, but there is no TAS cache information for the assigned flavor",
+			})
 			psAssignment.Flavors = nil
 			return
 		}
 		var reason string
 		psAssignment.TopologyAssignment, reason = snapshot.FindTopologyAssignment(podSet.TopologyRequest,
-			singlePodRequests, podCount, podSet.Template.Spec.Tolerations)
+			singlePodRequests, podCount, &podSet.Template.Spec)
 		if psAssignment.TopologyAssignment == nil {
 			if psAssignment.Status == nil {
 				psAssignment.Status = &Status{}
 			}
-			psAssignment.Status.append(reason)
+			psAssignment.Status.appendPendingReason(kueue.PendingReason{
+				Reason:  kueue.PendingReasonTopologyInfeasible,
+				Flavor:  *tasFlvr,
+				Message: reason,
+			})
 			psAssignment.Flavors = nil
 		}
 		log.Info("TAS PodSet assignment", "tasAssignment", psAssignment.TopologyAssignment)
@@ -38,6 +38,7 @@ import (
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
@@ -55,9 +56,9 @@ type Preemptor struct {
 	client   client.Client
 	recorder record.EventRecorder
 
-	workloadOrdering  workload.Ordering
-	enableFairSharing bool
-	fsStrategies      []fsStrategy
+	workloadOrdering workload.Ordering
+	fairSharing      atomic.Pointer[fairSharingConfig]
+	priorityDecay    atomic.Pointer[priorityDecay]
 
 	// stubs
 	applyPreemption func(ctx context.Context, w *kueue.Workload, reason, message string) error
@@ -71,17 +72,39 @@ func New(
 	clock clock.Clock,
 ) *Preemptor {
 	p := &Preemptor{
-		clock:             clock,
-		client:            cl,
-		recorder:          recorder,
-		workloadOrdering:  workloadOrdering,
-		enableFairSharing: fs.Enable,
-		fsStrategies:      parseStrategies(fs.PreemptionStrategies),
+		clock:            clock,
+		client:           cl,
+		recorder:         recorder,
+		workloadOrdering: workloadOrdering,
 	}
+	p.SetFairSharing(fs)
 	p.applyPreemption = p.applyPreemptionWithSSA
 	return p
 }
 
+type fairSharingConfig struct {
+	enable         bool
+	strategies     []fsStrategy
+	victimOrdering []config.VictimOrderingCriterion
+}
+
+// SetFairSharing replaces the fair sharing configuration used to find the
+// preemption targets.
+func (p *Preemptor) SetFairSharing(fs config.FairSharing) {
+	p.fairSharing.Store(&fairSharingConfig{
+		enable:         fs.Enable,
+		strategies:     parseStrategies(fs.PreemptionStrategies),
+		victimOrdering: fs.VictimOrdering,
+	})
+}
+
+// SetPriorityDecay replaces the configuration of the decay of the priority
+// protecting the workloads running beyond their expected duration. A nil
+// configuration disables the decay.
+func (p *Preemptor) SetPriorityDecay(pd *config.PriorityDecay) {
+	p.priorityDecay.Store(newPriorityDecay(pd))
+}
+
 func (p *Preemptor) OverrideApply(f func(context.Context, *kueue.Workload, string, string) error) {
 	p.applyPreemption = f
 }
@@ -122,11 +145,18 @@ func (p *Preemptor) GetTargets(log logr.Logger, wl workload.Info, assignment fla
 func (p *Preemptor) getTargets(log logr.Logger, wl workload.Info, requests resources.FlavorResourceQuantities,
 	frsNeedPreemption sets.Set[resources.FlavorResource], snapshot *cache.Snapshot) []*Target {
 	cq := snapshot.ClusterQueues[wl.ClusterQueue]
-	candidates := p.findCandidates(wl.Obj, cq, frsNeedPreemption)
+	now := p.clock.Now()
+	decay := p.priorityDecay.Load()
+	candidates := p.findCandidates(wl.Obj, cq, frsNeedPreemption, decay, now)
 	if len(candidates) == 0 {
 		return nil
 	}
-	sort.Slice(candidates, candidatesOrdering(candidates, cq.Name, p.clock.Now()))
+	fs := p.fairSharing.Load()
+	victimOrdering := defaultVictimOrdering
+	if fs.enable && len(fs.victimOrdering) > 0 {
+		victimOrdering = fs.victimOrdering
+	}
+	sort.Slice(candidates, candidatesOrdering(candidates, cq.Name, victimCriteria(victimOrdering, cq, decay, now)))
 
 	sameQueueCandidates := candidatesOnlyFromQueue(candidates, wl.ClusterQueue)
 
@@ -142,8 +172,8 @@ func (p *Preemptor) getTargets(log logr.Logger, wl workload.Info, requests resou
 	}
 
 	borrowWithinCohort, thresholdPrio := canBorrowWithinCohort(cq, wl.Obj)
-	if p.enableFairSharing {
-		return p.fairPreemptions(log, wl, requests, snapshot, frsNeedPreemption, candidates, thresholdPrio)
+	if fs.enable {
+		return p.fairPreemptions(log, wl, requests, snapshot, frsNeedPreemption, candidates, thresholdPrio, fs.strategies)
 	}
 	// There is a potential of preemption of workloads from the other queue in the
 	// cohort. We proceed with borrowing only if the dedicated policy
@@ -209,8 +239,11 @@ func (p *Preemptor) IssuePreemptions(ctx context.Context, preemptor *workload.In
 			}
 
 			log.V(3).Info("Preempted", "targetWorkload", klog.KObj(target.WorkloadInfo.Obj), "reason", target.Reason, "message", message, "targetClusterQueue", klog.KRef("", target.WorkloadInfo.ClusterQueue))
-			p.recorder.Eventf(target.WorkloadInfo.Obj, corev1.EventTypeNormal, "Preempted", message)
-			metrics.ReportPreemption(preemptor.ClusterQueue, target.Reason, target.WorkloadInfo.ClusterQueue)
+			category := workload.PreemptionEvictionCategory(target.Reason)
+			p.recorder.AnnotatedEventf(target.WorkloadInfo.Obj, map[string]string{controllerconsts.EvictionCategoryAnnotation: string(category)},
+				corev1.EventTypeNormal, "Preempted", "%s", message)
+			metrics.ReportPreemption(preemptor.ClusterQueue, target.Reason, target.WorkloadInfo.ClusterQueue, p.runtimeAtPreemption(target.WorkloadInfo.Obj))
+			metrics.ReportEvictionCategory(target.WorkloadInfo.ClusterQueue, category)
 		} else {
 			log.V(3).Info("Preemption ongoing", "targetWorkload", klog.KObj(target.WorkloadInfo.Obj))
 		}
@@ -219,9 +252,17 @@ func (p *Preemptor) IssuePreemptions(ctx context.Context, preemptor *workload.In
 	return int(successfullyPreempted.Load()), errCh.ReceiveError()
 }
 
+// runtimeAtPreemption returns the time since the workload got quota reserved.
+func (p *Preemptor) runtimeAtPreemption(wl *kueue.Workload) time.Duration {
+	if c := meta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved); c != nil && c.Status == metav1.ConditionTrue {
+		return p.clock.Since(c.LastTransitionTime.Time)
+	}
+	return 0
+}
+
 func (p *Preemptor) applyPreemptionWithSSA(ctx context.Context, w *kueue.Workload, reason, message string) error {
 	w = w.DeepCopy()
-	workload.SetEvictedCondition(w, kueue.WorkloadEvictedByPreemption, message)
+	workload.SetEvictedCondition(w, kueue.WorkloadEvictedByPreemption, workload.PreemptionEvictionCategory(reason), message)
 	workload.ResetChecksOnEviction(w, p.clock.Now())
 	workload.SetPreemptedCondition(w, reason, message)
 	return workload.ApplyAdmissionStatus(ctx, p.client, w, true)
@@ -341,7 +382,7 @@ func parseStrategies(s []config.PreemptionStrategy) []fsStrategy {
 	return strategies
 }
 
-func (p *Preemptor) fairPreemptions(log logr.Logger, wl workload.Info, requests resources.FlavorResourceQuantities, snapshot *cache.Snapshot, frsNeedPreemption sets.Set[resources.FlavorResource], candidates []*workload.Info, allowBorrowingBelowPriority *int32) []*Target {
+func (p *Preemptor) fairPreemptions(log logr.Logger, wl workload.Info, requests resources.FlavorResourceQuantities, snapshot *cache.Snapshot, frsNeedPreemption sets.Set[resources.FlavorResource], candidates []*workload.Info, allowBorrowingBelowPriority *int32, strategies []fsStrategy) []*Target {
 	if logV := log.V(5); logV.Enabled() {
 		logV.Info("Simulating fair preemption", "candidates", workload.References(candidates), "resourcesRequiringPreemption", frsNeedPreemption, "allowBorrowingBelowPriority", allowBorrowingBelowPriority)
 	}
@@ -377,7 +418,7 @@ func (p *Preemptor) fairPreemptions(log logr.Logger, wl workload.Info, requests
 		for i, candWl := range candCQ.workloads {
 			belowThreshold := allowBorrowingBelowPriority != nil && priority.Priority(candWl.Obj) < *allowBorrowingBelowPriority
 			newCandShareVal, _ := candCQ.cq.DominantResourceShareWithout(candWl.FlavorResourceUsage())
-			strategy := p.fsStrategies[0](newNominatedShareValue, candCQ.share, newCandShareVal)
+			strategy := strategies[0](newNominatedShareValue, candCQ.share, newCandShareVal)
 			if belowThreshold || strategy {
 				snapshot.RemoveWorkload(candWl)
 				reason := kueue.InCohortFairSharingReason
@@ -405,7 +446,7 @@ func (p *Preemptor) fairPreemptions(log logr.Logger, wl workload.Info, requests
 			}
 		}
 	}
-	if !fits && len(p.fsStrategies) > 1 {
+	if !fits && len(strategies) > 1 {
 		// Try next strategy if the previous strategy wasn't enough
 		cqHeap = cqHeapFromCandidates(retryCandidates, true, snapshot)
 
@@ -413,7 +454,7 @@ func (p *Preemptor) fairPreemptions(log logr.Logger, wl workload.Info, requests
 			candCQ := cqHeap.Pop()
 			// Due to API validation, we can only reach here if the second strategy is LessThanInitialShare,
 			// in which case the last parameter for the strategy function is irrelevant.
-			if p.fsStrategies[1](newNominatedShareValue, candCQ.share, 0) {
+			if strategies[1](newNominatedShareValue, candCQ.share, 0) {
 				// The criteria doesn't depend on the preempted workload, so just preempt the first candidate.
 				candWl := candCQ.workloads[0]
 				snapshot.RemoveWorkload(candWl)
@@ -485,8 +526,9 @@ func flavorResourcesNeedPreemption(assignment flavorassigner.Assignment) sets.Se
 
 // findCandidates obtains candidates for preemption within the ClusterQueue and
 // cohort that respect the preemption policy and are using a resource that the
-// preempting workload needs.
-func (p *Preemptor) findCandidates(wl *kueue.Workload, cq *cache.ClusterQueueSnapshot, frsNeedPreemption sets.Set[resources.FlavorResource]) []*workload.Info {
+// preempting workload needs. The priorities of the candidates are decayed at
+// the time.
+func (p *Preemptor) findCandidates(wl *kueue.Workload, cq *cache.ClusterQueueSnapshot, frsNeedPreemption sets.Set[resources.FlavorResource], decay *priorityDecay, now time.Time) []*workload.Info {
 	var candidates []*workload.Info
 	wlPriority := priority.Priority(wl)
 
@@ -495,7 +537,7 @@ func (p *Preemptor) findCandidates(wl *kueue.Workload, cq *cache.ClusterQueueSna
 		preemptorTS := p.workloadOrdering.GetQueueOrderTimestamp(wl)
 
 		for _, candidateWl := range cq.Workloads {
-			candidatePriority := priority.Priority(candidateWl.Obj)
+			candidatePriority := decay.priority(candidateWl.Obj, now)
 			if candidatePriority > wlPriority {
 				continue
 			}
@@ -519,7 +561,7 @@ func (p *Preemptor) findCandidates(wl *kueue.Workload, cq *cache.ClusterQueueSna
 				continue
 			}
 			for _, candidateWl := range cohortCQ.Workloads {
-				if onlyLowerPriority && priority.Priority(candidateWl.Obj) >= priority.Priority(wl) {
+				if onlyLowerPriority && decay.priority(candidateWl.Obj, now) >= wlPriority {
 					continue
 				}
 				if !workloadUsesResources(candidateWl, frsNeedPreemption) {
@@ -583,9 +625,9 @@ func queueUnderNominalInResourcesNeedingPreemption(frsNeedPreemption sets.Set[re
 // 0. Workloads already marked for preemption first.
 // 1. Workloads from other ClusterQueues in the cohort before the ones in the
 // same ClusterQueue as the preemptor.
-// 2. Workloads with lower priority first.
-// 3. Workloads admitted more recently first.
-func candidatesOrdering(candidates []*workload.Info, cq string, now time.Time) func(int, int) bool {
+// 2. The victim criteria, by default workloads with lower priority, decayed at
+// the time, first, then workloads admitted more recently first.
+func candidatesOrdering(candidates []*workload.Info, cq string, criteria []victimCriterion) func(int, int) bool {
 	return func(i, j int) bool {
 		a := candidates[i]
 		b := candidates[j]
@@ -599,15 +641,10 @@ func candidatesOrdering(candidates []*workload.Info, cq string, now time.Time) f
 		if aInCQ != bInCQ {
 			return !aInCQ
 		}
-		pa := priority.Priority(a.Obj)
-		pb := priority.Priority(b.Obj)
-		if pa != pb {
-			return pa < pb
-		}
-		timeA := quotaReservationTime(a.Obj, now)
-		timeB := quotaReservationTime(b.Obj, now)
-		if !timeA.Equal(timeB) {
-			return timeA.After(timeB)
+		for _, criterion := range criteria {
+			if c := criterion(a, b); c != 0 {
+				return c < 0
+			}
 		}
 		// Arbitrary comparison for deterministic sorting.
 		return a.Obj.UID < b.Obj.UID
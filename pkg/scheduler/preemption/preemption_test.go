@@ -41,6 +41,7 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/hierarchy"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
@@ -55,7 +56,9 @@ var snapCmpOpts = []cmp.Option{
 	cmpopts.IgnoreUnexported(hierarchy.ClusterQueue[*cache.CohortSnapshot]{}),
 	cmpopts.IgnoreUnexported(hierarchy.Manager[*cache.ClusterQueueSnapshot, *cache.CohortSnapshot]{}),
 	cmpopts.IgnoreUnexported(hierarchy.CycleChecker{}),
+	cmpopts.IgnoreUnexported(cache.ClusterQueueSnapshot{}),
 	cmpopts.IgnoreFields(cache.ClusterQueueSnapshot{}, "AllocatableResourceGeneration"),
+	cmpopts.IgnoreFields(cache.Snapshot{}, "Epoch"),
 	cmp.Transformer("Cohort.Members", func(s sets.Set[*cache.ClusterQueueSnapshot]) sets.Set[string] {
 		result := make(sets.Set[string], len(s))
 		for cq := range s {
@@ -288,6 +291,7 @@ func TestPreemption(t *testing.T) {
 		assignment          flavorassigner.Assignment
 		wantPreempted       sets.Set[string]
 		disableLendingLimit bool
+		priorityDecay       *config.PriorityDecay
 	}{
 		"preempt lowest priority": {
 			clusterQueues: defaultClusterQueues,
@@ -320,6 +324,44 @@ func TestPreemption(t *testing.T) {
 			}),
 			wantPreempted: sets.New(targetKeyReason("/low", kueue.InClusterQueueReason)),
 		},
+		"preempt the workload overrunning its expected duration": {
+			clusterQueues: defaultClusterQueues,
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("low", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(), now.Add(-3*time.Hour)).
+					Obj(),
+				*utiltesting.MakeWorkload("overrun", "").
+					Priority(1).
+					Annotations(map[string]string{controllerconsts.ExpectedDurationAnnotation: "1h"}).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(), now.Add(-3*time.Hour)).
+					Obj(),
+				*utiltesting.MakeWorkload("on-time", "").
+					Priority(1).
+					Annotations(map[string]string{controllerconsts.ExpectedDurationAnnotation: "2h"}).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuotaAt(utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(), now.Add(-3*time.Hour)).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			priorityDecay: &config.PriorityDecay{
+				ThresholdPercent: ptr.To[int32](200),
+				Step:             ptr.To[int32](100),
+				Interval:         &metav1.Duration{Duration: time.Hour},
+			},
+			wantPreempted: sets.New(targetKeyReason("/overrun", kueue.InClusterQueueReason)),
+		},
 		"preempt multiple": {
 			clusterQueues: defaultClusterQueues,
 			admitted: []kueue.Workload{
@@ -1535,6 +1577,7 @@ func TestPreemption(t *testing.T) {
 			}
 			recorder := broadcaster.NewRecorder(scheme, corev1.EventSource{Component: constants.AdmissionName})
 			preemptor := New(cl, workload.Ordering{}, recorder, config.FairSharing{}, clocktesting.NewFakeClock(now))
+			preemptor.SetPriorityDecay(tc.priorityDecay)
 			preemptor.applyPreemption = func(ctx context.Context, w *kueue.Workload, reason, _ string) error {
 				lock.Lock()
 				gotPreempted.Insert(targetKeyReason(workload.Key(w), reason))
@@ -1624,12 +1667,13 @@ func TestFairPreemptions(t *testing.T) {
 	}
 	unitWl := *utiltesting.MakeWorkload("unit", "").Request(corev1.ResourceCPU, "1")
 	cases := map[string]struct {
-		clusterQueues []*kueue.ClusterQueue
-		strategies    []config.PreemptionStrategy
-		admitted      []kueue.Workload
-		incoming      *kueue.Workload
-		targetCQ      string
-		wantPreempted sets.Set[string]
+		clusterQueues  []*kueue.ClusterQueue
+		strategies     []config.PreemptionStrategy
+		victimOrdering []config.VictimOrderingCriterion
+		admitted       []kueue.Workload
+		incoming       *kueue.Workload
+		targetCQ       string
+		wantPreempted  sets.Set[string]
 	}{
 		"reclaim nominal from user using the most": {
 			clusterQueues: baseCQs,
@@ -2032,6 +2076,42 @@ func TestFairPreemptions(t *testing.T) {
 			incoming: unitWl.Clone().Name("a_incoming").Obj(),
 			targetCQ: "a",
 		},
+		"reclaim the workload of the user using the most using the fewest resources": {
+			clusterQueues:  baseCQs,
+			victimOrdering: []config.VictimOrderingCriterion{config.Smallest},
+			admitted: []kueue.Workload{
+				*unitWl.Clone().Name("a1").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a2").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a3").SimpleReserveQuota("a", "default", now).Obj(),
+				*utiltesting.MakeWorkload("b1", "").Request(corev1.ResourceCPU, "3").SimpleReserveQuota("b", "default", now).Obj(),
+				*utiltesting.MakeWorkload("b2", "").Request(corev1.ResourceCPU, "2").SimpleReserveQuota("b", "default", now).Obj(),
+				*utiltesting.MakeWorkload("b3", "").Request(corev1.ResourceCPU, "1").SimpleReserveQuota("b", "default", now).Obj(),
+			},
+			incoming:      unitWl.Clone().Name("c_incoming").Obj(),
+			targetCQ:      "c",
+			wantPreempted: sets.New(targetKeyReason("/b3", kueue.InCohortFairSharingReason)),
+		},
+		"reclaim the workload of the user using the most with the least progress": {
+			clusterQueues:  baseCQs,
+			victimOrdering: []config.VictimOrderingCriterion{config.LeastProgress},
+			admitted: []kueue.Workload{
+				*unitWl.Clone().Name("a1").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a2").SimpleReserveQuota("a", "default", now).Obj(),
+				*unitWl.Clone().Name("a3").SimpleReserveQuota("a", "default", now).Obj(),
+				*utiltesting.MakeWorkload("b1", "").Request(corev1.ResourceCPU, "2").
+					Annotations(map[string]string{controllerconsts.ProgressAnnotation: "3/4"}).
+					SimpleReserveQuota("b", "default", now).Obj(),
+				*utiltesting.MakeWorkload("b2", "").Request(corev1.ResourceCPU, "2").
+					Annotations(map[string]string{controllerconsts.ProgressAnnotation: "1/4"}).
+					SimpleReserveQuota("b", "default", now).Obj(),
+				*utiltesting.MakeWorkload("b3", "").Request(corev1.ResourceCPU, "2").
+					Annotations(map[string]string{controllerconsts.ProgressAnnotation: "1/2"}).
+					SimpleReserveQuota("b", "default", now).Obj(),
+			},
+			incoming:      unitWl.Clone().Name("c_incoming").Obj(),
+			targetCQ:      "c",
+			wantPreempted: sets.New(targetKeyReason("/b2", kueue.InCohortFairSharingReason)),
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -2059,6 +2139,7 @@ func TestFairPreemptions(t *testing.T) {
 			preemptor := New(cl, workload.Ordering{}, recorder, config.FairSharing{
 				Enable:               true,
 				PreemptionStrategies: tc.strategies,
+				VictimOrdering:       tc.victimOrdering,
 			}, clocktesting.NewFakeClock(now))
 
 			snapshot, err := cqCache.Snapshot(ctx)
@@ -2126,7 +2207,7 @@ func TestCandidatesOrdering(t *testing.T) {
 			}).
 			Obj()),
 	}
-	sort.Slice(candidates, candidatesOrdering(candidates, "self", now))
+	sort.Slice(candidates, candidatesOrdering(candidates, "self", victimCriteria(defaultVictimOrdering, nil, nil, now)))
 	gotNames := make([]string, len(candidates))
 	for i, c := range candidates {
 		gotNames[i] = workload.Key(c.Obj)
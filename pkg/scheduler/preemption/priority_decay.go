@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemption
+
+import (
+	"math"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/util/priority"
+)
+
+// priorityDecay lowers the priority protecting the admitted workloads from
+// preemption once they run beyond their expected duration.
+type priorityDecay struct {
+	thresholdPercent int64
+	step             int64
+	interval         time.Duration
+	minPriority      *int32
+}
+
+func newPriorityDecay(pd *config.PriorityDecay) *priorityDecay {
+	if pd == nil {
+		return nil
+	}
+	d := &priorityDecay{
+		thresholdPercent: int64(ptr.Deref(pd.ThresholdPercent, config.DefaultPriorityDecayThresholdPercent)),
+		step:             int64(ptr.Deref(pd.Step, config.DefaultPriorityDecayStep)),
+		interval:         config.DefaultPriorityDecayInterval,
+		minPriority:      pd.MinPriority,
+	}
+	if pd.Interval != nil {
+		d.interval = pd.Interval.Duration
+	}
+	return d
+}
+
+// priority returns the priority of the workload protecting it from
+// preemption at the time. It is the priority of the workload, lowered by the
+// step once the workload runs for longer than the threshold percentage of its
+// expected duration, and again every interval after that, down to the minimum
+// priority.
+// A nil priorityDecay returns the priority of the workload.
+func (d *priorityDecay) priority(wl *kueue.Workload, now time.Time) int32 {
+	p := priority.Priority(wl)
+	if d == nil {
+		return p
+	}
+	expected, err := time.ParseDuration(wl.Annotations[controllerconsts.ExpectedDurationAnnotation])
+	if err != nil || expected <= 0 {
+		return p
+	}
+	cond := meta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return p
+	}
+	threshold := time.Duration(float64(expected) * float64(d.thresholdPercent) / 100)
+	overrun := now.Sub(cond.LastTransitionTime.Time) - threshold
+	if overrun <= 0 {
+		return p
+	}
+	floor := int64(math.MinInt32)
+	if d.minPriority != nil {
+		floor = min(int64(p), int64(*d.minPriority))
+	}
+	// The steps are capped to the ones reaching the floor, to avoid
+	// overflowing.
+	steps := min(int64(overrun/d.interval)+1, (int64(p)-floor)/d.step+1)
+	return int32(max(int64(p)-steps*d.step, floor))
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemption
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestPriorityDecay(t *testing.T) {
+	now := time.Now()
+	defaultDecay := &config.PriorityDecay{
+		ThresholdPercent: ptr.To[int32](200),
+		Step:             ptr.To[int32](100),
+		Interval:         &metav1.Duration{Duration: time.Hour},
+	}
+	admitted := func(expectedDuration string, runtime time.Duration) *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload("wl", "ns").
+			Priority(1000).
+			Annotations(map[string]string{controllerconsts.ExpectedDurationAnnotation: expectedDuration}).
+			ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), now.Add(-runtime))
+	}
+
+	cases := map[string]struct {
+		decay        *config.PriorityDecay
+		workload     *utiltesting.WorkloadWrapper
+		wantPriority int32
+	}{
+		"no decay": {
+			workload:     admitted("1h", 10*time.Hour),
+			wantPriority: 1000,
+		},
+		"no expected duration": {
+			decay:        defaultDecay,
+			workload:     utiltesting.MakeWorkload("wl", "ns").Priority(1000).ReserveQuotaAt(utiltesting.MakeAdmission("cq").Obj(), now.Add(-10*time.Hour)),
+			wantPriority: 1000,
+		},
+		"invalid expected duration": {
+			decay:        defaultDecay,
+			workload:     admitted("1 hour", 10*time.Hour),
+			wantPriority: 1000,
+		},
+		"not admitted": {
+			decay:        defaultDecay,
+			workload:     utiltesting.MakeWorkload("wl", "ns").Priority(1000).Annotations(map[string]string{controllerconsts.ExpectedDurationAnnotation: "1h"}),
+			wantPriority: 1000,
+		},
+		"under the threshold": {
+			decay:        defaultDecay,
+			workload:     admitted("1h", 2*time.Hour),
+			wantPriority: 1000,
+		},
+		"over the threshold": {
+			decay:        defaultDecay,
+			workload:     admitted("1h", 2*time.Hour+time.Minute),
+			wantPriority: 900,
+		},
+		"over the threshold for several intervals": {
+			decay:        defaultDecay,
+			workload:     admitted("1h", 5*time.Hour+time.Minute),
+			wantPriority: 600,
+		},
+		"custom threshold": {
+			decay: &config.PriorityDecay{
+				ThresholdPercent: ptr.To[int32](150),
+				Step:             ptr.To[int32](100),
+				Interval:         &metav1.Duration{Duration: time.Hour},
+			},
+			workload:     admitted("2h", 3*time.Hour+time.Minute),
+			wantPriority: 900,
+		},
+		"down to the minimum priority": {
+			decay: &config.PriorityDecay{
+				ThresholdPercent: ptr.To[int32](200),
+				Step:             ptr.To[int32](100),
+				Interval:         &metav1.Duration{Duration: time.Hour},
+				MinPriority:      ptr.To[int32](750),
+			},
+			workload:     admitted("1h", 5*time.Hour+time.Minute),
+			wantPriority: 750,
+		},
+		"priority below the minimum priority": {
+			decay: &config.PriorityDecay{
+				ThresholdPercent: ptr.To[int32](200),
+				Step:             ptr.To[int32](100),
+				Interval:         &metav1.Duration{Duration: time.Hour},
+				MinPriority:      ptr.To[int32](2000),
+			},
+			workload:     admitted("1h", 5*time.Hour+time.Minute),
+			wantPriority: 1000,
+		},
+		"without limit": {
+			decay: &config.PriorityDecay{
+				ThresholdPercent: ptr.To[int32](100),
+				Step:             ptr.To[int32](math.MaxInt32),
+				Interval:         &metav1.Duration{Duration: time.Nanosecond},
+			},
+			workload:     admitted("1s", 24*time.Hour),
+			wantPriority: math.MinInt32,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := newPriorityDecay(tc.decay).priority(tc.workload.Obj(), now)
+			if got != tc.wantPriority {
+				t.Errorf("Unexpected priority %d, want %d", got, tc.wantPriority)
+			}
+		})
+	}
+}
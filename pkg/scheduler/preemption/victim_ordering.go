@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemption
+
+import (
+	"cmp"
+	"time"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// defaultVictimOrdering orders the candidates when fair sharing is disabled,
+// or enabled without a victim ordering.
+var defaultVictimOrdering = []config.VictimOrderingCriterion{config.LowestPriority, config.Newest}
+
+// victimCriterion compares two candidates, returning a negative number when a
+// is to be preempted before b.
+type victimCriterion func(a, b *workload.Info) int
+
+// victimCriteria returns the criteria comparing the candidates to preempt for
+// the workload of the ClusterQueue, in the order of the victim ordering.
+// The priorities of the candidates are decayed at the time.
+func victimCriteria(ordering []config.VictimOrderingCriterion, cq *cache.ClusterQueueSnapshot, decay *priorityDecay, now time.Time) []victimCriterion {
+	criteria := make([]victimCriterion, 0, len(ordering))
+	for _, criterion := range ordering {
+		switch criterion {
+		case config.LowestPriority:
+			criteria = append(criteria, func(a, b *workload.Info) int {
+				return cmp.Compare(decay.priority(a.Obj, now), decay.priority(b.Obj, now))
+			})
+		case config.Newest:
+			criteria = append(criteria, func(a, b *workload.Info) int {
+				return quotaReservationTime(b.Obj, now).Compare(quotaReservationTime(a.Obj, now))
+			})
+		case config.Smallest:
+			share := quotaShare(cq)
+			criteria = append(criteria, func(a, b *workload.Info) int {
+				return cmp.Compare(share(a), share(b))
+			})
+		case config.LeastProgress:
+			criteria = append(criteria, func(a, b *workload.Info) int {
+				return cmp.Compare(workload.Progress(a.Obj), workload.Progress(b.Obj))
+			})
+		}
+	}
+	return criteria
+}
+
+// quotaShare returns a function computing the dominant share, in per mille,
+// of the quota of the root of the cohort of the ClusterQueue, or of the
+// ClusterQueue if it has no cohort, used by a workload. The shares are
+// memoized, as they are computed for every comparison.
+func quotaShare(cq *cache.ClusterQueueSnapshot) func(*workload.Info) int64 {
+	quota := cq.ResourceNode.SubtreeQuota
+	if cq.HasParent() {
+		quota = cq.Parent().Root().ResourceNode.SubtreeQuota
+	}
+	shares := make(map[*workload.Info]int64)
+	return func(wl *workload.Info) int64 {
+		if share, found := shares[wl]; found {
+			return share
+		}
+		share := dominantShare(wl.FlavorResourceUsage(), quota)
+		shares[wl] = share
+		return share
+	}
+}
+
+func dominantShare(usage, quota resources.FlavorResourceQuantities) int64 {
+	var share int64
+	for fr, v := range usage {
+		if q := quota[fr]; q > 0 {
+			share = max(share, v*1000/q)
+		}
+	}
+	return share
+}
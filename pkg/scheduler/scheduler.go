@@ -20,13 +20,18 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"slices"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -39,15 +44,20 @@ import (
 
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/admissionpolicy"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/scheduler/audit"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
 	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/tracing"
 	"sigs.k8s.io/kueue/pkg/util/api"
+	"sigs.k8s.io/kueue/pkg/util/event"
 	"sigs.k8s.io/kueue/pkg/util/limitrange"
+	"sigs.k8s.io/kueue/pkg/util/parallelize"
 	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/util/resource"
 	"sigs.k8s.io/kueue/pkg/util/routine"
@@ -57,6 +67,18 @@ import (
 
 const (
 	errCouldNotAdmitWL = "Could not admit Workload and assign flavors in apiserver"
+
+	// defaultPendingEventsInterval is the interval in which the repeated
+	// Pending events of a workload are aggregated into a single event.
+	defaultPendingEventsInterval = 5 * time.Minute
+
+	// defaultStatusUpdateBatchPeriod is the period in which the status
+	// updates of the pending workloads are coalesced, when they are batched.
+	defaultStatusUpdateBatchPeriod = time.Second
+
+	// reclaimDebtExpiration is the time after which the reclaim debt of a
+	// ClusterQueue is dropped, if it wasn't preempted again meanwhile.
+	reclaimDebtExpiration = 10 * time.Minute
 )
 
 var (
@@ -71,8 +93,13 @@ type Scheduler struct {
 	admissionRoutineWrapper routine.Wrapper
 	preemptor               *preemption.Preemptor
 	workloadOrdering        workload.Ordering
-	fairSharing             config.FairSharing
+	fairSharing             atomic.Pointer[config.FairSharing]
 	clock                   clock.Clock
+	auditRecorder           audit.Recorder
+	pendingEvents           *event.Aggregator
+	statusBatcher           *workload.StatusBatcher
+	assignments             *assignmentCache
+	admissionPolicies       []*admissionpolicy.Policy
 
 	// attemptCount identifies the number of scheduling attempt in logs, from the last restart.
 	attemptCount int64
@@ -83,8 +110,14 @@ type Scheduler struct {
 
 type options struct {
 	podsReadyRequeuingTimestamp config.RequeuingTimestamp
+	tieBreakers                 []config.TieBreaker
 	fairSharing                 config.FairSharing
+	priorityDecay               *config.PriorityDecay
 	clock                       clock.Clock
+	auditRecorder               audit.Recorder
+	pendingEventsInterval       time.Duration
+	statusUpdateBatchPeriod     time.Duration
+	admissionPolicies           []*admissionpolicy.Policy
 }
 
 // Option configures the reconciler.
@@ -93,6 +126,8 @@ type Option func(*options)
 var defaultOptions = options{
 	podsReadyRequeuingTimestamp: config.EvictionTimestamp,
 	clock:                       realClock,
+	pendingEventsInterval:       defaultPendingEventsInterval,
+	statusUpdateBatchPeriod:     defaultStatusUpdateBatchPeriod,
 }
 
 // WithPodsReadyRequeuingTimestamp sets the timestamp that is used for ordering
@@ -103,6 +138,14 @@ func WithPodsReadyRequeuingTimestamp(ts config.RequeuingTimestamp) Option {
 	}
 }
 
+// WithTieBreakers sets the keys ordering the workloads of equal priority and
+// timestamp.
+func WithTieBreakers(tieBreakers []config.TieBreaker) Option {
+	return func(o *options) {
+		o.tieBreakers = tieBreakers
+	}
+}
+
 func WithFairSharing(fs *config.FairSharing) Option {
 	return func(o *options) {
 		if fs != nil {
@@ -111,6 +154,45 @@ func WithFairSharing(fs *config.FairSharing) Option {
 	}
 }
 
+// WithPriorityDecay sets the decay of the priority protecting the workloads
+// running beyond their expected duration from preemption.
+func WithPriorityDecay(pd *config.PriorityDecay) Option {
+	return func(o *options) {
+		o.priorityDecay = pd
+	}
+}
+
+// WithAuditRecorder sets the recorder of the scheduling decisions.
+func WithAuditRecorder(r audit.Recorder) Option {
+	return func(o *options) {
+		o.auditRecorder = r
+	}
+}
+
+// WithPendingEventsInterval sets the interval in which the repeated Pending
+// events of a workload are aggregated into a single event.
+func WithPendingEventsInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.pendingEventsInterval = interval
+	}
+}
+
+// WithStatusUpdateBatchPeriod sets the period in which the status updates of
+// the pending workloads are coalesced, when they are batched.
+func WithStatusUpdateBatchPeriod(period time.Duration) Option {
+	return func(o *options) {
+		o.statusUpdateBatchPeriod = period
+	}
+}
+
+// WithAdmissionPolicies sets the admission policies evaluated for the
+// workloads of all the ClusterQueues.
+func WithAdmissionPolicies(policies []*admissionpolicy.Policy) Option {
+	return func(o *options) {
+		o.admissionPolicies = policies
+	}
+}
+
 func WithClock(_ testing.TB, c clock.Clock) Option {
 	return func(o *options) {
 		o.clock = c
@@ -124,9 +206,9 @@ func New(queues *queue.Manager, cache *cache.Cache, cl client.Client, recorder r
 	}
 	wo := workload.Ordering{
 		PodsReadyRequeuingTimestamp: options.podsReadyRequeuingTimestamp,
+		TieBreakers:                 options.tieBreakers,
 	}
 	s := &Scheduler{
-		fairSharing:             options.fairSharing,
 		queues:                  queues,
 		cache:                   cache,
 		client:                  cl,
@@ -135,16 +217,51 @@ func New(queues *queue.Manager, cache *cache.Cache, cl client.Client, recorder r
 		admissionRoutineWrapper: routine.DefaultWrapper,
 		workloadOrdering:        wo,
 		clock:                   options.clock,
+		auditRecorder:           options.auditRecorder,
+		pendingEvents:           event.NewAggregator(recorder, options.pendingEventsInterval, options.clock),
+		admissionPolicies:       options.admissionPolicies,
 	}
+	if features.Enabled(features.BatchedWorkloadStatusUpdates) {
+		s.statusBatcher = workload.NewStatusBatcher(cl, options.statusUpdateBatchPeriod)
+	}
+	if features.Enabled(features.FlavorAssignmentCache) {
+		s.assignments = newAssignmentCache()
+	}
+	s.fairSharing.Store(&options.fairSharing)
+	s.preemptor.SetPriorityDecay(options.priorityDecay)
 	s.applyAdmission = s.applyAdmissionWithSSA
 	return s
 }
 
+// SetFairSharing replaces the fair sharing configuration of the scheduler and
+// of its preemptor.
+func (s *Scheduler) SetFairSharing(fs *config.FairSharing) {
+	var value config.FairSharing
+	if fs != nil {
+		value = *fs
+	}
+	s.fairSharing.Store(&value)
+	s.preemptor.SetFairSharing(value)
+	if s.assignments != nil {
+		s.assignments.reset()
+	}
+}
+
+// SetPriorityDecay replaces the configuration of the decay of the priority
+// protecting the workloads from preemption.
+func (s *Scheduler) SetPriorityDecay(pd *config.PriorityDecay) {
+	s.preemptor.SetPriorityDecay(pd)
+}
+
 // Start implements the Runnable interface to run scheduler as a controller.
 func (s *Scheduler) Start(ctx context.Context) error {
 	log := ctrl.LoggerFrom(ctx).WithName("scheduler")
 	ctx = ctrl.LoggerInto(ctx, log)
 	go wait.UntilWithBackoff(ctx, s.schedule)
+	go s.pendingEvents.Run(ctx)
+	if s.statusBatcher != nil {
+		go s.statusBatcher.Run(ctx)
+	}
 	return nil
 }
 
@@ -174,6 +291,38 @@ func reportSkippedPreemptions(p map[string]int) {
 	}
 }
 
+type cohortCycleStats struct {
+	duration           time.Duration
+	evaluated, skipped int
+}
+
+// reportCohortSchedulingCycle reports the time spent evaluating the entries,
+// and the number of entries evaluated and skipped, per cohort of their
+// ClusterQueues.
+func reportCohortSchedulingCycle(entries []entry, snapshot *cache.Snapshot) {
+	stats := make(map[string]*cohortCycleStats)
+	for i := range entries {
+		e := &entries[i]
+		var cohort string
+		if cq := snapshot.ClusterQueues[e.ClusterQueue]; cq != nil && cq.HasParent() {
+			cohort = cq.Parent().GetName()
+		}
+		st, found := stats[cohort]
+		if !found {
+			st = &cohortCycleStats{}
+			stats[cohort] = st
+		}
+		st.duration += e.evaluationTime
+		st.evaluated++
+		if e.status == skipped {
+			st.skipped++
+		}
+	}
+	for cohort, st := range stats {
+		metrics.ReportCohortSchedulingCycle(cohort, st.duration, st.evaluated, st.skipped)
+	}
+}
+
 func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 	s.attemptCount++
 	log := ctrl.LoggerFrom(ctx).WithValues("attemptCount", s.attemptCount)
@@ -182,8 +331,9 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 	// 1. Get the heads from the queues, including their desired clusterQueue.
 	// This operation blocks while the queues are empty.
 	headWorkloads := s.queues.Heads(ctx)
+	resizeRequests := s.queues.PopResizeRequests()
 	// If there are no elements, it means that the program is finishing.
-	if len(headWorkloads) == 0 {
+	if len(headWorkloads) == 0 && len(resizeRequests) == 0 {
 		return wait.KeepGoing
 	}
 	startTime := s.clock.Now()
@@ -195,13 +345,23 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 		return wait.SlowDown
 	}
 	logSnapshotIfVerbose(log, snapshot)
+	if features.Enabled(features.ReclaimDebtAccounting) {
+		s.forgetStaleReclaimDebt(headWorkloads, snapshot)
+	}
+
+	// The additional quota of the resized workloads, which already run, is
+	// reserved before the heads are considered.
+	s.resize(ctx, resizeRequests, snapshot)
+	if len(headWorkloads) == 0 {
+		return wait.KeepGoing
+	}
 
 	// 3. Calculate requirements (resource flavors, borrowing) for admitting workloads.
 	entries := s.nominate(ctx, headWorkloads, snapshot)
 
 	// 4. Sort entries based on borrowing, priorities (if enabled) and timestamps.
 	sort.Sort(entryOrdering{
-		enableFairSharing: s.fairSharing.Enable,
+		enableFairSharing: s.fairSharing.Load().Enable,
 		entries:           entries,
 		workloadOrdering:  s.workloadOrdering,
 	})
@@ -211,10 +371,26 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 	// This is because there can be other workloads deeper in a clusterQueue whose
 	// head got admitted that should be scheduled in the cohort before the heads
 	// of other clusterQueues.
+	// In the Cohorts ordering the workloads in strict FIFO, only the first
+	// workload can be admitted.
+	var strictFIFOHeads map[string]*entry
+	if features.Enabled(features.CohortStrictFIFO) {
+		strictFIFOHeads = s.strictFIFOHeads(entries, snapshot)
+	}
 	preemptedWorkloads := sets.New[string]()
 	skippedPreemptions := make(map[string]int)
+	lap := s.clock.Now()
 	for i := range entries {
+		// Charge the time since the previous entry started to it.
+		if i > 0 {
+			now := s.clock.Now()
+			entries[i-1].evaluationTime += now.Sub(lap)
+			lap = now
+		}
 		e := &entries[i]
+		if s.auditRecorder != nil {
+			snapshotClusterQueueUsage(e, snapshot)
+		}
 		mode := e.assignment.RepresentativeMode()
 		if mode == flavorassigner.NoFit {
 			continue
@@ -224,6 +400,13 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 		log := log.WithValues("workload", klog.KObj(e.Obj), "clusterQueue", klog.KRef("", e.ClusterQueue))
 		ctx := ctrl.LoggerInto(ctx, log)
 
+		if strictFIFOHeads != nil {
+			if cohort := cq.StrictFIFOCohort(); cohort != nil && strictFIFOHeads[cohort.Name] != e {
+				setSkipped(e, fmt.Sprintf("Workload is waiting for the workload %s, ahead in the strict FIFO order of the Cohort %s", klog.KObj(strictFIFOHeads[cohort.Name].Obj), cohort.Name))
+				continue
+			}
+		}
+
 		if mode == flavorassigner.Preempt && len(e.preemptionTargets) == 0 {
 			log.V(2).Info("Workload requires preemption, but there are no candidate workloads allowed for preemption", "preemption", cq.Preemption)
 			// we use resourcesToReserve to block capacity up to either the nominal capacity,
@@ -252,15 +435,33 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 			}
 			continue
 		}
+		tenantUsage := e.assignment.Usage.ByResource()
+		if tenant, _ := exceededTenantLimits(snapshot, e.Obj, tenantUsage); tenant != nil {
+			setSkipped(e, fmt.Sprintf("Workload no longer fits in the usage limits of the Tenant %s after processing another workload", tenant.Name))
+			continue
+		}
+		if flavor, _ := exceededFlavorCaps(snapshot, usage); flavor != "" {
+			setSkipped(e, fmt.Sprintf("Workload no longer fits in the capacity of the flavor %s after processing another workload", flavor))
+			continue
+		}
 		preemptedWorkloads.Insert(pendingPreemptions...)
-		cq.AddUsage(usage)
+		addSnapshotUsage(snapshot, cq, e.Obj, usage, tenantUsage)
 
 		if e.assignment.RepresentativeMode() == flavorassigner.Preempt {
 			// If preemptions are issued, the next attempt should try all the flavors.
 			e.LastAssignment = nil
+			if s.queues.ClusterQueueInShadowMode(e.ClusterQueue) {
+				s.recordShadowPreemptions(ctx, e)
+				continue
+			}
 			preempted, err := s.preemptor.IssuePreemptions(ctx, &e.Info, e.preemptionTargets)
 			if err != nil {
 				log.Error(err, "Failed to preempt workloads")
+			} else if features.Enabled(features.ReclaimDebtAccounting) {
+				for cqName, debt := range reclaimDebt(snapshot, e.ClusterQueue, e.preemptionTargets) {
+					log.V(3).Info("Recording reclaim debt", "clusterQueue", klog.KRef("", cqName), "debt", debt)
+					s.cache.AddReclaimDebt(cqName, debt, s.clock.Now())
+				}
 			}
 			if preempted != 0 {
 				e.inadmissibleMsg += fmt.Sprintf(". Pending the preemption of %d workload(s)", preempted)
@@ -268,11 +469,11 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 			}
 			continue
 		}
-		if !s.cache.PodsReadyForAllAdmittedWorkloads(log) {
+		if s.cache.BlocksAdmissionForPodsReady(cq.Name) && !s.cache.PodsReadyForAllAdmittedWorkloads(log) {
 			log.V(5).Info("Waiting for all admitted workloads to be in the PodsReady condition")
 			// If WaitForPodsReady is enabled and WaitForPodsReady.BlockAdmission is true
-			// Block admission until all currently admitted workloads are in
-			// PodsReady condition if the waitForPodsReady is enabled
+			// for the ClusterQueue, block admission until all currently admitted
+			// workloads of the ClusterQueues blocking admission are in PodsReady condition
 			workload.UnsetQuotaReservationWithCondition(e.Obj, "Waiting", "waiting for all admitted workloads to be in PodsReady condition", s.clock.Now())
 			if err := workload.ApplyAdmissionStatus(ctx, s.client, e.Obj, false); err != nil {
 				log.Error(err, "Could not update Workload status")
@@ -281,15 +482,21 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 			log.V(5).Info("Finished waiting for all admitted workloads to be in the PodsReady condition")
 		}
 		e.status = nominated
-		if err := s.admit(ctx, e, cq); err != nil {
+		if err := s.admit(ctx, e, cq, startTime); err != nil {
 			e.inadmissibleMsg = fmt.Sprintf("Failed to admit workload: %v", err)
 		}
 	}
+	if len(entries) > 0 {
+		entries[len(entries)-1].evaluationTime += s.clock.Since(lap)
+	}
 
 	// 6. Requeue the heads that were not scheduled.
 	result := metrics.AdmissionResultInadmissible
-	for _, e := range entries {
+	for i, e := range entries {
 		logAdmissionAttemptIfVerbose(log, &e)
+		if s.auditRecorder != nil {
+			s.auditRecorder.Record(s.auditDecision(&e, i))
+		}
 		if e.status != assumed {
 			s.requeueAndUpdate(ctx, e)
 		} else {
@@ -297,6 +504,7 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 		}
 	}
 	reportSkippedPreemptions(skippedPreemptions)
+	reportCohortSchedulingCycle(entries, snapshot)
 	metrics.AdmissionAttempt(result, s.clock.Since(startTime))
 	if result != metrics.AdmissionResultSuccess {
 		return wait.SlowDown
@@ -304,6 +512,115 @@ func (s *Scheduler) schedule(ctx context.Context) wait.SpeedSignal {
 	return wait.KeepGoing
 }
 
+// addSnapshotUsage adds the usage of the workload to its ClusterQueue, to the
+// Tenants of its LocalQueue and to the caps of its flavors in the snapshot.
+func addSnapshotUsage(snapshot *cache.Snapshot, cq *cache.ClusterQueueSnapshot, wl *kueue.Workload, usage resources.FlavorResourceQuantities, tenantUsage resources.Requests) {
+	cq.AddUsage(usage)
+	for _, tenant := range snapshot.LocalQueueTenants[workload.QueueKey(wl)] {
+		tenant.AddUsage(tenantUsage)
+	}
+	for flavor, flavorUsage := range usage.ByFlavor() {
+		if flavorCap := snapshot.FlavorCaps[flavor]; flavorCap != nil {
+			flavorCap.AddUsage(flavorUsage)
+		}
+	}
+}
+
+// resize reserves the additional quota requested by the resized workloads
+// with quota reserved, when it is available in the snapshot, and updates
+// their admission. Otherwise, it marks their resize as pending.
+func (s *Scheduler) resize(ctx context.Context, requests []workload.Info, snapshot *cache.Snapshot) {
+	for i := range requests {
+		resized := &requests[i]
+		log := ctrl.LoggerFrom(ctx).WithValues("workload", klog.KObj(resized.Obj), "clusterQueue", klog.KRef("", resized.ClusterQueue))
+		ctx := ctrl.LoggerInto(ctx, log)
+		cq := snapshot.ClusterQueues[resized.ClusterQueue]
+		if cq == nil {
+			continue
+		}
+		current, found := cq.Workloads[workload.Key(resized.Obj)]
+		if !found {
+			log.V(3).Info("Skipping the resize of a workload without quota reserved")
+			continue
+		}
+
+		currentUsage := current.FlavorResourceUsage()
+		growth := make(resources.FlavorResourceQuantities)
+		for fr, q := range resized.FlavorResourceUsage() {
+			if delta := q - currentUsage[fr]; delta > 0 {
+				growth[fr] = delta
+			}
+		}
+		tenantGrowth := growth.ByResource()
+		fits := cq.Fits(growth)
+		if tenant, _ := exceededTenantLimits(snapshot, resized.Obj, tenantGrowth); tenant != nil {
+			fits = false
+		}
+		if flavor, _ := exceededFlavorCaps(snapshot, growth); flavor != "" {
+			fits = false
+		}
+		if !fits {
+			s.markResizePending(ctx, resized.Obj, current.Obj.Status.Admission)
+			continue
+		}
+
+		addSnapshotUsage(snapshot, cq, resized.Obj, growth, tenantGrowth)
+		newWorkload := resized.Obj.DeepCopy()
+		workload.SetResizedCondition(newWorkload)
+		if err := s.cache.ResizeWorkload(current.Obj, newWorkload); err != nil {
+			log.Error(err, "Failed to resize the workload in the cache")
+			continue
+		}
+		s.admissionRoutineWrapper.Run(func() {
+			if err := workload.ApplyAdmissionStatus(ctx, s.client, newWorkload, true); err != nil {
+				// Restore the usage of the workload before the resize.
+				s.cache.AddOrUpdateWorkload(current.Obj)
+				if !errors.IsNotFound(err) {
+					log.Error(err, "Could not resize the workload")
+				}
+				return
+			}
+			log.V(2).Info("Workload resized")
+			s.recorder.Eventf(newWorkload, corev1.EventTypeNormal, "Resized", "Resized in ClusterQueue %v", resized.ClusterQueue)
+		})
+	}
+}
+
+// markResizePending marks the resize of the workload as pending, keeping its
+// current admission, if it isn't marked already.
+func (s *Scheduler) markResizePending(ctx context.Context, resized *kueue.Workload, admission *kueue.Admission) {
+	wl := resized.DeepCopy()
+	wl.Status.Admission = admission.DeepCopy()
+	if !workload.SetResizePendingCondition(wl) {
+		return
+	}
+	log := ctrl.LoggerFrom(ctx)
+	log.V(3).Info("Workload resize is pending, the additional quota is not available")
+	s.admissionRoutineWrapper.Run(func() {
+		if err := workload.ApplyAdmissionStatus(ctx, s.client, wl, true); err != nil {
+			if !errors.IsNotFound(err) {
+				log.Error(err, "Could not mark the resize of the workload as pending")
+			}
+			return
+		}
+		condition := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadResizePending)
+		s.recorder.Event(wl, corev1.EventTypeNormal, kueue.WorkloadResizePending, condition.Message)
+	})
+}
+
+// recordShadowPreemptions records the preemptions that the entry requires,
+// without issuing them, because its ClusterQueue is in shadow mode.
+func (s *Scheduler) recordShadowPreemptions(ctx context.Context, e *entry) {
+	log := ctrl.LoggerFrom(ctx)
+	for _, target := range e.preemptionTargets {
+		log.V(3).Info("Would preempt workload in shadow mode", "targetWorkload", klog.KObj(target.WorkloadInfo.Obj), "reason", target.Reason)
+		s.recorder.Eventf(target.WorkloadInfo.Obj, corev1.EventTypeNormal, "WouldBePreempted", "Would be preempted by workload %s in shadow mode, reason: %s", klog.KObj(e.Obj), target.Reason)
+		metrics.ReportShadowPreemption(e.ClusterQueue, target.Reason)
+	}
+	s.recorder.Eventf(e.Obj, corev1.EventTypeNormal, "WouldPreempt", "Would preempt %d workload(s) in shadow mode", len(e.preemptionTargets))
+	e.inadmissibleMsg += fmt.Sprintf(". Would preempt %d workload(s) in shadow mode", len(e.preemptionTargets))
+}
+
 type entryStatus string
 
 const (
@@ -324,11 +641,29 @@ type entry struct {
 	workload.Info
 	dominantResourceShare int
 	dominantResourceName  corev1.ResourceName
-	assignment            flavorassigner.Assignment
-	status                entryStatus
-	inadmissibleMsg       string
-	requeueReason         queue.RequeueReason
-	preemptionTargets     []*preemption.Target
+	// reclaimDebt is set when the ClusterQueue has reclaim debt for the
+	// resources requested by the workload.
+	reclaimDebt       bool
+	assignment        flavorassigner.Assignment
+	status            entryStatus
+	inadmissibleMsg   string
+	pendingReasons    []kueue.PendingReason
+	requeueReason     queue.RequeueReason
+	preemptionTargets []*preemption.Target
+	// clusterQueueUsage and clusterQueueShare are the usage and the dominant
+	// resource share of the ClusterQueue when the entry was processed. They
+	// are only populated when the scheduling decisions are audited.
+	clusterQueueUsage resources.FlavorResourceQuantities
+	clusterQueueShare int
+	// evaluationTime is the time spent nominating and processing the entry
+	// in the scheduling cycle.
+	evaluationTime time.Duration
+	// deactivate is set when the workload violates an admission policy with
+	// the Deactivate action.
+	deactivate bool
+	// tenantShare is the highest weighted share of the Tenants of the
+	// workload's LocalQueue.
+	tenantShare int
 }
 
 // netUsage returns how much capacity this entry will require from the ClusterQueue/Cohort.
@@ -354,42 +689,343 @@ func (e *entry) netUsage() resources.FlavorResourceQuantities {
 // nominate returns the workloads with their requirements (resource flavors, borrowing) if
 // they were admitted by the clusterQueues in the snapshot.
 func (s *Scheduler) nominate(ctx context.Context, workloads []workload.Info, snap *cache.Snapshot) []entry {
-	log := ctrl.LoggerFrom(ctx)
+	if !features.Enabled(features.ParallelCohortScheduling) {
+		entries := make([]entry, 0, len(workloads))
+		for _, w := range workloads {
+			if e, ok := s.nominateWorkload(ctx, w, snap); ok {
+				entries = append(entries, e)
+			}
+		}
+		return entries
+	}
+
+	// The workloads of different groups don't share quota, nor topology
+	// snapshots, so they are nominated concurrently.
+	groups := nominationGroups(workloads, snap)
+	nominated := make([]*entry, len(workloads))
+	_ = parallelize.Until(ctx, len(groups), func(g int) error {
+		for _, i := range groups[g] {
+			if e, ok := s.nominateWorkload(ctx, workloads[i], snap); ok {
+				nominated[i] = &e
+			}
+		}
+		return nil
+	})
 	entries := make([]entry, 0, len(workloads))
-	for _, w := range workloads {
-		log := log.WithValues("workload", klog.KObj(w.Obj), "clusterQueue", klog.KRef("", w.ClusterQueue))
-		cq := snap.ClusterQueues[w.ClusterQueue]
-		ns := corev1.Namespace{}
-		e := entry{Info: w}
-		if s.cache.IsAssumedOrAdmittedWorkload(w) {
-			log.Info("Workload skipped from admission because it's already assumed or admitted", "workload", klog.KObj(w.Obj))
+	for _, e := range nominated {
+		if e != nil {
+			entries = append(entries, *e)
+		}
+	}
+	return entries
+}
+
+// nominateWorkload calculates the requirements for admitting the workload.
+// It returns false if the workload is skipped from admission.
+func (s *Scheduler) nominateWorkload(ctx context.Context, w workload.Info, snap *cache.Snapshot) (entry, bool) {
+	log := ctrl.LoggerFrom(ctx).WithValues("workload", klog.KObj(w.Obj), "clusterQueue", klog.KRef("", w.ClusterQueue))
+	cq := snap.ClusterQueues[w.ClusterQueue]
+	ns := corev1.Namespace{}
+	e := entry{Info: w}
+	e.Info.WaitingFlavors = nil
+	start := s.clock.Now()
+	if s.cache.IsAssumedOrAdmittedWorkload(w) {
+		log.Info("Workload skipped from admission because it's already assumed or admitted", "workload", klog.KObj(w.Obj))
+		return e, false
+	} else if workload.HasRetryChecks(w.Obj) || workload.HasRejectedChecks(w.Obj) {
+		e.inadmissibleMsg = "The workload has failed admission checks"
+		e.pendingReasons = failedAdmissionCheckPendingReasons(w.Obj)
+	} else if snap.InactiveClusterQueueSets.Has(w.ClusterQueue) {
+		e.inadmissibleMsg = fmt.Sprintf("ClusterQueue %s is inactive", w.ClusterQueue)
+	} else if cq == nil {
+		e.inadmissibleMsg = fmt.Sprintf("ClusterQueue %s not found", w.ClusterQueue)
+	} else if snap.ClosedBySchedule(cq) {
+		e.inadmissibleMsg = fmt.Sprintf("ClusterQueue %s is closed by the Schedule %s", w.ClusterQueue, cq.Schedule)
+	} else if err := s.client.Get(ctx, types.NamespacedName{Name: w.Obj.Namespace}, &ns); err != nil {
+		e.inadmissibleMsg = fmt.Sprintf("Could not obtain workload namespace: %v", err)
+	} else if !cq.NamespaceSelector.Matches(labels.Set(ns.Labels)) {
+		e.inadmissibleMsg = "Workload namespace doesn't match ClusterQueue selector"
+		e.requeueReason = queue.RequeueReasonNamespaceMismatch
+	} else if cq.LocalQueueNamespaceSelector != nil && !cq.LocalQueueNamespaceSelector.Matches(labels.Set(ns.Labels)) {
+		e.inadmissibleMsg = "LocalQueue namespace doesn't match the LocalQueue selector of the ClusterQueue"
+		e.requeueReason = queue.RequeueReasonNamespaceMismatch
+	} else if exceeded := quotaShrinkHeld(cq, totalRequests(&w)); len(exceeded) > 0 {
+		e.inadmissibleMsg = fmt.Sprintf("ClusterQueue %s exceeds its quota for %s", w.ClusterQueue, formatFlavorResources(exceeded))
+	} else if v := admissionpolicy.Evaluate(w.Obj, s.admissionPolicies, cq.AdmissionPolicies); v != nil {
+		e.inadmissibleMsg = v.Message
+		e.deactivate = v.Action == kueue.AdmissionPolicyDeactivate
+	} else if budget := snap.ExceededBudget(workload.QueueKey(w.Obj)); budget != nil {
+		e.inadmissibleMsg = fmt.Sprintf("The Budget %s of the LocalQueue is exceeded", budget.Name)
+	} else if tenant, exceeded := exceededTenantLimits(snap, w.Obj, totalRequests(&w)); tenant != nil {
+		e.inadmissibleMsg = fmt.Sprintf("The workload exceeds the usage limits of the Tenant %s for %s", tenant.Name, formatResourceNames(exceeded))
+	} else if err := s.validateResources(&w); err != nil {
+		e.inadmissibleMsg = err.Error()
+	} else if err := s.validateLimitRange(ctx, &w); err != nil {
+		e.inadmissibleMsg = err.Error()
+	} else {
+		_, span := tracing.StartWorkloadSpan(ctx, w.Obj, tracing.SpanFlavorAssignment, trace.WithAttributes(tracing.ClusterQueueKey.String(w.ClusterQueue)))
+		e.assignment, e.preemptionTargets = s.getAssignments(log, &e.Info, snap)
+		span.SetAttributes(
+			tracing.AssignmentKey.String(e.assignment.RepresentativeMode().String()),
+			tracing.BorrowingKey.Bool(e.assignment.Borrowing),
+		)
+		span.End()
+		e.inadmissibleMsg = e.assignment.Message()
+		e.pendingReasons = e.assignment.PendingReasons()
+		e.Info.LastAssignment = &e.assignment.LastState
+		if features.Enabled(features.FlavorAwareRequeue) && e.assignment.RepresentativeMode() != flavorassigner.Fit {
+			e.Info.WaitingFlavors = waitingFlavors(&e.Info, cq)
+		}
+		if s.fairSharing.Load().Enable && e.assignment.RepresentativeMode() != flavorassigner.NoFit {
+			e.dominantResourceShare, e.dominantResourceName = cq.DominantResourceShareWith(e.assignment.TotalRequestsFor(&w))
+			e.tenantShare = tenantShare(snap, w.Obj)
+		}
+		if features.Enabled(features.ReclaimDebtAccounting) && e.assignment.RepresentativeMode() != flavorassigner.NoFit {
+			e.reclaimDebt = cq.HasReclaimDebt(e.assignment.TotalRequestsFor(&w))
+		}
+	}
+	e.evaluationTime = s.clock.Since(start)
+	return e, true
+}
+
+// nominationGroups partitions the indexes of the workloads in groups which
+// can be nominated concurrently. The workloads of the ClusterQueues in the
+// same cohort tree are in the same group, as they share quota. The workloads
+// of all the cohort trees using topology aware flavors are in the same group,
+// as the topology snapshots are shared by the ClusterQueues.
+func nominationGroups(workloads []workload.Info, snap *cache.Snapshot) [][]int {
+	keys := make([]string, len(workloads))
+	tasKeys := sets.New[string]()
+	for i := range workloads {
+		cq := snap.ClusterQueues[workloads[i].ClusterQueue]
+		switch {
+		case cq == nil:
+			keys[i] = "clusterqueue/" + workloads[i].ClusterQueue
+		case cq.HasParent():
+			keys[i] = "cohort/" + cq.Parent().Root().Name
+		default:
+			keys[i] = "clusterqueue/" + cq.Name
+		}
+		if cq != nil && len(cq.TASFlavors) > 0 {
+			tasKeys.Insert(keys[i])
+		}
+	}
+	var groups [][]int
+	groupIndex := make(map[string]int)
+	for i, key := range keys {
+		if tasKeys.Has(key) {
+			key = "topology"
+		}
+		g, found := groupIndex[key]
+		if !found {
+			g = len(groups)
+			groupIndex[key] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
+
+// failedAdmissionCheckPendingReasons returns the pending reasons for the
+// admission checks of the workload which are in the Retry or Rejected state.
+func failedAdmissionCheckPendingReasons(wl *kueue.Workload) []kueue.PendingReason {
+	var reasons []kueue.PendingReason
+	for _, check := range wl.Status.AdmissionChecks {
+		if check.State == kueue.CheckStateRetry || check.State == kueue.CheckStateRejected {
+			reasons = append(reasons, kueue.PendingReason{
+				Reason:         kueue.PendingReasonAdmissionCheck,
+				AdmissionCheck: check.Name,
+				Message:        check.Message,
+			})
+		}
+	}
+	return reasons
+}
+
+// quotaShrinkHeld returns the FlavorResources, of the resources requested by
+// the workload, for which the ClusterQueue exceeds its quota, if its
+// quotaShrinkPolicy holds the admission in that case. The pods are requested
+// by all the workloads.
+func quotaShrinkHeld(cq *cache.ClusterQueueSnapshot, requests resources.Requests) []resources.FlavorResource {
+	switch cq.QuotaShrinkAction {
+	case kueue.QuotaShrinkHoldAdmission, kueue.QuotaShrinkEvictLowestPriority:
+		var held []resources.FlavorResource
+		for _, fr := range cq.ExceededQuota() {
+			if fr.Resource == corev1.ResourcePods || requests[fr.Resource] > 0 {
+				held = append(held, fr)
+			}
+		}
+		return held
+	}
+	return nil
+}
+
+// exceededTenantLimits returns the first Tenant of the workload's LocalQueue
+// whose limits would be exceeded by the usage, and the exceeded resources.
+func exceededTenantLimits(snap *cache.Snapshot, wl *kueue.Workload, usage resources.Requests) (*cache.TenantSnapshot, []corev1.ResourceName) {
+	for _, tenant := range snap.LocalQueueTenants[workload.QueueKey(wl)] {
+		if exceeded := tenant.ExceededLimits(usage); len(exceeded) > 0 {
+			return tenant, exceeded
+		}
+	}
+	return nil, nil
+}
+
+// exceededFlavorCaps returns the first flavor, by name, whose cap would be
+// exceeded by the usage, and the exceeded resources.
+func exceededFlavorCaps(snap *cache.Snapshot, usage resources.FlavorResourceQuantities) (kueue.ResourceFlavorReference, []corev1.ResourceName) {
+	if len(snap.FlavorCaps) == 0 {
+		return "", nil
+	}
+	byFlavor := usage.ByFlavor()
+	flavors := slices.Sorted(maps.Keys(byFlavor))
+	for _, flavor := range flavors {
+		if flavorCap := snap.FlavorCaps[flavor]; flavorCap != nil {
+			if exceeded := flavorCap.ExceededLimits(byFlavor[flavor]); len(exceeded) > 0 {
+				return flavor, exceeded
+			}
+		}
+	}
+	return "", nil
+}
+
+// tenantShare returns the highest weighted share of the Tenants of the
+// workload's LocalQueue.
+func tenantShare(snap *cache.Snapshot, wl *kueue.Workload) int {
+	share := 0
+	for _, tenant := range snap.LocalQueueTenants[workload.QueueKey(wl)] {
+		share = max(share, tenant.WeightedShare())
+	}
+	return share
+}
+
+// totalRequests returns the requests of all the pods of the workload.
+func totalRequests(wl *workload.Info) resources.Requests {
+	total := resources.Requests{}
+	for _, ps := range wl.TotalRequests {
+		total.Add(ps.Requests)
+	}
+	return total
+}
+
+// waitingFlavors returns the flavors which could be assigned to the workload in
+// the ClusterQueue, for the resources it requests.
+func waitingFlavors(wl *workload.Info, cq *cache.ClusterQueueSnapshot) sets.Set[kueue.ResourceFlavorReference] {
+	flavors := sets.New[kueue.ResourceFlavorReference]()
+	if rg := cq.RGByResource(corev1.ResourcePods); rg != nil {
+		flavors.Insert(rg.Flavors...)
+	}
+	for _, ps := range wl.TotalRequests {
+		for res := range ps.Requests {
+			if rg := cq.RGByResource(res); rg != nil {
+				flavors.Insert(rg.Flavors...)
+			}
+		}
+	}
+	return flavors
+}
+
+// strictFIFOHeads returns, by Cohort ordering the workloads of all its
+// ClusterQueues together in strict FIFO, the entry which is first in the
+// order of the queues: by priority, then by timestamp and then by the
+// tie-breakers.
+func (s *Scheduler) strictFIFOHeads(entries []entry, snapshot *cache.Snapshot) map[string]*entry {
+	heads := make(map[string]*entry)
+	for i := range entries {
+		e := &entries[i]
+		cq := snapshot.ClusterQueues[e.ClusterQueue]
+		if cq == nil {
 			continue
-		} else if workload.HasRetryChecks(w.Obj) || workload.HasRejectedChecks(w.Obj) {
-			e.inadmissibleMsg = "The workload has failed admission checks"
-		} else if snap.InactiveClusterQueueSets.Has(w.ClusterQueue) {
-			e.inadmissibleMsg = fmt.Sprintf("ClusterQueue %s is inactive", w.ClusterQueue)
-		} else if cq == nil {
-			e.inadmissibleMsg = fmt.Sprintf("ClusterQueue %s not found", w.ClusterQueue)
-		} else if err := s.client.Get(ctx, types.NamespacedName{Name: w.Obj.Namespace}, &ns); err != nil {
-			e.inadmissibleMsg = fmt.Sprintf("Could not obtain workload namespace: %v", err)
-		} else if !cq.NamespaceSelector.Matches(labels.Set(ns.Labels)) {
-			e.inadmissibleMsg = "Workload namespace doesn't match ClusterQueue selector"
-			e.requeueReason = queue.RequeueReasonNamespaceMismatch
-		} else if err := s.validateResources(&w); err != nil {
-			e.inadmissibleMsg = err.Error()
-		} else if err := s.validateLimitRange(ctx, &w); err != nil {
-			e.inadmissibleMsg = err.Error()
-		} else {
-			e.assignment, e.preemptionTargets = s.getAssignments(log, &e.Info, snap)
-			e.inadmissibleMsg = e.assignment.Message()
-			e.Info.LastAssignment = &e.assignment.LastState
-			if s.fairSharing.Enable && e.assignment.RepresentativeMode() != flavorassigner.NoFit {
-				e.dominantResourceShare, e.dominantResourceName = cq.DominantResourceShareWith(e.assignment.TotalRequestsFor(&w))
+		}
+		cohort := cq.StrictFIFOCohort()
+		if cohort == nil {
+			continue
+		}
+		if head, found := heads[cohort.Name]; !found || s.queueOrderLess(e, head) {
+			heads[cohort.Name] = e
+		}
+	}
+	return heads
+}
+
+func (s *Scheduler) queueOrderLess(a, b *entry) bool {
+	if p1, p2 := priority.Priority(a.Obj), priority.Priority(b.Obj); p1 != p2 {
+		return p1 > p2
+	}
+	tA := s.workloadOrdering.GetQueueOrderTimestamp(a.Obj)
+	tB := s.workloadOrdering.GetQueueOrderTimestamp(b.Obj)
+	if !tA.Equal(tB) {
+		return tA.Before(tB)
+	}
+	return s.workloadOrdering.CompareTies(&a.Info, &b.Info) < 0
+}
+
+// forgetStaleReclaimDebt drops the reclaim debt of the ClusterQueues which
+// have no pending workloads to compensate it with, or which weren't preempted
+// for reclaimDebtExpiration, so that they aren't prioritized indefinitely.
+func (s *Scheduler) forgetStaleReclaimDebt(heads []workload.Info, snapshot *cache.Snapshot) {
+	withHeads := sets.New[string]()
+	for i := range heads {
+		withHeads.Insert(heads[i].ClusterQueue)
+	}
+	for cqName, cq := range snapshot.ClusterQueues {
+		if len(cq.ReclaimDebt) == 0 {
+			continue
+		}
+		pending := withHeads.Has(cqName) || s.queues.PendingInClusterQueue(cqName) > 0
+		if !pending || s.clock.Since(cq.ReclaimDebtRecorded) > reclaimDebtExpiration {
+			s.cache.ForgetReclaimDebt(cqName)
+			cq.ReclaimDebt = nil
+		}
+	}
+}
+
+// reclaimDebt returns, by ClusterQueue, the quota within the nominal quota
+// which the targets of the other ClusterQueues lose with their preemption.
+// The ClusterQueues borrowing only transiently, when the preemptions are
+// issued, can lose quota they are entitled to, which they are compensated
+// for in the next scheduling cycles.
+func reclaimDebt(snapshot *cache.Snapshot, preemptorCQ string, targets []*preemption.Target) map[string]resources.FlavorResourceQuantities {
+	debt := make(map[string]resources.FlavorResourceQuantities)
+	removed := make(map[string]resources.FlavorResourceQuantities)
+	for _, target := range targets {
+		cqName := target.WorkloadInfo.ClusterQueue
+		cq := snapshot.ClusterQueues[cqName]
+		if cqName == preemptorCQ || cq == nil {
+			continue
+		}
+		if removed[cqName] == nil {
+			removed[cqName] = make(resources.FlavorResourceQuantities)
+		}
+		for fr, q := range target.WorkloadInfo.FlavorResourceUsage() {
+			remaining := cq.ResourceNode.Usage[fr] - removed[cqName][fr] - q
+			removed[cqName][fr] += q
+			if lost := min(q, cq.QuotaFor(fr).Nominal-remaining); lost > 0 {
+				if debt[cqName] == nil {
+					debt[cqName] = make(resources.FlavorResourceQuantities)
+				}
+				debt[cqName][fr] += lost
 			}
 		}
-		entries = append(entries, e)
 	}
-	return entries
+	return debt
+}
+
+func formatFlavorResources(frs []resources.FlavorResource) string {
+	parts := make([]string, len(frs))
+	for i, fr := range frs {
+		parts[i] = fmt.Sprintf("%s in flavor %s", fr.Resource, fr.Flavor)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatResourceNames(names []corev1.ResourceName) string {
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = string(name)
+	}
+	return strings.Join(parts, ", ")
 }
 
 // resourcesToReserve calculates how much of the available resources in cq/cohort assignment should be reserved.
@@ -418,9 +1054,32 @@ type partialAssignment struct {
 	preemptionTargets []*preemption.Target
 }
 
+// getAssignments returns the assignment and the preemption targets of the
+// workload. The assignments of the workloads which can't be admitted are
+// reused for the identical workloads, while the cache is not modified, if the
+// FlavorAssignmentCache feature is enabled.
 func (s *Scheduler) getAssignments(log logr.Logger, wl *workload.Info, snap *cache.Snapshot) (flavorassigner.Assignment, []*preemption.Target) {
+	if s.assignments == nil {
+		return s.computeAssignments(log, wl, snap)
+	}
+	key, cacheable := assignmentCacheKey(wl, snap.ClusterQueues[wl.ClusterQueue], s.fairSharing.Load().Enable)
+	if !cacheable {
+		return s.computeAssignments(log, wl, snap)
+	}
+	if assignment, found := s.assignments.get(snap.Epoch, key); found {
+		log.V(5).Info("Reusing the flavor assignment of an identical workload")
+		return assignment, nil
+	}
+	assignment, targets := s.computeAssignments(log, wl, snap)
+	if assignment.RepresentativeMode() != flavorassigner.Fit && len(targets) == 0 {
+		s.assignments.set(snap.Epoch, key, assignment)
+	}
+	return assignment, targets
+}
+
+func (s *Scheduler) computeAssignments(log logr.Logger, wl *workload.Info, snap *cache.Snapshot) (flavorassigner.Assignment, []*preemption.Target) {
 	cq := snap.ClusterQueues[wl.ClusterQueue]
-	flvAssigner := flavorassigner.New(wl, cq, snap.ResourceFlavors, s.fairSharing.Enable, preemption.NewOracle(s.preemptor, snap))
+	flvAssigner := flavorassigner.New(wl, cq, snap.ResourceFlavors, s.fairSharing.Load().Enable, preemption.NewOracle(s.preemptor, snap))
 	fullAssignment := flvAssigner.Assign(log, nil)
 	var faPreemptionTargets []*preemption.Target
 
@@ -522,8 +1181,9 @@ func (s *Scheduler) validateLimitRange(ctx context.Context, wi *workload.Info) e
 
 // admit sets the admitting clusterQueue and flavors into the workload of
 // the entry, and asynchronously updates the object in the apiserver after
-// assuming it in the cache.
-func (s *Scheduler) admit(ctx context.Context, e *entry, cq *cache.ClusterQueueSnapshot) error {
+// assuming it in the cache. The cycleStart is the start of the scheduling
+// cycle, used to report the scheduling overhead.
+func (s *Scheduler) admit(ctx context.Context, e *entry, cq *cache.ClusterQueueSnapshot, cycleStart time.Time) error {
 	log := ctrl.LoggerFrom(ctx)
 	newWorkload := e.Obj.DeepCopy()
 	admission := &kueue.Admission{
@@ -532,7 +1192,9 @@ func (s *Scheduler) admit(ctx context.Context, e *entry, cq *cache.ClusterQueueS
 	}
 
 	workload.SetQuotaReservation(newWorkload, admission)
-	if workload.HasAllChecks(newWorkload, workload.AdmissionChecksForWorkload(log, newWorkload, cq.AdmissionChecks)) {
+	admissionChecks := workload.AdmissionChecksForWorkload(log, newWorkload, cq.AdmissionChecks)
+	skippedChecks := s.cache.SkippedAdmissionChecks(newWorkload)
+	if workload.HasAllChecks(newWorkload, admissionChecks.Difference(skippedChecks)) {
 		// sync Admitted, ignore the result since an API update is always done.
 		_ = workload.SyncAdmittedCondition(newWorkload, s.clock.Now())
 	}
@@ -541,24 +1203,45 @@ func (s *Scheduler) admit(ctx context.Context, e *entry, cq *cache.ClusterQueueS
 	}
 	e.status = assumed
 	log.V(2).Info("Workload assumed in the cache")
+	if s.statusBatcher != nil {
+		// The pending status queued in a previous cycle is stale.
+		s.statusBatcher.Forget(newWorkload)
+	}
 
+	// The cycle keeps charging its evaluation time to the entry while the
+	// admission is applied.
+	requeueEntry := *e
 	s.admissionRoutineWrapper.Run(func() {
+		_, span := tracing.StartWorkloadSpan(ctx, newWorkload, tracing.SpanQueueing,
+			trace.WithTimestamp(workload.QueuedTime(newWorkload)),
+			trace.WithAttributes(
+				tracing.ClusterQueueKey.String(string(admission.ClusterQueue)),
+				tracing.LocalQueueKey.String(newWorkload.Spec.QueueName),
+			))
 		err := s.applyAdmission(ctx, newWorkload)
+		tracing.EndSpan(span, err)
 		if err == nil {
 			waitTime := workload.QueuedWaitTime(newWorkload)
 			s.recorder.Eventf(newWorkload, corev1.EventTypeNormal, "QuotaReserved", "Quota reserved in ClusterQueue %v, wait time since queued was %.0fs", admission.ClusterQueue, waitTime.Seconds())
 			metrics.QuotaReservedWorkload(admission.ClusterQueue, waitTime)
+			priorityClass := newWorkload.Spec.PriorityClassName
+			schedulingOverhead := s.clock.Since(cycleStart)
+			metrics.ReportAdmissionLatency(admission.ClusterQueue, priorityClass, metrics.AdmissionLatencySchedulingOverhead, schedulingOverhead)
+			metrics.ReportAdmissionLatency(admission.ClusterQueue, priorityClass, metrics.AdmissionLatencyQuotaWait, max(waitTime-schedulingOverhead, 0))
+			s.queues.RecordQuotaReservation(string(admission.ClusterQueue))
 			if features.Enabled(features.LocalQueueMetrics) {
 				metrics.LocalQueueQuotaReservedWorkload(metrics.LQRefFromWorkload(newWorkload), waitTime)
 			}
 			if workload.IsAdmitted(newWorkload) {
 				s.recorder.Eventf(newWorkload, corev1.EventTypeNormal, "Admitted", "Admitted by ClusterQueue %v, wait time since reservation was 0s", admission.ClusterQueue)
 				metrics.AdmittedWorkload(admission.ClusterQueue, waitTime)
+				metrics.ReportTimeToAdmission(admission.ClusterQueue, priorityClass, waitTime)
 				if features.Enabled(features.LocalQueueMetrics) {
 					metrics.LocalQueueAdmittedWorkload(metrics.LQRefFromWorkload(newWorkload), waitTime)
 				}
 				if len(newWorkload.Status.AdmissionChecks) > 0 {
 					metrics.AdmissionChecksWaitTime(admission.ClusterQueue, 0)
+					metrics.ReportAdmissionLatency(admission.ClusterQueue, priorityClass, metrics.AdmissionLatencyAdmissionCheckWait, 0)
 					if features.Enabled(features.LocalQueueMetrics) {
 						metrics.LocalQueueAdmissionChecksWaitTime(metrics.LQRefFromWorkload(newWorkload), 0)
 					}
@@ -576,7 +1259,7 @@ func (s *Scheduler) admit(ctx context.Context, e *entry, cq *cache.ClusterQueueS
 		}
 
 		log.Error(err, errCouldNotAdmitWL)
-		s.requeueAndUpdate(ctx, *e)
+		s.requeueAndUpdate(ctx, requeueEntry)
 	})
 
 	return nil
@@ -615,12 +1298,20 @@ func (e entryOrdering) Less(i, j int) bool {
 		return !aBorrows
 	}
 
-	// 2. Fair share, if enabled.
+	// 2. Compensation of the reclaim debt, if enabled.
+	if a.reclaimDebt != b.reclaimDebt {
+		return a.reclaimDebt
+	}
+
+	// 3. Fair share, if enabled.
 	if e.enableFairSharing && a.dominantResourceShare != b.dominantResourceShare {
 		return a.dominantResourceShare < b.dominantResourceShare
 	}
+	if e.enableFairSharing && a.tenantShare != b.tenantShare {
+		return a.tenantShare < b.tenantShare
+	}
 
-	// 3. Higher priority first if not disabled.
+	// 4. Higher priority first if not disabled.
 	if features.Enabled(features.PrioritySortingWithinCohort) {
 		p1 := priority.Priority(a.Obj)
 		p2 := priority.Priority(b.Obj)
@@ -629,10 +1320,15 @@ func (e entryOrdering) Less(i, j int) bool {
 		}
 	}
 
-	// 4. FIFO.
+	// 5. FIFO.
 	aComparisonTimestamp := e.workloadOrdering.GetQueueOrderTimestamp(a.Obj)
 	bComparisonTimestamp := e.workloadOrdering.GetQueueOrderTimestamp(b.Obj)
-	return aComparisonTimestamp.Before(bComparisonTimestamp)
+	if !aComparisonTimestamp.Equal(bComparisonTimestamp) {
+		return aComparisonTimestamp.Before(bComparisonTimestamp)
+	}
+
+	// 6. Tie-breakers, if configured.
+	return e.workloadOrdering.CompareTies(&a.Info, &b.Info) < 0
 }
 
 func (s *Scheduler) requeueAndUpdate(ctx context.Context, e entry) {
@@ -649,11 +1345,22 @@ func (s *Scheduler) requeueAndUpdate(ctx context.Context, e entry) {
 		workload.AdmissionStatusPatch(e.Obj, patch, true)
 		reservationIsChanged := workload.UnsetQuotaReservationWithCondition(patch, "Pending", e.inadmissibleMsg, s.clock.Now())
 		resourceRequestsIsChanged := workload.PropagateResourceRequests(patch, &e.Info)
-		if reservationIsChanged || resourceRequestsIsChanged {
-			if err := workload.ApplyAdmissionStatusPatch(ctx, s.client, patch); err != nil {
+		pendingReasonsIsChanged := workload.SetPendingReasons(patch, e.pendingReasons)
+		deactivationIsChanged := false
+		if e.deactivate && !apimeta.IsStatusConditionTrue(e.Obj.Status.Conditions, kueue.WorkloadDeactivationTarget) {
+			workload.SetDeactivationTarget(patch, kueue.WorkloadAdmissionPolicyViolated, e.inadmissibleMsg)
+			deactivationIsChanged = true
+		}
+		if reservationIsChanged || resourceRequestsIsChanged || pendingReasonsIsChanged || deactivationIsChanged {
+			if s.statusBatcher != nil {
+				// The batched patch is applied later, so it must not overwrite
+				// the status if the workload was admitted meanwhile.
+				patch.ResourceVersion = e.Obj.ResourceVersion
+				s.statusBatcher.ApplyAdmissionStatusPatch(patch)
+			} else if err := workload.ApplyAdmissionStatusPatch(ctx, s.client, patch); err != nil {
 				log.Error(err, "Could not update Workload status")
 			}
 		}
-		s.recorder.Eventf(e.Obj, corev1.EventTypeWarning, "Pending", api.TruncateEventMessage(e.inadmissibleMsg))
+		s.pendingEvents.Eventf(e.Obj, corev1.EventTypeWarning, "Pending", api.TruncateEventMessage(e.inadmissibleMsg))
 	}
 }
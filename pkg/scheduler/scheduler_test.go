@@ -46,12 +46,15 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	tasindexer "sigs.k8s.io/kueue/pkg/controller/tas/indexer"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/scheduler/audit"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
 	"sigs.k8s.io/kueue/pkg/util/routine"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
@@ -78,6 +81,7 @@ func TestSchedule(t *testing.T) {
 		utiltesting.MakeResourceFlavor("on-demand").Obj(),
 		utiltesting.MakeResourceFlavor("spot").Obj(),
 		utiltesting.MakeResourceFlavor("model-a").Obj(),
+		utiltesting.MakeResourceFlavor("karpenter").NodeLabel(constants.KarpenterNodePoolLabel, "general").Obj(),
 	}
 	clusterQueues := []kueue.ClusterQueue{
 		*utiltesting.MakeClusterQueue("sales").
@@ -250,6 +254,13 @@ func TestSchedule(t *testing.T) {
 		// additional*Queues can hold any extra queues needed by the tc
 		additionalClusterQueues []kueue.ClusterQueue
 		additionalLocalQueues   []kueue.LocalQueue
+		tenants                 []kueuealpha.Tenant
+		budgets                 []kueuealpha.Budget
+		schedules               []kueuealpha.Schedule
+		admissionChecks         []kueue.AdmissionCheck
+		workloadTemplates       []kueuealpha.WorkloadTemplate
+		// nodePoolLimits are the limits of the Karpenter NodePools, by name.
+		nodePoolLimits map[string]corev1.ResourceList
 
 		// wantAssignments is a summary of all the admissions in the cache after this cycle.
 		wantAssignments map[string]kueue.Admission
@@ -269,6 +280,12 @@ func TestSchedule(t *testing.T) {
 		wantEvents []utiltesting.EventRecord
 
 		wantSkippedPreemptions map[string]int
+		// wantCohortEvaluated and wantCohortSkipped are the number of workloads
+		// evaluated and skipped in the cycle, per cohort.
+		wantCohortEvaluated map[string]int
+		wantCohortSkipped   map[string]int
+		// wantDecisions ignored if empty, the Time, Cycle, UID, QueueOrderTimestamp and Message are ignored
+		wantDecisions []audit.Decision
 	}{
 		"workload fits in single clusterQueue, with check state ready": {
 			workloads: []kueue.Workload{
@@ -443,6 +460,16 @@ func TestSchedule(t *testing.T) {
 			wantLeft: map[string][]string{
 				"sales": {"sales/new"},
 			},
+			wantDecisions: []audit.Decision{
+				{
+					Workload:          "sales/new",
+					ClusterQueue:      "sales",
+					Result:            audit.Inadmissible,
+					Mode:              "Preempt",
+					Usage:             audit.Quantities{"default": {"cpu": "11"}},
+					ClusterQueueUsage: audit.Quantities{"default": {"cpu": "40"}},
+				},
+			},
 		},
 		"failed to match clusterQueue selector": {
 			workloads: []kueue.Workload{
@@ -756,6 +783,372 @@ func TestSchedule(t *testing.T) {
 				"lend/b",
 			},
 		},
+		"quota shrink policy holds admission while the quota is exceeded": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("shrunk").
+					QuotaShrinkPolicy(kueue.QuotaShrinkHoldAdmission, nil).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "2").
+						Resource(corev1.ResourceMemory, "10Gi").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("shrunk", "sales").ClusterQueue("shrunk").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("admitted", "sales").
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuota(utiltesting.MakeAdmission("shrunk").Assignment(corev1.ResourceCPU, "default", "4000m").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("pending", "sales").
+					Queue("shrunk").
+					Request(corev1.ResourceCPU, "1").
+					Request(corev1.ResourceMemory, "1Gi").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/admitted": *utiltesting.MakeAdmission("shrunk").Assignment(corev1.ResourceCPU, "default", "4000m").Obj(),
+			},
+			wantInadmissibleLeft: map[string][]string{
+				"shrunk": {"sales/pending"},
+			},
+		},
+		"quota shrink policy admits the workloads not requesting the exceeded resources": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("shrunk").
+					QuotaShrinkPolicy(kueue.QuotaShrinkHoldAdmission, nil).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "2").
+						Resource(corev1.ResourceMemory, "10Gi").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("shrunk", "sales").ClusterQueue("shrunk").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("admitted", "sales").
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuota(utiltesting.MakeAdmission("shrunk").Assignment(corev1.ResourceCPU, "default", "4000m").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("pending", "sales").
+					Queue("shrunk").
+					Request(corev1.ResourceMemory, "1Gi").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/admitted": *utiltesting.MakeAdmission("shrunk").Assignment(corev1.ResourceCPU, "default", "4000m").Obj(),
+				"sales/pending":  *utiltesting.MakeAdmission("shrunk").Assignment(corev1.ResourceMemory, "default", "1Gi").Obj(),
+			},
+			wantScheduled: []string{"sales/pending"},
+		},
+		"admission policy keeps the violating workloads pending": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("policies").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					AdmissionPolicies(kueue.AdmissionPolicy{
+						Name:       "team-label",
+						Expression: "has(workload.metadata.labels.team)",
+					}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("policies", "sales").ClusterQueue("policies").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("unlabeled", "sales").
+					Queue("policies").
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			wantInadmissibleLeft: map[string][]string{
+				"policies": {"sales/unlabeled"},
+			},
+		},
+		"LocalQueue namespace not selected by the ClusterQueue": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("eng-only").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					LocalQueueNamespaceSelector(&metav1.LabelSelector{
+						MatchLabels: map[string]string{"dep": "eng"},
+					}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("eng-only", "sales").ClusterQueue("eng-only").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foreign", "sales").
+					Queue("eng-only").
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			wantInadmissibleLeft: map[string][]string{
+				"eng-only": {"sales/foreign"},
+			},
+		},
+		"tenant usage limits across ClusterQueues": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("team-a").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("team-b").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("team-c").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("team-a", "sales").ClusterQueue("team-a").Obj(),
+				*utiltesting.MakeLocalQueue("team-b", "sales").ClusterQueue("team-b").Obj(),
+				*utiltesting.MakeLocalQueue("team-c", "sales").ClusterQueue("team-c").Obj(),
+			},
+			tenants: []kueuealpha.Tenant{
+				*utiltesting.MakeTenant("team").
+					LocalQueue("sales", "team-a").
+					LocalQueue("sales", "team-b").
+					LocalQueue("sales", "team-c").
+					UsageLimit(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("first", "sales").
+					Queue("team-a").
+					Creation(now.Add(-time.Second)).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+				*utiltesting.MakeWorkload("second", "sales").
+					Queue("team-b").
+					Creation(now).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+				*utiltesting.MakeWorkload("too-big", "sales").
+					Queue("team-c").
+					Request(corev1.ResourceCPU, "4").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/first": *utiltesting.MakeAdmission("team-a").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+			},
+			wantScheduled: []string{"sales/first"},
+			wantLeft: map[string][]string{
+				// Skipped, as the first workload took the usage left in the Tenant.
+				"team-b": {"sales/second"},
+			},
+			wantInadmissibleLeft: map[string][]string{
+				"team-c": {"sales/too-big"},
+			},
+		},
+		"exceeded budget stops the admission": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("team-a").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("team-b").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("team-a", "sales").ClusterQueue("team-a").Obj(),
+				*utiltesting.MakeLocalQueue("team-b", "sales").ClusterQueue("team-b").Obj(),
+			},
+			budgets: []kueuealpha.Budget{
+				*utiltesting.MakeBudget("team-a", "sales").
+					LocalQueues("team-a").
+					ResourceHours(corev1.ResourceCPU, "100").
+					EnforcementAction(kueuealpha.BudgetStopAdmission).
+					Condition(metav1.Condition{Type: kueuealpha.BudgetExceeded, Status: metav1.ConditionTrue, Reason: "LimitsReached"}).
+					Obj(),
+				*utiltesting.MakeBudget("warn-only", "sales").
+					ResourceHours(corev1.ResourceCPU, "100").
+					Condition(metav1.Condition{Type: kueuealpha.BudgetExceeded, Status: metav1.ConditionTrue, Reason: "LimitsReached"}).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "sales").
+					Queue("team-a").
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+				*utiltesting.MakeWorkload("b", "sales").
+					Queue("team-b").
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/b": *utiltesting.MakeAdmission("team-b").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+			},
+			wantScheduled: []string{"sales/b"},
+			wantInadmissibleLeft: map[string][]string{
+				"team-a": {"sales/a"},
+			},
+		},
+		"closed schedule holds the workloads of its ClusterQueues": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("team-a").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Schedule("business-hours").
+					Obj(),
+				*utiltesting.MakeClusterQueue("team-b").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Schedule("nights").
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("team-a", "sales").ClusterQueue("team-a").Obj(),
+				*utiltesting.MakeLocalQueue("team-b", "sales").ClusterQueue("team-b").Obj(),
+			},
+			schedules: []kueuealpha.Schedule{
+				*utiltesting.MakeSchedule("business-hours").Window("09:00", "17:00").Obj(),
+				*utiltesting.MakeSchedule("nights").Window("22:00", "06:00").Open(true).Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "sales").
+					Queue("team-a").
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+				*utiltesting.MakeWorkload("b", "sales").
+					Queue("team-b").
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/b": *utiltesting.MakeAdmission("team-b").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+			},
+			wantScheduled: []string{"sales/b"},
+			wantInadmissibleLeft: map[string][]string{
+				"team-a": {"sales/a"},
+			},
+		},
+		"workload matching its template skips the admission check": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("approved").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					AdmissionChecks("approval").
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("approved", "sales").ClusterQueue("approved").Obj(),
+			},
+			admissionChecks: []kueue.AdmissionCheck{
+				*utiltesting.MakeAdmissionCheck("approval").Active(metav1.ConditionTrue).Obj(),
+			},
+			workloadTemplates: []kueuealpha.WorkloadTemplate{
+				*utiltesting.MakeWorkloadTemplate("small").
+					PodSet(kueue.DefaultPodSetName, 1, corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}).
+					SkippedAdmissionChecks("approval").
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "sales").
+					Queue("approved").
+					Label(controllerconsts.WorkloadTemplateLabel, "small").
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/a": *utiltesting.MakeAdmission("approved").Assignment(corev1.ResourceCPU, "default", "2000m").Obj(),
+			},
+			wantScheduled: []string{"sales/a"},
+			workloadCmpOpts: []cmp.Option{
+				cmpopts.EquateEmpty(),
+				cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
+				cmpopts.IgnoreFields(kueue.Workload{}, "TypeMeta", "ObjectMeta.ResourceVersion"),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "sales").
+					Queue("approved").
+					Label(controllerconsts.WorkloadTemplateLabel, "small").
+					Request(corev1.ResourceCPU, "2").
+					Admission(utiltesting.MakeAdmission("approved").Assignment(corev1.ResourceCPU, "default", "2000m").Obj()).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadQuotaReserved,
+						Message: "Quota reserved in ClusterQueue approved",
+					}).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadAdmitted,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadAdmitted,
+						Message: "The workload is admitted",
+					}).
+					Obj(),
+			},
+		},
+		"karpenter nodepool limits across ClusterQueues": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("team-a").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("karpenter").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("team-b").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("karpenter").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("team-c").
+					NamespaceSelector(&metav1.LabelSelector{}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("karpenter").
+						Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("team-a", "sales").ClusterQueue("team-a").Obj(),
+				*utiltesting.MakeLocalQueue("team-b", "sales").ClusterQueue("team-b").Obj(),
+				*utiltesting.MakeLocalQueue("team-c", "sales").ClusterQueue("team-c").Obj(),
+			},
+			nodePoolLimits: map[string]corev1.ResourceList{
+				"general": {corev1.ResourceCPU: resource.MustParse("3")},
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("first", "sales").
+					Queue("team-a").
+					Creation(now.Add(-time.Second)).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+				*utiltesting.MakeWorkload("second", "sales").
+					Queue("team-b").
+					Creation(now).
+					Request(corev1.ResourceCPU, "2").
+					Obj(),
+				*utiltesting.MakeWorkload("too-big", "sales").
+					Queue("team-c").
+					Request(corev1.ResourceCPU, "4").
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/first": *utiltesting.MakeAdmission("team-a").Assignment(corev1.ResourceCPU, "karpenter", "2000m").Obj(),
+			},
+			wantScheduled: []string{"sales/first"},
+			wantLeft: map[string][]string{
+				// Skipped, as the first workload took the capacity left in the NodePool.
+				"team-b": {"sales/second"},
+			},
+			wantInadmissibleLeft: map[string][]string{
+				"team-c": {"sales/too-big"},
+			},
+		},
 		"preempt workloads in ClusterQueue and cohort": {
 			workloads: []kueue.Workload{
 				*utiltesting.MakeWorkload("preemptor", "eng-beta").
@@ -793,6 +1186,21 @@ func TestSchedule(t *testing.T) {
 				"eng-beta/low-2":     *utiltesting.MakeAdmission("eng-beta").Assignment(corev1.ResourceCPU, "on-demand", "10").Obj(),
 				"eng-alpha/borrower": *utiltesting.MakeAdmission("eng-alpha").Assignment(corev1.ResourceCPU, "on-demand", "60").Obj(),
 			},
+			wantDecisions: []audit.Decision{
+				{
+					Workload:          "eng-beta/preemptor",
+					ClusterQueue:      "eng-beta",
+					Result:            audit.Preempting,
+					Mode:              "Preempt",
+					Borrowing:         true,
+					Usage:             audit.Quantities{"on-demand": {"cpu": "20"}},
+					ClusterQueueUsage: audit.Quantities{"on-demand": {"cpu": "40"}},
+					PreemptionTargets: []audit.PreemptionTarget{
+						{Workload: "eng-alpha/borrower", ClusterQueue: "eng-alpha", Reason: kueue.InCohortReclamationReason},
+						{Workload: "eng-beta/low-2", ClusterQueue: "eng-beta", Reason: kueue.InClusterQueueReason},
+					},
+				},
+			},
 		},
 		"multiple CQs need preemption": {
 			additionalClusterQueues: []kueue.ClusterQueue{
@@ -1526,6 +1934,29 @@ func TestSchedule(t *testing.T) {
 			wantLeft: map[string][]string{
 				"eng-beta": {"eng-beta/older_new"},
 			},
+			wantDecisions: []audit.Decision{
+				{
+					Workload:          "eng-alpha/new",
+					ClusterQueue:      "eng-alpha",
+					Result:            audit.QuotaReserved,
+					Mode:              "Fit",
+					Borrowing:         true,
+					Usage:             audit.Quantities{"on-demand": {"cpu": "5"}},
+					ClusterQueueUsage: audit.Quantities{"on-demand": {"cpu": "50"}},
+					FairSharing:       &audit.FairSharing{DominantResourceShare: 23, DominantResource: "cpu"},
+				},
+				{
+					Position:          1,
+					Workload:          "eng-beta/older_new",
+					ClusterQueue:      "eng-beta",
+					Result:            audit.Skipped,
+					Mode:              "Fit",
+					Borrowing:         true,
+					Usage:             audit.Quantities{"on-demand": {"cpu": "1"}},
+					ClusterQueueUsage: audit.Quantities{"on-demand": {"cpu": "55"}},
+					FairSharing:       &audit.FairSharing{DominantResourceShare: 28, DominantResource: "cpu", ClusterQueueShare: 23},
+				},
+			},
 		},
 		"minimal preemptions when target queue is exhausted": {
 			additionalClusterQueues: []kueue.ClusterQueue{
@@ -1943,6 +2374,12 @@ func TestSchedule(t *testing.T) {
 				"other-alpha": 0,
 				"other-beta":  1,
 			},
+			wantCohortEvaluated: map[string]int{
+				"other": 2,
+			},
+			wantCohortSkipped: map[string]int{
+				"other": 1,
+			},
 		},
 		"multiple preemptions within cq when fair sharing": {
 			// Multiple CQs can preempt within their CQs, with
@@ -2166,6 +2603,39 @@ func TestSchedule(t *testing.T) {
 				"other-beta":  1,
 				"other-gamma": 0,
 			},
+			wantDecisions: []audit.Decision{
+				{
+					Workload:          "eng-alpha/preemptor",
+					ClusterQueue:      "other-alpha",
+					Priority:          100,
+					Result:            audit.Preempting,
+					Mode:              "Preempt",
+					Borrowing:         true,
+					Usage:             audit.Quantities{"default": {"alpha-resource": "1", "cpu": "3"}},
+					ClusterQueueUsage: audit.Quantities{"default": {"alpha-resource": "1"}},
+					FairSharing:       &audit.FairSharing{DominantResourceShare: 1000, DominantResource: "alpha-resource"},
+					PreemptionTargets: []audit.PreemptionTarget{
+						{Workload: "eng-alpha/a1", ClusterQueue: "other-alpha", Reason: kueue.InClusterQueueReason},
+						{Workload: "eng-gamma/c1", ClusterQueue: "other-gamma", Reason: kueue.InCohortFairSharingReason},
+					},
+				},
+				{
+					Position:          1,
+					Workload:          "eng-beta/pretending-preemptor",
+					ClusterQueue:      "other-beta",
+					Priority:          99,
+					Result:            audit.Skipped,
+					Mode:              "Preempt",
+					Borrowing:         true,
+					Usage:             audit.Quantities{"default": {"beta-resource": "1", "cpu": "3"}},
+					ClusterQueueUsage: audit.Quantities{"default": {"beta-resource": "1"}},
+					FairSharing:       &audit.FairSharing{DominantResourceShare: 1000, DominantResource: "beta-resource"},
+					PreemptionTargets: []audit.PreemptionTarget{
+						{Workload: "eng-beta/b1", ClusterQueue: "other-beta", Reason: kueue.InClusterQueueReason},
+						{Workload: "eng-gamma/c1", ClusterQueue: "other-gamma", Reason: kueue.InCohortFairSharingReason},
+					},
+				},
+			},
 		},
 		"not enough resources": {
 			workloads: []kueue.Workload{
@@ -2255,6 +2725,58 @@ func TestSchedule(t *testing.T) {
 				"eng-beta/b1":  *utiltesting.MakeAdmission("other-beta").Assignment("gpu", "spot", "5").Obj(),
 			},
 		},
+		"preemption is not issued in shadow mode": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("shadow").
+					Preemption(kueue.ClusterQueuePreemption{
+						WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+					}).
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("on-demand").Resource("gpu", "10").Obj(),
+					).
+					ShadowMode(true).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("shadow", "eng-alpha").ClusterQueue("shadow").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a1", "eng-alpha").
+					Priority(50).
+					Queue("shadow").
+					Request("gpu", "10").
+					SimpleReserveQuota("shadow", "on-demand", now).
+					Obj(),
+				*utiltesting.MakeWorkload("preemptor", "eng-alpha").
+					Priority(100).
+					Queue("shadow").
+					Request("gpu", "5").
+					Obj(),
+			},
+			wantInadmissibleLeft: map[string][]string{
+				"shadow": {"eng-alpha/preemptor"},
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/a1": *utiltesting.MakeAdmission("shadow").Assignment("gpu", "on-demand", "10").Obj(),
+			},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "eng-alpha", Name: "a1"},
+					Reason:    "WouldBePreempted",
+					EventType: corev1.EventTypeNormal,
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "eng-alpha", Name: "preemptor"},
+					Reason:    "WouldPreempt",
+					EventType: corev1.EventTypeNormal,
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "eng-alpha", Name: "preemptor"},
+					Reason:    "Pending",
+					EventType: corev1.EventTypeWarning,
+				},
+			},
+		},
 		"prefer first preemption flavor when second flavor requires both reclaim and cq priority preemption": {
 			// Flavor 1, on-demand, requires preemption of workload in CQ.
 			// Flavor 2, spot, requires preemption of workload in Cohort and CQ
@@ -2490,76 +3012,559 @@ func TestSchedule(t *testing.T) {
 	}
 
 	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			metrics.AdmissionCyclePreemptionSkips.Reset()
-			if tc.disableLendingLimit {
-				features.SetFeatureGateDuringTest(t, features.LendingLimit, false)
-			}
-			if tc.disablePartialAdmission {
-				features.SetFeatureGateDuringTest(t, features.PartialAdmission, false)
-			}
-			ctx, _ := utiltesting.ContextWithLog(t)
+		for _, parallel := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s, parallel cohort scheduling: %t", name, parallel), func(t *testing.T) {
+				features.SetFeatureGateDuringTest(t, features.ParallelCohortScheduling, parallel)
+				metrics.AdmissionCyclePreemptionSkips.Reset()
+				metrics.CohortSchedulingCycleWorkloadsEvaluatedTotal.Reset()
+				metrics.CohortSchedulingCycleWorkloadsSkippedTotal.Reset()
+				if tc.disableLendingLimit {
+					features.SetFeatureGateDuringTest(t, features.LendingLimit, false)
+				}
+				if tc.disablePartialAdmission {
+					features.SetFeatureGateDuringTest(t, features.PartialAdmission, false)
+				}
+				ctx, _ := utiltesting.ContextWithLog(t)
 
-			allQueues := append(queues, tc.additionalLocalQueues...)
-			allClusterQueues := append(clusterQueues, tc.additionalClusterQueues...)
+				allQueues := append(queues, tc.additionalLocalQueues...)
+				allClusterQueues := append(clusterQueues, tc.additionalClusterQueues...)
 
-			clientBuilder := utiltesting.NewClientBuilder().
-				WithLists(&kueue.WorkloadList{Items: tc.workloads}, &kueue.LocalQueueList{Items: allQueues}).
-				WithObjects(
-					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eng-alpha", Labels: map[string]string{"dep": "eng"}}},
-					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eng-beta", Labels: map[string]string{"dep": "eng"}}},
-					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eng-gamma", Labels: map[string]string{"dep": "eng"}}},
-					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "sales", Labels: map[string]string{"dep": "sales"}}},
-					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "lend", Labels: map[string]string{"dep": "lend"}}},
-				)
-			cl := clientBuilder.Build()
-			recorder := &utiltesting.EventRecorder{}
-			cqCache := cache.New(cl)
-			qManager := queue.NewManager(cl, cqCache)
-			// Workloads are loaded into queues or clusterQueues as we add them.
-			for _, q := range allQueues {
-				if err := qManager.AddLocalQueue(ctx, &q); err != nil {
-					t.Fatalf("Inserting queue %s/%s in manager: %v", q.Namespace, q.Name, err)
+				clientBuilder := utiltesting.NewClientBuilder().
+					WithLists(&kueue.WorkloadList{Items: tc.workloads}, &kueue.LocalQueueList{Items: allQueues}).
+					WithObjects(
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eng-alpha", Labels: map[string]string{"dep": "eng"}}},
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eng-beta", Labels: map[string]string{"dep": "eng"}}},
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eng-gamma", Labels: map[string]string{"dep": "eng"}}},
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "sales", Labels: map[string]string{"dep": "sales"}}},
+						&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "lend", Labels: map[string]string{"dep": "lend"}}},
+					)
+				cl := clientBuilder.Build()
+				recorder := &utiltesting.EventRecorder{}
+				cqCache := cache.New(cl)
+				qManager := queue.NewManager(cl, cqCache)
+				// Workloads are loaded into queues or clusterQueues as we add them.
+				for _, q := range allQueues {
+					if err := qManager.AddLocalQueue(ctx, &q); err != nil {
+						t.Fatalf("Inserting queue %s/%s in manager: %v", q.Namespace, q.Name, err)
+					}
 				}
-			}
-			for i := range resourceFlavors {
-				cqCache.AddOrUpdateResourceFlavor(resourceFlavors[i])
-			}
-			for _, cq := range allClusterQueues {
-				if err := cqCache.AddClusterQueue(ctx, &cq); err != nil {
-					t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+				for i := range resourceFlavors {
+					cqCache.AddOrUpdateResourceFlavor(resourceFlavors[i])
 				}
-				if err := qManager.AddClusterQueue(ctx, &cq); err != nil {
-					t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+				for i := range tc.admissionChecks {
+					cqCache.AddOrUpdateAdmissionCheck(&tc.admissionChecks[i])
 				}
-				if err := cl.Create(ctx, &cq); err != nil {
-					t.Errorf("couldn't create the cluster queue: %v", err)
+				for _, cq := range allClusterQueues {
+					if err := cqCache.AddClusterQueue(ctx, &cq); err != nil {
+						t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+					}
+					if err := qManager.AddClusterQueue(ctx, &cq); err != nil {
+						t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+					}
+					if err := cl.Create(ctx, &cq); err != nil {
+						t.Errorf("couldn't create the cluster queue: %v", err)
+					}
+				}
+				for i := range tc.tenants {
+					cqCache.AddOrUpdateTenant(&tc.tenants[i])
+				}
+				for i := range tc.budgets {
+					cqCache.AddOrUpdateBudget(&tc.budgets[i])
+				}
+				for i := range tc.schedules {
+					cqCache.AddOrUpdateSchedule(&tc.schedules[i])
+				}
+				for i := range tc.workloadTemplates {
+					cqCache.AddOrUpdateWorkloadTemplate(&tc.workloadTemplates[i])
+				}
+				for name, limits := range tc.nodePoolLimits {
+					cqCache.AddOrUpdateNodePool(name, limits, nil)
+				}
+				auditRecorder := &fakeAuditRecorder{}
+				scheduler := New(qManager, cqCache, cl, recorder, WithFairSharing(&config.FairSharing{Enable: tc.enableFairSharing}), WithClock(t, fakeClock), WithAuditRecorder(auditRecorder))
+				gotScheduled := make(map[string]kueue.Admission)
+				var mu sync.Mutex
+				scheduler.applyAdmission = func(ctx context.Context, w *kueue.Workload) error {
+					if tc.admissionError != nil {
+						return tc.admissionError
+					}
+					mu.Lock()
+					gotScheduled[workload.Key(w)] = *w.Status.Admission
+					mu.Unlock()
+					return nil
+				}
+				wg := sync.WaitGroup{}
+				scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
+					func() { wg.Add(1) },
+					func() { wg.Done() },
+				))
+				gotPreempted := sets.New[string]()
+				scheduler.preemptor.OverrideApply(func(_ context.Context, w *kueue.Workload, _, _ string) error {
+					mu.Lock()
+					gotPreempted.Insert(workload.Key(w))
+					mu.Unlock()
+					return nil
+				})
+
+				ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
+				go qManager.CleanUpOnContext(ctx)
+				defer cancel()
+
+				scheduler.schedule(ctx)
+				wg.Wait()
+
+				wantScheduled := make(map[string]kueue.Admission)
+				for _, key := range tc.wantScheduled {
+					wantScheduled[key] = tc.wantAssignments[key]
+				}
+				if diff := cmp.Diff(wantScheduled, gotScheduled); diff != "" {
+					t.Errorf("Unexpected scheduled workloads (-want,+got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff(tc.wantPreempted, gotPreempted); diff != "" {
+					t.Errorf("Unexpected preemptions (-want,+got):\n%s", diff)
+				}
+
+				// Verify assignments in cache.
+				gotAssignments := make(map[string]kueue.Admission)
+				var gotWorkloads []kueue.Workload
+				snapshot, err := cqCache.Snapshot(ctx)
+				if err != nil {
+					t.Fatalf("unexpected error while building snapshot: %v", err)
+				}
+				for cqName, c := range snapshot.ClusterQueues {
+					for name, w := range c.Workloads {
+						gotWorkloads = append(gotWorkloads, *w.Obj)
+						switch {
+						case !workload.HasQuotaReservation(w.Obj):
+							t.Errorf("Workload %s is not admitted by a clusterQueue, but it is found as member of clusterQueue %s in the cache", name, cqName)
+						case string(w.Obj.Status.Admission.ClusterQueue) != cqName:
+							t.Errorf("Workload %s is admitted by clusterQueue %s, but it is found as member of clusterQueue %s in the cache", name, w.Obj.Status.Admission.ClusterQueue, cqName)
+						default:
+							gotAssignments[name] = *w.Obj.Status.Admission
+						}
+					}
+				}
+
+				if tc.wantWorkloads != nil {
+					if diff := cmp.Diff(tc.wantWorkloads, gotWorkloads, tc.workloadCmpOpts...); diff != "" {
+						t.Errorf("Unexpected workloads in cache (-want,+got):\n%s", diff)
+					}
+				}
+
+				if len(gotAssignments) == 0 {
+					gotAssignments = nil
+				}
+				if diff := cmp.Diff(tc.wantAssignments, gotAssignments); diff != "" {
+					t.Errorf("Unexpected assigned clusterQueues in cache (-want,+got):\n%s", diff)
 				}
+
+				qDump := qManager.Dump()
+				if diff := cmp.Diff(tc.wantLeft, qDump, cmpDump...); diff != "" {
+					t.Errorf("Unexpected elements left in the queue (-want,+got):\n%s", diff)
+				}
+				qDumpInadmissible := qManager.DumpInadmissible()
+				if diff := cmp.Diff(tc.wantInadmissibleLeft, qDumpInadmissible, cmpDump...); diff != "" {
+					t.Errorf("Unexpected elements left in inadmissible workloads (-want,+got):\n%s", diff)
+				}
+
+				if len(tc.wantEvents) > 0 {
+					if diff := cmp.Diff(tc.wantEvents, recorder.RecordedEvents, cmpopts.IgnoreFields(utiltesting.EventRecord{}, "Message")); diff != "" {
+						t.Errorf("unexpected events (-want/+got):\n%s", diff)
+					}
+				}
+
+				if len(tc.wantDecisions) > 0 {
+					if diff := cmp.Diff(tc.wantDecisions, auditRecorder.decisions, cmpopts.IgnoreFields(audit.Decision{}, "Time", "Cycle", "UID", "QueueOrderTimestamp", "Message")); diff != "" {
+						t.Errorf("unexpected audit decisions (-want/+got):\n%s", diff)
+					}
+				}
+
+				for cqName, want := range tc.wantSkippedPreemptions {
+					val, err := testutil.GetGaugeMetricValue(metrics.AdmissionCyclePreemptionSkips.WithLabelValues(cqName))
+					if err != nil {
+						t.Fatalf("Couldn't get value for metric admission_cycle_preemption_skips for %q: %v", cqName, err)
+					}
+					got := int(val)
+					if want != got {
+						t.Errorf("Counted %d skips for %q, want %d", got, cqName, want)
+					}
+				}
+
+				for cohort, want := range tc.wantCohortEvaluated {
+					val, err := testutil.GetCounterMetricValue(metrics.CohortSchedulingCycleWorkloadsEvaluatedTotal.WithLabelValues(cohort))
+					if err != nil {
+						t.Fatalf("Couldn't get value for metric cohort_scheduling_cycle_workloads_evaluated_total for %q: %v", cohort, err)
+					}
+					if got := int(val); want != got {
+						t.Errorf("Counted %d evaluated workloads for cohort %q, want %d", got, cohort, want)
+					}
+				}
+				for cohort, want := range tc.wantCohortSkipped {
+					val, err := testutil.GetCounterMetricValue(metrics.CohortSchedulingCycleWorkloadsSkippedTotal.WithLabelValues(cohort))
+					if err != nil {
+						t.Fatalf("Couldn't get value for metric cohort_scheduling_cycle_workloads_skipped_total for %q: %v", cohort, err)
+					}
+					if got := int(val); want != got {
+						t.Errorf("Counted %d skipped workloads for cohort %q, want %d", got, cohort, want)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestWaitingFlavors(t *testing.T) {
+	cq := &cache.ClusterQueueSnapshot{
+		ResourceGroups: []cache.ResourceGroup{
+			{
+				CoveredResources: sets.New(corev1.ResourceCPU, corev1.ResourceMemory),
+				Flavors:          []kueue.ResourceFlavorReference{"on-demand", "spot"},
+			},
+			{
+				CoveredResources: sets.New[corev1.ResourceName]("example.com/gpu"),
+				Flavors:          []kueue.ResourceFlavorReference{"a100", "t4"},
+			},
+		},
+	}
+	cases := map[string]struct {
+		workload *kueue.Workload
+		want     sets.Set[kueue.ResourceFlavorReference]
+	}{
+		"cpu workload": {
+			workload: utiltesting.MakeWorkload("wl", "ns").Request(corev1.ResourceCPU, "1").Obj(),
+			want:     sets.New[kueue.ResourceFlavorReference]("on-demand", "spot"),
+		},
+		"gpu workload": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Request(corev1.ResourceCPU, "1").
+				Request("example.com/gpu", "1").
+				Obj(),
+			want: sets.New[kueue.ResourceFlavorReference]("on-demand", "spot", "a100", "t4"),
+		},
+		"resource not covered by the ClusterQueue": {
+			workload: utiltesting.MakeWorkload("wl", "ns").Request("example.com/fpga", "1").Obj(),
+			want:     sets.New[kueue.ResourceFlavorReference](),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := waitingFlavors(workload.NewInfo(tc.workload), cq)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected waiting flavors (-want,+got):\n%s", diff)
 			}
-			scheduler := New(qManager, cqCache, cl, recorder, WithFairSharing(&config.FairSharing{Enable: tc.enableFairSharing}), WithClock(t, fakeClock))
-			gotScheduled := make(map[string]kueue.Admission)
+		})
+	}
+}
+
+func TestReclaimDebt(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cqCache := cache.New(utiltesting.NewFakeClient())
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	for _, cq := range []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("cq-a").Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "6").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("cq-b").Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "6").Obj()).
+			Obj(),
+	} {
+		if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Inserting ClusterQueue %s in cache: %v", cq.Name, err)
+		}
+	}
+	for _, wl := range []*kueue.Workload{
+		utiltesting.MakeWorkload("a1", "ns").Request(corev1.ResourceCPU, "2").
+			ReserveQuota(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+			Obj(),
+		utiltesting.MakeWorkload("b1", "ns").Request(corev1.ResourceCPU, "4").
+			ReserveQuota(utiltesting.MakeAdmission("cq-b").Assignment(corev1.ResourceCPU, "default", "4").Obj()).
+			Obj(),
+		utiltesting.MakeWorkload("b2", "ns").Request(corev1.ResourceCPU, "4").
+			ReserveQuota(utiltesting.MakeAdmission("cq-b").Assignment(corev1.ResourceCPU, "default", "4").Obj()).
+			Obj(),
+	} {
+		cqCache.AddOrUpdateWorkload(wl)
+	}
+	snapshot, err := cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	target := func(cq, name string) *preemption.Target {
+		return &preemption.Target{WorkloadInfo: snapshot.ClusterQueues[cq].Workloads["ns/"+name]}
+	}
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+
+	cases := map[string]struct {
+		targets []*preemption.Target
+		want    map[string]resources.FlavorResourceQuantities
+	}{
+		"no preemption targets": {
+			targets: []*preemption.Target{},
+			want:    map[string]resources.FlavorResourceQuantities{},
+		},
+		"preemption partially within the nominal quota": {
+			targets: []*preemption.Target{target("cq-b", "b1")},
+			want: map[string]resources.FlavorResourceQuantities{
+				"cq-b": {cpu: 2_000},
+			},
+		},
+		"preemptions within the nominal quota": {
+			targets: []*preemption.Target{target("cq-b", "b1"), target("cq-b", "b2")},
+			want: map[string]resources.FlavorResourceQuantities{
+				"cq-b": {cpu: 6_000},
+			},
+		},
+		"preemption in the ClusterQueue of the preemptor": {
+			targets: []*preemption.Target{target("cq-a", "a1")},
+			want:    map[string]resources.FlavorResourceQuantities{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := reclaimDebt(snapshot, "cq-a", tc.targets)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected reclaim debt (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestForgetStaleReclaimDebt(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	now := time.Now().Truncate(time.Second)
+	fakeClock := testingclock.NewFakeClock(now)
+	cl := utiltesting.NewFakeClient()
+	cqCache := cache.New(cl)
+	qManager := queue.NewManager(cl, cqCache)
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+	debtRecorded := map[string]time.Time{
+		"with-head":         now,
+		"with-pending":      now,
+		"without-pending":   now,
+		"with-expired-debt": now.Add(-reclaimDebtExpiration - time.Second),
+	}
+	for cqName, recorded := range debtRecorded {
+		cq := utiltesting.MakeClusterQueue(cqName).
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "6").Obj()).
+			Obj()
+		if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Inserting ClusterQueue %s in cache: %v", cqName, err)
+		}
+		if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Inserting ClusterQueue %s in manager: %v", cqName, err)
+		}
+		lq := utiltesting.MakeLocalQueue(cqName, "ns").ClusterQueue(cqName).Obj()
+		if err := qManager.AddLocalQueue(ctx, lq); err != nil {
+			t.Fatalf("Inserting LocalQueue %s in manager: %v", cqName, err)
+		}
+		cqCache.AddReclaimDebt(cqName, resources.FlavorResourceQuantities{cpu: 2_000}, recorded)
+	}
+	for _, cqName := range []string{"with-pending", "with-expired-debt"} {
+		qManager.AddOrUpdateWorkload(utiltesting.MakeWorkload(cqName, "ns").Queue(cqName).Request(corev1.ResourceCPU, "1").Obj())
+	}
+	scheduler := New(qManager, cqCache, cl, nil, WithClock(t, fakeClock))
+
+	snapshot, err := cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	scheduler.forgetStaleReclaimDebt([]workload.Info{{ClusterQueue: "with-head"}}, snapshot)
+
+	wantDebt := map[string]resources.FlavorResourceQuantities{
+		"with-head":    {cpu: 2_000},
+		"with-pending": {cpu: 2_000},
+	}
+	gotDebt := make(map[string]resources.FlavorResourceQuantities)
+	for cqName, cq := range snapshot.ClusterQueues {
+		if len(cq.ReclaimDebt) > 0 {
+			gotDebt[cqName] = cq.ReclaimDebt
+		}
+	}
+	if diff := cmp.Diff(wantDebt, gotDebt); diff != "" {
+		t.Errorf("Unexpected reclaim debt in the snapshot (-want,+got):\n%s", diff)
+	}
+	snapshot, err = cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	gotDebt = make(map[string]resources.FlavorResourceQuantities)
+	for cqName, cq := range snapshot.ClusterQueues {
+		if len(cq.ReclaimDebt) > 0 {
+			gotDebt[cqName] = cq.ReclaimDebt
+		}
+	}
+	if diff := cmp.Diff(wantDebt, gotDebt); diff != "" {
+		t.Errorf("Unexpected reclaim debt in the cache (-want,+got):\n%s", diff)
+	}
+}
+
+func TestScheduleResize(t *testing.T) {
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+		Obj()
+	lq := utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj()
+	admittedWorkload := func(name string, count, admittedCount int32) *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload(name, "ns").
+			Queue("lq").
+			PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, int(count)).Request(corev1.ResourceCPU, "1").Obj()).
+			ReserveQuota(utiltesting.MakeAdmission("cq").
+				Assignment(corev1.ResourceCPU, "default", fmt.Sprintf("%d", admittedCount)).
+				AssignmentPodCount(admittedCount).
+				Obj()).
+			Admitted(true)
+	}
+	resizePending := metav1.Condition{
+		Type:    kueue.WorkloadResizePending,
+		Status:  metav1.ConditionTrue,
+		Reason:  "InsufficientQuota",
+		Message: "The additional quota is not available in ClusterQueue cq",
+	}
+	cpu := resources.FlavorResource{Flavor: "default", Resource: corev1.ResourceCPU}
+
+	cases := map[string]struct {
+		workload          *kueue.Workload
+		otherWorkloads    []*kueue.Workload
+		pendingWorkloads  []*kueue.Workload
+		wantWorkload      *kueue.Workload
+		wantUsage         int64
+		wantEvents        []utiltesting.EventRecord
+		wantStatusUpdates int
+	}{
+		"grow within quota": {
+			workload:       admittedWorkload("wl", 3, 2).Obj(),
+			otherWorkloads: []*kueue.Workload{admittedWorkload("other", 1, 1).Obj()},
+			wantWorkload:   admittedWorkload("wl", 3, 3).Obj(),
+			wantUsage:      4_000,
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+				EventType: corev1.EventTypeNormal,
+				Reason:    "Resized",
+				Message:   "Resized in ClusterQueue cq",
+			}},
+			wantStatusUpdates: 1,
+		},
+		"grow within quota after a pending resize": {
+			workload: admittedWorkload("wl", 3, 2).Condition(resizePending).Obj(),
+			wantWorkload: admittedWorkload("wl", 3, 3).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadResizePending,
+					Status:  metav1.ConditionFalse,
+					Reason:  "Resized",
+					Message: "Resized in ClusterQueue cq",
+				}).
+				Obj(),
+			wantUsage: 3_000,
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+				EventType: corev1.EventTypeNormal,
+				Reason:    "Resized",
+				Message:   "Resized in ClusterQueue cq",
+			}},
+			wantStatusUpdates: 1,
+		},
+		"grow over quota": {
+			workload:       admittedWorkload("wl", 3, 2).Obj(),
+			otherWorkloads: []*kueue.Workload{admittedWorkload("other", 2, 2).Obj()},
+			wantWorkload:   admittedWorkload("wl", 3, 2).Condition(resizePending).Obj(),
+			wantUsage:      4_000,
+			wantEvents: []utiltesting.EventRecord{{
+				Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+				EventType: corev1.EventTypeNormal,
+				Reason:    kueue.WorkloadResizePending,
+				Message:   "The additional quota is not available in ClusterQueue cq",
+			}},
+			wantStatusUpdates: 1,
+		},
+		"grow over quota already pending": {
+			workload:       admittedWorkload("wl", 3, 2).Condition(resizePending).Obj(),
+			otherWorkloads: []*kueue.Workload{admittedWorkload("other", 2, 2).Obj()},
+			wantWorkload:   admittedWorkload("wl", 3, 2).Condition(resizePending).Obj(),
+			wantUsage:      4_000,
+		},
+		"grow is reserved before the heads are admitted": {
+			workload: admittedWorkload("wl", 3, 2).Obj(),
+			pendingWorkloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("pending", "ns").Queue("lq").Request(corev1.ResourceCPU, "2").Obj(),
+			},
+			wantWorkload: admittedWorkload("wl", 3, 3).Obj(),
+			wantUsage:    3_000,
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "pending"},
+					EventType: corev1.EventTypeWarning,
+					Reason:    "Pending",
+					Message:   "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed",
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: corev1.EventTypeNormal,
+					Reason:    "Resized",
+					Message:   "Resized in ClusterQueue cq",
+				},
+			},
+			wantStatusUpdates: 2,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
 			var mu sync.Mutex
+			updates := 0
+			objs := []client.Object{tc.workload, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}}
+			for _, wl := range tc.pendingWorkloads {
+				objs = append(objs, wl)
+			}
+			cl := utiltesting.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, client client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					mu.Lock()
+					updates++
+					mu.Unlock()
+					return utiltesting.TreatSSAAsStrategicMerge(ctx, client, subResourceName, obj, patch, opts...)
+				},
+			}).WithObjects(objs...).WithStatusSubresource(objs...).Build()
+			recorder := &utiltesting.EventRecorder{}
+			cqCache := cache.New(cl)
+			qManager := queue.NewManager(cl, cqCache)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue %s to cache: %v", cq.Name, err)
+			}
+			if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+			}
+			if err := qManager.AddLocalQueue(ctx, lq); err != nil {
+				t.Fatalf("Inserting queue %s/%s in manager: %v", lq.Namespace, lq.Name, err)
+			}
+			var wl kueue.Workload
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(tc.workload), &wl); err != nil {
+				t.Fatalf("Failed obtaining the workload: %v", err)
+			}
+			cqCache.AddOrUpdateWorkload(&wl)
+			for _, other := range tc.otherWorkloads {
+				cqCache.AddOrUpdateWorkload(other)
+			}
+			for _, pending := range tc.pendingWorkloads {
+				qManager.AddOrUpdateWorkload(pending)
+			}
+			resizedWl := wl.DeepCopy()
+			resizedWl.Status.Admission = workload.ResizedAdmission(&wl)
+			qManager.RequestResize(resizedWl)
+
+			scheduler := New(qManager, cqCache, cl, recorder)
+			var gotAdmitted []string
 			scheduler.applyAdmission = func(ctx context.Context, w *kueue.Workload) error {
-				if tc.admissionError != nil {
-					return tc.admissionError
-				}
 				mu.Lock()
-				gotScheduled[workload.Key(w)] = *w.Status.Admission
+				gotAdmitted = append(gotAdmitted, workload.Key(w))
 				mu.Unlock()
-				return nil
+				return workload.ApplyAdmissionStatus(ctx, cl, w, false)
 			}
 			wg := sync.WaitGroup{}
 			scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
 				func() { wg.Add(1) },
 				func() { wg.Done() },
 			))
-			gotPreempted := sets.New[string]()
-			scheduler.preemptor.OverrideApply(func(_ context.Context, w *kueue.Workload, _, _ string) error {
-				mu.Lock()
-				gotPreempted.Insert(workload.Key(w))
-				mu.Unlock()
-				return nil
-			})
 
 			ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
 			go qManager.CleanUpOnContext(ctx)
@@ -2568,81 +3573,166 @@ func TestSchedule(t *testing.T) {
 			scheduler.schedule(ctx)
 			wg.Wait()
 
-			wantScheduled := make(map[string]kueue.Admission)
-			for _, key := range tc.wantScheduled {
-				wantScheduled[key] = tc.wantAssignments[key]
+			var gotWorkload kueue.Workload
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(tc.workload), &gotWorkload); err != nil {
+				t.Fatalf("Failed obtaining the workload: %v", err)
 			}
-			if diff := cmp.Diff(wantScheduled, gotScheduled); diff != "" {
-				t.Errorf("Unexpected scheduled workloads (-want,+got):\n%s", diff)
+			if diff := cmp.Diff(tc.wantWorkload.Status, gotWorkload.Status, ignoreConditionTimestamps); diff != "" {
+				t.Errorf("Unexpected workload status (-want,+got):\n%s", diff)
 			}
-
-			if diff := cmp.Diff(tc.wantPreempted, gotPreempted); diff != "" {
-				t.Errorf("Unexpected preemptions (-want,+got):\n%s", diff)
+			if len(gotAdmitted) != 0 {
+				t.Errorf("Unexpected admitted workloads: %v", gotAdmitted)
+			}
+			sortEvents := cmpopts.SortSlices(func(a, b utiltesting.EventRecord) bool { return a.Key.String() < b.Key.String() })
+			if diff := cmp.Diff(tc.wantEvents, recorder.RecordedEvents, sortEvents); diff != "" {
+				t.Errorf("Unexpected events (-want,+got):\n%s", diff)
+			}
+			if updates != tc.wantStatusUpdates {
+				t.Errorf("Observed %d status updates, want %d", updates, tc.wantStatusUpdates)
 			}
-
-			// Verify assignments in cache.
-			gotAssignments := make(map[string]kueue.Admission)
-			var gotWorkloads []kueue.Workload
 			snapshot, err := cqCache.Snapshot(ctx)
 			if err != nil {
 				t.Fatalf("unexpected error while building snapshot: %v", err)
 			}
-			for cqName, c := range snapshot.ClusterQueues {
-				for name, w := range c.Workloads {
-					gotWorkloads = append(gotWorkloads, *w.Obj)
-					switch {
-					case !workload.HasQuotaReservation(w.Obj):
-						t.Errorf("Workload %s is not admitted by a clusterQueue, but it is found as member of clusterQueue %s in the cache", name, cqName)
-					case string(w.Obj.Status.Admission.ClusterQueue) != cqName:
-						t.Errorf("Workload %s is admitted by clusterQueue %s, but it is found as member of clusterQueue %s in the cache", name, w.Obj.Status.Admission.ClusterQueue, cqName)
-					default:
-						gotAssignments[name] = *w.Obj.Status.Admission
-					}
-				}
+			if got := snapshot.ClusterQueues["cq"].ResourceNode.Usage[cpu]; got != tc.wantUsage {
+				t.Errorf("Unexpected usage in the cache, want=%d, got=%d", tc.wantUsage, got)
 			}
+		})
+	}
+}
 
-			if tc.wantWorkloads != nil {
-				if diff := cmp.Diff(tc.wantWorkloads, gotWorkloads, tc.workloadCmpOpts...); diff != "" {
-					t.Errorf("Unexpected workloads in cache (-want,+got):\n%s", diff)
-				}
-			}
+func TestNominationGroups(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cqCache := cache.New(utiltesting.NewFakeClient())
+	cohorts := []*kueuealpha.Cohort{
+		utiltesting.MakeCohort("root").Obj(),
+		utiltesting.MakeCohort("child").Parent("root").Obj(),
+	}
+	for _, cohort := range cohorts {
+		if err := cqCache.AddOrUpdateCohort(cohort); err != nil {
+			t.Fatalf("Inserting Cohort %s in cache: %v", cohort.Name, err)
+		}
+	}
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("root-cq").Cohort("root").Obj(),
+		utiltesting.MakeClusterQueue("child-cq").Cohort("child").Obj(),
+		utiltesting.MakeClusterQueue("other-cq").Cohort("other").Obj(),
+		utiltesting.MakeClusterQueue("standalone-cq").Obj(),
+		utiltesting.MakeClusterQueue("tas-cq-1").Cohort("tas-1").Obj(),
+		utiltesting.MakeClusterQueue("tas-cq-2").Cohort("tas-2").Obj(),
+	}
+	for _, cq := range clusterQueues {
+		if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Inserting ClusterQueue %s in cache: %v", cq.Name, err)
+		}
+	}
+	snapshot, err := cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
+	snapshot.ClusterQueues["tas-cq-1"].TASFlavors = map[kueue.ResourceFlavorReference]*cache.TASFlavorSnapshot{"tas": nil}
+	snapshot.ClusterQueues["tas-cq-2"].TASFlavors = map[kueue.ResourceFlavorReference]*cache.TASFlavorSnapshot{"tas": nil}
 
-			if len(gotAssignments) == 0 {
-				gotAssignments = nil
-			}
-			if diff := cmp.Diff(tc.wantAssignments, gotAssignments); diff != "" {
-				t.Errorf("Unexpected assigned clusterQueues in cache (-want,+got):\n%s", diff)
-			}
+	workloads := []workload.Info{
+		{ClusterQueue: "root-cq"},
+		{ClusterQueue: "other-cq"},
+		{ClusterQueue: "child-cq"},
+		{ClusterQueue: "standalone-cq"},
+		{ClusterQueue: "tas-cq-1"},
+		{ClusterQueue: "tas-cq-2"},
+		{ClusterQueue: "missing-cq"},
+	}
+	want := [][]int{{0, 2}, {1}, {3}, {4, 5}, {6}}
+	if diff := cmp.Diff(want, nominationGroups(workloads, snapshot)); diff != "" {
+		t.Errorf("Unexpected nomination groups (-want,+got):\n%s", diff)
+	}
+}
 
-			qDump := qManager.Dump()
-			if diff := cmp.Diff(tc.wantLeft, qDump, cmpDump...); diff != "" {
-				t.Errorf("Unexpected elements left in the queue (-want,+got):\n%s", diff)
-			}
-			qDumpInadmissible := qManager.DumpInadmissible()
-			if diff := cmp.Diff(tc.wantInadmissibleLeft, qDumpInadmissible, cmpDump...); diff != "" {
-				t.Errorf("Unexpected elements left in inadmissible workloads (-want,+got):\n%s", diff)
-			}
+func TestStrictFIFOHeads(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cqCache := cache.New(utiltesting.NewFakeClient())
+	cohorts := []*kueuealpha.Cohort{
+		utiltesting.MakeCohort("root").QueueingStrategy(kueue.StrictFIFO).Obj(),
+		utiltesting.MakeCohort("child").Parent("root").Obj(),
+		utiltesting.MakeCohort("best-effort").Obj(),
+	}
+	for _, cohort := range cohorts {
+		if err := cqCache.AddOrUpdateCohort(cohort); err != nil {
+			t.Fatalf("Inserting Cohort %s in cache: %v", cohort.Name, err)
+		}
+	}
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("root-cq").Cohort("root").Obj(),
+		utiltesting.MakeClusterQueue("child-cq").Cohort("child").Obj(),
+		utiltesting.MakeClusterQueue("best-effort-cq-1").Cohort("best-effort").Obj(),
+		utiltesting.MakeClusterQueue("best-effort-cq-2").Cohort("best-effort").Obj(),
+	}
+	for _, cq := range clusterQueues {
+		if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Inserting ClusterQueue %s in cache: %v", cq.Name, err)
+		}
+	}
+	snapshot, err := cqCache.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error while building snapshot: %v", err)
+	}
 
-			if len(tc.wantEvents) > 0 {
-				if diff := cmp.Diff(tc.wantEvents, recorder.RecordedEvents, cmpopts.IgnoreFields(utiltesting.EventRecord{}, "Message")); diff != "" {
-					t.Errorf("unexpected events (-want/+got):\n%s", diff)
-				}
+	now := time.Now()
+	makeEntry := func(name, cq string, priority int32, created time.Time) entry {
+		wl := utiltesting.MakeWorkload(name, "ns").Priority(priority).Creation(created).Obj()
+		e := entry{Info: *workload.NewInfo(wl)}
+		e.ClusterQueue = cq
+		return e
+	}
+	cases := map[string]struct {
+		entries []entry
+		want    map[string]string
+	}{
+		"oldest workload of the Cohort tree": {
+			entries: []entry{
+				makeEntry("root-new", "root-cq", 0, now.Add(time.Second)),
+				makeEntry("child-old", "child-cq", 0, now),
+				makeEntry("best-effort-old", "best-effort-cq-1", 0, now.Add(-time.Second)),
+			},
+			want: map[string]string{"root": "child-old"},
+		},
+		"higher priority workload first": {
+			entries: []entry{
+				makeEntry("root-high", "root-cq", 10, now.Add(time.Second)),
+				makeEntry("child-old", "child-cq", 0, now),
+			},
+			want: map[string]string{"root": "root-high"},
+		},
+		"no ClusterQueues in strict FIFO Cohorts": {
+			entries: []entry{
+				makeEntry("best-effort-1", "best-effort-cq-1", 0, now),
+				makeEntry("best-effort-2", "best-effort-cq-2", 0, now),
+			},
+			want: map[string]string{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := &Scheduler{workloadOrdering: workload.Ordering{}}
+			got := make(map[string]string)
+			for cohort, head := range s.strictFIFOHeads(tc.entries, snapshot) {
+				got[cohort] = head.Obj.Name
 			}
-
-			for cqName, want := range tc.wantSkippedPreemptions {
-				val, err := testutil.GetGaugeMetricValue(metrics.AdmissionCyclePreemptionSkips.WithLabelValues(cqName))
-				if err != nil {
-					t.Fatalf("Couldn't get value for metric admission_cycle_preemption_skips for %q: %v", cqName, err)
-				}
-				got := int(val)
-				if want != got {
-					t.Errorf("Counted %d skips for %q, want %d", got, cqName, want)
-				}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected strict FIFO heads (-want,+got):\n%s", diff)
 			}
 		})
 	}
 }
 
+type fakeAuditRecorder struct {
+	decisions []audit.Decision
+}
+
+func (r *fakeAuditRecorder) Record(d audit.Decision) {
+	r.decisions = append(r.decisions, d)
+}
+
 func TestEntryOrdering(t *testing.T) {
 	now := time.Now()
 	input := []entry{
@@ -2830,6 +3920,39 @@ func TestEntryOrdering(t *testing.T) {
 			},
 		},
 	}
+	inputForOrderingReclaimDebt := []entry{
+		{
+			Info: workload.Info{
+				Obj: &kueue.Workload{ObjectMeta: metav1.ObjectMeta{
+					Name:              "old_high_pri",
+					CreationTimestamp: metav1.NewTime(now),
+				}, Spec: kueue.WorkloadSpec{
+					Priority: ptr.To[int32](1),
+				}},
+			},
+		},
+		{
+			Info: workload.Info{
+				Obj: &kueue.Workload{ObjectMeta: metav1.ObjectMeta{
+					Name:              "new_with_reclaim_debt",
+					CreationTimestamp: metav1.NewTime(now.Add(time.Second)),
+				}},
+			},
+			reclaimDebt: true,
+		},
+		{
+			Info: workload.Info{
+				Obj: &kueue.Workload{ObjectMeta: metav1.ObjectMeta{
+					Name:              "borrowing_with_reclaim_debt",
+					CreationTimestamp: metav1.NewTime(now),
+				}},
+			},
+			assignment: flavorassigner.Assignment{
+				Borrowing: true,
+			},
+			reclaimDebt: true,
+		},
+	}
 	for _, tc := range []struct {
 		name             string
 		input            []entry
@@ -2889,6 +4012,12 @@ func TestEntryOrdering(t *testing.T) {
 				"old-mid-not-preempted-yet",
 			},
 		},
+		{
+			name:            "Some ClusterQueues have reclaim debt",
+			input:           inputForOrderingReclaimDebt,
+			prioritySorting: true,
+			wantOrder:       []string{"new_with_reclaim_debt", "old_high_pri", "borrowing_with_reclaim_debt"},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			features.SetFeatureGateDuringTest(t, features.PrioritySortingWithinCohort, tc.prioritySorting)
@@ -3424,6 +4553,8 @@ func TestRequeueAndUpdate(t *testing.T) {
 		name                    string
 		e                       entry
 		resourceRequestsSummary bool
+		pendingReasons          bool
+		batchedStatusUpdates    bool
 		wantWorkloads           map[string][]string
 		wantInadmissible        map[string][]string
 		wantStatus              kueue.WorkloadStatus
@@ -3451,6 +4582,27 @@ func TestRequeueAndUpdate(t *testing.T) {
 			},
 			wantStatusUpdates: 1,
 		},
+		{
+			name: "workload didn't fit with batched status updates",
+			e: entry{
+				inadmissibleMsg: "didn't fit",
+			},
+			batchedStatusUpdates: true,
+			wantStatus: kueue.WorkloadStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionFalse,
+						Reason:  "Pending",
+						Message: "didn't fit",
+					},
+				},
+			},
+			wantInadmissible: map[string][]string{
+				"cq": {workload.Key(w1)},
+			},
+			wantStatusUpdates: 1,
+		},
 		{
 			name: "workload didn't fit without summary",
 			e: entry{
@@ -3472,6 +4624,98 @@ func TestRequeueAndUpdate(t *testing.T) {
 			},
 			wantStatusUpdates: 1,
 		},
+		{
+			name: "workload didn't fit with pending reasons",
+			e: entry{
+				inadmissibleMsg: "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed",
+				pendingReasons: []kueue.PendingReason{{
+					Reason:   kueue.PendingReasonInsufficientQuota,
+					PodSet:   "main",
+					Flavor:   "default",
+					Resource: corev1.ResourceCPU,
+					Missing:  ptr.To(resource.MustParse("1")),
+					Message:  "insufficient unused quota for cpu in flavor default, 1 more needed",
+				}},
+			},
+			pendingReasons: true,
+			wantStatus: kueue.WorkloadStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionFalse,
+						Reason:  "Pending",
+						Message: "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed",
+					},
+				},
+				PendingReasons: []kueue.PendingReason{{
+					Reason:   kueue.PendingReasonInsufficientQuota,
+					PodSet:   "main",
+					Flavor:   "default",
+					Resource: corev1.ResourceCPU,
+					Missing:  ptr.To(resource.MustParse("1")),
+					Message:  "insufficient unused quota for cpu in flavor default, 1 more needed",
+				}},
+			},
+			wantInadmissible: map[string][]string{
+				"cq": {workload.Key(w1)},
+			},
+			wantStatusUpdates: 1,
+		},
+		{
+			name: "workload didn't fit with pending reasons disabled",
+			e: entry{
+				inadmissibleMsg: "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed",
+				pendingReasons: []kueue.PendingReason{{
+					Reason:   kueue.PendingReasonInsufficientQuota,
+					PodSet:   "main",
+					Flavor:   "default",
+					Resource: corev1.ResourceCPU,
+					Missing:  ptr.To(resource.MustParse("1")),
+					Message:  "insufficient unused quota for cpu in flavor default, 1 more needed",
+				}},
+			},
+			wantStatus: kueue.WorkloadStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionFalse,
+						Reason:  "Pending",
+						Message: "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed",
+					},
+				},
+			},
+			wantInadmissible: map[string][]string{
+				"cq": {workload.Key(w1)},
+			},
+			wantStatusUpdates: 1,
+		},
+		{
+			name: "workload violating an admission policy with the Deactivate action",
+			e: entry{
+				inadmissibleMsg: `The workload violates the admission policy "max-pods": too many pods`,
+				deactivate:      true,
+			},
+			wantStatus: kueue.WorkloadStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:    kueue.WorkloadQuotaReserved,
+						Status:  metav1.ConditionFalse,
+						Reason:  "Pending",
+						Message: `The workload violates the admission policy "max-pods": too many pods`,
+					},
+					{
+						Type:    kueue.WorkloadDeactivationTarget,
+						Status:  metav1.ConditionTrue,
+						Reason:  kueue.WorkloadAdmissionPolicyViolated,
+						Message: `The workload violates the admission policy "max-pods": too many pods`,
+					},
+				},
+			},
+			wantInadmissible: map[string][]string{
+				"cq": {workload.Key(w1)},
+			},
+			wantStatusUpdates: 1,
+		},
 		{
 			name: "assumed",
 			e: entry{
@@ -3542,6 +4786,8 @@ func TestRequeueAndUpdate(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			features.SetFeatureGateDuringTest(t, features.WorkloadResourceRequestsSummary, tc.resourceRequestsSummary)
+			features.SetFeatureGateDuringTest(t, features.WorkloadPendingReasons, tc.pendingReasons)
+			features.SetFeatureGateDuringTest(t, features.BatchedWorkloadStatusUpdates, tc.batchedStatusUpdates)
 			ctx, _ := utiltesting.ContextWithLog(t)
 			scheme := runtime.NewScheme()
 
@@ -3577,6 +4823,12 @@ func TestRequeueAndUpdate(t *testing.T) {
 			}
 			tc.e.Info = wInfos[0]
 			scheduler.requeueAndUpdate(ctx, tc.e)
+			if tc.batchedStatusUpdates {
+				if updates != 0 {
+					t.Errorf("Observed %d status updates before flushing the batch, want 0", updates)
+				}
+				scheduler.statusBatcher.Flush(ctx)
+			}
 
 			qDump := qManager.Dump()
 			if diff := cmp.Diff(tc.wantWorkloads, qDump, cmpDump...); diff != "" {
@@ -3597,6 +4849,9 @@ func TestRequeueAndUpdate(t *testing.T) {
 			}
 			// Make sure a second call doesn't make unnecessary updates.
 			scheduler.requeueAndUpdate(ctx, tc.e)
+			if tc.batchedStatusUpdates {
+				scheduler.statusBatcher.Flush(ctx)
+			}
 			if updates != tc.wantStatusUpdates {
 				t.Errorf("Observed %d status updates, want %d", updates, tc.wantStatusUpdates)
 			}
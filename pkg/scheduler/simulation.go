@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+var errWorkloadAdmitted = errors.New("the workload is already admitted")
+
+// SimulationResult is the outcome of the simulated admission of a workload.
+type SimulationResult struct {
+	// ClusterQueue is the ClusterQueue the workload would be queued in.
+	ClusterQueue string
+	// Assignment holds the flavors assigned to the pod sets of the workload.
+	// It's empty if the workload wasn't evaluated against the quota, for
+	// example because the ClusterQueue is inactive.
+	Assignment flavorassigner.Assignment
+	// PreemptionTargets are the workloads that would be preempted to admit
+	// the workload.
+	PreemptionTargets []*preemption.Target
+	// Message explains why the workload wouldn't fit, if it doesn't.
+	Message string
+}
+
+// SimulateAdmission nominates the workload against a snapshot of the cache,
+// like the scheduler does for the heads of the queues, without reserving quota
+// for the workload nor preempting other workloads.
+func (s *Scheduler) SimulateAdmission(ctx context.Context, wl *kueue.Workload) (*SimulationResult, error) {
+	cqName, _ := s.queues.ClusterQueueForWorkload(wl)
+	if cqName == "" {
+		return &SimulationResult{Message: fmt.Sprintf("LocalQueue %s doesn't exist", wl.Spec.QueueName)}, nil
+	}
+	wlInfo := workload.NewInfo(wl, s.cache.WorkloadInfoOptions()...)
+	wlInfo.ClusterQueue = cqName
+
+	snapshot, err := s.cache.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries := s.nominate(ctx, []workload.Info{*wlInfo}, snapshot)
+	if len(entries) == 0 {
+		return nil, errWorkloadAdmitted
+	}
+	e := &entries[0]
+	return &SimulationResult{
+		ClusterQueue:      e.ClusterQueue,
+		Assignment:        e.assignment,
+		PreemptionTargets: e.preemptionTargets,
+		Message:           e.inadmissibleMsg,
+	}, nil
+}
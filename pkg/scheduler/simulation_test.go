@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestSimulateAdmission(t *testing.T) {
+	rf := utiltesting.MakeResourceFlavor("default").Obj()
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "2").Obj()).
+		Preemption(kueue.ClusterQueuePreemption{WithinClusterQueue: kueue.PreemptionPolicyLowerPriority}).
+		Obj()
+	lq := utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue(cq.Name).Obj()
+	admitted := utiltesting.MakeWorkload("admitted", "ns").
+		Queue(lq.Name).
+		Request(corev1.ResourceCPU, "1").
+		ReserveQuota(utiltesting.MakeAdmission(cq.Name).Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+		Obj()
+
+	cases := map[string]struct {
+		workload        *kueue.Workload
+		wantMode        flavorassigner.FlavorAssignmentMode
+		wantAssignments []kueue.PodSetAssignment
+		wantTargets     []string
+		wantMessage     string
+	}{
+		"fits": {
+			workload: utiltesting.MakeWorkload("simulation", "ns").Queue(lq.Name).Request(corev1.ResourceCPU, "1").Obj(),
+			wantMode: flavorassigner.Fit,
+			wantAssignments: []kueue.PodSetAssignment{{
+				Name:    kueue.DefaultPodSetName,
+				Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "default"},
+				ResourceUsage: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("1"),
+				},
+				Count: ptr.To[int32](1),
+			}},
+		},
+		"fits when the admitted workloads finish": {
+			workload: utiltesting.MakeWorkload("simulation", "ns").Queue(lq.Name).Request(corev1.ResourceCPU, "2").Obj(),
+			wantMode: flavorassigner.Preempt,
+			wantAssignments: []kueue.PodSetAssignment{{
+				Name:    kueue.DefaultPodSetName,
+				Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "default"},
+				ResourceUsage: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("2"),
+				},
+				Count: ptr.To[int32](1),
+			}},
+			wantMessage: "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed",
+		},
+		"fits after preempting the lower priority workloads": {
+			workload: utiltesting.MakeWorkload("simulation", "ns").Queue(lq.Name).Priority(100).Request(corev1.ResourceCPU, "2").Obj(),
+			wantMode: flavorassigner.Preempt,
+			wantAssignments: []kueue.PodSetAssignment{{
+				Name:    kueue.DefaultPodSetName,
+				Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "default"},
+				ResourceUsage: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("2"),
+				},
+				Count: ptr.To[int32](1),
+			}},
+			wantTargets: []string{"ns/admitted"},
+			wantMessage: "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed",
+		},
+		"doesn't fit": {
+			workload:    utiltesting.MakeWorkload("simulation", "ns").Queue(lq.Name).Request(corev1.ResourceCPU, "3").Obj(),
+			wantMode:    flavorassigner.NoFit,
+			wantMessage: "couldn't assign flavors to pod set main: insufficient quota for cpu in flavor default, request > maximum capacity (3 > 2)",
+		},
+		"nonexistent LocalQueue": {
+			workload:    utiltesting.MakeWorkload("simulation", "ns").Queue("invalid-queue").Request(corev1.ResourceCPU, "1").Obj(),
+			wantMode:    flavorassigner.NoFit,
+			wantMessage: "LocalQueue invalid-queue doesn't exist",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			objs := []client.Object{lq, admitted, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}}
+			cl := utiltesting.NewClientBuilder().WithObjects(objs...).Build()
+			recorder := record.NewBroadcaster().NewRecorder(runtime.NewScheme(), corev1.EventSource{Component: constants.AdmissionName})
+			cqCache := cache.New(cl)
+			qManager := queue.NewManager(cl, cqCache)
+			scheduler := New(qManager, cqCache, cl, recorder)
+			cqCache.AddOrUpdateResourceFlavor(rf)
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue %s to cache: %v", cq.Name, err)
+			}
+			if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+			}
+			if err := qManager.AddLocalQueue(ctx, lq); err != nil {
+				t.Fatalf("Inserting queue %s/%s in manager: %v", lq.Namespace, lq.Name, err)
+			}
+			cqCache.AddOrUpdateWorkload(admitted)
+
+			result, err := scheduler.SimulateAdmission(ctx, tc.workload)
+			if err != nil {
+				t.Fatalf("Simulating the admission: %v", err)
+			}
+			if result.ClusterQueue != "" && result.ClusterQueue != cq.Name {
+				t.Errorf("Unexpected ClusterQueue %q, want %q", result.ClusterQueue, cq.Name)
+			}
+			if got := result.Assignment.RepresentativeMode(); got != tc.wantMode {
+				t.Errorf("Unexpected assignment mode %s, want %s", got, tc.wantMode)
+			}
+			if tc.wantMode != flavorassigner.NoFit {
+				if diff := cmp.Diff(tc.wantAssignments, result.Assignment.ToAPI()); diff != "" {
+					t.Errorf("Unexpected assignments (-want,+got):\n%s", diff)
+				}
+			}
+			var gotTargets []string
+			for _, target := range result.PreemptionTargets {
+				gotTargets = append(gotTargets, workload.Key(target.WorkloadInfo.Obj))
+			}
+			if diff := cmp.Diff(tc.wantTargets, gotTargets); diff != "" {
+				t.Errorf("Unexpected preemption targets (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantMessage, result.Message); diff != "" {
+				t.Errorf("Unexpected message (-want,+got):\n%s", diff)
+			}
+			if pending := qManager.PendingWorkloadsInfo(cq.Name); len(pending) != 0 {
+				t.Errorf("The simulated workload was queued: %v", pending)
+			}
+		})
+	}
+}
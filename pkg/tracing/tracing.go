@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"crypto/sha256"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"k8s.io/component-base/tracing"
+	tracingv1 "k8s.io/component-base/tracing/api/v1"
+	"k8s.io/klog/v2"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/version"
+)
+
+const (
+	instrumentationScope = "sigs.k8s.io/kueue"
+	serviceName          = "kueue"
+)
+
+// The names of the spans recorded for the admission stages of a workload.
+const (
+	// SpanQueueing covers the time from the queueing of the workload to its quota reservation.
+	SpanQueueing = "Queueing"
+	// SpanFlavorAssignment covers the assignment of the flavors to the workload in a scheduling cycle.
+	SpanFlavorAssignment = "FlavorAssignment"
+	// SpanAdmissionChecks covers the time from the quota reservation to the admission of the workload.
+	SpanAdmissionChecks = "AdmissionChecks"
+	// SpanJobStart covers the time from the admission of the workload to the un-suspension of its job.
+	SpanJobStart = "JobStart"
+)
+
+// The attributes of the spans.
+const (
+	WorkloadKey       = attribute.Key("kueue.workload")
+	ClusterQueueKey   = attribute.Key("kueue.clusterqueue")
+	LocalQueueKey     = attribute.Key("kueue.localqueue")
+	AssignmentKey     = attribute.Key("kueue.assignment.mode")
+	BorrowingKey      = attribute.Key("kueue.assignment.borrowing")
+	AdmissionCheckKey = attribute.Key("kueue.admissionchecks")
+	JobKey            = attribute.Key("kueue.job")
+)
+
+var provider trace.TracerProvider = noop.NewTracerProvider()
+
+// SetProvider sets the TracerProvider used to record the spans.
+// It is meant to be called once, before starting the controllers and the scheduler.
+func SetProvider(tp trace.TracerProvider) {
+	provider = tp
+}
+
+// NewProvider creates a TracerProvider exporting the spans to the collector
+// set in the configuration, or a no-op TracerProvider if the tracing isn't configured.
+// The sampling decision is derived from the trace ID, and hence from the UID
+// of the workload, so that the spans of a workload are either all recorded or
+// all dropped, no matter which controller records them.
+func NewProvider(ctx context.Context, cfg *tracingv1.TracingConfiguration) (tracing.TracerProvider, error) {
+	if cfg == nil {
+		return tracing.NewNoopTracerProvider(), nil
+	}
+	var opts []otlptracegrpc.Option
+	if cfg.Endpoint != nil {
+		opts = append(opts, otlptracegrpc.WithEndpoint(*cfg.Endpoint))
+	}
+	opts = append(opts, otlptracegrpc.WithInsecure())
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.New(ctx, resource.WithAttributes(
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version.GitVersion),
+	))
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(Sampler(cfg)),
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	), nil
+}
+
+// Sampler returns the sampler for the configuration, which samples
+// samplingRatePerMillion traces per million. It respects the decision
+// of the parent span, except for the parent spans derived from the workloads.
+func Sampler(cfg *tracingv1.TracingConfiguration) sdktrace.Sampler {
+	sampler := sdktrace.NeverSample()
+	if rate := cfg.SamplingRatePerMillion; rate != nil && *rate > 0 {
+		sampler = sdktrace.TraceIDRatioBased(float64(*rate) / 1000000)
+	}
+	return sdktrace.ParentBased(sampler, sdktrace.WithRemoteParentNotSampled(sampler))
+}
+
+// WorkloadSpanContext returns the span context all the spans of the workload descend from.
+// Its trace ID is derived from the UID of the workload, which links the spans
+// recorded by the different controllers for the same workload into one trace.
+func WorkloadSpanContext(wl *kueue.Workload) trace.SpanContext {
+	sum := sha256.Sum256([]byte(wl.UID))
+	var traceID trace.TraceID
+	var spanID trace.SpanID
+	copy(traceID[:], sum[:len(traceID)])
+	copy(spanID[:], sum[len(traceID):])
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+		Remote:  true,
+	})
+}
+
+// StartWorkloadSpan starts a span in the trace of the workload.
+// Use trace.WithTimestamp to start spans covering a stage which began before
+// the call, like the time spent in the queue.
+func StartWorkloadSpan(ctx context.Context, wl *kueue.Workload, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	ctx = trace.ContextWithRemoteSpanContext(ctx, WorkloadSpanContext(wl))
+	opts = append(opts, trace.WithAttributes(WorkloadKey.String(klog.KObj(wl).String())))
+	return provider.Tracer(instrumentationScope).Start(ctx, name, opts...)
+}
+
+// RecordWorkloadSpan records a span in the trace of the workload for a stage
+// which began at start and is already finished.
+func RecordWorkloadSpan(ctx context.Context, wl *kueue.Workload, name string, start time.Time, attrs ...attribute.KeyValue) {
+	_, span := StartWorkloadSpan(ctx, wl, name, trace.WithTimestamp(start), trace.WithAttributes(attrs...))
+	span.End()
+}
+
+// EndSpan ends the span, recording the error, if any.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
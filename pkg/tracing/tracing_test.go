@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	tracingv1 "k8s.io/component-base/tracing/api/v1"
+	"k8s.io/utils/ptr"
+
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestWorkloadSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	defaultProvider := provider
+	SetProvider(sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(Sampler(&tracingv1.TracingConfiguration{SamplingRatePerMillion: ptr.To[int32](1000000)})),
+		sdktrace.WithSpanProcessor(recorder),
+	))
+	t.Cleanup(func() { SetProvider(defaultProvider) })
+
+	ctx := context.Background()
+	wlA := utiltesting.MakeWorkload("a", "ns").UID("uid-a").Obj()
+	wlB := utiltesting.MakeWorkload("b", "ns").UID("uid-b").Obj()
+	start := time.Now().Add(-time.Minute)
+
+	RecordWorkloadSpan(ctx, wlA, SpanQueueing, start, ClusterQueueKey.String("cq"))
+	_, span := StartWorkloadSpan(ctx, wlA, SpanJobStart)
+	EndSpan(span, nil)
+	RecordWorkloadSpan(ctx, wlB, SpanQueueing, start)
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Unexpected number of spans, want 3, got %d", len(spans))
+	}
+	if spans[0].SpanContext().TraceID() != WorkloadSpanContext(wlA).TraceID() {
+		t.Errorf("The span isn't in the trace of the workload")
+	}
+	if spans[0].SpanContext().TraceID() != spans[1].SpanContext().TraceID() {
+		t.Errorf("The spans of the same workload belong to different traces")
+	}
+	if spans[0].SpanContext().TraceID() == spans[2].SpanContext().TraceID() {
+		t.Errorf("The spans of different workloads belong to the same trace")
+	}
+	if !spans[0].StartTime().Equal(start) {
+		t.Errorf("Unexpected start time of the span, want %v, got %v", start, spans[0].StartTime())
+	}
+	wantAttrs := []attribute.KeyValue{ClusterQueueKey.String("cq"), WorkloadKey.String("ns/a")}
+	if diff := cmp.Diff(wantAttrs, spans[0].Attributes(), cmp.AllowUnexported(attribute.Value{})); diff != "" {
+		t.Errorf("Unexpected attributes of the span (-want,+got):\n%s", diff)
+	}
+}
+
+func TestSampler(t *testing.T) {
+	cases := map[string]struct {
+		rate        *int32
+		wantSampled bool
+	}{
+		"unset": {},
+		"zero": {
+			rate: ptr.To[int32](0),
+		},
+		"all": {
+			rate:        ptr.To[int32](1000000),
+			wantSampled: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			defaultProvider := provider
+			SetProvider(sdktrace.NewTracerProvider(
+				sdktrace.WithSampler(Sampler(&tracingv1.TracingConfiguration{SamplingRatePerMillion: tc.rate})),
+				sdktrace.WithSpanProcessor(recorder),
+			))
+			t.Cleanup(func() { SetProvider(defaultProvider) })
+
+			_, span := StartWorkloadSpan(context.Background(), utiltesting.MakeWorkload("wl", "ns").UID("uid").Obj(), SpanFlavorAssignment)
+			span.End()
+			if got := len(recorder.Ended()) > 0; got != tc.wantSampled {
+				t.Errorf("Unexpected sampling of the span, want %v, got %v", tc.wantSampled, got)
+			}
+		})
+	}
+}
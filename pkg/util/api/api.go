@@ -23,8 +23,9 @@ import (
 )
 
 const (
-	maxEventMsgSize     = 1024
-	maxConditionMsgSize = 32 * 1024
+	maxEventMsgSize         = 1024
+	maxConditionMsgSize     = 32 * 1024
+	maxPendingReasonMsgSize = 1024
 )
 
 // TruncateEventMessage truncates a message if it hits the maxEventMessage.
@@ -36,6 +37,11 @@ func TruncateConditionMessage(message string) string {
 	return truncateMessage(message, maxConditionMsgSize)
 }
 
+// TruncatePendingReasonMessage truncates a message if it hits the maxPendingReasonMsgSize.
+func TruncatePendingReasonMessage(message string) string {
+	return truncateMessage(message, maxPendingReasonMsgSize)
+}
+
 // truncateMessage truncates a message if it hits the NoteLengthLimit.
 func truncateMessage(message string, limit int) string {
 	if len(message) <= limit {
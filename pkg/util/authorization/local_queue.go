@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorization
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+const localQueuesResource = "localqueues"
+
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// LocalQueueAuthorizer verifies, with SubjectAccessReviews, that the users
+// of the admission requests are allowed a verb on the LocalQueues they submit
+// workloads to.
+type LocalQueueAuthorizer struct {
+	client client.Client
+	verb   string
+}
+
+func NewLocalQueueAuthorizer(c client.Client, verb string) *LocalQueueAuthorizer {
+	return &LocalQueueAuthorizer{
+		client: c,
+		verb:   verb,
+	}
+}
+
+// Authorize returns a Forbidden error for fldPath when the user of the
+// admission request in the context isn't allowed the verb on the LocalQueue.
+// It doesn't verify anything when the authorizer is nil, as the authorization
+// is optional, or when no LocalQueue is referenced.
+func (a *LocalQueueAuthorizer) Authorize(ctx context.Context, namespace, queueName string, fldPath *field.Path) field.ErrorList {
+	if a == nil || queueName == "" {
+		return nil
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, fmt.Errorf("getting the admission request: %w", err))}
+	}
+	extra := make(map[string]authorizationv1.ExtraValue, len(req.UserInfo.Extra))
+	for k, v := range req.UserInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			Groups: req.UserInfo.Groups,
+			UID:    req.UserInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      a.verb,
+				Group:     kueue.GroupVersion.Group,
+				Resource:  localQueuesResource,
+				Name:      queueName,
+			},
+		},
+	}
+	if err := a.client.Create(ctx, sar); err != nil {
+		return field.ErrorList{field.InternalError(fldPath, fmt.Errorf("reviewing the access to the LocalQueue: %w", err))}
+	}
+	if !sar.Status.Allowed {
+		return field.ErrorList{field.Forbidden(fldPath, fmt.Sprintf("user %q is not allowed to %s the LocalQueue %q", req.UserInfo.Username, a.verb, queueName))}
+	}
+	return nil
+}
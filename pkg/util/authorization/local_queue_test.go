@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestAuthorize(t *testing.T) {
+	queueNamePath := field.NewPath("spec", "queueName")
+	allowedUsers := map[string]bool{"alice": true}
+	cases := map[string]struct {
+		authorizer *LocalQueueAuthorizer
+		queueName  string
+		user       string
+		wantErr    field.ErrorList
+		wantReview *authorizationv1.SubjectAccessReviewSpec
+	}{
+		"authorization disabled": {
+			queueName: "lq",
+			user:      "bob",
+		},
+		"no LocalQueue": {
+			authorizer: &LocalQueueAuthorizer{verb: "submit"},
+			user:       "bob",
+		},
+		"allowed user": {
+			authorizer: &LocalQueueAuthorizer{verb: "submit"},
+			queueName:  "lq",
+			user:       "alice",
+			wantReview: &authorizationv1.SubjectAccessReviewSpec{
+				User:   "alice",
+				Groups: []string{"team"},
+				Extra:  map[string]authorizationv1.ExtraValue{},
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: "ns",
+					Verb:      "submit",
+					Group:     "kueue.x-k8s.io",
+					Resource:  "localqueues",
+					Name:      "lq",
+				},
+			},
+		},
+		"forbidden user": {
+			authorizer: &LocalQueueAuthorizer{verb: "submit"},
+			queueName:  "lq",
+			user:       "bob",
+			wantErr: field.ErrorList{
+				field.Forbidden(queueNamePath, `user "bob" is not allowed to submit the LocalQueue "lq"`),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var gotReview *authorizationv1.SubjectAccessReviewSpec
+			cl := utiltesting.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						sar := obj.(*authorizationv1.SubjectAccessReview)
+						gotReview = sar.Spec.DeepCopy()
+						sar.Status.Allowed = allowedUsers[sar.Spec.User]
+						return nil
+					},
+				}).
+				Build()
+			if tc.authorizer != nil {
+				tc.authorizer.client = cl
+			}
+			ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					UserInfo: authenticationv1.UserInfo{
+						Username: tc.user,
+						Groups:   []string{"team"},
+					},
+				},
+			})
+
+			gotErr := tc.authorizer.Authorize(ctx, "ns", tc.queueName, queueNamePath)
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "BadValue")); diff != "" {
+				t.Errorf("Unexpected errors (-want,+got):\n%s", diff)
+			}
+			if tc.wantReview != nil {
+				if diff := cmp.Diff(tc.wantReview, gotReview); diff != "" {
+					t.Errorf("Unexpected SubjectAccessReview (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -27,10 +27,15 @@ import (
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 )
 
+const (
+	// ValidatingWebhookConfigurationName is the name of the validating webhook configuration of Kueue.
+	ValidatingWebhookConfigurationName = "kueue-validating-webhook-configuration"
+	// MutatingWebhookConfigurationName is the name of the mutating webhook configuration of Kueue.
+	MutatingWebhookConfigurationName = "kueue-mutating-webhook-configuration"
+)
+
 const (
 	certDir        = "/tmp/k8s-webhook-server/serving-certs"
-	vwcName        = "kueue-validating-webhook-configuration"
-	mwcName        = "kueue-mutating-webhook-configuration"
 	caName         = "kueue-ca"
 	caOrganization = "kueue"
 )
@@ -58,10 +63,10 @@ func ManageCerts(mgr ctrl.Manager, cfg config.Configuration, setupFinished chan
 		IsReady:        setupFinished,
 		Webhooks: []cert.WebhookInfo{{
 			Type: cert.Validating,
-			Name: vwcName,
+			Name: ValidatingWebhookConfigurationName,
 		}, {
 			Type: cert.Mutating,
-			Name: mwcName,
+			Name: MutatingWebhookConfigurationName,
 		}},
 		// When kueue is running in the leader election mode,
 		// we expect webhook server will run in primary and secondary instance
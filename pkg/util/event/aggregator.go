@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/kueue/pkg/util/api"
+)
+
+const maxFlushPeriod = time.Second
+
+// Aggregator records the events of an object which repeat, with the same
+// type and reason, at most once per interval. The first event is recorded
+// right away. The events repeated within the interval are counted, and
+// recorded when the interval ends as a single event, with the count and the
+// last message.
+type Aggregator struct {
+	recorder record.EventRecorder
+	clock    clock.Clock
+	interval time.Duration
+
+	mu      sync.Mutex
+	entries map[key]*entry
+}
+
+type key struct {
+	namespace, name string
+	uid             types.UID
+	eventtype       string
+	reason          string
+}
+
+type entry struct {
+	object    runtime.Object
+	windowEnd time.Time
+	count     int
+	message   string
+}
+
+func NewAggregator(recorder record.EventRecorder, interval time.Duration, clock clock.Clock) *Aggregator {
+	return &Aggregator{
+		recorder: recorder,
+		clock:    clock,
+		interval: interval,
+		entries:  make(map[key]*entry),
+	}
+}
+
+// Eventf records the event right away if no event of the object with the
+// same type and reason was recorded in the current interval, and counts it
+// otherwise.
+func (a *Aggregator) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...any) {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		a.recorder.Eventf(object, eventtype, reason, messageFmt, args...)
+		return
+	}
+	k := key{
+		namespace: accessor.GetNamespace(),
+		name:      accessor.GetName(),
+		uid:       accessor.GetUID(),
+		eventtype: eventtype,
+		reason:    reason,
+	}
+	message := fmt.Sprintf(messageFmt, args...)
+	now := a.clock.Now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if e, found := a.entries[k]; found {
+		if now.Before(e.windowEnd) {
+			e.object = object
+			e.message = message
+			e.count++
+			return
+		}
+		a.recordSummary(k, e)
+	}
+	a.entries[k] = &entry{object: object, windowEnd: now.Add(a.interval)}
+	a.recorder.Event(object, eventtype, reason, message)
+}
+
+// Flush records the summaries of the intervals which ended. An object keeps
+// being aggregated in a new interval after a summary, and is forgotten after
+// an interval without events.
+func (a *Aggregator) Flush() {
+	now := a.clock.Now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for k, e := range a.entries {
+		if now.Before(e.windowEnd) {
+			continue
+		}
+		if e.count == 0 {
+			delete(a.entries, k)
+			continue
+		}
+		a.recordSummary(k, e)
+		e.windowEnd = now.Add(a.interval)
+		e.count = 0
+	}
+}
+
+func (a *Aggregator) recordSummary(k key, e *entry) {
+	if e.count == 0 {
+		return
+	}
+	a.recorder.Event(e.object, k.eventtype, k.reason,
+		api.TruncateEventMessage(fmt.Sprintf("Repeated %d times in the last %s: %s", e.count, a.interval, e.message)))
+}
+
+// Run flushes the summaries until the context is done.
+func (a *Aggregator) Run(ctx context.Context) {
+	wait.UntilWithContext(ctx, func(context.Context) { a.Flush() }, min(a.interval, maxFlushPeriod))
+}
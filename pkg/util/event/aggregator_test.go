@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestAggregator(t *testing.T) {
+	now := time.Now()
+	wlA := utiltesting.MakeWorkload("a", "ns").Obj()
+	wlB := utiltesting.MakeWorkload("b", "ns").Obj()
+
+	type step struct {
+		elapsed time.Duration
+		other   bool
+		message string
+		flush   bool
+	}
+	cases := map[string]struct {
+		steps      []step
+		wantEvents []string
+	}{
+		"first event is recorded right away": {
+			steps: []step{
+				{message: "first"},
+			},
+			wantEvents: []string{
+				"Warning Pending first",
+			},
+		},
+		"repeated events are summarized when the interval ends": {
+			steps: []step{
+				{message: "first"},
+				{elapsed: time.Second, message: "second"},
+				{elapsed: 2 * time.Second, message: "third"},
+				{elapsed: 30 * time.Second, flush: true},
+				{elapsed: time.Minute, flush: true},
+			},
+			wantEvents: []string{
+				"Warning Pending first",
+				"Warning Pending Repeated 2 times in the last 1m0s: third",
+			},
+		},
+		"summary is recorded before the next event after the interval": {
+			steps: []step{
+				{message: "first"},
+				{elapsed: time.Second, message: "second"},
+				{elapsed: 2 * time.Minute, message: "third"},
+			},
+			wantEvents: []string{
+				"Warning Pending first",
+				"Warning Pending Repeated 1 times in the last 1m0s: second",
+				"Warning Pending third",
+			},
+		},
+		"object is forgotten after an interval without events": {
+			steps: []step{
+				{message: "first"},
+				{elapsed: time.Minute, flush: true},
+				{elapsed: time.Minute + time.Second, message: "second"},
+			},
+			wantEvents: []string{
+				"Warning Pending first",
+				"Warning Pending second",
+			},
+		},
+		"summaries keep being recorded while the events repeat": {
+			steps: []step{
+				{message: "first"},
+				{elapsed: time.Second, message: "second"},
+				{elapsed: time.Minute, flush: true},
+				{elapsed: time.Minute + time.Second, message: "third"},
+				{elapsed: 2 * time.Minute, flush: true},
+				{elapsed: 3 * time.Minute, flush: true},
+			},
+			wantEvents: []string{
+				"Warning Pending first",
+				"Warning Pending Repeated 1 times in the last 1m0s: second",
+				"Warning Pending Repeated 1 times in the last 1m0s: third",
+			},
+		},
+		"objects are aggregated separately": {
+			steps: []step{
+				{message: "a first"},
+				{elapsed: time.Second, other: true, message: "b first"},
+				{elapsed: 2 * time.Second, message: "a second"},
+				{elapsed: 3 * time.Second, other: true, message: "b second"},
+			},
+			wantEvents: []string{
+				"Warning Pending a first",
+				"Warning Pending b first",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			fakeClock := testingclock.NewFakeClock(now)
+			aggregator := NewAggregator(recorder, time.Minute, fakeClock)
+			for _, s := range tc.steps {
+				fakeClock.SetTime(now.Add(s.elapsed))
+				if s.flush {
+					aggregator.Flush()
+					continue
+				}
+				wl := wlA
+				if s.other {
+					wl = wlB
+				}
+				aggregator.Eventf(wl, corev1.EventTypeWarning, "Pending", s.message)
+			}
+			close(recorder.Events)
+			var gotEvents []string
+			for e := range recorder.Events {
+				gotEvents = append(gotEvents, e)
+			}
+			if diff := cmp.Diff(tc.wantEvents, gotEvents); diff != "" {
+				t.Errorf("Unexpected events (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
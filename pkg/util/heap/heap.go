@@ -31,22 +31,20 @@ type lessFunc[T any] func(a, b *T) bool
 type keyFunc[T any] func(obj *T) string
 
 type heapItem[T any] struct {
+	key   string
 	obj   *T
 	index int
 }
 
-type itemKeyValue[T any] struct {
-	key string
-	obj *T
-}
-
 // data is an internal struct that implements the standard heap interface
 // and keeps the data stored in the heap.
 type data[T any] struct {
-	// items is a map from key of the objects to the objects and their index
+	// items is a map from key of the objects to the objects and their index.
 	items map[string]*heapItem[T]
-	// keys keeps the keys of the objects ordered according to the heap invariant.
-	keys     []string
+	// queue keeps the items ordered according to the heap invariant. The
+	// items are kept in the slice, so that comparing and swapping them, which
+	// are the hot paths of the heap, don't require looking up the map.
+	queue    []*heapItem[T]
 	keyFunc  keyFunc[T]
 	lessFunc lessFunc[T]
 }
@@ -54,53 +52,37 @@ type data[T any] struct {
 // Less compares two objects and returns true if the first one should go
 // in front of the second one in the heap.
 func (h *data[T]) Less(i, j int) bool {
-	if i > h.Len() || j > h.Len() {
-		return false
-	}
-	a, ok := h.items[h.keys[i]]
-	if !ok {
-		return false
-	}
-	b, ok := h.items[h.keys[j]]
-	if !ok {
-		return false
-	}
-	return h.lessFunc(a.obj, b.obj)
+	return h.lessFunc(h.queue[i].obj, h.queue[j].obj)
 }
 
 // Len returns the number of items in the Heap.
 func (h *data[T]) Len() int {
-	return len(h.keys)
+	return len(h.queue)
 }
 
 // Swap implements swapping of two elements in the heap. This is a part of standard
 // heap interface and should never be called directly.
 func (h *data[T]) Swap(i, j int) {
-	h.keys[i], h.keys[j] = h.keys[j], h.keys[i]
-	h.items[h.keys[i]].index = i
-	h.items[h.keys[j]].index = j
+	h.queue[i], h.queue[j] = h.queue[j], h.queue[i]
+	h.queue[i].index = i
+	h.queue[j].index = j
 }
 
 // Push is supposed to be called by heap.Push only.
-func (h *data[T]) Push(kv interface{}) {
-	keyValue := kv.(itemKeyValue[T])
-	h.items[keyValue.key] = &heapItem[T]{
-		obj:   keyValue.obj,
-		index: len(h.keys),
-	}
-	h.keys = append(h.keys, keyValue.key)
+func (h *data[T]) Push(x interface{}) {
+	item := x.(*heapItem[T])
+	item.index = len(h.queue)
+	h.items[item.key] = item
+	h.queue = append(h.queue, item)
 }
 
 // Pop is supposed to be called by heap.Pop only.
 func (h *data[T]) Pop() interface{} {
-	key := h.keys[len(h.keys)-1]
-	h.keys = h.keys[:len(h.keys)-1]
-	item, ok := h.items[key]
-	if !ok {
-		// This is an error
-		return nil
-	}
-	delete(h.items, key)
+	n := len(h.queue) - 1
+	item := h.queue[n]
+	h.queue[n] = nil
+	h.queue = h.queue[:n]
+	delete(h.items, item.key)
 	return item.obj
 }
 
@@ -114,11 +96,11 @@ type Heap[T any] struct {
 // The item will be updated if it already exists.
 func (h *Heap[T]) PushOrUpdate(obj *T) {
 	key := h.data.keyFunc(obj)
-	if _, exists := h.data.items[key]; exists {
-		h.data.items[key].obj = obj
-		heap.Fix(&h.data, h.data.items[key].index)
+	if item, exists := h.data.items[key]; exists {
+		item.obj = obj
+		heap.Fix(&h.data, item.index)
 	} else {
-		heap.Push(&h.data, itemKeyValue[T]{key, obj})
+		heap.Push(&h.data, &heapItem[T]{key: key, obj: obj})
 	}
 }
 
@@ -130,7 +112,7 @@ func (h *Heap[T]) PushIfNotPresent(obj *T) (added bool) {
 		return false
 	}
 
-	heap.Push(&h.data, itemKeyValue[T]{key, obj})
+	heap.Push(&h.data, &heapItem[T]{key: key, obj: obj})
 	return true
 }
 
@@ -148,6 +130,69 @@ func (h *Heap[T]) Pop() *T {
 	return heap.Pop(&h.data).(*T)
 }
 
+// Peek returns the head of the heap without removing it, or nil if the heap
+// is empty.
+func (h *Heap[T]) Peek() *T {
+	if len(h.data.queue) == 0 {
+		return nil
+	}
+	return h.data.queue[0].obj
+}
+
+// Top returns up to n items from the head of the heap, in order, without
+// removing them. It only visits the items which can be among the first n, so
+// its cost doesn't depend on the number of items in the heap.
+func (h *Heap[T]) Top(n int) []*T {
+	n = min(n, h.Len())
+	top := make([]*T, 0, n)
+	if n == 0 {
+		return top
+	}
+	// The next item is always the head of the candidates, which are the
+	// children, in the queue, of the items already taken.
+	candidates := &indexHeap[T]{data: &h.data, indexes: []int{0}}
+	for len(top) < n {
+		i := heap.Pop(candidates).(int)
+		top = append(top, h.data.queue[i].obj)
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < len(h.data.queue) {
+				heap.Push(candidates, child)
+			}
+		}
+	}
+	return top
+}
+
+// indexHeap is a heap of indexes of the queue of data, ordered like the
+// items at these indexes.
+type indexHeap[T any] struct {
+	data    *data[T]
+	indexes []int
+}
+
+func (h *indexHeap[T]) Len() int {
+	return len(h.indexes)
+}
+
+func (h *indexHeap[T]) Less(i, j int) bool {
+	return h.data.Less(h.indexes[i], h.indexes[j])
+}
+
+func (h *indexHeap[T]) Swap(i, j int) {
+	h.indexes[i], h.indexes[j] = h.indexes[j], h.indexes[i]
+}
+
+func (h *indexHeap[T]) Push(x interface{}) {
+	h.indexes = append(h.indexes, x.(int))
+}
+
+func (h *indexHeap[T]) Pop() interface{} {
+	n := len(h.indexes) - 1
+	i := h.indexes[n]
+	h.indexes = h.indexes[:n]
+	return i
+}
+
 // GetByKey returns the requested item, or sets exists=false.
 func (h *Heap[T]) GetByKey(key string) *T {
 	item, exists := h.data.items[key]
@@ -165,7 +210,7 @@ func (h *Heap[T]) Len() int {
 // List returns a list of all the items.
 func (h *Heap[T]) List() []*T {
 	list := make([]*T, 0, h.Len())
-	for _, item := range h.data.items {
+	for _, item := range h.data.queue {
 		list = append(list, item.obj)
 	}
 	return list
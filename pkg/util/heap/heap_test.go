@@ -200,7 +200,7 @@ func TestHeap_Update(t *testing.T) {
 
 	// Update an item to a value that should push it to the head.
 	h.PushOrUpdate(mkHeapObj("baz", 0))
-	if h.data.keys[0] != "baz" || h.data.items["baz"].index != 0 {
+	if h.data.queue[0].key != "baz" || h.data.items["baz"].index != 0 {
 		t.Fatalf("expected baz to be at the head")
 	}
 	item := h.Pop()
@@ -209,11 +209,61 @@ func TestHeap_Update(t *testing.T) {
 	}
 	// Update bar to push it farther back in the queue.
 	h.PushOrUpdate(mkHeapObj("bar", 100))
-	if h.data.keys[0] != "foo" || h.data.items["foo"].index != 0 {
+	if h.data.queue[0].key != "foo" || h.data.items["foo"].index != 0 {
 		t.Fatalf("expected foo to be at the head")
 	}
 }
 
+// TestHeap_Peek tests Heap.Peek and ensures that the head is not removed.
+func TestHeap_Peek(t *testing.T) {
+	h := New(testHeapObjectKeyFunc, compareInts)
+	if obj := h.Peek(); obj != nil {
+		t.Fatalf("didn't expect to get any object from an empty heap")
+	}
+	h.PushOrUpdate(mkHeapObj("foo", 10))
+	h.PushOrUpdate(mkHeapObj("bar", 1))
+	h.PushOrUpdate(mkHeapObj("baz", 11))
+
+	obj := h.Peek()
+	if obj == nil || obj.val != 1 {
+		t.Fatalf("expected bar to be at the head, got %v", obj)
+	}
+	if h.Len() != 3 {
+		t.Fatalf("expected the head to be kept in the heap")
+	}
+	if item := h.Pop(); item != obj {
+		t.Fatalf("expected to pop the peeked object, got %v", item)
+	}
+}
+
+// TestHeap_Top tests Heap.Top and ensures that the items are returned in
+// order and kept in the heap.
+func TestHeap_Top(t *testing.T) {
+	h := New(testHeapObjectKeyFunc, compareInts)
+	if top := h.Top(3); len(top) != 0 {
+		t.Fatalf("didn't expect to get any object from an empty heap, got %v", top)
+	}
+	const amount = 500
+	for i := amount; i > 0; i-- {
+		h.PushOrUpdate(mkHeapObj(string([]rune{'a', rune(i)}), i))
+	}
+
+	for _, n := range []int{1, 10, amount, amount + 1} {
+		top := h.Top(n)
+		if want := min(n, amount); len(top) != want {
+			t.Fatalf("expected %d items, got %d", want, len(top))
+		}
+		for i, obj := range top {
+			if obj.val != i+1 {
+				t.Fatalf("got %v at position %d, expected the value %d", obj, i, i+1)
+			}
+		}
+	}
+	if h.Len() != amount {
+		t.Fatalf("expected the items to be kept in the heap, got %d items", h.Len())
+	}
+}
+
 // TestHeap_GetByKey tests Heap.GetByKey and is very similar to TestHeap_Get.
 func TestHeap_GetByKey(t *testing.T) {
 	h := New(testHeapObjectKeyFunc, compareInts)
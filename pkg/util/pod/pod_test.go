@@ -202,6 +202,49 @@ func TestGate(t *testing.T) {
 	}
 }
 
+func TestIsActive(t *testing.T) {
+	now := metav1.Now()
+	testCases := map[string]struct {
+		pod  corev1.Pod
+		want bool
+	}{
+		"running": {
+			pod: corev1.Pod{
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			want: true,
+		},
+		"succeeded": {
+			pod: corev1.Pod{
+				Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+			},
+			want: false,
+		},
+		"failed": {
+			pod: corev1.Pod{
+				Status: corev1.PodStatus{Phase: corev1.PodFailed},
+			},
+			want: false,
+		},
+		"being deleted": {
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			want: false,
+		},
+	}
+
+	for desc, tc := range testCases {
+		t.Run(desc, func(t *testing.T) {
+			got := IsActive(&tc.pod)
+			if got != tc.want {
+				t.Errorf("Unexpected result: want=%v, got=%v", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestReadUIntFromLabel(t *testing.T) {
 	testCases := map[string]struct {
 		obj     client.Object
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submitter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+var annotations = []string{
+	constants.SubmitterUserAnnotation,
+	constants.SubmitterGroupsAnnotation,
+	constants.SubmitterServiceAccountAnnotation,
+}
+
+// Recorder records the identity of the users submitting jobs and workloads,
+// from the admission requests, in annotations of the objects.
+type Recorder struct {
+	hash         bool
+	recordGroups bool
+}
+
+// NewRecorder returns a Recorder for the configuration, or nil when the
+// identity of the submitters isn't recorded.
+func NewRecorder(cfg *configapi.SubmitterIdentity) *Recorder {
+	if cfg == nil {
+		return nil
+	}
+	return &Recorder{
+		hash:         cfg.Redaction == configapi.HashSubmitterRedaction,
+		recordGroups: ptr.Deref(cfg.RecordGroups, true),
+	}
+}
+
+// Record sets the annotations with the identity of the user of the admission
+// request in the context, when the object is created. The annotations set by
+// the user are overwritten, so that they can't be forged, except in the
+// objects created by other controllers, which keep the identity copied from
+// their owners.
+// It doesn't record anything when the recorder is nil.
+func (r *Recorder) Record(ctx context.Context, obj client.Object) error {
+	if r == nil {
+		return nil
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting the admission request: %w", err)
+	}
+	if req.Operation != admissionv1.Create {
+		return nil
+	}
+	objAnnotations := obj.GetAnnotations()
+	if metav1.GetControllerOf(obj) != nil && objAnnotations[constants.SubmitterUserAnnotation] != "" {
+		return nil
+	}
+	if objAnnotations == nil {
+		objAnnotations = make(map[string]string, len(annotations))
+	}
+	for _, k := range annotations {
+		delete(objAnnotations, k)
+	}
+	user := req.UserInfo.Username
+	objAnnotations[constants.SubmitterUserAnnotation] = r.redact(user)
+	if r.recordGroups && len(req.UserInfo.Groups) > 0 {
+		groups := make([]string, len(req.UserInfo.Groups))
+		for i, g := range req.UserInfo.Groups {
+			groups[i] = r.redact(g)
+		}
+		objAnnotations[constants.SubmitterGroupsAnnotation] = strings.Join(groups, ",")
+	}
+	if namespace, name, err := serviceaccount.SplitUsername(user); err == nil {
+		objAnnotations[constants.SubmitterServiceAccountAnnotation] = r.redact(namespace + "/" + name)
+	}
+	obj.SetAnnotations(objAnnotations)
+	return nil
+}
+
+func (r *Recorder) redact(s string) string {
+	if !r.hash {
+		return s
+	}
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// CopyAnnotations copies the annotations with the identity of the submitter
+// from src to dst, which is allocated if needed, and returns dst.
+func CopyAnnotations(dst, src map[string]string) map[string]string {
+	for _, k := range annotations {
+		if v, found := src[k]; found {
+			if dst == nil {
+				dst = make(map[string]string, len(annotations))
+			}
+			dst[k] = v
+		}
+	}
+	return dst
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submitter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+func TestRecord(t *testing.T) {
+	owner := metav1.OwnerReference{APIVersion: "jobset.x-k8s.io/v1alpha2", Kind: "JobSet", Name: "parent", Controller: ptr.To(true)}
+	cases := map[string]struct {
+		cfg             *configapi.SubmitterIdentity
+		operation       admissionv1.Operation
+		user            authenticationv1.UserInfo
+		owners          []metav1.OwnerReference
+		annotations     map[string]string
+		wantAnnotations map[string]string
+	}{
+		"recording disabled": {
+			operation: admissionv1.Create,
+			user:      authenticationv1.UserInfo{Username: "alice"},
+		},
+		"user and groups": {
+			cfg:       &configapi.SubmitterIdentity{},
+			operation: admissionv1.Create,
+			user:      authenticationv1.UserInfo{Username: "alice", Groups: []string{"team-a", "system:authenticated"}},
+			wantAnnotations: map[string]string{
+				constants.SubmitterUserAnnotation:   "alice",
+				constants.SubmitterGroupsAnnotation: "team-a,system:authenticated",
+			},
+		},
+		"service account without groups": {
+			cfg:       &configapi.SubmitterIdentity{RecordGroups: ptr.To(false)},
+			operation: admissionv1.Create,
+			user:      authenticationv1.UserInfo{Username: "system:serviceaccount:ns:pipeline", Groups: []string{"system:serviceaccounts"}},
+			wantAnnotations: map[string]string{
+				constants.SubmitterUserAnnotation:           "system:serviceaccount:ns:pipeline",
+				constants.SubmitterServiceAccountAnnotation: "ns/pipeline",
+			},
+		},
+		"hashed identities": {
+			cfg:       &configapi.SubmitterIdentity{Redaction: configapi.HashSubmitterRedaction},
+			operation: admissionv1.Create,
+			user:      authenticationv1.UserInfo{Username: "alice", Groups: []string{"team-a"}},
+			wantAnnotations: map[string]string{
+				constants.SubmitterUserAnnotation:   "2bd806c97f0e00af1a1fc3328fa763a9269723c8db8fac4f93af71db186d6e90",
+				constants.SubmitterGroupsAnnotation: "96c2886c51d1dfb4901d9feccff66213ce3e27406282ff6f602a4258a33dacec",
+			},
+		},
+		"forged identity": {
+			cfg:       &configapi.SubmitterIdentity{},
+			operation: admissionv1.Create,
+			user:      authenticationv1.UserInfo{Username: "alice"},
+			annotations: map[string]string{
+				constants.SubmitterUserAnnotation:   "bob",
+				constants.SubmitterGroupsAnnotation: "admins",
+				"other":                             "value",
+			},
+			wantAnnotations: map[string]string{
+				constants.SubmitterUserAnnotation: "alice",
+				"other":                           "value",
+			},
+		},
+		"identity of the owner": {
+			cfg:       &configapi.SubmitterIdentity{},
+			operation: admissionv1.Create,
+			user:      authenticationv1.UserInfo{Username: "system:serviceaccount:jobset-system:controller"},
+			owners:    []metav1.OwnerReference{owner},
+			annotations: map[string]string{
+				constants.SubmitterUserAnnotation: "alice",
+			},
+			wantAnnotations: map[string]string{
+				constants.SubmitterUserAnnotation: "alice",
+			},
+		},
+		"update": {
+			cfg:       &configapi.SubmitterIdentity{},
+			operation: admissionv1.Update,
+			user:      authenticationv1.UserInfo{Username: "bob"},
+			annotations: map[string]string{
+				constants.SubmitterUserAnnotation: "alice",
+			},
+			wantAnnotations: map[string]string{
+				constants.SubmitterUserAnnotation: "alice",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewRecorder(tc.cfg)
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "job",
+					Namespace:       "ns",
+					OwnerReferences: tc.owners,
+					Annotations:     tc.annotations,
+				},
+			}
+			ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: tc.operation,
+					UserInfo:  tc.user,
+				},
+			})
+			if err := r.Record(ctx, job); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantAnnotations, job.Annotations); diff != "" {
+				t.Errorf("Unexpected annotations (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCopyAnnotations(t *testing.T) {
+	got := CopyAnnotations(map[string]string{"provreq.kueue.x-k8s.io/key": "value"}, map[string]string{
+		constants.SubmitterUserAnnotation: "alice",
+		"other":                           "value",
+	})
+	want := map[string]string{
+		"provreq.kueue.x-k8s.io/key":      "value",
+		constants.SubmitterUserAnnotation: "alice",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected annotations (-want,+got):\n%s", diff)
+	}
+	if got := CopyAnnotations(nil, map[string]string{"other": "value"}); got != nil {
+		t.Errorf("Unexpected annotations allocated without the identity of a submitter: %v", got)
+	}
+}
@@ -17,11 +17,13 @@ limitations under the License.
 package tas
 
 import (
+	"slices"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 )
 
 type TopologyDomainID string
@@ -62,3 +64,110 @@ func IsNodeStatusConditionTrue(conditions []corev1.NodeCondition, conditionType
 	}
 	return false
 }
+
+// TopologyDomains returns the list of topology domains of the assignment,
+// expanding the domainGroups if the assignment is compact.
+func TopologyDomains(ta *kueue.TopologyAssignment) []kueue.TopologyDomainAssignment {
+	if len(ta.DomainGroups) == 0 {
+		return ta.Domains
+	}
+	var result []kueue.TopologyDomainAssignment
+	for _, group := range ta.DomainGroups {
+		for i, lowestLevelValue := range group.LowestLevelValues {
+			values := make([]string, len(group.Values)+1)
+			copy(values, group.Values)
+			values[len(group.Values)] = group.LowestLevelPrefix + lowestLevelValue + group.LowestLevelSuffix
+			count := group.Counts[0]
+			if len(group.Counts) > 1 {
+				count = group.Counts[i]
+			}
+			result = append(result, kueue.TopologyDomainAssignment{
+				Values: values,
+				Count:  count,
+			})
+		}
+	}
+	return result
+}
+
+// CompactTopologyAssignment returns the assignment with its domains grouped
+// by the values of the levels above the lowest level, keeping their order.
+// The shared prefix and suffix of the lowest level values of each group, and
+// the counts, if they are all equal, are stored once.
+func CompactTopologyAssignment(ta *kueue.TopologyAssignment) *kueue.TopologyAssignment {
+	if ta == nil || len(ta.Domains) == 0 {
+		return ta
+	}
+	result := &kueue.TopologyAssignment{
+		Levels: slices.Clone(ta.Levels),
+	}
+	lowestLevelIdx := len(ta.Levels) - 1
+	for start := 0; start < len(ta.Domains); {
+		upperValues := ta.Domains[start].Values[:lowestLevelIdx]
+		end := start + 1
+		for end < len(ta.Domains) && slices.Equal(ta.Domains[end].Values[:lowestLevelIdx], upperValues) {
+			end++
+		}
+		result.DomainGroups = append(result.DomainGroups, compactDomainGroup(upperValues, ta.Domains[start:end]))
+		start = end
+	}
+	return result
+}
+
+func compactDomainGroup(upperValues []string, domains []kueue.TopologyDomainAssignment) kueue.TopologyDomainGroupAssignment {
+	lowestLevelIdx := len(upperValues)
+	values := make([]string, len(domains))
+	counts := make([]int32, len(domains))
+	sameCounts := true
+	for i, domain := range domains {
+		values[i] = domain.Values[lowestLevelIdx]
+		counts[i] = domain.Count
+		sameCounts = sameCounts && counts[i] == counts[0]
+	}
+	group := kueue.TopologyDomainGroupAssignment{
+		Values:            slices.Clone(upperValues),
+		LowestLevelValues: values,
+		Counts:            counts,
+	}
+	if len(group.Values) == 0 {
+		group.Values = nil
+	}
+	if sameCounts {
+		group.Counts = counts[:1]
+	}
+	if len(values) > 1 {
+		group.LowestLevelPrefix = commonPrefix(values)
+		for i := range values {
+			values[i] = values[i][len(group.LowestLevelPrefix):]
+		}
+		group.LowestLevelSuffix = commonSuffix(values)
+		for i := range values {
+			values[i] = values[i][:len(values[i])-len(group.LowestLevelSuffix)]
+		}
+	}
+	return group
+}
+
+func commonPrefix(values []string) string {
+	prefix := values[0]
+	for _, v := range values[1:] {
+		n := 0
+		for n < len(prefix) && n < len(v) && prefix[n] == v[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	return prefix
+}
+
+func commonSuffix(values []string) string {
+	suffix := values[0]
+	for _, v := range values[1:] {
+		n := 0
+		for n < len(suffix) && n < len(v) && suffix[len(suffix)-1-n] == v[len(v)-1-n] {
+			n++
+		}
+		suffix = suffix[len(suffix)-n:]
+	}
+	return suffix
+}
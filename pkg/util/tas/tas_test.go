@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tas
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+func TestCompactTopologyAssignment(t *testing.T) {
+	cases := map[string]struct {
+		assignment *kueue.TopologyAssignment
+		want       *kueue.TopologyAssignment
+	}{
+		"nil assignment": {},
+		"single level": {
+			assignment: &kueue.TopologyAssignment{
+				Levels: []string{"kubernetes.io/hostname"},
+				Domains: []kueue.TopologyDomainAssignment{
+					{Values: []string{"node-1.zone-a"}, Count: 4},
+					{Values: []string{"node-2.zone-a"}, Count: 4},
+					{Values: []string{"node-13.zone-a"}, Count: 4},
+				},
+			},
+			want: &kueue.TopologyAssignment{
+				Levels: []string{"kubernetes.io/hostname"},
+				DomainGroups: []kueue.TopologyDomainGroupAssignment{{
+					LowestLevelPrefix: "node-",
+					LowestLevelSuffix: ".zone-a",
+					LowestLevelValues: []string{"1", "2", "13"},
+					Counts:            []int32{4},
+				}},
+			},
+		},
+		"multiple levels": {
+			assignment: &kueue.TopologyAssignment{
+				Levels: []string{"block", "rack", "kubernetes.io/hostname"},
+				Domains: []kueue.TopologyDomainAssignment{
+					{Values: []string{"b1", "r1", "node-1"}, Count: 2},
+					{Values: []string{"b1", "r1", "node-2"}, Count: 1},
+					{Values: []string{"b1", "r2", "node-3"}, Count: 2},
+					{Values: []string{"b2", "r1", "node-4"}, Count: 2},
+					{Values: []string{"b2", "r1", "node-44"}, Count: 2},
+				},
+			},
+			want: &kueue.TopologyAssignment{
+				Levels: []string{"block", "rack", "kubernetes.io/hostname"},
+				DomainGroups: []kueue.TopologyDomainGroupAssignment{
+					{
+						Values:            []string{"b1", "r1"},
+						LowestLevelPrefix: "node-",
+						LowestLevelValues: []string{"1", "2"},
+						Counts:            []int32{2, 1},
+					},
+					{
+						Values:            []string{"b1", "r2"},
+						LowestLevelValues: []string{"node-3"},
+						Counts:            []int32{2},
+					},
+					{
+						Values:            []string{"b2", "r1"},
+						LowestLevelPrefix: "node-4",
+						LowestLevelValues: []string{"", "4"},
+						Counts:            []int32{2},
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := CompactTopologyAssignment(tc.assignment)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected compact assignment (-want,+got):\n%s", diff)
+			}
+			if tc.assignment == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.assignment.Domains, TopologyDomains(got)); diff != "" {
+				t.Errorf("Unexpected domains of the compact assignment (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
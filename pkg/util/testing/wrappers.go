@@ -32,6 +32,7 @@ import (
 
 	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
 	utilResource "sigs.k8s.io/kueue/pkg/util/resource"
 )
 
@@ -271,6 +272,11 @@ func (w *WorkloadWrapper) ReclaimablePods(rps ...kueue.ReclaimablePod) *Workload
 	return w
 }
 
+func (w *WorkloadWrapper) RecommendedRequests(psrs ...kueue.PodSetRequest) *WorkloadWrapper {
+	w.Status.RecommendedRequests = psrs
+	return w
+}
+
 func (w *WorkloadWrapper) Labels(l map[string]string) *WorkloadWrapper {
 	w.ObjectMeta.Labels = l
 	return w
@@ -349,6 +355,16 @@ func (w *WorkloadWrapper) RequeueState(count *int32, requeueAt *metav1.Time) *Wo
 	return w
 }
 
+func (w *WorkloadWrapper) Deactivation(d *kueue.WorkloadDeactivation) *WorkloadWrapper {
+	w.Status.Deactivation = d
+	return w
+}
+
+func (w *WorkloadWrapper) Eviction(e *kueue.WorkloadEviction) *WorkloadWrapper {
+	w.Status.Eviction = e
+	return w
+}
+
 func (w *WorkloadWrapper) ResourceVersion(v string) *WorkloadWrapper {
 	w.SetResourceVersion(v)
 	return w
@@ -613,6 +629,15 @@ func (q *LocalQueueWrapper) StopPolicy(p kueue.StopPolicy) *LocalQueueWrapper {
 	return q
 }
 
+// PendingTimeout sets the pending timeout and the fallback queue of the local queue.
+func (q *LocalQueueWrapper) PendingTimeout(timeoutSeconds int32, fallbackQueueName string) *LocalQueueWrapper {
+	q.Spec.PendingTimeout = &kueue.PendingTimeout{
+		TimeoutSeconds:    timeoutSeconds,
+		FallbackQueueName: fallbackQueueName,
+	}
+	return q
+}
+
 // PendingWorkloads updates the pendingWorkloads in status.
 func (q *LocalQueueWrapper) PendingWorkloads(n int32) *LocalQueueWrapper {
 	q.Status.PendingWorkloads = n
@@ -625,6 +650,12 @@ func (q *LocalQueueWrapper) AdmittedWorkloads(n int32) *LocalQueueWrapper {
 	return q
 }
 
+// FlavorUsage sets the flavorUsage in status.
+func (q *LocalQueueWrapper) FlavorUsage(usage ...kueue.LocalQueueFlavorUsage) *LocalQueueWrapper {
+	q.Status.FlavorUsage = usage
+	return q
+}
+
 // Condition sets a condition on the LocalQueue.
 func (q *LocalQueueWrapper) Condition(conditionType string, status metav1.ConditionStatus, reason, message string, generation int64) *LocalQueueWrapper {
 	apimeta.SetStatusCondition(&q.Status.Conditions, metav1.Condition{
@@ -670,6 +701,224 @@ func (c *CohortWrapper) ResourceGroup(flavors ...kueue.FlavorQuotas) *CohortWrap
 	return c
 }
 
+// QueueingStrategy sets the queueing strategy of the Cohort.
+func (c *CohortWrapper) QueueingStrategy(strategy kueue.QueueingStrategy) *CohortWrapper {
+	c.Spec.QueueingStrategy = strategy
+	return c
+}
+
+// PriorityClassMapping adds a mapping of a WorkloadPriorityClass to the Cohort.
+func (c *CohortWrapper) PriorityClassMapping(from, to string) *CohortWrapper {
+	c.Spec.PriorityClassMappings = append(c.Spec.PriorityClassMappings, kueue.PriorityClassMapping{From: from, To: to})
+	return c
+}
+
+// TenantWrapper wraps a Tenant.
+type TenantWrapper struct{ kueuealpha.Tenant }
+
+// MakeTenant creates a wrapper for a Tenant.
+func MakeTenant(name string) *TenantWrapper {
+	return &TenantWrapper{kueuealpha.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}}
+}
+
+// Obj returns the inner Tenant.
+func (t *TenantWrapper) Obj() *kueuealpha.Tenant {
+	return &t.Tenant
+}
+
+// LocalQueue adds a LocalQueue to the Tenant.
+func (t *TenantWrapper) LocalQueue(namespace, name string) *TenantWrapper {
+	t.Spec.LocalQueues = append(t.Spec.LocalQueues, kueuealpha.TenantLocalQueue{Namespace: namespace, Name: name})
+	return t
+}
+
+// UsageLimit sets the usage limit of the Tenant for a resource.
+func (t *TenantWrapper) UsageLimit(name corev1.ResourceName, quantity string) *TenantWrapper {
+	if t.Spec.UsageLimits == nil {
+		t.Spec.UsageLimits = corev1.ResourceList{}
+	}
+	t.Spec.UsageLimits[name] = resource.MustParse(quantity)
+	return t
+}
+
+// FairWeight sets the fair sharing weight of the Tenant.
+func (t *TenantWrapper) FairWeight(w resource.Quantity) *TenantWrapper {
+	if t.Spec.FairSharing == nil {
+		t.Spec.FairSharing = &kueue.FairSharing{}
+	}
+	t.Spec.FairSharing.Weight = &w
+	return t
+}
+
+// ResourceFairWeight sets the fair sharing weight of a resource of the Tenant.
+func (t *TenantWrapper) ResourceFairWeight(name corev1.ResourceName, w resource.Quantity) *TenantWrapper {
+	if t.Spec.FairSharing == nil {
+		t.Spec.FairSharing = &kueue.FairSharing{}
+	}
+	t.Spec.FairSharing.ResourceWeights = append(t.Spec.FairSharing.ResourceWeights, kueue.ResourceWeight{Name: name, Weight: w})
+	return t
+}
+
+// BudgetWrapper wraps a Budget.
+type BudgetWrapper struct{ kueuealpha.Budget }
+
+// MakeBudget creates a wrapper for a Budget.
+func MakeBudget(name, namespace string) *BudgetWrapper {
+	return &BudgetWrapper{kueuealpha.Budget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: kueuealpha.BudgetSpec{
+			EnforcementAction: kueuealpha.BudgetWarn,
+		},
+	}}
+}
+
+// Obj returns the inner Budget.
+func (b *BudgetWrapper) Obj() *kueuealpha.Budget {
+	return &b.Budget
+}
+
+// LocalQueues sets the LocalQueues bound to the Budget.
+func (b *BudgetWrapper) LocalQueues(names ...string) *BudgetWrapper {
+	b.Spec.LocalQueues = names
+	return b
+}
+
+// ResourceHours sets the limit of the Budget, in resource-hours, for a
+// resource.
+func (b *BudgetWrapper) ResourceHours(name corev1.ResourceName, quantity string) *BudgetWrapper {
+	if b.Spec.ResourceHours == nil {
+		b.Spec.ResourceHours = corev1.ResourceList{}
+	}
+	b.Spec.ResourceHours[name] = resource.MustParse(quantity)
+	return b
+}
+
+// CostLimit sets the cost limit of the Budget.
+func (b *BudgetWrapper) CostLimit(limit string) *BudgetWrapper {
+	if b.Spec.Cost == nil {
+		b.Spec.Cost = &kueuealpha.BudgetCost{}
+	}
+	b.Spec.Cost.Limit = resource.MustParse(limit)
+	return b
+}
+
+// Price adds the price of a resource-hour of the resource in the flavor.
+func (b *BudgetWrapper) Price(flavor kueue.ResourceFlavorReference, name corev1.ResourceName, pricePerHour string) *BudgetWrapper {
+	if b.Spec.Cost == nil {
+		b.Spec.Cost = &kueuealpha.BudgetCost{}
+	}
+	b.Spec.Cost.Prices = append(b.Spec.Cost.Prices, kueuealpha.ResourcePrice{
+		Flavor:       flavor,
+		Resource:     name,
+		PricePerHour: resource.MustParse(pricePerHour),
+	})
+	return b
+}
+
+// EnforcementAction sets the action taken when the Budget is exceeded.
+func (b *BudgetWrapper) EnforcementAction(action kueuealpha.BudgetEnforcementAction) *BudgetWrapper {
+	b.Spec.EnforcementAction = action
+	return b
+}
+
+// Condition sets a condition on the Budget.
+func (b *BudgetWrapper) Condition(cond metav1.Condition) *BudgetWrapper {
+	apimeta.SetStatusCondition(&b.Status.Conditions, cond)
+	return b
+}
+
+// ScheduleWrapper wraps a Schedule.
+type ScheduleWrapper struct{ kueuealpha.Schedule }
+
+// MakeSchedule creates a wrapper for a Schedule in the UTC time zone.
+func MakeSchedule(name string) *ScheduleWrapper {
+	return &ScheduleWrapper{kueuealpha.Schedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kueuealpha.ScheduleSpec{
+			TimeZone: "UTC",
+		},
+	}}
+}
+
+// Obj returns the inner Schedule.
+func (s *ScheduleWrapper) Obj() *kueuealpha.Schedule {
+	return &s.Schedule
+}
+
+// TimeZone sets the time zone of the windows of the Schedule.
+func (s *ScheduleWrapper) TimeZone(tz string) *ScheduleWrapper {
+	s.Spec.TimeZone = tz
+	return s
+}
+
+// Window adds a window, from start to end in the HH:MM format, on the days
+// of the week, or every day if none.
+func (s *ScheduleWrapper) Window(start, end string, days ...kueuealpha.DayOfWeek) *ScheduleWrapper {
+	s.Spec.Windows = append(s.Spec.Windows, kueuealpha.ScheduleWindow{
+		DaysOfWeek: days,
+		Start:      start,
+		End:        end,
+	})
+	return s
+}
+
+// Open sets whether the ClusterQueues of the Schedule are open.
+func (s *ScheduleWrapper) Open(open bool) *ScheduleWrapper {
+	s.Status.Open = open
+	return s
+}
+
+// WorkloadTemplateWrapper wraps a WorkloadTemplate.
+type WorkloadTemplateWrapper struct{ kueuealpha.WorkloadTemplate }
+
+// MakeWorkloadTemplate creates a wrapper for a WorkloadTemplate.
+func MakeWorkloadTemplate(name string) *WorkloadTemplateWrapper {
+	return &WorkloadTemplateWrapper{kueuealpha.WorkloadTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}}
+}
+
+// Obj returns the inner WorkloadTemplate.
+func (t *WorkloadTemplateWrapper) Obj() *kueuealpha.WorkloadTemplate {
+	return &t.WorkloadTemplate
+}
+
+// PodSet adds an approved shape of a pod set, with the flavors it can be
+// assigned, or any if none.
+func (t *WorkloadTemplateWrapper) PodSet(name string, maxCount int32, maxRequests corev1.ResourceList, flavors ...kueue.ResourceFlavorReference) *WorkloadTemplateWrapper {
+	t.Spec.PodSets = append(t.Spec.PodSets, kueuealpha.WorkloadTemplatePodSet{
+		Name:        name,
+		MaxCount:    maxCount,
+		MaxRequests: maxRequests,
+		Flavors:     flavors,
+	})
+	return t
+}
+
+// MaxPriority sets the highest priority of the matching workloads.
+func (t *WorkloadTemplateWrapper) MaxPriority(p int32) *WorkloadTemplateWrapper {
+	t.Spec.MaxPriority = &p
+	return t
+}
+
+// SkippedAdmissionChecks sets the admission checks skipped by the matching
+// workloads.
+func (t *WorkloadTemplateWrapper) SkippedAdmissionChecks(checks ...string) *WorkloadTemplateWrapper {
+	t.Spec.SkippedAdmissionChecks = checks
+	return t
+}
+
 // ClusterQueueWrapper wraps a ClusterQueue.
 type ClusterQueueWrapper struct{ kueue.ClusterQueue }
 
@@ -759,6 +1008,13 @@ func (c *ClusterQueueWrapper) NamespaceSelector(s *metav1.LabelSelector) *Cluste
 	return c
 }
 
+// LocalQueueNamespaceSelector sets the selector of the namespaces of the
+// LocalQueues allowed to point at the ClusterQueue.
+func (c *ClusterQueueWrapper) LocalQueueNamespaceSelector(s *metav1.LabelSelector) *ClusterQueueWrapper {
+	c.Spec.LocalQueueNamespaceSelector = s
+	return c
+}
+
 // Preemption sets the preemption policies.
 func (c *ClusterQueueWrapper) Preemption(p kueue.ClusterQueuePreemption) *ClusterQueueWrapper {
 	c.Spec.Preemption = &p
@@ -777,6 +1033,67 @@ func (c *ClusterQueueWrapper) StopPolicy(p kueue.StopPolicy) *ClusterQueueWrappe
 	return c
 }
 
+// ShadowMode sets whether the cluster queue is in shadow mode.
+func (c *ClusterQueueWrapper) ShadowMode(enabled bool) *ClusterQueueWrapper {
+	c.Spec.ShadowMode = &enabled
+	return c
+}
+
+// AllowedPriorityClasses sets the priority classes allowed in the cluster queue.
+func (c *ClusterQueueWrapper) AllowedPriorityClasses(names ...string) *ClusterQueueWrapper {
+	c.Spec.AllowedPriorityClasses = names
+	return c
+}
+
+// PriorityClassMapping adds a mapping of a WorkloadPriorityClass to the cluster queue.
+func (c *ClusterQueueWrapper) PriorityClassMapping(from, to string) *ClusterQueueWrapper {
+	c.Spec.PriorityClassMappings = append(c.Spec.PriorityClassMappings, kueue.PriorityClassMapping{From: from, To: to})
+	return c
+}
+
+// AdmissionPolicies sets the admission policies of the ClusterQueue.
+func (c *ClusterQueueWrapper) AdmissionPolicies(policies ...kueue.AdmissionPolicy) *ClusterQueueWrapper {
+	c.Spec.AdmissionPolicies = policies
+	return c
+}
+
+// Schedule sets the Schedule opening and closing the ClusterQueue.
+func (c *ClusterQueueWrapper) Schedule(name string) *ClusterQueueWrapper {
+	c.Spec.Schedule = name
+	return c
+}
+
+// AdmitAll sets the annotation which makes the cluster queue admit all the workloads.
+func (c *ClusterQueueWrapper) AdmitAll() *ClusterQueueWrapper {
+	if c.Annotations == nil {
+		c.Annotations = make(map[string]string, 1)
+	}
+	c.Annotations[constants.AdmitAllAnnotation] = "true"
+	return c
+}
+
+// MaximumExecutionTimeSeconds sets the maximum execution time of the workloads admitted by the cluster queue.
+func (c *ClusterQueueWrapper) MaximumExecutionTimeSeconds(v int32) *ClusterQueueWrapper {
+	c.Spec.MaximumExecutionTimeSeconds = &v
+	return c
+}
+
+// QuotaShrinkPolicy sets the quota shrink policy.
+func (c *ClusterQueueWrapper) QuotaShrinkPolicy(action kueue.QuotaShrinkAction, evictionIntervalSeconds *int32) *ClusterQueueWrapper {
+	c.Spec.QuotaShrinkPolicy = &kueue.QuotaShrinkPolicy{
+		Action:                  action,
+		EvictionIntervalSeconds: evictionIntervalSeconds,
+	}
+	return c
+}
+
+// RequestsAccountingPolicy sets the source of the requests counted against
+// the quota.
+func (c *ClusterQueueWrapper) RequestsAccountingPolicy(source kueue.RequestsSource) *ClusterQueueWrapper {
+	c.Spec.RequestsAccountingPolicy = &kueue.RequestsAccountingPolicy{Source: source}
+	return c
+}
+
 // DeletionTimestamp sets a deletion timestamp for the cluster queue.
 func (c *ClusterQueueWrapper) DeletionTimestamp(t time.Time) *ClusterQueueWrapper {
 	c.ClusterQueue.DeletionTimestamp = ptr.To(metav1.NewTime(t).Rfc3339Copy())
@@ -799,6 +1116,15 @@ func (c *ClusterQueueWrapper) FairWeight(w resource.Quantity) *ClusterQueueWrapp
 	return c
 }
 
+// ResourceFairWeight sets the fair sharing weight of a resource.
+func (c *ClusterQueueWrapper) ResourceFairWeight(name corev1.ResourceName, w resource.Quantity) *ClusterQueueWrapper {
+	if c.Spec.FairSharing == nil {
+		c.Spec.FairSharing = &kueue.FairSharing{}
+	}
+	c.Spec.FairSharing.ResourceWeights = append(c.Spec.FairSharing.ResourceWeights, kueue.ResourceWeight{Name: name, Weight: w})
+	return c
+}
+
 // Condition sets a condition on the ClusterQueue.
 func (c *ClusterQueueWrapper) Condition(conditionType string, status metav1.ConditionStatus, reason, message string) *ClusterQueueWrapper {
 	apimeta.SetStatusCondition(&c.Status.Conditions, metav1.Condition{
@@ -834,6 +1160,18 @@ func (c *ClusterQueueWrapper) AdmittedWorkloads(n int32) *ClusterQueueWrapper {
 	return c
 }
 
+// FlavorsUsage sets the flavorsUsage in status.
+func (c *ClusterQueueWrapper) FlavorsUsage(usage ...kueue.FlavorUsage) *ClusterQueueWrapper {
+	c.Status.FlavorsUsage = usage
+	return c
+}
+
+// WeightedShare sets the weighted share of the fair sharing status.
+func (c *ClusterQueueWrapper) WeightedShare(share int64) *ClusterQueueWrapper {
+	c.Status.FairSharing = &kueue.FairSharingStatus{WeightedShare: share}
+	return c
+}
+
 // FlavorQuotasWrapper wraps a FlavorQuotas object.
 type FlavorQuotasWrapper struct{ kueue.FlavorQuotas }
 
@@ -1138,6 +1476,11 @@ func (ac *AdmissionCheckWrapper) Parameters(apigroup, kind, name string) *Admiss
 	return ac
 }
 
+func (ac *AdmissionCheckWrapper) PendingTimeout(d time.Duration) *AdmissionCheckWrapper {
+	ac.Spec.PendingTimeout = &metav1.Duration{Duration: d}
+	return ac
+}
+
 func (ac *AdmissionCheckWrapper) SingleInstanceInClusterQueue(singleInstance bool, reason, message string, observedGeneration int64) *AdmissionCheckWrapper {
 	cond := metav1.Condition{
 		Type:               kueue.AdmissionChecksSingleInstanceInClusterQueue,
@@ -1248,6 +1591,22 @@ func (mkc *MultiKueueClusterWrapper) KubeConfig(locationType kueue.LocationType,
 	return mkc
 }
 
+// KubeConfigSecretKey sets the key of the kubeconfig in its Secret.
+func (mkc *MultiKueueClusterWrapper) KubeConfigSecretKey(key string) *MultiKueueClusterWrapper {
+	mkc.Spec.KubeConfig.SecretKey = key
+	return mkc
+}
+
+// ServiceAccountToken sets the ServiceAccount whose bound tokens are used
+// to authenticate to the cluster.
+func (mkc *MultiKueueClusterWrapper) ServiceAccountToken(name string, audiences ...string) *MultiKueueClusterWrapper {
+	mkc.Spec.KubeConfig.ServiceAccountToken = &kueue.ServiceAccountTokenSource{
+		Name:      name,
+		Audiences: audiences,
+	}
+	return mkc
+}
+
 func (mkc *MultiKueueClusterWrapper) Active(state metav1.ConditionStatus, reason, message string, generation int64) *MultiKueueClusterWrapper {
 	cond := metav1.Condition{
 		Type:               kueue.MultiKueueClusterActive,
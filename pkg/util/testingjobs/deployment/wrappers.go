@@ -80,6 +80,15 @@ func (d *DeploymentWrapper) Label(k, v string) *DeploymentWrapper {
 	return d
 }
 
+// Annotation sets the annotation of the Deployment
+func (d *DeploymentWrapper) Annotation(k, v string) *DeploymentWrapper {
+	if d.Annotations == nil {
+		d.Annotations = make(map[string]string)
+	}
+	d.Annotations[k] = v
+	return d
+}
+
 // Queue updates the queue name of the Deployment
 func (d *DeploymentWrapper) Queue(q string) *DeploymentWrapper {
 	return d.Label(constants.QueueLabel, q)
@@ -132,3 +141,12 @@ func (d *DeploymentWrapper) PodTemplateSpecLabel(k, v string) *DeploymentWrapper
 func (d *DeploymentWrapper) PodTemplateSpecQueue(q string) *DeploymentWrapper {
 	return d.PodTemplateSpecLabel(constants.QueueLabel, q)
 }
+
+// PodTemplateSpecAnnotation sets the annotation of the pod template spec of the Deployment
+func (d *DeploymentWrapper) PodTemplateSpecAnnotation(k, v string) *DeploymentWrapper {
+	if d.Spec.Template.Annotations == nil {
+		d.Spec.Template.Annotations = make(map[string]string, 1)
+	}
+	d.Spec.Template.Annotations[k] = v
+	return d
+}
@@ -229,6 +229,12 @@ func (j *JobWrapper) Active(c int32) *JobWrapper {
 	return j
 }
 
+// Succeeded sets the .status.succeeded
+func (j *JobWrapper) Succeeded(c int32) *JobWrapper {
+	j.Status.Succeeded = c
+	return j
+}
+
 // Condition adds a condition
 func (j *JobWrapper) Condition(c batchv1.JobCondition) *JobWrapper {
 	j.Status.Conditions = append(j.Status.Conditions, c)
@@ -225,6 +225,15 @@ func (j *MPIJobWrapper) StatusConditions(c kfmpi.JobCondition) *MPIJobWrapper {
 	return j
 }
 
+// StatusReplicaSucceeded sets the number of succeeded pods of the replica type.
+func (j *MPIJobWrapper) StatusReplicaSucceeded(replicaType kfmpi.MPIReplicaType, succeeded int32) *MPIJobWrapper {
+	if j.Status.ReplicaStatuses == nil {
+		j.Status.ReplicaStatuses = make(map[kfmpi.MPIReplicaType]*kfmpi.ReplicaStatus)
+	}
+	j.Status.ReplicaStatuses[replicaType] = &kfmpi.ReplicaStatus{Succeeded: succeeded}
+	return j
+}
+
 func (j *MPIJobWrapper) Image(replicaType kfmpi.MPIReplicaType, image string, args []string) *MPIJobWrapper {
 	j.Spec.MPIReplicaSpecs[replicaType].Template.Spec.Containers[0].Image = image
 	j.Spec.MPIReplicaSpecs[replicaType].Template.Spec.Containers[0].Args = args
@@ -68,6 +68,12 @@ func (n *NodeWrapper) StatusAllocatable(resourceList corev1.ResourceList) *NodeW
 	return n
 }
 
+// StatusCapacity updates the capacity of the Node.
+func (n *NodeWrapper) StatusCapacity(resourceList corev1.ResourceList) *NodeWrapper {
+	n.Status.Capacity = resourceList
+	return n
+}
+
 // Taints appends the given taints to the Node.
 func (n *NodeWrapper) Taints(taints ...corev1.Taint) *NodeWrapper {
 	n.Spec.Taints = append(n.Spec.Taints, taints...)
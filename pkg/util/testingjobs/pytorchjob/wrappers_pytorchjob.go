@@ -221,6 +221,15 @@ func (j *PyTorchJobWrapper) StatusConditions(c kftraining.JobCondition) *PyTorch
 	return j
 }
 
+// StatusReplicaSucceeded sets the number of succeeded pods of the replica type.
+func (j *PyTorchJobWrapper) StatusReplicaSucceeded(replicaType kftraining.ReplicaType, succeeded int32) *PyTorchJobWrapper {
+	if j.Status.ReplicaStatuses == nil {
+		j.Status.ReplicaStatuses = make(map[kftraining.ReplicaType]*kftraining.ReplicaStatus)
+	}
+	j.Status.ReplicaStatuses[replicaType] = &kftraining.ReplicaStatus{Succeeded: succeeded}
+	return j
+}
+
 func (j *PyTorchJobWrapper) Image(replicaType kftraining.ReplicaType, image string, args []string) *PyTorchJobWrapper {
 	j.Spec.PyTorchReplicaSpecs[replicaType].Template.Spec.Containers[0].Image = image
 	j.Spec.PyTorchReplicaSpecs[replicaType].Template.Spec.Containers[0].Args = args
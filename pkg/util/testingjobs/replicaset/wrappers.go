@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicaset
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+// ReplicaSetWrapper wraps a ReplicaSet.
+type ReplicaSetWrapper struct {
+	appsv1.ReplicaSet
+}
+
+// MakeReplicaSet creates a wrapper for a ReplicaSet with a single container.
+func MakeReplicaSet(name, ns string) *ReplicaSetWrapper {
+	podLabels := map[string]string{
+		"app": fmt.Sprintf("%s-pod", name),
+	}
+	return &ReplicaSetWrapper{appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   ns,
+			Annotations: make(map[string]string, 1),
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: podLabels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: podLabels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:      "c",
+							Image:     "pause",
+							Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{}},
+						},
+					},
+					NodeSelector: map[string]string{},
+				},
+			},
+		},
+	}}
+}
+
+// Obj returns the inner ReplicaSet.
+func (rs *ReplicaSetWrapper) Obj() *appsv1.ReplicaSet {
+	return &rs.ReplicaSet
+}
+
+// Label sets the label of the ReplicaSet
+func (rs *ReplicaSetWrapper) Label(k, v string) *ReplicaSetWrapper {
+	if rs.Labels == nil {
+		rs.Labels = make(map[string]string)
+	}
+	rs.Labels[k] = v
+	return rs
+}
+
+// Annotation sets the annotation of the ReplicaSet
+func (rs *ReplicaSetWrapper) Annotation(k, v string) *ReplicaSetWrapper {
+	if rs.Annotations == nil {
+		rs.Annotations = make(map[string]string)
+	}
+	rs.Annotations[k] = v
+	return rs
+}
+
+// Queue updates the queue name of the ReplicaSet
+func (rs *ReplicaSetWrapper) Queue(q string) *ReplicaSetWrapper {
+	return rs.Label(constants.QueueLabel, q)
+}
+
+// Image sets an image to the default container.
+func (rs *ReplicaSetWrapper) Image(image string, args []string) *ReplicaSetWrapper {
+	rs.Spec.Template.Spec.Containers[0].Image = image
+	rs.Spec.Template.Spec.Containers[0].Args = args
+	return rs
+}
+
+// Request adds a resource request to the default container.
+func (rs *ReplicaSetWrapper) Request(r corev1.ResourceName, v string) *ReplicaSetWrapper {
+	if rs.Spec.Template.Spec.Containers[0].Resources.Requests == nil {
+		rs.Spec.Template.Spec.Containers[0].Resources.Requests = corev1.ResourceList{}
+	}
+	rs.Spec.Template.Spec.Containers[0].Resources.Requests[r] = resource.MustParse(v)
+	return rs
+}
+
+// Replicas updated the replicas of the ReplicaSet
+func (rs *ReplicaSetWrapper) Replicas(replicas int32) *ReplicaSetWrapper {
+	rs.Spec.Replicas = &replicas
+	return rs
+}
+
+// ReadyReplicas updated the readyReplicas of the ReplicaSet
+func (rs *ReplicaSetWrapper) ReadyReplicas(readyReplicas int32) *ReplicaSetWrapper {
+	rs.Status.ReadyReplicas = readyReplicas
+	return rs
+}
+
+// OwnerReference sets the controller of the ReplicaSet
+func (rs *ReplicaSetWrapper) OwnerReference(ownerName string, ownerGVK schema.GroupVersionKind) *ReplicaSetWrapper {
+	rs.OwnerReferences = append(rs.OwnerReferences, metav1.OwnerReference{
+		APIVersion: ownerGVK.GroupVersion().String(),
+		Kind:       ownerGVK.Kind,
+		Name:       ownerName,
+		UID:        types.UID(ownerName),
+		Controller: ptr.To(true),
+	})
+	return rs
+}
+
+// PodTemplateSpecLabel sets the label of the pod template spec of the ReplicaSet
+func (rs *ReplicaSetWrapper) PodTemplateSpecLabel(k, v string) *ReplicaSetWrapper {
+	if rs.Spec.Template.Labels == nil {
+		rs.Spec.Template.Labels = make(map[string]string, 1)
+	}
+	rs.Spec.Template.Labels[k] = v
+	return rs
+}
+
+// PodTemplateSpecQueue updates the queue name of the pod template spec of the ReplicaSet
+func (rs *ReplicaSetWrapper) PodTemplateSpecQueue(q string) *ReplicaSetWrapper {
+	return rs.PodTemplateSpecLabel(constants.QueueLabel, q)
+}
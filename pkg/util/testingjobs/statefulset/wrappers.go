@@ -86,6 +86,15 @@ func (ss *StatefulSetWrapper) Label(k, v string) *StatefulSetWrapper {
 	return ss
 }
 
+// Annotation sets the annotation of the StatefulSet
+func (ss *StatefulSetWrapper) Annotation(k, v string) *StatefulSetWrapper {
+	if ss.Annotations == nil {
+		ss.Annotations = make(map[string]string)
+	}
+	ss.Annotations[k] = v
+	return ss
+}
+
 // Queue updates the queue name of the StatefulSet
 func (ss *StatefulSetWrapper) Queue(q string) *StatefulSetWrapper {
 	return ss.Label(constants.QueueLabel, q)
@@ -125,6 +134,14 @@ func (ss *StatefulSetWrapper) Replicas(r int32) *StatefulSetWrapper {
 	return ss
 }
 
+// VolumeClaimTemplate adds a volume claim template to the StatefulSet
+func (ss *StatefulSetWrapper) VolumeClaimTemplate(name string) *StatefulSetWrapper {
+	ss.Spec.VolumeClaimTemplates = append(ss.Spec.VolumeClaimTemplates, corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	})
+	return ss
+}
+
 func (ss *StatefulSetWrapper) PodTemplateSpecPodGroupNameLabel(
 	ownerName string, ownerUID types.UID, ownerGVK schema.GroupVersionKind,
 ) *StatefulSetWrapper {
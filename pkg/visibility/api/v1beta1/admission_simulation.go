@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+)
+
+// simulatedWorkloadName is the name of the workload whose admission is
+// simulated, if the request doesn't set one.
+const simulatedWorkloadName = "admission-simulation"
+
+// AdmissionSimulator simulates the admission of workloads.
+type AdmissionSimulator interface {
+	SimulateAdmission(ctx context.Context, wl *kueue.Workload) (*scheduler.SimulationResult, error)
+}
+
+type admissionSimulationREST struct {
+	queueMgr  *queue.Manager
+	simulator AdmissionSimulator
+	log       logr.Logger
+	clock     clock.Clock
+}
+
+var _ rest.Storage = &admissionSimulationREST{}
+var _ rest.NamedCreater = &admissionSimulationREST{}
+var _ rest.Scoper = &admissionSimulationREST{}
+
+func NewAdmissionSimulationREST(kueueMgr *queue.Manager, simulator AdmissionSimulator) *admissionSimulationREST {
+	return &admissionSimulationREST{
+		queueMgr:  kueueMgr,
+		simulator: simulator,
+		log:       ctrl.Log.WithName("admission-simulation"),
+		clock:     realClock,
+	}
+}
+
+// New implements rest.Storage interface
+func (m *admissionSimulationREST) New() runtime.Object {
+	return &visibility.AdmissionSimulation{}
+}
+
+// Destroy implements rest.Storage interface
+func (m *admissionSimulationREST) Destroy() {}
+
+// Create implements rest.NamedCreater interface
+// It simulates the admission of the workload described in the request, as if
+// it was submitted to the LocalQueue, and returns the request with the result
+// of the simulation in its status.
+func (m *admissionSimulationREST) Create(ctx context.Context, name string, obj runtime.Object, _ rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	simulation, ok := obj.(*visibility.AdmissionSimulation)
+	if !ok {
+		return nil, fmt.Errorf("invalid object: %#v", obj)
+	}
+	if len(simulation.Spec.PodSets) == 0 {
+		return nil, errors.NewBadRequest("at least one pod set is required")
+	}
+	namespace := genericapirequest.NamespaceValue(ctx)
+	lqKey := queue.QueueKey(namespace, name)
+	cqName, ok := m.queueMgr.ClusterQueueFromLocalQueue(lqKey)
+	if !ok {
+		return nil, errors.NewNotFound(visibility.Resource("localqueue"), name)
+	}
+
+	now := m.clock.Now()
+	wl := newSimulatedWorkload(namespace, name, simulation, now)
+	result, err := m.simulator.SimulateAdmission(ctx, wl)
+	if err != nil {
+		m.log.Error(err, "Simulating the admission", "localQueue", lqKey)
+		return nil, errors.NewInternalError(err)
+	}
+
+	mode := result.Assignment.RepresentativeMode()
+	position := positionInClusterQueue(m.queueMgr, cqName, simulation.Spec.Priority)
+	simulation.Status = visibility.AdmissionSimulationStatus{
+		ClusterQueueName:       cqName,
+		Mode:                   mode.String(),
+		Message:                result.Message,
+		PositionInClusterQueue: int32(position),
+	}
+	if mode != flavorassigner.NoFit {
+		simulation.Status.Borrowing = result.Assignment.Borrowing
+		simulation.Status.Preemptions = int32(len(result.PreemptionTargets))
+		simulation.Status.PodSetAssignments = podSetAssignments(&result.Assignment)
+	}
+	if mode == flavorassigner.Fit && position == 0 {
+		simulation.Status.EstimatedAdmissionTime = ptr.To(metav1.NewTime(now.Truncate(time.Second)))
+	} else {
+		simulation.Status.EstimatedAdmissionTime = estimatedAdmissionTime(now, m.queueMgr.AdmissionThroughput(cqName), position)
+	}
+	return simulation, nil
+}
+
+// NamespaceScoped implements rest.Scoper interface
+func (m *admissionSimulationREST) NamespaceScoped() bool {
+	return true
+}
+
+func newSimulatedWorkload(namespace, lqName string, simulation *visibility.AdmissionSimulation, now time.Time) *kueue.Workload {
+	name := simulation.Name
+	if name == "" {
+		name = simulatedWorkloadName
+	}
+	wl := &kueue.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(now),
+		},
+		Spec: kueue.WorkloadSpec{
+			QueueName: lqName,
+			Priority:  ptr.To(simulation.Spec.Priority),
+			PodSets:   make([]kueue.PodSet, 0, len(simulation.Spec.PodSets)),
+		},
+	}
+	for _, ps := range simulation.Spec.PodSets {
+		wl.Spec.PodSets = append(wl.Spec.PodSets, kueue.PodSet{
+			Name:  ps.Name,
+			Count: ps.Count,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					NodeSelector: ps.NodeSelector,
+					Containers: []corev1.Container{{
+						Name: ps.Name,
+						Resources: corev1.ResourceRequirements{
+							Requests: ps.Requests,
+						},
+					}},
+				},
+			},
+		})
+	}
+	return wl
+}
+
+// positionInClusterQueue returns the position a new workload with the given
+// priority would take among the pending workloads of the ClusterQueue, after
+// the workloads with the same or a higher priority.
+func positionInClusterQueue(queueMgr *queue.Manager, cqName string, priority int32) int {
+	position := 0
+	for _, wlInfo := range queueMgr.PendingWorkloadsInfo(cqName) {
+		if ptr.Deref(wlInfo.Obj.Spec.Priority, 0) >= priority {
+			position++
+		}
+	}
+	return position
+}
+
+func podSetAssignments(assignment *flavorassigner.Assignment) []visibility.AdmissionSimulationPodSetAssignment {
+	assignments := make([]visibility.AdmissionSimulationPodSetAssignment, 0, len(assignment.PodSets))
+	for _, psa := range assignment.PodSets {
+		flavors := make(map[corev1.ResourceName]string, len(psa.Flavors))
+		for res, flv := range psa.Flavors {
+			flavors[res] = string(flv.Name)
+		}
+		assignments = append(assignments, visibility.AdmissionSimulationPodSetAssignment{
+			Name:    psa.Name,
+			Flavors: flavors,
+			Count:   psa.Count,
+		})
+	}
+	return assignments
+}
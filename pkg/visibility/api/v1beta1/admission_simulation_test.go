@@ -0,0 +1,251 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+type fakeAdmissionSimulator struct {
+	result   *scheduler.SimulationResult
+	workload *kueue.Workload
+}
+
+func (s *fakeAdmissionSimulator) SimulateAdmission(_ context.Context, wl *kueue.Workload) (*scheduler.SimulationResult, error) {
+	s.workload = wl
+	return s.result, nil
+}
+
+func TestAdmissionSimulation(t *testing.T) {
+	const (
+		nsName   = "ns"
+		cqName   = "cq"
+		lqName   = "lq"
+		lowPrio  = 50
+		highPrio = 100
+	)
+
+	now := time.Now()
+	podSets := []visibility.AdmissionSimulationPodSet{{
+		Name:  "main",
+		Count: 2,
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("1"),
+		},
+		NodeSelector: map[string]string{"instance-type": "spot"},
+	}}
+
+	cases := map[string]struct {
+		workloads         []*kueue.Workload
+		quotaReservations int
+		queueName         string
+		spec              visibility.AdmissionSimulationSpec
+		result            *scheduler.SimulationResult
+		wantWorkload      *kueue.WorkloadSpec
+		wantStatus        visibility.AdmissionSimulationStatus
+		wantErrMatch      func(error) bool
+	}{
+		"fits without pending workloads": {
+			queueName: lqName,
+			spec: visibility.AdmissionSimulationSpec{
+				PodSets: podSets,
+			},
+			result: &scheduler.SimulationResult{
+				ClusterQueue: cqName,
+				Assignment: flavorassigner.Assignment{
+					PodSets: []flavorassigner.PodSetAssignment{{
+						Name: "main",
+						Flavors: flavorassigner.ResourceAssignment{
+							corev1.ResourceCPU: {Name: "spot", Mode: flavorassigner.Fit},
+						},
+						Count: 2,
+					}},
+					Borrowing: true,
+				},
+			},
+			wantWorkload: &kueue.WorkloadSpec{
+				QueueName: lqName,
+				Priority:  ptr.To[int32](0),
+				PodSets: []kueue.PodSet{{
+					Name:  "main",
+					Count: 2,
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							NodeSelector: map[string]string{"instance-type": "spot"},
+							Containers: []corev1.Container{{
+								Name: "main",
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceCPU: resource.MustParse("1"),
+									},
+								},
+							}},
+						},
+					},
+				}},
+			},
+			wantStatus: visibility.AdmissionSimulationStatus{
+				ClusterQueueName: cqName,
+				Mode:             "Fit",
+				Borrowing:        true,
+				PodSetAssignments: []visibility.AdmissionSimulationPodSetAssignment{{
+					Name:    "main",
+					Flavors: map[corev1.ResourceName]string{corev1.ResourceCPU: "spot"},
+					Count:   2,
+				}},
+				EstimatedAdmissionTime: ptr.To(metav1.NewTime(now.Truncate(time.Second))),
+			},
+		},
+		"doesn't fit behind pending workloads": {
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("a", nsName).Queue(lqName).Priority(highPrio).Creation(now).Obj(),
+				utiltesting.MakeWorkload("b", nsName).Queue(lqName).Priority(lowPrio).Creation(now).Obj(),
+				utiltesting.MakeWorkload("c", nsName).Queue(lqName).Priority(lowPrio - 1).Creation(now).Obj(),
+			},
+			quotaReservations: 1800,
+			queueName:         lqName,
+			spec: visibility.AdmissionSimulationSpec{
+				Priority: lowPrio,
+				PodSets:  podSets,
+			},
+			result: &scheduler.SimulationResult{
+				ClusterQueue: cqName,
+				Message:      "couldn't assign flavors to pod set main: insufficient quota for cpu in flavor spot, 1 more needed",
+			},
+			wantStatus: visibility.AdmissionSimulationStatus{
+				ClusterQueueName:       cqName,
+				Mode:                   "NoFit",
+				Message:                "couldn't assign flavors to pod set main: insufficient quota for cpu in flavor spot, 1 more needed",
+				PositionInClusterQueue: 2,
+				EstimatedAdmissionTime: ptr.To(metav1.NewTime(now.Add(6 * time.Second).Truncate(time.Second))),
+			},
+		},
+		"fits after preempting workloads": {
+			quotaReservations: 1800,
+			queueName:         lqName,
+			spec: visibility.AdmissionSimulationSpec{
+				PodSets: podSets,
+			},
+			result: &scheduler.SimulationResult{
+				ClusterQueue: cqName,
+				Assignment: flavorassigner.Assignment{
+					PodSets: []flavorassigner.PodSetAssignment{{
+						Name: "main",
+						Flavors: flavorassigner.ResourceAssignment{
+							corev1.ResourceCPU: {Name: "spot", Mode: flavorassigner.Preempt},
+						},
+						Status: &flavorassigner.Status{},
+						Count:  2,
+					}},
+				},
+				PreemptionTargets: []*preemption.Target{{
+					WorkloadInfo: workload.NewInfo(utiltesting.MakeWorkload("admitted", nsName).Obj()),
+					Reason:       kueue.InClusterQueueReason,
+				}},
+				Message: "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor spot, 1 more needed",
+			},
+			wantStatus: visibility.AdmissionSimulationStatus{
+				ClusterQueueName: cqName,
+				Mode:             "Preempt",
+				Preemptions:      1,
+				Message:          "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor spot, 1 more needed",
+				PodSetAssignments: []visibility.AdmissionSimulationPodSetAssignment{{
+					Name:    "main",
+					Flavors: map[corev1.ResourceName]string{corev1.ResourceCPU: "spot"},
+					Count:   2,
+				}},
+				EstimatedAdmissionTime: ptr.To(metav1.NewTime(now.Add(2 * time.Second).Truncate(time.Second))),
+			},
+		},
+		"nonexistent LocalQueue": {
+			queueName: "invalid-queue",
+			spec: visibility.AdmissionSimulationSpec{
+				PodSets: podSets,
+			},
+			wantErrMatch: errors.IsNotFound,
+		},
+		"no pod sets": {
+			queueName:    lqName,
+			wantErrMatch: errors.IsBadRequest,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			manager := queue.NewManager(utiltesting.NewFakeClient(), nil)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go manager.CleanUpOnContext(ctx)
+			simulator := &fakeAdmissionSimulator{result: tc.result}
+			admissionSimulationRest := NewAdmissionSimulationREST(manager, simulator)
+			admissionSimulationRest.clock = testingclock.NewFakeClock(now)
+			if err := manager.AddClusterQueue(ctx, utiltesting.MakeClusterQueue(cqName).Obj()); err != nil {
+				t.Fatalf("Adding cluster queue %s: %v", cqName, err)
+			}
+			if err := manager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue(lqName, nsName).ClusterQueue(cqName).Obj()); err != nil {
+				t.Fatalf("Adding queue %q: %v", lqName, err)
+			}
+			for _, w := range tc.workloads {
+				manager.AddOrUpdateWorkload(w)
+			}
+			for range tc.quotaReservations {
+				manager.RecordQuotaReservation(cqName)
+			}
+
+			ctx = request.WithNamespace(ctx, nsName)
+			obj, err := admissionSimulationRest.Create(ctx, tc.queueName, &visibility.AdmissionSimulation{Spec: tc.spec}, nil, &metav1.CreateOptions{})
+			switch {
+			case tc.wantErrMatch != nil:
+				if !tc.wantErrMatch(err) {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			case err != nil:
+				t.Error(err)
+			default:
+				simulation := obj.(*visibility.AdmissionSimulation)
+				if diff := cmp.Diff(tc.wantStatus, simulation.Status, cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("Unexpected status (-want,+got):\n%s", diff)
+				}
+				if tc.wantWorkload != nil {
+					if diff := cmp.Diff(*tc.wantWorkload, simulator.workload.Spec, cmpopts.EquateEmpty()); diff != "" {
+						t.Errorf("Unexpected simulated workload (-want,+got):\n%s", diff)
+					}
+				}
+			}
+		})
+	}
+}
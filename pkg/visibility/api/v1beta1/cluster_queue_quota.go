@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+)
+
+type cqQuotaREST struct {
+	client client.Client
+}
+
+var _ rest.Storage = &cqQuotaREST{}
+var _ rest.Getter = &cqQuotaREST{}
+var _ rest.Updater = &cqQuotaREST{}
+var _ rest.Scoper = &cqQuotaREST{}
+
+func NewCqQuotaREST(c client.Client) *cqQuotaREST {
+	return &cqQuotaREST{client: c}
+}
+
+// New implements rest.Storage interface
+func (m *cqQuotaREST) New() runtime.Object {
+	return &visibility.ClusterQueueQuota{}
+}
+
+// Destroy implements rest.Storage interface
+func (m *cqQuotaREST) Destroy() {}
+
+// Get implements rest.Getter interface
+// It returns the nominal quotas of the ClusterQueue.
+func (m *cqQuotaREST) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	cq, err := m.getClusterQueue(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return quotaOf(cq), nil
+}
+
+// Update implements rest.Updater interface
+// It sets the nominal quotas listed in the request on the ClusterQueue, in a
+// single update of the ClusterQueue. When the request has a resourceVersion,
+// the update fails with a conflict if the ClusterQueue changed since.
+func (m *cqQuotaREST) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, _ rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, _ bool, _ *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	cq, err := m.getClusterQueue(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	oldQuota := quotaOf(cq)
+	obj, err := objInfo.UpdatedObject(ctx, oldQuota)
+	if err != nil {
+		return nil, false, err
+	}
+	newQuota, ok := obj.(*visibility.ClusterQueueQuota)
+	if !ok {
+		return nil, false, fmt.Errorf("invalid object: %#v", obj)
+	}
+	if updateValidation != nil {
+		if err := updateValidation(ctx, newQuota, oldQuota); err != nil {
+			return nil, false, err
+		}
+	}
+	if newQuota.ResourceVersion != "" && newQuota.ResourceVersion != cq.ResourceVersion {
+		return nil, false, errors.NewConflict(visibility.Resource("clusterqueues"), name,
+			fmt.Errorf("the ClusterQueue has been modified; please apply your changes to the latest version and try again"))
+	}
+	if errs := setNominalQuotas(cq, newQuota.Quotas); len(errs) > 0 {
+		return nil, false, errors.NewInvalid(visibility.GroupVersion.WithKind("ClusterQueueQuota").GroupKind(), name, errs)
+	}
+	if err := m.client.Update(ctx, cq); err != nil {
+		return nil, false, err
+	}
+	return quotaOf(cq), false, nil
+}
+
+// NamespaceScoped implements rest.Scoper interface
+func (m *cqQuotaREST) NamespaceScoped() bool {
+	return false
+}
+
+func (m *cqQuotaREST) getClusterQueue(ctx context.Context, name string) (*kueue.ClusterQueue, error) {
+	cq := &kueue.ClusterQueue{}
+	if err := m.client.Get(ctx, client.ObjectKey{Name: name}, cq); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.NewNotFound(visibility.Resource("clusterqueue"), name)
+		}
+		return nil, err
+	}
+	return cq, nil
+}
+
+// quotaOf returns the nominal quotas of the ClusterQueue, in the order of its
+// resource groups, flavors and resources.
+func quotaOf(cq *kueue.ClusterQueue) *visibility.ClusterQueueQuota {
+	quota := &visibility.ClusterQueueQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              cq.Name,
+			UID:               cq.UID,
+			ResourceVersion:   cq.ResourceVersion,
+			CreationTimestamp: cq.CreationTimestamp,
+		},
+		Quotas: []visibility.FlavorResourceQuota{},
+	}
+	for _, rg := range cq.Spec.ResourceGroups {
+		for _, fq := range rg.Flavors {
+			for _, rq := range fq.Resources {
+				quota.Quotas = append(quota.Quotas, visibility.FlavorResourceQuota{
+					Flavor:       string(fq.Name),
+					Resource:     rq.Name,
+					NominalQuota: rq.NominalQuota,
+				})
+			}
+		}
+	}
+	return quota
+}
+
+// setNominalQuotas sets the nominal quotas on the resource groups of the
+// ClusterQueue. It returns an error for every quota of a flavor and resource
+// missing from the resource groups.
+func setNominalQuotas(cq *kueue.ClusterQueue, quotas []visibility.FlavorResourceQuota) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, q := range quotas {
+		path := field.NewPath("quotas").Index(i)
+		if q.NominalQuota.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("nominalQuota"), q.NominalQuota.String(), "must be greater than or equal to 0"))
+			continue
+		}
+		if rq := findResourceQuota(cq, kueue.ResourceFlavorReference(q.Flavor), q.Resource); rq != nil {
+			rq.NominalQuota = q.NominalQuota
+		} else {
+			allErrs = append(allErrs, field.NotFound(path, fmt.Sprintf("%s/%s", q.Flavor, q.Resource)))
+		}
+	}
+	return allErrs
+}
+
+func findResourceQuota(cq *kueue.ClusterQueue, flavor kueue.ResourceFlavorReference, resource corev1.ResourceName) *kueue.ResourceQuota {
+	for i := range cq.Spec.ResourceGroups {
+		rg := &cq.Spec.ResourceGroups[i]
+		for j := range rg.Flavors {
+			fq := &rg.Flavors[j]
+			if fq.Name != flavor {
+				continue
+			}
+			for k := range fq.Resources {
+				if fq.Resources[k].Name == resource {
+					return &fq.Resources[k]
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestClusterQueueQuota(t *testing.T) {
+	const cqName = "cq"
+	cq := utiltesting.MakeClusterQueue(cqName).
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("on-demand").
+				Resource(corev1.ResourceCPU, "10").
+				Resource(corev1.ResourceMemory, "10Gi").
+				Obj(),
+			*utiltesting.MakeFlavorQuotas("spot").
+				Resource(corev1.ResourceCPU, "20").
+				Resource(corev1.ResourceMemory, "20Gi").
+				Obj(),
+		).
+		Obj()
+	makeQuota := func(flavor string, res corev1.ResourceName, quantity string) visibility.FlavorResourceQuota {
+		return visibility.FlavorResourceQuota{
+			Flavor:       flavor,
+			Resource:     res,
+			NominalQuota: resource.MustParse(quantity),
+		}
+	}
+	initialQuotas := []visibility.FlavorResourceQuota{
+		makeQuota("on-demand", corev1.ResourceCPU, "10"),
+		makeQuota("on-demand", corev1.ResourceMemory, "10Gi"),
+		makeQuota("spot", corev1.ResourceCPU, "20"),
+		makeQuota("spot", corev1.ResourceMemory, "20Gi"),
+	}
+
+	cases := map[string]struct {
+		name            string
+		update          []visibility.FlavorResourceQuota
+		staleVersion    bool
+		wantQuotas      []visibility.FlavorResourceQuota
+		wantErrMatch    func(error) bool
+		wantGetErrMatch func(error) bool
+	}{
+		"get": {
+			name:       cqName,
+			wantQuotas: initialQuotas,
+		},
+		"update a quota": {
+			name:   cqName,
+			update: []visibility.FlavorResourceQuota{makeQuota("spot", corev1.ResourceCPU, "5")},
+			wantQuotas: []visibility.FlavorResourceQuota{
+				makeQuota("on-demand", corev1.ResourceCPU, "10"),
+				makeQuota("on-demand", corev1.ResourceMemory, "10Gi"),
+				makeQuota("spot", corev1.ResourceCPU, "5"),
+				makeQuota("spot", corev1.ResourceMemory, "20Gi"),
+			},
+		},
+		"update several quotas": {
+			name: cqName,
+			update: []visibility.FlavorResourceQuota{
+				makeQuota("on-demand", corev1.ResourceMemory, "1Gi"),
+				makeQuota("spot", corev1.ResourceCPU, "40"),
+			},
+			wantQuotas: []visibility.FlavorResourceQuota{
+				makeQuota("on-demand", corev1.ResourceCPU, "10"),
+				makeQuota("on-demand", corev1.ResourceMemory, "1Gi"),
+				makeQuota("spot", corev1.ResourceCPU, "40"),
+				makeQuota("spot", corev1.ResourceMemory, "20Gi"),
+			},
+		},
+		"unknown flavor": {
+			name: cqName,
+			update: []visibility.FlavorResourceQuota{
+				makeQuota("spot", corev1.ResourceCPU, "5"),
+				makeQuota("reserved", corev1.ResourceCPU, "5"),
+			},
+			wantQuotas:   initialQuotas,
+			wantErrMatch: errors.IsInvalid,
+		},
+		"negative quota": {
+			name:         cqName,
+			update:       []visibility.FlavorResourceQuota{makeQuota("spot", corev1.ResourceCPU, "-1")},
+			wantQuotas:   initialQuotas,
+			wantErrMatch: errors.IsInvalid,
+		},
+		"stale resource version": {
+			name:         cqName,
+			update:       []visibility.FlavorResourceQuota{makeQuota("spot", corev1.ResourceCPU, "5")},
+			staleVersion: true,
+			wantQuotas:   initialQuotas,
+			wantErrMatch: errors.IsConflict,
+		},
+		"nonexistent ClusterQueue": {
+			name:            "missing",
+			update:          []visibility.FlavorResourceQuota{makeQuota("spot", corev1.ResourceCPU, "5")},
+			wantErrMatch:    errors.IsNotFound,
+			wantGetErrMatch: errors.IsNotFound,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			cl := utiltesting.NewClientBuilder().WithObjects(cq.DeepCopy()).Build()
+			quotaREST := NewCqQuotaREST(cl)
+
+			if tc.update != nil {
+				quota := &visibility.ClusterQueueQuota{
+					ObjectMeta: metav1.ObjectMeta{Name: tc.name},
+					Quotas:     tc.update,
+				}
+				if tc.staleVersion {
+					quota.ResourceVersion = "1"
+					updated := &kueue.ClusterQueue{}
+					if err := cl.Get(ctx, client.ObjectKey{Name: cqName}, updated); err != nil {
+						t.Fatalf("Getting the ClusterQueue: %v", err)
+					}
+					updated.Labels = map[string]string{"updated": "true"}
+					if err := cl.Update(ctx, updated); err != nil {
+						t.Fatalf("Updating the ClusterQueue: %v", err)
+					}
+				}
+				_, _, err := quotaREST.Update(ctx, tc.name, rest.DefaultUpdatedObjectInfo(quota), nil, nil, false, &metav1.UpdateOptions{})
+				if tc.wantErrMatch != nil {
+					if !tc.wantErrMatch(err) {
+						t.Errorf("Unexpected error: %v", err)
+					}
+				} else if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+
+			got, err := quotaREST.Get(ctx, tc.name, &metav1.GetOptions{})
+			if tc.wantGetErrMatch != nil {
+				if !tc.wantGetErrMatch(err) {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantQuotas, got.(*visibility.ClusterQueueQuota).Quotas); diff != "" {
+				t.Errorf("Unexpected quotas (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
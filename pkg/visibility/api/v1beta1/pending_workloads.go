@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// continueToken identifies where the next page of pending workloads starts.
+type continueToken struct {
+	// Workload is the key of the last workload of the previous page. The next
+	// page starts after it, if it's still pending.
+	Workload string `json:"workload"`
+	// Position is the position of the last workload of the previous page among
+	// the workloads matching the query. If the workload is no longer pending,
+	// the next page starts at its position, as the following workloads moved
+	// ahead.
+	Position int `json:"position"`
+}
+
+func encodeContinueToken(t continueToken) string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeContinueToken(s string) (*continueToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	t := &continueToken{}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// pendingWorkloadsQuery selects the pending workloads in a ClusterQueue.
+type pendingWorkloadsQuery struct {
+	clusterQueue string
+	// localQueue, if not empty, is the key of the LocalQueue the workloads
+	// should be submitted to.
+	localQueue    string
+	labelSelector labels.Selector
+	fieldSelector fields.Selector
+	offset        int
+	limit         int
+	continueToken *continueToken
+}
+
+func newPendingWorkloadsQuery(cqName, lqKey string, opts *visibility.PendingWorkloadOptions) (*pendingWorkloadsQuery, error) {
+	q := &pendingWorkloadsQuery{
+		clusterQueue:  cqName,
+		localQueue:    lqKey,
+		labelSelector: labels.Everything(),
+		fieldSelector: fields.Everything(),
+		offset:        int(opts.Offset),
+		limit:         int(opts.Limit),
+	}
+	var err error
+	if opts.LabelSelector != "" {
+		if q.labelSelector, err = labels.Parse(opts.LabelSelector); err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid label selector: %v", err))
+		}
+	}
+	if opts.FieldSelector != "" {
+		if q.fieldSelector, err = fields.ParseSelector(opts.FieldSelector); err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid field selector: %v", err))
+		}
+		for _, r := range q.fieldSelector.Requirements() {
+			if !supportedPendingWorkloadFields.Has(r.Field) {
+				return nil, errors.NewBadRequest(fmt.Sprintf("field selector %q is not supported", r.Field))
+			}
+		}
+	}
+	if opts.Continue != "" {
+		if q.continueToken, err = decodeContinueToken(opts.Continue); err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid continue token: %v", err))
+		}
+	}
+	return q, nil
+}
+
+var supportedPendingWorkloadFields = fields.Set{
+	"metadata.name":      "",
+	"metadata.namespace": "",
+	"localQueueName":     "",
+	"priority":           "",
+}
+
+func pendingWorkloadFields(wlInfo *workload.Info) fields.Set {
+	return fields.Set{
+		"metadata.name":      wlInfo.Obj.Name,
+		"metadata.namespace": wlInfo.Obj.Namespace,
+		"localQueueName":     wlInfo.Obj.Spec.QueueName,
+		"priority":           strconv.Itoa(int(*wlInfo.Obj.Spec.Priority)),
+	}
+}
+
+func (q *pendingWorkloadsQuery) matches(wlInfo *workload.Info, lqKey string) bool {
+	if q.localQueue != "" && q.localQueue != lqKey {
+		return false
+	}
+	return q.labelSelector.Matches(labels.Set(wlInfo.Obj.Labels)) && q.fieldSelector.Matches(pendingWorkloadFields(wlInfo))
+}
+
+// list returns the page of pending workloads matching the query, and the
+// continue token for the next page if more workloads match the query.
+func (q *pendingWorkloadsQuery) list(queueMgr *queue.Manager, now time.Time) ([]visibility.PendingWorkload, string, bool) {
+	pendingWorkloadsInfo := queueMgr.PendingWorkloadsInfo(q.clusterQueue)
+	if pendingWorkloadsInfo == nil {
+		return []visibility.PendingWorkload{}, "", false
+	}
+
+	type match struct {
+		positionInCq int
+		positionInLq int32
+	}
+	localQueuePositions := make(map[string]int32)
+	matches := make([]match, 0)
+	for index, wlInfo := range pendingWorkloadsInfo {
+		lqKey := queue.QueueKey(wlInfo.Obj.Namespace, wlInfo.Obj.Spec.QueueName)
+		positionInLocalQueue := localQueuePositions[lqKey]
+		localQueuePositions[lqKey]++
+		if q.matches(wlInfo, lqKey) {
+			matches = append(matches, match{positionInCq: index, positionInLq: positionInLocalQueue})
+		}
+	}
+
+	start := q.offset
+	if q.continueToken != nil {
+		start = q.continueToken.Position
+		for i, m := range matches {
+			if workload.Key(pendingWorkloadsInfo[m.positionInCq].Obj) == q.continueToken.Workload {
+				start = i + 1
+				break
+			}
+		}
+	}
+	start = min(max(start, 0), len(matches))
+	end := min(start+q.limit, len(matches))
+
+	wls := make([]visibility.PendingWorkload, 0, end-start)
+	requestsAhead := resources.Requests{}
+	throughput := queueMgr.AdmissionThroughput(q.clusterQueue)
+	next := start
+	for index := 0; next < end; index++ {
+		wlInfo := pendingWorkloadsInfo[index]
+		if m := matches[next]; m.positionInCq == index {
+			// Add a workload to results
+			wls = append(wls, *newPendingWorkload(wlInfo, m.positionInLq, index, requestsAhead, estimatedAdmissionTime(now, throughput, index)))
+			next++
+		}
+		addTotalRequests(requestsAhead, wlInfo)
+	}
+
+	var continueValue string
+	if start < end && end < len(matches) {
+		continueValue = encodeContinueToken(continueToken{
+			Workload: workload.Key(pendingWorkloadsInfo[matches[end-1].positionInCq].Obj),
+			Position: end - 1,
+		})
+	}
+	return wls, continueValue, true
+}
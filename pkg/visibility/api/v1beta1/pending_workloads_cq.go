@@ -19,11 +19,13 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
@@ -36,6 +38,9 @@ import (
 type pendingWorkloadsInCqREST struct {
 	queueMgr *queue.Manager
 	log      logr.Logger
+	clock    clock.Clock
+
+	watchInterval time.Duration
 }
 
 var _ rest.Storage = &pendingWorkloadsInCqREST{}
@@ -46,6 +51,9 @@ func NewPendingWorkloadsInCqREST(kueueMgr *queue.Manager) *pendingWorkloadsInCqR
 	return &pendingWorkloadsInCqREST{
 		queueMgr: kueueMgr,
 		log:      ctrl.Log.WithName("pending-workload-in-cq"),
+		clock:    realClock,
+
+		watchInterval: defaultWatchInterval,
 	}
 }
 
@@ -64,30 +72,25 @@ func (m *pendingWorkloadsInCqREST) Get(_ context.Context, name string, opts runt
 	if !ok {
 		return nil, fmt.Errorf("invalid options object: %#v", opts)
 	}
-	limit := pendingWorkloadOpts.Limit
-	offset := pendingWorkloadOpts.Offset
+	query, err := newPendingWorkloadsQuery(name, "", pendingWorkloadOpts)
+	if err != nil {
+		return nil, err
+	}
 
-	wls := make([]visibility.PendingWorkload, 0, limit)
-	pendingWorkloadsInfo := m.queueMgr.PendingWorkloadsInfo(name)
-	if pendingWorkloadsInfo == nil {
+	wls, continueValue, found := query.list(m.queueMgr, m.clock.Now())
+	if !found {
 		return nil, errors.NewNotFound(visibility.Resource("clusterqueue"), name)
 	}
-
-	localQueuePositions := make(map[string]int32, 0)
-
-	for index := 0; index < int(offset+limit) && index < len(pendingWorkloadsInfo); index++ {
-		// Update positions in LocalQueue
-		wlInfo := pendingWorkloadsInfo[index]
-		queueName := wlInfo.Obj.Spec.QueueName
-		positionInLocalQueue := localQueuePositions[queueName]
-		localQueuePositions[queueName]++
-
-		if index >= int(offset) {
-			// Add a workload to results
-			wls = append(wls, *newPendingWorkload(wlInfo, positionInLocalQueue, index))
-		}
+	if pendingWorkloadOpts.Watch {
+		return &pendingWorkloadsWatch{
+			queueMgr: m.queueMgr,
+			query:    query,
+			notFound: errors.NewNotFound(visibility.Resource("clusterqueue"), name),
+			interval: m.watchInterval,
+			now:      m.clock.Now,
+		}, nil
 	}
-	return &visibility.PendingWorkloadsSummary{Items: wls}, nil
+	return &visibility.PendingWorkloadsSummary{Items: wls, Continue: continueValue}, nil
 }
 
 // NewGetOptions creates a new options object
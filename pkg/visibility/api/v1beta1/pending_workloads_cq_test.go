@@ -23,9 +23,13 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
@@ -62,12 +66,13 @@ func TestPendingWorkloadsInCQ(t *testing.T) {
 
 	now := time.Now()
 	cases := map[string]struct {
-		clusterQueues []*kueue.ClusterQueue
-		queues        []*kueue.LocalQueue
-		workloads     []*kueue.Workload
-		req           *req
-		wantResp      *resp
-		wantErrMatch  func(error) bool
+		clusterQueues     []*kueue.ClusterQueue
+		queues            []*kueue.LocalQueue
+		workloads         []*kueue.Workload
+		quotaReservations map[string]int
+		req               *req
+		wantResp          *resp
+		wantErrMatch      func(error) bool
 	}{
 		"single ClusterQueue and single LocalQueue setup with two workloads and default query parameters": {
 			clusterQueues: []*kueue.ClusterQueue{
@@ -218,6 +223,7 @@ func TestPendingWorkloadsInCQ(t *testing.T) {
 						PositionInClusterQueue: 1,
 						PositionInLocalQueue:   1,
 					}},
+				wantContinue: encodeContinueToken(continueToken{Workload: "foo/b", Position: 1}),
 			},
 		},
 		"offset query parameter set": {
@@ -297,6 +303,7 @@ func TestPendingWorkloadsInCQ(t *testing.T) {
 						PositionInClusterQueue: 1,
 						PositionInLocalQueue:   1,
 					}},
+				wantContinue: encodeContinueToken(continueToken{Workload: "foo/b", Position: 1}),
 			},
 		},
 		"empty cluster queue": {
@@ -319,6 +326,281 @@ func TestPendingWorkloadsInCQ(t *testing.T) {
 			},
 			wantErrMatch: errors.IsNotFound,
 		},
+		"requests ahead and estimated admission time": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue(cqNameA).Obj(),
+			},
+			queues: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue(lqNameA, nsName).ClusterQueue(cqNameA).Obj(),
+			},
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("a", nsName).Queue(lqNameA).Priority(highPrio).Creation(now).Request(corev1.ResourceCPU, "1").Obj(),
+				utiltesting.MakeWorkload("b", nsName).Queue(lqNameA).Priority(highPrio).Creation(now.Add(time.Second)).Request(corev1.ResourceCPU, "2").Obj(),
+				utiltesting.MakeWorkload("c", nsName).Queue(lqNameA).Priority(highPrio).Creation(now.Add(time.Second*2)).Request(corev1.ResourceCPU, "3").Obj(),
+			},
+			quotaReservations: map[string]int{cqNameA: 1800},
+			req: &req{
+				queueName: cqNameA,
+				queryParams: &visibility.PendingWorkloadOptions{
+					Offset: 1,
+					Limit:  constants.DefaultPendingWorkloadsLimit,
+				},
+			},
+			wantResp: &resp{
+				wantPendingWorkloads: []visibility.PendingWorkload{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "b",
+							Namespace:         nsName,
+							CreationTimestamp: metav1.NewTime(now.Add(time.Second)),
+						},
+						LocalQueueName:         lqNameA,
+						Priority:               highPrio,
+						PositionInClusterQueue: 1,
+						PositionInLocalQueue:   1,
+						RequestsAhead: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("1"),
+						},
+						EstimatedAdmissionTime: ptr.To(metav1.NewTime(now.Add(4 * time.Second).Truncate(time.Second))),
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "c",
+							Namespace:         nsName,
+							CreationTimestamp: metav1.NewTime(now.Add(time.Second * 2)),
+						},
+						LocalQueueName:         lqNameA,
+						Priority:               highPrio,
+						PositionInClusterQueue: 2,
+						PositionInLocalQueue:   2,
+						RequestsAhead: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("3"),
+						},
+						EstimatedAdmissionTime: ptr.To(metav1.NewTime(now.Add(6 * time.Second).Truncate(time.Second))),
+					},
+				},
+			},
+		},
+		"label selector": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue(cqNameA).Obj(),
+			},
+			queues: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue(lqNameA, nsName).ClusterQueue(cqNameA).Obj(),
+			},
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("a", nsName).Queue(lqNameA).Priority(highPrio).Creation(now).Label("team", "x").Obj(),
+				utiltesting.MakeWorkload("b", nsName).Queue(lqNameA).Priority(highPrio).Creation(now.Add(time.Second)).Label("team", "y").Obj(),
+				utiltesting.MakeWorkload("c", nsName).Queue(lqNameA).Priority(highPrio).Creation(now.Add(time.Second*2)).Label("team", "x").Obj(),
+			},
+			req: &req{
+				queueName: cqNameA,
+				queryParams: &visibility.PendingWorkloadOptions{
+					Limit:         constants.DefaultPendingWorkloadsLimit,
+					LabelSelector: "team=x",
+				},
+			},
+			wantResp: &resp{
+				wantPendingWorkloads: []visibility.PendingWorkload{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "a",
+							Namespace:         nsName,
+							CreationTimestamp: metav1.NewTime(now),
+						},
+						LocalQueueName:         lqNameA,
+						Priority:               highPrio,
+						PositionInClusterQueue: 0,
+						PositionInLocalQueue:   0,
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "c",
+							Namespace:         nsName,
+							CreationTimestamp: metav1.NewTime(now.Add(time.Second * 2)),
+						},
+						LocalQueueName:         lqNameA,
+						Priority:               highPrio,
+						PositionInClusterQueue: 2,
+						PositionInLocalQueue:   2,
+					},
+				},
+			},
+		},
+		"field selector": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue(cqNameA).Obj(),
+			},
+			queues: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue(lqNameA, nsName).ClusterQueue(cqNameA).Obj(),
+				utiltesting.MakeLocalQueue(lqNameB, nsName).ClusterQueue(cqNameA).Obj(),
+			},
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("a", nsName).Queue(lqNameA).Priority(highPrio).Creation(now).Obj(),
+				utiltesting.MakeWorkload("b", nsName).Queue(lqNameB).Priority(highPrio).Creation(now.Add(time.Second)).Obj(),
+				utiltesting.MakeWorkload("c", nsName).Queue(lqNameB).Priority(lowPrio).Creation(now.Add(time.Second * 2)).Obj(),
+			},
+			req: &req{
+				queueName: cqNameA,
+				queryParams: &visibility.PendingWorkloadOptions{
+					Limit:         constants.DefaultPendingWorkloadsLimit,
+					FieldSelector: "localQueueName=lqB,priority!=50",
+				},
+			},
+			wantResp: &resp{
+				wantPendingWorkloads: []visibility.PendingWorkload{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "b",
+							Namespace:         nsName,
+							CreationTimestamp: metav1.NewTime(now.Add(time.Second)),
+						},
+						LocalQueueName:         lqNameB,
+						Priority:               highPrio,
+						PositionInClusterQueue: 1,
+						PositionInLocalQueue:   0,
+					},
+				},
+			},
+		},
+		"unsupported field selector": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue(cqNameA).Obj(),
+			},
+			req: &req{
+				queueName: cqNameA,
+				queryParams: &visibility.PendingWorkloadOptions{
+					Limit:         constants.DefaultPendingWorkloadsLimit,
+					FieldSelector: "spec.active=true",
+				},
+			},
+			wantResp: &resp{
+				wantErr: errors.NewBadRequest(`field selector "spec.active" is not supported`),
+			},
+			wantErrMatch: errors.IsBadRequest,
+		},
+		"limit with more pending workloads returns a continue token": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue(cqNameA).Obj(),
+			},
+			queues: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue(lqNameA, nsName).ClusterQueue(cqNameA).Obj(),
+			},
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("a", nsName).Queue(lqNameA).Priority(highPrio).Creation(now).Obj(),
+				utiltesting.MakeWorkload("b", nsName).Queue(lqNameA).Priority(highPrio).Creation(now.Add(time.Second)).Obj(),
+				utiltesting.MakeWorkload("c", nsName).Queue(lqNameA).Priority(highPrio).Creation(now.Add(time.Second * 2)).Obj(),
+			},
+			req: &req{
+				queueName: cqNameA,
+				queryParams: &visibility.PendingWorkloadOptions{
+					Limit: 1,
+				},
+			},
+			wantResp: &resp{
+				wantPendingWorkloads: []visibility.PendingWorkload{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "a",
+							Namespace:         nsName,
+							CreationTimestamp: metav1.NewTime(now),
+						},
+						LocalQueueName:         lqNameA,
+						Priority:               highPrio,
+						PositionInClusterQueue: 0,
+						PositionInLocalQueue:   0,
+					},
+				},
+				wantContinue: encodeContinueToken(continueToken{Workload: "foo/a", Position: 0}),
+			},
+		},
+		"continue token": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue(cqNameA).Obj(),
+			},
+			queues: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue(lqNameA, nsName).ClusterQueue(cqNameA).Obj(),
+			},
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("a", nsName).Queue(lqNameA).Priority(highPrio).Creation(now).Obj(),
+				utiltesting.MakeWorkload("b", nsName).Queue(lqNameA).Priority(highPrio).Creation(now.Add(time.Second)).Obj(),
+				utiltesting.MakeWorkload("c", nsName).Queue(lqNameA).Priority(highPrio).Creation(now.Add(time.Second * 2)).Obj(),
+			},
+			req: &req{
+				queueName: cqNameA,
+				queryParams: &visibility.PendingWorkloadOptions{
+					Limit:    1,
+					Continue: encodeContinueToken(continueToken{Workload: "foo/a", Position: 0}),
+				},
+			},
+			wantResp: &resp{
+				wantPendingWorkloads: []visibility.PendingWorkload{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "b",
+							Namespace:         nsName,
+							CreationTimestamp: metav1.NewTime(now.Add(time.Second)),
+						},
+						LocalQueueName:         lqNameA,
+						Priority:               highPrio,
+						PositionInClusterQueue: 1,
+						PositionInLocalQueue:   1,
+					},
+				},
+				wantContinue: encodeContinueToken(continueToken{Workload: "foo/b", Position: 1}),
+			},
+		},
+		"continue token of a workload no longer pending": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue(cqNameA).Obj(),
+			},
+			queues: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue(lqNameA, nsName).ClusterQueue(cqNameA).Obj(),
+			},
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("b", nsName).Queue(lqNameA).Priority(highPrio).Creation(now.Add(time.Second)).Obj(),
+				utiltesting.MakeWorkload("c", nsName).Queue(lqNameA).Priority(highPrio).Creation(now.Add(time.Second * 2)).Obj(),
+			},
+			req: &req{
+				queueName: cqNameA,
+				queryParams: &visibility.PendingWorkloadOptions{
+					Limit:    1,
+					Continue: encodeContinueToken(continueToken{Workload: "foo/a", Position: 0}),
+				},
+			},
+			wantResp: &resp{
+				wantPendingWorkloads: []visibility.PendingWorkload{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "b",
+							Namespace:         nsName,
+							CreationTimestamp: metav1.NewTime(now.Add(time.Second)),
+						},
+						LocalQueueName:         lqNameA,
+						Priority:               highPrio,
+						PositionInClusterQueue: 0,
+						PositionInLocalQueue:   0,
+					},
+				},
+				wantContinue: encodeContinueToken(continueToken{Workload: "foo/b", Position: 0}),
+			},
+		},
+		"invalid continue token": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue(cqNameA).Obj(),
+			},
+			req: &req{
+				queueName: cqNameA,
+				queryParams: &visibility.PendingWorkloadOptions{
+					Limit:    1,
+					Continue: "invalid",
+				},
+			},
+			wantResp: &resp{
+				wantErr: errors.NewBadRequest("invalid continue token"),
+			},
+			wantErrMatch: errors.IsBadRequest,
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -327,6 +609,7 @@ func TestPendingWorkloadsInCQ(t *testing.T) {
 			defer cancel()
 			go manager.CleanUpOnContext(ctx)
 			pendingWorkloadsInCqRest := NewPendingWorkloadsInCqREST(manager)
+			pendingWorkloadsInCqRest.clock = testingclock.NewFakeClock(now)
 			for _, cq := range tc.clusterQueues {
 				if err := manager.AddClusterQueue(ctx, cq); err != nil {
 					t.Fatalf("Adding cluster queue %s: %v", cq.Name, err)
@@ -340,6 +623,11 @@ func TestPendingWorkloadsInCQ(t *testing.T) {
 			for _, w := range tc.workloads {
 				manager.AddOrUpdateWorkload(w)
 			}
+			for cqName, count := range tc.quotaReservations {
+				for range count {
+					manager.RecordQuotaReservation(cqName)
+				}
+			}
 
 			info, err := pendingWorkloadsInCqRest.Get(ctx, tc.req.queueName, tc.req.queryParams)
 			switch {
@@ -354,6 +642,9 @@ func TestPendingWorkloadsInCQ(t *testing.T) {
 				if diff := cmp.Diff(tc.wantResp.wantPendingWorkloads, pendingWorkloadsInfo.Items, cmpopts.EquateEmpty()); diff != "" {
 					t.Errorf("Pending workloads differ: (-want,+got):\n%s", diff)
 				}
+				if diff := cmp.Diff(tc.wantResp.wantContinue, pendingWorkloadsInfo.Continue); diff != "" {
+					t.Errorf("Continue token differs: (-want,+got):\n%s", diff)
+				}
 			}
 		})
 	}
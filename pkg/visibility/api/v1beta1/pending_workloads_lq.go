@@ -19,12 +19,14 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
@@ -37,6 +39,9 @@ import (
 type pendingWorkloadsInLqREST struct {
 	queueMgr *queue.Manager
 	log      logr.Logger
+	clock    clock.Clock
+
+	watchInterval time.Duration
 }
 
 var _ rest.Storage = &pendingWorkloadsInLqREST{}
@@ -47,6 +52,9 @@ func NewPendingWorkloadsInLqREST(kueueMgr *queue.Manager) *pendingWorkloadsInLqR
 	return &pendingWorkloadsInLqREST{
 		queueMgr: kueueMgr,
 		log:      ctrl.Log.WithName("pending-workload-in-lq"),
+		clock:    realClock,
+
+		watchInterval: defaultWatchInterval,
 	}
 }
 
@@ -65,32 +73,29 @@ func (m *pendingWorkloadsInLqREST) Get(ctx context.Context, name string, opts ru
 	if !ok {
 		return nil, fmt.Errorf("invalid options object: %#v", opts)
 	}
-	limit := pendingWorkloadOpts.Limit
-	offset := pendingWorkloadOpts.Offset
-
 	namespace := genericapirequest.NamespaceValue(ctx)
-	cqName, ok := m.queueMgr.ClusterQueueFromLocalQueue(queue.QueueKey(namespace, name))
+	lqKey := queue.QueueKey(namespace, name)
+	cqName, ok := m.queueMgr.ClusterQueueFromLocalQueue(lqKey)
 	if !ok {
 		return nil, errors.NewNotFound(visibility.Resource("localqueue"), name)
 	}
 
-	wls := make([]visibility.PendingWorkload, 0, limit)
-	skippedWls := 0
-	for index, wlInfo := range m.queueMgr.PendingWorkloadsInfo(cqName) {
-		if len(wls) >= int(limit) {
-			break
-		}
-		if wlInfo.Obj.Spec.QueueName == name {
-			if skippedWls < int(offset) {
-				skippedWls++
-			} else {
-				// Add a workload to results
-				wls = append(wls, *newPendingWorkload(wlInfo, int32(len(wls)+int(offset)), index))
-			}
-		}
+	query, err := newPendingWorkloadsQuery(cqName, lqKey, pendingWorkloadOpts)
+	if err != nil {
+		return nil, err
 	}
 
-	return &visibility.PendingWorkloadsSummary{Items: wls}, nil
+	wls, continueValue, _ := query.list(m.queueMgr, m.clock.Now())
+	if pendingWorkloadOpts.Watch {
+		return &pendingWorkloadsWatch{
+			queueMgr: m.queueMgr,
+			query:    query,
+			notFound: errors.NewNotFound(visibility.Resource("localqueue"), name),
+			interval: m.watchInterval,
+			now:      m.clock.Now,
+		}, nil
+	}
+	return &visibility.PendingWorkloadsSummary{Items: wls, Continue: continueValue}, nil
 }
 
 // NewGetOptions creates a new options object
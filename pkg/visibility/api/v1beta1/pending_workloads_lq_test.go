@@ -23,10 +23,14 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/endpoints/request"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
@@ -62,12 +66,13 @@ func TestPendingWorkloadsInLQ(t *testing.T) {
 
 	now := time.Now()
 	cases := map[string]struct {
-		clusterQueues []*kueue.ClusterQueue
-		queues        []*kueue.LocalQueue
-		workloads     []*kueue.Workload
-		req           *req
-		wantResp      *resp
-		wantErrMatch  func(error) bool
+		clusterQueues     []*kueue.ClusterQueue
+		queues            []*kueue.LocalQueue
+		workloads         []*kueue.Workload
+		quotaReservations map[string]int
+		req               *req
+		wantResp          *resp
+		wantErrMatch      func(error) bool
 	}{
 		"single ClusterQueue and single LocalQueue setup with two workloads and default query parameters": {
 			clusterQueues: []*kueue.ClusterQueue{
@@ -340,6 +345,7 @@ func TestPendingWorkloadsInLQ(t *testing.T) {
 						PositionInLocalQueue:   1,
 					},
 				},
+				wantContinue: encodeContinueToken(continueToken{Workload: "nsA/b", Position: 1}),
 			},
 		},
 		"offset query parameter set": {
@@ -423,6 +429,7 @@ func TestPendingWorkloadsInLQ(t *testing.T) {
 						PositionInLocalQueue:   1,
 					},
 				},
+				wantContinue: encodeContinueToken(continueToken{Workload: "nsA/b", Position: 1}),
 			},
 		},
 		"nonexistent queue name": {
@@ -435,6 +442,96 @@ func TestPendingWorkloadsInLQ(t *testing.T) {
 			},
 			wantErrMatch: errors.IsNotFound,
 		},
+		"requests ahead and estimated admission time with workloads from other LocalQueues": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue(cqNameA).Obj(),
+			},
+			queues: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue(lqNameA, nsNameA).ClusterQueue(cqNameA).Obj(),
+				utiltesting.MakeLocalQueue(lqNameB, nsNameB).ClusterQueue(cqNameA).Obj(),
+			},
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("a", nsNameA).Queue(lqNameA).Priority(highPrio).Creation(now).Request(corev1.ResourceCPU, "1").Obj(),
+				utiltesting.MakeWorkload("b", nsNameB).Queue(lqNameB).Priority(highPrio).Creation(now.Add(time.Second)).Request(corev1.ResourceCPU, "2").Obj(),
+				utiltesting.MakeWorkload("c", nsNameA).Queue(lqNameA).Priority(highPrio).Creation(now.Add(time.Second*2)).Request(corev1.ResourceCPU, "3").Obj(),
+			},
+			quotaReservations: map[string]int{cqNameA: 1800},
+			req: &req{
+				nsName:      nsNameA,
+				queueName:   lqNameA,
+				queryParams: defaultQueryParams,
+			},
+			wantResp: &resp{
+				wantPendingWorkloads: []visibility.PendingWorkload{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "a",
+							Namespace:         nsNameA,
+							CreationTimestamp: metav1.NewTime(now),
+						},
+						LocalQueueName:         lqNameA,
+						Priority:               highPrio,
+						PositionInClusterQueue: 0,
+						PositionInLocalQueue:   0,
+						EstimatedAdmissionTime: ptr.To(metav1.NewTime(now.Add(2 * time.Second).Truncate(time.Second))),
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "c",
+							Namespace:         nsNameA,
+							CreationTimestamp: metav1.NewTime(now.Add(time.Second * 2)),
+						},
+						LocalQueueName:         lqNameA,
+						Priority:               highPrio,
+						PositionInClusterQueue: 2,
+						PositionInLocalQueue:   1,
+						RequestsAhead: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("3"),
+						},
+						EstimatedAdmissionTime: ptr.To(metav1.NewTime(now.Add(6 * time.Second).Truncate(time.Second))),
+					},
+				},
+			},
+		},
+		"continue token with workloads from other LocalQueues": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue(cqNameA).Obj(),
+			},
+			queues: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue(lqNameA, nsNameA).ClusterQueue(cqNameA).Obj(),
+				utiltesting.MakeLocalQueue(lqNameA, nsNameB).ClusterQueue(cqNameA).Obj(),
+			},
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("a", nsNameA).Queue(lqNameA).Priority(highPrio).Creation(now).Obj(),
+				utiltesting.MakeWorkload("b", nsNameB).Queue(lqNameA).Priority(highPrio).Creation(now.Add(time.Second)).Obj(),
+				utiltesting.MakeWorkload("c", nsNameA).Queue(lqNameA).Priority(highPrio).Creation(now.Add(time.Second * 2)).Obj(),
+				utiltesting.MakeWorkload("d", nsNameA).Queue(lqNameA).Priority(highPrio).Creation(now.Add(time.Second * 3)).Obj(),
+			},
+			req: &req{
+				nsName:    nsNameA,
+				queueName: lqNameA,
+				queryParams: &visibility.PendingWorkloadOptions{
+					Limit:    1,
+					Continue: encodeContinueToken(continueToken{Workload: "nsA/a", Position: 0}),
+				},
+			},
+			wantResp: &resp{
+				wantPendingWorkloads: []visibility.PendingWorkload{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "c",
+							Namespace:         nsNameA,
+							CreationTimestamp: metav1.NewTime(now.Add(time.Second * 2)),
+						},
+						LocalQueueName:         lqNameA,
+						Priority:               highPrio,
+						PositionInClusterQueue: 2,
+						PositionInLocalQueue:   1,
+					},
+				},
+				wantContinue: encodeContinueToken(continueToken{Workload: "nsA/c", Position: 1}),
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -443,6 +540,7 @@ func TestPendingWorkloadsInLQ(t *testing.T) {
 			defer cancel()
 			go manager.CleanUpOnContext(ctx)
 			pendingWorkloadsInLqRest := NewPendingWorkloadsInLqREST(manager)
+			pendingWorkloadsInLqRest.clock = testingclock.NewFakeClock(now)
 			for _, cq := range tc.clusterQueues {
 				if err := manager.AddClusterQueue(ctx, cq); err != nil {
 					t.Fatalf("Adding cluster queue %s: %v", cq.Name, err)
@@ -456,6 +554,11 @@ func TestPendingWorkloadsInLQ(t *testing.T) {
 			for _, w := range tc.workloads {
 				manager.AddOrUpdateWorkload(w)
 			}
+			for cqName, count := range tc.quotaReservations {
+				for range count {
+					manager.RecordQuotaReservation(cqName)
+				}
+			}
 
 			ctx = request.WithNamespace(ctx, tc.req.nsName)
 			info, err := pendingWorkloadsInLqRest.Get(ctx, tc.req.queueName, tc.req.queryParams)
@@ -471,6 +574,9 @@ func TestPendingWorkloadsInLQ(t *testing.T) {
 				if diff := cmp.Diff(tc.wantResp.wantPendingWorkloads, pendingWorkloadsInfo.Items, cmpopts.EquateEmpty()); diff != "" {
 					t.Errorf("Pending workloads differ: (-want,+got):\n%s", diff)
 				}
+				if diff := cmp.Diff(tc.wantResp.wantContinue, pendingWorkloadsInfo.Continue); diff != "" {
+					t.Errorf("Continue token differs: (-want,+got):\n%s", diff)
+				}
 			}
 		})
 	}
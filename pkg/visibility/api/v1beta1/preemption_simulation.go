@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/util/priority"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+type preemptionSimulationREST struct {
+	queueMgr  *queue.Manager
+	simulator AdmissionSimulator
+	log       logr.Logger
+}
+
+var _ rest.Storage = &preemptionSimulationREST{}
+var _ rest.NamedCreater = &preemptionSimulationREST{}
+var _ rest.Scoper = &preemptionSimulationREST{}
+
+func NewPreemptionSimulationREST(kueueMgr *queue.Manager, simulator AdmissionSimulator) *preemptionSimulationREST {
+	return &preemptionSimulationREST{
+		queueMgr:  kueueMgr,
+		simulator: simulator,
+		log:       ctrl.Log.WithName("preemption-simulation"),
+	}
+}
+
+// New implements rest.Storage interface
+func (m *preemptionSimulationREST) New() runtime.Object {
+	return &visibility.PreemptionSimulation{}
+}
+
+// Destroy implements rest.Storage interface
+func (m *preemptionSimulationREST) Destroy() {}
+
+// Create implements rest.NamedCreater interface
+// It simulates the admission of the pending workload of the ClusterQueue
+// described in the request, as if it was at the head of the ClusterQueue, and
+// returns the request with the workloads that would be preempted in its
+// status. No workload is preempted.
+func (m *preemptionSimulationREST) Create(ctx context.Context, name string, obj runtime.Object, _ rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	simulation, ok := obj.(*visibility.PreemptionSimulation)
+	if !ok {
+		return nil, fmt.Errorf("invalid object: %#v", obj)
+	}
+	if simulation.Spec.WorkloadNamespace == "" || simulation.Spec.WorkloadName == "" {
+		return nil, errors.NewBadRequest("the namespace and the name of the workload are required")
+	}
+	pendingWorkloads := m.queueMgr.PendingWorkloadsInfo(name)
+	if pendingWorkloads == nil {
+		return nil, errors.NewNotFound(visibility.Resource("clusterqueue"), name)
+	}
+	idx := slices.IndexFunc(pendingWorkloads, func(wlInfo *workload.Info) bool {
+		return wlInfo.Obj.Namespace == simulation.Spec.WorkloadNamespace && wlInfo.Obj.Name == simulation.Spec.WorkloadName
+	})
+	if idx == -1 {
+		return nil, errors.NewNotFound(kueue.Resource("workload"), simulation.Spec.WorkloadName)
+	}
+
+	wl := pendingWorkloads[idx].Obj.DeepCopy()
+	if simulation.Spec.Priority != nil {
+		wl.Spec.Priority = ptr.To(*simulation.Spec.Priority)
+	}
+	result, err := m.simulator.SimulateAdmission(ctx, wl)
+	if err != nil {
+		m.log.Error(err, "Simulating the preemption", "clusterQueue", name, "workload", klog.KObj(wl))
+		return nil, errors.NewInternalError(err)
+	}
+
+	mode := result.Assignment.RepresentativeMode()
+	simulation.Status = visibility.PreemptionSimulationStatus{
+		Mode:    mode.String(),
+		Message: result.Message,
+	}
+	if mode != flavorassigner.NoFit {
+		simulation.Status.Victims = preemptionVictims(result.PreemptionTargets)
+	}
+	return simulation, nil
+}
+
+// NamespaceScoped implements rest.Scoper interface
+func (m *preemptionSimulationREST) NamespaceScoped() bool {
+	return false
+}
+
+// preemptionVictims returns the workloads of the preemption targets, sorted
+// by ClusterQueue, namespace and name.
+func preemptionVictims(targets []*preemption.Target) []visibility.PreemptionVictim {
+	victims := make([]visibility.PreemptionVictim, 0, len(targets))
+	for _, target := range targets {
+		wl := target.WorkloadInfo.Obj
+		victims = append(victims, visibility.PreemptionVictim{
+			Namespace:        wl.Namespace,
+			Name:             wl.Name,
+			ClusterQueueName: target.WorkloadInfo.ClusterQueue,
+			Priority:         priority.Priority(wl),
+			Reason:           target.Reason,
+		})
+	}
+	slices.SortFunc(victims, func(a, b visibility.PreemptionVictim) int {
+		return cmp.Or(
+			cmp.Compare(a.ClusterQueueName, b.ClusterQueueName),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+	return victims
+}
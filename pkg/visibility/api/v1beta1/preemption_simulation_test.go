@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestPreemptionSimulation(t *testing.T) {
+	const (
+		nsName = "ns"
+		cqName = "cq"
+		lqName = "lq"
+	)
+
+	preemptAssignment := flavorassigner.Assignment{
+		PodSets: []flavorassigner.PodSetAssignment{{
+			Name: "main",
+			Flavors: flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: {Name: "default", Mode: flavorassigner.Preempt},
+			},
+			Status: &flavorassigner.Status{},
+			Count:  1,
+		}},
+	}
+	target := func(name, cq string, priority int32, reason string) *preemption.Target {
+		wlInfo := workload.NewInfo(utiltesting.MakeWorkload(name, nsName).Priority(priority).Obj())
+		wlInfo.ClusterQueue = cq
+		return &preemption.Target{WorkloadInfo: wlInfo, Reason: reason}
+	}
+
+	cases := map[string]struct {
+		clusterQueue string
+		spec         visibility.PreemptionSimulationSpec
+		result       *scheduler.SimulationResult
+		wantPriority int32
+		wantStatus   visibility.PreemptionSimulationStatus
+		wantErrMatch func(error) bool
+	}{
+		"preempts the lower priority workloads": {
+			clusterQueue: cqName,
+			spec: visibility.PreemptionSimulationSpec{
+				WorkloadNamespace: nsName,
+				WorkloadName:      "pending",
+			},
+			result: &scheduler.SimulationResult{
+				ClusterQueue: cqName,
+				Assignment:   preemptAssignment,
+				PreemptionTargets: []*preemption.Target{
+					target("b", "other-cq", 0, kueue.InCohortReclamationReason),
+					target("c", cqName, 5, kueue.InClusterQueueReason),
+					target("a", cqName, 0, kueue.InClusterQueueReason),
+				},
+				Message: "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed",
+			},
+			wantPriority: 10,
+			wantStatus: visibility.PreemptionSimulationStatus{
+				Mode:    "Preempt",
+				Message: "couldn't assign flavors to pod set main: insufficient unused quota for cpu in flavor default, 1 more needed",
+				Victims: []visibility.PreemptionVictim{
+					{Namespace: nsName, Name: "a", ClusterQueueName: cqName, Priority: 0, Reason: kueue.InClusterQueueReason},
+					{Namespace: nsName, Name: "c", ClusterQueueName: cqName, Priority: 5, Reason: kueue.InClusterQueueReason},
+					{Namespace: nsName, Name: "b", ClusterQueueName: "other-cq", Priority: 0, Reason: kueue.InCohortReclamationReason},
+				},
+			},
+		},
+		"with a raised priority": {
+			clusterQueue: cqName,
+			spec: visibility.PreemptionSimulationSpec{
+				WorkloadNamespace: nsName,
+				WorkloadName:      "pending",
+				Priority:          ptr.To[int32](100),
+			},
+			result: &scheduler.SimulationResult{
+				ClusterQueue:      cqName,
+				Assignment:        preemptAssignment,
+				PreemptionTargets: []*preemption.Target{target("a", cqName, 50, kueue.InClusterQueueReason)},
+			},
+			wantPriority: 100,
+			wantStatus: visibility.PreemptionSimulationStatus{
+				Mode: "Preempt",
+				Victims: []visibility.PreemptionVictim{
+					{Namespace: nsName, Name: "a", ClusterQueueName: cqName, Priority: 50, Reason: kueue.InClusterQueueReason},
+				},
+			},
+		},
+		"doesn't fit": {
+			clusterQueue: cqName,
+			spec: visibility.PreemptionSimulationSpec{
+				WorkloadNamespace: nsName,
+				WorkloadName:      "pending",
+			},
+			result: &scheduler.SimulationResult{
+				ClusterQueue: cqName,
+				Message:      "couldn't assign flavors to pod set main: insufficient quota for cpu in flavor default, request > maximum capacity (3 > 2)",
+			},
+			wantPriority: 10,
+			wantStatus: visibility.PreemptionSimulationStatus{
+				Mode:    "NoFit",
+				Message: "couldn't assign flavors to pod set main: insufficient quota for cpu in flavor default, request > maximum capacity (3 > 2)",
+			},
+		},
+		"nonexistent ClusterQueue": {
+			clusterQueue: "invalid-cq",
+			spec: visibility.PreemptionSimulationSpec{
+				WorkloadNamespace: nsName,
+				WorkloadName:      "pending",
+			},
+			wantErrMatch: errors.IsNotFound,
+		},
+		"workload not pending in the ClusterQueue": {
+			clusterQueue: cqName,
+			spec: visibility.PreemptionSimulationSpec{
+				WorkloadNamespace: nsName,
+				WorkloadName:      "admitted",
+			},
+			wantErrMatch: errors.IsNotFound,
+		},
+		"no workload": {
+			clusterQueue: cqName,
+			wantErrMatch: errors.IsBadRequest,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			manager := queue.NewManager(utiltesting.NewFakeClient(), nil)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go manager.CleanUpOnContext(ctx)
+			simulator := &fakeAdmissionSimulator{result: tc.result}
+			preemptionSimulationRest := NewPreemptionSimulationREST(manager, simulator)
+			if err := manager.AddClusterQueue(ctx, utiltesting.MakeClusterQueue(cqName).Obj()); err != nil {
+				t.Fatalf("Adding cluster queue %s: %v", cqName, err)
+			}
+			if err := manager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue(lqName, nsName).ClusterQueue(cqName).Obj()); err != nil {
+				t.Fatalf("Adding queue %q: %v", lqName, err)
+			}
+			manager.AddOrUpdateWorkload(utiltesting.MakeWorkload("pending", nsName).Queue(lqName).Priority(10).Obj())
+
+			obj, err := preemptionSimulationRest.Create(ctx, tc.clusterQueue, &visibility.PreemptionSimulation{Spec: tc.spec}, nil, &metav1.CreateOptions{})
+			switch {
+			case tc.wantErrMatch != nil:
+				if !tc.wantErrMatch(err) {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			case err != nil:
+				t.Error(err)
+			default:
+				simulation := obj.(*visibility.PreemptionSimulation)
+				if diff := cmp.Diff(tc.wantStatus, simulation.Status, cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("Unexpected status (-want,+got):\n%s", diff)
+				}
+				if got := ptr.Deref(simulator.workload.Spec.Priority, 0); got != tc.wantPriority {
+					t.Errorf("Unexpected priority of the simulated workload %d, want %d", got, tc.wantPriority)
+				}
+				if pending := manager.PendingWorkloadsInfo(cqName); ptr.Deref(pending[0].Obj.Spec.Priority, 0) != 10 {
+					t.Errorf("The priority of the pending workload was changed")
+				}
+			}
+		})
+	}
+}
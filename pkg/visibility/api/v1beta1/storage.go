@@ -18,15 +18,23 @@ package v1beta1
 
 import (
 	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 )
 
-func NewStorage(mgr *queue.Manager) map[string]rest.Storage {
-	return map[string]rest.Storage{
-		"clusterqueues":                  NewCqREST(),
-		"clusterqueues/pendingworkloads": NewPendingWorkloadsInCqREST(mgr),
-		"localqueues":                    NewLqREST(),
-		"localqueues/pendingworkloads":   NewPendingWorkloadsInLqREST(mgr),
+func NewStorage(c client.Client, mgr *queue.Manager, simulator AdmissionSimulator) map[string]rest.Storage {
+	storage := map[string]rest.Storage{
+		"clusterqueues":                      NewCqREST(),
+		"clusterqueues/pendingworkloads":     NewPendingWorkloadsInCqREST(mgr),
+		"clusterqueues/preemptionsimulation": NewPreemptionSimulationREST(mgr, simulator),
+		"localqueues":                        NewLqREST(),
+		"localqueues/pendingworkloads":       NewPendingWorkloadsInLqREST(mgr),
+		"localqueues/admissionsimulation":    NewAdmissionSimulationREST(mgr, simulator),
 	}
+	if features.Enabled(features.ClusterQueueQuotaSubresource) {
+		storage["clusterqueues/quota"] = NewCqQuotaREST(c)
+	}
+	return storage
 }
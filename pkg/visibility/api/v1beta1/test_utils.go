@@ -29,4 +29,5 @@ type req struct {
 type resp struct {
 	wantErr              error
 	wantPendingWorkloads []visibility.PendingWorkload
+	wantContinue         string
 }
@@ -17,13 +17,22 @@ limitations under the License.
 package v1beta1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/resources"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
-func newPendingWorkload(wlInfo *workload.Info, positionInLq int32, positionInCq int) *visibility.PendingWorkload {
+var (
+	realClock = clock.RealClock{}
+)
+
+func newPendingWorkload(wlInfo *workload.Info, positionInLq int32, positionInCq int, requestsAhead resources.Requests, estimatedAdmissionTime *metav1.Time) *visibility.PendingWorkload {
 	ownerReferences := make([]metav1.OwnerReference, 0, len(wlInfo.Obj.OwnerReferences))
 	for _, ref := range wlInfo.Obj.OwnerReferences {
 		ownerReferences = append(ownerReferences, metav1.OwnerReference{
@@ -33,7 +42,7 @@ func newPendingWorkload(wlInfo *workload.Info, positionInLq int32, positionInCq
 			UID:        ref.UID,
 		})
 	}
-	return &visibility.PendingWorkload{
+	pendingWorkload := &visibility.PendingWorkload{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:              wlInfo.Obj.Name,
 			Namespace:         wlInfo.Obj.Namespace,
@@ -44,5 +53,28 @@ func newPendingWorkload(wlInfo *workload.Info, positionInLq int32, positionInCq
 		Priority:               *wlInfo.Obj.Spec.Priority,
 		LocalQueueName:         wlInfo.Obj.Spec.QueueName,
 		PositionInLocalQueue:   positionInLq,
+		EstimatedAdmissionTime: estimatedAdmissionTime,
+	}
+	if len(requestsAhead) > 0 {
+		pendingWorkload.RequestsAhead = requestsAhead.ToResourceList()
+	}
+	return pendingWorkload
+}
+
+// addTotalRequests adds the total requests of the workload to the requests.
+func addTotalRequests(requests resources.Requests, wlInfo *workload.Info) {
+	for _, ps := range wlInfo.TotalRequests {
+		requests.Add(ps.Requests)
+	}
+}
+
+// estimatedAdmissionTime returns when the workload in the given position of the
+// ClusterQueue is expected to get quota reserved, assuming that the ClusterQueue
+// keeps its admission throughput, in workloads per second.
+func estimatedAdmissionTime(now time.Time, throughput float64, positionInCq int) *metav1.Time {
+	if throughput <= 0 {
+		return nil
 	}
+	wait := time.Duration(float64(positionInCq+1) / throughput * float64(time.Second))
+	return ptr.To(metav1.NewTime(now.Add(wait).Truncate(time.Second)))
 }
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/queue"
+)
+
+const defaultWatchInterval = time.Second
+
+// pendingWorkloadsWatch streams the changes in the results of a query of
+// pending workloads as newline-delimited watch events.
+type pendingWorkloadsWatch struct {
+	queueMgr *queue.Manager
+	query    *pendingWorkloadsQuery
+	notFound *errors.StatusError
+	interval time.Duration
+	now      func() time.Time
+}
+
+var _ runtime.Object = &pendingWorkloadsWatch{}
+var _ rest.ResourceStreamer = &pendingWorkloadsWatch{}
+
+// GetObjectKind implements runtime.Object interface
+func (w *pendingWorkloadsWatch) GetObjectKind() schema.ObjectKind {
+	return schema.EmptyObjectKind
+}
+
+// DeepCopyObject implements runtime.Object interface
+func (w *pendingWorkloadsWatch) DeepCopyObject() runtime.Object {
+	c := *w
+	return &c
+}
+
+// InputStream implements rest.ResourceStreamer interface
+// It polls the query results and writes an event for each pending workload
+// that is added, modified or deleted, until the request is done.
+func (w *pendingWorkloadsWatch) InputStream(ctx context.Context, _, _ string) (io.ReadCloser, bool, string, error) {
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(w.run(ctx, json.NewEncoder(writer)))
+	}()
+	return reader, true, runtime.ContentTypeJSON, nil
+}
+
+func (w *pendingWorkloadsWatch) run(ctx context.Context, encoder *json.Encoder) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	// The workloads are matched by key, and only sent again if they change
+	// other than in the estimated admission time, which moves all the time.
+	previous := make(map[string]visibility.PendingWorkload)
+	for {
+		wls, _, found := w.query.list(w.queueMgr, w.now())
+		if !found {
+			return encodeWatchEvent(encoder, watch.Error, &w.notFound.ErrStatus)
+		}
+		current := make(map[string]visibility.PendingWorkload, len(wls))
+		for _, wl := range wls {
+			key := queue.QueueKey(wl.Namespace, wl.Name)
+			current[key] = wl
+			prev, ok := previous[key]
+			switch {
+			case !ok:
+				if err := encodeWatchEvent(encoder, watch.Added, &wl); err != nil {
+					return err
+				}
+			case !equalIgnoringEstimatedAdmissionTime(prev, wl):
+				if err := encodeWatchEvent(encoder, watch.Modified, &wl); err != nil {
+					return err
+				}
+			}
+		}
+		for key, wl := range previous {
+			if _, ok := current[key]; !ok {
+				if err := encodeWatchEvent(encoder, watch.Deleted, &wl); err != nil {
+					return err
+				}
+			}
+		}
+		previous = current
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func equalIgnoringEstimatedAdmissionTime(a, b visibility.PendingWorkload) bool {
+	a.EstimatedAdmissionTime = nil
+	b.EstimatedAdmissionTime = nil
+	return equality.Semantic.DeepEqual(a, b)
+}
+
+func encodeWatchEvent(encoder *json.Encoder, eventType watch.EventType, obj any) error {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return encoder.Encode(&metav1.WatchEvent{
+		Type:   string(eventType),
+		Object: runtime.RawExtension{Raw: raw},
+	})
+}
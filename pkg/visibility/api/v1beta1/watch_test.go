@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	visibility "sigs.k8s.io/kueue/apis/visibility/v1beta1"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+type watchedEvent struct {
+	Type                   watch.EventType
+	Name                   string
+	PositionInClusterQueue int32
+}
+
+func TestWatchPendingWorkloadsInCQ(t *testing.T) {
+	const (
+		nsName = "foo"
+		cqName = "cq"
+		lqName = "lq"
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager := queue.NewManager(utiltesting.NewFakeClient(), nil)
+	go manager.CleanUpOnContext(ctx)
+	if err := manager.AddClusterQueue(ctx, utiltesting.MakeClusterQueue(cqName).Obj()); err != nil {
+		t.Fatalf("Adding cluster queue: %v", err)
+	}
+	if err := manager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue(lqName, nsName).ClusterQueue(cqName).Obj()); err != nil {
+		t.Fatalf("Adding queue: %v", err)
+	}
+	now := time.Now()
+	wlA := utiltesting.MakeWorkload("a", nsName).Queue(lqName).Priority(0).Creation(now).Obj()
+	wlB := utiltesting.MakeWorkload("b", nsName).Queue(lqName).Priority(0).Creation(now.Add(time.Second)).Obj()
+	manager.AddOrUpdateWorkload(wlA)
+
+	pendingWorkloadsInCqRest := NewPendingWorkloadsInCqREST(manager)
+	pendingWorkloadsInCqRest.watchInterval = 10 * time.Millisecond
+	obj, err := pendingWorkloadsInCqRest.Get(ctx, cqName, &visibility.PendingWorkloadOptions{
+		Limit: constants.DefaultPendingWorkloadsLimit,
+		Watch: true,
+	})
+	if err != nil {
+		t.Fatalf("Getting the pending workloads: %v", err)
+	}
+	streamer, ok := obj.(rest.ResourceStreamer)
+	if !ok {
+		t.Fatalf("Unexpected object %T, want a rest.ResourceStreamer", obj)
+	}
+	stream, _, _, err := streamer.InputStream(ctx, "", "")
+	if err != nil {
+		t.Fatalf("Opening the stream: %v", err)
+	}
+	defer stream.Close()
+	decoder := json.NewDecoder(stream)
+	nextEvent := func() watchedEvent {
+		t.Helper()
+		var event metav1.WatchEvent
+		if err := decoder.Decode(&event); err != nil {
+			t.Fatalf("Decoding the event: %v", err)
+		}
+		var wl visibility.PendingWorkload
+		if err := json.Unmarshal(event.Object.Raw, &wl); err != nil {
+			t.Fatalf("Decoding the pending workload: %v", err)
+		}
+		return watchedEvent{Type: watch.EventType(event.Type), Name: wl.Name, PositionInClusterQueue: wl.PositionInClusterQueue}
+	}
+
+	if diff := cmp.Diff(watchedEvent{Type: watch.Added, Name: "a"}, nextEvent()); diff != "" {
+		t.Errorf("Unexpected initial event (-want,+got):\n%s", diff)
+	}
+
+	manager.AddOrUpdateWorkload(wlB)
+	if diff := cmp.Diff(watchedEvent{Type: watch.Added, Name: "b", PositionInClusterQueue: 1}, nextEvent()); diff != "" {
+		t.Errorf("Unexpected event after adding a workload (-want,+got):\n%s", diff)
+	}
+
+	manager.DeleteWorkload(wlA)
+	gotEvents := []watchedEvent{nextEvent(), nextEvent()}
+	wantEvents := []watchedEvent{
+		{Type: watch.Modified, Name: "b"},
+		{Type: watch.Deleted, Name: "a"},
+	}
+	if diff := cmp.Diff(wantEvents, gotEvents); diff != "" {
+		t.Errorf("Unexpected events after deleting a workload (-want,+got):\n%s", diff)
+	}
+
+	manager.DeleteClusterQueue(utiltesting.MakeClusterQueue(cqName).Obj())
+	var event metav1.WatchEvent
+	if err := decoder.Decode(&event); err != nil {
+		t.Fatalf("Decoding the event: %v", err)
+	}
+	var status metav1.Status
+	if err := json.Unmarshal(event.Object.Raw, &status); err != nil {
+		t.Fatalf("Decoding the status: %v", err)
+	}
+	if event.Type != string(watch.Error) || status.Reason != metav1.StatusReasonNotFound {
+		t.Errorf("Unexpected event after deleting the ClusterQueue, got type %q with status reason %q", event.Type, status.Reason)
+	}
+}
@@ -20,20 +20,25 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	openapinamer "k8s.io/apiserver/pkg/endpoints/openapi"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	genericoptions "k8s.io/apiserver/pkg/server/options"
 	utilversion "k8s.io/apiserver/pkg/util/version"
 	"k8s.io/client-go/pkg/version"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	generatedopenapi "sigs.k8s.io/kueue/apis/visibility/openapi"
 	visibilityv1beta1 "sigs.k8s.io/kueue/apis/visibility/v1beta1"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/visibility/api"
+	apiv1beta1 "sigs.k8s.io/kueue/pkg/visibility/api/v1beta1"
 
 	_ "k8s.io/component-base/metrics/prometheus/restclient" // for client-go metrics registration
 )
@@ -46,8 +51,9 @@ var (
 // +kubebuilder:rbac:groups=flowcontrol.apiserver.k8s.io,resources=flowschemas,verbs=list;watch
 // +kubebuilder:rbac:groups=flowcontrol.apiserver.k8s.io,resources=flowschemas/status,verbs=patch
 
-// CreateAndStartVisibilityServer creates visibility server injecting KueueManager and starts it
-func CreateAndStartVisibilityServer(ctx context.Context, kueueMgr *queue.Manager) {
+// CreateAndStartVisibilityServer creates visibility server injecting the client,
+// KueueManager and the simulator of the admission of workloads, and starts it
+func CreateAndStartVisibilityServer(ctx context.Context, c client.Client, kueueMgr *queue.Manager, simulator apiv1beta1.AdmissionSimulator) {
 	config := newVisibilityServerConfig()
 	if err := applyVisibilityServerOptions(config); err != nil {
 		setupLog.Error(err, "Unable to apply VisibilityServerOptions")
@@ -60,7 +66,7 @@ func CreateAndStartVisibilityServer(ctx context.Context, kueueMgr *queue.Manager
 		os.Exit(1)
 	}
 
-	if err := api.Install(visibilityServer, kueueMgr); err != nil {
+	if err := api.Install(visibilityServer, c, kueueMgr, simulator); err != nil {
 		setupLog.Error(err, "Unable to install visibility.kueue.x-k8s.io API")
 		os.Exit(1)
 	}
@@ -98,6 +104,20 @@ func newVisibilityServerConfig() *genericapiserver.RecommendedConfig {
 	c.Config.OpenAPIV3Config.Info.Version = version
 
 	c.EnableMetrics = true
+	c.LongRunningFunc = longRunningRequestCheck(c.LongRunningFunc)
 
 	return c
 }
+
+// longRunningRequestCheck extends the check with the watches of pending
+// workloads, so that they aren't closed by the request timeout.
+func longRunningRequestCheck(check apirequest.LongRunningRequestCheck) apirequest.LongRunningRequestCheck {
+	return func(r *http.Request, requestInfo *apirequest.RequestInfo) bool {
+		if requestInfo.Subresource == "pendingworkloads" {
+			if watch, _ := strconv.ParseBool(r.URL.Query().Get("watch")); watch {
+				return true
+			}
+		}
+		return check(r, requestInfo)
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/admissionpolicy"
 	"sigs.k8s.io/kueue/pkg/features"
 )
 
@@ -104,6 +105,8 @@ func ValidateClusterQueue(cq *kueue.ClusterQueue) field.ErrorList {
 	allErrs = append(allErrs, validateResourceGroups(cq.Spec.ResourceGroups, config, path.Child("resourceGroups"))...)
 	allErrs = append(allErrs,
 		validation.ValidateLabelSelector(cq.Spec.NamespaceSelector, validation.LabelSelectorValidationOptions{}, path.Child("namespaceSelector"))...)
+	allErrs = append(allErrs,
+		validation.ValidateLabelSelector(cq.Spec.LocalQueueNamespaceSelector, validation.LabelSelectorValidationOptions{}, path.Child("localQueueNamespaceSelector"))...)
 	allErrs = append(allErrs, validateCQAdmissionChecks(&cq.Spec, path)...)
 	if cq.Spec.Preemption != nil {
 		allErrs = append(allErrs, validatePreemption(cq.Spec.Preemption, path.Child("preemption"))...)
@@ -111,6 +114,7 @@ func ValidateClusterQueue(cq *kueue.ClusterQueue) field.ErrorList {
 	if cq.Spec.FairSharing != nil {
 		allErrs = append(allErrs, validateFairSharing(cq.Spec.FairSharing, path.Child("fairSharing"))...)
 	}
+	allErrs = append(allErrs, admissionpolicy.Validate(cq.Spec.AdmissionPolicies, path.Child("admissionPolicies"))...)
 	return allErrs
 }
 
@@ -228,5 +232,10 @@ func validateFairSharing(fs *kueue.FairSharing, fldPath *field.Path) field.Error
 	if fs.Weight != nil && fs.Weight.Cmp(resource.Quantity{}) < 0 {
 		allErrs = append(allErrs, field.Invalid(fldPath, fs.Weight.String(), apimachineryvalidation.IsNegativeErrorMsg))
 	}
+	for i, rw := range fs.ResourceWeights {
+		if rw.Weight.Cmp(resource.Quantity{}) < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("resourceWeights").Index(i).Child("weight"), rw.Weight.String(), apimachineryvalidation.IsNegativeErrorMsg))
+		}
+	}
 	return allErrs
 }
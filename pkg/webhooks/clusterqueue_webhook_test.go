@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
@@ -310,6 +311,24 @@ func TestValidateClusterQueue(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "fair sharing with resource weights",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				FairWeight(resource.MustParse("1")).
+				ResourceFairWeight("nvidia.com/gpu", resource.MustParse("2")).
+				ResourceFairWeight(corev1.ResourceCPU, resource.MustParse("0")).
+				Obj(),
+		},
+		{
+			name: "fair sharing with a negative resource weight",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ResourceFairWeight(corev1.ResourceCPU, resource.MustParse("1")).
+				ResourceFairWeight("nvidia.com/gpu", resource.MustParse("-1")).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("fairSharing", "resourceWeights").Index(1).Child("weight"), "-1", ""),
+			},
+		},
 		{
 			name: "existing cluster queue created with older Kueue version that has a nil borrowWithinCohort field",
 			clusterQueue: &kueue.ClusterQueue{
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"sigs.k8s.io/kueue/pkg/config"
+)
+
+// ConfigurationKey is the key of the data of the ConfigMap which holds the
+// configuration of Kueue.
+const ConfigurationKey = "controller_manager_config.yaml"
+
+// ConfigurationWebhook validates the configuration of Kueue, when the ConfigMap
+// which holds it is created or updated, so that an invalid configuration is
+// rejected instead of preventing Kueue from starting, or being ignored when
+// the configuration is reloaded.
+type ConfigurationWebhook struct {
+	scheme *runtime.Scheme
+}
+
+func setupWebhookForConfiguration(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&corev1.ConfigMap{}).
+		WithValidator(&ConfigurationWebhook{scheme: mgr.GetScheme()}).
+		Complete()
+}
+
+// The webhook is restricted to the ConfigMap holding the configuration, in the
+// namespace of Kueue, by the namespace and object selectors of the webhook
+// configuration. It fails open, so that the configuration can still be fixed
+// when Kueue is not running.
+//+kubebuilder:webhook:path=/validate--v1-configmap,mutating=false,failurePolicy=ignore,sideEffects=None,groups="",resources=configmaps,verbs=create;update,versions=v1,name=vconfiguration.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomValidator = &ConfigurationWebhook{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *ConfigurationWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cm := obj.(*corev1.ConfigMap)
+	log := ctrl.LoggerFrom(ctx).WithName("configuration-webhook")
+	log.V(5).Info("Validating Configuration create")
+	return w.validateConfigMap(cm)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *ConfigurationWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	cm := newObj.(*corev1.ConfigMap)
+	log := ctrl.LoggerFrom(ctx).WithName("configuration-webhook")
+	log.V(5).Info("Validating Configuration update")
+	return w.validateConfigMap(cm)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *ConfigurationWebhook) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (w *ConfigurationWebhook) validateConfigMap(cm *corev1.ConfigMap) (admission.Warnings, error) {
+	content, found := cm.Data[ConfigurationKey]
+	if !found {
+		return nil, nil
+	}
+	return config.ValidateContent(w.scheme, []byte(content))
+}
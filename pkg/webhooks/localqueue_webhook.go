@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+type LocalQueueWebhook struct {
+	client client.Client
+}
+
+func setupWebhookForLocalQueue(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&kueue.LocalQueue{}).
+		WithValidator(&LocalQueueWebhook{client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-kueue-x-k8s-io-v1beta1-localqueue,mutating=false,failurePolicy=fail,sideEffects=None,groups=kueue.x-k8s.io,resources=localqueues,verbs=create,versions=v1beta1,name=vlocalqueue.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomValidator = &LocalQueueWebhook{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *LocalQueueWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	lq := obj.(*kueue.LocalQueue)
+	log := ctrl.LoggerFrom(ctx).WithName("localqueue-webhook")
+	log.V(5).Info("Validating create")
+	allErrs, err := w.validateNamespaceAllowed(ctx, lq)
+	if err != nil {
+		return nil, err
+	}
+	return nil, allErrs.ToAggregate()
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *LocalQueueWebhook) ValidateUpdate(_ context.Context, _, _ runtime.Object) (admission.Warnings, error) {
+	// the clusterQueue of a LocalQueue is immutable
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *LocalQueueWebhook) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateNamespaceAllowed verifies that the namespace of the LocalQueue is
+// selected by the localQueueNamespaceSelector of its ClusterQueue. The
+// LocalQueues pointing at missing ClusterQueues are allowed, the scheduler
+// doesn't admit their workloads if the ClusterQueue is created later with a
+// selector not matching their namespace.
+func (w *LocalQueueWebhook) validateNamespaceAllowed(ctx context.Context, lq *kueue.LocalQueue) (field.ErrorList, error) {
+	cq := &kueue.ClusterQueue{}
+	if err := w.client.Get(ctx, types.NamespacedName{Name: string(lq.Spec.ClusterQueue)}, cq); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if cq.Spec.LocalQueueNamespaceSelector == nil {
+		return nil, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(cq.Spec.LocalQueueNamespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	ns := &corev1.Namespace{}
+	if err := w.client.Get(ctx, types.NamespacedName{Name: lq.Namespace}, ns); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if !selector.Matches(labels.Set(ns.Labels)) {
+		return field.ErrorList{field.Forbidden(field.NewPath("spec", "clusterQueue"),
+			fmt.Sprintf("the namespace %s isn't selected by the localQueueNamespaceSelector of the ClusterQueue %s", lq.Namespace, cq.Name))}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestValidateLocalQueueCreate(t *testing.T) {
+	teamSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+	namespaces := []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"team": "b"}}},
+	}
+	cases := map[string]struct {
+		clusterQueue *kueue.ClusterQueue
+		localQueue   *kueue.LocalQueue
+		wantErr      field.ErrorList
+	}{
+		"ClusterQueue without selector": {
+			clusterQueue: utiltesting.MakeClusterQueue("cq").Obj(),
+			localQueue:   utiltesting.MakeLocalQueue("lq", "team-b").ClusterQueue("cq").Obj(),
+		},
+		"namespace selected": {
+			clusterQueue: utiltesting.MakeClusterQueue("cq").LocalQueueNamespaceSelector(teamSelector).Obj(),
+			localQueue:   utiltesting.MakeLocalQueue("lq", "team-a").ClusterQueue("cq").Obj(),
+		},
+		"namespace not selected": {
+			clusterQueue: utiltesting.MakeClusterQueue("cq").LocalQueueNamespaceSelector(teamSelector).Obj(),
+			localQueue:   utiltesting.MakeLocalQueue("lq", "team-b").ClusterQueue("cq").Obj(),
+			wantErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "clusterQueue"), ""),
+			},
+		},
+		"missing ClusterQueue": {
+			localQueue: utiltesting.MakeLocalQueue("lq", "team-b").ClusterQueue("cq").Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			builder := utiltesting.NewClientBuilder()
+			for _, ns := range namespaces {
+				builder = builder.WithObjects(ns)
+			}
+			if tc.clusterQueue != nil {
+				builder = builder.WithObjects(tc.clusterQueue)
+			}
+			w := &LocalQueueWebhook{client: builder.Build()}
+			gotErr, err := w.validateNamespaceAllowed(context.Background(), tc.localQueue)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "Detail", "BadValue")); diff != "" {
+				t.Errorf("Unexpected errors (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+)
+
+type TenantWebhook struct{}
+
+func setupWebhookForTenant(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&kueuealpha.Tenant{}).
+		WithValidator(&TenantWebhook{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-kueue-x-k8s-io-v1alpha1-tenant,mutating=false,failurePolicy=fail,sideEffects=None,groups=kueue.x-k8s.io,resources=tenants,verbs=create;update,versions=v1alpha1,name=vtenant.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomValidator = &TenantWebhook{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *TenantWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	tenant := obj.(*kueuealpha.Tenant)
+	log := ctrl.LoggerFrom(ctx).WithName("tenant-webhook")
+	log.V(5).Info("Validating Tenant create")
+	return nil, validateTenant(tenant).ToAggregate()
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *TenantWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	tenant := newObj.(*kueuealpha.Tenant)
+	log := ctrl.LoggerFrom(ctx).WithName("tenant-webhook")
+	log.V(5).Info("Validating Tenant update")
+	return nil, validateTenant(tenant).ToAggregate()
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *TenantWebhook) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateTenant(tenant *kueuealpha.Tenant) field.ErrorList {
+	var allErrs field.ErrorList
+	path := field.NewPath("spec", "usageLimits")
+	for name, q := range tenant.Spec.UsageLimits {
+		if q.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Key(string(name)), q.String(), "must be greater than 0"))
+		}
+	}
+	return allErrs
+}
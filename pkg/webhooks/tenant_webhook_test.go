@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestValidateTenant(t *testing.T) {
+	limitsPath := field.NewPath("spec", "usageLimits")
+	cases := map[string]struct {
+		tenant  *kueuealpha.Tenant
+		wantErr field.ErrorList
+	}{
+		"no usage limits": {
+			tenant: utiltesting.MakeTenant("tenant").LocalQueue("ns", "lq").Obj(),
+		},
+		"positive usage limits": {
+			tenant: utiltesting.MakeTenant("tenant").
+				UsageLimit(corev1.ResourceCPU, "10").
+				UsageLimit(corev1.ResourceMemory, "1Gi").
+				Obj(),
+		},
+		"zero usage limit": {
+			tenant: utiltesting.MakeTenant("tenant").
+				UsageLimit(corev1.ResourceCPU, "10").
+				UsageLimit(corev1.ResourceMemory, "0").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(limitsPath.Key(string(corev1.ResourceMemory)), "0", ""),
+			},
+		},
+		"negative usage limit": {
+			tenant: utiltesting.MakeTenant("tenant").
+				UsageLimit(corev1.ResourceCPU, "-1").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(limitsPath.Key(string(corev1.ResourceCPU)), "-1", ""),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := validateTenant(tc.tenant)
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "Detail")); diff != "" {
+				t.Errorf("Unexpected errors (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
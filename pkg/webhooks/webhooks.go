@@ -18,12 +18,45 @@ package webhooks
 
 import (
 	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/kueue/pkg/util/authorization"
+	"sigs.k8s.io/kueue/pkg/util/submitter"
 )
 
+type options struct {
+	localQueueAuthorizer *authorization.LocalQueueAuthorizer
+	submitterRecorder    *submitter.Recorder
+}
+
+// Option configures the webhooks.
+type Option func(*options)
+
+// WithLocalQueueAuthorizer sets the authorizer with which the workload webhook
+// verifies that the users creating workloads are allowed to submit them to
+// their LocalQueues.
+func WithLocalQueueAuthorizer(a *authorization.LocalQueueAuthorizer) Option {
+	return func(o *options) {
+		o.localQueueAuthorizer = a
+	}
+}
+
+// WithSubmitterRecorder sets the recorder with which the workload webhook
+// records the identity of the users creating workloads.
+func WithSubmitterRecorder(r *submitter.Recorder) Option {
+	return func(o *options) {
+		o.submitterRecorder = r
+	}
+}
+
 // Setup sets up the webhooks for core controllers. It returns the name of the
 // webhook that failed to create and an error, if any.
-func Setup(mgr ctrl.Manager) (string, error) {
-	if err := setupWebhookForWorkload(mgr); err != nil {
+func Setup(mgr ctrl.Manager, opts ...Option) (string, error) {
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if err := setupWebhookForWorkload(mgr, options); err != nil {
 		return "Workload", err
 	}
 
@@ -39,5 +72,17 @@ func Setup(mgr ctrl.Manager) (string, error) {
 		return "Cohort", err
 	}
 
+	if err := setupWebhookForConfiguration(mgr); err != nil {
+		return "Configuration", err
+	}
+
+	if err := setupWebhookForLocalQueue(mgr); err != nil {
+		return "LocalQueue", err
+	}
+
+	if err := setupWebhookForTenant(mgr); err != nil {
+		return "Tenant", err
+	}
+
 	return "", nil
 }
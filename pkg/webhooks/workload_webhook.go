@@ -22,6 +22,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -32,19 +33,29 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/resources"
+	"sigs.k8s.io/kueue/pkg/util/authorization"
 	"sigs.k8s.io/kueue/pkg/util/slices"
+	"sigs.k8s.io/kueue/pkg/util/submitter"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
-type WorkloadWebhook struct{}
+type WorkloadWebhook struct {
+	localQueueAuthorizer *authorization.LocalQueueAuthorizer
+	submitterRecorder    *submitter.Recorder
+}
 
-func setupWebhookForWorkload(mgr ctrl.Manager) error {
+func setupWebhookForWorkload(mgr ctrl.Manager, options options) error {
+	wh := &WorkloadWebhook{
+		localQueueAuthorizer: options.localQueueAuthorizer,
+		submitterRecorder:    options.submitterRecorder,
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&kueue.Workload{}).
-		WithDefaulter(&WorkloadWebhook{}).
-		WithValidator(&WorkloadWebhook{}).
+		WithDefaulter(wh).
+		WithValidator(wh).
 		Complete()
 }
 
@@ -65,6 +76,11 @@ func (w *WorkloadWebhook) Default(ctx context.Context, obj runtime.Object) error
 		}
 	}
 
+	// The workloads of the jobs are created by Kueue, with the identity
+	// recorded in the jobs.
+	if metav1.GetControllerOf(wl) == nil {
+		return w.submitterRecorder.Record(ctx, wl)
+	}
 	return nil
 }
 
@@ -77,7 +93,13 @@ func (w *WorkloadWebhook) ValidateCreate(ctx context.Context, obj runtime.Object
 	wl := obj.(*kueue.Workload)
 	log := ctrl.LoggerFrom(ctx).WithName("workload-webhook")
 	log.V(5).Info("Validating create")
-	return nil, ValidateWorkload(wl).ToAggregate()
+	allErrs := ValidateWorkload(wl)
+	// The workloads of the jobs are created by Kueue, their users were
+	// verified when creating the jobs.
+	if metav1.GetControllerOf(wl) == nil {
+		allErrs = append(allErrs, w.localQueueAuthorizer.Authorize(ctx, wl.Namespace, wl.Spec.QueueName, field.NewPath("spec", "queueName"))...)
+	}
+	return nil, allErrs.ToAggregate()
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
@@ -99,17 +121,21 @@ func ValidateWorkload(obj *kueue.Workload) field.ErrorList {
 	specPath := field.NewPath("spec")
 
 	variableCountPodSets := 0
+	podSetNames := make([]string, len(obj.Spec.PodSets))
 	for i := range obj.Spec.PodSets {
 		ps := &obj.Spec.PodSets[i]
 		allErrs = append(allErrs, validatePodSet(ps, specPath.Child("podSets").Index(i))...)
 		if ps.MinCount != nil {
 			variableCountPodSets++
 		}
+		podSetNames[i] = ps.Name
 	}
 
 	if variableCountPodSets > 1 {
 		allErrs = append(allErrs, field.Invalid(specPath.Child("podSets"), variableCountPodSets, "at most one podSet can use minCount"))
 	}
+	allErrs = append(allErrs, workload.ValidatePinnedFlavors(obj.Annotations, field.NewPath("metadata", "annotations"), podSetNames...)...)
+	allErrs = append(allErrs, workload.ValidateExcludedFlavors(obj.Annotations, field.NewPath("metadata", "annotations"))...)
 
 	statusPath := field.NewPath("status")
 	if workload.HasQuotaReservation(obj) {
@@ -198,6 +224,22 @@ func validateImmutablePodSetUpdates(newObj, oldObj *kueue.Workload, basePath *fi
 	return allErrs
 }
 
+func validateAdmissionCheckStateTransitions(newObj, oldObj *kueue.Workload, basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	oldAcs := slices.ToRefMap(oldObj.Status.AdmissionChecks, func(f *kueue.AdmissionCheckState) string { return f.Name })
+	for i := range newObj.Status.AdmissionChecks {
+		newAc := &newObj.Status.AdmissionChecks[i]
+		oldAc, found := oldAcs[newAc.Name]
+		if !found {
+			continue
+		}
+		if !workload.IsValidCheckStateTransition(oldAc.State, newAc.State) {
+			allErrs = append(allErrs, field.Forbidden(basePath.Index(i).Child("state"), fmt.Sprintf("cannot transition from %s to %s", oldAc.State, newAc.State)))
+		}
+	}
+	return allErrs
+}
+
 // validateTolerations is extracted from git.k8s.io/kubernetes/pkg/apis/core/validation/validation.go
 // we do not import it as dependency, see the comment:
 // https://github.com/kubernetes/kubernetes/issues/79384#issuecomment-505627280
@@ -227,7 +269,8 @@ func validateAdmission(obj *kueue.Workload, path *field.Path) field.ErrorList {
 		if !names.Has(ps.Name) {
 			allErrs = append(allErrs, field.NotFound(psaPath.Child("name"), ps.Name))
 		}
-		if count := ptr.Deref(ps.Count, 0); count > 0 {
+		// The pods resized in place make the resource usage of the podSet uneven.
+		if count := ptr.Deref(ps.Count, 0); count > 0 && !features.Enabled(features.InPlacePodResize) {
 			for k, v := range ps.ResourceUsage {
 				if (resources.ResourceValue(k, v) % int64(count)) != 0 {
 					allErrs = append(allErrs, field.Invalid(psaPath.Child("resourceUsage").Key(string(k)), v, fmt.Sprintf("is not a multiple of %d", ps.Count)))
@@ -272,23 +315,82 @@ func ValidateWorkloadUpdate(newObj, oldObj *kueue.Workload) field.ErrorList {
 	allErrs = append(allErrs, ValidateWorkload(newObj)...)
 
 	if workload.HasQuotaReservation(oldObj) {
-		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newObj.Spec.PodSets, oldObj.Spec.PodSets, specPath.Child("podSets"))...)
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newObj.Annotations[controllerconsts.PodSetFlavorsAnnotation],
+			oldObj.Annotations[controllerconsts.PodSetFlavorsAnnotation], field.NewPath("metadata", "annotations").Key(controllerconsts.PodSetFlavorsAnnotation))...)
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newObj.Annotations[controllerconsts.ExcludedFlavorsAnnotation],
+			oldObj.Annotations[controllerconsts.ExcludedFlavorsAnnotation], field.NewPath("metadata", "annotations").Key(controllerconsts.ExcludedFlavorsAnnotation))...)
+		if features.Enabled(features.ElasticWorkloadResize) {
+			allErrs = append(allErrs, validatePodSetsResize(newObj, oldObj, specPath.Child("podSets"))...)
+		} else {
+			allErrs = append(allErrs, apivalidation.ValidateImmutableField(newObj.Spec.PodSets, oldObj.Spec.PodSets, specPath.Child("podSets"))...)
+		}
 	}
 	if workload.HasQuotaReservation(newObj) && workload.HasQuotaReservation(oldObj) {
 		allErrs = append(allErrs, validateReclaimablePodsUpdate(newObj, oldObj, field.NewPath("status", "reclaimablePods"))...)
 	}
 	allErrs = append(allErrs, validateAdmissionUpdate(newObj.Status.Admission, oldObj.Status.Admission, field.NewPath("status", "admission"))...)
 	allErrs = append(allErrs, validateImmutablePodSetUpdates(newObj, oldObj, statusPath.Child("admissionChecks"))...)
+	allErrs = append(allErrs, validateAdmissionCheckStateTransitions(newObj, oldObj, statusPath.Child("admissionChecks"))...)
 
 	return allErrs
 }
 
+// validatePodSetsResize validates that only the counts of the podSets of a
+// workload with quota reserved can change, and only for podSets with a non-zero
+// count assigned, which don't use minCount nor topology.
+func validatePodSetsResize(newObj, oldObj *kueue.Workload, path *field.Path) field.ErrorList {
+	if len(newObj.Spec.PodSets) != len(oldObj.Spec.PodSets) {
+		return apivalidation.ValidateImmutableField(newObj.Spec.PodSets, oldObj.Spec.PodSets, path)
+	}
+	resized := make([]kueue.PodSet, len(newObj.Spec.PodSets))
+	for i := range newObj.Spec.PodSets {
+		resized[i] = *newObj.Spec.PodSets[i].DeepCopy()
+		resized[i].Count = oldObj.Spec.PodSets[i].Count
+	}
+	if allErrs := apivalidation.ValidateImmutableField(resized, oldObj.Spec.PodSets, path); len(allErrs) > 0 {
+		return allErrs
+	}
+
+	assignments := slices.ToRefMap(oldObj.Status.Admission.PodSetAssignments, func(psa *kueue.PodSetAssignment) string { return psa.Name })
+	var allErrs field.ErrorList
+	for i := range newObj.Spec.PodSets {
+		ps := &newObj.Spec.PodSets[i]
+		if ps.Count == oldObj.Spec.PodSets[i].Count {
+			continue
+		}
+		countPath := path.Index(i).Child("count")
+		psa := assignments[ps.Name]
+		switch {
+		case ps.MinCount != nil:
+			allErrs = append(allErrs, field.Forbidden(countPath, "cannot be changed for a podSet with minCount"))
+		case psa == nil || ptr.Deref(psa.Count, 0) == 0:
+			allErrs = append(allErrs, field.Forbidden(countPath, "cannot be changed for a podSet without pods assigned"))
+		case psa.TopologyAssignment != nil:
+			allErrs = append(allErrs, field.Forbidden(countPath, "cannot be changed for a podSet with topology assignment"))
+		}
+	}
+	return allErrs
+}
+
 // validateAdmissionUpdate validates that admission can be set or unset, but the
-// fields within can't change.
+// fields within can't change, except for the counts and resource usage of the
+// podSet assignments when resizing workloads is enabled, and for the resource
+// usage when resizing pods in place is enabled.
 func validateAdmissionUpdate(new, old *kueue.Admission, path *field.Path) field.ErrorList {
 	if old == nil || new == nil {
 		return nil
 	}
+	elasticResize := features.Enabled(features.ElasticWorkloadResize)
+	if (elasticResize || features.Enabled(features.InPlacePodResize)) && len(new.PodSetAssignments) == len(old.PodSetAssignments) {
+		resized := new.DeepCopy()
+		for i := range resized.PodSetAssignments {
+			if elasticResize {
+				resized.PodSetAssignments[i].Count = old.PodSetAssignments[i].Count
+			}
+			resized.PodSetAssignments[i].ResourceUsage = old.PodSetAssignments[i].ResourceUsage
+		}
+		new = resized
+	}
 	return apivalidation.ValidateImmutableField(new, old, path)
 }
 
@@ -29,6 +29,8 @@ import (
 	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	testingutil "sigs.k8s.io/kueue/pkg/util/testing"
 )
 
@@ -237,6 +239,36 @@ func TestValidateWorkload(t *testing.T) {
 				field.Invalid(podSetsPath, nil, ""),
 			},
 		},
+		"pinned flavors for the podSets of the workload": {
+			workload: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Annotations(map[string]string{controllerconsts.PodSetFlavorsAnnotation: "driver=on-demand,workers=spot"}).
+				PodSets(
+					*testingutil.MakePodSet("driver", 1).Obj(),
+					*testingutil.MakePodSet("workers", 3).Obj(),
+				).
+				Obj(),
+		},
+		"pinned flavor for an unknown podSet": {
+			workload: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Annotations(map[string]string{controllerconsts.PodSetFlavorsAnnotation: "workers=spot"}).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("metadata", "annotations").Key(controllerconsts.PodSetFlavorsAnnotation), nil, ""),
+			},
+		},
+		"excluded flavors": {
+			workload: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Annotations(map[string]string{controllerconsts.ExcludedFlavorsAnnotation: "spot,preemptible"}).
+				Obj(),
+		},
+		"invalid excluded flavors": {
+			workload: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Annotations(map[string]string{controllerconsts.ExcludedFlavorsAnnotation: "spot,,preemptible"}).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("metadata", "annotations").Key(controllerconsts.ExcludedFlavorsAnnotation), nil, ""),
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -250,8 +282,10 @@ func TestValidateWorkload(t *testing.T) {
 
 func TestValidateWorkloadUpdate(t *testing.T) {
 	testCases := map[string]struct {
-		before, after *kueue.Workload
-		wantErr       field.ErrorList
+		before, after          *kueue.Workload
+		enableElasticResize    bool
+		enableInPlacePodResize bool
+		wantErr                field.ErrorList
 	}{
 		"reclaimable pod count can change up": {
 			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
@@ -392,9 +426,187 @@ func TestValidateWorkloadUpdate(t *testing.T) {
 				State:              kueue.CheckStateReady,
 			}).Obj(),
 		},
+		"admission check cannot transition from retry to ready": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).AdmissionChecks(kueue.AdmissionCheckState{
+				Name:  "ac1",
+				State: kueue.CheckStateRetry,
+			}).Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).AdmissionChecks(kueue.AdmissionCheckState{
+				Name:  "ac1",
+				State: kueue.CheckStateReady,
+			}).Obj(),
+			wantErr: field.ErrorList{
+				field.Forbidden(field.NewPath("status").Child("admissionChecks").Index(0).Child("state"), ""),
+			},
+		},
+		"admission check can be reset from rejected to pending": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).AdmissionChecks(kueue.AdmissionCheckState{
+				Name:  "ac1",
+				State: kueue.CheckStateRejected,
+			}).Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).AdmissionChecks(kueue.AdmissionCheckState{
+				Name:  "ac1",
+				State: kueue.CheckStatePending,
+			}).Obj(),
+		},
+		"podSet count cannot change once quota is reserved": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 3).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").AssignmentPodCount(3).Obj()).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 4).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").AssignmentPodCount(3).Obj()).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "podSets"), nil, ""),
+			},
+		},
+		"podSet count and admission can change once quota is reserved when resizing is enabled": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 3).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").
+					Assignment(corev1.ResourceCPU, "default", "3").
+					AssignmentPodCount(3).
+					Obj()).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 4).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").
+					Assignment(corev1.ResourceCPU, "default", "4").
+					AssignmentPodCount(4).
+					Obj()).
+				Obj(),
+			enableElasticResize: true,
+		},
+		"podSet with minCount cannot be resized": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 3).SetMinimumCount(2).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").AssignmentPodCount(3).Obj()).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 4).SetMinimumCount(2).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").AssignmentPodCount(3).Obj()).
+				Obj(),
+			enableElasticResize: true,
+			wantErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "podSets").Index(0).Child("count"), ""),
+			},
+		},
+		"podSet template cannot change when resizing is enabled": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 3).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").AssignmentPodCount(3).Obj()).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 4).Image("other").Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").AssignmentPodCount(3).Obj()).
+				Obj(),
+			enableElasticResize: true,
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "podSets"), nil, ""),
+			},
+		},
+		"admission flavors cannot change when resizing is enabled": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 3).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").
+					Assignment(corev1.ResourceCPU, "default", "3").
+					AssignmentPodCount(3).
+					Obj()).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 3).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").
+					Assignment(corev1.ResourceCPU, "other", "3").
+					AssignmentPodCount(3).
+					Obj()).
+				Obj(),
+			enableElasticResize: true,
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("status", "admission"), nil, ""),
+			},
+		},
+		"admission resource usage can change when resizing pods is enabled": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 3).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").
+					Assignment(corev1.ResourceCPU, "default", "3").
+					AssignmentPodCount(3).
+					Obj()).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 3).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").
+					Assignment(corev1.ResourceCPU, "default", "4").
+					AssignmentPodCount(3).
+					Obj()).
+				Obj(),
+			enableInPlacePodResize: true,
+		},
+		"admission count cannot change when only resizing pods is enabled": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 3).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").
+					Assignment(corev1.ResourceCPU, "default", "3").
+					AssignmentPodCount(3).
+					Obj()).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 3).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").
+					Assignment(corev1.ResourceCPU, "default", "4").
+					AssignmentPodCount(4).
+					Obj()).
+				Obj(),
+			enableInPlacePodResize: true,
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("status", "admission"), nil, ""),
+			},
+		}, "pinned flavors can change while the workload has no quota reserved": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Annotations(map[string]string{controllerconsts.PodSetFlavorsAnnotation: "ps1=on-demand"}).
+				PodSets(*testingutil.MakePodSet("ps1", 3).Obj()).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Annotations(map[string]string{controllerconsts.PodSetFlavorsAnnotation: "ps1=spot"}).
+				PodSets(*testingutil.MakePodSet("ps1", 3).Obj()).
+				Obj(),
+		},
+		"pinned flavors cannot change while the workload has quota reserved": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Annotations(map[string]string{controllerconsts.PodSetFlavorsAnnotation: "ps1=on-demand"}).
+				PodSets(*testingutil.MakePodSet("ps1", 3).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").Obj()).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Annotations(map[string]string{controllerconsts.PodSetFlavorsAnnotation: "ps1=spot"}).
+				PodSets(*testingutil.MakePodSet("ps1", 3).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").Obj()).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("metadata", "annotations").Key(controllerconsts.PodSetFlavorsAnnotation), nil, ""),
+			},
+		},
+		"excluded flavors cannot change while the workload has quota reserved": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Annotations(map[string]string{controllerconsts.ExcludedFlavorsAnnotation: "spot"}).
+				PodSets(*testingutil.MakePodSet("ps1", 3).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").Obj()).
+				Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("ps1", 3).Obj()).
+				ReserveQuota(testingutil.MakeAdmission("cluster-queue", "ps1").Obj()).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("metadata", "annotations").Key(controllerconsts.ExcludedFlavorsAnnotation), nil, ""),
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
+			features.SetFeatureGateDuringTest(t, features.ElasticWorkloadResize, tc.enableElasticResize)
+			features.SetFeatureGateDuringTest(t, features.InPlacePodResize, tc.enableInPlacePodResize)
 			errList := ValidateWorkloadUpdate(tc.after, tc.before)
 			if diff := cmp.Diff(tc.wantErr, errList, cmpopts.IgnoreFields(field.Error{}, "Detail", "BadValue")); diff != "" {
 				t.Errorf("ValidateWorkloadUpdate() mismatch (-want +got):\n%s", diff)
@@ -26,16 +26,17 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 )
 
-// SyncAdmittedCondition sync the state of the Admitted condition
-// with the state of QuotaReserved and AdmissionChecks.
+// SyncAdmittedCondition sync the state of the Admitted condition, and the
+// pending reasons, with the state of QuotaReserved and AdmissionChecks.
 // Return true if any change was done.
 func SyncAdmittedCondition(w *kueue.Workload, now time.Time) bool {
 	hasReservation := HasQuotaReservation(w)
 	hasAllChecksReady := HasAllChecksReady(w)
 	isAdmitted := IsAdmitted(w)
+	pendingReasonsChanged := hasReservation && syncAdmissionCheckPendingReasons(w)
 
 	if isAdmitted == (hasReservation && hasAllChecksReady) {
-		return false
+		return pendingReasonsChanged
 	}
 	newCondition := metav1.Condition{
 		Type:               kueue.WorkloadAdmitted,
@@ -72,7 +73,24 @@ func SyncAdmittedCondition(w *kueue.Workload, now time.Time) bool {
 			}
 		}
 	}
-	return apimeta.SetStatusCondition(&w.Status.Conditions, newCondition)
+	return apimeta.SetStatusCondition(&w.Status.Conditions, newCondition) || pendingReasonsChanged
+}
+
+// syncAdmissionCheckPendingReasons sets the pending reasons of a workload with
+// quota reserved to the admission checks which are not ready.
+// Return true if any change was done.
+func syncAdmissionCheckPendingReasons(w *kueue.Workload) bool {
+	var reasons []kueue.PendingReason
+	for _, check := range w.Status.AdmissionChecks {
+		if check.State != kueue.CheckStateReady {
+			reasons = append(reasons, kueue.PendingReason{
+				Reason:         kueue.PendingReasonAdmissionCheck,
+				AdmissionCheck: check.Name,
+				Message:        check.Message,
+			})
+		}
+	}
+	return SetPendingReasons(w, reasons)
 }
 
 // FindAdmissionCheck - returns a pointer to the check identified by checkName if found in checks.
@@ -99,13 +117,45 @@ func ResetChecksOnEviction(w *kueue.Workload, now time.Time) bool {
 			State:              kueue.CheckStatePending,
 			LastTransitionTime: metav1.NewTime(now),
 			Message:            "Reset to Pending after eviction. Previously: " + string(checks[i].State),
+			History:            appendCheckHistory(checks[i].History, checks[i].State, checks[i].LastTransitionTime),
 		}
 		updated = true
 	}
 	return updated
 }
 
+// IsValidCheckStateTransition returns whether an admission check may transition
+// from the state from to the state to. A Rejected check may only be reset to
+// Pending, and a Retry check may only be reset to Pending or be Rejected, so
+// that a check is never Ready without being evaluated again.
+func IsValidCheckStateTransition(from, to kueue.CheckState) bool {
+	if from == to {
+		return true
+	}
+	switch from {
+	case kueue.CheckStateRejected:
+		return to == kueue.CheckStatePending
+	case kueue.CheckStateRetry:
+		return to == kueue.CheckStatePending || to == kueue.CheckStateRejected
+	default:
+		return true
+	}
+}
+
+// appendCheckHistory records the state left by an admission check in its
+// history, dropping the oldest states beyond kueue.MaxAdmissionCheckHistory.
+func appendCheckHistory(history []kueue.AdmissionCheckStateTransition, state kueue.CheckState, since metav1.Time) []kueue.AdmissionCheckStateTransition {
+	history = append(history, kueue.AdmissionCheckStateTransition{State: state, LastTransitionTime: since})
+	if len(history) > kueue.MaxAdmissionCheckHistory {
+		history = history[len(history)-kueue.MaxAdmissionCheckHistory:]
+	}
+	return history
+}
+
 // SetAdmissionCheckState - adds or updates newCheck in the provided checks list.
+// An existing check is left unchanged, including its message and pod set
+// updates, if the transition to the new state is not valid, see
+// IsValidCheckStateTransition.
 func SetAdmissionCheckState(checks *[]kueue.AdmissionCheckState, newCheck kueue.AdmissionCheckState) {
 	if checks == nil {
 		return
@@ -118,8 +168,12 @@ func SetAdmissionCheckState(checks *[]kueue.AdmissionCheckState, newCheck kueue.
 		*checks = append(*checks, newCheck)
 		return
 	}
+	if !IsValidCheckStateTransition(existingCondition.State, newCheck.State) {
+		return
+	}
 
 	if existingCondition.State != newCheck.State {
+		existingCondition.History = appendCheckHistory(existingCondition.History, existingCondition.State, existingCondition.LastTransitionTime)
 		existingCondition.State = newCheck.State
 		if !newCheck.LastTransitionTime.IsZero() {
 			existingCondition.LastTransitionTime = newCheck.LastTransitionTime
@@ -27,6 +27,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 )
 
@@ -36,10 +37,14 @@ func TestSyncAdmittedCondition(t *testing.T) {
 		checkStates      []kueue.AdmissionCheckState
 		conditions       []metav1.Condition
 		pastAdmittedTime int32
+		pendingReasons   []kueue.PendingReason
 
-		wantConditions   []metav1.Condition
-		wantChange       bool
-		wantAdmittedTime int32
+		enablePendingReasons bool
+
+		wantConditions     []metav1.Condition
+		wantChange         bool
+		wantAdmittedTime   int32
+		wantPendingReasons []kueue.PendingReason
 	}{
 		"empty": {},
 		"reservation no checks": {
@@ -87,6 +92,101 @@ func TestSyncAdmittedCondition(t *testing.T) {
 				},
 			},
 		},
+		"reservation, checks not ready, with pending reasons": {
+			checkStates: []kueue.AdmissionCheckState{
+				{
+					Name:    "check1",
+					State:   kueue.CheckStatePending,
+					Message: "waiting for capacity",
+				},
+				{
+					Name:  "check2",
+					State: kueue.CheckStateReady,
+				},
+			},
+			conditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+			},
+			pendingReasons: []kueue.PendingReason{
+				{
+					Reason:   kueue.PendingReasonInsufficientQuota,
+					PodSet:   "main",
+					Flavor:   "default",
+					Resource: corev1.ResourceCPU,
+				},
+			},
+			enablePendingReasons: true,
+			wantConditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+			},
+			wantChange: true,
+			wantPendingReasons: []kueue.PendingReason{
+				{
+					Reason:         kueue.PendingReasonAdmissionCheck,
+					AdmissionCheck: "check1",
+					Message:        "waiting for capacity",
+				},
+			},
+		},
+		"reservation, checks ready, with pending reasons": {
+			checkStates: []kueue.AdmissionCheckState{
+				{
+					Name:  "check1",
+					State: kueue.CheckStateReady,
+				},
+			},
+			conditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+			},
+			pendingReasons: []kueue.PendingReason{
+				{
+					Reason:         kueue.PendingReasonAdmissionCheck,
+					AdmissionCheck: "check1",
+				},
+			},
+			enablePendingReasons: true,
+			wantConditions: []metav1.Condition{
+				{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionTrue,
+				},
+				{
+					Type:               kueue.WorkloadAdmitted,
+					Status:             metav1.ConditionTrue,
+					Reason:             "Admitted",
+					ObservedGeneration: 1,
+				},
+			},
+			wantChange: true,
+		},
+		"no reservation, with pending reasons": {
+			pendingReasons: []kueue.PendingReason{
+				{
+					Reason:   kueue.PendingReasonInsufficientQuota,
+					PodSet:   "main",
+					Flavor:   "default",
+					Resource: corev1.ResourceCPU,
+				},
+			},
+			enablePendingReasons: true,
+			wantPendingReasons: []kueue.PendingReason{
+				{
+					Reason:   kueue.PendingReasonInsufficientQuota,
+					PodSet:   "main",
+					Flavor:   "default",
+					Resource: corev1.ResourceCPU,
+				},
+			},
+		},
 		"reservation, checks ready": {
 			checkStates: []kueue.AdmissionCheckState{
 				{
@@ -263,6 +363,8 @@ func TestSyncAdmittedCondition(t *testing.T) {
 				builder = builder.PastAdmittedTime(tc.pastAdmittedTime)
 			}
 			wl := builder.Obj()
+			wl.Status.PendingReasons = tc.pendingReasons
+			features.SetFeatureGateDuringTest(t, features.WorkloadPendingReasons, tc.enablePendingReasons)
 
 			gotChange := SyncAdmittedCondition(wl, testTime)
 
@@ -274,6 +376,10 @@ func TestSyncAdmittedCondition(t *testing.T) {
 				t.Errorf("Unexpected conditions after sync (- want/+ got):\n%s", diff)
 			}
 
+			if diff := cmp.Diff(tc.wantPendingReasons, wl.Status.PendingReasons, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected pending reasons after sync (- want/+ got):\n%s", diff)
+			}
+
 			if tc.wantAdmittedTime > 0 {
 				if wl.Status.AccumulatedPastExexcutionTimeSeconds == nil {
 					t.Fatalf("Expecting AccumulatedPastExexcutionTimeSeconds not to be nil")
@@ -368,6 +474,9 @@ func TestSetCheckState(t *testing.T) {
 					LastTransitionTime: *t1.DeepCopy(),
 					Message:            "msg2",
 					PodSetUpdates:      []kueue.PodSetUpdate{*ps1Updates.DeepCopy()},
+					History: []kueue.AdmissionCheckStateTransition{
+						{State: kueue.CheckStatePending, LastTransitionTime: *t0.DeepCopy()},
+					},
 				},
 				{
 					Name:               "check2",
@@ -424,6 +533,9 @@ func TestSetCheckState(t *testing.T) {
 					State:         kueue.CheckStateReady,
 					Message:       "msg2",
 					PodSetUpdates: []kueue.PodSetUpdate{*ps1Updates.DeepCopy()},
+					History: []kueue.AdmissionCheckStateTransition{
+						{State: kueue.CheckStatePending, LastTransitionTime: *t0.DeepCopy()},
+					},
 				},
 				{
 					Name:               "check2",
@@ -434,6 +546,96 @@ func TestSetCheckState(t *testing.T) {
 				},
 			},
 		},
+		"invalid transition is ignored": {
+			origStates: []kueue.AdmissionCheckState{
+				{
+					Name:               "check1",
+					State:              kueue.CheckStateRetry,
+					LastTransitionTime: *t0.DeepCopy(),
+					Message:            "msg1",
+				},
+			},
+			state: kueue.AdmissionCheckState{
+				Name:               "check1",
+				State:              kueue.CheckStateReady,
+				LastTransitionTime: *t1.DeepCopy(),
+				Message:            "msg2",
+			},
+			wantStates: []kueue.AdmissionCheckState{
+				{
+					Name:               "check1",
+					State:              kueue.CheckStateRetry,
+					LastTransitionTime: *t0.DeepCopy(),
+					Message:            "msg1",
+				},
+			},
+		},
+		"rejected check keeps its message and pod set updates": {
+			origStates: []kueue.AdmissionCheckState{
+				{
+					Name:               "check1",
+					State:              kueue.CheckStateRejected,
+					LastTransitionTime: *t0.DeepCopy(),
+					Message:            "rejected",
+				},
+			},
+			state: kueue.AdmissionCheckState{
+				Name:               "check1",
+				State:              kueue.CheckStateReady,
+				LastTransitionTime: *t1.DeepCopy(),
+				Message:            "ready",
+				PodSetUpdates:      []kueue.PodSetUpdate{ps1Updates},
+			},
+			wantStates: []kueue.AdmissionCheckState{
+				{
+					Name:               "check1",
+					State:              kueue.CheckStateRejected,
+					LastTransitionTime: *t0.DeepCopy(),
+					Message:            "rejected",
+				},
+			},
+		},
+		"history keeps the most recent states": {
+			origStates: []kueue.AdmissionCheckState{
+				{
+					Name:               "check1",
+					State:              kueue.CheckStateReady,
+					LastTransitionTime: *t1.DeepCopy(),
+					History: []kueue.AdmissionCheckStateTransition{
+						{State: kueue.CheckStatePending},
+						{State: kueue.CheckStateReady},
+						{State: kueue.CheckStatePending},
+						{State: kueue.CheckStateReady},
+						{State: kueue.CheckStatePending},
+						{State: kueue.CheckStateReady},
+						{State: kueue.CheckStatePending},
+						{State: kueue.CheckStateRetry},
+					},
+				},
+			},
+			state: kueue.AdmissionCheckState{
+				Name:               "check1",
+				State:              kueue.CheckStatePending,
+				LastTransitionTime: *t1.DeepCopy(),
+			},
+			wantStates: []kueue.AdmissionCheckState{
+				{
+					Name:               "check1",
+					State:              kueue.CheckStatePending,
+					LastTransitionTime: *t1.DeepCopy(),
+					History: []kueue.AdmissionCheckStateTransition{
+						{State: kueue.CheckStateReady},
+						{State: kueue.CheckStatePending},
+						{State: kueue.CheckStateReady},
+						{State: kueue.CheckStatePending},
+						{State: kueue.CheckStateReady},
+						{State: kueue.CheckStatePending},
+						{State: kueue.CheckStateRetry},
+						{State: kueue.CheckStateReady, LastTransitionTime: *t1.DeepCopy()},
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -461,3 +663,26 @@ func TestSetCheckState(t *testing.T) {
 		})
 	}
 }
+
+func TestIsValidCheckStateTransition(t *testing.T) {
+	cases := map[string]struct {
+		from, to kueue.CheckState
+		want     bool
+	}{
+		"pending to ready":    {from: kueue.CheckStatePending, to: kueue.CheckStateReady, want: true},
+		"ready to retry":      {from: kueue.CheckStateReady, to: kueue.CheckStateRetry, want: true},
+		"retry to pending":    {from: kueue.CheckStateRetry, to: kueue.CheckStatePending, want: true},
+		"retry to rejected":   {from: kueue.CheckStateRetry, to: kueue.CheckStateRejected, want: true},
+		"retry to ready":      {from: kueue.CheckStateRetry, to: kueue.CheckStateReady},
+		"rejected to pending": {from: kueue.CheckStateRejected, to: kueue.CheckStatePending, want: true},
+		"rejected to ready":   {from: kueue.CheckStateRejected, to: kueue.CheckStateReady},
+		"rejected to retry":   {from: kueue.CheckStateRejected, to: kueue.CheckStateRetry},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsValidCheckStateTransition(tc.from, tc.to); got != tc.want {
+				t.Errorf("Unexpected result, want=%v, got=%v", tc.want, got)
+			}
+		})
+	}
+}
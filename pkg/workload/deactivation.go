@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"strings"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// IsDeactivationReason returns true if the reason of the Evicted or the
+// Requeued condition of the workload corresponds to its deactivation.
+func IsDeactivationReason(reason string) bool {
+	return reason == kueue.WorkloadEvictedByDeactivation || strings.HasPrefix(reason, kueue.WorkloadDeactivated)
+}
+
+// DeactivationReasonCategory returns the category of the reason for which
+// the workload was deactivated.
+func DeactivationReasonCategory(reason string) kueue.DeactivationReasonCategory {
+	switch reason {
+	case kueue.WorkloadRequeuingLimitExceeded, kueue.WorkloadEvictedByAdmissionCheck:
+		return kueue.DeactivationCategoryInfrastructure
+	default:
+		return kueue.DeactivationCategoryUser
+	}
+}
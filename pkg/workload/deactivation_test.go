@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"testing"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+func TestDeactivationReasons(t *testing.T) {
+	cases := map[string]struct {
+		reason           string
+		wantDeactivation bool
+		wantCategory     kueue.DeactivationReasonCategory
+	}{
+		"deactivated": {
+			reason:           kueue.WorkloadDeactivated,
+			wantDeactivation: true,
+			wantCategory:     kueue.DeactivationCategoryUser,
+		},
+		"deactivated before upgrade": {
+			reason:           kueue.WorkloadEvictedByDeactivation,
+			wantDeactivation: true,
+			wantCategory:     kueue.DeactivationCategoryUser,
+		},
+		"deactivated due to requeuing limit exceeded": {
+			reason:           "DeactivatedDueToRequeuingLimitExceeded",
+			wantDeactivation: true,
+			wantCategory:     kueue.DeactivationCategoryUser,
+		},
+		"requeuing limit exceeded": {
+			reason:       kueue.WorkloadRequeuingLimitExceeded,
+			wantCategory: kueue.DeactivationCategoryInfrastructure,
+		},
+		"admission check rejected": {
+			reason:       kueue.WorkloadEvictedByAdmissionCheck,
+			wantCategory: kueue.DeactivationCategoryInfrastructure,
+		},
+		"maximum execution time exceeded": {
+			reason:       kueue.WorkloadMaximumExecutionTimeExceeded,
+			wantCategory: kueue.DeactivationCategoryUser,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsDeactivationReason(tc.reason); got != tc.wantDeactivation {
+				t.Errorf("Unexpected IsDeactivationReason, want=%v, got=%v", tc.wantDeactivation, got)
+			}
+			if got := DeactivationReasonCategory(tc.reason); got != tc.wantCategory {
+				t.Errorf("Unexpected DeactivationReasonCategory, want=%q, got=%q", tc.wantCategory, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// EvictionCategory returns the category of the last eviction of the workload
+// with the given reason, or EvictionCategoryOther if it wasn't recorded.
+func EvictionCategory(w *kueue.Workload, reason string) kueue.EvictionReasonCategory {
+	if w.Status.Eviction != nil && w.Status.Eviction.Reason == reason {
+		return w.Status.Eviction.Category
+	}
+	return kueue.EvictionCategoryOther
+}
+
+// PreemptionEvictionCategory returns the category of the eviction of a workload
+// preempted with the reason of the Preempted condition.
+func PreemptionEvictionCategory(preemptionReason string) kueue.EvictionReasonCategory {
+	if preemptionReason == kueue.InClusterQueueReason {
+		return kueue.EvictionCategoryPreemptedByPriority
+	}
+	return kueue.EvictionCategoryPreemptedByReclaim
+}
+
+// DeactivationEvictionCategory returns the category of the eviction of a
+// workload deactivated with the reason of the DeactivationTarget condition,
+// or by setting spec.active to false if the reason is empty.
+func DeactivationEvictionCategory(deactivationReason string) kueue.EvictionReasonCategory {
+	switch deactivationReason {
+	case "":
+		return kueue.EvictionCategoryAdminStop
+	case kueue.WorkloadRequeuingLimitExceeded:
+		return kueue.EvictionCategoryPodsReadyTimeout
+	case kueue.WorkloadEvictedByAdmissionCheck:
+		return kueue.EvictionCategoryChecksFailed
+	default:
+		return kueue.EvictionCategoryOther
+	}
+}
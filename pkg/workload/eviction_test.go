@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"testing"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestEvictionCategories(t *testing.T) {
+	cases := map[string]struct {
+		category     kueue.EvictionReasonCategory
+		wantCategory kueue.EvictionReasonCategory
+	}{
+		"preempted in the ClusterQueue": {
+			category:     PreemptionEvictionCategory(kueue.InClusterQueueReason),
+			wantCategory: kueue.EvictionCategoryPreemptedByPriority,
+		},
+		"preempted by reclamation in the cohort": {
+			category:     PreemptionEvictionCategory(kueue.InCohortReclamationReason),
+			wantCategory: kueue.EvictionCategoryPreemptedByReclaim,
+		},
+		"preempted by fair sharing": {
+			category:     PreemptionEvictionCategory(kueue.InCohortFairSharingReason),
+			wantCategory: kueue.EvictionCategoryPreemptedByReclaim,
+		},
+		"deactivated by the user": {
+			category:     DeactivationEvictionCategory(""),
+			wantCategory: kueue.EvictionCategoryAdminStop,
+		},
+		"deactivated due to requeuing limit exceeded": {
+			category:     DeactivationEvictionCategory(kueue.WorkloadRequeuingLimitExceeded),
+			wantCategory: kueue.EvictionCategoryPodsReadyTimeout,
+		},
+		"deactivated due to a rejected admission check": {
+			category:     DeactivationEvictionCategory(kueue.WorkloadEvictedByAdmissionCheck),
+			wantCategory: kueue.EvictionCategoryChecksFailed,
+		},
+		"deactivated due to maximum execution time exceeded": {
+			category:     DeactivationEvictionCategory(kueue.WorkloadMaximumExecutionTimeExceeded),
+			wantCategory: kueue.EvictionCategoryOther,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if tc.category != tc.wantCategory {
+				t.Errorf("Unexpected category, want=%q, got=%q", tc.wantCategory, tc.category)
+			}
+		})
+	}
+}
+
+func TestEvictionCategory(t *testing.T) {
+	wl := utiltesting.MakeWorkload("wl", "ns").Obj()
+	if got := EvictionCategory(wl, kueue.WorkloadEvictedByPodsReadyTimeout); got != kueue.EvictionCategoryOther {
+		t.Errorf("Unexpected category of a workload never evicted, want=%q, got=%q", kueue.EvictionCategoryOther, got)
+	}
+	SetEvictedCondition(wl, kueue.WorkloadEvictedByPodsReadyTimeout, kueue.EvictionCategoryPodsReadyTimeout, "timeout")
+	if got := EvictionCategory(wl, kueue.WorkloadEvictedByPodsReadyTimeout); got != kueue.EvictionCategoryPodsReadyTimeout {
+		t.Errorf("Unexpected category, want=%q, got=%q", kueue.EvictionCategoryPodsReadyTimeout, got)
+	}
+	if got := EvictionCategory(wl, kueue.WorkloadEvictedByPreemption); got != kueue.EvictionCategoryOther {
+		t.Errorf("Unexpected category for another reason, want=%q, got=%q", kueue.EvictionCategoryOther, got)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+// ParseExcludedFlavors parses the value of the ExcludedFlavorsAnnotation, a
+// comma-separated list of flavor names.
+func ParseExcludedFlavors(value string) (sets.Set[kueue.ResourceFlavorReference], error) {
+	excluded := sets.New[kueue.ResourceFlavorReference]()
+	for _, name := range strings.Split(value, ",") {
+		flavor := strings.TrimSpace(name)
+		if errs := validation.IsDNS1123Subdomain(flavor); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid flavor name %q: %s", flavor, strings.Join(errs, ", "))
+		}
+		if excluded.Has(kueue.ResourceFlavorReference(flavor)) {
+			return nil, fmt.Errorf("flavor %q is excluded more than once", flavor)
+		}
+		excluded.Insert(kueue.ResourceFlavorReference(flavor))
+	}
+	return excluded, nil
+}
+
+// ExcludedFlavors returns the flavors excluded by the ExcludedFlavorsAnnotation
+// of the workload, or nil if no flavor is excluded or the annotation is
+// invalid.
+func ExcludedFlavors(wl *kueue.Workload) sets.Set[kueue.ResourceFlavorReference] {
+	value, found := wl.Annotations[controllerconsts.ExcludedFlavorsAnnotation]
+	if !found {
+		return nil
+	}
+	excluded, err := ParseExcludedFlavors(value)
+	if err != nil {
+		return nil
+	}
+	return excluded
+}
+
+// ValidateExcludedFlavors validates the ExcludedFlavorsAnnotation of the
+// object with the annotations.
+func ValidateExcludedFlavors(annotations map[string]string, path *field.Path) field.ErrorList {
+	value, found := annotations[controllerconsts.ExcludedFlavorsAnnotation]
+	if !found {
+		return nil
+	}
+	if _, err := ParseExcludedFlavors(value); err != nil {
+		return field.ErrorList{field.Invalid(path.Key(controllerconsts.ExcludedFlavorsAnnotation), value, err.Error())}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+func TestParseExcludedFlavors(t *testing.T) {
+	cases := map[string]struct {
+		value        string
+		wantExcluded sets.Set[kueue.ResourceFlavorReference]
+		wantErr      bool
+	}{
+		"single flavor": {
+			value:        "spot",
+			wantExcluded: sets.New[kueue.ResourceFlavorReference]("spot"),
+		},
+		"multiple flavors": {
+			value:        "spot, preemptible",
+			wantExcluded: sets.New[kueue.ResourceFlavorReference]("spot", "preemptible"),
+		},
+		"empty": {
+			value:   "",
+			wantErr: true,
+		},
+		"invalid flavor name": {
+			value:   "spot,Preemptible",
+			wantErr: true,
+		},
+		"flavor excluded twice": {
+			value:   "spot,spot",
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseExcludedFlavors(tc.value)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Unexpected error, want error %t, got %v", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.wantExcluded, got); diff != "" {
+				t.Errorf("Unexpected excluded flavors (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateExcludedFlavors(t *testing.T) {
+	annotationsPath := field.NewPath("metadata", "annotations")
+	annotationPath := annotationsPath.Key(controllerconsts.ExcludedFlavorsAnnotation)
+
+	cases := map[string]struct {
+		value   *string
+		wantErr field.ErrorList
+	}{
+		"no annotation": {},
+		"valid": {
+			value: ptr.To("spot,preemptible"),
+		},
+		"invalid value": {
+			value: ptr.To("spot,"),
+			wantErr: field.ErrorList{
+				field.Invalid(annotationPath, "spot,", ""),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			annotations := map[string]string{}
+			if tc.value != nil {
+				annotations[controllerconsts.ExcludedFlavorsAnnotation] = *tc.value
+			}
+			gotErr := ValidateExcludedFlavors(annotations, annotationsPath)
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "Detail")); diff != "" {
+				t.Errorf("Unexpected error (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExcludedFlavors(t *testing.T) {
+	cases := map[string]struct {
+		annotations  map[string]string
+		wantExcluded sets.Set[kueue.ResourceFlavorReference]
+	}{
+		"no annotation": {},
+		"excluded": {
+			annotations:  map[string]string{controllerconsts.ExcludedFlavorsAnnotation: "spot"},
+			wantExcluded: sets.New[kueue.ResourceFlavorReference]("spot"),
+		},
+		"invalid annotation": {
+			annotations: map[string]string{controllerconsts.ExcludedFlavorsAnnotation: "Spot"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			wl := &kueue.Workload{}
+			wl.Annotations = tc.annotations
+			if diff := cmp.Diff(tc.wantExcluded, ExcludedFlavors(wl)); diff != "" {
+				t.Errorf("Unexpected excluded flavors (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+// ParsePinnedFlavors parses the value of the PodSetFlavorsAnnotation, a
+// comma-separated list of <podSet>=<flavor> pairs, into the flavors pinned
+// by pod set name.
+func ParsePinnedFlavors(value string) (map[string]kueue.ResourceFlavorReference, error) {
+	pinned := make(map[string]kueue.ResourceFlavorReference)
+	for _, pair := range strings.Split(value, ",") {
+		podSet, flavor, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found {
+			return nil, fmt.Errorf("%q is not a <podSet>=<flavor> pair", pair)
+		}
+		if errs := validation.IsDNS1123Label(podSet); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid podSet name %q: %s", podSet, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsDNS1123Subdomain(flavor); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid flavor name %q: %s", flavor, strings.Join(errs, ", "))
+		}
+		if _, found := pinned[podSet]; found {
+			return nil, fmt.Errorf("podSet %q is pinned more than once", podSet)
+		}
+		pinned[podSet] = kueue.ResourceFlavorReference(flavor)
+	}
+	return pinned, nil
+}
+
+// PinnedFlavors returns the flavors to which the pod sets of the workload are
+// pinned by its PodSetFlavorsAnnotation, by pod set name, or nil if the pod
+// sets aren't pinned or the annotation is invalid.
+func PinnedFlavors(wl *kueue.Workload) map[string]kueue.ResourceFlavorReference {
+	value, found := wl.Annotations[controllerconsts.PodSetFlavorsAnnotation]
+	if !found {
+		return nil
+	}
+	pinned, err := ParsePinnedFlavors(value)
+	if err != nil {
+		return nil
+	}
+	return pinned
+}
+
+// ValidatePinnedFlavors validates the PodSetFlavorsAnnotation of the object
+// with the annotations. When the pod set names are given, the pinned pod sets
+// must be among them.
+func ValidatePinnedFlavors(annotations map[string]string, path *field.Path, podSetNames ...string) field.ErrorList {
+	value, found := annotations[controllerconsts.PodSetFlavorsAnnotation]
+	if !found {
+		return nil
+	}
+	annotationPath := path.Key(controllerconsts.PodSetFlavorsAnnotation)
+	pinned, err := ParsePinnedFlavors(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(annotationPath, value, err.Error())}
+	}
+	if len(podSetNames) == 0 {
+		return nil
+	}
+	var allErrs field.ErrorList
+	for _, podSet := range slices.Sorted(maps.Keys(pinned)) {
+		if !slices.Contains(podSetNames, podSet) {
+			allErrs = append(allErrs, field.Invalid(annotationPath, value, fmt.Sprintf("podSet %q not found", podSet)))
+		}
+	}
+	return allErrs
+}
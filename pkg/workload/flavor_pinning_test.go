@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+func TestParsePinnedFlavors(t *testing.T) {
+	cases := map[string]struct {
+		value      string
+		wantPinned map[string]kueue.ResourceFlavorReference
+		wantErr    bool
+	}{
+		"single pod set": {
+			value:      "main=spot",
+			wantPinned: map[string]kueue.ResourceFlavorReference{"main": "spot"},
+		},
+		"multiple pod sets": {
+			value: "driver=on-demand, workers=spot",
+			wantPinned: map[string]kueue.ResourceFlavorReference{
+				"driver":  "on-demand",
+				"workers": "spot",
+			},
+		},
+		"empty": {
+			value:   "",
+			wantErr: true,
+		},
+		"not a pair": {
+			value:   "main",
+			wantErr: true,
+		},
+		"invalid pod set name": {
+			value:   "Main=spot",
+			wantErr: true,
+		},
+		"invalid flavor name": {
+			value:   "main=",
+			wantErr: true,
+		},
+		"pod set pinned twice": {
+			value:   "main=spot,main=on-demand",
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParsePinnedFlavors(tc.value)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Unexpected error, want error %t, got %v", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.wantPinned, got); diff != "" {
+				t.Errorf("Unexpected pinned flavors (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatePinnedFlavors(t *testing.T) {
+	annotationsPath := field.NewPath("metadata", "annotations")
+	annotationPath := annotationsPath.Key(controllerconsts.PodSetFlavorsAnnotation)
+
+	cases := map[string]struct {
+		value       *string
+		podSetNames []string
+		wantErr     field.ErrorList
+	}{
+		"no annotation": {},
+		"valid without pod set names": {
+			value: ptr.To("main=spot"),
+		},
+		"valid with pod set names": {
+			value:       ptr.To("driver=on-demand,workers=spot"),
+			podSetNames: []string{"driver", "workers"},
+		},
+		"invalid value": {
+			value: ptr.To("main"),
+			wantErr: field.ErrorList{
+				field.Invalid(annotationPath, "main", ""),
+			},
+		},
+		"unknown pod sets": {
+			value:       ptr.To("workers=spot,launcher=on-demand,driver=on-demand"),
+			podSetNames: []string{"driver"},
+			wantErr: field.ErrorList{
+				field.Invalid(annotationPath, "workers=spot,launcher=on-demand,driver=on-demand", ""),
+				field.Invalid(annotationPath, "workers=spot,launcher=on-demand,driver=on-demand", ""),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			annotations := map[string]string{}
+			if tc.value != nil {
+				annotations[controllerconsts.PodSetFlavorsAnnotation] = *tc.value
+			}
+			gotErr := ValidatePinnedFlavors(annotations, annotationsPath, tc.podSetNames...)
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "Detail")); diff != "" {
+				t.Errorf("Unexpected error (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPinnedFlavors(t *testing.T) {
+	cases := map[string]struct {
+		annotations map[string]string
+		wantPinned  map[string]kueue.ResourceFlavorReference
+	}{
+		"no annotation": {},
+		"pinned": {
+			annotations: map[string]string{controllerconsts.PodSetFlavorsAnnotation: "main=spot"},
+			wantPinned:  map[string]kueue.ResourceFlavorReference{"main": "spot"},
+		},
+		"invalid annotation": {
+			annotations: map[string]string{controllerconsts.PodSetFlavorsAnnotation: "main"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			wl := &kueue.Workload{}
+			wl.Annotations = tc.annotations
+			if diff := cmp.Diff(tc.wantPinned, PinnedFlavors(wl)); diff != "" {
+				t.Errorf("Unexpected pinned flavors (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+// ProgressValue returns the value of the ProgressAnnotation for the counts of
+// the completed pods and of all the pods of a workload.
+func ProgressValue(completed, total int32) string {
+	return fmt.Sprintf("%d/%d", completed, total)
+}
+
+// Progress returns the fraction, between 0 and 1, of the pods of the
+// workload which completed, as reported by its ProgressAnnotation. A workload
+// without a valid annotation didn't progress.
+func Progress(wl *kueue.Workload) float64 {
+	value, found := wl.Annotations[controllerconsts.ProgressAnnotation]
+	if !found {
+		return 0
+	}
+	completedStr, totalStr, found := strings.Cut(value, "/")
+	if !found {
+		return 0
+	}
+	completed, err := strconv.ParseInt(completedStr, 10, 32)
+	if err != nil || completed < 0 {
+		return 0
+	}
+	total, err := strconv.ParseInt(totalStr, 10, 32)
+	if err != nil || total <= 0 {
+		return 0
+	}
+	return float64(min(completed, total)) / float64(total)
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"testing"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+func TestProgress(t *testing.T) {
+	cases := map[string]struct {
+		annotations  map[string]string
+		wantProgress float64
+	}{
+		"no annotation": {},
+		"in progress": {
+			annotations:  map[string]string{controllerconsts.ProgressAnnotation: ProgressValue(3, 4)},
+			wantProgress: 0.75,
+		},
+		"completed beyond the total": {
+			annotations:  map[string]string{controllerconsts.ProgressAnnotation: "5/4"},
+			wantProgress: 1,
+		},
+		"not a fraction": {
+			annotations: map[string]string{controllerconsts.ProgressAnnotation: "3"},
+		},
+		"invalid completed count": {
+			annotations: map[string]string{controllerconsts.ProgressAnnotation: "-1/4"},
+		},
+		"zero total": {
+			annotations: map[string]string{controllerconsts.ProgressAnnotation: "0/0"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			wl := &kueue.Workload{}
+			wl.Annotations = tc.annotations
+			if got := Progress(wl); got != tc.wantProgress {
+				t.Errorf("Unexpected progress %v, want %v", got, tc.wantProgress)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/resources"
+	utilpod "sigs.k8s.io/kueue/pkg/util/pod"
+)
+
+// ResizeRequested returns true if the counts of the podSets of the workload
+// with quota reserved differ from the counts of its podSet assignments.
+func ResizeRequested(wl *kueue.Workload) bool {
+	if !HasQuotaReservation(wl) {
+		return false
+	}
+	counts := podSetsCounts(wl)
+	for _, psa := range wl.Status.Admission.PodSetAssignments {
+		if count, found := counts[psa.Name]; found && psa.Count != nil && *psa.Count != count {
+			return true
+		}
+	}
+	return false
+}
+
+// resizeInsufficientQuotaReason is the reason of the ResizePending condition
+// when the additional quota is not available in the ClusterQueue.
+const resizeInsufficientQuotaReason = "InsufficientQuota"
+
+// SetResizePendingCondition marks the resize of the workload as pending,
+// because the additional quota is not available in its ClusterQueue. It
+// returns true if the condition changed.
+func SetResizePendingCondition(wl *kueue.Workload) bool {
+	return apimeta.SetStatusCondition(&wl.Status.Conditions, metav1.Condition{
+		Type:               kueue.WorkloadResizePending,
+		Status:             metav1.ConditionTrue,
+		Reason:             resizeInsufficientQuotaReason,
+		Message:            fmt.Sprintf("The additional quota is not available in ClusterQueue %s", wl.Status.Admission.ClusterQueue),
+		ObservedGeneration: wl.Generation,
+	})
+}
+
+// SetResizedCondition marks the pending resize of the workload, if any, as
+// done.
+func SetResizedCondition(wl *kueue.Workload) {
+	if apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadResizePending) == nil {
+		return
+	}
+	apimeta.SetStatusCondition(&wl.Status.Conditions, metav1.Condition{
+		Type:               kueue.WorkloadResizePending,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Resized",
+		Message:            fmt.Sprintf("Resized in ClusterQueue %s", wl.Status.Admission.ClusterQueue),
+		ObservedGeneration: wl.Generation,
+	})
+}
+
+// ResizePendingForQuota returns true if the resize of the workload is pending
+// because the additional quota is not available in its ClusterQueue.
+func ResizePendingForQuota(wl *kueue.Workload) bool {
+	c := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadResizePending)
+	return c != nil && c.Status == metav1.ConditionTrue && c.Reason == resizeInsufficientQuotaReason
+}
+
+// ResizedAdmission returns a copy of the admission of the workload with the
+// counts and the resource usage of the podSet assignments scaled to the
+// counts of the podSets.
+func ResizedAdmission(wl *kueue.Workload) *kueue.Admission {
+	admission := wl.Status.Admission.DeepCopy()
+	counts := podSetsCounts(wl)
+	for i := range admission.PodSetAssignments {
+		psa := &admission.PodSetAssignments[i]
+		count, found := counts[psa.Name]
+		if !found || ptr.Deref(psa.Count, 0) == 0 || *psa.Count == count {
+			continue
+		}
+		usage := resources.NewRequests(psa.ResourceUsage)
+		scaleDown(usage, int64(*psa.Count))
+		scaleUp(usage, int64(count))
+		psa.ResourceUsage = usage.ToResourceList()
+		psa.Count = ptr.To(count)
+	}
+	return admission
+}
+
+// PodsResizedAdmission returns a copy of the admission with the resource usage
+// of the podSet assignments computed from the resources of the active pods of
+// the workload, which can be resized in place, and from the podSet templates
+// for the pods which are not created yet. The pods are matched to the podSets
+// with the podSet label.
+func PodsResizedAdmission(wl *kueue.Workload, admission *kueue.Admission, pods []corev1.Pod, opts ...InfoOption) *kueue.Admission {
+	options := defaultOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	activePods := make(map[string][]*corev1.Pod)
+	for i := range pods {
+		pod := &pods[i]
+		if !utilpod.IsActive(pod) {
+			continue
+		}
+		podSetName := pod.Labels[kueuealpha.PodSetLabel]
+		activePods[podSetName] = append(activePods[podSetName], pod)
+	}
+
+	resized := admission.DeepCopy()
+	for i := range resized.PodSetAssignments {
+		psa := &resized.PodSetAssignments[i]
+		psIdx := slices.IndexFunc(wl.Spec.PodSets, func(ps kueue.PodSet) bool { return ps.Name == psa.Name })
+		if psIdx < 0 {
+			continue
+		}
+		ps := &wl.Spec.PodSets[psIdx]
+		remaining := ptr.Deref(psa.Count, ps.Count)
+
+		podSetPods := activePods[psa.Name]
+		slices.SortFunc(podSetPods, func(a, b *corev1.Pod) int { return strings.Compare(a.Name, b.Name) })
+		usage := resources.Requests{}
+		for _, pod := range podSetPods {
+			if remaining == 0 {
+				break
+			}
+			usage.Add(podRequests(withPodResources(&ps.Template.Spec, &pod.Spec), &options))
+			remaining--
+		}
+		templateUsage := podRequests(&ps.Template.Spec, &options)
+		scaleUp(templateUsage, int64(remaining))
+		usage.Add(templateUsage)
+
+		assignedUsage := make(resources.Requests, len(psa.ResourceUsage))
+		for res := range psa.ResourceUsage {
+			assignedUsage[res] = usage[res]
+		}
+		psa.ResourceUsage = assignedUsage.ToResourceList()
+	}
+	return resized
+}
+
+// withPodResources returns a copy of the template spec with the resources of
+// its containers replaced with the resources of the pod containers with the
+// same names.
+func withPodResources(template, pod *corev1.PodSpec) *corev1.PodSpec {
+	spec := template.DeepCopy()
+	replaceResources := func(containers, podContainers []corev1.Container) {
+		for i := range containers {
+			idx := slices.IndexFunc(podContainers, func(c corev1.Container) bool { return c.Name == containers[i].Name })
+			if idx >= 0 {
+				containers[i].Resources = *podContainers[idx].Resources.DeepCopy()
+			}
+		}
+	}
+	replaceResources(spec.InitContainers, pod.InitContainers)
+	replaceResources(spec.Containers, pod.Containers)
+	return spec
+}
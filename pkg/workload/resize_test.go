@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
+)
+
+func TestResizedAdmission(t *testing.T) {
+	cases := map[string]struct {
+		wl                  *kueue.Workload
+		wantResizeRequested bool
+		wantAdmission       *kueue.Admission
+	}{
+		"no quota reserved": {
+			wl: utiltesting.MakeWorkload("wl", "ns").
+				PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 3).Obj()).
+				Obj(),
+		},
+		"not resized": {
+			wl: utiltesting.MakeWorkload("wl", "ns").
+				PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 2).Obj()).
+				ReserveQuota(utiltesting.MakeAdmission("cq").
+					Assignment(corev1.ResourceCPU, "default", "2").
+					AssignmentPodCount(2).
+					Obj()).
+				Obj(),
+			wantAdmission: utiltesting.MakeAdmission("cq").
+				Assignment(corev1.ResourceCPU, "default", "2").
+				AssignmentPodCount(2).
+				Obj(),
+		},
+		"grown": {
+			wl: utiltesting.MakeWorkload("wl", "ns").
+				PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 3).Obj()).
+				ReserveQuota(utiltesting.MakeAdmission("cq").
+					Assignment(corev1.ResourceCPU, "default", "2").
+					AssignmentPodCount(2).
+					Obj()).
+				Obj(),
+			wantResizeRequested: true,
+			wantAdmission: utiltesting.MakeAdmission("cq").
+				Assignment(corev1.ResourceCPU, "default", "3").
+				AssignmentPodCount(3).
+				Obj(),
+		},
+		"shrunk": {
+			wl: utiltesting.MakeWorkload("wl", "ns").
+				PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Obj()).
+				ReserveQuota(utiltesting.MakeAdmission("cq").
+					Assignment(corev1.ResourceCPU, "default", "1500m").
+					Assignment(corev1.ResourceMemory, "default", "3Gi").
+					AssignmentPodCount(3).
+					Obj()).
+				Obj(),
+			wantResizeRequested: true,
+			wantAdmission: utiltesting.MakeAdmission("cq").
+				Assignment(corev1.ResourceCPU, "default", "500m").
+				Assignment(corev1.ResourceMemory, "default", "1Gi").
+				AssignmentPodCount(1).
+				Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := ResizeRequested(tc.wl); got != tc.wantResizeRequested {
+				t.Errorf("Unexpected ResizeRequested, want=%v, got=%v", tc.wantResizeRequested, got)
+			}
+			if tc.wantAdmission == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.wantAdmission, ResizedAdmission(tc.wl)); diff != "" {
+				t.Errorf("Unexpected admission (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPodsResizedAdmission(t *testing.T) {
+	wl := utiltesting.MakeWorkload("wl", "ns").
+		PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 3).
+			Request(corev1.ResourceCPU, "1").
+			Request(corev1.ResourceMemory, "1Gi").
+			Obj()).
+		ReserveQuota(utiltesting.MakeAdmission("cq").
+			Assignment(corev1.ResourceCPU, "default", "3").
+			Assignment(corev1.ResourceMemory, "default", "3Gi").
+			AssignmentPodCount(3).
+			Obj()).
+		Obj()
+	basePod := testingpod.MakePod("", "ns").
+		Label(kueuealpha.PodSetLabel, kueue.DefaultPodSetName).
+		Request(corev1.ResourceCPU, "1").
+		Request(corev1.ResourceMemory, "1Gi")
+
+	cases := map[string]struct {
+		pods          []corev1.Pod
+		wantAdmission *kueue.Admission
+	}{
+		"no pods": {
+			wantAdmission: wl.Status.Admission,
+		},
+		"pods not resized": {
+			pods: []corev1.Pod{
+				*basePod.Clone().Name("pod1").Obj(),
+				*basePod.Clone().Name("pod2").Obj(),
+			},
+			wantAdmission: wl.Status.Admission,
+		},
+		"pod grown": {
+			pods: []corev1.Pod{
+				*basePod.Clone().Name("pod1").Request(corev1.ResourceCPU, "2").Obj(),
+				*basePod.Clone().Name("pod2").Obj(),
+			},
+			wantAdmission: utiltesting.MakeAdmission("cq").
+				Assignment(corev1.ResourceCPU, "default", "4").
+				Assignment(corev1.ResourceMemory, "default", "3Gi").
+				AssignmentPodCount(3).
+				Obj(),
+		},
+		"pods shrunk": {
+			pods: []corev1.Pod{
+				*basePod.Clone().Name("pod1").Request(corev1.ResourceMemory, "512Mi").Obj(),
+				*basePod.Clone().Name("pod2").Request(corev1.ResourceMemory, "512Mi").Obj(),
+				*basePod.Clone().Name("pod3").Request(corev1.ResourceMemory, "512Mi").Obj(),
+			},
+			wantAdmission: utiltesting.MakeAdmission("cq").
+				Assignment(corev1.ResourceCPU, "default", "3").
+				Assignment(corev1.ResourceMemory, "default", "1536Mi").
+				AssignmentPodCount(3).
+				Obj(),
+		},
+		"inactive pods are ignored": {
+			pods: []corev1.Pod{
+				*basePod.Clone().Name("pod1").Request(corev1.ResourceCPU, "2").StatusPhase(corev1.PodSucceeded).Obj(),
+				*basePod.Clone().Name("pod2").Request(corev1.ResourceCPU, "2").DeletionTimestamp(time.Now()).Obj(),
+				*basePod.Clone().Name("pod3").Request(corev1.ResourceCPU, "2").Label(kueuealpha.PodSetLabel, "other").Obj(),
+			},
+			wantAdmission: wl.Status.Admission,
+		},
+		"pods exceeding the count are ignored": {
+			pods: []corev1.Pod{
+				*basePod.Clone().Name("pod1").Obj(),
+				*basePod.Clone().Name("pod2").Obj(),
+				*basePod.Clone().Name("pod3").Obj(),
+				*basePod.Clone().Name("pod4").Request(corev1.ResourceCPU, "2").Obj(),
+			},
+			wantAdmission: wl.Status.Admission,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := PodsResizedAdmission(wl, wl.Status.Admission, tc.pods)
+			if diff := cmp.Diff(tc.wantAdmission, got); diff != "" {
+				t.Errorf("Unexpected admission (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
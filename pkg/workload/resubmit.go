@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+var (
+	ErrWorkloadNotFinished = errors.New("workload is not finished")
+	ErrWorkloadWithoutJob  = errors.New("workload has no associated job")
+)
+
+// jobGeneratedTemplateLabels are the labels added by the Job controller
+// to the pod template, which are rejected when creating a new Job.
+var jobGeneratedTemplateLabels = []string{
+	batchv1.ControllerUidLabel,
+	batchv1.JobNameLabel,
+	"controller-uid",
+	"job-name",
+}
+
+const (
+	// kueueAnnotationPrefix is the prefix of the annotations set by Kueue, which
+	// are not copied to the resubmitted job.
+	kueueAnnotationPrefix = "kueue.x-k8s.io/"
+
+	// maxResubmittedJobNameLength is the maximum length of the name of a job
+	// which can label its pods with its name.
+	maxResubmittedJobNameLength = 63
+	resubmittedJobHashLength    = 5
+)
+
+// ResubmittableJob returns the reference to the job owning the Workload, which
+// can be resubmitted once the Workload is finished, either succeeded or failed.
+func ResubmittableJob(wl *kueue.Workload) (*metav1.OwnerReference, error) {
+	if !IsFinished(wl) {
+		return nil, ErrWorkloadNotFinished
+	}
+	if owner := metav1.GetControllerOfNoCopy(wl); owner != nil {
+		return owner, nil
+	}
+	if len(wl.OwnerReferences) == 0 {
+		return nil, ErrWorkloadWithoutJob
+	}
+	return &wl.OwnerReferences[0], nil
+}
+
+// CloneJob returns a copy of the job, keeping its labels, which include the
+// queue settings, that can be created to resubmit the job. The annotations set
+// by Kueue are dropped, and a queue name set with the deprecated annotation is
+// kept as a label. The name of the copy is generated from the name of the job.
+func CloneJob(job *unstructured.Unstructured) *unstructured.Unstructured {
+	clone := &unstructured.Unstructured{Object: make(map[string]any, len(job.Object))}
+	for k, v := range job.DeepCopy().Object {
+		if k != "metadata" && k != "status" {
+			clone.Object[k] = v
+		}
+	}
+	clone.SetNamespace(job.GetNamespace())
+	generateName := job.GetGenerateName()
+	if generateName == "" {
+		generateName = strings.TrimSuffix(job.GetName(), "-") + "-"
+	}
+	clone.SetGenerateName(generateName)
+
+	labels := job.GetLabels()
+	annotations := job.GetAnnotations()
+	if queueName, found := annotations[constants.QueueAnnotation]; found && labels[constants.QueueLabel] == "" {
+		if labels == nil {
+			labels = make(map[string]string, 1)
+		}
+		labels[constants.QueueLabel] = queueName
+	}
+	if len(labels) > 0 {
+		delete(labels, constants.PrebuiltWorkloadLabel)
+		clone.SetLabels(labels)
+	}
+	for key := range annotations {
+		if strings.HasPrefix(key, kueueAnnotationPrefix) {
+			delete(annotations, key)
+		}
+	}
+	if len(annotations) > 0 {
+		clone.SetAnnotations(annotations)
+	}
+
+	if clone.GroupVersionKind() == batchv1.SchemeGroupVersion.WithKind("Job") {
+		if manualSelector, _, _ := unstructured.NestedBool(clone.Object, "spec", "manualSelector"); !manualSelector {
+			unstructured.RemoveNestedField(clone.Object, "spec", "selector")
+			for _, label := range jobGeneratedTemplateLabels {
+				unstructured.RemoveNestedField(clone.Object, "spec", "template", "metadata", "labels", label)
+			}
+		}
+	}
+	return clone
+}
+
+// ResubmittedJobName returns the name of the copy of the job resubmitted for
+// the Workload with the given UID, so that the same copy is created again if
+// the resubmission is retried.
+func ResubmittedJobName(jobName string, wlUID types.UID) string {
+	h := sha1.New()
+	h.Write([]byte(jobName))
+	h.Write([]byte("\n"))
+	h.Write([]byte(wlUID))
+	hash := hex.EncodeToString(h.Sum(nil))[:resubmittedJobHashLength]
+	prefix := strings.TrimSuffix(jobName, "-")
+	if maxPrefix := maxResubmittedJobNameLength - resubmittedJobHashLength - 1; len(prefix) > maxPrefix {
+		prefix = strings.TrimSuffix(prefix[:maxPrefix], "-")
+	}
+	return prefix + "-" + hash
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestResubmittableJob(t *testing.T) {
+	jobGVK := batchv1.SchemeGroupVersion.WithKind("Job")
+	finished := metav1.Condition{
+		Type:   kueue.WorkloadFinished,
+		Status: metav1.ConditionTrue,
+		Reason: kueue.WorkloadFinishedReasonSucceeded,
+	}
+	cases := map[string]struct {
+		wl        *kueue.Workload
+		wantOwner *metav1.OwnerReference
+		wantErr   error
+	}{
+		"not finished": {
+			wl:      utiltesting.MakeWorkload("wl", "ns").ControllerReference(jobGVK, "job", "uid").Obj(),
+			wantErr: ErrWorkloadNotFinished,
+		},
+		"finished without owner": {
+			wl:      utiltesting.MakeWorkload("wl", "ns").Condition(finished).Obj(),
+			wantErr: ErrWorkloadWithoutJob,
+		},
+		"finished with controller": {
+			wl: utiltesting.MakeWorkload("wl", "ns").
+				OwnerReference(jobGVK, "other", "other-uid").
+				ControllerReference(jobGVK, "job", "uid").
+				Condition(finished).
+				Obj(),
+			wantOwner: &metav1.OwnerReference{
+				APIVersion:         "batch/v1",
+				Kind:               "Job",
+				Name:               "job",
+				UID:                "uid",
+				Controller:         ptr.To(true),
+				BlockOwnerDeletion: ptr.To(true),
+			},
+		},
+		"finished with owner": {
+			wl: utiltesting.MakeWorkload("wl", "ns").
+				OwnerReference(jobGVK, "job", "uid").
+				Condition(finished).
+				Obj(),
+			wantOwner: &metav1.OwnerReference{
+				APIVersion: "batch/v1",
+				Kind:       "Job",
+				Name:       "job",
+				UID:        "uid",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotOwner, gotErr := ResubmittableJob(tc.wl)
+			if !errors.Is(gotErr, tc.wantErr) {
+				t.Errorf("Unexpected error, want=%v, got=%v", tc.wantErr, gotErr)
+			}
+			if diff := cmp.Diff(tc.wantOwner, gotOwner); diff != "" {
+				t.Errorf("Unexpected owner (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCloneJob(t *testing.T) {
+	cases := map[string]struct {
+		job  map[string]any
+		want map[string]any
+	}{
+		"job without manual selector": {
+			job: map[string]any{
+				"apiVersion": "batch/v1",
+				"kind":       "Job",
+				"metadata": map[string]any{
+					"name":            "job",
+					"namespace":       "ns",
+					"uid":             "uid",
+					"resourceVersion": "1",
+					"labels": map[string]any{
+						constants.QueueLabel:            "lq",
+						constants.PrebuiltWorkloadLabel: "wl",
+					},
+					"annotations": map[string]any{
+						"key":                               "value",
+						constants.ExcludedFlavorsAnnotation: "spot",
+						constants.IdleSinceAnnotation:       "2024-01-01T00:00:00Z",
+					},
+				},
+				"spec": map[string]any{
+					"parallelism": int64(1),
+					"selector": map[string]any{
+						"matchLabels": map[string]any{batchv1.ControllerUidLabel: "uid"},
+					},
+					"template": map[string]any{
+						"metadata": map[string]any{
+							"labels": map[string]any{
+								batchv1.ControllerUidLabel: "uid",
+								batchv1.JobNameLabel:       "job",
+								"app":                      "test",
+							},
+						},
+					},
+				},
+				"status": map[string]any{"failed": int64(1)},
+			},
+			want: map[string]any{
+				"apiVersion": "batch/v1",
+				"kind":       "Job",
+				"metadata": map[string]any{
+					"generateName": "job-",
+					"namespace":    "ns",
+					"labels":       map[string]any{constants.QueueLabel: "lq"},
+					"annotations":  map[string]any{"key": "value"},
+				},
+				"spec": map[string]any{
+					"parallelism": int64(1),
+					"template": map[string]any{
+						"metadata": map[string]any{
+							"labels": map[string]any{"app": "test"},
+						},
+					},
+				},
+			},
+		},
+		"job with the deprecated queue annotation": {
+			job: map[string]any{
+				"apiVersion": "batch/v1",
+				"kind":       "Job",
+				"metadata": map[string]any{
+					"name":        "job",
+					"namespace":   "ns",
+					"annotations": map[string]any{constants.QueueAnnotation: "lq"},
+				},
+				"spec": map[string]any{
+					"manualSelector": true,
+				},
+			},
+			want: map[string]any{
+				"apiVersion": "batch/v1",
+				"kind":       "Job",
+				"metadata": map[string]any{
+					"generateName": "job-",
+					"namespace":    "ns",
+					"labels":       map[string]any{constants.QueueLabel: "lq"},
+				},
+				"spec": map[string]any{
+					"manualSelector": true,
+				},
+			},
+		},
+		"job with manual selector and generate name": {
+			job: map[string]any{
+				"apiVersion": "batch/v1",
+				"kind":       "Job",
+				"metadata": map[string]any{
+					"name":         "job-abcde",
+					"generateName": "job-",
+					"namespace":    "ns",
+				},
+				"spec": map[string]any{
+					"manualSelector": true,
+					"selector": map[string]any{
+						"matchLabels": map[string]any{"app": "test"},
+					},
+				},
+			},
+			want: map[string]any{
+				"apiVersion": "batch/v1",
+				"kind":       "Job",
+				"metadata": map[string]any{
+					"generateName": "job-",
+					"namespace":    "ns",
+				},
+				"spec": map[string]any{
+					"manualSelector": true,
+					"selector": map[string]any{
+						"matchLabels": map[string]any{"app": "test"},
+					},
+				},
+			},
+		},
+		"other kind": {
+			job: map[string]any{
+				"apiVersion": "kubeflow.org/v1",
+				"kind":       "PyTorchJob",
+				"metadata": map[string]any{
+					"name":      "job",
+					"namespace": "ns",
+				},
+				"spec": map[string]any{
+					"selector": "kept",
+				},
+			},
+			want: map[string]any{
+				"apiVersion": "kubeflow.org/v1",
+				"kind":       "PyTorchJob",
+				"metadata": map[string]any{
+					"generateName": "job-",
+					"namespace":    "ns",
+				},
+				"spec": map[string]any{
+					"selector": "kept",
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job := &unstructured.Unstructured{Object: tc.job}
+			original := job.DeepCopy()
+			got := CloneJob(job)
+			if diff := cmp.Diff(tc.want, got.Object); diff != "" {
+				t.Errorf("Unexpected clone (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(original.Object, job.Object); diff != "" {
+				t.Errorf("Unexpected change of the original job (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResubmittedJobName(t *testing.T) {
+	cases := map[string]struct {
+		jobName    string
+		wantPrefix string
+	}{
+		"short name": {
+			jobName:    "job",
+			wantPrefix: "job-",
+		},
+		"generated name": {
+			jobName:    "job-",
+			wantPrefix: "job-",
+		},
+		"long name": {
+			jobName:    strings.Repeat("a", 56) + "-" + strings.Repeat("b", 10),
+			wantPrefix: strings.Repeat("a", 56) + "-",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ResubmittedJobName(tc.jobName, "uid")
+			if !strings.HasPrefix(got, tc.wantPrefix) || len(got) != len(tc.wantPrefix)+resubmittedJobHashLength {
+				t.Errorf("Unexpected name %q, want the prefix %q and a hash", got, tc.wantPrefix)
+			}
+			if again := ResubmittedJobName(tc.jobName, "uid"); again != got {
+				t.Errorf("Unexpected name %q for the same workload, want %q", again, got)
+			}
+			if other := ResubmittedJobName(tc.jobName, "other-uid"); other == got {
+				t.Errorf("Unexpected name %q for another workload", other)
+			}
+		})
+	}
+}
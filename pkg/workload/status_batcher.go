@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/util/parallelize"
+)
+
+// StatusBatcher coalesces the patches of the admission related status fields
+// of the workloads, and applies them once per period, so that a workload
+// whose status changes many times in a period, for example when it is
+// requeued in every scheduling cycle, is written only once.
+// Only the last patch of a workload in a period is applied. The patches
+// are applied after the scheduling cycle which computed them, so they must
+// carry the resourceVersion of the workload they were computed from. A patch
+// which became stale, because the workload was admitted or evicted meanwhile,
+// then fails with a conflict and is skipped, instead of overwriting the status.
+type StatusBatcher struct {
+	client client.Client
+	period time.Duration
+
+	mu      sync.Mutex
+	patches map[types.NamespacedName]*kueue.Workload
+}
+
+func NewStatusBatcher(c client.Client, period time.Duration) *StatusBatcher {
+	return &StatusBatcher{
+		client:  c,
+		period:  period,
+		patches: make(map[types.NamespacedName]*kueue.Workload),
+	}
+}
+
+// ApplyAdmissionStatusPatch queues the patch of the admission related status
+// fields of a workload, replacing the patch queued for the workload, if any.
+// The patch must carry the resourceVersion of the workload.
+func (b *StatusBatcher) ApplyAdmissionStatusPatch(patch *kueue.Workload) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.patches[client.ObjectKeyFromObject(patch)] = patch
+}
+
+// Forget drops the patch queued for the workload, if any.
+func (b *StatusBatcher) Forget(wl *kueue.Workload) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.patches, client.ObjectKeyFromObject(wl))
+}
+
+// Flush applies the queued patches.
+func (b *StatusBatcher) Flush(ctx context.Context) {
+	b.mu.Lock()
+	patches := make([]*kueue.Workload, 0, len(b.patches))
+	for _, patch := range b.patches {
+		patches = append(patches, patch)
+	}
+	clear(b.patches)
+	b.mu.Unlock()
+
+	log := ctrl.LoggerFrom(ctx)
+	_ = parallelize.Until(ctx, len(patches), func(i int) error {
+		err := ApplyAdmissionStatusPatch(ctx, b.client, patches[i])
+		switch {
+		case apierrors.IsConflict(err) || apierrors.IsNotFound(err):
+			log.V(3).Info("Skipped a stale Workload status update", "workload", klog.KObj(patches[i]), "error", err)
+		case err != nil:
+			log.Error(err, "Could not update Workload status", "workload", klog.KObj(patches[i]))
+		}
+		return nil
+	})
+}
+
+// Run applies the queued patches once per period until the context is done.
+func (b *StatusBatcher) Run(ctx context.Context) {
+	wait.UntilWithContext(ctx, b.Flush, b.period)
+}
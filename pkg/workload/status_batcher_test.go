@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestStatusBatcher(t *testing.T) {
+	pendingPatch := func(wl *kueue.Workload, message string) *kueue.Workload {
+		patch := BaseSSAWorkload(wl)
+		patch.ResourceVersion = wl.ResourceVersion
+		patch.Status.Conditions = []metav1.Condition{{
+			Type:    kueue.WorkloadQuotaReserved,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Pending",
+			Message: message,
+		}}
+		return patch
+	}
+	type patch struct {
+		workload string
+		message  string
+	}
+	cases := map[string]struct {
+		apply       []patch
+		forget      []string
+		update      []string
+		wantPatches int32
+		wantMessage map[string]string
+	}{
+		"patches of the same workload are coalesced": {
+			apply: []patch{
+				{workload: "a", message: "pending 1"},
+				{workload: "b", message: "pending 1"},
+				{workload: "a", message: "pending 2"},
+			},
+			wantPatches: 2,
+			wantMessage: map[string]string{
+				"a": "pending 2",
+				"b": "pending 1",
+			},
+		},
+		"forgotten patches are not applied": {
+			apply: []patch{
+				{workload: "a", message: "pending 1"},
+				{workload: "b", message: "pending 1"},
+			},
+			forget:      []string{"a"},
+			wantPatches: 1,
+			wantMessage: map[string]string{
+				"b": "pending 1",
+			},
+		},
+		"stale patches are skipped": {
+			apply: []patch{
+				{workload: "a", message: "pending 1"},
+				{workload: "b", message: "pending 1"},
+			},
+			update:      []string{"a"},
+			wantPatches: 2,
+			wantMessage: map[string]string{
+				"b": "pending 1",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			workloads := map[string]*kueue.Workload{
+				"a": utiltesting.MakeWorkload("a", "ns").Obj(),
+				"b": utiltesting.MakeWorkload("b", "ns").Obj(),
+			}
+			var patches atomic.Int32
+			cl := utiltesting.NewClientBuilder().
+				WithObjects(workloads["a"], workloads["b"]).
+				WithStatusSubresource(workloads["a"], workloads["b"]).
+				WithInterceptorFuncs(interceptor.Funcs{
+					SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+						patches.Add(1)
+						return utiltesting.TreatSSAAsStrategicMerge(ctx, c, subResourceName, obj, patch, opts...)
+					},
+				}).
+				Build()
+			batcher := NewStatusBatcher(cl, time.Second)
+
+			for _, p := range tc.apply {
+				var wl kueue.Workload
+				if err := cl.Get(ctx, client.ObjectKeyFromObject(workloads[p.workload]), &wl); err != nil {
+					t.Fatalf("Getting workload %s: %v", p.workload, err)
+				}
+				batcher.ApplyAdmissionStatusPatch(pendingPatch(&wl, p.message))
+			}
+			for _, name := range tc.forget {
+				batcher.Forget(workloads[name])
+			}
+			for _, name := range tc.update {
+				var wl kueue.Workload
+				if err := cl.Get(ctx, client.ObjectKeyFromObject(workloads[name]), &wl); err != nil {
+					t.Fatalf("Getting workload %s: %v", name, err)
+				}
+				wl.Labels = map[string]string{"updated": "true"}
+				if err := cl.Update(ctx, &wl); err != nil {
+					t.Fatalf("Updating workload %s: %v", name, err)
+				}
+			}
+			if got := patches.Load(); got != 0 {
+				t.Errorf("Unexpected patches before flushing: %d", got)
+			}
+			batcher.Flush(ctx)
+			if got := patches.Load(); got != tc.wantPatches {
+				t.Errorf("Unexpected number of patches, want %d, got %d", tc.wantPatches, got)
+			}
+
+			gotMessage := make(map[string]string)
+			for name, wl := range workloads {
+				var got kueue.Workload
+				if err := cl.Get(ctx, client.ObjectKeyFromObject(wl), &got); err != nil {
+					t.Fatalf("Getting workload %s: %v", name, err)
+				}
+				if cond := apimeta.FindStatusCondition(got.Status.Conditions, kueue.WorkloadQuotaReserved); cond != nil {
+					gotMessage[name] = cond.Message
+				}
+			}
+			if diff := cmp.Diff(tc.wantMessage, gotMessage); diff != "" {
+				t.Errorf("Unexpected messages (-want,+got):\n%s", diff)
+			}
+
+			batcher.Flush(ctx)
+			if got := patches.Load(); got != tc.wantPatches {
+				t.Errorf("Unexpected patches after flushing again, want %d, got %d", tc.wantPatches, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/util/limitrange"
+)
+
+// MatchesTemplate returns whether the workload fits in the shapes of the
+// WorkloadTemplate. The flavors of the template are only matched once the
+// quota of the workload is reserved.
+func MatchesTemplate(wl *kueue.Workload, template *kueuealpha.WorkloadTemplate) bool {
+	if template.Spec.MaxPriority != nil && ptr.Deref(wl.Spec.Priority, 0) > *template.Spec.MaxPriority {
+		return false
+	}
+	shapes := make(map[string]*kueuealpha.WorkloadTemplatePodSet, len(template.Spec.PodSets))
+	for i := range template.Spec.PodSets {
+		shapes[template.Spec.PodSets[i].Name] = &template.Spec.PodSets[i]
+	}
+	for i := range wl.Spec.PodSets {
+		ps := &wl.Spec.PodSets[i]
+		shape, found := shapes[ps.Name]
+		if !found || ps.Count > shape.MaxCount {
+			return false
+		}
+		for name, request := range limitrange.TotalRequests(&ps.Template.Spec) {
+			if request.IsZero() {
+				continue
+			}
+			maxRequest, found := shape.MaxRequests[name]
+			if !found || request.Cmp(maxRequest) > 0 {
+				return false
+			}
+		}
+	}
+	if wl.Status.Admission == nil {
+		return true
+	}
+	for _, psa := range wl.Status.Admission.PodSetAssignments {
+		shape, found := shapes[psa.Name]
+		if !found || len(shape.Flavors) == 0 {
+			continue
+		}
+		for _, flavor := range psa.Flavors {
+			if !slices.Contains(shape.Flavors, flavor) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SkippedAdmissionChecks returns the admission checks skipped by the workload
+// for matching the WorkloadTemplate, among the given ones by name, referenced
+// by its label, or nil when it doesn't reference a template or doesn't match
+// it.
+func SkippedAdmissionChecks(wl *kueue.Workload, templates map[string]*kueuealpha.WorkloadTemplate) sets.Set[string] {
+	name, found := wl.Labels[controllerconsts.WorkloadTemplateLabel]
+	if !found {
+		return nil
+	}
+	template, found := templates[name]
+	if !found || !MatchesTemplate(wl, template) {
+		return nil
+	}
+	return sets.New(template.Spec.SkippedAdmissionChecks...)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueuealpha "sigs.k8s.io/kueue/apis/kueue/v1alpha1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestSkippedAdmissionChecks(t *testing.T) {
+	template := utiltesting.MakeWorkloadTemplate("small").
+		PodSet(kueue.DefaultPodSetName, 4, corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		}, "on-demand").
+		MaxPriority(100).
+		SkippedAdmissionChecks("approval").
+		Obj()
+	wlWithTemplate := func(name string) *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload("wl", "ns").Label(controllerconsts.WorkloadTemplateLabel, name)
+	}
+
+	cases := map[string]struct {
+		wl   *kueue.Workload
+		want sets.Set[string]
+	}{
+		"no template": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Request(corev1.ResourceCPU, "1").Obj(),
+		},
+		"missing template": {
+			wl: wlWithTemplate("missing").Request(corev1.ResourceCPU, "1").Obj(),
+		},
+		"matching the template": {
+			wl: wlWithTemplate("small").
+				PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 4).
+					Request(corev1.ResourceCPU, "2").
+					Request(corev1.ResourceMemory, "1Gi").
+					Obj()).
+				Priority(100).
+				Obj(),
+			want: sets.New("approval"),
+		},
+		"more pods than the template": {
+			wl: wlWithTemplate("small").
+				PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 5).
+					Request(corev1.ResourceCPU, "1").
+					Obj()).
+				Obj(),
+		},
+		"larger requests than the template": {
+			wl: wlWithTemplate("small").Request(corev1.ResourceCPU, "3").Obj(),
+		},
+		"resource without a maximum": {
+			wl: wlWithTemplate("small").
+				Request(corev1.ResourceCPU, "1").
+				Request("example.com/gpu", "1").
+				Obj(),
+		},
+		"higher priority than the template": {
+			wl: wlWithTemplate("small").Request(corev1.ResourceCPU, "1").Priority(101).Obj(),
+		},
+		"pod set without a shape": {
+			wl: wlWithTemplate("small").
+				PodSets(*utiltesting.MakePodSet("workers", 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj()).
+				Obj(),
+		},
+		"assigned a flavor of the template": {
+			wl: wlWithTemplate("small").
+				Request(corev1.ResourceCPU, "1").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "on-demand", "1").Obj()).
+				Obj(),
+			want: sets.New("approval"),
+		},
+		"assigned a flavor out of the template": {
+			wl: wlWithTemplate("small").
+				Request(corev1.ResourceCPU, "1").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "spot", "1").Obj()).
+				Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := SkippedAdmissionChecks(tc.wl, map[string]*kueuealpha.WorkloadTemplate{template.Name: template})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected skipped admission checks (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package workload
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"hash/fnv"
 	"maps"
 	"slices"
 	"strings"
@@ -26,6 +28,7 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -40,6 +43,7 @@ import (
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/constants"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/resources"
@@ -47,6 +51,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/util/limitrange"
 	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
 	utilslices "sigs.k8s.io/kueue/pkg/util/slices"
+	utiltas "sigs.k8s.io/kueue/pkg/util/tas"
 )
 
 const (
@@ -54,6 +59,10 @@ const (
 	StatusQuotaReserved = "quotaReserved"
 	StatusAdmitted      = "admitted"
 	StatusFinished      = "finished"
+
+	// maxPendingReasons is the maximum number of pending reasons in the
+	// status of a workload.
+	maxPendingReasons = 64
 )
 
 var (
@@ -64,6 +73,7 @@ var (
 		kueue.WorkloadPreempted,
 		kueue.WorkloadRequeued,
 		kueue.WorkloadDeactivationTarget,
+		kueue.WorkloadResizePending,
 	}
 )
 
@@ -159,6 +169,15 @@ type Info struct {
 	// already admitted.
 	ClusterQueue   string
 	LastAssignment *AssignmentClusterQueueState
+	// WaitingFlavors are the flavors which could be assigned to the workload,
+	// when it was found inadmissible for lack of quota. Only a release of
+	// quota in one of them can make the workload admissible. It's nil if
+	// the workload is waiting for any event.
+	WaitingFlavors sets.Set[kueue.ResourceFlavorReference]
+	// SubmissionCounter is the position of the workload in the order in which
+	// the workloads were queued. It's only set by the queue manager when the
+	// workloads are ordered by it.
+	SubmissionCounter int64
 }
 
 type PodSetResources struct {
@@ -379,17 +398,46 @@ func totalRequestsFromPodSets(wl *kueue.Workload, info *InfoOptions) []PodSetRes
 			Count: count,
 		}
 		specRequests := limitrange.TotalRequests(&ps.Template.Spec)
-		effectiveRequests := dropExcludedResources(specRequests, info.excludedResourcePrefixes)
-		if features.Enabled(features.ConfigurableResourceTransformations) {
-			effectiveRequests = applyResourceTransformations(effectiveRequests, info.resourceTransformations)
+		if recommended, found := recommendedRequests(wl, ps.Name); found {
+			specRequests = recommended
 		}
-		setRes.Requests = resources.NewRequests(effectiveRequests)
+		setRes.Requests = effectivePodRequests(specRequests, info)
 		scaleUp(setRes.Requests, int64(count))
 		res = append(res, setRes)
 	}
 	return res
 }
 
+// recommendedRequests returns the requests of a pod of the podSet recommended
+// by the VerticalPodAutoscaler, if any.
+func recommendedRequests(wl *kueue.Workload, psName string) (corev1.ResourceList, bool) {
+	if !features.Enabled(features.VPAQuotaAccounting) {
+		return nil, false
+	}
+	idx := slices.IndexFunc(wl.Status.RecommendedRequests, func(psr kueue.PodSetRequest) bool {
+		return psr.Name == psName
+	})
+	if idx == -1 {
+		return nil, false
+	}
+	return wl.Status.RecommendedRequests[idx].Resources, true
+}
+
+// podRequests returns the effective requests of a pod with the given spec.
+func podRequests(spec *corev1.PodSpec, info *InfoOptions) resources.Requests {
+	return effectivePodRequests(limitrange.TotalRequests(spec), info)
+}
+
+// effectivePodRequests returns the requests of a pod after dropping the excluded
+// resources and applying the resource transformations.
+func effectivePodRequests(specRequests corev1.ResourceList, info *InfoOptions) resources.Requests {
+	effectiveRequests := dropExcludedResources(specRequests, info.excludedResourcePrefixes)
+	if features.Enabled(features.ConfigurableResourceTransformations) {
+		effectiveRequests = applyResourceTransformations(effectiveRequests, info.resourceTransformations)
+	}
+	return resources.NewRequests(effectiveRequests)
+}
+
 func totalRequestsFromAdmission(wl *kueue.Workload) []PodSetResources {
 	if wl.Status.Admission == nil {
 		return nil
@@ -408,7 +456,7 @@ func totalRequestsFromAdmission(wl *kueue.Workload) []PodSetResources {
 			setRes.TopologyRequest = &TopologyRequest{
 				Levels: psa.TopologyAssignment.Levels,
 			}
-			for _, domain := range psa.TopologyAssignment.Domains {
+			for _, domain := range utiltas.TopologyDomains(psa.TopologyAssignment) {
 				domainRequests := setRes.Requests.Clone()
 				scaleDown(domainRequests, int64(setRes.Count))
 				scaleUp(domainRequests, int64(domain.Count))
@@ -485,6 +533,9 @@ func UnsetQuotaReservationWithCondition(wl *kueue.Workload, reason, message stri
 		wl.Status.Admission = nil
 		changed = true
 	}
+	if apimeta.RemoveStatusCondition(&wl.Status.Conditions, kueue.WorkloadResizePending) {
+		changed = true
+	}
 
 	// Reset the admitted condition if necessary.
 	if SyncAdmittedCondition(wl, now) {
@@ -493,8 +544,12 @@ func UnsetQuotaReservationWithCondition(wl *kueue.Workload, reason, message stri
 	return changed
 }
 
+// RequeuingBackoffJitter is the default jitter of the backoff of the requeued
+// workloads.
+const RequeuingBackoffJitter = 0.0001
+
 // UpdateRequeueState calculate requeueAt time and update requeuingCount
-func UpdateRequeueState(wl *kueue.Workload, backoffBaseSeconds int32, backoffMaxSeconds int32, clock clock.Clock) {
+func UpdateRequeueState(wl *kueue.Workload, backoffBaseSeconds int32, backoffMaxSeconds int32, jitter float64, clock clock.Clock) {
 	if wl.Status.RequeueState == nil {
 		wl.Status.RequeueState = &kueue.RequeueState{}
 	}
@@ -508,7 +563,7 @@ func UpdateRequeueState(wl *kueue.Workload, backoffBaseSeconds int32, backoffMax
 	backoff := &wait.Backoff{
 		Duration: time.Duration(backoffBaseSeconds) * time.Second,
 		Factor:   2,
-		Jitter:   0.0001,
+		Jitter:   jitter,
 		Steps:    int(requeuingCount),
 	}
 	var waitDuration time.Duration
@@ -544,6 +599,19 @@ func QueuedWaitTime(wl *kueue.Workload) time.Duration {
 	return time.Since(queuedTime)
 }
 
+// QueuedTime returns the time at which the workload was queued in its current LocalQueue.
+// It is the latest of the creation time, the time of the last requeue and the
+// time of the last move to a fallback queue.
+func QueuedTime(wl *kueue.Workload) time.Time {
+	queuedTime := wl.CreationTimestamp.Time
+	for _, condType := range []string{kueue.WorkloadRequeued, kueue.WorkloadQueueRerouted} {
+		if c := apimeta.FindStatusCondition(wl.Status.Conditions, condType); c != nil && c.LastTransitionTime.After(queuedTime) {
+			queuedTime = c.LastTransitionTime.Time
+		}
+	}
+	return queuedTime
+}
+
 // BaseSSAWorkload creates a new object based on the input workload that
 // only contains the fields necessary to identify the original object.
 // The object can be used in as a base for Server-Side-Apply.
@@ -617,7 +685,10 @@ func SetDeactivationTarget(w *kueue.Workload, reason string, message string) {
 	apimeta.SetStatusCondition(&w.Status.Conditions, condition)
 }
 
-func SetEvictedCondition(w *kueue.Workload, reason string, message string) {
+// SetEvictedCondition sets the Evicted condition of the workload with the
+// reason, and records the reason and the category of the eviction in its
+// status.
+func SetEvictedCondition(w *kueue.Workload, reason string, category kueue.EvictionReasonCategory, message string) {
 	condition := metav1.Condition{
 		Type:               kueue.WorkloadEvicted,
 		Status:             metav1.ConditionTrue,
@@ -626,6 +697,30 @@ func SetEvictedCondition(w *kueue.Workload, reason string, message string) {
 		ObservedGeneration: w.Generation,
 	}
 	apimeta.SetStatusCondition(&w.Status.Conditions, condition)
+	w.Status.Eviction = &kueue.WorkloadEviction{
+		Reason:   reason,
+		Category: category,
+	}
+}
+
+// SetPendingReasons sets the pending reasons of the workload, at most
+// maxPendingReasons of them, if the WorkloadPendingReasons feature gate is
+// enabled. Returns true if w was updated.
+func SetPendingReasons(w *kueue.Workload, reasons []kueue.PendingReason) bool {
+	if !features.Enabled(features.WorkloadPendingReasons) {
+		return false
+	}
+	if len(reasons) > maxPendingReasons {
+		reasons = reasons[:maxPendingReasons]
+	}
+	for i := range reasons {
+		reasons[i].Message = api.TruncatePendingReasonMessage(reasons[i].Message)
+	}
+	if equality.Semantic.DeepEqual(w.Status.PendingReasons, reasons) {
+		return false
+	}
+	w.Status.PendingReasons = reasons
+	return true
 }
 
 // PropagateResourceRequests synchronizes w.Status.ResourceRequests to
@@ -663,6 +758,11 @@ func PropagateResourceRequests(w *kueue.Workload, info *Info) bool {
 func AdmissionStatusPatch(w *kueue.Workload, wlCopy *kueue.Workload, strict bool) {
 	wlCopy.Status.Admission = w.Status.Admission.DeepCopy()
 	wlCopy.Status.RequeueState = w.Status.RequeueState.DeepCopy()
+	wlCopy.Status.Deactivation = w.Status.Deactivation.DeepCopy()
+	wlCopy.Status.Eviction = w.Status.Eviction.DeepCopy()
+	for _, r := range w.Status.PendingReasons {
+		wlCopy.Status.PendingReasons = append(wlCopy.Status.PendingReasons, *r.DeepCopy())
+	}
 	if wlCopy.Status.Admission != nil {
 		// Clear ResourceRequests; Assignment.PodSetAssignment[].ResourceUsage supercedes it
 		wlCopy.Status.ResourceRequests = []kueue.PodSetRequest{}
@@ -708,6 +808,49 @@ func ApplyAdmissionStatusPatch(ctx context.Context, c client.Client, patch *kueu
 
 type Ordering struct {
 	PodsReadyRequeuingTimestamp config.RequeuingTimestamp
+	// TieBreakers are the keys ordering the workloads of equal priority and
+	// queue order timestamp. If empty, the order of these workloads is
+	// unspecified.
+	TieBreakers []config.TieBreaker
+}
+
+// UsesSubmissionCounter returns whether the workloads are ordered by their
+// submission counter.
+func (o Ordering) UsesSubmissionCounter() bool {
+	return slices.Contains(o.TieBreakers, config.SubmissionCounterTieBreaker)
+}
+
+// CompareTies compares the workloads of equal priority and queue order
+// timestamp by the tie-breakers, and finally by namespace and name. It
+// returns 0 if there are no tie-breakers.
+func (o Ordering) CompareTies(a, b *Info) int {
+	if len(o.TieBreakers) == 0 {
+		return 0
+	}
+	for _, tb := range o.TieBreakers {
+		var c int
+		switch tb {
+		case config.CreationTimestampTieBreaker:
+			c = a.Obj.CreationTimestamp.Compare(b.Obj.CreationTimestamp.Time)
+		case config.NamespaceHashTieBreaker:
+			c = cmp.Compare(namespaceHash(a.Obj.Namespace), namespaceHash(b.Obj.Namespace))
+		case config.SubmissionCounterTieBreaker:
+			c = cmp.Compare(a.SubmissionCounter, b.SubmissionCounter)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Or(
+		cmp.Compare(a.Obj.Namespace, b.Obj.Namespace),
+		cmp.Compare(a.Obj.Name, b.Obj.Name),
+	)
+}
+
+func namespaceHash(namespace string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(namespace))
+	return h.Sum32()
 }
 
 // GetQueueOrderTimestamp return the timestamp to be used by the scheduler. It could
@@ -761,6 +904,18 @@ func IsAdmitted(w *kueue.Workload) bool {
 	return apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadAdmitted)
 }
 
+// InShadowMode returns true if the workload was created in shadow mode.
+func InShadowMode(w *kueue.Workload) bool {
+	return w != nil && w.Labels[controllerconsts.ShadowModeLabel] == "true"
+}
+
+// AdmittedByBypass returns true if the job of the workload is started without
+// waiting for the admission of the workload, because its ClusterQueue admitted
+// all the workloads.
+func AdmittedByBypass(w *kueue.Workload) bool {
+	return w != nil && w.Annotations[controllerconsts.AdmitAllAnnotation] == "true"
+}
+
 // IsFinished returns true if the workload is finished.
 func IsFinished(w *kueue.Workload) bool {
 	return apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadFinished)
@@ -852,11 +1007,14 @@ func AdmissionChecksForWorkload(log logr.Logger, wl *kueue.Workload, admissionCh
 }
 
 func ReportEvictedWorkload(recorder record.EventRecorder, wl *kueue.Workload, cqName, reason, message string) {
+	category := EvictionCategory(wl, reason)
 	metrics.ReportEvictedWorkloads(cqName, reason)
+	metrics.ReportEvictionCategory(cqName, category)
 	if features.Enabled(features.LocalQueueMetrics) {
 		metrics.ReportLocalQueueEvictedWorkloads(metrics.LQRefFromWorkload(wl), reason)
 	}
-	recorder.Event(wl, corev1.EventTypeNormal, fmt.Sprintf("%sDueTo%s", kueue.WorkloadEvicted, reason), message)
+	recorder.AnnotatedEventf(wl, map[string]string{controllerconsts.EvictionCategoryAnnotation: string(category)},
+		corev1.EventTypeNormal, fmt.Sprintf("%sDueTo%s", kueue.WorkloadEvicted, reason), "%s", message)
 }
 
 func References(wls []*Info) []klog.ObjectRef {
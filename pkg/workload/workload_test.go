@@ -44,6 +44,7 @@ func TestNewInfo(t *testing.T) {
 		infoOptions                         []InfoOption
 		wantInfo                            Info
 		configurableResourceTransformations bool
+		vpaQuotaAccounting                  bool
 	}{
 		"pending": {
 			workload: *utiltesting.MakeWorkload("", "").
@@ -351,10 +352,78 @@ func TestNewInfo(t *testing.T) {
 			},
 			configurableResourceTransformations: true,
 		},
+		"pending with recommended requests": {
+			workload: *utiltesting.MakeWorkload("", "").
+				PodSets(
+					*utiltesting.MakePodSet("driver", 1).
+						Request(corev1.ResourceCPU, "1").
+						Request(corev1.ResourceMemory, "1Gi").
+						Obj(),
+					*utiltesting.MakePodSet("workers", 3).
+						Request(corev1.ResourceCPU, "2").
+						Request(corev1.ResourceMemory, "4Gi").
+						Request("networking.example.com/vpc1", "1").
+						Obj(),
+				).
+				RecommendedRequests(kueue.PodSetRequest{
+					Name: "workers",
+					Resources: corev1.ResourceList{
+						corev1.ResourceCPU:            resource.MustParse("500m"),
+						corev1.ResourceMemory:         resource.MustParse("1Gi"),
+						"networking.example.com/vpc1": resource.MustParse("1"),
+					},
+				}).
+				Obj(),
+			infoOptions: []InfoOption{WithExcludedResourcePrefixes([]string{"networking.example.com/"})},
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name: "driver",
+						Requests: resources.Requests{
+							corev1.ResourceCPU:    1000,
+							corev1.ResourceMemory: 1024 * 1024 * 1024,
+						},
+						Count: 1,
+					},
+					{
+						Name: "workers",
+						Requests: resources.Requests{
+							corev1.ResourceCPU:    3 * 500,
+							corev1.ResourceMemory: 3 * 1024 * 1024 * 1024,
+						},
+						Count: 3,
+					},
+				},
+			},
+			vpaQuotaAccounting: true,
+		},
+		"pending with recommended requests, feature disabled": {
+			workload: *utiltesting.MakeWorkload("", "").
+				Request(corev1.ResourceCPU, "1").
+				RecommendedRequests(kueue.PodSetRequest{
+					Name: "main",
+					Resources: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("500m"),
+					},
+				}).
+				Obj(),
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name: "main",
+						Requests: resources.Requests{
+							corev1.ResourceCPU: 1000,
+						},
+						Count: 1,
+					},
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			features.SetFeatureGateDuringTest(t, features.ConfigurableResourceTransformations, tc.configurableResourceTransformations)
+			features.SetFeatureGateDuringTest(t, features.VPAQuotaAccounting, tc.vpaQuotaAccounting)
 			info := NewInfo(&tc.workload, tc.infoOptions...)
 			if diff := cmp.Diff(info, &tc.wantInfo, cmpopts.IgnoreFields(Info{}, "Obj")); diff != "" {
 				t.Errorf("NewInfo(_) = (-want,+got):\n%s", diff)
@@ -441,25 +510,20 @@ func TestUpdateWorkloadStatus(t *testing.T) {
 }
 
 func TestGetQueueOrderTimestamp(t *testing.T) {
-	var (
-		evictionOrdering = Ordering{PodsReadyRequeuingTimestamp: config.EvictionTimestamp}
-		creationOrdering = Ordering{PodsReadyRequeuingTimestamp: config.CreationTimestamp}
-	)
-
 	creationTime := metav1.Now()
 	conditionTime := metav1.NewTime(creationTime.Add(time.Hour))
 
 	cases := map[string]struct {
 		wl   *kueue.Workload
-		want map[Ordering]metav1.Time
+		want map[config.RequeuingTimestamp]metav1.Time
 	}{
 		"no condition": {
 			wl: utiltesting.MakeWorkload("name", "ns").
 				Creation(creationTime.Time).
 				Obj(),
-			want: map[Ordering]metav1.Time{
-				evictionOrdering: creationTime,
-				creationOrdering: creationTime,
+			want: map[config.RequeuingTimestamp]metav1.Time{
+				config.EvictionTimestamp: creationTime,
+				config.CreationTimestamp: creationTime,
 			},
 		},
 		"evicted by preemption": {
@@ -472,9 +536,9 @@ func TestGetQueueOrderTimestamp(t *testing.T) {
 					Reason:             kueue.WorkloadEvictedByPreemption,
 				}).
 				Obj(),
-			want: map[Ordering]metav1.Time{
-				evictionOrdering: creationTime,
-				creationOrdering: creationTime,
+			want: map[config.RequeuingTimestamp]metav1.Time{
+				config.EvictionTimestamp: creationTime,
+				config.CreationTimestamp: creationTime,
 			},
 		},
 		"evicted by PodsReady timeout": {
@@ -487,9 +551,9 @@ func TestGetQueueOrderTimestamp(t *testing.T) {
 					Reason:             kueue.WorkloadEvictedByPodsReadyTimeout,
 				}).
 				Obj(),
-			want: map[Ordering]metav1.Time{
-				evictionOrdering: conditionTime,
-				creationOrdering: creationTime,
+			want: map[config.RequeuingTimestamp]metav1.Time{
+				config.EvictionTimestamp: conditionTime,
+				config.CreationTimestamp: creationTime,
 			},
 		},
 		"after eviction": {
@@ -502,15 +566,16 @@ func TestGetQueueOrderTimestamp(t *testing.T) {
 					Reason:             kueue.WorkloadEvictedByPodsReadyTimeout,
 				}).
 				Obj(),
-			want: map[Ordering]metav1.Time{
-				evictionOrdering: creationTime,
-				creationOrdering: creationTime,
+			want: map[config.RequeuingTimestamp]metav1.Time{
+				config.EvictionTimestamp: creationTime,
+				config.CreationTimestamp: creationTime,
 			},
 		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			for ordering, want := range tc.want {
+			for ts, want := range tc.want {
+				ordering := Ordering{PodsReadyRequeuingTimestamp: ts}
 				gotTime := ordering.GetQueueOrderTimestamp(tc.wl)
 				if diff := cmp.Diff(*gotTime, want); diff != "" {
 					t.Errorf("Unexpected time (-want,+got):\n%s", diff)
@@ -520,6 +585,68 @@ func TestGetQueueOrderTimestamp(t *testing.T) {
 	}
 }
 
+func TestCompareTies(t *testing.T) {
+	now := time.Now()
+	// The FNV-1a hash of "team-b" is lower than the hash of "team-a".
+	a := &Info{
+		Obj:               utiltesting.MakeWorkload("a", "team-a").Creation(now.Add(time.Second)).Obj(),
+		SubmissionCounter: 1,
+	}
+	b := &Info{
+		Obj:               utiltesting.MakeWorkload("b", "team-b").Creation(now).Obj(),
+		SubmissionCounter: 2,
+	}
+	cases := map[string]struct {
+		tieBreakers []config.TieBreaker
+		a, b        *Info
+		want        int
+	}{
+		"no tie-breakers": {
+			a:    a,
+			b:    b,
+			want: 0,
+		},
+		"creation timestamp": {
+			tieBreakers: []config.TieBreaker{config.CreationTimestampTieBreaker},
+			a:           a,
+			b:           b,
+			want:        1,
+		},
+		"namespace hash": {
+			tieBreakers: []config.TieBreaker{config.NamespaceHashTieBreaker},
+			a:           a,
+			b:           b,
+			want:        1,
+		},
+		"submission counter": {
+			tieBreakers: []config.TieBreaker{config.SubmissionCounterTieBreaker},
+			a:           a,
+			b:           b,
+			want:        -1,
+		},
+		"first tie-breaker deciding": {
+			tieBreakers: []config.TieBreaker{config.SubmissionCounterTieBreaker, config.CreationTimestampTieBreaker},
+			a:           a,
+			b:           b,
+			want:        -1,
+		},
+		"equal keys ordered by namespace and name": {
+			tieBreakers: []config.TieBreaker{config.CreationTimestampTieBreaker},
+			a:           &Info{Obj: utiltesting.MakeWorkload("b", "ns").Creation(now).Obj()},
+			b:           &Info{Obj: utiltesting.MakeWorkload("a", "ns").Creation(now).Obj()},
+			want:        1,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ordering := Ordering{TieBreakers: tc.tieBreakers}
+			if got := ordering.CompareTies(tc.a, tc.b); got != tc.want {
+				t.Errorf("Unexpected comparison, want %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestReclaimablePodsAreEqual(t *testing.T) {
 	cases := map[string]struct {
 		a, b       []kueue.ReclaimablePod